@@ -15,6 +15,7 @@
 package v1alpha1
 
 import (
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -22,6 +23,38 @@ const (
 	AccessManagementKind = "AccessManagement"
 
 	AccessManagementName = "kcm"
+
+	// NamespaceRoleLabelKey is the label key marking the KCM namespace ClusterRoles
+	// and the RoleBindings managed by the AccessManagement. Its value is the lowercased role name.
+	NamespaceRoleLabelKey = "k0rdent.mirantis.com/namespace-role"
+)
+
+const (
+	// NamespaceRoleViewer grants the read-only access to the KCM objects in a namespace.
+	NamespaceRoleViewer = "Viewer"
+	// NamespaceRoleEditor grants the access to manage ClusterDeployments and their services in a namespace.
+	NamespaceRoleEditor = "Editor"
+	// NamespaceRoleAdmin grants the full access to the KCM objects in a namespace.
+	NamespaceRoleAdmin = "Admin"
+)
+
+const (
+	// PrunePolicyDelete deletes the distributed objects no longer matching the access rules.
+	PrunePolicyDelete = "Delete"
+	// PrunePolicyOrphan leaves the distributed objects no longer matching the access rules
+	// in place and stops managing them.
+	PrunePolicyOrphan = "Orphan"
+)
+
+const (
+	// DeliveryResultCreated means the object was distributed to the namespace.
+	DeliveryResultCreated = "Created"
+	// DeliveryResultSkipped means the object was not distributed to the namespace because it already exists there.
+	DeliveryResultSkipped = "Skipped"
+	// DeliveryResultFailed means the object failed to be distributed to or pruned from the namespace.
+	DeliveryResultFailed = "Failed"
+	// DeliveryResultPruned means the object no longer matching the access rules was pruned from the namespace.
+	DeliveryResultPruned = "Pruned"
 )
 
 // AccessManagementSpec defines the desired state of AccessManagement
@@ -29,6 +62,21 @@ type AccessManagementSpec struct {
 	// AccessRules is the list of access rules. Each AccessRule enforces
 	// objects distribution to the TargetNamespaces.
 	AccessRules []AccessRule `json:"accessRules,omitempty"`
+	// EnforceAccessRules restricts ClusterDeployments outside of the system namespace
+	// to the ClusterTemplates and Credentials granted to their namespace by the AccessRules,
+	// regardless of the objects existing in the namespace.
+	EnforceAccessRules bool `json:"enforceAccessRules,omitempty"`
+	// DryRun makes the controller only report in the status the objects it would
+	// distribute or prune in each namespace, without applying the access rules.
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// +kubebuilder:default=Delete
+	// +kubebuilder:validation:Enum=Delete;Orphan
+
+	// PrunePolicy defines what happens to the distributed ClusterTemplateChains, ServiceTemplateChains
+	// and Credentials no longer matching the access rules. With Delete they are deleted along with the
+	// distributed templates, with Orphan they are left in place and are no longer managed by KCM.
+	PrunePolicy string `json:"prunePolicy,omitempty"`
 }
 
 // AccessManagementStatus defines the observed state of AccessManagement
@@ -37,10 +85,51 @@ type AccessManagementStatus struct {
 	Error string `json:"error,omitempty"`
 	// Current reflects the applied access rules configuration.
 	Current []AccessRule `json:"current,omitempty"`
+	// Changes is the list of the objects the last reconciliation distributed or pruned,
+	// or would distribute or prune in the dry-run mode, in each namespace.
+	Changes []NamespaceAccessChanges `json:"changes,omitempty"`
+	// Delivery is the result of the distribution of the objects to each of the target namespaces
+	// during the last reconciliation applying the access rules.
+	Delivery []NamespaceAccessDelivery `json:"delivery,omitempty"`
+	// Conditions contains details for the current state of the AccessManagement.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// ObservedGeneration is the last observed generation.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// NamespaceAccessChanges is the list of the objects distributed or pruned in a namespace by the AccessManagement.
+type NamespaceAccessChanges struct {
+	// Namespace is the name of the namespace.
+	Namespace string `json:"namespace"`
+	// Distribute is the list of the objects distributed to the namespace in the Kind/name form.
+	Distribute []string `json:"distribute,omitempty"`
+	// Prune is the list of the objects pruned from the namespace in the Kind/name form.
+	Prune []string `json:"prune,omitempty"`
+}
+
+// NamespaceAccessDelivery is the result of the distribution of the objects to a namespace by the AccessManagement.
+type NamespaceAccessDelivery struct {
+	// Namespace is the name of the namespace.
+	Namespace string `json:"namespace"`
+	// Objects is the list of the delivery results of the objects distributed to or pruned from the namespace.
+	Objects []AccessDeliveryResult `json:"objects,omitempty"`
+}
+
+// AccessDeliveryResult is the result of the distribution of a single object to a namespace.
+type AccessDeliveryResult struct {
+	// Kind is the kind of the object.
+	Kind string `json:"kind"`
+	// Name is the name of the object.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Enum=Created;Skipped;Failed;Pruned
+
+	// Result is the result of the distribution of the object.
+	Result string `json:"result"`
+	// Message explains the result of the distribution.
+	Message string `json:"message,omitempty"`
+}
+
 // AccessRule is the definition of the AccessManagement access rule. Each AccessRule enforces
 // Templates and Credentials distribution to the TargetNamespaces
 type AccessRule struct {
@@ -56,6 +145,34 @@ type AccessRule struct {
 	// Credentials is the list of Credential names that will be distributed to all the
 	// namespaces specified in TargetNamespaces.
 	Credentials []string `json:"credentials,omitempty"`
+	// ClusterTemplateGrants lists the ClusterTemplates of the other namespaces, e.g. the shared catalog
+	// namespace, the ClusterDeployments in all namespaces specified in TargetNamespaces are allowed
+	// to reference with the TemplateRef instead of the ClusterTemplates being copied into the namespaces.
+	ClusterTemplateGrants []ClusterTemplateGrant `json:"clusterTemplateGrants,omitempty"`
+	// Subjects is the list of subjects bound to the KCM namespace roles
+	// in all namespaces specified in TargetNamespaces.
+	Subjects []AccessSubject `json:"subjects,omitempty"`
+}
+
+// AccessSubject binds a subject to one of the KCM namespace roles.
+type AccessSubject struct {
+	rbacv1.Subject `json:",inline"`
+
+	// +kubebuilder:validation:Enum=Viewer;Editor;Admin
+
+	// Role is the KCM namespace role the subject is bound to.
+	Role string `json:"role"`
+}
+
+// ClusterTemplateGrant grants the ClusterTemplates of a namespace to be referenced from the other namespaces.
+type ClusterTemplateGrant struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Namespace is the namespace of the granted ClusterTemplates.
+	Namespace string `json:"namespace"`
+	// ClusterTemplates lists the names of the granted ClusterTemplates.
+	// All of the ClusterTemplates of the namespace are granted if unset.
+	ClusterTemplates []string `json:"clusterTemplates,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="((has(self.stringSelector) ? 1 : 0) + (has(self.selector) ? 1 : 0) + (has(self.list) ? 1 : 0)) <= 1", message="only one of spec.targetNamespaces.selector or spec.targetNamespaces.stringSelector or spec.targetNamespaces.list can be specified"
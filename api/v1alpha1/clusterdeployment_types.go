@@ -17,10 +17,15 @@ package v1alpha1
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"slices"
+	"time"
 
+	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
@@ -35,6 +40,16 @@ const (
 	KCMManagedLabelValue = "true"
 
 	ClusterNameLabelKey = "cluster.x-k8s.io/cluster-name"
+
+	// ProviderLabelKey is the label set on the CAPI Cluster holding the infrastructure provider
+	// of the cluster without the infrastructure- prefix, e.g. aws.
+	ProviderLabelKey = "k0rdent.mirantis.com/provider"
+	// RegionLabelKey is the label set on the CAPI Cluster holding the region of the cluster
+	// set in its configuration, e.g. eu-west-1.
+	RegionLabelKey = "k0rdent.mirantis.com/region"
+	// KubernetesVersionLabelKey is the label set on the CAPI Cluster holding the minor
+	// Kubernetes version of the cluster, e.g. 1.29.
+	KubernetesVersionLabelKey = "k0rdent.mirantis.com/kubernetes-version"
 )
 
 const (
@@ -48,40 +63,1079 @@ const (
 	HelmReleaseReadyCondition = "HelmReleaseReady"
 	// SveltosClusterReadyCondition indicates the sveltos cluster is valid and ready.
 	SveltosClusterReadyCondition = "SveltosClusterReady"
+	// DNSRecordReadyCondition indicates the DNS record for the cluster API endpoint is published.
+	DNSRecordReadyCondition = "DNSRecordReady"
+	// ClusterReachableCondition indicates the API server of the cluster is reachable from the management cluster.
+	ClusterReachableCondition = "ClusterReachable"
+	// CertificatesValidCondition indicates the certificates of the cluster machines are not expired.
+	CertificatesValidCondition = "CertificatesValid"
+	// ProvisionedCondition indicates the initial provisioning of the cluster has succeeded.
+	ProvisionedCondition = "Provisioned"
+	// ProvisioningFailedReason indicates the initial provisioning of the cluster has failed terminally.
+	ProvisioningFailedReason = "ProvisioningFailed"
+	// ConfigValidatedCondition indicates the configuration is validated by the validation Job of the infrastructure provider.
+	ConfigValidatedCondition = "ConfigValidated"
+	// QuotaAvailableCondition indicates the remaining quota of the infrastructure provider account is sufficient
+	// for the requested topology as checked by the quota Job of the infrastructure provider.
+	QuotaAvailableCondition = "QuotaAvailable"
+	// QuotaExceededReason indicates the requested topology exceeds the remaining quota of the infrastructure provider account.
+	QuotaExceededReason = "QuotaExceeded"
+	// FailureDomainsSpreadCondition indicates the machines of the cluster are spread across the configured failure domains.
+	FailureDomainsSpreadCondition = "FailureDomainsSpread"
+	// FailureDomainsNotSpreadReason indicates the machines of the cluster are not spread across the configured failure domains.
+	FailureDomainsNotSpreadReason = "FailureDomainsNotSpread"
+	// ReadinessGatesPassedCondition indicates the readiness gates of the cluster have passed.
+	ReadinessGatesPassedCondition = "ReadinessGatesPassed"
+	// SveltosAgentsHealthyCondition indicates the Sveltos agents running in the cluster are ready and match
+	// the version of the Sveltos components of the management cluster.
+	SveltosAgentsHealthyCondition = "SveltosAgentsHealthy"
+	// SveltosAgentsUpgradingReason indicates the outdated Sveltos agents running in the cluster are being upgraded.
+	SveltosAgentsUpgradingReason = "SveltosAgentsUpgrading"
+	// RegistryCredentialsPropagatedCondition indicates the image pull secret of the Management
+	// has been created in the namespaces of the cluster.
+	RegistryCredentialsPropagatedCondition = "RegistryCredentialsPropagated"
+	// FleetAccessProvisionedCondition indicates the ServiceAccount the short-lived credentials
+	// of the fleet kubeconfig are issued for has been created in the cluster.
+	FleetAccessProvisionedCondition = "FleetAccessProvisioned"
+	// TrustedCAPropagatedCondition indicates the trusted CA bundle of the Management
+	// has been published in the cluster and rolled out to its machines.
+	TrustedCAPropagatedCondition = "TrustedCAPropagated"
+	// TrustedCARolloutPendingReason indicates the machines of the cluster are to be rolled out
+	// once the template of the cluster is upgraded with the changed trusted CA bundle.
+	TrustedCARolloutPendingReason = "TrustedCARolloutPending"
+	// MachineAccessConvergedCondition indicates the machines of the cluster are bootstrapped
+	// with the current SSH keys of the cluster.
+	MachineAccessConvergedCondition = "MachineAccessConverged"
+	// MachinesRollingOutReason indicates the machines of the cluster are being rolled out.
+	MachinesRollingOutReason = "MachinesRollingOut"
+	// ServiceAdvisoriesCondition indicates the services of the cluster are installed from the ServiceTemplates
+	// past their end of life or with known vulnerabilities and are to be upgraded.
+	ServiceAdvisoriesCondition = "ServiceAdvisories"
+	// ServicesEndOfLifeReason indicates the services of the cluster are past their end of life.
+	ServicesEndOfLifeReason = "ServicesEndOfLife"
+	// ServicesVulnerableReason indicates the services of the cluster have known vulnerabilities.
+	ServicesVulnerableReason = "ServicesVulnerable"
+	// KubernetesVersionEndOfLifeCondition indicates the Kubernetes version of the cluster
+	// approaches or is past its upstream end of life and the cluster is to be upgraded.
+	KubernetesVersionEndOfLifeCondition = "KubernetesVersionEndOfLife"
+	// KubernetesVersionEndOfLifeApproachingReason indicates the Kubernetes version of the cluster approaches its end of life.
+	KubernetesVersionEndOfLifeApproachingReason = "KubernetesVersionEndOfLifeApproaching"
+	// KubernetesVersionEndOfLifeReason indicates the Kubernetes version of the cluster is past its end of life.
+	KubernetesVersionEndOfLifeReason = "KubernetesVersionEndOfLife"
+	// DeletingCondition indicates the deletion of the cluster is in progress and reports the resources blocking it.
+	DeletingCondition = "Deleting"
+	// DeletionStuckReason indicates the deletion of the cluster takes longer than expected.
+	DeletionStuckReason = "DeletionStuck"
+	// ForceFinalizedReason indicates the deletion of the cluster has been forcibly finalized.
+	ForceFinalizedReason = "ForceFinalized"
+
+	// CertificatesExpiringReason indicates the certificates of the cluster machines expire soon.
+	CertificatesExpiringReason = "CertificatesExpiring"
+	// CertificatesExpiryUnknownReason indicates none of the machines of the cluster report the expiry of the certificates.
+	CertificatesExpiryUnknownReason = "CertificatesExpiryUnknown"
+	// CertificatesRotationUnsupportedReason indicates the rotation of the certificates has been requested
+	// for a control plane not supporting it.
+	CertificatesRotationUnsupportedReason = "CertificatesRotationUnsupported"
+	// LifetimeValidCondition indicates the cluster has not expired.
+	LifetimeValidCondition = "LifetimeValid"
+	// ExpirationApproachingReason indicates the cluster expires soon.
+	ExpirationApproachingReason = "ExpirationApproaching"
+	// HibernatedReason indicates the cluster has expired and has been hibernated.
+	HibernatedReason = "Hibernated"
+	// ControlPlaneMigrationCondition indicates the progress of the migration of the cluster
+	// between the hosted and the standalone control plane.
+	ControlPlaneMigrationCondition = "ControlPlaneMigration"
+	// ControlPlaneMigrationRejectedReason indicates the requested migration of the control plane has been rejected.
+	ControlPlaneMigrationRejectedReason = "ControlPlaneMigrationRejected"
+	// DataContinuityCheckFailedReason indicates resources of the cluster are missing after the migration of the control plane.
+	DataContinuityCheckFailedReason = "DataContinuityCheckFailed"
+	// ExpiredReason indicates the cluster has expired and is being deleted.
+	ExpiredReason = "Expired"
+	// RolledBackReason indicates the cluster has been reverted to the last known-good revision.
+	RolledBackReason = "RolledBack"
+	// RollbackFailedReason indicates the requested rollback of the cluster has been rejected.
+	RollbackFailedReason = "RollbackFailed"
+	// ConfigDriftCondition indicates the values of the HelmRelease of the cluster have been changed
+	// outside of the ClusterDeployment and diverge from the values produced from its spec.
+	ConfigDriftCondition = "ConfigDrift"
+	// ConfigDriftHeldReason indicates the drifted values of the HelmRelease are kept as requested
+	// by the Hold config drift policy.
+	ConfigDriftHeldReason = "ConfigDriftHeld"
+	// ConfigDriftReconciledReason indicates the drifted values of the HelmRelease have been reverted
+	// to the values produced from the spec of the ClusterDeployment.
+	ConfigDriftReconciledReason = "ConfigDriftReconciled"
+	// FailureInjectedReason indicates a failure has been injected into the reconciliation of the cluster
+	// as requested by the failure injection annotation.
+	FailureInjectedReason = "FailureInjected"
+
+	// ProvisioningStrategyKeepRetrying denotes the failed provisioning is retried up to the maximum number of retries.
+	ProvisioningStrategyKeepRetrying = "KeepRetrying"
+	// ProvisioningStrategyFailFast denotes the provisioning fails on the first failure.
+	ProvisioningStrategyFailFast = "FailFast"
+
+	// ProvisioningPhaseProvisioning denotes the cluster is being provisioned.
+	ProvisioningPhaseProvisioning = "Provisioning"
+	// ProvisioningPhaseProvisioned denotes the cluster has been provisioned.
+	ProvisioningPhaseProvisioned = "Provisioned"
+	// ProvisioningPhaseFailed denotes the provisioning of the cluster has failed terminally.
+	ProvisioningPhaseFailed = "Failed"
+
+	// ConfigDriftPolicyReconcile denotes the drifted values of the HelmRelease are reverted.
+	ConfigDriftPolicyReconcile = "Reconcile"
+	// ConfigDriftPolicyHold denotes the drifted values of the HelmRelease are kept and the updates
+	// of the HelmRelease are held until the drift is resolved.
+	ConfigDriftPolicyHold = "Hold"
+
+	// ExpirationActionDelete denotes the expired cluster is deleted.
+	ExpirationActionDelete = "Delete"
+	// ExpirationActionHibernate denotes the worker nodes of the expired cluster are scaled down to zero.
+	ExpirationActionHibernate = "Hibernate"
+
+	// EndpointTypeDNSRecord denotes the DNS record published for the API endpoint of the cluster.
+	EndpointTypeDNSRecord = "DNSRecord"
+	// EndpointTypeAPIServer denotes the API server of the cluster.
+	EndpointTypeAPIServer = "APIServer"
+	// EndpointTypeIngressLoadBalancer denotes the load balancer of the ingress controller of the cluster.
+	EndpointTypeIngressLoadBalancer = "IngressLoadBalancer"
+	// EndpointTypeOIDCIssuer denotes the OpenID issuer the API server of the cluster authenticates with.
+	EndpointTypeOIDCIssuer = "OIDCIssuer"
+
+	// ServiceDeliverySveltos denotes the services are delivered to the cluster by Sveltos.
+	ServiceDeliverySveltos = "Sveltos"
+	// ServiceDeliveryFlux denotes the services are delivered to the cluster by the Flux HelmReleases
+	// reconciled in the management cluster against the cluster kubeconfig.
+	ServiceDeliveryFlux = "Flux"
+	// ServiceDeliveryApplier denotes the manifests of the services are applied to the cluster
+	// by the server-side apply against the cluster kubeconfig.
+	ServiceDeliveryApplier = "Applier"
+
+	// ServiceDeliveryClusterLabelKey is the label on the objects of the Flux and Applier service deliveries
+	// in the management cluster holding the name of the ClusterDeployment.
+	ServiceDeliveryClusterLabelKey = "k0rdent.mirantis.com/service-delivery-cluster"
+
+	// SecurityBaselineEnforced denotes the security baseline is deployed to the cluster.
+	SecurityBaselineEnforced = "Enforced"
+	// SecurityBaselineOptedOut denotes the cluster is opted out of the security baseline.
+	SecurityBaselineOptedOut = "OptedOut"
+
+	// RotateCertificatesAnnotation is an annotation on a ClusterDeployment requesting
+	// the rotation of the control plane certificates of the cluster.
+	// The annotation is removed once the rotation is triggered. Only the KubeadmControlPlane is supported,
+	// the annotation is removed with a warning event for the other control planes, e.g. K0sControlPlane.
+	RotateCertificatesAnnotation = "k0rdent.mirantis.com/rotate-certificates"
+
+	// PropagatedLabelsAnnotation is an annotation on the objects the ClusterDeployment
+	// labels are propagated to, listing the keys of the propagated labels.
+	PropagatedLabelsAnnotation = "k0rdent.mirantis.com/propagated-labels"
+	// PropagatedAnnotationsAnnotation is an annotation on the objects the ClusterDeployment
+	// annotations are propagated to, listing the keys of the propagated annotations.
+	PropagatedAnnotationsAnnotation = "k0rdent.mirantis.com/propagated-annotations"
+
+	// MonthlyBudgetAnnotation is an annotation on a ClusterDeployment setting the monthly budget
+	// of the cluster in the currency of the price list. A warning is returned on the creation
+	// or update of the ClusterDeployment if the estimated monthly cost exceeds the budget.
+	MonthlyBudgetAnnotation = "k0rdent.mirantis.com/monthly-budget"
+
+	// ExpirationExtensionAnnotation is an annotation on a ClusterDeployment extending its expiration
+	// by the duration set in the value, e.g. "24h". The value is limited by the max extension
+	// of the expiration policy.
+	ExpirationExtensionAnnotation = "k0rdent.mirantis.com/expiration-extension"
+
+	// ForceFinalizeAnnotation is an annotation on a ClusterDeployment being deleted requesting
+	// the forced finalization of the deletion: the finalizers of the resources blocking the deletion
+	// and of the ClusterDeployment itself are removed. The value must be the UID of the
+	// ClusterDeployment to confirm the request, the cloud resources of the cluster may be orphaned.
+	ForceFinalizeAnnotation = "k0rdent.mirantis.com/force-finalize"
+
+	// RollbackAnnotation is an annotation on a ClusterDeployment requesting the rollback of a failing upgrade:
+	// the template and the configuration are reverted to the last known-good revision recorded in the status.
+	// The annotation is removed once the rollback is applied.
+	RollbackAnnotation = "k0rdent.mirantis.com/rollback"
+
+	// ControlPlaneMigrationAnnotation is an annotation on a ClusterDeployment requesting the migration of the cluster
+	// between the hosted and the standalone control plane. The value is the name of the ClusterTemplate
+	// the cluster is migrated to. The annotation is removed once the migration succeeds, the removal
+	// of the annotation acknowledges the failed migration.
+	ControlPlaneMigrationAnnotation = "k0rdent.mirantis.com/migrate-control-plane"
+
+	// AppliedValuesHashAnnotation is an annotation on the HelmRelease of a ClusterDeployment holding
+	// the hash of the values last applied from the ClusterDeployment, used to detect the changes
+	// of the values made outside of the ClusterDeployment.
+	AppliedValuesHashAnnotation = "k0rdent.mirantis.com/applied-values-hash"
+
+	// ConfigOverlaysAnnotation is an annotation on a ClusterDeployment listing the overlays
+	// of the ConfigPolicies merged into its configuration on creation as <policy>/<overlay>.
+	ConfigOverlaysAnnotation = "k0rdent.mirantis.com/config-overlays"
 )
 
+// ClusterExpirationPolicy defines the handling of the cluster expiration.
+type ClusterExpirationPolicy struct {
+	// +kubebuilder:default:="24h"
+
+	// WarningPeriod is the period before the expiration within which
+	// the warning events about the upcoming expiration are emitted.
+	WarningPeriod *metav1.Duration `json:"warningPeriod,omitempty"`
+	// MaxExtension is the maximum extension of the expiration allowed to set with
+	// the k0rdent.mirantis.com/expiration-extension annotation. Not limited if unset.
+	MaxExtension *metav1.Duration `json:"maxExtension,omitempty"`
+
+	// +kubebuilder:default:=Delete
+	// +kubebuilder:validation:Enum=Delete;Hibernate
+
+	// Action is the action taken once the cluster expires. Delete removes the ClusterDeployment,
+	// Hibernate scales the worker nodes of the cluster down to zero with the workersNumber value
+	// until the expiration is extended.
+	Action string `json:"action,omitempty"`
+}
+
+// ClusterLifecycleHooks configures the gating of the Cluster API lifecycle transitions of the cluster
+// with the Runtime Extension hooks. The hooks are called only for the clusters with a managed topology.
+type ClusterLifecycleHooks struct {
+	// MaintenanceWindows restricts the start of the Kubernetes upgrades of the cluster to the given windows.
+	// The upgrades are allowed at any time if empty.
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// BackupBeforeUpgrade postpones the Kubernetes upgrades of the cluster
+	// until a ManagementBackup taken for the upgrade is completed.
+	BackupBeforeUpgrade bool `json:"backupBeforeUpgrade,omitempty"`
+	// WaitForServicesAfterControlPlaneUpgrade postpones the upgrade of the worker nodes
+	// until the services of the cluster are ready after the control plane is upgraded.
+	WaitForServicesAfterControlPlaneUpgrade bool `json:"waitForServicesAfterControlPlaneUpgrade,omitempty"`
+	// QuiesceServicesBeforeDelete postpones the deletion of the cluster
+	// until the services are removed from it.
+	QuiesceServicesBeforeDelete bool `json:"quiesceServicesBeforeDelete,omitempty"`
+}
+
+// MaintenanceWindow defines a recurring window of time.
+type MaintenanceWindow struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Schedule is a Cron expression defining when the window opens.
+	Schedule string `json:"schedule"`
+	// Duration is the period the window stays open for.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// ClusterObservability configures the metrics and logs agents of the cluster.
+// The fields set override the ones of the Management, the labels are merged.
+type ClusterObservability struct {
+	// ServiceTemplate is the name of the ServiceTemplate installing the metrics and logs agents.
+	// Must be set unless the agents are enabled in the Management.
+	ServiceTemplate string `json:"serviceTemplate,omitempty"`
+	// Namespace is the namespace the agents are installed in.
+	Namespace string `json:"namespace,omitempty"`
+
+	// +kubebuilder:validation:Pattern=`^https?://`
+
+	// MetricsEndpoint is the URL the agents ship the metrics to.
+	MetricsEndpoint string `json:"metricsEndpoint,omitempty"`
+
+	// +kubebuilder:validation:Pattern=`^https?://`
+
+	// LogsEndpoint is the URL the agents ship the logs to.
+	LogsEndpoint string `json:"logsEndpoint,omitempty"`
+	// CredentialsSecretName is the name of the Secret in the namespace of the ClusterDeployment
+	// holding the credentials of the central store passed to the agents.
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+	// Labels are the additional labels attached to the metrics and logs of the cluster.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Values is the helm values passed to the chart of the ServiceTemplate.
+	Values string `json:"values,omitempty"`
+	// Disabled opts the cluster out of the agents enabled in the Management.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// ProvisioningPolicy defines the handling of the failures of the initial provisioning of the cluster.
+type ProvisioningPolicy struct {
+	// +kubebuilder:validation:Enum=KeepRetrying;FailFast
+	// +kubebuilder:default=KeepRetrying
+
+	// Strategy is the handling of the provisioning failures. KeepRetrying retries the failed
+	// provisioning up to MaxRetries times, FailFast fails the provisioning on the first failure.
+	Strategy string `json:"strategy,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+
+	// MaxRetries is the maximum number of retries of the failed provisioning.
+	// Unlimited if not set, ignored by the FailFast strategy.
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+	// Backoff is the delay before the first retry, doubled with each further retry. Defaults to 1m.
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+	// MaxBackoff is the maximum delay between the retries. Defaults to 30m.
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+// ProvisioningStatus reflects the initial provisioning of the cluster.
+type ProvisioningStatus struct {
+	// +kubebuilder:validation:Enum=Provisioning;Provisioned;Failed
+
+	// Phase is the phase of the provisioning, Failed is terminal until the spec of the ClusterDeployment is changed.
+	Phase string `json:"phase"`
+	// Retries is the number of the retries of the failed provisioning.
+	Retries int32 `json:"retries,omitempty"`
+	// LastFailureTime is the time the last provisioning failure has been observed.
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+	// LastFailureReason is the machine-readable reason of the last provisioning failure.
+	LastFailureReason string `json:"lastFailureReason,omitempty"`
+	// LastFailureMessage is the details of the last provisioning failure.
+	LastFailureMessage string `json:"lastFailureMessage,omitempty"`
+	// NextRetryTime is the time the failed provisioning is retried at.
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+	// ObservedGeneration is the generation of the ClusterDeployment the provisioning has been observed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ClusterFailureDomains defines the failure domains, e.g. the availability zones or the host groups,
+// the machines of the cluster are spread across.
+type ClusterFailureDomains struct {
+	// +listType=set
+	// +kubebuilder:validation:items:MinLength=1
+
+	// ControlPlane is the list of the failure domains the control plane machines are spread across.
+	ControlPlane []string `json:"controlPlane,omitempty"`
+
+	// +listType=set
+	// +kubebuilder:validation:items:MinLength=1
+
+	// Workers is the list of the failure domains the worker machines are spread across.
+	Workers []string `json:"workers,omitempty"`
+}
+
+// ClusterNetworks configures the networks attached to the machines of the cluster
+// in addition to the primary network of the template.
+type ClusterNetworks struct {
+	// +listType=map
+	// +listMapKey=name
+
+	// Additional is the list of the additional networks attached to the machines.
+	Additional []NodeNetwork `json:"additional,omitempty"`
+	// Storage is the network dedicated to the storage traffic, e.g. of the CSI driver.
+	Storage *NodeNetwork `json:"storage,omitempty"`
+}
+
+const (
+	// NodeNetworkMachinesAll denotes the network attached to all of the machines of the cluster.
+	NodeNetworkMachinesAll = "All"
+	// NodeNetworkMachinesControlPlane denotes the network attached to the control plane machines only.
+	NodeNetworkMachinesControlPlane = "ControlPlane"
+	// NodeNetworkMachinesWorkers denotes the network attached to the worker machines only.
+	NodeNetworkMachinesWorkers = "Workers"
+)
+
+const (
+	// CNICalico denotes the Calico CNI.
+	CNICalico = "calico"
+	// CNICilium denotes the Cilium CNI.
+	CNICilium = "cilium"
+	// CNINone denotes the CNI brought by the user.
+	CNINone = "none"
+)
+
+// NodeNetwork defines a network attached to the machines of the cluster.
+type NodeNetwork struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Name is the name of the network in the infrastructure, e.g. the vSphere port group or the OpenStack network.
+	Name string `json:"name"`
+
+	// +listType=set
+	// +kubebuilder:validation:items:MinLength=1
+
+	// Subnets is the list of the CIDRs of the secondary subnets of the network the machines are addressed from.
+	Subnets []string `json:"subnets,omitempty"`
+
+	// +kubebuilder:default:=All
+	// +kubebuilder:validation:Enum=All;ControlPlane;Workers
+
+	// Machines selects the machines the network is attached to.
+	Machines string `json:"machines,omitempty"`
+}
+
+// ClusterReadinessGates defines the checks of the cluster that must pass before the cluster is declared ready.
+type ClusterReadinessGates struct {
+	// +listType=set
+	// +kubebuilder:validation:items:MinLength=1
+
+	// Services is the list of the names of the services of the cluster required to be healthy.
+	Services []string `json:"services,omitempty"`
+
+	// +listType=map
+	// +listMapKey=name
+
+	// Probes is the list of the custom probes run against the cluster.
+	Probes []ReadinessProbe `json:"probes,omitempty"`
+
+	// NodesReady requires all of the nodes of the cluster to be ready.
+	NodesReady bool `json:"nodesReady,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="has(self.http) != has(self.job)",message="exactly one of http or job must be set"
+
+// ReadinessProbe is a custom check of the cluster.
+type ReadinessProbe struct {
+	// HTTP requests a path from the API server of the cluster.
+	HTTP *HTTPReadinessProbe `json:"http,omitempty"`
+	// Job runs a Job in the cluster.
+	Job *JobReadinessProbe `json:"job,omitempty"`
+
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=40
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+
+	// Name is the unique name of the probe.
+	Name string `json:"name"`
+}
+
+// HTTPReadinessProbe passes if the API server of the cluster responds to the request with a 2xx status code.
+type HTTPReadinessProbe struct {
+	// +kubebuilder:validation:Pattern=`^/`
+
+	// Path is the path requested from the API server of the cluster, e.g. /readyz or the proxy path of a Service
+	// /api/v1/namespaces/<namespace>/services/<name>:<port>/proxy/<path>.
+	Path string `json:"path"`
+}
+
+// JobReadinessProbe passes once the Job run in the cluster completes successfully.
+// The Job is run again on each change of the ClusterDeployment.
+type JobReadinessProbe struct {
+	// +kubebuilder:default:=kube-system
+
+	// Namespace is the namespace of the cluster the Job is run in.
+	Namespace string `json:"namespace,omitempty"`
+
+	// +kubebuilder:validation:MinLength=1
+
+	// Image is the image of the container of the Job.
+	Image string `json:"image"`
+	// Command is the command of the container of the Job, the entrypoint of the image is used if empty.
+	Command []string `json:"command,omitempty"`
+}
+
+// ReadinessGateStatus reflects the result of a readiness gate of the cluster.
+type ReadinessGateStatus struct {
+	// Name is the name of the gate: NodesReady, service/<name> or probe/<name>.
+	Name string `json:"name"`
+	// Message is the human-readable description of the result.
+	Message string `json:"message,omitempty"`
+	// Passed reports whether the gate has passed.
+	Passed bool `json:"passed"`
+}
+
+// SveltosAgentStatus reflects a Sveltos agent running in the cluster.
+type SveltosAgentStatus struct {
+	// Name is the name of the agent Deployment.
+	Name string `json:"name"`
+	// Version is the version of the agent running in the cluster.
+	Version string `json:"version,omitempty"`
+	// ExpectedVersion is the version of the agent matching the Sveltos components of the management cluster.
+	ExpectedVersion string `json:"expectedVersion,omitempty"`
+	// Ready reports whether all the replicas of the agent are updated and available.
+	Ready bool `json:"ready"`
+}
+
+// TrustedCAStatus reflects the propagation of the trusted CA bundle to the cluster.
+type TrustedCAStatus struct {
+	// LastRolloutTime is the time the machines of the cluster were last rolled out to trust the changed bundle.
+	LastRolloutTime *metav1.Time `json:"lastRolloutTime,omitempty"`
+	// Hash is the hash of the bundle published in the cluster.
+	Hash string `json:"hash,omitempty"`
+	// MachinesHash is the hash of the bundle the machines of the cluster are bootstrapped with,
+	// empty if the template of the cluster does not support the trusted CA bundle.
+	MachinesHash string `json:"machinesHash,omitempty"`
+}
+
+// ClusterTimings reflects the duration of the provisioning and the last upgrade of the cluster.
+type ClusterTimings struct {
+	// Provisioning reflects the initial provisioning of the cluster.
+	Provisioning *ClusterOperationTiming `json:"provisioning,omitempty"`
+	// LastUpgrade reflects the last upgrade of the cluster to another ClusterTemplate.
+	LastUpgrade *ClusterOperationTiming `json:"lastUpgrade,omitempty"`
+}
+
+// ClusterOperationTiming reflects the start and the completion of a provisioning or an upgrade of the cluster.
+type ClusterOperationTiming struct {
+	// StartTime is the time the operation has been started at.
+	StartTime metav1.Time `json:"startTime"`
+	// CompletionTime is the time the cluster has been successfully deployed at after the operation.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Duration is the time the operation has taken to complete, unset if the operation has not been tracked since its start.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// Template is the name of the ClusterTemplate deployed by the operation.
+	Template string `json:"template"`
+	// FromTemplate is the name of the ClusterTemplate the cluster has been upgraded from, set for the upgrades only.
+	FromTemplate string `json:"fromTemplate,omitempty"`
+}
+
+// ControlPlaneMigrationPhase is the phase of the migration of the cluster control plane.
+type ControlPlaneMigrationPhase string
+
+const (
+	// ControlPlaneMigrationPhaseMigrating denotes the control plane of the target mode is being deployed.
+	ControlPlaneMigrationPhaseMigrating ControlPlaneMigrationPhase = "Migrating"
+	// ControlPlaneMigrationPhaseSucceeded denotes the cluster has been migrated with all of the recorded resources.
+	ControlPlaneMigrationPhaseSucceeded ControlPlaneMigrationPhase = "Succeeded"
+	// ControlPlaneMigrationPhaseFailed denotes resources recorded before the migration are missing after it.
+	ControlPlaneMigrationPhaseFailed ControlPlaneMigrationPhase = "Failed"
+)
+
+// ControlPlaneMigration reflects the migration of the cluster between the hosted and the standalone control plane.
+type ControlPlaneMigration struct {
+	// StartTime is the time the migration has been started at.
+	StartTime metav1.Time `json:"startTime"`
+	// CompletionTime is the time the migration has been completed at.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// FromTemplate is the name of the ClusterTemplate the cluster is migrated from.
+	FromTemplate string `json:"fromTemplate"`
+	// ToTemplate is the name of the ClusterTemplate the cluster is migrated to.
+	ToTemplate string `json:"toTemplate"`
+	// FromMode is the control plane mode the cluster is migrated from.
+	FromMode string `json:"fromMode"`
+	// ToMode is the control plane mode the cluster is migrated to.
+	ToMode string `json:"toMode"`
+
+	// +kubebuilder:validation:Enum=Migrating;Succeeded;Failed
+
+	// Phase is the phase of the migration.
+	Phase ControlPlaneMigrationPhase `json:"phase"`
+	// Inventory is the list of the resources of the cluster recorded before the migration
+	// and verified to exist after it, e.g. "Namespace/default" or "PersistentVolumeClaim/default/data".
+	Inventory []string `json:"inventory,omitempty"`
+	// Missing is the list of the resources of the inventory missing after the migration.
+	Missing []string `json:"missing,omitempty"`
+}
+
+// FailureDomainStatus reflects the machines of the cluster placed in a failure domain.
+type FailureDomainStatus struct {
+	// Name is the name of the failure domain.
+	Name string `json:"name"`
+	// ControlPlaneMachines is the number of the control plane machines in the failure domain.
+	ControlPlaneMachines int32 `json:"controlPlaneMachines,omitempty"`
+	// WorkerMachines is the number of the worker machines in the failure domain.
+	WorkerMachines int32 `json:"workerMachines,omitempty"`
+}
+
+// ResolvedOSImage is a reference to an OSImage in the values of the cluster resolved to an image.
+// The image is pinned until the Kubernetes version or the region of the cluster changes
+// or a NodeImageRollout rolls out a newer image of the OSImage.
+type ResolvedOSImage struct {
+	// Path is the dot-separated path of the values key referencing the OSImage, e.g. worker.amiID.
+	Path string `json:"path"`
+	// OSImage is the name of the referenced OSImage.
+	OSImage string `json:"osImage"`
+	// KubernetesVersion is the Kubernetes version the image has been resolved for.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// Region is the region the image has been resolved for.
+	Region string `json:"region,omitempty"`
+	// ID is the ID of the image.
+	ID string `json:"id"`
+}
+
+// ClusterRevision is a combination of the template and the configuration the cluster has been deployed with.
+type ClusterRevision struct {
+	// RecordedAt is the time the revision has been recorded at.
+	RecordedAt metav1.Time `json:"recordedAt"`
+	// Config is the configuration of the cluster.
+	Config *apiextensionsv1.JSON `json:"config,omitempty"`
+	// Template is the name of the ClusterTemplate.
+	Template string `json:"template"`
+	// KubernetesVersion is the Kubernetes version provided by the ClusterTemplate.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+}
+
+// DeletionStatus reflects the deletion of the cluster.
+type DeletionStatus struct {
+	// LastTransitionTime is the time the resources blocking the deletion have last changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+	// Finalizers is the list of the finalizers of the ClusterDeployment.
+	Finalizers []string `json:"finalizers,omitempty"`
+	// BlockingResources is the list of the resources the deletion of the cluster waits for.
+	BlockingResources []BlockingResource `json:"blockingResources,omitempty"`
+	// Stuck indicates the deletion takes longer than expected.
+	Stuck bool `json:"stuck,omitempty"`
+}
+
+// BlockingResource represents a resource the deletion of the cluster waits for.
+type BlockingResource struct {
+	// APIVersion is the API version of the resource.
+	APIVersion string `json:"apiVersion"`
+	// Kind is the kind of the resource.
+	Kind string `json:"kind"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+	// Finalizers is the list of the finalizers of the resource.
+	Finalizers []string `json:"finalizers,omitempty"`
+	// Deleting indicates the deletion of the resource has been requested.
+	Deleting bool `json:"deleting,omitempty"`
+	// Message is the details of the state of the resource.
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterRemediation configures the remediation of the unhealthy machines of the cluster
+// performed by the Cluster API MachineHealthChecks.
+type ClusterRemediation struct {
+	// MaxInFlight is the maximum number or percentage of the unhealthy machines
+	// the remediation is performed for, the remediation is stopped if more machines are unhealthy.
+	// Overrides the maxUnhealthy of the MachineHealthChecks of the cluster.
+	MaxInFlight *intstr.IntOrString `json:"maxInFlight,omitempty"`
+	// Paused pauses the MachineHealthChecks of the cluster so that no further machines are remediated.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// RemediationStatus reflects the remediation of the unhealthy machines of the cluster.
+type RemediationStatus struct {
+	// Remediations is the list of the most recent machine remediations.
+	Remediations []MachineRemediation `json:"remediations,omitempty"`
+	// UnhealthyMachines is the number of the machines failing the health checks.
+	UnhealthyMachines int32 `json:"unhealthyMachines,omitempty"`
+	// RemediationsAllowed is the number of further remediations allowed by the MachineHealthChecks.
+	RemediationsAllowed int32 `json:"remediationsAllowed,omitempty"`
+	// Paused indicates the MachineHealthChecks of the cluster are paused.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// MachineRemediation represents a machine remediated after failing a health check.
+type MachineRemediation struct {
+	// Time is the time the machine has failed the health check.
+	Time metav1.Time `json:"time"`
+	// Machine is the name of the remediated machine.
+	Machine string `json:"machine"`
+	// Reason is the reason the machine has failed the health check.
+	Reason string `json:"reason,omitempty"`
+	// Message is the details of the failed health check.
+	Message string `json:"message,omitempty"`
+}
+
+// ClusterTemplateReference references a ClusterTemplate in the given namespace.
+type ClusterTemplateReference struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Namespace is the namespace of the ClusterTemplate.
+	Namespace string `json:"namespace"`
+
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+
+	// Name is the name of the ClusterTemplate.
+	Name string `json:"name"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!(has(self.ttl) && has(self.expireAt))",message="ttl and expireAt are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.templateRef) || self.template == self.templateRef.name",message="template must match the name of templateRef"
+
 // ClusterDeploymentSpec defines the desired state of ClusterDeployment
 type ClusterDeploymentSpec struct {
 	// Config allows to provide parameters for template customization.
-	// If no Config provided, the field will be populated with the default values for
-	// the template and DryRun will be enabled.
+	// The default values of the template are applied on deployment and are not stored in the Config,
+	// the values set in the Config take precedence and a value set to null removes the default.
 	Config *apiextensionsv1.JSON `json:"config,omitempty"`
 
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=253
 
-	// Template is a reference to a Template object located in the same namespace.
+	// Template is a reference to a Template object located in the same namespace,
+	// or the name of the ClusterTemplate referenced with the TemplateRef.
 	Template string `json:"template"`
+	// TemplateRef references the ClusterTemplate located in another namespace, e.g. the shared catalog
+	// namespace, the ClusterTemplates of which must be granted to the namespace of the ClusterDeployment
+	// with the ClusterTemplateGrants of the AccessManagement rules. The Template is set to its name.
+	TemplateRef *ClusterTemplateReference `json:"templateRef,omitempty"`
 	// Name reference to the related Credentials object.
 	Credential string `json:"credential,omitempty"`
 	// ServiceSpec is spec related to deployment of services.
 	ServiceSpec ServiceSpec `json:"serviceSpec,omitempty"`
 	// DryRun specifies whether the template should be applied after validation or only validated.
 	DryRun bool `json:"dryRun,omitempty"`
+	// RenderValues enables the rendering of the Go templates with the Sprig functions in the string values
+	// of the Config and in the values of the services with the context of the cluster: .Cluster.Name,
+	// .Cluster.Namespace, .Cluster.Region, .Cluster.Labels, .Cluster.APIServerURL and .Cluster.Endpoints.
+	// The values are rendered just before they are applied, the Sveltos templates in the values
+	// of the services must be escaped as the literal strings of the Go templates.
+	RenderValues bool `json:"renderValues,omitempty"`
 	// +kubebuilder:default:=true
 
 	// PropagateCredentials indicates whether credentials should be propagated
 	// for use by CCM (Cloud Controller Manager).
 	PropagateCredentials bool `json:"propagateCredentials,omitempty"`
+	// ControlPlaneVIP configures the reservation of the virtual IP address
+	// used as the control plane endpoint of the cluster.
+	ControlPlaneVIP *ControlPlaneVIP `json:"controlPlaneVIP,omitempty"`
+	// DNS configures the DNS record published for the API endpoint of the cluster.
+	DNS *ClusterDNS `json:"dns,omitempty"`
+	// ClusterRoleBindings is the list of ClusterRoleBindings created in the cluster
+	// to grant the subjects access to the cluster.
+	ClusterRoleBindings []ClusterRoleBinding `json:"clusterRoleBindings,omitempty"`
+	// Authentication configures the authentication of the API server of the cluster.
+	Authentication *ClusterAuthentication `json:"authentication,omitempty"`
+	// GPU enables the NVIDIA GPU worker nodes of the cluster.
+	// The worker instance type is validated to provide GPUs and
+	// the GPU driver and device plugin are installed as a system service.
+	GPU *ClusterGPU `json:"gpu,omitempty"`
+	// PropagateLabels is the map of labels applied to the CAPI Cluster,
+	// its Machines and the Nodes of the cluster.
+	PropagateLabels map[string]string `json:"propagateLabels,omitempty"`
+	// PropagateAnnotations is the map of annotations applied to the CAPI Cluster,
+	// its Machines and the Nodes of the cluster.
+	PropagateAnnotations map[string]string `json:"propagateAnnotations,omitempty"`
+	// TTL is the lifetime of the cluster counted from the creation of the ClusterDeployment.
+	// The cluster expires once the lifetime elapses.
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// ExpireAt is the time the cluster expires at.
+	ExpireAt *metav1.Time `json:"expireAt,omitempty"`
+	// ExpirationPolicy defines the handling of the cluster expiration set with TTL or ExpireAt.
+	ExpirationPolicy *ClusterExpirationPolicy `json:"expirationPolicy,omitempty"`
+
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="cloneFrom is immutable"
+
+	// CloneFrom is the name of the ClusterDeployment in the same namespace the template,
+	// credential, configuration and services are copied from on creation. The values set
+	// in the ClusterDeployment take precedence, the configuration is merged with the copied one.
+	CloneFrom string `json:"cloneFrom,omitempty"`
+
+	// +kubebuilder:validation:Enum:=Sveltos;Flux;Applier
+
+	// ServiceDelivery defines how the services are delivered to the cluster, overrides the StateManagementProviders
+	// and the Management setting. With the Flux delivery, only the Helm-based ServiceTemplates are supported
+	// and the values are not templated. With the Applier delivery, only the resources-based ServiceTemplates
+	// with a ConfigMap or a Secret source are supported.
+	ServiceDelivery string `json:"serviceDelivery,omitempty"`
+
+	// LifecycleHooks configures the gating of the Cluster API lifecycle transitions of the cluster.
+	// Supported only by the ClusterClass-based ClusterTemplates.
+	LifecycleHooks *ClusterLifecycleHooks `json:"lifecycleHooks,omitempty"`
+	// Remediation configures the remediation of the unhealthy machines of the cluster.
+	Remediation *ClusterRemediation `json:"remediation,omitempty"`
+	// Observability overrides the settings of the metrics and logs agents enabled in the Management
+	// for the cluster, or enables the agents for the cluster only.
+	Observability *ClusterObservability `json:"observability,omitempty"`
+	// ProvisioningPolicy configures the retries of the failed initial provisioning of the cluster.
+	// The failed provisioning is retried indefinitely without backoff if not set.
+	ProvisioningPolicy *ProvisioningPolicy `json:"provisioningPolicy,omitempty"`
+	// FailureDomains configures the failure domains the control plane and the worker machines
+	// are spread across. The failure domains are passed to the template in the failureDomains value.
+	FailureDomains *ClusterFailureDomains `json:"failureDomains,omitempty"`
+	// Networks configures the networks attached to the machines of the cluster in addition to the primary
+	// network of the template. The networks are passed to the template in the networks value.
+	Networks *ClusterNetworks `json:"networks,omitempty"`
+	// Network configures the pod network of the cluster. The CNI selected here replaces the CNI
+	// of the template and is installed before the other services of the cluster.
+	Network *ClusterNetwork `json:"network,omitempty"`
+	// Proxy configures the HTTP proxy the machines and the services of the cluster reach the internet through.
+	// The proxy is passed to the template in the proxy value and to the services in the global.proxy value.
+	Proxy *ClusterProxy `json:"proxy,omitempty"`
+	// MachineAccess configures the SSH access to the machines and the bastion host of the cluster.
+	// The keys are passed to the template in the machineAccess value, the machines are rolled out
+	// once the keys change, e.g. the shared keys are rotated.
+	MachineAccess *MachineAccess `json:"machineAccess,omitempty"`
+	// CloudTags are the tags applied to all the cloud resources the infrastructure provider creates
+	// for the cluster. The tags are passed to the template in the cloudTags value and must include
+	// the mandatory tags of the cloud tag policy of the Management.
+	CloudTags map[string]string `json:"cloudTags,omitempty"`
+	// ReadinessGates configures the checks of the cluster that must pass before the cluster is declared ready,
+	// the rollouts to the clusters proceed to the next clusters only once the gates of the cluster pass.
+	ReadinessGates *ClusterReadinessGates `json:"readinessGates,omitempty"`
+
+	// +kubebuilder:validation:Enum:=Reconcile;Hold
+	// +kubebuilder:default:=Reconcile
+
+	// ConfigDriftPolicy defines the handling of the values of the HelmRelease changed outside
+	// of the ClusterDeployment. With Reconcile, the drifted values are reverted to the values
+	// produced from the spec. With Hold, the drifted values are kept and the updates of the HelmRelease
+	// are held until the drift is resolved by aligning the spec or switching the policy to Reconcile.
+	// The drift is reported in the ConfigDrift condition while it exists and with an event once detected.
+	ConfigDriftPolicy string `json:"configDriftPolicy,omitempty"`
+}
+
+// ClusterGPU configures the GPU worker nodes of the cluster.
+type ClusterGPU struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// ServiceTemplate is the name of the ServiceTemplate installing the NVIDIA
+	// driver and device plugin, e.g. the NVIDIA GPU Operator.
+	ServiceTemplate string `json:"serviceTemplate"`
+
+	// +kubebuilder:default:=gpu-operator
+
+	// Namespace is the namespace the GPU driver and device plugin are installed in.
+	Namespace string `json:"namespace,omitempty"`
+	// Values is the helm values passed to the chart of the ServiceTemplate.
+	Values string `json:"values,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="self.cni == 'none' || has(self.serviceTemplate)",message="serviceTemplate is required unless the CNI is none"
+
+// ClusterNetwork configures the pod network of the cluster.
+type ClusterNetwork struct {
+	// +kubebuilder:validation:Enum:=calico;cilium;none
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="cni is immutable"
+
+	// CNI is the network plugin of the cluster. The ClusterTemplate must list the CNI in its
+	// supported CNIs. With none, no CNI is installed and the CNI is brought by the user.
+	CNI string `json:"cni"`
+	// ServiceTemplate is the name of the ServiceTemplate installing the CNI.
+	ServiceTemplate string `json:"serviceTemplate,omitempty"`
+
+	// +kubebuilder:default:=kube-system
+
+	// Namespace is the namespace the CNI is installed in.
+	Namespace string `json:"namespace,omitempty"`
+	// Values is the helm values passed to the chart of the ServiceTemplate.
+	Values string `json:"values,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="has(self.httpProxy) || has(self.httpsProxy)",message="either httpProxy or httpsProxy must be set"
+
+// ClusterProxy configures the HTTP proxy of the cluster.
+type ClusterProxy struct {
+	// +kubebuilder:validation:Pattern=`^https?://`
+
+	// HTTPProxy is the URL of the proxy of the HTTP requests.
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// +kubebuilder:validation:Pattern=`^https?://`
+
+	// HTTPSProxy is the URL of the proxy of the HTTPS requests.
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// NoProxy is the list of the hosts, domains and CIDRs reached without the proxy
+	// in addition to the pods and the services CIDRs of the cluster, e.g. the subnets of the machines.
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// MachineAccess configures the SSH access to the machines of the cluster.
+type MachineAccess struct {
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=32
+	// +kubebuilder:validation:Pattern=`^[a-z_][a-z0-9_-]*$`
+
+	// User is the user of the machines the keys are authorized for, e.g. ubuntu.
+	User string `json:"user"`
+	// AuthorizedKeys is the list of the public SSH keys in the authorized_keys format
+	// authorized to log in to the machines.
+	AuthorizedKeys []string `json:"authorizedKeys,omitempty"`
+	// AuthorizedKeysConfigMap is the name of the ConfigMap in the namespace of the cluster holding
+	// the public SSH keys in its authorized_keys key in addition to the AuthorizedKeys.
+	// The ConfigMap is to be shared by the clusters to rotate the keys of all of them at once.
+	AuthorizedKeysConfigMap string `json:"authorizedKeysConfigMap,omitempty"`
+	// Bastion configures the bastion host the machines are reached through.
+	// The ClusterTemplate must support the bastion host.
+	Bastion *MachineBastion `json:"bastion,omitempty"`
+}
+
+// MachineBastion configures the bastion host of the cluster created by the infrastructure provider.
+type MachineBastion struct {
+	// Enabled creates the bastion host.
+	Enabled bool `json:"enabled"`
+	// AllowedCIDRBlocks is the list of the CIDRs allowed to reach the bastion host,
+	// the ClusterTemplate must support restricting the access to the bastion host.
+	AllowedCIDRBlocks []string `json:"allowedCIDRBlocks,omitempty"`
+}
+
+// MachineAccessStatus reflects the SSH access to the machines of the cluster.
+type MachineAccessStatus struct {
+	// LastRolloutTime is the time the machines of the cluster were last rolled out to apply the changed keys.
+	LastRolloutTime *metav1.Time `json:"lastRolloutTime,omitempty"`
+	// AuthorizedKeysHash is the hash of the user and the keys the machines of the cluster are bootstrapped with.
+	AuthorizedKeysHash string `json:"authorizedKeysHash,omitempty"`
+}
+
+// ClusterAuthentication configures the authentication of the API server of the cluster.
+type ClusterAuthentication struct {
+	// OIDC configures the OpenID Connect authentication.
+	// The ClusterTemplate must support passing extra arguments to the API server.
+	OIDC *ClusterOIDC `json:"oidc,omitempty"`
+}
+
+// ClusterOIDC configures the OpenID Connect authentication of the API server.
+type ClusterOIDC struct {
+	// +kubebuilder:validation:Pattern=`^https://`
+
+	// IssuerURL is the URL of the OpenID issuer, only the https scheme is accepted.
+	IssuerURL string `json:"issuerURL"`
+
+	// +kubebuilder:validation:MinLength=1
+
+	// ClientID is the client ID all the tokens must be issued for.
+	ClientID string `json:"clientID"`
+	// ClaimMappings configures the mapping of the token claims to the user attributes.
+	ClaimMappings OIDCClaimMappings `json:"claimMappings,omitempty"`
+	// RequiredClaims is the map of the claims required to be present in the token with the given values.
+	RequiredClaims map[string]string `json:"requiredClaims,omitempty"`
+}
+
+// OIDCClaimMappings configures the mapping of the OIDC token claims to the user attributes.
+type OIDCClaimMappings struct {
+	// UsernameClaim is the claim to use as the user name, defaults to "sub".
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+	// UsernamePrefix is the prefix prepended to the user names.
+	UsernamePrefix string `json:"usernamePrefix,omitempty"`
+	// GroupsClaim is the claim to use as the user groups.
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+	// GroupsPrefix is the prefix prepended to the group names.
+	GroupsPrefix string `json:"groupsPrefix,omitempty"`
+}
+
+// ClusterRoleBinding defines a ClusterRoleBinding created in the deployed cluster.
+type ClusterRoleBinding struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Name is the name of the ClusterRoleBinding.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:MinLength=1
+
+	// ClusterRole is the name of the ClusterRole in the cluster the subjects are bound to.
+	ClusterRole string `json:"clusterRole"`
+
+	// +kubebuilder:validation:MinItems=1
+
+	// Subjects is the list of the subjects bound to the ClusterRole.
+	Subjects []rbacv1.Subject `json:"subjects"`
+}
+
+// ClusterDNS configures the DNS record for the API endpoint of the cluster.
+// The record is published via external-dns using the DNSEndpoint source.
+type ClusterDNS struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Zone is the DNS zone the record is created in, e.g. "example.com".
+	Zone string `json:"zone"`
+	// Hostname is the name of the record within the zone.
+	// Defaults to the name of the ClusterDeployment.
+	Hostname string `json:"hostname,omitempty"`
+
+	// +kubebuilder:default:=300
+	// +kubebuilder:validation:Minimum=1
+
+	// TTL is the time to live of the record in seconds.
+	TTL int64 `json:"ttl,omitempty"`
+}
+
+// ClusterEndpoint represents an endpoint of the cluster: the API server, the ingress
+// load balancer, the OIDC issuer or a DNS record published for the cluster.
+type ClusterEndpoint struct {
+	// +kubebuilder:default:=DNSRecord
+	// +kubebuilder:validation:Enum=DNSRecord;APIServer;IngressLoadBalancer;OIDCIssuer
+
+	// Type is the type of the endpoint.
+	Type string `json:"type,omitempty"`
+	// URL is the URL of the API server or the OIDC issuer.
+	URL string `json:"url,omitempty"`
+	// DNSName is the fully qualified name of the DNS record.
+	DNSName string `json:"dnsName,omitempty"`
+	// RecordType is the type of the DNS record, e.g. A or CNAME.
+	RecordType string `json:"recordType,omitempty"`
+	// Targets is the list of targets the DNS record points to
+	// or the addresses of the ingress load balancer.
+	Targets []string `json:"targets,omitempty"`
+}
+
+// CostEstimate is the estimated cost of the cluster nodes.
+type CostEstimate struct {
+	// MonthlyCost is the estimated monthly cost of the cluster nodes.
+	MonthlyCost string `json:"monthlyCost"`
+	// Currency is the currency of the cost as defined by the price list.
+	Currency string `json:"currency"`
+	// UnpricedInstanceTypes is the list of the instance types missing in the price list
+	// and therefore not accounted in the estimated cost.
+	UnpricedInstanceTypes []string `json:"unpricedInstanceTypes,omitempty"`
+}
+
+const (
+	// UpgradeCandidateReasonNotInChain denotes the template is not listed as an upgrade
+	// of the current template in any ClusterTemplateChain.
+	UpgradeCandidateReasonNotInChain = "NotInChain"
+	// UpgradeCandidateReasonInvalid denotes the template has not passed validation.
+	UpgradeCandidateReasonInvalid = "InvalidTemplate"
+	// UpgradeCandidateReasonProviderMismatch denotes the template requires
+	// other infrastructure providers than the current template.
+	UpgradeCandidateReasonProviderMismatch = "ProviderMismatch"
+	// UpgradeCandidateReasonKubernetesVersionSkew denotes the Kubernetes version of the template
+	// is either lower than the current one or skips a minor version.
+	UpgradeCandidateReasonKubernetesVersionSkew = "KubernetesVersionSkew"
+	// UpgradeCandidateReasonDeprecated denotes the template is deprecated.
+	UpgradeCandidateReasonDeprecated = "Deprecated"
+)
+
+// UpgradeCandidateReason describes why a ClusterTemplate is not a valid upgrade target.
+type UpgradeCandidateReason struct {
+	// +kubebuilder:validation:Enum=NotInChain;InvalidTemplate;ProviderMismatch;KubernetesVersionSkew;Deprecated
+
+	// Type is the machine-readable kind of the reason.
+	Type string `json:"type"`
+	// Message is the human-readable explanation of the reason.
+	Message string `json:"message,omitempty"`
+}
+
+// UpgradeCandidate describes whether a ClusterTemplate is a valid upgrade target of the cluster.
+type UpgradeCandidate struct {
+	// Template is the name of the ClusterTemplate.
+	Template string `json:"template"`
+	// Reasons is the list of reasons the template is not a valid upgrade target.
+	Reasons []UpgradeCandidateReason `json:"reasons,omitempty"`
+	// Available reports whether the template is listed in the available upgrades of the cluster.
+	Available bool `json:"available"`
 }
 
 // ClusterDeploymentStatus defines the observed state of ClusterDeployment
 type ClusterDeploymentStatus struct {
 	// Services contains details for the state of services.
 	Services []ServiceStatus `json:"services,omitempty"`
+	// ServiceConflicts is the list of the services of the ClusterDeployment also deployed
+	// to the cluster by the MultiClusterServices along with the objects deploying them.
+	ServiceConflicts []ServiceConflict `json:"serviceConflicts,omitempty"`
+	// ServiceHistory is the list of the revisions the services have been successfully deployed with,
+	// the services are reverted to them with the k0rdent.mirantis.com/service-rollback annotation.
+	ServiceHistory []ServiceHistory `json:"serviceHistory,omitempty"`
 	// Currently compatible exact Kubernetes version of the cluster. Being set only if
 	// provided by the corresponding ClusterTemplate.
 	KubernetesVersion string `json:"k8sVersion,omitempty"`
+	// ControlPlaneVIP is the virtual IP address reserved for the control plane endpoint of the cluster.
+	ControlPlaneVIP string `json:"controlPlaneVIP,omitempty"`
+	// Endpoints is the list of the endpoints of the cluster: the API server URL, the addresses of the
+	// ingress load balancer, the OIDC issuer URL and the DNS records published for the API endpoint.
+	Endpoints []ClusterEndpoint `json:"endpoints,omitempty"`
+	// CertificatesExpireAt is the earliest expiry time of the certificates
+	// of the cluster machines as reported by the CAPI Machines.
+	// Only the kubeadm bootstrap provider reports the expiry, the expiry of the certificates of the
+	// k0s and k0smotron machines and of the kubelet certificates is unknown and is reported
+	// with the CertificatesExpiryUnknown reason of the CertificatesValid condition.
+	CertificatesExpireAt *metav1.Time `json:"certificatesExpireAt,omitempty"`
+	// ExpiresAt is the time the cluster expires at including the extension
+	// set with the k0rdent.mirantis.com/expiration-extension annotation.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// CostEstimate is the estimated cost of the cluster nodes
+	// based on the price list of the infrastructure provider.
+	CostEstimate *CostEstimate `json:"costEstimate,omitempty"`
+	// Remediation reflects the remediation of the unhealthy machines of the cluster.
+	Remediation *RemediationStatus `json:"remediation,omitempty"`
+	// Provisioning reflects the initial provisioning of the cluster governed by the provisioning policy.
+	Provisioning *ProvisioningStatus `json:"provisioning,omitempty"`
+	// Deletion reflects the deletion of the cluster and the resources blocking it.
+	Deletion *DeletionStatus `json:"deletion,omitempty"`
+	// FailureDomains is the distribution of the machines of the cluster across the failure domains.
+	FailureDomains []FailureDomainStatus `json:"failureDomains,omitempty"`
+	// OSImages is the list of the references to the OSImages in the values of the cluster
+	// resolved to the images the machines of the cluster are deployed with.
+	OSImages []ResolvedOSImage `json:"osImages,omitempty"`
+	// LastKnownGood is the last revision of the cluster that has been successfully deployed,
+	// the cluster is reverted to it on the request with the k0rdent.mirantis.com/rollback annotation.
+	LastKnownGood *ClusterRevision `json:"lastKnownGood,omitempty"`
+	// ReadinessGates is the list of the results of the readiness gates of the cluster.
+	ReadinessGates []ReadinessGateStatus `json:"readinessGates,omitempty"`
+	// SveltosAgents is the list of the Sveltos agents running in the cluster.
+	SveltosAgents []SveltosAgentStatus `json:"sveltosAgents,omitempty"`
+	// Timings reflects the duration of the provisioning and the last upgrade of the cluster.
+	Timings *ClusterTimings `json:"timings,omitempty"`
+	// ControlPlaneMigration reflects the last migration of the cluster between the hosted and the standalone control plane.
+	ControlPlaneMigration *ControlPlaneMigration `json:"controlPlaneMigration,omitempty"`
+	// TrustedCA reflects the propagation of the trusted CA bundle of the Management to the cluster.
+	TrustedCA *TrustedCAStatus `json:"trustedCA,omitempty"`
+	// MachineAccess reflects the SSH keys the machines of the cluster are bootstrapped with.
+	MachineAccess *MachineAccessStatus `json:"machineAccess,omitempty"`
+
+	// +kubebuilder:validation:Enum=Enforced;OptedOut
+
+	// SecurityBaseline reflects whether the security baseline configured
+	// in the Management is enforced on the cluster.
+	SecurityBaseline string `json:"securityBaseline,omitempty"`
 	// Conditions contains details for the current state of the ClusterDeployment.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
@@ -89,6 +1143,9 @@ type ClusterDeploymentStatus struct {
 	// this cluster can be upgraded. It can be an empty array, which means no upgrades are
 	// available.
 	AvailableUpgrades []string `json:"availableUpgrades,omitempty"`
+	// UpgradeCandidates is the list of the ClusterTemplates from the namespace of the cluster
+	// along with the reasons why they are or are not valid upgrade targets.
+	UpgradeCandidates []UpgradeCandidate `json:"upgradeCandidates,omitempty"`
 	// ObservedGeneration is the last observed generation.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
@@ -96,6 +1153,7 @@ type ClusterDeploymentStatus struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=clusterd;cld
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Shows readiness of the ClusterDeployment",priority=0
 // +kubebuilder:printcolumn:name="Services",type="string",JSONPath=`.status.conditions[?(@.type=="ServicesInReadyState")].message`,description="Number of ready out of total services",priority=0
 // +kubebuilder:printcolumn:name="Template",type="string",JSONPath=`.spec.template`,description="ClusterTemplate used for the ClusterDeployment",priority=0
@@ -147,6 +1205,100 @@ func (in *ClusterDeployment) AddHelmValues(fn func(map[string]any) error) error
 	return in.SetHelmValues(values)
 }
 
+// TemplateNamespace returns the namespace of the ClusterTemplate of the ClusterDeployment.
+func (in *ClusterDeployment) TemplateNamespace() string {
+	if in.Spec.TemplateRef != nil {
+		return in.Spec.TemplateRef.Namespace
+	}
+	return in.Namespace
+}
+
+// SetTemplate sets the name of the ClusterTemplate of the ClusterDeployment
+// keeping the ClusterTemplate referenced with the TemplateRef in sync.
+func (in *ClusterDeployment) SetTemplate(name string) {
+	in.Spec.Template = name
+	if in.Spec.TemplateRef != nil {
+		in.Spec.TemplateRef.Name = name
+	}
+}
+
+// Expiration returns the time the cluster expires at including the extension set with
+// the ExpirationExtensionAnnotation annotation or nil if the expiration is not configured.
+func (in *ClusterDeployment) Expiration() (*metav1.Time, error) {
+	var expireAt time.Time
+	switch {
+	case in.Spec.ExpireAt != nil:
+		expireAt = in.Spec.ExpireAt.Time
+	case in.Spec.TTL != nil:
+		expireAt = in.CreationTimestamp.Add(in.Spec.TTL.Duration)
+	default:
+		return nil, nil
+	}
+
+	if v, ok := in.Annotations[ExpirationExtensionAnnotation]; ok {
+		extension, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the %s annotation: %w", ExpirationExtensionAnnotation, err)
+		}
+		expireAt = expireAt.Add(extension)
+	}
+
+	return &metav1.Time{Time: expireAt}, nil
+}
+
+// endpointTypes is the order the endpoints of the cluster are listed in.
+var endpointTypes = []string{EndpointTypeAPIServer, EndpointTypeIngressLoadBalancer, EndpointTypeOIDCIssuer, EndpointTypeDNSRecord}
+
+// Endpoints returns the endpoints of the cluster of the given type.
+// The endpoints without the type set are the DNS records.
+func (in *ClusterDeployment) Endpoints(typ string) []ClusterEndpoint {
+	var endpoints []ClusterEndpoint
+	for _, e := range in.Status.Endpoints {
+		if e.EndpointType() == typ {
+			endpoints = append(endpoints, e)
+		}
+	}
+
+	return endpoints
+}
+
+// SetEndpoints replaces the endpoints of the cluster of the given type with the given ones
+// and reports whether the endpoints have changed.
+func (in *ClusterDeployment) SetEndpoints(typ string, endpoints ...ClusterEndpoint) bool {
+	for i := range endpoints {
+		endpoints[i].Type = typ
+	}
+	if current := in.Endpoints(typ); len(current) == len(endpoints) && (len(current) == 0 || reflect.DeepEqual(current, endpoints)) {
+		return false
+	}
+
+	updated := make([]ClusterEndpoint, 0, len(in.Status.Endpoints)+len(endpoints))
+	for _, e := range in.Status.Endpoints {
+		if e.EndpointType() != typ {
+			updated = append(updated, e)
+		}
+	}
+	updated = append(updated, endpoints...)
+	slices.SortStableFunc(updated, func(a, b ClusterEndpoint) int {
+		return slices.Index(endpointTypes, a.EndpointType()) - slices.Index(endpointTypes, b.EndpointType())
+	})
+
+	in.Status.Endpoints = nil
+	if len(updated) > 0 {
+		in.Status.Endpoints = updated
+	}
+
+	return true
+}
+
+// EndpointType returns the type of the endpoint defaulting to the DNS record.
+func (in *ClusterEndpoint) EndpointType() string {
+	if in.Type == "" {
+		return EndpointTypeDNSRecord
+	}
+	return in.Type
+}
+
 func (in *ClusterDeployment) GetConditions() *[]metav1.Condition {
 	return &in.Status.Conditions
 }
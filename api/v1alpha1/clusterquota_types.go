@@ -0,0 +1,75 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterQuotaKind is the string representation of a ClusterQuota.
+const ClusterQuotaKind = "ClusterQuota"
+
+// ClusterQuotaSpec defines the desired state of ClusterQuota
+type ClusterQuotaSpec struct {
+	// NamespaceSelector selects the namespaces the quota is applied to.
+	// An empty selector matches all namespaces.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+
+	// MaxClusters is the maximum number of ClusterDeployments in each of the selected namespaces.
+	MaxClusters *int32 `json:"maxClusters,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+
+	// MaxNodes is the maximum total number of control plane and worker nodes
+	// of the ClusterDeployments in each of the selected namespaces.
+	// The number of nodes is read from the node pools values of the ClusterDeployment
+	// configuration merged with the ClusterTemplate defaults, such as controlPlaneNumber
+	// and workersNumber. The ClusterDeployments being deleted are not counted.
+	MaxNodes *int32 `json:"maxNodes,omitempty"`
+
+	// AllowedInstanceSizes is the list of instance sizes allowed for the node pools
+	// of the ClusterDeployment, such as the instanceType, vmSize, machineType or flavor
+	// values of the controlPlane and worker. If empty, any instance size is allowed.
+	AllowedInstanceSizes []string `json:"allowedInstanceSizes,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=cquota
+// +kubebuilder:printcolumn:name="Max clusters",type="integer",JSONPath=`.spec.maxClusters`,description="Maximum number of clusters per namespace",priority=0
+// +kubebuilder:printcolumn:name="Max nodes",type="integer",JSONPath=`.spec.maxNodes`,description="Maximum number of nodes per namespace",priority=0
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+
+// ClusterQuota is the Schema for the clusterquotas API
+type ClusterQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterQuotaSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterQuotaList contains a list of ClusterQuota
+type ClusterQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterQuota{}, &ClusterQuotaList{})
+}
@@ -0,0 +1,156 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterRequestKind is the string representation of a ClusterRequest.
+	ClusterRequestKind = "ClusterRequest"
+
+	// ClusterRequestLabelKey is the label set on the ClusterDeployment
+	// created for a ClusterRequest holding the name of the request.
+	ClusterRequestLabelKey = "k0rdent.mirantis.com/cluster-request"
+
+	// ClusterRequestProvisionedCondition indicates that the ClusterDeployment has been created for the request.
+	ClusterRequestProvisionedCondition = "Provisioned"
+)
+
+const (
+	// ClusterRequestDecisionApproved denotes the approved request.
+	ClusterRequestDecisionApproved = "Approved"
+	// ClusterRequestDecisionDenied denotes the denied request.
+	ClusterRequestDecisionDenied = "Denied"
+)
+
+const (
+	// ClusterRequestPhasePending denotes the request waiting for a decision.
+	ClusterRequestPhasePending = "Pending"
+	// ClusterRequestPhaseDenied denotes the denied request.
+	ClusterRequestPhaseDenied = "Denied"
+	// ClusterRequestPhaseFailed denotes the approved request the ClusterDeployment could not be created for.
+	ClusterRequestPhaseFailed = "Failed"
+	// ClusterRequestPhaseProvisioned denotes the approved request the ClusterDeployment has been created for.
+	ClusterRequestPhaseProvisioned = "Provisioned"
+	// ClusterRequestPhaseExpired denotes the request the ClusterDeployment has been removed for
+	// either due to the expiration of the requested duration or manually.
+	ClusterRequestPhaseExpired = "Expired"
+)
+
+// ClusterRequestSize defines the number of nodes of the requested cluster.
+type ClusterRequestSize struct {
+	// +kubebuilder:validation:Minimum=1
+
+	// ControlPlaneNumber is the number of the control plane nodes.
+	// It is passed with the controlPlaneNumber value of the ClusterDeployment configuration.
+	ControlPlaneNumber int32 `json:"controlPlaneNumber,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+
+	// WorkersNumber is the number of the worker nodes.
+	// It is passed with the workersNumber value of the ClusterDeployment configuration.
+	WorkersNumber int32 `json:"workersNumber,omitempty"`
+}
+
+// ClusterRequestSpec defines the desired state of ClusterRequest
+type ClusterRequestSpec struct {
+	// Config allows to provide parameters for the ClusterDeployment created for the request.
+	// The values from Size take precedence over the corresponding values of the Config.
+	Config *apiextensionsv1.JSON `json:"config,omitempty"`
+	// Size is the requested number of nodes of the cluster.
+	Size *ClusterRequestSize `json:"size,omitempty"`
+	// Duration is the requested lifetime of the cluster set as the TTL of the created ClusterDeployment.
+	// The cluster is not removed automatically if unset.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// +kubebuilder:validation:MinLength=1
+
+	// Template is the name of the ClusterTemplate the cluster is requested to be deployed from.
+	Template string `json:"template"`
+	// Credential is the name of the Credential the cluster is requested to be deployed with.
+	// It can be overridden by the platform administrator approving the request.
+	Credential string `json:"credential,omitempty"`
+	// Justification is the human-readable reason of the request.
+	Justification string `json:"justification,omitempty"`
+}
+
+// ClusterRequestApproval defines the decision made on the ClusterRequest by the platform administrator.
+type ClusterRequestApproval struct {
+	// +kubebuilder:validation:Enum=Approved;Denied
+
+	// Decision is the decision made on the request.
+	Decision string `json:"decision"`
+	// Reviewer is the name of the platform administrator who made the decision.
+	Reviewer string `json:"reviewer,omitempty"`
+	// Message is the human-readable explanation of the decision.
+	Message string `json:"message,omitempty"`
+	// Credential is the name of the Credential the cluster is deployed with.
+	// Takes precedence over the Credential requested in the spec.
+	Credential string `json:"credential,omitempty"`
+}
+
+// ClusterRequestStatus defines the observed state of ClusterRequest
+type ClusterRequestStatus struct {
+	// Approval is the decision made on the request. It is set by the platform
+	// administrator via the status subresource and cannot be changed once set.
+	Approval *ClusterRequestApproval `json:"approval,omitempty"`
+	// ExpiresAt is the time the ClusterDeployment created for the request is removed at.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+	// ClusterDeployment is the name of the ClusterDeployment created for the request.
+	ClusterDeployment string `json:"clusterDeployment,omitempty"`
+
+	// +kubebuilder:validation:Enum=Pending;Denied;Failed;Provisioned;Expired
+
+	// Phase is the current phase of the request.
+	Phase string `json:"phase,omitempty"`
+	// Conditions contains details for the current state of the ClusterRequest.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ObservedGeneration is the last observed generation.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=creq
+// +kubebuilder:printcolumn:name="Template",type="string",JSONPath=`.spec.template`,description="Requested ClusterTemplate",priority=0
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=`.status.phase`,description="Phase of the request",priority=0
+// +kubebuilder:printcolumn:name="Reviewer",type="string",JSONPath=`.status.approval.reviewer`,description="Reviewer of the request",priority=1
+// +kubebuilder:printcolumn:name="Expires",type="string",JSONPath=`.status.expiresAt`,description="Expiration time of the cluster",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+
+// ClusterRequest is the Schema for the clusterrequests API
+type ClusterRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterRequestSpec   `json:"spec,omitempty"`
+	Status ClusterRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterRequestList contains a list of ClusterRequest
+type ClusterRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterRequest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterRequest{}, &ClusterRequestList{})
+}
@@ -16,8 +16,10 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -26,6 +28,20 @@ const (
 	ClusterTemplateKind = "ClusterTemplate"
 	// ChartAnnotationKubernetesVersion is an annotation containing the Kubernetes exact version in the SemVer format associated with a ClusterTemplate.
 	ChartAnnotationKubernetesVersion = "k0rdent.mirantis.com/k8s-version"
+	// ClusterTemplateDeprecatedAnnotation marks a ClusterTemplate as deprecated. The value
+	// is an optional human-readable explanation, e.g. the suggested replacement.
+	ClusterTemplateDeprecatedAnnotation = "k0rdent.mirantis.com/deprecated"
+	// ChartAnnotationControlPlaneMode is an annotation containing the mode of the control plane
+	// of the clusters deployed by a ClusterTemplate, either "hosted" or "standalone".
+	ChartAnnotationControlPlaneMode = "k0rdent.mirantis.com/control-plane-mode"
+	// ChartAnnotationSupportedCNIs is an annotation containing the comma-separated list of the CNIs
+	// a ClusterTemplate can run the clusters with instead of its own CNI, e.g. "calico, cilium, none".
+	ChartAnnotationSupportedCNIs = "k0rdent.mirantis.com/supported-cnis"
+
+	// ControlPlaneModeHosted denotes the control plane running in the management cluster.
+	ControlPlaneModeHosted = "hosted"
+	// ControlPlaneModeStandalone denotes the control plane running on the machines of the cluster.
+	ControlPlaneModeStandalone = "standalone"
 )
 
 // ClusterTemplateSpec defines the desired state of ClusterTemplate
@@ -43,6 +59,21 @@ type ClusterTemplateSpec struct {
 	// Providers represent required CAPI providers.
 	// Should be set if not present in the Helm chart metadata.
 	Providers Providers `json:"providers,omitempty"`
+	// ClusterClass references the Cluster API ClusterClass the clusters are created from
+	// with a managed topology. The reference is passed to the chart with the clusterClass value,
+	// the topology variables set with the variables value are validated against the ClusterClass.
+	ClusterClass *ClusterClassReference `json:"clusterClass,omitempty"`
+}
+
+// ClusterClassReference references a Cluster API ClusterClass.
+type ClusterClassReference struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Name is the name of the ClusterClass.
+	Name string `json:"name"`
+	// Namespace is the namespace of the ClusterClass.
+	// Defaults to the namespace of the ClusterDeployment.
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // ClusterTemplateStatus defines the observed state of ClusterTemplate
@@ -58,6 +89,16 @@ type ClusterTemplateStatus struct {
 	KubernetesVersion string `json:"k8sVersion,omitempty"`
 	// Providers represent required CAPI providers.
 	Providers Providers `json:"providers,omitempty"`
+	// ControlPlaneMode is the mode of the control plane of the clusters deployed by this ClusterTemplate,
+	// either "hosted" or "standalone", if set in the Helm chart metadata.
+	ControlPlaneMode string `json:"controlPlaneMode,omitempty"`
+	// SupportedCNIs is the list of the CNIs the clusters deployed by this ClusterTemplate
+	// can be run with instead of the CNI of the template, if set in the Helm chart metadata.
+	SupportedCNIs []string `json:"supportedCNIs,omitempty"`
+	// ConfigSchema is the JSON schema of the configuration of the ClusterDeployments deployed by this
+	// ClusterTemplate, i.e. the schema of the Helm chart values, if the chart defines one. The clients
+	// can render the forms and validate the configuration against the schema before creating the ClusterDeployments.
+	ConfigSchema *apiextensionsv1.JSON `json:"configSchema,omitempty"`
 
 	TemplateStatusCommon `json:",inline"`
 }
@@ -74,6 +115,26 @@ func (t *ClusterTemplate) FillStatusWithProviders(annotations map[string]string)
 
 	t.Status.ProviderContracts = contractsStatus
 
+	switch mode := annotations[ChartAnnotationControlPlaneMode]; mode {
+	case "", ControlPlaneModeHosted, ControlPlaneModeStandalone:
+		t.Status.ControlPlaneMode = mode
+	default:
+		return fmt.Errorf("invalid control plane mode %s for ClusterTemplate %s/%s, expected %s or %s",
+			mode, t.GetNamespace(), t.GetName(), ControlPlaneModeHosted, ControlPlaneModeStandalone)
+	}
+
+	t.Status.SupportedCNIs = nil
+	for cni := range strings.SplitSeq(annotations[ChartAnnotationSupportedCNIs], ",") {
+		switch cni = strings.TrimSpace(cni); cni {
+		case "":
+		case CNICalico, CNICilium, CNINone:
+			t.Status.SupportedCNIs = append(t.Status.SupportedCNIs, cni)
+		default:
+			return fmt.Errorf("invalid CNI %s for ClusterTemplate %s/%s, expected %s, %s or %s",
+				cni, t.GetNamespace(), t.GetName(), CNICalico, CNICilium, CNINone)
+		}
+	}
+
 	kversion := annotations[ChartAnnotationKubernetesVersion]
 	if t.Spec.KubernetesVersion != "" {
 		kversion = t.Spec.KubernetesVersion
@@ -31,6 +31,18 @@ const (
 // ReadyCondition indicates a resource is ready and fully reconciled.
 const ReadyCondition string = "Ready"
 
+const (
+	// ReconciliationPausedCondition indicates the changes of a resource made by the KCM controllers are paused
+	// with the reconciliation freeze switch of the Management or of the namespace of the resource.
+	ReconciliationPausedCondition = "ReconciliationPaused"
+	// ReconciliationPausedReason indicates the reconciliation of a resource is paused.
+	ReconciliationPausedReason = "ReconciliationPaused"
+
+	// ReconciliationPausedAnnotation is an annotation on a namespace overriding the reconciliation freeze switch
+	// of the Management for the resources in the namespace: "true" pauses and "false" resumes the reconciliation.
+	ReconciliationPausedAnnotation = "k0rdent.mirantis.com/reconciliation-paused"
+)
+
 type (
 	// Holds different types of CAPI providers.
 	Providers []string
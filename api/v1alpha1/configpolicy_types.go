@@ -0,0 +1,121 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConfigPolicyKind is the string representation of a ConfigPolicy.
+const ConfigPolicyKind = "ConfigPolicy"
+
+// +kubebuilder:validation:XValidation:rule="has(self.rules) || has(self.overlays)",message="either rules or overlays must be set"
+
+// ConfigPolicySpec defines the desired state of ConfigPolicy
+type ConfigPolicySpec struct {
+	// NamespaceSelector selects the namespaces of the ClusterDeployments the policy is applied to.
+	// An empty selector matches all namespaces.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ClusterSelector selects the ClusterDeployments the policy is applied to by their labels.
+	// An empty selector matches all ClusterDeployments.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// +listType=map
+	// +listMapKey=name
+
+	// Rules is the list of rules the configuration of the selected ClusterDeployments must satisfy.
+	Rules []ConfigPolicyRule `json:"rules,omitempty"`
+
+	// +listType=map
+	// +listMapKey=name
+
+	// Overlays is the list of the values merged into the configuration of the selected ClusterDeployments
+	// on their creation, e.g. per environment or region. The values set in the configuration of
+	// a ClusterDeployment take precedence over the overlays, the overlays take precedence over
+	// the defaults of the ClusterTemplate. The overlays of the policies are merged in the order
+	// of the names of the policies, the later overlays take precedence over the earlier ones.
+	Overlays []ConfigOverlay `json:"overlays,omitempty"`
+}
+
+// ConfigOverlay is the values merged into the configuration of the ClusterDeployments.
+type ConfigOverlay struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Name is the name of the overlay, unique within the policy.
+	Name string `json:"name"`
+
+	// Selector additionally selects the ClusterDeployments the overlay is applied to by their labels,
+	// e.g. environment=prod. An empty selector matches all of the ClusterDeployments selected by the policy.
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Values is the values merged into the configuration. The values are rendered with the context
+	// of the cluster as the rest of the configuration if RenderValues is enabled in the ClusterDeployment,
+	// e.g. to derive the values from the name of the cluster with .Cluster.Name.
+	Values apiextensionsv1.JSON `json:"values"`
+}
+
+// ConfigPolicyRule is a rule evaluated against the configuration of a ClusterDeployment.
+type ConfigPolicyRule struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Name is the name of the rule, unique within the policy.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:MinLength=1
+
+	// Expression is the CEL expression returning true if the configuration satisfies the rule.
+	// The following variables are available in the expression:
+	//   - 'config' is the configuration of the ClusterDeployment merged with
+	//     the defaults of its ClusterTemplate;
+	//   - 'object' is the ClusterDeployment itself.
+	//
+	// Example: "!has(config.controlPlaneNumber) || config.controlPlaneNumber % 2 == 1"
+	Expression string `json:"expression"`
+
+	// Message is the message reported when the rule is violated.
+	// Defaults to the expression of the rule.
+	Message string `json:"message,omitempty"`
+
+	// FieldPath is the dot-separated path of the configuration field the violation
+	// is reported for, e.g. "worker.instanceType". Defaults to the whole configuration.
+	FieldPath string `json:"fieldPath,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=cpol
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+
+// ConfigPolicy is the Schema for the configpolicies API
+type ConfigPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ConfigPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ConfigPolicyList contains a list of ConfigPolicy
+type ConfigPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConfigPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ConfigPolicy{}, &ConfigPolicyList{})
+}
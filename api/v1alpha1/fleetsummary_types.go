@@ -0,0 +1,140 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// FleetSummaryKind is the string representation of a FleetSummary.
+	FleetSummaryKind = "FleetSummary"
+	// FleetSummaryName is the name of the FleetSummary object maintained by the controller.
+	FleetSummaryName = "kcm"
+
+	// FleetKubeconfigSecretName is the name of the Secret in each namespace holding the aggregate
+	// kubeconfig with a context per ready ClusterDeployment of the namespace.
+	FleetKubeconfigSecretName = "kcm-fleet-kubeconfig"
+	// FleetKubeconfigSecretKey is the key of the aggregate kubeconfig in the fleet kubeconfig Secret.
+	FleetKubeconfigSecretKey = "value"
+)
+
+const (
+	// ClusterPhaseProvisioning denotes the ClusterDeployment has not yet reported its readiness.
+	ClusterPhaseProvisioning = "Provisioning"
+	// ClusterPhaseReady denotes the ClusterDeployment is ready.
+	ClusterPhaseReady = "Ready"
+	// ClusterPhaseNotReady denotes the ClusterDeployment is not ready.
+	ClusterPhaseNotReady = "NotReady"
+	// ClusterPhaseDeleting denotes the ClusterDeployment is being deleted.
+	ClusterPhaseDeleting = "Deleting"
+)
+
+// FleetSummarySpec defines the desired state of FleetSummary
+type FleetSummarySpec struct{}
+
+// FleetClusterSummary contains the key attributes of a ClusterDeployment.
+type FleetClusterSummary struct {
+	// Name is the name of the ClusterDeployment.
+	Name string `json:"name"`
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string `json:"namespace"`
+	// Template is the name of the ClusterTemplate the cluster is deployed from.
+	Template string `json:"template"`
+	// Providers is the list of the infrastructure providers of the ClusterTemplate.
+	Providers []string `json:"providers,omitempty"`
+	// KubernetesVersion is the Kubernetes version of the cluster.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// +kubebuilder:validation:Enum=Provisioning;Ready;NotReady;Deleting
+
+	// Phase is the lifecycle phase of the cluster.
+	Phase string `json:"phase"`
+	// AvailableUpgrades is the list of ClusterTemplate names the cluster can be upgraded to.
+	AvailableUpgrades []string `json:"availableUpgrades,omitempty"`
+	// Region is the region of the cluster set in the region or the location value of the cluster configuration.
+	Region string `json:"region,omitempty"`
+	// KubernetesMinorVersion is the minor version of Kubernetes of the cluster, e.g. 1.32.
+	KubernetesMinorVersion string `json:"kubernetesMinorVersion,omitempty"`
+	// Services is the list of the services installed in the cluster.
+	Services []FleetServiceSummary `json:"services,omitempty"`
+}
+
+// FleetServiceSummary contains the key attributes of a service installed in a cluster.
+type FleetServiceSummary struct {
+	// Name is the name of the service.
+	Name string `json:"name"`
+	// Template is the name of the ServiceTemplate the service is installed from.
+	Template string `json:"template"`
+	// Chart is the name of the Helm chart of the ServiceTemplate.
+	Chart string `json:"chart,omitempty"`
+	// Version is the version of the Helm chart of the ServiceTemplate.
+	Version string `json:"version,omitempty"`
+}
+
+// FleetSummaryStatus defines the observed state of FleetSummary
+type FleetSummaryStatus struct {
+	// ByProvider is the number of clusters per infrastructure provider.
+	ByProvider map[string]int32 `json:"byProvider,omitempty"`
+	// ByTemplate is the number of clusters per ClusterTemplate.
+	ByTemplate map[string]int32 `json:"byTemplate,omitempty"`
+	// ByKubernetesVersion is the number of clusters per Kubernetes version.
+	ByKubernetesVersion map[string]int32 `json:"byKubernetesVersion,omitempty"`
+	// ByPhase is the number of clusters per lifecycle phase.
+	ByPhase map[string]int32 `json:"byPhase,omitempty"`
+	// ByRegion is the number of clusters per region.
+	ByRegion map[string]int32 `json:"byRegion,omitempty"`
+	// LastUpdateTime is the time the summary was last updated.
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+	// Clusters contains the key attributes of all the ClusterDeployments indexed
+	// for the searches of the clusters, e.g. with the kcm fleet search command.
+	Clusters []FleetClusterSummary `json:"clusters,omitempty"`
+	// TotalClusters is the total number of ClusterDeployments.
+	TotalClusters int32 `json:"totalClusters"`
+	// PendingUpgrades is the number of clusters with available upgrades.
+	PendingUpgrades int32 `json:"pendingUpgrades"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=fleet
+// +kubebuilder:printcolumn:name="Clusters",type="integer",JSONPath=`.status.totalClusters`,description="Total number of clusters",priority=0
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=`.status.byPhase.Ready`,description="Number of ready clusters",priority=0
+// +kubebuilder:printcolumn:name="Pending Upgrades",type="integer",JSONPath=`.status.pendingUpgrades`,description="Number of clusters with available upgrades",priority=0
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+
+// FleetSummary is the Schema for the fleetsummaries API.
+// The object is maintained by the controller and aggregates
+// the state of all the ClusterDeployments.
+type FleetSummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FleetSummarySpec   `json:"spec,omitempty"`
+	Status FleetSummaryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FleetSummaryList contains a list of FleetSummary
+type FleetSummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FleetSummary `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FleetSummary{}, &FleetSummaryList{})
+}
@@ -0,0 +1,110 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GitOpsExportKind is the string representation of a GitOpsExport.
+	GitOpsExportKind = "GitOpsExport"
+
+	// DefaultGitOpsExportTag is the default tag of the exported OCI artifact.
+	DefaultGitOpsExportTag = "latest"
+)
+
+// GitOpsExportSpec defines the desired state of GitOpsExport
+type GitOpsExportSpec struct {
+	// OCI defines the OCI repository the manifests are pushed to.
+	OCI OCIExportTarget `json:"oci"`
+	// Namespaces is the list of namespaces the ClusterDeployments are exported from.
+	// The ClusterDeployments from all namespaces are exported if unset.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Suspend stops exporting the objects while set to true.
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// OCIExportTarget defines the OCI repository the exported manifests are pushed to.
+// The manifests are packed in a tarball layer compatible with the Flux OCIRepository
+// and the Argo CD OCI sources.
+type OCIExportTarget struct {
+	// +kubebuilder:validation:Pattern=`^oci://.+$`
+
+	// URL is the OCI repository the artifact is pushed to, e.g. oci://ghcr.io/org/kcm-state.
+	URL string `json:"url"`
+
+	// +kubebuilder:default:=latest
+
+	// Tag is the tag of the pushed artifact.
+	Tag string `json:"tag,omitempty"`
+	// SecretRef is the reference to the Secret in the system namespace
+	// with the username and password keys used to authenticate to the registry.
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+	// Insecure allows connecting to the registry over plain HTTP.
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// GitOpsExportStatus defines the observed state of GitOpsExport
+type GitOpsExportStatus struct {
+	// Revision is the digest of the exported manifests.
+	Revision string `json:"revision,omitempty"`
+	// Digest is the digest of the last pushed artifact manifest.
+	Digest string `json:"digest,omitempty"`
+	// LastExportTime is the time the manifests were last pushed.
+	LastExportTime *metav1.Time `json:"lastExportTime,omitempty"`
+	// Error is the error message occurred during the export (if any).
+	Error string `json:"error,omitempty"`
+	// ExportedObjects is the number of the exported objects.
+	ExportedObjects int32 `json:"exportedObjects,omitempty"`
+	// Conditions contains details for the current state of the GitOpsExport.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ObservedGeneration is the last observed generation.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=gexp
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=`.spec.oci.url`,description="OCI repository the manifests are pushed to",priority=0
+// +kubebuilder:printcolumn:name="Objects",type="integer",JSONPath=`.status.exportedObjects`,description="Number of the exported objects",priority=0
+// +kubebuilder:printcolumn:name="Last Export",type=date,JSONPath=`.status.lastExportTime`,description="Time elapsed since the last export",priority=0
+// +kubebuilder:printcolumn:name="Error",type="string",JSONPath=`.status.error`,description="Error during the export",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+
+// GitOpsExport is the Schema for the gitopsexports API.
+// It mirrors the canonical manifests of the ClusterDeployments
+// and MultiClusterServices to an OCI repository on every change.
+type GitOpsExport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GitOpsExportSpec   `json:"spec,omitempty"`
+	Status GitOpsExportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GitOpsExportList contains a list of GitOpsExport
+type GitOpsExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GitOpsExport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GitOpsExport{}, &GitOpsExportList{})
+}
@@ -17,6 +17,7 @@ package v1alpha1
 import (
 	"context"
 	"errors"
+	"slices"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -26,14 +27,19 @@ func SetupIndexers(ctx context.Context, mgr ctrl.Manager) error {
 	var merr error
 	for _, f := range []func(context.Context, ctrl.Manager) error{
 		setupClusterDeploymentIndexer,
+		setupClusterDeploymentTemplateRefIndexer,
 		setupClusterDeploymentServicesIndexer,
+		setupClusterDeploymentServiceBundlesIndexer,
 		setupClusterDeploymentCredentialIndexer,
+		setupClusterDeploymentVIPPoolIndexer,
+		setupClusterDeploymentControlPlaneVIPIndexer,
 		setupReleaseVersionIndexer,
 		setupReleaseTemplatesIndexer,
 		setupClusterTemplateChainIndexer,
 		setupServiceTemplateChainIndexer,
 		setupClusterTemplateProvidersIndexer,
 		setupMultiClusterServiceServicesIndexer,
+		setupMultiClusterServiceServiceBundlesIndexer,
 		setupOwnerReferenceIndexers,
 		setupManagementBackupIndexer,
 		setupManagementBackupAutoUpgradesIndexer,
@@ -64,6 +70,25 @@ func ExtractTemplateNameFromClusterDeployment(rawObj client.Object) []string {
 	return []string{cluster.Spec.Template}
 }
 
+// ClusterDeploymentTemplateRefIndexKey indexer field name to extract the namespaced name of the ClusterTemplate
+// referenced from another namespace with the TemplateRef from a ClusterDeployment object.
+const ClusterDeploymentTemplateRefIndexKey = ".spec.templateRef"
+
+func setupClusterDeploymentTemplateRefIndexer(ctx context.Context, mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, &ClusterDeployment{}, ClusterDeploymentTemplateRefIndexKey, ExtractTemplateRefFromClusterDeployment)
+}
+
+// ExtractTemplateRefFromClusterDeployment returns the namespaced name of the ClusterTemplate
+// referenced from another namespace declared in a ClusterDeployment object.
+func ExtractTemplateRefFromClusterDeployment(rawObj client.Object) []string {
+	cluster, ok := rawObj.(*ClusterDeployment)
+	if !ok || cluster.TemplateNamespace() == cluster.Namespace {
+		return nil
+	}
+
+	return []string{client.ObjectKey{Namespace: cluster.TemplateNamespace(), Name: cluster.Spec.Template}.String()}
+}
+
 // ClusterDeploymentServiceTemplatesIndexKey indexer field name to extract service templates names from a ClusterDeployment object.
 const ClusterDeploymentServiceTemplatesIndexKey = ".spec.services[].Template"
 
@@ -83,10 +108,35 @@ func ExtractServiceTemplateNamesFromClusterDeployment(rawObj client.Object) []st
 	for _, s := range cluster.Spec.ServiceSpec.Services {
 		templates = append(templates, s.Template)
 	}
+	if cluster.Spec.GPU != nil {
+		templates = append(templates, cluster.Spec.GPU.ServiceTemplate)
+	}
+	if cluster.Spec.Observability != nil && cluster.Spec.Observability.ServiceTemplate != "" {
+		templates = append(templates, cluster.Spec.Observability.ServiceTemplate)
+	}
 
 	return templates
 }
 
+// ClusterDeploymentServiceBundlesIndexKey indexer field name to extract the names of the included ServiceBundles
+// from a ClusterDeployment object.
+const ClusterDeploymentServiceBundlesIndexKey = ".spec.serviceSpec.bundles"
+
+func setupClusterDeploymentServiceBundlesIndexer(ctx context.Context, mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, &ClusterDeployment{}, ClusterDeploymentServiceBundlesIndexKey, ExtractServiceBundlesFromClusterDeployment)
+}
+
+// ExtractServiceBundlesFromClusterDeployment returns the names of the ServiceBundles
+// included by a ClusterDeployment object.
+func ExtractServiceBundlesFromClusterDeployment(rawObj client.Object) []string {
+	cluster, ok := rawObj.(*ClusterDeployment)
+	if !ok {
+		return nil
+	}
+
+	return cluster.Spec.ServiceSpec.Bundles
+}
+
 // ClusterDeploymentCredentialIndexKey indexer field name to extract Credential name reference from a ClusterDeployment object.
 const ClusterDeploymentCredentialIndexKey = ".spec.credential"
 
@@ -105,6 +155,51 @@ func extractCredentialNameFromClusterDeployment(rawObj client.Object) []string {
 	return []string{cluster.Spec.Credential}
 }
 
+// ClusterDeploymentVIPPoolIndexKey indexer field name to extract VIPPool name reference from a ClusterDeployment object.
+const ClusterDeploymentVIPPoolIndexKey = ".spec.controlPlaneVIP.pool"
+
+func setupClusterDeploymentVIPPoolIndexer(ctx context.Context, mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, &ClusterDeployment{}, ClusterDeploymentVIPPoolIndexKey, ExtractVIPPoolFromClusterDeployment)
+}
+
+// ExtractVIPPoolFromClusterDeployment returns referenced VIPPool name
+// declared in a ClusterDeployment object.
+func ExtractVIPPoolFromClusterDeployment(rawObj client.Object) []string {
+	cluster, ok := rawObj.(*ClusterDeployment)
+	if !ok || cluster.Spec.ControlPlaneVIP == nil || cluster.Spec.ControlPlaneVIP.Pool == "" {
+		return nil
+	}
+
+	return []string{cluster.Spec.ControlPlaneVIP.Pool}
+}
+
+// ClusterDeploymentControlPlaneVIPIndexKey indexer field name to extract control plane VIPs
+// either requested or reserved by a ClusterDeployment object.
+const ClusterDeploymentControlPlaneVIPIndexKey = "controlPlaneVIP"
+
+func setupClusterDeploymentControlPlaneVIPIndexer(ctx context.Context, mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, &ClusterDeployment{}, ClusterDeploymentControlPlaneVIPIndexKey, ExtractControlPlaneVIPsFromClusterDeployment)
+}
+
+// ExtractControlPlaneVIPsFromClusterDeployment returns the control plane VIPs
+// either requested in the spec or reserved in the status of a ClusterDeployment object.
+func ExtractControlPlaneVIPsFromClusterDeployment(rawObj client.Object) []string {
+	cluster, ok := rawObj.(*ClusterDeployment)
+	if !ok {
+		return nil
+	}
+
+	var vips []string
+	if cluster.Spec.ControlPlaneVIP != nil && cluster.Spec.ControlPlaneVIP.Address != "" {
+		vips = append(vips, cluster.Spec.ControlPlaneVIP.Address)
+	}
+	if cluster.Status.ControlPlaneVIP != "" && !slices.Contains(vips, cluster.Status.ControlPlaneVIP) {
+		vips = append(vips, cluster.Status.ControlPlaneVIP)
+	}
+
+	return vips
+}
+
 // release
 
 // ReleaseVersionIndexKey indexer field name to extract release version from a Release object.
@@ -214,6 +309,25 @@ func ExtractServiceTemplateNamesFromMultiClusterService(rawObj client.Object) []
 	return templates
 }
 
+// MultiClusterServiceBundlesIndexKey indexer field name to extract the names of the included ServiceBundles
+// from a MultiClusterService object.
+const MultiClusterServiceBundlesIndexKey = "serviceBundles"
+
+func setupMultiClusterServiceServiceBundlesIndexer(ctx context.Context, mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, &MultiClusterService{}, MultiClusterServiceBundlesIndexKey, ExtractServiceBundlesFromMultiClusterService)
+}
+
+// ExtractServiceBundlesFromMultiClusterService returns the names of the ServiceBundles
+// included by a MultiClusterService object.
+func ExtractServiceBundlesFromMultiClusterService(rawObj client.Object) []string {
+	mcs, ok := rawObj.(*MultiClusterService)
+	if !ok {
+		return nil
+	}
+
+	return mcs.Spec.ServiceSpec.Bundles
+}
+
 // ownerref indexers
 
 // OwnerRefIndexKey indexer field name to extract ownerReference names from objects
@@ -56,6 +56,8 @@ type ManagementBackupStatus struct {
 	LastBackupName string `json:"lastBackupName,omitempty"`
 	// Error stores messages in case of failed backup creation.
 	Error string `json:"error,omitempty"`
+	// Conditions contains details for the current state of the ManagementBackup.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // IsSchedule checks if an instance of [ManagementBackup] is schedulable.
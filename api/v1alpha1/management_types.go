@@ -15,6 +15,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
@@ -30,6 +31,39 @@ const (
 	ManagementFinalizer = "k0rdent.mirantis.com/management"
 )
 
+const (
+	// SecurityBaselineName is the name of the objects deploying the security baseline
+	// to the clusters, created in the system namespace.
+	SecurityBaselineName = "kcm-security-baseline"
+	// SecurityBaselineKey is the key of the ClusterDeployment annotation opting the cluster
+	// out of the security baseline and of the corresponding cluster label.
+	SecurityBaselineKey = "k0rdent.mirantis.com/security-baseline"
+	// SecurityBaselineOptOutValue is the value of the [SecurityBaselineKey] opting the cluster out of the security baseline.
+	SecurityBaselineOptOutValue = "disabled"
+)
+
+const (
+	// TLSProfileDefault leaves the TLS configuration to the defaults of the Go standard library.
+	TLSProfileDefault = "Default"
+	// TLSProfileRestricted restricts TLS to version 1.2 or higher and the FIPS 140 approved cipher suites.
+	TLSProfileRestricted = "Restricted"
+)
+
+// AdmissionBypassLabelKey is the label of the namespaces exempted from the KCM admission webhooks
+// if the emergency bypass is enabled in the Management, the label value must be "true".
+const AdmissionBypassLabelKey = "k0rdent.mirantis.com/admission-bypass"
+
+const (
+	// ManagementProfileStandard runs KCM with the full component set.
+	ManagementProfileStandard = "Standard"
+	// ManagementProfileEdge runs KCM with the minimal component set for the resource-constrained
+	// management clusters: without Sveltos, Velero and cert-manager, with the reduced set of the admission
+	// webhooks complemented by the CEL ValidatingAdmissionPolicies.
+	ManagementProfileEdge = "Edge"
+)
+
+// +kubebuilder:validation:XValidation:rule="(has(self.profile) ? self.profile : 'Standard') == (has(oldSelf.profile) ? oldSelf.profile : 'Standard')",message="profile is immutable"
+
 // ManagementSpec defines the desired state of Management
 type ManagementSpec struct {
 	// +kubebuilder:validation:MinLength=1
@@ -43,6 +77,273 @@ type ManagementSpec struct {
 
 	// Providers is the list of supported CAPI providers.
 	Providers []Provider `json:"providers,omitempty"`
+	// SecurityBaseline configures the security baseline deployed to all the clusters.
+	// A cluster is opted out of the baseline with the k0rdent.mirantis.com/security-baseline=disabled
+	// annotation on the ClusterDeployment.
+	SecurityBaseline *SecurityBaseline `json:"securityBaseline,omitempty"`
+
+	// +kubebuilder:validation:Enum=Default;Restricted
+
+	// TLSProfile configures the TLS settings of the KCM webhook server, metrics endpoint
+	// and outbound connections to the Helm repositories and the telemetry endpoint.
+	// The Restricted profile allows only TLS 1.2 or higher with the FIPS 140 approved cipher suites.
+	// The Restricted profile is always used if KCM is built in the FIPS 140-3 mode.
+	TLSProfile string `json:"tlsProfile,omitempty"`
+	// ArgoCD enables the registration of the ready clusters in Argo CD.
+	ArgoCD *ArgoCDIntegration `json:"argoCD,omitempty"`
+
+	// +kubebuilder:validation:Enum:=Sveltos;Flux;Applier
+
+	// ServiceDelivery defines how the services of the ClusterDeployments are delivered to the clusters
+	// unless set in the ClusterDeployment or selected by a StateManagementProvider.
+	// Defaults to Sveltos, or to Flux with the Edge profile. The MultiClusterServices are always delivered by Sveltos.
+	ServiceDelivery string `json:"serviceDelivery,omitempty"`
+	// Monitoring enables the self-monitoring stack of the management cluster.
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+	// Observability enables the metrics and logs agents on all the clusters shipping
+	// to the central store. A cluster overrides the settings or opts out of the agents
+	// with the observability settings of the ClusterDeployment.
+	Observability *Observability `json:"observability,omitempty"`
+	// CloudTagPolicy defines the tags the cloud resources of all the clusters must carry.
+	CloudTagPolicy *CloudTagPolicy `json:"cloudTagPolicy,omitempty"`
+	// ReconciliationPaused pauses the changes made by the KCM controllers, e.g. during a change freeze
+	// or an incident response. The controllers keep observing the status of the objects and report the pause
+	// in the ReconciliationPaused condition. The pause is overridden for the objects in a namespace
+	// with the k0rdent.mirantis.com/reconciliation-paused annotation of the namespace.
+	ReconciliationPaused bool `json:"reconciliationPaused,omitempty"`
+	// Telemetry enables the daily report of the anonymized statistics of the fleet,
+	// e.g. to the vendor operating KCM as a service. The report is sent independently
+	// of the telemetry of the controller.
+	Telemetry *Telemetry `json:"telemetry,omitempty"`
+	// RegistryCredentials distributes the image pull secret of the private registries to all the clusters,
+	// so the workloads of the clusters pull the images with the same credentials.
+	RegistryCredentials *RegistryCredentials `json:"registryCredentials,omitempty"`
+	// TrustedCA distributes the CA bundle of the organization to all the clusters: the bundle is added to the trust store
+	// of the machines of the templates supporting it and published as a ConfigMap in the clusters.
+	// The machines are rolled out once the bundle changes.
+	TrustedCA *TrustedCA `json:"trustedCA,omitempty"`
+
+	// +kubebuilder:validation:Enum=Standard;Edge
+
+	// Profile defines the component set KCM runs with, defaults to Standard.
+	// The Edge profile runs the minimal component set for the resource-constrained management clusters:
+	// Sveltos and Velero are not installed, hence the MultiClusterServices and the ManagementBackups are not supported,
+	// the webhook certificates are issued by the builtin provider instead of cert-manager and only the ClusterDeployment
+	// and Management validation webhooks are served, the other validations are enforced by the CEL ValidatingAdmissionPolicies.
+	// The profile is immutable.
+	Profile string `json:"profile,omitempty"`
+	// AdmissionWebhooks tunes the admission behavior of the KCM webhooks.
+	// The webhook configurations are maintained by the controller accordingly.
+	AdmissionWebhooks *AdmissionWebhooks `json:"admissionWebhooks,omitempty"`
+}
+
+// AdmissionWebhooks defines the admission behavior of the KCM webhooks.
+type AdmissionWebhooks struct {
+	// +kubebuilder:validation:Enum=Fail;Ignore
+
+	// FailurePolicy defines how the errors calling the webhooks, e.g. the webhook server being unavailable,
+	// are handled: Fail rejects the request, Ignore admits it without the validation and mutation. Defaults to Fail.
+	FailurePolicy string `json:"failurePolicy,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30
+
+	// TimeoutSeconds is the timeout of the webhook calls in seconds, defaults to 10.
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// ExemptNamespaces are the namespaces the objects of which are not sent to the webhooks, e.g. kube-system.
+	// The cluster-scoped objects are always sent to the webhooks.
+	ExemptNamespaces []string `json:"exemptNamespaces,omitempty"`
+	// EmergencyBypass exempts the namespaces labeled with k0rdent.mirantis.com/admission-bypass=true
+	// from the webhooks, e.g. to recover the objects the webhooks reject because of a bug.
+	EmergencyBypass bool `json:"emergencyBypass,omitempty"`
+}
+
+// Monitoring defines the self-monitoring stack of the management cluster: the Prometheus rules
+// alerting on the failed clusters and stale backups, and the Grafana dashboards for the KCM metrics.
+type Monitoring struct {
+	// Labels are the additional labels set on the PrometheusRule, ServiceMonitor and
+	// dashboard ConfigMaps, e.g. to be selected by an existing Prometheus and Grafana.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// +kubebuilder:default:="15m"
+
+	// ClusterNotReadyFor is the duration a ClusterDeployment is not ready for before the alert fires.
+	ClusterNotReadyFor metav1.Duration `json:"clusterNotReadyFor,omitempty"`
+
+	// +kubebuilder:default:="24h"
+
+	// BackupMaxAge is the maximum age of the last successful scheduled ManagementBackup before the alert fires.
+	BackupMaxAge metav1.Duration `json:"backupMaxAge,omitempty"`
+	// ExternalPrometheus disables the installation of the bundled Prometheus Operator, Prometheus,
+	// Alertmanager and Grafana; the rules and dashboards are deployed for the stack already
+	// installed in the management cluster.
+	ExternalPrometheus bool `json:"externalPrometheus,omitempty"`
+}
+
+// RegistryCredentials defines the image pull secret distributed to the clusters.
+type RegistryCredentials struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// SecretName is the name of the Secret of the kubernetes.io/dockerconfigjson type in the system namespace
+	// holding the credentials of the private registries. The Secret is created under the same name in the clusters.
+	SecretName string `json:"secretName"`
+
+	// +listType=set
+	// +kubebuilder:default:={"default","kube-system"}
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:MaxLength=63
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+
+	// Namespaces is the list of the namespaces of the clusters the pull secret is created in.
+	// The missing namespaces are created.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// +kubebuilder:default:=true
+
+	// PatchDefaultServiceAccounts adds the pull secret to the image pull secrets
+	// of the default ServiceAccounts of the namespaces.
+	PatchDefaultServiceAccounts bool `json:"patchDefaultServiceAccounts,omitempty"`
+}
+
+// TrustedCA defines the CA bundle distributed to the clusters.
+type TrustedCA struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// ConfigMapName is the name of the ConfigMap in the system namespace holding the PEM-encoded CA bundle.
+	// The ConfigMap is created under the same name in the clusters.
+	ConfigMapName string `json:"configMapName"`
+
+	// +kubebuilder:default:="ca.crt"
+
+	// Key is the key of the ConfigMap holding the CA bundle, defaults to ca.crt.
+	Key string `json:"key,omitempty"`
+
+	// +listType=set
+	// +kubebuilder:default:={"kube-system"}
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:MaxLength=63
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+
+	// Namespaces is the list of the namespaces of the clusters the CA bundle ConfigMap is created in.
+	// The missing namespaces are created.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// +kubebuilder:default:=true
+
+	// RolloutMachines replaces the machines of the clusters once the CA bundle changes,
+	// so the trust store of the machines is updated. Enabling the bundle does not replace
+	// the existing machines, they trust the bundle once replaced otherwise.
+	// Only the worker machines and the KubeadmControlPlane machines are rolled out.
+	RolloutMachines bool `json:"rolloutMachines,omitempty"`
+}
+
+// Observability defines the metrics and logs agents installed on the clusters.
+// The agents are installed with the ServiceTemplate as a system service of each cluster
+// and receive the cluster identity, the endpoints and the credentials as the helm values.
+type Observability struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// ServiceTemplate is the name of the ServiceTemplate installing the metrics and logs agents.
+	// The ServiceTemplate must exist in the namespace of each ClusterDeployment.
+	ServiceTemplate string `json:"serviceTemplate"`
+
+	// +kubebuilder:default:=kcm-observability
+
+	// Namespace is the namespace the agents are installed in.
+	Namespace string `json:"namespace,omitempty"`
+
+	// +kubebuilder:validation:Pattern=`^https?://`
+
+	// MetricsEndpoint is the URL the agents ship the metrics to, e.g. a Prometheus remote write endpoint.
+	MetricsEndpoint string `json:"metricsEndpoint,omitempty"`
+
+	// +kubebuilder:validation:Pattern=`^https?://`
+
+	// LogsEndpoint is the URL the agents ship the logs to, e.g. a Loki push endpoint.
+	LogsEndpoint string `json:"logsEndpoint,omitempty"`
+	// CredentialsSecretName is the name of the Secret in the system namespace
+	// holding the credentials of the central store passed to the agents.
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+	// Labels are the additional labels attached to the metrics and logs of the clusters.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Values is the helm values passed to the chart of the ServiceTemplate.
+	Values string `json:"values,omitempty"`
+}
+
+// CloudTagPolicy defines the tags of the cloud resources of the clusters.
+type CloudTagPolicy struct {
+	// MandatoryTags are the keys of the tags, e.g. cost-center and owner, each ClusterDeployment
+	// must set in its cloudTags with a non-empty value if its ClusterTemplate supports the cloud tags.
+	MandatoryTags []string `json:"mandatoryTags,omitempty"`
+}
+
+const (
+	// TelemetryModeSend posts the telemetry report to the endpoint.
+	TelemetryModeSend = "Send"
+	// TelemetryModePrint only logs the telemetry report that would be sent.
+	TelemetryModePrint = "Print"
+)
+
+const (
+	// TelemetryCategoryProviders is the category of the numbers of the clusters per infrastructure provider.
+	TelemetryCategoryProviders = "Providers"
+	// TelemetryCategoryTemplates is the category of the numbers of the clusters per hashed name of ClusterTemplate.
+	TelemetryCategoryTemplates = "Templates"
+	// TelemetryCategoryKubernetesVersions is the category of the numbers of the clusters per Kubernetes version.
+	TelemetryCategoryKubernetesVersions = "KubernetesVersions"
+	// TelemetryCategoryPhases is the category of the numbers of the clusters per lifecycle phase.
+	TelemetryCategoryPhases = "Phases"
+)
+
+// Telemetry defines the report of the anonymized statistics of the fleet.
+// The report carries the KCM version, the total number of the clusters
+// and the numbers of the clusters in the enabled categories.
+// +kubebuilder:validation:XValidation:rule="self.mode == 'Print' || has(self.endpoint)",message="endpoint is required in the Send mode"
+type Telemetry struct {
+	// +kubebuilder:validation:Pattern=`^https?://`
+
+	// Endpoint is the URL the report is posted to as JSON.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// +kubebuilder:default:=Send
+	// +kubebuilder:validation:Enum=Send;Print
+
+	// Mode is the mode of the reporting, the Print mode only logs the report that would be sent.
+	Mode string `json:"mode,omitempty"`
+	// DisabledCategories are the categories of the statistics opted out of the report.
+	// +kubebuilder:validation:items:Enum=Providers;Templates;KubernetesVersions;Phases
+	// +listType=set
+	DisabledCategories []string `json:"disabledCategories,omitempty"`
+}
+
+// ArgoCDIntegration defines the registration of the clusters in Argo CD.
+// A cluster Secret is maintained for each ready ClusterDeployment and removed on its deletion.
+type ArgoCDIntegration struct {
+	// +kubebuilder:default:=argocd
+
+	// Namespace is the namespace Argo CD is installed to. The cluster Secrets are created in this namespace.
+	Namespace string `json:"namespace,omitempty"`
+	// Labels are the additional labels set on the cluster Secrets,
+	// e.g. to be matched by the ApplicationSet cluster generators.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// SecurityBaseline defines the security baseline deployed to the clusters.
+type SecurityBaseline struct {
+	// +kubebuilder:validation:MinItems=1
+
+	// Namespaces is the list of namespaces in the clusters the baseline is applied to.
+	// The namespaces are created if missing and labeled with the Pod Security Admission labels.
+	Namespaces []string `json:"namespaces"`
+
+	// +kubebuilder:default:=baseline
+	// +kubebuilder:validation:Enum=privileged;baseline;restricted
+
+	// PodSecurityStandard is the Pod Security Standard level enforced in the namespaces.
+	// The restricted level also enforces the RuntimeDefault seccomp profile.
+	PodSecurityStandard string `json:"podSecurityStandard,omitempty"`
+	// DefaultDenyNetworkPolicies enables the NetworkPolicies denying all ingress traffic in the namespaces.
+	DefaultDenyNetworkPolicies bool `json:"defaultDenyNetworkPolicies,omitempty"`
 }
 
 const (
@@ -55,11 +356,15 @@ const (
 )
 
 // Core represents a structure describing core Management components.
+// +kubebuilder:validation:XValidation:rule="!has(self.velero) || !has(self.velero.replicas)",message="Velero does not support the replicas"
 type Core struct {
 	// KCM represents the core KCM component and references the KCM template.
+	// The tuning of the component applies to the KCM controller.
 	KCM Component `json:"kcm,omitempty"`
 	// CAPI represents the core Cluster API component and references the Cluster API template.
 	CAPI Component `json:"capi,omitempty"`
+	// Velero tunes the Velero server installed with the KCM component.
+	Velero *ComponentTuning `json:"velero,omitempty"`
 }
 
 // Component represents KCM management component
@@ -71,6 +376,22 @@ type Component struct {
 	// Template is the name of the Template associated with this component.
 	// If not specified, will be taken from the Release object.
 	Template string `json:"template,omitempty"`
+	// Tuning sets the resources and the scheduling of the workloads of the component.
+	// The tuning takes precedence over the corresponding values of the Config.
+	Tuning *ComponentTuning `json:"tuning,omitempty"`
+}
+
+// ComponentTuning defines the resources and the scheduling of the workloads of a management component.
+type ComponentTuning struct {
+	// Resources are the compute resources of the containers of the component.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Replicas is the number of the replicas of the workloads of the component.
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+	// NodeSelector constrains the pods of the component to the nodes with the given labels.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations of the pods of the component.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 }
 
 type Provider struct {
@@ -120,6 +441,9 @@ type ManagementStatus struct {
 	CAPIContracts map[string]CompatibilityContracts `json:"capiContracts,omitempty"`
 	// Components indicates the status of installed KCM components and CAPI providers.
 	Components map[string]ComponentStatus `json:"components,omitempty"`
+	// Integrations indicates the availability of the optional integrations, e.g. Sveltos and Velero,
+	// the subsystems of the unavailable integrations are disabled.
+	Integrations map[string]IntegrationStatus `json:"integrations,omitempty"`
 	// +listType=map
 	// +listMapKey=type
 	// +kubebuilder:validation:MaxItems=32
@@ -146,6 +470,14 @@ type ComponentStatus struct {
 	Success bool `json:"success,omitempty"`
 }
 
+// IntegrationStatus is the availability of an optional integration.
+type IntegrationStatus struct {
+	// Message explains why the integration is unavailable.
+	Message string `json:"message,omitempty"`
+	// Available represents if the CRDs of the integration are installed.
+	Available bool `json:"available"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=kcm-mgmt;mgmt,scope=Cluster
 // +kubebuilder:subresource:status
@@ -33,6 +33,12 @@ const (
 	// SveltosHelmReleaseReadyCondition indicates if the HelmRelease
 	// managed by a Sveltos Profile/ClusterProfile is ready.
 	SveltosHelmReleaseReadyCondition = "SveltosHelmReleaseReady"
+	// FluxHelmReleaseReadyCondition indicates if the Flux HelmRelease
+	// delivering a service of a ClusterDeployment is ready.
+	FluxHelmReleaseReadyCondition = "FluxHelmReleaseReady"
+	// AppliedServiceReadyCondition indicates if the manifests of a service
+	// of a ClusterDeployment have been applied by the Applier service delivery.
+	AppliedServiceReadyCondition = "AppliedServiceReady"
 
 	// FetchServicesStatusSuccessCondition indicates if status
 	// for the deployed services have been fetched successfully.
@@ -50,6 +56,27 @@ const (
 
 	// ServicesReferencesValidationCondition defines the condition of services' references validation.
 	ServicesReferencesValidationCondition = "ServicesReferencesValidation"
+
+	// ServiceConflictCondition is set if a ClusterDeployment and MultiClusterServices or several MultiClusterServices
+	// deploy the same service to a cluster, the message names the competing owners and the one which deploys the service.
+	ServiceConflictCondition = "ServiceConflict"
+	// ServiceConflictWonReason indicates the object deploys all its conflicting services due to the higher priority
+	// or the tie-breakers of the same priority.
+	ServiceConflictWonReason = "ServiceConflictWon"
+	// ServiceConflictLostReason indicates some of the conflicting services of the object are deployed by an object
+	// with a higher priority or taking precedence by the tie-breakers of the same priority.
+	ServiceConflictLostReason = "ServiceConflictLost"
+
+	// ServiceRollbackAnnotation is an annotation on a ClusterDeployment or a MultiClusterService requesting the rollback
+	// of services to the revisions recorded in the service history in the status. The value is a comma-separated list
+	// of the service names with the optional revisions, e.g. "ingress-nginx=2,cert-manager". A service without
+	// the revision is reverted to the latest recorded revision differing from the one currently requested.
+	// The annotation is removed once the rollback is applied.
+	ServiceRollbackAnnotation = "k0rdent.mirantis.com/service-rollback"
+	// ServiceRolledBackReason indicates services have been reverted to the revisions recorded in the service history.
+	ServiceRolledBackReason = "ServiceRolledBack"
+	// ServiceRollbackFailedReason indicates the requested rollback of services has been rejected.
+	ServiceRollbackFailedReason = "ServiceRollbackFailed"
 )
 
 // Service represents a Service to be deployed.
@@ -78,6 +105,9 @@ type Service struct {
 	Disable bool `json:"disable,omitempty"`
 }
 
+// SyncModeContinuous is the default sync mode of the services continuously reconciling the services in the cluster.
+const SyncModeContinuous = "Continuous"
+
 // ServiceSpec contains all the spec related to deployment of services.
 type ServiceSpec struct {
 	// +kubebuilder:default:=Continuous
@@ -88,6 +118,16 @@ type ServiceSpec struct {
 	// Services is a list of services created via ServiceTemplates
 	// that could be installed on the target cluster.
 	Services []Service `json:"services,omitempty"`
+
+	// +listType=set
+
+	// Bundles is a list of the names of the ServiceBundles whose services are installed on the target cluster
+	// before the services listed in Services, in the order of the bundles. The ServiceBundles are looked up
+	// in the namespace of the ClusterDeployment and in the system namespace for the MultiClusterService.
+	// A service listed in Services with the same name and namespace as a service of a bundle overrides it
+	// in place, keeping the values of the bundle if its own values are not set.
+	Bundles []string `json:"bundles,omitempty"`
+
 	// TemplateResourceRefs is a list of resources to collect from the management cluster,
 	// the values from which can be used in templates.
 	TemplateResourceRefs []sveltosv1beta1.TemplateResourceRef `json:"templateResourceRefs,omitempty"`
@@ -104,6 +144,11 @@ type ServiceSpec struct {
 	// Higher value means higher priority and lower means lower.
 	// In case of conflict with another object managing the service,
 	// the one with higher priority will get to deploy its services.
+	// With the same priority the precedence is decided by the tie-breakers in order:
+	// the ClusterDeployment takes precedence over the MultiClusterServices,
+	// then the object created earlier, then the object with the lexicographically lower name.
+	// The conflicts are reported with the ServiceConflict condition and the winner
+	// of each conflicting service is listed in the serviceConflicts of the status.
 	Priority int32 `json:"priority,omitempty"`
 
 	// +kubebuilder:default:=false
@@ -125,7 +170,14 @@ type ServiceSpec struct {
 // MultiClusterServiceSpec defines the desired state of MultiClusterService
 type MultiClusterServiceSpec struct {
 	// ClusterSelector identifies target clusters to manage services on.
+	// Besides the own labels the clusters are labeled with their properties derived by KCM:
+	// the infrastructure provider with k0rdent.mirantis.com/provider, e.g. aws, the region
+	// with k0rdent.mirantis.com/region and the minor Kubernetes version with
+	// k0rdent.mirantis.com/kubernetes-version, e.g. 1.29.
 	ClusterSelector metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	// KubernetesVersion additionally selects the target clusters by the constraint
+	// of their minor Kubernetes version, e.g. ">=1.29".
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
 	// ServiceSpec is spec related to deployment of services.
 	ServiceSpec ServiceSpec `json:"serviceSpec,omitempty"`
 }
@@ -140,10 +192,54 @@ type ServiceStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// ServiceRevision is a combination of the template and the values a service has been successfully deployed with.
+type ServiceRevision struct {
+	// RecordedAt is the time the revision has been recorded at.
+	RecordedAt metav1.Time `json:"recordedAt"`
+	// Template is the name of the ServiceTemplate.
+	Template string `json:"template"`
+	// Values is the helm values passed to the chart used by the template.
+	Values string `json:"values,omitempty"`
+	// Revision is the sequence number of the revision, starting from 1.
+	Revision int32 `json:"revision"`
+}
+
+// ServiceHistory is the history of the revisions of a service deployed to all the selected clusters.
+type ServiceHistory struct {
+	// Name is the name of the service.
+	Name string `json:"name"`
+	// Namespace is the namespace the service is installed in.
+	Namespace string `json:"namespace"`
+	// Revisions is the list of the last revisions of the service, the latest one is the last.
+	Revisions []ServiceRevision `json:"revisions,omitempty"`
+}
+
+// ServiceConflict is a service deployed to a cluster by several objects along with the object deploying it.
+type ServiceConflict struct {
+	// ClusterName is the name of the cluster, empty in the status of a ClusterDeployment.
+	ClusterName string `json:"clusterName,omitempty"`
+	// ClusterNamespace is the namespace of the cluster, empty in the status of a ClusterDeployment.
+	ClusterNamespace string `json:"clusterNamespace,omitempty"`
+	// Service is the namespace and the name of the service release in the cluster.
+	Service string `json:"service"`
+	// WinnerKind is the kind of the object deploying the service, either ClusterDeployment or MultiClusterService.
+	WinnerKind string `json:"winnerKind"`
+	// WinnerName is the name of the object deploying the service, prefixed with the namespace for a ClusterDeployment.
+	WinnerName string `json:"winnerName"`
+	// Owners is the list of the objects deploying the service in the order of their precedence.
+	Owners []string `json:"owners,omitempty"`
+}
+
 // MultiClusterServiceStatus defines the observed state of MultiClusterService.
 type MultiClusterServiceStatus struct {
 	// Services contains details for the state of services.
 	Services []ServiceStatus `json:"services,omitempty"`
+	// ServiceConflicts is the list of the services of the MultiClusterService also deployed
+	// to the selected clusters by other objects along with the objects deploying them.
+	ServiceConflicts []ServiceConflict `json:"serviceConflicts,omitempty"`
+	// ServiceHistory is the list of the revisions the services have been successfully deployed with to all
+	// the selected clusters, the services are reverted to them with the k0rdent.mirantis.com/service-rollback annotation.
+	ServiceHistory []ServiceHistory `json:"serviceHistory,omitempty"`
 	// Conditions contains details for the current state of the MultiClusterService.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// ObservedGeneration is the last observed generation.
@@ -153,6 +249,7 @@ type MultiClusterServiceStatus struct {
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,shortName=mcs
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Services",type="string",JSONPath=`.status.conditions[?(@.type=="ServicesInReadyState")].message`,description="Number of ready out of total services",priority=0
 // +kubebuilder:printcolumn:name="Clusters",type="string",JSONPath=`.status.conditions[?(@.type=="ClusterInReadyState")].message`,description="Number of ready out of total selected clusters",priority=0
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
@@ -0,0 +1,134 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// NodeImageRolloutKind is the string representation of a NodeImageRollout.
+	NodeImageRolloutKind = "NodeImageRollout"
+
+	// NodeImageRolloutPausedAnnotation pauses the rollouts of the new images to the annotated ClusterDeployment.
+	NodeImageRolloutPausedAnnotation = "k0rdent.mirantis.com/node-image-rollout-paused"
+)
+
+const (
+	// NodeImageRolloutPhasePending denotes the cluster is waiting for the new images to be rolled out.
+	NodeImageRolloutPhasePending = "Pending"
+	// NodeImageRolloutPhaseUpdating denotes the new images are being rolled out to the cluster.
+	NodeImageRolloutPhaseUpdating = "Updating"
+	// NodeImageRolloutPhaseUpdated denotes the machines of the cluster are deployed with the latest images.
+	NodeImageRolloutPhaseUpdated = "Updated"
+	// NodeImageRolloutPhasePaused denotes the rollout to the cluster is paused.
+	NodeImageRolloutPhasePaused = "Paused"
+)
+
+// NodeImageRolloutSpec defines the desired state of NodeImageRollout
+type NodeImageRolloutSpec struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// OSImage is the name of the OSImage the new images of which are rolled out.
+	OSImage string `json:"osImage"`
+
+	// NamespaceSelector selects the namespaces of the ClusterDeployments the images are rolled out to.
+	// An empty selector matches all namespaces.
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ClusterSelector selects the ClusterDeployments the images are rolled out to by their labels.
+	// An empty selector matches all ClusterDeployments.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// MaintenanceWindows restricts the start of the rollouts to the clusters to the given windows.
+	// The rollouts are started at any time if empty.
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum=1
+
+	// MaxConcurrentClusters is the maximum number of the clusters the images are rolled out to at the same time.
+	// The machines of the node pools of each cluster are replaced as per the rollout strategy of the pools,
+	// e.g. the max surge of the MachineDeployments.
+	MaxConcurrentClusters int32 `json:"maxConcurrentClusters,omitempty"`
+
+	// Paused pauses starting the rollouts to the clusters, the rollouts in progress are completed.
+	Paused bool `json:"paused,omitempty"`
+}
+
+// NodeImageRolloutClusterStatus reflects the rollout of the new images to a cluster.
+type NodeImageRolloutClusterStatus struct {
+	// LastTransitionTime is the time the phase has last changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// ClusterDeployment is the namespaced name of the ClusterDeployment.
+	ClusterDeployment string `json:"clusterDeployment"`
+
+	// +kubebuilder:validation:Enum=Pending;Updating;Updated;Paused
+
+	// Phase is the phase of the rollout to the cluster.
+	Phase string `json:"phase"`
+
+	// Message is the human-readable description of the phase.
+	Message string `json:"message,omitempty"`
+}
+
+// NodeImageRolloutStatus defines the observed state of NodeImageRollout
+type NodeImageRolloutStatus struct {
+	// Clusters is the list of the rollouts to the selected clusters using the OSImage.
+	Clusters []NodeImageRolloutClusterStatus `json:"clusters,omitempty"`
+	// Updated is the number of the clusters deployed with the latest images.
+	Updated int32 `json:"updated,omitempty"`
+	// Total is the number of the selected clusters using the OSImage.
+	Total int32 `json:"total,omitempty"`
+	// Conditions contains details for the current state of the NodeImageRollout.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Error is the error message occurred during the reconciliation (if any).
+	Error string `json:"error,omitempty"`
+	// ObservedGeneration is the last observed generation.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=nir
+// +kubebuilder:printcolumn:name="OSImage",type="string",JSONPath=`.spec.osImage`,description="OSImage the images of which are rolled out",priority=0
+// +kubebuilder:printcolumn:name="Updated",type="integer",JSONPath=`.status.updated`,description="Number of the updated clusters",priority=0
+// +kubebuilder:printcolumn:name="Total",type="integer",JSONPath=`.status.total`,description="Number of the selected clusters",priority=0
+// +kubebuilder:printcolumn:name="Paused",type="boolean",JSONPath=`.spec.paused`,description="Whether the rollout is paused",priority=0
+// +kubebuilder:printcolumn:name="Error",type="string",JSONPath=`.status.error`,description="Error during the reconciliation",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+
+// NodeImageRollout is the Schema for the nodeimagerollouts API
+type NodeImageRollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NodeImageRolloutSpec   `json:"spec,omitempty"`
+	Status NodeImageRolloutStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeImageRolloutList contains a list of NodeImageRollout
+type NodeImageRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeImageRollout `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeImageRollout{}, &NodeImageRolloutList{})
+}
@@ -0,0 +1,123 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// OSImageKind is the string representation of an OSImage.
+	OSImageKind = "OSImage"
+
+	// OSImageReferencePrefix is the prefix of the ClusterTemplate values referencing an OSImage
+	// by its name instead of a hard-coded image ID, e.g. "osimage:ubuntu-22-04".
+	// The reference is resolved to the ID of the image matching the Kubernetes version
+	// of the ClusterTemplate and the region of the cluster.
+	OSImageReferencePrefix = "osimage:"
+)
+
+// OSImageEntry is an image of the catalog.
+type OSImageEntry struct {
+	// KubernetesVersion is the Kubernetes version the image is built for, either the exact version,
+	// e.g. v1.32.2, or the minor version, e.g. v1.32. The image matches any version if empty.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// Region is the region (location) the image is available in. The image matches any region if empty.
+	Region string `json:"region,omitempty"`
+
+	// +kubebuilder:validation:MinLength=1
+
+	// ID is the provider-specific identifier of the image,
+	// e.g. the AMI ID, the Azure image ID or the vSphere template path.
+	ID string `json:"id"`
+}
+
+// OSImageDiscovery configures the discovery of the images from an index.
+type OSImageDiscovery struct {
+	// +kubebuilder:validation:Pattern=`^https?://`
+
+	// URL is the URL of the index in JSON or YAML holding the list of the images under the "images" key,
+	// each image defined in the same form as in the spec, e.g.:
+	//
+	//	images:
+	//	- kubernetesVersion: v1.32.2
+	//	  region: us-east-1
+	//	  id: ami-0123456789abcdef0
+	URL string `json:"url"`
+
+	// +kubebuilder:default:="6h"
+
+	// Interval is the interval the index is fetched at.
+	Interval metav1.Duration `json:"interval,omitempty"`
+}
+
+// OSImageSpec defines the desired state of OSImage
+type OSImageSpec struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Provider is the name of the infrastructure provider the images are deployed with, e.g. infrastructure-aws.
+	Provider string `json:"provider"`
+
+	// Images is the list of the images maintained manually.
+	// The images take precedence over the discovered ones.
+	Images []OSImageEntry `json:"images,omitempty"`
+
+	// Discovery configures the discovery of the images from an index.
+	Discovery *OSImageDiscovery `json:"discovery,omitempty"`
+}
+
+// OSImageStatus defines the observed state of OSImage
+type OSImageStatus struct {
+	// LastDiscoveryTime is the time the images have been last discovered.
+	LastDiscoveryTime *metav1.Time `json:"lastDiscoveryTime,omitempty"`
+	// Images is the list of the images the references are resolved against,
+	// the images of the spec followed by the discovered images.
+	Images []OSImageEntry `json:"images,omitempty"`
+	// Error is the error message occurred during the reconciliation (if any).
+	Error string `json:"error,omitempty"`
+	// ObservedGeneration is the last observed generation.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=osimg
+// +kubebuilder:printcolumn:name="Provider",type="string",JSONPath=`.spec.provider`,description="Infrastructure provider of the images",priority=0
+// +kubebuilder:printcolumn:name="Discovered",type="date",JSONPath=`.status.lastDiscoveryTime`,description="Time the images have been last discovered",priority=1
+// +kubebuilder:printcolumn:name="Error",type="string",JSONPath=`.status.error`,description="Error during the reconciliation",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+
+// OSImage is the Schema for the osimages API
+type OSImage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OSImageSpec   `json:"spec,omitempty"`
+	Status OSImageStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// OSImageList contains a list of OSImage
+type OSImageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OSImage `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OSImage{}, &OSImageList{})
+}
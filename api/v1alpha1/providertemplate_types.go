@@ -37,10 +37,36 @@ type ProviderTemplateStatus struct {
 	CAPIContracts CompatibilityContracts `json:"capiContracts,omitempty"`
 	// Providers represent exposed CAPI providers.
 	Providers Providers `json:"providers,omitempty"`
+	// CRDUpgradeIssues holds the issues of upgrading the installed CustomResourceDefinitions
+	// to the ones shipped by the template, rechecked periodically. The blocking issues
+	// prevent the Management from switching to the template.
+	CRDUpgradeIssues []CRDUpgradeIssue `json:"crdUpgradeIssues,omitempty"`
 
 	TemplateStatusCommon `json:",inline"`
 }
 
+// CRDUpgradeIssue describes an issue of upgrading an installed CustomResourceDefinition
+// to the one shipped by a ProviderTemplate.
+type CRDUpgradeIssue struct {
+	// CRD is the name of the CustomResourceDefinition.
+	CRD string `json:"crd"`
+	// Version is the version of the CustomResourceDefinition the issue relates to.
+	Version string `json:"version"`
+	// Field is the path of the removed field the issue relates to, if any.
+	Field string `json:"field,omitempty"`
+	// Message describes the issue.
+	Message string `json:"message"`
+	// Blocking indicates whether the issue blocks the upgrade to the template.
+	Blocking bool `json:"blocking,omitempty"`
+}
+
+func (i CRDUpgradeIssue) String() string {
+	if i.Field != "" {
+		return fmt.Sprintf("CRD %s version %s field %s: %s", i.CRD, i.Version, i.Field, i.Message)
+	}
+	return fmt.Sprintf("CRD %s version %s: %s", i.CRD, i.Version, i.Message)
+}
+
 // FillStatusWithProviders sets the status of the template with providers
 // either from the spec or from the given annotations.
 func (t *ProviderTemplate) FillStatusWithProviders(annotations map[string]string) error {
@@ -37,6 +37,19 @@ type ReleaseSpec struct {
 	CAPI CoreProviderTemplate `json:"capi"`
 	// Providers contains a list of Providers associated with the Release.
 	Providers []NamedProviderTemplate `json:"providers,omitempty"`
+	// AddonVersions contains the versions of the cloud-controller-manager and the CSI driver add-ons
+	// compatible with the Kubernetes versions of the clusters. The first entry matching the infrastructure
+	// provider and the Kubernetes version of the ClusterTemplate is passed to the cluster in the
+	// "addonVersions" values, otherwise the versions defined by the ClusterTemplate are used.
+	AddonVersions []AddonVersions `json:"addonVersions,omitempty"`
+	// KubernetesVersions contains the end of life dates of the upstream Kubernetes minor versions.
+	// The clusters running the versions approaching or past their end of life are reported
+	// with the KubernetesVersionEndOfLife condition.
+	KubernetesVersions []KubernetesVersionSupport `json:"kubernetesVersions,omitempty"`
+	// KubernetesVersionSkew contains the Kubernetes versions of the clusters supported by the management
+	// components, e.g. Cluster API and the Sveltos agents. The clusters cannot be deployed or upgraded
+	// with the ClusterTemplates of the Kubernetes versions unsupported by any of the components.
+	KubernetesVersionSkew []ComponentKubernetesVersions `json:"kubernetesVersionSkew,omitempty"`
 }
 
 type CoreProviderTemplate struct {
@@ -50,6 +63,51 @@ type NamedProviderTemplate struct {
 	Name string `json:"name"`
 }
 
+// AddonVersions defines the versions of the add-ons of an infrastructure provider
+// compatible with a range of the Kubernetes versions.
+type AddonVersions struct {
+	// CloudControllerManager is the version of the cloud-controller-manager add-on.
+	CloudControllerManager *AddonVersion `json:"cloudControllerManager,omitempty"`
+	// CSIDriver is the version of the CSI driver add-on.
+	CSIDriver *AddonVersion `json:"csiDriver,omitempty"`
+	// Provider is the name of the infrastructure provider the add-ons are deployed with, e.g. "infrastructure-aws".
+	// +kubebuilder:validation:MinLength=1
+	Provider string `json:"provider"`
+	// KubernetesVersions is the semver constraint of the Kubernetes versions the add-ons
+	// are compatible with, e.g. ">=1.31.0 <1.32.0".
+	// +kubebuilder:validation:MinLength=1
+	KubernetesVersions string `json:"kubernetesVersions"`
+}
+
+// AddonVersion defines the version of an add-on.
+type AddonVersion struct {
+	// ChartVersion is the version of the Helm chart of the add-on.
+	// +kubebuilder:validation:MinLength=1
+	ChartVersion string `json:"chartVersion"`
+	// ImageTag is the tag of the images of the add-on, the tag defined by the ClusterTemplate is used if not set.
+	ImageTag string `json:"imageTag,omitempty"`
+}
+
+// KubernetesVersionSupport defines the upstream support period of a Kubernetes minor version.
+type KubernetesVersionSupport struct {
+	// EndOfLife is the date the upstream support of the version ends.
+	EndOfLife metav1.Time `json:"endOfLife"`
+	// Version is the Kubernetes minor version, e.g. "1.31".
+	// +kubebuilder:validation:Pattern=`^v?[0-9]+\.[0-9]+$`
+	Version string `json:"version"`
+}
+
+// ComponentKubernetesVersions defines the Kubernetes versions of the clusters supported by a management component.
+type ComponentKubernetesVersions struct {
+	// Component is the name of the management component, e.g. "cluster-api".
+	// +kubebuilder:validation:MinLength=1
+	Component string `json:"component"`
+	// KubernetesVersions is the semver constraint of the Kubernetes versions of the clusters
+	// supported by the component, e.g. ">=1.28.0 <1.33.0".
+	// +kubebuilder:validation:MinLength=1
+	KubernetesVersions string `json:"kubernetesVersions"`
+}
+
 func (in *Release) ProviderTemplate(name string) string {
 	for _, p := range in.Spec.Providers {
 		if p.Name == name {
@@ -0,0 +1,125 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SecretPropagationKind is the string representation of a SecretPropagation.
+	SecretPropagationKind = "SecretPropagation"
+
+	// SecretPropagationFinalizer is the finalizer applied to the SecretPropagations
+	// to remove the propagated objects from the clusters on deletion.
+	SecretPropagationFinalizer = "k0rdent.mirantis.com/secret-propagation"
+
+	// SecretPropagationLabel is the label on the Secrets and the ConfigMaps propagated to the clusters,
+	// the value is the name of the SecretPropagation.
+	SecretPropagationLabel = "k0rdent.mirantis.com/secret-propagation"
+
+	// SecretPropagationHashAnnotation is the annotation on the Secrets and the ConfigMaps propagated
+	// to the clusters holding the hash of the propagated data.
+	SecretPropagationHashAnnotation = "k0rdent.mirantis.com/secret-propagation-hash"
+)
+
+// +kubebuilder:validation:XValidation:rule="(has(self.secrets) && size(self.secrets) > 0) || (has(self.configMaps) && size(self.configMaps) > 0)",message="at least one Secret or ConfigMap must be propagated"
+
+// SecretPropagationSpec defines the desired state of SecretPropagation
+type SecretPropagationSpec struct {
+	// ClusterSelector selects the ClusterDeployments in the namespace of the SecretPropagation
+	// the objects are propagated to. An empty selector matches all ClusterDeployments.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// +listType=set
+
+	// Secrets is the list of the names of the Secrets in the namespace of the SecretPropagation to propagate.
+	Secrets []string `json:"secrets,omitempty"`
+
+	// +listType=set
+
+	// ConfigMaps is the list of the names of the ConfigMaps in the namespace of the SecretPropagation to propagate.
+	ConfigMaps []string `json:"configMaps,omitempty"`
+
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:MaxLength=63
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+
+	// TargetNamespaces is the list of the namespaces in the clusters the objects are propagated to.
+	// The missing namespaces are created.
+	TargetNamespaces []string `json:"targetNamespaces"`
+}
+
+// SecretPropagationClusterStatus reflects the propagation of the objects to a cluster.
+type SecretPropagationClusterStatus struct {
+	// LastSyncTime is the time the objects have last been synchronized to the cluster.
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ClusterDeployment is the name of the ClusterDeployment.
+	ClusterDeployment string `json:"clusterDeployment"`
+
+	// Hash is the hash of the objects last synchronized to the cluster.
+	Hash string `json:"hash,omitempty"`
+
+	// Error is the error occurred during the last synchronization to the cluster (if any).
+	Error string `json:"error,omitempty"`
+}
+
+// SecretPropagationStatus defines the observed state of SecretPropagation
+type SecretPropagationStatus struct {
+	// Clusters is the list of the propagations to the selected clusters.
+	Clusters []SecretPropagationClusterStatus `json:"clusters,omitempty"`
+	// Synced is the number of the clusters the current objects are synchronized to.
+	Synced int32 `json:"synced,omitempty"`
+	// Total is the number of the selected clusters.
+	Total int32 `json:"total,omitempty"`
+	// Conditions contains details for the current state of the SecretPropagation.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Error is the error message occurred during the reconciliation (if any).
+	Error string `json:"error,omitempty"`
+	// ObservedGeneration is the last observed generation.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=sprop
+// +kubebuilder:printcolumn:name="Synced",type="integer",JSONPath=`.status.synced`,description="Number of the synchronized clusters",priority=0
+// +kubebuilder:printcolumn:name="Total",type="integer",JSONPath=`.status.total`,description="Number of the selected clusters",priority=0
+// +kubebuilder:printcolumn:name="Error",type="string",JSONPath=`.status.error`,description="Error during the reconciliation",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+
+// SecretPropagation is the Schema for the secretpropagations API
+type SecretPropagation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecretPropagationSpec   `json:"spec,omitempty"`
+	Status SecretPropagationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecretPropagationList contains a list of SecretPropagation
+type SecretPropagationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecretPropagation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SecretPropagation{}, &SecretPropagationList{})
+}
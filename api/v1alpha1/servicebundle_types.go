@@ -0,0 +1,58 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServiceBundleKind is the string representation of a ServiceBundle.
+const ServiceBundleKind = "ServiceBundle"
+
+// ServiceBundleSpec defines the desired state of ServiceBundle
+type ServiceBundleSpec struct {
+	// +kubebuilder:validation:MinItems=1
+
+	// Services is the list of the services of the bundle in the order they are deployed in.
+	// The values of the services are the defaults which the ClusterDeployments and the MultiClusterServices
+	// including the bundle override by listing a service with the same name and namespace.
+	// The ServiceTemplates are looked up in the namespace of the bundle.
+	Services []Service `json:"services"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=sbundle
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+
+// ServiceBundle is the Schema for the servicebundles API
+type ServiceBundle struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceBundleSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServiceBundleList contains a list of ServiceBundle
+type ServiceBundleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceBundle `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServiceBundle{}, &ServiceBundleList{})
+}
@@ -16,6 +16,9 @@ package v1alpha1
 
 import (
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
@@ -28,6 +31,28 @@ const (
 	ServiceTemplateKind = "ServiceTemplate"
 	// ChartAnnotationKubernetesConstraint is an annotation containing the Kubernetes constrained version in the SemVer format associated with a ServiceTemplate.
 	ChartAnnotationKubernetesConstraint = "k0rdent.mirantis.com/k8s-version-constraint"
+	// ServiceTemplateEndOfLifeAnnotation is an annotation of the chart or of the ServiceTemplate holding the date
+	// in the YYYY-MM-DD format the upstream support of the version of the service ends. The annotation
+	// of the ServiceTemplate, e.g. set from an external advisories feed, takes precedence.
+	ServiceTemplateEndOfLifeAnnotation = "k0rdent.mirantis.com/end-of-life"
+	// ServiceTemplateVulnerabilitiesAnnotation is an annotation of the chart or of the ServiceTemplate holding
+	// the comma-separated list of the IDs of the known vulnerabilities of the version of the service,
+	// e.g. "CVE-2025-1974, CVE-2025-24514". The annotation of the ServiceTemplate takes precedence.
+	ServiceTemplateVulnerabilitiesAnnotation = "k0rdent.mirantis.com/vulnerabilities"
+	// ServiceTemplateDeprecatedAnnotation marks a ServiceTemplate as deprecated. The value
+	// is an optional human-readable explanation, e.g. the suggested replacement.
+	ServiceTemplateDeprecatedAnnotation = "k0rdent.mirantis.com/deprecated"
+	// ServiceTemplateFinalizer blocks the deletion of a ServiceTemplate
+	// until it is no longer referenced by the ClusterDeployments and MultiClusterServices.
+	ServiceTemplateFinalizer = "k0rdent.mirantis.com/service-template"
+
+	// ServiceTemplateUpdatedReason indicates a referenced ServiceTemplate has been updated.
+	ServiceTemplateUpdatedReason = "ReferencedTemplateUpdated"
+	// ServiceTemplateDeprecatedReason indicates a referenced ServiceTemplate is deprecated.
+	ServiceTemplateDeprecatedReason = "ReferencedTemplateDeprecated"
+	// ServiceTemplateDeletionBlockedReason indicates the deletion of a ServiceTemplate
+	// is blocked since the template is still referenced.
+	ServiceTemplateDeletionBlockedReason = "DeletionBlocked"
 )
 
 // +kubebuilder:validation:XValidation:rule="has(self.helm) ? (!has(self.kustomize) && !has(self.resources)): true",message="Helm, Kustomize and Resources are mutually exclusive."
@@ -123,9 +148,46 @@ type ServiceTemplateStatus struct {
 	// SourceStatus reflects the status of the source.
 	SourceStatus *SourceStatus `json:"sourceStatus,omitempty"`
 
+	// ReferencedBy is the list of the ClusterDeployments and MultiClusterServices
+	// referencing the ServiceTemplate. A referenced ServiceTemplate is not deleted
+	// until all the references are removed.
+	ReferencedBy []ServiceTemplateReference `json:"referencedBy,omitempty"`
+
+	// Advisories are the end of life and the known vulnerabilities of the version of the service
+	// set with the annotations of the chart or of the ServiceTemplate.
+	Advisories *ServiceTemplateAdvisories `json:"advisories,omitempty"`
+
 	TemplateStatusCommon `json:",inline"`
 }
 
+// ServiceTemplateAdvisories are the end of life and the known vulnerabilities of the version of a service.
+type ServiceTemplateAdvisories struct {
+	// EndOfLife is the date the upstream support of the version of the service ends.
+	EndOfLife *metav1.Time `json:"endOfLife,omitempty"`
+	// Vulnerabilities is the list of the IDs of the known vulnerabilities of the version of the service.
+	Vulnerabilities []string `json:"vulnerabilities,omitempty"`
+}
+
+// ServiceTemplateReference is an object referencing a ServiceTemplate.
+type ServiceTemplateReference struct {
+	// +kubebuilder:validation:Enum=ClusterDeployment;MultiClusterService
+
+	// Kind is the kind of the referencing object.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the referencing object, empty for the MultiClusterServices.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the referencing object.
+	Name string `json:"name"`
+}
+
+// String returns the kind and the namespaced name of the referencing object.
+func (r ServiceTemplateReference) String() string {
+	if r.Namespace == "" {
+		return r.Kind + " " + r.Name
+	}
+	return r.Kind + " " + r.Namespace + "/" + r.Name
+}
+
 // SourceStatus reflects the status of the source.
 type SourceStatus struct {
 	// Kind is the kind of the remote source.
@@ -150,6 +212,10 @@ type SourceStatus struct {
 // FillStatusWithProviders sets the status of the template with providers
 // either from the spec or from the given annotations.
 func (t *ServiceTemplate) FillStatusWithProviders(annotations map[string]string) error {
+	if err := t.FillAdvisories(annotations); err != nil {
+		return err
+	}
+
 	kconstraint := annotations[ChartAnnotationKubernetesConstraint]
 	if t.Spec.KubernetesConstraint != "" {
 		kconstraint = t.Spec.KubernetesConstraint
@@ -167,6 +233,40 @@ func (t *ServiceTemplate) FillStatusWithProviders(annotations map[string]string)
 	return nil
 }
 
+// FillAdvisories sets the advisories in the status of the template from the given chart annotations
+// and the annotations of the template, the latter take precedence.
+func (t *ServiceTemplate) FillAdvisories(chartAnnotations map[string]string) error {
+	annotation := func(key string) string {
+		if value, ok := t.Annotations[key]; ok {
+			return value
+		}
+		return chartAnnotations[key]
+	}
+
+	advisories := new(ServiceTemplateAdvisories)
+	if eol := strings.TrimSpace(annotation(ServiceTemplateEndOfLifeAnnotation)); eol != "" {
+		date, err := time.Parse(time.DateOnly, eol)
+		if err != nil {
+			return fmt.Errorf("failed to parse the end of life date %s for ServiceTemplate %s/%s: %w", eol, t.GetNamespace(), t.GetName(), err)
+		}
+		advisories.EndOfLife = &metav1.Time{Time: date}
+	}
+	for id := range strings.SplitSeq(annotation(ServiceTemplateVulnerabilitiesAnnotation), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			advisories.Vulnerabilities = append(advisories.Vulnerabilities, id)
+		}
+	}
+	slices.Sort(advisories.Vulnerabilities)
+	advisories.Vulnerabilities = slices.Compact(advisories.Vulnerabilities)
+
+	t.Status.Advisories = nil
+	if advisories.EndOfLife != nil || len(advisories.Vulnerabilities) > 0 {
+		t.Status.Advisories = advisories
+	}
+
+	return nil
+}
+
 // GetHelmSpec returns .spec.helm of the Template.
 func (t *ServiceTemplate) GetHelmSpec() *HelmSpec {
 	return t.Spec.Helm
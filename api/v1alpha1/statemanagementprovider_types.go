@@ -0,0 +1,80 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StateManagementProviderKind is the string representation of a StateManagementProvider.
+const StateManagementProviderKind = "StateManagementProvider"
+
+// StateManagementProviderSpec defines the desired state of StateManagementProvider
+type StateManagementProviderSpec struct {
+	// Selector selects the ClusterDeployments the services of which are delivered by the engine
+	// unless the delivery is set in the ClusterDeployment. The selector takes precedence over the Management setting,
+	// the first provider in the alphabetical order wins if several providers select the same ClusterDeployment.
+	// No ClusterDeployments are selected if empty.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// +kubebuilder:validation:Enum:=Sveltos;Flux;Applier
+
+	// Engine is the engine delivering the services.
+	Engine string `json:"engine"`
+
+	// RequiredCRDs is the list of the names of the CustomResourceDefinitions the engine requires,
+	// the provider is ready once all of them are installed. Defaults to the CRDs of the engine.
+	RequiredCRDs []string `json:"requiredCRDs,omitempty"`
+}
+
+// StateManagementProviderStatus defines the observed state of StateManagementProvider
+type StateManagementProviderStatus struct {
+	// Error is the error message occurred during the reconciliation (if any).
+	Error string `json:"error,omitempty"`
+	// ObservedGeneration is the last observed generation.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Ready reports whether the engine is able to deliver the services.
+	Ready bool `json:"ready"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=smp
+// +kubebuilder:printcolumn:name="Engine",type="string",JSONPath=`.spec.engine`,description="Engine delivering the services",priority=0
+// +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=`.status.ready`,description="Whether the engine is able to deliver the services",priority=0
+// +kubebuilder:printcolumn:name="Error",type="string",JSONPath=`.status.error`,description="Error during the reconciliation",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+
+// StateManagementProvider is the Schema for the statemanagementproviders API
+type StateManagementProvider struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StateManagementProviderSpec   `json:"spec,omitempty"`
+	Status StateManagementProviderStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// StateManagementProviderList contains a list of StateManagementProvider
+type StateManagementProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StateManagementProvider `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&StateManagementProvider{}, &StateManagementProviderList{})
+}
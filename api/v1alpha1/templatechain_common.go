@@ -17,6 +17,9 @@ package v1alpha1
 import (
 	"fmt"
 	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // TemplateChainSpec defines the desired state of *TemplateChain
@@ -27,8 +30,13 @@ type TemplateChainSpec struct {
 
 // TemplateChainStatus defines the observed state of *TemplateChain
 type TemplateChainStatus struct {
-	// ValidationErrors is the list of the errors due to the incorrect given spec.
+	// ValidationErrors is the list of the errors due to the incorrect given spec
+	// or to the supported templates missing in the system namespace.
 	ValidationErrors []string `json:"validationErrors,omitempty"`
+	// UpgradeGraph is the adjacency list of the upgrade graph described by the spec.
+	UpgradeGraph []TemplateChainNode `json:"upgradeGraph,omitempty"`
+	// Conditions contains details for the current state of the *TemplateChain.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// IsValid indicates whether the object is ready to be consumed.
 	IsValid bool `json:"isValid,omitempty"`
 }
@@ -47,7 +55,51 @@ type AvailableUpgrade struct {
 	Name string `json:"name"`
 }
 
+// TemplateChainNode is a node of the upgrade graph of a *TemplateChain.
+type TemplateChainNode struct {
+	// Name is the name of the Template.
+	Name string `json:"name"`
+	// Upgrades is the list of the Templates to which the upgrade is available.
+	Upgrades []string `json:"upgrades,omitempty"`
+}
+
+// NewLinearTemplateChainSpec returns the [TemplateChainSpec] for the given ordered
+// list of templates, where each template can be upgraded to the next one.
+func NewLinearTemplateChainSpec(templates ...string) TemplateChainSpec {
+	spec := TemplateChainSpec{SupportedTemplates: make([]SupportedTemplate, 0, len(templates))}
+	for i, name := range templates {
+		supportedTemplate := SupportedTemplate{Name: name}
+		if i+1 < len(templates) {
+			supportedTemplate.AvailableUpgrades = []AvailableUpgrade{{Name: templates[i+1]}}
+		}
+		spec.SupportedTemplates = append(spec.SupportedTemplates, supportedTemplate)
+	}
+	return spec
+}
+
+// UpgradeGraph returns the adjacency list of the upgrade graph in the order of the supported templates.
+func (s *TemplateChainSpec) UpgradeGraph() []TemplateChainNode {
+	if len(s.SupportedTemplates) == 0 {
+		return nil
+	}
+
+	graph := make([]TemplateChainNode, 0, len(s.SupportedTemplates))
+	for _, supportedTemplate := range s.SupportedTemplates {
+		node := TemplateChainNode{Name: supportedTemplate.Name}
+		for _, upgrade := range supportedTemplate.AvailableUpgrades {
+			if !slices.Contains(node.Upgrades, upgrade.Name) {
+				node.Upgrades = append(node.Upgrades, upgrade.Name)
+			}
+		}
+		graph = append(graph, node)
+	}
+
+	return graph
+}
+
 // IsValid checks if the [TemplateChainSpec] is valid, otherwise provides warning messages.
+// The spec is invalid if an upgrade target or a template is missing or duplicated,
+// if a template is unreachable in the upgrade graph or if the graph contains a cycle.
 func (s *TemplateChainSpec) IsValid() (warnings []string, ok bool) {
 	supportedTemplates := make(map[string]struct{}, len(s.SupportedTemplates))
 	availableForUpgrade := make(map[string]struct{}, len(s.SupportedTemplates))
@@ -64,9 +116,75 @@ func (s *TemplateChainSpec) IsValid() (warnings []string, ok bool) {
 		}
 	}
 
+	seen := make(map[string]struct{}, len(s.SupportedTemplates))
+	for _, supportedTemplate := range s.SupportedTemplates {
+		if _, ok := seen[supportedTemplate.Name]; ok {
+			warnings = append(warnings, fmt.Sprintf("template %s is present more than once in the list of '.spec.supportedTemplates'", supportedTemplate.Name))
+		}
+		seen[supportedTemplate.Name] = struct{}{}
+
+		// chains without any upgrades only distribute the templates, otherwise
+		// a template neither upgraded from nor to another one is unreachable
+		if _, ok := availableForUpgrade[supportedTemplate.Name]; !ok && len(availableForUpgrade) > 0 && len(supportedTemplate.AvailableUpgrades) == 0 {
+			warnings = append(warnings, fmt.Sprintf("template %s is not connected to any other template of the chain", supportedTemplate.Name))
+		}
+	}
+
+	if cycle := s.findCycle(); len(cycle) > 0 {
+		warnings = append(warnings, fmt.Sprintf("the upgrade graph contains a cycle: %s", strings.Join(cycle, " -> ")))
+	}
+
 	if len(warnings) > 0 {
 		slices.Sort(warnings)
 	}
 
 	return warnings, len(warnings) == 0
 }
+
+// findCycle returns the templates forming the first found upgrade cycle, with the first template repeated at the end.
+func (s *TemplateChainSpec) findCycle() []string {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+
+	graph := s.UpgradeGraph()
+	adjacency := make(map[string][]string, len(graph))
+	for _, node := range graph {
+		adjacency[node.Name] = append(adjacency[node.Name], node.Upgrades...)
+	}
+
+	state := make(map[string]int, len(adjacency))
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		state[name] = inProgress
+		path = append(path, name)
+		for _, next := range adjacency[name] {
+			switch state[next] {
+			case inProgress:
+				return append(slices.Clone(path[slices.Index(path, next):]), next)
+			case unvisited:
+				if cycle := visit(next); len(cycle) > 0 {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		return nil
+	}
+
+	for _, node := range graph {
+		if state[node.Name] != unvisited {
+			continue
+		}
+		if cycle := visit(node.Name); len(cycle) > 0 {
+			return cycle
+		}
+	}
+
+	return nil
+}
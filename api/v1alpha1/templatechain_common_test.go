@@ -0,0 +1,100 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTemplateChainSpecIsValid(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		spec     TemplateChainSpec
+		warnings []string
+	}{
+		{
+			name: "linear chain",
+			spec: NewLinearTemplateChainSpec("t-1-0-0", "t-1-0-1", "t-1-0-2"),
+		},
+		{
+			name: "no upgrades",
+			spec: TemplateChainSpec{SupportedTemplates: []SupportedTemplate{{Name: "a"}, {Name: "b"}}},
+		},
+		{
+			name: "missing upgrade target",
+			spec: TemplateChainSpec{SupportedTemplates: []SupportedTemplate{
+				{Name: "a", AvailableUpgrades: []AvailableUpgrade{{Name: "b"}}},
+			}},
+			warnings: []string{"template b is allowed for upgrade but is not present in the list of '.spec.supportedTemplates'"},
+		},
+		{
+			name: "duplicate and unreachable templates",
+			spec: TemplateChainSpec{SupportedTemplates: []SupportedTemplate{
+				{Name: "a", AvailableUpgrades: []AvailableUpgrade{{Name: "b"}}},
+				{Name: "b"},
+				{Name: "b"},
+				{Name: "c"},
+			}},
+			warnings: []string{
+				"template b is present more than once in the list of '.spec.supportedTemplates'",
+				"template c is not connected to any other template of the chain",
+			},
+		},
+		{
+			name: "self upgrade",
+			spec: TemplateChainSpec{SupportedTemplates: []SupportedTemplate{
+				{Name: "a", AvailableUpgrades: []AvailableUpgrade{{Name: "a"}}},
+			}},
+			warnings: []string{"the upgrade graph contains a cycle: a -> a"},
+		},
+		{
+			name: "cycle",
+			spec: TemplateChainSpec{SupportedTemplates: []SupportedTemplate{
+				{Name: "a", AvailableUpgrades: []AvailableUpgrade{{Name: "b"}}},
+				{Name: "b", AvailableUpgrades: []AvailableUpgrade{{Name: "c"}}},
+				{Name: "c", AvailableUpgrades: []AvailableUpgrade{{Name: "b"}}},
+			}},
+			warnings: []string{"the upgrade graph contains a cycle: b -> c -> b"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			warnings, ok := tc.spec.IsValid()
+			if ok != (len(tc.warnings) == 0) {
+				t.Errorf("IsValid() ok = %v, want %v", ok, len(tc.warnings) == 0)
+			}
+			if !reflect.DeepEqual(warnings, tc.warnings) {
+				t.Errorf("IsValid() warnings = %q, want %q", warnings, tc.warnings)
+			}
+		})
+	}
+}
+
+func TestTemplateChainSpecUpgradeGraph(t *testing.T) {
+	spec := TemplateChainSpec{SupportedTemplates: []SupportedTemplate{
+		{Name: "a", AvailableUpgrades: []AvailableUpgrade{{Name: "b"}, {Name: "c"}, {Name: "b"}}},
+		{Name: "b", AvailableUpgrades: []AvailableUpgrade{{Name: "c"}}},
+		{Name: "c"},
+	}}
+
+	want := []TemplateChainNode{
+		{Name: "a", Upgrades: []string{"b", "c"}},
+		{Name: "b", Upgrades: []string{"c"}},
+		{Name: "c"},
+	}
+	if got := spec.UpgradeGraph(); !reflect.DeepEqual(got, want) {
+		t.Errorf("UpgradeGraph() = %v, want %v", got, want)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	helmcontrollerv2 "github.com/fluxcd/helm-controller/api/v2"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -30,6 +31,14 @@ const (
 	chartAnnoCAPIPrefix = "cluster.x-k8s.io/"
 
 	DefaultRepoName = "kcm-templates"
+
+	// ChartSourceLabelKey is the label set on the HelmCharts of the templates declaring chart mirrors
+	// holding whether the HelmChart pulls the chart from the primary source or from a mirror.
+	ChartSourceLabelKey = "k0rdent.mirantis.com/chart-source"
+	// ChartSourcePrimary is the value of the ChartSourceLabelKey label of the HelmChart of the primary source.
+	ChartSourcePrimary = "primary"
+	// ChartSourceMirror is the value of the ChartSourceLabelKey label of the HelmCharts of the mirrors.
+	ChartSourceMirror = "mirror"
 )
 
 var DefaultSourceRef = sourcev1.LocalHelmChartSourceReference{
@@ -38,6 +47,9 @@ var DefaultSourceRef = sourcev1.LocalHelmChartSourceReference{
 }
 
 // +kubebuilder:validation:XValidation:rule="(has(self.chartSpec) && !has(self.chartRef)) || (!has(self.chartSpec) && has(self.chartRef))", message="either chartSpec or chartRef must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.registryAuth) || has(self.chartSpec)", message="registryAuth can only be set with chartSpec"
+// +kubebuilder:validation:XValidation:rule="!has(self.mirrors) || has(self.chartSpec)", message="mirrors can only be set with chartSpec"
+// +kubebuilder:validation:XValidation:rule="!has(self.mirrors) || self.mirrors.all(m, has(m.url))", message="url must be set for each mirror"
 
 // HelmSpec references a Helm chart representing the KCM template
 type HelmSpec struct {
@@ -47,6 +59,57 @@ type HelmSpec struct {
 	// ChartRef is a reference to a source controller resource containing the
 	// Helm chart representing the template.
 	ChartRef *helmcontrollerv2.CrossNamespaceSourceReference `json:"chartRef,omitempty"`
+
+	// RegistryAuth configures the authentication to the registry the chart is pulled from for this template only.
+	// If set, the controller creates a HelmRepository dedicated to the template, named after it, instead of
+	// using the default repository shared by the templates, and the source reference of the ChartSpec is ignored.
+	// The authentication applies to the services of the template delivered to the clusters as well.
+	RegistryAuth *RegistryAuth `json:"registryAuth,omitempty"`
+
+	// +kubebuilder:validation:MaxItems=5
+
+	// Mirrors are the registries the chart is mirrored to, with their authentication, in the order of preference.
+	// The chart is pulled from every source, the primary one being the default registry or RegistryAuth,
+	// and the template uses the chart of the first ready source, failing over to the mirrors while
+	// the primary source is unavailable and back once it recovers. The URL of each mirror is required.
+	Mirrors []RegistryAuth `json:"mirrors,omitempty"`
+}
+
+const (
+	// RegistryAuthProviderGeneric authenticates to the registry with the credentials of a Secret, if any.
+	RegistryAuthProviderGeneric = "generic"
+	// RegistryAuthProviderAWS authenticates to the Amazon ECR registry with the IRSA workload identity.
+	RegistryAuthProviderAWS = "aws"
+	// RegistryAuthProviderAzure authenticates to the Azure Container Registry with the Azure Workload Identity.
+	RegistryAuthProviderAzure = "azure"
+	// RegistryAuthProviderGCP authenticates to the Google Artifact Registry with the GKE Workload Identity.
+	RegistryAuthProviderGCP = "gcp"
+)
+
+// +kubebuilder:validation:XValidation:rule="!has(self.secretRef) || !has(self.provider) || self.provider == 'generic'", message="secretRef can only be set with the generic provider"
+
+// RegistryAuth defines the authentication to the registry of a Helm chart.
+type RegistryAuth struct {
+	// URL is the URL of the registry, e.g. oci://registry.example.com/charts.
+	// Defaults to the URL of the default registry.
+	URL string `json:"url,omitempty"`
+
+	// +kubebuilder:default:=generic
+	// +kubebuilder:validation:Enum=generic;aws;azure;gcp
+
+	// Provider is the authentication provider of the registry. The aws, azure and gcp providers
+	// authenticate to the OCI registries with the workload identity (IRSA, Azure Workload Identity,
+	// GKE Workload Identity) of the ServiceAccount of the Flux source-controller, the generic
+	// provider authenticates with the credentials of SecretRef, if set.
+	Provider string `json:"provider,omitempty"`
+
+	// SecretRef is the name of the Secret in the namespace of the template holding the credentials
+	// of the registry, either a docker config JSON or username and password. The Secret of a ProviderTemplate
+	// is looked up in the system namespace.
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// Insecure allows connecting to the registry over plain HTTP.
+	Insecure bool `json:"insecure,omitempty"`
 }
 
 func (s *HelmSpec) String() string {
@@ -75,15 +138,35 @@ type TemplateStatusCommon struct {
 	ChartRef *helmcontrollerv2.CrossNamespaceSourceReference `json:"chartRef,omitempty"`
 	// ChartVersion represents the version of the Helm Chart associated with this template.
 	ChartVersion string `json:"chartVersion,omitempty"`
+	// ChartSources reports the health of the chart sources of the templates declaring chart mirrors,
+	// the primary source first and the mirrors after it.
+	ChartSources []ChartSourceStatus `json:"chartSources,omitempty"`
 	// Description contains information about the template.
 	Description string `json:"description,omitempty"`
 
 	TemplateValidationStatus `json:",inline"`
 
+	// Conditions contains details for the current state of the template.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
 	// ObservedGeneration is the last observed generation.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// ChartSourceStatus defines the observed state of a chart source of a template.
+type ChartSourceStatus struct {
+	// URL is the URL of the registry of the source.
+	URL string `json:"url"`
+	// HelmChart is the name of the HelmChart pulling the chart from the source.
+	HelmChart string `json:"helmChart"`
+	// Message holds the reason the chart of the source is not ready, if any.
+	Message string `json:"message,omitempty"`
+	// Ready indicates whether the chart of the source is pulled successfully.
+	Ready bool `json:"ready"`
+	// Active indicates whether the template uses the chart of the source.
+	Active bool `json:"active,omitempty"`
+}
+
 type TemplateValidationStatus struct {
 	// ValidationError provides information regarding issues encountered during template validation.
 	ValidationError string `json:"validationError,omitempty"`
@@ -0,0 +1,121 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VIPPoolKind is the string representation of a VIPPool.
+	VIPPoolKind = "VIPPool"
+
+	// ControlPlaneVIPReadyCondition indicates that the control plane VIP has been reserved for the cluster.
+	ControlPlaneVIPReadyCondition = "ControlPlaneVIPReady"
+
+	// DefaultControlPlaneVIPValuesKey is the default ClusterTemplate values key
+	// the reserved control plane VIP is passed with.
+	DefaultControlPlaneVIPValuesKey = "controlPlaneEndpointIP"
+)
+
+const (
+	// VIPPoolTypeKubeVIP denotes addresses announced by kube-vip.
+	VIPPoolTypeKubeVIP = "KubeVIP"
+	// VIPPoolTypeMetalLB denotes addresses served by a MetalLB address pool.
+	VIPPoolTypeMetalLB = "MetalLB"
+	// VIPPoolTypeCloudEIP denotes pre-allocated cloud elastic IP addresses.
+	VIPPoolTypeCloudEIP = "CloudEIP"
+)
+
+// +kubebuilder:validation:XValidation:rule="has(self.address) || has(self.pool)",message="either address or pool must be set"
+
+// ControlPlaneVIP configures the virtual IP address used as the control plane endpoint of the cluster.
+type ControlPlaneVIP struct {
+	// Address is the statically assigned VIP.
+	// If Pool is set as well, the address must belong to the pool.
+	Address string `json:"address,omitempty"`
+	// Pool is the name of the VIPPool to reserve the VIP from.
+	Pool string `json:"pool,omitempty"`
+
+	// +kubebuilder:default:=controlPlaneEndpointIP
+
+	// ValuesKey is the key in the ClusterTemplate values the reserved VIP is passed with.
+	ValuesKey string `json:"valuesKey,omitempty"`
+}
+
+// VIPPoolSpec defines the desired state of VIPPool
+type VIPPoolSpec struct {
+	// +kubebuilder:validation:MinItems=1
+
+	// Addresses is the list of addresses the VIPs are reserved from.
+	// Each item is either a single IP address, a CIDR or
+	// an inclusive range of addresses in the form of "<first>-<last>".
+	Addresses []string `json:"addresses"`
+
+	// +kubebuilder:default:=KubeVIP
+	// +kubebuilder:validation:Enum:=KubeVIP;MetalLB;CloudEIP
+
+	// Type describes how the addresses from the pool are announced.
+	Type string `json:"type,omitempty"`
+}
+
+// VIPAllocation represents an address reserved by a ClusterDeployment.
+type VIPAllocation struct {
+	// Address is the reserved VIP.
+	Address string `json:"address"`
+	// ClusterDeployment is the namespaced name of the ClusterDeployment the address is reserved by.
+	ClusterDeployment string `json:"clusterDeployment"`
+}
+
+// VIPPoolStatus defines the observed state of VIPPool
+type VIPPoolStatus struct {
+	// Allocations is the list of addresses reserved from the pool.
+	Allocations []VIPAllocation `json:"allocations,omitempty"`
+	// Conditions contains details for the current state of the VIPPool.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Error is the error message occurred during the reconciliation (if any).
+	Error string `json:"error,omitempty"`
+	// ObservedGeneration is the last observed generation.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=vip
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=`.spec.type`,description="Type of the pool",priority=0
+// +kubebuilder:printcolumn:name="Error",type="string",JSONPath=`.status.error`,description="Error during the reconciliation",priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+
+// VIPPool is the Schema for the vippools API
+type VIPPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VIPPoolSpec   `json:"spec,omitempty"`
+	Status VIPPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VIPPoolList contains a list of VIPPool
+type VIPPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VIPPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VIPPool{}, &VIPPoolList{})
+}
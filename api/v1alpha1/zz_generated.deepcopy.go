@@ -25,11 +25,28 @@ import (
 	apiv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessDeliveryResult) DeepCopyInto(out *AccessDeliveryResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessDeliveryResult.
+func (in *AccessDeliveryResult) DeepCopy() *AccessDeliveryResult {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessDeliveryResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessManagement) DeepCopyInto(out *AccessManagement) {
 	*out = *in
@@ -121,6 +138,27 @@ func (in *AccessManagementStatus) DeepCopyInto(out *AccessManagementStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]NamespaceAccessChanges, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Delivery != nil {
+		in, out := &in.Delivery, &out.Delivery
+		*out = make([]NamespaceAccessDelivery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessManagementStatus.
@@ -152,6 +190,18 @@ func (in *AccessRule) DeepCopyInto(out *AccessRule) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClusterTemplateGrants != nil {
+		in, out := &in.ClusterTemplateGrants, &out.ClusterTemplateGrants
+		*out = make([]ClusterTemplateGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]AccessSubject, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessRule.
@@ -165,449 +215,3119 @@ func (in *AccessRule) DeepCopy() *AccessRule {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AvailableUpgrade) DeepCopyInto(out *AvailableUpgrade) {
+func (in *AccessSubject) DeepCopyInto(out *AccessSubject) {
 	*out = *in
+	out.Subject = in.Subject
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailableUpgrade.
-func (in *AvailableUpgrade) DeepCopy() *AvailableUpgrade {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessSubject.
+func (in *AccessSubject) DeepCopy() *AccessSubject {
 	if in == nil {
 		return nil
 	}
-	out := new(AvailableUpgrade)
+	out := new(AccessSubject)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeployment) DeepCopyInto(out *ClusterDeployment) {
+func (in *AddonVersion) DeepCopyInto(out *AddonVersion) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeployment.
-func (in *ClusterDeployment) DeepCopy() *ClusterDeployment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonVersion.
+func (in *AddonVersion) DeepCopy() *AddonVersion {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeployment)
+	out := new(AddonVersion)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterDeployment) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeploymentList) DeepCopyInto(out *ClusterDeploymentList) {
+func (in *AddonVersions) DeepCopyInto(out *AddonVersions) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterDeployment, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.CloudControllerManager != nil {
+		in, out := &in.CloudControllerManager, &out.CloudControllerManager
+		*out = new(AddonVersion)
+		**out = **in
+	}
+	if in.CSIDriver != nil {
+		in, out := &in.CSIDriver, &out.CSIDriver
+		*out = new(AddonVersion)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentList.
-func (in *ClusterDeploymentList) DeepCopy() *ClusterDeploymentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonVersions.
+func (in *AddonVersions) DeepCopy() *AddonVersions {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeploymentList)
+	out := new(AddonVersions)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterDeploymentList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
+func (in *AdmissionWebhooks) DeepCopyInto(out *AdmissionWebhooks) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = new(apiextensionsv1.JSON)
-		(*in).DeepCopyInto(*out)
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExemptNamespaces != nil {
+		in, out := &in.ExemptNamespaces, &out.ExemptNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	in.ServiceSpec.DeepCopyInto(&out.ServiceSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentSpec.
-func (in *ClusterDeploymentSpec) DeepCopy() *ClusterDeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionWebhooks.
+func (in *AdmissionWebhooks) DeepCopy() *AdmissionWebhooks {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeploymentSpec)
+	out := new(AdmissionWebhooks)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
+func (in *ArgoCDIntegration) DeepCopyInto(out *ArgoCDIntegration) {
 	*out = *in
-	if in.Services != nil {
-		in, out := &in.Services, &out.Services
-		*out = make([]ServiceStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
-	if in.AvailableUpgrades != nil {
-		in, out := &in.AvailableUpgrades, &out.AvailableUpgrades
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentStatus.
-func (in *ClusterDeploymentStatus) DeepCopy() *ClusterDeploymentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDIntegration.
+func (in *ArgoCDIntegration) DeepCopy() *ArgoCDIntegration {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeploymentStatus)
+	out := new(ArgoCDIntegration)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTemplate) DeepCopyInto(out *ClusterTemplate) {
+func (in *AvailableUpgrade) DeepCopyInto(out *AvailableUpgrade) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplate.
-func (in *ClusterTemplate) DeepCopy() *ClusterTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AvailableUpgrade.
+func (in *AvailableUpgrade) DeepCopy() *AvailableUpgrade {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTemplate)
+	out := new(AvailableUpgrade)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterTemplate) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTemplateChain) DeepCopyInto(out *ClusterTemplateChain) {
+func (in *BlockingResource) DeepCopyInto(out *BlockingResource) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Finalizers != nil {
+		in, out := &in.Finalizers, &out.Finalizers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateChain.
-func (in *ClusterTemplateChain) DeepCopy() *ClusterTemplateChain {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlockingResource.
+func (in *BlockingResource) DeepCopy() *BlockingResource {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTemplateChain)
+	out := new(BlockingResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterTemplateChain) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTemplateChainList) DeepCopyInto(out *ClusterTemplateChainList) {
+func (in *CRDUpgradeIssue) DeepCopyInto(out *CRDUpgradeIssue) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterTemplateChain, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateChainList.
-func (in *ClusterTemplateChainList) DeepCopy() *ClusterTemplateChainList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDUpgradeIssue.
+func (in *CRDUpgradeIssue) DeepCopy() *CRDUpgradeIssue {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTemplateChainList)
+	out := new(CRDUpgradeIssue)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterTemplateChainList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTemplateList) DeepCopyInto(out *ClusterTemplateList) {
+func (in *ChartSourceStatus) DeepCopyInto(out *ChartSourceStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterTemplate, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateList.
-func (in *ClusterTemplateList) DeepCopy() *ClusterTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChartSourceStatus.
+func (in *ChartSourceStatus) DeepCopy() *ChartSourceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTemplateList)
+	out := new(ChartSourceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterTemplateList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTemplateSpec) DeepCopyInto(out *ClusterTemplateSpec) {
+func (in *CloudTagPolicy) DeepCopyInto(out *CloudTagPolicy) {
 	*out = *in
-	in.Helm.DeepCopyInto(&out.Helm)
-	if in.ProviderContracts != nil {
-		in, out := &in.ProviderContracts, &out.ProviderContracts
-		*out = make(CompatibilityContracts, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Providers != nil {
-		in, out := &in.Providers, &out.Providers
-		*out = make(Providers, len(*in))
+	if in.MandatoryTags != nil {
+		in, out := &in.MandatoryTags, &out.MandatoryTags
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateSpec.
-func (in *ClusterTemplateSpec) DeepCopy() *ClusterTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudTagPolicy.
+func (in *CloudTagPolicy) DeepCopy() *CloudTagPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTemplateSpec)
+	out := new(CloudTagPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterTemplateStatus) DeepCopyInto(out *ClusterTemplateStatus) {
+func (in *ClusterAuthentication) DeepCopyInto(out *ClusterAuthentication) {
 	*out = *in
-	if in.ProviderContracts != nil {
-		in, out := &in.ProviderContracts, &out.ProviderContracts
-		*out = make(CompatibilityContracts, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Providers != nil {
-		in, out := &in.Providers, &out.Providers
-		*out = make(Providers, len(*in))
-		copy(*out, *in)
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(ClusterOIDC)
+		(*in).DeepCopyInto(*out)
 	}
-	in.TemplateStatusCommon.DeepCopyInto(&out.TemplateStatusCommon)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateStatus.
-func (in *ClusterTemplateStatus) DeepCopy() *ClusterTemplateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAuthentication.
+func (in *ClusterAuthentication) DeepCopy() *ClusterAuthentication {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterTemplateStatus)
+	out := new(ClusterAuthentication)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in CompatibilityContracts) DeepCopyInto(out *CompatibilityContracts) {
-	{
-		in := &in
-		*out = make(CompatibilityContracts, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+func (in *ClusterClassReference) DeepCopyInto(out *ClusterClassReference) {
+	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompatibilityContracts.
-func (in CompatibilityContracts) DeepCopy() CompatibilityContracts {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassReference.
+func (in *ClusterClassReference) DeepCopy() *ClusterClassReference {
 	if in == nil {
 		return nil
 	}
-	out := new(CompatibilityContracts)
+	out := new(ClusterClassReference)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Component) DeepCopyInto(out *Component) {
+func (in *ClusterDNS) DeepCopyInto(out *ClusterDNS) {
 	*out = *in
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = new(apiextensionsv1.JSON)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Component.
-func (in *Component) DeepCopy() *Component {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDNS.
+func (in *ClusterDNS) DeepCopy() *ClusterDNS {
 	if in == nil {
 		return nil
 	}
-	out := new(Component)
+	out := new(ClusterDNS)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+func (in *ClusterDeployment) DeepCopyInto(out *ClusterDeployment) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
-func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeployment.
+func (in *ClusterDeployment) DeepCopy() *ClusterDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDeployment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentList) DeepCopyInto(out *ClusterDeploymentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterDeployment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentList.
+func (in *ClusterDeploymentList) DeepCopy() *ClusterDeploymentList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDeploymentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(ClusterTemplateReference)
+		**out = **in
+	}
+	in.ServiceSpec.DeepCopyInto(&out.ServiceSpec)
+	if in.ControlPlaneVIP != nil {
+		in, out := &in.ControlPlaneVIP, &out.ControlPlaneVIP
+		*out = new(ControlPlaneVIP)
+		**out = **in
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(ClusterDNS)
+		**out = **in
+	}
+	if in.ClusterRoleBindings != nil {
+		in, out := &in.ClusterRoleBindings, &out.ClusterRoleBindings
+		*out = make([]ClusterRoleBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(ClusterAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(ClusterGPU)
+		**out = **in
+	}
+	if in.PropagateLabels != nil {
+		in, out := &in.PropagateLabels, &out.PropagateLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PropagateAnnotations != nil {
+		in, out := &in.PropagateAnnotations, &out.PropagateAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ExpireAt != nil {
+		in, out := &in.ExpireAt, &out.ExpireAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpirationPolicy != nil {
+		in, out := &in.ExpirationPolicy, &out.ExpirationPolicy
+		*out = new(ClusterExpirationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LifecycleHooks != nil {
+		in, out := &in.LifecycleHooks, &out.LifecycleHooks
+		*out = new(ClusterLifecycleHooks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(ClusterRemediation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Observability != nil {
+		in, out := &in.Observability, &out.Observability
+		*out = new(ClusterObservability)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProvisioningPolicy != nil {
+		in, out := &in.ProvisioningPolicy, &out.ProvisioningPolicy
+		*out = new(ProvisioningPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = new(ClusterFailureDomains)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Networks != nil {
+		in, out := &in.Networks, &out.Networks
+		*out = new(ClusterNetworks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(ClusterNetwork)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ClusterProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineAccess != nil {
+		in, out := &in.MachineAccess, &out.MachineAccess
+		*out = new(MachineAccess)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudTags != nil {
+		in, out := &in.CloudTags, &out.CloudTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = new(ClusterReadinessGates)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentSpec.
+func (in *ClusterDeploymentSpec) DeepCopy() *ClusterDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]ServiceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceConflicts != nil {
+		in, out := &in.ServiceConflicts, &out.ServiceConflicts
+		*out = make([]ServiceConflict, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceHistory != nil {
+		in, out := &in.ServiceHistory, &out.ServiceHistory
+		*out = make([]ServiceHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]ClusterEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CertificatesExpireAt != nil {
+		in, out := &in.CertificatesExpireAt, &out.CertificatesExpireAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CostEstimate != nil {
+		in, out := &in.CostEstimate, &out.CostEstimate
+		*out = new(CostEstimate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(RemediationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = new(ProvisioningStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Deletion != nil {
+		in, out := &in.Deletion, &out.Deletion
+		*out = new(DeletionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make([]FailureDomainStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.OSImages != nil {
+		in, out := &in.OSImages, &out.OSImages
+		*out = make([]ResolvedOSImage, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastKnownGood != nil {
+		in, out := &in.LastKnownGood, &out.LastKnownGood
+		*out = new(ClusterRevision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]ReadinessGateStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.SveltosAgents != nil {
+		in, out := &in.SveltosAgents, &out.SveltosAgents
+		*out = make([]SveltosAgentStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timings != nil {
+		in, out := &in.Timings, &out.Timings
+		*out = new(ClusterTimings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneMigration != nil {
+		in, out := &in.ControlPlaneMigration, &out.ControlPlaneMigration
+		*out = new(ControlPlaneMigration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrustedCA != nil {
+		in, out := &in.TrustedCA, &out.TrustedCA
+		*out = new(TrustedCAStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineAccess != nil {
+		in, out := &in.MachineAccess, &out.MachineAccess
+		*out = new(MachineAccessStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AvailableUpgrades != nil {
+		in, out := &in.AvailableUpgrades, &out.AvailableUpgrades
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UpgradeCandidates != nil {
+		in, out := &in.UpgradeCandidates, &out.UpgradeCandidates
+		*out = make([]UpgradeCandidate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentStatus.
+func (in *ClusterDeploymentStatus) DeepCopy() *ClusterDeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterEndpoint) DeepCopyInto(out *ClusterEndpoint) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterEndpoint.
+func (in *ClusterEndpoint) DeepCopy() *ClusterEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterExpirationPolicy) DeepCopyInto(out *ClusterExpirationPolicy) {
+	*out = *in
+	if in.WarningPeriod != nil {
+		in, out := &in.WarningPeriod, &out.WarningPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxExtension != nil {
+		in, out := &in.MaxExtension, &out.MaxExtension
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterExpirationPolicy.
+func (in *ClusterExpirationPolicy) DeepCopy() *ClusterExpirationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterExpirationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterFailureDomains) DeepCopyInto(out *ClusterFailureDomains) {
+	*out = *in
+	if in.ControlPlane != nil {
+		in, out := &in.ControlPlane, &out.ControlPlane
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workers != nil {
+		in, out := &in.Workers, &out.Workers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterFailureDomains.
+func (in *ClusterFailureDomains) DeepCopy() *ClusterFailureDomains {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterFailureDomains)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGPU) DeepCopyInto(out *ClusterGPU) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGPU.
+func (in *ClusterGPU) DeepCopy() *ClusterGPU {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGPU)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterLifecycleHooks) DeepCopyInto(out *ClusterLifecycleHooks) {
+	*out = *in
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLifecycleHooks.
+func (in *ClusterLifecycleHooks) DeepCopy() *ClusterLifecycleHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterLifecycleHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNetwork) DeepCopyInto(out *ClusterNetwork) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetwork.
+func (in *ClusterNetwork) DeepCopy() *ClusterNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNetworks) DeepCopyInto(out *ClusterNetworks) {
+	*out = *in
+	if in.Additional != nil {
+		in, out := &in.Additional, &out.Additional
+		*out = make([]NodeNetwork, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(NodeNetwork)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetworks.
+func (in *ClusterNetworks) DeepCopy() *ClusterNetworks {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNetworks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOIDC) DeepCopyInto(out *ClusterOIDC) {
+	*out = *in
+	out.ClaimMappings = in.ClaimMappings
+	if in.RequiredClaims != nil {
+		in, out := &in.RequiredClaims, &out.RequiredClaims
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOIDC.
+func (in *ClusterOIDC) DeepCopy() *ClusterOIDC {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOIDC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterObservability) DeepCopyInto(out *ClusterObservability) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterObservability.
+func (in *ClusterObservability) DeepCopy() *ClusterObservability {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterObservability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOperationTiming) DeepCopyInto(out *ClusterOperationTiming) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperationTiming.
+func (in *ClusterOperationTiming) DeepCopy() *ClusterOperationTiming {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOperationTiming)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProxy) DeepCopyInto(out *ClusterProxy) {
+	*out = *in
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProxy.
+func (in *ClusterProxy) DeepCopy() *ClusterProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQuota) DeepCopyInto(out *ClusterQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuota.
+func (in *ClusterQuota) DeepCopy() *ClusterQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQuotaList) DeepCopyInto(out *ClusterQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaList.
+func (in *ClusterQuotaList) DeepCopy() *ClusterQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterQuotaSpec) DeepCopyInto(out *ClusterQuotaSpec) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	if in.MaxClusters != nil {
+		in, out := &in.MaxClusters, &out.MaxClusters
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxNodes != nil {
+		in, out := &in.MaxNodes, &out.MaxNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AllowedInstanceSizes != nil {
+		in, out := &in.AllowedInstanceSizes, &out.AllowedInstanceSizes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQuotaSpec.
+func (in *ClusterQuotaSpec) DeepCopy() *ClusterQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterReadinessGates) DeepCopyInto(out *ClusterReadinessGates) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = make([]ReadinessProbe, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReadinessGates.
+func (in *ClusterReadinessGates) DeepCopy() *ClusterReadinessGates {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterReadinessGates)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRemediation) DeepCopyInto(out *ClusterRemediation) {
+	*out = *in
+	if in.MaxInFlight != nil {
+		in, out := &in.MaxInFlight, &out.MaxInFlight
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRemediation.
+func (in *ClusterRemediation) DeepCopy() *ClusterRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRequest) DeepCopyInto(out *ClusterRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRequest.
+func (in *ClusterRequest) DeepCopy() *ClusterRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRequestApproval) DeepCopyInto(out *ClusterRequestApproval) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRequestApproval.
+func (in *ClusterRequestApproval) DeepCopy() *ClusterRequestApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRequestApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRequestList) DeepCopyInto(out *ClusterRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRequestList.
+func (in *ClusterRequestList) DeepCopy() *ClusterRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRequestSize) DeepCopyInto(out *ClusterRequestSize) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRequestSize.
+func (in *ClusterRequestSize) DeepCopy() *ClusterRequestSize {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRequestSize)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRequestSpec) DeepCopyInto(out *ClusterRequestSpec) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(ClusterRequestSize)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRequestSpec.
+func (in *ClusterRequestSpec) DeepCopy() *ClusterRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRequestStatus) DeepCopyInto(out *ClusterRequestStatus) {
+	*out = *in
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(ClusterRequestApproval)
+		**out = **in
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRequestStatus.
+func (in *ClusterRequestStatus) DeepCopy() *ClusterRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRevision) DeepCopyInto(out *ClusterRevision) {
+	*out = *in
+	in.RecordedAt.DeepCopyInto(&out.RecordedAt)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRevision.
+func (in *ClusterRevision) DeepCopy() *ClusterRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRoleBinding) DeepCopyInto(out *ClusterRoleBinding) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRoleBinding.
+func (in *ClusterRoleBinding) DeepCopy() *ClusterRoleBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRoleBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplate) DeepCopyInto(out *ClusterTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplate.
+func (in *ClusterTemplate) DeepCopy() *ClusterTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateChain) DeepCopyInto(out *ClusterTemplateChain) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateChain.
+func (in *ClusterTemplateChain) DeepCopy() *ClusterTemplateChain {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateChain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTemplateChain) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateChainList) DeepCopyInto(out *ClusterTemplateChainList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterTemplateChain, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateChainList.
+func (in *ClusterTemplateChainList) DeepCopy() *ClusterTemplateChainList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateChainList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTemplateChainList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateGrant) DeepCopyInto(out *ClusterTemplateGrant) {
+	*out = *in
+	if in.ClusterTemplates != nil {
+		in, out := &in.ClusterTemplates, &out.ClusterTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateGrant.
+func (in *ClusterTemplateGrant) DeepCopy() *ClusterTemplateGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateList) DeepCopyInto(out *ClusterTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateList.
+func (in *ClusterTemplateList) DeepCopy() *ClusterTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateReference) DeepCopyInto(out *ClusterTemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateReference.
+func (in *ClusterTemplateReference) DeepCopy() *ClusterTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateSpec) DeepCopyInto(out *ClusterTemplateSpec) {
+	*out = *in
+	in.Helm.DeepCopyInto(&out.Helm)
+	if in.ProviderContracts != nil {
+		in, out := &in.ProviderContracts, &out.ProviderContracts
+		*out = make(CompatibilityContracts, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make(Providers, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterClass != nil {
+		in, out := &in.ClusterClass, &out.ClusterClass
+		*out = new(ClusterClassReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateSpec.
+func (in *ClusterTemplateSpec) DeepCopy() *ClusterTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTemplateStatus) DeepCopyInto(out *ClusterTemplateStatus) {
+	*out = *in
+	if in.ProviderContracts != nil {
+		in, out := &in.ProviderContracts, &out.ProviderContracts
+		*out = make(CompatibilityContracts, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make(Providers, len(*in))
+		copy(*out, *in)
+	}
+	if in.SupportedCNIs != nil {
+		in, out := &in.SupportedCNIs, &out.SupportedCNIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigSchema != nil {
+		in, out := &in.ConfigSchema, &out.ConfigSchema
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	in.TemplateStatusCommon.DeepCopyInto(&out.TemplateStatusCommon)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTemplateStatus.
+func (in *ClusterTemplateStatus) DeepCopy() *ClusterTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTimings) DeepCopyInto(out *ClusterTimings) {
+	*out = *in
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = new(ClusterOperationTiming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastUpgrade != nil {
+		in, out := &in.LastUpgrade, &out.LastUpgrade
+		*out = new(ClusterOperationTiming)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTimings.
+func (in *ClusterTimings) DeepCopy() *ClusterTimings {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTimings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in CompatibilityContracts) DeepCopyInto(out *CompatibilityContracts) {
+	{
+		in := &in
+		*out = make(CompatibilityContracts, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompatibilityContracts.
+func (in CompatibilityContracts) DeepCopy() CompatibilityContracts {
+	if in == nil {
+		return nil
+	}
+	out := new(CompatibilityContracts)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Component) DeepCopyInto(out *Component) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(apiextensionsv1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tuning != nil {
+		in, out := &in.Tuning, &out.Tuning
+		*out = new(ComponentTuning)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Component.
+func (in *Component) DeepCopy() *Component {
+	if in == nil {
+		return nil
+	}
+	out := new(Component)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentKubernetesVersions) DeepCopyInto(out *ComponentKubernetesVersions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentKubernetesVersions.
+func (in *ComponentKubernetesVersions) DeepCopy() *ComponentKubernetesVersions {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentKubernetesVersions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentTuning) DeepCopyInto(out *ComponentTuning) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentTuning.
+func (in *ComponentTuning) DeepCopy() *ComponentTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigOverlay) DeepCopyInto(out *ConfigOverlay) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	in.Values.DeepCopyInto(&out.Values)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigOverlay.
+func (in *ConfigOverlay) DeepCopy() *ConfigOverlay {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigOverlay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigPolicy) DeepCopyInto(out *ConfigPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigPolicy.
+func (in *ConfigPolicy) DeepCopy() *ConfigPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConfigPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigPolicyList) DeepCopyInto(out *ConfigPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConfigPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigPolicyList.
+func (in *ConfigPolicyList) DeepCopy() *ConfigPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConfigPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigPolicyRule) DeepCopyInto(out *ConfigPolicyRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigPolicyRule.
+func (in *ConfigPolicyRule) DeepCopy() *ConfigPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigPolicySpec) DeepCopyInto(out *ConfigPolicySpec) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ConfigPolicyRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Overlays != nil {
+		in, out := &in.Overlays, &out.Overlays
+		*out = make([]ConfigOverlay, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigPolicySpec.
+func (in *ConfigPolicySpec) DeepCopy() *ConfigPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneMigration) DeepCopyInto(out *ControlPlaneMigration) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Missing != nil {
+		in, out := &in.Missing, &out.Missing
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneMigration.
+func (in *ControlPlaneMigration) DeepCopy() *ControlPlaneMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneVIP) DeepCopyInto(out *ControlPlaneVIP) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneVIP.
+func (in *ControlPlaneVIP) DeepCopy() *ControlPlaneVIP {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneVIP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Core) DeepCopyInto(out *Core) {
+	*out = *in
+	in.KCM.DeepCopyInto(&out.KCM)
+	in.CAPI.DeepCopyInto(&out.CAPI)
+	if in.Velero != nil {
+		in, out := &in.Velero, &out.Velero
+		*out = new(ComponentTuning)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Core.
+func (in *Core) DeepCopy() *Core {
+	if in == nil {
+		return nil
+	}
+	out := new(Core)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoreProviderTemplate) DeepCopyInto(out *CoreProviderTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreProviderTemplate.
+func (in *CoreProviderTemplate) DeepCopy() *CoreProviderTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(CoreProviderTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CostEstimate) DeepCopyInto(out *CostEstimate) {
+	*out = *in
+	if in.UnpricedInstanceTypes != nil {
+		in, out := &in.UnpricedInstanceTypes, &out.UnpricedInstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CostEstimate.
+func (in *CostEstimate) DeepCopy() *CostEstimate {
+	if in == nil {
+		return nil
+	}
+	out := new(CostEstimate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Credential) DeepCopyInto(out *Credential) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Credential.
+func (in *Credential) DeepCopy() *Credential {
+	if in == nil {
+		return nil
+	}
+	out := new(Credential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Credential) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialList) DeepCopyInto(out *CredentialList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Credential, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialList.
+func (in *CredentialList) DeepCopy() *CredentialList {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CredentialList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialSpec) DeepCopyInto(out *CredentialSpec) {
+	*out = *in
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(corev1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialSpec.
+func (in *CredentialSpec) DeepCopy() *CredentialSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialStatus) DeepCopyInto(out *CredentialStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialStatus.
+func (in *CredentialStatus) DeepCopy() *CredentialStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionStatus) DeepCopyInto(out *DeletionStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.Finalizers != nil {
+		in, out := &in.Finalizers, &out.Finalizers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlockingResources != nil {
+		in, out := &in.BlockingResources, &out.BlockingResources
+		*out = make([]BlockingResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionStatus.
+func (in *DeletionStatus) DeepCopy() *DeletionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddedBucketSpec) DeepCopyInto(out *EmbeddedBucketSpec) {
+	*out = *in
+	in.BucketSpec.DeepCopyInto(&out.BucketSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedBucketSpec.
+func (in *EmbeddedBucketSpec) DeepCopy() *EmbeddedBucketSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddedBucketSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddedGitRepositorySpec) DeepCopyInto(out *EmbeddedGitRepositorySpec) {
+	*out = *in
+	in.GitRepositorySpec.DeepCopyInto(&out.GitRepositorySpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedGitRepositorySpec.
+func (in *EmbeddedGitRepositorySpec) DeepCopy() *EmbeddedGitRepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddedGitRepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddedOCIRepositorySpec) DeepCopyInto(out *EmbeddedOCIRepositorySpec) {
+	*out = *in
+	in.OCIRepositorySpec.DeepCopyInto(&out.OCIRepositorySpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedOCIRepositorySpec.
+func (in *EmbeddedOCIRepositorySpec) DeepCopy() *EmbeddedOCIRepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddedOCIRepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureDomainStatus) DeepCopyInto(out *FailureDomainStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureDomainStatus.
+func (in *FailureDomainStatus) DeepCopy() *FailureDomainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureDomainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetClusterSummary) DeepCopyInto(out *FleetClusterSummary) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AvailableUpgrades != nil {
+		in, out := &in.AvailableUpgrades, &out.AvailableUpgrades
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]FleetServiceSummary, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetClusterSummary.
+func (in *FleetClusterSummary) DeepCopy() *FleetClusterSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetClusterSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetServiceSummary) DeepCopyInto(out *FleetServiceSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetServiceSummary.
+func (in *FleetServiceSummary) DeepCopy() *FleetServiceSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetServiceSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetSummary) DeepCopyInto(out *FleetSummary) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetSummary.
+func (in *FleetSummary) DeepCopy() *FleetSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FleetSummary) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetSummaryList) DeepCopyInto(out *FleetSummaryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FleetSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetSummaryList.
+func (in *FleetSummaryList) DeepCopy() *FleetSummaryList {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetSummaryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FleetSummaryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetSummarySpec) DeepCopyInto(out *FleetSummarySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetSummarySpec.
+func (in *FleetSummarySpec) DeepCopy() *FleetSummarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetSummarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetSummaryStatus) DeepCopyInto(out *FleetSummaryStatus) {
+	*out = *in
+	if in.ByProvider != nil {
+		in, out := &in.ByProvider, &out.ByProvider
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ByTemplate != nil {
+		in, out := &in.ByTemplate, &out.ByTemplate
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ByKubernetesVersion != nil {
+		in, out := &in.ByKubernetesVersion, &out.ByKubernetesVersion
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ByPhase != nil {
+		in, out := &in.ByPhase, &out.ByPhase
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ByRegion != nil {
+		in, out := &in.ByRegion, &out.ByRegion
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]FleetClusterSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetSummaryStatus.
+func (in *FleetSummaryStatus) DeepCopy() *FleetSummaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetSummaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsExport) DeepCopyInto(out *GitOpsExport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsExport.
+func (in *GitOpsExport) DeepCopy() *GitOpsExport {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitOpsExport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsExportList) DeepCopyInto(out *GitOpsExportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GitOpsExport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsExportList.
+func (in *GitOpsExportList) DeepCopy() *GitOpsExportList {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsExportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitOpsExportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsExportSpec) DeepCopyInto(out *GitOpsExportSpec) {
+	*out = *in
+	in.OCI.DeepCopyInto(&out.OCI)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsExportSpec.
+func (in *GitOpsExportSpec) DeepCopy() *GitOpsExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsExportStatus) DeepCopyInto(out *GitOpsExportStatus) {
+	*out = *in
+	if in.LastExportTime != nil {
+		in, out := &in.LastExportTime, &out.LastExportTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsExportStatus.
+func (in *GitOpsExportStatus) DeepCopy() *GitOpsExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPReadinessProbe) DeepCopyInto(out *HTTPReadinessProbe) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPReadinessProbe.
+func (in *HTTPReadinessProbe) DeepCopy() *HTTPReadinessProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPReadinessProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmSpec) DeepCopyInto(out *HelmSpec) {
+	*out = *in
+	if in.ChartSpec != nil {
+		in, out := &in.ChartSpec, &out.ChartSpec
+		*out = new(apiv1.HelmChartSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ChartRef != nil {
+		in, out := &in.ChartRef, &out.ChartRef
+		*out = new(v2.CrossNamespaceSourceReference)
+		**out = **in
+	}
+	if in.RegistryAuth != nil {
+		in, out := &in.RegistryAuth, &out.RegistryAuth
+		*out = new(RegistryAuth)
+		**out = **in
+	}
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]RegistryAuth, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmSpec.
+func (in *HelmSpec) DeepCopy() *HelmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationStatus) DeepCopyInto(out *IntegrationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationStatus.
+func (in *IntegrationStatus) DeepCopy() *IntegrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobReadinessProbe) DeepCopyInto(out *JobReadinessProbe) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobReadinessProbe.
+func (in *JobReadinessProbe) DeepCopy() *JobReadinessProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(JobReadinessProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesVersionSupport) DeepCopyInto(out *KubernetesVersionSupport) {
+	*out = *in
+	in.EndOfLife.DeepCopyInto(&out.EndOfLife)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesVersionSupport.
+func (in *KubernetesVersionSupport) DeepCopy() *KubernetesVersionSupport {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesVersionSupport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalSourceRef) DeepCopyInto(out *LocalSourceRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalSourceRef.
+func (in *LocalSourceRef) DeepCopy() *LocalSourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalSourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineAccess) DeepCopyInto(out *MachineAccess) {
+	*out = *in
+	if in.AuthorizedKeys != nil {
+		in, out := &in.AuthorizedKeys, &out.AuthorizedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bastion != nil {
+		in, out := &in.Bastion, &out.Bastion
+		*out = new(MachineBastion)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineAccess.
+func (in *MachineAccess) DeepCopy() *MachineAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineAccessStatus) DeepCopyInto(out *MachineAccessStatus) {
+	*out = *in
+	if in.LastRolloutTime != nil {
+		in, out := &in.LastRolloutTime, &out.LastRolloutTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineAccessStatus.
+func (in *MachineAccessStatus) DeepCopy() *MachineAccessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineAccessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineBastion) DeepCopyInto(out *MachineBastion) {
+	*out = *in
+	if in.AllowedCIDRBlocks != nil {
+		in, out := &in.AllowedCIDRBlocks, &out.AllowedCIDRBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineBastion.
+func (in *MachineBastion) DeepCopy() *MachineBastion {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineBastion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineRemediation) DeepCopyInto(out *MachineRemediation) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineRemediation.
+func (in *MachineRemediation) DeepCopy() *MachineRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Management) DeepCopyInto(out *Management) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Management.
+func (in *Management) DeepCopy() *Management {
+	if in == nil {
+		return nil
+	}
+	out := new(Management)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Management) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementBackup) DeepCopyInto(out *ManagementBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackup.
+func (in *ManagementBackup) DeepCopy() *ManagementBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManagementBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementBackupList) DeepCopyInto(out *ManagementBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ManagementBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackupList.
+func (in *ManagementBackupList) DeepCopy() *ManagementBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManagementBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementBackupSpec) DeepCopyInto(out *ManagementBackupSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackupSpec.
+func (in *ManagementBackupSpec) DeepCopy() *ManagementBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementBackupStatus) DeepCopyInto(out *ManagementBackupStatus) {
+	*out = *in
+	if in.NextAttempt != nil {
+		in, out := &in.NextAttempt, &out.NextAttempt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastBackupTime != nil {
+		in, out := &in.LastBackupTime, &out.LastBackupTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastBackup != nil {
+		in, out := &in.LastBackup, &out.LastBackup
+		*out = new(velerov1.BackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackupStatus.
+func (in *ManagementBackupStatus) DeepCopy() *ManagementBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementList) DeepCopyInto(out *ManagementList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Management, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementList.
+func (in *ManagementList) DeepCopy() *ManagementList {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ManagementList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementSpec) DeepCopyInto(out *ManagementSpec) {
+	*out = *in
+	if in.Core != nil {
+		in, out := &in.Core, &out.Core
+		*out = new(Core)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]Provider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityBaseline != nil {
+		in, out := &in.SecurityBaseline, &out.SecurityBaseline
+		*out = new(SecurityBaseline)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ArgoCD != nil {
+		in, out := &in.ArgoCD, &out.ArgoCD
+		*out = new(ArgoCDIntegration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(Monitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Observability != nil {
+		in, out := &in.Observability, &out.Observability
+		*out = new(Observability)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudTagPolicy != nil {
+		in, out := &in.CloudTagPolicy, &out.CloudTagPolicy
+		*out = new(CloudTagPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Telemetry != nil {
+		in, out := &in.Telemetry, &out.Telemetry
+		*out = new(Telemetry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegistryCredentials != nil {
+		in, out := &in.RegistryCredentials, &out.RegistryCredentials
+		*out = new(RegistryCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrustedCA != nil {
+		in, out := &in.TrustedCA, &out.TrustedCA
+		*out = new(TrustedCA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdmissionWebhooks != nil {
+		in, out := &in.AdmissionWebhooks, &out.AdmissionWebhooks
+		*out = new(AdmissionWebhooks)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementSpec.
+func (in *ManagementSpec) DeepCopy() *ManagementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagementStatus) DeepCopyInto(out *ManagementStatus) {
+	*out = *in
+	if in.CAPIContracts != nil {
+		in, out := &in.CAPIContracts, &out.CAPIContracts
+		*out = make(map[string]CompatibilityContracts, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(CompatibilityContracts, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make(map[string]ComponentStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Integrations != nil {
+		in, out := &in.Integrations, &out.Integrations
+		*out = make(map[string]IntegrationStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AvailableProviders != nil {
+		in, out := &in.AvailableProviders, &out.AvailableProviders
+		*out = make(Providers, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementStatus.
+func (in *ManagementStatus) DeepCopy() *ManagementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.ClusterNotReadyFor = in.ClusterNotReadyFor
+	out.BackupMaxAge = in.BackupMaxAge
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
+func (in *Monitoring) DeepCopy() *Monitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Monitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiClusterService) DeepCopyInto(out *MultiClusterService) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterService.
+func (in *MultiClusterService) DeepCopy() *MultiClusterService {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiClusterService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultiClusterService) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiClusterServiceList) DeepCopyInto(out *MultiClusterServiceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MultiClusterService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterServiceList.
+func (in *MultiClusterServiceList) DeepCopy() *MultiClusterServiceList {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiClusterServiceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MultiClusterServiceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiClusterServiceSpec) DeepCopyInto(out *MultiClusterServiceSpec) {
+	*out = *in
+	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+	in.ServiceSpec.DeepCopyInto(&out.ServiceSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterServiceSpec.
+func (in *MultiClusterServiceSpec) DeepCopy() *MultiClusterServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiClusterServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiClusterServiceStatus) DeepCopyInto(out *MultiClusterServiceStatus) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]ServiceStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceConflicts != nil {
+		in, out := &in.ServiceConflicts, &out.ServiceConflicts
+		*out = make([]ServiceConflict, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceHistory != nil {
+		in, out := &in.ServiceHistory, &out.ServiceHistory
+		*out = make([]ServiceHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterServiceStatus.
+func (in *MultiClusterServiceStatus) DeepCopy() *MultiClusterServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiClusterServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamedProviderTemplate) DeepCopyInto(out *NamedProviderTemplate) {
+	*out = *in
+	out.CoreProviderTemplate = in.CoreProviderTemplate
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedProviderTemplate.
+func (in *NamedProviderTemplate) DeepCopy() *NamedProviderTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(NamedProviderTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceAccessChanges) DeepCopyInto(out *NamespaceAccessChanges) {
+	*out = *in
+	if in.Distribute != nil {
+		in, out := &in.Distribute, &out.Distribute
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Prune != nil {
+		in, out := &in.Prune, &out.Prune
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceAccessChanges.
+func (in *NamespaceAccessChanges) DeepCopy() *NamespaceAccessChanges {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceAccessChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceAccessDelivery) DeepCopyInto(out *NamespaceAccessDelivery) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]AccessDeliveryResult, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceAccessDelivery.
+func (in *NamespaceAccessDelivery) DeepCopy() *NamespaceAccessDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceAccessDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeImageRollout) DeepCopyInto(out *NodeImageRollout) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeImageRollout.
+func (in *NodeImageRollout) DeepCopy() *NodeImageRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeImageRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeImageRollout) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeImageRolloutClusterStatus) DeepCopyInto(out *NodeImageRolloutClusterStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeImageRolloutClusterStatus.
+func (in *NodeImageRolloutClusterStatus) DeepCopy() *NodeImageRolloutClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeImageRolloutClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeImageRolloutList) DeepCopyInto(out *NodeImageRolloutList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeImageRollout, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeImageRolloutList.
+func (in *NodeImageRolloutList) DeepCopy() *NodeImageRolloutList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeImageRolloutList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeImageRolloutList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeImageRolloutSpec) DeepCopyInto(out *NodeImageRolloutSpec) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeImageRolloutSpec.
+func (in *NodeImageRolloutSpec) DeepCopy() *NodeImageRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeImageRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeImageRolloutStatus) DeepCopyInto(out *NodeImageRolloutStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]NodeImageRolloutClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeImageRolloutStatus.
+func (in *NodeImageRolloutStatus) DeepCopy() *NodeImageRolloutStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ComponentStatus)
+	out := new(NodeImageRolloutStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Core) DeepCopyInto(out *Core) {
+func (in *NodeNetwork) DeepCopyInto(out *NodeNetwork) {
 	*out = *in
-	in.KCM.DeepCopyInto(&out.KCM)
-	in.CAPI.DeepCopyInto(&out.CAPI)
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Core.
-func (in *Core) DeepCopy() *Core {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeNetwork.
+func (in *NodeNetwork) DeepCopy() *NodeNetwork {
 	if in == nil {
 		return nil
 	}
-	out := new(Core)
+	out := new(NodeNetwork)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CoreProviderTemplate) DeepCopyInto(out *CoreProviderTemplate) {
+func (in *OCIExportTarget) DeepCopyInto(out *OCIExportTarget) {
 	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreProviderTemplate.
-func (in *CoreProviderTemplate) DeepCopy() *CoreProviderTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIExportTarget.
+func (in *OCIExportTarget) DeepCopy() *OCIExportTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(CoreProviderTemplate)
+	out := new(OCIExportTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Credential) DeepCopyInto(out *Credential) {
+func (in *OIDCClaimMappings) DeepCopyInto(out *OIDCClaimMappings) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Credential.
-func (in *Credential) DeepCopy() *Credential {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCClaimMappings.
+func (in *OIDCClaimMappings) DeepCopy() *OIDCClaimMappings {
 	if in == nil {
 		return nil
 	}
-	out := new(Credential)
+	out := new(OIDCClaimMappings)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Credential) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CredentialList) DeepCopyInto(out *CredentialList) {
+func (in *OSImage) DeepCopyInto(out *OSImage) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Credential, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialList.
-func (in *CredentialList) DeepCopy() *CredentialList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSImage.
+func (in *OSImage) DeepCopy() *OSImage {
 	if in == nil {
 		return nil
 	}
-	out := new(CredentialList)
+	out := new(OSImage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CredentialList) DeepCopyObject() runtime.Object {
+func (in *OSImage) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -615,137 +3335,157 @@ func (in *CredentialList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CredentialSpec) DeepCopyInto(out *CredentialSpec) {
+func (in *OSImageDiscovery) DeepCopyInto(out *OSImageDiscovery) {
 	*out = *in
-	if in.IdentityRef != nil {
-		in, out := &in.IdentityRef, &out.IdentityRef
-		*out = new(corev1.ObjectReference)
-		**out = **in
-	}
+	out.Interval = in.Interval
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialSpec.
-func (in *CredentialSpec) DeepCopy() *CredentialSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSImageDiscovery.
+func (in *OSImageDiscovery) DeepCopy() *OSImageDiscovery {
 	if in == nil {
 		return nil
 	}
-	out := new(CredentialSpec)
+	out := new(OSImageDiscovery)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CredentialStatus) DeepCopyInto(out *CredentialStatus) {
+func (in *OSImageEntry) DeepCopyInto(out *OSImageEntry) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialStatus.
-func (in *CredentialStatus) DeepCopy() *CredentialStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSImageEntry.
+func (in *OSImageEntry) DeepCopy() *OSImageEntry {
 	if in == nil {
 		return nil
 	}
-	out := new(CredentialStatus)
+	out := new(OSImageEntry)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EmbeddedBucketSpec) DeepCopyInto(out *EmbeddedBucketSpec) {
+func (in *OSImageList) DeepCopyInto(out *OSImageList) {
 	*out = *in
-	in.BucketSpec.DeepCopyInto(&out.BucketSpec)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OSImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedBucketSpec.
-func (in *EmbeddedBucketSpec) DeepCopy() *EmbeddedBucketSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSImageList.
+func (in *OSImageList) DeepCopy() *OSImageList {
 	if in == nil {
 		return nil
 	}
-	out := new(EmbeddedBucketSpec)
+	out := new(OSImageList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OSImageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EmbeddedGitRepositorySpec) DeepCopyInto(out *EmbeddedGitRepositorySpec) {
+func (in *OSImageSpec) DeepCopyInto(out *OSImageSpec) {
 	*out = *in
-	in.GitRepositorySpec.DeepCopyInto(&out.GitRepositorySpec)
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]OSImageEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.Discovery != nil {
+		in, out := &in.Discovery, &out.Discovery
+		*out = new(OSImageDiscovery)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedGitRepositorySpec.
-func (in *EmbeddedGitRepositorySpec) DeepCopy() *EmbeddedGitRepositorySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSImageSpec.
+func (in *OSImageSpec) DeepCopy() *OSImageSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(EmbeddedGitRepositorySpec)
+	out := new(OSImageSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EmbeddedOCIRepositorySpec) DeepCopyInto(out *EmbeddedOCIRepositorySpec) {
+func (in *OSImageStatus) DeepCopyInto(out *OSImageStatus) {
 	*out = *in
-	in.OCIRepositorySpec.DeepCopyInto(&out.OCIRepositorySpec)
+	if in.LastDiscoveryTime != nil {
+		in, out := &in.LastDiscoveryTime, &out.LastDiscoveryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]OSImageEntry, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedOCIRepositorySpec.
-func (in *EmbeddedOCIRepositorySpec) DeepCopy() *EmbeddedOCIRepositorySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSImageStatus.
+func (in *OSImageStatus) DeepCopy() *OSImageStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(EmbeddedOCIRepositorySpec)
+	out := new(OSImageStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HelmSpec) DeepCopyInto(out *HelmSpec) {
+func (in *Observability) DeepCopyInto(out *Observability) {
 	*out = *in
-	if in.ChartSpec != nil {
-		in, out := &in.ChartSpec, &out.ChartSpec
-		*out = new(apiv1.HelmChartSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ChartRef != nil {
-		in, out := &in.ChartRef, &out.ChartRef
-		*out = new(v2.CrossNamespaceSourceReference)
-		**out = **in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmSpec.
-func (in *HelmSpec) DeepCopy() *HelmSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Observability.
+func (in *Observability) DeepCopy() *Observability {
 	if in == nil {
 		return nil
 	}
-	out := new(HelmSpec)
+	out := new(Observability)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalSourceRef) DeepCopyInto(out *LocalSourceRef) {
+func (in *Provider) DeepCopyInto(out *Provider) {
 	*out = *in
+	in.Component.DeepCopyInto(&out.Component)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalSourceRef.
-func (in *LocalSourceRef) DeepCopy() *LocalSourceRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provider.
+func (in *Provider) DeepCopy() *Provider {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalSourceRef)
+	out := new(Provider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Management) DeepCopyInto(out *Management) {
+func (in *ProviderTemplate) DeepCopyInto(out *ProviderTemplate) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -753,18 +3493,18 @@ func (in *Management) DeepCopyInto(out *Management) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Management.
-func (in *Management) DeepCopy() *Management {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderTemplate.
+func (in *ProviderTemplate) DeepCopy() *ProviderTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(Management)
+	out := new(ProviderTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Management) DeepCopyObject() runtime.Object {
+func (in *ProviderTemplate) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -772,26 +3512,31 @@ func (in *Management) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementBackup) DeepCopyInto(out *ManagementBackup) {
+func (in *ProviderTemplateList) DeepCopyInto(out *ProviderTemplateList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProviderTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackup.
-func (in *ManagementBackup) DeepCopy() *ManagementBackup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderTemplateList.
+func (in *ProviderTemplateList) DeepCopy() *ProviderTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementBackup)
+	out := new(ProviderTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ManagementBackup) DeepCopyObject() runtime.Object {
+func (in *ProviderTemplateList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -799,193 +3544,215 @@ func (in *ManagementBackup) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementBackupList) DeepCopyInto(out *ManagementBackupList) {
+func (in *ProviderTemplateSpec) DeepCopyInto(out *ProviderTemplateSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ManagementBackup, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	in.Helm.DeepCopyInto(&out.Helm)
+	if in.CAPIContracts != nil {
+		in, out := &in.CAPIContracts, &out.CAPIContracts
+		*out = make(CompatibilityContracts, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make(Providers, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackupList.
-func (in *ManagementBackupList) DeepCopy() *ManagementBackupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderTemplateSpec.
+func (in *ProviderTemplateSpec) DeepCopy() *ProviderTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementBackupList)
+	out := new(ProviderTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ManagementBackupList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderTemplateStatus) DeepCopyInto(out *ProviderTemplateStatus) {
+	*out = *in
+	if in.CAPIContracts != nil {
+		in, out := &in.CAPIContracts, &out.CAPIContracts
+		*out = make(CompatibilityContracts, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	return nil
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make(Providers, len(*in))
+		copy(*out, *in)
+	}
+	if in.CRDUpgradeIssues != nil {
+		in, out := &in.CRDUpgradeIssues, &out.CRDUpgradeIssues
+		*out = make([]CRDUpgradeIssue, len(*in))
+		copy(*out, *in)
+	}
+	in.TemplateStatusCommon.DeepCopyInto(&out.TemplateStatusCommon)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderTemplateStatus.
+func (in *ProviderTemplateStatus) DeepCopy() *ProviderTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementBackupSpec) DeepCopyInto(out *ManagementBackupSpec) {
+func (in Providers) DeepCopyInto(out *Providers) {
+	{
+		in := &in
+		*out = make(Providers, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Providers.
+func (in Providers) DeepCopy() Providers {
+	if in == nil {
+		return nil
+	}
+	out := new(Providers)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisioningPolicy) DeepCopyInto(out *ProvisioningPolicy) {
 	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackupSpec.
-func (in *ManagementBackupSpec) DeepCopy() *ManagementBackupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningPolicy.
+func (in *ProvisioningPolicy) DeepCopy() *ProvisioningPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementBackupSpec)
+	out := new(ProvisioningPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementBackupStatus) DeepCopyInto(out *ManagementBackupStatus) {
+func (in *ProvisioningStatus) DeepCopyInto(out *ProvisioningStatus) {
 	*out = *in
-	if in.NextAttempt != nil {
-		in, out := &in.NextAttempt, &out.NextAttempt
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
 		*out = (*in).DeepCopy()
 	}
-	if in.LastBackupTime != nil {
-		in, out := &in.LastBackupTime, &out.LastBackupTime
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
 		*out = (*in).DeepCopy()
 	}
-	if in.LastBackup != nil {
-		in, out := &in.LastBackup, &out.LastBackup
-		*out = new(velerov1.BackupStatus)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementBackupStatus.
-func (in *ManagementBackupStatus) DeepCopy() *ManagementBackupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningStatus.
+func (in *ProvisioningStatus) DeepCopy() *ProvisioningStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementBackupStatus)
+	out := new(ProvisioningStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementList) DeepCopyInto(out *ManagementList) {
+func (in *ReadinessGateStatus) DeepCopyInto(out *ReadinessGateStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Management, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementList.
-func (in *ManagementList) DeepCopy() *ManagementList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessGateStatus.
+func (in *ReadinessGateStatus) DeepCopy() *ReadinessGateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementList)
+	out := new(ReadinessGateStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ManagementList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementSpec) DeepCopyInto(out *ManagementSpec) {
+func (in *ReadinessProbe) DeepCopyInto(out *ReadinessProbe) {
 	*out = *in
-	if in.Core != nil {
-		in, out := &in.Core, &out.Core
-		*out = new(Core)
-		(*in).DeepCopyInto(*out)
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPReadinessProbe)
+		**out = **in
 	}
-	if in.Providers != nil {
-		in, out := &in.Providers, &out.Providers
-		*out = make([]Provider, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(JobReadinessProbe)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementSpec.
-func (in *ManagementSpec) DeepCopy() *ManagementSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessProbe.
+func (in *ReadinessProbe) DeepCopy() *ReadinessProbe {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementSpec)
+	out := new(ReadinessProbe)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ManagementStatus) DeepCopyInto(out *ManagementStatus) {
+func (in *RegistryAuth) DeepCopyInto(out *RegistryAuth) {
 	*out = *in
-	if in.CAPIContracts != nil {
-		in, out := &in.CAPIContracts, &out.CAPIContracts
-		*out = make(map[string]CompatibilityContracts, len(*in))
-		for key, val := range *in {
-			var outVal map[string]string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				inVal := (*in)[key]
-				in, out := &inVal, &outVal
-				*out = make(CompatibilityContracts, len(*in))
-				for key, val := range *in {
-					(*out)[key] = val
-				}
-			}
-			(*out)[key] = outVal
-		}
-	}
-	if in.Components != nil {
-		in, out := &in.Components, &out.Components
-		*out = make(map[string]ComponentStatus, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryAuth.
+func (in *RegistryAuth) DeepCopy() *RegistryAuth {
+	if in == nil {
+		return nil
 	}
-	if in.AvailableProviders != nil {
-		in, out := &in.AvailableProviders, &out.AvailableProviders
-		*out = make(Providers, len(*in))
+	out := new(RegistryAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryCredentials) DeepCopyInto(out *RegistryCredentials) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagementStatus.
-func (in *ManagementStatus) DeepCopy() *ManagementStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryCredentials.
+func (in *RegistryCredentials) DeepCopy() *RegistryCredentials {
 	if in == nil {
 		return nil
 	}
-	out := new(ManagementStatus)
+	out := new(RegistryCredentials)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultiClusterService) DeepCopyInto(out *MultiClusterService) {
+func (in *Release) DeepCopyInto(out *Release) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -993,18 +3760,18 @@ func (in *MultiClusterService) DeepCopyInto(out *MultiClusterService) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterService.
-func (in *MultiClusterService) DeepCopy() *MultiClusterService {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Release.
+func (in *Release) DeepCopy() *Release {
 	if in == nil {
 		return nil
 	}
-	out := new(MultiClusterService)
+	out := new(Release)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MultiClusterService) DeepCopyObject() runtime.Object {
+func (in *Release) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1012,31 +3779,31 @@ func (in *MultiClusterService) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultiClusterServiceList) DeepCopyInto(out *MultiClusterServiceList) {
+func (in *ReleaseList) DeepCopyInto(out *ReleaseList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]MultiClusterService, len(*in))
+		*out = make([]Release, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterServiceList.
-func (in *MultiClusterServiceList) DeepCopy() *MultiClusterServiceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReleaseList.
+func (in *ReleaseList) DeepCopy() *ReleaseList {
 	if in == nil {
 		return nil
 	}
-	out := new(MultiClusterServiceList)
+	out := new(ReleaseList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MultiClusterServiceList) DeepCopyObject() runtime.Object {
+func (in *ReleaseList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1044,85 +3811,137 @@ func (in *MultiClusterServiceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultiClusterServiceSpec) DeepCopyInto(out *MultiClusterServiceSpec) {
+func (in *ReleaseSpec) DeepCopyInto(out *ReleaseSpec) {
 	*out = *in
-	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
-	in.ServiceSpec.DeepCopyInto(&out.ServiceSpec)
+	out.KCM = in.KCM
+	out.CAPI = in.CAPI
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]NamedProviderTemplate, len(*in))
+		copy(*out, *in)
+	}
+	if in.AddonVersions != nil {
+		in, out := &in.AddonVersions, &out.AddonVersions
+		*out = make([]AddonVersions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KubernetesVersions != nil {
+		in, out := &in.KubernetesVersions, &out.KubernetesVersions
+		*out = make([]KubernetesVersionSupport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KubernetesVersionSkew != nil {
+		in, out := &in.KubernetesVersionSkew, &out.KubernetesVersionSkew
+		*out = make([]ComponentKubernetesVersions, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterServiceSpec.
-func (in *MultiClusterServiceSpec) DeepCopy() *MultiClusterServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReleaseSpec.
+func (in *ReleaseSpec) DeepCopy() *ReleaseSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MultiClusterServiceSpec)
+	out := new(ReleaseSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MultiClusterServiceStatus) DeepCopyInto(out *MultiClusterServiceStatus) {
+func (in *ReleaseStatus) DeepCopyInto(out *ReleaseStatus) {
 	*out = *in
-	if in.Services != nil {
-		in, out := &in.Services, &out.Services
-		*out = make([]ServiceStatus, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReleaseStatus.
+func (in *ReleaseStatus) DeepCopy() *ReleaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReleaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationStatus) DeepCopyInto(out *RemediationStatus) {
+	*out = *in
+	if in.Remediations != nil {
+		in, out := &in.Remediations, &out.Remediations
+		*out = make([]MachineRemediation, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiClusterServiceStatus.
-func (in *MultiClusterServiceStatus) DeepCopy() *MultiClusterServiceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationStatus.
+func (in *RemediationStatus) DeepCopy() *RemediationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MultiClusterServiceStatus)
+	out := new(RemediationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NamedProviderTemplate) DeepCopyInto(out *NamedProviderTemplate) {
+func (in *RemoteSourceSpec) DeepCopyInto(out *RemoteSourceSpec) {
 	*out = *in
-	out.CoreProviderTemplate = in.CoreProviderTemplate
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(EmbeddedGitRepositorySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(EmbeddedBucketSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(EmbeddedOCIRepositorySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamedProviderTemplate.
-func (in *NamedProviderTemplate) DeepCopy() *NamedProviderTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSourceSpec.
+func (in *RemoteSourceSpec) DeepCopy() *RemoteSourceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(NamedProviderTemplate)
+	out := new(RemoteSourceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Provider) DeepCopyInto(out *Provider) {
+func (in *ResolvedOSImage) DeepCopyInto(out *ResolvedOSImage) {
 	*out = *in
-	in.Component.DeepCopyInto(&out.Component)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provider.
-func (in *Provider) DeepCopy() *Provider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedOSImage.
+func (in *ResolvedOSImage) DeepCopy() *ResolvedOSImage {
 	if in == nil {
 		return nil
 	}
-	out := new(Provider)
+	out := new(ResolvedOSImage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderTemplate) DeepCopyInto(out *ProviderTemplate) {
+func (in *SecretPropagation) DeepCopyInto(out *SecretPropagation) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1130,18 +3949,18 @@ func (in *ProviderTemplate) DeepCopyInto(out *ProviderTemplate) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderTemplate.
-func (in *ProviderTemplate) DeepCopy() *ProviderTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretPropagation.
+func (in *SecretPropagation) DeepCopy() *SecretPropagation {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderTemplate)
+	out := new(SecretPropagation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProviderTemplate) DeepCopyObject() runtime.Object {
+func (in *SecretPropagation) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1149,133 +3968,176 @@ func (in *ProviderTemplate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderTemplateList) DeepCopyInto(out *ProviderTemplateList) {
+func (in *SecretPropagationClusterStatus) DeepCopyInto(out *SecretPropagationClusterStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretPropagationClusterStatus.
+func (in *SecretPropagationClusterStatus) DeepCopy() *SecretPropagationClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretPropagationClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretPropagationList) DeepCopyInto(out *SecretPropagationList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ProviderTemplate, len(*in))
+		*out = make([]SecretPropagation, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderTemplateList.
-func (in *ProviderTemplateList) DeepCopy() *ProviderTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretPropagationList.
+func (in *SecretPropagationList) DeepCopy() *SecretPropagationList {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretPropagationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SecretPropagationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretPropagationSpec) DeepCopyInto(out *SecretPropagationSpec) {
+	*out = *in
+	in.ClusterSelector.DeepCopyInto(&out.ClusterSelector)
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetNamespaces != nil {
+		in, out := &in.TargetNamespaces, &out.TargetNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretPropagationSpec.
+func (in *SecretPropagationSpec) DeepCopy() *SecretPropagationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderTemplateList)
+	out := new(SecretPropagationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProviderTemplateList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderTemplateSpec) DeepCopyInto(out *ProviderTemplateSpec) {
+func (in *SecretPropagationStatus) DeepCopyInto(out *SecretPropagationStatus) {
 	*out = *in
-	in.Helm.DeepCopyInto(&out.Helm)
-	if in.CAPIContracts != nil {
-		in, out := &in.CAPIContracts, &out.CAPIContracts
-		*out = make(CompatibilityContracts, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]SecretPropagationClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Providers != nil {
-		in, out := &in.Providers, &out.Providers
-		*out = make(Providers, len(*in))
-		copy(*out, *in)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderTemplateSpec.
-func (in *ProviderTemplateSpec) DeepCopy() *ProviderTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretPropagationStatus.
+func (in *SecretPropagationStatus) DeepCopy() *SecretPropagationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderTemplateSpec)
+	out := new(SecretPropagationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProviderTemplateStatus) DeepCopyInto(out *ProviderTemplateStatus) {
+func (in *SecurityBaseline) DeepCopyInto(out *SecurityBaseline) {
 	*out = *in
-	if in.CAPIContracts != nil {
-		in, out := &in.CAPIContracts, &out.CAPIContracts
-		*out = make(CompatibilityContracts, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Providers != nil {
-		in, out := &in.Providers, &out.Providers
-		*out = make(Providers, len(*in))
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	in.TemplateStatusCommon.DeepCopyInto(&out.TemplateStatusCommon)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderTemplateStatus.
-func (in *ProviderTemplateStatus) DeepCopy() *ProviderTemplateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityBaseline.
+func (in *SecurityBaseline) DeepCopy() *SecurityBaseline {
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderTemplateStatus)
+	out := new(SecurityBaseline)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in Providers) DeepCopyInto(out *Providers) {
-	{
-		in := &in
-		*out = make(Providers, len(*in))
+func (in *Service) DeepCopyInto(out *Service) {
+	*out = *in
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]v1beta1.ValueFrom, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Providers.
-func (in Providers) DeepCopy() Providers {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Service.
+func (in *Service) DeepCopy() *Service {
 	if in == nil {
 		return nil
 	}
-	out := new(Providers)
+	out := new(Service)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Release) DeepCopyInto(out *Release) {
+func (in *ServiceBundle) DeepCopyInto(out *ServiceBundle) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Release.
-func (in *Release) DeepCopy() *Release {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBundle.
+func (in *ServiceBundle) DeepCopy() *ServiceBundle {
 	if in == nil {
 		return nil
 	}
-	out := new(Release)
+	out := new(ServiceBundle)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Release) DeepCopyObject() runtime.Object {
+func (in *ServiceBundle) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1283,31 +4145,31 @@ func (in *Release) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReleaseList) DeepCopyInto(out *ReleaseList) {
+func (in *ServiceBundleList) DeepCopyInto(out *ServiceBundleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Release, len(*in))
+		*out = make([]ServiceBundle, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReleaseList.
-func (in *ReleaseList) DeepCopy() *ReleaseList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBundleList.
+func (in *ServiceBundleList) DeepCopy() *ServiceBundleList {
 	if in == nil {
 		return nil
 	}
-	out := new(ReleaseList)
+	out := new(ServiceBundleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ReleaseList) DeepCopyObject() runtime.Object {
+func (in *ServiceBundleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1315,95 +4177,81 @@ func (in *ReleaseList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReleaseSpec) DeepCopyInto(out *ReleaseSpec) {
+func (in *ServiceBundleSpec) DeepCopyInto(out *ServiceBundleSpec) {
 	*out = *in
-	out.KCM = in.KCM
-	out.CAPI = in.CAPI
-	if in.Providers != nil {
-		in, out := &in.Providers, &out.Providers
-		*out = make([]NamedProviderTemplate, len(*in))
-		copy(*out, *in)
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]Service, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReleaseSpec.
-func (in *ReleaseSpec) DeepCopy() *ReleaseSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBundleSpec.
+func (in *ServiceBundleSpec) DeepCopy() *ServiceBundleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ReleaseSpec)
+	out := new(ServiceBundleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReleaseStatus) DeepCopyInto(out *ReleaseStatus) {
+func (in *ServiceConflict) DeepCopyInto(out *ServiceConflict) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Owners != nil {
+		in, out := &in.Owners, &out.Owners
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReleaseStatus.
-func (in *ReleaseStatus) DeepCopy() *ReleaseStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceConflict.
+func (in *ServiceConflict) DeepCopy() *ServiceConflict {
 	if in == nil {
 		return nil
 	}
-	out := new(ReleaseStatus)
+	out := new(ServiceConflict)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RemoteSourceSpec) DeepCopyInto(out *RemoteSourceSpec) {
+func (in *ServiceHistory) DeepCopyInto(out *ServiceHistory) {
 	*out = *in
-	if in.Git != nil {
-		in, out := &in.Git, &out.Git
-		*out = new(EmbeddedGitRepositorySpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Bucket != nil {
-		in, out := &in.Bucket, &out.Bucket
-		*out = new(EmbeddedBucketSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.OCI != nil {
-		in, out := &in.OCI, &out.OCI
-		*out = new(EmbeddedOCIRepositorySpec)
-		(*in).DeepCopyInto(*out)
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]ServiceRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSourceSpec.
-func (in *RemoteSourceSpec) DeepCopy() *RemoteSourceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceHistory.
+func (in *ServiceHistory) DeepCopy() *ServiceHistory {
 	if in == nil {
 		return nil
 	}
-	out := new(RemoteSourceSpec)
+	out := new(ServiceHistory)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Service) DeepCopyInto(out *Service) {
+func (in *ServiceRevision) DeepCopyInto(out *ServiceRevision) {
 	*out = *in
-	if in.ValuesFrom != nil {
-		in, out := &in.ValuesFrom, &out.ValuesFrom
-		*out = make([]v1beta1.ValueFrom, len(*in))
-		copy(*out, *in)
-	}
+	in.RecordedAt.DeepCopyInto(&out.RecordedAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Service.
-func (in *Service) DeepCopy() *Service {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceRevision.
+func (in *ServiceRevision) DeepCopy() *ServiceRevision {
 	if in == nil {
 		return nil
 	}
-	out := new(Service)
+	out := new(ServiceRevision)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1418,6 +4266,11 @@ func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Bundles != nil {
+		in, out := &in.Bundles, &out.Bundles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.TemplateResourceRefs != nil {
 		in, out := &in.TemplateResourceRefs, &out.TemplateResourceRefs
 		*out = make([]v1beta1.TemplateResourceRef, len(*in))
@@ -1496,6 +4349,30 @@ func (in *ServiceTemplate) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceTemplateAdvisories) DeepCopyInto(out *ServiceTemplateAdvisories) {
+	*out = *in
+	if in.EndOfLife != nil {
+		in, out := &in.EndOfLife, &out.EndOfLife
+		*out = (*in).DeepCopy()
+	}
+	if in.Vulnerabilities != nil {
+		in, out := &in.Vulnerabilities, &out.Vulnerabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceTemplateAdvisories.
+func (in *ServiceTemplateAdvisories) DeepCopy() *ServiceTemplateAdvisories {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceTemplateAdvisories)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceTemplateChain) DeepCopyInto(out *ServiceTemplateChain) {
 	*out = *in
@@ -1587,6 +4464,21 @@ func (in *ServiceTemplateList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceTemplateReference) DeepCopyInto(out *ServiceTemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceTemplateReference.
+func (in *ServiceTemplateReference) DeepCopy() *ServiceTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceTemplateSpec) DeepCopyInto(out *ServiceTemplateSpec) {
 	*out = *in
@@ -1625,67 +4517,176 @@ func (in *ServiceTemplateStatus) DeepCopyInto(out *ServiceTemplateStatus) {
 		*out = new(SourceStatus)
 		(*in).DeepCopyInto(*out)
 	}
-	in.TemplateStatusCommon.DeepCopyInto(&out.TemplateStatusCommon)
+	if in.ReferencedBy != nil {
+		in, out := &in.ReferencedBy, &out.ReferencedBy
+		*out = make([]ServiceTemplateReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Advisories != nil {
+		in, out := &in.Advisories, &out.Advisories
+		*out = new(ServiceTemplateAdvisories)
+		(*in).DeepCopyInto(*out)
+	}
+	in.TemplateStatusCommon.DeepCopyInto(&out.TemplateStatusCommon)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceTemplateStatus.
+func (in *ServiceTemplateStatus) DeepCopy() *ServiceTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceSpec) DeepCopyInto(out *SourceSpec) {
+	*out = *in
+	if in.LocalSourceRef != nil {
+		in, out := &in.LocalSourceRef, &out.LocalSourceRef
+		*out = new(LocalSourceRef)
+		**out = **in
+	}
+	if in.RemoteSourceSpec != nil {
+		in, out := &in.RemoteSourceSpec, &out.RemoteSourceSpec
+		*out = new(RemoteSourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceSpec.
+func (in *SourceSpec) DeepCopy() *SourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceStatus) DeepCopyInto(out *SourceStatus) {
+	*out = *in
+	if in.Artifact != nil {
+		in, out := &in.Artifact, &out.Artifact
+		*out = new(apiv1.Artifact)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceStatus.
+func (in *SourceStatus) DeepCopy() *SourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateManagementProvider) DeepCopyInto(out *StateManagementProvider) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateManagementProvider.
+func (in *StateManagementProvider) DeepCopy() *StateManagementProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(StateManagementProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StateManagementProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateManagementProviderList) DeepCopyInto(out *StateManagementProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]StateManagementProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceTemplateStatus.
-func (in *ServiceTemplateStatus) DeepCopy() *ServiceTemplateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateManagementProviderList.
+func (in *StateManagementProviderList) DeepCopy() *StateManagementProviderList {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceTemplateStatus)
+	out := new(StateManagementProviderList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StateManagementProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SourceSpec) DeepCopyInto(out *SourceSpec) {
+func (in *StateManagementProviderSpec) DeepCopyInto(out *StateManagementProviderSpec) {
 	*out = *in
-	if in.LocalSourceRef != nil {
-		in, out := &in.LocalSourceRef, &out.LocalSourceRef
-		*out = new(LocalSourceRef)
-		**out = **in
-	}
-	if in.RemoteSourceSpec != nil {
-		in, out := &in.RemoteSourceSpec, &out.RemoteSourceSpec
-		*out = new(RemoteSourceSpec)
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RequiredCRDs != nil {
+		in, out := &in.RequiredCRDs, &out.RequiredCRDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceSpec.
-func (in *SourceSpec) DeepCopy() *SourceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateManagementProviderSpec.
+func (in *StateManagementProviderSpec) DeepCopy() *StateManagementProviderSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SourceSpec)
+	out := new(StateManagementProviderSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SourceStatus) DeepCopyInto(out *SourceStatus) {
+func (in *StateManagementProviderStatus) DeepCopyInto(out *StateManagementProviderStatus) {
 	*out = *in
-	if in.Artifact != nil {
-		in, out := &in.Artifact, &out.Artifact
-		*out = new(apiv1.Artifact)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceStatus.
-func (in *SourceStatus) DeepCopy() *SourceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateManagementProviderStatus.
+func (in *StateManagementProviderStatus) DeepCopy() *StateManagementProviderStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SourceStatus)
+	out := new(StateManagementProviderStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1710,6 +4711,21 @@ func (in *SupportedTemplate) DeepCopy() *SupportedTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SveltosAgentStatus) DeepCopyInto(out *SveltosAgentStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SveltosAgentStatus.
+func (in *SveltosAgentStatus) DeepCopy() *SveltosAgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SveltosAgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetNamespaces) DeepCopyInto(out *TargetNamespaces) {
 	*out = *in
@@ -1735,6 +4751,46 @@ func (in *TargetNamespaces) DeepCopy() *TargetNamespaces {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Telemetry) DeepCopyInto(out *Telemetry) {
+	*out = *in
+	if in.DisabledCategories != nil {
+		in, out := &in.DisabledCategories, &out.DisabledCategories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Telemetry.
+func (in *Telemetry) DeepCopy() *Telemetry {
+	if in == nil {
+		return nil
+	}
+	out := new(Telemetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateChainNode) DeepCopyInto(out *TemplateChainNode) {
+	*out = *in
+	if in.Upgrades != nil {
+		in, out := &in.Upgrades, &out.Upgrades
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateChainNode.
+func (in *TemplateChainNode) DeepCopy() *TemplateChainNode {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateChainNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TemplateChainSpec) DeepCopyInto(out *TemplateChainSpec) {
 	*out = *in
@@ -1765,6 +4821,20 @@ func (in *TemplateChainStatus) DeepCopyInto(out *TemplateChainStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.UpgradeGraph != nil {
+		in, out := &in.UpgradeGraph, &out.UpgradeGraph
+		*out = make([]TemplateChainNode, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateChainStatus.
@@ -1790,7 +4860,19 @@ func (in *TemplateStatusCommon) DeepCopyInto(out *TemplateStatusCommon) {
 		*out = new(v2.CrossNamespaceSourceReference)
 		**out = **in
 	}
+	if in.ChartSources != nil {
+		in, out := &in.ChartSources, &out.ChartSources
+		*out = make([]ChartSourceStatus, len(*in))
+		copy(*out, *in)
+	}
 	out.TemplateValidationStatus = in.TemplateValidationStatus
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateStatusCommon.
@@ -1817,3 +4899,198 @@ func (in *TemplateValidationStatus) DeepCopy() *TemplateValidationStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustedCA) DeepCopyInto(out *TrustedCA) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustedCA.
+func (in *TrustedCA) DeepCopy() *TrustedCA {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustedCA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustedCAStatus) DeepCopyInto(out *TrustedCAStatus) {
+	*out = *in
+	if in.LastRolloutTime != nil {
+		in, out := &in.LastRolloutTime, &out.LastRolloutTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustedCAStatus.
+func (in *TrustedCAStatus) DeepCopy() *TrustedCAStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustedCAStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeCandidate) DeepCopyInto(out *UpgradeCandidate) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]UpgradeCandidateReason, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeCandidate.
+func (in *UpgradeCandidate) DeepCopy() *UpgradeCandidate {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeCandidate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeCandidateReason) DeepCopyInto(out *UpgradeCandidateReason) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeCandidateReason.
+func (in *UpgradeCandidateReason) DeepCopy() *UpgradeCandidateReason {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeCandidateReason)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VIPAllocation) DeepCopyInto(out *VIPAllocation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VIPAllocation.
+func (in *VIPAllocation) DeepCopy() *VIPAllocation {
+	if in == nil {
+		return nil
+	}
+	out := new(VIPAllocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VIPPool) DeepCopyInto(out *VIPPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VIPPool.
+func (in *VIPPool) DeepCopy() *VIPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(VIPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VIPPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VIPPoolList) DeepCopyInto(out *VIPPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VIPPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VIPPoolList.
+func (in *VIPPoolList) DeepCopy() *VIPPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(VIPPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VIPPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VIPPoolSpec) DeepCopyInto(out *VIPPoolSpec) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VIPPoolSpec.
+func (in *VIPPoolSpec) DeepCopy() *VIPPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VIPPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VIPPoolStatus) DeepCopyInto(out *VIPPoolStatus) {
+	*out = *in
+	if in.Allocations != nil {
+		in, out := &in.Allocations, &out.Allocations
+		*out = make([]VIPAllocation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VIPPoolStatus.
+func (in *VIPPoolStatus) DeepCopy() *VIPPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VIPPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,485 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// The types having the same fields in both versions are converted with the
+// Go type conversions, so the diverging types fail to compile until the
+// explicit conversion is implemented.
+
+// ConvertTo converts the ClusterDeployment to the hub version.
+func (src *ClusterDeployment) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ClusterDeployment)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha1.ClusterDeploymentSpec{
+		Config:   src.Spec.Config,
+		Template: src.Spec.Template,
+		TemplateRef: convertPtr(src.Spec.TemplateRef, func(in ClusterTemplateReference) v1alpha1.ClusterTemplateReference {
+			return v1alpha1.ClusterTemplateReference(in)
+		}),
+		Credential:           src.Spec.Credential,
+		ServiceSpec:          convertServiceSpecToHub(src.Spec.ServiceSpec),
+		DryRun:               src.Spec.DryRun,
+		RenderValues:         src.Spec.RenderValues,
+		PropagateCredentials: src.Spec.PropagateCredentials,
+		ControlPlaneVIP:      convertPtr(src.Spec.ControlPlaneVIP, func(in ControlPlaneVIP) v1alpha1.ControlPlaneVIP { return v1alpha1.ControlPlaneVIP(in) }),
+		DNS:                  convertPtr(src.Spec.DNS, func(in ClusterDNS) v1alpha1.ClusterDNS { return v1alpha1.ClusterDNS(in) }),
+		ClusterRoleBindings:  convertSlice(src.Spec.ClusterRoleBindings, func(in ClusterRoleBinding) v1alpha1.ClusterRoleBinding { return v1alpha1.ClusterRoleBinding(in) }),
+		Authentication: convertPtr(src.Spec.Authentication, func(in ClusterAuthentication) v1alpha1.ClusterAuthentication {
+			return v1alpha1.ClusterAuthentication{
+				OIDC: convertPtr(in.OIDC, func(in ClusterOIDC) v1alpha1.ClusterOIDC {
+					return v1alpha1.ClusterOIDC{
+						IssuerURL:      in.IssuerURL,
+						ClientID:       in.ClientID,
+						ClaimMappings:  v1alpha1.OIDCClaimMappings(in.ClaimMappings),
+						RequiredClaims: in.RequiredClaims,
+					}
+				}),
+			}
+		}),
+		GPU:     convertPtr(src.Spec.GPU, func(in ClusterGPU) v1alpha1.ClusterGPU { return v1alpha1.ClusterGPU(in) }),
+		Network: convertPtr(src.Spec.Network, func(in ClusterNetwork) v1alpha1.ClusterNetwork { return v1alpha1.ClusterNetwork(in) }),
+		Proxy:   convertPtr(src.Spec.Proxy, func(in ClusterProxy) v1alpha1.ClusterProxy { return v1alpha1.ClusterProxy(in) }),
+		MachineAccess: convertPtr(src.Spec.MachineAccess, func(in MachineAccess) v1alpha1.MachineAccess {
+			return v1alpha1.MachineAccess{
+				User:                    in.User,
+				AuthorizedKeys:          in.AuthorizedKeys,
+				AuthorizedKeysConfigMap: in.AuthorizedKeysConfigMap,
+				Bastion:                 convertPtr(in.Bastion, func(in MachineBastion) v1alpha1.MachineBastion { return v1alpha1.MachineBastion(in) }),
+			}
+		}),
+		CloudTags:            src.Spec.CloudTags,
+		ConfigDriftPolicy:    src.Spec.ConfigDriftPolicy,
+		PropagateLabels:      src.Spec.PropagateLabels,
+		PropagateAnnotations: src.Spec.PropagateAnnotations,
+		TTL:                  src.Spec.TTL,
+		ExpireAt:             src.Spec.ExpireAt,
+		ExpirationPolicy: convertPtr(src.Spec.ExpirationPolicy, func(in ClusterExpirationPolicy) v1alpha1.ClusterExpirationPolicy {
+			return v1alpha1.ClusterExpirationPolicy(in)
+		}),
+		CloneFrom:       src.Spec.CloneFrom,
+		ServiceDelivery: src.Spec.ServiceDelivery,
+		LifecycleHooks: convertPtr(src.Spec.LifecycleHooks, func(in ClusterLifecycleHooks) v1alpha1.ClusterLifecycleHooks {
+			return v1alpha1.ClusterLifecycleHooks{
+				MaintenanceWindows:                      convertSlice(in.MaintenanceWindows, func(in MaintenanceWindow) v1alpha1.MaintenanceWindow { return v1alpha1.MaintenanceWindow(in) }),
+				BackupBeforeUpgrade:                     in.BackupBeforeUpgrade,
+				WaitForServicesAfterControlPlaneUpgrade: in.WaitForServicesAfterControlPlaneUpgrade,
+				QuiesceServicesBeforeDelete:             in.QuiesceServicesBeforeDelete,
+			}
+		}),
+		Remediation: convertPtr(src.Spec.Remediation, func(in ClusterRemediation) v1alpha1.ClusterRemediation { return v1alpha1.ClusterRemediation(in) }),
+		Observability: convertPtr(src.Spec.Observability, func(in ClusterObservability) v1alpha1.ClusterObservability {
+			return v1alpha1.ClusterObservability(in)
+		}),
+		ProvisioningPolicy: convertPtr(src.Spec.ProvisioningPolicy, func(in ProvisioningPolicy) v1alpha1.ProvisioningPolicy {
+			return v1alpha1.ProvisioningPolicy(in)
+		}),
+		FailureDomains: convertPtr(src.Spec.FailureDomains, func(in ClusterFailureDomains) v1alpha1.ClusterFailureDomains {
+			return v1alpha1.ClusterFailureDomains(in)
+		}),
+		Networks: convertPtr(src.Spec.Networks, func(in ClusterNetworks) v1alpha1.ClusterNetworks {
+			return v1alpha1.ClusterNetworks{
+				Additional: convertSlice(in.Additional, func(in NodeNetwork) v1alpha1.NodeNetwork { return v1alpha1.NodeNetwork(in) }),
+				Storage:    convertPtr(in.Storage, func(in NodeNetwork) v1alpha1.NodeNetwork { return v1alpha1.NodeNetwork(in) }),
+			}
+		}),
+		ReadinessGates: convertPtr(src.Spec.ReadinessGates, func(in ClusterReadinessGates) v1alpha1.ClusterReadinessGates {
+			return v1alpha1.ClusterReadinessGates{
+				Services: in.Services,
+				Probes: convertSlice(in.Probes, func(in ReadinessProbe) v1alpha1.ReadinessProbe {
+					return v1alpha1.ReadinessProbe{
+						HTTP: convertPtr(in.HTTP, func(in HTTPReadinessProbe) v1alpha1.HTTPReadinessProbe { return v1alpha1.HTTPReadinessProbe(in) }),
+						Job:  convertPtr(in.Job, func(in JobReadinessProbe) v1alpha1.JobReadinessProbe { return v1alpha1.JobReadinessProbe(in) }),
+						Name: in.Name,
+					}
+				}),
+				NodesReady: in.NodesReady,
+			}
+		}),
+	}
+	dst.Status = v1alpha1.ClusterDeploymentStatus{
+		Services:             convertSlice(src.Status.Services, func(in ServiceStatus) v1alpha1.ServiceStatus { return v1alpha1.ServiceStatus(in) }),
+		ServiceConflicts:     convertSlice(src.Status.ServiceConflicts, func(in ServiceConflict) v1alpha1.ServiceConflict { return v1alpha1.ServiceConflict(in) }),
+		ServiceHistory:       convertSlice(src.Status.ServiceHistory, convertServiceHistoryToHub),
+		KubernetesVersion:    src.Status.KubernetesVersion,
+		ControlPlaneVIP:      src.Status.ControlPlaneVIP,
+		Endpoints:            convertSlice(src.Status.Endpoints, func(in ClusterEndpoint) v1alpha1.ClusterEndpoint { return v1alpha1.ClusterEndpoint(in) }),
+		CertificatesExpireAt: src.Status.CertificatesExpireAt,
+		ExpiresAt:            src.Status.ExpiresAt,
+		CostEstimate:         convertPtr(src.Status.CostEstimate, func(in CostEstimate) v1alpha1.CostEstimate { return v1alpha1.CostEstimate(in) }),
+		Remediation: convertPtr(src.Status.Remediation, func(in RemediationStatus) v1alpha1.RemediationStatus {
+			return v1alpha1.RemediationStatus{
+				Remediations:        convertSlice(in.Remediations, func(in MachineRemediation) v1alpha1.MachineRemediation { return v1alpha1.MachineRemediation(in) }),
+				UnhealthyMachines:   in.UnhealthyMachines,
+				RemediationsAllowed: in.RemediationsAllowed,
+				Paused:              in.Paused,
+			}
+		}),
+		Provisioning: convertPtr(src.Status.Provisioning, func(in ProvisioningStatus) v1alpha1.ProvisioningStatus {
+			return v1alpha1.ProvisioningStatus(in)
+		}),
+		Deletion: convertPtr(src.Status.Deletion, func(in DeletionStatus) v1alpha1.DeletionStatus {
+			return v1alpha1.DeletionStatus{
+				LastTransitionTime: in.LastTransitionTime,
+				Finalizers:         in.Finalizers,
+				BlockingResources:  convertSlice(in.BlockingResources, func(in BlockingResource) v1alpha1.BlockingResource { return v1alpha1.BlockingResource(in) }),
+				Stuck:              in.Stuck,
+			}
+		}),
+		FailureDomains: convertSlice(src.Status.FailureDomains, func(in FailureDomainStatus) v1alpha1.FailureDomainStatus { return v1alpha1.FailureDomainStatus(in) }),
+		OSImages:       convertSlice(src.Status.OSImages, func(in ResolvedOSImage) v1alpha1.ResolvedOSImage { return v1alpha1.ResolvedOSImage(in) }),
+		LastKnownGood:  convertPtr(src.Status.LastKnownGood, func(in ClusterRevision) v1alpha1.ClusterRevision { return v1alpha1.ClusterRevision(in) }),
+		ReadinessGates: convertSlice(src.Status.ReadinessGates, func(in ReadinessGateStatus) v1alpha1.ReadinessGateStatus { return v1alpha1.ReadinessGateStatus(in) }),
+		SveltosAgents:  convertSlice(src.Status.SveltosAgents, func(in SveltosAgentStatus) v1alpha1.SveltosAgentStatus { return v1alpha1.SveltosAgentStatus(in) }),
+		TrustedCA:      convertPtr(src.Status.TrustedCA, func(in TrustedCAStatus) v1alpha1.TrustedCAStatus { return v1alpha1.TrustedCAStatus(in) }),
+		MachineAccess:  convertPtr(src.Status.MachineAccess, func(in MachineAccessStatus) v1alpha1.MachineAccessStatus { return v1alpha1.MachineAccessStatus(in) }),
+		Timings: convertPtr(src.Status.Timings, func(in ClusterTimings) v1alpha1.ClusterTimings {
+			return v1alpha1.ClusterTimings{
+				Provisioning: convertPtr(in.Provisioning, func(in ClusterOperationTiming) v1alpha1.ClusterOperationTiming {
+					return v1alpha1.ClusterOperationTiming(in)
+				}),
+				LastUpgrade: convertPtr(in.LastUpgrade, func(in ClusterOperationTiming) v1alpha1.ClusterOperationTiming {
+					return v1alpha1.ClusterOperationTiming(in)
+				}),
+			}
+		}),
+		ControlPlaneMigration: convertPtr(src.Status.ControlPlaneMigration, func(in ControlPlaneMigration) v1alpha1.ControlPlaneMigration {
+			return v1alpha1.ControlPlaneMigration{
+				StartTime:      in.StartTime,
+				CompletionTime: in.CompletionTime,
+				FromTemplate:   in.FromTemplate,
+				ToTemplate:     in.ToTemplate,
+				FromMode:       in.FromMode,
+				ToMode:         in.ToMode,
+				Phase:          v1alpha1.ControlPlaneMigrationPhase(in.Phase),
+				Inventory:      in.Inventory,
+				Missing:        in.Missing,
+			}
+		}),
+		SecurityBaseline:  src.Status.SecurityBaseline,
+		Conditions:        src.Status.Conditions,
+		AvailableUpgrades: src.Status.AvailableUpgrades,
+		UpgradeCandidates: convertSlice(src.Status.UpgradeCandidates, func(in UpgradeCandidate) v1alpha1.UpgradeCandidate {
+			return v1alpha1.UpgradeCandidate{
+				Template: in.Template,
+				Reasons: convertSlice(in.Reasons, func(in UpgradeCandidateReason) v1alpha1.UpgradeCandidateReason {
+					return v1alpha1.UpgradeCandidateReason(in)
+				}),
+				Available: in.Available,
+			}
+		}),
+		ObservedGeneration: src.Status.ObservedGeneration,
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the ClusterDeployment from the hub version.
+func (dst *ClusterDeployment) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ClusterDeployment)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = ClusterDeploymentSpec{
+		Config:   src.Spec.Config,
+		Template: src.Spec.Template,
+		TemplateRef: convertPtr(src.Spec.TemplateRef, func(in v1alpha1.ClusterTemplateReference) ClusterTemplateReference {
+			return ClusterTemplateReference(in)
+		}),
+		Credential:           src.Spec.Credential,
+		ServiceSpec:          convertServiceSpecFromHub(src.Spec.ServiceSpec),
+		DryRun:               src.Spec.DryRun,
+		RenderValues:         src.Spec.RenderValues,
+		PropagateCredentials: src.Spec.PropagateCredentials,
+		ControlPlaneVIP:      convertPtr(src.Spec.ControlPlaneVIP, func(in v1alpha1.ControlPlaneVIP) ControlPlaneVIP { return ControlPlaneVIP(in) }),
+		DNS:                  convertPtr(src.Spec.DNS, func(in v1alpha1.ClusterDNS) ClusterDNS { return ClusterDNS(in) }),
+		ClusterRoleBindings:  convertSlice(src.Spec.ClusterRoleBindings, func(in v1alpha1.ClusterRoleBinding) ClusterRoleBinding { return ClusterRoleBinding(in) }),
+		Authentication: convertPtr(src.Spec.Authentication, func(in v1alpha1.ClusterAuthentication) ClusterAuthentication {
+			return ClusterAuthentication{
+				OIDC: convertPtr(in.OIDC, func(in v1alpha1.ClusterOIDC) ClusterOIDC {
+					return ClusterOIDC{
+						IssuerURL:      in.IssuerURL,
+						ClientID:       in.ClientID,
+						ClaimMappings:  OIDCClaimMappings(in.ClaimMappings),
+						RequiredClaims: in.RequiredClaims,
+					}
+				}),
+			}
+		}),
+		GPU:     convertPtr(src.Spec.GPU, func(in v1alpha1.ClusterGPU) ClusterGPU { return ClusterGPU(in) }),
+		Network: convertPtr(src.Spec.Network, func(in v1alpha1.ClusterNetwork) ClusterNetwork { return ClusterNetwork(in) }),
+		Proxy:   convertPtr(src.Spec.Proxy, func(in v1alpha1.ClusterProxy) ClusterProxy { return ClusterProxy(in) }),
+		MachineAccess: convertPtr(src.Spec.MachineAccess, func(in v1alpha1.MachineAccess) MachineAccess {
+			return MachineAccess{
+				User:                    in.User,
+				AuthorizedKeys:          in.AuthorizedKeys,
+				AuthorizedKeysConfigMap: in.AuthorizedKeysConfigMap,
+				Bastion:                 convertPtr(in.Bastion, func(in v1alpha1.MachineBastion) MachineBastion { return MachineBastion(in) }),
+			}
+		}),
+		CloudTags:            src.Spec.CloudTags,
+		ConfigDriftPolicy:    src.Spec.ConfigDriftPolicy,
+		PropagateLabels:      src.Spec.PropagateLabels,
+		PropagateAnnotations: src.Spec.PropagateAnnotations,
+		TTL:                  src.Spec.TTL,
+		ExpireAt:             src.Spec.ExpireAt,
+		ExpirationPolicy:     convertPtr(src.Spec.ExpirationPolicy, func(in v1alpha1.ClusterExpirationPolicy) ClusterExpirationPolicy { return ClusterExpirationPolicy(in) }),
+		CloneFrom:            src.Spec.CloneFrom,
+		ServiceDelivery:      src.Spec.ServiceDelivery,
+		LifecycleHooks: convertPtr(src.Spec.LifecycleHooks, func(in v1alpha1.ClusterLifecycleHooks) ClusterLifecycleHooks {
+			return ClusterLifecycleHooks{
+				MaintenanceWindows:                      convertSlice(in.MaintenanceWindows, func(in v1alpha1.MaintenanceWindow) MaintenanceWindow { return MaintenanceWindow(in) }),
+				BackupBeforeUpgrade:                     in.BackupBeforeUpgrade,
+				WaitForServicesAfterControlPlaneUpgrade: in.WaitForServicesAfterControlPlaneUpgrade,
+				QuiesceServicesBeforeDelete:             in.QuiesceServicesBeforeDelete,
+			}
+		}),
+		Remediation: convertPtr(src.Spec.Remediation, func(in v1alpha1.ClusterRemediation) ClusterRemediation { return ClusterRemediation(in) }),
+		Observability: convertPtr(src.Spec.Observability, func(in v1alpha1.ClusterObservability) ClusterObservability {
+			return ClusterObservability(in)
+		}),
+		ProvisioningPolicy: convertPtr(src.Spec.ProvisioningPolicy, func(in v1alpha1.ProvisioningPolicy) ProvisioningPolicy {
+			return ProvisioningPolicy(in)
+		}),
+		FailureDomains: convertPtr(src.Spec.FailureDomains, func(in v1alpha1.ClusterFailureDomains) ClusterFailureDomains {
+			return ClusterFailureDomains(in)
+		}),
+		Networks: convertPtr(src.Spec.Networks, func(in v1alpha1.ClusterNetworks) ClusterNetworks {
+			return ClusterNetworks{
+				Additional: convertSlice(in.Additional, func(in v1alpha1.NodeNetwork) NodeNetwork { return NodeNetwork(in) }),
+				Storage:    convertPtr(in.Storage, func(in v1alpha1.NodeNetwork) NodeNetwork { return NodeNetwork(in) }),
+			}
+		}),
+		ReadinessGates: convertPtr(src.Spec.ReadinessGates, func(in v1alpha1.ClusterReadinessGates) ClusterReadinessGates {
+			return ClusterReadinessGates{
+				Services: in.Services,
+				Probes: convertSlice(in.Probes, func(in v1alpha1.ReadinessProbe) ReadinessProbe {
+					return ReadinessProbe{
+						HTTP: convertPtr(in.HTTP, func(in v1alpha1.HTTPReadinessProbe) HTTPReadinessProbe { return HTTPReadinessProbe(in) }),
+						Job:  convertPtr(in.Job, func(in v1alpha1.JobReadinessProbe) JobReadinessProbe { return JobReadinessProbe(in) }),
+						Name: in.Name,
+					}
+				}),
+				NodesReady: in.NodesReady,
+			}
+		}),
+	}
+	dst.Status = ClusterDeploymentStatus{
+		Services:             convertSlice(src.Status.Services, func(in v1alpha1.ServiceStatus) ServiceStatus { return ServiceStatus(in) }),
+		ServiceConflicts:     convertSlice(src.Status.ServiceConflicts, func(in v1alpha1.ServiceConflict) ServiceConflict { return ServiceConflict(in) }),
+		ServiceHistory:       convertSlice(src.Status.ServiceHistory, convertServiceHistoryFromHub),
+		KubernetesVersion:    src.Status.KubernetesVersion,
+		ControlPlaneVIP:      src.Status.ControlPlaneVIP,
+		Endpoints:            convertSlice(src.Status.Endpoints, func(in v1alpha1.ClusterEndpoint) ClusterEndpoint { return ClusterEndpoint(in) }),
+		CertificatesExpireAt: src.Status.CertificatesExpireAt,
+		ExpiresAt:            src.Status.ExpiresAt,
+		CostEstimate:         convertPtr(src.Status.CostEstimate, func(in v1alpha1.CostEstimate) CostEstimate { return CostEstimate(in) }),
+		Remediation: convertPtr(src.Status.Remediation, func(in v1alpha1.RemediationStatus) RemediationStatus {
+			return RemediationStatus{
+				Remediations:        convertSlice(in.Remediations, func(in v1alpha1.MachineRemediation) MachineRemediation { return MachineRemediation(in) }),
+				UnhealthyMachines:   in.UnhealthyMachines,
+				RemediationsAllowed: in.RemediationsAllowed,
+				Paused:              in.Paused,
+			}
+		}),
+		Provisioning: convertPtr(src.Status.Provisioning, func(in v1alpha1.ProvisioningStatus) ProvisioningStatus {
+			return ProvisioningStatus(in)
+		}),
+		Deletion: convertPtr(src.Status.Deletion, func(in v1alpha1.DeletionStatus) DeletionStatus {
+			return DeletionStatus{
+				LastTransitionTime: in.LastTransitionTime,
+				Finalizers:         in.Finalizers,
+				BlockingResources:  convertSlice(in.BlockingResources, func(in v1alpha1.BlockingResource) BlockingResource { return BlockingResource(in) }),
+				Stuck:              in.Stuck,
+			}
+		}),
+		FailureDomains: convertSlice(src.Status.FailureDomains, func(in v1alpha1.FailureDomainStatus) FailureDomainStatus { return FailureDomainStatus(in) }),
+		OSImages:       convertSlice(src.Status.OSImages, func(in v1alpha1.ResolvedOSImage) ResolvedOSImage { return ResolvedOSImage(in) }),
+		LastKnownGood:  convertPtr(src.Status.LastKnownGood, func(in v1alpha1.ClusterRevision) ClusterRevision { return ClusterRevision(in) }),
+		ReadinessGates: convertSlice(src.Status.ReadinessGates, func(in v1alpha1.ReadinessGateStatus) ReadinessGateStatus { return ReadinessGateStatus(in) }),
+		SveltosAgents:  convertSlice(src.Status.SveltosAgents, func(in v1alpha1.SveltosAgentStatus) SveltosAgentStatus { return SveltosAgentStatus(in) }),
+		TrustedCA:      convertPtr(src.Status.TrustedCA, func(in v1alpha1.TrustedCAStatus) TrustedCAStatus { return TrustedCAStatus(in) }),
+		MachineAccess:  convertPtr(src.Status.MachineAccess, func(in v1alpha1.MachineAccessStatus) MachineAccessStatus { return MachineAccessStatus(in) }),
+		Timings: convertPtr(src.Status.Timings, func(in v1alpha1.ClusterTimings) ClusterTimings {
+			return ClusterTimings{
+				Provisioning: convertPtr(in.Provisioning, func(in v1alpha1.ClusterOperationTiming) ClusterOperationTiming { return ClusterOperationTiming(in) }),
+				LastUpgrade:  convertPtr(in.LastUpgrade, func(in v1alpha1.ClusterOperationTiming) ClusterOperationTiming { return ClusterOperationTiming(in) }),
+			}
+		}),
+		ControlPlaneMigration: convertPtr(src.Status.ControlPlaneMigration, func(in v1alpha1.ControlPlaneMigration) ControlPlaneMigration {
+			return ControlPlaneMigration{
+				StartTime:      in.StartTime,
+				CompletionTime: in.CompletionTime,
+				FromTemplate:   in.FromTemplate,
+				ToTemplate:     in.ToTemplate,
+				FromMode:       in.FromMode,
+				ToMode:         in.ToMode,
+				Phase:          ControlPlaneMigrationPhase(in.Phase),
+				Inventory:      in.Inventory,
+				Missing:        in.Missing,
+			}
+		}),
+		SecurityBaseline:  src.Status.SecurityBaseline,
+		Conditions:        src.Status.Conditions,
+		AvailableUpgrades: src.Status.AvailableUpgrades,
+		UpgradeCandidates: convertSlice(src.Status.UpgradeCandidates, func(in v1alpha1.UpgradeCandidate) UpgradeCandidate {
+			return UpgradeCandidate{
+				Template:  in.Template,
+				Reasons:   convertSlice(in.Reasons, func(in v1alpha1.UpgradeCandidateReason) UpgradeCandidateReason { return UpgradeCandidateReason(in) }),
+				Available: in.Available,
+			}
+		}),
+		ObservedGeneration: src.Status.ObservedGeneration,
+	}
+
+	return nil
+}
+
+// ConvertTo converts the MultiClusterService to the hub version.
+func (src *MultiClusterService) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.MultiClusterService)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha1.MultiClusterServiceSpec{
+		ClusterSelector:   src.Spec.ClusterSelector,
+		KubernetesVersion: src.Spec.KubernetesVersion,
+		ServiceSpec:       convertServiceSpecToHub(src.Spec.ServiceSpec),
+	}
+	dst.Status = v1alpha1.MultiClusterServiceStatus{
+		Services:           convertSlice(src.Status.Services, func(in ServiceStatus) v1alpha1.ServiceStatus { return v1alpha1.ServiceStatus(in) }),
+		ServiceConflicts:   convertSlice(src.Status.ServiceConflicts, func(in ServiceConflict) v1alpha1.ServiceConflict { return v1alpha1.ServiceConflict(in) }),
+		ServiceHistory:     convertSlice(src.Status.ServiceHistory, convertServiceHistoryToHub),
+		Conditions:         src.Status.Conditions,
+		ObservedGeneration: src.Status.ObservedGeneration,
+	}
+
+	return nil
+}
+
+// ConvertFrom converts the MultiClusterService from the hub version.
+func (dst *MultiClusterService) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.MultiClusterService)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = MultiClusterServiceSpec{
+		ClusterSelector:   src.Spec.ClusterSelector,
+		KubernetesVersion: src.Spec.KubernetesVersion,
+		ServiceSpec:       convertServiceSpecFromHub(src.Spec.ServiceSpec),
+	}
+	dst.Status = MultiClusterServiceStatus{
+		Services:           convertSlice(src.Status.Services, func(in v1alpha1.ServiceStatus) ServiceStatus { return ServiceStatus(in) }),
+		ServiceConflicts:   convertSlice(src.Status.ServiceConflicts, func(in v1alpha1.ServiceConflict) ServiceConflict { return ServiceConflict(in) }),
+		ServiceHistory:     convertSlice(src.Status.ServiceHistory, convertServiceHistoryFromHub),
+		Conditions:         src.Status.Conditions,
+		ObservedGeneration: src.Status.ObservedGeneration,
+	}
+
+	return nil
+}
+
+// ConvertTo converts the Credential to the hub version.
+func (src *Credential) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Credential)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha1.CredentialSpec(src.Spec)
+	dst.Status = v1alpha1.CredentialStatus(src.Status)
+
+	return nil
+}
+
+// ConvertFrom converts the Credential from the hub version.
+func (dst *Credential) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Credential)
+
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = CredentialSpec(src.Spec)
+	dst.Status = CredentialStatus(src.Status)
+
+	return nil
+}
+
+func convertServiceSpecToHub(in ServiceSpec) v1alpha1.ServiceSpec {
+	return v1alpha1.ServiceSpec{
+		SyncMode:             in.SyncMode,
+		Services:             convertSlice(in.Services, func(in Service) v1alpha1.Service { return v1alpha1.Service(in) }),
+		Bundles:              in.Bundles,
+		TemplateResourceRefs: in.TemplateResourceRefs,
+		DriftIgnore:          in.DriftIgnore,
+		DriftExclusions:      in.DriftExclusions,
+		Priority:             in.Priority,
+		StopOnConflict:       in.StopOnConflict,
+		Reload:               in.Reload,
+		ContinueOnError:      in.ContinueOnError,
+	}
+}
+
+func convertServiceSpecFromHub(in v1alpha1.ServiceSpec) ServiceSpec {
+	return ServiceSpec{
+		SyncMode:             in.SyncMode,
+		Services:             convertSlice(in.Services, func(in v1alpha1.Service) Service { return Service(in) }),
+		Bundles:              in.Bundles,
+		TemplateResourceRefs: in.TemplateResourceRefs,
+		DriftIgnore:          in.DriftIgnore,
+		DriftExclusions:      in.DriftExclusions,
+		Priority:             in.Priority,
+		StopOnConflict:       in.StopOnConflict,
+		Reload:               in.Reload,
+		ContinueOnError:      in.ContinueOnError,
+	}
+}
+
+func convertServiceHistoryToHub(in ServiceHistory) v1alpha1.ServiceHistory {
+	return v1alpha1.ServiceHistory{
+		Name:      in.Name,
+		Namespace: in.Namespace,
+		Revisions: convertSlice(in.Revisions, func(in ServiceRevision) v1alpha1.ServiceRevision { return v1alpha1.ServiceRevision(in) }),
+	}
+}
+
+func convertServiceHistoryFromHub(in v1alpha1.ServiceHistory) ServiceHistory {
+	return ServiceHistory{
+		Name:      in.Name,
+		Namespace: in.Namespace,
+		Revisions: convertSlice(in.Revisions, func(in v1alpha1.ServiceRevision) ServiceRevision { return ServiceRevision(in) }),
+	}
+}
+
+func convertSlice[In, Out any](in []In, convert func(In) Out) []Out {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]Out, len(in))
+	for i := range in {
+		out[i] = convert(in[i])
+	}
+
+	return out
+}
+
+func convertPtr[In, Out any](in *In, convert func(In) Out) *Out {
+	if in == nil {
+		return nil
+	}
+
+	out := convert(*in)
+	return &out
+}
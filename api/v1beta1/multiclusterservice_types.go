@@ -0,0 +1,214 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Service represents a Service to be deployed.
+type Service struct {
+	// Values is the helm values to be passed to the chart used by the template.
+	// The string type is used in order to allow for templating.
+	Values string `json:"values,omitempty"`
+
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+
+	// Template is a reference to a Template object located in the same namespace.
+	Template string `json:"template"`
+
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+
+	// Name is the chart release.
+	Name string `json:"name"`
+	// Namespace is the namespace the release will be installed in.
+	// It will default to Name if not provided.
+	Namespace string `json:"namespace,omitempty"`
+	// ValuesFrom can reference a ConfigMap or Secret containing helm values.
+	ValuesFrom []sveltosv1beta1.ValueFrom `json:"valuesFrom,omitempty"`
+	// Disable can be set to disable handling of this service.
+	Disable bool `json:"disable,omitempty"`
+}
+
+// ServiceSpec contains all the spec related to deployment of services.
+type ServiceSpec struct {
+	// +kubebuilder:default:=Continuous
+	// +kubebuilder:validation:Enum:=OneTime;Continuous;ContinuousWithDriftDetection;DryRun
+
+	// SyncMode specifies how services are synced in the target cluster.
+	SyncMode string `json:"syncMode,omitempty"`
+	// Services is a list of services created via ServiceTemplates
+	// that could be installed on the target cluster.
+	Services []Service `json:"services,omitempty"`
+
+	// +listType=set
+
+	// Bundles is a list of the names of the ServiceBundles whose services are installed on the target cluster
+	// before the services listed in Services, in the order of the bundles. The ServiceBundles are looked up
+	// in the namespace of the ClusterDeployment and in the system namespace for the MultiClusterService.
+	// A service listed in Services with the same name and namespace as a service of a bundle overrides it
+	// in place, keeping the values of the bundle if its own values are not set.
+	Bundles []string `json:"bundles,omitempty"`
+
+	// TemplateResourceRefs is a list of resources to collect from the management cluster,
+	// the values from which can be used in templates.
+	TemplateResourceRefs []sveltosv1beta1.TemplateResourceRef `json:"templateResourceRefs,omitempty"`
+	// DriftIgnore specifies resources to ignore for drift detection.
+	DriftIgnore []libsveltosv1beta1.PatchSelector `json:"driftIgnore,omitempty"`
+	// DriftExclusions specifies specific configurations of resources to ignore for drift detection.
+	DriftExclusions []sveltosv1beta1.DriftExclusion `json:"driftExclusions,omitempty"`
+
+	// +kubebuilder:default:=100
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2147483646
+
+	// Priority sets the priority for the services defined in this spec.
+	// Higher value means higher priority and lower means lower.
+	// In case of conflict with another object managing the service,
+	// the one with higher priority will get to deploy its services.
+	// With the same priority the precedence is decided by the tie-breakers in order:
+	// the ClusterDeployment takes precedence over the MultiClusterServices,
+	// then the object created earlier, then the object with the lexicographically lower name.
+	// The conflicts are reported with the ServiceConflict condition and the winner
+	// of each conflicting service is listed in the serviceConflicts of the status.
+	Priority int32 `json:"priority,omitempty"`
+
+	// +kubebuilder:default:=false
+
+	// StopOnConflict specifies what to do in case of a conflict.
+	// E.g. If another object is already managing a service.
+	// By default the remaining services will be deployed even if conflict is detected.
+	// If set to true, the deployment will stop after encountering the first conflict.
+	StopOnConflict bool `json:"stopOnConflict,omitempty"`
+	// Reload instances via rolling upgrade when a ConfigMap/Secret mounted as volume is modified.
+	Reload bool `json:"reload,omitempty"`
+
+	// +kubebuilder:default:=false
+
+	// ContinueOnError specifies if the services deployment should continue if an error occurs.
+	ContinueOnError bool `json:"continueOnError,omitempty"`
+}
+
+// MultiClusterServiceSpec defines the desired state of MultiClusterService
+type MultiClusterServiceSpec struct {
+	// ClusterSelector identifies target clusters to manage services on.
+	// Besides the own labels the clusters are labeled with their properties derived by KCM:
+	// the infrastructure provider with k0rdent.mirantis.com/provider, e.g. aws, the region
+	// with k0rdent.mirantis.com/region and the minor Kubernetes version with
+	// k0rdent.mirantis.com/kubernetes-version, e.g. 1.29.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	// KubernetesVersion additionally selects the target clusters by the constraint
+	// of their minor Kubernetes version, e.g. ">=1.29".
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// ServiceSpec is spec related to deployment of services.
+	ServiceSpec ServiceSpec `json:"serviceSpec,omitempty"`
+}
+
+// ServiceStatus contains details for the state of services.
+type ServiceStatus struct {
+	// ClusterName is the name of the associated cluster.
+	ClusterName string `json:"clusterName"`
+	// ClusterNamespace is the namespace of the associated cluster.
+	ClusterNamespace string `json:"clusterNamespace,omitempty"`
+	// Conditions contains details for the current state of managed services.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ServiceRevision is a combination of the template and the values a service has been successfully deployed with.
+type ServiceRevision struct {
+	// RecordedAt is the time the revision has been recorded at.
+	RecordedAt metav1.Time `json:"recordedAt"`
+	// Template is the name of the ServiceTemplate.
+	Template string `json:"template"`
+	// Values is the helm values passed to the chart used by the template.
+	Values string `json:"values,omitempty"`
+	// Revision is the sequence number of the revision, starting from 1.
+	Revision int32 `json:"revision"`
+}
+
+// ServiceHistory is the history of the revisions of a service deployed to all the selected clusters.
+type ServiceHistory struct {
+	// Name is the name of the service.
+	Name string `json:"name"`
+	// Namespace is the namespace the service is installed in.
+	Namespace string `json:"namespace"`
+	// Revisions is the list of the last revisions of the service, the latest one is the last.
+	Revisions []ServiceRevision `json:"revisions,omitempty"`
+}
+
+// ServiceConflict is a service deployed to a cluster by several objects along with the object deploying it.
+type ServiceConflict struct {
+	// ClusterName is the name of the cluster, empty in the status of a ClusterDeployment.
+	ClusterName string `json:"clusterName,omitempty"`
+	// ClusterNamespace is the namespace of the cluster, empty in the status of a ClusterDeployment.
+	ClusterNamespace string `json:"clusterNamespace,omitempty"`
+	// Service is the namespace and the name of the service release in the cluster.
+	Service string `json:"service"`
+	// WinnerKind is the kind of the object deploying the service, either ClusterDeployment or MultiClusterService.
+	WinnerKind string `json:"winnerKind"`
+	// WinnerName is the name of the object deploying the service, prefixed with the namespace for a ClusterDeployment.
+	WinnerName string `json:"winnerName"`
+	// Owners is the list of the objects deploying the service in the order of their precedence.
+	Owners []string `json:"owners,omitempty"`
+}
+
+// MultiClusterServiceStatus defines the observed state of MultiClusterService.
+type MultiClusterServiceStatus struct {
+	// Services contains details for the state of services.
+	Services []ServiceStatus `json:"services,omitempty"`
+	// ServiceConflicts is the list of the services of the MultiClusterService also deployed
+	// to the selected clusters by other objects along with the objects deploying them.
+	ServiceConflicts []ServiceConflict `json:"serviceConflicts,omitempty"`
+	// ServiceHistory is the list of the revisions the services have been successfully deployed with to all
+	// the selected clusters, the services are reverted to them with the k0rdent.mirantis.com/service-rollback annotation.
+	ServiceHistory []ServiceHistory `json:"serviceHistory,omitempty"`
+	// Conditions contains details for the current state of the MultiClusterService.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// ObservedGeneration is the last observed generation.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=mcs
+// +kubebuilder:printcolumn:name="Services",type="string",JSONPath=`.status.conditions[?(@.type=="ServicesInReadyState")].message`,description="Number of ready out of total services",priority=0
+// +kubebuilder:printcolumn:name="Clusters",type="string",JSONPath=`.status.conditions[?(@.type=="ClusterInReadyState")].message`,description="Number of ready out of total selected clusters",priority=0
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`,description="Time elapsed since object creation",priority=0
+
+// MultiClusterService is the Schema for the multiclusterservices API
+type MultiClusterService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MultiClusterServiceSpec   `json:"spec,omitempty"`
+	Status MultiClusterServiceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MultiClusterServiceList contains a list of MultiClusterService
+type MultiClusterServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MultiClusterService `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MultiClusterService{}, &MultiClusterServiceList{})
+}
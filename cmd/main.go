@@ -15,11 +15,14 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	hcv2 "github.com/fluxcd/helm-controller/api/v2"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
@@ -28,6 +31,8 @@ import (
 	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	velerov2alpha1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v2alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -35,21 +40,37 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	capioperatorv1 "sigs.k8s.io/cluster-api-operator/api/v1alpha2"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	runtimeserver "sigs.k8s.io/cluster-api/exp/runtime/server"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1alpha1"
+	kcmv1beta1 "github.com/K0rdent/kcm/api/v1beta1"
 	"github.com/K0rdent/kcm/internal/build"
+	"github.com/K0rdent/kcm/internal/connectivity"
 	"github.com/K0rdent/kcm/internal/controller"
+	"github.com/K0rdent/kcm/internal/faultinjection"
+	"github.com/K0rdent/kcm/internal/fleetview"
 	"github.com/K0rdent/kcm/internal/helm"
+	"github.com/K0rdent/kcm/internal/integrations"
+	"github.com/K0rdent/kcm/internal/leaderelection"
+	"github.com/K0rdent/kcm/internal/lifecyclehooks"
 	"github.com/K0rdent/kcm/internal/providers"
 	"github.com/K0rdent/kcm/internal/telemetry"
 	"github.com/K0rdent/kcm/internal/utils"
+	"github.com/K0rdent/kcm/internal/utils/ratelimit"
+	"github.com/K0rdent/kcm/internal/utils/tlsprofile"
+	"github.com/K0rdent/kcm/internal/utils/workloads"
 	kcmwebhook "github.com/K0rdent/kcm/internal/webhook"
+	"github.com/K0rdent/kcm/internal/webhookcerts"
 )
 
 var (
@@ -70,12 +91,14 @@ func init() {
 	// velero deps
 
 	utilruntime.Must(kcmv1.AddToScheme(scheme))
+	utilruntime.Must(kcmv1beta1.AddToScheme(scheme))
 	utilruntime.Must(sourcev1.AddToScheme(scheme))
 	utilruntime.Must(sourcev1beta2.AddToScheme(scheme))
 	utilruntime.Must(hcv2.AddToScheme(scheme))
 	utilruntime.Must(sveltosv1beta1.AddToScheme(scheme))
 	utilruntime.Must(libsveltosv1beta1.AddToScheme(scheme))
 	utilruntime.Must(capioperatorv1.AddToScheme(scheme)) // required only for the mgmt status updates
+	utilruntime.Must(clusterapiv1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -85,6 +108,8 @@ func main() {
 		probeAddr                  string
 		secureMetrics              bool
 		enableHTTP2                bool
+		tlsProfile                 string
+		managementProfile          string
 		defaultRegistryURL         string
 		insecureRegistry           bool
 		registryCredentialsSecret  string
@@ -96,19 +121,54 @@ func main() {
 		kcmTemplatesChartName      string
 		enableTelemetry            bool
 		enableWebhook              bool
+		enableAdmissionPolicies    bool
 		webhookPort                int
 		webhookCertDir             string
+		webhookCertProvider        string
+		webhookCertSecret          string
+		webhookServiceName         string
+		clusterDomain              string
+		runtimeExtensionPort       int
+		fleetViewPort              int
 		pprofBindAddress           string
 		leaderElectionNamespace    string
+		clusterProbeInterval       time.Duration
+		enableFailureInjection     bool
+		workloadList               string
+		leaseDuration              time.Duration
+		renewDeadline              time.Duration
+		retryPeriod                time.Duration
+		releaseLeaseOnCancel       bool
+		gracefulShutdownTimeout    time.Duration
+		syncPeriod                 time.Duration
+		initialSyncQPS             float64
+		initialSyncJitter          time.Duration
 	)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.StringVar(&leaderElectionNamespace, "leader-election-namespace", "", "The namespace to use for leader election.")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"The duration the non-leader replicas wait before acquiring the leadership not renewed by the leader.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+		"The duration the leader retries renewing the leadership before giving it up.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second,
+		"The duration the replicas wait between the attempts of acquiring and renewing the leadership.")
+	flag.BoolVar(&releaseLeaseOnCancel, "leader-elect-release-on-cancel", true,
+		"Release the leadership on shutdown, so another replica takes it over without waiting for the lease duration.")
+	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", 30*time.Second,
+		"The duration the controllers are given to stop before the leadership is released, "+
+			"expected to be shorter than the termination grace period of the pod.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", false,
 		"If set the metrics endpoint is served securely")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&tlsProfile, "tls-profile", kcmv1.TLSProfileDefault,
+		"TLS profile of the webhook server, metrics endpoint and chart downloads, one of Default or Restricted. "+
+			"Restricted is always used if the binary is built in the FIPS 140-3 mode.")
+	flag.StringVar(&managementProfile, "management-profile", kcmv1.ManagementProfileStandard,
+		"Profile of the Management object created upon initial installation, one of Standard or Edge. "+
+			"Edge runs the minimal component set for the resource-constrained management clusters.")
 	flag.StringVar(&defaultRegistryURL, "default-registry-url", "oci://ghcr.io/k0rdent/kcm/charts",
 		"The default registry to download Helm charts from, prefix with oci:// for OCI registries.")
 	flag.StringVar(&registryCredentialsSecret, "registry-creds-secret", "",
@@ -123,11 +183,41 @@ func main() {
 	flag.StringVar(&kcmTemplatesChartName, "kcm-templates-chart-name", "kcm-templates",
 		"The name of the helm chart with KCM Templates.")
 	flag.BoolVar(&enableTelemetry, "enable-telemetry", true, "Collect and send telemetry data.")
+	flag.DurationVar(&clusterProbeInterval, "cluster-probe-interval", time.Minute,
+		"Interval of the API connectivity probing of the deployed clusters, 0 disables the probing.")
 	flag.BoolVar(&enableWebhook, "enable-webhook", true, "Enable admission webhook.")
+	flag.BoolVar(&enableAdmissionPolicies, "enable-admission-policies", false,
+		"Maintain the CEL ValidatingAdmissionPolicies enforcing the structural checks of the admission webhook.")
 	flag.IntVar(&webhookPort, "webhook-port", 9443, "Admission webhook port.")
 	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs/",
 		"Webhook cert dir, only used when webhook-port is specified.")
+	flag.StringVar(&webhookCertProvider, "webhook-cert-provider", webhookcerts.ProviderCertManager,
+		"Provider of the webhook certificates, one of cert-manager or builtin. "+
+			"The builtin provider issues and rotates the certificates with a self-signed CA maintained by the controller.")
+	flag.StringVar(&webhookCertSecret, "webhook-cert-secret", "kcm-webhook-serving-cert",
+		"Secret storing the webhook certificates, only used with the builtin webhook cert provider.")
+	flag.StringVar(&webhookServiceName, "webhook-service-name", "kcm-webhook-service",
+		"Name of the webhook Service, only used with the builtin webhook cert provider.")
+	flag.StringVar(&clusterDomain, "cluster-domain", "cluster.local",
+		"Kubernetes cluster domain, only used with the builtin webhook cert provider.")
+	flag.IntVar(&runtimeExtensionPort, "runtime-extension-port", 0,
+		"Cluster API Runtime Extension port serving the lifecycle hooks with the webhook certificates, 0 disables the Runtime Extension.")
+	flag.IntVar(&fleetViewPort, "fleet-view-port", 0,
+		"Port serving the read-only views of the clusters for the dashboards and the credentials of the fleet kubeconfig "+
+			"with the webhook certificates, 0 disables the fleet views.")
 	flag.StringVar(&pprofBindAddress, "pprof-bind-address", "", "The TCP address that the controller should bind to for serving pprof, \"0\" or empty value disables pprof")
+	flag.BoolVar(&enableFailureInjection, "enable-failure-injection", false,
+		"Enable the injection of failures into the reconciliation of the objects annotated with "+faultinjection.Annotation+". Intended for the e2e testing only.")
+
+	flag.DurationVar(&syncPeriod, "sync-period", 10*time.Hour,
+		"The minimum period the watched objects are reconciled at regardless of their changes.")
+	flag.Float64Var(&initialSyncQPS, "initial-sync-qps", 10,
+		"The rate of the reconciles of the ClusterDeployments existing upon the start of the controller, 0 reconciles them all at once.")
+	flag.DurationVar(&initialSyncJitter, "initial-sync-jitter", 10*time.Second,
+		"The maximum random delay of the reconciles of the ClusterDeployments existing upon the start of the controller.")
+	flag.StringVar(&workloadList, "workloads", workloads.All,
+		"Comma-separated list of the workloads run by the manager, any of webhooks, controllers, backup, templates or all. "+
+			"Allows running the admission webhooks, the core controllers and the heavy subsystems as separate Deployments.")
 
 	opts := zap.Options{
 		Development: true,
@@ -177,37 +267,80 @@ func main() {
 		tlsOpts = append(tlsOpts, disableHTTP2)
 	}
 
+	resolvedTLSProfile, err := tlsprofile.Resolve(tlsProfile)
+	if err != nil {
+		setupLog.Error(err, "invalid TLS profile")
+		os.Exit(1)
+	}
+	setupLog.Info("using TLS profile", "profile", resolvedTLSProfile)
+	tlsOpts = append(tlsOpts, func(c *tls.Config) {
+		tlsprofile.Apply(resolvedTLSProfile, c)
+	})
+	helm.SetClientTLSProfile(resolvedTLSProfile)
+
+	workloadSet, err := workloads.Parse(workloadList)
+	if err != nil {
+		setupLog.Error(err, "invalid workloads")
+		os.Exit(1)
+	}
+	setupLog.Info("running workloads", "workloads", workloadSet.String())
+
+	// the webhooks are served only by the manager running them, though the validation
+	// is enabled for the whole installation, e.g. for the Management controller
+	serveWebhooks := enableWebhook && workloadSet.Has(workloads.Webhooks)
+	// the builtin webhook certificates are rotated by the leader of the manager serving the webhooks
+	rotateWebhookCerts := webhookCertProvider == webhookcerts.ProviderBuiltin && (serveWebhooks || !enableWebhook)
+
+	if enableFailureInjection {
+		setupLog.Info("failure injection is enabled, not intended for production use")
+		faultinjection.SetEnabled(true)
+	}
+
+	if err := leaderelection.ValidateTimings(leaseDuration, renewDeadline, retryPeriod); err != nil {
+		setupLog.Error(err, "invalid leader election timings")
+		os.Exit(1)
+	}
+
+	// the lease is read with the live reader of the manager once it is created
+	leaderStatus := &leaderelection.Handler{}
+
 	managerOpts := ctrl.Options{
 		Scheme: scheme,
 		Metrics: metricsserver.Options{
 			BindAddress:   metricsAddr,
 			SecureServing: secureMetrics,
 			TLSOpts:       tlsOpts,
+			ExtraHandlers: map[string]http.Handler{leaderelection.StatusPath: leaderStatus},
 		},
 		HealthProbeBindAddress:  probeAddr,
-		LeaderElection:          true,
-		LeaderElectionID:        "31c555b4.k0rdent.mirantis.com",
+		LeaderElection:          workloadSet.NeedLeaderElection() || rotateWebhookCerts,
+		LeaderElectionID:        workloadSet.LeaderElectionID("31c555b4.k0rdent.mirantis.com"),
 		LeaderElectionNamespace: leaderElectionNamespace,
-		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
-		// when the Manager ends. This requires the binary to immediately end when the
-		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
-		// speeds up voluntary leader transitions as the new leader don't have to wait
-		// LeaseDuration time first.
-		//
-		// In the default scaffold provided, the program ends immediately after
-		// the manager stops, so would be fine to enable this option. However,
-		// if you are doing or is intended to do any operation such as perform cleanups
-		// after the manager stops then its usage might be unsafe.
-		// LeaderElectionReleaseOnCancel: true,
+		LeaseDuration:           &leaseDuration,
+		RenewDeadline:           &renewDeadline,
+		RetryPeriod:             &retryPeriod,
+		// the binary ends immediately once the manager is stopped, hence it is safe
+		// to release the leadership, so the rolling restarts hand it over right away
+		LeaderElectionReleaseOnCancel: releaseLeaseOnCancel,
+		GracefulShutdownTimeout:       &gracefulShutdownTimeout,
 
 		PprofBindAddress: pprofBindAddress,
 
 		Cache: cache.Options{
+			SyncPeriod:       &syncPeriod,
 			DefaultTransform: cache.TransformStripManagedFields(),
 		},
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				// pods are only read for the results of the config validation Jobs
+				// and deployments for the versions of the sveltos controllers,
+				// caching them for the whole cluster is not worth it
+				DisableFor: []client.Object{&corev1.Pod{}, &appsv1.Deployment{}},
+			},
+		},
 	}
 
-	if enableWebhook {
+	if serveWebhooks {
 		managerOpts.WebhookServer = webhook.NewServer(webhook.Options{
 			Port:    webhookPort,
 			TLSOpts: tlsOpts,
@@ -221,120 +354,235 @@ func main() {
 		os.Exit(1)
 	}
 
+	leaderStatus.Client = mgr.GetAPIReader()
+	leaderStatus.Elected = mgr.Elected()
+	if managerOpts.LeaderElection {
+		leaseNamespace := leaderElectionNamespace
+		if leaseNamespace == "" {
+			leaseNamespace = utils.CurrentNamespace()
+		}
+		leaderStatus.Lease = client.ObjectKey{Namespace: leaseNamespace, Name: managerOpts.LeaderElectionID}
+	}
+
 	ctx := ctrl.SetupSignalHandler()
 	if err = kcmv1.SetupIndexers(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to setup indexers")
 		os.Exit(1)
 	}
 
-	currentNamespace := utils.CurrentNamespace()
-
-	templateReconciler := controller.TemplateReconciler{
-		Client:           mgr.GetClient(),
-		CreateManagement: createManagement,
-		SystemNamespace:  currentNamespace,
-		DefaultRegistryConfig: helm.DefaultRegistryConfig{
-			URL:               defaultRegistryURL,
-			RepoType:          determinedRepositoryType,
-			CredentialsSecret: registryCredentialsSecret,
-			Insecure:          insecureRegistry,
-		},
-	}
-
-	if err = (&controller.ClusterTemplateReconciler{
-		TemplateReconciler: templateReconciler,
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ClusterTemplate")
-		os.Exit(1)
-	}
-	if err = (&controller.ServiceTemplateReconciler{
-		TemplateReconciler: templateReconciler,
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ServiceTemplate")
-		os.Exit(1)
-	}
-	if err = (&controller.ProviderTemplateReconciler{
-		TemplateReconciler: templateReconciler,
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ProviderTemplate")
-		os.Exit(1)
-	}
-	if err = (&controller.ManagementReconciler{
-		SystemNamespace:        currentNamespace,
-		CreateAccessManagement: createAccessManagement,
-		IsDisabledValidation:   !enableWebhook,
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Management")
-		os.Exit(1)
+	// the subsystems of the missing integrations are disabled by the controllers, e.g. on partial installs
+	detectedIntegrations, err := integrations.Detect(mgr.GetRESTMapper())
+	if err != nil {
+		setupLog.Error(err, "unable to detect optional integrations")
 	}
-	if err = (&controller.AccessManagementReconciler{
-		Client:          mgr.GetClient(),
-		SystemNamespace: currentNamespace,
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "AccessManagement")
-		os.Exit(1)
+	for name, status := range detectedIntegrations {
+		setupLog.Info("detected optional integration", "integration", name, "available", status.Available, "message", status.Message)
 	}
 
-	templateChainReconciler := controller.TemplateChainReconciler{
-		Client:          mgr.GetClient(),
-		SystemNamespace: currentNamespace,
-	}
-	if err = (&controller.ClusterTemplateChainReconciler{
-		TemplateChainReconciler: templateChainReconciler,
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ClusterTemplateChain")
-		os.Exit(1)
-	}
-	if err = (&controller.ServiceTemplateChainReconciler{
-		TemplateChainReconciler: templateChainReconciler,
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ServiceTemplateChain")
-		os.Exit(1)
-	}
+	currentNamespace := utils.CurrentNamespace()
 
-	if err = (&controller.ReleaseReconciler{
-		Client:                mgr.GetClient(),
-		Config:                mgr.GetConfig(),
-		CreateManagement:      createManagement,
-		CreateRelease:         createRelease,
-		CreateTemplates:       createTemplates,
-		KCMTemplatesChartName: kcmTemplatesChartName,
-		SystemNamespace:       currentNamespace,
-		DefaultRegistryConfig: helm.DefaultRegistryConfig{
-			URL:               defaultRegistryURL,
-			RepoType:          determinedRepositoryType,
-			CredentialsSecret: registryCredentialsSecret,
-			Insecure:          insecureRegistry,
-		},
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Release")
-		os.Exit(1)
+	if workloadSet.Has(workloads.Templates) {
+		templateReconciler := controller.TemplateReconciler{
+			Client:           mgr.GetClient(),
+			CreateManagement: createManagement,
+			SystemNamespace:  currentNamespace,
+			DefaultRegistryConfig: helm.DefaultRegistryConfig{
+				URL:               defaultRegistryURL,
+				RepoType:          determinedRepositoryType,
+				CredentialsSecret: registryCredentialsSecret,
+				Insecure:          insecureRegistry,
+			},
+		}
+
+		if err = (&controller.ClusterTemplateReconciler{
+			TemplateReconciler: templateReconciler,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterTemplate")
+			os.Exit(1)
+		}
+		if err = (&controller.ServiceTemplateReconciler{
+			TemplateReconciler: templateReconciler,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ServiceTemplate")
+			os.Exit(1)
+		}
+		if err = (&controller.ProviderTemplateReconciler{
+			TemplateReconciler: templateReconciler,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ProviderTemplate")
+			os.Exit(1)
+		}
+
+		templateChainReconciler := controller.TemplateChainReconciler{
+			Client:          mgr.GetClient(),
+			SystemNamespace: currentNamespace,
+		}
+		if err = (&controller.ClusterTemplateChainReconciler{
+			TemplateChainReconciler: templateChainReconciler,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterTemplateChain")
+			os.Exit(1)
+		}
+		if err = (&controller.ServiceTemplateChainReconciler{
+			TemplateChainReconciler: templateChainReconciler,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ServiceTemplateChain")
+			os.Exit(1)
+		}
 	}
 
-	if enableTelemetry {
-		if err = mgr.Add(&telemetry.Tracker{
+	if workloadSet.Has(workloads.Controllers) {
+		if err = (&controller.ManagementReconciler{
+			SystemNamespace:        currentNamespace,
+			CreateAccessManagement: createAccessManagement,
+			IsDisabledValidation:   !enableWebhook,
+			AdmissionPolicies:      enableAdmissionPolicies,
+			ClusterDeploymentInitialSync: ratelimit.InitialSyncOptions{
+				QPS:    initialSyncQPS,
+				Jitter: initialSyncJitter,
+			},
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Management")
+			os.Exit(1)
+		}
+		if err = (&controller.AccessManagementReconciler{
 			Client:          mgr.GetClient(),
 			SystemNamespace: currentNamespace,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "AccessManagement")
+			os.Exit(1)
+		}
+
+		if err = (&controller.ReleaseReconciler{
+			Client:                mgr.GetClient(),
+			Config:                mgr.GetConfig(),
+			CreateManagement:      createManagement,
+			ManagementProfile:     managementProfile,
+			CreateRelease:         createRelease,
+			CreateTemplates:       createTemplates,
+			KCMTemplatesChartName: kcmTemplatesChartName,
+			SystemNamespace:       currentNamespace,
+			DefaultRegistryConfig: helm.DefaultRegistryConfig{
+				URL:               defaultRegistryURL,
+				RepoType:          determinedRepositoryType,
+				CredentialsSecret: registryCredentialsSecret,
+				Insecure:          insecureRegistry,
+			},
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Release")
+			os.Exit(1)
+		}
+
+		if enableTelemetry {
+			if err = mgr.Add(&telemetry.Tracker{
+				Client:          mgr.GetClient(),
+				SystemNamespace: currentNamespace,
+			}); err != nil {
+				setupLog.Error(err, "unable to create telemetry tracker")
+				os.Exit(1)
+			}
+		}
+
+		if err = mgr.Add(&telemetry.FleetReporter{
+			Client:     mgr.GetClient(),
+			TLSProfile: resolvedTLSProfile,
 		}); err != nil {
-			setupLog.Error(err, "unable to create telemetry tracker")
+			setupLog.Error(err, "unable to create fleet telemetry reporter")
 			os.Exit(1)
 		}
-	}
 
-	if err = (&controller.CredentialReconciler{
-		SystemNamespace: currentNamespace,
-		Client:          mgr.GetClient(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Credential")
-		os.Exit(1)
+		if clusterProbeInterval > 0 {
+			if err = mgr.Add(&connectivity.Prober{
+				Client:   mgr.GetClient(),
+				Interval: clusterProbeInterval,
+			}); err != nil {
+				setupLog.Error(err, "unable to create connectivity prober")
+				os.Exit(1)
+			}
+		}
+
+		if err = (&controller.CredentialReconciler{
+			SystemNamespace: currentNamespace,
+			Client:          mgr.GetClient(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Credential")
+			os.Exit(1)
+		}
+
+		if err = (&controller.VIPPoolReconciler{
+			Client: mgr.GetClient(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "VIPPool")
+			os.Exit(1)
+		}
+
+		if err = (&controller.OSImageReconciler{
+			Client:     mgr.GetClient(),
+			HTTPClient: &http.Client{Timeout: time.Minute},
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "OSImage")
+			os.Exit(1)
+		}
+
+		if err = (&controller.NodeImageRolloutReconciler{
+			Client: mgr.GetClient(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "NodeImageRollout")
+			os.Exit(1)
+		}
+
+		if err = (&controller.SecretPropagationReconciler{
+			Client: mgr.GetClient(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SecretPropagation")
+			os.Exit(1)
+		}
+
+		if err = (&controller.StateManagementProviderReconciler{
+			Client: mgr.GetClient(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "StateManagementProvider")
+			os.Exit(1)
+		}
+
+		if err = (&controller.FleetSummaryReconciler{
+			Client: mgr.GetClient(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "FleetSummary")
+			os.Exit(1)
+		}
+
+		if err = (&controller.FleetKubeconfigReconciler{
+			Client: mgr.GetClient(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "FleetKubeconfig")
+			os.Exit(1)
+		}
+
+		if err = (&controller.ClusterRequestReconciler{
+			Client: mgr.GetClient(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterRequest")
+			os.Exit(1)
+		}
+
+		if err = (&controller.GitOpsExportReconciler{
+			Client:          mgr.GetClient(),
+			SystemNamespace: currentNamespace,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "GitOpsExport")
+			os.Exit(1)
+		}
 	}
 
-	if err = (&controller.ManagementBackupReconciler{
-		Client:          mgr.GetClient(),
-		SystemNamespace: currentNamespace,
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ManagementBackup")
-		os.Exit(1)
+	if workloadSet.Has(workloads.Backup) {
+		if err = (&controller.ManagementBackupReconciler{
+			Client:          mgr.GetClient(),
+			SystemNamespace: currentNamespace,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ManagementBackup")
+			os.Exit(1)
+		}
 	}
 	// +kubebuilder:scaffold:builder
 
@@ -347,13 +595,45 @@ func main() {
 		os.Exit(1)
 	}
 
-	if enableWebhook {
+	if serveWebhooks {
+		// the admission availability depends only on the webhook server of the replica
+		if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+			setupLog.Error(err, "unable to set up webhook ready check")
+			os.Exit(1)
+		}
 		if err := setupWebhooks(mgr, currentNamespace, validateClusterUpgradePath); err != nil {
 			setupLog.Error(err, "failed to setup webhooks")
 			os.Exit(1)
 		}
 	}
 
+	if serveWebhooks || runtimeExtensionPort > 0 || fleetViewPort > 0 {
+		if err := setupWebhookCerts(ctx, mgr, webhookCertProvider, rotateWebhookCerts, &webhookcerts.Rotator{
+			SecretName:    webhookCertSecret,
+			Namespace:     currentNamespace,
+			ServiceName:   webhookServiceName,
+			ClusterDomain: clusterDomain,
+			CertDir:       webhookCertDir,
+		}); err != nil {
+			setupLog.Error(err, "failed to setup webhook certificates")
+			os.Exit(1)
+		}
+	}
+
+	if runtimeExtensionPort > 0 {
+		if err := setupRuntimeExtension(mgr, runtimeExtensionPort, webhookCertDir, tlsOpts); err != nil {
+			setupLog.Error(err, "failed to setup runtime extension")
+			os.Exit(1)
+		}
+	}
+
+	if fleetViewPort > 0 {
+		if err := setupFleetView(mgr, fleetViewPort, webhookCertDir, tlsOpts); err != nil {
+			setupLog.Error(err, "failed to setup fleet view")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -361,8 +641,80 @@ func main() {
 	}
 }
 
+// setupWebhookCerts provisions the webhook certificates before the webhook server is started
+// and keeps them in sync if the builtin provider is selected, rotating them if requested.
+func setupWebhookCerts(ctx context.Context, mgr ctrl.Manager, provider string, rotate bool, rotator *webhookcerts.Rotator) error {
+	switch provider {
+	case webhookcerts.ProviderCertManager:
+		return nil
+	case webhookcerts.ProviderBuiltin:
+	default:
+		return fmt.Errorf("unsupported webhook cert provider %q", provider)
+	}
+
+	// the manager cache is not started yet
+	cl, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	rotator.Client = cl
+
+	if err := rotator.Bootstrap(ctx); err != nil {
+		return err
+	}
+
+	if rotate {
+		if err := mgr.Add(rotator); err != nil {
+			return err
+		}
+	}
+	return mgr.Add(rotator.Syncer())
+}
+
+func setupRuntimeExtension(mgr ctrl.Manager, port int, certDir string, tlsOpts []func(*tls.Config)) error {
+	catalog := runtimecatalog.New()
+	if err := runtimehooksv1.AddToCatalog(catalog); err != nil {
+		return fmt.Errorf("failed to add lifecycle hooks to the catalog: %w", err)
+	}
+
+	srv, err := runtimeserver.New(runtimeserver.Options{
+		Catalog: catalog,
+		Port:    port,
+		CertDir: certDir,
+		TLSOpts: tlsOpts,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create runtime extension server: %w", err)
+	}
+
+	if err := (&lifecyclehooks.Handler{Client: mgr.GetClient()}).Register(srv); err != nil {
+		return err
+	}
+
+	return mgr.Add(srv)
+}
+
+func setupFleetView(mgr ctrl.Manager, port int, certDir string, tlsOpts []func(*tls.Config)) error {
+	srv := webhook.NewServer(webhook.Options{
+		Port:    port,
+		CertDir: certDir,
+		TLSOpts: tlsOpts,
+	})
+	authorizer := &fleetview.ReviewAuthorizer{Client: mgr.GetClient()}
+	srv.Register(fleetview.ClustersPath, &fleetview.Handler{
+		Client:     mgr.GetClient(),
+		Authorizer: authorizer,
+	})
+	srv.Register(fleetview.CredentialsPath, &fleetview.CredentialsHandler{
+		Client:     mgr.GetClient(),
+		Authorizer: authorizer,
+	})
+
+	return mgr.Add(srv)
+}
+
 func setupWebhooks(mgr ctrl.Manager, currentNamespace string, validateClusterUpgradePath bool) error {
-	if err := (&kcmwebhook.ClusterDeploymentValidator{ValidateClusterUpgradePath: validateClusterUpgradePath}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&kcmwebhook.ClusterDeploymentValidator{SystemNamespace: currentNamespace, ValidateClusterUpgradePath: validateClusterUpgradePath}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "ClusterDeployment")
 		return err
 	}
@@ -406,5 +758,21 @@ func setupWebhooks(mgr ctrl.Manager, currentNamespace string, validateClusterUpg
 		setupLog.Error(err, "unable to create webhook", "webhook", "Release")
 		return err
 	}
+	if err := (&kcmwebhook.VIPPoolValidator{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "VIPPool")
+		return err
+	}
+	if err := (&kcmwebhook.ClusterRequestValidator{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "ClusterRequest")
+		return err
+	}
+	if err := (&kcmwebhook.ConfigPolicyValidator{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "ConfigPolicy")
+		return err
+	}
+	if err := (&kcmwebhook.ServiceBundleValidator{SystemNamespace: currentNamespace}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "ServiceBundle")
+		return err
+	}
 	return nil
 }
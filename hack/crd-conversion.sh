@@ -0,0 +1,52 @@
+#!/bin/sh
+# Copyright 2025
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Configures the conversion webhook in the generated CRDs serving multiple versions.
+# The helm templates are quoted to keep the CRDs valid YAML for envtest.
+
+set -eu
+
+# Directory containing the generated KCM CRDs
+CRDS_DIR=${CRDS_DIR:-templates/provider/kcm/templates/crds}
+
+for crd in "$CRDS_DIR"/*.yaml; do
+    if [ "$(grep -c '^    name: v1' "$crd")" -lt 2 ] || grep -q '^  conversion:' "$crd"; then
+        continue
+    fi
+
+    awk '
+    /^    controller-gen.kubebuilder.io\/version:/ {
+        print
+        print "    cert-manager.io/inject-ca-from: \x27{{ include \"kcm.webhook.certNamespace\" . }}/{{ include \"kcm.webhook.certName\" . }}\x27"
+        next
+    }
+    /^spec:$/ {
+        print
+        print "  conversion:"
+        print "    strategy: Webhook"
+        print "    webhook:"
+        print "      clientConfig:"
+        print "        service:"
+        print "          name: \x27{{ include \"kcm.webhook.serviceName\" . }}\x27"
+        print "          namespace: \x27{{ include \"kcm.webhook.serviceNamespace\" . }}\x27"
+        print "          path: /convert"
+        print "      conversionReviewVersions:"
+        print "      - v1"
+        next
+    }
+    { print }
+    ' "$crd" > "$crd.tmp"
+    mv "$crd.tmp" "$crd"
+done
@@ -0,0 +1,95 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package addonversions selects the versions of the cloud-controller-manager and the CSI driver
+// add-ons compatible with the Kubernetes version of the cluster from the versions shipped with the Release.
+package addonversions
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// ValuesKey is the key of the ClusterTemplate values with the versions of the add-ons.
+const ValuesKey = "addonVersions"
+
+// Validate validates the add-on versions of the given Release.
+func Validate(release *kcm.Release) error {
+	var errs error
+	for i, versions := range release.Spec.AddonVersions {
+		if _, err := semver.NewConstraint(versions.KubernetesVersions); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid Kubernetes versions %q of the add-on versions %d: %w", versions.KubernetesVersions, i, err))
+		}
+	}
+
+	return errs
+}
+
+// Select returns the first add-on versions of the Release compatible with the infrastructure provider
+// and the Kubernetes version of the given ClusterTemplate or nil if there are no compatible ones.
+func Select(release *kcm.Release, template *kcm.ClusterTemplate) (*kcm.AddonVersions, error) {
+	if len(release.Spec.AddonVersions) == 0 || template.Status.KubernetesVersion == "" {
+		return nil, nil
+	}
+
+	version, err := semver.NewVersion(template.Status.KubernetesVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Kubernetes version %s of the ClusterTemplate %s: %w", template.Status.KubernetesVersion, template.Name, err)
+	}
+	// the build metadata, e.g. "+k0s.0", and the prerelease are irrelevant for the compatibility
+	core, _ := version.SetMetadata("")
+	core, _ = core.SetPrerelease("")
+
+	for i := range release.Spec.AddonVersions {
+		versions := &release.Spec.AddonVersions[i]
+		if !slices.Contains(template.Status.Providers, versions.Provider) {
+			continue
+		}
+
+		constraint, err := semver.NewConstraint(versions.KubernetesVersions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Kubernetes versions %q of the add-on versions of the Release %s: %w", versions.KubernetesVersions, release.Name, err)
+		}
+		if constraint.Check(&core) {
+			return versions, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Values returns the ClusterTemplate values with the given add-on versions.
+func Values(versions *kcm.AddonVersions) map[string]any {
+	values := make(map[string]any)
+	for key, version := range map[string]*kcm.AddonVersion{
+		"cloudControllerManager": versions.CloudControllerManager,
+		"csiDriver":              versions.CSIDriver,
+	} {
+		if version == nil {
+			continue
+		}
+		addon := map[string]any{"chartVersion": version.ChartVersion}
+		if version.ImageTag != "" {
+			addon["imageTag"] = version.ImageTag
+		}
+		values[key] = addon
+	}
+
+	return values
+}
@@ -0,0 +1,112 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package addonversions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestSelect(t *testing.T) {
+	release := &kcm.Release{Spec: kcm.ReleaseSpec{AddonVersions: []kcm.AddonVersions{
+		{
+			Provider:               "infrastructure-aws",
+			KubernetesVersions:     "<1.31.0",
+			CloudControllerManager: &kcm.AddonVersion{ChartVersion: "0.0.8", ImageTag: "v1.30.3"},
+		},
+		{
+			Provider:               "infrastructure-aws",
+			KubernetesVersions:     ">=1.31.0 <1.32.0",
+			CloudControllerManager: &kcm.AddonVersion{ChartVersion: "0.0.9", ImageTag: "v1.31.1"},
+		},
+		{
+			Provider:           "infrastructure-azure",
+			KubernetesVersions: ">=1.31.0",
+			CSIDriver:          &kcm.AddonVersion{ChartVersion: "1.31.0"},
+		},
+	}}}
+
+	for _, tc := range []struct {
+		name              string
+		providers         kcm.Providers
+		kubernetesVersion string
+		expected          *kcm.AddonVersions
+		err               string
+	}{
+		{
+			name:              "matching provider and version",
+			providers:         kcm.Providers{"infrastructure-aws", "control-plane-k0sproject-k0smotron"},
+			kubernetesVersion: "v1.31.5+k0s.0",
+			expected:          &release.Spec.AddonVersions[1],
+		},
+		{
+			name:              "older version",
+			providers:         kcm.Providers{"infrastructure-aws"},
+			kubernetesVersion: "v1.30.4",
+			expected:          &release.Spec.AddonVersions[0],
+		},
+		{
+			name:              "no matching version",
+			providers:         kcm.Providers{"infrastructure-aws"},
+			kubernetesVersion: "v1.32.1+k0s.0",
+		},
+		{
+			name:              "no matching provider",
+			providers:         kcm.Providers{"infrastructure-vsphere"},
+			kubernetesVersion: "v1.31.5+k0s.0",
+		},
+		{
+			name:      "unknown version",
+			providers: kcm.Providers{"infrastructure-aws"},
+		},
+		{
+			name:              "invalid version",
+			providers:         kcm.Providers{"infrastructure-aws"},
+			kubernetesVersion: "latest",
+			err:               "failed to parse Kubernetes version latest",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			template := &kcm.ClusterTemplate{Status: kcm.ClusterTemplateStatus{
+				Providers:         tc.providers,
+				KubernetesVersion: tc.kubernetesVersion,
+			}}
+
+			versions, err := Select(release, template)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, versions)
+		})
+	}
+}
+
+func TestValues(t *testing.T) {
+	values := Values(&kcm.AddonVersions{
+		CloudControllerManager: &kcm.AddonVersion{ChartVersion: "0.0.9", ImageTag: "v1.31.1"},
+		CSIDriver:              &kcm.AddonVersion{ChartVersion: "2.33.0"},
+	})
+	require.Equal(t, map[string]any{
+		"cloudControllerManager": map[string]any{"chartVersion": "0.0.9", "imageTag": "v1.31.1"},
+		"csiDriver":              map[string]any{"chartVersion": "2.33.0"},
+	}, values)
+
+	require.Empty(t, Values(&kcm.AddonVersions{}))
+}
@@ -0,0 +1,262 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admissionpolicies generates the CEL ValidatingAdmissionPolicies enforcing the structural subset
+// of the webhook validations, the checks of the fields of the admitted object alone, so those keep working
+// even if the webhook is not available. The webhook still performs the same checks, hence those are enforced
+// on the clusters not serving the ValidatingAdmissionPolicies API as well. The checks requiring lookups
+// of other objects are left to the webhook.
+// The enum, required and immutable fields are enforced by the schemas of the CustomResourceDefinitions.
+package admissionpolicies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const namePrefix = "kcm-"
+
+// check is a structural validation of the objects of the given resources.
+type check struct {
+	name       string
+	resources  []string
+	operations []admissionregistrationv1.OperationType
+	validation admissionregistrationv1.Validation
+}
+
+var (
+	createUpdate = []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+
+	checks = []check{
+		{
+			name:       "templatechains",
+			resources:  []string{"clustertemplatechains", "servicetemplatechains"},
+			operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			validation: admissionregistrationv1.Validation{
+				Expression: `!has(object.spec.supportedTemplates) ||
+object.spec.supportedTemplates.all(t, !has(t.availableUpgrades) ||
+  t.availableUpgrades.all(u, object.spec.supportedTemplates.exists(s, s.name == u.name)))`,
+				Message: "the templates allowed for upgrade must be present in the list of '.spec.supportedTemplates'",
+			},
+		},
+		{
+			name:       "clusterrequests-decision",
+			resources:  []string{"clusterrequests", "clusterrequests/status"},
+			operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Update},
+			validation: admissionregistrationv1.Validation{
+				Expression: `!has(oldObject.status) || !has(oldObject.status.approval) ||
+(object.spec == oldObject.spec && has(object.status) && has(object.status.approval) &&
+  object.status.approval == oldObject.status.approval)`,
+				Message: "ClusterRequest spec and decision cannot be changed once the decision has been made",
+			},
+		},
+		{
+			name:       "clusterrequests-credential",
+			resources:  []string{"clusterrequests", "clusterrequests/status"},
+			operations: createUpdate,
+			validation: admissionregistrationv1.Validation{
+				Expression: `!has(object.status) || !has(object.status.approval) ||
+object.status.approval.decision != '` + kcm.ClusterRequestDecisionApproved + `' ||
+(has(object.spec.credential) && object.spec.credential != '') ||
+(has(object.status.approval.credential) && object.status.approval.credential != '')`,
+				Message: "the credential must be set either in the request or in the approval",
+			},
+		},
+		{
+			name:       "clusterdeployments-maintenancewindows",
+			resources:  []string{"clusterdeployments"},
+			operations: createUpdate,
+			validation: admissionregistrationv1.Validation{
+				Expression: `!has(object.spec.lifecycleHooks) || !has(object.spec.lifecycleHooks.maintenanceWindows) ||
+object.spec.lifecycleHooks.maintenanceWindows.all(w, duration(w.duration) > duration('0s'))`,
+				Message: "maintenance window duration must be positive",
+			},
+		},
+		{
+			name:       "managements-profile",
+			resources:  []string{"managements"},
+			operations: createUpdate,
+			validation: admissionregistrationv1.Validation{
+				Expression: `!has(object.spec.profile) || object.spec.profile != '` + kcm.ManagementProfileEdge + `' ||
+!has(object.spec.serviceDelivery) || object.spec.serviceDelivery != '` + kcm.ServiceDeliverySveltos + `'`,
+				Message: "the " + kcm.ServiceDeliverySveltos + " service delivery is not supported with the " + kcm.ManagementProfileEdge + " profile",
+			},
+		},
+	}
+)
+
+// Policies returns the ValidatingAdmissionPolicies of the structural checks.
+func Policies() []*admissionregistrationv1.ValidatingAdmissionPolicy {
+	policies := make([]*admissionregistrationv1.ValidatingAdmissionPolicy, 0, len(checks))
+	for _, c := range checks {
+		policy := &admissionregistrationv1.ValidatingAdmissionPolicy{ObjectMeta: objectMeta(c.name)}
+		mutatePolicy(policy, c)
+		policies = append(policies, policy)
+	}
+
+	return policies
+}
+
+// Bindings returns the ValidatingAdmissionPolicyBindings denying the requests not passing the structural checks.
+func Bindings() []*admissionregistrationv1.ValidatingAdmissionPolicyBinding {
+	bindings := make([]*admissionregistrationv1.ValidatingAdmissionPolicyBinding, 0, len(checks))
+	for _, c := range checks {
+		binding := &admissionregistrationv1.ValidatingAdmissionPolicyBinding{ObjectMeta: objectMeta(c.name)}
+		mutateBinding(binding)
+		bindings = append(bindings, binding)
+	}
+
+	return bindings
+}
+
+// Reconcile creates or updates the ValidatingAdmissionPolicies and their bindings owned by the given owner
+// and removes the stale ones. Nothing is done if the cluster does not serve the ValidatingAdmissionPolicies API,
+// the checks are enforced by the webhook only in that case.
+func Reconcile(ctx context.Context, cl client.Client, owner *metav1.OwnerReference) error {
+	l := ctrl.LoggerFrom(ctx)
+
+	names := make([]string, 0, len(checks))
+	for _, c := range checks {
+		policy := &admissionregistrationv1.ValidatingAdmissionPolicy{ObjectMeta: objectMeta(c.name)}
+		binding := &admissionregistrationv1.ValidatingAdmissionPolicyBinding{ObjectMeta: objectMeta(c.name)}
+		names = append(names, policy.Name)
+
+		for _, obj := range []struct {
+			client.Object
+			mutate func()
+		}{
+			{policy, func() { mutatePolicy(policy, c) }},
+			{binding, func() { mutateBinding(binding) }},
+		} {
+			operation, err := controllerutil.CreateOrUpdate(ctx, cl, obj.Object, func() error {
+				setOwner(obj.Object, owner)
+				obj.mutate()
+				return nil
+			})
+			if apimeta.IsNoMatchError(err) {
+				l.Info("ValidatingAdmissionPolicies are not supported by the cluster, the structural checks are enforced by the webhook only")
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to reconcile %T %s: %w", obj.Object, obj.GetName(), err)
+			}
+			if operation == controllerutil.OperationResultCreated || operation == controllerutil.OperationResultUpdated {
+				l.Info("Successfully mutated admission policy object", "object", obj.GetName(), "operation_result", operation)
+			}
+		}
+	}
+
+	return deleteStale(ctx, cl, names)
+}
+
+// Remove deletes all the ValidatingAdmissionPolicies and their bindings of the structural checks.
+// Nothing is done if the cluster does not serve the ValidatingAdmissionPolicies API.
+func Remove(ctx context.Context, cl client.Client) error {
+	if err := deleteStale(ctx, cl, nil); err != nil && !apimeta.IsNoMatchError(err) {
+		return err
+	}
+
+	return nil
+}
+
+// deleteStale deletes the ValidatingAdmissionPolicies and their bindings of the checks no longer generated.
+func deleteStale(ctx context.Context, cl client.Client, names []string) error {
+	managed := client.MatchingLabels{kcm.KCMManagedLabelKey: kcm.KCMManagedLabelValue}
+
+	policies := new(admissionregistrationv1.ValidatingAdmissionPolicyList)
+	if err := cl.List(ctx, policies, managed); err != nil {
+		return fmt.Errorf("failed to list ValidatingAdmissionPolicies: %w", err)
+	}
+	bindings := new(admissionregistrationv1.ValidatingAdmissionPolicyBindingList)
+	if err := cl.List(ctx, bindings, managed); err != nil {
+		return fmt.Errorf("failed to list ValidatingAdmissionPolicyBindings: %w", err)
+	}
+
+	var errs error
+	for i := range policies.Items {
+		if !slices.Contains(names, policies.Items[i].Name) {
+			errs = errors.Join(errs, client.IgnoreNotFound(cl.Delete(ctx, &policies.Items[i])))
+		}
+	}
+	for i := range bindings.Items {
+		if !slices.Contains(names, bindings.Items[i].Name) {
+			errs = errors.Join(errs, client.IgnoreNotFound(cl.Delete(ctx, &bindings.Items[i])))
+		}
+	}
+
+	return errs
+}
+
+func mutatePolicy(policy *admissionregistrationv1.ValidatingAdmissionPolicy, c check) {
+	failurePolicy := admissionregistrationv1.Fail
+	matchPolicy := admissionregistrationv1.Equivalent
+
+	policy.Spec = admissionregistrationv1.ValidatingAdmissionPolicySpec{
+		FailurePolicy: &failurePolicy,
+		MatchConstraints: &admissionregistrationv1.MatchResources{
+			MatchPolicy: &matchPolicy,
+			ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{{
+				RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+					Operations: c.operations,
+					Rule: admissionregistrationv1.Rule{
+						APIGroups:   []string{kcm.GroupVersion.Group},
+						APIVersions: []string{kcm.GroupVersion.Version},
+						Resources:   c.resources,
+					},
+				},
+			}},
+		},
+		Validations: []admissionregistrationv1.Validation{c.validation},
+	}
+}
+
+func mutateBinding(binding *admissionregistrationv1.ValidatingAdmissionPolicyBinding) {
+	binding.Spec = admissionregistrationv1.ValidatingAdmissionPolicyBindingSpec{
+		PolicyName:        binding.Name,
+		ValidationActions: []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny},
+	}
+}
+
+func setOwner(obj client.Object, owner *metav1.OwnerReference) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[kcm.KCMManagedLabelKey] = kcm.KCMManagedLabelValue
+	obj.SetLabels(labels)
+
+	if owner != nil {
+		obj.SetOwnerReferences([]metav1.OwnerReference{*owner})
+	}
+}
+
+func objectMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name: namePrefix + name,
+		Labels: map[string]string{
+			kcm.KCMManagedLabelKey: kcm.KCMManagedLabelValue,
+		},
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissionpolicies
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// evaluate evaluates the validation of the given check against the given objects the way the API server does.
+func evaluate(t *testing.T, name string, object, oldObject runtime.Object) bool {
+	t.Helper()
+
+	var validation string
+	for _, c := range checks {
+		if c.name == name {
+			validation = c.validation.Expression
+		}
+	}
+	if validation == "" {
+		t.Fatalf("no check %s", name)
+	}
+
+	env, err := cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		cel.Variable("oldObject", cel.DynType),
+	)
+	if err != nil {
+		t.Fatalf("failed to create CEL environment: %v", err)
+	}
+	ast, iss := env.Compile(validation)
+	if iss.Err() != nil {
+		t.Fatalf("failed to compile %s: %v", name, iss.Err())
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		t.Fatalf("failed to build program %s: %v", name, err)
+	}
+
+	vars := map[string]any{"object": toUnstructured(t, object), "oldObject": toUnstructured(t, oldObject)}
+	out, _, err := program.Eval(vars)
+	if err != nil {
+		t.Fatalf("failed to evaluate %s: %v", name, err)
+	}
+
+	return out.Value().(bool)
+}
+
+func toUnstructured(t *testing.T, obj runtime.Object) map[string]any {
+	t.Helper()
+
+	if obj == nil {
+		return nil
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		t.Fatalf("failed to convert %T: %v", obj, err)
+	}
+	return u
+}
+
+func TestChecks(t *testing.T) {
+	chain := func(upgrade string) *kcm.ClusterTemplateChain {
+		return &kcm.ClusterTemplateChain{Spec: kcm.TemplateChainSpec{SupportedTemplates: []kcm.SupportedTemplate{
+			{Name: "aws-0-0-1", AvailableUpgrades: []kcm.AvailableUpgrade{{Name: upgrade}}},
+			{Name: "aws-0-0-2"},
+		}}}
+	}
+	request := func(credential string, approval *kcm.ClusterRequestApproval) *kcm.ClusterRequest {
+		return &kcm.ClusterRequest{
+			Spec:   kcm.ClusterRequestSpec{Template: "aws-0-0-1", Credential: credential},
+			Status: kcm.ClusterRequestStatus{Approval: approval},
+		}
+	}
+	approved := &kcm.ClusterRequestApproval{Decision: kcm.ClusterRequestDecisionApproved}
+	rejected := &kcm.ClusterRequestApproval{Decision: "Rejected"}
+	window := func(d time.Duration) *kcm.ClusterDeployment {
+		return &kcm.ClusterDeployment{Spec: kcm.ClusterDeploymentSpec{LifecycleHooks: &kcm.ClusterLifecycleHooks{
+			MaintenanceWindows: []kcm.MaintenanceWindow{{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: d}}},
+		}}}
+	}
+	management := func(profile, delivery string) *kcm.Management {
+		return &kcm.Management{Spec: kcm.ManagementSpec{Profile: profile, ServiceDelivery: delivery}}
+	}
+
+	for _, tc := range []struct {
+		name      string
+		check     string
+		object    runtime.Object
+		oldObject runtime.Object
+		expected  bool
+	}{
+		{name: "chain upgrade supported", check: "templatechains", object: chain("aws-0-0-2"), expected: true},
+		{name: "chain upgrade not supported", check: "templatechains", object: chain("aws-0-0-3")},
+		{name: "empty chain", check: "templatechains", object: &kcm.ServiceTemplateChain{}, expected: true},
+		{
+			name: "request changed before decision", check: "clusterrequests-decision",
+			object: request("aws", nil), oldObject: request("", nil), expected: true,
+		},
+		{
+			name: "request decided", check: "clusterrequests-decision",
+			object: request("", approved), oldObject: request("", nil), expected: true,
+		},
+		{
+			name: "request changed after decision", check: "clusterrequests-decision",
+			object: request("aws", approved), oldObject: request("", approved),
+		},
+		{
+			name: "decision changed", check: "clusterrequests-decision",
+			object: request("", rejected), oldObject: request("", approved),
+		},
+		{name: "pending request without credential", check: "clusterrequests-credential", object: request("", nil), expected: true},
+		{name: "rejected request without credential", check: "clusterrequests-credential", object: request("", rejected), expected: true},
+		{name: "approved request without credential", check: "clusterrequests-credential", object: request("", approved)},
+		{name: "approved request with credential", check: "clusterrequests-credential", object: request("aws", approved), expected: true},
+		{
+			name: "credential set in approval", check: "clusterrequests-credential",
+			object:   request("", &kcm.ClusterRequestApproval{Decision: kcm.ClusterRequestDecisionApproved, Credential: "aws"}),
+			expected: true,
+		},
+		{name: "positive window", check: "clusterdeployments-maintenancewindows", object: window(time.Hour), expected: true},
+		{name: "zero window", check: "clusterdeployments-maintenancewindows", object: window(0)},
+		{name: "no lifecycle hooks", check: "clusterdeployments-maintenancewindows", object: &kcm.ClusterDeployment{}, expected: true},
+		{name: "default profile", check: "managements-profile", object: management("", kcm.ServiceDeliverySveltos), expected: true},
+		{name: "Edge profile", check: "managements-profile", object: management(kcm.ManagementProfileEdge, ""), expected: true},
+		{
+			name: "Edge profile with Sveltos", check: "managements-profile",
+			object: management(kcm.ManagementProfileEdge, kcm.ServiceDeliverySveltos),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := evaluate(t, tc.check, tc.object, tc.oldObject); actual != tc.expected {
+				t.Errorf("unexpected result: got %t, want %t", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := admissionregistrationv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	stale := &admissionregistrationv1.ValidatingAdmissionPolicy{ObjectMeta: objectMeta("removed")}
+	unmanaged := &admissionregistrationv1.ValidatingAdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "custom"}}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stale, unmanaged).Build()
+
+	owner := &metav1.OwnerReference{APIVersion: kcm.GroupVersion.String(), Kind: kcm.ManagementKind, Name: kcm.ManagementName, UID: "mgmt-uid"}
+	if err := Reconcile(t.Context(), cl, owner); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, expected := range Policies() {
+		policy := new(admissionregistrationv1.ValidatingAdmissionPolicy)
+		if err := cl.Get(t.Context(), client.ObjectKeyFromObject(expected), policy); err != nil {
+			t.Fatalf("failed to get ValidatingAdmissionPolicy %s: %v", expected.Name, err)
+		}
+		if !reflect.DeepEqual(policy.Spec, expected.Spec) {
+			t.Errorf("unexpected ValidatingAdmissionPolicy %s spec:\ngot:  %+v\nwant: %+v", policy.Name, policy.Spec, expected.Spec)
+		}
+		if len(policy.OwnerReferences) != 1 || policy.OwnerReferences[0].UID != owner.UID {
+			t.Errorf("expected ValidatingAdmissionPolicy %s to be owned by the Management, got %v", policy.Name, policy.OwnerReferences)
+		}
+	}
+	for _, expected := range Bindings() {
+		binding := new(admissionregistrationv1.ValidatingAdmissionPolicyBinding)
+		if err := cl.Get(t.Context(), client.ObjectKeyFromObject(expected), binding); err != nil {
+			t.Fatalf("failed to get ValidatingAdmissionPolicyBinding %s: %v", expected.Name, err)
+		}
+		if binding.Spec.PolicyName != expected.Name {
+			t.Errorf("unexpected policy of the binding %s: %s", binding.Name, binding.Spec.PolicyName)
+		}
+	}
+
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(stale), stale); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the stale ValidatingAdmissionPolicy to be deleted, got %v", err)
+	}
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(unmanaged), unmanaged); err != nil {
+		t.Errorf("expected the unmanaged ValidatingAdmissionPolicy to be kept, got %v", err)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := admissionregistrationv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	unmanaged := &admissionregistrationv1.ValidatingAdmissionPolicy{ObjectMeta: metav1.ObjectMeta{Name: "custom"}}
+	objs := []client.Object{unmanaged}
+	for _, policy := range Policies() {
+		objs = append(objs, policy)
+	}
+	for _, binding := range Bindings() {
+		objs = append(objs, binding)
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	if err := Remove(t.Context(), cl); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	policies := new(admissionregistrationv1.ValidatingAdmissionPolicyList)
+	if err := cl.List(t.Context(), policies); err != nil {
+		t.Fatalf("failed to list ValidatingAdmissionPolicies: %v", err)
+	}
+	if len(policies.Items) != 1 || policies.Items[0].Name != unmanaged.Name {
+		t.Errorf("expected only the unmanaged ValidatingAdmissionPolicy to be kept, got %v", policies.Items)
+	}
+	bindings := new(admissionregistrationv1.ValidatingAdmissionPolicyBindingList)
+	if err := cl.List(t.Context(), bindings); err != nil {
+		t.Fatalf("failed to list ValidatingAdmissionPolicyBindings: %v", err)
+	}
+	if len(bindings.Items) != 0 {
+		t.Errorf("expected the ValidatingAdmissionPolicyBindings to be deleted, got %v", bindings.Items)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admissionwebhooks maintains the admission behavior of the KCM webhooks configured in the Management:
+// the failure policy, the timeout and the namespaces exempted from the webhooks.
+package admissionwebhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const defaultTimeoutSeconds int32 = 10
+
+// settings is the admission behavior set on each of the KCM webhooks.
+type settings struct {
+	failurePolicy     admissionregistrationv1.FailurePolicyType
+	timeoutSeconds    int32
+	namespaceSelector *metav1.LabelSelector
+}
+
+func newSettings(cfg *kcm.AdmissionWebhooks) settings {
+	s := settings{
+		failurePolicy:     admissionregistrationv1.Fail,
+		timeoutSeconds:    defaultTimeoutSeconds,
+		namespaceSelector: &metav1.LabelSelector{},
+	}
+	if cfg == nil {
+		return s
+	}
+
+	if cfg.FailurePolicy != "" {
+		s.failurePolicy = admissionregistrationv1.FailurePolicyType(cfg.FailurePolicy)
+	}
+	if cfg.TimeoutSeconds != nil {
+		s.timeoutSeconds = *cfg.TimeoutSeconds
+	}
+	if len(cfg.ExemptNamespaces) > 0 {
+		s.namespaceSelector.MatchExpressions = append(s.namespaceSelector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      corev1.LabelMetadataName,
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   cfg.ExemptNamespaces,
+		})
+	}
+	if cfg.EmergencyBypass {
+		s.namespaceSelector.MatchExpressions = append(s.namespaceSelector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      kcm.AdmissionBypassLabelKey,
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   []string{"true"},
+		})
+	}
+
+	return s
+}
+
+// Apply sets the admission behavior configured in the Management on the KCM webhooks,
+// the defaults are restored if not configured.
+// The webhooks of the ValidatingWebhookConfigurations and MutatingWebhookConfigurations
+// are identified by the KCM API group suffix of their names.
+func Apply(ctx context.Context, cl client.Client, cfg *kcm.AdmissionWebhooks) error {
+	s := newSettings(cfg)
+
+	var errs error
+
+	validating := new(admissionregistrationv1.ValidatingWebhookConfigurationList)
+	if err := cl.List(ctx, validating); err != nil {
+		return fmt.Errorf("failed to list ValidatingWebhookConfigurations: %w", err)
+	}
+	for i := range validating.Items {
+		config := &validating.Items[i]
+		original := config.DeepCopy()
+		for j := range config.Webhooks {
+			webhook := &config.Webhooks[j]
+			if isKCMWebhook(webhook.Name) {
+				webhook.FailurePolicy, webhook.TimeoutSeconds, webhook.NamespaceSelector = s.values()
+			}
+		}
+		errs = errors.Join(errs, patch(ctx, cl, config, original))
+	}
+
+	mutating := new(admissionregistrationv1.MutatingWebhookConfigurationList)
+	if err := cl.List(ctx, mutating); err != nil {
+		return fmt.Errorf("failed to list MutatingWebhookConfigurations: %w", err)
+	}
+	for i := range mutating.Items {
+		config := &mutating.Items[i]
+		original := config.DeepCopy()
+		for j := range config.Webhooks {
+			webhook := &config.Webhooks[j]
+			if isKCMWebhook(webhook.Name) {
+				webhook.FailurePolicy, webhook.TimeoutSeconds, webhook.NamespaceSelector = s.values()
+			}
+		}
+		errs = errors.Join(errs, patch(ctx, cl, config, original))
+	}
+
+	return errs
+}
+
+// values returns the copies of the settings to be set on a webhook.
+func (s settings) values() (*admissionregistrationv1.FailurePolicyType, *int32, *metav1.LabelSelector) {
+	failurePolicy, timeoutSeconds := s.failurePolicy, s.timeoutSeconds
+	return &failurePolicy, &timeoutSeconds, s.namespaceSelector.DeepCopy()
+}
+
+func isKCMWebhook(name string) bool {
+	return strings.HasSuffix(name, "."+kcm.GroupVersion.Group)
+}
+
+func patch(ctx context.Context, cl client.Client, obj, original client.Object) error {
+	if equality.Semantic.DeepEqual(obj, original) {
+		return nil
+	}
+
+	if err := cl.Patch(ctx, obj, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to patch %T %s: %w", obj, obj.GetName(), err)
+	}
+	ctrl.LoggerFrom(ctx).Info("Updated the admission behavior of the webhooks", "configuration", obj.GetName())
+
+	return nil
+}
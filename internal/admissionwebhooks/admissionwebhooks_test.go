@@ -0,0 +1,105 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissionwebhooks
+
+import (
+	"reflect"
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/utils"
+)
+
+func TestApply(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := admissionregistrationv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	fail, ignore := admissionregistrationv1.Fail, admissionregistrationv1.Ignore
+	timeout := int32(10)
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "kcm-validating-webhook-configuration"},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{Name: "validation.clusterdeployment.k0rdent.mirantis.com", FailurePolicy: &fail},
+			{Name: "validation.other.example.com", FailurePolicy: &fail, TimeoutSeconds: &timeout},
+		},
+	}
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "kcm-mutating-webhook-configuration"},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{Name: "mutation.clusterdeployment.k0rdent.mirantis.com", FailurePolicy: &fail},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(validating, mutating).Build()
+
+	cfg := &kcm.AdmissionWebhooks{
+		FailurePolicy:    string(admissionregistrationv1.Ignore),
+		TimeoutSeconds:   utils.PtrTo(int32(5)),
+		ExemptNamespaces: []string{"kube-system"},
+		EmergencyBypass:  true,
+	}
+
+	if err := Apply(t.Context(), cl, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedSelector := &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+		{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"kube-system"}},
+		{Key: kcm.AdmissionBypassLabelKey, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"true"}},
+	}}
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(validating), validating); err != nil {
+		t.Fatalf("failed to get ValidatingWebhookConfiguration: %v", err)
+	}
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(mutating), mutating); err != nil {
+		t.Fatalf("failed to get MutatingWebhookConfiguration: %v", err)
+	}
+	for _, webhook := range []struct {
+		name              string
+		failurePolicy     *admissionregistrationv1.FailurePolicyType
+		timeoutSeconds    *int32
+		namespaceSelector *metav1.LabelSelector
+	}{
+		{validating.Webhooks[0].Name, validating.Webhooks[0].FailurePolicy, validating.Webhooks[0].TimeoutSeconds, validating.Webhooks[0].NamespaceSelector},
+		{mutating.Webhooks[0].Name, mutating.Webhooks[0].FailurePolicy, mutating.Webhooks[0].TimeoutSeconds, mutating.Webhooks[0].NamespaceSelector},
+	} {
+		if *webhook.failurePolicy != ignore || *webhook.timeoutSeconds != 5 || !reflect.DeepEqual(webhook.namespaceSelector, expectedSelector) {
+			t.Errorf("unexpected settings of the webhook %s: %s, %d, %v", webhook.name, *webhook.failurePolicy, *webhook.timeoutSeconds, webhook.namespaceSelector)
+		}
+	}
+	if other := validating.Webhooks[1]; *other.FailurePolicy != fail || *other.TimeoutSeconds != 10 || other.NamespaceSelector != nil {
+		t.Errorf("expected the non-KCM webhook to be kept intact, got %+v", other)
+	}
+
+	// the defaults are restored once the settings are removed
+	if err := Apply(t.Context(), cl, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(validating), validating); err != nil {
+		t.Fatalf("failed to get ValidatingWebhookConfiguration: %v", err)
+	}
+	if webhook := validating.Webhooks[0]; *webhook.FailurePolicy != fail || *webhook.TimeoutSeconds != defaultTimeoutSeconds ||
+		!reflect.DeepEqual(webhook.NamespaceSelector, &metav1.LabelSelector{}) {
+		t.Errorf("expected the default settings of the webhook %s, got %s, %d, %v",
+			webhook.Name, *webhook.FailurePolicy, *webhook.TimeoutSeconds, webhook.NamespaceSelector)
+	}
+}
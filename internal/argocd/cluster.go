@@ -0,0 +1,167 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// SecretTypeLabelKey is the label Argo CD discovers the cluster Secrets by.
+	SecretTypeLabelKey = "argocd.argoproj.io/secret-type"
+	// SecretTypeCluster is the value of the SecretTypeLabelKey label of the cluster Secrets.
+	SecretTypeCluster = "cluster"
+
+	// ClusterDeploymentNameLabelKey is the label holding the name of the registered ClusterDeployment.
+	ClusterDeploymentNameLabelKey = "k0rdent.mirantis.com/cluster-deployment-name"
+	// ClusterDeploymentNamespaceLabelKey is the label holding the namespace of the registered ClusterDeployment.
+	ClusterDeploymentNamespaceLabelKey = "k0rdent.mirantis.com/cluster-deployment-namespace"
+	// ClusterTemplateLabelKey is the label holding the ClusterTemplate of the registered ClusterDeployment.
+	ClusterTemplateLabelKey = "k0rdent.mirantis.com/cluster-template"
+)
+
+// clusterConfig is the connection configuration of the cluster in the format expected by Argo CD.
+type clusterConfig struct {
+	BearerToken     string          `json:"bearerToken,omitempty"`
+	TLSClientConfig tlsClientConfig `json:"tlsClientConfig"`
+}
+
+type tlsClientConfig struct {
+	ServerName string `json:"serverName,omitempty"`
+	CAData     []byte `json:"caData,omitempty"`
+	CertData   []byte `json:"certData,omitempty"`
+	KeyData    []byte `json:"keyData,omitempty"`
+	Insecure   bool   `json:"insecure"`
+}
+
+// ClusterName returns the name the given ClusterDeployment is registered in Argo CD with.
+func ClusterName(cd *kcm.ClusterDeployment) string {
+	return cd.Namespace + "-" + cd.Name
+}
+
+// ClusterSecretName returns the name of the Argo CD cluster Secret of the given ClusterDeployment.
+func ClusterSecretName(cd *kcm.ClusterDeployment) string {
+	return "kcm-" + ClusterName(cd)
+}
+
+// ClusterSecretLabels returns the labels of the Argo CD cluster Secret of the given ClusterDeployment.
+// The labels of the ClusterDeployment are copied to be matched by the ApplicationSet cluster generators.
+func ClusterSecretLabels(cd *kcm.ClusterDeployment, extraLabels map[string]string) map[string]string {
+	labels := make(map[string]string, len(cd.Labels)+len(extraLabels)+5)
+	for k, v := range cd.Labels {
+		labels[k] = v
+	}
+	for k, v := range extraLabels {
+		labels[k] = v
+	}
+
+	labels[SecretTypeLabelKey] = SecretTypeCluster
+	labels[kcm.KCMManagedLabelKey] = kcm.KCMManagedLabelValue
+	labels[ClusterDeploymentNameLabelKey] = cd.Name
+	labels[ClusterDeploymentNamespaceLabelKey] = cd.Namespace
+	if len(validation.IsValidLabelValue(cd.Spec.Template)) == 0 {
+		labels[ClusterTemplateLabelKey] = cd.Spec.Template
+	}
+
+	return labels
+}
+
+// ReconcileClusterSecret creates or updates the Argo CD cluster Secret of the given ClusterDeployment
+// in the given namespace with the connection details from the cluster kubeconfig.
+func ReconcileClusterSecret(ctx context.Context, cl client.Client, namespace string, cd *kcm.ClusterDeployment, kubeconfig []byte, extraLabels map[string]string) error {
+	restCfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	config, err := json.Marshal(clusterConfig{
+		BearerToken: restCfg.BearerToken,
+		TLSClientConfig: tlsClientConfig{
+			ServerName: restCfg.ServerName,
+			CAData:     restCfg.CAData,
+			CertData:   restCfg.CertData,
+			KeyData:    restCfg.KeyData,
+			Insecure:   restCfg.Insecure,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cluster config: %w", err)
+	}
+
+	secret := &corev1.Secret{}
+	secret.Name = ClusterSecretName(cd)
+	secret.Namespace = namespace
+
+	_, err = ctrl.CreateOrUpdate(ctx, cl, secret, func() error {
+		secret.Labels = ClusterSecretLabels(cd, extraLabels)
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = map[string][]byte{
+			"name":   []byte(ClusterName(cd)),
+			"server": []byte(restCfg.Host),
+			"config": config,
+		}
+		return nil
+	})
+
+	return err
+}
+
+// ListClusterSecrets returns the Argo CD cluster Secrets maintained by KCM
+// additionally matching the given labels.
+func ListClusterSecrets(ctx context.Context, cl client.Client, matchLabels map[string]string) ([]corev1.Secret, error) {
+	selector := client.MatchingLabels{
+		SecretTypeLabelKey:     SecretTypeCluster,
+		kcm.KCMManagedLabelKey: kcm.KCMManagedLabelValue,
+	}
+	for k, v := range matchLabels {
+		selector[k] = v
+	}
+
+	secrets := new(corev1.SecretList)
+	if err := cl.List(ctx, secrets, selector); err != nil {
+		return nil, fmt.Errorf("failed to list Argo CD cluster Secrets: %w", err)
+	}
+
+	return secrets.Items, nil
+}
+
+// DeleteClusterSecrets deletes the Argo CD cluster Secrets of the given ClusterDeployment in any namespace.
+func DeleteClusterSecrets(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) error {
+	secrets, err := ListClusterSecrets(ctx, cl, map[string]string{
+		ClusterDeploymentNameLabelKey:      cd.Name,
+		ClusterDeploymentNamespaceLabelKey: cd.Namespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range secrets {
+		if err := cl.Delete(ctx, &secret); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete Argo CD cluster Secret %s: %w", client.ObjectKeyFromObject(&secret), err)
+		}
+	}
+
+	return nil
+}
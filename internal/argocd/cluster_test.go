@@ -0,0 +1,132 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newClusterDeployment(namespace, name string) *kcm.ClusterDeployment {
+	return &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"env": "prod"},
+		},
+		Spec: kcm.ClusterDeploymentSpec{Template: "aws-1-0-0"},
+	}
+}
+
+func newKubeconfig(t *testing.T) []byte {
+	t.Helper()
+
+	kubeconfig, err := clientcmd.Write(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"dev": {Server: "https://10.0.0.1:6443", CertificateAuthorityData: []byte("ca")},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			"admin": {ClientCertificateData: []byte("cert"), ClientKeyData: []byte("key")},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			"admin@dev": {Cluster: "dev", AuthInfo: "admin"},
+		},
+		CurrentContext: "admin@dev",
+	})
+	if err != nil {
+		t.Fatalf("failed to write kubeconfig: %v", err)
+	}
+
+	return kubeconfig
+}
+
+func TestClusterSecretLabels(t *testing.T) {
+	cd := newClusterDeployment("team-a", "dev")
+	cd.Labels[SecretTypeLabelKey] = "repository"
+
+	labels := ClusterSecretLabels(cd, map[string]string{"region": "eu"})
+	expected := map[string]string{
+		"env":                              "prod",
+		"region":                           "eu",
+		SecretTypeLabelKey:                 SecretTypeCluster,
+		kcm.KCMManagedLabelKey:             kcm.KCMManagedLabelValue,
+		ClusterDeploymentNameLabelKey:      "dev",
+		ClusterDeploymentNamespaceLabelKey: "team-a",
+		ClusterTemplateLabelKey:            "aws-1-0-0",
+	}
+	if !reflect.DeepEqual(labels, expected) {
+		t.Errorf("unexpected labels %v, expected %v", labels, expected)
+	}
+}
+
+func TestReconcileAndDeleteClusterSecrets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	dev := newClusterDeployment("team-a", "dev")
+	prod := newClusterDeployment("team-a", "prod")
+	for _, cd := range []*kcm.ClusterDeployment{dev, prod} {
+		if err := ReconcileClusterSecret(t.Context(), cl, "argocd", cd, newKubeconfig(t), nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	secret := new(corev1.Secret)
+	if err := cl.Get(t.Context(), client.ObjectKey{Namespace: "argocd", Name: "kcm-team-a-dev"}, secret); err != nil {
+		t.Fatalf("failed to get cluster Secret: %v", err)
+	}
+	if name := string(secret.Data["name"]); name != "team-a-dev" {
+		t.Errorf("unexpected cluster name %s", name)
+	}
+	if server := string(secret.Data["server"]); server != "https://10.0.0.1:6443" {
+		t.Errorf("unexpected server %s", server)
+	}
+
+	config := new(clusterConfig)
+	if err := json.Unmarshal(secret.Data["config"], config); err != nil {
+		t.Fatalf("failed to unmarshal config: %v", err)
+	}
+	expectedTLS := tlsClientConfig{CAData: []byte("ca"), CertData: []byte("cert"), KeyData: []byte("key")}
+	if !reflect.DeepEqual(config.TLSClientConfig, expectedTLS) {
+		t.Errorf("unexpected TLS config %+v, expected %+v", config.TLSClientConfig, expectedTLS)
+	}
+
+	if err := DeleteClusterSecrets(t.Context(), cl, dev); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	secrets, err := ListClusterSecrets(t.Context(), cl, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(secrets) != 1 || secrets[0].Name != "kcm-team-a-prod" {
+		t.Errorf("expected only the cluster Secret of team-a/prod to remain, got %v", secrets)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cli implements the kcm command line interface.
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	hcv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// kubeconfigEnvVar is the environment variable with the path to the kubeconfig of the management cluster,
+// used when the commands are run by kubectl as the credential exec plugin of the fleet kubeconfig.
+const kubeconfigEnvVar = "KCM_KUBECONFIG"
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(clusterapiv1beta1.AddToScheme(scheme))
+	utilruntime.Must(hcv2.AddToScheme(scheme))
+	utilruntime.Must(kcm.AddToScheme(scheme))
+}
+
+// options holds the flags shared by all the commands.
+type options struct {
+	// client and kubeClient are the clients to the management
+	// cluster, built from the kubeconfig unless set beforehand.
+	client     client.Client
+	kubeClient kubernetes.Interface
+	// fleetViewClient sends the requests to the fleet view endpoint of the management
+	// cluster, built from the kubeconfig unless set beforehand.
+	fleetViewClient *http.Client
+	// clientConfig is the loaded kubeconfig of the management cluster.
+	clientConfig clientcmd.ClientConfig
+
+	kubeconfig string
+	context    string
+	namespace  string
+}
+
+// complete builds the client to the management cluster and
+// defaults the namespace to the one of the kubeconfig context.
+func (o *options) complete() error {
+	if o.kubeconfig == "" {
+		o.kubeconfig = os.Getenv(kubeconfigEnvVar)
+	}
+
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = o.kubeconfig
+	o.clientConfig = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{CurrentContext: o.context})
+
+	if o.namespace == "" {
+		namespace, _, err := o.clientConfig.Namespace()
+		if err != nil {
+			return fmt.Errorf("failed to get namespace from kubeconfig: %w", err)
+		}
+		o.namespace = namespace
+	}
+
+	if o.client != nil && o.kubeClient != nil {
+		return nil
+	}
+
+	restConfig, err := o.clientConfig.ClientConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	if o.client == nil {
+		o.client, err = client.New(restConfig, client.Options{Scheme: scheme})
+		if err != nil {
+			return fmt.Errorf("failed to create client: %w", err)
+		}
+	}
+
+	if o.kubeClient == nil {
+		o.kubeClient, err = kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create Kubernetes client: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// NewRootCommand returns the kcm command.
+func NewRootCommand() *cobra.Command {
+	return newRootCommand(new(options))
+}
+
+func newRootCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "kcm",
+		Short:        "kcm manages the clusters deployed by k0rdent",
+		SilenceUsage: true,
+		PersistentPreRunE: func(*cobra.Command, []string) error {
+			return o.complete()
+		},
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&o.kubeconfig, "kubeconfig", "", "Path to the kubeconfig of the management cluster, defaults to the "+kubeconfigEnvVar+" environment variable")
+	flags.StringVar(&o.context, "context", "", "The kubeconfig context to use")
+	flags.StringVarP(&o.namespace, "namespace", "n", "", "The namespace of the ClusterDeployments, defaults to the namespace of the kubeconfig context")
+
+	cmd.AddCommand(
+		newClusterCommand(o),
+		newFleetCommand(o),
+		newSupportBundleCommand(o),
+		newTemplateCommand(o),
+	)
+
+	return cmd
+}
@@ -0,0 +1,375 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/chartutil"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	kubeconfigSecretSuffix = "-kubeconfig"
+	kubeconfigSecretKey    = "value"
+)
+
+func newClusterCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "cluster",
+		Aliases: []string{"clusters", "cd"},
+		Short:   "Manage the lifecycle of the ClusterDeployments",
+	}
+
+	cmd.AddCommand(
+		newClusterCreateCommand(o),
+		newClusterCloneCommand(o),
+		newClusterExportCommand(o),
+		newClusterUpgradeCommand(o),
+		newClusterKubeconfigCommand(o),
+		newClusterCredentialsCommand(o),
+		newClusterDescribeCommand(o),
+	)
+
+	return cmd
+}
+
+type clusterCreateOptions struct {
+	template   string
+	credential string
+	config     string
+	dryRun     bool
+}
+
+func newClusterCreateCommand(o *options) *cobra.Command {
+	co := new(clusterCreateOptions)
+	cmd := &cobra.Command{
+		Use:   "create NAME --template TEMPLATE --credential CREDENTIAL",
+		Short: "Create a ClusterDeployment",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cd, err := co.clusterDeployment(o.namespace, args[0], cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+
+			if err := validateClusterConfig(cmd.Context(), o.client, cd); err != nil {
+				return err
+			}
+
+			if err := o.client.Create(cmd.Context(), cd); err != nil {
+				return fmt.Errorf("failed to create ClusterDeployment %s: %w", client.ObjectKeyFromObject(cd), err)
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "ClusterDeployment %s created\n", client.ObjectKeyFromObject(cd))
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&co.template, "template", "", "The name of the ClusterTemplate to deploy the cluster from")
+	flags.StringVar(&co.credential, "credential", "", "The name of the Credential to deploy the cluster with")
+	flags.StringVar(&co.config, "config", "", "The path to the YAML or JSON file with the cluster configuration, - reads from stdin")
+	flags.BoolVar(&co.dryRun, "dry-run", false, "Validate the ClusterDeployment without deploying the cluster")
+	_ = cmd.MarkFlagRequired("template")
+	_ = cmd.MarkFlagRequired("credential")
+
+	return cmd
+}
+
+// clusterDeployment returns the ClusterDeployment to create.
+func (co *clusterCreateOptions) clusterDeployment(namespace, name string, stdin io.Reader) (*kcm.ClusterDeployment, error) {
+	cd := &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: kcm.ClusterDeploymentSpec{
+			Template:   co.template,
+			Credential: co.credential,
+			DryRun:     co.dryRun,
+		},
+	}
+
+	if co.config == "" {
+		return cd, nil
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if co.config == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(co.config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster configuration: %w", err)
+	}
+
+	raw, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cluster configuration: %w", err)
+	}
+	cd.Spec.Config = &apiextensionsv1.JSON{Raw: raw}
+
+	return cd, nil
+}
+
+// validateClusterConfig validates the configuration of the given ClusterDeployment merged with the default
+// values of its ClusterTemplate against the configuration schema of the ClusterTemplate before the submission.
+func validateClusterConfig(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) error {
+	template := new(kcm.ClusterTemplate)
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: cd.TemplateNamespace(), Name: cd.Spec.Template}, template); err != nil {
+		if apierrors.IsNotFound(err) {
+			// the missing ClusterTemplate is reported on the submission
+			return nil
+		}
+		return fmt.Errorf("failed to get ClusterTemplate %s/%s: %w", cd.TemplateNamespace(), cd.Spec.Template, err)
+	}
+	if template.Status.ConfigSchema == nil {
+		return nil
+	}
+
+	defaults := make(chartutil.Values)
+	if template.Status.Config != nil {
+		if err := json.Unmarshal(template.Status.Config.Raw, &defaults); err != nil {
+			return fmt.Errorf("failed to parse default configuration of ClusterTemplate %s/%s: %w", cd.TemplateNamespace(), cd.Spec.Template, err)
+		}
+	}
+	config := make(chartutil.Values)
+	if cd.Spec.Config != nil {
+		if err := json.Unmarshal(cd.Spec.Config.Raw, &config); err != nil {
+			return fmt.Errorf("failed to parse cluster configuration: %w", err)
+		}
+	}
+
+	if err := chartutil.ValidateAgainstSingleSchema(chartutil.CoalesceTables(config, defaults), template.Status.ConfigSchema.Raw); err != nil {
+		return fmt.Errorf("invalid cluster configuration for ClusterTemplate %s/%s:\n%w", cd.TemplateNamespace(), cd.Spec.Template, err)
+	}
+
+	return nil
+}
+
+func newClusterCloneCommand(o *options) *cobra.Command {
+	co := new(clusterCreateOptions)
+	var region string
+	cmd := &cobra.Command{
+		Use:   "clone SOURCE NAME",
+		Short: "Create a ClusterDeployment from an existing one",
+		Long: `Create a ClusterDeployment from an existing one.
+The template, credential, configuration and services are copied from the SOURCE ClusterDeployment,
+the given flags override the copied values and the configuration is merged with the copied one.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cd, err := co.clusterDeployment(o.namespace, args[1], cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			cd.Spec.CloneFrom = args[0]
+
+			if region != "" {
+				if err := setClusterConfigValue(cd, "region", region); err != nil {
+					return err
+				}
+			}
+
+			if err := o.client.Create(cmd.Context(), cd); err != nil {
+				return fmt.Errorf("failed to clone ClusterDeployment %s/%s: %w", o.namespace, args[0], err)
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "ClusterDeployment %s created from %s\n", client.ObjectKeyFromObject(cd), args[0])
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&co.template, "template", "", "The name of the ClusterTemplate to deploy the cluster from instead of the copied one")
+	flags.StringVar(&co.credential, "credential", "", "The name of the Credential to deploy the cluster with instead of the copied one")
+	flags.StringVar(&co.config, "config", "", "The path to the YAML or JSON file with the configuration overrides, - reads from stdin")
+	flags.StringVar(&region, "region", "", "The region to deploy the cluster to instead of the copied one")
+	flags.BoolVar(&co.dryRun, "dry-run", false, "Validate the ClusterDeployment without deploying the cluster")
+
+	return cmd
+}
+
+// setClusterConfigValue sets the top-level key of the ClusterDeployment configuration.
+func setClusterConfigValue(cd *kcm.ClusterDeployment, key string, value any) error {
+	values := make(map[string]any)
+	if cd.Spec.Config != nil {
+		if err := json.Unmarshal(cd.Spec.Config.Raw, &values); err != nil {
+			return fmt.Errorf("failed to parse cluster configuration: %w", err)
+		}
+	}
+	values[key] = value
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cluster configuration: %w", err)
+	}
+	cd.Spec.Config = &apiextensionsv1.JSON{Raw: raw}
+
+	return nil
+}
+
+func newClusterExportCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "export NAME",
+		Short: "Print the ClusterDeployment as a manifest reusable for other clusters",
+		Long: `Print the ClusterDeployment as a manifest reusable for other clusters.
+The status and the server-populated metadata are omitted.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cd, err := getClusterDeployment(cmd.Context(), o, args[0])
+			if err != nil {
+				return err
+			}
+
+			exported := &kcm.ClusterDeployment{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: kcm.GroupVersion.String(),
+					Kind:       kcm.ClusterDeploymentKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        cd.Name,
+					Namespace:   cd.Namespace,
+					Labels:      cd.Labels,
+					Annotations: cd.Annotations,
+				},
+				Spec: cd.Spec,
+			}
+			exported.Spec.CloneFrom = ""
+
+			data, err := yaml.Marshal(exported)
+			if err != nil {
+				return fmt.Errorf("failed to marshal ClusterDeployment %s: %w", client.ObjectKeyFromObject(cd), err)
+			}
+
+			_, err = cmd.OutOrStdout().Write(data)
+			return err
+		},
+	}
+}
+
+func newClusterUpgradeCommand(o *options) *cobra.Command {
+	var template string
+	cmd := &cobra.Command{
+		Use:   "upgrade NAME --template TEMPLATE",
+		Short: "Upgrade a ClusterDeployment to another ClusterTemplate",
+		Long: `Upgrade a ClusterDeployment to another ClusterTemplate.
+Without --template, the available upgrades of the ClusterDeployment are listed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return upgradeCluster(cmd.Context(), o, cmd, args[0], template)
+		},
+	}
+
+	cmd.Flags().StringVar(&template, "template", "", "The name of the ClusterTemplate to upgrade the cluster to")
+
+	return cmd
+}
+
+func upgradeCluster(ctx context.Context, o *options, cmd *cobra.Command, name, template string) error {
+	cd, err := getClusterDeployment(ctx, o, name)
+	if err != nil {
+		return err
+	}
+
+	if template == "" {
+		if len(cd.Status.AvailableUpgrades) == 0 {
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "No upgrades available for ClusterDeployment %s\n", client.ObjectKeyFromObject(cd))
+			return err
+		}
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Available upgrades: %s\n", strings.Join(cd.Status.AvailableUpgrades, ", "))
+		return err
+	}
+
+	if cd.Spec.Template == template {
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "ClusterDeployment %s already uses the ClusterTemplate %s\n", client.ObjectKeyFromObject(cd), template)
+		return err
+	}
+
+	if !slices.Contains(cd.Status.AvailableUpgrades, template) {
+		return fmt.Errorf("the ClusterTemplate %s is not in the list of the available upgrades of ClusterDeployment %s: %s",
+			template, client.ObjectKeyFromObject(cd), strings.Join(cd.Status.AvailableUpgrades, ", "))
+	}
+
+	patch := client.MergeFrom(cd.DeepCopy())
+	cd.SetTemplate(template)
+	if err := o.client.Patch(ctx, cd, patch); err != nil {
+		return fmt.Errorf("failed to upgrade ClusterDeployment %s: %w", client.ObjectKeyFromObject(cd), err)
+	}
+
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "ClusterDeployment %s upgraded to the ClusterTemplate %s\n", client.ObjectKeyFromObject(cd), template)
+	return err
+}
+
+func newClusterKubeconfigCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "kubeconfig NAME",
+		Short: "Print the kubeconfig of the cluster",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeconfig, err := getKubeconfig(cmd.Context(), o, args[0])
+			if err != nil {
+				return err
+			}
+
+			_, err = cmd.OutOrStdout().Write(kubeconfig)
+			return err
+		},
+	}
+}
+
+// getKubeconfig returns the admin kubeconfig of the cluster of the given ClusterDeployment.
+func getKubeconfig(ctx context.Context, o *options, name string) ([]byte, error) {
+	secret := new(corev1.Secret)
+	key := client.ObjectKey{Namespace: o.namespace, Name: name + kubeconfigSecretSuffix}
+	if err := o.client.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig Secret %s: %w", key, err)
+	}
+
+	kubeconfig, ok := secret.Data[kubeconfigSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig Secret %s has no %q key", key, kubeconfigSecretKey)
+	}
+
+	return kubeconfig, nil
+}
+
+func getClusterDeployment(ctx context.Context, o *options, name string) (*kcm.ClusterDeployment, error) {
+	cd := new(kcm.ClusterDeployment)
+	key := client.ObjectKey{Namespace: o.namespace, Name: name}
+	if err := o.client.Get(ctx, key, cd); err != nil {
+		return nil, fmt.Errorf("failed to get ClusterDeployment %s: %w", key, err)
+	}
+
+	return cd, nil
+}
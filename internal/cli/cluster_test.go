@@ -0,0 +1,204 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const testNamespace = "team-a"
+
+func run(t *testing.T, o *options, stdin string, args ...string) (string, error) {
+	t.Helper()
+
+	if o.kubeClient == nil {
+		o.kubeClient = kubefake.NewClientset()
+	}
+
+	out := new(bytes.Buffer)
+	cmd := newRootCommand(o)
+	cmd.SetArgs(append([]string{"--namespace", testNamespace}, args...))
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+
+	err := cmd.ExecuteContext(t.Context())
+	return out.String(), err
+}
+
+func newClusterDeployment(upgrades ...string) *kcm.ClusterDeployment {
+	return &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: testNamespace},
+		Spec:       kcm.ClusterDeploymentSpec{Template: "aws-1-0-0", Credential: "aws"},
+		Status: kcm.ClusterDeploymentStatus{
+			KubernetesVersion: "v1.32.2",
+			AvailableUpgrades: upgrades,
+			Conditions: []metav1.Condition{
+				{Type: kcm.ReadyCondition, Status: metav1.ConditionTrue, Reason: kcm.SucceededReason, Message: "Object is ready"},
+			},
+		},
+	}
+}
+
+func TestClusterCreate(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	out, err := run(t, &options{client: cl}, "workersNumber: 2\n", "cluster", "create", "dev", "--template", "aws-1-0-0", "--credential", "aws", "--config", "-")
+	require.NoError(t, err)
+	require.Equal(t, "ClusterDeployment team-a/dev created\n", out)
+
+	cd := new(kcm.ClusterDeployment)
+	require.NoError(t, cl.Get(t.Context(), client.ObjectKey{Namespace: testNamespace, Name: "dev"}, cd))
+	require.Equal(t, "aws-1-0-0", cd.Spec.Template)
+	require.Equal(t, "aws", cd.Spec.Credential)
+	require.JSONEq(t, `{"workersNumber":2}`, string(cd.Spec.Config.Raw))
+
+	_, err = run(t, &options{client: cl}, "", "cluster", "create", "other", "--credential", "aws")
+	require.ErrorContains(t, err, `required flag(s) "template" not set`)
+}
+
+func TestClusterCreateValidatesConfig(t *testing.T) {
+	template := &kcm.ClusterTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-1-0-0", Namespace: testNamespace},
+		Status: kcm.ClusterTemplateStatus{
+			ConfigSchema: &apiextensionsv1.JSON{Raw: []byte(`{"type":"object","required":["region"],"properties":{"region":{"type":"string"},"workersNumber":{"type":"integer","minimum":1}}}`)},
+			TemplateStatusCommon: kcm.TemplateStatusCommon{
+				Config: &apiextensionsv1.JSON{Raw: []byte(`{"region":"","workersNumber":1}`)},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(template).Build()
+
+	_, err := run(t, &options{client: cl}, "workersNumber: 0\n", "cluster", "create", "dev", "--template", "aws-1-0-0", "--credential", "aws", "--config", "-")
+	require.ErrorContains(t, err, "invalid cluster configuration for ClusterTemplate team-a/aws-1-0-0:\n")
+	require.ErrorContains(t, err, "workersNumber: Must be greater than or equal to 1")
+	require.True(t, apierrors.IsNotFound(cl.Get(t.Context(), client.ObjectKey{Namespace: testNamespace, Name: "dev"}, new(kcm.ClusterDeployment))))
+
+	// the default values of the ClusterTemplate are validated along with the configuration
+	_, err = run(t, &options{client: cl}, "region: us-east-2\nworkersNumber: 2\n", "cluster", "create", "dev", "--template", "aws-1-0-0", "--credential", "aws", "--config", "-")
+	require.NoError(t, err)
+}
+
+func TestClusterClone(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newClusterDeployment()).Build()
+
+	out, err := run(t, &options{client: cl}, "workersNumber: 2\n", "cluster", "clone", "dev", "prod", "--region", "us-west-1", "--credential", "aws-prod", "--config", "-")
+	require.NoError(t, err)
+	require.Equal(t, "ClusterDeployment team-a/prod created from dev\n", out)
+
+	cd := new(kcm.ClusterDeployment)
+	require.NoError(t, cl.Get(t.Context(), client.ObjectKey{Namespace: testNamespace, Name: "prod"}, cd))
+	require.Equal(t, "dev", cd.Spec.CloneFrom)
+	require.Empty(t, cd.Spec.Template)
+	require.Equal(t, "aws-prod", cd.Spec.Credential)
+	require.JSONEq(t, `{"region":"us-west-1","workersNumber":2}`, string(cd.Spec.Config.Raw))
+
+	_, err = run(t, &options{client: cl}, "", "cluster", "clone", "dev")
+	require.ErrorContains(t, err, "accepts 2 arg(s), received 1")
+}
+
+func TestClusterExport(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newClusterDeployment("aws-1-0-1")).Build()
+
+	out, err := run(t, &options{client: cl}, "", "cluster", "export", "dev")
+	require.NoError(t, err)
+	require.Contains(t, out, "kind: ClusterDeployment\n")
+	require.Contains(t, out, "template: aws-1-0-0\n")
+	require.NotContains(t, out, "resourceVersion")
+	require.NotContains(t, out, "availableUpgrades")
+}
+
+func TestClusterUpgrade(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newClusterDeployment("aws-1-0-1")).Build()
+
+	out, err := run(t, &options{client: cl}, "", "cluster", "upgrade", "dev")
+	require.NoError(t, err)
+	require.Equal(t, "Available upgrades: aws-1-0-1\n", out)
+
+	_, err = run(t, &options{client: cl}, "", "cluster", "upgrade", "dev", "--template", "aws-2-0-0")
+	require.EqualError(t, err, "the ClusterTemplate aws-2-0-0 is not in the list of the available upgrades of ClusterDeployment team-a/dev: aws-1-0-1")
+
+	out, err = run(t, &options{client: cl}, "", "cluster", "upgrade", "dev", "--template", "aws-1-0-1")
+	require.NoError(t, err)
+	require.Equal(t, "ClusterDeployment team-a/dev upgraded to the ClusterTemplate aws-1-0-1\n", out)
+
+	cd := new(kcm.ClusterDeployment)
+	require.NoError(t, cl.Get(t.Context(), client.ObjectKey{Namespace: testNamespace, Name: "dev"}, cd))
+	require.Equal(t, "aws-1-0-1", cd.Spec.Template)
+}
+
+func TestClusterKubeconfig(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev-kubeconfig", Namespace: testNamespace},
+		Data:       map[string][]byte{"value": []byte("apiVersion: v1\nkind: Config\n")},
+	}).Build()
+
+	out, err := run(t, &options{client: cl}, "", "cluster", "kubeconfig", "dev")
+	require.NoError(t, err)
+	require.Equal(t, "apiVersion: v1\nkind: Config\n", out)
+
+	_, err = run(t, &options{client: cl}, "", "cluster", "kubeconfig", "prod")
+	require.ErrorContains(t, err, "failed to get kubeconfig Secret team-a/prod-kubeconfig")
+}
+
+func TestClusterDescribe(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newClusterDeployment(),
+		&clusterapiv1beta1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: testNamespace},
+			Status:     clusterapiv1beta1.ClusterStatus{Phase: "Provisioned", ControlPlaneReady: true},
+		},
+		&clusterapiv1beta1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dev-md-abcde",
+				Namespace: testNamespace,
+				Labels:    map[string]string{kcm.ClusterNameLabelKey: "dev"},
+			},
+			Status: clusterapiv1beta1.MachineStatus{
+				Phase:   "Running",
+				NodeRef: &corev1.ObjectReference{Name: "node-1"},
+			},
+		},
+	).Build()
+
+	out, err := run(t, &options{client: cl}, "", "cluster", "describe", "dev")
+	require.NoError(t, err)
+	for _, expected := range []string{
+		"Template:            aws-1-0-0",
+		"Kubernetes Version:  v1.32.2",
+		"Phase:               Ready",
+		"Ready  True    Succeeded  Object is ready",
+		"  Phase:                 Provisioned",
+		"dev-md-abcde",
+		"node-1",
+		"HelmRelease:  <not found>",
+	} {
+		require.Contains(t, out, expected)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	clientauthv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/K0rdent/kcm/internal/fleetview"
+)
+
+const (
+	// fleetViewURLEnvVar is the environment variable with the URL of the fleet view endpoint of the management cluster.
+	fleetViewURLEnvVar = "KCM_FLEET_VIEW_URL"
+	// fleetViewCAFileEnvVar is the environment variable with the path to the CA certificate of the fleet view endpoint.
+	fleetViewCAFileEnvVar = "KCM_FLEET_VIEW_CA_FILE"
+)
+
+func newClusterCredentialsCommand(o *options) *cobra.Command {
+	var (
+		expiration   time.Duration
+		fleetViewURL string
+		caFile       string
+	)
+	cmd := &cobra.Command{
+		Use:   "credentials NAME",
+		Short: "Print short-lived credentials of the cluster as a kubectl ExecCredential",
+		Long: `Print short-lived credentials of the cluster as a kubectl ExecCredential.
+
+The command is the kubectl credential exec plugin of the fleet kubeconfig maintained by KCM
+in the kcm-fleet-kubeconfig Secret of each namespace. The token of the kcm-fleet ServiceAccount
+provisioned by KCM in the cluster is issued by the fleet view endpoint of the management cluster
+to the users allowed to create the credentials subresource of the ClusterDeployment, the admin
+kubeconfig of the cluster never leaves the management cluster.
+
+The kubeconfig of the management cluster is read from the ` + kubeconfigEnvVar + ` environment variable,
+its bearer token authenticates the user to the fleet view endpoint. The URL of the endpoint and
+its CA certificate are read from the ` + fleetViewURLEnvVar + ` and ` + fleetViewCAFileEnvVar + ` environment variables.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if fleetViewURL == "" {
+				return fmt.Errorf("the URL of the fleet view endpoint must be set with the --fleet-view-url flag or the %s environment variable", fleetViewURLEnvVar)
+			}
+
+			httpClient, err := o.getFleetViewClient(caFile)
+			if err != nil {
+				return err
+			}
+
+			cred, err := requestCredentials(cmd.Context(), httpClient, fleetViewURL, o.namespace, args[0], expiration)
+			if err != nil {
+				return fmt.Errorf("failed to request credentials of ClusterDeployment %s/%s: %w", o.namespace, args[0], err)
+			}
+
+			return json.NewEncoder(cmd.OutOrStdout()).Encode(cred)
+		},
+	}
+
+	cmd.Flags().DurationVar(&expiration, "expiration", fleetview.DefaultCredentialsExpiration, "The lifetime of the credentials")
+	cmd.Flags().StringVar(&fleetViewURL, "fleet-view-url", os.Getenv(fleetViewURLEnvVar),
+		"The URL of the fleet view endpoint of the management cluster, defaults to the "+fleetViewURLEnvVar+" environment variable")
+	cmd.Flags().StringVar(&caFile, "fleet-view-ca-file", os.Getenv(fleetViewCAFileEnvVar),
+		"The CA certificate of the fleet view endpoint, defaults to the "+fleetViewCAFileEnvVar+" environment variable")
+
+	return cmd
+}
+
+// getFleetViewClient returns the HTTP client of the fleet view endpoint authenticating the requests
+// with the credentials of the kubeconfig of the management cluster and trusting the given CA certificate.
+func (o *options) getFleetViewClient(caFile string) (*http.Client, error) {
+	if o.fleetViewClient != nil {
+		return o.fleetViewClient, nil
+	}
+
+	restConfig, err := o.clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	restConfig = rest.CopyConfig(restConfig)
+	restConfig.TLSClientConfig = rest.TLSClientConfig{CAFile: caFile}
+
+	httpClient, err := rest.HTTPClientFor(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fleet view client: %w", err)
+	}
+
+	return httpClient, nil
+}
+
+// requestCredentials requests the credentials of the cluster of the given ClusterDeployment
+// with the given lifetime from the fleet view endpoint.
+func requestCredentials(ctx context.Context, httpClient *http.Client, fleetViewURL, namespace, name string, expiration time.Duration) (*clientauthv1.ExecCredential, error) {
+	u, err := url.Parse(strings.TrimSuffix(fleetViewURL, "/") + fleetview.CredentialsPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fleet view URL %q: %w", fleetViewURL, err)
+	}
+	u.RawQuery = url.Values{
+		"namespace":  {namespace},
+		"name":       {name},
+		"expiration": {expiration.String()},
+	}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	cred := new(clientauthv1.ExecCredential)
+	if err := json.Unmarshal(body, cred); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if cred.Status == nil || cred.Status.Token == "" {
+		return nil, errors.New("no token in the response")
+	}
+
+	return cred, nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientauthv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/K0rdent/kcm/internal/fleetview"
+)
+
+func TestClusterCredentials(t *testing.T) {
+	expiresAt := metav1.NewTime(time.Now().Add(time.Hour).Truncate(time.Second))
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, fleetview.CredentialsPath, r.URL.Path)
+		require.Equal(t, testNamespace, r.URL.Query().Get("namespace"))
+		require.Equal(t, "dev", r.URL.Query().Get("name"))
+		require.Equal(t, "30m0s", r.URL.Query().Get("expiration"))
+
+		require.NoError(t, json.NewEncoder(w).Encode(&clientauthv1.ExecCredential{
+			Status: &clientauthv1.ExecCredentialStatus{Token: "short-lived", ExpirationTimestamp: &expiresAt},
+		}))
+	}))
+	defer srv.Close()
+
+	o := &options{
+		client:          fake.NewClientBuilder().WithScheme(scheme).Build(),
+		fleetViewClient: srv.Client(),
+	}
+
+	out, err := run(t, o, "", "cluster", "credentials", "dev", "--expiration", "30m", "--fleet-view-url", srv.URL+"/")
+	require.NoError(t, err)
+
+	cred := new(clientauthv1.ExecCredential)
+	require.NoError(t, json.Unmarshal([]byte(out), cred))
+	require.Equal(t, "short-lived", cred.Status.Token)
+	require.True(t, expiresAt.Equal(cred.Status.ExpirationTimestamp))
+
+	_, err = run(t, o, "", "cluster", "credentials", "dev", "--expiration", "30m", "--fleet-view-url", "")
+	require.ErrorContains(t, err, fleetViewURLEnvVar)
+}
+
+func TestClusterCredentialsForbidden(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "user cannot request credentials of ClusterDeployment", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	o := &options{
+		client:          fake.NewClientBuilder().WithScheme(scheme).Build(),
+		fleetViewClient: srv.Client(),
+	}
+
+	_, err := run(t, o, "", "cluster", "credentials", "dev", "--fleet-view-url", srv.URL)
+	require.ErrorContains(t, err, "403 Forbidden: user cannot request credentials of ClusterDeployment")
+}
@@ -0,0 +1,158 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	hcv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/utils/fleet"
+)
+
+func newClusterDescribeCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe NAME",
+		Short: "Show the aggregated status of the ClusterDeployment, its CAPI objects, HelmRelease and services",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return describeCluster(cmd.Context(), o, cmd.OutOrStdout(), args[0])
+		},
+	}
+}
+
+func describeCluster(ctx context.Context, o *options, out io.Writer, name string) error {
+	cd, err := getClusterDeployment(ctx, o, name)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Name:\t%s\n", cd.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", cd.Namespace)
+	fmt.Fprintf(w, "Template:\t%s\n", cd.Spec.Template)
+	fmt.Fprintf(w, "Credential:\t%s\n", cd.Spec.Credential)
+	fmt.Fprintf(w, "Kubernetes Version:\t%s\n", valueOrNone(cd.Status.KubernetesVersion))
+	fmt.Fprintf(w, "Phase:\t%s\n", fleet.Phase(cd))
+	fmt.Fprintf(w, "Available Upgrades:\t%s\n", valueOrNone(strings.Join(cd.Status.AvailableUpgrades, ", ")))
+	printConditions(w, "Conditions", cd.Status.Conditions)
+
+	cluster := new(clusterapiv1beta1.Cluster)
+	if err := o.client.Get(ctx, client.ObjectKeyFromObject(cd), cluster); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get Cluster %s: %w", client.ObjectKeyFromObject(cd), err)
+		}
+		fmt.Fprintf(w, "\nCluster:\t<not found>\n")
+	} else {
+		fmt.Fprintf(w, "\nCluster:\n")
+		fmt.Fprintf(w, "  Phase:\t%s\n", valueOrNone(cluster.Status.Phase))
+		fmt.Fprintf(w, "  Control Plane Ready:\t%t\n", cluster.Status.ControlPlaneReady)
+		fmt.Fprintf(w, "  Infrastructure Ready:\t%t\n", cluster.Status.InfrastructureReady)
+		printCAPIConditions(w, cluster.Status.Conditions)
+	}
+
+	machines := new(clusterapiv1beta1.MachineList)
+	if err := o.client.List(ctx, machines, client.InNamespace(cd.Namespace), client.MatchingLabels{kcm.ClusterNameLabelKey: cd.Name}); err != nil {
+		return fmt.Errorf("failed to list Machines of the cluster %s: %w", client.ObjectKeyFromObject(cd), err)
+	}
+	fmt.Fprintf(w, "\nMachines:\n")
+	if len(machines.Items) == 0 {
+		fmt.Fprintf(w, "  <none>\n")
+	} else {
+		fmt.Fprintf(w, "  NAME\tPHASE\tVERSION\tNODE\n")
+		for _, m := range machines.Items {
+			var node string
+			if m.Status.NodeRef != nil {
+				node = m.Status.NodeRef.Name
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", m.Name, m.Status.Phase, valueOrNone(ptrValue(m.Spec.Version)), valueOrNone(node))
+		}
+	}
+
+	hr := new(hcv2.HelmRelease)
+	if err := o.client.Get(ctx, client.ObjectKeyFromObject(cd), hr); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get HelmRelease %s: %w", client.ObjectKeyFromObject(cd), err)
+		}
+		fmt.Fprintf(w, "\nHelmRelease:\t<not found>\n")
+	} else {
+		fmt.Fprintf(w, "\nHelmRelease:\n")
+		fmt.Fprintf(w, "  Chart:\t%s\n", valueOrNone(hr.Status.LastAttemptedRevision))
+		printConditions(w, "  Conditions", hr.Status.Conditions)
+	}
+
+	fmt.Fprintf(w, "\nServices:\n")
+	if len(cd.Status.Services) == 0 {
+		fmt.Fprintf(w, "  <none>\n")
+	}
+	for _, svc := range cd.Status.Services {
+		fmt.Fprintf(w, "  %s/%s:\n", svc.ClusterNamespace, svc.ClusterName)
+		printConditions(w, "    Conditions", svc.Conditions)
+	}
+
+	return w.Flush()
+}
+
+func printConditions(w io.Writer, title string, conditions []metav1.Condition) {
+	fmt.Fprintf(w, "%s:\n", title)
+	indent := strings.Repeat(" ", len(title)-len(strings.TrimLeft(title, " "))+2)
+	if len(conditions) == 0 {
+		fmt.Fprintf(w, "%s<none>\n", indent)
+		return
+	}
+
+	fmt.Fprintf(w, "%sTYPE\tSTATUS\tREASON\tMESSAGE\n", indent)
+	for _, c := range conditions {
+		fmt.Fprintf(w, "%s%s\t%s\t%s\t%s\n", indent, c.Type, c.Status, c.Reason, c.Message)
+	}
+}
+
+func printCAPIConditions(w io.Writer, conditions clusterapiv1beta1.Conditions) {
+	fmt.Fprintf(w, "  Conditions:\n")
+	if len(conditions) == 0 {
+		fmt.Fprintf(w, "    <none>\n")
+		return
+	}
+
+	fmt.Fprintf(w, "    TYPE\tSTATUS\tREASON\tMESSAGE\n")
+	for _, c := range conditions {
+		fmt.Fprintf(w, "    %s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, c.Message)
+	}
+}
+
+func valueOrNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+func ptrValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
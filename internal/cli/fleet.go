@@ -0,0 +1,121 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/utils/fleet"
+)
+
+func newFleetCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fleet",
+		Short: "Query the fleet of the clusters",
+	}
+
+	cmd.AddCommand(newFleetSearchCommand(o))
+
+	return cmd
+}
+
+type fleetSearchOptions struct {
+	query         fleet.Query
+	services      []string
+	allNamespaces bool
+}
+
+func newFleetSearchCommand(o *options) *cobra.Command {
+	so := new(fleetSearchOptions)
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Search the clusters by the provider, region, Kubernetes version and installed services",
+		Long: `Search the clusters by the provider, region, Kubernetes version and installed services.
+
+The clusters are searched in the index of the FleetSummary without connecting to the clusters.`,
+		Example: `  # the clusters running ingress-nginx older than 1.10
+  kcm fleet search -A --service 'ingress-nginx<1.10'
+
+  # the AWS clusters in us-east-2 running Kubernetes 1.31
+  kcm fleet search --provider aws --region us-east-2 --k8s-minor 1.31`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return so.search(cmd.Context(), o, cmd.OutOrStdout())
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&so.query.Provider, "provider", "", "The infrastructure provider of the clusters, e.g. aws")
+	flags.StringVar(&so.query.Region, "region", "", "The region of the clusters")
+	flags.StringVar(&so.query.KubernetesMinorVersion, "k8s-minor", "", "The minor version of Kubernetes of the clusters, e.g. 1.32")
+	flags.StringArrayVar(&so.services, "service", nil, "The service or Helm chart the clusters run, optionally with the version constraint, e.g. 'ingress-nginx<1.10', can be repeated")
+	flags.BoolVarP(&so.allNamespaces, "all-namespaces", "A", false, "Search the clusters in all the namespaces")
+
+	return cmd
+}
+
+func (so *fleetSearchOptions) search(ctx context.Context, o *options, out io.Writer) error {
+	for _, s := range so.services {
+		c, err := fleet.ParseServiceConstraint(s)
+		if err != nil {
+			return err
+		}
+		so.query.Services = append(so.query.Services, c)
+	}
+
+	summary := new(kcm.FleetSummary)
+	if err := o.client.Get(ctx, client.ObjectKey{Name: kcm.FleetSummaryName}, summary); err != nil {
+		return fmt.Errorf("failed to get FleetSummary %s: %w", kcm.FleetSummaryName, err)
+	}
+
+	clusters := summary.Status.Clusters
+	if !so.allNamespaces {
+		var inNamespace []kcm.FleetClusterSummary
+		for _, c := range clusters {
+			if c.Namespace == o.namespace {
+				inNamespace = append(inNamespace, c)
+			}
+		}
+		clusters = inNamespace
+	}
+
+	matched := fleet.Search(clusters, so.query)
+	if len(matched) == 0 {
+		_, err := fmt.Fprintln(out, "No clusters found")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tPROVIDERS\tREGION\tKUBERNETES\tPHASE\tSERVICES")
+	for _, c := range matched {
+		services := make([]string, 0, len(c.Services))
+		for _, svc := range c.Services {
+			services = append(services, svc.Name+":"+valueOrNone(svc.Version))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", c.Namespace, c.Name,
+			valueOrNone(strings.Join(c.Providers, ",")), valueOrNone(c.Region), valueOrNone(c.KubernetesVersion),
+			c.Phase, valueOrNone(strings.Join(services, ",")))
+	}
+
+	return w.Flush()
+}
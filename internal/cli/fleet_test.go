@@ -0,0 +1,72 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestFleetSearch(t *testing.T) {
+	summary := &kcm.FleetSummary{
+		ObjectMeta: metav1.ObjectMeta{Name: kcm.FleetSummaryName},
+		Status: kcm.FleetSummaryStatus{
+			Clusters: []kcm.FleetClusterSummary{
+				{
+					Name: "dev", Namespace: testNamespace, Providers: []string{"infrastructure-aws"}, Region: "us-east-2",
+					KubernetesVersion: "v1.31.1", KubernetesMinorVersion: "1.31", Phase: kcm.ClusterPhaseReady,
+					Services: []kcm.FleetServiceSummary{{Name: "ingress", Template: "ingress-nginx-1-9-6", Chart: "ingress-nginx", Version: "1.9.6"}},
+				},
+				{
+					Name: "prod", Namespace: "team-b", Providers: []string{"infrastructure-aws"}, Region: "us-east-2",
+					KubernetesVersion: "v1.31.1", KubernetesMinorVersion: "1.31", Phase: kcm.ClusterPhaseReady,
+					Services: []kcm.FleetServiceSummary{{Name: "ingress", Template: "ingress-nginx-1-9-6", Chart: "ingress-nginx", Version: "1.9.6"}},
+				},
+				{
+					Name: "new", Namespace: testNamespace, Providers: []string{"infrastructure-aws"},
+					KubernetesVersion: "v1.32.2", KubernetesMinorVersion: "1.32", Phase: kcm.ClusterPhaseProvisioning,
+				},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(summary).Build()
+
+	out, err := run(t, &options{client: cl}, "", "fleet", "search", "--service", "ingress-nginx<1.10")
+	require.NoError(t, err)
+	require.Equal(t, `NAMESPACE  NAME  PROVIDERS           REGION     KUBERNETES  PHASE  SERVICES
+team-a     dev   infrastructure-aws  us-east-2  v1.31.1     Ready  ingress:1.9.6
+`, out)
+
+	out, err = run(t, &options{client: cl}, "", "fleet", "search", "-A", "--service", "ingress-nginx<1.10")
+	require.NoError(t, err)
+	require.Contains(t, out, "team-b     prod")
+
+	out, err = run(t, &options{client: cl}, "", "fleet", "search", "--k8s-minor", "1.32")
+	require.NoError(t, err)
+	require.Contains(t, out, "new")
+	require.NotContains(t, out, "dev")
+
+	out, err = run(t, &options{client: cl}, "", "fleet", "search", "--region", "eu-west-1")
+	require.NoError(t, err)
+	require.Equal(t, "No clusters found\n", out)
+
+	_, err = run(t, &options{client: cl}, "", "fleet", "search", "--service", "<1.10")
+	require.ErrorContains(t, err, "the name of the service is missing")
+}
@@ -0,0 +1,257 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/utils"
+)
+
+const redactedValue = "REDACTED"
+
+// supportBundleKinds is the list of the kinds collected in addition to the KCM kinds.
+var supportBundleKinds = []schema.GroupVersionKind{
+	{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "Cluster"},
+	{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "MachineDeployment"},
+	{Group: "cluster.x-k8s.io", Version: "v1beta1", Kind: "Machine"},
+	{Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"},
+	{Group: "source.toolkit.fluxcd.io", Version: "v1", Kind: "HelmChart"},
+	{Group: "config.projectsveltos.io", Version: "v1beta1", Kind: "ClusterSummary"},
+	{Group: "config.projectsveltos.io", Version: "v1beta1", Kind: "ClusterProfile"},
+	{Group: "config.projectsveltos.io", Version: "v1beta1", Kind: "Profile"},
+	{Group: "lib.projectsveltos.io", Version: "v1beta1", Kind: "SveltosCluster"},
+	{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "ValidatingWebhookConfiguration"},
+	{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "MutatingWebhookConfiguration"},
+}
+
+// sensitiveKeyRegexp matches the keys of the values redacted from the collected objects.
+var sensitiveKeyRegexp = regexp.MustCompile(`(?i)(password|passwd|secret|token|apikey|privatekey)`)
+
+type supportBundleOptions struct {
+	output          string
+	systemNamespace string
+}
+
+func newSupportBundleCommand(o *options) *cobra.Command {
+	so := new(supportBundleOptions)
+	cmd := &cobra.Command{
+		Use:   "support-bundle",
+		Short: "Collect the KCM objects, controller logs and related resources into an archive",
+		Long: `Collect the KCM objects, the logs of the controllers in the system namespace,
+the related CAPI, Flux and Sveltos objects and the webhook configurations into a gzipped tar archive.
+Secrets are not collected and the sensitive values of the collected objects are redacted.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if so.output == "" {
+				so.output = "kcm-support-bundle-" + time.Now().UTC().Format("20060102T150405Z") + ".tar.gz"
+			}
+
+			f, err := os.Create(so.output)
+			if err != nil {
+				return fmt.Errorf("failed to create support bundle: %w", err)
+			}
+			defer f.Close()
+
+			if err := collectSupportBundle(cmd.Context(), o, so.systemNamespace, f); err != nil {
+				return err
+			}
+
+			if err := f.Close(); err != nil {
+				return fmt.Errorf("failed to write support bundle: %w", err)
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Support bundle written to %s\n", so.output)
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&so.output, "output", "o", "", "The path of the archive, defaults to kcm-support-bundle-<timestamp>.tar.gz")
+	flags.StringVar(&so.systemNamespace, "system-namespace", utils.DefaultSystemNamespace, "The namespace KCM is installed to")
+
+	return cmd
+}
+
+// bundleWriter writes the files of the support bundle, collecting the errors
+// that occurred during the collection rather than failing on the first one.
+type bundleWriter struct {
+	tw     *tar.Writer
+	now    time.Time
+	errors []string
+}
+
+func (b *bundleWriter) writeFile(name string, data []byte) error {
+	if err := b.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: b.now,
+	}); err != nil {
+		return fmt.Errorf("failed to write %s to support bundle: %w", name, err)
+	}
+	if _, err := b.tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to support bundle: %w", name, err)
+	}
+
+	return nil
+}
+
+func (b *bundleWriter) collectError(format string, args ...any) {
+	b.errors = append(b.errors, fmt.Sprintf(format, args...))
+}
+
+func collectSupportBundle(ctx context.Context, o *options, systemNamespace string, out io.Writer) error {
+	gw := gzip.NewWriter(out)
+	b := &bundleWriter{tw: tar.NewWriter(gw), now: time.Now()}
+
+	var kinds []schema.GroupVersionKind
+	for kind := range scheme.KnownTypes(kcm.GroupVersion) {
+		if strings.HasSuffix(kind, "List") {
+			kinds = append(kinds, kcm.GroupVersion.WithKind(strings.TrimSuffix(kind, "List")))
+		}
+	}
+	kinds = append(kinds, supportBundleKinds...)
+
+	for _, gvk := range kinds {
+		if err := collectObjects(ctx, o.client, b, gvk); err != nil {
+			return err
+		}
+	}
+
+	if err := collectLogs(ctx, o, b, systemNamespace); err != nil {
+		return err
+	}
+
+	if len(b.errors) > 0 {
+		if err := b.writeFile("errors.txt", []byte(strings.Join(b.errors, "\n")+"\n")); err != nil {
+			return err
+		}
+	}
+
+	if err := b.tw.Close(); err != nil {
+		return fmt.Errorf("failed to write support bundle: %w", err)
+	}
+
+	return gw.Close()
+}
+
+// collectObjects writes the redacted objects of the given kind to resources/<group>/<kind>/<namespace>/<name>.yaml.
+func collectObjects(ctx context.Context, cl client.Client, b *bundleWriter, gvk schema.GroupVersionKind) error {
+	list := new(unstructured.UnstructuredList)
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := cl.List(ctx, list); err != nil {
+		if !apimeta.IsNoMatchError(err) {
+			b.collectError("failed to list %s: %v", gvk.Kind, err)
+		}
+		return nil
+	}
+
+	group := gvk.Group
+	if group == "" {
+		group = "core"
+	}
+
+	for _, obj := range list.Items {
+		obj.SetManagedFields(nil)
+		redact(obj.Object)
+
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			b.collectError("failed to marshal %s %s: %v", gvk.Kind, client.ObjectKeyFromObject(&obj), err)
+			continue
+		}
+
+		namespace := obj.GetNamespace()
+		if namespace == "" {
+			namespace = "_cluster"
+		}
+
+		if err := b.writeFile(path.Join("resources", group, strings.ToLower(gvk.Kind), namespace, obj.GetName()+".yaml"), data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// collectLogs writes the logs of the containers of the pods in the system namespace to logs/<pod>/<container>.log.
+func collectLogs(ctx context.Context, o *options, b *bundleWriter, namespace string) error {
+	pods, err := o.kubeClient.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		b.collectError("failed to list pods in the namespace %s: %v", namespace, err)
+		return nil
+	}
+
+	for _, pod := range pods.Items {
+		for _, container := range pod.Spec.Containers {
+			logs, err := o.kubeClient.CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: container.Name}).DoRaw(ctx)
+			if err != nil {
+				b.collectError("failed to get logs of the container %s of the pod %s/%s: %v", container.Name, namespace, pod.Name, err)
+				continue
+			}
+
+			if err := b.writeFile(path.Join("logs", pod.Name, container.Name+".log"), logs); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// redact replaces the sensitive string values of the given object.
+func redact(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if s, ok := value.(string); ok && s != "" && isSensitiveKey(key) {
+				v[key] = redactedValue
+				continue
+			}
+			redact(value)
+		}
+	case []any:
+		for _, value := range v {
+			redact(value)
+		}
+	}
+}
+
+// isSensitiveKey returns whether the value of the given key is sensitive,
+// skipping the references to the objects holding the sensitive values.
+func isSensitiveKey(key string) bool {
+	return sensitiveKeyRegexp.MatchString(key) &&
+		!strings.HasSuffix(key, "Name") && !strings.HasSuffix(key, "Ref") && !strings.HasSuffix(key, "Namespace")
+}
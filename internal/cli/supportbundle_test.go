@@ -0,0 +1,82 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestSupportBundle(t *testing.T) {
+	cd := newClusterDeployment()
+	cd.Spec.Config = &apiextensionsv1.JSON{Raw: []byte(`{"workersNumber":2,"auth":{"password":"p4ss","secretName":"auth"}}`)}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		cd,
+		&kcm.Management{ObjectMeta: metav1.ObjectMeta{Name: kcm.ManagementName}},
+	).Build()
+	kubeClient := kubefake.NewClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "kcm-controller-manager-abc", Namespace: "kcm-system"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "manager"}}},
+	})
+
+	output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	out, err := run(t, &options{client: cl, kubeClient: kubeClient}, "", "support-bundle", "-o", output)
+	require.NoError(t, err)
+	require.Equal(t, "Support bundle written to "+output+"\n", out)
+
+	f, err := os.Open(output)
+	require.NoError(t, err)
+	defer f.Close()
+
+	gr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(data)
+	}
+
+	require.Contains(t, files, "resources/k0rdent.mirantis.com/management/_cluster/kcm.yaml")
+	require.Equal(t, "fake logs", files["logs/kcm-controller-manager-abc/manager.log"])
+
+	cdFile := files["resources/k0rdent.mirantis.com/clusterdeployment/team-a/dev.yaml"]
+	require.Contains(t, cdFile, "password: REDACTED")
+	require.Contains(t, cdFile, "secretName: auth")
+	require.Contains(t, cdFile, "workersNumber: 2")
+	require.NotContains(t, cdFile, "p4ss")
+}
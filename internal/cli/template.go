@@ -0,0 +1,104 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/templatelint"
+)
+
+func newTemplateCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "template",
+		Aliases: []string{"templates"},
+		Short:   "Author and inspect the ClusterTemplates",
+	}
+
+	cmd.AddCommand(
+		newTemplateLintCommand(),
+		newTemplateSchemaCommand(o),
+	)
+
+	return cmd
+}
+
+func newTemplateLintCommand() *cobra.Command {
+	var providers []string
+	cmd := &cobra.Command{
+		Use:   "lint PATH",
+		Short: "Validate the structure of the Helm chart of a ClusterTemplate",
+		Long: `Validate the structure of the Helm chart of a ClusterTemplate.
+
+The chart directory or archive is checked the same way KCM checks the chart of a ClusterTemplate:
+the Kubernetes version, the providers and their contract versions set in the chart annotations,
+the cluster identity kind and the default values against the schema of the values.`,
+		Args: cobra.ExactArgs(1),
+		// the lint works with the local chart and does not need the management cluster
+		PersistentPreRunE: func(*cobra.Command, []string) error { return nil },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			helmChart, err := loader.Load(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to load chart %s: %w", args[0], err)
+			}
+
+			issues := templatelint.ClusterTemplate(helmChart, providers)
+			for _, issue := range issues {
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), issue)
+			}
+			if len(issues) > 0 {
+				return fmt.Errorf("chart %s has %d issue(s)", args[0], len(issues))
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Chart %s is valid\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&providers, "provider", nil, "The providers set in the ClusterTemplate spec instead of the chart annotations")
+
+	return cmd
+}
+
+func newTemplateSchemaCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema NAME",
+		Short: "Print the JSON schema of the configuration of the clusters deployed by the ClusterTemplate",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			template := new(kcm.ClusterTemplate)
+			if err := o.client.Get(cmd.Context(), client.ObjectKey{Namespace: o.namespace, Name: args[0]}, template); err != nil {
+				return fmt.Errorf("failed to get ClusterTemplate %s/%s: %w", o.namespace, args[0], err)
+			}
+			if template.Status.ConfigSchema == nil {
+				return fmt.Errorf("ClusterTemplate %s/%s defines no configuration schema", o.namespace, args[0])
+			}
+
+			schema := new(bytes.Buffer)
+			if err := json.Indent(schema, template.Status.ConfigSchema.Raw, "", "  "); err != nil {
+				return fmt.Errorf("failed to parse configuration schema of ClusterTemplate %s/%s: %w", o.namespace, args[0], err)
+			}
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), schema.String())
+			return err
+		},
+	}
+}
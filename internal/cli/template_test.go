@@ -0,0 +1,81 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestTemplateLint(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte(`apiVersion: v2
+name: test
+version: 0.1.0
+annotations:
+  cluster.x-k8s.io/provider: infrastructure-aws, control-plane-k0sproject-k0smotron, bootstrap-k0sproject-k0smotron
+  cluster.x-k8s.io/infrastructure-aws: v1beta2
+`), 0o600))
+
+	// the lint does not need the management cluster
+	out, err := run(t, new(options), "", "template", "lint", dir)
+	require.NoError(t, err)
+	require.Equal(t, "Chart "+dir+" is valid\n", out)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "values.yaml"), []byte("workersNumber: two\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "values.schema.json"), []byte(`{"type":"object","properties":{"workersNumber":{"type":"integer"}}}`), 0o600))
+
+	out, err = run(t, new(options), "", "template", "lint", dir)
+	require.EqualError(t, err, "chart "+dir+" has 1 issue(s)")
+	require.Contains(t, out, "values.schema.json: the default values do not match the schema: - workersNumber: Invalid type. Expected: integer, given: string\n")
+
+	_, err = run(t, new(options), "", "template", "lint", filepath.Join(dir, "missing"))
+	require.ErrorContains(t, err, "failed to load chart")
+}
+
+func TestTemplateSchema(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&kcm.ClusterTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws-1-0-0", Namespace: testNamespace},
+			Status: kcm.ClusterTemplateStatus{
+				ConfigSchema: &apiextensionsv1.JSON{Raw: []byte(`{"type":"object","properties":{"region":{"type":"string"}}}`)},
+			},
+		},
+		&kcm.ClusterTemplate{ObjectMeta: metav1.ObjectMeta{Name: "adopted-1-0-0", Namespace: testNamespace}},
+	).Build()
+
+	out, err := run(t, &options{client: cl}, "", "template", "schema", "aws-1-0-0")
+	require.NoError(t, err)
+	require.Equal(t, `{
+  "type": "object",
+  "properties": {
+    "region": {
+      "type": "string"
+    }
+  }
+}
+`, out)
+
+	_, err = run(t, &options{client: cl}, "", "template", "schema", "adopted-1-0-0")
+	require.EqualError(t, err, "ClusterTemplate team-a/adopted-1-0-0 defines no configuration schema")
+}
@@ -0,0 +1,91 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloudtags implements the ClusterTemplate values conventions of the tags
+// of the cloud resources of the clusters and enforces the cloud tag policy of the Management.
+//
+// A ClusterTemplate supports the cloud tags if its default values define the "cloudTags" key.
+// The template passes the tags to the infrastructure provider applying them to all the
+// cloud resources of the cluster, e.g. the additionalTags of the AWSCluster.
+package cloudtags
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// ValuesKey is the key of the template values holding the cloud tags.
+const ValuesKey = "cloudTags"
+
+// Values returns the template values of the given cloud tags.
+func Values(tags map[string]string) map[string]any {
+	values := make(map[string]any, len(tags))
+	for k, v := range tags {
+		values[k] = v
+	}
+	return values
+}
+
+// TemplateSupported returns true if the ClusterTemplate with the given default values
+// supports tagging the cloud resources of the cluster.
+func TemplateSupported(config *apiextensionsv1.JSON) (bool, error) {
+	if config == nil || len(config.Raw) == 0 {
+		return false, nil
+	}
+
+	values := make(map[string]any)
+	if err := json.Unmarshal(config.Raw, &values); err != nil {
+		return false, fmt.Errorf("failed to parse template config: %w", err)
+	}
+
+	_, found, err := unstructured.NestedMap(values, ValuesKey)
+	return found && err == nil, nil
+}
+
+// Validate checks that the given cloud tags set all the mandatory tags of the given policy with a non-empty value.
+func Validate(tags map[string]string, policy *kcm.CloudTagPolicy) error {
+	if policy == nil {
+		return nil
+	}
+
+	var missing []string
+	for _, key := range policy.MandatoryTags {
+		if tags[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the mandatory cloud tags are not set: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// Merge returns the given cloud tags merged with the given template values of the tags,
+// the given cloud tags take precedence.
+func Merge(values map[string]any, tags map[string]string) map[string]any {
+	merged := maps.Clone(values)
+	if merged == nil {
+		merged = make(map[string]any, len(tags))
+	}
+	maps.Copy(merged, Values(tags))
+	return merged
+}
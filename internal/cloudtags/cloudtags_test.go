@@ -0,0 +1,49 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudtags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestTemplateSupported(t *testing.T) {
+	supported, err := TemplateSupported(&apiextensionsv1.JSON{Raw: []byte(`{"cloudTags":{}}`)})
+	require.NoError(t, err)
+	require.True(t, supported)
+
+	supported, err = TemplateSupported(&apiextensionsv1.JSON{Raw: []byte(`{"controlPlaneNumber":3}`)})
+	require.NoError(t, err)
+	require.False(t, supported)
+}
+
+func TestValidate(t *testing.T) {
+	policy := &kcm.CloudTagPolicy{MandatoryTags: []string{"cost-center", "owner"}}
+
+	require.NoError(t, Validate(nil, nil))
+	require.NoError(t, Validate(map[string]string{"cost-center": "cc-1", "owner": "team-a", "env": "dev"}, policy))
+	require.EqualError(t, Validate(map[string]string{"owner": "team-a"}, policy), "the mandatory cloud tags are not set: cost-center")
+	require.EqualError(t, Validate(nil, policy), "the mandatory cloud tags are not set: cost-center, owner")
+}
+
+func TestMerge(t *testing.T) {
+	require.Equal(t, map[string]any{"owner": "team-a", "env": "dev"},
+		Merge(map[string]any{"owner": "team-b", "env": "dev"}, map[string]string{"owner": "team-a"}))
+	require.Equal(t, map[string]any{"owner": "team-a"}, Merge(nil, map[string]string{"owner": "team-a"}))
+}
@@ -0,0 +1,390 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clustercache maintains the cached clients of the clusters deployed by the ClusterDeployments.
+// The nodes and the Jobs managed by KCM are watched in the clusters, so their changes requeue
+// the ClusterDeployments instead of polling the clusters through their kubeconfigs.
+package clustercache
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// syncTimeout is the time the caches of a cluster are given to sync before the cluster is considered unreachable.
+	syncTimeout = 10 * time.Second
+	// healthCheckInterval is the interval the reachability of the clusters is checked at.
+	healthCheckInterval = time.Minute
+	// healthCheckTimeout is the timeout of a single health check of a cluster.
+	healthCheckTimeout = 10 * time.Second
+	// maxHealthCheckFailures is the number of the consecutive failed health checks the caches of a cluster are stopped after.
+	maxHealthCheckFailures = 3
+)
+
+// Tracker maintains the cached clients of the clusters. The caches of a cluster are started on the first
+// request of its client, restarted once its kubeconfig is rotated and stopped once it is deleted or unreachable.
+type Tracker struct {
+	scheme *runtime.Scheme
+
+	// newCluster creates the caches and the client of the cluster reachable with the given configuration.
+	newCluster func(cfg *rest.Config, scheme *runtime.Scheme) (*cluster, error)
+
+	clusters map[client.ObjectKey]*cluster
+	events   chan event.GenericEvent
+
+	mu sync.Mutex
+}
+
+// NewTracker returns the [Tracker] of the clusters reading the objects into the given scheme.
+func NewTracker(scheme *runtime.Scheme) *Tracker {
+	return &Tracker{
+		scheme:     scheme,
+		newCluster: newCluster,
+		clusters:   make(map[client.ObjectKey]*cluster),
+		events:     make(chan event.GenericEvent, 1024),
+	}
+}
+
+// Source returns the source of the requests of the ClusterDeployments whose clusters have changed.
+func (t *Tracker) Source() source.Source {
+	return source.Channel(t.events, &handler.EnqueueRequestForObject{})
+}
+
+// Client returns the client of the cluster of the ClusterDeployment with the given key reachable with the given kubeconfig.
+// The nodes and the Jobs managed by KCM are read from the caches of the cluster, the other objects from the cluster itself.
+func (t *Tracker) Client(ctx context.Context, key client.ObjectKey, kubeconfig []byte) (client.Client, error) {
+	hash := sha256.Sum256(kubeconfig)
+
+	t.mu.Lock()
+	if c, ok := t.clusters[key]; ok {
+		if c.kubeconfigHash == hash {
+			t.mu.Unlock()
+			return c.client, nil
+		}
+		ctrl.LoggerFrom(ctx).Info("Kubeconfig of the cluster has been rotated, restarting the cluster caches")
+		c.stop()
+		delete(t.clusters, key)
+	}
+	t.mu.Unlock()
+
+	// the caches are started without holding the lock, so the unreachable
+	// clusters do not block the clients of the other ones
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	c, err := t.newCluster(cfg, t.scheme)
+	if err != nil {
+		return nil, err
+	}
+	c.kubeconfigHash = hash
+
+	if err := c.start(ctx, t.requeue(key)); err != nil {
+		c.stop()
+		return nil, fmt.Errorf("failed to start the cluster caches: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if existing, ok := t.clusters[key]; ok {
+		existing.stop()
+	}
+	t.clusters[key] = c
+
+	return c.client, nil
+}
+
+// Stop stops the caches of the cluster of the ClusterDeployment with the given key, e.g. once the ClusterDeployment is deleted.
+func (t *Tracker) Stop(key client.ObjectKey) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if c, ok := t.clusters[key]; ok {
+		c.stop()
+		delete(t.clusters, key)
+	}
+}
+
+// NeedLeaderElection implements the [sigs.k8s.io/controller-runtime/pkg/manager.LeaderElectionRunnable]
+// interface, the clusters are only watched by the leader reconciling the ClusterDeployments.
+func (*Tracker) NeedLeaderElection() bool {
+	return true
+}
+
+// Start checks the reachability of the clusters until the given context is done, stopping the caches of
+// the unreachable clusters, so their clients are recreated once the ClusterDeployments are reconciled again.
+func (t *Tracker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			t.stopAll()
+			return nil
+		case <-ticker.C:
+			t.checkHealth(ctx)
+		}
+	}
+}
+
+func (t *Tracker) checkHealth(ctx context.Context) {
+	t.mu.Lock()
+	clusters := maps.Clone(t.clusters)
+	t.mu.Unlock()
+
+	for key, c := range clusters {
+		if err := c.checkHealth(); err != nil {
+			c.healthCheckFailures++
+			if c.healthCheckFailures < maxHealthCheckFailures {
+				continue
+			}
+
+			ctrl.LoggerFrom(ctx).Info("Cluster is unreachable, stopping the cluster caches", "ClusterDeployment", key, "error", err.Error())
+			t.mu.Lock()
+			// the cluster could have been restarted meanwhile
+			if t.clusters[key] == c {
+				c.stop()
+				delete(t.clusters, key)
+			}
+			t.mu.Unlock()
+			t.requeue(key)()
+			continue
+		}
+		c.healthCheckFailures = 0
+	}
+}
+
+func (t *Tracker) stopAll() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, c := range t.clusters {
+		c.stop()
+		delete(t.clusters, key)
+	}
+}
+
+// requeue returns the function requesting the reconcile of the ClusterDeployment with the given key.
+func (t *Tracker) requeue(key client.ObjectKey) func() {
+	return func() {
+		obj := &kcm.ClusterDeployment{}
+		obj.SetNamespace(key.Namespace)
+		obj.SetName(key.Name)
+		select {
+		case t.events <- event.GenericEvent{Object: obj}:
+		default: // the ClusterDeployment is requeued on the next change or its periodic reconcile
+		}
+	}
+}
+
+// cluster holds the caches and the client of a cluster.
+type cluster struct {
+	cache  cache.Cache
+	client client.Client
+	health discovery.ServerVersionInterface
+	cancel context.CancelFunc
+
+	kubeconfigHash      [sha256.Size]byte
+	healthCheckFailures int
+}
+
+func newCluster(cfg *rest.Config, scheme *runtime.Scheme) (*cluster, error) {
+	httpClient, err := rest.HTTPClientFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	c, err := cache.New(cfg, cache.Options{
+		HTTPClient: httpClient,
+		Scheme:     scheme,
+		ByObject: map[client.Object]cache.ByObject{
+			&batchv1.Job{}: {Label: labels.SelectorFromSet(labels.Set{kcm.KCMManagedLabelKey: kcm.KCMManagedLabelValue})},
+		},
+		DefaultTransform: cache.TransformStripManagedFields(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache: %w", err)
+	}
+
+	cl, err := client.New(cfg, client.Options{
+		HTTPClient: httpClient,
+		Scheme:     scheme,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	healthCfg := rest.CopyConfig(cfg)
+	healthCfg.Timeout = healthCheckTimeout
+	health, err := discovery.NewDiscoveryClientForConfig(healthCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+	}
+
+	return &cluster{
+		cache:  c,
+		client: &cachedClient{Client: cl, cache: c},
+		health: health,
+	}, nil
+}
+
+// start starts the caches of the cluster calling the given function on the changes of the watched objects.
+func (c *cluster) start(ctx context.Context, changed func()) error {
+	// the caches outlive the request they are started for
+	cacheCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	c.cancel = cancel
+
+	for obj, handler := range map[client.Object]toolscache.ResourceEventHandler{
+		&corev1.Node{}: toolscache.ResourceEventHandlerFuncs{
+			AddFunc:    func(any) { changed() },
+			UpdateFunc: func(oldObj, newObj any) { nodeReadinessChanged(oldObj, newObj, changed) },
+			DeleteFunc: func(any) { changed() },
+		},
+		&batchv1.Job{}: toolscache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(oldObj, newObj any) { jobFinished(oldObj, newObj, changed) },
+			DeleteFunc: func(any) { changed() },
+		},
+	} {
+		informer, err := c.cache.GetInformer(cacheCtx, obj)
+		if err != nil {
+			return fmt.Errorf("failed to get informer of %T: %w", obj, err)
+		}
+		if _, err := informer.AddEventHandler(handler); err != nil {
+			return fmt.Errorf("failed to add event handler of %T: %w", obj, err)
+		}
+	}
+
+	go func() {
+		if err := c.cache.Start(cacheCtx); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "failed to start the cluster caches")
+		}
+	}()
+
+	syncCtx, cancelSync := context.WithTimeout(cacheCtx, syncTimeout)
+	defer cancelSync()
+	if !c.cache.WaitForCacheSync(syncCtx) {
+		return errors.New("timed out waiting for the caches to sync")
+	}
+
+	return nil
+}
+
+func (c *cluster) stop() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+func (c *cluster) checkHealth() error {
+	_, err := c.health.ServerVersion()
+	return err
+}
+
+// nodeReadinessChanged calls the given function if the readiness of the node has changed,
+// the other updates, e.g. the heartbeats, are ignored.
+func nodeReadinessChanged(oldObj, newObj any, changed func()) {
+	oldNode, ok := oldObj.(*corev1.Node)
+	if !ok {
+		return
+	}
+	newNode, ok := newObj.(*corev1.Node)
+	if !ok {
+		return
+	}
+	if nodeReady(oldNode) != nodeReady(newNode) {
+		changed()
+	}
+}
+
+func nodeReady(node *corev1.Node) corev1.ConditionStatus {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status
+		}
+	}
+	return corev1.ConditionUnknown
+}
+
+// jobFinished calls the given function once the Job has finished.
+func jobFinished(oldObj, newObj any, changed func()) {
+	oldJob, ok := oldObj.(*batchv1.Job)
+	if !ok {
+		return
+	}
+	newJob, ok := newObj.(*batchv1.Job)
+	if !ok {
+		return
+	}
+	if oldJob.Status.CompletionTime == nil && newJob.Status.CompletionTime != nil ||
+		oldJob.Status.Failed != newJob.Status.Failed {
+		changed()
+	}
+}
+
+// cachedClient reads the watched objects from the caches of the cluster and the other ones from the cluster itself.
+type cachedClient struct {
+	client.Client
+
+	cache cache.Cache
+}
+
+func (c *cachedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if watched(obj) {
+		return c.cache.Get(ctx, key, obj, opts...)
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *cachedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if watched(list) {
+		return c.cache.List(ctx, list, opts...)
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+// watched returns true if the given object or list is watched in the clusters.
+func watched(obj runtime.Object) bool {
+	switch obj.(type) {
+	case *corev1.Node, *corev1.NodeList, *batchv1.Job, *batchv1.JobList:
+		return true
+	default:
+		return false
+	}
+}
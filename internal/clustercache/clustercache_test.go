@@ -0,0 +1,182 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustercache
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func kubeconfigFor(server string) []byte {
+	return fmt.Appendf(nil, `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: %s
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: test
+`, server)
+}
+
+func newTestTracker() (*Tracker, map[string]*informertest.FakeInformers) {
+	caches := make(map[string]*informertest.FakeInformers)
+
+	t := NewTracker(scheme.Scheme)
+	t.newCluster = func(cfg *rest.Config, s *runtime.Scheme) (*cluster, error) {
+		c := &informertest.FakeInformers{Scheme: s}
+		caches[cfg.Host] = c
+		return &cluster{
+			cache:  c,
+			client: &cachedClient{Client: fake.NewClientBuilder().WithScheme(s).Build(), cache: c},
+		}, nil
+	}
+
+	return t, caches
+}
+
+func TestClient(t *testing.T) {
+	g := NewWithT(t)
+
+	tracker, caches := newTestTracker()
+	key := client.ObjectKey{Namespace: "default", Name: "test"}
+
+	cl, err := tracker.Client(t.Context(), key, kubeconfigFor("https://first"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(caches).To(HaveKey("https://first"))
+
+	same, err := tracker.Client(t.Context(), key, kubeconfigFor("https://first"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(same).To(BeIdenticalTo(cl), "the client of the tracked cluster must be reused")
+
+	rotated, err := tracker.Client(t.Context(), key, kubeconfigFor("https://second"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rotated).NotTo(BeIdenticalTo(cl), "the client must be recreated once the kubeconfig is rotated")
+	g.Expect(caches).To(HaveKey("https://second"))
+	g.Expect(tracker.clusters).To(HaveLen(1))
+
+	tracker.Stop(key)
+	g.Expect(tracker.clusters).To(BeEmpty())
+
+	_, err = tracker.Client(t.Context(), key, []byte("invalid"))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(tracker.clusters).To(BeEmpty())
+}
+
+func TestClientSyncFailure(t *testing.T) {
+	g := NewWithT(t)
+
+	synced := false
+	tracker := NewTracker(scheme.Scheme)
+	tracker.newCluster = func(_ *rest.Config, s *runtime.Scheme) (*cluster, error) {
+		return &cluster{cache: &informertest.FakeInformers{Scheme: s, Synced: &synced}}, nil
+	}
+
+	_, err := tracker.Client(t.Context(), client.ObjectKey{Namespace: "default", Name: "test"}, kubeconfigFor("https://unreachable"))
+	g.Expect(err).To(MatchError(ContainSubstring("failed to start the cluster caches")))
+	g.Expect(tracker.clusters).To(BeEmpty())
+}
+
+func TestEvents(t *testing.T) {
+	g := NewWithT(t)
+
+	tracker, caches := newTestTracker()
+	key := client.ObjectKey{Namespace: "default", Name: "test"}
+
+	_, err := tracker.Client(t.Context(), key, kubeconfigFor("https://cluster"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	nodes, err := caches["https://cluster"].FakeInformerFor(t.Context(), &corev1.Node{})
+	g.Expect(err).NotTo(HaveOccurred())
+	jobs, err := caches["https://cluster"].FakeInformerFor(t.Context(), &batchv1.Job{})
+	g.Expect(err).NotTo(HaveOccurred())
+
+	node := func(ready corev1.ConditionStatus, heartbeat int64) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{{
+				Type:              corev1.NodeReady,
+				Status:            ready,
+				LastHeartbeatTime: metav1.Unix(heartbeat, 0),
+			}}},
+		}
+	}
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "job"}}
+	completed := job.DeepCopy()
+	completed.Status.CompletionTime = &metav1.Time{}
+
+	for _, tc := range []struct {
+		name    string
+		trigger func()
+		requeue bool
+	}{
+		{name: "node added", trigger: func() { nodes.Add(node(corev1.ConditionTrue, 0)) }, requeue: true},
+		{name: "node heartbeat", trigger: func() { nodes.Update(node(corev1.ConditionTrue, 0), node(corev1.ConditionTrue, 1)) }},
+		{name: "node not ready", trigger: func() { nodes.Update(node(corev1.ConditionTrue, 0), node(corev1.ConditionFalse, 1)) }, requeue: true},
+		{name: "node deleted", trigger: func() { nodes.Delete(node(corev1.ConditionTrue, 0)) }, requeue: true},
+		{name: "job added", trigger: func() { jobs.Add(job) }},
+		{name: "job running", trigger: func() { jobs.Update(job, job) }},
+		{name: "job completed", trigger: func() { jobs.Update(job, completed) }, requeue: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			tc.trigger()
+			if !tc.requeue {
+				g.Expect(tracker.events).To(BeEmpty())
+				return
+			}
+			g.Expect(tracker.events).To(HaveLen(1))
+			e := <-tracker.events
+			g.Expect(client.ObjectKeyFromObject(e.Object)).To(Equal(key))
+		})
+	}
+}
+
+func TestCachedClient(t *testing.T) {
+	g := NewWithT(t)
+
+	tracker, _ := newTestTracker()
+	cl, err := tracker.Client(t.Context(), client.ObjectKey{Namespace: "default", Name: "test"}, kubeconfigFor("https://cluster"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// the fake cache finds any object, the fake client none
+	g.Expect(cl.Get(t.Context(), client.ObjectKey{Name: "node"}, &corev1.Node{})).To(Succeed())
+	g.Expect(cl.List(t.Context(), &batchv1.JobList{})).To(Succeed())
+
+	err = cl.Get(t.Context(), client.ObjectKey{Namespace: "default", Name: "cm"}, &corev1.ConfigMap{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "the objects that are not watched must be read from the cluster")
+}
@@ -0,0 +1,183 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configdrift detects the changes of the values of the HelmRelease of a ClusterDeployment
+// made outside of the ClusterDeployment, e.g. by editing the HelmRelease directly.
+//
+// The hash of the values applied from the ClusterDeployment is recorded in an annotation of the HelmRelease.
+// The values drift once they neither match the recorded hash nor the values produced from the spec.
+package configdrift
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	hcv2 "github.com/fluxcd/helm-controller/api/v2"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// maxReportedDiffs is the maximum number of the differing values reported in the condition.
+const maxReportedDiffs = 10
+
+// Hash returns the hash of the given values independent of the formatting and the order of the keys.
+func Hash(values *apiextensionsv1.JSON) (string, error) {
+	parsed, err := parse(values)
+	if err != nil {
+		return "", err
+	}
+
+	// the keys of the maps are sorted on marshaling
+	normalized, err := json.Marshal(parsed)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal values: %w", err)
+	}
+
+	sum := sha256.Sum256(normalized)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Detect returns the differences between the given values produced from the spec of the ClusterDeployment
+// and the values of the given HelmRelease if the values of the HelmRelease have been changed outside
+// of the ClusterDeployment. The HelmRelease without the recorded hash of the applied values is not
+// considered drifted.
+func Detect(hr *hcv2.HelmRelease, desired *apiextensionsv1.JSON) ([]string, error) {
+	if hr == nil {
+		return nil, nil
+	}
+	applied, ok := hr.Annotations[kcm.AppliedValuesHashAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	actual, err := Hash(hr.Spec.Values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash values of the HelmRelease: %w", err)
+	}
+	if actual == applied {
+		return nil, nil
+	}
+
+	return Diff(desired, hr.Spec.Values)
+}
+
+// Diff returns the sorted list of the values differing between the given desired and actual values
+// in the form of "path: desired -> actual".
+func Diff(desired, actual *apiextensionsv1.JSON) ([]string, error) {
+	desiredValues, err := parse(desired)
+	if err != nil {
+		return nil, err
+	}
+	actualValues, err := parse(actual)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredLeaves, actualLeaves := make(map[string]any), make(map[string]any)
+	flatten("", desiredValues, desiredLeaves)
+	flatten("", actualValues, actualLeaves)
+
+	var diffs []string
+	for path, want := range desiredLeaves {
+		got, ok := actualLeaves[path]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("%s: %s -> <unset>", path, format(want)))
+			continue
+		}
+		if !reflect.DeepEqual(want, got) {
+			diffs = append(diffs, fmt.Sprintf("%s: %s -> %s", path, format(want), format(got)))
+		}
+	}
+	for path, got := range actualLeaves {
+		if _, ok := desiredLeaves[path]; !ok {
+			diffs = append(diffs, fmt.Sprintf("%s: <unset> -> %s", path, format(got)))
+		}
+	}
+	slices.Sort(diffs)
+
+	return diffs, nil
+}
+
+// Message returns the given differences of the values joined into a message,
+// only the first differences are included.
+func Message(diffs []string) string {
+	if len(diffs) > maxReportedDiffs {
+		diffs = append(slices.Clone(diffs[:maxReportedDiffs]), fmt.Sprintf("and %d more", len(diffs)-maxReportedDiffs))
+	}
+	return strings.Join(diffs, "; ")
+}
+
+// HeldCondition returns the ConfigDrift condition reporting the given differences of the values
+// held with the Hold config drift policy.
+func HeldCondition(diffs []string) metav1.Condition {
+	return metav1.Condition{
+		Type:    kcm.ConfigDriftCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  kcm.ConfigDriftHeldReason,
+		Message: "The values of the HelmRelease have been changed outside of the ClusterDeployment, the updates are held: " + Message(diffs),
+	}
+}
+
+// ReconciledCondition returns the ConfigDrift condition reporting the given differences of the values
+// reverted with the Reconcile config drift policy.
+func ReconciledCondition(diffs []string) metav1.Condition {
+	return metav1.Condition{
+		Type:    kcm.ConfigDriftCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  kcm.ConfigDriftReconciledReason,
+		Message: "The values of the HelmRelease changed outside of the ClusterDeployment are being reverted: " + Message(diffs),
+	}
+}
+
+func parse(values *apiextensionsv1.JSON) (map[string]any, error) {
+	parsed := make(map[string]any)
+	if values == nil || len(values.Raw) == 0 {
+		return parsed, nil
+	}
+	if err := json.Unmarshal(values.Raw, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal values: %w", err)
+	}
+	return parsed, nil
+}
+
+// flatten collects the leaves of the given values keyed by their dot-separated paths,
+// the lists are compared as a whole.
+func flatten(prefix string, values map[string]any, leaves map[string]any) {
+	for k, v := range values {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]any); ok && len(nested) > 0 {
+			flatten(path, nested, leaves)
+			continue
+		}
+		leaves[path] = v
+	}
+}
+
+func format(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,127 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configdrift
+
+import (
+	"strings"
+	"testing"
+
+	hcv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestHash(t *testing.T) {
+	a, err := Hash(&apiextensionsv1.JSON{Raw: []byte(`{"b":{"y":1,"x":"v"},"a":[1,2]}`)})
+	require.NoError(t, err)
+	b, err := Hash(&apiextensionsv1.JSON{Raw: []byte(`{ "a": [1, 2], "b": {"x": "v", "y": 1} }`)})
+	require.NoError(t, err)
+	require.Equal(t, a, b)
+
+	c, err := Hash(&apiextensionsv1.JSON{Raw: []byte(`{"a":[2,1],"b":{"x":"v","y":1}}`)})
+	require.NoError(t, err)
+	require.NotEqual(t, a, c)
+
+	empty, err := Hash(nil)
+	require.NoError(t, err)
+	emptyObject, err := Hash(&apiextensionsv1.JSON{Raw: []byte(`{}`)})
+	require.NoError(t, err)
+	require.Equal(t, empty, emptyObject)
+}
+
+func TestDiff(t *testing.T) {
+	diffs, err := Diff(
+		&apiextensionsv1.JSON{Raw: []byte(`{"controlPlaneNumber":3,"worker":{"instanceType":"t3.small","rootVolumeSize":30},"region":"us-east-2"}`)},
+		&apiextensionsv1.JSON{Raw: []byte(`{"controlPlaneNumber":3,"worker":{"instanceType":"t3.large"},"region":"us-east-2","extra":true}`)},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"extra: <unset> -> true",
+		`worker.instanceType: "t3.small" -> "t3.large"`,
+		"worker.rootVolumeSize: 30 -> <unset>",
+	}, diffs)
+
+	diffs, err = Diff(&apiextensionsv1.JSON{Raw: []byte(`{"a":{"b":1}}`)}, &apiextensionsv1.JSON{Raw: []byte(`{"a":{"b":1}}`)})
+	require.NoError(t, err)
+	require.Empty(t, diffs)
+}
+
+func TestDetect(t *testing.T) {
+	applied := &apiextensionsv1.JSON{Raw: []byte(`{"workersNumber":2}`)}
+	appliedHash, err := Hash(applied)
+	require.NoError(t, err)
+
+	newHelmRelease := func(values string, annotations map[string]string) *hcv2.HelmRelease {
+		return &hcv2.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Spec:       hcv2.HelmReleaseSpec{Values: &apiextensionsv1.JSON{Raw: []byte(values)}},
+		}
+	}
+	recorded := map[string]string{kcm.AppliedValuesHashAnnotation: appliedHash}
+
+	// not changed since applied, the spec has been updated
+	diffs, err := Detect(newHelmRelease(`{"workersNumber":2}`, recorded), &apiextensionsv1.JSON{Raw: []byte(`{"workersNumber":3}`)})
+	require.NoError(t, err)
+	require.Empty(t, diffs)
+
+	// edited outside of the ClusterDeployment
+	diffs, err = Detect(newHelmRelease(`{"workersNumber":5}`, recorded), applied)
+	require.NoError(t, err)
+	require.Equal(t, []string{"workersNumber: 2 -> 5"}, diffs)
+
+	// edited to match the spec
+	diffs, err = Detect(newHelmRelease(`{"workersNumber":5}`, recorded), &apiextensionsv1.JSON{Raw: []byte(`{"workersNumber":5}`)})
+	require.NoError(t, err)
+	require.Empty(t, diffs)
+
+	// the applied values are not recorded
+	diffs, err = Detect(newHelmRelease(`{"workersNumber":5}`, nil), applied)
+	require.NoError(t, err)
+	require.Empty(t, diffs)
+
+	diffs, err = Detect(nil, applied)
+	require.NoError(t, err)
+	require.Empty(t, diffs)
+}
+
+func TestHeldCondition(t *testing.T) {
+	condition := HeldCondition([]string{"workersNumber: 2 -> 5"})
+	require.Equal(t, kcm.ConfigDriftCondition, condition.Type)
+	require.Equal(t, metav1.ConditionTrue, condition.Status)
+	require.Equal(t, kcm.ConfigDriftHeldReason, condition.Reason)
+	require.Contains(t, condition.Message, "workersNumber: 2 -> 5")
+}
+
+func TestReconciledCondition(t *testing.T) {
+	condition := ReconciledCondition([]string{"workersNumber: 2 -> 5"})
+	require.Equal(t, kcm.ConfigDriftCondition, condition.Type)
+	require.Equal(t, metav1.ConditionTrue, condition.Status)
+	require.Equal(t, kcm.ConfigDriftReconciledReason, condition.Reason)
+	require.Contains(t, condition.Message, "workersNumber: 2 -> 5")
+}
+
+func TestMessage(t *testing.T) {
+	require.Equal(t, "a: 1 -> 2; b: <unset> -> 3", Message([]string{"a: 1 -> 2", "b: <unset> -> 3"}))
+
+	diffs := make([]string, 12)
+	for i := range diffs {
+		diffs[i] = "key"
+	}
+	require.True(t, strings.HasSuffix(Message(diffs), "key; and 2 more"))
+	require.Len(t, diffs, 12)
+}
@@ -0,0 +1,250 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configpolicy evaluates the CEL rules of the ConfigPolicies against the configuration
+// of the ClusterDeployments and merges the overlays of the ConfigPolicies into the configuration.
+package configpolicy
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"helm.sh/helm/v3/pkg/chartutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// costLimit bounds the evaluation cost of a single rule.
+const costLimit = 1_000_000
+
+// Violation is a rule of a ConfigPolicy the configuration of a ClusterDeployment does not satisfy.
+type Violation struct {
+	// Policy is the name of the violated ConfigPolicy.
+	Policy string
+	// Rule is the name of the violated rule.
+	Rule string
+	// FieldPath is the path of the configuration field the violation is reported for.
+	FieldPath string
+	// Message is the message of the violation.
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("ConfigPolicy %s rule %s: %s", v.Policy, v.Rule, v.Message)
+}
+
+var newEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("config", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("object", cel.MapType(cel.StringType, cel.DynType)),
+		cel.CrossTypeNumericComparisons(true),
+		ext.Strings(),
+		ext.Sets(),
+		ext.Lists(),
+	)
+})
+
+// Compile compiles the rules of the given ConfigPolicy and returns the joined compilation errors if any.
+func Compile(policy *kcm.ConfigPolicy) error {
+	var errs error
+	for _, rule := range policy.Spec.Rules {
+		if _, err := compile(rule.Expression); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("rule %s: %w", rule.Name, err))
+		}
+	}
+	return errs
+}
+
+func compile(expression string) (cel.Program, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	ast, iss := env.Compile(expression)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("expression must return a bool, got %s", ast.OutputType())
+	}
+
+	return env.Program(ast, cel.CostLimit(costLimit))
+}
+
+// Selects returns true if the given ConfigPolicy is applied to the ClusterDeployment
+// with the given labels in the namespace with the given labels.
+func Selects(policy *kcm.ConfigPolicy, namespaceLabels, clusterLabels map[string]string) (bool, error) {
+	namespaceSelector, err := metav1.LabelSelectorAsSelector(&policy.Spec.NamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse namespace selector of ConfigPolicy %s: %w", policy.Name, err)
+	}
+	clusterSelector, err := metav1.LabelSelectorAsSelector(&policy.Spec.ClusterSelector)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse cluster selector of ConfigPolicy %s: %w", policy.Name, err)
+	}
+
+	return namespaceSelector.Matches(labels.Set(namespaceLabels)) && clusterSelector.Matches(labels.Set(clusterLabels)), nil
+}
+
+// Overlays merges the values of the overlays of the given ConfigPolicies applied to the ClusterDeployment
+// with the given labels in the namespace with the given labels, the policies are merged in the order
+// of their names and the later overlays take precedence over the earlier ones.
+// Returns the merged values, nil if no overlay is applied, and the applied overlays as <policy>/<overlay>.
+func Overlays(policies []kcm.ConfigPolicy, namespaceLabels, clusterLabels map[string]string) (map[string]any, []string, error) {
+	policies = slices.Clone(policies)
+	slices.SortFunc(policies, func(a, b kcm.ConfigPolicy) int { return cmp.Compare(a.Name, b.Name) })
+
+	var (
+		values  map[string]any
+		applied []string
+	)
+	for i := range policies {
+		policy := &policies[i]
+		if len(policy.Spec.Overlays) == 0 {
+			continue
+		}
+
+		selected, err := Selects(policy, namespaceLabels, clusterLabels)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !selected {
+			continue
+		}
+
+		for _, overlay := range policy.Spec.Overlays {
+			selector, err := metav1.LabelSelectorAsSelector(&overlay.Selector)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to parse selector of overlay %s of ConfigPolicy %s: %w", overlay.Name, policy.Name, err)
+			}
+			if !selector.Matches(labels.Set(clusterLabels)) {
+				continue
+			}
+
+			overlayValues := make(map[string]any)
+			if len(overlay.Values.Raw) > 0 {
+				if err := json.Unmarshal(overlay.Values.Raw, &overlayValues); err != nil {
+					return nil, nil, fmt.Errorf("failed to unmarshal values of overlay %s of ConfigPolicy %s: %w", overlay.Name, policy.Name, err)
+				}
+			}
+
+			values = chartutil.CoalesceTables(overlayValues, values)
+			applied = append(applied, policy.Name+"/"+overlay.Name)
+		}
+	}
+
+	return values, applied, nil
+}
+
+// Evaluate evaluates the rules of the given ConfigPolicy against the given ClusterDeployment
+// and its configuration merged with the template defaults and returns the violated rules.
+// A rule failing to evaluate, e.g. referring to a missing field, is reported as violated.
+func Evaluate(policy *kcm.ConfigPolicy, cd *kcm.ClusterDeployment, config []byte) ([]Violation, error) {
+	object, err := toMap(cd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert ClusterDeployment: %w", err)
+	}
+
+	values := map[string]any{}
+	if len(config) > 0 {
+		if err := decode(config, &values); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		}
+	}
+
+	activation := map[string]any{"config": values, "object": object}
+
+	var violations []Violation
+	for _, rule := range policy.Spec.Rules {
+		violation := Violation{Policy: policy.Name, Rule: rule.Name, FieldPath: rule.FieldPath, Message: rule.Message}
+		if violation.Message == "" {
+			violation.Message = "failed expression: " + rule.Expression
+		}
+
+		prg, err := compile(rule.Expression)
+		if err != nil {
+			violation.Message = fmt.Sprintf("failed to compile expression: %v", err)
+			violations = append(violations, violation)
+			continue
+		}
+
+		out, _, err := prg.Eval(activation)
+		if err != nil {
+			violation.Message = fmt.Sprintf("failed to evaluate expression: %v", err)
+			violations = append(violations, violation)
+			continue
+		}
+
+		if ok, isBool := out.Value().(bool); !isBool || !ok {
+			violations = append(violations, violation)
+		}
+	}
+
+	return violations, nil
+}
+
+func toMap(obj any) (map[string]any, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]any{}
+	return m, decode(raw, &m)
+}
+
+// decode unmarshals the given JSON keeping the integers as int64,
+// so the integer arithmetic is available in the expressions.
+func decode(raw []byte, v *map[string]any) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+
+	for k, val := range *v {
+		(*v)[k] = convertNumbers(val)
+	}
+	return nil
+}
+
+func convertNumbers(v any) any {
+	switch val := v.(type) {
+	case json.Number:
+		if i, err := val.Int64(); err == nil {
+			return i
+		}
+		f, _ := val.Float64()
+		return f
+	case map[string]any:
+		for k, item := range val {
+			val[k] = convertNumbers(item)
+		}
+	case []any:
+		for i, item := range val {
+			val[i] = convertNumbers(item)
+		}
+	}
+	return v
+}
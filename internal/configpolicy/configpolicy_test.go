@@ -0,0 +1,213 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestCompile(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		expression string
+		valid      bool
+	}{
+		{name: "bool expression", expression: "config.controlPlaneNumber % 2 == 1", valid: true},
+		{name: "uses object", expression: "object.metadata.name.startsWith('prod-')", valid: true},
+		{name: "syntax error", expression: "config.controlPlaneNumber %"},
+		{name: "not a bool", expression: "'string'"},
+		{name: "unknown variable", expression: "cluster.name == 'dev'"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := &kcm.ConfigPolicy{Spec: kcm.ConfigPolicySpec{Rules: []kcm.ConfigPolicyRule{{Name: "rule", Expression: tc.expression}}}}
+			if err := Compile(policy); (err == nil) != tc.valid {
+				t.Errorf("unexpected compilation result for %q: %v", tc.expression, err)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	policy := &kcm.ConfigPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "fleet"},
+		Spec: kcm.ConfigPolicySpec{
+			Rules: []kcm.ConfigPolicyRule{
+				{
+					Name:       "odd-control-plane",
+					Expression: "!has(config.controlPlaneNumber) || config.controlPlaneNumber % 2 == 1",
+					Message:    "controlPlaneNumber must be odd",
+					FieldPath:  "controlPlaneNumber",
+				},
+				{
+					Name: "approved-instance-types",
+					Expression: "!has(object.metadata.labels) || object.metadata.labels['environment'] != 'prod' || " +
+						"config.worker.instanceType in ['m5.large', 'm5.xlarge']",
+					FieldPath: "worker.instanceType",
+				},
+				{
+					Name:       "region",
+					Expression: "config.region.startsWith('eu-')",
+				},
+			},
+		},
+	}
+
+	newClusterDeployment := func(labels map[string]string) *kcm.ClusterDeployment {
+		return &kcm.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a", Labels: labels}}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		labels   map[string]string
+		config   string
+		expected []Violation
+	}{
+		{
+			name:   "satisfied",
+			labels: map[string]string{"environment": "prod"},
+			config: `{"controlPlaneNumber":3,"worker":{"instanceType":"m5.large"},"region":"eu-west-1"}`,
+		},
+		{
+			name:   "instance types are not restricted outside of prod",
+			labels: map[string]string{"environment": "dev"},
+			config: `{"worker":{"instanceType":"m5.metal"},"region":"eu-west-1"}`,
+		},
+		{
+			name:   "violated",
+			labels: map[string]string{"environment": "prod"},
+			config: `{"controlPlaneNumber":2,"worker":{"instanceType":"m5.metal"},"region":"us-east-1"}`,
+			expected: []Violation{
+				{Policy: "fleet", Rule: "odd-control-plane", FieldPath: "controlPlaneNumber", Message: "controlPlaneNumber must be odd"},
+				{
+					Policy: "fleet", Rule: "approved-instance-types", FieldPath: "worker.instanceType",
+					Message: "failed expression: " + policy.Spec.Rules[1].Expression,
+				},
+				{Policy: "fleet", Rule: "region", Message: "failed expression: config.region.startsWith('eu-')"},
+			},
+		},
+		{
+			name:   "missing field",
+			config: `{"controlPlaneNumber":1}`,
+			expected: []Violation{
+				{Policy: "fleet", Rule: "region", Message: "failed to evaluate expression: no such key: region"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			violations, err := Evaluate(policy, newClusterDeployment(tc.labels), []byte(tc.config))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(violations, tc.expected) {
+				t.Errorf("unexpected violations:\ngot:  %+v\nwant: %+v", violations, tc.expected)
+			}
+		})
+	}
+}
+
+func TestOverlays(t *testing.T) {
+	overlay := func(name string, matchLabels map[string]string, values string) kcm.ConfigOverlay {
+		return kcm.ConfigOverlay{
+			Name:     name,
+			Selector: metav1.LabelSelector{MatchLabels: matchLabels},
+			Values:   apiextensionsv1.JSON{Raw: []byte(values)},
+		}
+	}
+	policies := []kcm.ConfigPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "regions"},
+			Spec: kcm.ConfigPolicySpec{
+				Overlays: []kcm.ConfigOverlay{
+					overlay("eu", map[string]string{"region": "eu-west-1"}, `{"region":"eu-west-1","worker":{"instanceType":"t3.medium"}}`),
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "environments"},
+			Spec: kcm.ConfigPolicySpec{
+				NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}},
+				Overlays: []kcm.ConfigOverlay{
+					overlay("all", nil, `{"bastion":{"enabled":true}}`),
+					overlay("prod", map[string]string{"env": "prod"}, `{"controlPlaneNumber":3,"worker":{"instanceType":"t3.large","rootVolumeSize":64}}`),
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "rules-only"},
+			Spec:       kcm.ConfigPolicySpec{Rules: []kcm.ConfigPolicyRule{{Name: "rule", Expression: "true"}}},
+		},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		namespaceLabels map[string]string
+		clusterLabels   map[string]string
+		values          map[string]any
+		applied         []string
+	}{
+		{name: "no overlays applied"},
+		{
+			name:            "policy overlays",
+			namespaceLabels: map[string]string{"team": "platform"},
+			clusterLabels:   map[string]string{"env": "prod"},
+			values: map[string]any{
+				"bastion":            map[string]any{"enabled": true},
+				"controlPlaneNumber": float64(3),
+				"worker":             map[string]any{"instanceType": "t3.large", "rootVolumeSize": float64(64)},
+			},
+			applied: []string{"environments/all", "environments/prod"},
+		},
+		{
+			name:            "later policies take precedence",
+			namespaceLabels: map[string]string{"team": "platform"},
+			clusterLabels:   map[string]string{"env": "prod", "region": "eu-west-1"},
+			values: map[string]any{
+				"bastion":            map[string]any{"enabled": true},
+				"controlPlaneNumber": float64(3),
+				"region":             "eu-west-1",
+				"worker":             map[string]any{"instanceType": "t3.medium", "rootVolumeSize": float64(64)},
+			},
+			applied: []string{"environments/all", "environments/prod", "regions/eu"},
+		},
+		{
+			name:          "namespace not selected",
+			clusterLabels: map[string]string{"env": "prod", "region": "eu-west-1"},
+			values: map[string]any{
+				"region": "eu-west-1",
+				"worker": map[string]any{"instanceType": "t3.medium"},
+			},
+			applied: []string{"regions/eu"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			values, applied, err := Overlays(policies, tc.namespaceLabels, tc.clusterLabels)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(values, tc.values) {
+				t.Errorf("unexpected values:\ngot:  %+v\nwant: %+v", values, tc.values)
+			}
+			if !reflect.DeepEqual(applied, tc.applied) {
+				t.Errorf("unexpected applied overlays: got %v, want %v", applied, tc.applied)
+			}
+		})
+	}
+}
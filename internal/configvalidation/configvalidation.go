@@ -0,0 +1,228 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configvalidation validates the ClusterDeployment configuration against
+// the infrastructure providers semantically, either at admission against the catalog
+// of the provider offerings or asynchronously with the provider validation Jobs.
+package configvalidation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/providers"
+)
+
+const (
+	// CatalogConfigMapName is the name of the ConfigMap in the system namespace holding the catalogs
+	// of the provider offerings. Each key of the ConfigMap is the name of an infrastructure provider,
+	// e.g. infrastructure-aws, with the value mapping the regions to the lists of the available instance types.
+	CatalogConfigMapName = "kcm-provider-catalog"
+
+	// ConfigHashAnnotation is the annotation of the validation Job holding the hash of the validated configuration.
+	ConfigHashAnnotation = "k0rdent.mirantis.com/config-hash"
+
+	// ConfigEnvVar is the environment variable of the validation container holding the configuration in JSON.
+	ConfigEnvVar = "CLUSTER_DEPLOYMENT_CONFIG"
+
+	jobNameSuffix = "-config-validation"
+)
+
+// Catalog maps the regions to the lists of the available instance types.
+type Catalog map[string][]string
+
+// GetCatalog returns the catalog of the offerings of the given infrastructure provider
+// or nil if the catalog is not defined.
+func GetCatalog(ctx context.Context, cl client.Client, systemNamespace, provider string) (Catalog, error) {
+	cm := new(corev1.ConfigMap)
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: systemNamespace, Name: CatalogConfigMapName}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get provider catalog ConfigMap: %w", err)
+	}
+
+	data, ok := cm.Data[provider]
+	if !ok {
+		return nil, nil
+	}
+
+	catalog := make(Catalog)
+	if err := yaml.Unmarshal([]byte(data), &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse catalog of the provider %s: %w", provider, err)
+	}
+
+	return catalog, nil
+}
+
+// Validate validates the instance types of the given values against the offerings of the region
+// in the given catalog. The instance types of the regions missing in the catalog are not validated.
+func Validate(validation *providers.ConfigValidation, catalog Catalog, values map[string]any) field.ErrorList {
+	if validation == nil || len(catalog) == 0 || validation.RegionKey == "" {
+		return nil
+	}
+
+	configPath := field.NewPath("spec", "config")
+
+	region, _ := lookup(values, validation.RegionKey).(string)
+	if region == "" {
+		return nil
+	}
+	offerings, ok := catalog[region]
+	if !ok {
+		return nil
+	}
+
+	var errs field.ErrorList
+	for _, key := range validation.InstanceTypeKeys {
+		instanceType, _ := lookup(values, key).(string)
+		if instanceType == "" || slices.Contains(offerings, instanceType) {
+			continue
+		}
+		errs = append(errs, field.NotSupported(childPath(configPath, key), instanceType, offerings))
+	}
+
+	return errs
+}
+
+func lookup(values map[string]any, key string) any {
+	var v any = values
+	for _, name := range strings.Split(key, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[name]
+	}
+	return v
+}
+
+func childPath(path *field.Path, key string) *field.Path {
+	for _, name := range strings.Split(key, ".") {
+		path = path.Child(name)
+	}
+	return path
+}
+
+// JobName returns the name of the validation Job of the given ClusterDeployment.
+func JobName(cd *kcm.ClusterDeployment) string {
+	return cd.Name + jobNameSuffix
+}
+
+// ConfigHash returns the hash of the given configuration the validation Job is run for.
+func ConfigHash(config *apiextensionsv1.JSON) string {
+	var raw []byte
+	if config != nil {
+		raw = config.Raw
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:8])
+}
+
+// NewJob returns the Job validating the given configuration of the ClusterDeployment
+// deployed with the given infrastructure provider.
+func NewJob(cd *kcm.ClusterDeployment, provider string, spec *providers.ConfigValidationJob, config *apiextensionsv1.JSON) (*batchv1.Job, error) {
+	raw := []byte("{}")
+	if config != nil && len(config.Raw) > 0 {
+		raw = config.Raw
+	}
+	if !json.Valid(raw) {
+		return nil, fmt.Errorf("invalid configuration of the ClusterDeployment %s", client.ObjectKeyFromObject(cd))
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        JobName(cd),
+			Namespace:   cd.Namespace,
+			Labels:      map[string]string{kcm.KCMManagedLabelKey: kcm.KCMManagedLabelValue},
+			Annotations: map[string]string{ConfigHashAnnotation: ConfigHash(config)},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         kcm.GroupVersion.String(),
+				Kind:               kcm.ClusterDeploymentKind,
+				Name:               cd.Name,
+				UID:                cd.UID,
+				Controller:         ptr.To(true),
+				BlockOwnerDeletion: ptr.To(true),
+			}},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: spec.ServiceAccountName,
+					Containers: []corev1.Container{{
+						Name:                     "validate",
+						Image:                    spec.Image,
+						Command:                  spec.Command,
+						Args:                     spec.Args,
+						TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+						Env: []corev1.EnvVar{
+							{Name: "CLUSTER_DEPLOYMENT_NAME", Value: cd.Name},
+							{Name: "CLUSTER_DEPLOYMENT_NAMESPACE", Value: cd.Namespace},
+							{Name: "CLUSTER_DEPLOYMENT_CREDENTIAL", Value: cd.Spec.Credential},
+							{Name: "INFRASTRUCTURE_PROVIDER", Value: provider},
+							{Name: ConfigEnvVar, Value: string(raw)},
+						},
+					}},
+				},
+			},
+		},
+	}, nil
+}
+
+// JobResult returns whether the given validation Job has finished and,
+// if the Job has failed, the reason of the failure.
+func JobResult(job *batchv1.Job) (finished bool, failure string) {
+	for _, c := range job.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return true, ""
+		case batchv1.JobFailed:
+			return true, c.Message
+		}
+	}
+	return false, ""
+}
+
+// TerminationMessage returns the termination message of the validation container of the given Job pods.
+func TerminationMessage(pods []corev1.Pod) string {
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated != nil && status.State.Terminated.ExitCode != 0 && status.State.Terminated.Message != "" {
+				return strings.TrimSpace(status.State.Terminated.Message)
+			}
+		}
+	}
+	return ""
+}
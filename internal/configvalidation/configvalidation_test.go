@@ -0,0 +1,163 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configvalidation
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/providers"
+)
+
+func TestGetCatalog(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	catalog, err := GetCatalog(t.Context(), cl, "kcm-system", "infrastructure-aws")
+	if err != nil || catalog != nil {
+		t.Fatalf("expected no catalog without the ConfigMap, got %v, %v", catalog, err)
+	}
+
+	cl = fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: CatalogConfigMapName, Namespace: "kcm-system"},
+		Data:       map[string]string{"infrastructure-aws": "us-east-1: [t3.small, t3.medium]"},
+	}).Build()
+
+	catalog, err = GetCatalog(t.Context(), cl, "kcm-system", "infrastructure-aws")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := (Catalog{"us-east-1": {"t3.small", "t3.medium"}}); !reflect.DeepEqual(catalog, expected) {
+		t.Errorf("unexpected catalog: got %v, want %v", catalog, expected)
+	}
+
+	catalog, err = GetCatalog(t.Context(), cl, "kcm-system", "infrastructure-azure")
+	if err != nil || catalog != nil {
+		t.Errorf("expected no catalog for the provider missing in the ConfigMap, got %v, %v", catalog, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	validation := &providers.ConfigValidation{
+		RegionKey:        "region",
+		InstanceTypeKeys: []string{"controlPlane.instanceType", "worker.instanceType"},
+	}
+	catalog := Catalog{"us-east-1": {"t3.small", "t3.medium"}}
+
+	for _, tc := range []struct {
+		name     string
+		values   string
+		expected field.ErrorList
+	}{
+		{
+			name:   "available instance types",
+			values: `{"region":"us-east-1","controlPlane":{"instanceType":"t3.small"},"worker":{"instanceType":"t3.medium"}}`,
+		},
+		{
+			name:   "region missing in the catalog",
+			values: `{"region":"eu-west-1","worker":{"instanceType":"t3.2xlarge"}}`,
+		},
+		{
+			name:   "unset instance type",
+			values: `{"region":"us-east-1","worker":{"instanceType":""}}`,
+		},
+		{
+			name:   "unavailable instance type",
+			values: `{"region":"us-east-1","controlPlane":{"instanceType":"t3.small"},"worker":{"instanceType":"t3.2xlarge"}}`,
+			expected: field.ErrorList{
+				field.NotSupported(field.NewPath("spec", "config", "worker", "instanceType"), "t3.2xlarge", []string{"t3.small", "t3.medium"}),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			values := make(map[string]any)
+			if err := json.Unmarshal([]byte(tc.values), &values); err != nil {
+				t.Fatalf("failed to unmarshal values: %v", err)
+			}
+
+			if errs := Validate(validation, catalog, values); !reflect.DeepEqual(errs, tc.expected) {
+				t.Errorf("unexpected errors:\ngot:  %v\nwant: %v", errs, tc.expected)
+			}
+		})
+	}
+}
+
+func TestJob(t *testing.T) {
+	cd := &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a", UID: "cd-uid"},
+		Spec:       kcm.ClusterDeploymentSpec{Credential: "vsphere-cred"},
+	}
+	config := &apiextensionsv1.JSON{Raw: []byte(`{"vsphere":{"datastore":"ds-1"}}`)}
+
+	job, err := NewJob(cd, "infrastructure-vsphere", &providers.ConfigValidationJob{Image: "validator:latest"}, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if job.Name != "dev-config-validation" || job.Namespace != cd.Namespace {
+		t.Errorf("unexpected Job key %s/%s", job.Namespace, job.Name)
+	}
+	if len(job.OwnerReferences) != 1 || job.OwnerReferences[0].UID != cd.UID {
+		t.Errorf("expected the Job to be owned by the ClusterDeployment, got %v", job.OwnerReferences)
+	}
+	if job.Annotations[ConfigHashAnnotation] != ConfigHash(config) {
+		t.Errorf("unexpected config hash %q", job.Annotations[ConfigHashAnnotation])
+	}
+	if ConfigHash(config) == ConfigHash(nil) {
+		t.Errorf("expected the hashes of different configurations to differ")
+	}
+
+	env := make(map[string]string)
+	for _, e := range job.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	if env[ConfigEnvVar] != string(config.Raw) || env["CLUSTER_DEPLOYMENT_CREDENTIAL"] != "vsphere-cred" {
+		t.Errorf("unexpected environment %v", env)
+	}
+
+	if finished, _ := JobResult(job); finished {
+		t.Errorf("expected the Job not to be finished")
+	}
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+	if finished, failure := JobResult(job); !finished || failure != "BackoffLimitExceeded" {
+		t.Errorf("expected the Job to be failed, got %t, %q", finished, failure)
+	}
+
+	pods := []corev1.Pod{{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: "datastore ds-1 not found\n"}},
+	}}}}}
+	if message := TerminationMessage(pods); message != "datastore ds-1 not found" {
+		t.Errorf("unexpected termination message %q", message)
+	}
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if finished, failure := JobResult(job); !finished || failure != "" {
+		t.Errorf("expected the Job to be completed, got %t, %q", finished, failure)
+	}
+}
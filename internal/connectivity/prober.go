@@ -0,0 +1,388 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectivity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/metrics"
+)
+
+const (
+	// DefaultTimeout is the default timeout of a single probe.
+	DefaultTimeout = 10 * time.Second
+	// DefaultWorkers is the default number of clusters probed concurrently.
+	DefaultWorkers = 10
+	// DefaultInterval is the default interval between the probes,
+	// also bounding the duration of a single round of the probes.
+	DefaultInterval = time.Minute
+
+	kubeconfigSecretSuffix = "-kubeconfig"
+	kubeconfigSecretKey    = "value"
+)
+
+// IngressLoadBalancerLabelKey is the label marking the LoadBalancer Service of an ingress controller
+// of the cluster which is not discovered by the well-known labels of the ingress controllers.
+const IngressLoadBalancerLabelKey = "k0rdent.mirantis.com/ingress-load-balancer"
+
+var errKubeconfigNotFound = errors.New("kubeconfig is not found")
+
+// ingressSelectors select the LoadBalancer Services of the ingress controllers in the clusters.
+var ingressSelectors = []client.MatchingLabels{
+	{"app.kubernetes.io/name": "ingress-nginx", "app.kubernetes.io/component": "controller"},
+	{"app.kubernetes.io/name": "traefik"},
+	{IngressLoadBalancerLabelKey: "true"},
+}
+
+// Result is the result of a successful probe of the cluster API server.
+type Result struct {
+	// CertificateExpiry is the expiry time of the serving certificate of the API server.
+	CertificateExpiry time.Time
+	// Latency is the round-trip time of the probe request.
+	Latency time.Duration
+}
+
+// Prober periodically probes the API servers of the clusters
+// deployed by ClusterDeployments and reports their reachability.
+type Prober struct {
+	client.Client
+
+	Interval time.Duration
+	Timeout  time.Duration
+	Workers  int
+
+	probed map[client.ObjectKey]struct{}
+}
+
+func (p *Prober) Start(ctx context.Context) error {
+	timer := time.NewTimer(0)
+	for {
+		select {
+		case <-timer.C:
+			p.Tick(ctx)
+			timer.Reset(p.interval())
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Tick probes the clusters concurrently by a bounded number of workers.
+// The round is bounded by the interval, the clusters not probed until the deadline
+// are probed within the next round.
+func (p *Prober) Tick(ctx context.Context) {
+	l := log.FromContext(ctx).WithName("connectivity prober")
+
+	ctx, cancel := context.WithTimeout(ctx, p.interval())
+	defer cancel()
+
+	clusterDeployments := &kcm.ClusterDeploymentList{}
+	if err := p.List(ctx, clusterDeployments); err != nil {
+		l.Error(err, "failed to list ClusterDeployments")
+		return
+	}
+
+	queue := make(chan *kcm.ClusterDeployment)
+	go func() {
+		defer close(queue)
+		for i := range clusterDeployments.Items {
+			cd := &clusterDeployments.Items[i]
+			if cd.Spec.DryRun || !cd.DeletionTimestamp.IsZero() {
+				continue
+			}
+			select {
+			case queue <- cd:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		probed = make(map[client.ObjectKey]struct{}, len(clusterDeployments.Items))
+	)
+	for range p.workers() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for cd := range queue {
+				if p.tickCluster(ctx, cd) {
+					mu.Lock()
+					probed[client.ObjectKeyFromObject(cd)] = struct{}{}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		l.Info("Not all clusters have been probed within the interval", "interval", p.interval())
+	}
+
+	for key := range p.probed {
+		if _, ok := probed[key]; !ok {
+			metrics.DeleteMetricClusterProbe(key.Namespace, key.Name)
+		}
+	}
+	p.probed = probed
+}
+
+// tickCluster probes the cluster of the given ClusterDeployment and reports the result,
+// it returns false if the cluster is not provisioned yet.
+func (p *Prober) tickCluster(ctx context.Context, cd *kcm.ClusterDeployment) bool {
+	logger := log.FromContext(ctx).WithName("connectivity prober").WithValues("ClusterDeployment", client.ObjectKeyFromObject(cd))
+
+	result, err := p.probeCluster(ctx, cd)
+	if errors.Is(err, errKubeconfigNotFound) {
+		// the cluster is not provisioned yet
+		return false
+	}
+
+	metrics.TrackMetricClusterProbe(ctx, cd.ObjectMeta, err == nil, result.Latency, result.CertificateExpiry)
+
+	if err := p.updateCondition(ctx, cd, result, err); err != nil {
+		logger.Error(err, "failed to update ClusterDeployment status")
+	}
+	if err != nil {
+		return true
+	}
+
+	addresses, err := p.discoverIngress(ctx, cd)
+	if err != nil {
+		logger.Error(err, "failed to discover ingress load balancer addresses")
+		return true
+	}
+	if err := p.updateIngressEndpoints(ctx, cd, addresses); err != nil {
+		logger.Error(err, "failed to update ClusterDeployment endpoints")
+	}
+
+	return true
+}
+
+func (p *Prober) probeCluster(ctx context.Context, cd *kcm.ClusterDeployment) (Result, error) {
+	kubeconfig, err := p.kubeconfig(ctx, cd)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Probe(ctx, kubeconfig, p.timeout())
+}
+
+func (p *Prober) discoverIngress(ctx context.Context, cd *kcm.ClusterDeployment) ([]string, error) {
+	kubeconfig, err := p.kubeconfig(ctx, cd)
+	if err != nil {
+		return nil, err
+	}
+
+	return IngressAddresses(ctx, kubeconfig, p.timeout())
+}
+
+func (p *Prober) kubeconfig(ctx context.Context, cd *kcm.ClusterDeployment) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := p.Get(ctx, client.ObjectKey{Namespace: cd.Namespace, Name: cd.Name + kubeconfigSecretSuffix}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errKubeconfigNotFound
+		}
+		return nil, fmt.Errorf("failed to get kubeconfig Secret: %w", err)
+	}
+
+	kubeconfig, ok := secret.Data[kubeconfigSecretKey]
+	if !ok {
+		return nil, errKubeconfigNotFound
+	}
+
+	return kubeconfig, nil
+}
+
+func (p *Prober) timeout() time.Duration {
+	if p.Timeout == 0 {
+		return DefaultTimeout
+	}
+	return p.Timeout
+}
+
+func (p *Prober) interval() time.Duration {
+	if p.Interval == 0 {
+		return DefaultInterval
+	}
+	return p.Interval
+}
+
+func (p *Prober) workers() int {
+	if p.Workers <= 0 {
+		return DefaultWorkers
+	}
+	return p.Workers
+}
+
+// updateCondition sets the reachability condition of the given ClusterDeployment.
+// The object listed at the start of the round may be stale, hence the patch is rejected on conflict
+// not to override the status concurrently set by the ClusterDeployment controller, and retried on the fresh object.
+func (p *Prober) updateCondition(ctx context.Context, cd *kcm.ClusterDeployment, result Result, probeErr error) error {
+	condition := metav1.Condition{
+		Type:    kcm.ClusterReachableCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  kcm.SucceededReason,
+		Message: "API server is reachable",
+	}
+	if !result.CertificateExpiry.IsZero() {
+		condition.Message += ", serving certificate expires at " + result.CertificateExpiry.UTC().Format(time.RFC3339)
+	}
+	if probeErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kcm.FailedReason
+		condition.Message = fmt.Sprintf("API server is unreachable: %s", probeErr)
+	}
+
+	first := true
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if !first {
+			if err := p.Get(ctx, client.ObjectKeyFromObject(cd), cd); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		patch := client.MergeFromWithOptions(cd.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		if !apimeta.SetStatusCondition(cd.GetConditions(), condition) {
+			return nil
+		}
+
+		return p.Status().Patch(ctx, cd, patch)
+	})
+}
+
+// updateIngressEndpoints records the given addresses of the ingress load balancer in the endpoints of the cluster.
+// The patch is rejected on conflict not to override the endpoints concurrently set by the ClusterDeployment controller.
+func (p *Prober) updateIngressEndpoints(ctx context.Context, cd *kcm.ClusterDeployment, addresses []string) error {
+	var endpoints []kcm.ClusterEndpoint
+	if len(addresses) > 0 {
+		endpoints = append(endpoints, kcm.ClusterEndpoint{Targets: addresses})
+	}
+
+	patch := client.MergeFromWithOptions(cd.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if !cd.SetEndpoints(kcm.EndpointTypeIngressLoadBalancer, endpoints...) {
+		return nil
+	}
+
+	return p.Status().Patch(ctx, cd, patch)
+}
+
+// Probe checks the readiness of the API server described by the given kubeconfig.
+func Probe(ctx context.Context, kubeconfig []byte, timeout time.Duration) (Result, error) {
+	restCfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	restCfg.Timeout = timeout
+
+	httpClient, err := rest.HTTPClientFor(restCfg)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(restCfg.Host, "/")+"/readyz", nil)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	start := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return Result{}, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	result := Result{Latency: time.Since(start)}
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		result.CertificateExpiry = resp.TLS.PeerCertificates[0].NotAfter
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return result, fmt.Errorf("API server is not ready: %s", resp.Status)
+	}
+
+	return result, nil
+}
+
+// IngressAddresses returns the addresses of the load balancers of the ingress controllers
+// of the cluster described by the given kubeconfig.
+func IngressAddresses(ctx context.Context, kubeconfig []byte, timeout time.Duration) ([]string, error) {
+	restCfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	restCfg.Timeout = timeout
+
+	cl, err := client.New(restCfg, client.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	var services []corev1.Service
+	for _, selector := range ingressSelectors {
+		list := &corev1.ServiceList{}
+		if err := cl.List(ctx, list, selector); err != nil {
+			return nil, fmt.Errorf("failed to list Services: %w", err)
+		}
+		services = append(services, list.Items...)
+	}
+
+	return LoadBalancerAddresses(services), nil
+}
+
+// LoadBalancerAddresses returns the sorted unique addresses of the given LoadBalancer Services.
+func LoadBalancerAddresses(services []corev1.Service) []string {
+	var addresses []string
+	for _, svc := range services {
+		if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+		for _, ingress := range svc.Status.LoadBalancer.Ingress {
+			if ingress.IP != "" {
+				addresses = append(addresses, ingress.IP)
+			}
+			if ingress.Hostname != "" {
+				addresses = append(addresses, ingress.Hostname)
+			}
+		}
+	}
+	slices.Sort(addresses)
+
+	return slices.Compact(addresses)
+}
@@ -0,0 +1,245 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connectivity
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/objects/clusterdeployment"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func kubeconfigFor(server string) []byte {
+	return fmt.Appendf(nil, `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: %s
+    insecure-skip-tls-verify: true
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: test
+`, server)
+}
+
+func TestProbe(t *testing.T) {
+	g := NewWithT(t)
+
+	ready := true
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/readyz" || !ready {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	result, err := Probe(t.Context(), kubeconfigFor(srv.URL), time.Second)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(result.Latency).To(BeNumerically(">", 0))
+	g.Expect(result.CertificateExpiry).To(Equal(srv.Certificate().NotAfter))
+
+	ready = false
+	_, err = Probe(t.Context(), kubeconfigFor(srv.URL), time.Second)
+	g.Expect(err).To(MatchError(ContainSubstring("API server is not ready")))
+
+	srv.Close()
+	_, err = Probe(t.Context(), kubeconfigFor(srv.URL), time.Second)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestTick(t *testing.T) {
+	g := NewWithT(t)
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	reachable := clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("reachable"))
+	unreachable := clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("unreachable"))
+	provisioning := clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("provisioning"))
+
+	kubeconfigSecret := func(cd *kcm.ClusterDeployment, server string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: cd.Name + kubeconfigSecretSuffix, Namespace: cd.Namespace},
+			Data:       map[string][]byte{kubeconfigSecretKey: kubeconfigFor(server)},
+		}
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(reachable, unreachable, provisioning,
+			kubeconfigSecret(reachable, srv.URL),
+			kubeconfigSecret(unreachable, "https://127.0.0.1:1"),
+		).
+		WithStatusSubresource(&kcm.ClusterDeployment{}).
+		Build()
+
+	p := &Prober{Client: cl, Timeout: time.Second}
+	p.Tick(t.Context())
+
+	getCondition := func(cd *kcm.ClusterDeployment) *metav1.Condition {
+		g.Expect(cl.Get(t.Context(), client.ObjectKeyFromObject(cd), cd)).To(Succeed())
+		return apimeta.FindStatusCondition(cd.Status.Conditions, kcm.ClusterReachableCondition)
+	}
+
+	cond := getCondition(reachable)
+	g.Expect(cond).NotTo(BeNil())
+	g.Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(cond.Message).To(ContainSubstring("serving certificate expires at"))
+
+	cond = getCondition(unreachable)
+	g.Expect(cond).NotTo(BeNil())
+	g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(cond.Message).To(HavePrefix("API server is unreachable"))
+
+	g.Expect(getCondition(provisioning)).To(BeNil())
+	g.Expect(p.probed).To(HaveLen(2))
+}
+
+func TestLoadBalancerAddresses(t *testing.T) {
+	g := NewWithT(t)
+
+	loadBalancer := func(ingress ...corev1.LoadBalancerIngress) corev1.Service {
+		return corev1.Service{
+			Spec:   corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+			Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: ingress}},
+		}
+	}
+
+	g.Expect(LoadBalancerAddresses(nil)).To(BeEmpty())
+	g.Expect(LoadBalancerAddresses([]corev1.Service{
+		loadBalancer(corev1.LoadBalancerIngress{Hostname: "lb.example.com"}, corev1.LoadBalancerIngress{IP: "203.0.113.10"}),
+		loadBalancer(corev1.LoadBalancerIngress{IP: "203.0.113.10"}),
+		{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP}},
+	})).To(Equal([]string{"203.0.113.10", "lb.example.com"}))
+}
+
+func TestUpdateIngressEndpoints(t *testing.T) {
+	g := NewWithT(t)
+
+	cd := clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("ingress"))
+	cd.Status.Endpoints = []kcm.ClusterEndpoint{
+		{DNSName: "ingress.example.com", RecordType: "A", Targets: []string{"192.0.2.1"}},
+		{Type: kcm.EndpointTypeAPIServer, URL: "https://192.0.2.1:6443"},
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(cd).
+		WithStatusSubresource(&kcm.ClusterDeployment{}).
+		Build()
+
+	p := &Prober{Client: cl}
+	g.Expect(p.updateIngressEndpoints(t.Context(), cd, []string{"203.0.113.10"})).To(Succeed())
+
+	g.Expect(cl.Get(t.Context(), client.ObjectKeyFromObject(cd), cd)).To(Succeed())
+	g.Expect(cd.Status.Endpoints).To(Equal([]kcm.ClusterEndpoint{
+		{Type: kcm.EndpointTypeAPIServer, URL: "https://192.0.2.1:6443"},
+		{Type: kcm.EndpointTypeIngressLoadBalancer, Targets: []string{"203.0.113.10"}},
+		{DNSName: "ingress.example.com", RecordType: "A", Targets: []string{"192.0.2.1"}},
+	}))
+	g.Expect(cd.Endpoints(kcm.EndpointTypeDNSRecord)).To(HaveLen(1))
+
+	g.Expect(p.updateIngressEndpoints(t.Context(), cd, nil)).To(Succeed())
+	g.Expect(cl.Get(t.Context(), client.ObjectKeyFromObject(cd), cd)).To(Succeed())
+	g.Expect(cd.Endpoints(kcm.EndpointTypeIngressLoadBalancer)).To(BeEmpty())
+	g.Expect(cd.Status.Endpoints).To(HaveLen(2))
+}
+
+func TestUpdateConditionConflict(t *testing.T) {
+	g := NewWithT(t)
+
+	cd := clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("stale"))
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(cd).
+		WithStatusSubresource(&kcm.ClusterDeployment{}).
+		Build()
+
+	stale := &kcm.ClusterDeployment{}
+	g.Expect(cl.Get(t.Context(), client.ObjectKeyFromObject(cd), stale)).To(Succeed())
+
+	// the ClusterDeployment controller updates the status after the prober has listed the object
+	g.Expect(cl.Get(t.Context(), client.ObjectKeyFromObject(cd), cd)).To(Succeed())
+	apimeta.SetStatusCondition(&cd.Status.Conditions, metav1.Condition{Type: kcm.ReadyCondition, Status: metav1.ConditionTrue, Reason: kcm.SucceededReason})
+	g.Expect(cl.Status().Update(t.Context(), cd)).To(Succeed())
+
+	p := &Prober{Client: cl}
+	g.Expect(p.updateCondition(t.Context(), stale, Result{}, nil)).To(Succeed())
+
+	g.Expect(cl.Get(t.Context(), client.ObjectKeyFromObject(cd), cd)).To(Succeed())
+	g.Expect(apimeta.IsStatusConditionTrue(cd.Status.Conditions, kcm.ReadyCondition)).To(BeTrue())
+	g.Expect(apimeta.IsStatusConditionTrue(cd.Status.Conditions, kcm.ClusterReachableCondition)).To(BeTrue())
+}
+
+func TestTickDeadline(t *testing.T) {
+	g := NewWithT(t)
+
+	release := make(chan struct{})
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	var objs []client.Object
+	for i := range 4 {
+		cd := clusterdeployment.NewClusterDeployment(clusterdeployment.WithName(fmt.Sprintf("hanging-%d", i)))
+		objs = append(objs, cd, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: cd.Name + kubeconfigSecretSuffix, Namespace: cd.Namespace},
+			Data:       map[string][]byte{kubeconfigSecretKey: kubeconfigFor(srv.URL)},
+		})
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&kcm.ClusterDeployment{}).
+		Build()
+
+	p := &Prober{Client: cl, Interval: 500 * time.Millisecond, Timeout: time.Minute, Workers: 2}
+
+	start := time.Now()
+	p.Tick(t.Context())
+	g.Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+}
@@ -15,19 +15,26 @@
 package controller
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/freeze"
 	"github.com/K0rdent/kcm/internal/utils"
 	"github.com/K0rdent/kcm/internal/utils/ratelimit"
 )
@@ -57,6 +64,17 @@ func (r *AccessManagementReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, "")
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		l.Info("Reconciliation is paused, only observing the status")
+		apimeta.SetStatusCondition(&accessMgmt.Status.Conditions, freeze.Condition(pausedMessage, accessMgmt.Generation))
+		return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, r.updateStatus(ctx, accessMgmt)
+	}
+	apimeta.RemoveStatusCondition(&accessMgmt.Status.Conditions, kcm.ReconciliationPausedCondition)
+
 	if updated, err := utils.AddKCMComponentLabel(ctx, r.Client, accessMgmt); updated || err != nil {
 		if err != nil {
 			l.Error(err, "adding component label")
@@ -64,7 +82,7 @@ func (r *AccessManagementReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
-	err := r.reconcileObj(ctx, accessMgmt)
+	err = r.reconcileObj(ctx, accessMgmt)
 	if err != nil {
 		accessMgmt.Status.Error = err.Error()
 	}
@@ -74,6 +92,10 @@ func (r *AccessManagementReconciler) Reconcile(ctx context.Context, req ctrl.Req
 }
 
 func (r *AccessManagementReconciler) reconcileObj(ctx context.Context, accessMgmt *kcm.AccessManagement) error {
+	accessMgmt.Status.Changes = nil
+	if !accessMgmt.Spec.DryRun {
+		accessMgmt.Status.Delivery = nil
+	}
 	if len(accessMgmt.Spec.AccessRules) == 0 {
 		return nil // nothing to do
 	}
@@ -93,11 +115,14 @@ func (r *AccessManagementReconciler) reconcileObj(ctx context.Context, accessMgm
 		return err
 	}
 
-	keepCtChains := make(map[string]bool)
-	keepStChains := make(map[string]bool)
-	keepCredentials := make(map[string]bool)
-
-	var errs error
+	var (
+		errs       error
+		distribute []distributedObject
+		keep       = make(map[distributedObject]bool)
+		delivery   = make(namespaceDelivery)
+		// namespace -> role -> subjects
+		roleSubjects = make(map[string]map[string][]rbacv1.Subject)
+	)
 	for _, rule := range accessMgmt.Spec.AccessRules {
 		namespaces, err := getTargetNamespaces(ctx, r.Client, rule.TargetNamespaces)
 		if err != nil {
@@ -106,64 +131,147 @@ func (r *AccessManagementReconciler) reconcileObj(ctx context.Context, accessMgm
 
 		for _, namespace := range namespaces {
 			for _, ctChain := range rule.ClusterTemplateChains {
-				keepCtChains[getNamespacedName(namespace, ctChain)] = true
-				if systemCtChains[ctChain] == nil {
-					errs = errors.Join(errs, fmt.Errorf("ClusterTemplateChain %s/%s is not found", r.SystemNamespace, ctChain))
+				obj := distributedObject{kind: kcm.ClusterTemplateChainKind, namespace: namespace, name: ctChain}
+				if keep[obj] {
 					continue
 				}
-
-				if err := r.createTemplateChain(ctx, systemCtChains[ctChain], namespace); err != nil {
+				keep[obj] = true
+				if systemCtChains[ctChain] == nil {
+					err := fmt.Errorf("ClusterTemplateChain %s/%s is not found", r.SystemNamespace, ctChain)
+					delivery.add(obj, kcm.DeliveryResultFailed, err.Error())
 					errs = errors.Join(errs, err)
 					continue
 				}
+				distribute = append(distribute, obj)
 			}
 			for _, stChain := range rule.ServiceTemplateChains {
-				keepStChains[getNamespacedName(namespace, stChain)] = true
-				if systemStChains[stChain] == nil {
-					errs = errors.Join(errs, fmt.Errorf("ServiceTemplateChain %s/%s is not found", r.SystemNamespace, stChain))
+				obj := distributedObject{kind: kcm.ServiceTemplateChainKind, namespace: namespace, name: stChain}
+				if keep[obj] {
 					continue
 				}
-
-				if err := r.createTemplateChain(ctx, systemStChains[stChain], namespace); err != nil {
+				keep[obj] = true
+				if systemStChains[stChain] == nil {
+					err := fmt.Errorf("ServiceTemplateChain %s/%s is not found", r.SystemNamespace, stChain)
+					delivery.add(obj, kcm.DeliveryResultFailed, err.Error())
 					errs = errors.Join(errs, err)
 					continue
 				}
+				distribute = append(distribute, obj)
 			}
 			for _, credentialName := range rule.Credentials {
-				keepCredentials[getNamespacedName(namespace, credentialName)] = true
+				obj := distributedObject{kind: kcm.CredentialKind, namespace: namespace, name: credentialName}
+				if keep[obj] {
+					continue
+				}
+				keep[obj] = true
 				if systemCredentials[credentialName] == nil {
-					errs = errors.Join(errs, fmt.Errorf("credential %s/%s is not found", r.SystemNamespace, credentialName))
+					err := fmt.Errorf("credential %s/%s is not found", r.SystemNamespace, credentialName)
+					delivery.add(obj, kcm.DeliveryResultFailed, err.Error())
+					errs = errors.Join(errs, err)
 					continue
 				}
-
-				errs = errors.Join(errs, r.createCredential(ctx, namespace, credentialName, systemCredentials[credentialName]))
+				distribute = append(distribute, obj)
+			}
+			for _, subject := range rule.Subjects {
+				if roleSubjects[namespace] == nil {
+					roleSubjects[namespace] = make(map[string][]rbacv1.Subject)
+				}
+				if !slices.Contains(roleSubjects[namespace][subject.Role], subject.Subject) {
+					roleSubjects[namespace][subject.Role] = append(roleSubjects[namespace][subject.Role], subject.Subject)
+				}
 			}
 		}
 	}
 
-	managedObjects := append(append(managedCtChains, managedStChains...), managedCredentials...)
-	for _, managedObject := range managedObjects {
-		keep := false
-		namespacedName := getNamespacedName(managedObject.GetNamespace(), managedObject.GetName())
-		switch managedObject.GetObjectKind().GroupVersionKind().Kind {
+	var (
+		prune   []client.Object
+		managed = make(map[distributedObject]bool)
+	)
+	for _, managedObject := range append(append(managedCtChains, managedStChains...), managedCredentials...) {
+		obj := distributedObject{
+			kind:      managedObject.GetObjectKind().GroupVersionKind().Kind,
+			namespace: managedObject.GetNamespace(),
+			name:      managedObject.GetName(),
+		}
+		switch obj.kind {
+		case kcm.ClusterTemplateChainKind, kcm.ServiceTemplateChainKind, kcm.CredentialKind:
+		default:
+			errs = errors.Join(errs, fmt.Errorf("invalid kind. Supported kinds are %s, %s and %s", kcm.ClusterTemplateChainKind, kcm.ServiceTemplateChainKind, kcm.CredentialKind))
+			continue
+		}
+
+		managed[obj] = true
+		if !keep[obj] {
+			prune = append(prune, managedObject)
+		}
+	}
+
+	accessMgmt.Status.Changes = getAccessChanges(distribute, managed, prune)
+	if accessMgmt.Spec.DryRun {
+		ctrl.LoggerFrom(ctx).Info("AccessManagement is in the dry-run mode, skipping applying the access rules")
+		return errs
+	}
+
+	for _, obj := range distribute {
+		if managed[obj] {
+			delivery.add(obj, kcm.DeliveryResultSkipped, "already distributed")
+			continue
+		}
+
+		var (
+			created bool
+			err     error
+		)
+		switch obj.kind {
 		case kcm.ClusterTemplateChainKind:
-			keep = keepCtChains[namespacedName]
+			created, err = r.createTemplateChain(ctx, systemCtChains[obj.name], obj.namespace)
 		case kcm.ServiceTemplateChainKind:
-			keep = keepStChains[namespacedName]
+			created, err = r.createTemplateChain(ctx, systemStChains[obj.name], obj.namespace)
 		case kcm.CredentialKind:
-			keep = keepCredentials[namespacedName]
+			created, err = r.createCredential(ctx, obj.namespace, obj.name, systemCredentials[obj.name])
+		}
+
+		switch {
+		case err != nil:
+			delivery.add(obj, kcm.DeliveryResultFailed, err.Error())
+			errs = errors.Join(errs, err)
+		case !created:
+			delivery.add(obj, kcm.DeliveryResultSkipped, "an object with the same name not managed by KCM already exists")
 		default:
-			errs = errors.Join(errs, fmt.Errorf("invalid kind. Supported kinds are %s, %s and %s", kcm.ClusterTemplateChainKind, kcm.ServiceTemplateChainKind, kcm.CredentialKind))
+			delivery.add(obj, kcm.DeliveryResultCreated, "")
 		}
+	}
 
-		if !keep {
-			err := r.deleteManagedObject(ctx, managedObject)
-			if err != nil {
-				errs = errors.Join(errs, err)
-				continue
-			}
+	for _, managedObject := range prune {
+		obj := distributedObject{
+			kind:      managedObject.GetObjectKind().GroupVersionKind().Kind,
+			namespace: managedObject.GetNamespace(),
+			name:      managedObject.GetName(),
+		}
+
+		var (
+			err     error
+			message string
+		)
+		switch accessMgmt.Spec.PrunePolicy {
+		case kcm.PrunePolicyOrphan:
+			err = r.orphanManagedObject(ctx, managedObject)
+			message = "orphaned"
+		default:
+			err = r.deleteManagedObject(ctx, managedObject)
+			message = "deleted"
 		}
+
+		if err != nil {
+			delivery.add(obj, kcm.DeliveryResultFailed, err.Error())
+			errs = errors.Join(errs, err)
+			continue
+		}
+		delivery.add(obj, kcm.DeliveryResultPruned, message)
 	}
+	accessMgmt.Status.Delivery = delivery.list()
+
+	errs = errors.Join(errs, r.reconcileRoleBindings(ctx, roleSubjects))
 
 	if errs != nil {
 		return errs
@@ -173,6 +281,75 @@ func (r *AccessManagementReconciler) reconcileObj(ctx context.Context, accessMgm
 	return nil
 }
 
+// distributedObject identifies an object distributed by the AccessManagement to a namespace.
+type distributedObject struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// namespaceDelivery collects the delivery results of the distributed objects per namespace.
+type namespaceDelivery map[string][]kcm.AccessDeliveryResult
+
+func (d namespaceDelivery) add(obj distributedObject, result, message string) {
+	d[obj.namespace] = append(d[obj.namespace], kcm.AccessDeliveryResult{
+		Kind:    obj.kind,
+		Name:    obj.name,
+		Result:  result,
+		Message: message,
+	})
+}
+
+// list returns the delivery results sorted by namespace, kind and name.
+func (d namespaceDelivery) list() []kcm.NamespaceAccessDelivery {
+	result := make([]kcm.NamespaceAccessDelivery, 0, len(d))
+	for namespace, objects := range d {
+		slices.SortFunc(objects, func(a, b kcm.AccessDeliveryResult) int {
+			return cmp.Or(strings.Compare(a.Kind, b.Kind), strings.Compare(a.Name, b.Name))
+		})
+		result = append(result, kcm.NamespaceAccessDelivery{Namespace: namespace, Objects: objects})
+	}
+	slices.SortFunc(result, func(a, b kcm.NamespaceAccessDelivery) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+	return result
+}
+
+// getAccessChanges groups by namespace the objects to be distributed, skipping the already distributed ones, and the objects to be pruned.
+func getAccessChanges(distribute []distributedObject, distributed map[distributedObject]bool, prune []client.Object) []kcm.NamespaceAccessChanges {
+	changes := make(map[string]*kcm.NamespaceAccessChanges)
+	namespaceChanges := func(namespace string) *kcm.NamespaceAccessChanges {
+		if changes[namespace] == nil {
+			changes[namespace] = &kcm.NamespaceAccessChanges{Namespace: namespace}
+		}
+		return changes[namespace]
+	}
+
+	for _, obj := range distribute {
+		if distributed[obj] {
+			continue
+		}
+		c := namespaceChanges(obj.namespace)
+		c.Distribute = append(c.Distribute, obj.kind+"/"+obj.name)
+	}
+	for _, obj := range prune {
+		c := namespaceChanges(obj.GetNamespace())
+		c.Prune = append(c.Prune, obj.GetObjectKind().GroupVersionKind().Kind+"/"+obj.GetName())
+	}
+
+	result := make([]kcm.NamespaceAccessChanges, 0, len(changes))
+	for _, c := range changes {
+		slices.Sort(c.Distribute)
+		slices.Sort(c.Prune)
+		result = append(result, *c)
+	}
+	slices.SortFunc(result, func(a, b kcm.NamespaceAccessChanges) int {
+		return strings.Compare(a.Namespace, b.Namespace)
+	})
+
+	return result
+}
+
 func getNamespacedName(namespace, name string) string {
 	return namespace + "/" + name
 }
@@ -281,7 +458,7 @@ func getTargetNamespaces(ctx context.Context, cl client.Client, targetNamespaces
 	return result, nil
 }
 
-func (r *AccessManagementReconciler) createTemplateChain(ctx context.Context, source templateChain, targetNamespace string) error {
+func (r *AccessManagementReconciler) createTemplateChain(ctx context.Context, source templateChain, targetNamespace string) (created bool, _ error) {
 	l := ctrl.LoggerFrom(ctx)
 
 	meta := metav1.ObjectMeta{
@@ -300,14 +477,14 @@ func (r *AccessManagementReconciler) createTemplateChain(ctx context.Context, so
 		target = &kcm.ServiceTemplateChain{ObjectMeta: meta, Spec: *source.GetSpec()}
 	}
 
-	if err := r.Create(ctx, target); client.IgnoreAlreadyExists(err) != nil {
-		return err
+	if err := r.Create(ctx, target); err != nil {
+		return false, client.IgnoreAlreadyExists(err)
 	}
 	l.Info(kind+" was successfully created", "target namespace", targetNamespace, "source name", source.GetName())
-	return nil
+	return true, nil
 }
 
-func (r *AccessManagementReconciler) createCredential(ctx context.Context, namespace, name string, spec *kcm.CredentialSpec) error {
+func (r *AccessManagementReconciler) createCredential(ctx context.Context, namespace, name string, spec *kcm.CredentialSpec) (created bool, _ error) {
 	l := ctrl.LoggerFrom(ctx)
 
 	target := &kcm.Credential{
@@ -320,11 +497,100 @@ func (r *AccessManagementReconciler) createCredential(ctx context.Context, names
 		},
 		Spec: *spec,
 	}
-	if err := r.Create(ctx, target); client.IgnoreAlreadyExists(err) != nil {
-		return err
+	if err := r.Create(ctx, target); err != nil {
+		return false, client.IgnoreAlreadyExists(err)
 	}
 	l.Info("Credential was successfully created", "namespace", namespace, "name", name)
-	return nil
+	return true, nil
+}
+
+// reconcileRoleBindings binds the subjects to the KCM namespace ClusterRoles in each of the given namespaces
+// and removes the managed RoleBindings which are no longer required.
+func (r *AccessManagementReconciler) reconcileRoleBindings(ctx context.Context, roleSubjects map[string]map[string][]rbacv1.Subject) error {
+	l := ctrl.LoggerFrom(ctx)
+
+	managedRoleBindings := new(rbacv1.RoleBindingList)
+	if err := r.List(ctx, managedRoleBindings,
+		client.MatchingLabels{kcm.KCMManagedLabelKey: kcm.KCMManagedLabelValue},
+		client.HasLabels{kcm.NamespaceRoleLabelKey},
+	); err != nil {
+		return fmt.Errorf("failed to list RoleBindings: %w", err)
+	}
+
+	if len(roleSubjects) == 0 && len(managedRoleBindings.Items) == 0 {
+		return nil
+	}
+
+	clusterRoles := new(rbacv1.ClusterRoleList)
+	if err := r.List(ctx, clusterRoles, client.HasLabels{kcm.NamespaceRoleLabelKey}); err != nil {
+		return fmt.Errorf("failed to list ClusterRoles: %w", err)
+	}
+	clusterRoleNames := make(map[string]string, len(clusterRoles.Items))
+	for _, clusterRole := range clusterRoles.Items {
+		clusterRoleNames[clusterRole.Labels[kcm.NamespaceRoleLabelKey]] = clusterRole.Name
+	}
+
+	var errs error
+	keepRoleBindings := make(map[string]bool)
+	for namespace, subjectsByRole := range roleSubjects {
+		for role, subjects := range subjectsByRole {
+			roleLabel := strings.ToLower(role)
+			clusterRoleName, ok := clusterRoleNames[roleLabel]
+			if !ok {
+				errs = errors.Join(errs, fmt.Errorf("ClusterRole with the %s=%s label is not found", kcm.NamespaceRoleLabelKey, roleLabel))
+				continue
+			}
+
+			roleBinding := &rbacv1.RoleBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "kcm-namespace-" + roleLabel,
+					Namespace: namespace,
+				},
+			}
+			keepRoleBindings[getNamespacedName(namespace, roleBinding.Name)] = true
+
+			operation, err := controllerutil.CreateOrUpdate(ctx, r.Client, roleBinding, func() error {
+				if roleBinding.Labels == nil {
+					roleBinding.Labels = make(map[string]string)
+				}
+				roleBinding.Labels[kcm.KCMManagedLabelKey] = kcm.KCMManagedLabelValue
+				roleBinding.Labels[kcm.NamespaceRoleLabelKey] = roleLabel
+				// the role reference is immutable, the RoleBinding is recreated below if it differs
+				if roleBinding.CreationTimestamp.IsZero() {
+					roleBinding.RoleRef = rbacv1.RoleRef{
+						APIGroup: rbacv1.GroupName,
+						Kind:     "ClusterRole",
+						Name:     clusterRoleName,
+					}
+				}
+				roleBinding.Subjects = subjects
+				return nil
+			})
+			if err != nil {
+				errs = errors.Join(errs, fmt.Errorf("failed to reconcile RoleBinding %s/%s: %w", namespace, roleBinding.Name, err))
+				continue
+			}
+
+			if roleBinding.RoleRef.Name != clusterRoleName {
+				errs = errors.Join(errs, r.deleteManagedObject(ctx, roleBinding),
+					fmt.Errorf("RoleBinding %s/%s references the outdated ClusterRole %s and was deleted to be recreated", namespace, roleBinding.Name, roleBinding.RoleRef.Name))
+				continue
+			}
+
+			if operation != controllerutil.OperationResultNone {
+				l.Info("RoleBinding was successfully "+string(operation), "namespace", namespace, "name", roleBinding.Name)
+			}
+		}
+	}
+
+	for _, roleBinding := range managedRoleBindings.Items {
+		if keepRoleBindings[getNamespacedName(roleBinding.Namespace, roleBinding.Name)] {
+			continue
+		}
+		errs = errors.Join(errs, r.deleteManagedObject(ctx, &roleBinding))
+	}
+
+	return errs
 }
 
 func (r *AccessManagementReconciler) deleteManagedObject(ctx context.Context, obj client.Object) error {
@@ -341,6 +607,22 @@ func (r *AccessManagementReconciler) deleteManagedObject(ctx context.Context, ob
 	return nil
 }
 
+// orphanManagedObject removes the KCM managed label from the given object so it is no longer managed by the AccessManagement.
+func (r *AccessManagementReconciler) orphanManagedObject(ctx context.Context, obj client.Object) error {
+	l := ctrl.LoggerFrom(ctx)
+
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	labels := obj.GetLabels()
+	delete(labels, kcm.KCMManagedLabelKey)
+	obj.SetLabels(labels)
+	if err := r.Patch(ctx, obj, patch); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	l.Info(kind+" was successfully orphaned", "namespace", obj.GetNamespace(), "name", obj.GetName())
+	return nil
+}
+
 func (r *AccessManagementReconciler) updateStatus(ctx context.Context, accessMgmt *kcm.AccessManagement) error {
 	if err := r.Status().Update(ctx, accessMgmt); err != nil {
 		return fmt.Errorf("failed to update status for AccessManagement %s: %w", accessMgmt.Name, err)
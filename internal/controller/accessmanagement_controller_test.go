@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -113,6 +114,12 @@ var _ = Describe("Template Management Controller", func() {
 					List: []string{namespace3Name},
 				},
 				ServiceTemplateChains: []string{stChainName},
+				Subjects: []kcm.AccessSubject{
+					{
+						Subject: rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "tenant-viewer"},
+						Role:    kcm.NamespaceRoleViewer,
+					},
+				},
 			},
 		}
 
@@ -149,6 +156,24 @@ var _ = Describe("Template Management Controller", func() {
 			credential.WithIdentityRef(credIdentityRef),
 		)
 
+		viewerClusterRole := &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "kcm-namespace-viewer-role",
+				Labels: map[string]string{kcm.NamespaceRoleLabelKey: "viewer"},
+			},
+		}
+		roleBindingToDelete := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "kcm-namespace-admin",
+				Namespace: namespace2Name,
+				Labels: map[string]string{
+					kcm.KCMManagedLabelKey:    kcm.KCMManagedLabelValue,
+					kcm.NamespaceRoleLabelKey: "admin",
+				},
+			},
+			RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "kcm-namespace-admin-role"},
+		}
+
 		BeforeEach(func() {
 			By("creating test namespaces")
 			var err error
@@ -169,6 +194,7 @@ var _ = Describe("Template Management Controller", func() {
 				ctChain, ctChainToDelete, ctChainUnmanaged,
 				stChain, stChainToDelete, stChainUnmanaged,
 				cred, credToDelete, credUnmanaged,
+				viewerClusterRole, roleBindingToDelete,
 			} {
 				err = k8sClient.Get(ctx, types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}, obj)
 				if err != nil && errors.IsNotFound(err) {
@@ -199,6 +225,7 @@ var _ = Describe("Template Management Controller", func() {
 					Expect(crclient.IgnoreNotFound(err)).To(Succeed())
 				}
 			}
+			Expect(crclient.IgnoreNotFound(k8sClient.Delete(ctx, viewerClusterRole))).To(Succeed())
 			for _, ns := range []*corev1.Namespace{namespace1, namespace2, namespace3} {
 				err := k8sClient.Get(ctx, types.NamespacedName{Name: ns.Name}, ns)
 				Expect(err).NotTo(HaveOccurred())
@@ -220,11 +247,36 @@ var _ = Describe("Template Management Controller", func() {
 			err = k8sClient.Get(ctx, types.NamespacedName{Namespace: credUnmanaged.Namespace, Name: credUnmanaged.Name}, credUnmanagedBefore)
 			Expect(err).NotTo(HaveOccurred())
 
-			By("Reconciling the created resource")
 			controllerReconciler := &AccessManagementReconciler{
 				Client:          k8sClient,
 				SystemNamespace: systemNamespace.Name,
 			}
+
+			By("Reconciling the created resource in the dry-run mode")
+			amDryRun := &kcm.AccessManagement{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: amName}, amDryRun)).To(Succeed())
+			amDryRun.Spec.DryRun = true
+			Expect(k8sClient.Update(ctx, amDryRun)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: amName},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: amName}, amDryRun)).To(Succeed())
+			Expect(amDryRun.Status.Current).To(BeEmpty())
+			Expect(amDryRun.Status.Changes).To(Equal([]kcm.NamespaceAccessChanges{
+				{Namespace: namespace1Name, Distribute: []string{"ClusterTemplateChain/" + ctChainName, "Credential/" + credName, "ServiceTemplateChain/" + stChainName}},
+				{Namespace: namespace2Name, Distribute: []string{"ClusterTemplateChain/" + ctChainName, "Credential/" + credName}, Prune: []string{"ClusterTemplateChain/" + ctChainToDeleteName}},
+				{Namespace: namespace3Name, Distribute: []string{"ServiceTemplateChain/" + stChainName}, Prune: []string{"Credential/" + credToDeleteName, "ServiceTemplateChain/" + stChainToDeleteName}},
+			}))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace1Name, Name: ctChainName}, &kcm.ClusterTemplateChain{})).To(MatchError(ContainSubstring("not found")))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace2Name, Name: ctChainToDeleteName}, &kcm.ClusterTemplateChain{})).To(Succeed())
+
+			amDryRun.Spec.DryRun = false
+			Expect(k8sClient.Update(ctx, amDryRun)).To(Succeed())
+
+			By("Reconciling the created resource")
 			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: types.NamespacedName{Name: amName},
 			})
@@ -244,6 +296,9 @@ var _ = Describe("Template Management Controller", func() {
 					* namespace2/test-cred - should be created
 					* namespace2/test-cred-unmanaged - should be unchanged (unmanaged by KCM)
 					* namespace3/test-cred-to delete - should be deleted
+
+					* namespace3/kcm-namespace-viewer - should be created
+					* namespace2/kcm-namespace-admin - should be deleted
 			*/
 			verifyObjectCreated(ctx, namespace1Name, ctChain)
 			verifyObjectCreated(ctx, namespace1Name, stChain)
@@ -259,6 +314,23 @@ var _ = Describe("Template Management Controller", func() {
 			verifyObjectDeleted(ctx, namespace2Name, ctChainToDelete)
 			verifyObjectDeleted(ctx, namespace3Name, stChainToDelete)
 			verifyObjectDeleted(ctx, namespace3Name, credToDelete)
+
+			amApplied := &kcm.AccessManagement{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: amName}, amApplied)).To(Succeed())
+			Expect(amApplied.Status.Delivery).To(ContainElement(kcm.NamespaceAccessDelivery{
+				Namespace: namespace3Name,
+				Objects: []kcm.AccessDeliveryResult{
+					{Kind: kcm.CredentialKind, Name: credToDeleteName, Result: kcm.DeliveryResultPruned, Message: "deleted"},
+					{Kind: kcm.ServiceTemplateChainKind, Name: stChainName, Result: kcm.DeliveryResultCreated},
+					{Kind: kcm.ServiceTemplateChainKind, Name: stChainToDeleteName, Result: kcm.DeliveryResultPruned, Message: "deleted"},
+				},
+			}))
+
+			roleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "kcm-namespace-viewer"}}
+			verifyObjectCreated(ctx, namespace3Name, roleBinding)
+			Expect(roleBinding.RoleRef.Name).To(Equal(viewerClusterRole.Name))
+			Expect(roleBinding.Subjects).To(ConsistOf(accessRules[2].Subjects[0].Subject))
+			verifyObjectDeleted(ctx, namespace2Name, roleBindingToDelete)
 		})
 	})
 })
@@ -31,9 +31,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kcmv1alpha1 "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/integrations"
+	"github.com/K0rdent/kcm/internal/metrics"
 	"github.com/K0rdent/kcm/internal/utils"
 )
 
+// veleroNotInstalledMsg is the error of the ManagementBackups reconciled without Velero installed.
+const veleroNotInstalledMsg = "Velero is not installed, the backups are disabled until it is installed"
+
 // scheduleMgmtNameLabel holds a reference to the [github.com/K0rdent/kcm/api/v1alpha1.ManagementBackup] object name.
 const scheduleMgmtNameLabel = "k0rdent.mirantis.com/management-backup"
 
@@ -49,6 +54,17 @@ func (r *Reconciler) ReconcileBackup(ctx context.Context, mgmtBackup *kcmv1alpha
 		return ctrl.Result{}, nil
 	}
 
+	veleroInstalled, err := integrations.Available(r.cl.RESTMapper(), integrations.Velero)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to check if Velero is installed: %w", err)
+	}
+	if !veleroInstalled {
+		return r.setVeleroNotInstalled(ctx, mgmtBackup)
+	}
+	if mgmtBackup.Status.Error == veleroNotInstalledMsg { // persisted along with the other changes of the status
+		mgmtBackup.Status.Error = ""
+	}
+
 	if isRestored(mgmtBackup) {
 		return r.updateAfterRestoration(ctx, mgmtBackup)
 	}
@@ -106,6 +122,10 @@ func (r *Reconciler) ReconcileBackup(ctx context.Context, mgmtBackup *kcmv1alpha
 		return ctrl.Result{}, fmt.Errorf("failed to update ManagementBackup %s status: %w", mgmtBackup.Name, err)
 	}
 
+	if mgmtBackup.IsSchedule() && veleroBackup.Status.Phase == velerov1.BackupPhaseCompleted && veleroBackup.Status.CompletionTimestamp != nil {
+		metrics.TrackMetricBackupLastSuccess(ctx, mgmtBackup.Name, veleroBackup.Status.CompletionTimestamp.Time)
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -300,6 +320,22 @@ func (r *Reconciler) propagateMetaError(ctx context.Context, mgmtBackup *kcmv1al
 	return ctrl.Result{}, nil // no need to requeue if got such error
 }
 
+// setVeleroNotInstalled reports the missing Velero in the status of the given ManagementBackup once, the backups
+// are not attempted until Velero is installed and the ManagementBackup is reconciled again, e.g. by the schedule.
+func (r *Reconciler) setVeleroNotInstalled(ctx context.Context, mgmtBackup *kcmv1alpha1.ManagementBackup) (ctrl.Result, error) {
+	if mgmtBackup.Status.Error == veleroNotInstalledMsg {
+		return ctrl.Result{}, nil
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Velero is not installed, skipping the backup")
+	mgmtBackup.Status.Error = veleroNotInstalledMsg
+	if err := r.cl.Status().Update(ctx, mgmtBackup); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update ManagementBackup %s status: %w", mgmtBackup.Name, err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
 func getMostRecentProducedBackup(mgmtBackupName string, backups []velerov1.Backup) (*velerov1.Backup, bool) {
 	if len(backups) == 0 {
 		return &velerov1.Backup{}, false
@@ -18,7 +18,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"net"
+	"net/netip"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,14 +34,19 @@ import (
 	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -48,19 +57,95 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/addonversions"
+	"github.com/K0rdent/kcm/internal/argocd"
+	"github.com/K0rdent/kcm/internal/cloudtags"
+	"github.com/K0rdent/kcm/internal/clustercache"
+	"github.com/K0rdent/kcm/internal/configdrift"
+	"github.com/K0rdent/kcm/internal/configvalidation"
+	"github.com/K0rdent/kcm/internal/cost"
+	"github.com/K0rdent/kcm/internal/cpmigration"
+	"github.com/K0rdent/kcm/internal/credspropagation"
+	"github.com/K0rdent/kcm/internal/deletion"
+	"github.com/K0rdent/kcm/internal/dns"
+	"github.com/K0rdent/kcm/internal/failuredomains"
+	"github.com/K0rdent/kcm/internal/faultinjection"
+	"github.com/K0rdent/kcm/internal/fleetaccess"
+	"github.com/K0rdent/kcm/internal/freeze"
 	"github.com/K0rdent/kcm/internal/helm"
+	"github.com/K0rdent/kcm/internal/kubernetessupport"
+	"github.com/K0rdent/kcm/internal/machineaccess"
+	"github.com/K0rdent/kcm/internal/machinerollout"
 	"github.com/K0rdent/kcm/internal/metrics"
+	"github.com/K0rdent/kcm/internal/networking"
+	"github.com/K0rdent/kcm/internal/nodeimagerollout"
+	"github.com/K0rdent/kcm/internal/observability"
+	"github.com/K0rdent/kcm/internal/osimage"
 	providersloader "github.com/K0rdent/kcm/internal/providers"
+	"github.com/K0rdent/kcm/internal/provisioning"
+	"github.com/K0rdent/kcm/internal/proxy"
+	"github.com/K0rdent/kcm/internal/quotacheck"
+	"github.com/K0rdent/kcm/internal/readinessgates"
+	"github.com/K0rdent/kcm/internal/registrycredentials"
+	"github.com/K0rdent/kcm/internal/remediation"
+	"github.com/K0rdent/kcm/internal/rollback"
+	"github.com/K0rdent/kcm/internal/serviceadvisories"
+	"github.com/K0rdent/kcm/internal/servicebundle"
+	"github.com/K0rdent/kcm/internal/serviceconflicts"
+	"github.com/K0rdent/kcm/internal/servicehistory"
+	"github.com/K0rdent/kcm/internal/statemanagement"
 	"github.com/K0rdent/kcm/internal/sveltos"
 	"github.com/K0rdent/kcm/internal/telemetry"
+	"github.com/K0rdent/kcm/internal/timings"
+	"github.com/K0rdent/kcm/internal/trustedca"
 	"github.com/K0rdent/kcm/internal/utils"
+	"github.com/K0rdent/kcm/internal/utils/clusterclass"
+	"github.com/K0rdent/kcm/internal/utils/cni"
+	"github.com/K0rdent/kcm/internal/utils/fleet"
+	"github.com/K0rdent/kcm/internal/utils/gpu"
+	"github.com/K0rdent/kcm/internal/utils/oidc"
+	"github.com/K0rdent/kcm/internal/utils/propagation"
 	"github.com/K0rdent/kcm/internal/utils/ratelimit"
 	"github.com/K0rdent/kcm/internal/utils/status"
+	"github.com/K0rdent/kcm/internal/utils/upgrade"
 	"github.com/K0rdent/kcm/internal/utils/validation"
+	"github.com/K0rdent/kcm/internal/utils/vip"
+	"github.com/K0rdent/kcm/internal/valuestemplate"
 )
 
 var ErrClusterNotFound = errors.New("cluster is not found")
 
+// errCertificatesRotationUnsupported is returned for the rotation of the certificates of an unsupported control plane.
+var errCertificatesRotationUnsupported = errors.New("certificates rotation is not supported")
+
+const (
+	kubeadmControlPlaneKind = "KubeadmControlPlane"
+
+	clusterRBACConfigMapSuffix = "-cluster-rbac"
+
+	kubeconfigSecretSuffix = "-kubeconfig"
+	kubeconfigSecretKey    = "value"
+
+	certificatesExpiryWarningPeriod = 30 * 24 * time.Hour
+	certificatesCheckInterval       = 12 * time.Hour
+
+	// readinessGatesCheckInterval is the interval the passed HTTP probes of the readiness gates of the clusters are rechecked at.
+	readinessGatesCheckInterval = 5 * time.Minute
+	// readinessGatesResyncInterval is the interval the other passed readiness gates of the clusters are rechecked at,
+	// their changes are otherwise observed with the watches of the clusters.
+	readinessGatesResyncInterval = 30 * time.Minute
+	// sveltosAgentsCheckInterval is the interval the Sveltos agents of the clusters are rechecked at.
+	sveltosAgentsCheckInterval = 30 * time.Minute
+	// registryCredentialsCheckInterval is the interval the registry credentials are redistributed at to pick up their rotation.
+	registryCredentialsCheckInterval = 10 * time.Minute
+	// trustedCACheckInterval is the interval the trusted CA bundle is redistributed at to pick up its changes.
+	trustedCACheckInterval = 10 * time.Minute
+	// machineAccessCheckInterval is the interval the shared SSH keys of the clusters are rechecked at to pick up their rotation.
+	machineAccessCheckInterval = 10 * time.Minute
+
+	defaultExpirationWarningPeriod = 24 * time.Hour
+)
+
 type helmActor interface {
 	DownloadChartFromArtifact(ctx context.Context, artifact *sourcev1.Artifact) (*chart.Chart, error)
 	InitializeConfiguration(clusterDeployment *kcm.ClusterDeployment, log action.DebugLog) (*action.Configuration, error)
@@ -74,7 +159,16 @@ type ClusterDeploymentReconciler struct {
 	Config          *rest.Config
 	DynamicClient   *dynamic.DynamicClient
 	SystemNamespace string
-
+	// PriceSource provides the price lists the cost of the clusters is estimated with.
+	PriceSource cost.PriceSource
+	// SveltosDisabled is set if Sveltos is not installed, e.g. with the Edge management profile,
+	// so the services are delivered only by the other engines and the Sveltos objects are not watched.
+	SveltosDisabled bool
+	// InitialSync spreads the reconciles of the ClusterDeployments listed upon the start of the controller.
+	InitialSync ratelimit.InitialSyncOptions
+
+	eventRecorder      record.EventRecorder
+	clusterTracker     *clustercache.Tracker
 	defaultRequeueTime time.Duration
 }
 
@@ -95,6 +189,16 @@ func (r *ClusterDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, err
 	}
 
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, clusterDeployment.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		l.Info("Reconciliation is paused, only observing the status")
+		return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, r.reconcilePaused(ctx, clusterDeployment, pausedMessage)
+	}
+	apimeta.RemoveStatusCondition(clusterDeployment.GetConditions(), kcm.ReconciliationPausedCondition)
+
 	if !clusterDeployment.DeletionTimestamp.IsZero() {
 		l.Info("Deleting ClusterDeployment")
 		return r.Delete(ctx, clusterDeployment)
@@ -124,6 +228,31 @@ func (r *ClusterDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	return r.reconcileUpdate(ctx, clusterDeployment)
 }
 
+// reconcilePaused reports the paused reconciliation of the given ClusterDeployment in its status
+// without making any changes, the readiness of the HelmRelease of the cluster is still observed.
+func (r *ClusterDeploymentReconciler) reconcilePaused(ctx context.Context, cd *kcm.ClusterDeployment, message string) error {
+	apimeta.SetStatusCondition(cd.GetConditions(), freeze.Condition(message, cd.Generation))
+
+	hr := new(hcv2.HelmRelease)
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(cd), hr); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get HelmRelease %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+	if hrReadyCondition := fluxconditions.Get(hr, fluxmeta.ReadyCondition); hrReadyCondition != nil {
+		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+			Type:    kcm.HelmReleaseReadyCondition,
+			Status:  hrReadyCondition.Status,
+			Reason:  hrReadyCondition.Reason,
+			Message: hrReadyCondition.Message,
+		})
+	}
+	cd.Status.Conditions = updateStatusConditions(cd.Status.Conditions)
+
+	if err := r.Client.Status().Update(ctx, cd); err != nil {
+		return fmt.Errorf("failed to update status for clusterDeployment %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+	return nil
+}
+
 func (r *ClusterDeploymentReconciler) setStatusFromChildObjects(ctx context.Context, clusterDeployment *kcm.ClusterDeployment, gvr schema.GroupVersionResource, conditions []string) (requeue bool, _ error) {
 	l := ctrl.LoggerFrom(ctx)
 
@@ -156,7 +285,7 @@ func (r *ClusterDeploymentReconciler) setStatusFromChildObjects(ctx context.Cont
 	return !allConditionsComplete, nil
 }
 
-func (r *ClusterDeploymentReconciler) reconcileUpdate(ctx context.Context, cd *kcm.ClusterDeployment) (_ ctrl.Result, err error) {
+func (r *ClusterDeploymentReconciler) reconcileUpdate(ctx context.Context, cd *kcm.ClusterDeployment) (result ctrl.Result, err error) {
 	l := ctrl.LoggerFrom(ctx)
 
 	if controllerutil.AddFinalizer(cd, kcm.ClusterDeploymentFinalizer) {
@@ -177,13 +306,39 @@ func (r *ClusterDeploymentReconciler) reconcileUpdate(ctx context.Context, cd *k
 		cd.InitConditions()
 	}
 
+	if rollback.Requested(cd) {
+		return ctrl.Result{}, r.rollback(ctx, cd)
+	}
+	if servicehistory.Requested(cd) {
+		return ctrl.Result{}, r.rollbackServices(ctx, cd)
+	}
+
+	if _, ok := cpmigration.Pending(cd); ok {
+		return ctrl.Result{}, r.migrateControlPlane(ctx, cd)
+	}
+	if cpmigration.InProgress(cd) && cd.Spec.Template != cd.Status.ControlPlaneMigration.ToTemplate {
+		return ctrl.Result{}, r.switchControlPlaneMigrationTemplate(ctx, cd)
+	}
+
+	expirationRequeue, deleted, err := r.reconcileExpiration(ctx, cd)
+	if err != nil || deleted {
+		return ctrl.Result{}, err
+	}
+	if expirationRequeue > 0 {
+		defer func() {
+			if result.RequeueAfter == 0 || expirationRequeue < result.RequeueAfter {
+				result.RequeueAfter = expirationRequeue
+			}
+		}()
+	}
+
 	clusterTpl := &kcm.ClusterTemplate{}
 
 	defer func() {
 		err = errors.Join(err, r.updateStatus(ctx, cd, clusterTpl))
 	}()
 
-	if err = r.Client.Get(ctx, client.ObjectKey{Name: cd.Spec.Template, Namespace: cd.Namespace}, clusterTpl); err != nil {
+	if err = r.Client.Get(ctx, client.ObjectKey{Name: cd.Spec.Template, Namespace: cd.TemplateNamespace()}, clusterTpl); err != nil {
 		l.Error(err, "Failed to get Template")
 		errMsg := fmt.Sprintf("failed to get provided template: %s", err)
 		if apierrors.IsNotFound(err) {
@@ -200,8 +355,9 @@ func (r *ClusterDeploymentReconciler) reconcileUpdate(ctx context.Context, cd *k
 
 	clusterRes, clusterErr := r.updateCluster(ctx, cd, clusterTpl)
 	servicesRes, servicesErr := r.updateServices(ctx, cd)
+	migrationRes, migrationErr := r.reconcileControlPlaneMigration(ctx, cd)
 
-	if err = errors.Join(clusterErr, servicesErr); err != nil {
+	if err = errors.Join(clusterErr, servicesErr, migrationErr); err != nil {
 		return ctrl.Result{}, err
 	}
 	if !clusterRes.IsZero() {
@@ -210,6 +366,9 @@ func (r *ClusterDeploymentReconciler) reconcileUpdate(ctx context.Context, cd *k
 	if !servicesRes.IsZero() {
 		return servicesRes, nil
 	}
+	if !migrationRes.IsZero() {
+		return migrationRes, nil
+	}
 
 	return ctrl.Result{}, nil
 }
@@ -234,6 +393,8 @@ func (r *ClusterDeploymentReconciler) updateCluster(ctx context.Context, cd *kcm
 	// template is ok, propagate data from it
 	cd.Status.KubernetesVersion = clusterTpl.Status.KubernetesVersion
 
+	r.updateCostEstimate(ctx, cd, clusterTpl)
+
 	apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
 		Type:    kcm.TemplateReadyCondition,
 		Status:  metav1.ConditionTrue,
@@ -241,6 +402,8 @@ func (r *ClusterDeploymentReconciler) updateCluster(ctx context.Context, cd *kcm
 		Message: "Template is valid",
 	})
 
+	timings.Start(cd, time.Now())
+
 	source, err := r.getSource(ctx, clusterTpl.Status.ChartRef)
 	if err != nil {
 		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
@@ -270,7 +433,10 @@ func (r *ClusterDeploymentReconciler) updateCluster(ctx context.Context, cd *kcm
 	}
 
 	l.Info("Validating Helm chart with provided values")
-	if err = r.EnsureReleaseWithValues(ctx, actionConfig, hcChart, cd); err != nil {
+	if err = r.injectFailure(cd, faultinjection.PointHelmRender); err == nil {
+		err = r.EnsureReleaseWithValues(ctx, actionConfig, hcChart, cd)
+	}
+	if err != nil {
 		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
 			Type:    kcm.HelmChartReadyCondition,
 			Status:  metav1.ConditionFalse,
@@ -318,18 +484,171 @@ func (r *ClusterDeploymentReconciler) updateCluster(ctx context.Context, cd *kcm
 		Message: "Credential is Ready",
 	})
 
+	validated, err := r.reconcileConfigValidation(ctx, cd, clusterTpl)
+	if err != nil || !validated {
+		return ctrl.Result{}, err
+	}
+
+	quotaAvailable, err := r.reconcileQuotaCheck(ctx, cd, clusterTpl)
+	if err != nil || !quotaAvailable {
+		return ctrl.Result{}, err
+	}
+
 	if cd.Spec.DryRun {
 		return ctrl.Result{}, nil
 	}
 
+	if err := r.updateSecurityBaselineStatus(ctx, cd); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	controlPlaneVIP, err := r.reserveControlPlaneVIP(ctx, cd)
+	if err != nil {
+		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+			Type:    kcm.ControlPlaneVIPReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  kcm.FailedReason,
+			Message: fmt.Sprintf("Failed to reserve control plane VIP: %s", err),
+		})
+		return ctrl.Result{}, err
+	}
+
+	approvedImageRollouts, err := nodeimagerollout.Approved(ctx, r.Client, cd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	release, err := r.getRelease(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.updateKubernetesSupport(ctx, cd, release); err != nil {
+		l.Error(err, "failed to check the end of life of the Kubernetes version")
+	}
+
+	addonVersions, err := releaseAddonVersions(release, clusterTpl)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	trustedCA, err := r.getTrustedCABundle(ctx, clusterTpl)
+	if err != nil {
+		r.setCondition(cd, kcm.TrustedCAPropagatedCondition, err)
+		return ctrl.Result{}, err
+	}
+
+	machineAccessSupported, err := machineaccess.TemplateSupported(clusterTpl.Status.Config)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	authorizedKeys, authorizedKeysHash, err := machineaccess.AuthorizedKeys(ctx, r.Client, cd)
+	if err != nil {
+		r.setCondition(cd, kcm.MachineAccessConvergedCondition, err)
+		return ctrl.Result{}, err
+	}
+
+	// the context is collected before the values are modified, e.g. the region of the cluster
+	templateContext := valuestemplate.NewContext(cd)
 	if err := cd.AddHelmValues(func(values map[string]any) error {
+		if cd.Spec.RenderValues {
+			if err := valuestemplate.RenderValues(values, templateContext); err != nil {
+				return fmt.Errorf("failed to render the configuration: %w", err)
+			}
+		}
+
+		osImages, err := osimage.ResolveValues(ctx, r.Client, clusterTpl, values, cd.Status.OSImages, func(name string) bool {
+			return slices.Contains(approvedImageRollouts, name)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to resolve OS images: %w", err)
+		}
+		cd.Status.OSImages = osImages
+
 		values["clusterIdentity"] = cred.Spec.IdentityRef
 
+		if _, ok := values[addonversions.ValuesKey]; !ok && addonVersions != nil {
+			values[addonversions.ValuesKey] = addonVersions
+		}
+
+		if clusterTpl.Spec.ClusterClass != nil {
+			values[clusterclass.ClassValuesKey] = clusterclass.ClassValues(clusterTpl, cd.Namespace)
+		}
+
+		if controlPlaneVIP != "" {
+			valuesKey := cd.Spec.ControlPlaneVIP.ValuesKey
+			if valuesKey == "" {
+				valuesKey = kcm.DefaultControlPlaneVIPValuesKey
+			}
+			values[valuesKey] = controlPlaneVIP
+		}
+
+		if cd.Spec.Authentication != nil && cd.Spec.Authentication.OIDC != nil {
+			if err := oidc.SetAPIServerArgs(values, cd.Spec.Authentication.OIDC); err != nil {
+				return fmt.Errorf("failed to set OIDC API server arguments: %w", err)
+			}
+		}
+
+		if cd.Spec.FailureDomains != nil {
+			values[failuredomains.ValuesKey] = failuredomains.Values(cd.Spec.FailureDomains)
+		}
+
+		if cd.Spec.Networks != nil {
+			values[networking.ValuesKey] = networking.Values(cd.Spec.Networks)
+		}
+
+		if cd.Spec.Network != nil {
+			values[cni.ValuesKey] = cd.Spec.Network.CNI
+		}
+
+		if cd.Spec.Proxy != nil {
+			values[proxy.ValuesKey] = proxy.Values(cd.Spec.Proxy)
+		}
+
+		if trustedCA != nil && trustedCA.machines {
+			values[trustedca.ValuesKey] = trustedca.Values(trustedCA.bundle)
+		}
+
+		if access := cd.Spec.MachineAccess; access != nil {
+			if machineAccessSupported {
+				values[machineaccess.ValuesKey] = machineaccess.Values(access.User, authorizedKeys)
+			}
+			if access.Bastion != nil {
+				if err := machineaccess.SetBastionValues(values, access.Bastion); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(cd.Spec.CloudTags) > 0 {
+			tags, _ := values[cloudtags.ValuesKey].(map[string]any)
+			values[cloudtags.ValuesKey] = cloudtags.Merge(tags, cd.Spec.CloudTags)
+		}
+
+		if isHibernated(cd) {
+			values["workersNumber"] = 0
+		}
+
 		if _, ok := values["clusterLabels"]; !ok {
 			// Use the ManagedCluster's own labels if not defined.
 			values["clusterLabels"] = cd.GetObjectMeta().GetLabels()
 		}
 
+		if cd.Annotations[kcm.SecurityBaselineKey] == kcm.SecurityBaselineOptOutValue {
+			clusterLabels := make(map[string]any)
+			switch l := values["clusterLabels"].(type) {
+			case map[string]any:
+				maps.Copy(clusterLabels, l)
+			case map[string]string:
+				for k, v := range l {
+					clusterLabels[k] = v
+				}
+			}
+			// the label excludes the cluster from the security baseline MultiClusterService
+			clusterLabels[kcm.SecurityBaselineKey] = kcm.SecurityBaselineOptOutValue
+			values["clusterLabels"] = clusterLabels
+		}
+
 		return nil
 	}); err != nil {
 		return ctrl.Result{}, err
@@ -349,16 +668,30 @@ func (r *ClusterDeploymentReconciler) updateCluster(ctx context.Context, cd *kcm
 		hrReconcileOpts.ReconcileInterval = &clusterTpl.Spec.Helm.ChartSpec.Interval.Duration
 	}
 
-	hr, _, err := helm.ReconcileHelmRelease(ctx, r.Client, cd.Name, cd.Namespace, hrReconcileOpts)
+	valuesHash, err := configdrift.Hash(cd.Spec.Config)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to hash values of ClusterDeployment %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+	hrReconcileOpts.Annotations = map[string]string{kcm.AppliedValuesHashAnnotation: valuesHash}
+
+	hr, held, err := r.reconcileConfigDrift(ctx, cd)
 	if err != nil {
-		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
-			Type:    kcm.HelmReleaseReadyCondition,
-			Status:  metav1.ConditionFalse,
-			Reason:  kcm.FailedReason,
-			Message: err.Error(),
-		})
 		return ctrl.Result{}, err
 	}
+	if !held {
+		if err = r.injectFailure(cd, faultinjection.PointCAPIApply); err == nil {
+			hr, _, err = helm.ReconcileHelmRelease(ctx, r.Client, cd.Name, cd.Namespace, hrReconcileOpts)
+		}
+		if err != nil {
+			apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+				Type:    kcm.HelmReleaseReadyCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  kcm.FailedReason,
+				Message: err.Error(),
+			})
+			return ctrl.Result{}, err
+		}
+	}
 
 	hrReadyCondition := fluxconditions.Get(hr, fluxmeta.ReadyCondition)
 	if hrReadyCondition != nil {
@@ -370,6 +703,34 @@ func (r *ClusterDeploymentReconciler) updateCluster(ctx context.Context, cd *kcm
 		})
 	}
 
+	provisioningStatus, retryAfter, err := provisioning.Reconcile(ctx, r.Client, cd, hr, time.Now())
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile provisioning of %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+	cd.Status.Provisioning = provisioningStatus
+	if provisioningStatus == nil {
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.ProvisionedCondition)
+	} else {
+		apimeta.SetStatusCondition(cd.GetConditions(), provisioning.Condition(provisioningStatus, cd.Generation))
+		if provisioningStatus.Phase == kcm.ProvisioningPhaseFailed {
+			// terminal until the spec is changed
+			return ctrl.Result{}, nil
+		}
+	}
+
+	if err := r.reconcileDNSRecord(ctx, cd); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcileEndpoints(ctx, cd); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	certificatesTracked, err := r.reconcileCertificates(ctx, cd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	requeue, err := r.aggregateCapoConditions(ctx, cd)
 	if err != nil {
 		if requeue {
@@ -383,142 +744,1551 @@ func (r *ClusterDeploymentReconciler) updateCluster(ctx context.Context, cd *kcm
 		return ctrl.Result{RequeueAfter: r.defaultRequeueTime}, nil
 	}
 
-	if !fluxconditions.IsReady(hr) {
-		return ctrl.Result{RequeueAfter: r.defaultRequeueTime}, nil
+	if err := r.propagateMetadata(ctx, cd); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to propagate labels and annotations: %w", err)
 	}
 
-	return ctrl.Result{}, nil
-}
-
-func (r *ClusterDeploymentReconciler) updateSveltosClusterCondition(ctx context.Context, clusterDeployment *kcm.ClusterDeployment) (bool, error) {
-	sveltosClusters := &libsveltosv1beta1.SveltosClusterList{}
+	if err := r.labelClusterProperties(ctx, cd, clusterTpl); err != nil {
+		return ctrl.Result{}, err
+	}
 
-	if err := r.Client.List(ctx, sveltosClusters, &client.ListOptions{
-		LabelSelector: labels.SelectorFromSet(map[string]string{kcm.FluxHelmChartNameKey: clusterDeployment.Name}),
-	}); err != nil {
-		return true, fmt.Errorf("failed to get sveltos cluster status: %w", err)
+	if err := r.reconcileArgoCDCluster(ctx, cd); err != nil {
+		return ctrl.Result{}, err
 	}
 
-	for _, sveltosCluster := range sveltosClusters.Items {
-		sveltosCondition := metav1.Condition{
-			Status: metav1.ConditionUnknown,
-			Type:   kcm.SveltosClusterReadyCondition,
-		}
+	remediationStatus, err := remediation.Reconcile(ctx, r.Client, cd)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile remediation of %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+	cd.Status.Remediation = remediationStatus
 
-		if sveltosCluster.Status.ConnectionStatus == libsveltosv1beta1.ConnectionHealthy {
-			sveltosCondition.Status = metav1.ConditionTrue
-			sveltosCondition.Message = "sveltos cluster is healthy"
-			sveltosCondition.Reason = kcm.SucceededReason
-		} else {
-			sveltosCondition.Status = metav1.ConditionFalse
-			sveltosCondition.Reason = kcm.FailedReason
-			if sveltosCluster.Status.FailureMessage != nil {
-				sveltosCondition.Message = *sveltosCluster.Status.FailureMessage
-			}
-		}
-		apimeta.SetStatusCondition(clusterDeployment.GetConditions(), sveltosCondition)
+	distribution, err := failuredomains.Distribution(ctx, r.Client, cd)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get failure domains of %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+	cd.Status.FailureDomains = distribution
+	if condition := failuredomains.Condition(cd, distribution); condition != nil {
+		apimeta.SetStatusCondition(cd.GetConditions(), *condition)
+	} else {
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.FailureDomainsSpreadCondition)
 	}
 
-	return false, nil
-}
+	gatesPassed := r.reconcileReadinessGates(ctx, cd)
+	agentsRequeueAfter := r.reconcileSveltosAgents(ctx, cd)
+	credentialsRequeueAfter := r.reconcileRegistryCredentials(ctx, cd)
+	fleetAccessRequeueAfter := r.reconcileFleetAccess(ctx, cd)
+	trustedCARequeueAfter := r.reconcileTrustedCA(ctx, cd, hr, trustedCA)
+	machineAccessRequeueAfter := r.reconcileMachineAccess(ctx, cd, hr, authorizedKeysHash, machineAccessSupported)
 
-func (r *ClusterDeploymentReconciler) aggregateCapoConditions(ctx context.Context, clusterDeployment *kcm.ClusterDeployment) (requeue bool, _ error) {
-	type objectToCheck struct {
-		gvr        schema.GroupVersionResource
-		conditions []string
+	if !fluxconditions.IsReady(hr) || !gatesPassed {
+		return ctrl.Result{RequeueAfter: r.defaultRequeueTime}, nil
 	}
 
-	var errs error
-	needRequeue, err := r.updateSveltosClusterCondition(ctx, clusterDeployment)
-	if needRequeue {
-		requeue = true
+	if hr.Status.ObservedGeneration == hr.Generation {
+		now := time.Now()
+		rollback.Record(cd, clusterTpl, now)
+		timings.Complete(ctx, cd, clusterTpl, now)
 	}
-	errs = errors.Join(errs, err)
 
-	for _, obj := range []objectToCheck{
-		{
-			gvr: schema.GroupVersionResource{
-				Group:    "cluster.x-k8s.io",
-				Version:  "v1beta1",
-				Resource: "clusters",
-			},
-			conditions: []string{"ControlPlaneInitialized", "ControlPlaneReady", "InfrastructureReady"},
-		},
-		{
-			gvr: schema.GroupVersionResource{
-				Group:    "cluster.x-k8s.io",
-				Version:  "v1beta1",
-				Resource: "machinedeployments",
-			},
-			conditions: []string{"Available"},
-		},
-	} {
-		needRequeue, err = r.setStatusFromChildObjects(ctx, clusterDeployment, obj.gvr, obj.conditions)
-		errs = errors.Join(errs, err)
-		if needRequeue {
-			requeue = true
-		}
+	if retryAfter > 0 {
+		return ctrl.Result{RequeueAfter: retryAfter}, nil
 	}
 
-	return requeue, errs
-}
+	if cd.Spec.ReadinessGates != nil {
+		if readinessgates.Polled(cd.Spec.ReadinessGates) {
+			return ctrl.Result{RequeueAfter: readinessGatesCheckInterval}, nil
+		}
+		return ctrl.Result{RequeueAfter: readinessGatesResyncInterval}, nil
+	}
 
-func getProjectTemplateResourceRefs(mc *kcm.ClusterDeployment, cred *kcm.Credential) []sveltosv1beta1.TemplateResourceRef {
-	if !mc.Spec.PropagateCredentials || cred.Spec.IdentityRef == nil {
-		return nil
+	if agentsRequeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: agentsRequeueAfter}, nil
 	}
 
-	refs := []sveltosv1beta1.TemplateResourceRef{
-		{
-			Resource:   *cred.Spec.IdentityRef,
-			Identifier: "InfrastructureProviderIdentity",
-		},
+	if credentialsRequeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: credentialsRequeueAfter}, nil
 	}
 
-	if !strings.EqualFold(cred.Spec.IdentityRef.Kind, "Secret") {
-		refs = append(refs, sveltosv1beta1.TemplateResourceRef{
-			Resource: corev1.ObjectReference{
-				APIVersion: "v1",
-				Kind:       "Secret",
-				Namespace:  cred.Spec.IdentityRef.Namespace,
-				Name:       cred.Spec.IdentityRef.Name + "-secret",
-			},
-			Identifier: "InfrastructureProviderIdentitySecret",
-		})
+	if fleetAccessRequeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: fleetAccessRequeueAfter}, nil
 	}
 
-	return refs
-}
+	if trustedCARequeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: trustedCARequeueAfter}, nil
+	}
 
-func getProjectPolicyRefs(mc *kcm.ClusterDeployment, cred *kcm.Credential) []sveltosv1beta1.PolicyRef {
-	if !mc.Spec.PropagateCredentials || cred.Spec.IdentityRef == nil {
-		return nil
+	if machineAccessRequeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: machineAccessRequeueAfter}, nil
 	}
 
-	return []sveltosv1beta1.PolicyRef{
-		{
-			Kind:           "ConfigMap",
-			Namespace:      cred.Spec.IdentityRef.Namespace,
-			Name:           cred.Spec.IdentityRef.Name + "-resource-template",
-			DeploymentType: sveltosv1beta1.DeploymentTypeRemote,
-		},
+	if certificatesTracked {
+		return ctrl.Result{RequeueAfter: certificatesCheckInterval}, nil
 	}
+
+	return ctrl.Result{}, nil
 }
 
-func (*ClusterDeploymentReconciler) initServicesConditions(cd *kcm.ClusterDeployment) (changed bool) {
-	for _, typ := range [3]string{kcm.SveltosProfileReadyCondition, kcm.FetchServicesStatusSuccessCondition, kcm.ServicesReferencesValidationCondition} {
-		if apimeta.SetStatusCondition(&cd.Status.Conditions, metav1.Condition{
-			Type:               typ,
-			Status:             metav1.ConditionUnknown,
-			Reason:             kcm.ProgressingReason,
-			ObservedGeneration: cd.Generation,
-		}) {
-			changed = true
+// reconcileConfigValidation runs the validation Job of the infrastructure provider of the given template
+// against the configuration of the ClusterDeployment, reflecting the result in the ConfigValidated condition.
+// Returns true if the provider does not define the validation Job or the configuration is valid.
+func (r *ClusterDeploymentReconciler) reconcileConfigValidation(ctx context.Context, cd *kcm.ClusterDeployment, template *kcm.ClusterTemplate) (validated bool, _ error) {
+	var (
+		provider string
+		spec     *providersloader.ConfigValidationJob
+	)
+	for _, p := range template.Status.Providers {
+		if !strings.HasPrefix(p, providersloader.InfraPrefix) {
+			continue
 		}
+		if v := providersloader.GetConfigValidation(p); v != nil && v.Job != nil {
+			provider, spec = p, v.Job
+			break
+		}
+	}
+	if spec == nil {
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.ConfigValidatedCondition)
+		return true, nil
 	}
 
-	return changed
-}
+	desired, err := configvalidation.NewJob(cd, provider, spec, cd.Spec.Config)
+	if err != nil {
+		return false, err
+	}
+
+	finished, message, err := r.runProviderJob(ctx, desired)
+	if err != nil {
+		return false, fmt.Errorf("failed to run config validation Job: %w", err)
+	}
+
+	switch {
+	case !finished:
+		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+			Type:    kcm.ConfigValidatedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  kcm.ProgressingReason,
+			Message: message,
+		})
+		return false, nil
+	case message != "":
+		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+			Type:    kcm.ConfigValidatedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  kcm.FailedReason,
+			Message: "Invalid configuration: " + message,
+		})
+		return false, nil
+	}
+
+	apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+		Type:    kcm.ConfigValidatedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  kcm.SucceededReason,
+		Message: "Configuration is valid",
+	})
+	return true, nil
+}
+
+// reconcileQuotaCheck runs the quota Job of the infrastructure provider of the given template
+// against the topology requested by the ClusterDeployment, reflecting the result in the QuotaAvailable condition.
+// The quota is only checked before the cluster is deployed.
+// Returns true if the provider does not define the quota Job or the quota is sufficient.
+func (r *ClusterDeploymentReconciler) reconcileQuotaCheck(ctx context.Context, cd *kcm.ClusterDeployment, template *kcm.ClusterTemplate) (available bool, _ error) {
+	if apimeta.IsStatusConditionTrue(cd.Status.Conditions, kcm.QuotaAvailableCondition) ||
+		apimeta.FindStatusCondition(cd.Status.Conditions, kcm.HelmReleaseReadyCondition) != nil {
+		return true, nil
+	}
+
+	var (
+		provider   string
+		validation *providersloader.ConfigValidation
+	)
+	for _, p := range template.Status.Providers {
+		if !strings.HasPrefix(p, providersloader.InfraPrefix) {
+			continue
+		}
+		if v := providersloader.GetConfigValidation(p); v != nil && v.QuotaJob != nil {
+			provider, validation = p, v
+			break
+		}
+	}
+	if validation == nil {
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.QuotaAvailableCondition)
+		return true, nil
+	}
+
+	topology, err := quotacheck.RequestedTopology(template, cd.Spec.Config, validation.RegionKey)
+	if err != nil {
+		return false, err
+	}
+	desired, err := quotacheck.NewJob(cd, provider, validation.QuotaJob, cd.Spec.Config, topology)
+	if err != nil {
+		return false, err
+	}
+
+	finished, message, err := r.runProviderJob(ctx, desired)
+	if err != nil {
+		return false, fmt.Errorf("failed to run quota Job: %w", err)
+	}
+
+	switch {
+	case !finished:
+		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+			Type:    kcm.QuotaAvailableCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  kcm.ProgressingReason,
+			Message: message,
+		})
+		return false, nil
+	case message != "":
+		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+			Type:    kcm.QuotaAvailableCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  kcm.QuotaExceededReason,
+			Message: "Insufficient quota: " + message,
+		})
+		return false, nil
+	}
+
+	apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+		Type:    kcm.QuotaAvailableCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  kcm.SucceededReason,
+		Message: fmt.Sprintf("Quota is sufficient for %d machines", topology.Machines),
+	})
+	return true, nil
+}
+
+// runProviderJob runs the given desired Job of the infrastructure provider unless it already exists
+// and returns whether the Job has finished. The message describes the progress of the unfinished Job
+// or the failure of the finished one and is empty if the Job has succeeded.
+// The Job run for an outdated configuration, as per the config hash annotation, is removed to be recreated.
+func (r *ClusterDeploymentReconciler) runProviderJob(ctx context.Context, desired *batchv1.Job) (finished bool, message string, _ error) {
+	job := new(batchv1.Job)
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(desired), job)
+	switch {
+	case apierrors.IsNotFound(err):
+		if err := r.Client.Create(ctx, desired); err != nil {
+			return false, "", fmt.Errorf("failed to create Job %s: %w", client.ObjectKeyFromObject(desired), err)
+		}
+		return false, fmt.Sprintf("Waiting for the Job %s to finish", desired.Name), nil
+	case err != nil:
+		return false, "", fmt.Errorf("failed to get Job %s: %w", client.ObjectKeyFromObject(desired), err)
+	}
+
+	if job.Annotations[configvalidation.ConfigHashAnnotation] != desired.Annotations[configvalidation.ConfigHashAnnotation] {
+		// the configuration has changed since the Job has been run, the Job is recreated once removed
+		if job.DeletionTimestamp.IsZero() {
+			if err := r.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				return false, "", fmt.Errorf("failed to delete outdated Job %s: %w", client.ObjectKeyFromObject(job), err)
+			}
+		}
+		return false, fmt.Sprintf("Waiting for the outdated Job %s to be removed", job.Name), nil
+	}
+
+	finished, failure := configvalidation.JobResult(job)
+	if !finished {
+		return false, fmt.Sprintf("Waiting for the Job %s to finish", job.Name), nil
+	}
+	if failure == "" {
+		return true, "", nil
+	}
+
+	pods := new(corev1.PodList)
+	if err := r.Client.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabels{batchv1.JobNameLabel: job.Name}); err != nil {
+		return false, "", fmt.Errorf("failed to list pods of the Job %s: %w", client.ObjectKeyFromObject(job), err)
+	}
+	if message := configvalidation.TerminationMessage(pods.Items); message != "" {
+		failure = message
+	}
+
+	return true, failure, nil
+}
+
+func (r *ClusterDeploymentReconciler) updateSveltosClusterCondition(ctx context.Context, clusterDeployment *kcm.ClusterDeployment) (bool, error) {
+	sveltosClusters := &libsveltosv1beta1.SveltosClusterList{}
+
+	if err := r.Client.List(ctx, sveltosClusters, &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{kcm.FluxHelmChartNameKey: clusterDeployment.Name}),
+	}); err != nil {
+		return true, fmt.Errorf("failed to get sveltos cluster status: %w", err)
+	}
+
+	for _, sveltosCluster := range sveltosClusters.Items {
+		sveltosCondition := metav1.Condition{
+			Status: metav1.ConditionUnknown,
+			Type:   kcm.SveltosClusterReadyCondition,
+		}
+
+		if sveltosCluster.Status.ConnectionStatus == libsveltosv1beta1.ConnectionHealthy {
+			sveltosCondition.Status = metav1.ConditionTrue
+			sveltosCondition.Message = "sveltos cluster is healthy"
+			sveltosCondition.Reason = kcm.SucceededReason
+		} else {
+			sveltosCondition.Status = metav1.ConditionFalse
+			sveltosCondition.Reason = kcm.FailedReason
+			if sveltosCluster.Status.FailureMessage != nil {
+				sveltosCondition.Message = *sveltosCluster.Status.FailureMessage
+			}
+		}
+		apimeta.SetStatusCondition(clusterDeployment.GetConditions(), sveltosCondition)
+	}
+
+	return false, nil
+}
+
+// reserveControlPlaneVIP returns the control plane VIP of the given ClusterDeployment,
+// reserving a free address from the referenced VIPPool if the address is not set explicitly.
+// The addresses from a VIPPool are claimed in the allocations of its status first, the concurrent
+// claims of the same address are rejected on the resourceVersion conflict of the VIPPool.
+// The reserved address is stored in the status of the ClusterDeployment only once claimed.
+func (r *ClusterDeploymentReconciler) reserveControlPlaneVIP(ctx context.Context, cd *kcm.ClusterDeployment) (string, error) {
+	if cd.Spec.ControlPlaneVIP == nil {
+		cd.Status.ControlPlaneVIP = ""
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.ControlPlaneVIPReadyCondition)
+		return "", nil
+	}
+
+	inUse, err := r.getControlPlaneVIPsInUse(ctx, cd)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		pool    *kcm.VIPPool
+		ranges  []vip.Range
+		claimed netip.Addr
+	)
+	if poolName := cd.Spec.ControlPlaneVIP.Pool; poolName != "" {
+		pool = new(kcm.VIPPool)
+		if err := r.Client.Get(ctx, client.ObjectKey{Name: poolName}, pool); err != nil {
+			return "", fmt.Errorf("failed to get VIPPool %s: %w", poolName, err)
+		}
+
+		if ranges, err = vip.ParseRanges(pool.Spec.Addresses); err != nil {
+			return "", fmt.Errorf("failed to parse addresses of VIPPool %s: %w", poolName, err)
+		}
+
+		for _, allocation := range pool.Status.Allocations {
+			addr, err := netip.ParseAddr(allocation.Address)
+			if err != nil {
+				continue
+			}
+			if allocation.ClusterDeployment == client.ObjectKeyFromObject(cd).String() {
+				claimed = addr
+				continue
+			}
+			inUse[addr] = struct{}{}
+		}
+	}
+
+	var addr netip.Addr
+	switch {
+	case cd.Spec.ControlPlaneVIP.Address != "":
+		if addr, err = netip.ParseAddr(cd.Spec.ControlPlaneVIP.Address); err != nil {
+			return "", fmt.Errorf("failed to parse control plane VIP: %w", err)
+		}
+		if _, ok := inUse[addr]; ok {
+			return "", fmt.Errorf("address %s is already in use by another ClusterDeployment", addr)
+		}
+		if ranges != nil && !vip.Contains(ranges, addr) {
+			return "", fmt.Errorf("address %s does not belong to VIPPool %s", addr, cd.Spec.ControlPlaneVIP.Pool)
+		}
+	default:
+		// keep the previously claimed or reserved address if it is still valid
+		for _, v := range []string{claimed.String(), cd.Status.ControlPlaneVIP} {
+			if reserved, err := netip.ParseAddr(v); err == nil && vip.Contains(ranges, reserved) {
+				if _, ok := inUse[reserved]; !ok {
+					addr = reserved
+					break
+				}
+			}
+		}
+		if addr.IsValid() {
+			break
+		}
+
+		if addr, err = vip.Allocate(ranges, inUse); err != nil {
+			return "", fmt.Errorf("failed to reserve address from VIPPool %s: %w", cd.Spec.ControlPlaneVIP.Pool, err)
+		}
+	}
+
+	if pool != nil && addr != claimed {
+		if err := r.claimControlPlaneVIP(ctx, pool, cd, addr); err != nil {
+			return "", err
+		}
+	}
+
+	cd.Status.ControlPlaneVIP = addr.String()
+	apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+		Type:    kcm.ControlPlaneVIPReadyCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  kcm.SucceededReason,
+		Message: fmt.Sprintf("Control plane VIP %s is reserved", cd.Status.ControlPlaneVIP),
+	})
+
+	return cd.Status.ControlPlaneVIP, nil
+}
+
+// claimControlPlaneVIP records the given address as allocated to the given ClusterDeployment
+// in the status of the given VIPPool. The update fails on conflict if the VIPPool has been
+// changed since it was read, e.g. by a concurrent claim, and the reservation is retried.
+func (r *ClusterDeploymentReconciler) claimControlPlaneVIP(ctx context.Context, pool *kcm.VIPPool, cd *kcm.ClusterDeployment, addr netip.Addr) error {
+	key := client.ObjectKeyFromObject(cd).String()
+	pool.Status.Allocations = slices.DeleteFunc(pool.Status.Allocations, func(a kcm.VIPAllocation) bool {
+		return a.ClusterDeployment == key
+	})
+	pool.Status.Allocations = append(pool.Status.Allocations, kcm.VIPAllocation{Address: addr.String(), ClusterDeployment: key})
+	slices.SortFunc(pool.Status.Allocations, func(a, b kcm.VIPAllocation) int {
+		return strings.Compare(a.ClusterDeployment, b.ClusterDeployment)
+	})
+
+	if err := r.Client.Status().Update(ctx, pool); err != nil {
+		return fmt.Errorf("failed to claim address %s in VIPPool %s: %w", addr, pool.Name, err)
+	}
+
+	return nil
+}
+
+// reconcileDNSRecord publishes the DNS record for the API endpoint of the given ClusterDeployment
+// via external-dns and records it in the status. The record is removed if DNS is not configured.
+func (r *ClusterDeploymentReconciler) reconcileDNSRecord(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	if cd.Spec.DNS == nil {
+		if len(cd.Endpoints(kcm.EndpointTypeDNSRecord)) > 0 {
+			if err := dns.DeleteDNSEndpoint(ctx, r.Client, cd.Name, cd.Namespace); err != nil {
+				return fmt.Errorf("failed to delete DNSEndpoint %s/%s: %w", cd.Namespace, cd.Name, err)
+			}
+		}
+		cd.SetEndpoints(kcm.EndpointTypeDNSRecord)
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.DNSRecordReadyCondition)
+		return nil
+	}
+
+	target, err := r.getControlPlaneEndpointHost(ctx, cd)
+	if err != nil {
+		r.setCondition(cd, kcm.DNSRecordReadyCondition, err)
+		return err
+	}
+	if target == "" {
+		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+			Type:    kcm.DNSRecordReadyCondition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  kcm.ProgressingReason,
+			Message: "Waiting for the control plane endpoint",
+		})
+		return nil
+	}
+
+	endpoint := kcm.ClusterEndpoint{
+		DNSName:    dns.FQDN(cd),
+		RecordType: dns.RecordType(target),
+		Targets:    []string{target},
+	}
+	if err := dns.ReconcileDNSEndpoint(ctx, r.Client, cd.Name, cd.Namespace, dns.ReconcileDNSEndpointOpts{
+		OwnerReference: &metav1.OwnerReference{
+			APIVersion: kcm.GroupVersion.String(),
+			Kind:       kcm.ClusterDeploymentKind,
+			Name:       cd.Name,
+			UID:        cd.UID,
+		},
+		Endpoint: endpoint,
+		TTL:      cd.Spec.DNS.TTL,
+	}); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			err = errors.New("external-dns DNSEndpoint CRD is not installed")
+		}
+		err = fmt.Errorf("failed to reconcile DNSEndpoint %s/%s: %w", cd.Namespace, cd.Name, err)
+		r.setCondition(cd, kcm.DNSRecordReadyCondition, err)
+		return err
+	}
+
+	cd.SetEndpoints(kcm.EndpointTypeDNSRecord, endpoint)
+	apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+		Type:    kcm.DNSRecordReadyCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  kcm.SucceededReason,
+		Message: fmt.Sprintf("DNS record %s is published", endpoint.DNSName),
+	})
+
+	return nil
+}
+
+// reconcileEndpoints records the URL of the API server reported by the CAPI Cluster and the URL
+// of the OIDC issuer in the status. The ingress load balancer addresses are recorded by the connectivity prober.
+func (r *ClusterDeploymentReconciler) reconcileEndpoints(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	var apiServer []kcm.ClusterEndpoint
+	cluster, err := r.getCAPICluster(ctx, cd)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if err == nil {
+		host, _, _ := unstructured.NestedString(cluster.Object, "spec", "controlPlaneEndpoint", "host")
+		port, _, _ := unstructured.NestedInt64(cluster.Object, "spec", "controlPlaneEndpoint", "port")
+		if host != "" && port != 0 {
+			apiServer = append(apiServer, kcm.ClusterEndpoint{
+				URL: "https://" + net.JoinHostPort(host, strconv.FormatInt(port, 10)),
+			})
+		}
+	}
+	cd.SetEndpoints(kcm.EndpointTypeAPIServer, apiServer...)
+
+	var oidcIssuer []kcm.ClusterEndpoint
+	if cd.Spec.Authentication != nil && cd.Spec.Authentication.OIDC != nil {
+		oidcIssuer = append(oidcIssuer, kcm.ClusterEndpoint{URL: cd.Spec.Authentication.OIDC.IssuerURL})
+	}
+	cd.SetEndpoints(kcm.EndpointTypeOIDCIssuer, oidcIssuer...)
+
+	return nil
+}
+
+// getControlPlaneEndpointHost returns the host of the control plane endpoint of the given ClusterDeployment.
+// The reserved control plane VIP takes precedence over the endpoint reported by the CAPI Cluster.
+// Returns an empty string if the endpoint is not yet known.
+func (r *ClusterDeploymentReconciler) getControlPlaneEndpointHost(ctx context.Context, cd *kcm.ClusterDeployment) (string, error) {
+	if cd.Status.ControlPlaneVIP != "" {
+		return cd.Status.ControlPlaneVIP, nil
+	}
+
+	cluster, err := r.getCAPICluster(ctx, cd)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	host, _, err := unstructured.NestedString(cluster.Object, "spec", "controlPlaneEndpoint", "host")
+	if err != nil {
+		return "", fmt.Errorf("failed to get control plane endpoint of Cluster %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	return host, nil
+}
+
+// reconcileArgoCDCluster registers the ready cluster in Argo CD if the integration is enabled in the Management.
+// Once registered, the cluster Secret is kept up to date until the ClusterDeployment is deleted.
+func (r *ClusterDeploymentReconciler) reconcileArgoCDCluster(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	mgmt := &kcm.Management{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: kcm.ManagementName}, mgmt); err != nil {
+		return fmt.Errorf("failed to get Management: %w", err)
+	}
+	// the stale Secrets are removed by the Management controller
+	if mgmt.Spec.ArgoCD == nil {
+		return nil
+	}
+
+	if !apimeta.IsStatusConditionTrue(cd.Status.Conditions, kcm.ReadyCondition) {
+		return nil
+	}
+
+	secret := new(corev1.Secret)
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: cd.Namespace, Name: cd.Name + kubeconfigSecretSuffix}, secret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if err := argocd.ReconcileClusterSecret(ctx, r.Client, mgmt.Spec.ArgoCD.Namespace, cd, secret.Data[kubeconfigSecretKey], mgmt.Spec.ArgoCD.Labels); err != nil {
+		return fmt.Errorf("failed to reconcile Argo CD cluster Secret for %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	return nil
+}
+
+// getRelease returns the Release of the Management or nil if the Release does not exist.
+func (r *ClusterDeploymentReconciler) getRelease(ctx context.Context) (*kcm.Release, error) {
+	mgmt := &kcm.Management{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: kcm.ManagementName}, mgmt); err != nil {
+		return nil, fmt.Errorf("failed to get Management: %w", err)
+	}
+
+	release := &kcm.Release{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: mgmt.Spec.Release}, release); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get Release %s: %w", mgmt.Spec.Release, err)
+	}
+
+	return release, nil
+}
+
+// releaseAddonVersions returns the values with the versions of the add-ons of the given Release compatible
+// with the given ClusterTemplate or nil if the Release ships no compatible versions.
+func releaseAddonVersions(release *kcm.Release, clusterTpl *kcm.ClusterTemplate) (map[string]any, error) {
+	if release == nil {
+		// the versions defined by the ClusterTemplate are used
+		return nil, nil
+	}
+
+	versions, err := addonversions.Select(release, clusterTpl)
+	if err != nil || versions == nil {
+		return nil, err
+	}
+
+	return addonversions.Values(versions), nil
+}
+
+// updateKubernetesSupport reports the Kubernetes version of the cluster approaching or past
+// its end of life set in the given Release with the condition, the warning event and the metric.
+func (r *ClusterDeploymentReconciler) updateKubernetesSupport(ctx context.Context, cd *kcm.ClusterDeployment, release *kcm.Release) error {
+	endOfLife, err := kubernetessupport.EndOfLife(release, cd.Status.KubernetesVersion)
+	if err != nil {
+		return err
+	}
+
+	if endOfLife == nil {
+		metrics.DeleteMetricClusterKubernetesEndOfLife(cd.Namespace, cd.Name)
+	} else {
+		metrics.TrackMetricClusterKubernetesEndOfLife(ctx, cd.ObjectMeta, cd.Status.KubernetesVersion, endOfLife.Time)
+	}
+
+	if kubernetessupport.SetCondition(cd.GetConditions(), cd.Status.KubernetesVersion, endOfLife, time.Now()) {
+		if condition := apimeta.FindStatusCondition(cd.Status.Conditions, kcm.KubernetesVersionEndOfLifeCondition); condition != nil {
+			r.eventRecorder.Event(cd, corev1.EventTypeWarning, condition.Reason, condition.Message)
+		}
+	}
+
+	return nil
+}
+
+// updateSecurityBaselineStatus reflects whether the security baseline configured in the Management is enforced on the cluster.
+func (r *ClusterDeploymentReconciler) updateSecurityBaselineStatus(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	mgmt := &kcm.Management{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: kcm.ManagementName}, mgmt); err != nil {
+		return fmt.Errorf("failed to get Management: %w", err)
+	}
+
+	switch {
+	case mgmt.Spec.SecurityBaseline == nil:
+		cd.Status.SecurityBaseline = ""
+	case cd.Annotations[kcm.SecurityBaselineKey] == kcm.SecurityBaselineOptOutValue:
+		cd.Status.SecurityBaseline = kcm.SecurityBaselineOptedOut
+	default:
+		cd.Status.SecurityBaseline = kcm.SecurityBaselineEnforced
+	}
+
+	return nil
+}
+
+// updateCostEstimate sets the estimated cost of the cluster nodes in the ClusterDeployment status.
+// The estimation is informational only, hence the failures are logged and do not block the reconciliation.
+func (r *ClusterDeploymentReconciler) updateCostEstimate(ctx context.Context, cd *kcm.ClusterDeployment, template *kcm.ClusterTemplate) {
+	if r.PriceSource == nil {
+		return
+	}
+
+	estimate, err := cost.EstimateCluster(ctx, r.PriceSource, template, cd.Spec.Config)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to estimate the cost of the cluster")
+		return
+	}
+
+	if estimate == nil {
+		cd.Status.CostEstimate = nil
+		return
+	}
+
+	cd.Status.CostEstimate = &kcm.CostEstimate{
+		MonthlyCost:           strconv.FormatFloat(estimate.MonthlyCost, 'f', 2, 64),
+		Currency:              estimate.Currency,
+		UnpricedInstanceTypes: estimate.UnpricedInstanceTypes,
+	}
+}
+
+// getCAPICluster returns the CAPI Cluster deployed by the given ClusterDeployment.
+func (r *ClusterDeploymentReconciler) getCAPICluster(ctx context.Context, cd *kcm.ClusterDeployment) (*unstructured.Unstructured, error) {
+	cluster := new(unstructured.Unstructured)
+	cluster.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "cluster.x-k8s.io",
+		Version: "v1beta1",
+		Kind:    "Cluster",
+	})
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(cd), cluster); err != nil {
+		return nil, fmt.Errorf("failed to get Cluster %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	return cluster, nil
+}
+
+// propagateMetadata applies the labels and annotations to propagate from the ClusterDeployment
+// to the CAPI Cluster, its Machines and the Nodes of the Machines in the cluster.
+func (r *ClusterDeploymentReconciler) propagateMetadata(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	cluster, err := r.getCAPICluster(ctx, cd)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if len(cd.Spec.PropagateLabels) == 0 && len(cd.Spec.PropagateAnnotations) == 0 && !propagation.Tracked(cluster) {
+		return nil
+	}
+
+	if err := patchPropagatedMetadata(ctx, r.Client, cluster, cd); err != nil {
+		return err
+	}
+
+	machines := new(unstructured.UnstructuredList)
+	machines.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "cluster.x-k8s.io",
+		Version: "v1beta1",
+		Kind:    "MachineList",
+	})
+	if err := r.Client.List(ctx, machines,
+		client.InNamespace(cd.Namespace),
+		client.MatchingLabels{kcm.ClusterNameLabelKey: cd.Name},
+	); err != nil {
+		return fmt.Errorf("failed to list Machines: %w", err)
+	}
+
+	var nodes []string
+	for i := range machines.Items {
+		if err := patchPropagatedMetadata(ctx, r.Client, &machines.Items[i], cd); err != nil {
+			return err
+		}
+		if node, _, _ := unstructured.NestedString(machines.Items[i].Object, "status", "nodeRef", "name"); node != "" {
+			nodes = append(nodes, node)
+		}
+	}
+
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	clusterClient, err := r.getClusterClient(ctx, cd)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range nodes {
+		node := new(corev1.Node)
+		if err := clusterClient.Get(ctx, client.ObjectKey{Name: name}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get Node %s: %w", name, err)
+		}
+		if err := patchPropagatedMetadata(ctx, clusterClient, node, cd); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// labelClusterProperties labels the CAPI Cluster deployed by the given ClusterDeployment with the properties
+// of the cluster derived by KCM, e.g. the infrastructure provider, so the MultiClusterServices could select
+// the clusters by them. The labels of the properties which are no longer known are removed.
+func (r *ClusterDeploymentReconciler) labelClusterProperties(ctx context.Context, cd *kcm.ClusterDeployment, template *kcm.ClusterTemplate) error {
+	cluster, err := r.getCAPICluster(ctx, cd)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	patch := client.MergeFrom(cluster.DeepCopy())
+	clusterLabels := cluster.GetLabels()
+	if clusterLabels == nil {
+		clusterLabels = make(map[string]string)
+	}
+
+	changed := false
+	for key, value := range fleet.PropertyLabels(cd, template.Status.Providers) {
+		current, ok := clusterLabels[key]
+		switch {
+		case value == "" && ok:
+			delete(clusterLabels, key)
+		case value != "" && current != value:
+			clusterLabels[key] = value
+		default:
+			continue
+		}
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	cluster.SetLabels(clusterLabels)
+	if err := r.Client.Patch(ctx, cluster, patch); err != nil {
+		return fmt.Errorf("failed to label Cluster %s with its properties: %w", client.ObjectKeyFromObject(cluster), err)
+	}
+
+	return nil
+}
+
+// patchPropagatedMetadata patches the object with the labels and annotations to propagate from the ClusterDeployment.
+func patchPropagatedMetadata(ctx context.Context, cl client.Client, obj client.Object, cd *kcm.ClusterDeployment) error {
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	if !propagation.Apply(obj, cd.Spec.PropagateLabels, cd.Spec.PropagateAnnotations) {
+		return nil
+	}
+
+	if err := cl.Patch(ctx, obj, patch); err != nil {
+		return fmt.Errorf("failed to patch %s %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, client.ObjectKeyFromObject(obj), err)
+	}
+
+	return nil
+}
+
+// getClusterClient returns the client of the cluster deployed by the given ClusterDeployment.
+// The nodes and the Jobs of the ready clusters are read from their caches, so their changes
+// requeue the ClusterDeployment instead of the cluster being polled.
+func (r *ClusterDeploymentReconciler) getClusterClient(ctx context.Context, cd *kcm.ClusterDeployment) (client.Client, error) {
+	kubeconfig, err := r.getKubeconfig(ctx, cd)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.clusterTracker != nil && cd.DeletionTimestamp.IsZero() &&
+		apimeta.IsStatusConditionTrue(cd.Status.Conditions, kcm.HelmReleaseReadyCondition) {
+		return r.clusterTracker.Client(ctx, client.ObjectKeyFromObject(cd), kubeconfig)
+	}
+
+	restCfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	return client.New(restCfg, client.Options{})
+}
+
+// getClusterConfig returns the REST config of the cluster deployed by the given ClusterDeployment.
+func (r *ClusterDeploymentReconciler) getClusterConfig(ctx context.Context, cd *kcm.ClusterDeployment) (*rest.Config, error) {
+	kubeconfig, err := r.getKubeconfig(ctx, cd)
+	if err != nil {
+		return nil, err
+	}
+
+	restCfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	return restCfg, nil
+}
+
+// getKubeconfig returns the kubeconfig of the cluster deployed by the given ClusterDeployment.
+func (r *ClusterDeploymentReconciler) getKubeconfig(ctx context.Context, cd *kcm.ClusterDeployment) ([]byte, error) {
+	if err := r.injectFailure(cd, faultinjection.PointKubeconfigFetch); err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig Secret: %w", err)
+	}
+
+	secret := new(corev1.Secret)
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: cd.Namespace, Name: cd.Name + kubeconfigSecretSuffix}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig Secret: %w", err)
+	}
+
+	return secret.Data[kubeconfigSecretKey], nil
+}
+
+// injectFailure returns the failure injected at the given point of the reconcile pipeline if requested
+// by the ClusterDeployment and records the corresponding event, otherwise returns nil.
+func (r *ClusterDeploymentReconciler) injectFailure(cd *kcm.ClusterDeployment, point faultinjection.Point) error {
+	err := faultinjection.Inject(cd, point)
+	if err != nil {
+		r.eventRecorder.Event(cd, corev1.EventTypeWarning, kcm.FailureInjectedReason, err.Error())
+	}
+	return err
+}
+
+// reconcileConfigDrift detects the changes of the values of the existing HelmRelease of the given ClusterDeployment
+// made outside of the ClusterDeployment and reflects them in the ConfigDrift condition.
+// Returns the existing HelmRelease and true if its update must be held as requested by the config drift policy.
+func (r *ClusterDeploymentReconciler) reconcileConfigDrift(ctx context.Context, cd *kcm.ClusterDeployment) (*hcv2.HelmRelease, bool, error) {
+	hr := new(hcv2.HelmRelease)
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(cd), hr); err != nil {
+		if apierrors.IsNotFound(err) {
+			apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.ConfigDriftCondition)
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get HelmRelease %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	diffs, err := configdrift.Detect(hr, cd.Spec.Config)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to detect config drift of HelmRelease %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+	if len(diffs) == 0 {
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.ConfigDriftCondition)
+		return hr, false, nil
+	}
+
+	// the drift is reported with the event once detected, the condition is kept while the drift exists
+	detected := !apimeta.IsStatusConditionTrue(cd.Status.Conditions, kcm.ConfigDriftCondition)
+	held := cd.Spec.ConfigDriftPolicy == kcm.ConfigDriftPolicyHold
+
+	condition := configdrift.ReconciledCondition(diffs)
+	if held {
+		condition = configdrift.HeldCondition(diffs)
+	}
+	condition.ObservedGeneration = cd.Generation
+	apimeta.SetStatusCondition(cd.GetConditions(), condition)
+	if detected {
+		r.eventRecorder.Event(cd, corev1.EventTypeWarning, condition.Reason, condition.Message)
+	}
+
+	return hr, held, nil
+}
+
+// reconcileReadinessGates evaluates the readiness gates of the given ClusterDeployment
+// reflecting the results in the status and the ReadinessGatesPassed condition.
+// Returns true if the gates are not configured or all of them have passed.
+func (r *ClusterDeploymentReconciler) reconcileReadinessGates(ctx context.Context, cd *kcm.ClusterDeployment) bool {
+	if cd.Spec.ReadinessGates == nil {
+		cd.Status.ReadinessGates = nil
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.ReadinessGatesPassedCondition)
+		return true
+	}
+
+	restCfg, err := r.getClusterConfig(ctx, cd)
+	var clusterClient client.Client
+	if err == nil {
+		clusterClient, err = r.getClusterClient(ctx, cd)
+	}
+	if err != nil {
+		cd.Status.ReadinessGates = nil
+		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+			Type:    kcm.ReadinessGatesPassedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  kcm.FailedReason,
+			Message: fmt.Sprintf("Failed to connect to the cluster: %v", err),
+		})
+		return false
+	}
+
+	statuses := readinessgates.Evaluate(ctx, cd, restCfg, clusterClient)
+	cd.Status.ReadinessGates = statuses
+	apimeta.SetStatusCondition(cd.GetConditions(), readinessgates.Condition(statuses))
+
+	return readinessgates.Passed(statuses)
+}
+
+// reconcileSveltosAgents reflects the versions and the health of the Sveltos agents of the cluster
+// in the status and the SveltosAgentsHealthy condition, upgrading the agents outdated after
+// the upgrade of the Sveltos components of the management cluster.
+// Returns the duration after which the agents should be rechecked, zero if they are not tracked.
+func (r *ClusterDeploymentReconciler) reconcileSveltosAgents(ctx context.Context, cd *kcm.ClusterDeployment) (requeueAfter time.Duration) {
+	reset := func() {
+		cd.Status.SveltosAgents = nil
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.SveltosAgentsHealthyCondition)
+	}
+	setFailed := func(err error) {
+		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+			Type:    kcm.SveltosAgentsHealthyCondition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  kcm.FailedReason,
+			Message: fmt.Sprintf("Failed to check the Sveltos agents: %v", err),
+		})
+	}
+
+	if r.SveltosDisabled {
+		reset()
+		return 0
+	}
+
+	expectedVersions, err := sveltos.ExpectedAgentVersions(ctx, r.Client)
+	if err != nil {
+		setFailed(err)
+		return r.defaultRequeueTime
+	}
+	if len(expectedVersions) == 0 {
+		reset()
+		return 0
+	}
+
+	clusterClient, err := r.getClusterClient(ctx, cd)
+	if err != nil {
+		setFailed(err)
+		return r.defaultRequeueTime
+	}
+
+	statuses, err := sveltos.ReconcileAgents(ctx, clusterClient, expectedVersions)
+	if err != nil {
+		setFailed(err)
+		return r.defaultRequeueTime
+	}
+
+	if len(statuses) == 0 {
+		reset()
+		return sveltosAgentsCheckInterval
+	}
+	cd.Status.SveltosAgents = statuses
+	condition := sveltos.AgentsCondition(statuses)
+	apimeta.SetStatusCondition(cd.GetConditions(), condition)
+
+	if condition.Status != metav1.ConditionTrue {
+		return r.defaultRequeueTime
+	}
+	return sveltosAgentsCheckInterval
+}
+
+// reconcileRegistryCredentials distributes the registry credentials configured in the Management to the cluster
+// and reflects the result in the RegistryCredentialsPropagated condition, removing the distributed credentials
+// once the configuration is removed from the Management.
+// Returns the duration after which the credentials should be redistributed, zero if they are not configured.
+func (r *ClusterDeploymentReconciler) reconcileRegistryCredentials(ctx context.Context, cd *kcm.ClusterDeployment) (requeueAfter time.Duration) {
+	mgmt := &kcm.Management{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: kcm.ManagementName}, mgmt); err != nil {
+		r.setCondition(cd, kcm.RegistryCredentialsPropagatedCondition, fmt.Errorf("failed to get Management: %w", err))
+		return r.defaultRequeueTime
+	}
+
+	config := mgmt.Spec.RegistryCredentials
+	if config == nil && apimeta.FindStatusCondition(cd.Status.Conditions, kcm.RegistryCredentialsPropagatedCondition) == nil {
+		return 0
+	}
+
+	clusterClient, err := r.getClusterClient(ctx, cd)
+	if err != nil {
+		r.setCondition(cd, kcm.RegistryCredentialsPropagatedCondition, err)
+		return r.defaultRequeueTime
+	}
+
+	if config == nil {
+		if err := registrycredentials.Remove(ctx, clusterClient); err != nil {
+			r.setCondition(cd, kcm.RegistryCredentialsPropagatedCondition, fmt.Errorf("failed to remove registry credentials: %w", err))
+			return r.defaultRequeueTime
+		}
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.RegistryCredentialsPropagatedCondition)
+		return 0
+	}
+
+	source, err := registrycredentials.Source(ctx, r.Client, config, r.SystemNamespace)
+	if err == nil {
+		err = registrycredentials.Reconcile(ctx, clusterClient, config, source)
+	}
+	r.setCondition(cd, kcm.RegistryCredentialsPropagatedCondition, err)
+	if err != nil {
+		return r.defaultRequeueTime
+	}
+	return registryCredentialsCheckInterval
+}
+
+// reconcileFleetAccess provisions the ServiceAccount the short-lived credentials of the fleet kubeconfig
+// are issued for once the cluster is deployed and reflects the result in the FleetAccessProvisioned condition.
+// Returns the duration after which the provisioning should be retried, zero if it has succeeded.
+func (r *ClusterDeploymentReconciler) reconcileFleetAccess(ctx context.Context, cd *kcm.ClusterDeployment) (requeueAfter time.Duration) {
+	if !apimeta.IsStatusConditionTrue(cd.Status.Conditions, kcm.HelmReleaseReadyCondition) ||
+		apimeta.IsStatusConditionTrue(cd.Status.Conditions, kcm.FleetAccessProvisionedCondition) {
+		return 0
+	}
+
+	clusterClient, err := r.getClusterClient(ctx, cd)
+	if err == nil {
+		err = fleetaccess.Reconcile(ctx, clusterClient)
+	}
+	r.setCondition(cd, kcm.FleetAccessProvisionedCondition, err)
+	if err != nil {
+		return r.defaultRequeueTime
+	}
+	return 0
+}
+
+// trustedCABundle is the trusted CA bundle of the Management distributed to a cluster.
+type trustedCABundle struct {
+	config *kcm.TrustedCA
+	bundle string
+	hash   string
+	// machines reports whether the template of the cluster adds the bundle to the trust store of the machines.
+	machines bool
+}
+
+// getTrustedCABundle returns the trusted CA bundle of the Management distributed to the cluster
+// of the given ClusterTemplate or nil if the bundle is not configured.
+func (r *ClusterDeploymentReconciler) getTrustedCABundle(ctx context.Context, clusterTpl *kcm.ClusterTemplate) (*trustedCABundle, error) {
+	mgmt := &kcm.Management{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: kcm.ManagementName}, mgmt); err != nil {
+		return nil, fmt.Errorf("failed to get Management: %w", err)
+	}
+	if mgmt.Spec.TrustedCA == nil {
+		return nil, nil
+	}
+
+	bundle, hash, err := trustedca.Bundle(ctx, r.Client, mgmt.Spec.TrustedCA, r.SystemNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	machines, err := trustedca.TemplateSupported(clusterTpl.Status.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &trustedCABundle{config: mgmt.Spec.TrustedCA, bundle: bundle, hash: hash, machines: machines}, nil
+}
+
+// reconcileTrustedCA publishes the trusted CA bundle of the Management in the cluster and rolls out the machines
+// of the cluster once the template is upgraded with the changed bundle, reflecting the result in the status
+// and the TrustedCAPropagated condition. The published bundle is removed once the bundle is removed from the Management.
+// Returns the duration after which the bundle should be redistributed, zero if it is not configured.
+func (r *ClusterDeploymentReconciler) reconcileTrustedCA(ctx context.Context, cd *kcm.ClusterDeployment, hr *hcv2.HelmRelease, trustedCA *trustedCABundle) (requeueAfter time.Duration) {
+	if trustedCA == nil && cd.Status.TrustedCA == nil {
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.TrustedCAPropagatedCondition)
+		return 0
+	}
+
+	clusterClient, err := r.getClusterClient(ctx, cd)
+	if err != nil {
+		r.setCondition(cd, kcm.TrustedCAPropagatedCondition, err)
+		return r.defaultRequeueTime
+	}
+
+	if trustedCA == nil {
+		if err := trustedca.Remove(ctx, clusterClient); err != nil {
+			r.setCondition(cd, kcm.TrustedCAPropagatedCondition, fmt.Errorf("failed to remove trusted CA bundle: %w", err))
+			return r.defaultRequeueTime
+		}
+		cd.Status.TrustedCA = nil
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.TrustedCAPropagatedCondition)
+		return 0
+	}
+
+	if err := trustedca.Publish(ctx, clusterClient, trustedCA.config, trustedCA.bundle, trustedCA.hash); err != nil {
+		r.setCondition(cd, kcm.TrustedCAPropagatedCondition, err)
+		return r.defaultRequeueTime
+	}
+
+	if cd.Status.TrustedCA == nil {
+		cd.Status.TrustedCA = new(kcm.TrustedCAStatus)
+	}
+	status := cd.Status.TrustedCA
+	status.Hash = trustedCA.hash
+
+	if !trustedCA.machines {
+		status.MachinesHash = ""
+		r.setCondition(cd, kcm.TrustedCAPropagatedCondition, nil)
+		return trustedCACheckInterval
+	}
+
+	if status.MachinesHash != trustedCA.hash {
+		if hr.Status.ObservedGeneration != hr.Generation || !fluxconditions.IsReady(hr) {
+			apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+				Type:    kcm.TrustedCAPropagatedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  kcm.TrustedCARolloutPendingReason,
+				Message: "Waiting for the HelmRelease to be upgraded with the trusted CA bundle",
+			})
+			return r.defaultRequeueTime
+		}
+
+		// the machines bootstrapped before the bundle was enabled are not replaced
+		if status.MachinesHash != "" && trustedCA.config.RolloutMachines {
+			now := metav1.Now()
+			if err := machinerollout.Rollout(ctx, r.Client, cd, now); err != nil {
+				r.setCondition(cd, kcm.TrustedCAPropagatedCondition, fmt.Errorf("failed to roll out machines: %w", err))
+				return r.defaultRequeueTime
+			}
+			status.LastRolloutTime = &now
+			ctrl.LoggerFrom(ctx).Info("Rolled out machines to trust the changed CA bundle")
+		}
+		status.MachinesHash = trustedCA.hash
+	}
+
+	r.setCondition(cd, kcm.TrustedCAPropagatedCondition, nil)
+	return trustedCACheckInterval
+}
+
+// reconcileMachineAccess tracks the SSH keys the machines of the cluster are bootstrapped with and rolls out
+// the machines once the template is upgraded with the changed keys, reflecting the convergence of the machines
+// on the current keys in the MachineAccessConverged condition. The keys are tracked for the templates supporting
+// them even if none are configured, so the machines are rolled out once the keys are added or revoked.
+// Returns the duration after which the keys should be rechecked, zero if they are not to be rechecked.
+func (r *ClusterDeploymentReconciler) reconcileMachineAccess(ctx context.Context, cd *kcm.ClusterDeployment, hr *hcv2.HelmRelease, hash string, supported bool) (requeueAfter time.Duration) {
+	if !supported {
+		cd.Status.MachineAccess = nil
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.MachineAccessConvergedCondition)
+		return 0
+	}
+
+	setRollingOut := func(message string) {
+		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+			Type:    kcm.MachineAccessConvergedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  kcm.MachinesRollingOutReason,
+			Message: message,
+		})
+	}
+
+	if hr.Status.ObservedGeneration != hr.Generation || !fluxconditions.IsReady(hr) {
+		if cd.Status.MachineAccess != nil && cd.Status.MachineAccess.AuthorizedKeysHash != hash {
+			setRollingOut("Waiting for the HelmRelease to be upgraded with the changed SSH keys")
+			return r.defaultRequeueTime
+		}
+		// the machines of the new clusters are bootstrapped with the keys once the HelmRelease is ready
+		return 0
+	}
+
+	if cd.Status.MachineAccess == nil {
+		// the machines bootstrapped before the keys were tracked are not rolled out
+		cd.Status.MachineAccess = &kcm.MachineAccessStatus{AuthorizedKeysHash: hash}
+	}
+	status := cd.Status.MachineAccess
+
+	if status.AuthorizedKeysHash != hash {
+		now := metav1.Now()
+		if err := machinerollout.Rollout(ctx, r.Client, cd, now); err != nil {
+			r.setCondition(cd, kcm.MachineAccessConvergedCondition, fmt.Errorf("failed to roll out machines: %w", err))
+			return r.defaultRequeueTime
+		}
+		ctrl.LoggerFrom(ctx).Info("Rolled out machines to apply the changed SSH keys")
+		status.AuthorizedKeysHash = hash
+		status.LastRolloutTime = &now
+		setRollingOut("Waiting for the machines to be replaced")
+	}
+
+	if condition := apimeta.FindStatusCondition(cd.Status.Conditions, kcm.MachineAccessConvergedCondition); condition != nil && condition.Reason == kcm.MachinesRollingOutReason {
+		completed, message, err := machinerollout.Completed(ctx, r.Client, cd)
+		if err != nil {
+			r.setCondition(cd, kcm.MachineAccessConvergedCondition, err)
+			return r.defaultRequeueTime
+		}
+		if !completed {
+			setRollingOut(message)
+			return r.defaultRequeueTime
+		}
+	}
+
+	if cd.Spec.MachineAccess == nil {
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.MachineAccessConvergedCondition)
+		return 0
+	}
+
+	r.setCondition(cd, kcm.MachineAccessConvergedCondition, nil)
+	if cd.Spec.MachineAccess.AuthorizedKeysConfigMap != "" {
+		return machineAccessCheckInterval
+	}
+	return 0
+}
+
+// reconcileCertificates tracks the earliest expiry of the certificates of the cluster machines
+// and triggers the rotation of the control plane certificates if requested with the annotation.
+// The rotation requested for an unsupported control plane is dropped and reported in an event.
+// Returns whether the certificates expiry is tracked and should be periodically rechecked.
+func (r *ClusterDeploymentReconciler) reconcileCertificates(ctx context.Context, cd *kcm.ClusterDeployment) (tracked bool, _ error) {
+	var unsupportedErr error
+	if _, ok := cd.Annotations[kcm.RotateCertificatesAnnotation]; ok {
+		err := r.rotateCertificates(ctx, cd)
+		switch {
+		case errors.Is(err, errCertificatesRotationUnsupported):
+			unsupportedErr = err
+			r.eventRecorder.Event(cd, corev1.EventTypeWarning, kcm.CertificatesRotationUnsupportedReason, err.Error())
+		case err != nil:
+			err = fmt.Errorf("failed to trigger certificates rotation: %w", err)
+			r.setCondition(cd, kcm.CertificatesValidCondition, err)
+			return false, err
+		}
+	}
+
+	tracked, err := r.trackCertificatesExpiry(ctx, cd)
+	if err != nil {
+		return false, err
+	}
+
+	if unsupportedErr != nil {
+		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+			Type:    kcm.CertificatesValidCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  kcm.CertificatesRotationUnsupportedReason,
+			Message: fmt.Sprintf("The %s annotation has been dropped: %s", kcm.RotateCertificatesAnnotation, unsupportedErr),
+		})
+	}
+
+	return tracked, nil
+}
+
+// trackCertificatesExpiry reports the earliest expiry of the certificates of the cluster machines
+// in the status and the CertificatesValid condition of the given ClusterDeployment.
+// Returns whether the certificates expiry is tracked and should be periodically rechecked.
+func (r *ClusterDeploymentReconciler) trackCertificatesExpiry(ctx context.Context, cd *kcm.ClusterDeployment) (tracked bool, _ error) {
+	expireAt, machines, err := r.getCertificatesExpiry(ctx, cd)
+	if err != nil {
+		return false, err
+	}
+
+	cd.Status.CertificatesExpireAt = expireAt
+	if expireAt == nil && machines == 0 {
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.CertificatesValidCondition)
+		return false, nil
+	}
+	if expireAt == nil {
+		// e.g. the k0s and k0smotron machines, the condition is informational and does not affect the readiness
+		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+			Type:    kcm.CertificatesValidCondition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  kcm.CertificatesExpiryUnknownReason,
+			Message: "The expiry of the certificates is not reported by the machines of the cluster",
+		})
+		return false, nil
+	}
+
+	condition := metav1.Condition{
+		Type:    kcm.CertificatesValidCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  kcm.SucceededReason,
+		Message: fmt.Sprintf("Certificates expire at %s", expireAt.UTC().Format(time.RFC3339)),
+	}
+	switch untilExpiry := time.Until(expireAt.Time); {
+	case untilExpiry <= 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kcm.FailedReason
+		condition.Message = fmt.Sprintf("Certificates expired at %s", expireAt.UTC().Format(time.RFC3339))
+	case untilExpiry <= certificatesExpiryWarningPeriod:
+		condition.Reason = kcm.CertificatesExpiringReason
+		condition.Message += fmt.Sprintf(", set the %s annotation to rotate them", kcm.RotateCertificatesAnnotation)
+	}
+	apimeta.SetStatusCondition(cd.GetConditions(), condition)
+
+	return true, nil
+}
+
+// reconcileExpiration handles the expiration of the cluster set with the TTL or ExpireAt.
+// The expired cluster is either deleted or hibernated according to the expiration policy,
+// the warning events are emitted within the warning period before the expiration.
+// Returns the duration after which the expiration should be rechecked and whether the cluster has been deleted.
+func (r *ClusterDeploymentReconciler) reconcileExpiration(ctx context.Context, cd *kcm.ClusterDeployment) (requeueAfter time.Duration, deleted bool, _ error) {
+	expireAt, err := cd.Expiration()
+	if err != nil {
+		r.setCondition(cd, kcm.LifetimeValidCondition, err)
+		return 0, false, nil
+	}
+
+	cd.Status.ExpiresAt = expireAt
+	if expireAt == nil {
+		apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.LifetimeValidCondition)
+		return 0, false, nil
+	}
+
+	action, warningPeriod := kcm.ExpirationActionDelete, defaultExpirationWarningPeriod
+	if policy := cd.Spec.ExpirationPolicy; policy != nil {
+		if policy.Action != "" {
+			action = policy.Action
+		}
+		if policy.WarningPeriod != nil {
+			warningPeriod = policy.WarningPeriod.Duration
+		}
+	}
+
+	previous := apimeta.FindStatusCondition(cd.Status.Conditions, kcm.LifetimeValidCondition)
+	changed := func(reason string) bool { return previous == nil || previous.Reason != reason }
+
+	expireAtStr := expireAt.UTC().Format(time.RFC3339)
+	condition := metav1.Condition{
+		Type:    kcm.LifetimeValidCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  kcm.SucceededReason,
+		Message: "Cluster expires at " + expireAtStr,
+	}
+
+	switch untilExpiry := time.Until(expireAt.Time); {
+	case untilExpiry <= 0 && action == kcm.ExpirationActionHibernate:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kcm.HibernatedReason
+		condition.Message = fmt.Sprintf("Cluster expired at %s and has been hibernated, set the %s annotation to extend the expiration",
+			expireAtStr, kcm.ExpirationExtensionAnnotation)
+		if changed(kcm.HibernatedReason) {
+			r.eventRecorder.Event(cd, corev1.EventTypeWarning, kcm.HibernatedReason, condition.Message)
+		}
+	case untilExpiry <= 0:
+		ctrl.LoggerFrom(ctx).Info("ClusterDeployment has expired, deleting", "expireAt", expireAtStr)
+		r.eventRecorder.Event(cd, corev1.EventTypeWarning, kcm.ExpiredReason, fmt.Sprintf("Cluster expired at %s and is being deleted", expireAtStr))
+		if err := r.Client.Delete(ctx, cd); client.IgnoreNotFound(err) != nil {
+			return 0, false, fmt.Errorf("failed to delete expired ClusterDeployment %s: %w", client.ObjectKeyFromObject(cd), err)
+		}
+		return 0, true, nil
+	case untilExpiry <= warningPeriod:
+		condition.Reason = kcm.ExpirationApproachingReason
+		condition.Message += fmt.Sprintf(", set the %s annotation to extend the expiration", kcm.ExpirationExtensionAnnotation)
+		if changed(kcm.ExpirationApproachingReason) {
+			r.eventRecorder.Event(cd, corev1.EventTypeWarning, kcm.ExpirationApproachingReason, condition.Message)
+		}
+		requeueAfter = untilExpiry
+	default:
+		requeueAfter = untilExpiry - warningPeriod
+	}
+	apimeta.SetStatusCondition(cd.GetConditions(), condition)
+
+	return requeueAfter, false, nil
+}
+
+// isHibernated returns whether the cluster has expired and has been hibernated.
+func isHibernated(cd *kcm.ClusterDeployment) bool {
+	condition := apimeta.FindStatusCondition(cd.Status.Conditions, kcm.LifetimeValidCondition)
+	return condition != nil && condition.Reason == kcm.HibernatedReason
+}
+
+// getCertificatesExpiry returns the earliest expiry time of the certificates reported by the CAPI Machines
+// of the cluster or nil if none of the Machines report it along with the number of the Machines.
+// Only the kubeadm bootstrap provider reports the expiry, the kubelet certificates are never reported.
+func (r *ClusterDeploymentReconciler) getCertificatesExpiry(ctx context.Context, cd *kcm.ClusterDeployment) (*metav1.Time, int, error) {
+	machines := new(unstructured.UnstructuredList)
+	machines.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "cluster.x-k8s.io",
+		Version: "v1beta1",
+		Kind:    "MachineList",
+	})
+	if err := r.Client.List(ctx, machines,
+		client.InNamespace(cd.Namespace),
+		client.MatchingLabels{kcm.ClusterNameLabelKey: cd.Name},
+	); err != nil {
+		return nil, 0, fmt.Errorf("failed to list Machines: %w", err)
+	}
+
+	var expireAt *metav1.Time
+	for _, machine := range machines.Items {
+		v, found, err := unstructured.NestedString(machine.Object, "status", "certificatesExpiryDate")
+		if err != nil || !found || v == "" {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to parse certificates expiry date of Machine %s/%s: %w", machine.GetNamespace(), machine.GetName(), err)
+		}
+
+		if expireAt == nil || t.Before(expireAt.Time) {
+			expireAt = &metav1.Time{Time: t}
+		}
+	}
+
+	return expireAt, len(machines.Items), nil
+}
+
+// rotateCertificates triggers the rotation of the control plane certificates
+// by rolling out the control plane of the cluster and removes the rotation annotation.
+// Only KubeadmControlPlane is supported, the annotation is removed with the
+// [errCertificatesRotationUnsupported] error returned for the other control planes.
+func (r *ClusterDeploymentReconciler) rotateCertificates(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	cluster, err := r.getCAPICluster(ctx, cd)
+	if err != nil {
+		return err
+	}
+
+	ref, found, err := unstructured.NestedStringMap(cluster.Object, "spec", "controlPlaneRef")
+	if err != nil || !found {
+		return fmt.Errorf("control plane reference is not set in Cluster %s/%s", cluster.GetNamespace(), cluster.GetName())
+	}
+	if ref["kind"] != kubeadmControlPlaneKind {
+		if err := r.removeRotateCertificatesAnnotation(ctx, cd); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w for the %s control plane, only %s is supported", errCertificatesRotationUnsupported, ref["kind"], kubeadmControlPlaneKind)
+	}
+
+	gv, err := schema.ParseGroupVersion(ref["apiVersion"])
+	if err != nil {
+		return fmt.Errorf("failed to parse control plane apiVersion: %w", err)
+	}
+
+	controlPlane := new(unstructured.Unstructured)
+	controlPlane.SetGroupVersionKind(gv.WithKind(ref["kind"]))
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: cluster.GetNamespace(), Name: ref["name"]}, controlPlane); err != nil {
+		return fmt.Errorf("failed to get %s %s/%s: %w", ref["kind"], cluster.GetNamespace(), ref["name"], err)
+	}
+
+	patch := client.MergeFrom(controlPlane.DeepCopy())
+	if err := unstructured.SetNestedField(controlPlane.Object, metav1.Now().UTC().Format(time.RFC3339), "spec", "rolloutAfter"); err != nil {
+		return err
+	}
+	if err := r.Client.Patch(ctx, controlPlane, patch); err != nil {
+		return fmt.Errorf("failed to patch %s %s/%s: %w", ref["kind"], controlPlane.GetNamespace(), controlPlane.GetName(), err)
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Triggered control plane certificates rotation", "controlPlane", client.ObjectKeyFromObject(controlPlane))
+
+	return r.removeRotateCertificatesAnnotation(ctx, cd)
+}
+
+// removeRotateCertificatesAnnotation removes the [kcm.RotateCertificatesAnnotation] annotation from the given ClusterDeployment.
+func (r *ClusterDeploymentReconciler) removeRotateCertificatesAnnotation(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	// patch a copy not to override the status being reconciled
+	cdCopy := cd.DeepCopy()
+	cdPatch := client.MergeFrom(cdCopy.DeepCopy())
+	delete(cdCopy.Annotations, kcm.RotateCertificatesAnnotation)
+	if err := r.Client.Patch(ctx, cdCopy, cdPatch); err != nil {
+		return fmt.Errorf("failed to remove %s annotation: %w", kcm.RotateCertificatesAnnotation, err)
+	}
+	cd.Annotations = cdCopy.Annotations
+	cd.ResourceVersion = cdCopy.ResourceVersion
+
+	return nil
+}
+
+// getControlPlaneVIPsInUse returns the set of control plane VIPs used by
+// all of the ClusterDeployments except the given one.
+func (r *ClusterDeploymentReconciler) getControlPlaneVIPsInUse(ctx context.Context, cd *kcm.ClusterDeployment) (map[netip.Addr]struct{}, error) {
+	clusterDeployments := new(kcm.ClusterDeploymentList)
+	if err := r.Client.List(ctx, clusterDeployments); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterDeployments: %w", err)
+	}
+
+	inUse := make(map[netip.Addr]struct{})
+	for _, other := range clusterDeployments.Items {
+		if other.UID == cd.UID {
+			continue
+		}
+		for _, v := range kcm.ExtractControlPlaneVIPsFromClusterDeployment(&other) {
+			if addr, err := netip.ParseAddr(v); err == nil {
+				inUse[addr] = struct{}{}
+			}
+		}
+	}
+
+	return inUse, nil
+}
+
+func (r *ClusterDeploymentReconciler) aggregateCapoConditions(ctx context.Context, clusterDeployment *kcm.ClusterDeployment) (requeue bool, _ error) {
+	type objectToCheck struct {
+		gvr        schema.GroupVersionResource
+		conditions []string
+	}
+
+	var errs error
+	if !r.SveltosDisabled {
+		needRequeue, err := r.updateSveltosClusterCondition(ctx, clusterDeployment)
+		if needRequeue {
+			requeue = true
+		}
+		errs = errors.Join(errs, err)
+	}
+
+	for _, obj := range []objectToCheck{
+		{
+			gvr: schema.GroupVersionResource{
+				Group:    "cluster.x-k8s.io",
+				Version:  "v1beta1",
+				Resource: "clusters",
+			},
+			conditions: []string{"ControlPlaneInitialized", "ControlPlaneReady", "InfrastructureReady"},
+		},
+		{
+			gvr: schema.GroupVersionResource{
+				Group:    "cluster.x-k8s.io",
+				Version:  "v1beta1",
+				Resource: "machinedeployments",
+			},
+			conditions: []string{"Available"},
+		},
+	} {
+		needRequeue, err := r.setStatusFromChildObjects(ctx, clusterDeployment, obj.gvr, obj.conditions)
+		errs = errors.Join(errs, err)
+		if needRequeue {
+			requeue = true
+		}
+	}
+
+	return requeue, errs
+}
+
+func (*ClusterDeploymentReconciler) initServicesConditions(cd *kcm.ClusterDeployment) (changed bool) {
+	for _, typ := range [3]string{kcm.SveltosProfileReadyCondition, kcm.FetchServicesStatusSuccessCondition, kcm.ServicesReferencesValidationCondition} {
+		if apimeta.SetStatusCondition(&cd.Status.Conditions, metav1.Condition{
+			Type:               typ,
+			Status:             metav1.ConditionUnknown,
+			Reason:             kcm.ProgressingReason,
+			ObservedGeneration: cd.Generation,
+		}) {
+			changed = true
+		}
+	}
+
+	return changed
+}
 
 func (*ClusterDeploymentReconciler) setCondition(cd *kcm.ClusterDeployment, typ string, err error) (changed bool) { //nolint:unparam // readability
 	reason, cstatus, msg := kcm.SucceededReason, metav1.ConditionTrue, ""
@@ -541,10 +2311,46 @@ func (r *ClusterDeploymentReconciler) updateServices(ctx context.Context, cd *kc
 
 	r.initServicesConditions(cd)
 
+	userServices, bundleErr := servicebundle.Services(ctx, r.Client, cd.Namespace, cd.Spec.ServiceSpec)
+	var renderErr error
+	if cd.Spec.RenderValues {
+		userServices, renderErr = valuestemplate.RenderServices(userServices, valuestemplate.NewContext(cd))
+	}
+
+	// the CNI goes first, the nodes of the cluster are not ready to run the other services until the CNI is installed
+	services := append(cni.Services(cd.Spec.Network), userServices...)
+	if cd.Spec.GPU != nil {
+		services = append(slices.Clone(services), gpu.Service(cd.Spec.GPU))
+	}
+
+	observabilityConfig, err := r.observabilityConfig(ctx, cd)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if observabilityConfig == nil {
+		if err := observability.Delete(ctx, r.Client, cd); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else {
+		svc, err := observability.Reconcile(ctx, r.Client, cd, observabilityConfig)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		services = append(slices.Clone(services), svc)
+	}
+
+	if cd.Spec.Proxy == nil {
+		if err := proxy.Delete(ctx, r.Client, cd); err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if services, err = proxy.Reconcile(ctx, r.Client, cd, services); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	{
 		nsErr := validation.ClusterDeployCrossNamespaceServicesRefs(ctx, cd)
-		tplErr := validation.ServicesHaveValidTemplates(ctx, r.Client, cd.Spec.ServiceSpec.Services, cd.Namespace)
-		merr := errors.Join(nsErr, tplErr)
+		tplErr := validation.ServicesHaveValidTemplates(ctx, r.Client, services, cd.Namespace)
+		merr := errors.Join(nsErr, bundleErr, tplErr, renderErr)
 		r.setCondition(cd, kcm.ServicesReferencesValidationCondition, merr)
 		if merr != nil {
 			l.Error(merr, "failed to validate services, will not retrigger this error")
@@ -552,6 +2358,24 @@ func (r *ClusterDeploymentReconciler) updateServices(ctx context.Context, cd *kc
 		}
 	}
 
+	conflicts, err := serviceconflicts.ForCluster(ctx, r.Client, cd, r.SystemNamespace)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to detect services conflicts: %w", err)
+	}
+	owner, clusterConflicts := serviceconflicts.ClusterDeploymentOwner(cd), map[string][]serviceconflicts.Conflict{"": conflicts}
+	serviceconflicts.SetCondition(cd.GetConditions(), owner, clusterConflicts)
+	cd.Status.ServiceConflicts = serviceconflicts.Status(owner, clusterConflicts)
+
+	findings, err := serviceadvisories.ForServices(ctx, r.Client, cd.Namespace, services, time.Now())
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to collect services advisories: %w", err)
+	}
+	serviceadvisories.SetCondition(cd.GetConditions(), findings)
+	metrics.DeleteMetricClusterServiceAdvisories(cd.Namespace, cd.Name)
+	for _, f := range findings {
+		metrics.TrackMetricClusterServiceAdvisories(ctx, cd.ObjectMeta, f.Service, f.Template, f.EndOfLife != nil, len(f.Vulnerabilities))
+	}
+
 	// servicesErr is handled separately from err because we do not want
 	// to set the condition of SveltosProfileReady type to "False"
 	// if there is an error while retrieving status for the services.
@@ -564,17 +2388,68 @@ func (r *ClusterDeploymentReconciler) updateServices(ctx context.Context, cd *kc
 		err = errors.Join(err, servicesErr)
 	}()
 
-	helmCharts, err := sveltos.GetHelmCharts(ctx, r.Client, cd.Namespace, cd.Spec.ServiceSpec.Services)
+	engine, err := statemanagement.Engine(ctx, r.Client, cd)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	kustomizationRefs, err := sveltos.GetKustomizationRefs(ctx, r.Client, cd.Namespace, cd.Spec.ServiceSpec.Services)
-	if err != nil {
-		return ctrl.Result{}, err
+
+	// the services delivered by the engines no longer in use are removed
+	providers := statemanagement.Providers(r.Client, r.getClusterClient)
+	for _, name := range slices.Sorted(maps.Keys(providers)) {
+		if name == engine {
+			continue
+		}
+		if err := providers[name].DeleteServices(ctx, cd); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete services delivered by the %s engine: %w", name, err)
+		}
 	}
-	policyRefs, err := sveltos.GetPolicyRefs(ctx, r.Client, cd.Namespace, cd.Spec.ServiceSpec.Services)
-	if err != nil {
-		return ctrl.Result{}, err
+
+	var (
+		helmCharts        []sveltosv1beta1.HelmChart
+		kustomizationRefs []sveltosv1beta1.KustomizationRef
+		policyRefs        []sveltosv1beta1.PolicyRef
+	)
+	provider := providers[engine]
+	if provider != nil {
+		// the services are delivered by another engine, the Profile carries the policies only
+		if err := provider.ReconcileServices(ctx, cd, services); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile services with the %s engine: %w", engine, err)
+		}
+	} else {
+		helmCharts, err = sveltos.GetHelmCharts(ctx, r.Client, cd.Namespace, services)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		kustomizationRefs, err = sveltos.GetKustomizationRefs(ctx, r.Client, cd.Namespace, services)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		policyRefs, err = sveltos.GetPolicyRefs(ctx, r.Client, cd.Namespace, services)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	rbacConfigMapName := cd.Name + clusterRBACConfigMapSuffix
+	if len(cd.Spec.ClusterRoleBindings) == 0 {
+		if err := sveltos.DeleteClusterRoleBindings(ctx, r.Client, cd.Namespace, rbacConfigMapName); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete ClusterRoleBindings ConfigMap: %w", err)
+		}
+	} else {
+		rbacPolicyRef, err := sveltos.ReconcileClusterRoleBindings(ctx, r.Client, cd.Namespace, rbacConfigMapName,
+			sveltos.ReconcileClusterRoleBindingsOpts{
+				OwnerReference: &metav1.OwnerReference{
+					APIVersion: kcm.GroupVersion.String(),
+					Kind:       kcm.ClusterDeploymentKind,
+					Name:       cd.Name,
+					UID:        cd.UID,
+				},
+				ClusterRoleBindings: cd.Spec.ClusterRoleBindings,
+			})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile ClusterRoleBindings ConfigMap: %w", err)
+		}
+		policyRefs = append(policyRefs, *rbacPolicyRef)
 	}
 
 	cred := new(kcm.Credential)
@@ -582,7 +2457,25 @@ func (r *ClusterDeploymentReconciler) updateServices(ctx context.Context, cd *kc
 		return ctrl.Result{}, err
 	}
 
-	if _, err := sveltos.ReconcileProfile(ctx, r.Client, cd.Namespace, cd.Name,
+	credTemplateResourceRefs, credPolicyRefs, err := credspropagation.Refs(ctx, r.Client, cd, cred)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	templateResourceRefs := append(credTemplateResourceRefs, cd.Spec.ServiceSpec.TemplateResourceRefs...)
+	policyRefs = append(credPolicyRefs, policyRefs...)
+
+	// the Profile is not required if another engine delivers the services and there are no policies,
+	// so the services are delivered without Sveltos installed
+	profileRequired := provider == nil || len(policyRefs) > 0 || len(templateResourceRefs) > 0
+	if profileRequired && r.SveltosDisabled {
+		return ctrl.Result{}, errors.New("the services, policies and template resources of the cluster require Sveltos which is not installed, set another service delivery engine and remove the policies")
+	}
+	if !profileRequired {
+		if err := sveltos.DeleteProfile(ctx, r.Client, cd.Namespace, cd.Name); err != nil && !apimeta.IsNoMatchError(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to delete Profile: %w", err)
+		}
+	} else if _, err := sveltos.ReconcileProfile(ctx, r.Client, cd.Namespace, cd.Name,
 		sveltos.ReconcileProfileOpts{
 			OwnerReference: &metav1.OwnerReference{
 				APIVersion: kcm.GroupVersion.String(),
@@ -596,26 +2489,24 @@ func (r *ClusterDeploymentReconciler) updateServices(ctx context.Context, cd *kc
 					kcm.FluxHelmChartNameKey:      cd.Name,
 				},
 			},
-			HelmCharts:        helmCharts,
-			KustomizationRefs: kustomizationRefs,
-			Priority:          cd.Spec.ServiceSpec.Priority,
-			StopOnConflict:    cd.Spec.ServiceSpec.StopOnConflict,
-			Reload:            cd.Spec.ServiceSpec.Reload,
-			TemplateResourceRefs: append(
-				getProjectTemplateResourceRefs(cd, cred), cd.Spec.ServiceSpec.TemplateResourceRefs...,
-			),
-			PolicyRefs:      append(getProjectPolicyRefs(cd, cred), policyRefs...),
-			SyncMode:        cd.Spec.ServiceSpec.SyncMode,
-			DriftIgnore:     cd.Spec.ServiceSpec.DriftIgnore,
-			DriftExclusions: cd.Spec.ServiceSpec.DriftExclusions,
-			ContinueOnError: cd.Spec.ServiceSpec.ContinueOnError,
+			HelmCharts:           helmCharts,
+			KustomizationRefs:    kustomizationRefs,
+			Priority:             cd.Spec.ServiceSpec.Priority,
+			StopOnConflict:       cd.Spec.ServiceSpec.StopOnConflict,
+			Reload:               cd.Spec.ServiceSpec.Reload,
+			TemplateResourceRefs: templateResourceRefs,
+			PolicyRefs:           policyRefs,
+			SyncMode:             cd.Spec.ServiceSpec.SyncMode,
+			DriftIgnore:          cd.Spec.ServiceSpec.DriftIgnore,
+			DriftExclusions:      cd.Spec.ServiceSpec.DriftExclusions,
+			ContinueOnError:      cd.Spec.ServiceSpec.ContinueOnError,
 		}); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to reconcile Profile: %w", err)
 	}
 
 	metrics.TrackMetricTemplateUsage(ctx, kcm.ClusterTemplateKind, cd.Spec.Template, kcm.ClusterDeploymentKind, cd.ObjectMeta, true)
 
-	for _, svc := range cd.Spec.ServiceSpec.Services {
+	for _, svc := range userServices {
 		metrics.TrackMetricTemplateUsage(ctx, kcm.ServiceTemplateKind, svc.Template, kcm.ClusterDeploymentKind, cd.ObjectMeta, true)
 	}
 
@@ -624,16 +2515,28 @@ func (r *ClusterDeploymentReconciler) updateServices(ctx context.Context, cd *kc
 	// because we don't want the error content in servicesErr to be assigned to err.
 	// The servicesErr var is joined with err in the defer func() so this function
 	// will ultimately return the error in servicesErr instead of nil.
-	profile := sveltosv1beta1.Profile{}
-	profileRef := client.ObjectKey{Name: cd.Name, Namespace: cd.Namespace}
-	if servicesErr = r.Client.Get(ctx, profileRef, &profile); servicesErr != nil {
-		servicesErr = fmt.Errorf("failed to get Profile %s to fetch status from its associated ClusterSummary: %w", profileRef.String(), servicesErr)
-		return ctrl.Result{}, nil
-	}
+	switch {
+	case len(services) == 0:
+		cd.Status.Services = nil
+	case provider != nil:
+		var conditions []metav1.Condition
+		conditions, servicesErr = provider.ServicesConditions(ctx, cd)
+		if servicesErr != nil {
+			return ctrl.Result{}, nil
+		}
+		cd.Status.Services = []kcm.ServiceStatus{{
+			ClusterName:      cd.Name,
+			ClusterNamespace: cd.Namespace,
+			Conditions:       conditions,
+		}}
+	default:
+		profile := sveltosv1beta1.Profile{}
+		profileRef := client.ObjectKey{Name: cd.Name, Namespace: cd.Namespace}
+		if servicesErr = r.Client.Get(ctx, profileRef, &profile); servicesErr != nil {
+			servicesErr = fmt.Errorf("failed to get Profile %s to fetch status from its associated ClusterSummary: %w", profileRef.String(), servicesErr)
+			return ctrl.Result{}, nil
+		}
 
-	if len(cd.Spec.ServiceSpec.Services) == 0 {
-		cd.Status.Services = nil
-	} else {
 		var servicesStatus []kcm.ServiceStatus
 		servicesStatus, servicesErr = updateServicesStatus(ctx, r.Client, profileRef, profile.Status.MatchingClusterRefs, cd.Status.Services)
 		if servicesErr != nil {
@@ -642,17 +2545,49 @@ func (r *ClusterDeploymentReconciler) updateServices(ctx context.Context, cd *kc
 		cd.Status.Services = servicesStatus
 		l.Info("Successfully updated status of services")
 	}
+	cd.Status.ServiceHistory = servicehistory.Record(cd.Status.ServiceHistory, cd.Spec.ServiceSpec.Services, cd.Status.Services, time.Now())
 
 	return ctrl.Result{}, nil
 }
 
+// observabilityConfig returns the configuration of the observability agents of the cluster
+// merged from the Management and the ClusterDeployment, nil if the agents are not enabled.
+func (r *ClusterDeploymentReconciler) observabilityConfig(ctx context.Context, cd *kcm.ClusterDeployment) (*observability.Config, error) {
+	mgmt := &kcm.Management{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: kcm.ManagementName}, mgmt); err != nil {
+		return nil, fmt.Errorf("failed to get Management: %w", err)
+	}
+
+	return observability.EffectiveConfig(mgmt.Spec.Observability, r.SystemNamespace, cd), nil
+}
+
 // updateStatus updates the status for the ClusterDeployment object.
 func (r *ClusterDeploymentReconciler) updateStatus(ctx context.Context, cd *kcm.ClusterDeployment, template *kcm.ClusterTemplate) error {
-	apimeta.SetStatusCondition(cd.GetConditions(), getServicesReadinessCondition(cd.Status.Services, len(cd.Spec.ServiceSpec.Services)))
+	// the missing ServiceBundles are reported with the ServicesReferencesValidation condition
+	services, _ := servicebundle.Services(ctx, r.Client, cd.Namespace, cd.Spec.ServiceSpec)
+	desiredServices := len(services)
+	if cd.Spec.GPU != nil {
+		desiredServices++
+	}
+	observabilityConfig, err := r.observabilityConfig(ctx, cd)
+	if err != nil {
+		return err
+	}
+	if observabilityConfig != nil {
+		desiredServices++
+	}
+	apimeta.SetStatusCondition(cd.GetConditions(), getServicesReadinessCondition(cd.Status.Services, desiredServices))
 
 	cd.Status.ObservedGeneration = cd.Generation
 	cd.Status.Conditions = updateStatusConditions(cd.Status.Conditions)
 
+	// the dry-run ClusterDeployments are never ready and must not trigger the alerts
+	if cd.Spec.DryRun {
+		metrics.DeleteMetricClusterDeploymentReady(cd.Namespace, cd.Name)
+	} else {
+		metrics.TrackMetricClusterDeploymentReady(ctx, cd.ObjectMeta, cd.Spec.Template, apimeta.IsStatusConditionTrue(cd.Status.Conditions, kcm.ReadyCondition))
+	}
+
 	if err := r.setAvailableUpgrades(ctx, cd, template); err != nil {
 		return errors.New("failed to set available upgrades")
 	}
@@ -683,12 +2618,38 @@ func (r *ClusterDeploymentReconciler) Delete(ctx context.Context, cd *kcm.Cluste
 		if err == nil {
 			metrics.TrackMetricTemplateUsage(ctx, kcm.ClusterTemplateKind, cd.Spec.Template, kcm.ClusterDeploymentKind, cd.ObjectMeta, false)
 
-			for _, svc := range cd.Spec.ServiceSpec.Services {
+			services, _ := servicebundle.Services(ctx, r.Client, cd.Namespace, cd.Spec.ServiceSpec)
+			for _, svc := range services {
 				metrics.TrackMetricTemplateUsage(ctx, kcm.ServiceTemplateKind, svc.Template, kcm.ClusterDeploymentKind, cd.ObjectMeta, false)
 			}
+
+			metrics.DeleteMetricClusterDeploymentReady(cd.Namespace, cd.Name)
+			metrics.DeleteMetricClusterServiceAdvisories(cd.Namespace, cd.Name)
+			metrics.DeleteMetricClusterKubernetesEndOfLife(cd.Namespace, cd.Name)
 		}
 	}()
 
+	// the cluster is only reached directly from now on
+	if r.clusterTracker != nil {
+		r.clusterTracker.Stop(client.ObjectKeyFromObject(cd))
+	}
+
+	if deletion.ForceFinalizeRequested(cd) {
+		return ctrl.Result{}, r.forceFinalize(ctx, cd)
+	}
+
+	// unregister the cluster first so Argo CD stops syncing to it
+	if err := argocd.DeleteClusterSecrets(ctx, r.Client, cd); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// uninstall the services delivered by the engines other than Sveltos while the cluster is still reachable
+	for name, provider := range statemanagement.Providers(r.Client, r.getClusterClient) {
+		if err := provider.DeleteServices(ctx, cd); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete services delivered by the %s engine: %w", name, err)
+		}
+	}
+
 	hr := &hcv2.HelmRelease{}
 
 	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(cd), hr); err != nil {
@@ -717,16 +2678,26 @@ func (r *ClusterDeploymentReconciler) Delete(ctx context.Context, cd *kcm.Cluste
 		return ctrl.Result{}, err
 	}
 
+	if len(cd.Endpoints(kcm.EndpointTypeDNSRecord)) > 0 {
+		if err := dns.DeleteDNSEndpoint(ctx, r.Client, cd.Name, cd.Namespace); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete DNSEndpoint %s/%s: %w", cd.Namespace, cd.Name, err)
+		}
+	}
+
 	// Without explicitly deleting the Profile object, we run into a race condition
 	// which prevents Sveltos objects from being removed from the management cluster.
 	// It is detailed in https://github.com/projectsveltos/addon-controller/issues/732.
 	// We may try to remove the explicit call to Delete once a fix for it has been merged.
 	// TODO(https://github.com/K0rdent/kcm/issues/526).
-	if err := sveltos.DeleteProfile(ctx, r.Client, cd.Namespace, cd.Name); err != nil {
+	if err := sveltos.DeleteProfile(ctx, r.Client, cd.Namespace, cd.Name); err != nil && !apimeta.IsNoMatchError(err) {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.releaseCluster(ctx, cd); err != nil {
 		return ctrl.Result{}, err
 	}
 
-	if err := r.releaseCluster(ctx, cd.Namespace, cd.Name, cd.Spec.Template); err != nil {
+	if err := r.updateDeletionStatus(ctx, cd); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -734,8 +2705,231 @@ func (r *ClusterDeploymentReconciler) Delete(ctx context.Context, cd *kcm.Cluste
 	return ctrl.Result{RequeueAfter: r.defaultRequeueTime}, nil
 }
 
-func (r *ClusterDeploymentReconciler) releaseCluster(ctx context.Context, namespace, name, templateName string) error {
-	providers, err := r.getInfraProvidersNames(ctx, namespace, templateName)
+// updateDeletionStatus reports the resources blocking the deletion of the given ClusterDeployment.
+func (r *ClusterDeploymentReconciler) updateDeletionStatus(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	status, err := deletion.Diagnose(ctx, r.Client, cd, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to diagnose deletion of %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	original := cd.DeepCopy()
+	cd.Status.Deletion = status
+	apimeta.SetStatusCondition(cd.GetConditions(), deletion.Condition(cd, status))
+	if err := r.Client.Status().Patch(ctx, cd, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to update deletion status of %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	return nil
+}
+
+// forceFinalize forcibly finalizes the deletion of the given ClusterDeployment confirmed
+// with the [kcm.ForceFinalizeAnnotation] annotation, the removed finalizers are recorded in an event.
+func (r *ClusterDeploymentReconciler) forceFinalize(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	l := ctrl.LoggerFrom(ctx)
+
+	finalized, err := deletion.ForceFinalize(ctx, r.Client, cd)
+	if err != nil {
+		return fmt.Errorf("failed to force finalize deletion of %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	removed := make([]string, 0, len(finalized)+1)
+	for _, res := range finalized {
+		removed = append(removed, fmt.Sprintf("%s %s %v", res.Kind, res.Name, res.Finalizers))
+	}
+	removed = append(removed, fmt.Sprintf("%s %s [%s]", kcm.ClusterDeploymentKind, cd.Name, kcm.ClusterDeploymentFinalizer))
+
+	msg := "Deletion has been forcibly finalized, the cloud resources of the cluster may be orphaned. Removed finalizers: " + strings.Join(removed, ", ")
+	l.Info("Forcibly finalizing deletion", "finalizers", removed)
+	r.eventRecorder.Event(cd, corev1.EventTypeWarning, kcm.ForceFinalizedReason, msg)
+
+	if controllerutil.RemoveFinalizer(cd, kcm.ClusterDeploymentFinalizer) {
+		if err := r.Client.Update(ctx, cd); err != nil {
+			return fmt.Errorf("failed to update clusterDeployment %s/%s: %w", cd.Namespace, cd.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// rollback reverts the given ClusterDeployment to its last known-good revision as requested
+// with the [kcm.RollbackAnnotation] annotation, the rejected requests are reported in an event and dropped.
+func (r *ClusterDeploymentReconciler) rollback(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	l := ctrl.LoggerFrom(ctx)
+
+	validationErr := rollback.Validate(ctx, r.Client, cd)
+
+	// update a copy not to override the status being reconciled
+	cdCopy := cd.DeepCopy()
+	if validationErr != nil {
+		delete(cdCopy.Annotations, kcm.RollbackAnnotation)
+	} else {
+		rollback.Apply(cdCopy)
+	}
+	if err := r.Client.Update(ctx, cdCopy); err != nil {
+		return fmt.Errorf("failed to update clusterDeployment %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	if validationErr != nil {
+		r.eventRecorder.Event(cd, corev1.EventTypeWarning, kcm.RollbackFailedReason, "Rollback has been rejected: "+validationErr.Error())
+		return nil
+	}
+
+	revision := cd.Status.LastKnownGood
+	l.Info("Rolled back to the known-good revision", "template", revision.Template, "recordedAt", revision.RecordedAt)
+	r.eventRecorder.Event(cd, corev1.EventTypeNormal, kcm.RolledBackReason,
+		fmt.Sprintf("Rolled back from the ClusterTemplate %s to the ClusterTemplate %s and the configuration recorded at %s",
+			cd.Spec.Template, revision.Template, revision.RecordedAt.UTC().Format(time.RFC3339)))
+
+	return nil
+}
+
+// rollbackServices reverts the services of the given ClusterDeployment to the revisions requested with
+// the [kcm.ServiceRollbackAnnotation] annotation, the rejected requests are reported in an event and dropped.
+func (r *ClusterDeploymentReconciler) rollbackServices(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	l := ctrl.LoggerFrom(ctx)
+
+	// update a copy not to override the status being reconciled
+	cdCopy := cd.DeepCopy()
+	delete(cdCopy.Annotations, kcm.ServiceRollbackAnnotation)
+	reverted, validationErr := servicehistory.Apply(cdCopy.Spec.ServiceSpec.Services, cd.Status.ServiceHistory, cd.Annotations[kcm.ServiceRollbackAnnotation])
+	if validationErr == nil {
+		// the ServiceTemplates of the revisions may have been removed since the revisions have been recorded
+		if err := validation.ServicesHaveValidTemplates(ctx, r.Client, cdCopy.Spec.ServiceSpec.Services, cd.Namespace); err != nil {
+			validationErr = err
+			cdCopy.Spec.ServiceSpec.Services = cd.Spec.ServiceSpec.Services
+		}
+	}
+	if err := r.Client.Update(ctx, cdCopy); err != nil {
+		return fmt.Errorf("failed to update clusterDeployment %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	if validationErr != nil {
+		r.eventRecorder.Event(cd, corev1.EventTypeWarning, kcm.ServiceRollbackFailedReason, "Rollback of services has been rejected: "+validationErr.Error())
+		return nil
+	}
+
+	l.Info("Rolled back services", "services", reverted)
+	r.eventRecorder.Event(cd, corev1.EventTypeNormal, kcm.ServiceRolledBackReason, "Rolled back the services "+strings.Join(reverted, ", "))
+
+	return nil
+}
+
+// migrateControlPlane starts the migration of the given ClusterDeployment between the hosted and the standalone
+// control plane as requested with the [kcm.ControlPlaneMigrationAnnotation] annotation recording the inventory
+// of the cluster, the rejected requests are reported in an event and dropped.
+func (r *ClusterDeploymentReconciler) migrateControlPlane(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	l := ctrl.LoggerFrom(ctx)
+
+	target, _ := cpmigration.Pending(cd)
+	from, to, validationErr := cpmigration.Validate(ctx, r.Client, cd, target)
+	if validationErr != nil {
+		// update a copy not to override the status being reconciled
+		cdCopy := cd.DeepCopy()
+		delete(cdCopy.Annotations, kcm.ControlPlaneMigrationAnnotation)
+		if err := r.Client.Update(ctx, cdCopy); err != nil {
+			return fmt.Errorf("failed to update clusterDeployment %s/%s: %w", cd.Namespace, cd.Name, err)
+		}
+
+		r.eventRecorder.Event(cd, corev1.EventTypeWarning, kcm.ControlPlaneMigrationRejectedReason,
+			"Control plane migration has been rejected: "+validationErr.Error())
+		return nil
+	}
+
+	clusterClient, err := r.getClusterClient(ctx, cd)
+	if err != nil {
+		return fmt.Errorf("failed to get client of the cluster %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+	inventory, err := cpmigration.Inventory(ctx, clusterClient)
+	if err != nil {
+		return fmt.Errorf("failed to get inventory of the cluster %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	cpmigration.Start(cd, from, to, inventory, time.Now())
+	if err := r.Client.Status().Update(ctx, cd); err != nil {
+		return fmt.Errorf("failed to update status for clusterDeployment %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	l.Info("Started control plane migration", "fromTemplate", from.Name, "toTemplate", to.Name, "inventory", len(inventory))
+	r.eventRecorder.Event(cd, corev1.EventTypeNormal, kcm.ProgressingReason,
+		fmt.Sprintf("Started migration of the control plane from %s (%s) to %s (%s)",
+			from.Status.ControlPlaneMode, from.Name, to.Status.ControlPlaneMode, to.Name))
+
+	return r.switchControlPlaneMigrationTemplate(ctx, cd)
+}
+
+// switchControlPlaneMigrationTemplate switches the given ClusterDeployment to the ClusterTemplate
+// of the started control plane migration.
+func (r *ClusterDeploymentReconciler) switchControlPlaneMigrationTemplate(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	// update a copy not to override the status being reconciled
+	cdCopy := cd.DeepCopy()
+	cdCopy.SetTemplate(cd.Status.ControlPlaneMigration.ToTemplate)
+	if err := r.Client.Update(ctx, cdCopy); err != nil {
+		return fmt.Errorf("failed to update clusterDeployment %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	return nil
+}
+
+// reconcileControlPlaneMigration verifies the inventory of the cluster once the control plane of the started
+// migration is ready, removing the [kcm.ControlPlaneMigrationAnnotation] annotation on success.
+// The condition of the failed migration is removed once the failure is acknowledged by the removal of the annotation.
+func (r *ClusterDeploymentReconciler) reconcileControlPlaneMigration(ctx context.Context, cd *kcm.ClusterDeployment) (ctrl.Result, error) {
+	m := cd.Status.ControlPlaneMigration
+	if m == nil {
+		return ctrl.Result{}, nil
+	}
+
+	if !cpmigration.InProgress(cd) {
+		if _, requested := cpmigration.Requested(cd); !requested && m.Phase == kcm.ControlPlaneMigrationPhaseFailed {
+			apimeta.RemoveStatusCondition(cd.GetConditions(), kcm.ControlPlaneMigrationCondition)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	migrated, err := cpmigration.ControlPlaneMigrated(ctx, r.Client, cd)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get control plane of the cluster %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+	if !migrated {
+		return ctrl.Result{RequeueAfter: r.defaultRequeueTime}, nil
+	}
+
+	clusterClient, err := r.getClusterClient(ctx, cd)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get client of the cluster %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+	current, err := cpmigration.Inventory(ctx, clusterClient)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get inventory of the cluster %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	cpmigration.Verify(cd, current, time.Now())
+	if m.Phase == kcm.ControlPlaneMigrationPhaseFailed {
+		r.eventRecorder.Event(cd, corev1.EventTypeWarning, kcm.DataContinuityCheckFailedReason,
+			"Resources are missing after the control plane migration: "+strings.Join(m.Missing, ", "))
+		return ctrl.Result{}, nil
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Completed control plane migration", "fromTemplate", m.FromTemplate, "toTemplate", m.ToTemplate)
+	r.eventRecorder.Event(cd, corev1.EventTypeNormal, kcm.SucceededReason,
+		fmt.Sprintf("Migrated the control plane from %s (%s) to %s (%s)", m.FromMode, m.FromTemplate, m.ToMode, m.ToTemplate))
+
+	// patch a copy not to override the status being reconciled
+	cdCopy := cd.DeepCopy()
+	patch := client.MergeFrom(cdCopy.DeepCopy())
+	delete(cdCopy.Annotations, kcm.ControlPlaneMigrationAnnotation)
+	if err := r.Client.Patch(ctx, cdCopy, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to remove %s annotation: %w", kcm.ControlPlaneMigrationAnnotation, err)
+	}
+	cd.Annotations = cdCopy.Annotations
+	cd.ResourceVersion = cdCopy.ResourceVersion
+
+	return ctrl.Result{}, nil
+}
+
+func (r *ClusterDeploymentReconciler) releaseCluster(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	namespace, name := cd.Namespace, cd.Name
+	providers, err := r.getInfraProvidersNames(ctx, cd.TemplateNamespace(), cd.Spec.Template)
 	if err != nil {
 		return err
 	}
@@ -871,6 +3065,13 @@ func (r *ClusterDeploymentReconciler) setAvailableUpgrades(ctx context.Context,
 	}
 
 	clusterDeployment.Status.AvailableUpgrades = availableUpgrades
+
+	templates := &kcm.ClusterTemplateList{}
+	if err := r.Client.List(ctx, templates, client.InNamespace(template.Namespace)); err != nil {
+		return err
+	}
+	clusterDeployment.Status.UpgradeCandidates = upgrade.Candidates(template, templates.Items, availableUpgrades)
+
 	return nil
 }
 
@@ -881,25 +3082,60 @@ func (r *ClusterDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 	r.helmActor = helm.NewActor(r.Config, r.Client.RESTMapper())
 
+	if r.PriceSource == nil {
+		r.PriceSource = &cost.ConfigMapPriceSource{Client: r.Client, Namespace: r.SystemNamespace}
+	}
+
+	r.eventRecorder = mgr.GetEventRecorderFor("clusterdeployment-controller")
 	r.defaultRequeueTime = 10 * time.Second
 
-	return ctrl.NewControllerManagedBy(mgr).
+	r.clusterTracker = clustercache.NewTracker(mgr.GetScheme())
+	if err := mgr.Add(r.clusterTracker); err != nil {
+		return fmt.Errorf("failed to add cluster tracker: %w", err)
+	}
+
+	// the ClusterDeployments listed upon the start along with the objects requeueing them
+	// are reconciled at the limited rate instead of all at once
+	initialSync := ratelimit.NewInitialSync[ctrl.Request](r.InitialSync)
+	if initialSync != nil {
+		if err := mgr.Add(initialSync); err != nil {
+			return fmt.Errorf("failed to add initial sync: %w", err)
+		}
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.TypedOptions[ctrl.Request]{
 			RateLimiter: ratelimit.DefaultFastSlow(),
 		}).
-		For(&kcm.ClusterDeployment{}).
+		For(&kcm.ClusterDeployment{}, builder.WithPredicates(predicate.Funcs{
+			CreateFunc: func(e event.CreateEvent) bool { return !initialSync.Listed(e.Object) },
+		})).
+		Watches(&kcm.ClusterDeployment{},
+			initialSync.Handler(&handler.EnqueueRequestForObject{}),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc:  func(e event.CreateEvent) bool { return initialSync.Listed(e.Object) },
+				UpdateFunc:  func(event.UpdateEvent) bool { return false },
+				DeleteFunc:  func(event.DeleteEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+			}),
+		).
+		Owns(&batchv1.Job{}).
 		Watches(&hcv2.HelmRelease{},
-			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []ctrl.Request {
+			initialSync.Handler(handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []ctrl.Request {
 				clusterDeploymentRef := client.ObjectKeyFromObject(o)
+				// the HelmReleases delivering the services are labeled with the name of the ClusterDeployment
+				if name, ok := o.GetLabels()[kcm.ServiceDeliveryClusterLabelKey]; ok {
+					clusterDeploymentRef.Name = name
+				}
 				if err := r.Client.Get(ctx, clusterDeploymentRef, &kcm.ClusterDeployment{}); err != nil {
 					return []ctrl.Request{}
 				}
 
 				return []ctrl.Request{{NamespacedName: clusterDeploymentRef}}
-			}),
+			})),
 		).
 		Watches(&kcm.ClusterTemplateChain{},
-			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []ctrl.Request {
+			initialSync.Handler(handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []ctrl.Request {
 				chain, ok := o.(*kcm.ClusterTemplateChain)
 				if !ok {
 					return nil
@@ -914,7 +3150,14 @@ func (r *ClusterDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					if err != nil {
 						return []ctrl.Request{}
 					}
-					for _, cluster := range clusterDeployments.Items {
+					// along with the ones referencing the template from the other namespaces
+					referencing := &kcm.ClusterDeploymentList{}
+					err = r.Client.List(ctx, referencing,
+						client.MatchingFields{kcm.ClusterDeploymentTemplateRefIndexKey: client.ObjectKey{Namespace: chain.Namespace, Name: template}.String()})
+					if err != nil {
+						return []ctrl.Request{}
+					}
+					for _, cluster := range append(clusterDeployments.Items, referencing.Items...) {
 						req = append(req, ctrl.Request{
 							NamespacedName: client.ObjectKey{
 								Namespace: cluster.Namespace,
@@ -924,21 +3167,28 @@ func (r *ClusterDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 					}
 				}
 				return req
-			}),
+			})),
 			builder.WithPredicates(predicate.Funcs{
 				UpdateFunc:  func(event.UpdateEvent) bool { return false },
 				GenericFunc: func(event.GenericEvent) bool { return false },
 			}),
 		).
-		Watches(&sveltosv1beta1.ClusterSummary{},
-			handler.EnqueueRequestsFromMapFunc(requeueSveltosProfileForClusterSummary),
+		// the changes of the nodes and the Jobs in the clusters
+		WatchesRawSource(r.clusterTracker.Source())
+
+	if !r.SveltosDisabled {
+		b = b.Watches(&sveltosv1beta1.ClusterSummary{},
+			initialSync.Handler(handler.EnqueueRequestsFromMapFunc(requeueSveltosProfileForClusterSummary)),
 			builder.WithPredicates(predicate.Funcs{
 				DeleteFunc:  func(event.DeleteEvent) bool { return false },
 				GenericFunc: func(event.GenericEvent) bool { return false },
 			}),
-		).
+		)
+	}
+
+	return b.
 		Watches(&kcm.Credential{},
-			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []ctrl.Request {
+			initialSync.Handler(handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []ctrl.Request {
 				clusterDeployments := &kcm.ClusterDeploymentList{}
 				err := r.Client.List(ctx, clusterDeployments,
 					client.InNamespace(o.GetNamespace()),
@@ -958,6 +3208,167 @@ func (r *ClusterDeploymentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				}
 
 				return req
+			})),
+		).
+		Watches(&kcm.ServiceTemplate{},
+			initialSync.Handler(handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []ctrl.Request {
+				clusterDeployments := &kcm.ClusterDeploymentList{}
+				err := r.Client.List(ctx, clusterDeployments,
+					client.InNamespace(o.GetNamespace()),
+					client.MatchingFields{kcm.ClusterDeploymentServiceTemplatesIndexKey: o.GetName()})
+				if err != nil {
+					return []ctrl.Request{}
+				}
+
+				req := []ctrl.Request{}
+				for _, cluster := range clusterDeployments.Items {
+					req = append(req, ctrl.Request{
+						NamespacedName: client.ObjectKey{
+							Namespace: cluster.Namespace,
+							Name:      cluster.Name,
+						},
+					})
+				}
+
+				return req
+			})),
+			builder.WithPredicates(predicate.Funcs{
+				// only the changes of the advisories are reported on the clusters
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					oldTemplate, ok := e.ObjectOld.(*kcm.ServiceTemplate)
+					if !ok {
+						return false
+					}
+					newTemplate, ok := e.ObjectNew.(*kcm.ServiceTemplate)
+					if !ok {
+						return false
+					}
+					return !equality.Semantic.DeepEqual(oldTemplate.Status.Advisories, newTemplate.Status.Advisories)
+				},
+				CreateFunc:  func(event.CreateEvent) bool { return false },
+				DeleteFunc:  func(event.DeleteEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+			}),
+		).
+		Watches(&kcm.ServiceBundle{},
+			initialSync.Handler(handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []ctrl.Request {
+				clusterDeployments := &kcm.ClusterDeploymentList{}
+				err := r.Client.List(ctx, clusterDeployments,
+					client.InNamespace(o.GetNamespace()),
+					client.MatchingFields{kcm.ClusterDeploymentServiceBundlesIndexKey: o.GetName()})
+				if err != nil {
+					return []ctrl.Request{}
+				}
+
+				req := []ctrl.Request{}
+				for _, cluster := range clusterDeployments.Items {
+					req = append(req, ctrl.Request{
+						NamespacedName: client.ObjectKey{
+							Namespace: cluster.Namespace,
+							Name:      cluster.Name,
+						},
+					})
+				}
+
+				return req
+			})),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(&kcm.NodeImageRollout{},
+			initialSync.Handler(handler.EnqueueRequestsFromMapFunc(func(_ context.Context, o client.Object) []ctrl.Request {
+				rollout, ok := o.(*kcm.NodeImageRollout)
+				if !ok {
+					return nil
+				}
+
+				// the clusters the new images are approved to be rolled out to
+				var req []ctrl.Request
+				for _, status := range rollout.Status.Clusters {
+					if status.Phase != kcm.NodeImageRolloutPhaseUpdating {
+						continue
+					}
+					namespace, name, _ := strings.Cut(status.ClusterDeployment, "/")
+					req = append(req, ctrl.Request{NamespacedName: client.ObjectKey{Namespace: namespace, Name: name}})
+				}
+
+				return req
+			})),
+		).
+		Watches(&kcm.VIPPool{},
+			initialSync.Handler(handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []ctrl.Request {
+				clusterDeployments := &kcm.ClusterDeploymentList{}
+				err := r.Client.List(ctx, clusterDeployments,
+					client.MatchingFields{kcm.ClusterDeploymentVIPPoolIndexKey: o.GetName()})
+				if err != nil {
+					return []ctrl.Request{}
+				}
+
+				req := []ctrl.Request{}
+				for _, cluster := range clusterDeployments.Items {
+					req = append(req, ctrl.Request{
+						NamespacedName: client.ObjectKey{
+							Namespace: cluster.Namespace,
+							Name:      cluster.Name,
+						},
+					})
+				}
+
+				return req
+			})),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(&kcm.StateManagementProvider{},
+			initialSync.Handler(handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []ctrl.Request {
+				// the selectors may have stopped selecting the ClusterDeployments, hence all of them are requeued
+				clusterDeployments := &kcm.ClusterDeploymentList{}
+				if err := r.Client.List(ctx, clusterDeployments); err != nil {
+					return []ctrl.Request{}
+				}
+
+				req := make([]ctrl.Request, 0, len(clusterDeployments.Items))
+				for _, cluster := range clusterDeployments.Items {
+					req = append(req, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&cluster)})
+				}
+
+				return req
+			})),
+		).
+		Watches(&kcm.Management{},
+			initialSync.Handler(handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []ctrl.Request {
+				clusterDeployments := &kcm.ClusterDeploymentList{}
+				if err := r.Client.List(ctx, clusterDeployments); err != nil {
+					return []ctrl.Request{}
+				}
+
+				req := make([]ctrl.Request, 0, len(clusterDeployments.Items))
+				for _, cluster := range clusterDeployments.Items {
+					req = append(req, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&cluster)})
+				}
+
+				return req
+			})),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc: func(event.CreateEvent) bool { return false },
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					oldMgmt, ok := e.ObjectOld.(*kcm.Management)
+					if !ok {
+						return false
+					}
+					newMgmt, ok := e.ObjectNew.(*kcm.Management)
+					if !ok {
+						return false
+					}
+					// register the clusters in Argo CD, deploy the observability agents, distribute the registry
+					// credentials and the trusted CA bundle once the respective settings are enabled or changed,
+					// and update the versions of the add-ons once the Release is changed
+					return !equality.Semantic.DeepEqual(oldMgmt.Spec.ArgoCD, newMgmt.Spec.ArgoCD) ||
+						!equality.Semantic.DeepEqual(oldMgmt.Spec.Observability, newMgmt.Spec.Observability) ||
+						!equality.Semantic.DeepEqual(oldMgmt.Spec.RegistryCredentials, newMgmt.Spec.RegistryCredentials) ||
+						!equality.Semantic.DeepEqual(oldMgmt.Spec.TrustedCA, newMgmt.Spec.TrustedCA) ||
+						oldMgmt.Spec.Release != newMgmt.Spec.Release
+				},
+				DeleteFunc:  func(event.DeleteEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
 			}),
 		).
 		Complete(r)
@@ -16,6 +16,8 @@ package controller
 
 import (
 	"context"
+	"net/netip"
+	"testing"
 	"time"
 
 	hcv2 "github.com/fluxcd/helm-controller/api/v2"
@@ -27,16 +29,27 @@ import (
 	"helm.sh/helm/v3/pkg/chart"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/objects/clusterdeployment"
+	"github.com/K0rdent/kcm/test/objects/template"
+	"github.com/K0rdent/kcm/test/objects/vippool"
+	"github.com/K0rdent/kcm/test/scheme"
 )
 
 type fakeHelmActor struct{}
@@ -588,3 +601,257 @@ var _ = Describe("ClusterDeployment Controller", func() {
 		})
 	})
 })
+
+func Test_reserveControlPlaneVIP(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	pool := vippool.NewVIPPool(vippool.WithAddresses("10.0.0.10-10.0.0.11"))
+	newClusterDeployment := func(name string) *kcm.ClusterDeployment {
+		return &kcm.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name, UID: types.UID(name)},
+			Spec:       kcm.ClusterDeploymentSpec{ControlPlaneVIP: &kcm.ControlPlaneVIP{Pool: pool.Name}},
+		}
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).
+		WithObjects(pool, newClusterDeployment("first"), newClusterDeployment("second")).
+		WithStatusSubresource(&kcm.VIPPool{}).
+		Build()
+	r := &ClusterDeploymentReconciler{Client: cl}
+
+	// the address is claimed in the VIPPool before it is reserved by the ClusterDeployment
+	addr, err := r.reserveControlPlaneVIP(ctx, newClusterDeployment("first"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(addr).To(Equal("10.0.0.10"))
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(pool), pool)).To(Succeed())
+	g.Expect(pool.Status.Allocations).To(Equal([]kcm.VIPAllocation{{Address: "10.0.0.10", ClusterDeployment: "default/first"}}))
+	stale := pool.DeepCopy()
+
+	// the claimed address is not reserved again while the ClusterDeployment has not recorded it yet
+	addr, err = r.reserveControlPlaneVIP(ctx, newClusterDeployment("second"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(addr).To(Equal("10.0.0.11"))
+
+	// the claim is kept on the subsequent reservations
+	addr, err = r.reserveControlPlaneVIP(ctx, newClusterDeployment("first"))
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(addr).To(Equal("10.0.0.10"))
+
+	// the concurrent claim based on the outdated VIPPool is rejected
+	err = r.claimControlPlaneVIP(ctx, stale, newClusterDeployment("third"), netip.MustParseAddr("10.0.0.11"))
+	g.Expect(apierrors.IsConflict(err)).To(BeTrue(), "expected conflict, got %v", err)
+}
+
+func Test_releaseCluster(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	const catalogNamespace = "kcm-catalog"
+
+	// the ClusterTemplate is granted from the catalog namespace
+	clusterTemplate := template.NewClusterTemplate(
+		template.WithName("docker-hosted-cp-0-2-1"),
+		template.WithNamespace(catalogNamespace),
+		template.WithProvidersStatus("infrastructure-docker"),
+	)
+	cd := clusterdeployment.NewClusterDeployment(
+		clusterdeployment.WithName("dev"),
+		clusterdeployment.WithClusterTemplateRef(catalogNamespace, clusterTemplate.Name),
+		clusterdeployment.WithDeletionTimestamp(metav1.Now()),
+	)
+
+	dockerClusterGVK := schema.GroupVersionKind{Group: "infrastructure.cluster.x-k8s.io", Version: "v1beta1", Kind: "DockerCluster"}
+	testScheme := runtime.NewScheme()
+	g.Expect(kcm.AddToScheme(testScheme)).To(Succeed())
+	g.Expect(clusterapiv1beta1.AddToScheme(testScheme)).To(Succeed())
+	testScheme.AddKnownTypeWithName(dockerClusterGVK, new(unstructured.Unstructured))
+	testScheme.AddKnownTypeWithName(dockerClusterGVK.GroupVersion().WithKind("DockerClusterList"), new(unstructured.UnstructuredList))
+
+	cluster := new(unstructured.Unstructured)
+	cluster.SetGroupVersionKind(dockerClusterGVK)
+	cluster.SetNamespace(cd.Namespace)
+	cluster.SetName(cd.Name)
+	cluster.SetLabels(map[string]string{kcm.FluxHelmChartNameKey: cd.Name})
+	cluster.SetFinalizers([]string{kcm.BlockingFinalizer})
+
+	cl := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(clusterTemplate, cluster).Build()
+	r := &ClusterDeploymentReconciler{Client: cl}
+
+	// the finalizer blocking the deletion of the cluster is removed once the machines are gone
+	g.Expect(r.releaseCluster(ctx, cd)).To(Succeed())
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(cluster), cluster)).To(Succeed())
+	g.Expect(cluster.GetFinalizers()).To(BeEmpty())
+}
+
+func Test_reconcileCertificates(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	newMachine := func(name string, expireAt *time.Time) *clusterapiv1beta1.Machine {
+		machine := &clusterapiv1beta1.Machine{ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      name,
+			Labels:    map[string]string{kcm.ClusterNameLabelKey: clusterdeployment.DefaultName},
+		}}
+		if expireAt != nil {
+			machine.Status.CertificatesExpiryDate = &metav1.Time{Time: *expireAt}
+		}
+		return machine
+	}
+	newCluster := func(controlPlaneKind string) *clusterapiv1beta1.Cluster {
+		return &clusterapiv1beta1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: clusterdeployment.DefaultName},
+			Spec: clusterapiv1beta1.ClusterSpec{ControlPlaneRef: &corev1.ObjectReference{
+				APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+				Kind:       controlPlaneKind,
+				Name:       clusterdeployment.DefaultName + "-cp",
+			}},
+		}
+	}
+	newControlPlane := func(kind string) *unstructured.Unstructured {
+		controlPlane := new(unstructured.Unstructured)
+		controlPlane.SetGroupVersionKind(schema.GroupVersionKind{Group: "controlplane.cluster.x-k8s.io", Version: "v1beta1", Kind: kind})
+		controlPlane.SetNamespace(metav1.NamespaceDefault)
+		controlPlane.SetName(clusterdeployment.DefaultName + "-cp")
+		return controlPlane
+	}
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	for _, tc := range []struct {
+		name          string
+		rotate        bool
+		objects       []client.Object
+		tracked       bool
+		expireAt      *time.Time
+		condition     *metav1.Condition
+		rolledOut     bool
+		expectedEvent string
+	}{
+		{
+			name: "no machines",
+		},
+		{
+			name:     "earliest expiry",
+			objects:  []client.Object{newMachine("cp-0", at(90*24*time.Hour)), newMachine("cp-1", at(60*24*time.Hour)), newMachine("worker-0", nil)},
+			tracked:  true,
+			expireAt: at(60 * 24 * time.Hour),
+			condition: &metav1.Condition{
+				Status: metav1.ConditionTrue,
+				Reason: kcm.SucceededReason,
+			},
+		},
+		{
+			name:     "expiring",
+			objects:  []client.Object{newMachine("cp-0", at(7*24*time.Hour))},
+			tracked:  true,
+			expireAt: at(7 * 24 * time.Hour),
+			condition: &metav1.Condition{
+				Status: metav1.ConditionTrue,
+				Reason: kcm.CertificatesExpiringReason,
+			},
+		},
+		{
+			name:     "expired",
+			objects:  []client.Object{newMachine("cp-0", at(-time.Hour))},
+			tracked:  true,
+			expireAt: at(-time.Hour),
+			condition: &metav1.Condition{
+				Status: metav1.ConditionFalse,
+				Reason: kcm.FailedReason,
+			},
+		},
+		{
+			name:    "expiry not reported",
+			objects: []client.Object{newMachine("cp-0", nil)},
+			condition: &metav1.Condition{
+				Status: metav1.ConditionUnknown,
+				Reason: kcm.CertificatesExpiryUnknownReason,
+			},
+		},
+		{
+			name:      "rotation",
+			rotate:    true,
+			objects:   []client.Object{newCluster(kubeadmControlPlaneKind), newControlPlane(kubeadmControlPlaneKind), newMachine("cp-0", nil)},
+			rolledOut: true,
+			condition: &metav1.Condition{
+				Status: metav1.ConditionUnknown,
+				Reason: kcm.CertificatesExpiryUnknownReason,
+			},
+		},
+		{
+			name:    "rotation of unsupported control plane",
+			rotate:  true,
+			objects: []client.Object{newCluster("K0sControlPlane"), newControlPlane("K0sControlPlane"), newMachine("cp-0", nil)},
+			condition: &metav1.Condition{
+				Status: metav1.ConditionFalse,
+				Reason: kcm.CertificatesRotationUnsupportedReason,
+			},
+			expectedEvent: "Warning " + kcm.CertificatesRotationUnsupportedReason,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			testScheme := runtime.NewScheme()
+			g.Expect(kcm.AddToScheme(testScheme)).To(Succeed())
+			g.Expect(clusterapiv1beta1.AddToScheme(testScheme)).To(Succeed())
+			for _, kind := range []string{kubeadmControlPlaneKind, "K0sControlPlane"} {
+				testScheme.AddKnownTypeWithName(schema.GroupVersionKind{Group: "controlplane.cluster.x-k8s.io", Version: "v1beta1", Kind: kind}, new(unstructured.Unstructured))
+			}
+
+			cd := clusterdeployment.NewClusterDeployment()
+			if tc.rotate {
+				cd.Annotations = map[string]string{kcm.RotateCertificatesAnnotation: "true"}
+			}
+			cl := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(append(tc.objects, cd)...).Build()
+			g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(cd), cd)).To(Succeed())
+			recorder := record.NewFakeRecorder(1)
+			r := &ClusterDeploymentReconciler{Client: cl, eventRecorder: recorder}
+
+			tracked, err := r.reconcileCertificates(ctx, cd)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(tracked).To(Equal(tc.tracked))
+
+			if tc.expireAt != nil {
+				g.Expect(cd.Status.CertificatesExpireAt).NotTo(BeNil())
+				g.Expect(cd.Status.CertificatesExpireAt.Time).To(BeTemporally("==", *tc.expireAt))
+			} else {
+				g.Expect(cd.Status.CertificatesExpireAt).To(BeNil())
+			}
+
+			condition := meta.FindStatusCondition(cd.Status.Conditions, kcm.CertificatesValidCondition)
+			if tc.condition == nil {
+				g.Expect(condition).To(BeNil())
+			} else {
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(tc.condition.Status))
+				g.Expect(condition.Reason).To(Equal(tc.condition.Reason))
+			}
+
+			// the rotation request is removed whether the rotation is supported or not
+			g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(cd), cd)).To(Succeed())
+			g.Expect(cd.Annotations).NotTo(HaveKey(kcm.RotateCertificatesAnnotation))
+
+			if tc.rotate {
+				controlPlane := newControlPlane(kubeadmControlPlaneKind)
+				if !tc.rolledOut {
+					controlPlane = newControlPlane("K0sControlPlane")
+				}
+				g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(controlPlane), controlPlane)).To(Succeed())
+				_, found, err := unstructured.NestedString(controlPlane.Object, "spec", "rolloutAfter")
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(found).To(Equal(tc.rolledOut))
+			}
+
+			if tc.expectedEvent != "" {
+				g.Expect(recorder.Events).To(Receive(HavePrefix(tc.expectedEvent)))
+			} else {
+				g.Expect(recorder.Events).To(BeEmpty())
+			}
+		})
+	}
+}
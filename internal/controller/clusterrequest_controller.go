@@ -0,0 +1,218 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/freeze"
+	"github.com/K0rdent/kcm/internal/utils/ratelimit"
+)
+
+// ClusterRequestReconciler reconciles a ClusterRequest object
+type ClusterRequestReconciler struct {
+	client.Client
+}
+
+func (r *ClusterRequestReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	l := ctrl.LoggerFrom(ctx)
+	l.Info("ClusterRequest reconcile start")
+
+	clusterRequest := new(kcm.ClusterRequest)
+	if err := r.Get(ctx, req.NamespacedName, clusterRequest); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, clusterRequest.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		l.Info("Reconciliation is paused, only observing the status")
+		apimeta.SetStatusCondition(&clusterRequest.Status.Conditions, freeze.Condition(pausedMessage, clusterRequest.Generation))
+		if err := r.Status().Update(ctx, clusterRequest); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update ClusterRequest %s status: %w", client.ObjectKeyFromObject(clusterRequest), err)
+		}
+		return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, nil
+	}
+	apimeta.RemoveStatusCondition(&clusterRequest.Status.Conditions, kcm.ReconciliationPausedCondition)
+
+	if !clusterRequest.DeletionTimestamp.IsZero() {
+		l.Info("ClusterRequest is being deleted, skipping")
+		return ctrl.Result{}, nil
+	}
+
+	defer func() {
+		clusterRequest.Status.ObservedGeneration = clusterRequest.Generation
+		if serr := r.Status().Update(ctx, clusterRequest); serr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to update ClusterRequest %s status: %w", client.ObjectKeyFromObject(clusterRequest), serr))
+		}
+	}()
+
+	approval := clusterRequest.Status.Approval
+	switch {
+	case approval == nil:
+		clusterRequest.Status.Phase = kcm.ClusterRequestPhasePending
+		return ctrl.Result{}, nil
+	case approval.Decision == kcm.ClusterRequestDecisionDenied:
+		clusterRequest.Status.Phase = kcm.ClusterRequestPhaseDenied
+		return ctrl.Result{}, nil
+	}
+
+	return r.reconcileApproved(ctx, clusterRequest)
+}
+
+func (r *ClusterRequestReconciler) reconcileApproved(ctx context.Context, clusterRequest *kcm.ClusterRequest) (ctrl.Result, error) {
+	l := ctrl.LoggerFrom(ctx)
+
+	cd := new(kcm.ClusterDeployment)
+	err := r.Get(ctx, client.ObjectKey{Namespace: clusterRequest.Namespace, Name: clusterRequest.Name}, cd)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("failed to get ClusterDeployment %s/%s: %w", clusterRequest.Namespace, clusterRequest.Name, err)
+	}
+	exists := err == nil
+
+	if exists && !metav1.IsControlledBy(cd, clusterRequest) {
+		err := fmt.Errorf("ClusterDeployment %s/%s already exists and is not managed by the request", cd.Namespace, cd.Name)
+		r.setProvisionedCondition(clusterRequest, err)
+		clusterRequest.Status.Phase = kcm.ClusterRequestPhaseFailed
+		return ctrl.Result{}, err
+	}
+
+	// the cluster has already been provisioned, never recreate it
+	if clusterRequest.Status.ClusterDeployment != "" && !exists {
+		clusterRequest.Status.Phase = kcm.ClusterRequestPhaseExpired
+		return ctrl.Result{}, nil
+	}
+
+	if !exists {
+		cd, err = r.newClusterDeployment(clusterRequest)
+		if err == nil {
+			l.Info("Creating ClusterDeployment for the approved request", "ClusterDeployment", client.ObjectKeyFromObject(cd))
+			err = r.Create(ctx, cd)
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to create ClusterDeployment %s/%s: %w", clusterRequest.Namespace, clusterRequest.Name, err)
+			r.setProvisionedCondition(clusterRequest, err)
+			clusterRequest.Status.Phase = kcm.ClusterRequestPhaseFailed
+			return ctrl.Result{}, err
+		}
+	}
+
+	clusterRequest.Status.ClusterDeployment = cd.Name
+	clusterRequest.Status.ExpiresAt = cd.Status.ExpiresAt
+	clusterRequest.Status.Phase = kcm.ClusterRequestPhaseProvisioned
+	r.setProvisionedCondition(clusterRequest, nil)
+
+	return ctrl.Result{}, nil
+}
+
+// newClusterDeployment builds the ClusterDeployment for the approved ClusterRequest.
+func (*ClusterRequestReconciler) newClusterDeployment(clusterRequest *kcm.ClusterRequest) (*kcm.ClusterDeployment, error) {
+	credential := clusterRequest.Spec.Credential
+	if clusterRequest.Status.Approval.Credential != "" {
+		credential = clusterRequest.Status.Approval.Credential
+	}
+
+	config, err := clusterRequestConfig(clusterRequest.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterRequest.Name,
+			Namespace: clusterRequest.Namespace,
+			Labels: map[string]string{
+				kcm.ClusterRequestLabelKey: clusterRequest.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(clusterRequest, kcm.GroupVersion.WithKind(kcm.ClusterRequestKind)),
+			},
+		},
+		Spec: kcm.ClusterDeploymentSpec{
+			Template:   clusterRequest.Spec.Template,
+			Credential: credential,
+			Config:     config,
+			TTL:        clusterRequest.Spec.Duration,
+		},
+	}, nil
+}
+
+// clusterRequestConfig returns the ClusterDeployment configuration with the requested size applied.
+func clusterRequestConfig(spec kcm.ClusterRequestSpec) (*apiextensionsv1.JSON, error) {
+	if spec.Size == nil {
+		return spec.Config, nil
+	}
+
+	values := make(map[string]any)
+	if spec.Config != nil && len(spec.Config.Raw) > 0 {
+		if err := json.Unmarshal(spec.Config.Raw, &values); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		}
+	}
+
+	if spec.Size.ControlPlaneNumber > 0 {
+		values["controlPlaneNumber"] = spec.Size.ControlPlaneNumber
+	}
+	values["workersNumber"] = spec.Size.WorkersNumber
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	return &apiextensionsv1.JSON{Raw: raw}, nil
+}
+
+func (*ClusterRequestReconciler) setProvisionedCondition(clusterRequest *kcm.ClusterRequest, err error) {
+	condition := metav1.Condition{
+		Type:               kcm.ClusterRequestProvisionedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             kcm.SucceededReason,
+		Message:            "ClusterDeployment has been created",
+		ObservedGeneration: clusterRequest.Generation,
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kcm.FailedReason
+		condition.Message = err.Error()
+	}
+
+	apimeta.SetStatusCondition(&clusterRequest.Status.Conditions, condition)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterRequestReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.TypedOptions[ctrl.Request]{
+			RateLimiter: ratelimit.DefaultFastSlow(),
+		}).
+		For(&kcm.ClusterRequest{}).
+		Owns(&kcm.ClusterDeployment{}).
+		Complete(r)
+}
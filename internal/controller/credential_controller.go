@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/freeze"
 	"github.com/K0rdent/kcm/internal/utils"
 	"github.com/K0rdent/kcm/internal/utils/ratelimit"
 )
@@ -58,6 +59,17 @@ func (r *CredentialReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, cred.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		l.Info("Reconciliation is paused, only observing the status")
+		apimeta.SetStatusCondition(&cred.Status.Conditions, freeze.Condition(pausedMessage, cred.Generation))
+		return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, r.updateStatus(ctx, cred)
+	}
+	apimeta.RemoveStatusCondition(&cred.Status.Conditions, kcm.ReconciliationPausedCondition)
+
 	if updated, err := utils.AddKCMComponentLabel(ctx, r.Client, cred); updated || err != nil {
 		if err != nil {
 			l.Error(err, "adding component label")
@@ -0,0 +1,130 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/freeze"
+	"github.com/K0rdent/kcm/internal/utils/fleet"
+	"github.com/K0rdent/kcm/internal/utils/ratelimit"
+)
+
+// FleetKubeconfigReconciler maintains the Secret in each namespace holding the aggregate
+// kubeconfig of the ready ClusterDeployments of the namespace.
+// The requests are keyed by the namespace name.
+type FleetKubeconfigReconciler struct {
+	client.Client
+}
+
+func (r *FleetKubeconfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	l := ctrl.LoggerFrom(ctx)
+	l.Info("Fleet kubeconfig reconcile start")
+
+	namespace := req.Name
+
+	paused, _, err := freeze.Paused(ctx, r.Client, namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		l.Info("Reconciliation is paused, skipping")
+		return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, nil
+	}
+
+	clusterDeployments := new(kcm.ClusterDeploymentList)
+	if err := r.List(ctx, clusterDeployments, client.InNamespace(namespace)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list ClusterDeployments: %w", err)
+	}
+
+	var ready []kcm.ClusterDeployment
+	kubeconfigs := make(map[string][]byte)
+	for _, cd := range clusterDeployments.Items {
+		if fleet.Phase(&cd) != kcm.ClusterPhaseReady {
+			continue
+		}
+
+		secret := new(corev1.Secret)
+		if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: cd.Name + kubeconfigSecretSuffix}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return ctrl.Result{}, fmt.Errorf("failed to get kubeconfig Secret of ClusterDeployment %s/%s: %w", namespace, cd.Name, err)
+		}
+
+		ready = append(ready, cd)
+		kubeconfigs[cd.Name] = secret.Data[kubeconfigSecretKey]
+	}
+
+	kubeconfig, err := fleet.Kubeconfig(ready, kubeconfigs)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	secret := new(corev1.Secret)
+	secret.Name = kcm.FleetKubeconfigSecretName
+	secret.Namespace = namespace
+
+	if kubeconfig == nil {
+		if err := r.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete fleet kubeconfig Secret %s/%s: %w", namespace, secret.Name, err)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = make(map[string]string)
+		}
+		secret.Labels[kcm.KCMManagedLabelKey] = kcm.KCMManagedLabelValue
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = map[string][]byte{kcm.FleetKubeconfigSecretKey: kubeconfig}
+		return nil
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile fleet kubeconfig Secret %s/%s: %w", namespace, secret.Name, err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FleetKubeconfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	enqueueNamespace := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, o client.Object) []ctrl.Request {
+		return []ctrl.Request{{NamespacedName: client.ObjectKey{Name: o.GetNamespace()}}}
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("fleetkubeconfig").
+		WithOptions(controller.TypedOptions[ctrl.Request]{
+			RateLimiter: ratelimit.DefaultFastSlow(),
+		}).
+		Watches(&kcm.ClusterDeployment{}, enqueueNamespace).
+		Watches(&corev1.Secret{}, enqueueNamespace, builder.WithPredicates(predicate.NewPredicateFuncs(func(o client.Object) bool {
+			return strings.HasSuffix(o.GetName(), kubeconfigSecretSuffix)
+		}))).
+		Complete(r)
+}
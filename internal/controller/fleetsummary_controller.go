@@ -0,0 +1,112 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/utils/fleet"
+	"github.com/K0rdent/kcm/internal/utils/ratelimit"
+)
+
+// FleetSummaryReconciler maintains the FleetSummary object aggregating all ClusterDeployments.
+type FleetSummaryReconciler struct {
+	client.Client
+}
+
+func (r *FleetSummaryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	l := ctrl.LoggerFrom(ctx)
+	l.Info("FleetSummary reconcile start")
+
+	summary := new(kcm.FleetSummary)
+	if err := r.Get(ctx, req.NamespacedName, summary); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to get FleetSummary %s: %w", req.Name, err)
+		}
+
+		summary = &kcm.FleetSummary{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   req.Name,
+				Labels: map[string]string{kcm.GenericComponentNameLabel: kcm.GenericComponentLabelValueKCM},
+			},
+		}
+		if err := r.Create(ctx, summary); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create FleetSummary %s: %w", req.Name, err)
+		}
+		l.Info("Created FleetSummary")
+	}
+
+	clusterDeployments := new(kcm.ClusterDeploymentList)
+	if err := r.List(ctx, clusterDeployments); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list ClusterDeployments: %w", err)
+	}
+
+	templateProviders := make(map[client.ObjectKey][]string)
+	for _, cd := range clusterDeployments.Items {
+		key := client.ObjectKey{Namespace: cd.TemplateNamespace(), Name: cd.Spec.Template}
+		if _, ok := templateProviders[key]; ok {
+			continue
+		}
+
+		template := new(kcm.ClusterTemplate)
+		if err := r.Get(ctx, key, template); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("failed to get ClusterTemplate %s: %w", key, err)
+			}
+		}
+		templateProviders[key] = fleet.InfrastructureProviders(template.Status.Providers)
+	}
+
+	serviceCharts, err := fleet.ServiceCharts(ctx, r.Client, clusterDeployments.Items)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	summary.Status = fleet.Summarize(clusterDeployments.Items, templateProviders, serviceCharts)
+	now := metav1.Now()
+	summary.Status.LastUpdateTime = &now
+	if err := r.Status().Update(ctx, summary); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update FleetSummary %s status: %w", summary.Name, err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FleetSummaryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	enqueueSummary := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []ctrl.Request {
+		return []ctrl.Request{{NamespacedName: client.ObjectKey{Name: kcm.FleetSummaryName}}}
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.TypedOptions[ctrl.Request]{
+			RateLimiter: ratelimit.DefaultFastSlow(),
+		}).
+		For(&kcm.FleetSummary{}).
+		// the Management always exists, so the summary is created on the start
+		Watches(&kcm.Management{}, enqueueSummary).
+		Watches(&kcm.ClusterDeployment{}, enqueueSummary).
+		Watches(&kcm.ServiceTemplate{}, enqueueSummary).
+		Complete(r)
+}
@@ -0,0 +1,207 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/opencontainers/go-digest"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/freeze"
+	"github.com/K0rdent/kcm/internal/gitops"
+	"github.com/K0rdent/kcm/internal/utils"
+	"github.com/K0rdent/kcm/internal/utils/ratelimit"
+)
+
+// GitOpsExportReconciler reconciles a GitOpsExport object
+type GitOpsExportReconciler struct {
+	client.Client
+	SystemNamespace string
+}
+
+func (r *GitOpsExportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	l := ctrl.LoggerFrom(ctx)
+	l.Info("GitOpsExport reconcile start")
+
+	export := new(kcm.GitOpsExport)
+	if err := r.Get(ctx, req.NamespacedName, export); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, "")
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		l.Info("Reconciliation is paused, only observing the status")
+		apimeta.SetStatusCondition(&export.Status.Conditions, freeze.Condition(pausedMessage, export.Generation))
+		if err := r.Status().Update(ctx, export); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update GitOpsExport %s status: %w", export.Name, err)
+		}
+		return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, nil
+	}
+	apimeta.RemoveStatusCondition(&export.Status.Conditions, kcm.ReconciliationPausedCondition)
+
+	if updated, err := utils.AddKCMComponentLabel(ctx, r.Client, export); updated || err != nil {
+		if err != nil {
+			l.Error(err, "adding component label")
+		}
+		return ctrl.Result{}, err
+	}
+
+	if export.Spec.Suspend {
+		l.V(1).Info("GitOpsExport is suspended, skipping")
+		return ctrl.Result{}, nil
+	}
+
+	defer func() {
+		export.Status.ObservedGeneration = export.Generation
+		export.Status.Error = ""
+		if err != nil {
+			export.Status.Error = err.Error()
+		}
+
+		if serr := r.Status().Update(ctx, export); serr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to update GitOpsExport %s status: %w", export.Name, serr))
+		}
+	}()
+
+	clusterDeployments, err := r.listClusterDeployments(ctx, export.Spec.Namespaces)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	multiClusterServices := new(kcm.MultiClusterServiceList)
+	if err := r.List(ctx, multiClusterServices); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list MultiClusterServices: %w", err)
+	}
+
+	files, err := gitops.Files(clusterDeployments, multiClusterServices.Items)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to render manifests: %w", err)
+	}
+	archive, err := gitops.Archive(files)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to archive manifests: %w", err)
+	}
+
+	revision := digest.FromBytes(archive).String()
+	if revision == export.Status.Revision && export.Status.ObservedGeneration == export.Generation {
+		l.V(1).Info("Manifests are up to date", "revision", revision)
+		return ctrl.Result{}, nil
+	}
+
+	target, err := r.target(ctx, export.Spec.OCI)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	l.Info("Pushing manifests", "reference", target.Reference(), "revision", revision)
+	manifestDigest, err := gitops.Push(ctx, target, archive, map[string]string{
+		gitops.RevisionAnnotation: revision,
+		gitops.SourceAnnotation:   "kcm",
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := metav1.Now()
+	export.Status.Revision = revision
+	export.Status.Digest = manifestDigest
+	export.Status.LastExportTime = &now
+	export.Status.ExportedObjects = int32(len(files))
+
+	return ctrl.Result{}, nil
+}
+
+func (r *GitOpsExportReconciler) listClusterDeployments(ctx context.Context, namespaces []string) ([]kcm.ClusterDeployment, error) {
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	var clusterDeployments []kcm.ClusterDeployment
+	for _, namespace := range namespaces {
+		list := new(kcm.ClusterDeploymentList)
+		if err := r.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return nil, fmt.Errorf("failed to list ClusterDeployments: %w", err)
+		}
+		clusterDeployments = append(clusterDeployments, list.Items...)
+	}
+
+	return clusterDeployments, nil
+}
+
+// target returns the push target with the credentials from the referenced Secret.
+func (r *GitOpsExportReconciler) target(ctx context.Context, oci kcm.OCIExportTarget) (gitops.Target, error) {
+	target := gitops.Target{
+		URL:       oci.URL,
+		Tag:       oci.Tag,
+		PlainHTTP: oci.Insecure,
+	}
+	if target.Tag == "" {
+		target.Tag = kcm.DefaultGitOpsExportTag
+	}
+
+	if oci.SecretRef == nil {
+		return target, nil
+	}
+
+	secret := new(corev1.Secret)
+	key := client.ObjectKey{Namespace: r.SystemNamespace, Name: oci.SecretRef.Name}
+	if err := r.Get(ctx, key, secret); err != nil {
+		return target, fmt.Errorf("failed to get registry credentials Secret %s: %w", key, err)
+	}
+	target.Username = string(secret.Data["username"])
+	target.Password = string(secret.Data["password"])
+
+	return target, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GitOpsExportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	enqueueExports := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []ctrl.Request {
+		exports := new(kcm.GitOpsExportList)
+		if err := r.List(ctx, exports); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "failed to list GitOpsExports")
+			return nil
+		}
+
+		req := make([]ctrl.Request, 0, len(exports.Items))
+		for _, export := range exports.Items {
+			req = append(req, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&export)})
+		}
+
+		return req
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.TypedOptions[ctrl.Request]{
+			RateLimiter: ratelimit.DefaultFastSlow(),
+		}).
+		For(&kcm.GitOpsExport{}).
+		Watches(&kcm.ClusterDeployment{}, enqueueExports).
+		Watches(&kcm.MultiClusterService{}, enqueueExports).
+		Complete(r)
+}
@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -27,6 +29,8 @@ import (
 
 	kcmv1alpha1 "github.com/K0rdent/kcm/api/v1alpha1"
 	"github.com/K0rdent/kcm/internal/controller/backup"
+	"github.com/K0rdent/kcm/internal/freeze"
+	"github.com/K0rdent/kcm/internal/metrics"
 	"github.com/K0rdent/kcm/internal/utils/ratelimit"
 )
 
@@ -54,9 +58,27 @@ func (r *ManagementBackupReconciler) Reconcile(ctx context.Context, req ctrl.Req
 
 	mgmtBackup := new(kcmv1alpha1.ManagementBackup)
 	if err := r.Get(ctx, req.NamespacedName, mgmtBackup); err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.DeleteMetricBackupLastSuccess(req.Name)
+			return ctrl.Result{}, nil
+		}
 		l.Error(err, "unable to fetch ManagementBackup")
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		return ctrl.Result{}, err
+	}
+
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, "")
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		l.Info("Reconciliation is paused, only observing the status")
+		apimeta.SetStatusCondition(&mgmtBackup.Status.Conditions, freeze.Condition(pausedMessage, mgmtBackup.Generation))
+		if err := r.Status().Update(ctx, mgmtBackup); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update ManagementBackup %s status: %w", mgmtBackup.Name, err)
+		}
+		return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, nil
 	}
+	apimeta.RemoveStatusCondition(&mgmtBackup.Status.Conditions, kcmv1alpha1.ReconciliationPausedCondition)
 
 	res, err := r.internal.ReconcileBackup(ctx, mgmtBackup)
 	if err != nil {
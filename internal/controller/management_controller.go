@@ -53,10 +53,21 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/admissionpolicies"
+	"github.com/K0rdent/kcm/internal/admissionwebhooks"
+	"github.com/K0rdent/kcm/internal/argocd"
 	"github.com/K0rdent/kcm/internal/certmanager"
+	"github.com/K0rdent/kcm/internal/edge"
+	"github.com/K0rdent/kcm/internal/freeze"
 	"github.com/K0rdent/kcm/internal/helm"
+	"github.com/K0rdent/kcm/internal/integrations"
+	"github.com/K0rdent/kcm/internal/monitoring"
+	"github.com/K0rdent/kcm/internal/securitybaseline"
+	"github.com/K0rdent/kcm/internal/sveltos"
+	"github.com/K0rdent/kcm/internal/tuning"
 	"github.com/K0rdent/kcm/internal/utils"
 	"github.com/K0rdent/kcm/internal/utils/ratelimit"
+	"github.com/K0rdent/kcm/internal/webhookcerts"
 )
 
 // ManagementReconciler reconciles a Management object
@@ -67,10 +78,14 @@ type ManagementReconciler struct {
 	DynamicClient   *dynamic.DynamicClient
 	SystemNamespace string
 
+	// ClusterDeploymentInitialSync spreads the reconciles of the ClusterDeployments upon the start of their controller.
+	ClusterDeploymentInitialSync ratelimit.InitialSyncOptions
+
 	defaultRequeueTime time.Duration
 
 	CreateAccessManagement bool
 	IsDisabledValidation   bool // is webhook disabled set via the controller flags
+	AdmissionPolicies      bool // are the ValidatingAdmissionPolicies enabled set via the controller flags
 
 	sveltosDependentControllersStarted bool
 }
@@ -90,6 +105,21 @@ func (r *ManagementReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, "")
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		l.Info("Reconciliation is paused, only observing the status")
+		meta.SetStatusCondition(&management.Status.Conditions, freeze.Condition(pausedMessage, management.Generation))
+		if err := r.Client.Status().Update(ctx, management); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status for Management %s: %w", management.Name, err)
+		}
+		// the switch is turned off with the update of the Management triggering the reconciliation
+		return ctrl.Result{}, nil
+	}
+	meta.RemoveStatusCondition(&management.Status.Conditions, kcm.ReconciliationPausedCondition)
+
 	if !management.DeletionTimestamp.IsZero() {
 		l.Info("Deleting Management")
 		return r.Delete(ctx, management)
@@ -248,6 +278,10 @@ func (r *ManagementReconciler) Update(ctx context.Context, management *kcm.Manag
 	management.Status.ObservedGeneration = management.Generation
 	management.Status.Release = management.Spec.Release
 
+	if err := r.updateIntegrations(ctx, management); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
 	shouldRequeue, err := r.startDependentControllers(ctx, management)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -256,6 +290,26 @@ func (r *ManagementReconciler) Update(ctx context.Context, management *kcm.Manag
 		requeue = true
 	}
 
+	baselinePending, err := r.reconcileSecurityBaseline(ctx, management)
+	if err != nil {
+		errs = errors.Join(errs, fmt.Errorf("failed to reconcile security baseline: %w", err))
+	}
+	if baselinePending {
+		requeue = true
+	}
+
+	if err := r.reconcileAdmissionPolicies(ctx, management); err != nil {
+		errs = errors.Join(errs, fmt.Errorf("failed to reconcile admission policies: %w", err))
+	}
+
+	if err := admissionwebhooks.Apply(ctx, r.Client, management.Spec.AdmissionWebhooks); err != nil {
+		errs = errors.Join(errs, fmt.Errorf("failed to apply admission webhooks settings: %w", err))
+	}
+
+	if err := r.removeStaleArgoCDClusters(ctx, management); err != nil {
+		errs = errors.Join(errs, fmt.Errorf("failed to remove stale Argo CD cluster Secrets: %w", err))
+	}
+
 	setReadyCondition(management)
 
 	if err := r.Client.Status().Update(ctx, management); err != nil {
@@ -282,17 +336,54 @@ func (r *ManagementReconciler) startDependentControllers(ctx context.Context, ma
 	}
 
 	l := ctrl.LoggerFrom(ctx).WithValues("provider_name", kcm.ProviderSveltosName)
+	currentNamespace := utils.CurrentNamespace()
+
+	// Sveltos is not installed with the Edge profile, the services are delivered by the other engines
+	// and the MultiClusterServices are not supported
+	if edge.Enabled(management) {
+		l.Info("Sveltos is not installed with the Edge profile, so setting up controller for ClusterDeployment without it")
+		if err = (&ClusterDeploymentReconciler{
+			DynamicClient:   r.DynamicClient,
+			SystemNamespace: currentNamespace,
+			SveltosDisabled: true,
+			InitialSync:     r.ClusterDeploymentInitialSync,
+		}).SetupWithManager(r.Manager); err != nil {
+			return false, fmt.Errorf("failed to setup controller for ClusterDeployment: %w", err)
+		}
+		l.Info("Setup for ClusterDeployment controller successful")
+
+		r.sveltosDependentControllersStarted = true
+		return false, nil
+	}
+
+	// Sveltos is neither managed by KCM nor installed, so the services cannot be delivered
+	// and the MultiClusterServices are not supported until it is installed
+	if _, managed := management.Status.Components[kcm.ProviderSveltosName]; !managed && !management.Status.Integrations[integrations.Sveltos].Available {
+		l.Info("Sveltos is not installed, so setting up controller for ClusterDeployment without it")
+		if err = (&ClusterDeploymentReconciler{
+			DynamicClient:   r.DynamicClient,
+			SystemNamespace: currentNamespace,
+			SveltosDisabled: true,
+			InitialSync:     r.ClusterDeploymentInitialSync,
+		}).SetupWithManager(r.Manager); err != nil {
+			return false, fmt.Errorf("failed to setup controller for ClusterDeployment: %w", err)
+		}
+		l.Info("Setup for ClusterDeployment controller successful")
+
+		r.sveltosDependentControllersStarted = true
+		return false, nil
+	}
+
 	if !management.Status.Components[kcm.ProviderSveltosName].Success {
 		l.Info("Waiting for provider to be ready to setup contollers dependent on it")
 		return true, nil
 	}
 
-	currentNamespace := utils.CurrentNamespace()
-
 	l.Info("Provider has been successfully installed, so setting up controller for ClusterDeployment")
 	if err = (&ClusterDeploymentReconciler{
 		DynamicClient:   r.DynamicClient,
 		SystemNamespace: currentNamespace,
+		InitialSync:     r.ClusterDeploymentInitialSync,
 	}).SetupWithManager(r.Manager); err != nil {
 		return false, fmt.Errorf("failed to setup controller for ClusterDeployment: %w", err)
 	}
@@ -310,6 +401,29 @@ func (r *ManagementReconciler) startDependentControllers(ctx context.Context, ma
 	return false, nil
 }
 
+// updateIntegrations sets the availability of the optional integrations in the status of the given Management,
+// logging only the changes of the availability. The previous availability is kept if it is unknown.
+func (r *ManagementReconciler) updateIntegrations(ctx context.Context, management *kcm.Management) error {
+	l := ctrl.LoggerFrom(ctx)
+
+	statuses, err := integrations.Detect(r.Client.RESTMapper())
+	if err != nil {
+		err = fmt.Errorf("failed to detect the optional integrations: %w", err)
+	}
+
+	if management.Status.Integrations == nil {
+		management.Status.Integrations = make(map[string]kcm.IntegrationStatus, len(statuses))
+	}
+	for name, status := range statuses {
+		if previous, ok := management.Status.Integrations[name]; !ok || previous.Available != status.Available {
+			l.Info("Optional integration availability changed", "integration", name, "available", status.Available, "message", status.Message)
+		}
+		management.Status.Integrations[name] = status
+	}
+
+	return err
+}
+
 func (r *ManagementReconciler) cleanupRemovedComponents(ctx context.Context, management *kcm.Management) error {
 	var (
 		errs error
@@ -402,6 +516,58 @@ func (r *ManagementReconciler) ensureAccessManagement(ctx context.Context, mgmt
 	return nil
 }
 
+// reconcileSecurityBaseline deploys the security baseline to the clusters
+// or removes it if the baseline is not configured.
+func (r *ManagementReconciler) reconcileSecurityBaseline(ctx context.Context, mgmt *kcm.Management) (pending bool, _ error) {
+	if mgmt.Spec.SecurityBaseline == nil {
+		return false, securitybaseline.Delete(ctx, r.Client, r.SystemNamespace)
+	}
+
+	return securitybaseline.Reconcile(ctx, r.Client, r.SystemNamespace, &metav1.OwnerReference{
+		APIVersion: kcm.GroupVersion.String(),
+		Kind:       kcm.ManagementKind,
+		Name:       mgmt.Name,
+		UID:        mgmt.UID,
+	}, mgmt.Spec.SecurityBaseline)
+}
+
+// reconcileAdmissionPolicies maintains the ValidatingAdmissionPolicies of the structural checks
+// or removes them if the policies are disabled.
+func (r *ManagementReconciler) reconcileAdmissionPolicies(ctx context.Context, mgmt *kcm.Management) error {
+	if !r.AdmissionPolicies {
+		return admissionpolicies.Remove(ctx, r.Client)
+	}
+
+	return admissionpolicies.Reconcile(ctx, r.Client, &metav1.OwnerReference{
+		APIVersion: kcm.GroupVersion.String(),
+		Kind:       kcm.ManagementKind,
+		Name:       mgmt.Name,
+		UID:        mgmt.UID,
+	})
+}
+
+// removeStaleArgoCDClusters removes the Argo CD cluster Secrets if the integration is disabled
+// or located outside of the configured Argo CD namespace.
+// The Secrets of the clusters are maintained by the ClusterDeployment controller.
+func (r *ManagementReconciler) removeStaleArgoCDClusters(ctx context.Context, mgmt *kcm.Management) error {
+	secrets, err := argocd.ListClusterSecrets(ctx, r.Client, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range secrets {
+		if mgmt.Spec.ArgoCD != nil && secret.Namespace == mgmt.Spec.ArgoCD.Namespace {
+			continue
+		}
+		if err := r.Client.Delete(ctx, &secret); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete Argo CD cluster Secret %s: %w", client.ObjectKeyFromObject(&secret), err)
+		}
+		ctrl.LoggerFrom(ctx).Info("Removed stale Argo CD cluster Secret", "secret", client.ObjectKeyFromObject(&secret))
+	}
+
+	return nil
+}
+
 // checkProviderStatus checks the status of a provider associated with a given
 // ProviderTemplate name. Since there's no way to determine resource Kind from
 // the given template iterate over all possible provider types.
@@ -607,7 +773,7 @@ type component struct {
 	isCAPIProvider bool
 }
 
-func applyKCMDefaults(config *apiextensionsv1.JSON) (*apiextensionsv1.JSON, error) {
+func applyKCMDefaults(config *apiextensionsv1.JSON, tlsProfile string) (*apiextensionsv1.JSON, error) {
 	values := chartutil.Values{}
 	if config != nil && config.Raw != nil {
 		err := json.Unmarshal(config.Raw, &values)
@@ -626,6 +792,15 @@ func applyKCMDefaults(config *apiextensionsv1.JSON) (*apiextensionsv1.JSON, erro
 	}
 
 	chartutil.CoalesceTables(values, enforcedValues)
+
+	if tlsProfile != "" {
+		controllerValues, ok := values["controller"].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("failed to cast 'controller' (type %T) to map[string]any", values["controller"])
+		}
+		controllerValues["tlsProfile"] = tlsProfile
+	}
+
 	raw, err := json.Marshal(values)
 	if err != nil {
 		return nil, err
@@ -638,19 +813,29 @@ func getWrappedComponents(mgmt *kcm.Management, release *kcm.Release) ([]compone
 
 	kcmComponent := kcm.Component{}
 	capiComponent := kcm.Component{}
+	var veleroTuning *kcm.ComponentTuning
 	if mgmt.Spec.Core != nil {
 		kcmComponent = mgmt.Spec.Core.KCM
 		capiComponent = mgmt.Spec.Core.CAPI
+		veleroTuning = mgmt.Spec.Core.Velero
 	}
 
 	kcmComp := component{Component: kcmComponent, helmReleaseName: kcm.CoreKCMName}
 	if kcmComp.Template == "" {
 		kcmComp.Template = release.Spec.KCM.Template
 	}
-	kcmConfig, err := applyKCMDefaults(kcmComp.Config)
+	kcmConfig, err := applyKCMDefaults(kcmComp.Config, mgmt.Spec.TLSProfile)
 	if err != nil {
 		return nil, err
 	}
+	if kcmComp.Tuning != nil || veleroTuning != nil {
+		kcmConfig, err = applyTuning(kcmConfig, func(values map[string]any) error {
+			return tuning.ApplyKCM(values, kcmComp.Tuning, veleroTuning)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply the tuning of the %s component: %w", kcm.CoreKCMName, err)
+		}
+	}
 	kcmComp.Config = kcmConfig
 	components = append(components, kcmComp)
 
@@ -669,11 +854,21 @@ func getWrappedComponents(mgmt *kcm.Management, release *kcm.Release) ([]compone
 	if capiComp.Template == "" {
 		capiComp.Template = release.Spec.CAPI.Template
 	}
+	if capiComp.Tuning != nil {
+		if capiComp.Config, err = applyTuning(capiComp.Config, func(values map[string]any) error {
+			return tuning.ApplyCAPIProvider(values, capiComp.Tuning)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to apply the tuning of the %s component: %w", kcm.CoreCAPIName, err)
+		}
+	}
 	components = append(components, capiComp)
 
-	const sveltosTargetNamespace = "projectsveltos"
-
 	for _, p := range mgmt.Spec.Providers {
+		// Sveltos is never installed with the Edge profile
+		if p.Name == kcm.ProviderSveltosName && edge.Enabled(mgmt) {
+			continue
+		}
+
 		c := component{
 			Component: p.Component, helmReleaseName: p.Name,
 			dependsOn: []fluxmeta.NamespacedObjectReference{{Name: kcm.CoreCAPIName}}, isCAPIProvider: true,
@@ -685,20 +880,52 @@ func getWrappedComponents(mgmt *kcm.Management, release *kcm.Release) ([]compone
 
 		if p.Name == kcm.ProviderSveltosName {
 			c.isCAPIProvider = false
-			c.targetNamespace = sveltosTargetNamespace
+			c.targetNamespace = sveltos.Namespace
 			c.installSettings = &fluxv2.Install{
 				CreateNamespace: true,
 			}
 		}
 
+		if c.Tuning != nil {
+			applyProviderTuning := tuning.ApplyCAPIProvider
+			if p.Name == kcm.ProviderSveltosName {
+				applyProviderTuning = tuning.ApplySveltos
+			}
+			if c.Config, err = applyTuning(c.Config, func(values map[string]any) error {
+				return applyProviderTuning(values, c.Tuning)
+			}); err != nil {
+				return nil, fmt.Errorf("failed to apply the tuning of the %s provider: %w", p.Name, err)
+			}
+		}
+
 		components = append(components, c)
 	}
 
 	return components, nil
 }
 
+// applyTuning sets the tuning to the given component config with the given function.
+func applyTuning(config *apiextensionsv1.JSON, apply func(map[string]any) error) (*apiextensionsv1.JSON, error) {
+	values := make(map[string]any)
+	if config != nil && config.Raw != nil {
+		if err := json.Unmarshal(config.Raw, &values); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := apply(values); err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	return &apiextensionsv1.JSON{Raw: raw}, nil
+}
+
 // enableAdditionalComponents enables the admission controller and cluster api operator
-// once the cert manager is ready
+// once the cert manager is ready, and the monitoring stack if configured
 func (r *ManagementReconciler) enableAdditionalComponents(ctx context.Context, mgmt *kcm.Management) error {
 	l := ctrl.LoggerFrom(ctx)
 
@@ -713,6 +940,12 @@ func (r *ManagementReconciler) enableAdditionalComponents(ctx context.Context, m
 		}
 	}
 
+	if edge.Enabled(mgmt) {
+		if err := edge.ApplyValues(config); err != nil {
+			return fmt.Errorf("failed to set Edge profile values: %w", err)
+		}
+	}
+
 	admissionWebhookValues := make(map[string]any)
 	if config["admissionWebhook"] != nil {
 		v, ok := config["admissionWebhook"].(map[string]any)
@@ -743,8 +976,11 @@ func (r *ManagementReconciler) enableAdditionalComponents(ctx context.Context, m
 	}
 
 	if r.Config != nil {
-		if err := certmanager.VerifyAPI(ctx, r.Config, r.SystemNamespace); err != nil {
-			return fmt.Errorf("failed to check in the cert-manager API is installed: %w", err)
+		// the builtin webhook certificates do not require cert-manager
+		if admissionWebhookValues["certProvider"] != webhookcerts.ProviderBuiltin {
+			if err := certmanager.VerifyAPI(ctx, r.Config, r.SystemNamespace); err != nil {
+				return fmt.Errorf("failed to check in the cert-manager API is installed: %w", err)
+			}
 		}
 
 		// Enable KCM webhook only if it was not explicitly disabled in the config to
@@ -753,7 +989,7 @@ func (r *ManagementReconciler) enableAdditionalComponents(ctx context.Context, m
 			enabledV, found := admissionWebhookValues["enabled"]
 			enabledValue, castedOk := enabledV.(bool)
 			if !found || !castedOk || enabledValue {
-				l.Info("Cert manager is installed, enabling the KCM admission webhook")
+				l.Info("Enabling the KCM admission webhook")
 				admissionWebhookValues["enabled"] = true
 			} else {
 				l.Info("KCM admission webhook is disabled")
@@ -775,6 +1011,10 @@ func (r *ManagementReconciler) enableAdditionalComponents(ctx context.Context, m
 	}
 	config["cluster-api-operator"] = capiOperatorValues
 
+	if err := monitoring.ApplyValues(config, mgmt.Spec.Monitoring); err != nil {
+		return fmt.Errorf("failed to set monitoring values: %w", err)
+	}
+
 	updatedConfig, err := json.Marshal(config)
 	if err != nil {
 		return fmt.Errorf("failed to marshal KCM config: %w", err)
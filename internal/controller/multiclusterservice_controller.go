@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
 	sveltoscontrollers "github.com/projectsveltos/addon-controller/controllers"
@@ -29,6 +30,7 @@ import (
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -39,9 +41,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/freeze"
 	"github.com/K0rdent/kcm/internal/metrics"
+	"github.com/K0rdent/kcm/internal/servicebundle"
+	"github.com/K0rdent/kcm/internal/serviceconflicts"
+	"github.com/K0rdent/kcm/internal/servicehistory"
+	"github.com/K0rdent/kcm/internal/statemanagement"
 	"github.com/K0rdent/kcm/internal/sveltos"
 	"github.com/K0rdent/kcm/internal/utils"
+	"github.com/K0rdent/kcm/internal/utils/fleet"
 	"github.com/K0rdent/kcm/internal/utils/ratelimit"
 	"github.com/K0rdent/kcm/internal/utils/validation"
 )
@@ -49,6 +57,7 @@ import (
 // MultiClusterServiceReconciler reconciles a MultiClusterService object
 type MultiClusterServiceReconciler struct {
 	Client          client.Client
+	eventRecorder   record.EventRecorder
 	SystemNamespace string
 }
 
@@ -68,6 +77,16 @@ func (r *MultiClusterServiceReconciler) Reconcile(ctx context.Context, req ctrl.
 		return ctrl.Result{}, err
 	}
 
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, "")
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		l.Info("Reconciliation is paused, only observing the status")
+		return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, r.reconcilePaused(ctx, mcs, pausedMessage)
+	}
+	apimeta.RemoveStatusCondition(&mcs.Status.Conditions, kcm.ReconciliationPausedCondition)
+
 	if !mcs.DeletionTimestamp.IsZero() {
 		return r.reconcileDelete(ctx, mcs)
 	}
@@ -133,9 +152,15 @@ func (r *MultiClusterServiceReconciler) reconcileUpdate(ctx context.Context, mcs
 		return ctrl.Result{Requeue: true}, err // generation has not changed, need explicit requeue
 	}
 
+	if servicehistory.Requested(mcs) {
+		return ctrl.Result{}, r.rollbackServices(ctx, mcs)
+	}
+
 	r.initServicesConditions(mcs)
 
-	if err := validation.ServicesHaveValidTemplates(ctx, r.Client, mcs.Spec.ServiceSpec.Services, r.SystemNamespace); err != nil {
+	// MultiClusterServices can only include the ServiceBundles in the system namespace
+	services, bundleErr := servicebundle.Services(ctx, r.Client, r.SystemNamespace, mcs.Spec.ServiceSpec)
+	if err := errors.Join(bundleErr, validation.ServicesHaveValidTemplates(ctx, r.Client, services, r.SystemNamespace)); err != nil {
 		r.setCondition(mcs, kcm.ServicesReferencesValidationCondition, err)
 		l.Error(err, "failed to validate services reference valid ServiceTemplates, will not retrigger this error")
 		return ctrl.Result{}, r.updateStatus(ctx, mcs) // no reason to reconcile further
@@ -156,15 +181,20 @@ func (r *MultiClusterServiceReconciler) reconcileUpdate(ctx context.Context, mcs
 
 	// We are enforcing that MultiClusterService may only use
 	// ServiceTemplates that are present in the system namespace.
-	helmCharts, err := sveltos.GetHelmCharts(ctx, r.Client, r.SystemNamespace, mcs.Spec.ServiceSpec.Services)
+	helmCharts, err := sveltos.GetHelmCharts(ctx, r.Client, r.SystemNamespace, services)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	kustomizationRefs, err := sveltos.GetKustomizationRefs(ctx, r.Client, r.SystemNamespace, services)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	kustomizationRefs, err := sveltos.GetKustomizationRefs(ctx, r.Client, r.SystemNamespace, mcs.Spec.ServiceSpec.Services)
+	policyRefs, err := sveltos.GetPolicyRefs(ctx, r.Client, r.SystemNamespace, services)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	policyRefs, err := sveltos.GetPolicyRefs(ctx, r.Client, r.SystemNamespace, mcs.Spec.ServiceSpec.Services)
+
+	clusterSelector, err := r.clusterSelector(ctx, mcs)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -177,7 +207,7 @@ func (r *MultiClusterServiceReconciler) reconcileUpdate(ctx context.Context, mcs
 				Name:       mcs.Name,
 				UID:        mcs.UID,
 			},
-			LabelSelector:        mcs.Spec.ClusterSelector,
+			LabelSelector:        clusterSelector,
 			HelmCharts:           helmCharts,
 			KustomizationRefs:    kustomizationRefs,
 			PolicyRefs:           policyRefs,
@@ -193,7 +223,7 @@ func (r *MultiClusterServiceReconciler) reconcileUpdate(ctx context.Context, mcs
 		return ctrl.Result{}, fmt.Errorf("failed to reconcile ClusterProfile: %w", err)
 	}
 
-	for _, svc := range mcs.Spec.ServiceSpec.Services {
+	for _, svc := range services {
 		metrics.TrackMetricTemplateUsage(ctx, kcm.ServiceTemplateKind, svc.Template, kcm.MultiClusterServiceKind, mcs.ObjectMeta, true)
 	}
 
@@ -209,7 +239,7 @@ func (r *MultiClusterServiceReconciler) reconcileUpdate(ctx context.Context, mcs
 		return ctrl.Result{}, nil
 	}
 
-	if len(mcs.Spec.ServiceSpec.Services) == 0 {
+	if len(services) == 0 {
 		mcs.Status.Services = nil
 	} else {
 		var servicesStatus []kcm.ServiceStatus
@@ -219,9 +249,42 @@ func (r *MultiClusterServiceReconciler) reconcileUpdate(ctx context.Context, mcs
 		}
 		mcs.Status.Services = servicesStatus
 	}
+	mcs.Status.ServiceHistory = servicehistory.Record(mcs.Status.ServiceHistory, mcs.Spec.ServiceSpec.Services, mcs.Status.Services, time.Now())
 	return ctrl.Result{}, nil
 }
 
+// rollbackServices reverts the services of the given MultiClusterService across all the selected clusters
+// to the revisions requested with the [kcm.ServiceRollbackAnnotation] annotation,
+// the rejected requests are reported in an event and dropped.
+func (r *MultiClusterServiceReconciler) rollbackServices(ctx context.Context, mcs *kcm.MultiClusterService) error {
+	l := ctrl.LoggerFrom(ctx)
+
+	// update a copy not to override the status being reconciled
+	mcsCopy := mcs.DeepCopy()
+	delete(mcsCopy.Annotations, kcm.ServiceRollbackAnnotation)
+	reverted, validationErr := servicehistory.Apply(mcsCopy.Spec.ServiceSpec.Services, mcs.Status.ServiceHistory, mcs.Annotations[kcm.ServiceRollbackAnnotation])
+	if validationErr == nil {
+		// the ServiceTemplates of the revisions may have been removed since the revisions have been recorded
+		if err := validation.ServicesHaveValidTemplates(ctx, r.Client, mcsCopy.Spec.ServiceSpec.Services, r.SystemNamespace); err != nil {
+			validationErr = err
+			mcsCopy.Spec.ServiceSpec.Services = mcs.Spec.ServiceSpec.Services
+		}
+	}
+	if err := r.Client.Update(ctx, mcsCopy); err != nil {
+		return fmt.Errorf("failed to update MultiClusterService %s: %w", mcs.Name, err)
+	}
+
+	if validationErr != nil {
+		r.eventRecorder.Event(mcs, corev1.EventTypeWarning, kcm.ServiceRollbackFailedReason, "Rollback of services has been rejected: "+validationErr.Error())
+		return nil
+	}
+
+	l.Info("Rolled back services", "services", reverted)
+	r.eventRecorder.Event(mcs, corev1.EventTypeNormal, kcm.ServiceRolledBackReason, "Rolled back the services "+strings.Join(reverted, ", "))
+
+	return nil
+}
+
 // updateStatus updates the status for the MultiClusterService object.
 func (r *MultiClusterServiceReconciler) updateStatus(ctx context.Context, mcs *kcm.MultiClusterService) error {
 	if err := r.setClustersServicesReadinessConditions(ctx, mcs); err != nil {
@@ -238,12 +301,34 @@ func (r *MultiClusterServiceReconciler) updateStatus(ctx context.Context, mcs *k
 	return nil
 }
 
+// reconcilePaused reports the paused reconciliation of the given MultiClusterService in its status
+// without making any changes, the readiness of the clusters and the services is still observed.
+func (r *MultiClusterServiceReconciler) reconcilePaused(ctx context.Context, mcs *kcm.MultiClusterService, message string) error {
+	apimeta.SetStatusCondition(&mcs.Status.Conditions, freeze.Condition(message, mcs.Generation))
+
+	if err := r.setClustersServicesReadinessConditions(ctx, mcs); err != nil {
+		return fmt.Errorf("failed to set clusters and services readiness conditions: %w", err)
+	}
+	mcs.Status.Conditions = updateStatusConditions(mcs.Status.Conditions)
+
+	if err := r.Client.Status().Update(ctx, mcs); err != nil {
+		return fmt.Errorf("failed to update status for MultiClusterService %s: %w", mcs.Name, err)
+	}
+	return nil
+}
+
 // setClustersServicesReadinessConditions calculates and sets
 // [github.com/K0rdent/kcm/api/v1alpha1.ServicesInReadyStateCondition] and
 // [github.com/K0rdent/kcm/api/v1alpha1.ClusterInReadyStateCondition]
-// informational conditions with the number of ready services and clusters.
+// informational conditions with the number of ready services and clusters
+// along with the [github.com/K0rdent/kcm/api/v1alpha1.ServiceConflictCondition]
+// reporting the services also deployed to the matching clusters by other objects.
 func (r *MultiClusterServiceReconciler) setClustersServicesReadinessConditions(ctx context.Context, mcs *kcm.MultiClusterService) error {
-	sel, err := metav1.LabelSelectorAsSelector(&mcs.Spec.ClusterSelector)
+	clusterSelector, err := r.clusterSelector(ctx, mcs)
+	if err != nil {
+		return err
+	}
+	sel, err := metav1.LabelSelectorAsSelector(&clusterSelector)
 	if err != nil {
 		return fmt.Errorf("failed to construct selector from MultiClusterService %s selector: %w", client.ObjectKeyFromObject(mcs), err)
 	}
@@ -259,6 +344,7 @@ func (r *MultiClusterServiceReconciler) setClustersServicesReadinessConditions(c
 	}
 
 	ready := 0
+	conflicts := make(map[string][]serviceconflicts.Conflict, len(clusters.Items))
 	for _, cluster := range clusters.Items {
 		key := client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Name}
 		cld := new(kcm.ClusterDeployment)
@@ -266,13 +352,19 @@ func (r *MultiClusterServiceReconciler) setClustersServicesReadinessConditions(c
 			return fmt.Errorf("failed to get ClusterDeployment %s: %w", key.String(), err)
 		}
 
+		if conflicts[key.String()], err = serviceconflicts.ForCluster(ctx, r.Client, cld, r.SystemNamespace); err != nil {
+			return fmt.Errorf("failed to detect services conflicts on cluster %s: %w", key.String(), err)
+		}
+
 		rc := apimeta.FindStatusCondition(cld.Status.Conditions, kcm.ReadyCondition)
 		if rc != nil && rc.Status == metav1.ConditionTrue {
 			ready++
 		}
 	}
 
-	desiredClusters, desiredServices := len(clusters.Items), len(clusters.Items)*len(mcs.Spec.ServiceSpec.Services)
+	// the missing ServiceBundles are reported with the ServicesReferencesValidation condition
+	services, _ := servicebundle.Services(ctx, r.Client, r.SystemNamespace, mcs.Spec.ServiceSpec)
+	desiredClusters, desiredServices := len(clusters.Items), len(clusters.Items)*len(services)
 	c := metav1.Condition{
 		Type:    kcm.ClusterInReadyStateCondition,
 		Status:  metav1.ConditionTrue,
@@ -286,15 +378,41 @@ func (r *MultiClusterServiceReconciler) setClustersServicesReadinessConditions(c
 
 	apimeta.SetStatusCondition(&mcs.Status.Conditions, c)
 	apimeta.SetStatusCondition(&mcs.Status.Conditions, getServicesReadinessCondition(mcs.Status.Services, desiredServices))
+	owner := serviceconflicts.MultiClusterServiceOwner(mcs)
+	serviceconflicts.SetCondition(&mcs.Status.Conditions, owner, conflicts)
+	mcs.Status.ServiceConflicts = serviceconflicts.Status(owner, conflicts)
 
 	return nil
 }
 
+// clusterSelector returns the selector of the clusters of the given MultiClusterService with the constraint
+// of the Kubernetes version of the clusters expressed as the requirement of the minor Kubernetes versions
+// of the existing clusters satisfying the constraint, so the selector could be evaluated by Sveltos.
+func (r *MultiClusterServiceReconciler) clusterSelector(ctx context.Context, mcs *kcm.MultiClusterService) (metav1.LabelSelector, error) {
+	if mcs.Spec.KubernetesVersion == "" {
+		return mcs.Spec.ClusterSelector, nil
+	}
+
+	clusterDeployments := new(kcm.ClusterDeploymentList)
+	if err := r.Client.List(ctx, clusterDeployments); err != nil {
+		return metav1.LabelSelector{}, fmt.Errorf("failed to list ClusterDeployments: %w", err)
+	}
+
+	versions := make([]string, 0, len(clusterDeployments.Items))
+	for _, cd := range clusterDeployments.Items {
+		if version := fleet.MinorVersion(cd.Status.KubernetesVersion); version != "" {
+			versions = append(versions, version)
+		}
+	}
+
+	return fleet.KubernetesVersionSelector(mcs.Spec.ClusterSelector, mcs.Spec.KubernetesVersion, versions)
+}
+
 func getServicesReadinessCondition(serviceStatuses []kcm.ServiceStatus, desiredServices int) metav1.Condition {
 	ready := 0
 	for _, svcstatus := range serviceStatuses {
 		for _, c := range svcstatus.Conditions {
-			if strings.HasSuffix(c.Type, kcm.SveltosHelmReleaseReadyCondition) && c.Status == metav1.ConditionTrue {
+			if statemanagement.IsServiceCondition(c.Type) && c.Status == metav1.ConditionTrue {
 				ready++
 			}
 		}
@@ -331,6 +449,10 @@ func updateStatusConditions(conditions []metav1.Condition) []metav1.Condition {
 		if condition.Type == kcm.ReadyCondition {
 			continue
 		}
+		// the unknown expiry of the certificates and the dropped rotation request are informational
+		if condition.Reason == kcm.CertificatesExpiryUnknownReason || condition.Reason == kcm.CertificatesRotationUnsupportedReason {
+			continue
+		}
 		if condition.Status == metav1.ConditionUnknown {
 			_, _ = warnings.WriteString(condition.Message + ". ")
 		}
@@ -417,7 +539,8 @@ func (r *MultiClusterServiceReconciler) reconcileDelete(ctx context.Context, mcs
 
 	defer func() {
 		if err == nil {
-			for _, svc := range mcs.Spec.ServiceSpec.Services {
+			services, _ := servicebundle.Services(ctx, r.Client, r.SystemNamespace, mcs.Spec.ServiceSpec)
+			for _, svc := range services {
 				metrics.TrackMetricTemplateUsage(ctx, kcm.ServiceTemplateKind, svc.Template, kcm.MultiClusterServiceKind, mcs.ObjectMeta, false)
 			}
 		}
@@ -470,12 +593,13 @@ func requeueSveltosProfileForClusterSummary(ctx context.Context, obj client.Obje
 // SetupWithManager sets up the controller with the Manager.
 func (r *MultiClusterServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Client = mgr.GetClient()
+	r.eventRecorder = mgr.GetEventRecorderFor("multiclusterservice-controller")
 
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.TypedOptions[ctrl.Request]{
 			RateLimiter: ratelimit.DefaultFastSlow(),
 		}).
-		For(&kcm.MultiClusterService{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&kcm.MultiClusterService{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
 		Watches(&sveltosv1beta1.ClusterSummary{},
 			handler.EnqueueRequestsFromMapFunc(requeueSveltosProfileForClusterSummary),
 			builder.WithPredicates(predicate.Funcs{
@@ -483,5 +607,61 @@ func (r *MultiClusterServiceReconciler) SetupWithManager(mgr ctrl.Manager) error
 				GenericFunc: func(event.GenericEvent) bool { return false },
 			}),
 		).
+		Watches(&kcm.ClusterDeployment{},
+			handler.EnqueueRequestsFromMapFunc(r.requeueForKubernetesVersion),
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					oldCD, oldOk := e.ObjectOld.(*kcm.ClusterDeployment)
+					newCD, newOk := e.ObjectNew.(*kcm.ClusterDeployment)
+					return oldOk && newOk && oldCD.Status.KubernetesVersion != newCD.Status.KubernetesVersion
+				},
+				DeleteFunc:  func(event.DeleteEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+			}),
+		).
+		Watches(&kcm.ServiceBundle{},
+			handler.EnqueueRequestsFromMapFunc(r.requeueForServiceBundle),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		Complete(r)
 }
+
+// requeueForServiceBundle requeues the MultiClusterServices including the given ServiceBundle.
+func (r *MultiClusterServiceReconciler) requeueForServiceBundle(ctx context.Context, o client.Object) []ctrl.Request {
+	// MultiClusterServices can only include the ServiceBundles in the system namespace
+	if o.GetNamespace() != r.SystemNamespace {
+		return nil
+	}
+
+	mcsList := new(kcm.MultiClusterServiceList)
+	if err := r.Client.List(ctx, mcsList, client.MatchingFields{kcm.MultiClusterServiceBundlesIndexKey: o.GetName()}); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to list MultiClusterServices")
+		return nil
+	}
+
+	requests := make([]ctrl.Request, 0, len(mcsList.Items))
+	for _, mcs := range mcsList.Items {
+		requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&mcs)})
+	}
+
+	return requests
+}
+
+// requeueForKubernetesVersion requeues the MultiClusterServices selecting the clusters
+// by the Kubernetes version on the change of the Kubernetes version of a cluster.
+func (r *MultiClusterServiceReconciler) requeueForKubernetesVersion(ctx context.Context, _ client.Object) []ctrl.Request {
+	mcsList := new(kcm.MultiClusterServiceList)
+	if err := r.Client.List(ctx, mcsList); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to list MultiClusterServices")
+		return nil
+	}
+
+	var requests []ctrl.Request
+	for _, mcs := range mcsList.Items {
+		if mcs.Spec.KubernetesVersion != "" {
+			requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&mcs)})
+		}
+	}
+
+	return requests
+}
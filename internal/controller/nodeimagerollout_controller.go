@@ -0,0 +1,145 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/freeze"
+	"github.com/K0rdent/kcm/internal/nodeimagerollout"
+	"github.com/K0rdent/kcm/internal/utils"
+	"github.com/K0rdent/kcm/internal/utils/ratelimit"
+)
+
+// NodeImageRolloutReconciler reconciles a NodeImageRollout object
+type NodeImageRolloutReconciler struct {
+	client.Client
+}
+
+func (r *NodeImageRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	l := ctrl.LoggerFrom(ctx)
+	l.Info("NodeImageRollout reconcile start")
+
+	rollout := new(kcm.NodeImageRollout)
+	if err := r.Get(ctx, req.NamespacedName, rollout); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, "")
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		l.Info("Reconciliation is paused, only observing the status")
+		return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, r.reconcilePaused(ctx, rollout, pausedMessage)
+	}
+	apimeta.RemoveStatusCondition(&rollout.Status.Conditions, kcm.ReconciliationPausedCondition)
+
+	if updated, err := utils.AddKCMComponentLabel(ctx, r.Client, rollout); updated || err != nil {
+		if err != nil {
+			l.Error(err, "adding component label")
+		}
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		rollout.Status.ObservedGeneration = rollout.Generation
+		rollout.Status.Error = ""
+		if err != nil {
+			rollout.Status.Error = err.Error()
+		}
+
+		if serr := r.Status().Update(ctx, rollout); serr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to update NodeImageRollout %s status: %w", rollout.Name, serr))
+		}
+	}()
+
+	requeueAfter, err := nodeimagerollout.Reconcile(ctx, r.Client, rollout, time.Now())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcilePaused reports the paused reconciliation of the given NodeImageRollout in its status.
+// The phases of the rollouts to the clusters are still observed, the rollouts to the pending clusters
+// are held as with the paused rollout since they are started by the phases set in the status.
+func (r *NodeImageRolloutReconciler) reconcilePaused(ctx context.Context, rollout *kcm.NodeImageRollout, message string) error {
+	held := rollout.DeepCopy()
+	held.Spec.Paused = true
+	_, err := nodeimagerollout.Reconcile(ctx, r.Client, held, time.Now())
+
+	rollout.Status = held.Status
+	rollout.Status.Error = ""
+	if err != nil {
+		rollout.Status.Error = err.Error()
+	}
+	apimeta.SetStatusCondition(&rollout.Status.Conditions, freeze.Condition(message, rollout.Generation))
+
+	if err := r.Status().Update(ctx, rollout); err != nil {
+		return fmt.Errorf("failed to update NodeImageRollout %s status: %w", rollout.Name, err)
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NodeImageRolloutReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.TypedOptions[ctrl.Request]{
+			RateLimiter: ratelimit.DefaultFastSlow(),
+		}).
+		For(&kcm.NodeImageRollout{}).
+		Watches(&kcm.OSImage{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []ctrl.Request {
+				return r.requestsFor(ctx, func(rollout *kcm.NodeImageRollout) bool { return rollout.Spec.OSImage == o.GetName() })
+			}),
+		).
+		Watches(&kcm.ClusterDeployment{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []ctrl.Request {
+				// the rollouts are few, the selectors are evaluated during the reconciliation
+				return r.requestsFor(ctx, func(*kcm.NodeImageRollout) bool { return true })
+			}),
+		).
+		Complete(r)
+}
+
+// requestsFor returns the requests of the NodeImageRollouts matching the given predicate.
+func (r *NodeImageRolloutReconciler) requestsFor(ctx context.Context, match func(*kcm.NodeImageRollout) bool) []ctrl.Request {
+	rollouts := new(kcm.NodeImageRolloutList)
+	if err := r.List(ctx, rollouts); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to list NodeImageRollouts")
+		return nil
+	}
+
+	var req []ctrl.Request
+	for _, rollout := range rollouts.Items {
+		if match(&rollout) {
+			req = append(req, ctrl.Request{NamespacedName: client.ObjectKey{Name: rollout.Name}})
+		}
+	}
+
+	return req
+}
@@ -0,0 +1,114 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/osimage"
+	"github.com/K0rdent/kcm/internal/utils"
+	"github.com/K0rdent/kcm/internal/utils/ratelimit"
+)
+
+// defaultDiscoveryInterval is the interval the images are discovered at if not set.
+const defaultDiscoveryInterval = 6 * time.Hour
+
+// OSImageReconciler reconciles an OSImage object
+type OSImageReconciler struct {
+	client.Client
+	HTTPClient *http.Client
+}
+
+func (r *OSImageReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	l := ctrl.LoggerFrom(ctx)
+	l.Info("OSImage reconcile start")
+
+	catalog := new(kcm.OSImage)
+	if err := r.Get(ctx, req.NamespacedName, catalog); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if updated, err := utils.AddKCMComponentLabel(ctx, r.Client, catalog); updated || err != nil {
+		if err != nil {
+			l.Error(err, "adding component label")
+		}
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		catalog.Status.Error = ""
+		if err != nil {
+			catalog.Status.Error = err.Error()
+		} else {
+			// the generation is observed once the images are discovered for it
+			catalog.Status.ObservedGeneration = catalog.Generation
+		}
+
+		if serr := r.Status().Update(ctx, catalog); serr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to update OSImage %s status: %w", catalog.Name, serr))
+		}
+	}()
+
+	discovery := catalog.Spec.Discovery
+	if discovery == nil {
+		catalog.Status.Images = slices.Clone(catalog.Spec.Images)
+		catalog.Status.LastDiscoveryTime = nil
+		return ctrl.Result{}, nil
+	}
+
+	interval := discovery.Interval.Duration
+	if interval <= 0 {
+		interval = defaultDiscoveryInterval
+	}
+
+	// the images are rediscovered once the spec changes since the discovered images are not kept separately
+	if last := catalog.Status.LastDiscoveryTime; last != nil && catalog.Generation == catalog.Status.ObservedGeneration {
+		if remaining := time.Until(last.Add(interval)); remaining > 0 {
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	}
+
+	discovered, err := osimage.Discover(ctx, r.HTTPClient, discovery.URL)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	catalog.Status.Images = append(slices.Clone(catalog.Spec.Images), discovered...)
+	catalog.Status.LastDiscoveryTime = &metav1.Time{Time: time.Now()}
+	l.Info("Discovered images", "count", len(discovered))
+
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OSImageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.TypedOptions[ctrl.Request]{
+			RateLimiter: ratelimit.DefaultFastSlow(),
+		}).
+		For(&kcm.OSImage{}).
+		Complete(r)
+}
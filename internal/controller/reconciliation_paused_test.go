@@ -0,0 +1,314 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/controller/backup"
+	"github.com/K0rdent/kcm/internal/freeze"
+	am "github.com/K0rdent/kcm/test/objects/accessmanagement"
+	"github.com/K0rdent/kcm/test/objects/clusterrequest"
+	"github.com/K0rdent/kcm/test/objects/management"
+	"github.com/K0rdent/kcm/test/objects/release"
+	"github.com/K0rdent/kcm/test/objects/template"
+	tc "github.com/K0rdent/kcm/test/objects/templatechain"
+	"github.com/K0rdent/kcm/test/objects/vippool"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestReconcilePaused(t *testing.T) {
+	const systemNamespace = "kcm-system"
+
+	// the namespace annotation overrides the Management switch
+	pausedNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        metav1.NamespaceDefault,
+		Annotations: map[string]string{kcm.ReconciliationPausedAnnotation: "true"},
+	}}
+
+	for _, tt := range []struct {
+		name string
+		// obj is the reconciled object expected to report the paused reconciliation, if any
+		obj       client.Object
+		objects   []client.Object
+		reconcile func(context.Context, client.Client, ctrl.Request) (ctrl.Result, error)
+		// request overrides the request of the reconciled object
+		request *ctrl.Request
+		check   func(*WithT, client.Client, client.Object)
+	}{
+		{
+			name: "ClusterTemplate",
+			obj:  template.NewClusterTemplate(template.WithNamespace(metav1.NamespaceDefault)),
+			reconcile: func(ctx context.Context, cl client.Client, req ctrl.Request) (ctrl.Result, error) {
+				return (&ClusterTemplateReconciler{TemplateReconciler: TemplateReconciler{Client: cl}}).Reconcile(ctx, req)
+			},
+		},
+		{
+			name: "ProviderTemplate",
+			obj:  template.NewProviderTemplate(),
+			reconcile: func(ctx context.Context, cl client.Client, req ctrl.Request) (ctrl.Result, error) {
+				return (&ProviderTemplateReconciler{TemplateReconciler: TemplateReconciler{Client: cl}}).Reconcile(ctx, req)
+			},
+		},
+		{
+			name: "ServiceTemplate observing the references",
+			obj:  template.NewServiceTemplate(template.WithName("ingress-nginx"), template.WithNamespace(metav1.NamespaceDefault)),
+			objects: []client.Object{&kcm.ClusterDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: metav1.NamespaceDefault},
+				Spec: kcm.ClusterDeploymentSpec{ServiceSpec: kcm.ServiceSpec{
+					Services: []kcm.Service{{Name: "ingress", Template: "ingress-nginx"}},
+				}},
+			}},
+			reconcile: func(ctx context.Context, cl client.Client, req ctrl.Request) (ctrl.Result, error) {
+				return (&ServiceTemplateReconciler{TemplateReconciler: TemplateReconciler{Client: cl, SystemNamespace: systemNamespace}}).Reconcile(ctx, req)
+			},
+			check: func(g *WithT, _ client.Client, obj client.Object) {
+				serviceTemplate := obj.(*kcm.ServiceTemplate)
+				g.Expect(serviceTemplate.Finalizers).To(BeEmpty())
+				g.Expect(serviceTemplate.Status.ReferencedBy).To(Equal([]kcm.ServiceTemplateReference{
+					{Kind: kcm.ClusterDeploymentKind, Namespace: metav1.NamespaceDefault, Name: "cluster"},
+				}))
+			},
+		},
+		{
+			name: "ClusterTemplateChain observing the missing templates",
+			obj: tc.NewClusterTemplateChain(tc.WithName("chain"), tc.WithNamespace(metav1.NamespaceDefault), tc.ManagedByKCM(),
+				tc.WithSupportedTemplates([]kcm.SupportedTemplate{{Name: "aws-standalone-cp-1-0-0"}})),
+			reconcile: func(ctx context.Context, cl client.Client, req ctrl.Request) (ctrl.Result, error) {
+				r := &ClusterTemplateChainReconciler{TemplateChainReconciler: TemplateChainReconciler{
+					Client: cl, SystemNamespace: systemNamespace, templateKind: kcm.ClusterTemplateKind,
+				}}
+				return r.Reconcile(ctx, req)
+			},
+			check: func(g *WithT, cl client.Client, obj client.Object) {
+				g.Expect(obj.(*kcm.ClusterTemplateChain).Status.ValidationErrors).To(ConsistOf(ContainSubstring("is not found")))
+				templates := new(kcm.ClusterTemplateList)
+				g.Expect(cl.List(t.Context(), templates, client.InNamespace(metav1.NamespaceDefault))).To(Succeed())
+				g.Expect(templates.Items).To(BeEmpty())
+			},
+		},
+		{
+			name: "Release observing the templates validity",
+			obj:  release.New(),
+			reconcile: func(ctx context.Context, cl client.Client, req ctrl.Request) (ctrl.Result, error) {
+				return (&ReleaseReconciler{Client: cl, CreateTemplates: true, SystemNamespace: systemNamespace}).Reconcile(ctx, req)
+			},
+			check: func(g *WithT, _ client.Client, obj client.Object) {
+				rel := obj.(*kcm.Release)
+				g.Expect(rel.Status.Ready).To(BeFalse())
+				g.Expect(apimeta.IsStatusConditionFalse(rel.Status.Conditions, kcm.TemplatesValidCondition)).To(BeTrue())
+			},
+		},
+		{
+			name: "AccessManagement",
+			obj:  am.NewAccessManagement(),
+			reconcile: func(ctx context.Context, cl client.Client, req ctrl.Request) (ctrl.Result, error) {
+				return (&AccessManagementReconciler{Client: cl, SystemNamespace: systemNamespace}).Reconcile(ctx, req)
+			},
+		},
+		{
+			name:    "ClusterRequest paused in the namespace",
+			obj:     clusterrequest.NewClusterRequest(clusterrequest.WithApproval(kcm.ClusterRequestDecisionApproved, "aws-cred")),
+			objects: []client.Object{pausedNamespace},
+			reconcile: func(ctx context.Context, cl client.Client, req ctrl.Request) (ctrl.Result, error) {
+				return (&ClusterRequestReconciler{Client: cl}).Reconcile(ctx, req)
+			},
+			check: func(g *WithT, cl client.Client, obj client.Object) {
+				g.Expect(obj.(*kcm.ClusterRequest).Status.Phase).To(BeEmpty())
+				g.Expect(cl.Get(t.Context(), client.ObjectKeyFromObject(obj), new(kcm.ClusterDeployment))).NotTo(Succeed())
+			},
+		},
+		{
+			name: "SecretPropagation",
+			obj:  &kcm.SecretPropagation{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "registry"}},
+			reconcile: func(ctx context.Context, cl client.Client, req ctrl.Request) (ctrl.Result, error) {
+				return (&SecretPropagationReconciler{Client: cl}).Reconcile(ctx, req)
+			},
+			check: func(g *WithT, _ client.Client, obj client.Object) {
+				g.Expect(obj.GetFinalizers()).To(BeEmpty())
+			},
+		},
+		{
+			name: "NodeImageRollout",
+			obj: &kcm.NodeImageRollout{
+				ObjectMeta: metav1.ObjectMeta{Name: "ubuntu"},
+				Spec:       kcm.NodeImageRolloutSpec{OSImage: "ubuntu"},
+			},
+			objects: []client.Object{
+				&kcm.OSImage{ObjectMeta: metav1.ObjectMeta{Name: "ubuntu"}},
+			},
+			reconcile: func(ctx context.Context, cl client.Client, req ctrl.Request) (ctrl.Result, error) {
+				return (&NodeImageRolloutReconciler{Client: cl}).Reconcile(ctx, req)
+			},
+			check: func(g *WithT, _ client.Client, obj client.Object) {
+				rollout := obj.(*kcm.NodeImageRollout)
+				g.Expect(rollout.Spec.Paused).To(BeFalse())
+				g.Expect(rollout.Status.Error).To(BeEmpty())
+			},
+		},
+		{
+			name: "VIPPool releasing the stale allocations",
+			obj: func() client.Object {
+				pool := vippool.NewVIPPool(vippool.WithAddresses("10.0.0.10-10.0.0.11"))
+				pool.Status.Allocations = []kcm.VIPAllocation{{Address: "10.0.0.10", ClusterDeployment: "default/deleted"}}
+				return pool
+			}(),
+			reconcile: func(ctx context.Context, cl client.Client, req ctrl.Request) (ctrl.Result, error) {
+				return (&VIPPoolReconciler{Client: cl}).Reconcile(ctx, req)
+			},
+			check: func(g *WithT, _ client.Client, obj client.Object) {
+				pool := obj.(*kcm.VIPPool)
+				g.Expect(pool.Status.Allocations).To(BeEmpty())
+				g.Expect(pool.Status.ObservedGeneration).To(Equal(pool.Generation))
+			},
+		},
+		{
+			name: "ManagementBackup",
+			obj:  &kcm.ManagementBackup{ObjectMeta: metav1.ObjectMeta{Name: "backup"}},
+			reconcile: func(ctx context.Context, cl client.Client, req ctrl.Request) (ctrl.Result, error) {
+				r := &ManagementBackupReconciler{Client: cl, SystemNamespace: systemNamespace}
+				r.internal = backup.NewReconciler(cl, systemNamespace)
+				return r.Reconcile(ctx, req)
+			},
+			check: func(g *WithT, _ client.Client, obj client.Object) {
+				g.Expect(obj.(*kcm.ManagementBackup).Status.LastBackupName).To(BeEmpty())
+			},
+		},
+		{
+			name: "GitOpsExport",
+			obj: &kcm.GitOpsExport{
+				ObjectMeta: metav1.ObjectMeta{Name: "export"},
+				Spec:       kcm.GitOpsExportSpec{OCI: kcm.OCIExportTarget{URL: "oci://127.0.0.1:1/kcm-state"}},
+			},
+			reconcile: func(ctx context.Context, cl client.Client, req ctrl.Request) (ctrl.Result, error) {
+				return (&GitOpsExportReconciler{Client: cl, SystemNamespace: systemNamespace}).Reconcile(ctx, req)
+			},
+			check: func(g *WithT, _ client.Client, obj client.Object) {
+				g.Expect(obj.(*kcm.GitOpsExport).Status.Revision).To(BeEmpty())
+			},
+		},
+		{
+			name: "fleet kubeconfig paused in the namespace",
+			objects: []client.Object{pausedNamespace, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: kcm.FleetKubeconfigSecretName, Namespace: metav1.NamespaceDefault},
+			}},
+			request: &ctrl.Request{NamespacedName: client.ObjectKey{Name: metav1.NamespaceDefault}},
+			reconcile: func(ctx context.Context, cl client.Client, req ctrl.Request) (ctrl.Result, error) {
+				return (&FleetKubeconfigReconciler{Client: cl}).Reconcile(ctx, req)
+			},
+			check: func(g *WithT, cl client.Client, _ client.Object) {
+				// the stale Secret is not deleted while paused
+				key := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: kcm.FleetKubeconfigSecretName}
+				g.Expect(cl.Get(t.Context(), key, new(corev1.Secret))).To(Succeed())
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := t.Context()
+
+			paused := true
+			for _, obj := range tt.objects {
+				if obj == pausedNamespace {
+					paused = false // paused in the namespace only
+				}
+			}
+
+			objects := append([]client.Object{management.NewManagement(management.WithReconciliationPaused(paused))}, tt.objects...)
+			if tt.obj != nil {
+				objects = append(objects, tt.obj)
+			}
+			cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).
+				WithObjects(objects...).
+				WithStatusSubresource(objects...).
+				WithIndex(&kcm.ClusterDeployment{}, kcm.ClusterDeploymentServiceTemplatesIndexKey, kcm.ExtractServiceTemplateNamesFromClusterDeployment).
+				WithIndex(&kcm.ClusterDeployment{}, kcm.ClusterDeploymentVIPPoolIndexKey, kcm.ExtractVIPPoolFromClusterDeployment).
+				WithIndex(&kcm.ProviderTemplate{}, kcm.OwnerRefIndexKey, func(o client.Object) []string {
+					var owners []string
+					for _, ref := range o.GetOwnerReferences() {
+						owners = append(owners, ref.Name)
+					}
+					return owners
+				}).
+				Build()
+
+			req := tt.request
+			if req == nil {
+				req = &ctrl.Request{NamespacedName: client.ObjectKeyFromObject(tt.obj)}
+			}
+			result, err := tt.reconcile(ctx, cl, *req)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(result).To(Equal(ctrl.Result{RequeueAfter: freeze.RequeueInterval}))
+
+			var got client.Object
+			if tt.obj != nil {
+				got = tt.obj.DeepCopyObject().(client.Object)
+				g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(tt.obj), got)).To(Succeed())
+				g.Expect(got.GetLabels()).NotTo(HaveKey(kcm.GenericComponentNameLabel), "the paused object must not be changed")
+
+				condition := findPausedCondition(got)
+				g.Expect(condition).NotTo(BeNil())
+				g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				g.Expect(condition.Reason).To(Equal(kcm.ReconciliationPausedReason))
+			}
+
+			if tt.check != nil {
+				tt.check(g, cl, got)
+			}
+		})
+	}
+}
+
+// findPausedCondition returns the ReconciliationPaused condition of the given object.
+func findPausedCondition(obj client.Object) *metav1.Condition {
+	var conditions []metav1.Condition
+	switch o := obj.(type) {
+	case *kcm.ClusterTemplate:
+		conditions = o.Status.Conditions
+	case *kcm.ProviderTemplate:
+		conditions = o.Status.Conditions
+	case *kcm.ServiceTemplate:
+		conditions = o.Status.Conditions
+	case *kcm.ClusterTemplateChain:
+		conditions = o.Status.Conditions
+	case *kcm.Release:
+		conditions = o.Status.Conditions
+	case *kcm.AccessManagement:
+		conditions = o.Status.Conditions
+	case *kcm.ClusterRequest:
+		conditions = o.Status.Conditions
+	case *kcm.SecretPropagation:
+		conditions = o.Status.Conditions
+	case *kcm.NodeImageRollout:
+		conditions = o.Status.Conditions
+	case *kcm.VIPPool:
+		conditions = o.Status.Conditions
+	case *kcm.ManagementBackup:
+		conditions = o.Status.Conditions
+	case *kcm.GitOpsExport:
+		conditions = o.Status.Conditions
+	}
+	return apimeta.FindStatusCondition(conditions, kcm.ReconciliationPausedCondition)
+}
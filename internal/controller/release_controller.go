@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -48,6 +49,7 @@ import (
 
 	kcm "github.com/K0rdent/kcm/api/v1alpha1"
 	"github.com/K0rdent/kcm/internal/build"
+	"github.com/K0rdent/kcm/internal/freeze"
 	"github.com/K0rdent/kcm/internal/helm"
 	"github.com/K0rdent/kcm/internal/providers"
 	"github.com/K0rdent/kcm/internal/utils"
@@ -65,6 +67,9 @@ type ReleaseReconciler struct {
 
 	DefaultRegistryConfig helm.DefaultRegistryConfig
 
+	// ManagementProfile is the profile of the Management created upon initial installation.
+	ManagementProfile string
+
 	CreateManagement bool
 	CreateRelease    bool
 	CreateTemplates  bool
@@ -85,6 +90,15 @@ func (r *ReleaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		return ctrl.Result{}, nil
 	}
 
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, "")
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused && req.Name == "" {
+		l.Info("Reconciliation is paused, skipping the initial installation")
+		return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, nil
+	}
+
 	release := &kcm.Release{}
 	if req.Name != "" {
 		err := r.Get(ctx, req.NamespacedName, release)
@@ -97,6 +111,12 @@ func (r *ReleaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 			return ctrl.Result{}, err
 		}
 
+		if paused {
+			l.Info("Reconciliation is paused, only observing the status")
+			return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, r.reconcilePaused(ctx, release, pausedMessage)
+		}
+		meta.RemoveStatusCondition(&release.Status.Conditions, kcm.ReconciliationPausedCondition)
+
 		if updated, err := utils.AddKCMComponentLabel(ctx, r.Client, release); updated || err != nil {
 			if err != nil {
 				l.Error(err, "adding component label")
@@ -142,6 +162,26 @@ func (r *ReleaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	return ctrl.Result{}, nil
 }
 
+// reconcilePaused reports the paused reconciliation of the given Release in its status without creating
+// the templates, the validity of the provider templates of the Release is still observed.
+func (r *ReleaseReconciler) reconcilePaused(ctx context.Context, release *kcm.Release, message string) error {
+	meta.SetStatusCondition(&release.Status.Conditions, freeze.Condition(message, release.Generation))
+
+	err := r.validateProviderTemplates(ctx, release.Name, release.Templates())
+	release.Status.Ready = true
+	updateTemplatesValidCondition(release, err)
+	for _, condition := range release.Status.Conditions {
+		if condition.Status != metav1.ConditionTrue {
+			release.Status.Ready = false
+		}
+	}
+
+	if err := r.Status().Update(ctx, release); err != nil {
+		return fmt.Errorf("failed to update Release %s status: %w", release.Name, err)
+	}
+	return nil
+}
+
 func (r *ReleaseReconciler) validateProviderTemplates(ctx context.Context, releaseName string, expectedTemplates []string) error {
 	providerTemplates := &kcm.ProviderTemplateList{}
 	if err := r.List(ctx, providerTemplates, client.MatchingFields{kcm.OwnerRefIndexKey: releaseName}); err != nil {
@@ -224,7 +264,14 @@ func (r *ReleaseReconciler) ensureManagement(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	mgmtObj.Spec.Profile = r.ManagementProfile
 	mgmtObj.Spec.Providers = providers.List()
+	if r.ManagementProfile == kcm.ManagementProfileEdge {
+		// Sveltos is not installed with the Edge profile
+		mgmtObj.Spec.Providers = slices.DeleteFunc(mgmtObj.Spec.Providers, func(p kcm.Provider) bool {
+			return p.Name == kcm.ProviderSveltosName
+		})
+	}
 
 	getter := helm.NewMemoryRESTClientGetter(r.Config, r.RESTMapper())
 	actionConfig := new(action.Configuration)
@@ -0,0 +1,170 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/freeze"
+	"github.com/K0rdent/kcm/internal/secretpropagation"
+	"github.com/K0rdent/kcm/internal/utils"
+	"github.com/K0rdent/kcm/internal/utils/ratelimit"
+)
+
+// SecretPropagationReconciler reconciles a SecretPropagation object
+type SecretPropagationReconciler struct {
+	client.Client
+}
+
+func (r *SecretPropagationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	l := ctrl.LoggerFrom(ctx)
+	l.Info("SecretPropagation reconcile start")
+
+	sp := new(kcm.SecretPropagation)
+	if err := r.Get(ctx, req.NamespacedName, sp); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, sp.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		l.Info("Reconciliation is paused, only observing the status")
+		apimeta.SetStatusCondition(&sp.Status.Conditions, freeze.Condition(pausedMessage, sp.Generation))
+		if err := r.Status().Update(ctx, sp); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update SecretPropagation %s status: %w", req.NamespacedName, err)
+		}
+		return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, nil
+	}
+	apimeta.RemoveStatusCondition(&sp.Status.Conditions, kcm.ReconciliationPausedCondition)
+
+	if !sp.DeletionTimestamp.IsZero() {
+		if err := secretpropagation.Cleanup(ctx, r.Client, sp, r.clusterClient); err != nil {
+			return ctrl.Result{}, err
+		}
+		if controllerutil.RemoveFinalizer(sp, kcm.SecretPropagationFinalizer) {
+			return ctrl.Result{}, r.Update(ctx, sp)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if controllerutil.AddFinalizer(sp, kcm.SecretPropagationFinalizer) {
+		if err := r.Update(ctx, sp); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer to SecretPropagation %s: %w", req.NamespacedName, err)
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if updated, err := utils.AddKCMComponentLabel(ctx, r.Client, sp); updated || err != nil {
+		if err != nil {
+			l.Error(err, "adding component label")
+		}
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		sp.Status.ObservedGeneration = sp.Generation
+		sp.Status.Error = ""
+		if err != nil {
+			sp.Status.Error = err.Error()
+		}
+
+		if serr := r.Status().Update(ctx, sp); serr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to update SecretPropagation %s status: %w", req.NamespacedName, serr))
+		}
+	}()
+
+	requeueAfter, err := secretpropagation.Reconcile(ctx, r.Client, sp, r.clusterClient, time.Now())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// clusterClient returns the client of the cluster deployed by the given ClusterDeployment.
+func (r *SecretPropagationReconciler) clusterClient(ctx context.Context, cd *kcm.ClusterDeployment) (client.Client, error) {
+	secret := new(corev1.Secret)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: cd.Namespace, Name: cd.Name + kubeconfigSecretSuffix}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig Secret: %w", err)
+	}
+
+	restCfg, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[kubeconfigSecretKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	return client.New(restCfg, client.Options{})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SecretPropagationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// the changes of the propagated objects are synchronized to the clusters
+	sourceHandler := func(match func(*kcm.SecretPropagation, string) bool) handler.EventHandler {
+		return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []ctrl.Request {
+			return r.requestsFor(ctx, o.GetNamespace(), func(sp *kcm.SecretPropagation) bool { return match(sp, o.GetName()) })
+		})
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.TypedOptions[ctrl.Request]{
+			RateLimiter: ratelimit.DefaultFastSlow(),
+		}).
+		For(&kcm.SecretPropagation{}).
+		Watches(&corev1.Secret{}, sourceHandler(func(sp *kcm.SecretPropagation, name string) bool {
+			return slices.Contains(sp.Spec.Secrets, name)
+		})).
+		Watches(&corev1.ConfigMap{}, sourceHandler(func(sp *kcm.SecretPropagation, name string) bool {
+			return slices.Contains(sp.Spec.ConfigMaps, name)
+		})).
+		Watches(&kcm.ClusterDeployment{}, sourceHandler(func(*kcm.SecretPropagation, string) bool {
+			// the selectors are evaluated during the reconciliation
+			return true
+		})).
+		Complete(r)
+}
+
+// requestsFor returns the requests of the SecretPropagations in the given namespace matching the given predicate.
+func (r *SecretPropagationReconciler) requestsFor(ctx context.Context, namespace string, match func(*kcm.SecretPropagation) bool) []ctrl.Request {
+	propagations := new(kcm.SecretPropagationList)
+	if err := r.List(ctx, propagations, client.InNamespace(namespace)); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "failed to list SecretPropagations")
+		return nil
+	}
+
+	var req []ctrl.Request
+	for _, sp := range propagations.Items {
+		if match(&sp) {
+			req = append(req, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&sp)})
+		}
+	}
+
+	return req
+}
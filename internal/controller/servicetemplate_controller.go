@@ -19,22 +19,29 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	sourcev1beta2 "github.com/fluxcd/source-controller/api/v1beta2"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/freeze"
 	"github.com/K0rdent/kcm/internal/utils"
 	"github.com/K0rdent/kcm/internal/utils/ratelimit"
 )
@@ -44,6 +51,8 @@ const sourceNotReadyMessage = "Source is not ready"
 // ServiceTemplateReconciler reconciles a ServiceTemplate object
 type ServiceTemplateReconciler struct {
 	TemplateReconciler
+
+	eventRecorder record.EventRecorder
 }
 
 func (r *ServiceTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -60,6 +69,36 @@ func (r *ServiceTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	references, err := r.getReferences(ctx, serviceTemplate)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, serviceTemplate.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		l.Info("Reconciliation is paused, only observing the status")
+		serviceTemplate.Status.ReferencedBy = references
+		return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, r.reconcilePaused(ctx, serviceTemplate, pausedMessage)
+	}
+	apimeta.RemoveStatusCondition(&serviceTemplate.Status.Conditions, kcm.ReconciliationPausedCondition)
+
+	if !serviceTemplate.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, serviceTemplate, references)
+	}
+
+	if controllerutil.AddFinalizer(serviceTemplate, kcm.ServiceTemplateFinalizer) {
+		if err := r.Update(ctx, serviceTemplate); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer to ServiceTemplate %s: %w", req.NamespacedName, err)
+		}
+		return ctrl.Result{Requeue: true}, nil // generation has not changed, need explicit requeue
+	}
+
+	r.reportImpact(serviceTemplate, references)
+	serviceTemplate.Status.ReferencedBy = references
+
 	management, err := r.getManagement(ctx, serviceTemplate)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -80,6 +119,10 @@ func (r *ServiceTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{Requeue: true}, err // generation has not changed, need explicit requeue
 	}
 
+	if err := serviceTemplate.FillAdvisories(nil); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	switch {
 	case serviceTemplate.Spec.Helm != nil:
 		l.V(1).Info("reconciling helm template")
@@ -95,6 +138,107 @@ func (r *ServiceTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 }
 
+// getReferences returns the ClusterDeployments in the namespace of the given ServiceTemplate
+// and, for the templates in the system namespace, the MultiClusterServices referencing the template.
+func (r *ServiceTemplateReconciler) getReferences(ctx context.Context, template *kcm.ServiceTemplate) ([]kcm.ServiceTemplateReference, error) {
+	clusterDeployments := new(kcm.ClusterDeploymentList)
+	if err := r.List(ctx, clusterDeployments, client.InNamespace(template.Namespace),
+		client.MatchingFields{kcm.ClusterDeploymentServiceTemplatesIndexKey: template.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterDeployments referencing ServiceTemplate %s: %w", client.ObjectKeyFromObject(template), err)
+	}
+
+	var references []kcm.ServiceTemplateReference
+	for _, cd := range clusterDeployments.Items {
+		references = append(references, kcm.ServiceTemplateReference{Kind: kcm.ClusterDeploymentKind, Namespace: cd.Namespace, Name: cd.Name})
+	}
+
+	// MultiClusterServices can only refer to the ServiceTemplates in the system namespace
+	if template.Namespace == r.SystemNamespace {
+		multiClusterServices := new(kcm.MultiClusterServiceList)
+		if err := r.List(ctx, multiClusterServices, client.MatchingFields{kcm.MultiClusterServiceTemplatesIndexKey: template.Name}); err != nil {
+			return nil, fmt.Errorf("failed to list MultiClusterServices referencing ServiceTemplate %s: %w", client.ObjectKeyFromObject(template), err)
+		}
+		for _, mcs := range multiClusterServices.Items {
+			references = append(references, kcm.ServiceTemplateReference{Kind: kcm.MultiClusterServiceKind, Name: mcs.Name})
+		}
+	}
+
+	slices.SortFunc(references, func(a, b kcm.ServiceTemplateReference) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	return references, nil
+}
+
+// reportImpact emits the events listing the objects affected by the update
+// or the deprecation of the given ServiceTemplate.
+func (r *ServiceTemplateReconciler) reportImpact(template *kcm.ServiceTemplate, references []kcm.ServiceTemplateReference) {
+	if len(references) == 0 {
+		return
+	}
+
+	affected := formatReferences(references)
+	if template.Status.ObservedGeneration != 0 && template.Status.ObservedGeneration != template.Generation {
+		r.eventRecorder.Eventf(template, corev1.EventTypeNormal, kcm.ServiceTemplateUpdatedReason,
+			"ServiceTemplate is updated, the change affects %s", affected)
+	}
+
+	if message, deprecated := template.Annotations[kcm.ServiceTemplateDeprecatedAnnotation]; deprecated {
+		if message != "" {
+			message = ": " + message
+		}
+		r.eventRecorder.Eventf(template, corev1.EventTypeWarning, kcm.ServiceTemplateDeprecatedReason,
+			"ServiceTemplate is deprecated%s, still referenced by %s", message, affected)
+	}
+}
+
+// reconcileDelete removes the finalizer from the given ServiceTemplate once it is no longer referenced.
+func (r *ServiceTemplateReconciler) reconcileDelete(ctx context.Context, template *kcm.ServiceTemplate, references []kcm.ServiceTemplateReference) (ctrl.Result, error) {
+	l := ctrl.LoggerFrom(ctx)
+
+	if len(references) > 0 {
+		affected := formatReferences(references)
+		l.Info("ServiceTemplate is still referenced, blocking deletion", "references", affected)
+		r.eventRecorder.Eventf(template, corev1.EventTypeWarning, kcm.ServiceTemplateDeletionBlockedReason,
+			"ServiceTemplate can't be removed while referenced by %s", affected)
+
+		if !slices.Equal(template.Status.ReferencedBy, references) {
+			template.Status.ReferencedBy = references
+			if err := r.Status().Update(ctx, template); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to update status of ServiceTemplate %s: %w", client.ObjectKeyFromObject(template), err)
+			}
+		}
+
+		return ctrl.Result{RequeueAfter: r.defaultRequeueTime}, nil
+	}
+
+	if controllerutil.RemoveFinalizer(template, kcm.ServiceTemplateFinalizer) {
+		if err := r.Update(ctx, template); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to remove finalizer from ServiceTemplate %s: %w", client.ObjectKeyFromObject(template), err)
+		}
+		l.Info("ServiceTemplate is no longer referenced, removed finalizer")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// formatReferences returns the human-readable list of the given references truncated to a few items.
+func formatReferences(references []kcm.ServiceTemplateReference) string {
+	const maxListed = 5
+
+	names := make([]string, 0, min(len(references), maxListed))
+	for _, ref := range references[:min(len(references), maxListed)] {
+		names = append(names, ref.String())
+	}
+
+	list := strings.Join(names, ", ")
+	if len(references) > maxListed {
+		list += fmt.Sprintf(" and %d more", len(references)-maxListed)
+	}
+
+	return list
+}
+
 // ReconcileTemplateHelm reconciles a ServiceTemplate with a Helm chart
 func (r *ServiceTemplateReconciler) ReconcileTemplateHelm(ctx context.Context, template *kcm.ServiceTemplate) (ctrl.Result, error) {
 	return r.ReconcileTemplate(ctx, template)
@@ -155,6 +299,8 @@ func (r *ServiceTemplateReconciler) reconcileLocalSource(ctx context.Context, te
 	key := client.ObjectKey{Namespace: template.Namespace, Name: ref.Name}
 
 	status := kcm.ServiceTemplateStatus{
+		ReferencedBy: template.Status.ReferencedBy,
+		Advisories:   template.Status.Advisories,
 		TemplateStatusCommon: kcm.TemplateStatusCommon{
 			TemplateValidationStatus: kcm.TemplateValidationStatus{},
 			ObservedGeneration:       template.Generation,
@@ -425,18 +571,66 @@ func (r *ServiceTemplateReconciler) reconcileOCIRepository(
 // SetupWithManager sets up the controller with the Manager.
 func (r *ServiceTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.defaultRequeueTime = 1 * time.Minute
+	r.eventRecorder = mgr.GetEventRecorderFor("servicetemplate-controller")
 
 	return ctrl.NewControllerManagedBy(mgr).
 		WithOptions(controller.TypedOptions[ctrl.Request]{
 			RateLimiter: ratelimit.DefaultFastSlow(),
 		}).
-		For(&kcm.ServiceTemplate{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&kcm.ServiceTemplate{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		))).
 		Owns(&sourcev1beta2.OCIRepository{}).
 		Owns(&sourcev1.GitRepository{}).
 		Owns(&sourcev1.Bucket{}).
+		Watches(&sourcev1.HelmChart{}, handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &kcm.ServiceTemplate{}),
+			builder.WithPredicates(chartSourceReadinessChanged()),
+		).
+		Watches(&kcm.ClusterDeployment{}, r.enqueueReferencedTemplates(func(obj client.Object) []client.ObjectKey {
+			var keys []client.ObjectKey
+			for _, name := range kcm.ExtractServiceTemplateNamesFromClusterDeployment(obj) {
+				keys = append(keys, client.ObjectKey{Namespace: obj.GetNamespace(), Name: name})
+			}
+			return keys
+		})).
+		Watches(&kcm.MultiClusterService{}, r.enqueueReferencedTemplates(func(obj client.Object) []client.ObjectKey {
+			var keys []client.ObjectKey
+			for _, name := range kcm.ExtractServiceTemplateNamesFromMultiClusterService(obj) {
+				keys = append(keys, client.ObjectKey{Namespace: r.SystemNamespace, Name: name})
+			}
+			return keys
+		})).
 		Complete(r)
 }
 
+// enqueueReferencedTemplates enqueues the ServiceTemplates referenced by the watched objects
+// once the references are added or removed.
+func (*ServiceTemplateReconciler) enqueueReferencedTemplates(templates func(client.Object) []client.ObjectKey) handler.EventHandler {
+	enqueue := func(q workqueue.TypedRateLimitingInterface[ctrl.Request], objs ...client.Object) {
+		for _, obj := range objs {
+			for _, key := range templates(obj) {
+				q.Add(ctrl.Request{NamespacedName: key})
+			}
+		}
+	}
+
+	return handler.Funcs{
+		CreateFunc: func(_ context.Context, e event.TypedCreateEvent[client.Object], q workqueue.TypedRateLimitingInterface[ctrl.Request]) {
+			enqueue(q, e.Object)
+		},
+		UpdateFunc: func(_ context.Context, e event.TypedUpdateEvent[client.Object], q workqueue.TypedRateLimitingInterface[ctrl.Request]) {
+			if slices.Equal(templates(e.ObjectOld), templates(e.ObjectNew)) {
+				return
+			}
+			enqueue(q, e.ObjectOld, e.ObjectNew)
+		},
+		DeleteFunc: func(_ context.Context, e event.TypedDeleteEvent[client.Object], q workqueue.TypedRateLimitingInterface[ctrl.Request]) {
+			enqueue(q, e.Object)
+		},
+	}
+}
+
 func (r *ServiceTemplateReconciler) sourceStatusFromLocalObject(obj client.Object) (*kcm.SourceStatus, error) {
 	gvk, err := apiutil.GVKForObject(obj, r.Scheme())
 	if err != nil {
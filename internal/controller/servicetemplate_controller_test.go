@@ -17,12 +17,15 @@ package controller
 import (
 	"time"
 
+	helmcontrollerv2 "github.com/fluxcd/helm-controller/api/v2"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	sourcev1beta2 "github.com/fluxcd/source-controller/api/v1beta2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -46,8 +49,10 @@ var _ = Describe("ServiceTemplate Controller", func() {
 			By("creating reconciler", func() {
 				reconciler = ServiceTemplateReconciler{
 					TemplateReconciler: TemplateReconciler{
-						Client: k8sClient,
+						Client:          mgrClient,
+						SystemNamespace: testSystemNamespace,
 					},
+					eventRecorder: record.NewFakeRecorder(100),
 				}
 			})
 
@@ -249,6 +254,34 @@ var _ = Describe("ServiceTemplate Controller", func() {
 			})
 		})
 
+		It("should remove the finalizer once the service template is no longer referenced", func() {
+			By("creating service template", func() {
+				serviceTemplate.Spec = kcm.ServiceTemplateSpec{
+					Helm: &kcm.HelmSpec{
+						ChartRef: &helmcontrollerv2.CrossNamespaceSourceReference{Kind: "HelmChart", Name: "absent-chart"},
+					},
+				}
+				Expect(k8sClient.Create(ctx, &serviceTemplate)).To(Succeed())
+			})
+
+			By("reconciling service template", func() {
+				Eventually(func(g Gomega) {
+					_, _ = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&serviceTemplate)})
+					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&serviceTemplate), &serviceTemplate)).To(Succeed())
+					g.Expect(serviceTemplate.Finalizers).To(ContainElement(kcm.ServiceTemplateFinalizer))
+					g.Expect(serviceTemplate.Status.ReferencedBy).To(BeEmpty())
+				}, eventuallyTimeout, pollingInterval).Should(Succeed())
+			})
+
+			By("deleting service template", func() {
+				Expect(k8sClient.Delete(ctx, &serviceTemplate)).To(Succeed())
+				Eventually(func(g Gomega) {
+					_, _ = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&serviceTemplate)})
+					g.Expect(apierrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(&serviceTemplate), &serviceTemplate))).To(BeTrue())
+				}, eventuallyTimeout, pollingInterval).Should(Succeed())
+			})
+		})
+
 		It("should set service template state to invalid if local source is not ready", func() {
 			By("creating bucket with not ready status", func() {
 				bucket.Spec = sourcev1.BucketSpec{
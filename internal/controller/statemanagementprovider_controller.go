@@ -0,0 +1,113 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/statemanagement"
+	"github.com/K0rdent/kcm/internal/utils"
+	"github.com/K0rdent/kcm/internal/utils/ratelimit"
+)
+
+const (
+	// stateManagementProviderReadyInterval is the interval the required CRDs of the ready providers are rechecked at.
+	stateManagementProviderReadyInterval = 10 * time.Minute
+	// stateManagementProviderNotReadyInterval is the interval the required CRDs of the not ready providers are rechecked at.
+	stateManagementProviderNotReadyInterval = time.Minute
+)
+
+// StateManagementProviderReconciler reconciles a StateManagementProvider object
+type StateManagementProviderReconciler struct {
+	client.Client
+}
+
+func (r *StateManagementProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	l := ctrl.LoggerFrom(ctx)
+	l.Info("StateManagementProvider reconcile start")
+
+	smp := new(kcm.StateManagementProvider)
+	if err := r.Get(ctx, req.NamespacedName, smp); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if updated, err := utils.AddKCMComponentLabel(ctx, r.Client, smp); updated || err != nil {
+		if err != nil {
+			l.Error(err, "adding component label")
+		}
+		return ctrl.Result{}, err
+	}
+
+	var missing []string
+	defer func() {
+		smp.Status.ObservedGeneration = smp.Generation
+		smp.Status.Ready = err == nil && len(missing) == 0
+		smp.Status.Error = ""
+		switch {
+		case err != nil:
+			smp.Status.Error = err.Error()
+		case len(missing) > 0:
+			smp.Status.Error = "missing CustomResourceDefinitions: " + strings.Join(missing, ", ")
+		}
+
+		if serr := r.Status().Update(ctx, smp); serr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to update StateManagementProvider %s status: %w", smp.Name, serr))
+		}
+	}()
+
+	required := smp.Spec.RequiredCRDs
+	if len(required) == 0 {
+		required = statemanagement.DefaultRequiredCRDs(smp.Spec.Engine)
+	}
+
+	// the CRDs are looked up in the discovery since the served resources are of interest
+	for _, name := range required {
+		resource, group, _ := strings.Cut(name, ".")
+		if _, err := r.RESTMapper().KindFor(schema.GroupVersionResource{Group: group, Resource: resource}); err != nil {
+			if !apimeta.IsNoMatchError(err) {
+				return ctrl.Result{}, fmt.Errorf("failed to discover CustomResourceDefinition %s: %w", name, err)
+			}
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		l.Info("StateManagementProvider is not ready", "missing", missing)
+		return ctrl.Result{RequeueAfter: stateManagementProviderNotReadyInterval}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: stateManagementProviderReadyInterval}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *StateManagementProviderReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.TypedOptions[ctrl.Request]{
+			RateLimiter: ratelimit.DefaultFastSlow(),
+		}).
+		For(&kcm.StateManagementProvider{}).
+		Complete(r)
+}
@@ -193,6 +193,12 @@ var _ = BeforeSuite(func() {
 	err = (&kcmwebhook.ProviderTemplateValidator{TemplateValidator: templateValidator}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = (&kcmwebhook.VIPPoolValidator{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = (&kcmwebhook.ClusterRequestValidator{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	go func() {
 		defer GinkgoRecover()
 		err = mgr.Start(ctx)
@@ -261,8 +267,8 @@ func loadWebhooks(path string) ([]*admissionv1.ValidatingWebhookConfiguration, [
 
 func seedClusterScopedResources(ctx context.Context, k8sClient client.Client) error {
 	var (
-		someProviderName     = "test-provider-name"
-		otherProviderName    = "test-provider-name-other"
+		someProviderName     = "bootstrap-test-provider"
+		otherProviderName    = "infrastructure-test-provider"
 		someExposedContract  = "v1beta1_v1beta2"
 		otherExposedContract = "v1beta1"
 		capiVersion          = "v1beta1"
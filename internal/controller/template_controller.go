@@ -15,6 +15,7 @@
 package controller
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -28,19 +29,24 @@ import (
 	"helm.sh/helm/v3/pkg/chart"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/crdupgrade"
+	"github.com/K0rdent/kcm/internal/freeze"
 	"github.com/K0rdent/kcm/internal/helm"
 	"github.com/K0rdent/kcm/internal/metrics"
+	"github.com/K0rdent/kcm/internal/templatelint"
 	"github.com/K0rdent/kcm/internal/utils"
 	"github.com/K0rdent/kcm/internal/utils/ratelimit"
 )
@@ -81,6 +87,16 @@ func (r *ClusterTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	}
 
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, clusterTemplate.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		l.Info("Reconciliation is paused, only observing the status")
+		return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, r.reconcilePaused(ctx, clusterTemplate, pausedMessage)
+	}
+	apimeta.RemoveStatusCondition(&clusterTemplate.Status.Conditions, kcm.ReconciliationPausedCondition)
+
 	management, err := r.getManagement(ctx, clusterTemplate)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -136,6 +152,16 @@ func (r *ProviderTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, "")
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		l.Info("Reconciliation is paused, only observing the status")
+		return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, r.reconcilePaused(ctx, providerTemplate, pausedMessage)
+	}
+	apimeta.RemoveStatusCondition(&providerTemplate.Status.Conditions, kcm.ReconciliationPausedCondition)
+
 	management, err := r.getManagement(ctx, providerTemplate)
 	if r.CreateManagement && err != nil {
 		if apierrors.IsNotFound(err) {
@@ -210,7 +236,15 @@ func (r *TemplateReconciler) ReconcileTemplate(ctx context.Context, template tem
 			l.Error(err, "invalid helm chart reference")
 			return ctrl.Result{}, err
 		}
-		if template.GetNamespace() == r.SystemNamespace || !templateManagedByKCM(template) {
+		sourceRef := helmSpec.ChartSpec.SourceRef
+		if helmSpec.RegistryAuth != nil {
+			if err := r.reconcileTemplateHelmRepository(ctx, template, template.GetName(), helmSpec.RegistryAuth); err != nil {
+				l.Error(err, "Failed to reconcile the HelmRepository of the template")
+				_ = r.updateStatus(ctx, template, err.Error())
+				return ctrl.Result{}, err
+			}
+			sourceRef = sourcev1.LocalHelmChartSourceReference{Kind: sourcev1.HelmRepositoryKind, Name: template.GetName()}
+		} else if template.GetNamespace() == r.SystemNamespace || !templateManagedByKCM(template) {
 			namespace := template.GetNamespace()
 			if namespace == "" {
 				namespace = r.SystemNamespace
@@ -222,11 +256,19 @@ func (r *TemplateReconciler) ReconcileTemplate(ctx context.Context, template tem
 			}
 		}
 		l.Info("Reconciling helm-controller objects ")
-		hcChart, err = r.reconcileHelmChart(ctx, template)
+		hcChart, err = r.reconcileHelmChart(ctx, template, template.GetName(), sourceRef, kcm.ChartSourcePrimary)
 		if err != nil {
 			l.Error(err, "Failed to reconcile HelmChart")
 			return ctrl.Result{}, err
 		}
+		if len(helmSpec.Mirrors) > 0 || len(status.ChartSources) > 0 {
+			hcChart, err = r.reconcileChartMirrors(ctx, template, hcChart)
+			if err != nil {
+				l.Error(err, "Failed to reconcile the chart mirrors")
+				_ = r.updateStatus(ctx, template, err.Error())
+				return ctrl.Result{}, err
+			}
+		}
 	}
 	if hcChart == nil {
 		err := errors.New("HelmChart is nil")
@@ -278,6 +320,16 @@ func (r *TemplateReconciler) ReconcileTemplate(ctx context.Context, template tem
 		return ctrl.Result{}, err
 	}
 
+	if clusterTemplate, ok := template.(*kcm.ClusterTemplate); ok {
+		l.Info("Linting Helm chart structure")
+		if err := templatelint.Error(templatelint.ClusterTemplate(helmChart, clusterTemplate.Spec.Providers)); err != nil {
+			l.Error(err, "Helm chart linting failed")
+			err = fmt.Errorf("invalid chart structure: %w", err)
+			_ = r.updateStatus(ctx, template, err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
 	status.Description = helmChart.Metadata.Description
 
 	rawValues, err := json.Marshal(helmChart.Values)
@@ -289,9 +341,54 @@ func (r *TemplateReconciler) ReconcileTemplate(ctx context.Context, template tem
 	}
 	status.Config = &apiextensionsv1.JSON{Raw: rawValues}
 
+	if clusterTemplate, ok := template.(*kcm.ClusterTemplate); ok {
+		clusterTemplate.Status.ConfigSchema, err = configSchema(helmChart)
+		if err != nil {
+			l.Error(err, "Failed to parse Helm chart values schema")
+			_ = r.updateStatus(ctx, template, err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
+	var result ctrl.Result
+	if providerTemplate, ok := template.(*kcm.ProviderTemplate); ok {
+		l.Info("Checking the upgrade of the installed CRDs")
+		hasCRDs, err := r.checkCRDUpgrade(ctx, providerTemplate, helmChart)
+		if err != nil {
+			l.Error(err, "Failed to check the upgrade of the installed CRDs")
+			_ = r.updateStatus(ctx, template, err.Error())
+			return ctrl.Result{}, err
+		}
+		if hasCRDs {
+			// the installed CRDs and their objects change over time
+			result.RequeueAfter = crdUpgradeCheckInterval
+		}
+	}
+
 	l.Info("Chart validation completed successfully")
 
-	return ctrl.Result{}, r.updateStatus(ctx, template, "")
+	return result, r.updateStatus(ctx, template, "")
+}
+
+// crdUpgradeCheckInterval is the interval of rechecking the upgrade of the installed CRDs
+// to the ones shipped by the ProviderTemplates.
+const crdUpgradeCheckInterval = 10 * time.Minute
+
+// checkCRDUpgrade reports the issues of upgrading the installed CRDs to the ones shipped by the chart
+// of the given ProviderTemplate in its status and returns whether the chart ships any CRDs.
+func (r *TemplateReconciler) checkCRDUpgrade(ctx context.Context, template *kcm.ProviderTemplate, helmChart *chart.Chart) (bool, error) {
+	crds, err := crdupgrade.ChartCRDs(helmChart)
+	if err != nil {
+		// the templates may require the values the chart is installed with
+		ctrl.LoggerFrom(ctx).Info("Failed to render the CRDs of the chart, checking the ones of the crds directories only", "error", err.Error())
+	}
+
+	template.Status.CRDUpgradeIssues, err = crdupgrade.Check(ctx, r.Client, crds)
+	if err != nil {
+		return false, fmt.Errorf("failed to check the upgrade of the CRDs: %w", err)
+	}
+
+	return len(crds) > 0, nil
 }
 
 func templateManagedByKCM(template templateCommon) bool {
@@ -306,6 +403,30 @@ func fillStatusWithProviders(template templateCommon, helmChart *chart.Chart) er
 	return template.FillStatusWithProviders(helmChart.Metadata.Annotations)
 }
 
+// configSchema returns the compacted JSON schema of the values of the given chart or nil if the chart defines none.
+func configSchema(helmChart *chart.Chart) (*apiextensionsv1.JSON, error) {
+	if len(helmChart.Schema) == 0 {
+		return nil, nil
+	}
+
+	schema := new(bytes.Buffer)
+	if err := json.Compact(schema, helmChart.Schema); err != nil {
+		return nil, fmt.Errorf("failed to parse Helm chart values schema: %w", err)
+	}
+
+	return &apiextensionsv1.JSON{Raw: schema.Bytes()}, nil
+}
+
+// reconcilePaused reports the paused reconciliation of the given template in its status without making any changes.
+func (r *TemplateReconciler) reconcilePaused(ctx context.Context, template templateCommon, message string) error {
+	status := template.GetCommonStatus()
+	apimeta.SetStatusCondition(&status.Conditions, freeze.Condition(message, template.GetGeneration()))
+	if err := r.Status().Update(ctx, template); err != nil {
+		return fmt.Errorf("failed to update status for template %s/%s: %w", template.GetNamespace(), template.GetName(), err)
+	}
+	return nil
+}
+
 func (r *TemplateReconciler) updateStatus(ctx context.Context, template templateCommon, validationError string) error {
 	status := template.GetCommonStatus()
 	status.ObservedGeneration = template.GetGeneration()
@@ -321,14 +442,16 @@ func (r *TemplateReconciler) updateStatus(ctx context.Context, template template
 	return nil
 }
 
-func (r *TemplateReconciler) reconcileHelmChart(ctx context.Context, template templateCommon) (*sourcev1.HelmChart, error) {
+// reconcileHelmChart reconciles the HelmChart of the template with the given name pulling
+// the chart from the given source. The chart source label is set only if the template declares mirrors.
+func (r *TemplateReconciler) reconcileHelmChart(ctx context.Context, template templateCommon, name string, sourceRef sourcev1.LocalHelmChartSourceReference, chartSource string) (*sourcev1.HelmChart, error) {
 	namespace := template.GetNamespace()
 	if namespace == "" {
 		namespace = r.SystemNamespace
 	}
 	helmChart := &sourcev1.HelmChart{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      template.GetName(),
+			Name:      name,
 			Namespace: namespace,
 		},
 	}
@@ -340,15 +463,142 @@ func (r *TemplateReconciler) reconcileHelmChart(ctx context.Context, template te
 		}
 
 		helmChart.Labels[kcm.KCMManagedLabelKey] = kcm.KCMManagedLabelValue
+		if len(helmSpec.Mirrors) > 0 {
+			helmChart.Labels[kcm.ChartSourceLabelKey] = chartSource
+		} else {
+			delete(helmChart.Labels, kcm.ChartSourceLabelKey)
+		}
 		utils.AddOwnerReference(helmChart, template)
 
 		helmChart.Spec = *helmSpec.ChartSpec
+		helmChart.Spec.SourceRef = sourceRef
 		return nil
 	})
 
 	return helmChart, err
 }
 
+// reconcileChartMirrors reconciles the HelmRepositories and the HelmCharts of the chart mirrors of the template,
+// removes the ones of the mirrors no longer declared and reports the health of the chart sources in the status.
+// It returns the HelmChart of the first ready source, or the given HelmChart of the primary source if none is ready.
+func (r *TemplateReconciler) reconcileChartMirrors(ctx context.Context, template templateCommon, primary *sourcev1.HelmChart) (*sourcev1.HelmChart, error) {
+	helmSpec := template.GetHelmSpec()
+	status := template.GetCommonStatus()
+
+	// the status holds the primary source and the mirrors reconciled last time
+	for i := len(helmSpec.Mirrors); i < len(status.ChartSources)-1; i++ {
+		if err := r.deleteChartMirror(ctx, primary.Namespace, chartMirrorName(template.GetName(), i)); err != nil {
+			return nil, err
+		}
+	}
+	if len(helmSpec.Mirrors) == 0 {
+		status.ChartSources = nil
+		return primary, nil
+	}
+
+	primaryURL := r.DefaultRegistryConfig.URL
+	repo := new(sourcev1.HelmRepository)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: primary.Namespace, Name: primary.Spec.SourceRef.Name}, repo); err == nil {
+		primaryURL = repo.Spec.URL
+	}
+
+	charts := []*sourcev1.HelmChart{primary}
+	sources := []kcm.ChartSourceStatus{{URL: primaryURL, HelmChart: primary.Name}}
+	for i, mirror := range helmSpec.Mirrors {
+		name := chartMirrorName(template.GetName(), i)
+		if err := r.reconcileTemplateHelmRepository(ctx, template, name, &mirror); err != nil {
+			return nil, fmt.Errorf("mirror %s: %w", mirror.URL, err)
+		}
+
+		chart, err := r.reconcileHelmChart(ctx, template, name, sourcev1.LocalHelmChartSourceReference{Kind: sourcev1.HelmRepositoryKind, Name: name}, kcm.ChartSourceMirror)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reconcile HelmChart of mirror %s: %w", mirror.URL, err)
+		}
+
+		charts = append(charts, chart)
+		sources = append(sources, kcm.ChartSourceStatus{URL: mirror.URL, HelmChart: name})
+	}
+
+	active := -1
+	for i, chart := range charts {
+		if _, err := helm.ShouldReportStatusOnArtifactReadiness(chart); err != nil {
+			sources[i].Message = err.Error()
+			continue
+		}
+		sources[i].Ready = true
+		if active < 0 {
+			active = i
+		}
+	}
+	if active < 0 {
+		active = 0
+	}
+	if active > 0 {
+		ctrl.LoggerFrom(ctx).Info("Primary chart source is not ready, using the chart mirror", "mirror", sources[active].URL, "reason", sources[0].Message)
+	}
+
+	sources[active].Active = true
+	status.ChartSources = sources
+	return charts[active], nil
+}
+
+// deleteChartMirror deletes the HelmChart and the HelmRepository of the chart mirror with the given name.
+func (r *TemplateReconciler) deleteChartMirror(ctx context.Context, namespace, name string) error {
+	meta := metav1.ObjectMeta{Namespace: namespace, Name: name}
+	for _, obj := range []client.Object{&sourcev1.HelmChart{ObjectMeta: meta}, &sourcev1.HelmRepository{ObjectMeta: meta}} {
+		if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete chart mirror %s: %w", client.ObjectKeyFromObject(obj), err)
+		}
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Deleted the chart mirror no longer declared", "name", name, "namespace", namespace)
+	return nil
+}
+
+func chartMirrorName(templateName string, i int) string {
+	return fmt.Sprintf("%s-mirror-%d", templateName, i+1)
+}
+
+// reconcileTemplateHelmRepository reconciles the HelmRepository with the given name dedicated to the template
+// authenticating to the registry as configured by the given RegistryAuth.
+func (r *TemplateReconciler) reconcileTemplateHelmRepository(ctx context.Context, template templateCommon, name string, auth *kcm.RegistryAuth) error {
+	spec, err := r.DefaultRegistryConfig.RegistryAuthHelmRepositorySpec(auth)
+	if err != nil {
+		return fmt.Errorf("invalid registry authentication: %w", err)
+	}
+
+	namespace := template.GetNamespace()
+	if namespace == "" {
+		namespace = r.SystemNamespace
+	}
+	helmRepo := &sourcev1.HelmRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+
+	operation, err := ctrl.CreateOrUpdate(ctx, r.Client, helmRepo, func() error {
+		if helmRepo.Labels == nil {
+			helmRepo.Labels = make(map[string]string)
+		}
+
+		helmRepo.Labels[kcm.KCMManagedLabelKey] = kcm.KCMManagedLabelValue
+		utils.AddOwnerReference(helmRepo, template)
+
+		helmRepo.Spec = spec
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create or update HelmRepository %s: %w", client.ObjectKeyFromObject(helmRepo), err)
+	}
+	if operation == controllerutil.OperationResultCreated || operation == controllerutil.OperationResultUpdated {
+		ctrl.LoggerFrom(ctx).Info("Successfully mutated HelmRepository", "HelmRepository", client.ObjectKeyFromObject(helmRepo), "operation_result", operation)
+	}
+
+	return nil
+}
+
 func (r *TemplateReconciler) getHelmChartFromChartRef(ctx context.Context, chartRef *helmcontrollerv2.CrossNamespaceSourceReference) (*sourcev1.HelmChart, error) {
 	if chartRef.Kind != sourcev1.HelmChartKind {
 		return nil, fmt.Errorf("invalid chartRef.Kind: %s. Only HelmChart kind is supported", chartRef.Kind)
@@ -444,6 +694,9 @@ func (r *ClusterTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			RateLimiter: ratelimit.DefaultFastSlow(),
 		}).
 		For(&kcm.ClusterTemplate{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&sourcev1.HelmChart{}, handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &kcm.ClusterTemplate{}),
+			builder.WithPredicates(chartSourceReadinessChanged()),
+		).
 		Watches(&kcm.Management{}, handler.Funcs{ // address https://github.com/k0rdent/kcm/issues/954
 			UpdateFunc: func(ctx context.Context, tue event.TypedUpdateEvent[client.Object], q workqueue.TypedRateLimitingInterface[ctrl.Request]) {
 				newO, ok := tue.ObjectNew.(*kcm.Management)
@@ -510,6 +763,9 @@ func (r *ProviderTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			RateLimiter: ratelimit.DefaultFastSlow(),
 		}).
 		For(&kcm.ProviderTemplate{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&sourcev1.HelmChart{}, handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &kcm.ProviderTemplate{}),
+			builder.WithPredicates(chartSourceReadinessChanged()),
+		).
 		Watches(&kcm.Release{},
 			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, o client.Object) []ctrl.Request {
 				release, ok := o.(*kcm.Release)
@@ -535,3 +791,29 @@ func (r *ProviderTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		).
 		Complete(r)
 }
+
+// chartSourceReadinessChanged filters the updates of the HelmCharts of the templates declaring
+// chart mirrors changing the readiness of the chart to fail over to the mirrors and back.
+func chartSourceReadinessChanged() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if _, ok := e.ObjectNew.GetLabels()[kcm.ChartSourceLabelKey]; !ok {
+				return false
+			}
+
+			oldChart, ok := e.ObjectOld.(*sourcev1.HelmChart)
+			if !ok {
+				return false
+			}
+			newChart, ok := e.ObjectNew.(*sourcev1.HelmChart)
+			if !ok {
+				return false
+			}
+
+			return apimeta.IsStatusConditionTrue(oldChart.Status.Conditions, "Ready") != apimeta.IsStatusConditionTrue(newChart.Status.Conditions, "Ready")
+		},
+	}
+}
@@ -17,6 +17,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"testing"
 	"time"
 
 	helmcontrollerv2 "github.com/fluxcd/helm-controller/api/v2"
@@ -28,9 +29,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/helm"
+	"github.com/K0rdent/kcm/test/scheme"
 )
 
 var _ = Describe("Template Controller", func() {
@@ -46,10 +50,12 @@ var _ = Describe("Template Controller", func() {
 		fakeDownloadHelmChartFunc := func(context.Context, *sourcev1.Artifact) (*chart.Chart, error) {
 			return &chart.Chart{
 				Metadata: &chart.Metadata{
-					APIVersion: "v2",
-					Version:    "0.1.0",
-					Name:       "test-chart",
+					APIVersion:  "v2",
+					Version:     "0.1.0",
+					Name:        "test-chart",
+					Annotations: map[string]string{"cluster.x-k8s.io/provider": "infrastructure-test"},
 				},
+				Schema: []byte("{\n  \"type\": \"object\"\n}\n"),
 			}, nil
 		}
 
@@ -200,8 +206,8 @@ var _ = Describe("Template Controller", func() {
 			const (
 				clusterTemplateName   = "cluster-template-test-name"
 				mgmtName              = kcmv1.ManagementName
-				someProviderName      = "test-provider-name"
-				otherProviderName     = "test-provider-name-other"
+				someProviderName      = "bootstrap-test-provider"
+				otherProviderName     = "infrastructure-test-provider"
 				someRequiredContract  = "v1beta2"
 				otherRequiredContract = "v1beta1"
 				someExposedContract   = "v1beta1_v1beta2"
@@ -309,6 +315,8 @@ var _ = Describe("Template Controller", func() {
 			Expect(clusterTemplate.Status.ProviderContracts).To(HaveLen(2))
 			Expect(clusterTemplate.Status.Providers[0]).To(Equal(someProviderName))
 			Expect(clusterTemplate.Status.ProviderContracts).To(BeEquivalentTo(map[string]string{otherProviderName: otherRequiredContract, someProviderName: someRequiredContract}))
+			Expect(clusterTemplate.Status.ConfigSchema).NotTo(BeNil())
+			Expect(string(clusterTemplate.Status.ConfigSchema.Raw)).To(Equal(`{"type":"object"}`))
 
 			By("Removing the created objects")
 			Expect(k8sClient.Delete(ctx, clusterTemplate)).To(Succeed())
@@ -320,3 +328,113 @@ var _ = Describe("Template Controller", func() {
 		})
 	})
 })
+
+func Test_reconcileChartMirrors(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+
+	const (
+		systemNamespace = "kcm-system"
+		registryURL     = "oci://registry.example.com/charts"
+	)
+
+	template := &kcmv1.ServiceTemplate{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kcmv1.GroupVersion.String(), Kind: kcmv1.ServiceTemplateKind},
+		ObjectMeta: metav1.ObjectMeta{Namespace: systemNamespace, Name: "ingress-nginx-4-12-0", UID: "ingress-nginx-4-12-0"},
+		Spec: kcmv1.ServiceTemplateSpec{Helm: &kcmv1.HelmSpec{
+			ChartSpec: &sourcev1.HelmChartSpec{Chart: "ingress-nginx", Version: "4.12.0", SourceRef: kcmv1.DefaultSourceRef},
+			Mirrors: []kcmv1.RegistryAuth{
+				{URL: "oci://mirror-1.example.com/charts"},
+				{URL: "oci://mirror-2.example.com/charts", Provider: kcmv1.RegistryAuthProviderAWS},
+			},
+		}},
+	}
+	defaultRepo := &sourcev1.HelmRepository{
+		ObjectMeta: metav1.ObjectMeta{Namespace: systemNamespace, Name: kcmv1.DefaultRepoName},
+		Spec:       sourcev1.HelmRepositorySpec{Type: "oci", URL: registryURL},
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).
+		WithObjects(defaultRepo).
+		WithStatusSubresource(&sourcev1.HelmChart{}).
+		Build()
+	r := &TemplateReconciler{
+		Client:                cl,
+		SystemNamespace:       systemNamespace,
+		DefaultRegistryConfig: helm.DefaultRegistryConfig{RepoType: "oci", URL: registryURL},
+	}
+
+	reconcileCharts := func() *sourcev1.HelmChart {
+		t.Helper()
+		primary, err := r.reconcileHelmChart(ctx, template, template.Name, kcmv1.DefaultSourceRef, kcmv1.ChartSourcePrimary)
+		g.Expect(err).NotTo(HaveOccurred())
+		chart, err := r.reconcileChartMirrors(ctx, template, primary)
+		g.Expect(err).NotTo(HaveOccurred())
+		return chart
+	}
+	setReady := func(name string, ready bool) {
+		t.Helper()
+		chart := new(sourcev1.HelmChart)
+		g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: systemNamespace, Name: name}, chart)).To(Succeed())
+		status := metav1.ConditionFalse
+		if ready {
+			status = metav1.ConditionTrue
+		}
+		chart.Status.Conditions = []metav1.Condition{{
+			Type:               "Ready",
+			Status:             status,
+			ObservedGeneration: chart.Generation,
+			Reason:             "Test",
+			Message:            "registry is unavailable",
+			LastTransitionTime: metav1.Now(),
+		}}
+		chart.Status.URL = "http://source-controller/" + name
+		chart.Status.Artifact = &sourcev1.Artifact{URL: chart.Status.URL, LastUpdateTime: metav1.Now()}
+		g.Expect(cl.Status().Update(ctx, chart)).To(Succeed())
+	}
+
+	// none of the sources is ready yet, the primary one is used
+	g.Expect(reconcileCharts().Name).To(Equal(template.Name))
+	g.Expect(template.Status.ChartSources).To(Equal([]kcmv1.ChartSourceStatus{
+		{URL: registryURL, HelmChart: template.Name, Message: "helm chart artifact is not ready yet", Active: true},
+		{URL: "oci://mirror-1.example.com/charts", HelmChart: template.Name + "-mirror-1", Message: "helm chart artifact is not ready yet"},
+		{URL: "oci://mirror-2.example.com/charts", HelmChart: template.Name + "-mirror-2", Message: "helm chart artifact is not ready yet"},
+	}))
+
+	mirrorRepo := new(sourcev1.HelmRepository)
+	g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: systemNamespace, Name: template.Name + "-mirror-2"}, mirrorRepo)).To(Succeed())
+	g.Expect(mirrorRepo.Spec.URL).To(Equal("oci://mirror-2.example.com/charts"))
+	g.Expect(mirrorRepo.Spec.Provider).To(Equal(kcmv1.RegistryAuthProviderAWS))
+
+	// the primary source is unavailable, failing over to the first ready mirror
+	setReady(template.Name, false)
+	setReady(template.Name+"-mirror-1", false)
+	setReady(template.Name+"-mirror-2", true)
+	g.Expect(reconcileCharts().Name).To(Equal(template.Name + "-mirror-2"))
+	g.Expect(template.Status.ChartSources[0].Ready).To(BeFalse())
+	g.Expect(template.Status.ChartSources[0].Message).To(Equal("failed to download helm chart artifact: registry is unavailable"))
+	g.Expect(template.Status.ChartSources[2].Ready).To(BeTrue())
+	g.Expect(template.Status.ChartSources[2].Active).To(BeTrue())
+
+	// the primary source recovers
+	setReady(template.Name, true)
+	g.Expect(reconcileCharts().Name).To(Equal(template.Name))
+	g.Expect(template.Status.ChartSources[0].Active).To(BeTrue())
+	g.Expect(template.Status.ChartSources[2].Active).To(BeFalse())
+
+	// the mirrors no longer declared are removed
+	template.Spec.Helm.Mirrors = template.Spec.Helm.Mirrors[:1]
+	g.Expect(reconcileCharts().Name).To(Equal(template.Name))
+	g.Expect(template.Status.ChartSources).To(HaveLen(2))
+	err := cl.Get(ctx, client.ObjectKey{Namespace: systemNamespace, Name: template.Name + "-mirror-2"}, new(sourcev1.HelmChart))
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	err = cl.Get(ctx, client.ObjectKey{Namespace: systemNamespace, Name: template.Name + "-mirror-2"}, new(sourcev1.HelmRepository))
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	template.Spec.Helm.Mirrors = nil
+	g.Expect(reconcileCharts().Name).To(Equal(template.Name))
+	g.Expect(template.Status.ChartSources).To(BeNil())
+	primary := new(sourcev1.HelmChart)
+	g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: systemNamespace, Name: template.Name}, primary)).To(Succeed())
+	g.Expect(primary.Labels).NotTo(HaveKey(kcmv1.ChartSourceLabelKey))
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/freeze"
 	"github.com/K0rdent/kcm/internal/utils"
 	"github.com/K0rdent/kcm/internal/utils/ratelimit"
 )
@@ -93,6 +95,16 @@ func (r *ServiceTemplateChainReconciler) Reconcile(ctx context.Context, req ctrl
 func (r *TemplateChainReconciler) ReconcileTemplateChain(ctx context.Context, templateChain templateChain) (ctrl.Result, error) {
 	l := ctrl.LoggerFrom(ctx)
 
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, templateChain.GetNamespace())
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paused {
+		l.Info("Reconciliation is paused, only observing the status")
+		return ctrl.Result{RequeueAfter: freeze.RequeueInterval}, r.reconcilePaused(ctx, templateChain, pausedMessage)
+	}
+	apimeta.RemoveStatusCondition(&templateChain.GetStatus().Conditions, kcm.ReconciliationPausedCondition)
+
 	management := &kcm.Management{}
 	if err := r.Get(ctx, client.ObjectKey{Name: kcm.ManagementName}, management); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to get Management: %w", err)
@@ -114,13 +126,35 @@ func (r *TemplateChainReconciler) ReconcileTemplateChain(ctx context.Context, te
 		return ctrl.Result{}, r.updateStatus(ctx, templateChain)
 	}
 
+	l.V(1).Info("Getting system templates")
+	systemTemplates, err := r.getTemplates(ctx, &client.ListOptions{Namespace: r.SystemNamespace})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get system templates: %w", err)
+	}
+	r.setMissingTemplates(templateChain, systemTemplates)
+
 	if templateChain.GetNamespace() == r.SystemNamespace ||
 		templateChain.GetLabels()[kcm.KCMManagedLabelKey] != kcm.KCMManagedLabelValue {
 		l.Info("TemplateChain is not managed, skipping reconciliation")
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, r.updateStatus(ctx, templateChain)
+	}
+
+	return ctrl.Result{}, errors.Join(r.reconcileObj(ctx, templateChain, systemTemplates), r.updateStatus(ctx, templateChain))
+}
+
+// reconcilePaused reports the paused reconciliation of the given object in its status without creating
+// the supported templates, the validity of the object and the missing templates are still observed.
+func (r *TemplateChainReconciler) reconcilePaused(ctx context.Context, templateChain templateChain, message string) error {
+	if r.setObjectValidity(templateChain) {
+		systemTemplates, err := r.getTemplates(ctx, &client.ListOptions{Namespace: r.SystemNamespace})
+		if err != nil {
+			return fmt.Errorf("failed to get system templates: %w", err)
+		}
+		r.setMissingTemplates(templateChain, systemTemplates)
 	}
 
-	return ctrl.Result{}, errors.Join(r.reconcileObj(ctx, templateChain), r.updateStatus(ctx, templateChain))
+	apimeta.SetStatusCondition(&templateChain.GetStatus().Conditions, freeze.Condition(message, templateChain.GetGeneration()))
+	return r.updateStatus(ctx, templateChain)
 }
 
 // setObjectValidity returns if the given object is valid and ready to be proceeded, setting its status accordingly.
@@ -129,11 +163,22 @@ func (*TemplateChainReconciler) setObjectValidity(tc templateChain) (valid bool)
 	status := tc.GetStatus()
 	status.IsValid = isValid
 	status.ValidationErrors = warnings
+	status.UpgradeGraph = tc.GetSpec().UpgradeGraph()
 
 	return isValid
 }
 
-func (r *TemplateChainReconciler) reconcileObj(ctx context.Context, tplChain templateChain) error {
+// setMissingTemplates reports the supported templates not present in the system namespace in the status of the given object.
+func (r *TemplateChainReconciler) setMissingTemplates(tc templateChain, systemTemplates map[string]templateCommon) {
+	status := tc.GetStatus()
+	for _, supportedTemplate := range tc.GetSpec().SupportedTemplates {
+		if _, found := systemTemplates[supportedTemplate.Name]; !found {
+			status.ValidationErrors = append(status.ValidationErrors, fmt.Sprintf("%s %s/%s is not found", r.templateKind, r.SystemNamespace, supportedTemplate.Name))
+		}
+	}
+}
+
+func (r *TemplateChainReconciler) reconcileObj(ctx context.Context, tplChain templateChain, systemTemplates map[string]templateCommon) error {
 	spec := tplChain.GetSpec()
 	if len(spec.SupportedTemplates) == 0 {
 		return nil // nothing to do
@@ -141,12 +186,6 @@ func (r *TemplateChainReconciler) reconcileObj(ctx context.Context, tplChain tem
 
 	l := ctrl.LoggerFrom(ctx)
 
-	l.V(1).Info("Getting system templates")
-	systemTemplates, err := r.getTemplates(ctx, &client.ListOptions{Namespace: r.SystemNamespace})
-	if err != nil {
-		return fmt.Errorf("failed to get system templates: %w", err)
-	}
-
 	var errs error
 	for _, supportedTemplate := range spec.SupportedTemplates {
 		l.V(1).Info("Processing the supported template to create or update it", "supported template", supportedTemplate.Name)
@@ -0,0 +1,157 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/freeze"
+	"github.com/K0rdent/kcm/internal/utils"
+	"github.com/K0rdent/kcm/internal/utils/ratelimit"
+	"github.com/K0rdent/kcm/internal/utils/vip"
+)
+
+// VIPPoolReconciler reconciles a VIPPool object
+type VIPPoolReconciler struct {
+	client.Client
+}
+
+func (r *VIPPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	l := ctrl.LoggerFrom(ctx)
+	l.Info("VIPPool reconcile start")
+
+	pool := new(kcm.VIPPool)
+	if err := r.Get(ctx, req.NamespacedName, pool); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// the pool only observes the allocations in its status, hence it is still reconciled while paused
+	paused, pausedMessage, err := freeze.Paused(ctx, r.Client, "")
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	var result ctrl.Result
+	if paused {
+		l.Info("Reconciliation is paused, only observing the status")
+		apimeta.SetStatusCondition(&pool.Status.Conditions, freeze.Condition(pausedMessage, pool.Generation))
+		result.RequeueAfter = freeze.RequeueInterval
+	} else {
+		apimeta.RemoveStatusCondition(&pool.Status.Conditions, kcm.ReconciliationPausedCondition)
+		if updated, err := utils.AddKCMComponentLabel(ctx, r.Client, pool); updated || err != nil {
+			if err != nil {
+				l.Error(err, "adding component label")
+			}
+			return ctrl.Result{}, err
+		}
+	}
+
+	defer func() {
+		pool.Status.ObservedGeneration = pool.Generation
+		pool.Status.Error = ""
+		if err != nil {
+			pool.Status.Error = err.Error()
+		}
+
+		if serr := r.Status().Update(ctx, pool); serr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to update VIPPool %s status: %w", pool.Name, serr))
+		}
+	}()
+
+	ranges, err := vip.ParseRanges(pool.Spec.Addresses)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to parse addresses: %w", err)
+	}
+
+	clusterDeployments := new(kcm.ClusterDeploymentList)
+	if err := r.List(ctx, clusterDeployments, client.MatchingFields{kcm.ClusterDeploymentVIPPoolIndexKey: pool.Name}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list ClusterDeployments: %w", err)
+	}
+
+	// the addresses are claimed in the allocations by the ClusterDeployment controller,
+	// the allocations of the ClusterDeployments no longer reserving the addresses are released
+	claimed := make(map[string]kcm.VIPAllocation, len(pool.Status.Allocations))
+	claimedAddresses := make(map[string]struct{}, len(pool.Status.Allocations))
+	for _, allocation := range pool.Status.Allocations {
+		claimed[allocation.ClusterDeployment] = allocation
+		claimedAddresses[allocation.Address] = struct{}{}
+	}
+
+	allocations := make([]kcm.VIPAllocation, 0, len(clusterDeployments.Items))
+	for _, cd := range clusterDeployments.Items {
+		key := client.ObjectKeyFromObject(&cd).String()
+
+		allocation, ok := claimed[key]
+		if !ok {
+			// adopt the addresses reserved before they have been claimed in the allocations
+			if _, taken := claimedAddresses[cd.Status.ControlPlaneVIP]; taken {
+				continue
+			}
+			allocation = kcm.VIPAllocation{Address: cd.Status.ControlPlaneVIP, ClusterDeployment: key}
+		}
+
+		addr, err := netip.ParseAddr(allocation.Address)
+		if err != nil || !vip.Contains(ranges, addr) {
+			continue
+		}
+		if requested := cd.Spec.ControlPlaneVIP.Address; requested != "" && requested != allocation.Address {
+			continue
+		}
+
+		allocations = append(allocations, kcm.VIPAllocation{
+			Address:           addr.String(),
+			ClusterDeployment: key,
+		})
+	}
+
+	slices.SortFunc(allocations, func(a, b kcm.VIPAllocation) int {
+		return strings.Compare(a.ClusterDeployment, b.ClusterDeployment)
+	})
+	pool.Status.Allocations = allocations
+
+	return result, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VIPPoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.TypedOptions[ctrl.Request]{
+			RateLimiter: ratelimit.DefaultFastSlow(),
+		}).
+		For(&kcm.VIPPool{}).
+		Watches(&kcm.ClusterDeployment{},
+			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, o client.Object) []ctrl.Request {
+				pools := kcm.ExtractVIPPoolFromClusterDeployment(o)
+				req := make([]ctrl.Request, 0, len(pools))
+				for _, pool := range pools {
+					req = append(req, ctrl.Request{NamespacedName: client.ObjectKey{Name: pool}})
+				}
+
+				return req
+			}),
+		).
+		Complete(r)
+}
@@ -0,0 +1,225 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cost estimates the cost of the clusters by mapping the instance
+// types and counts of the cluster nodes to the provider price lists.
+package cost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/utils/windows"
+)
+
+const (
+	// PriceListConfigMapName is the name of the ConfigMap in the system namespace holding the price lists.
+	// Each key of the ConfigMap is the name of an infrastructure provider, e.g. infrastructure-aws, with
+	// the value mapping the instance types to their hourly prices. The optional "currency" key sets the
+	// currency of the prices.
+	PriceListConfigMapName = "kcm-price-list"
+
+	currencyKey     = "currency"
+	defaultCurrency = "USD"
+
+	// hoursPerMonth is the average number of hours in a month.
+	hoursPerMonth = 730
+)
+
+// PriceSource provides the hourly prices of the provider instance types.
+type PriceSource interface {
+	// PriceList returns the hourly prices of the instance types of the given
+	// infrastructure provider and the currency of the prices.
+	// A nil map is returned if the provider has no price list.
+	PriceList(ctx context.Context, provider string) (prices map[string]float64, currency string, _ error)
+}
+
+// ConfigMapPriceSource is the [PriceSource] reading the price lists from the [PriceListConfigMapName] ConfigMap.
+type ConfigMapPriceSource struct {
+	Client    client.Client
+	Namespace string
+}
+
+// PriceList implements [PriceSource].
+func (s *ConfigMapPriceSource) PriceList(ctx context.Context, provider string) (map[string]float64, string, error) {
+	cm := new(corev1.ConfigMap)
+	if err := s.Client.Get(ctx, client.ObjectKey{Namespace: s.Namespace, Name: PriceListConfigMapName}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("failed to get price list ConfigMap: %w", err)
+	}
+
+	currency := cm.Data[currencyKey]
+	if currency == "" {
+		currency = defaultCurrency
+	}
+
+	data, ok := cm.Data[provider]
+	if !ok {
+		return nil, currency, nil
+	}
+
+	prices := make(map[string]float64)
+	if err := yaml.Unmarshal([]byte(data), &prices); err != nil {
+		return nil, "", fmt.Errorf("failed to parse price list of the provider %s: %w", provider, err)
+	}
+
+	return prices, currency, nil
+}
+
+// nodePool describes the ClusterTemplate values keys of the number and the machines parameters of the nodes.
+type nodePool struct {
+	numberKey  string
+	machineKey string
+}
+
+var nodePools = []nodePool{
+	{numberKey: "controlPlaneNumber", machineKey: "controlPlane"},
+	{numberKey: "workersNumber", machineKey: "worker"},
+	{numberKey: windows.WorkersNumberValuesKey, machineKey: windows.WorkerValuesKey},
+}
+
+// instanceTypeValuesKeys is the list of the ClusterTemplate values keys defining the instance types.
+var instanceTypeValuesKeys = []string{"instanceType", "vmSize", "machineType", "flavor"}
+
+// Estimate is the estimated cost of the cluster nodes.
+type Estimate struct {
+	// Currency is the currency of the cost.
+	Currency string
+	// UnpricedInstanceTypes is the list of the instance types missing in the price list.
+	UnpricedInstanceTypes []string
+	// MonthlyCost is the estimated monthly cost.
+	MonthlyCost float64
+}
+
+// String returns the monthly cost with the currency.
+func (e *Estimate) String() string {
+	return strconv.FormatFloat(e.MonthlyCost, 'f', 2, 64) + " " + e.Currency
+}
+
+// EstimateCluster estimates the monthly cost of the nodes of the ClusterDeployment
+// with the given configuration deployed from the given ClusterTemplate.
+// Returns nil if none of the template providers has a price list.
+func EstimateCluster(ctx context.Context, source PriceSource, template *kcm.ClusterTemplate, config *apiextensionsv1.JSON) (*Estimate, error) {
+	var (
+		prices   map[string]float64
+		currency string
+	)
+	for _, provider := range template.Status.Providers {
+		if !strings.HasPrefix(provider, "infrastructure-") {
+			continue
+		}
+
+		var err error
+		prices, currency, err = source.PriceList(ctx, provider)
+		if err != nil {
+			return nil, err
+		}
+		if prices != nil {
+			break
+		}
+	}
+	if prices == nil {
+		return nil, nil
+	}
+
+	values, err := MergeValues(template.Status.Config, config)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &Estimate{Currency: currency}
+	for instanceType, number := range NodeCounts(values) {
+		if instanceType == "" {
+			continue
+		}
+
+		price, ok := prices[instanceType]
+		if !ok {
+			estimate.UnpricedInstanceTypes = append(estimate.UnpricedInstanceTypes, instanceType)
+			continue
+		}
+
+		estimate.MonthlyCost += price * float64(number) * hoursPerMonth
+	}
+	slices.Sort(estimate.UnpricedInstanceTypes)
+
+	return estimate, nil
+}
+
+// NodeCounts returns the number of the nodes of the cluster with the given values per instance type.
+// The nodes of the pools with no instance type set are counted under the empty instance type.
+func NodeCounts(values map[string]any) map[string]int {
+	counts := make(map[string]int)
+	for _, pool := range nodePools {
+		number, _ := values[pool.numberKey].(float64)
+		if number <= 0 {
+			continue
+		}
+		counts[poolInstanceType(values, pool)] += int(number)
+	}
+
+	return counts
+}
+
+// poolInstanceType returns the instance type of the node pool either from its machines parameters
+// or, for the workers, from the top-level values as defined by the hosted control plane templates.
+func poolInstanceType(values map[string]any, pool nodePool) string {
+	machine, _ := values[pool.machineKey].(map[string]any)
+	for _, key := range instanceTypeValuesKeys {
+		if v, ok := machine[key].(string); ok && v != "" {
+			return v
+		}
+		if pool.numberKey != "workersNumber" {
+			continue
+		}
+		if v, ok := values[key].(string); ok && v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// MergeValues merges the ClusterDeployment configuration with the default values of the ClusterTemplate.
+func MergeValues(defaults, config *apiextensionsv1.JSON) (map[string]any, error) {
+	values := make(map[string]any)
+	if config != nil && len(config.Raw) > 0 {
+		if err := json.Unmarshal(config.Raw, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse ClusterDeployment configuration: %w", err)
+		}
+	}
+
+	defaultValues := make(map[string]any)
+	if defaults != nil && len(defaults.Raw) > 0 {
+		if err := json.Unmarshal(defaults.Raw, &defaultValues); err != nil {
+			return nil, fmt.Errorf("failed to parse template config: %w", err)
+		}
+	}
+
+	return chartutil.CoalesceTables(values, defaultValues), nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+type fakePriceSource map[string]map[string]float64
+
+func (f fakePriceSource) PriceList(_ context.Context, provider string) (map[string]float64, string, error) {
+	return f[provider], "EUR", nil
+}
+
+func TestEstimateCluster(t *testing.T) {
+	source := fakePriceSource{
+		"infrastructure-azure": {
+			"Standard_A4_v2":  0.1,
+			"Standard_D4s_v3": 0.2,
+		},
+	}
+
+	for _, tc := range []struct {
+		name      string
+		providers []string
+		defaults  string
+		config    string
+		expected  *Estimate
+	}{
+		{
+			name:      "no price list",
+			providers: []string{"infrastructure-aws"},
+			config:    `{"workersNumber":2,"worker":{"instanceType":"t3.large"}}`,
+		},
+		{
+			name:      "control plane and workers",
+			providers: []string{"control-plane-k0sproject-k0smotron", "infrastructure-azure"},
+			defaults:  `{"controlPlaneNumber":3,"controlPlane":{"vmSize":"Standard_A4_v2"},"workersNumber":2,"worker":{"vmSize":"Standard_A4_v2"}}`,
+			config:    `{"worker":{"vmSize":"Standard_D4s_v3"}}`,
+			expected:  &Estimate{Currency: "EUR", MonthlyCost: 0.1*3*730 + 0.2*2*730},
+		},
+		{
+			name:      "hosted control plane workers",
+			providers: []string{"infrastructure-azure"},
+			config:    `{"workersNumber":1,"vmSize":"Standard_D4s_v3"}`,
+			expected:  &Estimate{Currency: "EUR", MonthlyCost: 0.2 * 730},
+		},
+		{
+			name:      "windows workers",
+			providers: []string{"infrastructure-azure"},
+			config:    `{"windowsWorkersNumber":2,"windowsWorker":{"vmSize":"Standard_A4_v2"}}`,
+			expected:  &Estimate{Currency: "EUR", MonthlyCost: 0.1 * 2 * 730},
+		},
+		{
+			name:      "unpriced instance types",
+			providers: []string{"infrastructure-azure"},
+			config:    `{"controlPlaneNumber":1,"controlPlane":{"vmSize":"Standard_B2s"},"workersNumber":0,"worker":{"vmSize":"Standard_B1s"}}`,
+			expected:  &Estimate{Currency: "EUR", UnpricedInstanceTypes: []string{"Standard_B2s"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			template := &kcm.ClusterTemplate{}
+			template.Status.Providers = tc.providers
+			if tc.defaults != "" {
+				template.Status.Config = &apiextensionsv1.JSON{Raw: []byte(tc.defaults)}
+			}
+
+			estimate, err := EstimateCluster(t.Context(), source, template, &apiextensionsv1.JSON{Raw: []byte(tc.config)})
+			require.NoError(t, err)
+			if tc.expected == nil {
+				require.Nil(t, estimate)
+				return
+			}
+			require.NotNil(t, estimate)
+			require.Equal(t, tc.expected.Currency, estimate.Currency)
+			require.Equal(t, tc.expected.UnpricedInstanceTypes, estimate.UnpricedInstanceTypes)
+			require.InDelta(t, tc.expected.MonthlyCost, estimate.MonthlyCost, 0.001)
+		})
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cpmigration migrates the clusters between the hosted and the standalone control plane
+// by switching the ClusterTemplate of the ClusterDeployment, verifying the resources of the cluster
+// recorded before the migration still exist after it.
+package cpmigration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/providers"
+)
+
+// controlPlaneKinds are the kinds of the control planes deployed in the control plane modes.
+var controlPlaneKinds = map[string]string{
+	kcm.ControlPlaneModeHosted:     "K0smotronControlPlane",
+	kcm.ControlPlaneModeStandalone: "K0sControlPlane",
+}
+
+// Requested returns the name of the ClusterTemplate the migration of the given ClusterDeployment
+// is requested to with the [kcm.ControlPlaneMigrationAnnotation] annotation.
+func Requested(cd *kcm.ClusterDeployment) (string, bool) {
+	target, ok := cd.Annotations[kcm.ControlPlaneMigrationAnnotation]
+	return target, ok
+}
+
+// InProgress returns true if the migration of the given ClusterDeployment has been started and not completed yet.
+func InProgress(cd *kcm.ClusterDeployment) bool {
+	m := cd.Status.ControlPlaneMigration
+	return m != nil && m.Phase == kcm.ControlPlaneMigrationPhaseMigrating
+}
+
+// Pending returns the name of the ClusterTemplate the migration of the given ClusterDeployment
+// is requested to if the migration has not been started yet.
+func Pending(cd *kcm.ClusterDeployment) (string, bool) {
+	target, ok := Requested(cd)
+	if !ok {
+		return "", false
+	}
+	if m := cd.Status.ControlPlaneMigration; m != nil && m.ToTemplate == target && (InProgress(cd) || cd.Spec.Template == target) {
+		return "", false
+	}
+
+	return target, true
+}
+
+// IsMigration returns true if the given update of the ClusterDeployment switches the template
+// to the one of the started migration.
+func IsMigration(oldCD, newCD *kcm.ClusterDeployment) bool {
+	return InProgress(oldCD) &&
+		oldCD.Spec.Template == oldCD.Status.ControlPlaneMigration.FromTemplate &&
+		newCD.Spec.Template == oldCD.Status.ControlPlaneMigration.ToTemplate
+}
+
+// Validate returns the current and the target ClusterTemplates of the given ClusterDeployment
+// or an error if the cluster cannot be migrated to the target ClusterTemplate.
+func Validate(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment, target string) (from, to *kcm.ClusterTemplate, _ error) {
+	if target == "" {
+		return nil, nil, errors.New("the ClusterTemplate to migrate the cluster to is not set")
+	}
+	if target == cd.Spec.Template {
+		return nil, nil, fmt.Errorf("the cluster is already deployed with the ClusterTemplate %s", target)
+	}
+	if InProgress(cd) {
+		return nil, nil, fmt.Errorf("the migration to the ClusterTemplate %s is in progress", cd.Status.ControlPlaneMigration.ToTemplate)
+	}
+	if !apimeta.IsStatusConditionTrue(cd.Status.Conditions, kcm.ReadyCondition) {
+		return nil, nil, errors.New("only the ready clusters can be migrated")
+	}
+
+	from = new(kcm.ClusterTemplate)
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: cd.TemplateNamespace(), Name: cd.Spec.Template}, from); err != nil {
+		return nil, nil, fmt.Errorf("failed to get ClusterTemplate %s/%s: %w", cd.TemplateNamespace(), cd.Spec.Template, err)
+	}
+	to = new(kcm.ClusterTemplate)
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: cd.TemplateNamespace(), Name: target}, to); err != nil {
+		return nil, nil, fmt.Errorf("failed to get ClusterTemplate %s/%s: %w", cd.TemplateNamespace(), target, err)
+	}
+	if !to.Status.Valid {
+		return nil, nil, fmt.Errorf("the ClusterTemplate %s/%s is not valid", cd.TemplateNamespace(), target)
+	}
+
+	if from.Status.ControlPlaneMode == "" || to.Status.ControlPlaneMode == "" {
+		return nil, nil, fmt.Errorf("the control plane mode of the ClusterTemplates %s and %s must be defined", from.Name, to.Name)
+	}
+	if from.Status.ControlPlaneMode == to.Status.ControlPlaneMode {
+		return nil, nil, fmt.Errorf("both ClusterTemplates %s and %s deploy the %s control plane", from.Name, to.Name, to.Status.ControlPlaneMode)
+	}
+
+	fromInfra, toInfra := infraProviders(from), infraProviders(to)
+	if !slices.Equal(fromInfra, toInfra) {
+		return nil, nil, fmt.Errorf("the infrastructure providers %v of the ClusterTemplate %s differ from the infrastructure providers %v of the ClusterTemplate %s",
+			toInfra, to.Name, fromInfra, from.Name)
+	}
+
+	if isDowngrade(from.Status.KubernetesVersion, to.Status.KubernetesVersion) {
+		return nil, nil, fmt.Errorf("the downgrade of Kubernetes from %s to %s is not supported", from.Status.KubernetesVersion, to.Status.KubernetesVersion)
+	}
+
+	return from, to, nil
+}
+
+// Start records the migration of the given ClusterDeployment between the given ClusterTemplates
+// with the inventory of the cluster. The status must be persisted before the template is switched.
+func Start(cd *kcm.ClusterDeployment, from, to *kcm.ClusterTemplate, inventory []string, now time.Time) {
+	cd.Status.ControlPlaneMigration = &kcm.ControlPlaneMigration{
+		StartTime:    metav1.NewTime(now),
+		FromTemplate: from.Name,
+		ToTemplate:   to.Name,
+		FromMode:     from.Status.ControlPlaneMode,
+		ToMode:       to.Status.ControlPlaneMode,
+		Phase:        kcm.ControlPlaneMigrationPhaseMigrating,
+		Inventory:    inventory,
+	}
+	apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+		Type:   kcm.ControlPlaneMigrationCondition,
+		Status: metav1.ConditionUnknown,
+		Reason: kcm.ProgressingReason,
+		Message: fmt.Sprintf("Migrating the control plane from %s (%s) to %s (%s)",
+			from.Status.ControlPlaneMode, from.Name, to.Status.ControlPlaneMode, to.Name),
+	})
+}
+
+// Inventory returns the resources of the cluster verified to exist after the migration.
+func Inventory(ctx context.Context, cl client.Client) ([]string, error) {
+	namespaces := new(corev1.NamespaceList)
+	if err := cl.List(ctx, namespaces); err != nil {
+		return nil, fmt.Errorf("failed to list Namespaces: %w", err)
+	}
+	pvcs := new(corev1.PersistentVolumeClaimList)
+	if err := cl.List(ctx, pvcs); err != nil {
+		return nil, fmt.Errorf("failed to list PersistentVolumeClaims: %w", err)
+	}
+
+	inventory := make([]string, 0, len(namespaces.Items)+len(pvcs.Items))
+	for _, ns := range namespaces.Items {
+		inventory = append(inventory, "Namespace/"+ns.Name)
+	}
+	for _, pvc := range pvcs.Items {
+		inventory = append(inventory, "PersistentVolumeClaim/"+pvc.Namespace+"/"+pvc.Name)
+	}
+	slices.Sort(inventory)
+
+	return inventory, nil
+}
+
+// ControlPlaneMigrated returns true if the control plane of the target mode of the migration
+// of the given ClusterDeployment is ready.
+func ControlPlaneMigrated(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) (bool, error) {
+	m := cd.Status.ControlPlaneMigration
+	if m == nil {
+		return false, nil
+	}
+
+	cluster := new(clusterv1.Cluster)
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(cd), cluster); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+
+	ref := cluster.Spec.ControlPlaneRef
+	return ref != nil && ref.Kind == controlPlaneKinds[m.ToMode] && cluster.Status.ControlPlaneReady, nil
+}
+
+// Verify completes the migration of the given ClusterDeployment comparing the recorded inventory
+// with the given current resources of the cluster.
+func Verify(cd *kcm.ClusterDeployment, current []string, now time.Time) {
+	m := cd.Status.ControlPlaneMigration
+	if m == nil {
+		return
+	}
+
+	m.Missing = nil
+	for _, res := range m.Inventory {
+		if !slices.Contains(current, res) {
+			m.Missing = append(m.Missing, res)
+		}
+	}
+	completionTime := metav1.NewTime(now)
+	m.CompletionTime = &completionTime
+
+	if len(m.Missing) > 0 {
+		m.Phase = kcm.ControlPlaneMigrationPhaseFailed
+		apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+			Type:    kcm.ControlPlaneMigrationCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  kcm.DataContinuityCheckFailedReason,
+			Message: "Resources missing after the migration: " + strings.Join(m.Missing, ", "),
+		})
+		return
+	}
+
+	m.Phase = kcm.ControlPlaneMigrationPhaseSucceeded
+	apimeta.SetStatusCondition(cd.GetConditions(), metav1.Condition{
+		Type:    kcm.ControlPlaneMigrationCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  kcm.SucceededReason,
+		Message: fmt.Sprintf("Control plane has been migrated from %s to %s", m.FromMode, m.ToMode),
+	})
+}
+
+func infraProviders(template *kcm.ClusterTemplate) []string {
+	var infra []string
+	for _, p := range template.Status.Providers {
+		if strings.HasPrefix(p, providers.InfraPrefix) {
+			infra = append(infra, p)
+		}
+	}
+	slices.Sort(infra)
+
+	return infra
+}
+
+func isDowngrade(current, target string) bool {
+	if current == "" || target == "" {
+		return false
+	}
+
+	currentVersion, err := semver.NewVersion(current)
+	if err != nil {
+		return false
+	}
+	targetVersion, err := semver.NewVersion(target)
+	if err != nil {
+		return false
+	}
+
+	return semver.New(targetVersion.Major(), targetVersion.Minor(), targetVersion.Patch(), "", "").
+		LessThan(semver.New(currentVersion.Major(), currentVersion.Minor(), currentVersion.Patch(), "", ""))
+}
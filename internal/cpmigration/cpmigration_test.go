@@ -0,0 +1,265 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cpmigration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func newTemplate(name, mode, k8sVersion string, providers ...string) *kcm.ClusterTemplate {
+	template := &kcm.ClusterTemplate{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"}}
+	template.Status.Valid = true
+	template.Status.ControlPlaneMode = mode
+	template.Status.KubernetesVersion = k8sVersion
+	template.Status.Providers = providers
+	return template
+}
+
+func newClusterDeployment(template string, ready bool) *kcm.ClusterDeployment {
+	cd := &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"},
+		Spec:       kcm.ClusterDeploymentSpec{Template: template},
+	}
+	status := metav1.ConditionFalse
+	if ready {
+		status = metav1.ConditionTrue
+	}
+	cd.Status.Conditions = []metav1.Condition{{Type: kcm.ReadyCondition, Status: status}}
+	return cd
+}
+
+func TestValidate(t *testing.T) {
+	invalid := newTemplate("aws-standalone-cp-0-9-0", kcm.ControlPlaneModeStandalone, "v1.31.5+k0s.0", "infrastructure-aws")
+	invalid.Status.Valid = false
+
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		newTemplate("aws-hosted-cp-1-0-0", kcm.ControlPlaneModeHosted, "v1.31.5+k0s.0", "infrastructure-aws", "control-plane-k0sproject-k0smotron"),
+		newTemplate("aws-standalone-cp-1-0-0", kcm.ControlPlaneModeStandalone, "v1.31.5+k0s.0", "infrastructure-aws", "control-plane-k0sproject-k0smotron"),
+		newTemplate("aws-standalone-cp-legacy", "", "v1.31.5+k0s.0", "infrastructure-aws"),
+		newTemplate("aws-standalone-cp-old", kcm.ControlPlaneModeStandalone, "v1.30.4+k0s.0", "infrastructure-aws"),
+		newTemplate("aws-hosted-cp-1-0-1", kcm.ControlPlaneModeHosted, "v1.31.5+k0s.0", "infrastructure-aws"),
+		newTemplate("azure-standalone-cp-1-0-0", kcm.ControlPlaneModeStandalone, "v1.31.5+k0s.0", "infrastructure-azure"),
+		invalid,
+	).Build()
+
+	inProgress := newClusterDeployment("aws-hosted-cp-1-0-0", true)
+	inProgress.Status.ControlPlaneMigration = &kcm.ControlPlaneMigration{ToTemplate: "aws-standalone-cp-1-0-0", Phase: kcm.ControlPlaneMigrationPhaseMigrating}
+
+	for _, tc := range []struct {
+		name   string
+		cd     *kcm.ClusterDeployment
+		target string
+		err    string
+	}{
+		{
+			name:   "hosted to standalone",
+			cd:     newClusterDeployment("aws-hosted-cp-1-0-0", true),
+			target: "aws-standalone-cp-1-0-0",
+		},
+		{
+			name:   "standalone to hosted",
+			cd:     newClusterDeployment("aws-standalone-cp-1-0-0", true),
+			target: "aws-hosted-cp-1-0-0",
+		},
+		{
+			name: "no target",
+			cd:   newClusterDeployment("aws-hosted-cp-1-0-0", true),
+			err:  "the ClusterTemplate to migrate the cluster to is not set",
+		},
+		{
+			name:   "same template",
+			cd:     newClusterDeployment("aws-hosted-cp-1-0-0", true),
+			target: "aws-hosted-cp-1-0-0",
+			err:    "the cluster is already deployed with the ClusterTemplate aws-hosted-cp-1-0-0",
+		},
+		{
+			name:   "migration in progress",
+			cd:     inProgress,
+			target: "aws-standalone-cp-1-0-0",
+			err:    "the migration to the ClusterTemplate aws-standalone-cp-1-0-0 is in progress",
+		},
+		{
+			name:   "cluster not ready",
+			cd:     newClusterDeployment("aws-hosted-cp-1-0-0", false),
+			target: "aws-standalone-cp-1-0-0",
+			err:    "only the ready clusters can be migrated",
+		},
+		{
+			name:   "missing target",
+			cd:     newClusterDeployment("aws-hosted-cp-1-0-0", true),
+			target: "aws-standalone-cp-2-0-0",
+			err:    "failed to get ClusterTemplate team-a/aws-standalone-cp-2-0-0",
+		},
+		{
+			name:   "invalid target",
+			cd:     newClusterDeployment("aws-hosted-cp-1-0-0", true),
+			target: "aws-standalone-cp-0-9-0",
+			err:    "the ClusterTemplate team-a/aws-standalone-cp-0-9-0 is not valid",
+		},
+		{
+			name:   "undefined mode",
+			cd:     newClusterDeployment("aws-hosted-cp-1-0-0", true),
+			target: "aws-standalone-cp-legacy",
+			err:    "the control plane mode of the ClusterTemplates aws-hosted-cp-1-0-0 and aws-standalone-cp-legacy must be defined",
+		},
+		{
+			name:   "same mode",
+			cd:     newClusterDeployment("aws-hosted-cp-1-0-0", true),
+			target: "aws-hosted-cp-1-0-1",
+			err:    "both ClusterTemplates aws-hosted-cp-1-0-0 and aws-hosted-cp-1-0-1 deploy the hosted control plane",
+		},
+		{
+			name:   "different infrastructure",
+			cd:     newClusterDeployment("aws-hosted-cp-1-0-0", true),
+			target: "azure-standalone-cp-1-0-0",
+			err:    "the infrastructure providers [infrastructure-azure] of the ClusterTemplate azure-standalone-cp-1-0-0 differ",
+		},
+		{
+			name:   "kubernetes downgrade",
+			cd:     newClusterDeployment("aws-hosted-cp-1-0-0", true),
+			target: "aws-standalone-cp-old",
+			err:    "the downgrade of Kubernetes from v1.31.5+k0s.0 to v1.30.4+k0s.0 is not supported",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to, err := Validate(t.Context(), cl, tc.cd, tc.target)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.cd.Spec.Template, from.Name)
+			require.Equal(t, tc.target, to.Name)
+		})
+	}
+}
+
+func TestPending(t *testing.T) {
+	cd := newClusterDeployment("aws-hosted-cp-1-0-0", true)
+	_, ok := Pending(cd)
+	require.False(t, ok)
+
+	cd.Annotations = map[string]string{kcm.ControlPlaneMigrationAnnotation: "aws-standalone-cp-1-0-0"}
+	target, ok := Pending(cd)
+	require.True(t, ok)
+	require.Equal(t, "aws-standalone-cp-1-0-0", target)
+
+	cd.Status.ControlPlaneMigration = &kcm.ControlPlaneMigration{
+		FromTemplate: "aws-hosted-cp-1-0-0",
+		ToTemplate:   "aws-standalone-cp-1-0-0",
+		Phase:        kcm.ControlPlaneMigrationPhaseMigrating,
+	}
+	_, ok = Pending(cd)
+	require.False(t, ok, "expected the started migration not to be pending")
+
+	migrated := cd.DeepCopy()
+	migrated.Spec.Template = "aws-standalone-cp-1-0-0"
+	require.True(t, IsMigration(cd, migrated))
+
+	upgraded := cd.DeepCopy()
+	upgraded.Spec.Template = "aws-hosted-cp-1-0-1"
+	require.False(t, IsMigration(cd, upgraded))
+
+	migrated.Status.ControlPlaneMigration.Phase = kcm.ControlPlaneMigrationPhaseFailed
+	_, ok = Pending(migrated)
+	require.False(t, ok, "expected the failed migration kept until acknowledged not to be pending")
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	from := newTemplate("aws-hosted-cp-1-0-0", kcm.ControlPlaneModeHosted, "v1.31.5+k0s.0")
+	to := newTemplate("aws-standalone-cp-1-0-0", kcm.ControlPlaneModeStandalone, "v1.31.5+k0s.0")
+	inventory := []string{"Namespace/default", "PersistentVolumeClaim/default/data"}
+
+	cd := newClusterDeployment("aws-hosted-cp-1-0-0", true)
+	Start(cd, from, to, inventory, now)
+	require.True(t, InProgress(cd))
+	require.True(t, apimeta.IsStatusConditionPresentAndEqual(cd.Status.Conditions, kcm.ControlPlaneMigrationCondition, metav1.ConditionUnknown))
+
+	Verify(cd, []string{"Namespace/default", "Namespace/kube-system"}, now.Add(time.Hour))
+	require.Equal(t, kcm.ControlPlaneMigrationPhaseFailed, cd.Status.ControlPlaneMigration.Phase)
+	require.Equal(t, []string{"PersistentVolumeClaim/default/data"}, cd.Status.ControlPlaneMigration.Missing)
+	condition := apimeta.FindStatusCondition(cd.Status.Conditions, kcm.ControlPlaneMigrationCondition)
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Equal(t, kcm.DataContinuityCheckFailedReason, condition.Reason)
+
+	Start(cd, from, to, inventory, now)
+	Verify(cd, inventory, now.Add(time.Hour))
+	require.Equal(t, kcm.ControlPlaneMigrationPhaseSucceeded, cd.Status.ControlPlaneMigration.Phase)
+	require.Empty(t, cd.Status.ControlPlaneMigration.Missing)
+	require.Equal(t, now.Add(time.Hour), cd.Status.ControlPlaneMigration.CompletionTime.Time)
+	require.True(t, apimeta.IsStatusConditionTrue(cd.Status.Conditions, kcm.ControlPlaneMigrationCondition))
+}
+
+func TestInventory(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"}},
+	).Build()
+
+	inventory, err := Inventory(t.Context(), cl)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Namespace/default", "Namespace/kube-system", "PersistentVolumeClaim/default/data"}, inventory)
+}
+
+func TestControlPlaneMigrated(t *testing.T) {
+	cd := newClusterDeployment("aws-standalone-cp-1-0-0", false)
+	cd.Status.ControlPlaneMigration = &kcm.ControlPlaneMigration{ToMode: kcm.ControlPlaneModeStandalone, Phase: kcm.ControlPlaneMigrationPhaseMigrating}
+
+	newCluster := func(kind string, ready bool) *clusterv1.Cluster {
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"},
+			Spec: clusterv1.ClusterSpec{ControlPlaneRef: &corev1.ObjectReference{
+				APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+				Kind:       kind,
+				Name:       "dev-cp",
+			}},
+		}
+		cluster.Status.ControlPlaneReady = ready
+		return cluster
+	}
+
+	for _, tc := range []struct {
+		name     string
+		objects  []client.Object
+		migrated bool
+	}{
+		{name: "no cluster"},
+		{name: "previous control plane", objects: []client.Object{newCluster("K0smotronControlPlane", true)}},
+		{name: "target control plane not ready", objects: []client.Object{newCluster("K0sControlPlane", false)}},
+		{name: "target control plane ready", objects: []client.Object{newCluster("K0sControlPlane", true)}, migrated: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tc.objects...).Build()
+
+			migrated, err := ControlPlaneMigrated(t.Context(), cl, cd)
+			require.NoError(t, err)
+			require.Equal(t, tc.migrated, migrated)
+		})
+	}
+}
@@ -0,0 +1,385 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package crdupgrade checks the upgrade of the installed CustomResourceDefinitions
+// to the ones shipped by the charts of the ProviderTemplates.
+package crdupgrade
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// listPageSize is the number of the custom resources listed at once to check the usage of the removed fields.
+const listPageSize = 500
+
+// ChartCRDs returns the CustomResourceDefinitions shipped by the given chart and its subcharts,
+// both in the crds directories and rendered from the templates with the default values.
+// If the templates fail to render, the CustomResourceDefinitions of the crds directories are returned with the error.
+func ChartCRDs(helmChart *chart.Chart) ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	var crds []*apiextensionsv1.CustomResourceDefinition
+	for _, crd := range helmChart.CRDObjects() {
+		objs, err := parseCRDs(string(crd.File.Data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", crd.Filename, err)
+		}
+		crds = append(crds, objs...)
+	}
+
+	if err := chartutil.ProcessDependencies(helmChart, helmChart.Values); err != nil {
+		return crds, fmt.Errorf("failed to process the chart dependencies: %w", err)
+	}
+	values, err := chartutil.ToRenderValues(helmChart, helmChart.Values, chartutil.ReleaseOptions{
+		Name:      helmChart.Name(),
+		Namespace: metav1.NamespaceDefault,
+		IsInstall: true,
+	}, chartutil.DefaultCapabilities)
+	if err != nil {
+		return crds, fmt.Errorf("failed to prepare the values to render the chart: %w", err)
+	}
+	rendered, err := engine.Render(helmChart, values)
+	if err != nil {
+		return crds, fmt.Errorf("failed to render the chart: %w", err)
+	}
+
+	// render the files in a stable order
+	for _, name := range slices.Sorted(maps.Keys(rendered)) {
+		objs, err := parseCRDs(rendered[name])
+		if err != nil {
+			return crds, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		crds = append(crds, objs...)
+	}
+
+	return crds, nil
+}
+
+// parseCRDs returns the CustomResourceDefinitions of the given multi-document manifest.
+func parseCRDs(manifest string) ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	var crds []*apiextensionsv1.CustomResourceDefinition
+	for _, doc := range releaseutil.SplitManifests(manifest) {
+		typeMeta := new(metav1.TypeMeta)
+		if err := yaml.Unmarshal([]byte(doc), typeMeta); err != nil {
+			// the files other than manifests, e.g. NOTES.txt
+			continue
+		}
+		if typeMeta.APIVersion != apiextensionsv1.SchemeGroupVersion.String() || typeMeta.Kind != "CustomResourceDefinition" {
+			continue
+		}
+
+		crd := new(apiextensionsv1.CustomResourceDefinition)
+		if err := yaml.Unmarshal([]byte(doc), crd); err != nil {
+			return nil, err
+		}
+		crds = append(crds, crd)
+	}
+
+	return crds, nil
+}
+
+// Check compares the given CustomResourceDefinitions with the installed ones and returns the issues of upgrading them:
+//   - a removed version still listed in the stored versions of the installed CRD blocks the upgrade
+//     since the API server rejects the update of the CRD;
+//   - a removed field of a kept version set by any of the custom resources blocks the upgrade since
+//     the field gets pruned from the objects;
+//   - a removed served version while the custom resources exist is a warning since the clients of the version break.
+//
+// The usage of the removed fields the controller is not allowed to verify is reported as a warning.
+func Check(ctx context.Context, cl client.Client, crds []*apiextensionsv1.CustomResourceDefinition) ([]kcm.CRDUpgradeIssue, error) {
+	var issues []kcm.CRDUpgradeIssue
+	for _, crd := range crds {
+		installed, err := getCRD(ctx, cl, crd.Name)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get CustomResourceDefinition %s: %w", crd.Name, err)
+		}
+
+		crdIssues, err := checkCRD(ctx, cl, installed, crd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check CustomResourceDefinition %s: %w", crd.Name, err)
+		}
+		issues = append(issues, crdIssues...)
+	}
+
+	return issues, nil
+}
+
+// getCRD reads the CustomResourceDefinition uncached, the controller does not watch the CRDs.
+func getCRD(ctx context.Context, cl client.Client, name string) (*apiextensionsv1.CustomResourceDefinition, error) {
+	u := new(unstructured.Unstructured)
+	u.SetGroupVersionKind(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+	if err := cl.Get(ctx, client.ObjectKey{Name: name}, u); err != nil {
+		return nil, err
+	}
+
+	crd := new(apiextensionsv1.CustomResourceDefinition)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, crd); err != nil {
+		return nil, fmt.Errorf("failed to convert CustomResourceDefinition %s: %w", name, err)
+	}
+	return crd, nil
+}
+
+func checkCRD(ctx context.Context, cl client.Client, installed, crd *apiextensionsv1.CustomResourceDefinition) ([]kcm.CRDUpgradeIssue, error) {
+	var issues []kcm.CRDUpgradeIssue
+	for _, installedVersion := range installed.Spec.Versions {
+		gvk := schema.GroupVersionKind{Group: installed.Spec.Group, Version: installedVersion.Name, Kind: installed.Spec.Names.ListKind}
+
+		idx := slices.IndexFunc(crd.Spec.Versions, func(v apiextensionsv1.CustomResourceDefinitionVersion) bool {
+			return v.Name == installedVersion.Name
+		})
+		if idx < 0 {
+			switch {
+			case slices.Contains(installed.Status.StoredVersions, installedVersion.Name):
+				issues = append(issues, kcm.CRDUpgradeIssue{
+					CRD:      crd.Name,
+					Version:  installedVersion.Name,
+					Message:  "the version is removed while the objects are still stored in it, migrate the stored objects and remove the version from the stored versions of the CRD first",
+					Blocking: true,
+				})
+			case installedVersion.Served:
+				objs, err := listObjects(ctx, cl, gvk, 1, "")
+				if err != nil {
+					if apierrors.IsForbidden(err) {
+						continue
+					}
+					return nil, err
+				}
+				if len(objs.Items) > 0 {
+					issues = append(issues, kcm.CRDUpgradeIssue{
+						CRD:     crd.Name,
+						Version: installedVersion.Name,
+						Message: "the served version is removed while the objects of the CRD exist, the clients of the version will break",
+					})
+				}
+			}
+			continue
+		}
+
+		removed := removedFields(installedVersion.Schema, crd.Spec.Versions[idx].Schema)
+		if len(removed) == 0 {
+			continue
+		}
+
+		used, err := usedFields(ctx, cl, gvk, removed)
+		if apierrors.IsForbidden(err) {
+			for _, f := range removed {
+				issues = append(issues, kcm.CRDUpgradeIssue{
+					CRD:     crd.Name,
+					Version: installedVersion.Name,
+					Field:   f.String(),
+					Message: "the field is removed, its usage cannot be verified: " + err.Error(),
+				})
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, f := range removed {
+			obj, ok := used[f.String()]
+			if !ok {
+				continue
+			}
+			issues = append(issues, kcm.CRDUpgradeIssue{
+				CRD:      crd.Name,
+				Version:  installedVersion.Name,
+				Field:    f.String(),
+				Message:  "the field is removed while set by " + obj + ", it would be pruned",
+				Blocking: true,
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+func listObjects(ctx context.Context, cl client.Client, gvk schema.GroupVersionKind, limit int64, continueToken string) (*unstructured.UnstructuredList, error) {
+	list := new(unstructured.UnstructuredList)
+	list.SetGroupVersionKind(gvk)
+	if err := cl.List(ctx, list, client.Limit(limit), client.Continue(continueToken)); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// usedFields returns the given fields set by any of the objects of the given kind
+// keyed by the path of the field with the first object setting it.
+func usedFields(ctx context.Context, cl client.Client, gvk schema.GroupVersionKind, fields []fieldPath) (map[string]string, error) {
+	used := make(map[string]string)
+	continueToken := ""
+	for {
+		list, err := listObjects(ctx, cl, gvk, listPageSize, continueToken)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, obj := range list.Items {
+			for _, f := range fields {
+				if _, ok := used[f.String()]; ok {
+					continue
+				}
+				if isSet(obj.Object, f) {
+					used[f.String()] = client.ObjectKeyFromObject(&obj).String()
+				}
+			}
+		}
+
+		continueToken = list.GetContinue()
+		if continueToken == "" || len(used) == len(fields) {
+			return used, nil
+		}
+	}
+}
+
+// fieldPath is the path to a field of a custom resource where the "[]" segment
+// stands for the items of an array and the "*" segment for the values of a map.
+type fieldPath []string
+
+func (f fieldPath) String() string {
+	var b strings.Builder
+	for i, seg := range f {
+		if i > 0 && seg != "[]" {
+			b.WriteByte('.')
+		}
+		b.WriteString(seg)
+	}
+	return b.String()
+}
+
+// removedFields returns the topmost fields of the old schema missing from the new one, ignoring the fields
+// under the ones preserving the unknown fields in the new schema, and the metadata and the status of the objects.
+func removedFields(oldValidation, newValidation *apiextensionsv1.CustomResourceValidation) []fieldPath {
+	if oldValidation == nil || oldValidation.OpenAPIV3Schema == nil || newValidation == nil || newValidation.OpenAPIV3Schema == nil {
+		return nil
+	}
+	if isTrue(newValidation.OpenAPIV3Schema.XPreserveUnknownFields) {
+		return nil
+	}
+
+	oldFields := fields(oldValidation.OpenAPIV3Schema)
+	newFields := fields(newValidation.OpenAPIV3Schema)
+
+	var removed []fieldPath
+	for _, key := range slices.Sorted(maps.Keys(oldFields)) {
+		if _, ok := newFields[key]; ok {
+			continue
+		}
+
+		path := oldFields[key].path
+		covered := false
+		for i := len(path) - 1; i > 0 && !covered; i-- {
+			parent, ok := newFields[path[:i].String()]
+			covered = ok && parent.preserveUnknown
+			// report only the topmost removed field
+			_, parentRemoved := oldFields[path[:i].String()]
+			covered = covered || (parentRemoved && !ok)
+		}
+		if !covered {
+			removed = append(removed, path)
+		}
+	}
+
+	return removed
+}
+
+type field struct {
+	path            fieldPath
+	preserveUnknown bool
+}
+
+// fields returns the fields of the given schema of a custom resource keyed by their paths.
+func fields(root *apiextensionsv1.JSONSchemaProps) map[string]field {
+	result := make(map[string]field)
+
+	var walk func(path fieldPath, props *apiextensionsv1.JSONSchemaProps)
+	walk = func(path fieldPath, props *apiextensionsv1.JSONSchemaProps) {
+		if len(path) > 0 {
+			result[path.String()] = field{path: path, preserveUnknown: isTrue(props.XPreserveUnknownFields)}
+		}
+		for name, child := range props.Properties {
+			if len(path) == 0 && (name == "apiVersion" || name == "kind" || name == "metadata" || name == "status") {
+				continue
+			}
+			walk(append(slices.Clone(path), name), &child)
+		}
+		if props.Items != nil && props.Items.Schema != nil {
+			walk(append(slices.Clone(path), "[]"), props.Items.Schema)
+		}
+		if props.AdditionalProperties != nil && props.AdditionalProperties.Schema != nil {
+			walk(append(slices.Clone(path), "*"), props.AdditionalProperties.Schema)
+		}
+	}
+	walk(nil, root)
+
+	return result
+}
+
+// isSet returns whether the field of the given path is set in the given value.
+func isSet(value any, path fieldPath) bool {
+	if len(path) == 0 {
+		return true
+	}
+
+	switch path[0] {
+	case "[]":
+		items, ok := value.([]any)
+		if !ok {
+			return false
+		}
+		return slices.ContainsFunc(items, func(item any) bool { return isSet(item, path[1:]) })
+	case "*":
+		m, ok := value.(map[string]any)
+		if !ok {
+			return false
+		}
+		for _, v := range m {
+			if isSet(v, path[1:]) {
+				return true
+			}
+		}
+		return false
+	default:
+		m, ok := value.(map[string]any)
+		if !ok {
+			return false
+		}
+		v, ok := m[path[0]]
+		return ok && isSet(v, path[1:])
+	}
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
@@ -0,0 +1,189 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crdupgrade
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newCRD(storedVersions []string, versions ...apiextensionsv1.CustomResourceDefinitionVersion) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		TypeMeta:   metav1.TypeMeta{APIVersion: apiextensionsv1.SchemeGroupVersion.String(), Kind: "CustomResourceDefinition"},
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group:    "example.com",
+			Names:    apiextensionsv1.CustomResourceDefinitionNames{Kind: "Widget", ListKind: "WidgetList", Plural: "widgets"},
+			Scope:    apiextensionsv1.NamespaceScoped,
+			Versions: versions,
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: storedVersions},
+	}
+}
+
+func newVersion(name string, storage bool, spec map[string]apiextensionsv1.JSONSchemaProps) apiextensionsv1.CustomResourceDefinitionVersion {
+	return apiextensionsv1.CustomResourceDefinitionVersion{
+		Name:    name,
+		Served:  true,
+		Storage: storage,
+		Schema: &apiextensionsv1.CustomResourceValidation{OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+			Type: "object",
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{
+				"spec":   {Type: "object", Properties: spec},
+				"status": {Type: "object", Properties: map[string]apiextensionsv1.JSONSchemaProps{"ready": {Type: "boolean"}}},
+			},
+		}},
+	}
+}
+
+func newWidget(name, version string, spec map[string]any) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	u.SetAPIVersion("example.com/" + version)
+	u.SetKind("Widget")
+	u.SetNamespace(metav1.NamespaceDefault)
+	u.SetName(name)
+	return u
+}
+
+func TestCheck(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	str := apiextensionsv1.JSONSchemaProps{Type: "string"}
+	installed := newCRD([]string{"v1alpha1", "v1beta1"},
+		newVersion("v1alpha1", false, map[string]apiextensionsv1.JSONSchemaProps{"size": str}),
+		newVersion("v1beta1", true, map[string]apiextensionsv1.JSONSchemaProps{
+			"size":  str,
+			"color": str,
+			"parts": {Type: "array", Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{
+				Type:       "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{"name": str, "weight": str},
+			}}},
+			"legacy": {Type: "object", Properties: map[string]apiextensionsv1.JSONSchemaProps{"a": str, "b": str}},
+		}),
+	)
+	upgraded := newCRD(nil,
+		newVersion("v1beta1", true, map[string]apiextensionsv1.JSONSchemaProps{
+			"size": str,
+			"parts": {Type: "array", Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: &apiextensionsv1.JSONSchemaProps{
+				Type:       "object",
+				Properties: map[string]apiextensionsv1.JSONSchemaProps{"name": str},
+			}}},
+		}),
+		newVersion("v1", false, map[string]apiextensionsv1.JSONSchemaProps{"size": str}),
+	)
+	unknown := newCRD(nil, newVersion("v1", true, nil))
+	unknown.Name = "gadgets.example.com"
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		installed,
+		newWidget("small", "v1beta1", map[string]any{"size": "s", "parts": []any{map[string]any{"name": "gear", "weight": "1kg"}}}),
+		newWidget("plain", "v1beta1", map[string]any{"size": "m"}),
+	).Build()
+
+	issues, err := Check(t.Context(), cl, []*apiextensionsv1.CustomResourceDefinition{upgraded, unknown})
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	want := []kcm.CRDUpgradeIssue{
+		{
+			CRD:      "widgets.example.com",
+			Version:  "v1alpha1",
+			Message:  "the version is removed while the objects are still stored in it, migrate the stored objects and remove the version from the stored versions of the CRD first",
+			Blocking: true,
+		},
+		{
+			CRD:      "widgets.example.com",
+			Version:  "v1beta1",
+			Field:    "spec.parts[].weight",
+			Message:  "the field is removed while set by default/small, it would be pruned",
+			Blocking: true,
+		},
+	}
+	if !reflect.DeepEqual(issues, want) {
+		t.Errorf("Check() = %+v, want %+v", issues, want)
+	}
+}
+
+func TestRemovedFields(t *testing.T) {
+	str := apiextensionsv1.JSONSchemaProps{Type: "string"}
+	preserve := true
+	oldV := newVersion("v1", true, map[string]apiextensionsv1.JSONSchemaProps{
+		"size":   str,
+		"legacy": {Type: "object", Properties: map[string]apiextensionsv1.JSONSchemaProps{"a": str, "b": str}},
+		"labels": {Type: "object", AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{Schema: &apiextensionsv1.JSONSchemaProps{
+			Type:       "object",
+			Properties: map[string]apiextensionsv1.JSONSchemaProps{"value": str},
+		}}},
+		"extra": {Type: "object", Properties: map[string]apiextensionsv1.JSONSchemaProps{"free": str}},
+	})
+	newV := newVersion("v1", true, map[string]apiextensionsv1.JSONSchemaProps{
+		"size":   str,
+		"labels": {Type: "object", AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{Schema: &apiextensionsv1.JSONSchemaProps{Type: "object"}}},
+		"extra":  {Type: "object", XPreserveUnknownFields: &preserve},
+	})
+	// the status is not checked
+	newV.Schema.OpenAPIV3Schema.Properties["status"] = apiextensionsv1.JSONSchemaProps{Type: "object"}
+
+	var got []string
+	for _, f := range removedFields(oldV.Schema, newV.Schema) {
+		got = append(got, f.String())
+	}
+	if want := []string{"spec.labels.*.value", "spec.legacy"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("removedFields() = %v, want %v", got, want)
+	}
+}
+
+func TestChartCRDs(t *testing.T) {
+	crd := `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: %s
+`
+	helmChart := &chart.Chart{
+		Metadata: &chart.Metadata{APIVersion: "v2", Name: "widgets", Version: "0.1.0"},
+		Values:   map[string]any{"gadgets": true},
+		Files:    []*chart.File{{Name: "crds/widgets.yaml", Data: []byte(fmt.Sprintf(crd, "widgets.example.com"))}},
+		Templates: []*chart.File{
+			{Name: "templates/gadgets.yaml", Data: []byte("{{- if .Values.gadgets }}\n" + fmt.Sprintf(crd, "gadgets.example.com") + "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: gadgets\n{{- end }}\n")},
+			{Name: "templates/NOTES.txt", Data: []byte("Widgets are installed.")},
+		},
+	}
+
+	crds, err := ChartCRDs(helmChart)
+	if err != nil {
+		t.Fatalf("ChartCRDs() error = %v", err)
+	}
+	var names []string
+	for _, crd := range crds {
+		names = append(names, crd.Name)
+	}
+	if want := []string{"widgets.example.com", "gadgets.example.com"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ChartCRDs() = %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credspropagation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/providers"
+)
+
+const (
+	// IdentityIdentifier is the identifier of the cluster identity in the resource template.
+	IdentityIdentifier = "InfrastructureProviderIdentity"
+	// IdentitySecretIdentifier is the identifier of the Secret referenced by the cluster identity in the resource template.
+	IdentitySecretIdentifier = "InfrastructureProviderIdentitySecret"
+
+	// resourceTemplateSuffix is the suffix of the name of the ConfigMap with the resource template
+	// projecting the cluster identity into the child cluster.
+	resourceTemplateSuffix = "-resource-template"
+	// legacySecretSuffix is the suffix of the name of the Secret referenced by the cluster identity
+	// of the providers not defining the credential propagation.
+	legacySecretSuffix = "-secret"
+)
+
+// Transformer projects the cluster identity of a Credential into the child cluster,
+// e.g. into the secrets of the cloud-controller-manager and the CSI driver.
+type Transformer interface {
+	// TemplateResourceRefs returns the resources the resource template is instantiated with.
+	// Sveltos redeploys the resource template once any of the resources changes.
+	TemplateResourceRefs(ctx context.Context, cl client.Client, identityRef *corev1.ObjectReference) ([]sveltosv1beta1.TemplateResourceRef, error)
+	// PolicyRefs returns the resource templates deploying the projected identity into the child cluster.
+	PolicyRefs(identityRef *corev1.ObjectReference) []sveltosv1beta1.PolicyRef
+}
+
+// Refs returns the template resources and the policies propagating the Credential into the child cluster
+// of the given ClusterDeployment. Nothing is returned if the propagation is disabled.
+func Refs(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment, cred *kcm.Credential) ([]sveltosv1beta1.TemplateResourceRef, []sveltosv1beta1.PolicyRef, error) {
+	if !cd.Spec.PropagateCredentials || cred.Spec.IdentityRef == nil {
+		return nil, nil, nil
+	}
+
+	t := ForIdentity(cred.Spec.IdentityRef.Kind)
+
+	templateResourceRefs, err := t.TemplateResourceRefs(ctx, cl, cred.Spec.IdentityRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get template resources of the Credential %s: %w", client.ObjectKeyFromObject(cred), err)
+	}
+
+	return templateResourceRefs, t.PolicyRefs(cred.Spec.IdentityRef), nil
+}
+
+// ForIdentity returns the transformer of the given cluster identity kind
+// defined by the infrastructure provider supporting the kind.
+func ForIdentity(kind string) Transformer {
+	if strings.EqualFold(kind, "Secret") {
+		return identityTransformer{}
+	}
+
+	cp := providers.GetCredentialPropagation(kind)
+	if cp == nil {
+		return legacyTransformer{}
+	}
+
+	ref, ok := cp.IdentitySecrets[kind]
+	if !ok {
+		return identityTransformer{}
+	}
+
+	return fieldRefTransformer{ref: ref}
+}
+
+// resourceTemplate provides the policies common to all of the transformers.
+type resourceTemplate struct{}
+
+func (resourceTemplate) PolicyRefs(identityRef *corev1.ObjectReference) []sveltosv1beta1.PolicyRef {
+	return []sveltosv1beta1.PolicyRef{
+		{
+			Kind:           "ConfigMap",
+			Namespace:      identityRef.Namespace,
+			Name:           identityRef.Name + resourceTemplateSuffix,
+			DeploymentType: sveltosv1beta1.DeploymentTypeRemote,
+		},
+	}
+}
+
+// identityTransformer projects the identities holding the credentials themselves, e.g. the Secrets,
+// or not having any credentials, e.g. the identities assumed by the provider controller.
+type identityTransformer struct{ resourceTemplate }
+
+func (identityTransformer) TemplateResourceRefs(_ context.Context, _ client.Client, identityRef *corev1.ObjectReference) ([]sveltosv1beta1.TemplateResourceRef, error) {
+	return []sveltosv1beta1.TemplateResourceRef{identityResourceRef(identityRef)}, nil
+}
+
+// fieldRefTransformer projects the identities referencing the Secrets by the fields defined by the provider.
+type fieldRefTransformer struct {
+	resourceTemplate
+	ref providers.IdentitySecretRef
+}
+
+func (t fieldRefTransformer) TemplateResourceRefs(ctx context.Context, cl client.Client, identityRef *corev1.ObjectReference) ([]sveltosv1beta1.TemplateResourceRef, error) {
+	identity := new(unstructured.Unstructured)
+	identity.SetAPIVersion(identityRef.APIVersion)
+	identity.SetKind(identityRef.Kind)
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: identityRef.Namespace, Name: identityRef.Name}, identity); err != nil {
+		return nil, fmt.Errorf("failed to get %s %s/%s: %w", identityRef.Kind, identityRef.Namespace, identityRef.Name, err)
+	}
+
+	name, err := nestedString(identity, t.ref.NameField)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := []sveltosv1beta1.TemplateResourceRef{identityResourceRef(identityRef)}
+	if name == "" {
+		return refs, nil
+	}
+
+	namespace := identityRef.Namespace
+	if t.ref.NamespaceField != "" {
+		ns, err := nestedString(identity, t.ref.NamespaceField)
+		if err != nil {
+			return nil, err
+		}
+		if ns != "" {
+			namespace = ns
+		}
+	}
+
+	return append(refs, secretResourceRef(namespace, name)), nil
+}
+
+// legacyTransformer projects the identities of the providers not defining the credential propagation,
+// the Secrets of such identities are expected to be named after the identities.
+type legacyTransformer struct{ resourceTemplate }
+
+func (legacyTransformer) TemplateResourceRefs(_ context.Context, _ client.Client, identityRef *corev1.ObjectReference) ([]sveltosv1beta1.TemplateResourceRef, error) {
+	return []sveltosv1beta1.TemplateResourceRef{
+		identityResourceRef(identityRef),
+		secretResourceRef(identityRef.Namespace, identityRef.Name+legacySecretSuffix),
+	}, nil
+}
+
+func identityResourceRef(identityRef *corev1.ObjectReference) sveltosv1beta1.TemplateResourceRef {
+	return sveltosv1beta1.TemplateResourceRef{
+		Resource:   *identityRef,
+		Identifier: IdentityIdentifier,
+	}
+}
+
+func secretResourceRef(namespace, name string) sveltosv1beta1.TemplateResourceRef {
+	return sveltosv1beta1.TemplateResourceRef{
+		Resource: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Namespace:  namespace,
+			Name:       name,
+		},
+		Identifier: IdentitySecretIdentifier,
+	}
+}
+
+func nestedString(obj *unstructured.Unstructured, field string) (string, error) {
+	v, _, err := unstructured.NestedString(obj.Object, strings.Split(field, ".")...)
+	if err != nil {
+		return "", fmt.Errorf("failed to get field %s of %s %s: %w", field, obj.GetKind(), client.ObjectKeyFromObject(obj), err)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credspropagation
+
+import (
+	"testing"
+
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func identity(apiVersion, kind, name string, spec map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace("kcm-system")
+	obj.SetName(name)
+	return obj
+}
+
+func TestRefs(t *testing.T) {
+	objs := []*unstructured.Unstructured{
+		identity("infrastructure.cluster.x-k8s.io/v1beta2", "AWSClusterStaticIdentity", "aws", map[string]any{
+			"secretRef": "aws-credentials",
+		}),
+		identity("infrastructure.cluster.x-k8s.io/v1beta1", "AzureClusterIdentity", "azure", map[string]any{
+			"clientSecret": map[string]any{"name": "azure-credentials", "namespace": "azure-system"},
+		}),
+		identity("infrastructure.cluster.x-k8s.io/v1beta1", "VSphereClusterIdentity", "vsphere", map[string]any{}),
+	}
+
+	builder := fake.NewClientBuilder().WithScheme(scheme.Scheme)
+	for _, obj := range objs {
+		builder = builder.WithObjects(obj)
+	}
+	cl := builder.Build()
+
+	resourceTemplate := func(name string) []sveltosv1beta1.PolicyRef {
+		return []sveltosv1beta1.PolicyRef{{
+			Kind:           "ConfigMap",
+			Namespace:      "kcm-system",
+			Name:           name + "-resource-template",
+			DeploymentType: sveltosv1beta1.DeploymentTypeRemote,
+		}}
+	}
+	secret := func(namespace, name string) *sveltosv1beta1.TemplateResourceRef {
+		return &sveltosv1beta1.TemplateResourceRef{
+			Resource:   corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Namespace: namespace, Name: name},
+			Identifier: IdentitySecretIdentifier,
+		}
+	}
+
+	for _, tc := range []struct {
+		name        string
+		identityRef *corev1.ObjectReference
+		disabled    bool
+		secret      *sveltosv1beta1.TemplateResourceRef
+		err         string
+	}{
+		{
+			name:        "propagation disabled",
+			identityRef: &corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Namespace: "kcm-system", Name: "gcp"},
+			disabled:    true,
+		},
+		{
+			name:        "secret identity",
+			identityRef: &corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Namespace: "kcm-system", Name: "gcp"},
+		},
+		{
+			name:        "identity referencing secret by name",
+			identityRef: &corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2", Kind: "AWSClusterStaticIdentity", Namespace: "kcm-system", Name: "aws"},
+			secret:      secret("kcm-system", "aws-credentials"),
+		},
+		{
+			name:        "identity referencing secret by name and namespace",
+			identityRef: &corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Kind: "AzureClusterIdentity", Namespace: "kcm-system", Name: "azure"},
+			secret:      secret("azure-system", "azure-credentials"),
+		},
+		{
+			name:        "identity without secret set",
+			identityRef: &corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Kind: "VSphereClusterIdentity", Namespace: "kcm-system", Name: "vsphere"},
+		},
+		{
+			name:        "identity without secret",
+			identityRef: &corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2", Kind: "AWSClusterRoleIdentity", Namespace: "kcm-system", Name: "aws-role"},
+		},
+		{
+			name:        "identity of provider without propagation defined",
+			identityRef: &corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Kind: "CustomClusterIdentity", Namespace: "kcm-system", Name: "custom"},
+			secret:      secret("kcm-system", "custom-secret"),
+		},
+		{
+			name:        "missing identity",
+			identityRef: &corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta2", Kind: "AWSClusterStaticIdentity", Namespace: "kcm-system", Name: "missing"},
+			err:         "failed to get AWSClusterStaticIdentity kcm-system/missing",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := &kcm.ClusterDeployment{Spec: kcm.ClusterDeploymentSpec{PropagateCredentials: !tc.disabled}}
+			cred := &kcm.Credential{Spec: kcm.CredentialSpec{IdentityRef: tc.identityRef}}
+
+			templateResourceRefs, policyRefs, err := Refs(t.Context(), cl, cd, cred)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.disabled {
+				require.Empty(t, templateResourceRefs)
+				require.Empty(t, policyRefs)
+				return
+			}
+
+			expected := []sveltosv1beta1.TemplateResourceRef{{Resource: *tc.identityRef, Identifier: IdentityIdentifier}}
+			if tc.secret != nil {
+				expected = append(expected, *tc.secret)
+			}
+			require.Equal(t, expected, templateResourceRefs)
+			require.Equal(t, resourceTemplate(tc.identityRef.Name), policyRefs)
+		})
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package deletion diagnoses the deletion of the ClusterDeployments reporting
+// the resources blocking it and forcibly finalizes the stuck deletions once
+// confirmed by the user.
+package deletion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	hcv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/fluxcd/pkg/apis/meta"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// StuckTimeout is the duration of the deletion after which the deletion is reported as stuck.
+const StuckTimeout = 30 * time.Minute
+
+// Diagnose returns the deletion status of the given ClusterDeployment listing the resources
+// of the cluster still present in the management cluster: the HelmRelease, the CAPI Cluster,
+// its infrastructure cluster and control plane and the Machines of the cluster.
+func Diagnose(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment, now time.Time) (*kcm.DeletionStatus, error) {
+	resources, err := blockingResources(ctx, cl, cd)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &kcm.DeletionStatus{
+		LastTransitionTime: metav1.NewTime(now),
+		Finalizers:         slices.Clone(cd.Finalizers),
+	}
+	for _, obj := range resources {
+		status.BlockingResources = append(status.BlockingResources, obj.BlockingResource)
+	}
+	if cd.DeletionTimestamp != nil {
+		status.Stuck = now.Sub(cd.DeletionTimestamp.Time) > StuckTimeout
+	}
+
+	// keep the transition time unless the blocking resources have changed to avoid needless status updates
+	if previous := cd.Status.Deletion; previous != nil {
+		status.LastTransitionTime = previous.LastTransitionTime
+		if equality.Semantic.DeepEqual(previous, status) {
+			return previous.DeepCopy(), nil
+		}
+		status.LastTransitionTime = metav1.NewTime(now)
+	}
+
+	return status, nil
+}
+
+// Condition returns the Deleting condition reflecting the given deletion status.
+func Condition(cd *kcm.ClusterDeployment, status *kcm.DeletionStatus) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               kcm.DeletingCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             kcm.ProgressingReason,
+		ObservedGeneration: cd.Generation,
+	}
+
+	blockers := make([]string, 0, len(status.BlockingResources))
+	for _, r := range status.BlockingResources {
+		blocker := r.Kind + " " + r.Name
+		if len(r.Finalizers) > 0 {
+			blocker += " (finalizers: " + strings.Join(r.Finalizers, ", ") + ")"
+		}
+		if r.Message != "" {
+			blocker += ": " + r.Message
+		}
+		blockers = append(blockers, blocker)
+	}
+
+	if len(blockers) == 0 {
+		condition.Message = "Waiting for the finalizers " + strings.Join(status.Finalizers, ", ")
+	} else {
+		condition.Message = "Waiting for " + strings.Join(blockers, "; ")
+	}
+
+	if status.Stuck {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kcm.DeletionStuckReason
+		condition.Message = fmt.Sprintf("Deletion requested at %s is stuck. %s. To forcibly finalize the deletion set the %s annotation to %q, the cloud resources of the cluster may be orphaned",
+			cd.DeletionTimestamp.UTC().Format(time.RFC3339), condition.Message, kcm.ForceFinalizeAnnotation, cd.UID)
+	}
+
+	return condition
+}
+
+// ForceFinalizeRequested returns true if the forced finalization of the deletion
+// of the given ClusterDeployment has been requested and confirmed.
+func ForceFinalizeRequested(cd *kcm.ClusterDeployment) bool {
+	return cd.DeletionTimestamp != nil && cd.Annotations[kcm.ForceFinalizeAnnotation] == string(cd.UID)
+}
+
+// ForceFinalize deletes the resources blocking the deletion of the given ClusterDeployment
+// removing their finalizers and returns the finalized resources.
+// The finalizers of the ClusterDeployment itself are left to the caller.
+func ForceFinalize(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) ([]kcm.BlockingResource, error) {
+	resources, err := blockingResources(ctx, cl, cd)
+	if err != nil {
+		return nil, err
+	}
+
+	// the dependents go first so that their owners are not recreating them
+	slices.Reverse(resources)
+
+	finalized := make([]kcm.BlockingResource, 0, len(resources))
+	for _, obj := range resources {
+		if !obj.Deleting {
+			if err := cl.Delete(ctx, obj.object); client.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("failed to delete %s %s/%s: %w", obj.Kind, cd.Namespace, obj.Name, err)
+			}
+		}
+
+		if len(obj.Finalizers) > 0 {
+			patch := client.RawPatch(client.Merge.Type(), []byte(`{"metadata":{"finalizers":null}}`))
+			if err := cl.Patch(ctx, obj.object, patch); client.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("failed to remove finalizers of %s %s/%s: %w", obj.Kind, cd.Namespace, obj.Name, err)
+			}
+		}
+
+		finalized = append(finalized, obj.BlockingResource)
+	}
+
+	return finalized, nil
+}
+
+// blockingResource is a resource the deletion of a cluster waits for.
+type blockingResource struct {
+	kcm.BlockingResource
+
+	object client.Object
+}
+
+// blockingResources returns the resources of the given ClusterDeployment present in the management cluster,
+// the owners go first.
+func blockingResources(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) ([]blockingResource, error) {
+	var resources []blockingResource
+
+	hr := new(hcv2.HelmRelease)
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(cd), hr); client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to get HelmRelease %s/%s: %w", cd.Namespace, cd.Name, err)
+	} else if err == nil {
+		resources = append(resources, newBlockingResource(hr, hcv2.GroupVersion.WithKind(hcv2.HelmReleaseKind), readyMessage(hr.Status.Conditions)))
+	}
+
+	cluster := new(clusterv1.Cluster)
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(cd), cluster); client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to get Cluster %s/%s: %w", cd.Namespace, cd.Name, err)
+	} else if err == nil {
+		resources = append(resources, newBlockingResource(cluster, clusterv1.GroupVersion.WithKind(clusterv1.ClusterKind), capiReadyMessage(cluster.Status.Conditions)))
+
+		for _, ref := range []*corev1.ObjectReference{cluster.Spec.ControlPlaneRef, cluster.Spec.InfrastructureRef} {
+			if ref == nil {
+				continue
+			}
+			obj, err := getReferenced(ctx, cl, cd.Namespace, ref)
+			if err != nil {
+				return nil, err
+			}
+			if obj != nil {
+				resources = append(resources, newBlockingResource(obj, obj.GroupVersionKind(), ""))
+			}
+		}
+	}
+
+	machines := new(clusterv1.MachineList)
+	if err := cl.List(ctx, machines, client.InNamespace(cd.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cd.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list Machines: %w", err)
+	}
+	for i := range machines.Items {
+		machine := &machines.Items[i]
+		resources = append(resources, newBlockingResource(machine, clusterv1.GroupVersion.WithKind("Machine"), capiReadyMessage(machine.Status.Conditions)))
+	}
+
+	return resources, nil
+}
+
+func newBlockingResource(obj client.Object, gvk schema.GroupVersionKind, message string) blockingResource {
+	return blockingResource{
+		BlockingResource: kcm.BlockingResource{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Name:       obj.GetName(),
+			Finalizers: slices.Clone(obj.GetFinalizers()),
+			Deleting:   obj.GetDeletionTimestamp() != nil,
+			Message:    message,
+		},
+		object: obj,
+	}
+}
+
+// getReferenced returns the metadata of the object referenced by the CAPI Cluster, nil if not found.
+func getReferenced(ctx context.Context, cl client.Client, namespace string, ref *corev1.ObjectReference) (*metav1.PartialObjectMetadata, error) {
+	obj := new(metav1.PartialObjectMetadata)
+	obj.SetGroupVersionKind(ref.GroupVersionKind())
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get %s %s/%s: %w", ref.Kind, namespace, ref.Name, err)
+	}
+
+	return obj, nil
+}
+
+// readyMessage returns the message of the Ready condition unless it is true.
+func readyMessage(conditions []metav1.Condition) string {
+	c := apimeta.FindStatusCondition(conditions, meta.ReadyCondition)
+	if c == nil || c.Status == metav1.ConditionTrue {
+		return ""
+	}
+	return c.Message
+}
+
+// capiReadyMessage returns the message of the CAPI Ready condition unless it is true.
+func capiReadyMessage(conditions clusterv1.Conditions) string {
+	for _, c := range conditions {
+		if c.Type == clusterv1.ReadyCondition && c.Status != corev1.ConditionTrue {
+			return c.Message
+		}
+	}
+	return ""
+}
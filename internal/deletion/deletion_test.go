@@ -0,0 +1,164 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deletion
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	hcv2 "github.com/fluxcd/helm-controller/api/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+var awsClusterGVK = schema.GroupVersionKind{Group: "infrastructure.cluster.x-k8s.io", Version: "v1beta2", Kind: "AWSCluster"}
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{clusterv1.AddToScheme, hcv2.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatalf("failed to build scheme: %v", err)
+		}
+	}
+	scheme.AddKnownTypeWithName(awsClusterGVK, new(unstructured.Unstructured))
+	return scheme
+}
+
+func newObjects() (*kcm.ClusterDeployment, []client.Object) {
+	deletionTimestamp := metav1.NewTime(time.Now().Add(-time.Hour))
+	cd := &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "dev",
+			Namespace:         "team-a",
+			UID:               "cd-uid",
+			Finalizers:        []string{kcm.ClusterDeploymentFinalizer},
+			DeletionTimestamp: &deletionTimestamp,
+		},
+	}
+
+	hr := &hcv2.HelmRelease{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"}}
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a", Finalizers: []string{clusterv1.ClusterFinalizer}},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: &corev1.ObjectReference{
+				APIVersion: awsClusterGVK.GroupVersion().String(),
+				Kind:       awsClusterGVK.Kind,
+				Name:       "dev",
+			},
+		},
+		Status: clusterv1.ClusterStatus{
+			Conditions: clusterv1.Conditions{{
+				Type:    clusterv1.ReadyCondition,
+				Status:  corev1.ConditionFalse,
+				Message: "waiting for the infrastructure to be deleted",
+			}},
+		},
+	}
+	awsCluster := new(unstructured.Unstructured)
+	awsCluster.SetGroupVersionKind(awsClusterGVK)
+	awsCluster.SetName("dev")
+	awsCluster.SetNamespace("team-a")
+	awsCluster.SetFinalizers([]string{"awscluster.infrastructure.cluster.x-k8s.io"})
+
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "dev-md-0",
+			Namespace:  "team-a",
+			Labels:     map[string]string{clusterv1.ClusterNameLabel: "dev"},
+			Finalizers: []string{clusterv1.MachineFinalizer},
+		},
+	}
+
+	return cd, []client.Object{hr, cluster, awsCluster, machine}
+}
+
+func TestDiagnose(t *testing.T) {
+	cd, objects := newObjects()
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(objects...).Build()
+	now := time.Now()
+
+	status, err := Diagnose(t.Context(), cl, cd, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !status.Stuck {
+		t.Errorf("expected the deletion to be stuck")
+	}
+
+	var kinds []string
+	for _, r := range status.BlockingResources {
+		kinds = append(kinds, r.Kind+"/"+r.Name)
+	}
+	if got, want := strings.Join(kinds, ","), "HelmRelease/dev,Cluster/dev,AWSCluster/dev,Machine/dev-md-0"; got != want {
+		t.Errorf("unexpected blocking resources: got %s, want %s", got, want)
+	}
+	if msg := status.BlockingResources[1].Message; msg != "waiting for the infrastructure to be deleted" {
+		t.Errorf("unexpected message of the Cluster: %s", msg)
+	}
+
+	condition := Condition(cd, status)
+	if condition.Reason != kcm.DeletionStuckReason || !strings.Contains(condition.Message, `set the k0rdent.mirantis.com/force-finalize annotation to "cd-uid"`) {
+		t.Errorf("unexpected condition: %+v", condition)
+	}
+
+	cd.Status.Deletion = status
+	unchanged, err := Diagnose(t.Context(), cl, cd, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !unchanged.LastTransitionTime.Equal(&status.LastTransitionTime) {
+		t.Errorf("expected the transition time to be kept, got %s", unchanged.LastTransitionTime)
+	}
+}
+
+func TestForceFinalize(t *testing.T) {
+	cd, objects := newObjects()
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(objects...).Build()
+
+	cd.Annotations = map[string]string{kcm.ForceFinalizeAnnotation: "wrong"}
+	if ForceFinalizeRequested(cd) {
+		t.Errorf("expected the forced finalization not to be confirmed")
+	}
+	cd.Annotations[kcm.ForceFinalizeAnnotation] = string(cd.UID)
+	if !ForceFinalizeRequested(cd) {
+		t.Errorf("expected the forced finalization to be confirmed")
+	}
+
+	finalized, err := ForceFinalize(t.Context(), cl, cd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(finalized) != len(objects) {
+		t.Errorf("expected %d finalized resources, got %d", len(objects), len(finalized))
+	}
+
+	for _, obj := range objects {
+		if err := cl.Get(t.Context(), client.ObjectKeyFromObject(obj), obj); !apierrors.IsNotFound(err) {
+			t.Errorf("expected %s %s to be deleted, got %v", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	RecordTypeA     = "A"
+	RecordTypeAAAA  = "AAAA"
+	RecordTypeCNAME = "CNAME"
+)
+
+// DNSEndpointGVK is the GroupVersionKind of the external-dns DNSEndpoint object.
+var DNSEndpointGVK = schema.GroupVersionKind{
+	Group:   "externaldns.k8s.io",
+	Version: "v1alpha1",
+	Kind:    "DNSEndpoint",
+}
+
+type ReconcileDNSEndpointOpts struct {
+	OwnerReference *metav1.OwnerReference
+	Endpoint       kcm.ClusterEndpoint
+	TTL            int64
+}
+
+// FQDN returns the fully qualified name of the API endpoint record of the given ClusterDeployment.
+func FQDN(cd *kcm.ClusterDeployment) string {
+	if cd.Spec.DNS == nil {
+		return ""
+	}
+
+	hostname := cd.Spec.DNS.Hostname
+	if hostname == "" {
+		hostname = cd.Name
+	}
+
+	return hostname + "." + strings.TrimSuffix(cd.Spec.DNS.Zone, ".")
+}
+
+// RecordType returns the type of the record pointing to the given target.
+func RecordType(target string) string {
+	addr, err := netip.ParseAddr(target)
+	switch {
+	case err != nil:
+		return RecordTypeCNAME
+	case addr.Is4():
+		return RecordTypeA
+	default:
+		return RecordTypeAAAA
+	}
+}
+
+// ReconcileDNSEndpoint creates or updates the DNSEndpoint object with the given name
+// publishing the record described by the opts.
+func ReconcileDNSEndpoint(ctx context.Context, cl client.Client, name, namespace string, opts ReconcileDNSEndpointOpts) error {
+	obj := new(unstructured.Unstructured)
+	obj.SetGroupVersionKind(DNSEndpointGVK)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+
+	_, err := ctrl.CreateOrUpdate(ctx, cl, obj, func() error {
+		labels := obj.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[kcm.KCMManagedLabelKey] = kcm.KCMManagedLabelValue
+		obj.SetLabels(labels)
+
+		if opts.OwnerReference != nil {
+			obj.SetOwnerReferences([]metav1.OwnerReference{*opts.OwnerReference})
+		}
+
+		targets := make([]any, 0, len(opts.Endpoint.Targets))
+		for _, t := range opts.Endpoint.Targets {
+			targets = append(targets, t)
+		}
+
+		return unstructured.SetNestedSlice(obj.Object, []any{
+			map[string]any{
+				"dnsName":    opts.Endpoint.DNSName,
+				"recordType": opts.Endpoint.RecordType,
+				"recordTTL":  opts.TTL,
+				"targets":    targets,
+			},
+		}, "spec", "endpoints")
+	})
+
+	return err
+}
+
+// DeleteDNSEndpoint deletes the DNSEndpoint object with the given name if it exists.
+func DeleteDNSEndpoint(ctx context.Context, cl client.Client, name, namespace string) error {
+	obj := new(unstructured.Unstructured)
+	obj.SetGroupVersionKind(DNSEndpointGVK)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+
+	err := cl.Delete(ctx, obj)
+	if client.IgnoreNotFound(err) != nil && !apimeta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
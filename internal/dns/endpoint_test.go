@@ -0,0 +1,100 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/objects/clusterdeployment"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestFQDN(t *testing.T) {
+	g := NewWithT(t)
+
+	cd := clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("cluster"))
+	g.Expect(FQDN(cd)).To(BeEmpty())
+
+	cd.Spec.DNS = &kcm.ClusterDNS{Zone: "example.com."}
+	g.Expect(FQDN(cd)).To(Equal("cluster.example.com"))
+
+	cd.Spec.DNS.Hostname = "api"
+	g.Expect(FQDN(cd)).To(Equal("api.example.com"))
+}
+
+func TestRecordType(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(RecordType("10.0.0.10")).To(Equal(RecordTypeA))
+	g.Expect(RecordType("fd00::10")).To(Equal(RecordTypeAAAA))
+	g.Expect(RecordType("lb.example.com")).To(Equal(RecordTypeCNAME))
+}
+
+func TestReconcileDNSEndpoint(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+
+	mapper := apimeta.NewDefaultRESTMapper(nil)
+	mapper.Add(DNSEndpointGVK, apimeta.RESTScopeNamespace)
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRESTMapper(mapper).Build()
+
+	const name, namespace = "cluster", metav1.NamespaceDefault
+
+	opts := ReconcileDNSEndpointOpts{
+		OwnerReference: &metav1.OwnerReference{
+			APIVersion: kcm.GroupVersion.String(),
+			Kind:       kcm.ClusterDeploymentKind,
+			Name:       name,
+		},
+		Endpoint: kcm.ClusterEndpoint{
+			DNSName:    "cluster.example.com",
+			RecordType: RecordTypeA,
+			Targets:    []string{"10.0.0.10"},
+		},
+		TTL: 300,
+	}
+	g.Expect(ReconcileDNSEndpoint(ctx, cl, name, namespace, opts)).To(Succeed())
+
+	opts.Endpoint.Targets = []string{"10.0.0.20"}
+	g.Expect(ReconcileDNSEndpoint(ctx, cl, name, namespace, opts)).To(Succeed())
+
+	obj := new(unstructured.Unstructured)
+	obj.SetGroupVersionKind(DNSEndpointGVK)
+	g.Expect(cl.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, obj)).To(Succeed())
+	g.Expect(obj.GetLabels()).To(HaveKeyWithValue(kcm.KCMManagedLabelKey, kcm.KCMManagedLabelValue))
+	g.Expect(obj.GetOwnerReferences()).To(ConsistOf(*opts.OwnerReference))
+
+	endpoints, found, err := unstructured.NestedSlice(obj.Object, "spec", "endpoints")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(endpoints).To(ConsistOf(map[string]any{
+		"dnsName":    "cluster.example.com",
+		"recordType": RecordTypeA,
+		"recordTTL":  int64(300),
+		"targets":    []any{"10.0.0.20"},
+	}))
+
+	g.Expect(DeleteDNSEndpoint(ctx, cl, name, namespace)).To(Succeed())
+	g.Expect(DeleteDNSEndpoint(ctx, cl, name, namespace)).To(Succeed())
+}
@@ -0,0 +1,97 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package edge adjusts the KCM configuration for the Edge management profile
+// running the minimal component set on the resource-constrained management clusters.
+package edge
+
+import (
+	"fmt"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// VeleroValuesKey is the key of the Velero values in the KCM chart configuration.
+	VeleroValuesKey = "velero"
+	// CertManagerValuesKey is the key of the cert-manager values in the KCM chart configuration.
+	CertManagerValuesKey = "cert-manager"
+	// AdmissionWebhookValuesKey is the key of the admission webhook values in the KCM chart configuration.
+	AdmissionWebhookValuesKey = "admissionWebhook"
+	// AdmissionPoliciesValuesKey is the key of the ValidatingAdmissionPolicies values in the KCM chart configuration.
+	AdmissionPoliciesValuesKey = "admissionPolicies"
+
+	certProviderBuiltin = "builtin"
+)
+
+// Enabled returns true if the given Management runs with the Edge profile.
+func Enabled(mgmt *kcm.Management) bool {
+	return mgmt != nil && mgmt.Spec.Profile == kcm.ManagementProfileEdge
+}
+
+// ApplyValues sets the KCM chart values of the Edge profile: Velero is disabled, only the ClusterDeployment
+// and Management validation webhooks are served and the other validations are enforced
+// by the ValidatingAdmissionPolicies. Unless explicitly configured, the webhook certificates
+// are issued by the builtin provider and cert-manager is not installed.
+func ApplyValues(config map[string]any) error {
+	veleroValues, err := subValues(config, VeleroValuesKey)
+	if err != nil {
+		return err
+	}
+	veleroValues["enabled"] = false
+	config[VeleroValuesKey] = veleroValues
+
+	webhookValues, err := subValues(config, AdmissionWebhookValuesKey)
+	if err != nil {
+		return err
+	}
+	if webhookValues["certProvider"] == nil {
+		webhookValues["certProvider"] = certProviderBuiltin
+	}
+	webhookValues["minimal"] = true
+	config[AdmissionWebhookValuesKey] = webhookValues
+
+	if webhookValues["certProvider"] == certProviderBuiltin {
+		certManagerValues, err := subValues(config, CertManagerValuesKey)
+		if err != nil {
+			return err
+		}
+		if certManagerValues["enabled"] == nil {
+			certManagerValues["enabled"] = false
+		}
+		config[CertManagerValuesKey] = certManagerValues
+	}
+
+	policiesValues, err := subValues(config, AdmissionPoliciesValuesKey)
+	if err != nil {
+		return err
+	}
+	policiesValues["enabled"] = true
+	config[AdmissionPoliciesValuesKey] = policiesValues
+
+	return nil
+}
+
+func subValues(config map[string]any, key string) (map[string]any, error) {
+	if config[key] == nil {
+		return make(map[string]any), nil
+	}
+
+	values, ok := config[key].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast '%s' (type %T) to map[string]any", key, config[key])
+	}
+
+	return values, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package edge
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   map[string]any
+		expected map[string]any
+		err      string
+	}{
+		{
+			name:   "defaults",
+			config: map[string]any{},
+			expected: map[string]any{
+				VeleroValuesKey:            map[string]any{"enabled": false},
+				AdmissionWebhookValuesKey:  map[string]any{"certProvider": "builtin", "minimal": true},
+				CertManagerValuesKey:       map[string]any{"enabled": false},
+				AdmissionPoliciesValuesKey: map[string]any{"enabled": true},
+			},
+		},
+		{
+			name: "cert-manager explicitly configured",
+			config: map[string]any{
+				VeleroValuesKey:           map[string]any{"enabled": true},
+				AdmissionWebhookValuesKey: map[string]any{"certProvider": "cert-manager", "port": 9443},
+			},
+			expected: map[string]any{
+				VeleroValuesKey:            map[string]any{"enabled": false},
+				AdmissionWebhookValuesKey:  map[string]any{"certProvider": "cert-manager", "port": 9443, "minimal": true},
+				AdmissionPoliciesValuesKey: map[string]any{"enabled": true},
+			},
+		},
+		{
+			name: "external cert-manager",
+			config: map[string]any{
+				CertManagerValuesKey: map[string]any{"enabled": true},
+			},
+			expected: map[string]any{
+				VeleroValuesKey:            map[string]any{"enabled": false},
+				AdmissionWebhookValuesKey:  map[string]any{"certProvider": "builtin", "minimal": true},
+				CertManagerValuesKey:       map[string]any{"enabled": true},
+				AdmissionPoliciesValuesKey: map[string]any{"enabled": true},
+			},
+		},
+		{
+			name:   "invalid configuration",
+			config: map[string]any{VeleroValuesKey: "enabled"},
+			err:    "failed to cast 'velero' (type string) to map[string]any",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ApplyValues(tt.config)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.config, tt.expected) {
+				t.Errorf("unexpected values %v, expected %v", tt.config, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package failuredomains reports the distribution of the machines of the ClusterDeployments
+// across the failure domains and verifies it against the configured failure domains.
+package failuredomains
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// ValuesKey is the key of the template values holding the configured failure domains.
+const ValuesKey = "failureDomains"
+
+// Values returns the template values of the given failure domains.
+func Values(fd *kcm.ClusterFailureDomains) map[string]any {
+	return map[string]any{
+		"controlPlane": slices.Clone(fd.ControlPlane),
+		"workers":      slices.Clone(fd.Workers),
+	}
+}
+
+// TemplateSupported returns true if the ClusterTemplate with the given default values
+// supports spreading the machines across the failure domains.
+func TemplateSupported(config *apiextensionsv1.JSON) (bool, error) {
+	if config == nil || len(config.Raw) == 0 {
+		return false, nil
+	}
+
+	values := make(map[string]any)
+	if err := json.Unmarshal(config.Raw, &values); err != nil {
+		return false, fmt.Errorf("failed to parse template config: %w", err)
+	}
+
+	_, found, err := unstructured.NestedMap(values, ValuesKey)
+	return found && err == nil, nil
+}
+
+// Distribution returns the number of the control plane and the worker machines of the given ClusterDeployment
+// per failure domain sorted by the failure domain name. The machines not yet placed in a failure domain are skipped.
+func Distribution(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) ([]kcm.FailureDomainStatus, error) {
+	machines := new(clusterv1.MachineList)
+	if err := cl.List(ctx, machines, client.InNamespace(cd.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cd.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list Machines: %w", err)
+	}
+
+	byName := make(map[string]*kcm.FailureDomainStatus)
+	for _, machine := range machines.Items {
+		if machine.Spec.FailureDomain == nil || *machine.Spec.FailureDomain == "" {
+			continue
+		}
+
+		name := *machine.Spec.FailureDomain
+		fd, ok := byName[name]
+		if !ok {
+			fd = &kcm.FailureDomainStatus{Name: name}
+			byName[name] = fd
+		}
+
+		if _, ok := machine.Labels[clusterv1.MachineControlPlaneLabel]; ok {
+			fd.ControlPlaneMachines++
+		} else {
+			fd.WorkerMachines++
+		}
+	}
+
+	distribution := make([]kcm.FailureDomainStatus, 0, len(byName))
+	for _, fd := range byName {
+		distribution = append(distribution, *fd)
+	}
+	slices.SortFunc(distribution, func(a, b kcm.FailureDomainStatus) int { return strings.Compare(a.Name, b.Name) })
+
+	if len(distribution) == 0 {
+		return nil, nil
+	}
+
+	return distribution, nil
+}
+
+// Condition returns the FailureDomainsSpread condition verifying the given distribution of the machines
+// against the failure domains configured in the given ClusterDeployment.
+// Returns nil if the failure domains are not configured.
+func Condition(cd *kcm.ClusterDeployment, distribution []kcm.FailureDomainStatus) *metav1.Condition {
+	fd := cd.Spec.FailureDomains
+	if fd == nil {
+		return nil
+	}
+
+	controlPlane := make(map[string]int32, len(distribution))
+	workers := make(map[string]int32, len(distribution))
+	for _, d := range distribution {
+		if d.ControlPlaneMachines > 0 {
+			controlPlane[d.Name] = d.ControlPlaneMachines
+		}
+		if d.WorkerMachines > 0 {
+			workers[d.Name] = d.WorkerMachines
+		}
+	}
+
+	var violations []string
+	violations = append(violations, verify("control plane", fd.ControlPlane, controlPlane)...)
+	violations = append(violations, verify("worker", fd.Workers, workers)...)
+
+	if len(violations) > 0 {
+		return &metav1.Condition{
+			Type:               kcm.FailureDomainsSpreadCondition,
+			Status:             metav1.ConditionFalse,
+			Reason:             kcm.FailureDomainsNotSpreadReason,
+			Message:            strings.Join(violations, "; "),
+			ObservedGeneration: cd.Generation,
+		}
+	}
+
+	return &metav1.Condition{
+		Type:               kcm.FailureDomainsSpreadCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             kcm.SucceededReason,
+		Message:            "Machines are spread across the failure domains",
+		ObservedGeneration: cd.Generation,
+	}
+}
+
+// verify returns the violations of the spread of the machines of the given role across the expected failure domains.
+// The machines are expected to be placed in the expected failure domains only, evenly, and in each of them
+// as long as there are enough machines.
+func verify(role string, expected []string, actual map[string]int32) []string {
+	if len(expected) == 0 {
+		return nil
+	}
+
+	var (
+		violations []string
+		unexpected []string
+		total      int32
+	)
+	for name, count := range actual {
+		total += count
+		if !slices.Contains(expected, name) {
+			unexpected = append(unexpected, name)
+		}
+	}
+	if len(unexpected) > 0 {
+		slices.Sort(unexpected)
+		violations = append(violations, fmt.Sprintf("%s machines are placed in the unexpected failure domains %s", role, strings.Join(unexpected, ", ")))
+	}
+
+	if total < 2 {
+		return violations
+	}
+
+	minCount, maxCount := total, int32(0)
+	for _, name := range expected {
+		minCount = min(minCount, actual[name])
+		maxCount = max(maxCount, actual[name])
+	}
+	if (int(total) >= len(expected) && minCount == 0) || maxCount-minCount > 1 {
+		counts := make([]string, 0, len(expected))
+		for _, name := range expected {
+			counts = append(counts, fmt.Sprintf("%s: %d", name, actual[name]))
+		}
+		violations = append(violations, fmt.Sprintf("%d %s machines are not spread evenly across the failure domains (%s)", total, role, strings.Join(counts, ", ")))
+	}
+
+	return violations
+}
@@ -0,0 +1,183 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package failuredomains
+
+import (
+	"reflect"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newMachine(name, cluster, failureDomain string, controlPlane bool) *clusterv1.Machine {
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "team-a",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: cluster},
+		},
+	}
+	if failureDomain != "" {
+		machine.Spec.FailureDomain = ptr.To(failureDomain)
+	}
+	if controlPlane {
+		machine.Labels[clusterv1.MachineControlPlaneLabel] = ""
+	}
+	return machine
+}
+
+func TestDistribution(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clusterv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newMachine("cp-0", "dev", "us-east-1a", true),
+		newMachine("cp-1", "dev", "us-east-1b", true),
+		newMachine("cp-2", "dev", "us-east-1c", true),
+		newMachine("md-0", "dev", "us-east-1b", false),
+		newMachine("md-1", "dev", "us-east-1b", false),
+		newMachine("md-2", "dev", "", false),
+		newMachine("other", "prod", "us-east-1a", false),
+	).Build()
+
+	cd := &kcm.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"}}
+	distribution, err := Distribution(t.Context(), cl, cd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []kcm.FailureDomainStatus{
+		{Name: "us-east-1a", ControlPlaneMachines: 1},
+		{Name: "us-east-1b", ControlPlaneMachines: 1, WorkerMachines: 2},
+		{Name: "us-east-1c", ControlPlaneMachines: 1},
+	}
+	if !reflect.DeepEqual(distribution, expected) {
+		t.Errorf("unexpected distribution:\ngot:  %+v\nwant: %+v", distribution, expected)
+	}
+
+	cd.Name = "staging"
+	distribution, err = Distribution(t.Context(), cl, cd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if distribution != nil {
+		t.Errorf("expected no distribution for a cluster without machines, got %+v", distribution)
+	}
+}
+
+func TestCondition(t *testing.T) {
+	zones := []string{"us-east-1a", "us-east-1b", "us-east-1c"}
+
+	for _, tc := range []struct {
+		name           string
+		failureDomains *kcm.ClusterFailureDomains
+		distribution   []kcm.FailureDomainStatus
+		expectedStatus metav1.ConditionStatus
+		expectedMsg    string
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name:           "spread evenly",
+			failureDomains: &kcm.ClusterFailureDomains{ControlPlane: zones, Workers: zones},
+			distribution: []kcm.FailureDomainStatus{
+				{Name: "us-east-1a", ControlPlaneMachines: 1, WorkerMachines: 2},
+				{Name: "us-east-1b", ControlPlaneMachines: 1, WorkerMachines: 1},
+				{Name: "us-east-1c", ControlPlaneMachines: 1, WorkerMachines: 1},
+			},
+			expectedStatus: metav1.ConditionTrue,
+			expectedMsg:    "Machines are spread across the failure domains",
+		},
+		{
+			name:           "fewer machines than failure domains",
+			failureDomains: &kcm.ClusterFailureDomains{Workers: zones},
+			distribution: []kcm.FailureDomainStatus{
+				{Name: "us-east-1a", WorkerMachines: 1},
+				{Name: "us-east-1c", WorkerMachines: 1},
+			},
+			expectedStatus: metav1.ConditionTrue,
+			expectedMsg:    "Machines are spread across the failure domains",
+		},
+		{
+			name:           "control plane in a single failure domain",
+			failureDomains: &kcm.ClusterFailureDomains{ControlPlane: zones},
+			distribution: []kcm.FailureDomainStatus{
+				{Name: "us-east-1a", ControlPlaneMachines: 3},
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedMsg:    "3 control plane machines are not spread evenly across the failure domains (us-east-1a: 3, us-east-1b: 0, us-east-1c: 0)",
+		},
+		{
+			name:           "workers in an unexpected failure domain",
+			failureDomains: &kcm.ClusterFailureDomains{Workers: zones[:2]},
+			distribution: []kcm.FailureDomainStatus{
+				{Name: "us-east-1a", WorkerMachines: 1},
+				{Name: "us-east-1b", WorkerMachines: 1},
+				{Name: "us-east-1c", WorkerMachines: 1},
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedMsg:    "worker machines are placed in the unexpected failure domains us-east-1c",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := &kcm.ClusterDeployment{Spec: kcm.ClusterDeploymentSpec{FailureDomains: tc.failureDomains}}
+			cond := Condition(cd, tc.distribution)
+			if tc.failureDomains == nil {
+				if cond != nil {
+					t.Errorf("expected no condition, got %+v", cond)
+				}
+				return
+			}
+			if cond == nil {
+				t.Fatal("expected condition, got nil")
+			}
+			if cond.Status != tc.expectedStatus || cond.Message != tc.expectedMsg {
+				t.Errorf("unexpected condition: got %s %q, want %s %q", cond.Status, cond.Message, tc.expectedStatus, tc.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestTemplateSupported(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		config   *apiextensionsv1.JSON
+		expected bool
+	}{
+		{name: "no config"},
+		{name: "without failure domains", config: &apiextensionsv1.JSON{Raw: []byte(`{"workersNumber":2}`)}},
+		{name: "with failure domains", config: &apiextensionsv1.JSON{Raw: []byte(`{"failureDomains":{"workers":[]}}`)}, expected: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			supported, err := TemplateSupported(tc.config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if supported != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, supported)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package faultinjection implements the developer-facing failure injection
+// used by the e2e tests to verify the retry, condition and event behavior of
+// the reconcilers. The facility is disabled unless explicitly enabled with
+// the controller flag, in which case the failures are requested per object
+// with the [Annotation].
+package faultinjection
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Annotation lists the points the failures are injected at, separated by commas.
+// Each point may be suffixed with the number of times to fail, e.g. "helm-render:2",
+// otherwise the point fails until the annotation is changed.
+const Annotation = "k0rdent.mirantis.com/inject-failure"
+
+// Point is a named point in the reconcile pipeline a failure can be injected at.
+type Point string
+
+const (
+	// PointHelmRender fails the rendering of the template chart with the provided configuration.
+	PointHelmRender Point = "helm-render"
+	// PointCAPIApply fails the application of the HelmRelease deploying the CAPI objects.
+	PointCAPIApply Point = "capi-apply"
+	// PointKubeconfigFetch fails fetching the kubeconfig of the managed cluster.
+	PointKubeconfigFetch Point = "kubeconfig-fetch"
+)
+
+// Error is the error returned at the point a failure has been injected at.
+type Error struct {
+	Point Point
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("injected failure at %s", e.Point)
+}
+
+var (
+	enabled bool
+
+	mu sync.Mutex
+	// injected counts the failures injected per object and point,
+	// the counters are reset once the annotation changes.
+	injected = make(map[key]*counter)
+)
+
+type key struct {
+	uid   types.UID
+	point Point
+}
+
+type counter struct {
+	spec  string
+	count int
+}
+
+// SetEnabled enables or disables the failure injection.
+func SetEnabled(enable bool) {
+	enabled = enable
+}
+
+// Enabled reports whether the failure injection is enabled.
+func Enabled() bool {
+	return enabled
+}
+
+// Inject returns an [*Error] if the failure injection is enabled and the given object requests
+// a failure at the given point, otherwise returns nil.
+func Inject(obj metav1.Object, point Point) error {
+	if !enabled {
+		return nil
+	}
+
+	value, ok := obj.GetAnnotations()[Annotation]
+	if !ok {
+		return nil
+	}
+
+	for spec := range strings.SplitSeq(value, ",") {
+		spec = strings.TrimSpace(spec)
+		name, times, limited := strings.Cut(spec, ":")
+		if Point(name) != point {
+			continue
+		}
+
+		if !limited {
+			return &Error{Point: point}
+		}
+
+		limit, err := strconv.Atoi(times)
+		if err != nil || limit < 0 {
+			return fmt.Errorf("invalid number of failures in the %s annotation: %q", Annotation, spec)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		k := key{uid: obj.GetUID(), point: point}
+		c, ok := injected[k]
+		if !ok || c.spec != spec {
+			c = &counter{spec: spec}
+			injected[k] = c
+		}
+
+		if c.count >= limit {
+			return nil
+		}
+		c.count++
+
+		return &Error{Point: point}
+	}
+
+	return nil
+}
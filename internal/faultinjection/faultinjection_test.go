@@ -0,0 +1,63 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faultinjection
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newObject(uid types.UID, value string) *metav1.ObjectMeta {
+	return &metav1.ObjectMeta{UID: uid, Annotations: map[string]string{Annotation: value}}
+}
+
+func TestInject(t *testing.T) {
+	t.Cleanup(func() { SetEnabled(false) })
+
+	obj := newObject("uid-1", "helm-render, kubeconfig-fetch:2")
+
+	require.NoError(t, Inject(obj, PointHelmRender), "failures must not be injected unless enabled")
+
+	SetEnabled(true)
+
+	err := Inject(obj, PointHelmRender)
+	var injectedErr *Error
+	require.True(t, errors.As(err, &injectedErr))
+	require.Equal(t, PointHelmRender, injectedErr.Point)
+	require.EqualError(t, err, "injected failure at helm-render")
+
+	require.NoError(t, Inject(obj, PointCAPIApply), "the point is not requested")
+	require.NoError(t, Inject(&metav1.ObjectMeta{UID: "uid-2"}, PointHelmRender), "the object is not annotated")
+
+	for range 2 {
+		require.Error(t, Inject(obj, PointKubeconfigFetch))
+	}
+	require.NoError(t, Inject(obj, PointKubeconfigFetch), "the limited failures must be injected the given number of times")
+
+	// the counter is reset once the annotation is changed
+	obj.Annotations[Annotation] = "kubeconfig-fetch:1"
+	require.Error(t, Inject(obj, PointKubeconfigFetch))
+	require.NoError(t, Inject(obj, PointKubeconfigFetch))
+
+	// the counters are kept per object
+	require.Error(t, Inject(newObject("uid-3", "kubeconfig-fetch:1"), PointKubeconfigFetch))
+
+	require.EqualError(t, Inject(newObject("uid-4", "capi-apply:x"), PointCAPIApply),
+		`invalid number of failures in the k0rdent.mirantis.com/inject-failure annotation: "capi-apply:x"`)
+}
@@ -0,0 +1,120 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fleetaccess provisions the ServiceAccount the short-lived credentials of the fleet kubeconfig
+// are issued for in the clusters. The ServiceAccount is bound to the ClusterRole aggregating the view
+// permissions, the permissions are extended per cluster by the ClusterRoles labeled with [AggregateLabel].
+package fleetaccess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// ServiceAccountName is the name of the ServiceAccount in the kube-system namespace
+	// of the clusters the short-lived credentials of the fleet kubeconfig are issued for,
+	// also the name of its ClusterRole and ClusterRoleBinding.
+	ServiceAccountName = "kcm-fleet"
+	// AggregateLabel is the label of the ClusterRoles aggregated to the ClusterRole of the fleet ServiceAccount.
+	AggregateLabel = "k0rdent.mirantis.com/aggregate-to-fleet"
+
+	// legacyServiceAccountName is the name of the ServiceAccount bound to the cluster-admin role
+	// previously created by the kcm CLI, removed in favor of the scoped one.
+	legacyServiceAccountName = "kcm-fleet-admin"
+
+	aggregateToViewLabel = "rbac.authorization.k8s.io/aggregate-to-view"
+)
+
+// Reconcile creates or updates the fleet ServiceAccount, its ClusterRole and ClusterRoleBinding
+// in the cluster of the given client and removes the ServiceAccount bound to the cluster-admin role
+// previously created by the kcm CLI.
+func Reconcile(ctx context.Context, cl client.Client) error {
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: ServiceAccountName, Namespace: metav1.NamespaceSystem}}
+	role := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: ServiceAccountName}}
+	binding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: ServiceAccountName}}
+
+	for _, obj := range []struct {
+		client.Object
+		mutate func()
+	}{
+		{sa, func() {}},
+		{role, func() {
+			role.AggregationRule = &rbacv1.AggregationRule{
+				ClusterRoleSelectors: []metav1.LabelSelector{
+					{MatchLabels: map[string]string{aggregateToViewLabel: "true"}},
+					{MatchLabels: map[string]string{AggregateLabel: "true"}},
+				},
+			}
+		}},
+		{binding, func() {
+			binding.RoleRef = rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: role.Name}
+			binding.Subjects = []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: sa.Name, Namespace: sa.Namespace}}
+		}},
+	} {
+		if _, err := controllerutil.CreateOrUpdate(ctx, cl, obj.Object, func() error {
+			labels := obj.GetLabels()
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			labels[kcm.KCMManagedLabelKey] = kcm.KCMManagedLabelValue
+			obj.SetLabels(labels)
+			obj.mutate()
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to reconcile %T %s: %w", obj.Object, client.ObjectKeyFromObject(obj.Object), err)
+		}
+	}
+
+	return removeLegacy(ctx, cl)
+}
+
+// removeLegacy removes the ServiceAccount bound to the cluster-admin role previously created by the kcm CLI.
+func removeLegacy(ctx context.Context, cl client.Client) error {
+	var errs error
+	for _, obj := range []client.Object{
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: legacyServiceAccountName}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: legacyServiceAccountName, Namespace: metav1.NamespaceSystem}},
+	} {
+		if err := cl.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to delete %T %s: %w", obj, client.ObjectKeyFromObject(obj), err))
+		}
+	}
+
+	return errs
+}
+
+// Token requests a token of the fleet ServiceAccount in the cluster of the given client with the given lifetime.
+func Token(ctx context.Context, cl client.Client, expiration time.Duration) (*authenticationv1.TokenRequest, error) {
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: ServiceAccountName, Namespace: metav1.NamespaceSystem}}
+
+	expirationSeconds := int64(expiration.Seconds())
+	token := &authenticationv1.TokenRequest{Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &expirationSeconds}}
+	if err := cl.SubResource("token").Create(ctx, sa, token); err != nil {
+		return nil, fmt.Errorf("failed to request token of ServiceAccount %s: %w", client.ObjectKeyFromObject(sa), err)
+	}
+
+	return token, nil
+}
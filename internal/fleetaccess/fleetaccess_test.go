@@ -0,0 +1,95 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleetaccess
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestReconcile(t *testing.T) {
+	legacySA := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: legacyServiceAccountName, Namespace: metav1.NamespaceSystem}}
+	legacyBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: legacyServiceAccountName},
+		RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(legacySA, legacyBinding).Build()
+
+	for range 2 {
+		if err := Reconcile(t.Context(), cl); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := cl.Get(t.Context(), client.ObjectKey{Namespace: metav1.NamespaceSystem, Name: ServiceAccountName}, new(corev1.ServiceAccount)); err != nil {
+		t.Fatalf("failed to get ServiceAccount: %v", err)
+	}
+
+	role := new(rbacv1.ClusterRole)
+	if err := cl.Get(t.Context(), client.ObjectKey{Name: ServiceAccountName}, role); err != nil {
+		t.Fatalf("failed to get ClusterRole: %v", err)
+	}
+	if role.AggregationRule == nil || len(role.AggregationRule.ClusterRoleSelectors) != 2 {
+		t.Errorf("expected the ClusterRole to aggregate the view and the fleet ClusterRoles, got %+v", role.AggregationRule)
+	}
+
+	binding := new(rbacv1.ClusterRoleBinding)
+	if err := cl.Get(t.Context(), client.ObjectKey{Name: ServiceAccountName}, binding); err != nil {
+		t.Fatalf("failed to get ClusterRoleBinding: %v", err)
+	}
+	if binding.RoleRef.Name != ServiceAccountName {
+		t.Errorf("expected the ServiceAccount to be bound to the %s ClusterRole, got %s", ServiceAccountName, binding.RoleRef.Name)
+	}
+	if len(binding.Subjects) != 1 || binding.Subjects[0].Name != ServiceAccountName || binding.Subjects[0].Namespace != metav1.NamespaceSystem {
+		t.Errorf("unexpected subjects of the ClusterRoleBinding: %+v", binding.Subjects)
+	}
+
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(legacyBinding), legacyBinding); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the cluster-admin ClusterRoleBinding to be deleted, got %v", err)
+	}
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(legacySA), legacySA); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the legacy ServiceAccount to be deleted, got %v", err)
+	}
+}
+
+func TestToken(t *testing.T) {
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	if _, err := Token(t.Context(), cl, time.Hour); err == nil {
+		t.Fatal("expected error for the missing ServiceAccount")
+	}
+
+	if err := Reconcile(t.Context(), cl); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	token, err := Token(t.Context(), cl, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.Status.Token == "" {
+		t.Error("expected the token to be issued")
+	}
+	if *token.Spec.ExpirationSeconds != 3600 {
+		t.Errorf("expected the token to expire in an hour, got %ds", *token.Spec.ExpirationSeconds)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleetview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// Authorizer authorizes the requests of the views of the clusters.
+type Authorizer interface {
+	// Authorize returns an error along with the HTTP status of the response
+	// if the request to view the clusters in the given namespace is not allowed,
+	// the empty namespace stands for all of the namespaces.
+	Authorize(ctx context.Context, r *http.Request, namespace string) (int, error)
+}
+
+// AuthorizerFunc is a function implementing [Authorizer].
+type AuthorizerFunc func(ctx context.Context, r *http.Request, namespace string) (int, error)
+
+// Authorize implements [Authorizer].
+func (f AuthorizerFunc) Authorize(ctx context.Context, r *http.Request, namespace string) (int, error) {
+	return f(ctx, r, namespace)
+}
+
+// CredentialsAuthorizer authorizes the requests of the credentials of the clusters.
+type CredentialsAuthorizer interface {
+	// AuthorizeCredentials returns an error along with the HTTP status of the response
+	// if the request of the credentials of the cluster of the given ClusterDeployment is not allowed.
+	AuthorizeCredentials(ctx context.Context, r *http.Request, namespace, name string) (int, error)
+}
+
+// CredentialsAuthorizerFunc is a function implementing [CredentialsAuthorizer].
+type CredentialsAuthorizerFunc func(ctx context.Context, r *http.Request, namespace, name string) (int, error)
+
+// AuthorizeCredentials implements [CredentialsAuthorizer].
+func (f CredentialsAuthorizerFunc) AuthorizeCredentials(ctx context.Context, r *http.Request, namespace, name string) (int, error) {
+	return f(ctx, r, namespace, name)
+}
+
+// ReviewAuthorizer authenticates the bearer token of the request with a TokenReview and
+// allows the request if the user is allowed to list the ClusterDeployments in the namespace
+// as reported by a SubjectAccessReview, so the views follow the RBAC of the ClusterDeployments.
+// The credentials of a cluster are allowed to the users allowed to create the credentials
+// subresource of its ClusterDeployment.
+type ReviewAuthorizer struct {
+	Client client.Client
+}
+
+// Authorize implements [Authorizer].
+func (a *ReviewAuthorizer) Authorize(ctx context.Context, r *http.Request, namespace string) (int, error) {
+	scope := "cluster scope"
+	if namespace != "" {
+		scope = "namespace " + namespace
+	}
+
+	return a.review(ctx, r, &authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      "list",
+		Group:     kcm.GroupVersion.Group,
+		Resource:  "clusterdeployments",
+	}, "list ClusterDeployments in the "+scope)
+}
+
+// AuthorizeCredentials implements [CredentialsAuthorizer].
+func (a *ReviewAuthorizer) AuthorizeCredentials(ctx context.Context, r *http.Request, namespace, name string) (int, error) {
+	return a.review(ctx, r, &authorizationv1.ResourceAttributes{
+		Namespace:   namespace,
+		Name:        name,
+		Verb:        "create",
+		Group:       kcm.GroupVersion.Group,
+		Resource:    "clusterdeployments",
+		Subresource: "credentials",
+	}, fmt.Sprintf("request credentials of ClusterDeployment %s/%s", namespace, name))
+}
+
+// review authenticates the user of the request and checks the user is allowed the given action.
+func (a *ReviewAuthorizer) review(ctx context.Context, r *http.Request, attributes *authorizationv1.ResourceAttributes, action string) (int, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return http.StatusUnauthorized, errors.New("bearer token is missing")
+	}
+
+	tokenReview := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := a.Client.Create(ctx, tokenReview); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to review token: %w", err)
+	}
+	if !tokenReview.Status.Authenticated {
+		return http.StatusUnauthorized, errors.New("invalid bearer token")
+	}
+
+	user := tokenReview.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	accessReview := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               user.Username,
+			UID:                user.UID,
+			Groups:             user.Groups,
+			Extra:              extra,
+			ResourceAttributes: attributes,
+		},
+	}
+	if err := a.Client.Create(ctx, accessReview); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to review access: %w", err)
+	}
+	if !accessReview.Status.Allowed {
+		return http.StatusForbidden, fmt.Errorf("user %s cannot %s", user.Username, action)
+	}
+
+	return http.StatusOK, nil
+}
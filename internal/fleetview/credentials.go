@@ -0,0 +1,155 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleetview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientauthv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/fleetaccess"
+)
+
+const (
+	// CredentialsPath is the path the short-lived credentials of the clusters are issued on.
+	CredentialsPath = "/fleet/v1/credentials"
+
+	// DefaultCredentialsExpiration is the lifetime of the credentials issued if no expiration is requested.
+	DefaultCredentialsExpiration = time.Hour
+	// MaxCredentialsExpiration is the maximum lifetime of the issued credentials.
+	MaxCredentialsExpiration = 24 * time.Hour
+
+	kubeconfigSecretSuffix = "-kubeconfig"
+	kubeconfigSecretKey    = "value"
+)
+
+// CredentialsHandler issues the short-lived credentials of the fleet ServiceAccount of the clusters
+// as the kubectl ExecCredentials, so the admin kubeconfigs of the clusters never leave the management cluster.
+type CredentialsHandler struct {
+	// Client reads the ClusterDeployments and their kubeconfig Secrets.
+	Client client.Reader
+	// Authorizer authorizes the requests on behalf of the users of the fleet kubeconfig.
+	Authorizer CredentialsAuthorizer
+	// NewClusterClient builds the client of a cluster from its kubeconfig, defaults to [client.New].
+	NewClusterClient func(kubeconfig []byte) (client.Client, error)
+}
+
+// ServeHTTP implements [http.Handler].
+func (h *CredentialsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method %s is not allowed", r.Method)
+		return
+	}
+
+	values := r.URL.Query()
+	namespace, name := values.Get("namespace"), values.Get("name")
+	if namespace == "" || name == "" {
+		writeError(w, http.StatusBadRequest, "namespace and name of the ClusterDeployment are required")
+		return
+	}
+	expiration := DefaultCredentialsExpiration
+	if s := values.Get("expiration"); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil || d <= 0 || d > MaxCredentialsExpiration {
+			writeError(w, http.StatusBadRequest, "invalid expiration %q: must be a positive duration up to %s", s, MaxCredentialsExpiration)
+			return
+		}
+		expiration = d
+	}
+
+	if status, err := h.Authorizer.AuthorizeCredentials(r.Context(), r, namespace, name); err != nil {
+		log.FromContext(r.Context()).Error(err, "failed to authorize fleet credentials request")
+		writeError(w, status, "%v", err)
+		return
+	}
+
+	cred, status, err := h.issue(r.Context(), namespace, name, expiration)
+	if err != nil {
+		log.FromContext(r.Context()).Error(err, "failed to issue fleet credentials", "clusterDeployment", namespace+"/"+name)
+		writeError(w, status, "%v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(cred); err != nil {
+		log.FromContext(r.Context()).Error(err, "failed to write fleet credentials response")
+	}
+}
+
+// issue requests the token of the fleet ServiceAccount of the cluster of the given ClusterDeployment.
+func (h *CredentialsHandler) issue(ctx context.Context, namespace, name string, expiration time.Duration) (*clientauthv1.ExecCredential, int, error) {
+	cd := new(kcm.ClusterDeployment)
+	if err := h.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, http.StatusNotFound, fmt.Errorf("ClusterDeployment %s/%s not found", namespace, name)
+		}
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to get ClusterDeployment %s/%s: %w", namespace, name, err)
+	}
+	if !apimeta.IsStatusConditionTrue(cd.Status.Conditions, kcm.FleetAccessProvisionedCondition) {
+		return nil, http.StatusConflict, fmt.Errorf("fleet access of ClusterDeployment %s/%s is not provisioned yet", namespace, name)
+	}
+
+	secret := new(corev1.Secret)
+	if err := h.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name + kubeconfigSecretSuffix}, secret); err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to get kubeconfig Secret of ClusterDeployment %s/%s: %w", namespace, name, err)
+	}
+
+	newClusterClient := h.NewClusterClient
+	if newClusterClient == nil {
+		newClusterClient = newClient
+	}
+	cl, err := newClusterClient(secret.Data[kubeconfigSecretKey])
+	if err != nil {
+		return nil, http.StatusInternalServerError, fmt.Errorf("failed to create client of ClusterDeployment %s/%s: %w", namespace, name, err)
+	}
+
+	token, err := fleetaccess.Token(ctx, cl, expiration)
+	if err != nil {
+		return nil, http.StatusBadGateway, fmt.Errorf("failed to issue credentials of ClusterDeployment %s/%s: %w", namespace, name, err)
+	}
+
+	return &clientauthv1.ExecCredential{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: clientauthv1.SchemeGroupVersion.String(),
+			Kind:       "ExecCredential",
+		},
+		Status: &clientauthv1.ExecCredentialStatus{
+			Token:               token.Status.Token,
+			ExpirationTimestamp: &token.Status.ExpirationTimestamp,
+		},
+	}, http.StatusOK, nil
+}
+
+func newClient(kubeconfig []byte) (client.Client, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+
+	return client.New(restConfig, client.Options{})
+}
@@ -0,0 +1,148 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleetview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientauthv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/fleetaccess"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestCredentials(t *testing.T) {
+	provisioned := newClusterDeployment("dev", "provisioned", "aws-standalone-cp-1-0-0", metav1.ConditionTrue)
+	provisioned.Status.Conditions = append(provisioned.Status.Conditions, metav1.Condition{
+		Type: kcm.FleetAccessProvisionedCondition, Status: metav1.ConditionTrue, Reason: kcm.SucceededReason,
+	})
+	pending := newClusterDeployment("dev", "pending", "aws-standalone-cp-1-0-0", metav1.ConditionTrue)
+	kubeconfig := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "dev", Name: "provisioned" + kubeconfigSecretSuffix},
+		Data:       map[string][]byte{kubeconfigSecretKey: []byte("kubeconfig")},
+	}
+
+	clusterClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	if err := fleetaccess.Reconcile(t.Context(), clusterClient); err != nil {
+		t.Fatalf("failed to provision fleet access: %v", err)
+	}
+
+	h := &CredentialsHandler{
+		Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(provisioned, pending, kubeconfig).Build(),
+		Authorizer: CredentialsAuthorizerFunc(func(_ context.Context, r *http.Request, namespace, name string) (int, error) {
+			if r.Header.Get("Authorization") != "Bearer "+namespace+"/"+name {
+				return http.StatusForbidden, errors.New("forbidden")
+			}
+			return http.StatusOK, nil
+		}),
+		NewClusterClient: func(data []byte) (client.Client, error) {
+			if string(data) != "kubeconfig" {
+				return nil, errors.New("unexpected kubeconfig")
+			}
+			return clusterClient, nil
+		},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		token  string
+		values url.Values
+		status int
+	}{
+		{
+			name:   "method not allowed",
+			method: http.MethodGet,
+			token:  "dev/provisioned",
+			values: url.Values{"namespace": {"dev"}, "name": {"provisioned"}},
+			status: http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "missing name",
+			method: http.MethodPost,
+			token:  "dev/",
+			values: url.Values{"namespace": {"dev"}},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "invalid expiration",
+			method: http.MethodPost,
+			token:  "dev/provisioned",
+			values: url.Values{"namespace": {"dev"}, "name": {"provisioned"}, "expiration": {"48h"}},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "forbidden",
+			method: http.MethodPost,
+			token:  "dev/pending",
+			values: url.Values{"namespace": {"dev"}, "name": {"provisioned"}},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "not found",
+			method: http.MethodPost,
+			token:  "dev/missing",
+			values: url.Values{"namespace": {"dev"}, "name": {"missing"}},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "not provisioned",
+			method: http.MethodPost,
+			token:  "dev/pending",
+			values: url.Values{"namespace": {"dev"}, "name": {"pending"}},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "issued",
+			method: http.MethodPost,
+			token:  "dev/provisioned",
+			values: url.Values{"namespace": {"dev"}, "name": {"provisioned"}, "expiration": {"30m"}},
+			status: http.StatusOK,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, CredentialsPath+"?"+tc.values.Encode(), nil)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				t.Fatalf("expected status %d, got %d: %s", tc.status, rec.Code, rec.Body.String())
+			}
+			if tc.status != http.StatusOK {
+				return
+			}
+
+			cred := new(clientauthv1.ExecCredential)
+			if err := json.Unmarshal(rec.Body.Bytes(), cred); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if cred.Kind != "ExecCredential" || cred.Status == nil || cred.Status.Token == "" {
+				t.Errorf("unexpected credential: %+v", cred)
+			}
+		})
+	}
+}
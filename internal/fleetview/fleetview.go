@@ -0,0 +1,373 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fleetview implements the read-only HTTP endpoint serving the denormalized views
+// of the clusters joined with their templates, credentials and services for the dashboards.
+package fleetview
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/statemanagement"
+	"github.com/K0rdent/kcm/internal/utils/fleet"
+)
+
+const (
+	// ClustersPath is the path the views of the clusters are served on.
+	ClustersPath = "/fleet/v1/clusters"
+
+	// DefaultLimit is the number of the clusters served per page if no limit is requested.
+	DefaultLimit = 100
+	// MaxLimit is the maximum number of the clusters served per page.
+	MaxLimit = 500
+)
+
+// Cluster is the view of a ClusterDeployment joined with its ClusterTemplate, Credential and services.
+type Cluster struct {
+	CreationTimestamp metav1.Time       `json:"creationTimestamp"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	Template          Template          `json:"template"`
+	Credential        Credential        `json:"credential"`
+	Namespace         string            `json:"namespace"`
+	Name              string            `json:"name"`
+	Phase             string            `json:"phase"`
+	Reason            string            `json:"reason,omitempty"`
+	Message           string            `json:"message,omitempty"`
+	KubernetesVersion string            `json:"kubernetesVersion,omitempty"`
+	Region            string            `json:"region,omitempty"`
+	AvailableUpgrades []string          `json:"availableUpgrades,omitempty"`
+	Services          []Service         `json:"services,omitempty"`
+	Ready             bool              `json:"ready"`
+}
+
+// Template is the view of the ClusterTemplate of a cluster.
+type Template struct {
+	Name              string   `json:"name"`
+	KubernetesVersion string   `json:"kubernetesVersion,omitempty"`
+	Providers         []string `json:"providers,omitempty"`
+	Valid             bool     `json:"valid"`
+}
+
+// Credential is the view of the Credential of a cluster.
+type Credential struct {
+	Name  string `json:"name"`
+	Ready bool   `json:"ready"`
+}
+
+// Service is the view of a service enabled on a cluster.
+type Service struct {
+	Name     string `json:"name"`
+	Template string `json:"template"`
+	Chart    string `json:"chart,omitempty"`
+	Version  string `json:"version,omitempty"`
+	Message  string `json:"message,omitempty"`
+	Ready    bool   `json:"ready"`
+}
+
+// ClusterList is a page of the views of the clusters.
+type ClusterList struct {
+	// Continue is the token of the next page, empty on the last page.
+	Continue string    `json:"continue,omitempty"`
+	Items    []Cluster `json:"items"`
+	// Total is the number of the clusters matching the query across all of the pages.
+	Total int `json:"total"`
+}
+
+// Handler serves the views of the clusters.
+type Handler struct {
+	// Client reads the objects, expected to be backed by the cache of the manager.
+	Client client.Reader
+	// Authorizer authorizes the requests on behalf of the users of the dashboards.
+	Authorizer Authorizer
+}
+
+// query holds the filters and the pagination parameters of a request.
+type query struct {
+	selector  labels.Selector
+	namespace string
+	template  string
+	phase     string
+	after     string
+	fleet     fleet.Query
+	limit     int
+}
+
+// ServeHTTP implements [http.Handler].
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, "method %s is not allowed", r.Method)
+		return
+	}
+
+	q, err := parseQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	if status, err := h.Authorizer.Authorize(r.Context(), r, q.namespace); err != nil {
+		log.FromContext(r.Context()).Error(err, "failed to authorize fleet view request")
+		writeError(w, status, "%v", err)
+		return
+	}
+
+	list, err := h.listClusters(r.Context(), q)
+	if err != nil {
+		log.FromContext(r.Context()).Error(err, "failed to list fleet views")
+		writeError(w, http.StatusInternalServerError, "failed to list clusters: %v", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(list); err != nil {
+		log.FromContext(r.Context()).Error(err, "failed to write fleet view response")
+	}
+}
+
+func parseQuery(r *http.Request) (query, error) {
+	values := r.URL.Query()
+	q := query{
+		namespace: values.Get("namespace"),
+		template:  values.Get("template"),
+		phase:     values.Get("phase"),
+		limit:     DefaultLimit,
+		fleet: fleet.Query{
+			Provider:               values.Get("provider"),
+			Region:                 values.Get("region"),
+			KubernetesMinorVersion: values.Get("kubernetesVersion"),
+		},
+		selector: labels.Everything(),
+	}
+
+	if s := values.Get("labelSelector"); s != "" {
+		selector, err := labels.Parse(s)
+		if err != nil {
+			return query{}, fmt.Errorf("invalid labelSelector: %w", err)
+		}
+		q.selector = selector
+	}
+
+	for _, s := range values["service"] {
+		c, err := fleet.ParseServiceConstraint(s)
+		if err != nil {
+			return query{}, err
+		}
+		q.fleet.Services = append(q.fleet.Services, c)
+	}
+
+	if s := values.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit <= 0 {
+			return query{}, fmt.Errorf("invalid limit %q: must be a positive integer", s)
+		}
+		q.limit = min(limit, MaxLimit)
+	}
+
+	if s := values.Get("continue"); s != "" {
+		key, err := base64.RawURLEncoding.DecodeString(s)
+		if err != nil {
+			return query{}, fmt.Errorf("invalid continue token %q", s)
+		}
+		q.after = string(key)
+	}
+
+	return q, nil
+}
+
+// listClusters lists the ClusterDeployments matching the given query and joins them with the
+// objects they refer to, the ClusterTemplates and Credentials are listed once per request.
+func (h *Handler) listClusters(ctx context.Context, q query) (*ClusterList, error) {
+	var opts []client.ListOption
+	if q.namespace != "" {
+		opts = append(opts, client.InNamespace(q.namespace))
+	}
+
+	cds := new(kcm.ClusterDeploymentList)
+	if err := h.Client.List(ctx, cds, append(opts, client.MatchingLabelsSelector{Selector: q.selector})...); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterDeployments: %w", err)
+	}
+
+	// the ClusterTemplates may be referenced from the other namespaces
+	templates := new(kcm.ClusterTemplateList)
+	if err := h.Client.List(ctx, templates); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterTemplates: %w", err)
+	}
+	templatesByKey := make(map[client.ObjectKey]*kcm.ClusterTemplate, len(templates.Items))
+	for i := range templates.Items {
+		templatesByKey[client.ObjectKeyFromObject(&templates.Items[i])] = &templates.Items[i]
+	}
+
+	credentials := new(kcm.CredentialList)
+	if err := h.Client.List(ctx, credentials, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list Credentials: %w", err)
+	}
+	credentialsReady := make(map[client.ObjectKey]bool, len(credentials.Items))
+	for _, cred := range credentials.Items {
+		credentialsReady[client.ObjectKeyFromObject(&cred)] = cred.Status.Ready
+	}
+
+	serviceCharts, err := fleet.ServiceCharts(ctx, h.Client, cds.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	var clusters []Cluster
+	for i := range cds.Items {
+		cd := &cds.Items[i]
+		if q.template != "" && cd.Spec.Template != q.template {
+			continue
+		}
+
+		cluster := newCluster(cd, templatesByKey[client.ObjectKey{Namespace: cd.TemplateNamespace(), Name: cd.Spec.Template}], serviceCharts)
+		cluster.Credential.Ready = credentialsReady[client.ObjectKey{Namespace: cd.Namespace, Name: cd.Spec.Credential}]
+		if q.phase != "" && !strings.EqualFold(cluster.Phase, q.phase) {
+			continue
+		}
+		if !matches(cd, &cluster, q.fleet) {
+			continue
+		}
+
+		clusters = append(clusters, cluster)
+	}
+
+	slices.SortFunc(clusters, func(a, b Cluster) int {
+		return cmp.Or(strings.Compare(a.Namespace, b.Namespace), strings.Compare(a.Name, b.Name))
+	})
+
+	return paginate(clusters, q.after, q.limit), nil
+}
+
+// paginate returns the page of the given sorted clusters following the one ended with the
+// cluster of the given key, the continue token of the page encodes the key of its last cluster.
+func paginate(clusters []Cluster, after string, limit int) *ClusterList {
+	list := &ClusterList{Total: len(clusters), Items: []Cluster{}}
+
+	start := 0
+	if after != "" {
+		start, _ = slices.BinarySearchFunc(clusters, after, func(c Cluster, key string) int {
+			return cmp.Compare(clusterKey(c), key)
+		})
+		// the binary search returns the position of the cluster itself if it still exists
+		if start < len(clusters) && clusterKey(clusters[start]) == after {
+			start++
+		}
+	}
+	end := min(start+limit, len(clusters))
+	list.Items = append(list.Items, clusters[start:end]...)
+
+	if end < len(clusters) {
+		list.Continue = base64.RawURLEncoding.EncodeToString([]byte(clusterKey(clusters[end-1])))
+	}
+
+	return list
+}
+
+// clusterKey returns the key the clusters are sorted by, the NUL separator sorts the same
+// as comparing the namespaces and then the names.
+func clusterKey(c Cluster) string {
+	return c.Namespace + "\x00" + c.Name
+}
+
+func newCluster(cd *kcm.ClusterDeployment, template *kcm.ClusterTemplate, serviceCharts map[client.ObjectKey]fleet.Chart) Cluster {
+	cluster := Cluster{
+		Namespace:         cd.Namespace,
+		Name:              cd.Name,
+		Labels:            cd.Labels,
+		CreationTimestamp: cd.CreationTimestamp,
+		Phase:             fleet.Phase(cd),
+		KubernetesVersion: cd.Status.KubernetesVersion,
+		Region:            fleet.Region(cd),
+		AvailableUpgrades: cd.Status.AvailableUpgrades,
+		Template:          Template{Name: cd.Spec.Template},
+		Credential:        Credential{Name: cd.Spec.Credential},
+	}
+
+	if cond := apimeta.FindStatusCondition(cd.Status.Conditions, kcm.ReadyCondition); cond != nil {
+		cluster.Ready = cond.Status == metav1.ConditionTrue
+		cluster.Reason = cond.Reason
+		cluster.Message = cond.Message
+	}
+
+	if template != nil {
+		cluster.Template.Valid = template.Status.Valid
+		cluster.Template.KubernetesVersion = template.Status.KubernetesVersion
+		cluster.Template.Providers = fleet.InfrastructureProviders(template.Status.Providers)
+	}
+
+	for _, svc := range fleet.Services(cd, serviceCharts) {
+		ready, message := serviceReadiness(cd, svc.Name)
+		cluster.Services = append(cluster.Services, Service{
+			Name:     svc.Name,
+			Template: svc.Template,
+			Chart:    svc.Chart,
+			Version:  svc.Version,
+			Ready:    ready,
+			Message:  message,
+		})
+	}
+
+	return cluster
+}
+
+// matches reports whether the given cluster matches the given query of the fleet attributes.
+func matches(cd *kcm.ClusterDeployment, cluster *Cluster, q fleet.Query) bool {
+	summary := kcm.FleetClusterSummary{
+		Providers:              cluster.Template.Providers,
+		Region:                 cluster.Region,
+		KubernetesMinorVersion: fleet.MinorVersion(cd.Status.KubernetesVersion),
+	}
+	for _, svc := range cluster.Services {
+		summary.Services = append(summary.Services, kcm.FleetServiceSummary{Name: svc.Name, Template: svc.Template, Chart: svc.Chart, Version: svc.Version})
+	}
+
+	return len(fleet.Search([]kcm.FleetClusterSummary{summary}, q)) == 1
+}
+
+// serviceReadiness returns the readiness of the release of the service with the given name
+// as reported in the status of the given ClusterDeployment.
+func serviceReadiness(cd *kcm.ClusterDeployment, name string) (ready bool, message string) {
+	for _, svc := range cd.Status.Services {
+		for _, c := range svc.Conditions {
+			// the condition types of the releases are <namespace>.<name>/<type>
+			_, release, _ := strings.Cut(c.Type, ".")
+			releaseName, _, _ := strings.Cut(release, "/")
+			if releaseName != name || !statemanagement.IsServiceCondition(c.Type) {
+				continue
+			}
+			return c.Status == metav1.ConditionTrue, c.Message
+		}
+	}
+
+	return false, "Service is not deployed"
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	http.Error(w, fmt.Sprintf(format, args...), status)
+}
@@ -0,0 +1,229 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleetview
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newClusterDeployment(namespace, name, template string, ready metav1.ConditionStatus) *kcm.ClusterDeployment {
+	return &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{"env": name}},
+		Spec:       kcm.ClusterDeploymentSpec{Template: template, Credential: "aws-cred"},
+		Status: kcm.ClusterDeploymentStatus{
+			KubernetesVersion: "v1.32.2",
+			Conditions:        []metav1.Condition{{Type: kcm.ReadyCondition, Status: ready, Reason: "Reason", Message: "message"}},
+		},
+	}
+}
+
+func newHandler(t *testing.T, objects ...client.Object) *Handler {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := kcm.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	return &Handler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		Authorizer: AuthorizerFunc(func(_ context.Context, r *http.Request, namespace string) (int, error) {
+			if r.Header.Get("Authorization") != "Bearer "+namespace {
+				return http.StatusForbidden, errors.New("forbidden")
+			}
+			return http.StatusOK, nil
+		}),
+	}
+}
+
+func get(t *testing.T, h *Handler, namespace string, values url.Values) *ClusterList {
+	t.Helper()
+
+	if namespace != "" {
+		values.Set("namespace", namespace)
+	}
+	req := httptest.NewRequest(http.MethodGet, ClustersPath+"?"+values.Encode(), nil)
+	req.Header.Set("Authorization", "Bearer "+namespace)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		return nil
+	}
+	list := new(ClusterList)
+	if err := json.Unmarshal(rec.Body.Bytes(), list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return list
+}
+
+func names(list *ClusterList) []string {
+	var names []string
+	for _, c := range list.Items {
+		names = append(names, c.Namespace+"/"+c.Name)
+	}
+	return names
+}
+
+func TestClusters(t *testing.T) {
+	prod := newClusterDeployment("team-a", "prod", "aws-1-0-0", metav1.ConditionTrue)
+	prod.Spec.ServiceSpec.Services = []kcm.Service{{Name: "ingress", Template: "ingress-nginx-4-11-0"}, {Name: "dns", Template: "external-dns"}}
+	prod.Status.Services = []kcm.ServiceStatus{{
+		ClusterName:      "prod",
+		ClusterNamespace: "team-a",
+		Conditions: []metav1.Condition{
+			{Type: "ingress.ingress/" + kcm.SveltosHelmReleaseReadyCondition, Status: metav1.ConditionTrue},
+		},
+	}}
+
+	h := newHandler(t,
+		prod,
+		newClusterDeployment("team-a", "dev", "azure-1-0-0", metav1.ConditionFalse),
+		newClusterDeployment("team-b", "prod", "aws-1-0-0", metav1.ConditionTrue),
+		&kcm.ClusterTemplate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "aws-1-0-0"},
+			Status: kcm.ClusterTemplateStatus{
+				KubernetesVersion: "v1.32.2",
+				Providers:         kcm.Providers{"bootstrap-k0sproject-k0smotron", "infrastructure-aws"},
+				TemplateStatusCommon: kcm.TemplateStatusCommon{
+					TemplateValidationStatus: kcm.TemplateValidationStatus{Valid: true},
+				},
+			},
+		},
+		&kcm.Credential{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "aws-cred"}, Status: kcm.CredentialStatus{Ready: true}},
+		&kcm.ServiceTemplate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "ingress-nginx-4-11-0"},
+			Spec:       kcm.ServiceTemplateSpec{Helm: &kcm.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: "ingress-nginx"}}},
+			Status:     kcm.ServiceTemplateStatus{TemplateStatusCommon: kcm.TemplateStatusCommon{ChartVersion: "4.11.0"}},
+		},
+	)
+
+	t.Run("joined view", func(t *testing.T) {
+		list := get(t, h, "team-a", url.Values{"labelSelector": {"env=prod"}})
+		if list == nil || len(list.Items) != 1 {
+			t.Fatalf("expected a single cluster, got %v", list)
+		}
+
+		cluster := list.Items[0]
+		cluster.CreationTimestamp = metav1.Time{}
+		expected := Cluster{
+			Namespace:         "team-a",
+			Name:              "prod",
+			Labels:            map[string]string{"env": "prod"},
+			Phase:             kcm.ClusterPhaseReady,
+			Ready:             true,
+			Reason:            "Reason",
+			Message:           "message",
+			KubernetesVersion: "v1.32.2",
+			Template:          Template{Name: "aws-1-0-0", KubernetesVersion: "v1.32.2", Providers: []string{"infrastructure-aws"}, Valid: true},
+			Credential:        Credential{Name: "aws-cred", Ready: true},
+			Services: []Service{
+				{Name: "ingress", Template: "ingress-nginx-4-11-0", Chart: "ingress-nginx", Version: "4.11.0", Ready: true},
+				{Name: "dns", Template: "external-dns", Message: "Service is not deployed"},
+			},
+		}
+		if !reflect.DeepEqual(cluster, expected) {
+			t.Errorf("unexpected cluster view:\n%+v\nexpected:\n%+v", cluster, expected)
+		}
+	})
+
+	for _, tc := range []struct {
+		name      string
+		namespace string
+		values    url.Values
+		expected  []string
+	}{
+		{name: "all namespaces", expected: []string{"team-a/dev", "team-a/prod", "team-b/prod"}},
+		{name: "template", values: url.Values{"template": {"aws-1-0-0"}}, expected: []string{"team-a/prod", "team-b/prod"}},
+		{name: "phase", values: url.Values{"phase": {"notready"}}, expected: []string{"team-a/dev"}},
+		{name: "provider", values: url.Values{"provider": {"aws"}}, expected: []string{"team-a/prod"}},
+		{name: "service", namespace: "team-a", values: url.Values{"service": {"ingress-nginx>=4.10"}}, expected: []string{"team-a/prod"}},
+		{name: "no match", namespace: "team-b", values: url.Values{"kubernetesVersion": {"1.31"}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			list := get(t, h, tc.namespace, tc.values)
+			if list == nil {
+				t.Fatal("expected a successful response")
+			}
+			if actual := names(list); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected clusters %v, got %v", tc.expected, actual)
+			}
+			if list.Total != len(tc.expected) {
+				t.Errorf("expected total %d, got %d", len(tc.expected), list.Total)
+			}
+		})
+	}
+
+	t.Run("pagination", func(t *testing.T) {
+		var pages [][]string
+		values := url.Values{"limit": {"2"}}
+		for {
+			list := get(t, h, "", values)
+			if list == nil {
+				t.Fatal("expected a successful response")
+			}
+			pages = append(pages, names(list))
+			if list.Continue == "" {
+				break
+			}
+			values.Set("continue", list.Continue)
+		}
+
+		expected := [][]string{{"team-a/dev", "team-a/prod"}, {"team-b/prod"}}
+		if !reflect.DeepEqual(pages, expected) {
+			t.Errorf("expected pages %v, got %v", expected, pages)
+		}
+	})
+
+	for _, tc := range []struct {
+		name     string
+		values   url.Values
+		header   string
+		method   string
+		expected int
+	}{
+		{name: "forbidden", header: "Bearer team-b", expected: http.StatusForbidden},
+		{name: "invalid limit", values: url.Values{"limit": {"0"}}, expected: http.StatusBadRequest},
+		{name: "invalid selector", values: url.Values{"labelSelector": {"env in"}}, expected: http.StatusBadRequest},
+		{name: "invalid continue", values: url.Values{"continue": {"!"}}, expected: http.StatusBadRequest},
+		{name: "method", method: http.MethodPost, expected: http.StatusMethodNotAllowed},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, ClustersPath+"?"+tc.values.Encode(), nil)
+			req.Header.Set("Authorization", tc.header)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tc.expected {
+				t.Errorf("expected status %d, got %d: %s", tc.expected, rec.Code, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	hcv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/fluxcd/pkg/apis/meta"
+	fluxconditions "github.com/fluxcd/pkg/runtime/conditions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/helm"
+)
+
+const (
+	kubeconfigSecretSuffix = "-kubeconfig"
+	kubeconfigSecretKey    = "value"
+)
+
+// ServiceHelmReleaseName returns the name of the HelmRelease delivering the given service to the cluster.
+func ServiceHelmReleaseName(cd *kcm.ClusterDeployment, svc kcm.Service) string {
+	return cd.Name + "-" + svc.Name
+}
+
+// ServiceHelmRelease returns the desired HelmRelease delivering the given service to the cluster
+// of the ClusterDeployment. The HelmRelease is reconciled in the management cluster
+// against the kubeconfig of the cluster and depends on the HelmRelease of the cluster itself.
+func ServiceHelmRelease(cd *kcm.ClusterDeployment, svc kcm.Service, tmpl *kcm.ServiceTemplate) (*hcv2.HelmRelease, error) {
+	if tmpl.Spec.Helm == nil {
+		return nil, fmt.Errorf("ServiceTemplate %s/%s is not Helm-based, only Helm-based ServiceTemplates are supported with the Flux service delivery", tmpl.Namespace, tmpl.Name)
+	}
+	if !tmpl.Status.Valid {
+		return nil, fmt.Errorf("ServiceTemplate %s/%s is not valid", tmpl.Namespace, tmpl.Name)
+	}
+	if tmpl.Status.ChartRef == nil {
+		return nil, fmt.Errorf("status for ServiceTemplate %s/%s has not been updated yet", tmpl.Namespace, tmpl.Name)
+	}
+
+	releaseNamespace := svc.Namespace
+	if releaseNamespace == "" {
+		releaseNamespace = svc.Name
+	}
+
+	hr := &hcv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ServiceHelmReleaseName(cd, svc),
+			Namespace: cd.Namespace,
+			Labels: map[string]string{
+				kcm.KCMManagedLabelKey:             kcm.KCMManagedLabelValue,
+				kcm.ServiceDeliveryClusterLabelKey: cd.Name,
+			},
+		},
+		Spec: hcv2.HelmReleaseSpec{
+			ChartRef:         tmpl.Status.ChartRef.DeepCopy(),
+			Interval:         metav1.Duration{Duration: helm.DefaultReconcileInterval},
+			ReleaseName:      svc.Name,
+			TargetNamespace:  releaseNamespace,
+			StorageNamespace: releaseNamespace,
+			KubeConfig: &meta.KubeConfigReference{
+				SecretRef: meta.SecretKeyReference{
+					Name: cd.Name + kubeconfigSecretSuffix,
+					Key:  kubeconfigSecretKey,
+				},
+			},
+			DependsOn: []meta.NamespacedObjectReference{{Name: cd.Name}},
+			Install:   &hcv2.Install{CreateNamespace: true},
+		},
+	}
+
+	if svc.Values != "" {
+		raw, err := yaml.YAMLToJSON([]byte(svc.Values))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse values of the service %s: %w", svc.Name, err)
+		}
+		hr.Spec.Values = &apiextensionsv1.JSON{Raw: raw}
+	}
+
+	for _, v := range svc.ValuesFrom {
+		hr.Spec.ValuesFrom = append(hr.Spec.ValuesFrom, hcv2.ValuesReference{Kind: v.Kind, Name: v.Name})
+	}
+
+	return hr, nil
+}
+
+// ReconcileServices creates or updates the HelmReleases delivering the given services
+// to the cluster of the ClusterDeployment and removes the HelmReleases of the services no longer desired.
+func ReconcileServices(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment, services []kcm.Service) error {
+	owner := metav1.OwnerReference{
+		APIVersion: kcm.GroupVersion.String(),
+		Kind:       kcm.ClusterDeploymentKind,
+		Name:       cd.Name,
+		UID:        cd.UID,
+	}
+
+	desired := make([]string, 0, len(services))
+	for _, svc := range services {
+		if svc.Disable {
+			continue
+		}
+
+		tmpl := new(kcm.ServiceTemplate)
+		tmplRef := client.ObjectKey{Namespace: cd.Namespace, Name: svc.Template}
+		if err := cl.Get(ctx, tmplRef, tmpl); err != nil {
+			return fmt.Errorf("failed to get ServiceTemplate %s: %w", tmplRef, err)
+		}
+
+		expected, err := ServiceHelmRelease(cd, svc, tmpl)
+		if err != nil {
+			return err
+		}
+		desired = append(desired, expected.Name)
+
+		hr := &hcv2.HelmRelease{ObjectMeta: metav1.ObjectMeta{Name: expected.Name, Namespace: expected.Namespace}}
+		if _, err := ctrl.CreateOrUpdate(ctx, cl, hr, func() error {
+			if hr.Labels == nil {
+				hr.Labels = make(map[string]string)
+			}
+			for k, v := range expected.Labels {
+				hr.Labels[k] = v
+			}
+			hr.OwnerReferences = []metav1.OwnerReference{owner}
+			hr.Spec = expected.Spec
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to reconcile HelmRelease %s/%s: %w", hr.Namespace, hr.Name, err)
+		}
+	}
+
+	return deleteServices(ctx, cl, cd, desired)
+}
+
+// DeleteServices deletes the HelmReleases delivering the services to the cluster of the ClusterDeployment.
+func DeleteServices(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) error {
+	return deleteServices(ctx, cl, cd, nil)
+}
+
+func deleteServices(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment, keep []string) error {
+	releases, err := listServiceHelmReleases(ctx, cl, cd)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, hr := range releases {
+		if slices.Contains(keep, hr.Name) {
+			continue
+		}
+		if err := cl.Delete(ctx, &hr); client.IgnoreNotFound(err) != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to delete HelmRelease %s/%s: %w", hr.Namespace, hr.Name, err))
+		}
+	}
+
+	return errs
+}
+
+// ServicesConditions returns the readiness conditions of the HelmReleases delivering the services
+// to the cluster of the ClusterDeployment.
+func ServicesConditions(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) ([]metav1.Condition, error) {
+	releases, err := listServiceHelmReleases(ctx, cl, cd)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := make([]metav1.Condition, 0, len(releases))
+	for _, hr := range releases {
+		condition := metav1.Condition{
+			Type:    fmt.Sprintf("%s.%s/%s", hr.Spec.TargetNamespace, hr.Spec.ReleaseName, kcm.FluxHelmReleaseReadyCondition),
+			Status:  metav1.ConditionUnknown,
+			Reason:  kcm.ProgressingReason,
+			Message: "Release " + hr.Spec.TargetNamespace + "/" + hr.Spec.ReleaseName,
+		}
+		if ready := fluxconditions.Get(&hr, meta.ReadyCondition); ready != nil {
+			condition.Status = ready.Status
+			condition.Reason = ready.Reason
+			if ready.Message != "" {
+				condition.Message += ": " + ready.Message
+			}
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, nil
+}
+
+func listServiceHelmReleases(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) ([]hcv2.HelmRelease, error) {
+	releases := new(hcv2.HelmReleaseList)
+	if err := cl.List(ctx, releases, client.InNamespace(cd.Namespace), client.MatchingLabels{
+		kcm.KCMManagedLabelKey:             kcm.KCMManagedLabelValue,
+		kcm.ServiceDeliveryClusterLabelKey: cd.Name,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list HelmReleases of the services of ClusterDeployment %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	return releases.Items, nil
+}
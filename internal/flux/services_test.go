@@ -0,0 +1,157 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flux
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	hcv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newServiceTemplate(name string) *kcm.ServiceTemplate {
+	return &kcm.ServiceTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "dev"},
+		Spec:       kcm.ServiceTemplateSpec{Helm: &kcm.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: name}}},
+		Status: kcm.ServiceTemplateStatus{
+			TemplateStatusCommon: kcm.TemplateStatusCommon{
+				TemplateValidationStatus: kcm.TemplateValidationStatus{Valid: true},
+				ChartRef:                 &hcv2.CrossNamespaceSourceReference{Kind: "HelmChart", Name: name, Namespace: "dev"},
+			},
+		},
+	}
+}
+
+func TestServiceHelmRelease(t *testing.T) {
+	cd := &kcm.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "dev"}}
+	svc := kcm.Service{
+		Name:       "ingress",
+		Template:   "ingress-nginx-4-11-0",
+		Values:     "replicas: 2",
+		ValuesFrom: []sveltosv1beta1.ValueFrom{{Kind: "ConfigMap", Name: "ingress-values"}},
+	}
+
+	hr, err := ServiceHelmRelease(cd, svc, newServiceTemplate(svc.Template))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := hcv2.HelmReleaseSpec{
+		ChartRef:         &hcv2.CrossNamespaceSourceReference{Kind: "HelmChart", Name: svc.Template, Namespace: "dev"},
+		Interval:         hr.Spec.Interval,
+		ReleaseName:      "ingress",
+		TargetNamespace:  "ingress",
+		StorageNamespace: "ingress",
+		KubeConfig:       &meta.KubeConfigReference{SecretRef: meta.SecretKeyReference{Name: "cluster-kubeconfig", Key: "value"}},
+		DependsOn:        []meta.NamespacedObjectReference{{Name: "cluster"}},
+		Install:          &hcv2.Install{CreateNamespace: true},
+		Values:           &apiextensionsv1.JSON{Raw: []byte(`{"replicas":2}`)},
+		ValuesFrom:       []hcv2.ValuesReference{{Kind: "ConfigMap", Name: "ingress-values"}},
+	}
+	if !reflect.DeepEqual(hr.Spec, expected) {
+		t.Errorf("unexpected HelmRelease spec:\ngot:  %+v\nwant: %+v", hr.Spec, expected)
+	}
+	if hr.Name != "cluster-ingress" || hr.Namespace != "dev" {
+		t.Errorf("unexpected HelmRelease %s/%s", hr.Namespace, hr.Name)
+	}
+	if hr.Labels[kcm.ServiceDeliveryClusterLabelKey] != "cluster" {
+		t.Errorf("expected the HelmRelease to be labeled with the cluster name, got %v", hr.Labels)
+	}
+
+	notHelm := newServiceTemplate(svc.Template)
+	notHelm.Spec.Helm = nil
+	if _, err := ServiceHelmRelease(cd, svc, notHelm); err == nil {
+		t.Error("expected an error for a non-Helm ServiceTemplate")
+	}
+
+	notReady := newServiceTemplate(svc.Template)
+	notReady.Status.ChartRef = nil
+	if _, err := ServiceHelmRelease(cd, svc, notReady); err == nil {
+		t.Error("expected an error for a ServiceTemplate without the chart reference")
+	}
+}
+
+func TestReconcileAndDeleteServices(t *testing.T) {
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{kcm.AddToScheme, hcv2.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatalf("failed to build scheme: %v", err)
+		}
+	}
+
+	cd := &kcm.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "dev"}}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newServiceTemplate("ingress-nginx-4-11-0"),
+		newServiceTemplate("cert-manager-1-16-2"),
+	).Build()
+	ctx := context.Background()
+
+	services := []kcm.Service{
+		{Name: "ingress", Template: "ingress-nginx-4-11-0"},
+		{Name: "cert-manager", Template: "cert-manager-1-16-2", Namespace: "cert-manager"},
+		{Name: "disabled", Template: "cert-manager-1-16-2", Disable: true},
+	}
+	if err := ReconcileServices(ctx, cl, cd, services); err != nil {
+		t.Fatalf("failed to reconcile services: %v", err)
+	}
+	assertReleases(t, cl, "cluster-cert-manager", "cluster-ingress")
+
+	conditions, err := ServicesConditions(ctx, cl, cd)
+	if err != nil {
+		t.Fatalf("failed to get services conditions: %v", err)
+	}
+	if len(conditions) != 2 || conditions[0].Type != "cert-manager.cert-manager/"+kcm.FluxHelmReleaseReadyCondition ||
+		conditions[0].Status != metav1.ConditionUnknown {
+		t.Errorf("unexpected services conditions: %+v", conditions)
+	}
+
+	if err := ReconcileServices(ctx, cl, cd, services[:1]); err != nil {
+		t.Fatalf("failed to reconcile services: %v", err)
+	}
+	assertReleases(t, cl, "cluster-ingress")
+
+	if err := DeleteServices(ctx, cl, cd); err != nil {
+		t.Fatalf("failed to delete services: %v", err)
+	}
+	assertReleases(t, cl)
+}
+
+func assertReleases(t *testing.T, cl client.Client, expected ...string) {
+	t.Helper()
+
+	releases := new(hcv2.HelmReleaseList)
+	if err := cl.List(context.Background(), releases); err != nil {
+		t.Fatalf("failed to list HelmReleases: %v", err)
+	}
+
+	var names []string
+	for _, hr := range releases.Items {
+		names = append(names, hr.Name)
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected HelmReleases %v, got %v", expected, names)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package freeze implements the reconciliation freeze switch pausing the changes made by the KCM controllers.
+//
+// The reconciliation is paused for all the resources with the reconciliationPaused field of the Management.
+// The k0rdent.mirantis.com/reconciliation-paused annotation of a namespace overrides the Management switch
+// for the resources in the namespace: "true" pauses and "false" resumes their reconciliation.
+// The paused controllers only observe the status of the resources and report the pause in the
+// ReconciliationPaused condition.
+package freeze
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// RequeueInterval is the interval the paused resources are checked for the resumed reconciliation at.
+const RequeueInterval = time.Minute
+
+// Paused returns whether the reconciliation of the resources in the given namespace is paused
+// along with the message explaining the pause. The empty namespace denotes the cluster-scoped resources
+// paused with the Management switch only.
+func Paused(ctx context.Context, cl client.Client, namespace string) (bool, string, error) {
+	if namespace != "" {
+		ns := new(corev1.Namespace)
+		if err := cl.Get(ctx, client.ObjectKey{Name: namespace}, ns); client.IgnoreNotFound(err) != nil {
+			return false, "", fmt.Errorf("failed to get Namespace %s: %w", namespace, err)
+		}
+		if value, ok := ns.Annotations[kcm.ReconciliationPausedAnnotation]; ok {
+			paused, err := strconv.ParseBool(value)
+			if err != nil {
+				return false, "", fmt.Errorf("invalid value %q of the %s annotation of the Namespace %s: %w", value, kcm.ReconciliationPausedAnnotation, namespace, err)
+			}
+			if !paused {
+				return false, "", nil
+			}
+			return true, fmt.Sprintf("Reconciliation is paused with the %s annotation of the Namespace %s", kcm.ReconciliationPausedAnnotation, namespace), nil
+		}
+	}
+
+	mgmt := new(kcm.Management)
+	if err := cl.Get(ctx, client.ObjectKey{Name: kcm.ManagementName}, mgmt); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, "", nil
+		}
+		return false, "", fmt.Errorf("failed to get Management: %w", err)
+	}
+
+	if !mgmt.Spec.ReconciliationPaused {
+		return false, "", nil
+	}
+	return true, "Reconciliation is paused with the reconciliationPaused switch of the Management", nil
+}
+
+// Condition returns the ReconciliationPaused condition with the given message.
+func Condition(message string, generation int64) metav1.Condition {
+	return metav1.Condition{
+		Type:               kcm.ReconciliationPausedCondition,
+		Status:             metav1.ConditionTrue,
+		Reason:             kcm.ReconciliationPausedReason,
+		Message:            message,
+		ObservedGeneration: generation,
+	}
+}
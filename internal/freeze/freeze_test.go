@@ -0,0 +1,103 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package freeze
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestPaused(t *testing.T) {
+	newManagement := func(paused bool) *kcm.Management {
+		return &kcm.Management{
+			ObjectMeta: metav1.ObjectMeta{Name: kcm.ManagementName},
+			Spec:       kcm.ManagementSpec{ReconciliationPaused: paused},
+		}
+	}
+	newNamespace := func(annotations map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Annotations: annotations}}
+	}
+
+	for _, tc := range []struct {
+		name      string
+		objects   []client.Object
+		namespace string
+		paused    bool
+		message   string
+		err       string
+	}{
+		{
+			name:      "no management",
+			namespace: "team-a",
+		},
+		{
+			name:      "not paused",
+			objects:   []client.Object{newManagement(false), newNamespace(nil)},
+			namespace: "team-a",
+		},
+		{
+			name:      "paused by management",
+			objects:   []client.Object{newManagement(true), newNamespace(nil)},
+			namespace: "team-a",
+			paused:    true,
+			message:   "Reconciliation is paused with the reconciliationPaused switch of the Management",
+		},
+		{
+			name:    "cluster-scoped paused by management",
+			objects: []client.Object{newManagement(true)},
+			paused:  true,
+			message: "Reconciliation is paused with the reconciliationPaused switch of the Management",
+		},
+		{
+			name:      "paused by namespace",
+			objects:   []client.Object{newManagement(false), newNamespace(map[string]string{kcm.ReconciliationPausedAnnotation: "true"})},
+			namespace: "team-a",
+			paused:    true,
+			message:   "Reconciliation is paused with the k0rdent.mirantis.com/reconciliation-paused annotation of the Namespace team-a",
+		},
+		{
+			name:      "resumed by namespace",
+			objects:   []client.Object{newManagement(true), newNamespace(map[string]string{kcm.ReconciliationPausedAnnotation: "false"})},
+			namespace: "team-a",
+		},
+		{
+			name:      "invalid namespace annotation",
+			objects:   []client.Object{newManagement(false), newNamespace(map[string]string{kcm.ReconciliationPausedAnnotation: "yes"})},
+			namespace: "team-a",
+			err:       `invalid value "yes" of the k0rdent.mirantis.com/reconciliation-paused annotation of the Namespace team-a`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tc.objects...).Build()
+
+			paused, message, err := Paused(t.Context(), cl, tc.namespace)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.paused, paused)
+			require.Equal(t, tc.message, message)
+		})
+	}
+}
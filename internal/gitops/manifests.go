@@ -0,0 +1,139 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"path"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// lastAppliedConfigAnnotation is omitted from the exported manifests
+// since it duplicates the object itself.
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// Files returns the canonical manifests of the given objects keyed by their paths in the export.
+// The ClusterDeployments are placed under the clusterdeployments/<namespace>/ directories,
+// the MultiClusterServices are placed under the multiclusterservices/ directory.
+func Files(clusterDeployments []kcm.ClusterDeployment, multiClusterServices []kcm.MultiClusterService) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(clusterDeployments)+len(multiClusterServices))
+	for i := range clusterDeployments {
+		cd := &clusterDeployments[i]
+		data, err := Manifest(cd, kcm.ClusterDeploymentKind)
+		if err != nil {
+			return nil, err
+		}
+		files[path.Join("clusterdeployments", cd.Namespace, cd.Name+".yaml")] = data
+	}
+	for i := range multiClusterServices {
+		mcs := &multiClusterServices[i]
+		data, err := Manifest(mcs, kcm.MultiClusterServiceKind)
+		if err != nil {
+			return nil, err
+		}
+		files[path.Join("multiclusterservices", mcs.Name+".yaml")] = data
+	}
+
+	return files, nil
+}
+
+// Manifest returns the canonical YAML manifest of the given object.
+// The status and the metadata populated by the server are omitted.
+func Manifest(obj client.Object, kind string) ([]byte, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s %s: %w", kind, client.ObjectKeyFromObject(obj), err)
+	}
+
+	metadata := map[string]any{"name": obj.GetName()}
+	if obj.GetNamespace() != "" {
+		metadata["namespace"] = obj.GetNamespace()
+	}
+	if labels := obj.GetLabels(); len(labels) > 0 {
+		metadata["labels"] = labels
+	}
+	annotations := make(map[string]string, len(obj.GetAnnotations()))
+	for k, v := range obj.GetAnnotations() {
+		if k != lastAppliedConfigAnnotation {
+			annotations[k] = v
+		}
+	}
+	if len(annotations) > 0 {
+		metadata["annotations"] = annotations
+	}
+
+	manifest := map[string]any{
+		"apiVersion": kcm.GroupVersion.String(),
+		"kind":       kind,
+		"metadata":   metadata,
+	}
+	if spec, ok := content["spec"]; ok {
+		manifest["spec"] = spec
+	}
+
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s %s: %w", kind, client.ObjectKeyFromObject(obj), err)
+	}
+
+	return data, nil
+}
+
+// Archive packs the given files to a gzipped tarball.
+// The result depends on the files only, so unchanged files produce the same archive.
+func Archive(files map[string][]byte) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		data := files[name]
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(data)),
+		}); err != nil {
+			return nil, fmt.Errorf("failed to write header of %s: %w", name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close tar writer: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close gzip writer: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
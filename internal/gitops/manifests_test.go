@@ -0,0 +1,162 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"reflect"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestManifest(t *testing.T) {
+	cd := &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "dev",
+			Namespace:       "team-a",
+			ResourceVersion: "42",
+			UID:             "0a1b2c",
+			Labels:          map[string]string{"env": "dev"},
+			Annotations: map[string]string{
+				lastAppliedConfigAnnotation: "{}",
+			},
+		},
+		Spec: kcm.ClusterDeploymentSpec{
+			Template:   "aws-1-0-0",
+			Credential: "aws",
+			Config:     &apiextensionsv1.JSON{Raw: []byte(`{"region":"us-east-2"}`)},
+		},
+		Status: kcm.ClusterDeploymentStatus{KubernetesVersion: "v1.32.2"},
+	}
+
+	data, err := Manifest(cd, kcm.ClusterDeploymentKind)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `apiVersion: k0rdent.mirantis.com/v1alpha1
+kind: ClusterDeployment
+metadata:
+  labels:
+    env: dev
+  name: dev
+  namespace: team-a
+spec:
+  config:
+    region: us-east-2
+  credential: aws
+  serviceSpec: {}
+  template: aws-1-0-0
+`
+	if string(data) != expected {
+		t.Errorf("unexpected manifest:\n%s\nexpected:\n%s", data, expected)
+	}
+}
+
+func TestFiles(t *testing.T) {
+	files, err := Files(
+		[]kcm.ClusterDeployment{
+			{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-b"}},
+		},
+		[]kcm.MultiClusterService{
+			{ObjectMeta: metav1.ObjectMeta{Name: "ingress"}},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names := archiveNames(t, files)
+	expected := []string{
+		"clusterdeployments/team-a/dev.yaml",
+		"clusterdeployments/team-b/dev.yaml",
+		"multiclusterservices/ingress.yaml",
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("unexpected archive entries %v, expected %v", names, expected)
+	}
+}
+
+func TestArchiveIsReproducible(t *testing.T) {
+	files := map[string][]byte{
+		"b.yaml": []byte("b: 1\n"),
+		"a.yaml": []byte("a: 1\n"),
+	}
+
+	first, err := Archive(files)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := Archive(files)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Error("expected the archives of the same files to be equal")
+	}
+
+	files["a.yaml"] = []byte("a: 2\n")
+	third, err := Archive(files)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bytes.Equal(first, third) {
+		t.Error("expected the archives of different files to differ")
+	}
+}
+
+func archiveNames(t *testing.T, files map[string][]byte) []string {
+	t.Helper()
+
+	archive, err := Archive(files)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("failed to read gzip: %v", err)
+	}
+	tr := tar.NewReader(gr)
+
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read tar: %v", err)
+		}
+		names = append(names, hdr.Name)
+	}
+
+	return names
+}
+
+func TestTargetReference(t *testing.T) {
+	target := Target{URL: "oci://ghcr.io/org/kcm-state", Tag: "latest"}
+	if ref := target.Reference(); ref != "ghcr.io/org/kcm-state:latest" {
+		t.Errorf("unexpected reference %s", ref)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitops
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/containerd/containerd/remotes/docker"
+	"oras.land/oras-go/pkg/content"
+	"oras.land/oras-go/pkg/oras"
+)
+
+const (
+	// ConfigMediaType is the media type of the artifact config recognized by Flux.
+	ConfigMediaType = "application/vnd.cncf.flux.config.v1+json"
+	// ContentMediaType is the media type of the artifact layer with the manifests recognized by Flux.
+	ContentMediaType = "application/vnd.cncf.flux.content.v1.tar+gzip"
+
+	// RevisionAnnotation is the OCI annotation holding the revision of the exported manifests.
+	RevisionAnnotation = "org.opencontainers.image.revision"
+	// SourceAnnotation is the OCI annotation holding the source of the exported manifests.
+	SourceAnnotation = "org.opencontainers.image.source"
+)
+
+// Target is the OCI repository the artifact is pushed to.
+type Target struct {
+	// URL is the repository URL prefixed with oci://.
+	URL string
+	// Tag is the tag of the artifact.
+	Tag string
+	// Username and Password are the registry credentials, optional.
+	Username, Password string
+	// PlainHTTP allows connecting to the registry over HTTP.
+	PlainHTTP bool
+}
+
+// Reference returns the reference of the artifact in the target repository.
+func (t Target) Reference() string {
+	return strings.TrimPrefix(t.URL, "oci://") + ":" + t.Tag
+}
+
+// Push pushes the archive with the manifests as a Flux-compatible OCI artifact to the given target
+// and returns the digest of the artifact manifest.
+func Push(ctx context.Context, target Target, archive []byte, annotations map[string]string) (string, error) {
+	ref := target.Reference()
+
+	store := content.NewMemory()
+	layer, err := store.Add("", ContentMediaType, archive)
+	if err != nil {
+		return "", fmt.Errorf("failed to add the manifests layer: %w", err)
+	}
+	config, err := store.Add("", ConfigMediaType, []byte("{}"))
+	if err != nil {
+		return "", fmt.Errorf("failed to add the artifact config: %w", err)
+	}
+
+	manifestData, manifest, err := content.GenerateManifest(&config, annotations, layer)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate the artifact manifest: %w", err)
+	}
+	if err := store.StoreManifest(ref, manifest, manifestData); err != nil {
+		return "", fmt.Errorf("failed to store the artifact manifest: %w", err)
+	}
+
+	registryOpts := []docker.RegistryOpt{
+		docker.WithClient(http.DefaultClient),
+		docker.WithPlainHTTP(func(string) (bool, error) { return target.PlainHTTP, nil }),
+	}
+	if target.Username != "" || target.Password != "" {
+		registryOpts = append(registryOpts, docker.WithAuthorizer(docker.NewDockerAuthorizer(
+			docker.WithAuthCreds(func(string) (string, string, error) { return target.Username, target.Password, nil }),
+		)))
+	}
+	registry := content.Registry{Resolver: docker.NewResolver(docker.ResolverOptions{
+		Hosts: docker.ConfigureDefaultRegistries(registryOpts...),
+	})}
+
+	if _, err := oras.Copy(ctx, store, ref, registry, "", oras.WithNameValidation(nil)); err != nil {
+		return "", fmt.Errorf("failed to push the artifact to %s: %w", ref, err)
+	}
+
+	return manifest.Digest.String(), nil
+}
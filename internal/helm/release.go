@@ -16,6 +16,7 @@ package helm
 
 import (
 	"context"
+	"maps"
 	"time"
 
 	hcv2 "github.com/fluxcd/helm-controller/api/v2"
@@ -41,6 +42,7 @@ type ReconcileHelmReleaseOpts struct {
 	Install           *hcv2.Install
 	TargetNamespace   string
 	DependsOn         []meta.NamespacedObjectReference
+	Annotations       map[string]string
 }
 
 func ReconcileHelmRelease(ctx context.Context,
@@ -62,6 +64,13 @@ func ReconcileHelmRelease(ctx context.Context,
 		}
 		hr.Labels[kcm.KCMManagedLabelKey] = kcm.KCMManagedLabelValue
 
+		if len(opts.Annotations) > 0 {
+			if hr.Annotations == nil {
+				hr.Annotations = make(map[string]string)
+			}
+			maps.Copy(hr.Annotations, opts.Annotations)
+		}
+
 		if opts.OwnerReference != nil {
 			hr.OwnerReferences = []metav1.OwnerReference{*opts.OwnerReference}
 		}
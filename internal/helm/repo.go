@@ -16,6 +16,7 @@ package helm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/fluxcd/pkg/apis/meta"
@@ -26,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/utils"
 )
 
 type DefaultRegistryConfig struct {
@@ -56,6 +58,49 @@ func (r *DefaultRegistryConfig) HelmRepositorySpec() sourcev1.HelmRepositorySpec
 	}
 }
 
+// RegistryAuthHelmRepositorySpec returns the spec of the HelmRepository authenticating to the registry
+// as configured by the given RegistryAuth of a template. The URL defaults to the URL of the default registry.
+func (r *DefaultRegistryConfig) RegistryAuthHelmRepositorySpec(auth *kcm.RegistryAuth) (sourcev1.HelmRepositorySpec, error) {
+	url := auth.URL
+	if url == "" {
+		url = r.URL
+	}
+
+	repoType, err := utils.DetermineDefaultRepositoryType(url)
+	if err != nil {
+		return sourcev1.HelmRepositorySpec{}, err
+	}
+
+	provider := auth.Provider
+	if provider == "" {
+		provider = kcm.RegistryAuthProviderGeneric
+	}
+	if provider != kcm.RegistryAuthProviderGeneric {
+		if repoType != utils.RegistryTypeOCI {
+			return sourcev1.HelmRepositorySpec{}, fmt.Errorf("the %s provider is supported only by the OCI registries, got %s", provider, url)
+		}
+		if auth.SecretRef != "" {
+			return sourcev1.HelmRepositorySpec{}, errors.New("secretRef can only be set with the generic provider")
+		}
+	}
+
+	spec := sourcev1.HelmRepositorySpec{
+		Type:     repoType,
+		URL:      url,
+		Interval: metav1.Duration{Duration: DefaultReconcileInterval},
+		Insecure: auth.Insecure,
+	}
+	if repoType == utils.RegistryTypeOCI {
+		// Flux accepts the provider only for the OCI repositories
+		spec.Provider = provider
+	}
+	if auth.SecretRef != "" {
+		spec.SecretRef = &meta.LocalObjectReference{Name: auth.SecretRef}
+	}
+
+	return spec, nil
+}
+
 func ReconcileHelmRepository(ctx context.Context, cl client.Client, name, namespace string, spec sourcev1.HelmRepositorySpec) error {
 	helmRepo := &sourcev1.HelmRepository{
 		ObjectMeta: metav1.ObjectMeta{
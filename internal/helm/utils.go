@@ -17,6 +17,7 @@ package helm
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
@@ -27,8 +28,18 @@ import (
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/K0rdent/kcm/internal/utils/tlsprofile"
 )
 
+// clientTLSProfile is the TLS profile of the connections downloading the charts.
+var clientTLSProfile string
+
+// SetClientTLSProfile sets the TLS profile of the connections downloading the charts.
+func SetClientTLSProfile(profile string) {
+	clientTLSProfile = profile
+}
+
 func DownloadChartFromArtifact(ctx context.Context, artifact *sourcev1.Artifact) (*chart.Chart, error) {
 	return DownloadChart(ctx, artifact.URL, artifact.Digest)
 }
@@ -37,6 +48,12 @@ func DownloadChart(ctx context.Context, chartURL, digest string) (*chart.Chart,
 	l := log.FromContext(ctx, "chart", chartURL)
 
 	client := retryablehttp.NewClient()
+	if transport, ok := client.HTTPClient.Transport.(*http.Transport); ok {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		tlsprofile.Apply(clientTLSProfile, transport.TLSClientConfig)
+	}
 	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, chartURL, nil)
 	if err != nil {
 		return nil, err
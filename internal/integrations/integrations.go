@@ -0,0 +1,101 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package integrations detects the optional integrations of KCM installed in the management cluster,
+// so the subsystems relying on them are disabled in the partial installs instead of failing on the missing CRDs.
+package integrations
+
+import (
+	"errors"
+	"fmt"
+
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterapiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// Sveltos is the name of the Sveltos integration delivering the services to the clusters.
+	Sveltos = "sveltos"
+	// Velero is the name of the Velero integration backing up the management cluster.
+	Velero = "velero"
+	// ClusterAPI is the name of the Cluster API integration provisioning the clusters.
+	ClusterAPI = "cluster-api"
+)
+
+// kinds holds the kinds each of the integrations requires.
+var kinds = map[string][]schema.GroupVersionKind{
+	Sveltos: {
+		sveltosv1beta1.GroupVersion.WithKind(sveltosv1beta1.ClusterProfileKind),
+		sveltosv1beta1.GroupVersion.WithKind(sveltosv1beta1.ClusterSummaryKind),
+	},
+	Velero: {
+		velerov1.SchemeGroupVersion.WithKind("Backup"),
+		velerov1.SchemeGroupVersion.WithKind("Restore"),
+	},
+	ClusterAPI: {
+		clusterapiv1.GroupVersion.WithKind(clusterapiv1.ClusterKind),
+		clusterapiv1.GroupVersion.WithKind("MachineDeployment"),
+	},
+}
+
+// Detect returns the availability of all of the known integrations.
+func Detect(mapper meta.RESTMapper) (map[string]kcm.IntegrationStatus, error) {
+	var errs error
+	statuses := make(map[string]kcm.IntegrationStatus, len(kinds))
+	for name := range kinds {
+		status, err := Status(mapper, name)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		statuses[name] = status
+	}
+
+	return statuses, errs
+}
+
+// Status returns the availability of the integration with the given name, which is available
+// only if the CRDs of all of its kinds are installed. The errors other than the missing
+// kinds, e.g. the discovery failures, are returned since the availability is unknown.
+func Status(mapper meta.RESTMapper, name string) (kcm.IntegrationStatus, error) {
+	gvks, ok := kinds[name]
+	if !ok {
+		return kcm.IntegrationStatus{}, fmt.Errorf("unknown integration %s", name)
+	}
+
+	for _, gvk := range gvks {
+		_, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if meta.IsNoMatchError(err) {
+			return kcm.IntegrationStatus{
+				Message: fmt.Sprintf("the CRD of %s is not installed", gvk.GroupKind()),
+			}, nil
+		}
+		if err != nil {
+			return kcm.IntegrationStatus{}, fmt.Errorf("failed to get the REST mapping of %s: %w", gvk, err)
+		}
+	}
+
+	return kcm.IntegrationStatus{Available: true}, nil
+}
+
+// Available returns true if the integration with the given name is installed.
+func Available(mapper meta.RESTMapper, name string) (bool, error) {
+	status, err := Status(mapper, name)
+	return status.Available, err
+}
@@ -0,0 +1,54 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integrations
+
+import (
+	"reflect"
+	"testing"
+
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	clusterapiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestDetect(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(clusterapiv1.GroupVersion.WithKind(clusterapiv1.ClusterKind), meta.RESTScopeNamespace)
+	mapper.Add(clusterapiv1.GroupVersion.WithKind("MachineDeployment"), meta.RESTScopeNamespace)
+	mapper.Add(sveltosv1beta1.GroupVersion.WithKind(sveltosv1beta1.ClusterProfileKind), meta.RESTScopeRoot)
+	mapper.Add(velerov1.SchemeGroupVersion.WithKind("Backup"), meta.RESTScopeNamespace)
+	mapper.Add(velerov1.SchemeGroupVersion.WithKind("Restore"), meta.RESTScopeNamespace)
+
+	statuses, err := Detect(mapper)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]kcm.IntegrationStatus{
+		ClusterAPI: {Available: true},
+		Sveltos:    {Message: "the CRD of ClusterSummary.config.projectsveltos.io is not installed"},
+		Velero:     {Available: true},
+	}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("expected statuses %v, got %v", expected, statuses)
+	}
+
+	if _, err := Available(mapper, "unknown"); err == nil {
+		t.Error("expected error for the unknown integration")
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kubernetessupport reports the clusters running the Kubernetes versions approaching or past
+// their upstream end of life and validates the Kubernetes versions of the clusters against the version
+// skew supported by the management components as set in the Release.
+package kubernetessupport
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// WarningPeriod is the period before the end of life of a Kubernetes version the clusters are reported within.
+const WarningPeriod = 90 * 24 * time.Hour
+
+// EndOfLife returns the end of life of the minor version of the given Kubernetes version
+// set in the Release or nil if the Release does not track the version.
+func EndOfLife(release *kcm.Release, kubernetesVersion string) (*metav1.Time, error) {
+	if release == nil || len(release.Spec.KubernetesVersions) == 0 || kubernetesVersion == "" {
+		return nil, nil
+	}
+
+	version, err := semver.NewVersion(kubernetesVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Kubernetes version %s: %w", kubernetesVersion, err)
+	}
+	minor := fmt.Sprintf("%d.%d", version.Major(), version.Minor())
+
+	for _, support := range release.Spec.KubernetesVersions {
+		if strings.TrimPrefix(support.Version, "v") == minor {
+			return support.EndOfLife.DeepCopy(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Describe describes the given end of life, e.g. "reaches its end of life on 2025-10-28", if it is
+// approaching or passed or returns an empty string if the end of life is not within the [WarningPeriod].
+func Describe(endOfLife *metav1.Time, now time.Time) string {
+	_, description := evaluate(endOfLife, now)
+	return description
+}
+
+// SetCondition sets the KubernetesVersionEndOfLife condition to the given conditions if the given
+// Kubernetes version approaches or is past the given end of life or removes the condition otherwise.
+// Returns whether the condition has been changed.
+func SetCondition(conditions *[]metav1.Condition, kubernetesVersion string, endOfLife *metav1.Time, now time.Time) bool {
+	reason, description := evaluate(endOfLife, now)
+	if reason == "" {
+		return apimeta.RemoveStatusCondition(conditions, kcm.KubernetesVersionEndOfLifeCondition)
+	}
+
+	return apimeta.SetStatusCondition(conditions, metav1.Condition{
+		Type:    kcm.KubernetesVersionEndOfLifeCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: fmt.Sprintf("Kubernetes version %s %s, upgrade the cluster to a supported version", kubernetesVersion, description),
+	})
+}
+
+func evaluate(endOfLife *metav1.Time, now time.Time) (reason, description string) {
+	if endOfLife == nil {
+		return "", ""
+	}
+
+	date := endOfLife.UTC().Format(time.DateOnly)
+	switch untilEndOfLife := endOfLife.Sub(now); {
+	case untilEndOfLife <= 0:
+		return kcm.KubernetesVersionEndOfLifeReason, "reached its end of life on " + date
+	case untilEndOfLife <= WarningPeriod:
+		return kcm.KubernetesVersionEndOfLifeApproachingReason, "reaches its end of life on " + date
+	}
+
+	return "", ""
+}
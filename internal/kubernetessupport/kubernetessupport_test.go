@@ -0,0 +1,92 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetessupport
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestEndOfLife(t *testing.T) {
+	eol131 := metav1.NewTime(time.Date(2025, time.October, 28, 0, 0, 0, 0, time.UTC))
+	release := &kcm.Release{Spec: kcm.ReleaseSpec{KubernetesVersions: []kcm.KubernetesVersionSupport{
+		{Version: "1.30", EndOfLife: metav1.NewTime(time.Date(2025, time.June, 28, 0, 0, 0, 0, time.UTC))},
+		{Version: "v1.31", EndOfLife: eol131},
+	}}}
+
+	for _, tc := range []struct {
+		name    string
+		release *kcm.Release
+		version string
+		want    *metav1.Time
+		wantErr bool
+	}{
+		{name: "no release", version: "v1.31.1"},
+		{name: "no version", release: release},
+		{name: "tracked", release: release, version: "v1.31.1+k0s.0", want: &eol131},
+		{name: "untracked", release: release, version: "v1.33.0"},
+		{name: "invalid", release: release, version: "latest", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := EndOfLife(tc.release, tc.version)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("EndOfLife() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if (got == nil) != (tc.want == nil) || got != nil && !got.Equal(tc.want) {
+				t.Errorf("EndOfLife() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetCondition(t *testing.T) {
+	eol := metav1.NewTime(time.Date(2025, time.October, 28, 0, 0, 0, 0, time.UTC))
+
+	var conditions []metav1.Condition
+	if SetCondition(&conditions, "v1.31.1", &eol, eol.Add(-WarningPeriod-time.Hour)); len(conditions) != 0 {
+		t.Fatalf("expected no condition outside of the warning period, got %+v", conditions)
+	}
+
+	if !SetCondition(&conditions, "v1.31.1", &eol, eol.Add(-time.Hour)) {
+		t.Fatal("expected the condition to be changed")
+	}
+	if len(conditions) != 1 || conditions[0].Status != metav1.ConditionTrue || conditions[0].Reason != kcm.KubernetesVersionEndOfLifeApproachingReason ||
+		conditions[0].Message != "Kubernetes version v1.31.1 reaches its end of life on 2025-10-28, upgrade the cluster to a supported version" {
+		t.Fatalf("unexpected conditions %+v", conditions)
+	}
+	if SetCondition(&conditions, "v1.31.1", &eol, eol.Add(-2*time.Hour)) {
+		t.Error("expected the condition to be unchanged")
+	}
+
+	SetCondition(&conditions, "v1.31.1", &eol, eol.Time)
+	if len(conditions) != 1 || conditions[0].Reason != kcm.KubernetesVersionEndOfLifeReason {
+		t.Fatalf("unexpected conditions %+v", conditions)
+	}
+	if got := Describe(&eol, eol.Time); got != "reached its end of life on 2025-10-28" {
+		t.Errorf("Describe() = %q", got)
+	}
+	if got := Describe(&eol, eol.Add(-WarningPeriod-time.Hour)); got != "" {
+		t.Errorf("Describe() = %q, want empty outside of the warning period", got)
+	}
+
+	SetCondition(&conditions, "v1.32.0", nil, eol.Time)
+	if len(conditions) != 0 {
+		t.Errorf("expected the condition to be removed, got %+v", conditions)
+	}
+}
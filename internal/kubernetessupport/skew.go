@@ -0,0 +1,71 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetessupport
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// ValidateSkew validates the Kubernetes version skew of the management components of the given Release.
+func ValidateSkew(release *kcm.Release) error {
+	var errs error
+	for _, skew := range release.Spec.KubernetesVersionSkew {
+		if _, err := semver.NewConstraint(skew.KubernetesVersions); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid Kubernetes versions %q of the component %s: %w", skew.KubernetesVersions, skew.Component, err))
+		}
+	}
+
+	return errs
+}
+
+// CheckSkew returns an error if the given Kubernetes version of a cluster is unsupported
+// by any of the management components of the given Release.
+func CheckSkew(release *kcm.Release, kubernetesVersion string) error {
+	if release == nil || len(release.Spec.KubernetesVersionSkew) == 0 || kubernetesVersion == "" {
+		return nil
+	}
+
+	version, err := semver.NewVersion(kubernetesVersion)
+	if err != nil {
+		return fmt.Errorf("failed to parse Kubernetes version %s: %w", kubernetesVersion, err)
+	}
+	// the build metadata, e.g. "+k0s.0", and the prerelease are irrelevant for the skew
+	core, _ := version.SetMetadata("")
+	core, _ = core.SetPrerelease("")
+
+	var unsupported []string
+	for _, skew := range release.Spec.KubernetesVersionSkew {
+		constraint, err := semver.NewConstraint(skew.KubernetesVersions)
+		if err != nil {
+			return fmt.Errorf("invalid Kubernetes versions %q of the component %s in the Release %s: %w", skew.KubernetesVersions, skew.Component, release.Name, err)
+		}
+		if !constraint.Check(&core) {
+			unsupported = append(unsupported, fmt.Sprintf("%s (%s)", skew.Component, skew.KubernetesVersions))
+		}
+	}
+
+	if len(unsupported) > 0 {
+		return fmt.Errorf("the Kubernetes version %s is unsupported by the management components %s of the Release %s",
+			kubernetesVersion, strings.Join(unsupported, ", "), release.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetessupport
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestCheckSkew(t *testing.T) {
+	release := &kcm.Release{
+		ObjectMeta: metav1.ObjectMeta{Name: "kcm-1-0-0"},
+		Spec: kcm.ReleaseSpec{KubernetesVersionSkew: []kcm.ComponentKubernetesVersions{
+			{Component: "cluster-api", KubernetesVersions: ">=1.28.0 <1.33.0"},
+			{Component: "projectsveltos", KubernetesVersions: ">=1.28.0 <1.34.0"},
+		}},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		release *kcm.Release
+		version string
+		wantErr string
+	}{
+		{name: "no release", version: "v1.40.0"},
+		{name: "no version", release: release},
+		{name: "supported", release: release, version: "v1.32.1+k0s.0"},
+		{name: "supported prerelease", release: release, version: "v1.32.0-rc.1"},
+		{
+			name: "unsupported by one component", release: release, version: "v1.33.1+k0s.0",
+			wantErr: "the Kubernetes version v1.33.1+k0s.0 is unsupported by the management components cluster-api (>=1.28.0 <1.33.0) of the Release kcm-1-0-0",
+		},
+		{
+			name: "unsupported by all components", release: release, version: "v1.27.4",
+			wantErr: "the Kubernetes version v1.27.4 is unsupported by the management components cluster-api (>=1.28.0 <1.33.0), projectsveltos (>=1.28.0 <1.34.0) of the Release kcm-1-0-0",
+		},
+		{name: "invalid version", release: release, version: "latest", wantErr: "failed to parse Kubernetes version latest: Invalid Semantic Version"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckSkew(tc.release, tc.version)
+			if tc.wantErr == "" && err != nil || tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr) {
+				t.Errorf("CheckSkew() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSkew(t *testing.T) {
+	release := &kcm.Release{Spec: kcm.ReleaseSpec{KubernetesVersionSkew: []kcm.ComponentKubernetesVersions{
+		{Component: "cluster-api", KubernetesVersions: ">=1.28.0 <1.33.0"},
+	}}}
+	if err := ValidateSkew(release); err != nil {
+		t.Errorf("ValidateSkew() error = %v", err)
+	}
+
+	release.Spec.KubernetesVersionSkew = append(release.Spec.KubernetesVersionSkew, kcm.ComponentKubernetesVersions{Component: "projectsveltos", KubernetesVersions: "recent"})
+	if err := ValidateSkew(release); err == nil {
+		t.Error("expected ValidateSkew() to fail on the invalid constraint")
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package leaderelection validates the timings of the leader election of the manager
+// and serves the status of the leader election of its replica.
+package leaderelection
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// StatusPath is the path the status of the leader election is served at.
+const StatusPath = "/leader"
+
+// ValidateTimings returns an error if the leader election with the given timings cannot be started, the leader
+// renews the lease until the renew deadline retrying each retry period, and the other replicas acquire the lease
+// once it is not renewed for the lease duration.
+func ValidateTimings(leaseDuration, renewDeadline, retryPeriod time.Duration) error {
+	switch {
+	case leaseDuration <= 0 || renewDeadline <= 0 || retryPeriod <= 0:
+		return errors.New("the lease duration, the renew deadline and the retry period must be positive")
+	case leaseDuration <= renewDeadline:
+		return fmt.Errorf("the lease duration %s must be greater than the renew deadline %s", leaseDuration, renewDeadline)
+	case float64(renewDeadline) <= leaderelection.JitterFactor*float64(retryPeriod):
+		return fmt.Errorf("the renew deadline %s must be greater than %.1f times the retry period %s", renewDeadline, leaderelection.JitterFactor, retryPeriod)
+	}
+
+	return nil
+}
+
+// Status is the status of the leader election served by a replica.
+type Status struct {
+	// AcquireTime is the time the current leader acquired the lease.
+	AcquireTime *metav1.MicroTime `json:"acquireTime,omitempty"`
+	// RenewTime is the time the current leader last renewed the lease.
+	RenewTime *metav1.MicroTime `json:"renewTime,omitempty"`
+	// Lease is the namespaced name of the lease.
+	Lease string `json:"lease,omitempty"`
+	// HolderIdentity is the identity of the current leader.
+	HolderIdentity string `json:"holderIdentity,omitempty"`
+	// LeaseDurationSeconds is the duration the other replicas wait before acquiring the lease not renewed.
+	LeaseDurationSeconds int32 `json:"leaseDurationSeconds,omitempty"`
+	// LeaseTransitions is the number of the transitions of the lease between the leaders.
+	LeaseTransitions int32 `json:"leaseTransitions,omitempty"`
+	// Enabled is false if the replica runs only the workloads served by each replica, e.g. the webhooks.
+	Enabled bool `json:"enabled"`
+	// Leader is true if the replica is the current leader.
+	Leader bool `json:"leader"`
+}
+
+// Handler serves the [Status] of the leader election of the replica.
+type Handler struct {
+	// Client reads the lease, the live reader is expected so the leases are not cached.
+	Client client.Reader
+	// Elected is closed once the replica is elected as the leader.
+	Elected <-chan struct{}
+	// Lease is the namespaced name of the lease, the leader election is disabled if empty.
+	Lease client.ObjectKey
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	status := Status{Enabled: h.Lease.Name != ""}
+	if status.Enabled {
+		status.Lease = h.Lease.String()
+		status.Leader = h.elected()
+
+		lease := new(coordinationv1.Lease)
+		err := h.Client.Get(r.Context(), h.Lease, lease)
+		switch {
+		case apierrors.IsNotFound(err): // not acquired yet
+		case err != nil:
+			ctrl.LoggerFrom(r.Context()).Error(err, "failed to get the leader election lease", "lease", h.Lease)
+			http.Error(w, "failed to get the leader election lease", http.StatusInternalServerError)
+			return
+		default:
+			status.AcquireTime = lease.Spec.AcquireTime
+			status.RenewTime = lease.Spec.RenewTime
+			status.HolderIdentity = ptr.Deref(lease.Spec.HolderIdentity, "")
+			status.LeaseDurationSeconds = ptr.Deref(lease.Spec.LeaseDurationSeconds, 0)
+			status.LeaseTransitions = ptr.Deref(lease.Spec.LeaseTransitions, 0)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(status)
+}
+
+func (h *Handler) elected() bool {
+	if h.Elected == nil {
+		return false
+	}
+
+	select {
+	case <-h.Elected:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package leaderelection
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidateTimings(t *testing.T) {
+	tests := []struct {
+		name                                      string
+		leaseDuration, renewDeadline, retryPeriod time.Duration
+		expectedErr                               bool
+	}{
+		{name: "defaults", leaseDuration: 15 * time.Second, renewDeadline: 10 * time.Second, retryPeriod: 2 * time.Second},
+		{name: "fast failover", leaseDuration: 6 * time.Second, renewDeadline: 4 * time.Second, retryPeriod: time.Second},
+		{name: "zero retry period", leaseDuration: 15 * time.Second, renewDeadline: 10 * time.Second, expectedErr: true},
+		{name: "renew deadline above lease duration", leaseDuration: 10 * time.Second, renewDeadline: 15 * time.Second, retryPeriod: 2 * time.Second, expectedErr: true},
+		{name: "retry period too long", leaseDuration: 15 * time.Second, renewDeadline: 10 * time.Second, retryPeriod: 9 * time.Second, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTimings(tt.leaseDuration, tt.renewDeadline, tt.retryPeriod)
+			if (err != nil) != tt.expectedErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	key := client.ObjectKey{Namespace: "kcm-system", Name: "31c555b4.k0rdent.mirantis.com"}
+	renewTime := metav1.NewMicroTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       ptr.To("kcm-controller-manager-0_uid"),
+			LeaseDurationSeconds: ptr.To[int32](15),
+			RenewTime:            &renewTime,
+			LeaseTransitions:     ptr.To[int32](3),
+		},
+	}
+	cl := fake.NewClientBuilder().WithObjects(lease).Build()
+
+	elected := make(chan struct{})
+	closedElected := make(chan struct{})
+	close(closedElected)
+
+	tests := []struct {
+		name     string
+		handler  *Handler
+		expected Status
+	}{
+		{
+			name:     "leader election disabled",
+			handler:  &Handler{Client: cl},
+			expected: Status{},
+		},
+		{
+			name:    "follower",
+			handler: &Handler{Client: cl, Elected: elected, Lease: key},
+			expected: Status{
+				Enabled:              true,
+				Lease:                key.String(),
+				HolderIdentity:       "kcm-controller-manager-0_uid",
+				LeaseDurationSeconds: 15,
+				LeaseTransitions:     3,
+			},
+		},
+		{
+			name:    "leader",
+			handler: &Handler{Client: cl, Elected: closedElected, Lease: key},
+			expected: Status{
+				Enabled:              true,
+				Leader:               true,
+				Lease:                key.String(),
+				HolderIdentity:       "kcm-controller-manager-0_uid",
+				LeaseDurationSeconds: 15,
+				LeaseTransitions:     3,
+			},
+		},
+		{
+			name:    "lease not acquired yet",
+			handler: &Handler{Client: cl, Elected: elected, Lease: client.ObjectKey{Namespace: key.Namespace, Name: "other"}},
+			expected: Status{
+				Enabled: true,
+				Lease:   key.Namespace + "/other",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, StatusPath, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status code %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+			}
+
+			var status Status
+			if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+				t.Fatalf("failed to decode the status: %v", err)
+			}
+			if tt.expected.Enabled && tt.expected.HolderIdentity != "" {
+				if status.RenewTime == nil || !status.RenewTime.Equal(&renewTime) {
+					t.Errorf("expected renew time %v, got %v", renewTime, status.RenewTime)
+				}
+			}
+			status.RenewTime = nil
+			if status != tt.expected {
+				t.Errorf("expected status %+v, got %+v", tt.expected, status)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	(&Handler{Client: cl}).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, StatusPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status code %d, got %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lifecyclehooks implements the Cluster API Runtime Extension lifecycle hooks
+// gating the lifecycle transitions of the clusters with the policies of the ClusterDeployments.
+package lifecyclehooks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/robfig/cron/v3"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	runtimeserver "sigs.k8s.io/cluster-api/exp/runtime/server"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/statemanagement"
+)
+
+const (
+	// retryAfterSeconds is the period Cluster API retries the blocked lifecycle transition after.
+	retryAfterSeconds int32 = 30
+	// maxRetryAfterSeconds is the maximum period Cluster API retries the lifecycle transition
+	// blocked until the next maintenance window after.
+	maxRetryAfterSeconds int32 = 600
+
+	backupNamePrefix = "pre-upgrade-"
+)
+
+// Handler handles the Cluster API Runtime Extension lifecycle hooks.
+type Handler struct {
+	Client client.Client
+
+	now func() time.Time
+}
+
+// Register adds the handlers of the lifecycle hooks to the given Runtime Extension server.
+func (h *Handler) Register(srv *runtimeserver.Server) error {
+	for _, handler := range []runtimeserver.ExtensionHandler{
+		{Hook: runtimehooksv1.BeforeClusterUpgrade, Name: "before-cluster-upgrade", HandlerFunc: h.BeforeClusterUpgrade},
+		{Hook: runtimehooksv1.AfterControlPlaneUpgrade, Name: "after-control-plane-upgrade", HandlerFunc: h.AfterControlPlaneUpgrade},
+		{Hook: runtimehooksv1.BeforeClusterDelete, Name: "before-cluster-delete", HandlerFunc: h.BeforeClusterDelete},
+	} {
+		if err := srv.AddExtensionHandler(handler); err != nil {
+			return fmt.Errorf("failed to add %s extension handler: %w", handler.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// BeforeClusterUpgrade postpones the Kubernetes upgrade of the cluster until a maintenance window opens
+// and the backup taken for the upgrade is completed.
+func (h *Handler) BeforeClusterUpgrade(ctx context.Context, req *runtimehooksv1.BeforeClusterUpgradeRequest, resp *runtimehooksv1.BeforeClusterUpgradeResponse) {
+	cd, err := h.getClusterDeployment(ctx, &req.Cluster)
+	if err != nil {
+		setFailure(resp, err)
+		return
+	}
+	resp.SetStatus(runtimehooksv1.ResponseStatusSuccess)
+	if cd == nil || cd.Spec.LifecycleHooks == nil {
+		return
+	}
+
+	hooks := cd.Spec.LifecycleHooks
+	open, nextOpening, err := MaintenanceWindowOpen(hooks.MaintenanceWindows, h.getNow())
+	if err != nil {
+		setFailure(resp, err)
+		return
+	}
+	if !open {
+		retryAfter := min(int32(nextOpening.Sub(h.getNow()).Seconds())+1, maxRetryAfterSeconds)
+		setRetry(resp, retryAfter, "waiting for the maintenance window opening at "+nextOpening.UTC().Format(time.RFC3339))
+		return
+	}
+
+	if !hooks.BackupBeforeUpgrade {
+		return
+	}
+
+	completed, err := h.ensureBackup(ctx, cd, req.ToKubernetesVersion)
+	if err != nil {
+		setFailure(resp, err)
+		return
+	}
+	if !completed {
+		setRetry(resp, retryAfterSeconds, "waiting for the ManagementBackup "+backupName(cd, req.ToKubernetesVersion)+" to complete")
+	}
+}
+
+// AfterControlPlaneUpgrade postpones the upgrade of the worker nodes of the cluster
+// until the services of the cluster are ready.
+func (h *Handler) AfterControlPlaneUpgrade(ctx context.Context, req *runtimehooksv1.AfterControlPlaneUpgradeRequest, resp *runtimehooksv1.AfterControlPlaneUpgradeResponse) {
+	cd, err := h.getClusterDeployment(ctx, &req.Cluster)
+	if err != nil {
+		setFailure(resp, err)
+		return
+	}
+	resp.SetStatus(runtimehooksv1.ResponseStatusSuccess)
+	if cd == nil || cd.Spec.LifecycleHooks == nil || !cd.Spec.LifecycleHooks.WaitForServicesAfterControlPlaneUpgrade {
+		return
+	}
+
+	if !servicesReady(cd) {
+		setRetry(resp, retryAfterSeconds, "waiting for the services of the cluster to be ready")
+	}
+}
+
+// BeforeClusterDelete postpones the deletion of the cluster until the services are removed from it.
+func (h *Handler) BeforeClusterDelete(ctx context.Context, req *runtimehooksv1.BeforeClusterDeleteRequest, resp *runtimehooksv1.BeforeClusterDeleteResponse) {
+	cd, err := h.getClusterDeployment(ctx, &req.Cluster)
+	if err != nil {
+		setFailure(resp, err)
+		return
+	}
+	resp.SetStatus(runtimehooksv1.ResponseStatusSuccess)
+	// the services are removed only once the ClusterDeployment is deleted
+	if cd == nil || cd.DeletionTimestamp.IsZero() || cd.Spec.LifecycleHooks == nil || !cd.Spec.LifecycleHooks.QuiesceServicesBeforeDelete {
+		return
+	}
+
+	quiesced, err := h.servicesQuiesced(ctx, cd)
+	if err != nil {
+		setFailure(resp, err)
+		return
+	}
+	if !quiesced {
+		setRetry(resp, retryAfterSeconds, "waiting for the services to be removed from the cluster")
+	}
+}
+
+// getClusterDeployment returns the ClusterDeployment of the given Cluster or nil if the Cluster is not managed by KCM.
+func (h *Handler) getClusterDeployment(ctx context.Context, cluster *clusterv1.Cluster) (*kcm.ClusterDeployment, error) {
+	cd := new(kcm.ClusterDeployment)
+	if err := h.Client.Get(ctx, client.ObjectKeyFromObject(cluster), cd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get ClusterDeployment %s/%s: %w", cluster.Namespace, cluster.Name, err)
+	}
+
+	return cd, nil
+}
+
+// ensureBackup creates the ManagementBackup taken before the upgrade of the cluster to the given version
+// and reports whether it is completed.
+func (h *Handler) ensureBackup(ctx context.Context, cd *kcm.ClusterDeployment, version string) (bool, error) {
+	backup := &kcm.ManagementBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   backupName(cd, version),
+			Labels: map[string]string{kcm.KCMManagedLabelKey: kcm.KCMManagedLabelValue},
+		},
+	}
+	if err := h.Client.Create(ctx, backup); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return false, fmt.Errorf("failed to create ManagementBackup %s: %w", backup.Name, err)
+		}
+		if err := h.Client.Get(ctx, client.ObjectKeyFromObject(backup), backup); err != nil {
+			return false, fmt.Errorf("failed to get ManagementBackup %s: %w", backup.Name, err)
+		}
+	}
+
+	if !backup.IsCompleted() {
+		return false, nil
+	}
+	if backup.Status.LastBackup.Phase != velerov1.BackupPhaseCompleted {
+		return false, fmt.Errorf("ManagementBackup %s has finished in the %s phase", backup.Name, backup.Status.LastBackup.Phase)
+	}
+
+	return true, nil
+}
+
+// servicesQuiesced reports whether all of the services have been removed from the cluster.
+func (h *Handler) servicesQuiesced(ctx context.Context, cd *kcm.ClusterDeployment) (bool, error) {
+	for _, provider := range statemanagement.Providers(h.Client, nil) {
+		conditions, err := provider.ServicesConditions(ctx, cd)
+		if err != nil {
+			return false, err
+		}
+		if len(conditions) > 0 {
+			return false, nil
+		}
+	}
+
+	summaries := new(sveltosv1beta1.ClusterSummaryList)
+	if err := h.Client.List(ctx, summaries, client.InNamespace(cd.Namespace), client.MatchingLabels{
+		sveltosv1beta1.ClusterNameLabel: cd.Name,
+		sveltosv1beta1.ClusterTypeLabel: string(libsveltosv1beta1.ClusterTypeCapi),
+	}); err != nil {
+		// Sveltos is not installed, e.g. with the Edge management profile
+		if apimeta.IsNoMatchError(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to list ClusterSummaries of the cluster %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	return len(summaries.Items) == 0, nil
+}
+
+func (h *Handler) getNow() time.Time {
+	if h.now != nil {
+		return h.now()
+	}
+	return time.Now()
+}
+
+// MaintenanceWindowOpen reports whether any of the given windows is open at the given time,
+// otherwise returns the time the next window opens at. The window is considered open if none is given.
+func MaintenanceWindowOpen(windows []kcm.MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	if len(windows) == 0 {
+		return true, time.Time{}, nil
+	}
+
+	var nextOpening time.Time
+	for _, w := range windows {
+		schedule, err := cron.ParseStandard(w.Schedule)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("failed to parse the maintenance window schedule %q: %w", w.Schedule, err)
+		}
+
+		// the window is open if it has been opened within the duration before now
+		if !schedule.Next(now.Add(-w.Duration.Duration)).After(now) {
+			return true, time.Time{}, nil
+		}
+
+		if next := schedule.Next(now); nextOpening.IsZero() || next.Before(nextOpening) {
+			nextOpening = next
+		}
+	}
+
+	return false, nextOpening, nil
+}
+
+// servicesReady reports whether all of the services of the ClusterDeployment are ready.
+func servicesReady(cd *kcm.ClusterDeployment) bool {
+	if len(cd.Spec.ServiceSpec.Services) > 0 && len(cd.Status.Services) == 0 {
+		return false
+	}
+
+	for _, status := range cd.Status.Services {
+		for _, c := range status.Conditions {
+			if statemanagement.IsServiceCondition(c.Type) && c.Status != metav1.ConditionTrue {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// backupName returns the name of the ManagementBackup taken before the upgrade of the cluster to the given version.
+func backupName(cd *kcm.ClusterDeployment, version string) string {
+	return backupNamePrefix + cd.Namespace + "-" + cd.Name + "-" + strings.ToLower(strings.NewReplacer("+", "-", "_", "-").Replace(version))
+}
+
+func setRetry(resp runtimehooksv1.RetryResponseObject, retryAfter int32, message string) {
+	resp.SetRetryAfterSeconds(retryAfter)
+	resp.SetMessage(message)
+}
+
+func setFailure(resp runtimehooksv1.ResponseObject, err error) {
+	resp.SetStatus(runtimehooksv1.ResponseStatusFailure)
+	resp.SetMessage(err.Error())
+}
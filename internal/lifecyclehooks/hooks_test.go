@@ -0,0 +1,214 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecyclehooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	hcv2 "github.com/fluxcd/helm-controller/api/v2"
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{kcm.AddToScheme, corev1.AddToScheme, hcv2.AddToScheme, sveltosv1beta1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatalf("failed to build scheme: %v", err)
+		}
+	}
+
+	return scheme
+}
+
+func newClusterDeployment(hooks *kcm.ClusterLifecycleHooks) *kcm.ClusterDeployment {
+	return &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "dev"},
+		Spec:       kcm.ClusterDeploymentSpec{LifecycleHooks: hooks},
+	}
+}
+
+func newCluster() clusterv1.Cluster {
+	return clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "dev"}}
+}
+
+func TestMaintenanceWindowOpen(t *testing.T) {
+	// Saturday
+	now := time.Date(2025, time.March, 15, 3, 0, 0, 0, time.UTC)
+	window := func(schedule string, duration time.Duration) kcm.MaintenanceWindow {
+		return kcm.MaintenanceWindow{Schedule: schedule, Duration: metav1.Duration{Duration: duration}}
+	}
+
+	tests := []struct {
+		name        string
+		windows     []kcm.MaintenanceWindow
+		open        bool
+		nextOpening time.Time
+	}{
+		{
+			name: "no windows",
+			open: true,
+		},
+		{
+			name:    "within the window",
+			windows: []kcm.MaintenanceWindow{window("0 2 * * 6", 2*time.Hour)},
+			open:    true,
+		},
+		{
+			name:        "after the window",
+			windows:     []kcm.MaintenanceWindow{window("0 2 * * 6", 30*time.Minute)},
+			nextOpening: time.Date(2025, time.March, 22, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "the nearest of the windows",
+			windows:     []kcm.MaintenanceWindow{window("0 2 * * 6", 30*time.Minute), window("0 22 * * *", time.Hour)},
+			nextOpening: time.Date(2025, time.March, 15, 22, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open, nextOpening, err := MaintenanceWindowOpen(tt.windows, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if open != tt.open || !nextOpening.Equal(tt.nextOpening) {
+				t.Errorf("expected open %t and next opening %s, got %t and %s", tt.open, tt.nextOpening, open, nextOpening)
+			}
+		})
+	}
+}
+
+func TestBeforeClusterUpgrade(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, time.March, 15, 3, 0, 0, 0, time.UTC)
+
+	t.Run("not managed cluster", func(t *testing.T) {
+		h := &Handler{Client: fake.NewClientBuilder().WithScheme(newScheme(t)).Build()}
+
+		resp := new(runtimehooksv1.BeforeClusterUpgradeResponse)
+		h.BeforeClusterUpgrade(ctx, &runtimehooksv1.BeforeClusterUpgradeRequest{Cluster: newCluster()}, resp)
+		if resp.Status != runtimehooksv1.ResponseStatusSuccess || resp.RetryAfterSeconds != 0 {
+			t.Errorf("expected the upgrade to be allowed, got %+v", resp)
+		}
+	})
+
+	t.Run("outside of the maintenance window", func(t *testing.T) {
+		cd := newClusterDeployment(&kcm.ClusterLifecycleHooks{
+			MaintenanceWindows: []kcm.MaintenanceWindow{{Schedule: "0 4 * * *", Duration: metav1.Duration{Duration: time.Hour}}},
+		})
+		h := &Handler{Client: fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(cd).Build(), now: func() time.Time { return now }}
+
+		resp := new(runtimehooksv1.BeforeClusterUpgradeResponse)
+		h.BeforeClusterUpgrade(ctx, &runtimehooksv1.BeforeClusterUpgradeRequest{Cluster: newCluster()}, resp)
+		if resp.Status != runtimehooksv1.ResponseStatusSuccess || resp.RetryAfterSeconds != maxRetryAfterSeconds {
+			t.Errorf("expected the upgrade to be retried after %d seconds, got %+v", maxRetryAfterSeconds, resp)
+		}
+	})
+
+	t.Run("backup before the upgrade", func(t *testing.T) {
+		cd := newClusterDeployment(&kcm.ClusterLifecycleHooks{BackupBeforeUpgrade: true})
+		cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(cd).WithStatusSubresource(&kcm.ManagementBackup{}).Build()
+		h := &Handler{Client: cl}
+		req := &runtimehooksv1.BeforeClusterUpgradeRequest{Cluster: newCluster(), ToKubernetesVersion: "v1.32.2+k0s.0"}
+
+		resp := new(runtimehooksv1.BeforeClusterUpgradeResponse)
+		h.BeforeClusterUpgrade(ctx, req, resp)
+		if resp.RetryAfterSeconds != retryAfterSeconds {
+			t.Fatalf("expected the upgrade to wait for the backup, got %+v", resp)
+		}
+
+		backup := new(kcm.ManagementBackup)
+		if err := cl.Get(ctx, client.ObjectKey{Name: "pre-upgrade-dev-cluster-v1.32.2-k0s.0"}, backup); err != nil {
+			t.Fatalf("failed to get ManagementBackup: %v", err)
+		}
+		backup.Status.LastBackup = &velerov1.BackupStatus{Phase: velerov1.BackupPhaseCompleted, CompletionTimestamp: &metav1.Time{Time: now}}
+		if err := cl.Status().Update(ctx, backup); err != nil {
+			t.Fatalf("failed to update ManagementBackup status: %v", err)
+		}
+
+		resp = new(runtimehooksv1.BeforeClusterUpgradeResponse)
+		h.BeforeClusterUpgrade(ctx, req, resp)
+		if resp.Status != runtimehooksv1.ResponseStatusSuccess || resp.RetryAfterSeconds != 0 {
+			t.Errorf("expected the upgrade to be allowed, got %+v", resp)
+		}
+	})
+}
+
+func TestAfterControlPlaneUpgrade(t *testing.T) {
+	cd := newClusterDeployment(&kcm.ClusterLifecycleHooks{WaitForServicesAfterControlPlaneUpgrade: true})
+	cd.Status.Services = []kcm.ServiceStatus{{
+		ClusterName:      cd.Name,
+		ClusterNamespace: cd.Namespace,
+		Conditions: []metav1.Condition{
+			{Type: "ingress.ingress/" + kcm.SveltosHelmReleaseReadyCondition, Status: metav1.ConditionFalse},
+		},
+	}}
+	h := &Handler{Client: fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(cd).Build()}
+
+	resp := new(runtimehooksv1.AfterControlPlaneUpgradeResponse)
+	h.AfterControlPlaneUpgrade(context.Background(), &runtimehooksv1.AfterControlPlaneUpgradeRequest{Cluster: newCluster()}, resp)
+	if resp.Status != runtimehooksv1.ResponseStatusSuccess || resp.RetryAfterSeconds != retryAfterSeconds {
+		t.Errorf("expected the upgrade of the workers to wait for the services, got %+v", resp)
+	}
+}
+
+func TestBeforeClusterDelete(t *testing.T) {
+	ctx := context.Background()
+
+	cd := newClusterDeployment(&kcm.ClusterLifecycleHooks{QuiesceServicesBeforeDelete: true})
+	cd.Finalizers = []string{kcm.ClusterDeploymentFinalizer}
+	cd.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	summary := &sveltosv1beta1.ClusterSummary{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "p--dev-cluster-capi-cluster",
+			Namespace: cd.Namespace,
+			Labels: map[string]string{
+				sveltosv1beta1.ClusterNameLabel: cd.Name,
+				sveltosv1beta1.ClusterTypeLabel: "Capi",
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(cd, summary).Build()
+	h := &Handler{Client: cl}
+
+	resp := new(runtimehooksv1.BeforeClusterDeleteResponse)
+	h.BeforeClusterDelete(ctx, &runtimehooksv1.BeforeClusterDeleteRequest{Cluster: newCluster()}, resp)
+	if resp.RetryAfterSeconds != retryAfterSeconds {
+		t.Fatalf("expected the deletion to wait for the services removal, got %+v", resp)
+	}
+
+	if err := cl.Delete(ctx, summary); err != nil {
+		t.Fatalf("failed to delete ClusterSummary: %v", err)
+	}
+
+	resp = new(runtimehooksv1.BeforeClusterDeleteResponse)
+	h.BeforeClusterDelete(ctx, &runtimehooksv1.BeforeClusterDeleteRequest{Cluster: newCluster()}, resp)
+	if resp.Status != runtimehooksv1.ResponseStatusSuccess || resp.RetryAfterSeconds != 0 {
+		t.Errorf("expected the deletion to be allowed, got %+v", resp)
+	}
+}
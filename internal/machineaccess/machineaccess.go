@@ -0,0 +1,200 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package machineaccess implements the ClusterTemplate values conventions of the SSH access
+// to the machines and the bastion host of the clusters.
+//
+// A ClusterTemplate supports the SSH access if its default values define the "machineAccess" key.
+// The template authorizes the keys for the user on the machines with the following values:
+//
+//	machineAccess:
+//	  user: <user of the machines>
+//	  authorizedKeys: <public SSH keys in the authorized_keys format>
+//
+// A ClusterTemplate supports the bastion host if its default values define the "bastion.enabled" key,
+// and restricting the access to it if they define the "bastion.allowedCIDRBlocks" key.
+package machineaccess
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// ValuesKey is the key of the template values holding the SSH access to the machines.
+	ValuesKey = "machineAccess"
+	// AuthorizedKeysKey is the key of the shared ConfigMap holding the public SSH keys.
+	AuthorizedKeysKey = "authorized_keys"
+
+	bastionValuesKey = "bastion"
+)
+
+// AuthorizedKeys returns the public SSH keys of the given ClusterDeployment including the keys
+// of the shared ConfigMap in the authorized_keys format along with the hash of the user and the keys.
+// The keys are deduplicated and sorted, so the hash changes only once the set of the keys changes.
+func AuthorizedKeys(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) (keys, hash string, _ error) {
+	access := cd.Spec.MachineAccess
+	if access == nil {
+		return "", Hash("", ""), nil
+	}
+
+	lines := slices.Clone(access.AuthorizedKeys)
+	if access.AuthorizedKeysConfigMap != "" {
+		cm := new(corev1.ConfigMap)
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: cd.Namespace, Name: access.AuthorizedKeysConfigMap}, cm); err != nil {
+			return "", "", fmt.Errorf("failed to get authorized keys ConfigMap %s/%s: %w", cd.Namespace, access.AuthorizedKeysConfigMap, err)
+		}
+		lines = append(lines, strings.Split(cm.Data[AuthorizedKeysKey], "\n")...)
+	}
+
+	normalized, err := normalize(lines)
+	if err != nil {
+		return "", "", err
+	}
+
+	keys = strings.Join(normalized, "\n")
+	return keys, Hash(access.User, keys), nil
+}
+
+// Hash returns the hash of the given user and the keys authorized for them.
+func Hash(user, keys string) string {
+	sum := sha256.Sum256([]byte(user + "\n" + keys))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalize parses the given public SSH keys skipping the empty lines and the comments
+// and returns them deduplicated and sorted.
+func normalize(lines []string) ([]string, error) {
+	var (
+		keys []string
+		errs error
+	)
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line)); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid public SSH key %q: %w", line, err))
+			continue
+		}
+		keys = append(keys, line)
+	}
+	if errs != nil {
+		return nil, errs
+	}
+
+	slices.Sort(keys)
+	return slices.Compact(keys), nil
+}
+
+// Values returns the template values of the SSH access of the given user with the given keys.
+func Values(user, keys string) map[string]any {
+	return map[string]any{
+		"user":           user,
+		"authorizedKeys": keys,
+	}
+}
+
+// SetBastionValues sets the given bastion host in the bastion value of the given template values
+// keeping the other provider-specific settings of the bastion host.
+func SetBastionValues(values map[string]any, bastion *kcm.MachineBastion) error {
+	if err := unstructured.SetNestedField(values, bastion.Enabled, bastionValuesKey, "enabled"); err != nil {
+		return fmt.Errorf("failed to set bastion values: %w", err)
+	}
+	if len(bastion.AllowedCIDRBlocks) == 0 {
+		return nil
+	}
+	if err := unstructured.SetNestedStringSlice(values, bastion.AllowedCIDRBlocks, bastionValuesKey, "allowedCIDRBlocks"); err != nil {
+		return fmt.Errorf("failed to set bastion values: %w", err)
+	}
+	return nil
+}
+
+// TemplateSupported returns true if the ClusterTemplate with the given default values
+// supports authorizing the SSH keys on the machines.
+func TemplateSupported(config *apiextensionsv1.JSON) (bool, error) {
+	values, err := templateValues(config)
+	if err != nil || values == nil {
+		return false, err
+	}
+
+	_, found, err := unstructured.NestedMap(values, ValuesKey)
+	return found && err == nil, nil
+}
+
+// Validate validates the SSH access of the given ClusterDeployment is supported by the ClusterTemplate
+// with the given default values and the inline keys are valid. The keys of the shared ConfigMap
+// are validated once the cluster is reconciled.
+func Validate(access *kcm.MachineAccess, config *apiextensionsv1.JSON) error {
+	values, err := templateValues(config)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	if len(access.AuthorizedKeys) > 0 || access.AuthorizedKeysConfigMap != "" {
+		if _, found, err := unstructured.NestedMap(values, ValuesKey); !found || err != nil {
+			errs = errors.Join(errs, errors.New("the template does not support authorizing the SSH keys"))
+		}
+	}
+	if _, err := normalize(access.AuthorizedKeys); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if bastion := access.Bastion; bastion != nil {
+		if _, found, err := unstructured.NestedBool(values, bastionValuesKey, "enabled"); !found || err != nil {
+			errs = errors.Join(errs, errors.New("the template does not support the bastion host"))
+		}
+		if len(bastion.AllowedCIDRBlocks) > 0 {
+			if _, found, err := unstructured.NestedSlice(values, bastionValuesKey, "allowedCIDRBlocks"); !found || err != nil {
+				errs = errors.Join(errs, errors.New("the template does not support restricting the access to the bastion host"))
+			}
+		}
+		for _, cidr := range bastion.AllowedCIDRBlocks {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("invalid bastion allowed CIDR %s: %w", cidr, err))
+			}
+		}
+	}
+
+	return errs
+}
+
+func templateValues(config *apiextensionsv1.JSON) (map[string]any, error) {
+	if config == nil || len(config.Raw) == 0 {
+		return nil, nil
+	}
+
+	values := make(map[string]any)
+	if err := json.Unmarshal(config.Raw, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse template config: %w", err)
+	}
+	return values, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package machineaccess
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+const (
+	aliceKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIC+sz7OezjP7/HoX5slsmx3U6/uV9PWYuv3eCRARAeCr alice"
+	bobKey   = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOA7z4n7k+feZj92H1ynaX4odpVSXWbWk601ImQQu5UJ bob"
+)
+
+func TestAuthorizedKeys(t *testing.T) {
+	ctx := t.Context()
+	cd := &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"},
+		Spec: kcm.ClusterDeploymentSpec{
+			MachineAccess: &kcm.MachineAccess{
+				User:                    "ubuntu",
+				AuthorizedKeys:          []string{bobKey},
+				AuthorizedKeysConfigMap: "fleet-keys",
+			},
+		},
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "fleet-keys", Namespace: "team-a"},
+		Data:       map[string]string{AuthorizedKeysKey: "# fleet keys\n" + aliceKey + "\n\n" + bobKey + "\n"},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cm).Build()
+
+	keys, hash, err := AuthorizedKeys(ctx, cl, cd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := aliceKey + "\n" + bobKey; keys != want {
+		t.Errorf("expected deduplicated sorted keys %q, got %q", want, keys)
+	}
+
+	// the rotation of the shared keys changes the hash
+	cm.Data[AuthorizedKeysKey] = bobKey
+	if err := cl.Update(ctx, cm); err != nil {
+		t.Fatalf("failed to update ConfigMap: %v", err)
+	}
+	_, rotatedHash, err := AuthorizedKeys(ctx, cl, cd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rotatedHash == hash {
+		t.Error("expected hash to change once the keys are rotated")
+	}
+
+	cm.Data[AuthorizedKeysKey] = "not-a-key"
+	if err := cl.Update(ctx, cm); err != nil {
+		t.Fatalf("failed to update ConfigMap: %v", err)
+	}
+	if _, _, err := AuthorizedKeys(ctx, cl, cd); err == nil || !strings.Contains(err.Error(), "invalid public SSH key") {
+		t.Errorf("expected invalid key error, got %v", err)
+	}
+
+	// the clusters without the keys are tracked with the hash of no keys
+	_, emptyHash, err := AuthorizedKeys(ctx, cl, &kcm.ClusterDeployment{})
+	if err != nil || emptyHash != Hash("", "") {
+		t.Errorf("unexpected hash %q of no keys: %v", emptyHash, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	supported := &apiextensionsv1.JSON{Raw: []byte(`{"machineAccess":{},"bastion":{"enabled":false,"allowedCIDRBlocks":[]}}`)}
+	bastionOnly := &apiextensionsv1.JSON{Raw: []byte(`{"bastion":{"enabled":false}}`)}
+
+	for _, tc := range []struct {
+		name   string
+		access *kcm.MachineAccess
+		config *apiextensionsv1.JSON
+		err    string
+	}{
+		{
+			name:   "supported",
+			access: &kcm.MachineAccess{User: "ubuntu", AuthorizedKeys: []string{aliceKey}, Bastion: &kcm.MachineBastion{Enabled: true, AllowedCIDRBlocks: []string{"10.0.0.0/8"}}},
+			config: supported,
+		},
+		{
+			name:   "keys not supported",
+			access: &kcm.MachineAccess{User: "ubuntu", AuthorizedKeysConfigMap: "fleet-keys"},
+			config: bastionOnly,
+			err:    "the template does not support authorizing the SSH keys",
+		},
+		{
+			name:   "bastion only",
+			access: &kcm.MachineAccess{User: "ubuntu", Bastion: &kcm.MachineBastion{Enabled: true}},
+			config: bastionOnly,
+		},
+		{
+			name:   "bastion CIDRs not supported",
+			access: &kcm.MachineAccess{User: "ubuntu", Bastion: &kcm.MachineBastion{Enabled: true, AllowedCIDRBlocks: []string{"10.0.0.0/8"}}},
+			config: bastionOnly,
+			err:    "the template does not support restricting the access to the bastion host",
+		},
+		{
+			name:   "invalid key and CIDR",
+			access: &kcm.MachineAccess{User: "ubuntu", AuthorizedKeys: []string{"ssh-ed25519 AAAA"}, Bastion: &kcm.MachineBastion{Enabled: true, AllowedCIDRBlocks: []string{"10.0.0.0"}}},
+			config: supported,
+			err:    "invalid bastion allowed CIDR 10.0.0.0",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.access, tc.config)
+			switch {
+			case tc.err == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)):
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestSetBastionValues(t *testing.T) {
+	values := map[string]any{"bastion": map[string]any{"enabled": false, "instanceType": "t3.micro"}}
+	if err := SetBastionValues(values, &kcm.MachineBastion{Enabled: true, AllowedCIDRBlocks: []string{"10.0.0.0/8"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	bastion, ok := values["bastion"].(map[string]any)
+	if !ok || bastion["enabled"] != true || bastion["instanceType"] != "t3.micro" {
+		t.Errorf("unexpected bastion values %v", bastion)
+	}
+	if cidrs, _ := bastion["allowedCIDRBlocks"].([]any); len(cidrs) != 1 || cidrs[0] != "10.0.0.0/8" {
+		t.Errorf("unexpected allowed CIDRs %v", bastion["allowedCIDRBlocks"])
+	}
+}
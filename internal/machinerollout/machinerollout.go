@@ -0,0 +1,95 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package machinerollout replaces the machines of the clusters, e.g. for the machines
+// to be bootstrapped with the changed settings of the template of the cluster.
+package machinerollout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const kubeadmControlPlaneKind = "KubeadmControlPlane"
+
+// Rollout rolls out the worker machines of the given ClusterDeployment and the control plane machines
+// if the control plane is the KubeadmControlPlane. The other control planes do not support the rollout
+// on request, their machines are bootstrapped with the changed settings once replaced otherwise.
+func Rollout(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment, now metav1.Time) error {
+	machineDeployments := new(clusterv1.MachineDeploymentList)
+	if err := cl.List(ctx, machineDeployments, client.InNamespace(cd.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cd.Name}); err != nil {
+		return fmt.Errorf("failed to list MachineDeployments: %w", err)
+	}
+	for i := range machineDeployments.Items {
+		md := &machineDeployments.Items[i]
+		patch := client.MergeFrom(md.DeepCopy())
+		md.Spec.RolloutAfter = &now
+		if err := cl.Patch(ctx, md, patch); err != nil {
+			return fmt.Errorf("failed to patch MachineDeployment %s/%s: %w", md.Namespace, md.Name, err)
+		}
+	}
+
+	cluster := new(clusterv1.Cluster)
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(cd), cluster); err != nil {
+		return fmt.Errorf("failed to get Cluster %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+	ref := cluster.Spec.ControlPlaneRef
+	if ref == nil || ref.Kind != kubeadmControlPlaneKind {
+		return nil
+	}
+
+	controlPlane := new(unstructured.Unstructured)
+	controlPlane.SetGroupVersionKind(ref.GroupVersionKind())
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: ref.Name}, controlPlane); err != nil {
+		return fmt.Errorf("failed to get %s %s/%s: %w", ref.Kind, cluster.Namespace, ref.Name, err)
+	}
+	patch := client.MergeFrom(controlPlane.DeepCopy())
+	if err := unstructured.SetNestedField(controlPlane.Object, now.UTC().Format(time.RFC3339), "spec", "rolloutAfter"); err != nil {
+		return err
+	}
+	if err := cl.Patch(ctx, controlPlane, patch); err != nil {
+		return fmt.Errorf("failed to patch %s %s/%s: %w", ref.Kind, controlPlane.GetNamespace(), controlPlane.GetName(), err)
+	}
+
+	return nil
+}
+
+// Completed returns whether the worker machines of the given ClusterDeployment have been replaced
+// after the rollout along with the message describing the rollout in progress.
+func Completed(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) (bool, string, error) {
+	machineDeployments := new(clusterv1.MachineDeploymentList)
+	if err := cl.List(ctx, machineDeployments, client.InNamespace(cd.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cd.Name}); err != nil {
+		return false, "", fmt.Errorf("failed to list MachineDeployments: %w", err)
+	}
+	for _, md := range machineDeployments.Items {
+		replicas := int32(0)
+		if md.Spec.Replicas != nil {
+			replicas = *md.Spec.Replicas
+		}
+		if md.Status.ObservedGeneration != md.Generation || md.Status.UpdatedReplicas != replicas ||
+			md.Status.Replicas != replicas || md.Status.ReadyReplicas != replicas {
+			return false, fmt.Sprintf("Waiting for the machines of the MachineDeployment %s to be replaced", md.Name), nil
+		}
+	}
+
+	return true, "", nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package machinerollout
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func newMachineDeployment(name, cluster string) *clusterv1.MachineDeployment {
+	return &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", Labels: map[string]string{clusterv1.ClusterNameLabel: cluster}},
+		Spec:       clusterv1.MachineDeploymentSpec{Replicas: ptr.To[int32](2)},
+	}
+}
+
+func TestRollout(t *testing.T) {
+	ctx := t.Context()
+	cd := &kcm.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"}}
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneRef: &corev1.ObjectReference{APIVersion: "controlplane.cluster.x-k8s.io/v1beta1", Kind: "K0sControlPlane", Name: "dev-cp"},
+		},
+	}
+	md := newMachineDeployment("dev-md", "dev")
+	other := newMachineDeployment("prod-md", "prod")
+
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cluster, md, other).Build()
+
+	now := metav1.NewTime(time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC))
+	if err := Rollout(ctx, cl, cd, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(md), md); err != nil {
+		t.Fatalf("failed to get MachineDeployment: %v", err)
+	}
+	if md.Spec.RolloutAfter == nil || !md.Spec.RolloutAfter.Equal(&now) {
+		t.Errorf("expected MachineDeployment to be rolled out after %s, got %v", now, md.Spec.RolloutAfter)
+	}
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(other), other); err != nil {
+		t.Fatalf("failed to get MachineDeployment: %v", err)
+	}
+	if other.Spec.RolloutAfter != nil {
+		t.Error("expected MachineDeployment of the other cluster not to be rolled out")
+	}
+}
+
+func TestCompleted(t *testing.T) {
+	cd := &kcm.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"}}
+
+	md := newMachineDeployment("dev-md", "dev")
+	md.Status = clusterv1.MachineDeploymentStatus{Replicas: 3, UpdatedReplicas: 1, ReadyReplicas: 2}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(md).WithStatusSubresource(md).Build()
+
+	completed, message, err := Completed(t.Context(), cl, cd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if completed || message == "" {
+		t.Errorf("expected rollout in progress, got completed %t with message %q", completed, message)
+	}
+
+	md.Status = clusterv1.MachineDeploymentStatus{Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2}
+	if err := cl.Status().Update(t.Context(), md); err != nil {
+		t.Fatalf("failed to update status: %v", err)
+	}
+	if completed, _, err = Completed(t.Context(), cl, cd); err != nil || !completed {
+		t.Errorf("expected rollout to be completed, got %t: %v", completed, err)
+	}
+}
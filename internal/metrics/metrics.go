@@ -16,6 +16,7 @@ package metrics
 
 import (
 	"context"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,6 +33,24 @@ const (
 	metricLabelParentKind        = "parent_kind"
 	metricLabelParentNamespace   = "parent_namespace"
 	metricLabelParentName        = "parent_name"
+	metricLabelClusterNamespace  = "cluster_namespace"
+	metricLabelClusterName       = "cluster_name"
+	metricLabelBackupName        = "backup_name"
+	metricLabelOperation         = "operation"
+	metricLabelProvider          = "provider"
+	metricLabelService           = "service"
+	metricLabelKubernetesVersion = "kubernetes_version"
+	metricLabelWebhook           = "webhook"
+	metricLabelCheck             = "check"
+	metricLabelKind              = "kind"
+	metricLabelResult            = "result"
+)
+
+const (
+	// ClusterOperationProvisioning is the operation label value of the initial provisioning of the cluster.
+	ClusterOperationProvisioning = "provisioning"
+	// ClusterOperationUpgrade is the operation label value of the upgrade of the cluster.
+	ClusterOperationUpgrade = "upgrade"
 )
 
 var metricTemplateUsage = prometheus.NewGaugeVec(
@@ -52,10 +71,124 @@ var metricTemplateInvalidity = prometheus.NewGaugeVec(
 	[]string{metricLabelTemplateKind, metricLabelTemplateNamespace, metricLabelTemplateName},
 )
 
+var metricClusterAPIReachable = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: kcm.CoreKCMName,
+		Name:      "cluster_api_reachable",
+		Help:      "Whether the API server of the cluster is reachable from the management cluster",
+	},
+	[]string{metricLabelClusterNamespace, metricLabelClusterName},
+)
+
+var metricClusterAPIProbeLatency = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: kcm.CoreKCMName,
+		Name:      "cluster_api_probe_latency_seconds",
+		Help:      "Latency of the last probe of the API server of the cluster",
+	},
+	[]string{metricLabelClusterNamespace, metricLabelClusterName},
+)
+
+var metricClusterAPICertificateExpiry = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: kcm.CoreKCMName,
+		Name:      "cluster_api_certificate_expiry_timestamp_seconds",
+		Help:      "Expiry time of the serving certificate of the API server of the cluster",
+	},
+	[]string{metricLabelClusterNamespace, metricLabelClusterName},
+)
+
+var metricClusterDeploymentReady = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: kcm.CoreKCMName,
+		Name:      "cluster_deployment_ready",
+		Help:      "Whether the ClusterDeployment is ready",
+	},
+	[]string{metricLabelClusterNamespace, metricLabelClusterName, metricLabelTemplateName},
+)
+
+var metricClusterServiceEndOfLife = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: kcm.CoreKCMName,
+		Name:      "cluster_service_end_of_life",
+		Help:      "Whether the service of the cluster is past the end of life set in its ServiceTemplate",
+	},
+	[]string{metricLabelClusterNamespace, metricLabelClusterName, metricLabelService, metricLabelTemplateName},
+)
+
+var metricClusterServiceVulnerabilities = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: kcm.CoreKCMName,
+		Name:      "cluster_service_vulnerabilities",
+		Help:      "Number of the known vulnerabilities of the service of the cluster set in its ServiceTemplate",
+	},
+	[]string{metricLabelClusterNamespace, metricLabelClusterName, metricLabelService, metricLabelTemplateName},
+)
+
+var metricClusterKubernetesEndOfLife = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: kcm.CoreKCMName,
+		Name:      "cluster_kubernetes_end_of_life_timestamp_seconds",
+		Help:      "End of life of the Kubernetes version of the cluster as set in the Release",
+	},
+	[]string{metricLabelClusterNamespace, metricLabelClusterName, metricLabelKubernetesVersion},
+)
+
+var metricClusterOperationDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: kcm.CoreKCMName,
+		Name:      "cluster_operation_duration_seconds",
+		Help:      "Time taken by the provisioning or the upgrade of the cluster to complete",
+		// the buckets are dense around the target of 15 minutes
+		Buckets: []float64{60, 180, 300, 420, 600, 720, 900, 1200, 1800, 2700, 3600, 7200},
+	},
+	[]string{metricLabelOperation, metricLabelProvider, metricLabelTemplateName},
+)
+
+var metricWebhookCheckDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: kcm.CoreKCMName,
+		Name:      "webhook_check_duration_seconds",
+		Help:      "Time taken by the checks of the admission webhooks",
+		Buckets:   prometheus.DefBuckets,
+	},
+	[]string{metricLabelWebhook, metricLabelCheck, metricLabelResult},
+)
+
+var metricWebhookLookupDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: kcm.CoreKCMName,
+		Name:      "webhook_lookup_duration_seconds",
+		Help:      "Time taken by the lookups of the objects by the admission webhooks",
+		Buckets:   prometheus.DefBuckets,
+	},
+	[]string{metricLabelKind, metricLabelOperation, metricLabelResult},
+)
+
+var metricBackupLastSuccess = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: kcm.CoreKCMName,
+		Name:      "management_backup_last_success_timestamp_seconds",
+		Help:      "Completion time of the last successful backup of the scheduled ManagementBackup",
+	},
+	[]string{metricLabelBackupName},
+)
+
 func init() {
 	metrics.Registry.MustRegister(
 		metricTemplateUsage,
 		metricTemplateInvalidity,
+		metricClusterAPIReachable,
+		metricClusterAPIProbeLatency,
+		metricClusterAPICertificateExpiry,
+		metricClusterDeploymentReady,
+		metricClusterServiceEndOfLife,
+		metricClusterServiceVulnerabilities,
+		metricClusterKubernetesEndOfLife,
+		metricClusterOperationDuration,
+		metricWebhookCheckDuration,
+		metricWebhookLookupDuration,
+		metricBackupLastSuccess,
 	)
 }
 
@@ -102,3 +235,197 @@ func TrackMetricTemplateInvalidity(ctx context.Context, templateKind, templateNa
 		"value", value,
 	)
 }
+
+func TrackMetricClusterProbe(ctx context.Context, cluster metav1.ObjectMeta, reachable bool, latency time.Duration, certificateExpiry time.Time) { //nolint:revive // false-positive
+	labels := prometheus.Labels{
+		metricLabelClusterNamespace: cluster.Namespace,
+		metricLabelClusterName:      cluster.Name,
+	}
+
+	var value float64
+	if reachable {
+		value = 1
+	}
+	metricClusterAPIReachable.With(labels).Set(value)
+
+	if reachable {
+		metricClusterAPIProbeLatency.With(labels).Set(latency.Seconds())
+	} else {
+		metricClusterAPIProbeLatency.Delete(labels)
+	}
+
+	if !certificateExpiry.IsZero() {
+		metricClusterAPICertificateExpiry.With(labels).Set(float64(certificateExpiry.Unix()))
+	}
+
+	ctrl.LoggerFrom(ctx).V(1).Info("Tracking cluster probe metrics",
+		metricLabelClusterNamespace, cluster.Namespace,
+		metricLabelClusterName, cluster.Name,
+		"reachable", reachable,
+		"latency", latency,
+		"certificate_expiry", certificateExpiry,
+	)
+}
+
+func DeleteMetricClusterProbe(namespace, name string) {
+	labels := prometheus.Labels{
+		metricLabelClusterNamespace: namespace,
+		metricLabelClusterName:      name,
+	}
+
+	metricClusterAPIReachable.Delete(labels)
+	metricClusterAPIProbeLatency.Delete(labels)
+	metricClusterAPICertificateExpiry.Delete(labels)
+}
+
+func TrackMetricClusterDeploymentReady(ctx context.Context, cluster metav1.ObjectMeta, templateName string, ready bool) { //nolint:revive // false-positive
+	var value float64
+	if ready {
+		value = 1
+	}
+
+	// the template label changes on upgrades
+	metricClusterDeploymentReady.DeletePartialMatch(prometheus.Labels{
+		metricLabelClusterNamespace: cluster.Namespace,
+		metricLabelClusterName:      cluster.Name,
+	})
+	metricClusterDeploymentReady.With(prometheus.Labels{
+		metricLabelClusterNamespace: cluster.Namespace,
+		metricLabelClusterName:      cluster.Name,
+		metricLabelTemplateName:     templateName,
+	}).Set(value)
+
+	ctrl.LoggerFrom(ctx).V(1).Info("Tracking ClusterDeployment readiness metric",
+		metricLabelClusterNamespace, cluster.Namespace,
+		metricLabelClusterName, cluster.Name,
+		metricLabelTemplateName, templateName,
+		"value", value,
+	)
+}
+
+func DeleteMetricClusterDeploymentReady(namespace, name string) {
+	metricClusterDeploymentReady.DeletePartialMatch(prometheus.Labels{
+		metricLabelClusterNamespace: namespace,
+		metricLabelClusterName:      name,
+	})
+}
+
+func TrackMetricClusterServiceAdvisories(ctx context.Context, cluster metav1.ObjectMeta, service, templateName string, endOfLife bool, vulnerabilities int) { //nolint:revive // false-positive
+	var value float64
+	if endOfLife {
+		value = 1
+	}
+
+	labels := prometheus.Labels{
+		metricLabelClusterNamespace: cluster.Namespace,
+		metricLabelClusterName:      cluster.Name,
+		metricLabelService:          service,
+		metricLabelTemplateName:     templateName,
+	}
+	metricClusterServiceEndOfLife.With(labels).Set(value)
+	metricClusterServiceVulnerabilities.With(labels).Set(float64(vulnerabilities))
+
+	ctrl.LoggerFrom(ctx).V(1).Info("Tracking cluster service advisories metrics",
+		metricLabelClusterNamespace, cluster.Namespace,
+		metricLabelClusterName, cluster.Name,
+		metricLabelService, service,
+		metricLabelTemplateName, templateName,
+		"end_of_life", endOfLife,
+		"vulnerabilities", vulnerabilities,
+	)
+}
+
+func DeleteMetricClusterServiceAdvisories(namespace, name string) {
+	labels := prometheus.Labels{
+		metricLabelClusterNamespace: namespace,
+		metricLabelClusterName:      name,
+	}
+	metricClusterServiceEndOfLife.DeletePartialMatch(labels)
+	metricClusterServiceVulnerabilities.DeletePartialMatch(labels)
+}
+
+func TrackMetricClusterKubernetesEndOfLife(ctx context.Context, cluster metav1.ObjectMeta, kubernetesVersion string, endOfLife time.Time) { //nolint:revive // false-positive
+	// the version label changes on upgrades
+	DeleteMetricClusterKubernetesEndOfLife(cluster.Namespace, cluster.Name)
+	metricClusterKubernetesEndOfLife.With(prometheus.Labels{
+		metricLabelClusterNamespace:  cluster.Namespace,
+		metricLabelClusterName:       cluster.Name,
+		metricLabelKubernetesVersion: kubernetesVersion,
+	}).Set(float64(endOfLife.Unix()))
+
+	ctrl.LoggerFrom(ctx).V(1).Info("Tracking cluster Kubernetes end of life metric",
+		metricLabelClusterNamespace, cluster.Namespace,
+		metricLabelClusterName, cluster.Name,
+		metricLabelKubernetesVersion, kubernetesVersion,
+		"end_of_life", endOfLife,
+	)
+}
+
+func DeleteMetricClusterKubernetesEndOfLife(namespace, name string) {
+	metricClusterKubernetesEndOfLife.DeletePartialMatch(prometheus.Labels{
+		metricLabelClusterNamespace: namespace,
+		metricLabelClusterName:      name,
+	})
+}
+
+func ObserveMetricClusterOperationDuration(ctx context.Context, operation, provider, templateName string, duration time.Duration) { //nolint:revive // false-positive
+	metricClusterOperationDuration.With(prometheus.Labels{
+		metricLabelOperation:    operation,
+		metricLabelProvider:     provider,
+		metricLabelTemplateName: templateName,
+	}).Observe(duration.Seconds())
+
+	ctrl.LoggerFrom(ctx).V(1).Info("Observing cluster operation duration metric",
+		metricLabelOperation, operation,
+		metricLabelProvider, provider,
+		metricLabelTemplateName, templateName,
+		"duration", duration,
+	)
+}
+
+func ObserveMetricWebhookCheckDuration(ctx context.Context, webhook, check, result string, duration time.Duration) { //nolint:revive // false-positive
+	metricWebhookCheckDuration.With(prometheus.Labels{
+		metricLabelWebhook: webhook,
+		metricLabelCheck:   check,
+		metricLabelResult:  result,
+	}).Observe(duration.Seconds())
+
+	ctrl.LoggerFrom(ctx).V(1).Info("Observing webhook check duration metric",
+		metricLabelWebhook, webhook,
+		metricLabelCheck, check,
+		metricLabelResult, result,
+		"duration", duration,
+	)
+}
+
+func ObserveMetricWebhookLookupDuration(ctx context.Context, kind, operation, result string, duration time.Duration) { //nolint:revive // false-positive
+	metricWebhookLookupDuration.With(prometheus.Labels{
+		metricLabelKind:      kind,
+		metricLabelOperation: operation,
+		metricLabelResult:    result,
+	}).Observe(duration.Seconds())
+
+	ctrl.LoggerFrom(ctx).V(1).Info("Observing webhook lookup duration metric",
+		metricLabelKind, kind,
+		metricLabelOperation, operation,
+		metricLabelResult, result,
+		"duration", duration,
+	)
+}
+
+func TrackMetricBackupLastSuccess(ctx context.Context, backupName string, completedAt time.Time) { //nolint:revive // false-positive
+	metricBackupLastSuccess.With(prometheus.Labels{
+		metricLabelBackupName: backupName,
+	}).Set(float64(completedAt.Unix()))
+
+	ctrl.LoggerFrom(ctx).V(1).Info("Tracking ManagementBackup last success metric",
+		metricLabelBackupName, backupName,
+		"completed_at", completedAt,
+	)
+}
+
+func DeleteMetricBackupLastSuccess(backupName string) {
+	metricBackupLastSuccess.Delete(prometheus.Labels{
+		metricLabelBackupName: backupName,
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"fmt"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// ValuesKey is the key of the monitoring stack values in the KCM chart configuration.
+	ValuesKey = "monitoring"
+	// PrometheusStackValuesKey is the key of the bundled Prometheus stack values in the KCM chart configuration.
+	PrometheusStackValuesKey = "kube-prometheus-stack"
+)
+
+// ApplyValues sets the KCM chart values of the monitoring stack configured in the Management.
+// The values explicitly set in the KCM configuration are preserved unless configured in the Management.
+// Nothing is set if the monitoring is not enabled.
+func ApplyValues(config map[string]any, monitoring *kcm.Monitoring) error {
+	if monitoring == nil {
+		return nil
+	}
+
+	values, err := subValues(config, ValuesKey)
+	if err != nil {
+		return err
+	}
+	values["enabled"] = true
+	if len(monitoring.Labels) > 0 {
+		labels := make(map[string]any, len(monitoring.Labels))
+		for k, v := range monitoring.Labels {
+			labels[k] = v
+		}
+		values["labels"] = labels
+	}
+	// the durations are passed in seconds to be used in the PromQL expressions as is
+	if monitoring.ClusterNotReadyFor.Duration > 0 {
+		values["clusterNotReadyForSeconds"] = int64(monitoring.ClusterNotReadyFor.Seconds())
+	}
+	if monitoring.BackupMaxAge.Duration > 0 {
+		values["backupMaxAgeSeconds"] = int64(monitoring.BackupMaxAge.Seconds())
+	}
+	config[ValuesKey] = values
+
+	prometheusStackValues, err := subValues(config, PrometheusStackValuesKey)
+	if err != nil {
+		return err
+	}
+	prometheusStackValues["enabled"] = !monitoring.ExternalPrometheus
+	config[PrometheusStackValuesKey] = prometheusStackValues
+
+	return nil
+}
+
+func subValues(config map[string]any, key string) (map[string]any, error) {
+	if config[key] == nil {
+		return make(map[string]any), nil
+	}
+
+	values, ok := config[key].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("failed to cast '%s' (type %T) to map[string]any", key, config[key])
+	}
+
+	return values, nil
+}
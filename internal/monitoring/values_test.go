@@ -0,0 +1,99 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestApplyValues(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     map[string]any
+		monitoring *kcm.Monitoring
+		expected   map[string]any
+		err        string
+	}{
+		{
+			name:     "monitoring is not enabled",
+			config:   map[string]any{"admissionWebhook": map[string]any{"enabled": true}},
+			expected: map[string]any{"admissionWebhook": map[string]any{"enabled": true}},
+		},
+		{
+			name: "bundled Prometheus stack",
+			config: map[string]any{
+				ValuesKey:                map[string]any{"grafanaDashboardLabel": "dashboards"},
+				PrometheusStackValuesKey: map[string]any{"grafana": map[string]any{"enabled": false}},
+			},
+			monitoring: &kcm.Monitoring{
+				Labels:             map[string]string{"release": "monitoring"},
+				ClusterNotReadyFor: metav1.Duration{Duration: 15 * time.Minute},
+				BackupMaxAge:       metav1.Duration{Duration: 24 * time.Hour},
+			},
+			expected: map[string]any{
+				ValuesKey: map[string]any{
+					"enabled":                   true,
+					"grafanaDashboardLabel":     "dashboards",
+					"labels":                    map[string]any{"release": "monitoring"},
+					"clusterNotReadyForSeconds": int64(900),
+					"backupMaxAgeSeconds":       int64(86400),
+				},
+				PrometheusStackValuesKey: map[string]any{
+					"enabled": true,
+					"grafana": map[string]any{"enabled": false},
+				},
+			},
+		},
+		{
+			name:       "external Prometheus stack",
+			config:     map[string]any{},
+			monitoring: &kcm.Monitoring{ExternalPrometheus: true},
+			expected: map[string]any{
+				ValuesKey:                map[string]any{"enabled": true},
+				PrometheusStackValuesKey: map[string]any{"enabled": false},
+			},
+		},
+		{
+			name:       "invalid configuration",
+			config:     map[string]any{ValuesKey: "enabled"},
+			monitoring: &kcm.Monitoring{},
+			err:        "failed to cast 'monitoring' (type string) to map[string]any",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ApplyValues(tt.config, tt.monitoring)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.config, tt.expected) {
+				t.Errorf("unexpected values %v, expected %v", tt.config, tt.expected)
+			}
+		})
+	}
+}
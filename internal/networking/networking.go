@@ -0,0 +1,152 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package networking implements the ClusterTemplate values conventions
+// of the networks attached to the machines in addition to the primary network.
+//
+// A ClusterTemplate supports the additional networks if its default values define
+// the "networks" key. The template translates the networks into the provider-specific
+// resources, e.g. the network devices of the vSphere machines or the ports of the OpenStack servers.
+package networking
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// ValuesKey is the key of the template values holding the additional networks.
+const ValuesKey = "networks"
+
+// Values returns the template values of the given networks.
+func Values(networks *kcm.ClusterNetworks) map[string]any {
+	additional := make([]any, 0, len(networks.Additional))
+	for i := range networks.Additional {
+		additional = append(additional, networkValues(&networks.Additional[i]))
+	}
+
+	values := map[string]any{"additional": additional}
+	if networks.Storage != nil {
+		values["storage"] = networkValues(networks.Storage)
+	}
+
+	return values
+}
+
+func networkValues(network *kcm.NodeNetwork) map[string]any {
+	return map[string]any{
+		"name":         network.Name,
+		"subnets":      slices.Clone(network.Subnets),
+		"controlPlane": network.Machines != kcm.NodeNetworkMachinesWorkers,
+		"workers":      network.Machines != kcm.NodeNetworkMachinesControlPlane,
+	}
+}
+
+// TemplateSupported returns true if the ClusterTemplate with the given default values
+// supports attaching the additional networks to the machines.
+func TemplateSupported(config *apiextensionsv1.JSON) (bool, error) {
+	values, err := parseValues(config)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse template config: %w", err)
+	}
+
+	_, found, err := unstructured.NestedMap(values, ValuesKey)
+	return found && err == nil, nil
+}
+
+// Validate validates the names of the given networks are unique and the subnets of the networks
+// overlap neither each other nor the pods and the services CIDRs of the cluster in the given configuration.
+func Validate(networks *kcm.ClusterNetworks, config *apiextensionsv1.JSON) error {
+	values, err := parseValues(config)
+	if err != nil {
+		return fmt.Errorf("failed to parse ClusterDeployment configuration: %w", err)
+	}
+
+	all := slices.Clone(networks.Additional)
+	if networks.Storage != nil {
+		all = append(all, *networks.Storage)
+	}
+
+	type pool struct {
+		owner   string
+		prefix  netip.Prefix
+		cluster bool
+	}
+	var (
+		pools []pool
+		names = make(map[string]struct{}, len(all))
+		errs  error
+	)
+	for _, network := range all {
+		if _, ok := names[network.Name]; ok {
+			errs = errors.Join(errs, fmt.Errorf("network %s is defined more than once", network.Name))
+		}
+		names[network.Name] = struct{}{}
+
+		for _, subnet := range network.Subnets {
+			prefix, err := netip.ParsePrefix(subnet)
+			if err != nil {
+				errs = errors.Join(errs, fmt.Errorf("invalid subnet %s of network %s: %w", subnet, network.Name, err))
+				continue
+			}
+			pools = append(pools, pool{owner: "network " + network.Name, prefix: prefix.Masked()})
+		}
+	}
+
+	for _, kind := range []string{"pods", "services"} {
+		cidrs, _, _ := unstructured.NestedStringSlice(values, "clusterNetwork", kind, "cidrBlocks")
+		for _, cidr := range cidrs {
+			prefix, err := netip.ParsePrefix(cidr)
+			if err != nil {
+				continue
+			}
+			pools = append(pools, pool{owner: "cluster " + kind, prefix: prefix.Masked(), cluster: true})
+		}
+	}
+
+	for i := range pools {
+		for j := i + 1; j < len(pools); j++ {
+			// the overlaps of the cluster CIDRs are not caused by the networks
+			if pools[i].cluster && pools[j].cluster {
+				continue
+			}
+			if pools[i].prefix.Overlaps(pools[j].prefix) {
+				errs = errors.Join(errs, fmt.Errorf("subnet %s of %s overlaps with subnet %s of %s",
+					pools[i].prefix, pools[i].owner, pools[j].prefix, pools[j].owner))
+			}
+		}
+	}
+
+	return errs
+}
+
+func parseValues(config *apiextensionsv1.JSON) (map[string]any, error) {
+	values := make(map[string]any)
+	if config == nil || len(config.Raw) == 0 {
+		return values, nil
+	}
+
+	if err := json.Unmarshal(config.Raw, &values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
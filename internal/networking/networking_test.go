@@ -0,0 +1,126 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestValues(t *testing.T) {
+	values := Values(&kcm.ClusterNetworks{
+		Additional: []kcm.NodeNetwork{
+			{Name: "backend", Subnets: []string{"10.10.0.0/24"}, Machines: kcm.NodeNetworkMachinesWorkers},
+			{Name: "management", Machines: kcm.NodeNetworkMachinesControlPlane},
+		},
+		Storage: &kcm.NodeNetwork{Name: "storage", Subnets: []string{"10.20.0.0/24"}},
+	})
+
+	require.Equal(t, map[string]any{
+		"additional": []any{
+			map[string]any{"name": "backend", "subnets": []string{"10.10.0.0/24"}, "controlPlane": false, "workers": true},
+			map[string]any{"name": "management", "subnets": []string(nil), "controlPlane": true, "workers": false},
+		},
+		"storage": map[string]any{"name": "storage", "subnets": []string{"10.20.0.0/24"}, "controlPlane": true, "workers": true},
+	}, values)
+}
+
+func TestTemplateSupported(t *testing.T) {
+	supported, err := TemplateSupported(&apiextensionsv1.JSON{Raw: []byte(`{"networks":{"additional":[]}}`)})
+	require.NoError(t, err)
+	require.True(t, supported)
+
+	supported, err = TemplateSupported(&apiextensionsv1.JSON{Raw: []byte(`{"controlPlaneNumber":3}`)})
+	require.NoError(t, err)
+	require.False(t, supported)
+
+	supported, err = TemplateSupported(nil)
+	require.NoError(t, err)
+	require.False(t, supported)
+}
+
+func TestValidate(t *testing.T) {
+	config := &apiextensionsv1.JSON{Raw: []byte(`{"clusterNetwork":{
+		"pods":{"cidrBlocks":["10.244.0.0/16"]},
+		"services":{"cidrBlocks":["10.96.0.0/12"]}
+	}}`)}
+
+	for _, tc := range []struct {
+		name     string
+		networks *kcm.ClusterNetworks
+		config   *apiextensionsv1.JSON
+		err      string
+	}{
+		{
+			name: "disjoint subnets",
+			networks: &kcm.ClusterNetworks{
+				Additional: []kcm.NodeNetwork{{Name: "backend", Subnets: []string{"10.10.0.0/24", "10.11.0.0/24"}}},
+				Storage:    &kcm.NodeNetwork{Name: "storage", Subnets: []string{"10.20.0.0/24"}},
+			},
+			config: config,
+		},
+		{
+			name: "networks without subnets",
+			networks: &kcm.ClusterNetworks{
+				Additional: []kcm.NodeNetwork{{Name: "backend"}},
+				Storage:    &kcm.NodeNetwork{Name: "storage"},
+			},
+		},
+		{
+			name: "duplicate network",
+			networks: &kcm.ClusterNetworks{
+				Additional: []kcm.NodeNetwork{{Name: "storage"}},
+				Storage:    &kcm.NodeNetwork{Name: "storage"},
+			},
+			err: "network storage is defined more than once",
+		},
+		{
+			name: "invalid subnet",
+			networks: &kcm.ClusterNetworks{
+				Storage: &kcm.NodeNetwork{Name: "storage", Subnets: []string{"10.20.0.0"}},
+			},
+			err: "invalid subnet 10.20.0.0 of network storage",
+		},
+		{
+			name: "overlapping subnets of networks",
+			networks: &kcm.ClusterNetworks{
+				Additional: []kcm.NodeNetwork{{Name: "backend", Subnets: []string{"10.10.0.0/16"}}},
+				Storage:    &kcm.NodeNetwork{Name: "storage", Subnets: []string{"10.10.20.0/24"}},
+			},
+			err: "subnet 10.10.0.0/16 of network backend overlaps with subnet 10.10.20.0/24 of network storage",
+		},
+		{
+			name: "subnet overlapping services CIDR",
+			networks: &kcm.ClusterNetworks{
+				Additional: []kcm.NodeNetwork{{Name: "backend", Subnets: []string{"10.100.0.1/24"}}},
+			},
+			config: config,
+			err:    "subnet 10.100.0.0/24 of network backend overlaps with subnet 10.96.0.0/12 of cluster services",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.networks, tc.config)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
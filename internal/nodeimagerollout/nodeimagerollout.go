@@ -0,0 +1,277 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nodeimagerollout rolls out the new images of the OSImage catalogs to the machines
+// of the selected ClusterDeployments cluster by cluster.
+//
+// The ClusterDeployments keep the images the references to the OSImages have been resolved to
+// until a NodeImageRollout approves the rollout of the newer images to the cluster by moving
+// the cluster to the Updating phase. The cluster is Updated once its HelmRelease is upgraded,
+// its machines are replaced and its readiness gates pass.
+package nodeimagerollout
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	hcv2 "github.com/fluxcd/helm-controller/api/v2"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/lifecyclehooks"
+	"github.com/K0rdent/kcm/internal/osimage"
+)
+
+// pollInterval is the interval the progress of the rollouts to the clusters is checked at.
+const pollInterval = time.Minute
+
+// Approved returns the names of the OSImages the newer images of which are approved
+// to be rolled out to the given ClusterDeployment.
+func Approved(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) ([]string, error) {
+	if paused(cd) {
+		return nil, nil
+	}
+
+	rollouts := new(kcm.NodeImageRolloutList)
+	if err := cl.List(ctx, rollouts); err != nil {
+		return nil, fmt.Errorf("failed to list NodeImageRollouts: %w", err)
+	}
+
+	key := client.ObjectKeyFromObject(cd).String()
+
+	var approved []string
+	for _, rollout := range rollouts.Items {
+		for _, status := range rollout.Status.Clusters {
+			if status.ClusterDeployment == key && status.Phase == kcm.NodeImageRolloutPhaseUpdating {
+				approved = append(approved, rollout.Spec.OSImage)
+			}
+		}
+	}
+
+	return approved, nil
+}
+
+// Reconcile updates the status of the given NodeImageRollout with the phases of the rollouts to the selected
+// ClusterDeployments using the OSImage and starts the rollouts to the pending clusters as permitted by the
+// maintenance windows and the maximum number of the concurrent rollouts.
+// Returns the duration after which the rollout should be reconciled again, zero if not needed.
+func Reconcile(ctx context.Context, cl client.Client, rollout *kcm.NodeImageRollout, now time.Time) (time.Duration, error) {
+	catalog := new(kcm.OSImage)
+	if err := cl.Get(ctx, client.ObjectKey{Name: rollout.Spec.OSImage}, catalog); err != nil {
+		return 0, fmt.Errorf("failed to get OSImage %s: %w", rollout.Spec.OSImage, err)
+	}
+
+	clusterDeployments, err := selectClusterDeployments(ctx, cl, rollout)
+	if err != nil {
+		return 0, err
+	}
+
+	open, nextOpening, err := lifecyclehooks.MaintenanceWindowOpen(rollout.Spec.MaintenanceWindows, now)
+	if err != nil {
+		return 0, err
+	}
+
+	previous := make(map[string]kcm.NodeImageRolloutClusterStatus, len(rollout.Status.Clusters))
+	for _, status := range rollout.Status.Clusters {
+		previous[status.ClusterDeployment] = status
+	}
+
+	clusters := make([]kcm.NodeImageRolloutClusterStatus, 0, len(clusterDeployments))
+	updating := 0
+	for _, cd := range clusterDeployments {
+		status := kcm.NodeImageRolloutClusterStatus{ClusterDeployment: client.ObjectKeyFromObject(&cd).String()}
+		prev, wasTracked := previous[status.ClusterDeployment]
+		outdated := slices.ContainsFunc(cd.Status.OSImages, func(image kcm.ResolvedOSImage) bool {
+			return image.OSImage == catalog.Name && osimage.Outdated(catalog.Status.Images, image)
+		})
+
+		switch {
+		case paused(&cd):
+			status.Phase = kcm.NodeImageRolloutPhasePaused
+			status.Message = fmt.Sprintf("Paused with the %s annotation", kcm.NodeImageRolloutPausedAnnotation)
+		case wasTracked && prev.Phase == kcm.NodeImageRolloutPhaseUpdating:
+			status.Phase = kcm.NodeImageRolloutPhaseUpdating
+			status.Message = "Waiting for the ClusterDeployment to be deployed with the new images"
+			if !outdated {
+				message, err := progress(ctx, cl, &cd)
+				if err != nil {
+					return 0, err
+				}
+				if message == "" {
+					status.Phase = kcm.NodeImageRolloutPhaseUpdated
+				} else {
+					status.Message = message
+				}
+			}
+		case outdated:
+			status.Phase = kcm.NodeImageRolloutPhasePending
+		default:
+			status.Phase = kcm.NodeImageRolloutPhaseUpdated
+		}
+
+		if status.Phase == kcm.NodeImageRolloutPhaseUpdating {
+			updating++
+		}
+		status.LastTransitionTime = metav1.Time{Time: now}
+		if wasTracked && prev.Phase == status.Phase {
+			status.LastTransitionTime = prev.LastTransitionTime
+		}
+		clusters = append(clusters, status)
+	}
+
+	maxConcurrent := max(int(rollout.Spec.MaxConcurrentClusters), 1)
+	for i := range clusters {
+		status := &clusters[i]
+		if status.Phase != kcm.NodeImageRolloutPhasePending {
+			continue
+		}
+
+		switch {
+		case rollout.Spec.Paused:
+			status.Message = "Waiting for the rollout to be resumed"
+		case !open:
+			status.Message = "Waiting for the maintenance window opening at " + nextOpening.UTC().Format(time.RFC3339)
+		case updating >= maxConcurrent:
+			status.Message = "Waiting for the rollouts to the other clusters to complete"
+		default:
+			status.Phase = kcm.NodeImageRolloutPhaseUpdating
+			status.Message = "Rolling out the new images"
+			status.LastTransitionTime = metav1.Time{Time: now}
+			updating++
+		}
+	}
+
+	rollout.Status.Clusters = clusters
+	rollout.Status.Total = int32(len(clusters))
+	rollout.Status.Updated = 0
+	pending := false
+	for _, status := range clusters {
+		switch status.Phase {
+		case kcm.NodeImageRolloutPhaseUpdated:
+			rollout.Status.Updated++
+		case kcm.NodeImageRolloutPhasePending:
+			pending = true
+		}
+	}
+
+	switch {
+	case updating > 0:
+		return pollInterval, nil
+	case pending && !open && !rollout.Spec.Paused:
+		return nextOpening.Sub(now), nil
+	}
+
+	return 0, nil
+}
+
+// selectClusterDeployments returns the ClusterDeployments selected by the given NodeImageRollout
+// using its OSImage sorted by the namespaced name.
+func selectClusterDeployments(ctx context.Context, cl client.Client, rollout *kcm.NodeImageRollout) ([]kcm.ClusterDeployment, error) {
+	namespaceSelector, err := metav1.LabelSelectorAsSelector(&rollout.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse namespace selector: %w", err)
+	}
+	clusterSelector, err := metav1.LabelSelectorAsSelector(&rollout.Spec.ClusterSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cluster selector: %w", err)
+	}
+
+	namespaces := new(corev1.NamespaceList)
+	if err := cl.List(ctx, namespaces, client.MatchingLabelsSelector{Selector: namespaceSelector}); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	selectedNamespaces := make(map[string]bool, len(namespaces.Items))
+	for _, ns := range namespaces.Items {
+		selectedNamespaces[ns.Name] = true
+	}
+
+	clusterDeployments := new(kcm.ClusterDeploymentList)
+	if err := cl.List(ctx, clusterDeployments, client.MatchingLabelsSelector{Selector: clusterSelector}); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterDeployments: %w", err)
+	}
+
+	var selected []kcm.ClusterDeployment
+	for _, cd := range clusterDeployments.Items {
+		if !selectedNamespaces[cd.Namespace] || !cd.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if slices.ContainsFunc(cd.Status.OSImages, func(image kcm.ResolvedOSImage) bool { return image.OSImage == rollout.Spec.OSImage }) {
+			selected = append(selected, cd)
+		}
+	}
+	slices.SortFunc(selected, func(a, b kcm.ClusterDeployment) int {
+		return strings.Compare(client.ObjectKeyFromObject(&a).String(), client.ObjectKeyFromObject(&b).String())
+	})
+
+	return selected, nil
+}
+
+// progress returns the description of the progress of the rollout of the new images to the machines
+// of the given ClusterDeployment or an empty string if the HelmRelease of the cluster is upgraded,
+// the machines are replaced and the readiness gates of the cluster have passed.
+func progress(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) (string, error) {
+	hr := new(hcv2.HelmRelease)
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(cd), hr); err != nil {
+		return "", fmt.Errorf("failed to get HelmRelease %s: %w", client.ObjectKeyFromObject(cd), err)
+	}
+	if hr.Status.ObservedGeneration != hr.Generation || !apimeta.IsStatusConditionTrue(hr.Status.Conditions, fluxmeta.ReadyCondition) {
+		return "Waiting for the HelmRelease to be upgraded", nil
+	}
+
+	selector := client.MatchingLabels{clusterv1.ClusterNameLabel: cd.Name}
+
+	machineDeployments := new(clusterv1.MachineDeploymentList)
+	if err := cl.List(ctx, machineDeployments, client.InNamespace(cd.Namespace), selector); err != nil {
+		return "", fmt.Errorf("failed to list MachineDeployments: %w", err)
+	}
+	for _, md := range machineDeployments.Items {
+		replicas := int32(0)
+		if md.Spec.Replicas != nil {
+			replicas = *md.Spec.Replicas
+		}
+		if md.Status.ObservedGeneration != md.Generation || md.Status.UpdatedReplicas != replicas ||
+			md.Status.Replicas != replicas || md.Status.ReadyReplicas != replicas {
+			return fmt.Sprintf("Waiting for the machines of the MachineDeployment %s to be replaced", md.Name), nil
+		}
+	}
+
+	machines := new(clusterv1.MachineList)
+	if err := cl.List(ctx, machines, client.InNamespace(cd.Namespace), selector); err != nil {
+		return "", fmt.Errorf("failed to list Machines: %w", err)
+	}
+	for _, machine := range machines.Items {
+		if !machine.DeletionTimestamp.IsZero() || machine.Status.Phase != string(clusterv1.MachinePhaseRunning) {
+			return fmt.Sprintf("Waiting for the Machine %s to be replaced", machine.Name), nil
+		}
+	}
+
+	if cd.Spec.ReadinessGates != nil && !apimeta.IsStatusConditionTrue(cd.Status.Conditions, kcm.ReadinessGatesPassedCondition) {
+		return "Waiting for the readiness gates of the cluster to pass", nil
+	}
+
+	return "", nil
+}
+
+// paused returns true if the rollouts to the given ClusterDeployment are paused.
+func paused(cd *kcm.ClusterDeployment) bool {
+	return cd.Annotations[kcm.NodeImageRolloutPausedAnnotation] == "true"
+}
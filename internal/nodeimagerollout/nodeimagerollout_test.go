@@ -0,0 +1,229 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodeimagerollout
+
+import (
+	"testing"
+	"time"
+
+	hcv2 "github.com/fluxcd/helm-controller/api/v2"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{kcm.AddToScheme, corev1.AddToScheme, clusterv1.AddToScheme, hcv2.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatalf("failed to build scheme: %v", err)
+		}
+	}
+	return scheme
+}
+
+func newClusterDeployment(name, imageID string, annotations map[string]string) *kcm.ClusterDeployment {
+	return &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", Annotations: annotations},
+		Status: kcm.ClusterDeploymentStatus{
+			OSImages: []kcm.ResolvedOSImage{{Path: "worker.amiID", OSImage: "ubuntu", KubernetesVersion: "v1.32.2", ID: imageID}},
+		},
+	}
+}
+
+func phases(rollout *kcm.NodeImageRollout) map[string]string {
+	result := make(map[string]string, len(rollout.Status.Clusters))
+	for _, status := range rollout.Status.Clusters {
+		result[status.ClusterDeployment] = status.Phase
+	}
+	return result
+}
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+
+	catalog := &kcm.OSImage{
+		ObjectMeta: metav1.ObjectMeta{Name: "ubuntu"},
+		Status:     kcm.OSImageStatus{Images: []kcm.OSImageEntry{{ID: "ami-new"}}},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+
+	for _, tc := range []struct {
+		name             string
+		spec             kcm.NodeImageRolloutSpec
+		expected         map[string]string
+		expectedUpdated  int32
+		expectedRequeue  time.Duration
+		expectedApproved []string
+	}{
+		{
+			name: "one cluster at a time",
+			spec: kcm.NodeImageRolloutSpec{OSImage: "ubuntu", MaxConcurrentClusters: 1},
+			expected: map[string]string{
+				"team-a/a": kcm.NodeImageRolloutPhaseUpdating,
+				"team-a/b": kcm.NodeImageRolloutPhasePending,
+				"team-a/c": kcm.NodeImageRolloutPhaseUpdated,
+				"team-a/d": kcm.NodeImageRolloutPhasePaused,
+			},
+			expectedUpdated:  1,
+			expectedRequeue:  pollInterval,
+			expectedApproved: []string{"ubuntu"},
+		},
+		{
+			name: "all clusters at once",
+			spec: kcm.NodeImageRolloutSpec{OSImage: "ubuntu", MaxConcurrentClusters: 3},
+			expected: map[string]string{
+				"team-a/a": kcm.NodeImageRolloutPhaseUpdating,
+				"team-a/b": kcm.NodeImageRolloutPhaseUpdating,
+				"team-a/c": kcm.NodeImageRolloutPhaseUpdated,
+				"team-a/d": kcm.NodeImageRolloutPhasePaused,
+			},
+			expectedUpdated:  1,
+			expectedRequeue:  pollInterval,
+			expectedApproved: []string{"ubuntu"},
+		},
+		{
+			name: "paused",
+			spec: kcm.NodeImageRolloutSpec{OSImage: "ubuntu", Paused: true},
+			expected: map[string]string{
+				"team-a/a": kcm.NodeImageRolloutPhasePending,
+				"team-a/b": kcm.NodeImageRolloutPhasePending,
+				"team-a/c": kcm.NodeImageRolloutPhaseUpdated,
+				"team-a/d": kcm.NodeImageRolloutPhasePaused,
+			},
+			expectedUpdated: 1,
+		},
+		{
+			name: "maintenance window closed",
+			spec: kcm.NodeImageRolloutSpec{
+				OSImage:            "ubuntu",
+				MaintenanceWindows: []kcm.MaintenanceWindow{{Schedule: "0 22 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}}},
+			},
+			expected: map[string]string{
+				"team-a/a": kcm.NodeImageRolloutPhasePending,
+				"team-a/b": kcm.NodeImageRolloutPhasePending,
+				"team-a/c": kcm.NodeImageRolloutPhaseUpdated,
+				"team-a/d": kcm.NodeImageRolloutPhasePaused,
+			},
+			expectedUpdated: 1,
+			expectedRequeue: 10 * time.Hour,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rollout := &kcm.NodeImageRollout{ObjectMeta: metav1.ObjectMeta{Name: "ubuntu"}, Spec: tc.spec}
+			cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(
+				catalog, namespace, rollout,
+				newClusterDeployment("a", "ami-old", nil),
+				newClusterDeployment("b", "ami-old", nil),
+				newClusterDeployment("c", "ami-new", nil),
+				newClusterDeployment("d", "ami-old", map[string]string{kcm.NodeImageRolloutPausedAnnotation: "true"}),
+			).WithStatusSubresource(rollout).Build()
+
+			requeue, err := Reconcile(t.Context(), cl, rollout, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if requeue != tc.expectedRequeue {
+				t.Errorf("expected requeue after %s, got %s", tc.expectedRequeue, requeue)
+			}
+			got := phases(rollout)
+			for cluster, phase := range tc.expected {
+				if got[cluster] != phase {
+					t.Errorf("expected cluster %s to be %s, got %s", cluster, phase, got[cluster])
+				}
+			}
+			if rollout.Status.Total != 4 || rollout.Status.Updated != tc.expectedUpdated {
+				t.Errorf("expected %d of 4 clusters updated, got %d of %d", tc.expectedUpdated, rollout.Status.Updated, rollout.Status.Total)
+			}
+
+			if err := cl.Status().Update(t.Context(), rollout); err != nil {
+				t.Fatalf("failed to update NodeImageRollout status: %v", err)
+			}
+			approved, err := Approved(t.Context(), cl, newClusterDeployment("a", "ami-old", nil))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(approved) != len(tc.expectedApproved) || (len(approved) > 0 && approved[0] != tc.expectedApproved[0]) {
+				t.Errorf("expected approved OSImages %v, got %v", tc.expectedApproved, approved)
+			}
+		})
+	}
+}
+
+func TestReconcileProgress(t *testing.T) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+
+	catalog := &kcm.OSImage{
+		ObjectMeta: metav1.ObjectMeta{Name: "ubuntu"},
+		Status:     kcm.OSImageStatus{Images: []kcm.OSImageEntry{{ID: "ami-new"}}},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+	cd := newClusterDeployment("a", "ami-new", nil)
+	hr := &hcv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "team-a", Generation: 2},
+		Status: hcv2.HelmReleaseStatus{
+			ObservedGeneration: 2,
+			Conditions:         []metav1.Condition{{Type: fluxmeta.ReadyCondition, Status: metav1.ConditionTrue}},
+		},
+	}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-worker-0", Namespace: "team-a", Labels: map[string]string{clusterv1.ClusterNameLabel: "a"}},
+		Status:     clusterv1.MachineStatus{Phase: string(clusterv1.MachinePhaseProvisioning)},
+	}
+	rollout := &kcm.NodeImageRollout{
+		ObjectMeta: metav1.ObjectMeta{Name: "ubuntu"},
+		Spec:       kcm.NodeImageRolloutSpec{OSImage: "ubuntu", MaxConcurrentClusters: 1},
+		Status: kcm.NodeImageRolloutStatus{Clusters: []kcm.NodeImageRolloutClusterStatus{{
+			ClusterDeployment:  "team-a/a",
+			Phase:              kcm.NodeImageRolloutPhaseUpdating,
+			LastTransitionTime: metav1.Time{Time: now.Add(-time.Hour)},
+		}}},
+	}
+	cl := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(catalog, namespace, cd, hr, machine).Build()
+
+	if _, err := Reconcile(t.Context(), cl, rollout, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	status := rollout.Status.Clusters[0]
+	if status.Phase != kcm.NodeImageRolloutPhaseUpdating || status.Message != "Waiting for the Machine a-worker-0 to be replaced" {
+		t.Errorf("expected the rollout to wait for the machine, got %s: %s", status.Phase, status.Message)
+	}
+	if !status.LastTransitionTime.Equal(&metav1.Time{Time: now.Add(-time.Hour)}) {
+		t.Errorf("expected the transition time to be kept, got %s", status.LastTransitionTime)
+	}
+
+	machine.Status.Phase = string(clusterv1.MachinePhaseRunning)
+	if err := cl.Update(t.Context(), machine); err != nil {
+		t.Fatalf("failed to update Machine: %v", err)
+	}
+
+	requeue, err := Reconcile(t.Context(), cl, rollout, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requeue != 0 {
+		t.Errorf("expected no requeue, got %s", requeue)
+	}
+	if phase := rollout.Status.Clusters[0].Phase; phase != kcm.NodeImageRolloutPhaseUpdated || rollout.Status.Updated != 1 {
+		t.Errorf("expected the cluster to be updated, got %s", phase)
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package observability installs the metrics and logs agents shipping to the central store
+// on the clusters as a system service of the ClusterDeployments.
+//
+// The chart of the ServiceTemplate receives the following values in addition to the configured ones:
+//
+//	cluster:
+//	  name: <ClusterDeployment name>
+//	  namespace: <ClusterDeployment namespace>
+//	externalLabels:
+//	  cluster: <ClusterDeployment name>
+//	  cluster_namespace: <ClusterDeployment namespace>
+//	  <additional labels>
+//	metrics:
+//	  endpoint: <metrics endpoint>
+//	logs:
+//	  endpoint: <logs endpoint>
+//	credentials:
+//	  <data of the credentials Secret>
+package observability
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// ServiceName is the name of the service installing the metrics and logs agents.
+	ServiceName = "kcm-observability-agents"
+
+	defaultNamespace = "kcm-observability"
+
+	valuesSecretSuffix = "-observability-values"
+	// valuesKey is the key of the values Secret, the default key of the Flux HelmRelease values references.
+	valuesKey = "values.yaml"
+)
+
+// Config is the effective configuration of the agents of a cluster.
+type Config struct {
+	kcm.ClusterObservability
+
+	// CredentialsNamespace is the namespace of the credentials Secret.
+	CredentialsNamespace string
+}
+
+// EffectiveConfig returns the configuration of the agents of the given ClusterDeployment
+// merged from the Management and the ClusterDeployment settings, the credentials Secret
+// of the Management is located in the given system namespace.
+// Nil is returned if the agents are not enabled for the cluster.
+func EffectiveConfig(mgmt *kcm.Observability, systemNamespace string, cd *kcm.ClusterDeployment) *Config {
+	override := cd.Spec.Observability
+	if override != nil && override.Disabled {
+		return nil
+	}
+
+	cfg := new(Config)
+	if mgmt != nil {
+		cfg.ServiceTemplate = mgmt.ServiceTemplate
+		cfg.Namespace = mgmt.Namespace
+		cfg.MetricsEndpoint = mgmt.MetricsEndpoint
+		cfg.LogsEndpoint = mgmt.LogsEndpoint
+		cfg.CredentialsSecretName = mgmt.CredentialsSecretName
+		cfg.CredentialsNamespace = systemNamespace
+		cfg.Labels = maps.Clone(mgmt.Labels)
+		cfg.Values = mgmt.Values
+	}
+
+	if override != nil {
+		setIfNotEmpty(&cfg.ServiceTemplate, override.ServiceTemplate)
+		setIfNotEmpty(&cfg.Namespace, override.Namespace)
+		setIfNotEmpty(&cfg.MetricsEndpoint, override.MetricsEndpoint)
+		setIfNotEmpty(&cfg.LogsEndpoint, override.LogsEndpoint)
+		setIfNotEmpty(&cfg.Values, override.Values)
+		if override.CredentialsSecretName != "" {
+			cfg.CredentialsSecretName = override.CredentialsSecretName
+			cfg.CredentialsNamespace = cd.Namespace
+		}
+		if len(override.Labels) > 0 {
+			if cfg.Labels == nil {
+				cfg.Labels = make(map[string]string, len(override.Labels))
+			}
+			maps.Copy(cfg.Labels, override.Labels)
+		}
+	}
+
+	if cfg.ServiceTemplate == "" {
+		return nil
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = defaultNamespace
+	}
+
+	return cfg
+}
+
+// ValuesSecretName returns the name of the Secret holding the values of the agents of the given ClusterDeployment.
+func ValuesSecretName(cd *kcm.ClusterDeployment) string {
+	return cd.Name + valuesSecretSuffix
+}
+
+// Values returns the values of the agents of the given ClusterDeployment
+// with the given credentials of the central store.
+func Values(cd *kcm.ClusterDeployment, cfg *Config, credentials map[string][]byte) ([]byte, error) {
+	externalLabels := make(map[string]string, len(cfg.Labels)+2)
+	maps.Copy(externalLabels, cfg.Labels)
+	externalLabels["cluster"] = cd.Name
+	externalLabels["cluster_namespace"] = cd.Namespace
+
+	values := map[string]any{
+		"cluster": map[string]string{
+			"name":      cd.Name,
+			"namespace": cd.Namespace,
+		},
+		"externalLabels": externalLabels,
+	}
+	if cfg.MetricsEndpoint != "" {
+		values["metrics"] = map[string]string{"endpoint": cfg.MetricsEndpoint}
+	}
+	if cfg.LogsEndpoint != "" {
+		values["logs"] = map[string]string{"endpoint": cfg.LogsEndpoint}
+	}
+	if len(credentials) > 0 {
+		creds := make(map[string]string, len(credentials))
+		for k, v := range credentials {
+			creds[k] = string(v)
+		}
+		values["credentials"] = creds
+	}
+
+	return yaml.Marshal(values)
+}
+
+// Reconcile creates or updates the Secret holding the values of the agents of the given ClusterDeployment
+// and returns the service installing the agents with the given configuration.
+// The values are kept in a Secret since they carry the credentials of the central store.
+func Reconcile(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment, cfg *Config) (kcm.Service, error) {
+	var credentials map[string][]byte
+	if cfg.CredentialsSecretName != "" {
+		creds := new(corev1.Secret)
+		key := client.ObjectKey{Namespace: cfg.CredentialsNamespace, Name: cfg.CredentialsSecretName}
+		if err := cl.Get(ctx, key, creds); err != nil {
+			return kcm.Service{}, fmt.Errorf("failed to get observability credentials Secret %s: %w", key, err)
+		}
+		credentials = creds.Data
+	}
+
+	values, err := Values(cd, cfg, credentials)
+	if err != nil {
+		return kcm.Service{}, fmt.Errorf("failed to marshal observability values: %w", err)
+	}
+
+	secret := &corev1.Secret{}
+	secret.Name = ValuesSecretName(cd)
+	secret.Namespace = cd.Namespace
+
+	if _, err := ctrl.CreateOrUpdate(ctx, cl, secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = make(map[string]string)
+		}
+		secret.Labels[kcm.KCMManagedLabelKey] = kcm.KCMManagedLabelValue
+		secret.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: kcm.GroupVersion.String(),
+			Kind:       kcm.ClusterDeploymentKind,
+			Name:       cd.Name,
+			UID:        cd.UID,
+		}}
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = map[string][]byte{valuesKey: values}
+		return nil
+	}); err != nil {
+		return kcm.Service{}, fmt.Errorf("failed to reconcile observability values Secret: %w", err)
+	}
+
+	return kcm.Service{
+		Name:       ServiceName,
+		Namespace:  cfg.Namespace,
+		Template:   cfg.ServiceTemplate,
+		Values:     cfg.Values,
+		ValuesFrom: []sveltosv1beta1.ValueFrom{{Kind: "Secret", Name: secret.Name}},
+	}, nil
+}
+
+// Delete deletes the Secret holding the values of the agents of the given ClusterDeployment.
+func Delete(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) error {
+	secret := &corev1.Secret{}
+	secret.Name = ValuesSecretName(cd)
+	secret.Namespace = cd.Namespace
+
+	if err := cl.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete observability values Secret: %w", err)
+	}
+
+	return nil
+}
+
+func setIfNotEmpty(dst *string, src string) {
+	if src != "" {
+		*dst = src
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observability
+
+import (
+	"reflect"
+	"testing"
+
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newClusterDeployment(obs *kcm.ClusterObservability) *kcm.ClusterDeployment {
+	return &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a", UID: "cd-uid"},
+		Spec:       kcm.ClusterDeploymentSpec{Observability: obs},
+	}
+}
+
+func TestEffectiveConfig(t *testing.T) {
+	mgmt := &kcm.Observability{
+		ServiceTemplate:       "k8s-monitoring-2-0-0",
+		Namespace:             "monitoring",
+		MetricsEndpoint:       "https://metrics.example.com/api/v1/push",
+		LogsEndpoint:          "https://logs.example.com/loki/api/v1/push",
+		CredentialsSecretName: "fleet-credentials",
+		Labels:                map[string]string{"region": "eu", "env": "dev"},
+		Values:                "replicas: 1",
+	}
+
+	for _, tc := range []struct {
+		name     string
+		mgmt     *kcm.Observability
+		override *kcm.ClusterObservability
+		expected *Config
+	}{
+		{
+			name: "disabled everywhere",
+		},
+		{
+			name: "enabled in the Management",
+			mgmt: mgmt,
+			expected: &Config{
+				ClusterObservability: kcm.ClusterObservability{
+					ServiceTemplate:       mgmt.ServiceTemplate,
+					Namespace:             mgmt.Namespace,
+					MetricsEndpoint:       mgmt.MetricsEndpoint,
+					LogsEndpoint:          mgmt.LogsEndpoint,
+					CredentialsSecretName: mgmt.CredentialsSecretName,
+					Labels:                mgmt.Labels,
+					Values:                mgmt.Values,
+				},
+				CredentialsNamespace: "kcm-system",
+			},
+		},
+		{
+			name:     "opted out in the ClusterDeployment",
+			mgmt:     mgmt,
+			override: &kcm.ClusterObservability{Disabled: true},
+		},
+		{
+			name: "overridden in the ClusterDeployment",
+			mgmt: mgmt,
+			override: &kcm.ClusterObservability{
+				MetricsEndpoint:       "https://team-a.example.com/api/v1/push",
+				CredentialsSecretName: "team-a-credentials",
+				Labels:                map[string]string{"env": "prod", "team": "a"},
+			},
+			expected: &Config{
+				ClusterObservability: kcm.ClusterObservability{
+					ServiceTemplate:       mgmt.ServiceTemplate,
+					Namespace:             mgmt.Namespace,
+					MetricsEndpoint:       "https://team-a.example.com/api/v1/push",
+					LogsEndpoint:          mgmt.LogsEndpoint,
+					CredentialsSecretName: "team-a-credentials",
+					Labels:                map[string]string{"region": "eu", "env": "prod", "team": "a"},
+					Values:                mgmt.Values,
+				},
+				CredentialsNamespace: "team-a",
+			},
+		},
+		{
+			name: "enabled in the ClusterDeployment only",
+			override: &kcm.ClusterObservability{
+				ServiceTemplate: "k8s-monitoring-2-0-0",
+				LogsEndpoint:    "https://logs.example.com/loki/api/v1/push",
+			},
+			expected: &Config{
+				ClusterObservability: kcm.ClusterObservability{
+					ServiceTemplate: "k8s-monitoring-2-0-0",
+					Namespace:       defaultNamespace,
+					LogsEndpoint:    "https://logs.example.com/loki/api/v1/push",
+				},
+			},
+		},
+		{
+			name:     "no ServiceTemplate",
+			override: &kcm.ClusterObservability{LogsEndpoint: "https://logs.example.com/loki/api/v1/push"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := EffectiveConfig(tc.mgmt, "kcm-system", newClusterDeployment(tc.override))
+			if !reflect.DeepEqual(cfg, tc.expected) {
+				t.Errorf("unexpected config:\ngot:  %+v\nwant: %+v", cfg, tc.expected)
+			}
+		})
+	}
+
+	if mgmt.Labels["env"] != "dev" {
+		t.Errorf("the Management labels must not be modified, got %v", mgmt.Labels)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "fleet-credentials", Namespace: "kcm-system"},
+		Data:       map[string][]byte{"username": []byte("fleet"), "password": []byte("s3cr3t")},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(credentials).Build()
+
+	cd := newClusterDeployment(nil)
+	cfg := &Config{
+		ClusterObservability: kcm.ClusterObservability{
+			ServiceTemplate:       "k8s-monitoring-2-0-0",
+			Namespace:             defaultNamespace,
+			MetricsEndpoint:       "https://metrics.example.com/api/v1/push",
+			CredentialsSecretName: credentials.Name,
+			Labels:                map[string]string{"region": "eu"},
+			Values:                "replicas: 1",
+		},
+		CredentialsNamespace: credentials.Namespace,
+	}
+
+	svc, err := Reconcile(t.Context(), cl, cd, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedSvc := kcm.Service{
+		Name:       ServiceName,
+		Namespace:  defaultNamespace,
+		Template:   "k8s-monitoring-2-0-0",
+		Values:     "replicas: 1",
+		ValuesFrom: []sveltosv1beta1.ValueFrom{{Kind: "Secret", Name: "dev-observability-values"}},
+	}
+	if !reflect.DeepEqual(svc, expectedSvc) {
+		t.Errorf("unexpected service:\ngot:  %+v\nwant: %+v", svc, expectedSvc)
+	}
+
+	secret := new(corev1.Secret)
+	if err := cl.Get(t.Context(), client.ObjectKey{Namespace: "team-a", Name: "dev-observability-values"}, secret); err != nil {
+		t.Fatalf("failed to get values Secret: %v", err)
+	}
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].UID != cd.UID {
+		t.Errorf("expected the values Secret to be owned by the ClusterDeployment, got %v", secret.OwnerReferences)
+	}
+
+	values := make(map[string]any)
+	if err := yaml.Unmarshal(secret.Data[valuesKey], &values); err != nil {
+		t.Fatalf("failed to parse values: %v", err)
+	}
+	expectedValues := map[string]any{
+		"cluster": map[string]any{"name": "dev", "namespace": "team-a"},
+		"externalLabels": map[string]any{
+			"cluster":           "dev",
+			"cluster_namespace": "team-a",
+			"region":            "eu",
+		},
+		"metrics":     map[string]any{"endpoint": "https://metrics.example.com/api/v1/push"},
+		"credentials": map[string]any{"username": "fleet", "password": "s3cr3t"},
+	}
+	if !reflect.DeepEqual(values, expectedValues) {
+		t.Errorf("unexpected values:\ngot:  %v\nwant: %v", values, expectedValues)
+	}
+
+	if err := Delete(t.Context(), cl, cd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(secret), secret); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the values Secret to be deleted, got %v", err)
+	}
+
+	cfg.CredentialsSecretName = "missing"
+	if _, err := Reconcile(t.Context(), cl, cd, cfg); !apierrors.IsNotFound(err) {
+		t.Errorf("expected not found error for missing credentials Secret, got %v", err)
+	}
+}
@@ -0,0 +1,248 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package osimage discovers the images of the OSImage catalogs and resolves the references
+// to the catalogs in the ClusterTemplate values to the IDs of the images.
+package osimage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/cost"
+	"github.com/K0rdent/kcm/internal/providers"
+)
+
+// maxIndexSize is the maximum size of the index of the discovered images.
+const maxIndexSize = 4 << 20
+
+// Discover fetches the index of the images from the given URL.
+func Discover(ctx context.Context, httpClient *http.Client, url string) ([]kcm.OSImageEntry, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch images index %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch images index %s: unexpected status %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxIndexSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read images index %s: %w", url, err)
+	}
+
+	index := struct {
+		Images []kcm.OSImageEntry `json:"images"`
+	}{}
+	if err := yaml.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse images index %s: %w", url, err)
+	}
+
+	for i, image := range index.Images {
+		if image.ID == "" {
+			return nil, fmt.Errorf("invalid images index %s: image %d has no ID", url, i)
+		}
+	}
+
+	return index.Images, nil
+}
+
+// Find returns the ID of the image matching the given Kubernetes version and region.
+// The images of the exact Kubernetes version are preferred over the ones of the minor version
+// and of any version, then the images of the region over the ones of any region.
+// The first of the equally matching images is returned.
+func Find(images []kcm.OSImageEntry, kubernetesVersion, region string) (string, bool) {
+	version := normalizeVersion(kubernetesVersion)
+
+	var (
+		id   string
+		best = -1
+	)
+	for _, image := range images {
+		score := 0
+
+		switch imageVersion := normalizeVersion(image.KubernetesVersion); {
+		case imageVersion == "":
+		case imageVersion == version:
+			score += 4
+		case strings.HasPrefix(version, imageVersion+"."):
+			score += 2
+		default:
+			continue
+		}
+
+		switch image.Region {
+		case "":
+		case region:
+			score++
+		default:
+			continue
+		}
+
+		if score > best {
+			id, best = image.ID, score
+		}
+	}
+
+	return id, best >= 0
+}
+
+// normalizeVersion strips the "v" prefix and the build metadata, e.g. "+k0s.0", of the given version.
+func normalizeVersion(version string) string {
+	version, _, _ = strings.Cut(version, "+")
+	return strings.TrimPrefix(version, "v")
+}
+
+// Outdated returns true if a newer image of the given images matches the Kubernetes version
+// and the region the given reference has been resolved for.
+func Outdated(images []kcm.OSImageEntry, resolved kcm.ResolvedOSImage) bool {
+	id, ok := Find(images, resolved.KubernetesVersion, resolved.Region)
+	return ok && id != resolved.ID
+}
+
+// reference is a reference to an OSImage in the values.
+type reference struct {
+	name string
+	path []string
+}
+
+// ResolveValues replaces the references to the OSImage catalogs in the given ClusterDeployment values
+// and in the default values of the given ClusterTemplate not overridden by the ClusterDeployment
+// with the IDs of the images matching the Kubernetes version of the template and the region of the cluster,
+// and returns the resolved references.
+//
+// The references found in the given pinned ones resolved for the same Kubernetes version and region
+// keep their images unless the given update function returns true for the referenced OSImage,
+// then the references are resolved to the newer images, if any. A nil update function keeps the pinned images.
+func ResolveValues(ctx context.Context, cl client.Client, template *kcm.ClusterTemplate, values map[string]any, pinned []kcm.ResolvedOSImage, update func(osImage string) bool) ([]kcm.ResolvedOSImage, error) {
+	var regionKey string
+	for _, p := range template.Status.Providers {
+		if v := providers.GetConfigValidation(p); v != nil && v.RegionKey != "" {
+			regionKey = v.RegionKey
+			break
+		}
+	}
+
+	return resolve(ctx, cl, template, values, regionKey, pinned, update)
+}
+
+func resolve(ctx context.Context, cl client.Client, template *kcm.ClusterTemplate, values map[string]any, regionKey string, pinned []kcm.ResolvedOSImage, update func(string) bool) ([]kcm.ResolvedOSImage, error) {
+	defaults, err := cost.MergeValues(template.Status.Config, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	references := walk(values, nil, nil)
+	for _, ref := range walk(defaults, nil, nil) {
+		if _, found, _ := unstructured.NestedFieldNoCopy(values, ref.path...); !found {
+			references = append(references, ref)
+		}
+	}
+	if len(references) == 0 {
+		return nil, nil
+	}
+
+	var region string
+	if regionKey != "" {
+		keys := strings.Split(regionKey, ".")
+		region, _, _ = unstructured.NestedString(values, keys...)
+		if region == "" {
+			region, _, _ = unstructured.NestedString(defaults, keys...)
+		}
+	}
+
+	version := template.Status.KubernetesVersion
+	resolved := make([]kcm.ResolvedOSImage, 0, len(references))
+	catalogs := make(map[string]*kcm.OSImage)
+	for _, ref := range references {
+		key := strings.Join(ref.path, ".")
+
+		current := slices.IndexFunc(pinned, func(r kcm.ResolvedOSImage) bool {
+			return r.Path == key && r.OSImage == ref.name && r.KubernetesVersion == version && r.Region == region
+		})
+
+		catalog, ok := catalogs[ref.name]
+		if !ok {
+			catalog = new(kcm.OSImage)
+			if err := cl.Get(ctx, client.ObjectKey{Name: ref.name}, catalog); err != nil {
+				// the images already deployed are kept even if the catalog has been removed
+				if current < 0 || !apierrors.IsNotFound(err) {
+					return nil, fmt.Errorf("failed to get OSImage %s referenced by %s: %w", ref.name, key, err)
+				}
+				catalog = nil
+			}
+			catalogs[ref.name] = catalog
+		}
+
+		if catalog != nil && !slices.Contains(template.Status.Providers, catalog.Spec.Provider) {
+			return nil, fmt.Errorf("OSImage %s referenced by %s is of the provider %s not used by the ClusterTemplate %s",
+				ref.name, key, catalog.Spec.Provider, template.Name)
+		}
+
+		image := kcm.ResolvedOSImage{Path: key, OSImage: ref.name, KubernetesVersion: version, Region: region}
+		switch {
+		case current >= 0 && (catalog == nil || update == nil || !update(ref.name) || !Outdated(catalog.Status.Images, pinned[current])):
+			image.ID = pinned[current].ID
+		case catalog == nil:
+			return nil, fmt.Errorf("failed to get OSImage %s referenced by %s: not found", ref.name, key)
+		default:
+			if image.ID, ok = Find(catalog.Status.Images, version, region); !ok {
+				return nil, fmt.Errorf("OSImage %s referenced by %s has no image of the Kubernetes version %s in the region %q",
+					ref.name, key, version, region)
+			}
+		}
+
+		if err := unstructured.SetNestedField(values, image.ID, ref.path...); err != nil {
+			return nil, fmt.Errorf("failed to set the image of %s: %w", key, err)
+		}
+		resolved = append(resolved, image)
+	}
+
+	slices.SortFunc(resolved, func(a, b kcm.ResolvedOSImage) int { return strings.Compare(a.Path, b.Path) })
+
+	return resolved, nil
+}
+
+// walk appends the references to the OSImages in the given values under the given path to the given references.
+func walk(values map[string]any, path []string, references []reference) []reference {
+	for k, v := range values {
+		switch v := v.(type) {
+		case map[string]any:
+			references = walk(v, append(slices.Clone(path), k), references)
+		case string:
+			if name, ok := strings.CutPrefix(v, kcm.OSImageReferencePrefix); ok {
+				references = append(references, reference{name: name, path: append(slices.Clone(path), k)})
+			}
+		}
+	}
+
+	return references
+}
@@ -0,0 +1,241 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osimage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestDiscover(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/index.yaml":
+			_, _ = w.Write([]byte("images:\n- kubernetesVersion: v1.32.2\n  region: us-east-1\n  id: ami-1\n- id: ami-2\n"))
+		case "/invalid.yaml":
+			_, _ = w.Write([]byte("images:\n- region: us-east-1\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	images, err := Discover(t.Context(), server.Client(), server.URL+"/index.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []kcm.OSImageEntry{
+		{KubernetesVersion: "v1.32.2", Region: "us-east-1", ID: "ami-1"},
+		{ID: "ami-2"},
+	}
+	if !reflect.DeepEqual(images, expected) {
+		t.Errorf("unexpected images:\ngot:  %+v\nwant: %+v", images, expected)
+	}
+
+	if _, err := Discover(t.Context(), server.Client(), server.URL+"/invalid.yaml"); err == nil {
+		t.Error("expected error for an image with no ID")
+	}
+	if _, err := Discover(t.Context(), server.Client(), server.URL+"/missing.yaml"); err == nil {
+		t.Error("expected error for a missing index")
+	}
+}
+
+func TestFind(t *testing.T) {
+	images := []kcm.OSImageEntry{
+		{ID: "any"},
+		{KubernetesVersion: "v1.31", ID: "minor-1.31"},
+		{KubernetesVersion: "v1.32", ID: "minor-1.32"},
+		{KubernetesVersion: "v1.32.2", Region: "us-east-1", ID: "exact-us-east-1"},
+		{KubernetesVersion: "v1.32.2", ID: "exact"},
+		{KubernetesVersion: "v1.32.2", Region: "us-east-1", ID: "exact-us-east-1-older"},
+	}
+
+	for _, tc := range []struct {
+		version, region string
+		expected        string
+	}{
+		{version: "v1.32.2+k0s.0", region: "us-east-1", expected: "exact-us-east-1"},
+		{version: "v1.32.2+k0s.0", region: "eu-west-1", expected: "exact"},
+		{version: "v1.32.3", expected: "minor-1.32"},
+		{version: "v1.31.6+k0s.0", expected: "minor-1.31"},
+		{version: "v1.30.0", expected: "any"},
+		{version: "v1.3", expected: "any"},
+	} {
+		t.Run(tc.version+"/"+tc.region, func(t *testing.T) {
+			if id, _ := Find(images, tc.version, tc.region); id != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, id)
+			}
+		})
+	}
+
+	if _, ok := Find(images[3:4], "v1.32.2", "eu-west-1"); ok {
+		t.Error("expected no image of another region")
+	}
+}
+
+func TestResolveValues(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kcm.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	ubuntu := &kcm.OSImage{
+		ObjectMeta: metav1.ObjectMeta{Name: "ubuntu-22-04"},
+		Spec:       kcm.OSImageSpec{Provider: "infrastructure-aws"},
+		Status: kcm.OSImageStatus{
+			Images: []kcm.OSImageEntry{
+				{KubernetesVersion: "v1.32.2", Region: "us-east-1", ID: "ami-us-east-1"},
+				{KubernetesVersion: "v1.32.2", Region: "eu-west-1", ID: "ami-eu-west-1"},
+			},
+		},
+	}
+	windows := &kcm.OSImage{
+		ObjectMeta: metav1.ObjectMeta{Name: "windows-2022"},
+		Spec:       kcm.OSImageSpec{Provider: "infrastructure-azure"},
+		Status:     kcm.OSImageStatus{Images: []kcm.OSImageEntry{{ID: "windows"}}},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ubuntu, windows).Build()
+
+	template := &kcm.ClusterTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "aws-standalone-cp-1-0-0"},
+		Status: kcm.ClusterTemplateStatus{
+			KubernetesVersion: "v1.32.2+k0s.0",
+			TemplateStatusCommon: kcm.TemplateStatusCommon{
+				Config: &apiextensionsv1.JSON{Raw: []byte(`{"region":"us-east-1","controlPlane":{"amiID":"osimage:ubuntu-22-04"},"worker":{"amiID":"osimage:ubuntu-22-04"}}`)},
+			},
+		},
+	}
+	template.Status.Providers = kcm.Providers{"infrastructure-aws", "control-plane-k0sproject-k0smotron"}
+
+	values := map[string]any{
+		"region":       "eu-west-1",
+		"worker":       map[string]any{"amiID": "ami-custom", "instanceType": "t3.large"},
+		"controlPlane": map[string]any{"instanceType": "t3.medium"},
+	}
+	resolved, err := resolve(t.Context(), cl, template, values, "region", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]any{
+		"region":       "eu-west-1",
+		"worker":       map[string]any{"amiID": "ami-custom", "instanceType": "t3.large"},
+		"controlPlane": map[string]any{"amiID": "ami-eu-west-1", "instanceType": "t3.medium"},
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("unexpected values:\ngot:  %v\nwant: %v", values, expected)
+	}
+	expectedResolved := []kcm.ResolvedOSImage{
+		{Path: "controlPlane.amiID", OSImage: "ubuntu-22-04", KubernetesVersion: "v1.32.2+k0s.0", Region: "eu-west-1", ID: "ami-eu-west-1"},
+	}
+	if !reflect.DeepEqual(resolved, expectedResolved) {
+		t.Errorf("unexpected resolved images:\ngot:  %+v\nwant: %+v", resolved, expectedResolved)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		values map[string]any
+		check  func(error) bool
+	}{
+		{
+			name:   "missing OSImage",
+			values: map[string]any{"worker": map[string]any{"amiID": "osimage:missing"}},
+			check:  apierrors.IsNotFound,
+		},
+		{
+			name:   "OSImage of another provider",
+			values: map[string]any{"worker": map[string]any{"amiID": "osimage:windows-2022"}},
+		},
+		{
+			name:   "no image in the region",
+			values: map[string]any{"region": "ap-south-1"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := resolve(t.Context(), cl, template, tc.values, "region", nil, nil)
+			if err == nil || (tc.check != nil && !tc.check(err)) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestResolveValuesPinned(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kcm.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	ubuntu := &kcm.OSImage{
+		ObjectMeta: metav1.ObjectMeta{Name: "ubuntu-22-04"},
+		Spec:       kcm.OSImageSpec{Provider: "infrastructure-aws"},
+		Status:     kcm.OSImageStatus{Images: []kcm.OSImageEntry{{ID: "ami-new"}}},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ubuntu).Build()
+
+	template := &kcm.ClusterTemplate{Status: kcm.ClusterTemplateStatus{KubernetesVersion: "v1.32.2"}}
+	template.Status.Providers = kcm.Providers{"infrastructure-aws"}
+
+	pinned := []kcm.ResolvedOSImage{{Path: "worker.amiID", OSImage: "ubuntu-22-04", KubernetesVersion: "v1.32.2", ID: "ami-old"}}
+
+	for _, tc := range []struct {
+		name       string
+		version    string
+		update     func(string) bool
+		expectedID string
+	}{
+		{name: "kept without the update", version: "v1.32.2", expectedID: "ami-old"},
+		{name: "kept if the update is not approved", version: "v1.32.2", update: func(string) bool { return false }, expectedID: "ami-old"},
+		{name: "updated if approved", version: "v1.32.2", update: func(name string) bool { return name == "ubuntu-22-04" }, expectedID: "ami-new"},
+		{name: "updated with the Kubernetes version", version: "v1.33.0", expectedID: "ami-new"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			template.Status.KubernetesVersion = tc.version
+			values := map[string]any{"worker": map[string]any{"amiID": "osimage:ubuntu-22-04"}}
+
+			resolved, err := resolve(t.Context(), cl, template, values, "", pinned, tc.update)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if id := values["worker"].(map[string]any)["amiID"]; id != tc.expectedID {
+				t.Errorf("expected image %s, got %v", tc.expectedID, id)
+			}
+			if len(resolved) != 1 || resolved[0].ID != tc.expectedID || resolved[0].KubernetesVersion != tc.version {
+				t.Errorf("unexpected resolved images %+v", resolved)
+			}
+		})
+	}
+
+	if err := cl.Delete(t.Context(), ubuntu); err != nil {
+		t.Fatalf("failed to delete OSImage: %v", err)
+	}
+	template.Status.KubernetesVersion = "v1.32.2"
+	values := map[string]any{"worker": map[string]any{"amiID": "osimage:ubuntu-22-04"}}
+	if _, err := resolve(t.Context(), cl, template, values, "", pinned, nil); err != nil {
+		t.Fatalf("expected the pinned image to be kept after the OSImage removal, got %v", err)
+	}
+	if id := values["worker"].(map[string]any)["amiID"]; id != "ami-old" {
+		t.Errorf("expected the pinned image, got %v", id)
+	}
+}
@@ -51,6 +51,57 @@ type ProviderModule interface {
 	GetClusterGVKs() []schema.GroupVersionKind
 	// GetClusterIdentityKinds returns a list of supported cluster identity kinds
 	GetClusterIdentityKinds() []string
+	// GetConfigValidation returns the semantic validation of the ClusterDeployment configuration
+	GetConfigValidation() *ConfigValidation
+	// GetCredentialPropagation returns how the cluster identities are propagated into the child clusters
+	GetCredentialPropagation() *CredentialPropagation
+}
+
+// ConfigValidation defines the provider-specific semantic validation of the ClusterDeployment configuration.
+type ConfigValidation struct {
+	// RegionKey is the dot-separated values key of the region the cluster is deployed to, e.g. "region".
+	RegionKey string `yaml:"regionKey"`
+	// InstanceTypeKeys are the dot-separated values keys of the instance types, e.g. "worker.instanceType".
+	// The instance types are validated at admission against the offerings of the region in the provider catalog.
+	InstanceTypeKeys []string `yaml:"instanceTypeKeys"`
+	// Job is the optional validation Job run asynchronously before the cluster is deployed.
+	Job *ConfigValidationJob `yaml:"job"`
+	// QuotaJob is the optional Job checking the remaining quota of the provider account, e.g. vCPUs,
+	// public IPs and load balancers, against the topology requested by the ClusterDeployment before
+	// the cluster is deployed. The Job is run in addition to the validation Job.
+	QuotaJob *ConfigValidationJob `yaml:"quotaJob"`
+}
+
+// ConfigValidationJob defines the Job validating the ClusterDeployment configuration against the
+// infrastructure, e.g. checking the existence of a datastore. The Job is run in the namespace
+// of the ClusterDeployment and fails with the termination message describing the invalid configuration.
+type ConfigValidationJob struct {
+	// Image is the image of the validation container.
+	Image string `yaml:"image"`
+	// Command is the entrypoint of the validation container.
+	Command []string `yaml:"command"`
+	// Args are the arguments of the validation container.
+	Args []string `yaml:"args"`
+	// ServiceAccountName is the name of the ServiceAccount the Job is run with.
+	ServiceAccountName string `yaml:"serviceAccountName"`
+}
+
+// CredentialPropagation defines how the identity of a Credential is projected into the child cluster,
+// e.g. into the secrets of the cloud-controller-manager and the CSI driver.
+type CredentialPropagation struct {
+	// IdentitySecrets map the cluster identity kinds to the references of the Secrets holding
+	// the credentials of the identities. The identity kinds of the provider absent from the map
+	// do not reference any Secret, e.g. the identities assumed by the provider controller.
+	IdentitySecrets map[string]IdentitySecretRef `yaml:"identitySecrets"`
+}
+
+// IdentitySecretRef defines the fields of the cluster identity referencing the Secret with the credentials.
+type IdentitySecretRef struct {
+	// NameField is the dot-separated path to the name of the Secret in the identity, e.g. "spec.secretRef".
+	NameField string `yaml:"nameField"`
+	// NamespaceField is the optional dot-separated path to the namespace of the Secret in the identity.
+	// The namespace of the identity is used if the field is not set.
+	NamespaceField string `yaml:"namespaceField"`
 }
 
 // Register adds a new provider module to the registry
@@ -111,3 +162,33 @@ func GetClusterIdentityKinds(infraName string) ([]string, bool) {
 
 	return list, len(list) > 0
 }
+
+// GetConfigValidation returns the semantic validation of the ClusterDeployment configuration
+// for a given infrastructure provider or nil if the provider does not define any.
+func GetConfigValidation(infraName string) *ConfigValidation {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	module, ok := registry[strings.TrimPrefix(infraName, InfraPrefix)]
+	if !ok {
+		return nil
+	}
+
+	return module.GetConfigValidation()
+}
+
+// GetCredentialPropagation returns the propagation of the given cluster identity kind into the child clusters
+// defined by the infrastructure provider supporting the kind or nil if no provider defines any.
+func GetCredentialPropagation(identityKind string) *CredentialPropagation {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, module := range registry {
+		cp := module.GetCredentialPropagation()
+		if cp != nil && slices.Contains(module.GetClusterIdentityKinds(), identityKind) {
+			return cp
+		}
+	}
+
+	return nil
+}
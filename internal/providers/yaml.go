@@ -26,9 +26,11 @@ import (
 
 // YAMLProviderDefinition represents a YAML-based provider configuration.
 type YAMLProviderDefinition struct {
-	Name                 string                    `yaml:"name"`
-	ClusterGVKs          []schema.GroupVersionKind `yaml:"clusterGVKs"`
-	ClusterIdentityKinds []string                  `yaml:"clusterIdentityKinds"`
+	Name                  string                    `yaml:"name"`
+	ClusterGVKs           []schema.GroupVersionKind `yaml:"clusterGVKs"`
+	ClusterIdentityKinds  []string                  `yaml:"clusterIdentityKinds"`
+	ConfigValidation      *ConfigValidation         `yaml:"configValidation"`
+	CredentialPropagation *CredentialPropagation    `yaml:"credentialPropagation"`
 }
 
 var _ ProviderModule = (*YAMLProviderDefinition)(nil)
@@ -57,6 +59,14 @@ func (p *YAMLProviderDefinition) GetClusterIdentityKinds() []string {
 	return slices.Clone(p.ClusterIdentityKinds)
 }
 
+func (p *YAMLProviderDefinition) GetConfigValidation() *ConfigValidation {
+	return p.ConfigValidation
+}
+
+func (p *YAMLProviderDefinition) GetCredentialPropagation() *CredentialPropagation {
+	return p.CredentialPropagation
+}
+
 // RegisterFromYAML registers a provider from a YAML file.
 func RegisterFromYAML(yamlFile string) error {
 	data, err := os.ReadFile(yamlFile)
@@ -0,0 +1,293 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package provisioning tracks the initial provisioning of the ClusterDeployments
+// against their provisioning policies, retrying the failed provisioning with
+// an exponential backoff and stopping at a terminal Failed phase.
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	hcv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/fluxcd/pkg/apis/meta"
+	fluxconditions "github.com/fluxcd/pkg/runtime/conditions"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// SuspendedAnnotation is set on the HelmReleases suspended by KCM after the failed provisioning
+	// so that only the suspension set by KCM is lifted once the provisioning is started over.
+	SuspendedAnnotation = "k0rdent.mirantis.com/provisioning-suspended"
+
+	// MachineFailedReason is the reason of the provisioning failure caused by a failed Machine.
+	MachineFailedReason = "MachineFailed"
+
+	defaultBackoff    = time.Minute
+	defaultMaxBackoff = 30 * time.Minute
+)
+
+// failure describes the observed provisioning failure.
+type failure struct {
+	reason  string
+	message string
+	// retryable is false if the failure requires a user intervention.
+	retryable bool
+	// installFailed is set if the installation of the HelmRelease failed.
+	installFailed bool
+	// machines are the failed Machines recreated on retry.
+	machines []*clusterv1.Machine
+}
+
+// Reconcile tracks the initial provisioning of the given ClusterDeployment deployed by the given HelmRelease
+// according to its provisioning policy and returns the observed provisioning status along with the delay
+// until the next scheduled retry, if any.
+// Returns nil if the ClusterDeployment has no provisioning policy.
+//
+// The Failed phase is terminal: the HelmRelease is suspended so that the objects of the cluster managed
+// by the chart are left as they are, and the provisioning is started over only once the spec
+// of the ClusterDeployment is changed. The CAPI Cluster managed by the chart is never patched.
+func Reconcile(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment, hr *hcv2.HelmRelease, now time.Time) (*kcm.ProvisioningStatus, time.Duration, error) {
+	cluster := new(clusterv1.Cluster)
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(cd), cluster); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, 0, fmt.Errorf("failed to get Cluster %s/%s: %w", cd.Namespace, cd.Name, err)
+		}
+		cluster = nil
+	}
+
+	policy := cd.Spec.ProvisioningPolicy
+	if policy == nil {
+		return nil, 0, setSuspended(ctx, cl, hr, false)
+	}
+
+	status := new(kcm.ProvisioningStatus)
+	if cd.Status.Provisioning != nil {
+		status = cd.Status.Provisioning.DeepCopy()
+	}
+	if status.Phase == "" || (status.Phase == kcm.ProvisioningPhaseFailed && status.ObservedGeneration != cd.Generation) {
+		status = &kcm.ProvisioningStatus{Phase: kcm.ProvisioningPhaseProvisioning}
+	}
+	status.ObservedGeneration = cd.Generation
+
+	switch status.Phase {
+	case kcm.ProvisioningPhaseProvisioned:
+		// the policy covers the initial provisioning only
+		return status, 0, nil
+	case kcm.ProvisioningPhaseFailed:
+		return status, 0, setSuspended(ctx, cl, hr, true)
+	}
+
+	if err := setSuspended(ctx, cl, hr, false); err != nil {
+		return nil, 0, err
+	}
+
+	if cluster != nil && cluster.Status.InfrastructureReady && cluster.Status.ControlPlaneReady && fluxconditions.IsReady(hr) {
+		status.Phase = kcm.ProvisioningPhaseProvisioned
+		status.NextRetryTime = nil
+		return status, 0, nil
+	}
+
+	f, err := detectFailure(ctx, cl, cd, hr, cluster)
+	if err != nil {
+		return nil, 0, err
+	}
+	if f == nil {
+		return status, 0, nil
+	}
+
+	if status.NextRetryTime == nil {
+		status.LastFailureTime = &metav1.Time{Time: now}
+	}
+	status.LastFailureReason = f.reason
+	status.LastFailureMessage = f.message
+
+	if !f.retryable || policy.Strategy == kcm.ProvisioningStrategyFailFast ||
+		(policy.MaxRetries != nil && status.Retries >= *policy.MaxRetries) {
+		status.Phase = kcm.ProvisioningPhaseFailed
+		status.NextRetryTime = nil
+		return status, 0, setSuspended(ctx, cl, hr, true)
+	}
+
+	if status.NextRetryTime == nil {
+		status.NextRetryTime = &metav1.Time{Time: now.Add(Backoff(policy, status.Retries))}
+	}
+	if wait := status.NextRetryTime.Sub(now); wait > 0 {
+		return status, wait, nil
+	}
+
+	if err := retry(ctx, cl, hr, f, now); err != nil {
+		return nil, 0, err
+	}
+	status.Retries++
+	status.NextRetryTime = nil
+
+	return status, 0, nil
+}
+
+// Backoff returns the delay before the retry following the given number of the retries
+// doubling the configured backoff on each retry up to the configured maximum.
+func Backoff(policy *kcm.ProvisioningPolicy, retries int32) time.Duration {
+	backoff, maxBackoff := defaultBackoff, defaultMaxBackoff
+	if policy.Backoff != nil && policy.Backoff.Duration > 0 {
+		backoff = policy.Backoff.Duration
+	}
+	if policy.MaxBackoff != nil && policy.MaxBackoff.Duration > 0 {
+		maxBackoff = policy.MaxBackoff.Duration
+	}
+
+	for range retries {
+		if backoff >= maxBackoff {
+			break
+		}
+		backoff *= 2
+	}
+
+	return min(backoff, maxBackoff)
+}
+
+// Condition returns the Provisioned condition reflecting the given provisioning status.
+func Condition(status *kcm.ProvisioningStatus, generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               kcm.ProvisionedCondition,
+		ObservedGeneration: generation,
+	}
+
+	switch {
+	case status.Phase == kcm.ProvisioningPhaseProvisioned:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = kcm.SucceededReason
+		condition.Message = "Cluster is provisioned"
+	case status.Phase == kcm.ProvisioningPhaseFailed:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kcm.ProvisioningFailedReason
+		condition.Message = fmt.Sprintf("Provisioning failed after %d retries, the HelmRelease is suspended until the ClusterDeployment is changed: %s: %s",
+			status.Retries, status.LastFailureReason, status.LastFailureMessage)
+	case status.NextRetryTime != nil:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = kcm.ProgressingReason
+		condition.Message = fmt.Sprintf("Provisioning failed, retry %d is scheduled at %s: %s: %s",
+			status.Retries+1, status.NextRetryTime.UTC().Format(time.RFC3339), status.LastFailureReason, status.LastFailureMessage)
+	default:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = kcm.ProgressingReason
+		condition.Message = "Cluster is being provisioned"
+	}
+
+	return condition
+}
+
+// detectFailure returns the provisioning failure of the given ClusterDeployment if any.
+func detectFailure(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment, hr *hcv2.HelmRelease, cluster *clusterv1.Cluster) (*failure, error) {
+	if cluster != nil && cluster.Status.FailureReason != nil {
+		f := &failure{reason: string(*cluster.Status.FailureReason)}
+		if cluster.Status.FailureMessage != nil {
+			f.message = *cluster.Status.FailureMessage
+		}
+		return f, nil
+	}
+
+	if hr != nil {
+		if c := apimeta.FindStatusCondition(hr.Status.Conditions, meta.ReadyCondition); c != nil &&
+			c.Status == metav1.ConditionFalse && c.Reason == hcv2.InstallFailedReason {
+			return &failure{reason: c.Reason, message: c.Message, retryable: true, installFailed: true}, nil
+		}
+	}
+
+	machines := new(clusterv1.MachineList)
+	if err := cl.List(ctx, machines, client.InNamespace(cd.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cd.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list Machines: %w", err)
+	}
+
+	var f *failure
+	for i := range machines.Items {
+		machine := &machines.Items[i]
+		if machine.Status.FailureReason == nil && machine.Status.Phase != string(clusterv1.MachinePhaseFailed) {
+			continue
+		}
+
+		if f == nil {
+			f = &failure{reason: MachineFailedReason, retryable: true}
+			f.message = fmt.Sprintf("Machine %s failed", machine.Name)
+			if machine.Status.FailureMessage != nil {
+				f.message += ": " + *machine.Status.FailureMessage
+			}
+		}
+		f.machines = append(f.machines, machine)
+	}
+
+	return f, nil
+}
+
+// retry retries the given provisioning failure, the failed Machines are deleted
+// to be recreated by their owners and the failed installation of the HelmRelease is forced.
+func retry(ctx context.Context, cl client.Client, hr *hcv2.HelmRelease, f *failure, now time.Time) error {
+	for _, machine := range f.machines {
+		if err := cl.Delete(ctx, machine); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete failed Machine %s/%s: %w", machine.Namespace, machine.Name, err)
+		}
+	}
+
+	if f.installFailed && hr != nil {
+		original := hr.DeepCopy()
+		if hr.Annotations == nil {
+			hr.Annotations = make(map[string]string)
+		}
+		requestedAt := now.UTC().Format(time.RFC3339Nano)
+		hr.Annotations[meta.ReconcileRequestAnnotation] = requestedAt
+		hr.Annotations[hcv2.ForceRequestAnnotation] = requestedAt
+		if err := cl.Patch(ctx, hr, client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("failed to patch HelmRelease %s/%s: %w", hr.Namespace, hr.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// setSuspended suspends or resumes the given HelmRelease, only the suspension set by KCM is lifted.
+func setSuspended(ctx context.Context, cl client.Client, hr *hcv2.HelmRelease, suspended bool) error {
+	if hr == nil {
+		return nil
+	}
+
+	original := hr.DeepCopy()
+	_, suspendedByKCM := hr.Annotations[SuspendedAnnotation]
+	switch {
+	case suspended && !hr.Spec.Suspend:
+		if hr.Annotations == nil {
+			hr.Annotations = make(map[string]string)
+		}
+		hr.Annotations[SuspendedAnnotation] = ""
+		hr.Spec.Suspend = true
+	case !suspended && suspendedByKCM:
+		delete(hr.Annotations, SuspendedAnnotation)
+		hr.Spec.Suspend = false
+	default:
+		return nil
+	}
+
+	if err := cl.Patch(ctx, hr, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to patch HelmRelease %s/%s: %w", hr.Namespace, hr.Name, err)
+	}
+
+	return nil
+}
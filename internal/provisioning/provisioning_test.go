@@ -0,0 +1,260 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provisioning
+
+import (
+	"testing"
+	"time"
+
+	hcv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/fluxcd/pkg/apis/meta"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{clusterv1.AddToScheme, hcv2.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatalf("failed to build scheme: %v", err)
+		}
+	}
+	return scheme
+}
+
+func newClusterDeployment(policy *kcm.ProvisioningPolicy) *kcm.ClusterDeployment {
+	return &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a", Generation: 1},
+		Spec:       kcm.ClusterDeploymentSpec{ProvisioningPolicy: policy},
+	}
+}
+
+func newHelmRelease(status metav1.ConditionStatus, reason string) *hcv2.HelmRelease {
+	return &hcv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"},
+		Status: hcv2.HelmReleaseStatus{
+			Conditions: []metav1.Condition{{Type: meta.ReadyCondition, Status: status, Reason: reason, Message: "install failed"}},
+		},
+	}
+}
+
+func newCluster() *clusterv1.Cluster {
+	return &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"}}
+}
+
+func newFailedMachine(name string) *clusterv1.Machine {
+	return &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "team-a",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "dev"},
+		},
+		Status: clusterv1.MachineStatus{
+			Phase:          string(clusterv1.MachinePhaseFailed),
+			FailureReason:  ptr.To(capierrors.CreateMachineError),
+			FailureMessage: ptr.To("insufficient quota"),
+		},
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := &kcm.ProvisioningPolicy{
+		Backoff:    &metav1.Duration{Duration: 30 * time.Second},
+		MaxBackoff: &metav1.Duration{Duration: 5 * time.Minute},
+	}
+
+	for retries, expected := range []time.Duration{
+		30 * time.Second, time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute,
+	} {
+		if backoff := Backoff(policy, int32(retries)); backoff != expected {
+			t.Errorf("unexpected backoff after %d retries: got %s, want %s", retries, backoff, expected)
+		}
+	}
+
+	if backoff := Backoff(&kcm.ProvisioningPolicy{}, 100); backoff != defaultMaxBackoff {
+		t.Errorf("unexpected default backoff: got %s, want %s", backoff, defaultMaxBackoff)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := newScheme(t)
+	now := time.Now().Truncate(time.Second)
+
+	t.Run("no policy", func(t *testing.T) {
+		cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		status, retryAfter, err := Reconcile(t.Context(), cl, newClusterDeployment(nil), newHelmRelease(metav1.ConditionUnknown, ""), now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status != nil || retryAfter != 0 {
+			t.Errorf("expected no status, got %+v, %s", status, retryAfter)
+		}
+	})
+
+	t.Run("provisioned", func(t *testing.T) {
+		cluster := newCluster()
+		cluster.Status.InfrastructureReady = true
+		cluster.Status.ControlPlaneReady = true
+		hr := newHelmRelease(metav1.ConditionTrue, hcv2.InstallSucceededReason)
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, hr).Build()
+
+		status, _, err := Reconcile(t.Context(), cl, newClusterDeployment(&kcm.ProvisioningPolicy{}), hr, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status.Phase != kcm.ProvisioningPhaseProvisioned {
+			t.Errorf("expected phase %s, got %s", kcm.ProvisioningPhaseProvisioned, status.Phase)
+		}
+	})
+
+	t.Run("keep retrying failed machines", func(t *testing.T) {
+		machine := newFailedMachine("dev-md-0")
+		hr := newHelmRelease(metav1.ConditionTrue, hcv2.InstallSucceededReason)
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newCluster(), machine, hr).Build()
+		cd := newClusterDeployment(&kcm.ProvisioningPolicy{
+			Strategy:   kcm.ProvisioningStrategyKeepRetrying,
+			MaxRetries: ptr.To[int32](1),
+			Backoff:    &metav1.Duration{Duration: time.Minute},
+		})
+
+		status, retryAfter, err := Reconcile(t.Context(), cl, cd, hr, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status.Phase != kcm.ProvisioningPhaseProvisioning || status.LastFailureReason != MachineFailedReason || retryAfter != time.Minute {
+			t.Fatalf("expected the retry to be scheduled in 1m, got %+v, %s", status, retryAfter)
+		}
+
+		cd.Status.Provisioning = status
+		status, _, err = Reconcile(t.Context(), cl, cd, hr, now.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status.Retries != 1 || status.NextRetryTime != nil {
+			t.Errorf("expected the provisioning to be retried, got %+v", status)
+		}
+		if err := cl.Get(t.Context(), client.ObjectKeyFromObject(machine), machine); !apierrors.IsNotFound(err) {
+			t.Errorf("expected the failed Machine to be deleted, got %v", err)
+		}
+
+		cd.Status.Provisioning = status
+		if err := cl.Create(t.Context(), newFailedMachine("dev-md-1")); err != nil {
+			t.Fatalf("failed to create Machine: %v", err)
+		}
+		status, _, err = Reconcile(t.Context(), cl, cd, hr, now.Add(2*time.Minute))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status.Phase != kcm.ProvisioningPhaseFailed {
+			t.Fatalf("expected the provisioning to fail once the retries are exhausted, got %+v", status)
+		}
+
+		if err := cl.Get(t.Context(), client.ObjectKeyFromObject(hr), hr); err != nil {
+			t.Fatalf("failed to get HelmRelease: %v", err)
+		}
+		if _, ok := hr.Annotations[SuspendedAnnotation]; !ok || !hr.Spec.Suspend {
+			t.Errorf("expected the HelmRelease to be suspended, got %+v", hr)
+		}
+		cluster := new(clusterv1.Cluster)
+		if err := cl.Get(t.Context(), client.ObjectKeyFromObject(cd), cluster); err != nil {
+			t.Fatalf("failed to get Cluster: %v", err)
+		}
+		if cluster.Spec.Paused || len(cluster.Annotations) > 0 {
+			t.Errorf("expected the Cluster managed by the chart not to be patched, got %+v", cluster)
+		}
+
+		cd.Status.Provisioning = status
+		cd.Generation++
+		status, _, err = Reconcile(t.Context(), cl, cd, hr, now.Add(3*time.Minute))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status.Phase != kcm.ProvisioningPhaseProvisioning || status.Retries != 0 {
+			t.Errorf("expected the provisioning to be started over on spec change, got %+v", status)
+		}
+		if err := cl.Get(t.Context(), client.ObjectKeyFromObject(hr), hr); err != nil {
+			t.Fatalf("failed to get HelmRelease: %v", err)
+		}
+		if _, ok := hr.Annotations[SuspendedAnnotation]; ok || hr.Spec.Suspend {
+			t.Errorf("expected the HelmRelease to be resumed, got %+v", hr)
+		}
+	})
+
+	t.Run("fail fast on install failure", func(t *testing.T) {
+		hr := newHelmRelease(metav1.ConditionFalse, hcv2.InstallFailedReason)
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(hr).Build()
+		cd := newClusterDeployment(&kcm.ProvisioningPolicy{Strategy: kcm.ProvisioningStrategyFailFast})
+
+		status, _, err := Reconcile(t.Context(), cl, cd, hr, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status.Phase != kcm.ProvisioningPhaseFailed || status.LastFailureReason != hcv2.InstallFailedReason {
+			t.Errorf("expected the provisioning to fail, got %+v", status)
+		}
+	})
+
+	t.Run("retry install failure", func(t *testing.T) {
+		hr := newHelmRelease(metav1.ConditionFalse, hcv2.InstallFailedReason)
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(hr).Build()
+		cd := newClusterDeployment(&kcm.ProvisioningPolicy{})
+		cd.Status.Provisioning = &kcm.ProvisioningStatus{
+			Phase:         kcm.ProvisioningPhaseProvisioning,
+			NextRetryTime: &metav1.Time{Time: now},
+		}
+
+		status, _, err := Reconcile(t.Context(), cl, cd, hr, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status.Retries != 1 {
+			t.Errorf("expected the provisioning to be retried, got %+v", status)
+		}
+		if err := cl.Get(t.Context(), client.ObjectKeyFromObject(hr), hr); err != nil {
+			t.Fatalf("failed to get HelmRelease: %v", err)
+		}
+		if hr.Annotations[hcv2.ForceRequestAnnotation] == "" ||
+			hr.Annotations[hcv2.ForceRequestAnnotation] != hr.Annotations[meta.ReconcileRequestAnnotation] {
+			t.Errorf("expected the HelmRelease to be forced, got %v", hr.Annotations)
+		}
+	})
+
+	t.Run("non-retryable cluster failure", func(t *testing.T) {
+		cluster := newCluster()
+		cluster.Status.FailureReason = ptr.To(capierrors.InvalidConfigurationClusterError)
+		cluster.Status.FailureMessage = ptr.To("invalid region")
+		hr := newHelmRelease(metav1.ConditionTrue, hcv2.InstallSucceededReason)
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, hr).Build()
+
+		status, _, err := Reconcile(t.Context(), cl, newClusterDeployment(&kcm.ProvisioningPolicy{}), hr, now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status.Phase != kcm.ProvisioningPhaseFailed || status.LastFailureMessage != "invalid region" {
+			t.Errorf("expected the provisioning to fail, got %+v", status)
+		}
+	})
+}
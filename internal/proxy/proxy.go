@@ -0,0 +1,199 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package proxy implements the ClusterTemplate values conventions of the HTTP proxy
+// of the clusters and passes the proxy to the services of the clusters.
+//
+// A ClusterTemplate supports the proxy if its default values define the "proxy" key.
+// The template configures the proxy of the k0s services of the machines, which is inherited
+// by containerd and kubelet. The services receive the following values in addition to the configured ones:
+//
+//	global:
+//	  proxy:
+//	    httpProxy: <HTTP proxy URL>
+//	    httpsProxy: <HTTPS proxy URL>
+//	    noProxy: <comma-separated list of the hosts reached without the proxy>
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// ValuesKey is the key of the template values holding the proxy.
+	ValuesKey = "proxy"
+
+	valuesConfigMapSuffix = "-proxy-values"
+	// valuesKey is the key of the values ConfigMap, the default key of the Flux HelmRelease values references.
+	valuesKey = "values.yaml"
+)
+
+// defaultNoProxy is the list of the in-cluster destinations never reached through the proxy.
+var defaultNoProxy = []string{"localhost", "127.0.0.1", ".svc", ".cluster.local"}
+
+// Values returns the template values of the given proxy.
+func Values(proxy *kcm.ClusterProxy) map[string]any {
+	return map[string]any{
+		"httpProxy":  proxy.HTTPProxy,
+		"httpsProxy": proxy.HTTPSProxy,
+		"noProxy":    slices.Clone(proxy.NoProxy),
+	}
+}
+
+// TemplateSupported returns true if the ClusterTemplate with the given default values
+// supports configuring the proxy of the machines.
+func TemplateSupported(config *apiextensionsv1.JSON) (bool, error) {
+	if config == nil || len(config.Raw) == 0 {
+		return false, nil
+	}
+
+	values := make(map[string]any)
+	if err := json.Unmarshal(config.Raw, &values); err != nil {
+		return false, fmt.Errorf("failed to parse template config: %w", err)
+	}
+
+	_, found, err := unstructured.NestedMap(values, ValuesKey)
+	return found && err == nil, nil
+}
+
+// Validate validates the URLs of the given proxy and the hosts reached without the proxy.
+func Validate(proxy *kcm.ClusterProxy) error {
+	var errs error
+	for _, u := range []string{proxy.HTTPProxy, proxy.HTTPSProxy} {
+		if u == "" {
+			continue
+		}
+		parsed, err := url.Parse(u)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid proxy URL %s: %w", u, err))
+			continue
+		}
+		if parsed.Hostname() == "" {
+			errs = errors.Join(errs, fmt.Errorf("invalid proxy URL %s: the host is missing", u))
+		}
+	}
+
+	for _, host := range proxy.NoProxy {
+		if host == "" || strings.ContainsAny(host, ", ") {
+			errs = errors.Join(errs, fmt.Errorf("invalid no proxy entry %q", host))
+		}
+	}
+
+	return errs
+}
+
+// NoProxy returns the comma-separated list of the hosts reached without the proxy of the given ClusterDeployment
+// including the pods and the services CIDRs of the cluster defined in the configuration.
+func NoProxy(cd *kcm.ClusterDeployment) (string, error) {
+	values, err := cd.HelmValues()
+	if err != nil {
+		return "", err
+	}
+
+	hosts := slices.Clone(defaultNoProxy)
+	for _, kind := range []string{"pods", "services"} {
+		cidrs, _, _ := unstructured.NestedStringSlice(values, "clusterNetwork", kind, "cidrBlocks")
+		hosts = append(hosts, cidrs...)
+	}
+	hosts = append(hosts, cd.Spec.Proxy.NoProxy...)
+
+	return strings.Join(hosts, ","), nil
+}
+
+// ValuesConfigMapName returns the name of the ConfigMap holding the proxy values of the services of the given ClusterDeployment.
+func ValuesConfigMapName(cd *kcm.ClusterDeployment) string {
+	return cd.Name + valuesConfigMapSuffix
+}
+
+// Reconcile creates or updates the ConfigMap holding the proxy values of the services
+// of the given ClusterDeployment and returns the given services referencing the ConfigMap.
+func Reconcile(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment, services []kcm.Service) ([]kcm.Service, error) {
+	noProxy, err := NoProxy(cd)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := yaml.Marshal(map[string]any{
+		"global": map[string]any{
+			"proxy": map[string]string{
+				"httpProxy":  cd.Spec.Proxy.HTTPProxy,
+				"httpsProxy": cd.Spec.Proxy.HTTPSProxy,
+				"noProxy":    noProxy,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal proxy values: %w", err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	cm.Name = ValuesConfigMapName(cd)
+	cm.Namespace = cd.Namespace
+
+	if _, err := ctrl.CreateOrUpdate(ctx, cl, cm, func() error {
+		if cm.Labels == nil {
+			cm.Labels = make(map[string]string)
+		}
+		cm.Labels[kcm.KCMManagedLabelKey] = kcm.KCMManagedLabelValue
+		cm.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: kcm.GroupVersion.String(),
+			Kind:       kcm.ClusterDeploymentKind,
+			Name:       cd.Name,
+			UID:        cd.UID,
+		}}
+		cm.Data = map[string]string{valuesKey: string(values)}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to reconcile proxy values ConfigMap: %w", err)
+	}
+
+	res := make([]kcm.Service, 0, len(services))
+	for _, svc := range services {
+		// the explicitly referenced values follow the proxy ones to take precedence
+		svc.ValuesFrom = append([]sveltosv1beta1.ValueFrom{{Kind: "ConfigMap", Name: cm.Name}}, svc.ValuesFrom...)
+		res = append(res, svc)
+	}
+
+	return res, nil
+}
+
+// Delete deletes the ConfigMap holding the proxy values of the services of the given ClusterDeployment.
+func Delete(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) error {
+	cm := &corev1.ConfigMap{}
+	cm.Name = ValuesConfigMapName(cd)
+	cm.Namespace = cd.Namespace
+
+	if err := cl.Delete(ctx, cm); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete proxy values ConfigMap: %w", err)
+	}
+
+	return nil
+}
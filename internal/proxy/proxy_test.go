@@ -0,0 +1,111 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestTemplateSupported(t *testing.T) {
+	supported, err := TemplateSupported(&apiextensionsv1.JSON{Raw: []byte(`{"proxy":{"httpProxy":""}}`)})
+	require.NoError(t, err)
+	require.True(t, supported)
+
+	supported, err = TemplateSupported(&apiextensionsv1.JSON{Raw: []byte(`{"controlPlaneNumber":3}`)})
+	require.NoError(t, err)
+	require.False(t, supported)
+
+	supported, err = TemplateSupported(nil)
+	require.NoError(t, err)
+	require.False(t, supported)
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		proxy *kcm.ClusterProxy
+		err   string
+	}{
+		{
+			name:  "valid proxy",
+			proxy: &kcm.ClusterProxy{HTTPSProxy: "http://proxy.example.com:3128", NoProxy: []string{"10.0.0.0/16", ".example.com"}},
+		},
+		{
+			name:  "proxy URL without host",
+			proxy: &kcm.ClusterProxy{HTTPProxy: "http://:3128"},
+			err:   "invalid proxy URL http://:3128: the host is missing",
+		},
+		{
+			name:  "no proxy entry with separator",
+			proxy: &kcm.ClusterProxy{HTTPProxy: "http://proxy.example.com:3128", NoProxy: []string{"10.0.0.0/16,.example.com"}},
+			err:   `invalid no proxy entry "10.0.0.0/16,.example.com"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.proxy)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	cd := &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "kcm-system", UID: "uid"},
+		Spec: kcm.ClusterDeploymentSpec{
+			Config: &apiextensionsv1.JSON{Raw: []byte(`{"clusterNetwork":{"pods":{"cidrBlocks":["10.244.0.0/16"]},"services":{"cidrBlocks":["10.96.0.0/12"]}}}`)},
+			Proxy:  &kcm.ClusterProxy{HTTPSProxy: "http://proxy.example.com:3128", NoProxy: []string{"10.0.0.0/16"}},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+
+	services, err := Reconcile(t.Context(), cl, cd, []kcm.Service{
+		{Name: "ingress-nginx", Template: "ingress-nginx-4-12-0"},
+		{Name: "cert-manager", Template: "cert-manager-1-17-1", ValuesFrom: []sveltosv1beta1.ValueFrom{{Kind: "Secret", Name: "cert-manager-values"}}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []sveltosv1beta1.ValueFrom{{Kind: "ConfigMap", Name: "dev-proxy-values"}}, services[0].ValuesFrom)
+	require.Equal(t, []sveltosv1beta1.ValueFrom{
+		{Kind: "ConfigMap", Name: "dev-proxy-values"},
+		{Kind: "Secret", Name: "cert-manager-values"},
+	}, services[1].ValuesFrom)
+
+	cm := new(corev1.ConfigMap)
+	require.NoError(t, cl.Get(t.Context(), client.ObjectKey{Namespace: "kcm-system", Name: "dev-proxy-values"}, cm))
+	require.YAMLEq(t, `global:
+  proxy:
+    httpProxy: ""
+    httpsProxy: http://proxy.example.com:3128
+    noProxy: localhost,127.0.0.1,.svc,.cluster.local,10.244.0.0/16,10.96.0.0/12,10.0.0.0/16
+`, cm.Data["values.yaml"])
+	require.Equal(t, cd.Name, cm.OwnerReferences[0].Name)
+
+	require.NoError(t, Delete(t.Context(), cl, cd))
+	require.NoError(t, Delete(t.Context(), cl, cd))
+}
@@ -0,0 +1,125 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package quotacheck checks the remaining quota of the infrastructure provider accounts
+// against the topology requested by the ClusterDeployments before the clusters are deployed.
+//
+// The quota Job of the provider is run with the environment of the config validation Job
+// and the requested topology in JSON in the CLUSTER_DEPLOYMENT_TOPOLOGY environment variable, e.g.:
+//
+//	{"region":"us-east-1","machines":5,"instanceTypes":{"t3.large":2,"t3.medium":3}}
+//
+// The Job queries the provider with the ClusterDeployment Credential and fails with the termination
+// message describing the exceeded quota, e.g. "vCPU quota exceeded in us-east-1: 14 requested, 8 available".
+package quotacheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/configvalidation"
+	"github.com/K0rdent/kcm/internal/cost"
+	"github.com/K0rdent/kcm/internal/providers"
+)
+
+const (
+	// TopologyEnvVar is the environment variable of the quota Job container holding the requested topology in JSON.
+	TopologyEnvVar = "CLUSTER_DEPLOYMENT_TOPOLOGY"
+
+	jobNameSuffix = "-quota-check"
+)
+
+// Topology is the topology of the cluster requested by the ClusterDeployment.
+type Topology struct {
+	// InstanceTypes is the number of the machines per instance type, the machines
+	// with no instance type set are not included.
+	InstanceTypes map[string]int `json:"instanceTypes,omitempty"`
+	// Region is the region the cluster is deployed to.
+	Region string `json:"region,omitempty"`
+	// Machines is the total number of the machines.
+	Machines int `json:"machines"`
+}
+
+// RequestedTopology returns the topology of the cluster deployed from the given ClusterTemplate
+// with the given configuration, the region is looked up by the given dot-separated values key.
+func RequestedTopology(template *kcm.ClusterTemplate, config *apiextensionsv1.JSON, regionKey string) (*Topology, error) {
+	values, err := cost.MergeValues(template.Status.Config, config)
+	if err != nil {
+		return nil, err
+	}
+
+	topology := new(Topology)
+	if regionKey != "" {
+		topology.Region, _, _ = unstructured.NestedString(values, strings.Split(regionKey, ".")...)
+	}
+
+	for instanceType, number := range cost.NodeCounts(values) {
+		topology.Machines += number
+		if instanceType == "" {
+			continue
+		}
+		if topology.InstanceTypes == nil {
+			topology.InstanceTypes = make(map[string]int)
+		}
+		topology.InstanceTypes[instanceType] = number
+	}
+
+	return topology, nil
+}
+
+// JobName returns the name of the quota Job of the given ClusterDeployment.
+func JobName(cd *kcm.ClusterDeployment) string {
+	return cd.Name + jobNameSuffix
+}
+
+// NewJob returns the Job checking the quota of the given infrastructure provider
+// for the given configuration and the requested topology of the ClusterDeployment.
+// The Job is rerun once either the configuration or the topology changes.
+func NewJob(cd *kcm.ClusterDeployment, provider string, spec *providers.ConfigValidationJob, config *apiextensionsv1.JSON, topology *Topology) (*batchv1.Job, error) {
+	job, err := configvalidation.NewJob(cd, provider, spec, config)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(topology)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal requested topology: %w", err)
+	}
+
+	job.Name = JobName(cd)
+	job.Annotations[configvalidation.ConfigHashAnnotation] = ConfigHash(config, topology)
+
+	container := &job.Spec.Template.Spec.Containers[0]
+	container.Name = "check-quota"
+	container.Env = append(container.Env, corev1.EnvVar{Name: TopologyEnvVar, Value: string(raw)})
+
+	return job, nil
+}
+
+// ConfigHash returns the hash of the given configuration and topology the quota Job is run for.
+func ConfigHash(config *apiextensionsv1.JSON, topology *Topology) string {
+	// json.Marshal sorts the keys of the maps, hence the hash is stable
+	raw, _ := json.Marshal(topology)
+	if config != nil {
+		raw = append(raw, config.Raw...)
+	}
+	return configvalidation.ConfigHash(&apiextensionsv1.JSON{Raw: raw})
+}
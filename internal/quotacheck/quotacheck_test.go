@@ -0,0 +1,92 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quotacheck
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/configvalidation"
+	"github.com/K0rdent/kcm/internal/providers"
+)
+
+func TestRequestedTopology(t *testing.T) {
+	template := &kcm.ClusterTemplate{
+		Status: kcm.ClusterTemplateStatus{
+			TemplateStatusCommon: kcm.TemplateStatusCommon{
+				Config: &apiextensionsv1.JSON{Raw: []byte(`{"region":"","controlPlaneNumber":3,"workersNumber":2,"controlPlane":{"instanceType":""},"worker":{"instanceType":"t3.small"}}`)},
+			},
+		},
+	}
+	config := &apiextensionsv1.JSON{Raw: []byte(`{"region":"us-east-1","workersNumber":4,"worker":{"instanceType":"t3.large"}}`)}
+
+	topology, err := RequestedTopology(template, config, "region")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &Topology{
+		Region:        "us-east-1",
+		Machines:      7,
+		InstanceTypes: map[string]int{"t3.large": 4},
+	}
+	if !reflect.DeepEqual(topology, expected) {
+		t.Errorf("unexpected topology:\ngot:  %+v\nwant: %+v", topology, expected)
+	}
+}
+
+func TestJob(t *testing.T) {
+	cd := &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a", UID: "cd-uid"},
+		Spec:       kcm.ClusterDeploymentSpec{Credential: "aws-cred"},
+	}
+	config := &apiextensionsv1.JSON{Raw: []byte(`{"region":"us-east-1"}`)}
+	topology := &Topology{Region: "us-east-1", Machines: 3, InstanceTypes: map[string]int{"t3.large": 3}}
+
+	job, err := NewJob(cd, "infrastructure-aws", &providers.ConfigValidationJob{Image: "quota:latest"}, config, topology)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if job.Name != "dev-quota-check" || job.Namespace != cd.Namespace {
+		t.Errorf("unexpected Job key %s/%s", job.Namespace, job.Name)
+	}
+	if job.Annotations[configvalidation.ConfigHashAnnotation] != ConfigHash(config, topology) {
+		t.Errorf("unexpected config hash %q", job.Annotations[configvalidation.ConfigHashAnnotation])
+	}
+	if ConfigHash(config, topology) == ConfigHash(config, &Topology{Region: "us-east-1", Machines: 5}) {
+		t.Errorf("expected the hashes of different topologies to differ")
+	}
+
+	env := make(map[string]string)
+	for _, e := range job.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	actual := new(Topology)
+	if err := json.Unmarshal([]byte(env[TopologyEnvVar]), actual); err != nil {
+		t.Fatalf("failed to parse topology: %v", err)
+	}
+	if !reflect.DeepEqual(actual, topology) {
+		t.Errorf("unexpected topology in the environment:\ngot:  %+v\nwant: %+v", actual, topology)
+	}
+	if env[configvalidation.ConfigEnvVar] != string(config.Raw) || env["CLUSTER_DEPLOYMENT_CREDENTIAL"] != "aws-cred" {
+		t.Errorf("unexpected environment %v", env)
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package readinessgates evaluates the readiness gates of the ClusterDeployments: the readiness
+// of the nodes, the health of the selected services and the custom HTTP and Job probes
+// run against the clusters.
+package readinessgates
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/configvalidation"
+	"github.com/K0rdent/kcm/internal/statemanagement"
+)
+
+const (
+	// NodesReadyGate is the name of the gate requiring all of the nodes of the cluster to be ready.
+	NodesReadyGate = "NodesReady"
+
+	servicePrefix = "service/"
+	probePrefix   = "probe/"
+
+	// ProbeHashAnnotation is the annotation on the Jobs of the probes holding the hash
+	// of the probe and the generation of the ClusterDeployment the Job has been run for.
+	ProbeHashAnnotation = "k0rdent.mirantis.com/readiness-probe-hash"
+
+	jobNamePrefix    = "kcm-readiness-"
+	defaultNamespace = "kube-system"
+
+	httpTimeout = 10 * time.Second
+	// maxMessageLength is the maximum length of the response body reported in the message of a failed HTTP probe.
+	maxMessageLength = 256
+)
+
+// Evaluate evaluates the readiness gates of the given ClusterDeployment against its cluster
+// reachable with the given configuration and client.
+// The failures to reach the cluster are reported as the failed gates.
+func Evaluate(ctx context.Context, cd *kcm.ClusterDeployment, cfg *rest.Config, cl client.Client) []kcm.ReadinessGateStatus {
+	gates := cd.Spec.ReadinessGates
+	if gates == nil {
+		return nil
+	}
+
+	var statuses []kcm.ReadinessGateStatus
+	if gates.NodesReady {
+		statuses = append(statuses, nodesReady(ctx, cl))
+	}
+	for _, name := range gates.Services {
+		statuses = append(statuses, serviceHealthy(cd, name))
+	}
+	for _, probe := range gates.Probes {
+		status := kcm.ReadinessGateStatus{Name: probePrefix + probe.Name}
+		switch {
+		case probe.HTTP != nil:
+			status.Passed, status.Message = httpProbe(ctx, cfg, probe.HTTP)
+		case probe.Job != nil:
+			status.Passed, status.Message = jobProbe(ctx, cl, cd, probe.Name, probe.Job)
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+// Polled returns true if any of the given gates must be polled, i.e. the HTTP probes,
+// the changes of the nodes and the Jobs of the cluster are watched.
+func Polled(gates *kcm.ClusterReadinessGates) bool {
+	for _, probe := range gates.Probes {
+		if probe.HTTP != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Passed returns true if all of the given gates have passed.
+func Passed(statuses []kcm.ReadinessGateStatus) bool {
+	for _, status := range statuses {
+		if !status.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Condition returns the ReadinessGatesPassed condition reflecting the given results of the gates.
+func Condition(statuses []kcm.ReadinessGateStatus) metav1.Condition {
+	condition := metav1.Condition{
+		Type:    kcm.ReadinessGatesPassedCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  kcm.SucceededReason,
+		Message: fmt.Sprintf("%d/%d readiness gates passed", len(statuses), len(statuses)),
+	}
+
+	var failed []string
+	for _, status := range statuses {
+		if !status.Passed {
+			failed = append(failed, status.Name+": "+status.Message)
+		}
+	}
+	if len(failed) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = kcm.FailedReason
+		condition.Message = fmt.Sprintf("%d/%d readiness gates passed, waiting for %s",
+			len(statuses)-len(failed), len(statuses), strings.Join(failed, "; "))
+	}
+
+	return condition
+}
+
+// nodesReady checks whether all of the nodes of the cluster are ready.
+func nodesReady(ctx context.Context, cl client.Client) kcm.ReadinessGateStatus {
+	status := kcm.ReadinessGateStatus{Name: NodesReadyGate}
+
+	nodes := new(corev1.NodeList)
+	if err := cl.List(ctx, nodes); err != nil {
+		status.Message = fmt.Sprintf("failed to list nodes: %v", err)
+		return status
+	}
+
+	ready := 0
+	for _, node := range nodes.Items {
+		for _, c := range node.Status.Conditions {
+			if c.Type == corev1.NodeReady && c.Status == corev1.ConditionTrue {
+				ready++
+				break
+			}
+		}
+	}
+
+	status.Passed = len(nodes.Items) > 0 && ready == len(nodes.Items)
+	status.Message = fmt.Sprintf("%d/%d nodes are ready", ready, len(nodes.Items))
+	return status
+}
+
+// serviceHealthy checks whether the release of the service with the given name is ready
+// as reported in the status of the given ClusterDeployment.
+func serviceHealthy(cd *kcm.ClusterDeployment, name string) kcm.ReadinessGateStatus {
+	status := kcm.ReadinessGateStatus{Name: servicePrefix + name, Message: "Service is not deployed"}
+
+	for _, svc := range cd.Status.Services {
+		for _, c := range svc.Conditions {
+			// the condition types of the releases are <namespace>.<name>/<type>
+			_, release, _ := strings.Cut(c.Type, ".")
+			releaseName, _, _ := strings.Cut(release, "/")
+			if releaseName != name || !statemanagement.IsServiceCondition(c.Type) {
+				continue
+			}
+
+			status.Passed = c.Status == metav1.ConditionTrue
+			status.Message = c.Message
+			if status.Passed && status.Message == "" {
+				status.Message = "Service is healthy"
+			}
+			return status
+		}
+	}
+
+	return status
+}
+
+// httpProbe requests the path of the given probe from the API server of the cluster.
+func httpProbe(ctx context.Context, cfg *rest.Config, probe *kcm.HTTPReadinessProbe) (passed bool, message string) {
+	httpClient, err := rest.HTTPClientFor(cfg)
+	if err != nil {
+		return false, fmt.Sprintf("failed to create HTTP client: %v", err)
+	}
+	httpClient.Timeout = httpTimeout
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(cfg.Host, "/")+probe.Path, http.NoBody)
+	if err != nil {
+		return false, fmt.Sprintf("failed to create request: %v", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Sprintf("failed to request %s: %v", probe.Path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxMessageLength))
+		message = fmt.Sprintf("%s responded with %s", probe.Path, resp.Status)
+		if s := strings.TrimSpace(string(body)); s != "" {
+			message += ": " + s
+		}
+		return false, message
+	}
+
+	return true, fmt.Sprintf("%s responded with %s", probe.Path, resp.Status)
+}
+
+// jobProbe runs the Job of the given probe in the cluster once per generation of the given ClusterDeployment
+// and checks whether the Job has completed successfully.
+func jobProbe(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment, name string, probe *kcm.JobReadinessProbe) (passed bool, message string) {
+	desired := newJob(cd, name, probe)
+
+	job := new(batchv1.Job)
+	err := cl.Get(ctx, client.ObjectKeyFromObject(desired), job)
+	switch {
+	case apierrors.IsNotFound(err):
+		// the Job read from the cache of the cluster could have been created meanwhile
+		if err := cl.Create(ctx, desired); err != nil && !apierrors.IsAlreadyExists(err) {
+			return false, fmt.Sprintf("failed to create Job %s: %v", client.ObjectKeyFromObject(desired), err)
+		}
+		return false, "Job has been started"
+	case err != nil:
+		return false, fmt.Sprintf("failed to get Job %s: %v", client.ObjectKeyFromObject(desired), err)
+	case job.Annotations[ProbeHashAnnotation] != desired.Annotations[ProbeHashAnnotation]:
+		if err := cl.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return false, fmt.Sprintf("failed to delete outdated Job %s: %v", client.ObjectKeyFromObject(job), err)
+		}
+		return false, "Job is being restarted"
+	}
+
+	finished, failure := configvalidation.JobResult(job)
+	switch {
+	case !finished:
+		return false, "Waiting for the Job to complete"
+	case failure != "":
+		return false, "Job has failed: " + failure
+	}
+
+	return true, "Job has completed"
+}
+
+// newJob returns the Job of the probe with the given name run for the current generation of the given ClusterDeployment.
+func newJob(cd *kcm.ClusterDeployment, name string, probe *kcm.JobReadinessProbe) *batchv1.Job {
+	namespace := probe.Namespace
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        jobNamePrefix + name,
+			Namespace:   namespace,
+			Labels:      map[string]string{kcm.KCMManagedLabelKey: kcm.KCMManagedLabelValue},
+			Annotations: map[string]string{ProbeHashAnnotation: probeHash(cd, probe)},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:                     "probe",
+						Image:                    probe.Image,
+						Command:                  probe.Command,
+						TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+					}},
+				},
+			},
+		},
+	}
+}
+
+// probeHash returns the hash of the given probe and the generation of the given ClusterDeployment.
+func probeHash(cd *kcm.ClusterDeployment, probe *kcm.JobReadinessProbe) string {
+	raw, _ := json.Marshal(probe)
+	raw = strconv.AppendInt(raw, cd.Generation, 10)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,194 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readinessgates
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newNode(name string, ready corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{corev1.AddToScheme, batchv1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatalf("failed to build scheme: %v", err)
+		}
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/readyz" {
+			_, _ = w.Write([]byte("ok"))
+			return
+		}
+		http.Error(w, "backend unavailable", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	cfg := &rest.Config{Host: srv.URL}
+
+	cd := &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a", Generation: 2},
+		Spec: kcm.ClusterDeploymentSpec{
+			ReadinessGates: &kcm.ClusterReadinessGates{
+				NodesReady: true,
+				Services:   []string{"ingress-nginx", "cert-manager", "velero"},
+				Probes: []kcm.ReadinessProbe{
+					{Name: "apiserver", HTTP: &kcm.HTTPReadinessProbe{Path: "/readyz"}},
+					{Name: "app", HTTP: &kcm.HTTPReadinessProbe{Path: "/api/v1/namespaces/app/services/app:80/proxy/healthz"}},
+					{Name: "smoke", Job: &kcm.JobReadinessProbe{Image: "busybox", Command: []string{"true"}}},
+				},
+			},
+		},
+		Status: kcm.ClusterDeploymentStatus{
+			Services: []kcm.ServiceStatus{{
+				ClusterName: "dev",
+				Conditions: []metav1.Condition{
+					{Type: "ingress-nginx.ingress-nginx/" + kcm.SveltosHelmReleaseReadyCondition, Status: metav1.ConditionTrue},
+					{Type: "cert-manager.cert-manager/" + kcm.SveltosHelmReleaseReadyCondition, Status: metav1.ConditionFalse, Message: "Release cert-manager/cert-manager: install failed"},
+				},
+			}},
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newNode("dev-cp-0", corev1.ConditionTrue),
+		newNode("dev-worker-0", corev1.ConditionFalse),
+	).Build()
+
+	statuses := Evaluate(t.Context(), cd, cfg, cl)
+	expected := []kcm.ReadinessGateStatus{
+		{Name: NodesReadyGate, Message: "1/2 nodes are ready"},
+		{Name: "service/ingress-nginx", Message: "Service is healthy", Passed: true},
+		{Name: "service/cert-manager", Message: "Release cert-manager/cert-manager: install failed"},
+		{Name: "service/velero", Message: "Service is not deployed"},
+		{Name: "probe/apiserver", Message: "/readyz responded with 200 OK", Passed: true},
+		{Name: "probe/app", Message: "/api/v1/namespaces/app/services/app:80/proxy/healthz responded with 503 Service Unavailable: backend unavailable"},
+		{Name: "probe/smoke", Message: "Job has been started"},
+	}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("unexpected statuses:\ngot:  %+v\nwant: %+v", statuses, expected)
+	}
+	if Passed(statuses) {
+		t.Errorf("expected the gates not to pass")
+	}
+
+	job := new(batchv1.Job)
+	if err := cl.Get(t.Context(), client.ObjectKey{Namespace: "kube-system", Name: "kcm-readiness-smoke"}, job); err != nil {
+		t.Fatalf("failed to get the Job of the probe: %v", err)
+	}
+	if image := job.Spec.Template.Spec.Containers[0].Image; image != "busybox" {
+		t.Errorf("expected the busybox image, got %s", image)
+	}
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if err := cl.Status().Update(t.Context(), job); err != nil {
+		t.Fatalf("failed to update the Job: %v", err)
+	}
+	if passed, message := jobProbe(t.Context(), cl, cd, "smoke", cd.Spec.ReadinessGates.Probes[2].Job); !passed {
+		t.Errorf("expected the completed Job to pass, got %q", message)
+	}
+
+	cd.Generation++
+	if passed, message := jobProbe(t.Context(), cl, cd, "smoke", cd.Spec.ReadinessGates.Probes[2].Job); passed || message != "Job is being restarted" {
+		t.Errorf("expected the Job to be restarted on the change of the ClusterDeployment, got %t %q", passed, message)
+	}
+}
+
+func TestCondition(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		statuses []kcm.ReadinessGateStatus
+		expected metav1.Condition
+	}{
+		{
+			name:     "passed",
+			statuses: []kcm.ReadinessGateStatus{{Name: NodesReadyGate, Passed: true}, {Name: "probe/apiserver", Passed: true}},
+			expected: metav1.Condition{
+				Type:    kcm.ReadinessGatesPassedCondition,
+				Status:  metav1.ConditionTrue,
+				Reason:  kcm.SucceededReason,
+				Message: "2/2 readiness gates passed",
+			},
+		},
+		{
+			name: "failed",
+			statuses: []kcm.ReadinessGateStatus{
+				{Name: NodesReadyGate, Message: "1/2 nodes are ready"},
+				{Name: "probe/apiserver", Passed: true},
+				{Name: "probe/smoke", Message: "Waiting for the Job to complete"},
+			},
+			expected: metav1.Condition{
+				Type:    kcm.ReadinessGatesPassedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  kcm.FailedReason,
+				Message: "1/3 readiness gates passed, waiting for NodesReady: 1/2 nodes are ready; probe/smoke: Waiting for the Job to complete",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if condition := Condition(tc.statuses); !reflect.DeepEqual(condition, tc.expected) {
+				t.Errorf("unexpected condition:\ngot:  %+v\nwant: %+v", condition, tc.expected)
+			}
+		})
+	}
+}
+
+func TestPolled(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		gates    *kcm.ClusterReadinessGates
+		expected bool
+	}{
+		{name: "nodes", gates: &kcm.ClusterReadinessGates{NodesReady: true}},
+		{
+			name: "job",
+			gates: &kcm.ClusterReadinessGates{Probes: []kcm.ReadinessProbe{
+				{Name: "smoke", Job: &kcm.JobReadinessProbe{}},
+			}},
+		},
+		{
+			name: "http",
+			gates: &kcm.ClusterReadinessGates{Probes: []kcm.ReadinessProbe{
+				{Name: "smoke", Job: &kcm.JobReadinessProbe{}},
+				{Name: "apiserver", HTTP: &kcm.HTTPReadinessProbe{Path: "/readyz"}},
+			}},
+			expected: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if polled := Polled(tc.gates); polled != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, polled)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registrycredentials distributes the image pull secret of the Management to the clusters
+// and adds it to the default ServiceAccounts of the namespaces of the clusters.
+package registrycredentials
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// ManagedLabel is the label on the pull secrets created in the clusters.
+	ManagedLabel = "k0rdent.mirantis.com/registry-credentials"
+	// hashAnnotation is the annotation on the pull secrets created in the clusters holding the hash of the credentials.
+	hashAnnotation = "k0rdent.mirantis.com/registry-credentials-hash"
+
+	defaultServiceAccount = "default"
+)
+
+// Source returns the Secret holding the credentials of the given configuration in the system namespace.
+func Source(ctx context.Context, cl client.Client, config *kcm.RegistryCredentials, systemNamespace string) (*corev1.Secret, error) {
+	secret := new(corev1.Secret)
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: systemNamespace, Name: config.SecretName}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get registry credentials Secret %s/%s: %w", systemNamespace, config.SecretName, err)
+	}
+	if secret.Type != corev1.SecretTypeDockerConfigJson {
+		return nil, fmt.Errorf("registry credentials Secret %s/%s must be of the %s type", systemNamespace, config.SecretName, corev1.SecretTypeDockerConfigJson)
+	}
+	return secret, nil
+}
+
+// Reconcile creates the pull secret with the credentials of the given source Secret in the namespaces
+// of the cluster of the given client, adds it to the default ServiceAccounts if configured and removes
+// the pull secrets from the namespaces no longer configured or under the previous name.
+func Reconcile(ctx context.Context, cl client.Client, config *kcm.RegistryCredentials, source *corev1.Secret) error {
+	sum := sha256.Sum256(source.Data[corev1.DockerConfigJsonKey])
+	hash := hex.EncodeToString(sum[:])
+
+	for _, namespace := range config.Namespaces {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		if err := cl.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create namespace %s: %w", namespace, err)
+		}
+
+		if err := reconcileSecret(ctx, cl, namespace, config.SecretName, source, hash); err != nil {
+			return err
+		}
+
+		if config.PatchDefaultServiceAccounts {
+			if err := patchServiceAccount(ctx, cl, namespace, func(secrets []corev1.LocalObjectReference) []corev1.LocalObjectReference {
+				if slices.Contains(secrets, corev1.LocalObjectReference{Name: config.SecretName}) {
+					return secrets
+				}
+				return append(secrets, corev1.LocalObjectReference{Name: config.SecretName})
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return removeStale(ctx, cl, func(secret *corev1.Secret) bool {
+		return secret.Name == config.SecretName && slices.Contains(config.Namespaces, secret.Namespace)
+	}, config.PatchDefaultServiceAccounts)
+}
+
+// Remove removes the pull secrets from the cluster of the given client
+// and from the image pull secrets of the default ServiceAccounts.
+func Remove(ctx context.Context, cl client.Client) error {
+	return removeStale(ctx, cl, func(*corev1.Secret) bool { return false }, false)
+}
+
+// reconcileSecret creates or updates the pull secret in the given namespace unless its credentials are up to date.
+// The existing Secrets not created by KCM are not overwritten.
+func reconcileSecret(ctx context.Context, cl client.Client, namespace, name string, source *corev1.Secret, hash string) error {
+	desired := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      map[string]string{ManagedLabel: "true"},
+			Annotations: map[string]string{hashAnnotation: hash},
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{corev1.DockerConfigJsonKey: source.Data[corev1.DockerConfigJsonKey]},
+	}
+
+	existing := new(corev1.Secret)
+	err := cl.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		if err := cl.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create pull secret %s/%s: %w", namespace, name, err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to get pull secret %s/%s: %w", namespace, name, err)
+	}
+
+	if existing.Labels[ManagedLabel] != "true" {
+		return fmt.Errorf("pull secret %s/%s already exists and is not managed by KCM", namespace, name)
+	}
+	if existing.Annotations[hashAnnotation] == hash {
+		return nil
+	}
+
+	desired.ResourceVersion = existing.ResourceVersion
+	if err := cl.Update(ctx, desired); err != nil {
+		return fmt.Errorf("failed to update pull secret %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// removeStale deletes the pull secrets created by KCM not matching the given predicate and removes them
+// from the default ServiceAccounts, the pull secrets matching the predicate are kept in the ServiceAccounts
+// only if keepInServiceAccounts is set.
+func removeStale(ctx context.Context, cl client.Client, keep func(*corev1.Secret) bool, keepInServiceAccounts bool) error {
+	secrets := new(corev1.SecretList)
+	if err := cl.List(ctx, secrets, client.MatchingLabels{ManagedLabel: "true"}); err != nil {
+		return fmt.Errorf("failed to list pull secrets: %w", err)
+	}
+
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		kept := keep(secret)
+		if !kept || !keepInServiceAccounts {
+			if err := patchServiceAccount(ctx, cl, secret.Namespace, func(refs []corev1.LocalObjectReference) []corev1.LocalObjectReference {
+				return slices.DeleteFunc(refs, func(ref corev1.LocalObjectReference) bool { return ref.Name == secret.Name })
+			}); err != nil {
+				return err
+			}
+		}
+		if kept {
+			continue
+		}
+		if err := cl.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete pull secret %s/%s: %w", secret.Namespace, secret.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// patchServiceAccount updates the image pull secrets of the default ServiceAccount of the given namespace.
+// The ServiceAccount not created yet is reported as an error to be retried.
+func patchServiceAccount(ctx context.Context, cl client.Client, namespace string, update func([]corev1.LocalObjectReference) []corev1.LocalObjectReference) error {
+	sa := new(corev1.ServiceAccount)
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: defaultServiceAccount}, sa); err != nil {
+		return fmt.Errorf("failed to get ServiceAccount %s/%s: %w", namespace, defaultServiceAccount, err)
+	}
+
+	patch := client.MergeFrom(sa.DeepCopy())
+	secrets := update(slices.Clone(sa.ImagePullSecrets))
+	if slices.Equal(secrets, sa.ImagePullSecrets) {
+		return nil
+	}
+
+	sa.ImagePullSecrets = secrets
+	if err := cl.Patch(ctx, sa, patch); err != nil {
+		return fmt.Errorf("failed to patch ServiceAccount %s/%s: %w", namespace, defaultServiceAccount, err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registrycredentials
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func newServiceAccount(namespace string, pullSecrets ...string) *corev1.ServiceAccount {
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: defaultServiceAccount, Namespace: namespace}}
+	for _, name := range pullSecrets {
+		sa.ImagePullSecrets = append(sa.ImagePullSecrets, corev1.LocalObjectReference{Name: name})
+	}
+	return sa
+}
+
+func pullSecrets(t *testing.T, cl client.Client, namespace string) []string {
+	t.Helper()
+
+	sa := new(corev1.ServiceAccount)
+	if err := cl.Get(t.Context(), client.ObjectKey{Namespace: namespace, Name: defaultServiceAccount}, sa); err != nil {
+		t.Fatalf("failed to get ServiceAccount: %v", err)
+	}
+	names := make([]string, 0, len(sa.ImagePullSecrets))
+	for _, ref := range sa.ImagePullSecrets {
+		names = append(names, ref.Name)
+	}
+	return names
+}
+
+func TestSource(t *testing.T) {
+	config := &kcm.RegistryCredentials{SecretName: "registry"}
+	opaque := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "kcm-system"}, Type: corev1.SecretTypeOpaque}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(opaque).Build()
+	if _, err := Source(t.Context(), cl, config, "kcm-system"); err == nil {
+		t.Fatal("expected error for the Secret of the wrong type")
+	}
+
+	cl = fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	if _, err := Source(t.Context(), cl, config, "kcm-system"); err == nil {
+		t.Fatal("expected error for the missing Secret")
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := t.Context()
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "kcm-system"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
+	}
+	config := &kcm.RegistryCredentials{
+		SecretName:                  "registry",
+		Namespaces:                  []string{"default", "apps"},
+		PatchDefaultServiceAccounts: true,
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		newServiceAccount("default", "other"),
+		newServiceAccount("apps"),
+		newServiceAccount("legacy", "registry"),
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "legacy", Labels: map[string]string{ManagedLabel: "true"}},
+		},
+	).Build()
+
+	if err := Reconcile(ctx, cl, config, source); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, namespace := range config.Namespaces {
+		secret := new(corev1.Secret)
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "registry"}, secret); err != nil {
+			t.Fatalf("expected pull secret in %s: %v", namespace, err)
+		}
+		if string(secret.Data[corev1.DockerConfigJsonKey]) != `{"auths":{}}` || secret.Type != corev1.SecretTypeDockerConfigJson {
+			t.Errorf("unexpected pull secret in %s: %+v", namespace, secret)
+		}
+	}
+	if got := pullSecrets(t, cl, "default"); len(got) != 2 || got[0] != "other" || got[1] != "registry" {
+		t.Errorf("unexpected image pull secrets in default: %v", got)
+	}
+	if got := pullSecrets(t, cl, "apps"); len(got) != 1 || got[0] != "registry" {
+		t.Errorf("unexpected image pull secrets in apps: %v", got)
+	}
+
+	// the namespaces no longer configured are cleaned up
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: "legacy", Name: "registry"}, new(corev1.Secret)); err == nil {
+		t.Error("expected stale pull secret to be deleted")
+	}
+	if got := pullSecrets(t, cl, "legacy"); len(got) != 0 {
+		t.Errorf("expected stale pull secret to be removed from the ServiceAccount, got %v", got)
+	}
+
+	// the Secrets not created by KCM are not overwritten
+	foreign := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "foreign"}}
+	cl = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(foreign, newServiceAccount("foreign")).Build()
+	if err := Reconcile(ctx, cl, &kcm.RegistryCredentials{SecretName: "registry", Namespaces: []string{"foreign"}}, source); err == nil {
+		t.Error("expected error for the existing Secret not managed by KCM")
+	}
+}
+
+func TestRemove(t *testing.T) {
+	ctx := t.Context()
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		newServiceAccount("default", "registry", "other"),
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "default", Labels: map[string]string{ManagedLabel: "true"}},
+		},
+	).Build()
+
+	if err := Remove(ctx, cl); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: "default", Name: "registry"}, new(corev1.Secret)); err == nil {
+		t.Error("expected pull secret to be deleted")
+	}
+	if got := pullSecrets(t, cl, "default"); len(got) != 1 || got[0] != "other" {
+		t.Errorf("unexpected image pull secrets: %v", got)
+	}
+}
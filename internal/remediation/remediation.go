@@ -0,0 +1,182 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remediation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// PausedAnnotation is set on the MachineHealthChecks paused by KCM so that
+	// only the pause set by KCM is lifted once the remediation is resumed.
+	PausedAnnotation = "k0rdent.mirantis.com/remediation-paused"
+
+	// maxRemediations is the number of the most recent remediations kept in the status.
+	maxRemediations = 10
+)
+
+// Reconcile applies the remediation configuration of the given ClusterDeployment
+// to the MachineHealthChecks of the cluster and returns the observed remediation status.
+// Returns nil if the cluster has neither MachineHealthChecks nor recorded remediations.
+func Reconcile(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) (*kcm.RemediationStatus, error) {
+	mhcs := new(clusterv1.MachineHealthCheckList)
+	if err := cl.List(ctx, mhcs, client.InNamespace(cd.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list MachineHealthChecks: %w", err)
+	}
+
+	status := new(kcm.RemediationStatus)
+	found := false
+	for i := range mhcs.Items {
+		mhc := &mhcs.Items[i]
+		if mhc.Spec.ClusterName != cd.Name {
+			continue
+		}
+		found = true
+
+		if err := applyConfig(ctx, cl, mhc, cd.Spec.Remediation); err != nil {
+			return nil, err
+		}
+
+		if _, ok := mhc.Annotations[clusterv1.PausedAnnotation]; ok {
+			status.Paused = true
+		}
+		if unhealthy := mhc.Status.ExpectedMachines - mhc.Status.CurrentHealthy; unhealthy > 0 {
+			status.UnhealthyMachines += unhealthy
+		}
+		status.RemediationsAllowed += mhc.Status.RemediationsAllowed
+	}
+
+	var previous []kcm.MachineRemediation
+	if cd.Status.Remediation != nil {
+		previous = cd.Status.Remediation.Remediations
+	}
+
+	remediations, err := listRemediations(ctx, cl, cd)
+	if err != nil {
+		return nil, err
+	}
+	status.Remediations = mergeRemediations(previous, remediations)
+
+	if !found && len(status.Remediations) == 0 {
+		return nil, nil
+	}
+
+	return status, nil
+}
+
+// applyConfig pauses or resumes the remediation of the given MachineHealthCheck
+// and overrides its maxUnhealthy according to the given configuration.
+func applyConfig(ctx context.Context, cl client.Client, mhc *clusterv1.MachineHealthCheck, cfg *kcm.ClusterRemediation) error {
+	original := mhc.DeepCopy()
+
+	_, pausedByKCM := mhc.Annotations[PausedAnnotation]
+	switch {
+	case cfg != nil && cfg.Paused:
+		if _, ok := mhc.Annotations[clusterv1.PausedAnnotation]; !ok {
+			if mhc.Annotations == nil {
+				mhc.Annotations = make(map[string]string)
+			}
+			mhc.Annotations[clusterv1.PausedAnnotation] = ""
+			mhc.Annotations[PausedAnnotation] = ""
+		}
+	case pausedByKCM:
+		delete(mhc.Annotations, clusterv1.PausedAnnotation)
+		delete(mhc.Annotations, PausedAnnotation)
+	}
+
+	if cfg != nil && cfg.MaxInFlight != nil {
+		maxInFlight := *cfg.MaxInFlight
+		mhc.Spec.MaxUnhealthy = &maxInFlight
+	}
+
+	if err := cl.Patch(ctx, mhc, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to patch MachineHealthCheck %s/%s: %w", mhc.Namespace, mhc.Name, err)
+	}
+
+	return nil
+}
+
+// listRemediations returns the remediations of the machines of the cluster failed the health checks.
+func listRemediations(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) ([]kcm.MachineRemediation, error) {
+	machines := new(clusterv1.MachineList)
+	if err := cl.List(ctx, machines, client.InNamespace(cd.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cd.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list Machines: %w", err)
+	}
+
+	var remediations []kcm.MachineRemediation
+	for _, machine := range machines.Items {
+		var healthCheck, ownerRemediated *clusterv1.Condition
+		for i, c := range machine.Status.Conditions {
+			switch c.Type {
+			case clusterv1.MachineHealthCheckSucceededCondition:
+				healthCheck = &machine.Status.Conditions[i]
+			case clusterv1.MachineOwnerRemediatedCondition:
+				ownerRemediated = &machine.Status.Conditions[i]
+			}
+		}
+		if healthCheck == nil || ownerRemediated == nil || healthCheck.Status != corev1.ConditionFalse {
+			continue
+		}
+
+		remediations = append(remediations, kcm.MachineRemediation{
+			Time:    healthCheck.LastTransitionTime,
+			Machine: machine.Name,
+			Reason:  healthCheck.Reason,
+			Message: healthCheck.Message,
+		})
+	}
+
+	return remediations, nil
+}
+
+// mergeRemediations merges the observed remediations into the previously recorded ones
+// keeping the latest remediation per machine, the most recent remediations go first.
+func mergeRemediations(previous, observed []kcm.MachineRemediation) []kcm.MachineRemediation {
+	byMachine := make(map[string]kcm.MachineRemediation, len(previous)+len(observed))
+	for _, r := range append(append([]kcm.MachineRemediation{}, previous...), observed...) {
+		if existing, ok := byMachine[r.Machine]; ok && existing.Time.After(r.Time.Time) {
+			continue
+		}
+		byMachine[r.Machine] = r
+	}
+
+	merged := make([]kcm.MachineRemediation, 0, len(byMachine))
+	for _, r := range byMachine {
+		merged = append(merged, r)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if !merged[i].Time.Equal(&merged[j].Time) {
+			return merged[j].Time.Before(&merged[i].Time)
+		}
+		return merged[i].Machine < merged[j].Machine
+	})
+
+	if len(merged) > maxRemediations {
+		merged = merged[:maxRemediations]
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+
+	return merged
+}
@@ -0,0 +1,206 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remediation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newMachine(name string, failedAt time.Time, reason string) *clusterv1.Machine {
+	return &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "team-a",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "dev"},
+		},
+		Status: clusterv1.MachineStatus{
+			Conditions: clusterv1.Conditions{
+				{
+					Type:               clusterv1.MachineHealthCheckSucceededCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             reason,
+					Message:            "Node failed to report startup",
+					LastTransitionTime: metav1.NewTime(failedAt),
+				},
+				{
+					Type:   clusterv1.MachineOwnerRemediatedCondition,
+					Status: corev1.ConditionFalse,
+				},
+			},
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clusterv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	mhc := &clusterv1.MachineHealthCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev-workers", Namespace: "team-a"},
+		Spec:       clusterv1.MachineHealthCheckSpec{ClusterName: "dev"},
+		Status: clusterv1.MachineHealthCheckStatus{
+			ExpectedMachines:    3,
+			CurrentHealthy:      1,
+			RemediationsAllowed: 1,
+		},
+	}
+	other := &clusterv1.MachineHealthCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod-workers", Namespace: "team-a"},
+		Spec:       clusterv1.MachineHealthCheckSpec{ClusterName: "prod"},
+	}
+	healthy := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dev-md-healthy",
+			Namespace: "team-a",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "dev"},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		mhc, other, healthy,
+		newMachine("dev-md-a", now.Add(-time.Minute), clusterv1.NodeStartupTimeoutReason),
+		newMachine("dev-md-b", now, clusterv1.UnhealthyNodeConditionReason),
+	).Build()
+
+	maxInFlight := intstr.FromString("40%")
+	cd := &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"},
+		Spec: kcm.ClusterDeploymentSpec{
+			Remediation: &kcm.ClusterRemediation{MaxInFlight: &maxInFlight, Paused: true},
+		},
+		Status: kcm.ClusterDeploymentStatus{
+			Remediation: &kcm.RemediationStatus{
+				Remediations: []kcm.MachineRemediation{
+					{Machine: "dev-md-old", Reason: clusterv1.UnhealthyNodeConditionReason, Time: metav1.NewTime(now.Add(-time.Hour))},
+				},
+			},
+		},
+	}
+
+	status, err := Reconcile(t.Context(), cl, cd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &kcm.RemediationStatus{
+		Remediations: []kcm.MachineRemediation{
+			{Machine: "dev-md-b", Reason: clusterv1.UnhealthyNodeConditionReason, Message: "Node failed to report startup", Time: metav1.NewTime(now)},
+			{Machine: "dev-md-a", Reason: clusterv1.NodeStartupTimeoutReason, Message: "Node failed to report startup", Time: metav1.NewTime(now.Add(-time.Minute))},
+			{Machine: "dev-md-old", Reason: clusterv1.UnhealthyNodeConditionReason, Time: metav1.NewTime(now.Add(-time.Hour))},
+		},
+		UnhealthyMachines:   2,
+		RemediationsAllowed: 1,
+		Paused:              true,
+	}
+	if !reflect.DeepEqual(status, expected) {
+		t.Errorf("unexpected status %+v, expected %+v", status, expected)
+	}
+
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(mhc), mhc); err != nil {
+		t.Fatalf("failed to get MachineHealthCheck: %v", err)
+	}
+	if _, ok := mhc.Annotations[clusterv1.PausedAnnotation]; !ok {
+		t.Errorf("expected MachineHealthCheck to be paused")
+	}
+	if mhc.Spec.MaxUnhealthy == nil || *mhc.Spec.MaxUnhealthy != maxInFlight {
+		t.Errorf("unexpected maxUnhealthy %v, expected %v", mhc.Spec.MaxUnhealthy, maxInFlight)
+	}
+
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(other), other); err != nil {
+		t.Fatalf("failed to get MachineHealthCheck: %v", err)
+	}
+	if _, ok := other.Annotations[clusterv1.PausedAnnotation]; ok {
+		t.Errorf("expected MachineHealthCheck of another cluster not to be paused")
+	}
+
+	cd.Spec.Remediation = nil
+	status, err = Reconcile(t.Context(), cl, cd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.Paused {
+		t.Errorf("expected remediation not to be reported as paused")
+	}
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(mhc), mhc); err != nil {
+		t.Fatalf("failed to get MachineHealthCheck: %v", err)
+	}
+	if _, ok := mhc.Annotations[clusterv1.PausedAnnotation]; ok {
+		t.Errorf("expected MachineHealthCheck to be resumed")
+	}
+}
+
+func TestReconcileKeepsUserPause(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clusterv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	mhc := &clusterv1.MachineHealthCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "dev-workers",
+			Namespace:   "team-a",
+			Annotations: map[string]string{clusterv1.PausedAnnotation: ""},
+		},
+		Spec: clusterv1.MachineHealthCheckSpec{ClusterName: "dev"},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mhc).Build()
+
+	cd := &kcm.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"}}
+	status, err := Reconcile(t.Context(), cl, cd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(status, &kcm.RemediationStatus{Paused: true}) {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(mhc), mhc); err != nil {
+		t.Fatalf("failed to get MachineHealthCheck: %v", err)
+	}
+	if _, ok := mhc.Annotations[clusterv1.PausedAnnotation]; !ok {
+		t.Errorf("expected pause set outside of KCM to be kept")
+	}
+}
+
+func TestReconcileNoHealthChecks(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clusterv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	cd := &kcm.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"}}
+	status, err := Reconcile(t.Context(), cl, cd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != nil {
+		t.Errorf("unexpected status %+v, expected none", status)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rollback records the last known-good revisions of the ClusterDeployments
+// and reverts the failing upgrades of the clusters to them on request.
+package rollback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// Requested returns true if the rollback of the given ClusterDeployment is requested with the annotation.
+func Requested(cd *kcm.ClusterDeployment) bool {
+	_, ok := cd.Annotations[kcm.RollbackAnnotation]
+	return ok
+}
+
+// Record records the template and the configuration the given ClusterDeployment
+// has been successfully deployed with as its last known-good revision.
+func Record(cd *kcm.ClusterDeployment, template *kcm.ClusterTemplate, now time.Time) {
+	if current := cd.Status.LastKnownGood; current != nil && !changed(cd, current) {
+		return
+	}
+
+	cd.Status.LastKnownGood = &kcm.ClusterRevision{
+		RecordedAt:        metav1.NewTime(now),
+		Config:            cd.Spec.Config.DeepCopy(),
+		Template:          cd.Spec.Template,
+		KubernetesVersion: template.Status.KubernetesVersion,
+	}
+}
+
+// IsRollback returns true if the given update of the ClusterDeployment applies the requested rollback.
+func IsRollback(oldCD, newCD *kcm.ClusterDeployment) bool {
+	revision := oldCD.Status.LastKnownGood
+	return Requested(oldCD) && !Requested(newCD) && revision != nil && !changed(newCD, revision)
+}
+
+// Validate returns an error if the given ClusterDeployment cannot be rolled back to its last known-good revision:
+// the revision is not recorded or already deployed, the cluster is ready, the ClusterTemplate of the revision
+// is not valid or the control plane of the cluster runs a Kubernetes version newer than the revision provides.
+func Validate(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) error {
+	revision := cd.Status.LastKnownGood
+	if revision == nil {
+		return errors.New("no known-good revision of the cluster is recorded")
+	}
+	if !changed(cd, revision) {
+		return fmt.Errorf("the cluster is already deployed with the known-good revision recorded at %s", revision.RecordedAt.UTC().Format(time.RFC3339))
+	}
+	if apimeta.IsStatusConditionTrue(cd.Status.Conditions, kcm.ReadyCondition) {
+		return errors.New("only the failing upgrades can be rolled back, the cluster is ready")
+	}
+
+	template := new(kcm.ClusterTemplate)
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: cd.Namespace, Name: revision.Template}, template); err != nil {
+		return fmt.Errorf("failed to get ClusterTemplate %s/%s of the known-good revision: %w", cd.Namespace, revision.Template, err)
+	}
+	if !template.Status.Valid {
+		return fmt.Errorf("the ClusterTemplate %s/%s of the known-good revision is not valid", cd.Namespace, revision.Template)
+	}
+
+	running, err := controlPlaneVersion(ctx, cl, cd)
+	if err != nil {
+		return err
+	}
+	if isDowngrade(running, revision.KubernetesVersion) {
+		return fmt.Errorf("the control plane already runs Kubernetes %s, the downgrade to %s of the known-good revision is not supported",
+			running, revision.KubernetesVersion)
+	}
+
+	return nil
+}
+
+// Apply reverts the template and the configuration of the given ClusterDeployment
+// to its last known-good revision and removes the rollback request.
+func Apply(cd *kcm.ClusterDeployment) {
+	delete(cd.Annotations, kcm.RollbackAnnotation)
+	if revision := cd.Status.LastKnownGood; revision != nil {
+		cd.SetTemplate(revision.Template)
+		cd.Spec.Config = revision.Config.DeepCopy()
+	}
+}
+
+// changed returns true if the template or the configuration of the given ClusterDeployment differ from the given revision.
+func changed(cd *kcm.ClusterDeployment, revision *kcm.ClusterRevision) bool {
+	return cd.Spec.Template != revision.Template || !equality.Semantic.DeepEqual(cd.Spec.Config, revision.Config)
+}
+
+// controlPlaneVersion returns the Kubernetes version the control plane of the cluster of the given
+// ClusterDeployment runs or an empty string if the control plane does not exist or does not report it.
+func controlPlaneVersion(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) (string, error) {
+	cluster := new(clusterv1.Cluster)
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(cd), cluster); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	ref := cluster.Spec.ControlPlaneRef
+	if ref == nil {
+		return "", nil
+	}
+
+	controlPlane := new(unstructured.Unstructured)
+	controlPlane.SetGroupVersionKind(ref.GroupVersionKind())
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: ref.Name}, controlPlane); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get %s %s/%s: %w", ref.Kind, cluster.Namespace, ref.Name, err)
+	}
+
+	version, _, err := unstructured.NestedString(controlPlane.Object, "status", "version")
+	if err != nil {
+		return "", fmt.Errorf("failed to get the version of %s %s/%s: %w", ref.Kind, cluster.Namespace, ref.Name, err)
+	}
+
+	return version, nil
+}
+
+// isDowngrade returns true if the target Kubernetes version is lower than the running one.
+// The pre-release and the build metadata of the versions, e.g. -k0s.0 or +k0s.0, are ignored.
+// Versions that are not set or cannot be parsed are not evaluated.
+func isDowngrade(running, target string) bool {
+	if running == "" || target == "" {
+		return false
+	}
+
+	runningVersion, err := semver.NewVersion(running)
+	if err != nil {
+		return false
+	}
+	targetVersion, err := semver.NewVersion(target)
+	if err != nil {
+		return false
+	}
+
+	return semver.New(targetVersion.Major(), targetVersion.Minor(), targetVersion.Patch(), "", "").
+		LessThan(semver.New(runningVersion.Major(), runningVersion.Minor(), runningVersion.Patch(), "", ""))
+}
@@ -0,0 +1,204 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollback
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newClusterDeployment(template, config string) *kcm.ClusterDeployment {
+	return &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"},
+		Spec: kcm.ClusterDeploymentSpec{
+			Template: template,
+			Config:   &apiextensionsv1.JSON{Raw: []byte(config)},
+		},
+	}
+}
+
+func TestRecord(t *testing.T) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	template := &kcm.ClusterTemplate{Status: kcm.ClusterTemplateStatus{KubernetesVersion: "v1.32.2+k0s.0"}}
+
+	cd := newClusterDeployment("aws-standalone-cp-1-0-0", `{"workersNumber":2}`)
+	Record(cd, template, now)
+
+	expected := &kcm.ClusterRevision{
+		RecordedAt:        metav1.NewTime(now),
+		Config:            &apiextensionsv1.JSON{Raw: []byte(`{"workersNumber":2}`)},
+		Template:          "aws-standalone-cp-1-0-0",
+		KubernetesVersion: "v1.32.2+k0s.0",
+	}
+	if !reflect.DeepEqual(cd.Status.LastKnownGood, expected) {
+		t.Fatalf("unexpected revision:\ngot:  %+v\nwant: %+v", cd.Status.LastKnownGood, expected)
+	}
+
+	Record(cd, template, now.Add(time.Hour))
+	if !cd.Status.LastKnownGood.RecordedAt.Equal(&expected.RecordedAt) {
+		t.Errorf("expected the unchanged revision not to be recorded again, got %s", cd.Status.LastKnownGood.RecordedAt)
+	}
+
+	cd.Spec.Template = "aws-standalone-cp-1-0-1"
+	Record(cd, template, now.Add(time.Hour))
+	if cd.Status.LastKnownGood.Template != "aws-standalone-cp-1-0-1" {
+		t.Errorf("expected the upgraded revision to be recorded, got %+v", cd.Status.LastKnownGood)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{kcm.AddToScheme, clusterv1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatalf("failed to build scheme: %v", err)
+		}
+	}
+
+	validTemplate := &kcm.ClusterTemplate{ObjectMeta: metav1.ObjectMeta{Name: "aws-standalone-cp-1-0-0", Namespace: "team-a"}}
+	validTemplate.Status.Valid = true
+	invalidTemplate := &kcm.ClusterTemplate{ObjectMeta: metav1.ObjectMeta{Name: "aws-standalone-cp-0-9-0", Namespace: "team-a"}}
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a"},
+		Spec: clusterv1.ClusterSpec{ControlPlaneRef: &corev1.ObjectReference{
+			APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+			Kind:       "K0sControlPlane",
+			Name:       "dev-cp",
+		}},
+	}
+	newControlPlane := func(version string) *unstructured.Unstructured {
+		controlPlane := new(unstructured.Unstructured)
+		controlPlane.SetAPIVersion("controlplane.cluster.x-k8s.io/v1beta1")
+		controlPlane.SetKind("K0sControlPlane")
+		controlPlane.SetNamespace("team-a")
+		controlPlane.SetName("dev-cp")
+		if err := unstructured.SetNestedField(controlPlane.Object, version, "status", "version"); err != nil {
+			t.Fatalf("failed to set control plane version: %v", err)
+		}
+		return controlPlane
+	}
+
+	failing := func(cd *kcm.ClusterDeployment, revision *kcm.ClusterRevision) *kcm.ClusterDeployment {
+		cd.Status.LastKnownGood = revision
+		cd.Status.Conditions = []metav1.Condition{{Type: kcm.ReadyCondition, Status: metav1.ConditionFalse}}
+		return cd
+	}
+
+	for _, tc := range []struct {
+		name    string
+		cd      *kcm.ClusterDeployment
+		objects []client.Object
+		err     string
+	}{
+		{
+			name: "no revision",
+			cd:   failing(newClusterDeployment("aws-standalone-cp-1-0-1", `{}`), nil),
+			err:  "no known-good revision of the cluster is recorded",
+		},
+		{
+			name: "revision deployed",
+			cd: failing(newClusterDeployment("aws-standalone-cp-1-0-0", `{}`), &kcm.ClusterRevision{
+				Template: "aws-standalone-cp-1-0-0", Config: &apiextensionsv1.JSON{Raw: []byte(`{}`)},
+			}),
+			err: "the cluster is already deployed with the known-good revision recorded at 0001-01-01T00:00:00Z",
+		},
+		{
+			name: "cluster ready",
+			cd: func() *kcm.ClusterDeployment {
+				cd := failing(newClusterDeployment("aws-standalone-cp-1-0-1", `{}`), &kcm.ClusterRevision{Template: "aws-standalone-cp-1-0-0"})
+				cd.Status.Conditions[0].Status = metav1.ConditionTrue
+				return cd
+			}(),
+			err: "only the failing upgrades can be rolled back, the cluster is ready",
+		},
+		{
+			name:    "invalid template",
+			cd:      failing(newClusterDeployment("aws-standalone-cp-1-0-1", `{}`), &kcm.ClusterRevision{Template: "aws-standalone-cp-0-9-0"}),
+			objects: []client.Object{invalidTemplate},
+			err:     "the ClusterTemplate team-a/aws-standalone-cp-0-9-0 of the known-good revision is not valid",
+		},
+		{
+			name: "control plane not upgraded yet",
+			cd: failing(newClusterDeployment("aws-standalone-cp-1-0-1", `{}`), &kcm.ClusterRevision{
+				Template: "aws-standalone-cp-1-0-0", KubernetesVersion: "v1.31.1+k0s.0",
+			}),
+			objects: []client.Object{validTemplate, cluster, newControlPlane("v1.31.1-k0s.0")},
+		},
+		{
+			name: "config change only",
+			cd: failing(newClusterDeployment("aws-standalone-cp-1-0-0", `{"workersNumber":3}`), &kcm.ClusterRevision{
+				Template: "aws-standalone-cp-1-0-0", Config: &apiextensionsv1.JSON{Raw: []byte(`{"workersNumber":2}`)},
+			}),
+			objects: []client.Object{validTemplate},
+		},
+		{
+			name: "control plane upgraded",
+			cd: failing(newClusterDeployment("aws-standalone-cp-1-0-1", `{}`), &kcm.ClusterRevision{
+				Template: "aws-standalone-cp-1-0-0", KubernetesVersion: "v1.31.1+k0s.0",
+			}),
+			objects: []client.Object{validTemplate, cluster, newControlPlane("v1.32.2-k0s.0")},
+			err:     "the control plane already runs Kubernetes v1.32.2-k0s.0, the downgrade to v1.31.1+k0s.0 of the known-good revision is not supported",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tc.objects...).Build()
+
+			err := Validate(t.Context(), cl, tc.cd)
+			switch {
+			case tc.err == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tc.err != "" && (err == nil || err.Error() != tc.err):
+				t.Errorf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	oldCD := newClusterDeployment("aws-standalone-cp-1-0-1", `{"workersNumber":3}`)
+	oldCD.Annotations = map[string]string{kcm.RollbackAnnotation: ""}
+	oldCD.Status.LastKnownGood = &kcm.ClusterRevision{
+		Template: "aws-standalone-cp-1-0-0",
+		Config:   &apiextensionsv1.JSON{Raw: []byte(`{"workersNumber":2}`)},
+	}
+
+	newCD := oldCD.DeepCopy()
+	if IsRollback(oldCD, newCD) {
+		t.Errorf("expected the update not to be a rollback before the revision is applied")
+	}
+
+	Apply(newCD)
+	if Requested(newCD) {
+		t.Errorf("expected the rollback request to be removed")
+	}
+	if newCD.Spec.Template != "aws-standalone-cp-1-0-0" || string(newCD.Spec.Config.Raw) != `{"workersNumber":2}` {
+		t.Errorf("expected the spec to be reverted, got %s %s", newCD.Spec.Template, newCD.Spec.Config.Raw)
+	}
+	if !IsRollback(oldCD, newCD) {
+		t.Errorf("expected the update to be a rollback")
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secretpropagation propagates the Secrets and the ConfigMaps of the management cluster
+// to the namespaces of the clusters selected by the SecretPropagations.
+package secretpropagation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// ResyncInterval is the interval the unchanged objects are synchronized to the clusters at
+	// to restore the objects modified or removed in the clusters.
+	ResyncInterval = 10 * time.Minute
+	// retryInterval is the interval the failed synchronizations are retried at.
+	retryInterval = time.Minute
+)
+
+// ClusterClientFunc returns the client of the cluster deployed by the given ClusterDeployment.
+type ClusterClientFunc func(ctx context.Context, cd *kcm.ClusterDeployment) (client.Client, error)
+
+// Reconcile synchronizes the objects propagated by the given SecretPropagation to the selected clusters
+// and removes them from the clusters no longer selected. The objects are synchronized to a cluster
+// only if their hash has changed since the last synchronization or the [ResyncInterval] has passed.
+// The errors of the clusters are reported in the status of the clusters.
+// Returns the duration after which the SecretPropagation should be reconciled again.
+func Reconcile(ctx context.Context, cl client.Client, sp *kcm.SecretPropagation, clusterClient ClusterClientFunc, now time.Time) (time.Duration, error) {
+	desired, err := desiredObjects(ctx, cl, sp)
+	if err != nil {
+		return 0, err
+	}
+	hash, err := hashObjects(desired)
+	if err != nil {
+		return 0, err
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&sp.Spec.ClusterSelector)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse cluster selector: %w", err)
+	}
+	clusterDeployments := new(kcm.ClusterDeploymentList)
+	if err := cl.List(ctx, clusterDeployments, client.InNamespace(sp.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return 0, fmt.Errorf("failed to list ClusterDeployments: %w", err)
+	}
+	slices.SortFunc(clusterDeployments.Items, func(a, b kcm.ClusterDeployment) int { return strings.Compare(a.Name, b.Name) })
+
+	previous := make(map[string]kcm.SecretPropagationClusterStatus, len(sp.Status.Clusters))
+	for _, status := range sp.Status.Clusters {
+		previous[status.ClusterDeployment] = status
+	}
+
+	var clusters []kcm.SecretPropagationClusterStatus
+	requeue := ResyncInterval
+	for _, cd := range clusterDeployments.Items {
+		if !cd.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		status, wasTracked := previous[cd.Name]
+		delete(previous, cd.Name)
+		if !wasTracked {
+			status = kcm.SecretPropagationClusterStatus{ClusterDeployment: cd.Name}
+		}
+
+		if status.Hash != hash || status.Error != "" || status.LastSyncTime == nil || now.Sub(status.LastSyncTime.Time) >= ResyncInterval {
+			status.Error = ""
+			if err := syncCluster(ctx, clusterClient, &cd, sp.Name, desired); err != nil {
+				status.Error = err.Error()
+				requeue = retryInterval
+			} else {
+				status.Hash = hash
+				status.LastSyncTime = &metav1.Time{Time: now}
+			}
+		}
+		if status.LastSyncTime != nil && status.Error == "" {
+			requeue = min(requeue, max(status.LastSyncTime.Add(ResyncInterval).Sub(now), time.Second))
+		}
+		clusters = append(clusters, status)
+	}
+
+	// the objects are removed from the clusters no longer selected, the failed removals are retried
+	for _, name := range slices.Sorted(maps.Keys(previous)) {
+		status := previous[name]
+		if err := cleanupCluster(ctx, cl, clusterClient, sp, name); err != nil {
+			status.Error = fmt.Sprintf("failed to remove the propagated objects from the deselected cluster: %s", err)
+			clusters = append(clusters, status)
+			requeue = retryInterval
+		}
+	}
+
+	sp.Status.Clusters = clusters
+	sp.Status.Total = 0
+	sp.Status.Synced = 0
+	for _, status := range clusters {
+		if _, deselected := previous[status.ClusterDeployment]; deselected {
+			continue
+		}
+		sp.Status.Total++
+		if status.Hash == hash && status.Error == "" {
+			sp.Status.Synced++
+		}
+	}
+
+	return requeue, nil
+}
+
+// Cleanup removes the objects propagated by the given SecretPropagation from all the clusters it has been synchronized to.
+func Cleanup(ctx context.Context, cl client.Client, sp *kcm.SecretPropagation, clusterClient ClusterClientFunc) error {
+	var errs error
+	for _, status := range sp.Status.Clusters {
+		if err := cleanupCluster(ctx, cl, clusterClient, sp, status.ClusterDeployment); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to remove the propagated objects from the cluster %s: %w", status.ClusterDeployment, err))
+		}
+	}
+	return errs
+}
+
+// desiredObjects returns the Secrets and the ConfigMaps to be propagated by the given SecretPropagation
+// to each of the target namespaces labeled and annotated with the hash of their data.
+func desiredObjects(ctx context.Context, cl client.Client, sp *kcm.SecretPropagation) ([]client.Object, error) {
+	var objects []client.Object
+	for _, name := range sp.Spec.Secrets {
+		source := new(corev1.Secret)
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: sp.Namespace, Name: name}, source); err != nil {
+			return nil, fmt.Errorf("failed to get Secret %s/%s: %w", sp.Namespace, name, err)
+		}
+		for _, namespace := range sp.Spec.TargetNamespaces {
+			objects = append(objects, &corev1.Secret{
+				ObjectMeta: targetMeta(sp.Name, namespace, name),
+				Type:       source.Type,
+				Data:       source.Data,
+			})
+		}
+	}
+	for _, name := range sp.Spec.ConfigMaps {
+		source := new(corev1.ConfigMap)
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: sp.Namespace, Name: name}, source); err != nil {
+			return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", sp.Namespace, name, err)
+		}
+		for _, namespace := range sp.Spec.TargetNamespaces {
+			objects = append(objects, &corev1.ConfigMap{
+				ObjectMeta: targetMeta(sp.Name, namespace, name),
+				Data:       source.Data,
+				BinaryData: source.BinaryData,
+			})
+		}
+	}
+
+	for _, obj := range objects {
+		hash, err := hashObjects([]client.Object{obj})
+		if err != nil {
+			return nil, err
+		}
+		obj.SetAnnotations(map[string]string{kcm.SecretPropagationHashAnnotation: hash})
+	}
+
+	return objects, nil
+}
+
+func targetMeta(propagation, namespace, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels:    map[string]string{kcm.SecretPropagationLabel: propagation},
+	}
+}
+
+// hashObjects returns the hash of the given objects.
+func hashObjects(objects []client.Object) (string, error) {
+	h := sha256.New()
+	for _, obj := range objects {
+		b, err := json.Marshal(obj)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+		}
+		_, _ = h.Write(b)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// syncCluster creates the target namespaces and the desired objects in the cluster of the given ClusterDeployment,
+// updates the objects the hash of which has changed and removes the objects propagated by the SecretPropagation
+// no longer desired. The existing objects not propagated by the SecretPropagation are not overwritten.
+func syncCluster(ctx context.Context, clusterClient ClusterClientFunc, cd *kcm.ClusterDeployment, propagation string, desired []client.Object) error {
+	cl, err := clusterClient(ctx, cd)
+	if err != nil {
+		return err
+	}
+
+	namespaces := make(map[string]struct{})
+	for _, obj := range desired {
+		if _, ok := namespaces[obj.GetNamespace()]; ok {
+			continue
+		}
+		namespaces[obj.GetNamespace()] = struct{}{}
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: obj.GetNamespace()}}
+		if err := cl.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create namespace %s: %w", ns.Name, err)
+		}
+	}
+
+	keep := make(map[string]struct{}, len(desired))
+	for _, obj := range desired {
+		keep[objectKey(obj)] = struct{}{}
+
+		existing := obj.DeepCopyObject().(client.Object) //nolint:forcetypeassert // the copy of the object is of the same type
+		err := cl.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+		switch {
+		case apierrors.IsNotFound(err):
+			if err := cl.Create(ctx, obj.DeepCopyObject().(client.Object)); err != nil { //nolint:forcetypeassert // the copy of the object is of the same type
+				return fmt.Errorf("failed to create %s: %w", objectKey(obj), err)
+			}
+			continue
+		case err != nil:
+			return fmt.Errorf("failed to get %s: %w", objectKey(obj), err)
+		}
+
+		if existing.GetLabels()[kcm.SecretPropagationLabel] != propagation {
+			return fmt.Errorf("%s already exists and is not propagated by the SecretPropagation", objectKey(obj))
+		}
+		if existing.GetAnnotations()[kcm.SecretPropagationHashAnnotation] == obj.GetAnnotations()[kcm.SecretPropagationHashAnnotation] {
+			continue
+		}
+
+		updated := obj.DeepCopyObject().(client.Object) //nolint:forcetypeassert // the copy of the object is of the same type
+		updated.SetResourceVersion(existing.GetResourceVersion())
+		if err := cl.Update(ctx, updated); err != nil {
+			return fmt.Errorf("failed to update %s: %w", objectKey(obj), err)
+		}
+	}
+
+	return deletePropagated(ctx, cl, propagation, keep)
+}
+
+// cleanupCluster removes the objects propagated by the given SecretPropagation from the cluster
+// of the ClusterDeployment with the given name. The clusters already deleted are skipped.
+func cleanupCluster(ctx context.Context, cl client.Client, clusterClient ClusterClientFunc, sp *kcm.SecretPropagation, name string) error {
+	cd := new(kcm.ClusterDeployment)
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: sp.Namespace, Name: name}, cd); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !cd.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	clusterCl, err := clusterClient(ctx, cd)
+	if err != nil {
+		return err
+	}
+	return deletePropagated(ctx, clusterCl, sp.Name, nil)
+}
+
+// deletePropagated deletes the Secrets and the ConfigMaps propagated by the given SecretPropagation
+// except for the ones with the keys in the given set.
+func deletePropagated(ctx context.Context, cl client.Client, propagation string, keep map[string]struct{}) error {
+	selector := client.MatchingLabels{kcm.SecretPropagationLabel: propagation}
+
+	secrets := new(corev1.SecretList)
+	if err := cl.List(ctx, secrets, selector); err != nil {
+		return fmt.Errorf("failed to list propagated Secrets: %w", err)
+	}
+	configMaps := new(corev1.ConfigMapList)
+	if err := cl.List(ctx, configMaps, selector); err != nil {
+		return fmt.Errorf("failed to list propagated ConfigMaps: %w", err)
+	}
+
+	var objects []client.Object
+	for i := range secrets.Items {
+		objects = append(objects, &secrets.Items[i])
+	}
+	for i := range configMaps.Items {
+		objects = append(objects, &configMaps.Items[i])
+	}
+
+	for _, obj := range objects {
+		if _, ok := keep[objectKey(obj)]; ok {
+			continue
+		}
+		if err := cl.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete %s: %w", objectKey(obj), err)
+		}
+	}
+
+	return nil
+}
+
+// objectKey returns the kind and the namespaced name of the given object.
+func objectKey(obj client.Object) string {
+	kind := "Secret"
+	if _, ok := obj.(*corev1.ConfigMap); ok {
+		kind = "ConfigMap"
+	}
+	return kind + " " + client.ObjectKeyFromObject(obj).String()
+}
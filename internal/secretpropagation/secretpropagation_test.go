@@ -0,0 +1,200 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretpropagation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func newClusterDeployment(name string, labels map[string]string) *kcm.ClusterDeployment {
+	return &kcm.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a", Labels: labels}}
+}
+
+type clusters struct {
+	clients map[string]client.Client
+	calls   int
+}
+
+func (c *clusters) client(_ context.Context, cd *kcm.ClusterDeployment) (client.Client, error) {
+	c.calls++
+	return c.clients[cd.Name], nil
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := t.Context()
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+
+	sp := &kcm.SecretPropagation{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "team-a"},
+		Spec: kcm.SecretPropagationSpec{
+			ClusterSelector:  metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			Secrets:          []string{"registry-credentials"},
+			ConfigMaps:       []string{"registry-ca"},
+			TargetNamespaces: []string{"apps"},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry-credentials", Namespace: "team-a"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`)},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry-ca", Namespace: "team-a"},
+		Data:       map[string]string{"ca.crt": "certificate"},
+	}
+	prod := newClusterDeployment("prod", map[string]string{"env": "prod"})
+	dev := newClusterDeployment("dev", map[string]string{"env": "dev"})
+
+	mgmt := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(sp, secret, configMap, prod, dev).Build()
+	prodCluster := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	c := &clusters{clients: map[string]client.Client{"prod": prodCluster, "dev": fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()}}
+
+	requeue, err := Reconcile(ctx, mgmt, sp, c.client, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requeue != ResyncInterval {
+		t.Errorf("expected the resync to be requeued in %s, got %s", ResyncInterval, requeue)
+	}
+	if sp.Status.Synced != 1 || sp.Status.Total != 1 || len(sp.Status.Clusters) != 1 || sp.Status.Clusters[0].ClusterDeployment != "prod" {
+		t.Fatalf("unexpected status: %+v", sp.Status)
+	}
+
+	propagated := new(corev1.Secret)
+	if err := prodCluster.Get(ctx, client.ObjectKey{Namespace: "apps", Name: "registry-credentials"}, propagated); err != nil {
+		t.Fatalf("expected the Secret to be propagated: %v", err)
+	}
+	if propagated.Type != corev1.SecretTypeDockerConfigJson || propagated.Labels[kcm.SecretPropagationLabel] != "registry" {
+		t.Errorf("unexpected propagated Secret: %+v", propagated)
+	}
+	if err := prodCluster.Get(ctx, client.ObjectKey{Name: "apps"}, new(corev1.Namespace)); err != nil {
+		t.Errorf("expected the target namespace to be created: %v", err)
+	}
+
+	// the unchanged objects are not synchronized until the resync interval passes
+	c.calls = 0
+	if _, err := Reconcile(ctx, mgmt, sp, c.client, now.Add(time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.calls != 0 {
+		t.Errorf("expected the unchanged objects not to be synchronized, got %d calls", c.calls)
+	}
+
+	// the changed objects are synchronized
+	configMap.Data["ca.crt"] = "rotated"
+	if err := mgmt.Update(ctx, configMap); err != nil {
+		t.Fatalf("failed to update ConfigMap: %v", err)
+	}
+	if _, err := Reconcile(ctx, mgmt, sp, c.client, now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	propagatedCM := new(corev1.ConfigMap)
+	if err := prodCluster.Get(ctx, client.ObjectKey{Namespace: "apps", Name: "registry-ca"}, propagatedCM); err != nil {
+		t.Fatalf("failed to get propagated ConfigMap: %v", err)
+	}
+	if propagatedCM.Data["ca.crt"] != "rotated" {
+		t.Errorf("expected the propagated ConfigMap to be updated, got %v", propagatedCM.Data)
+	}
+
+	// the objects removed from the spec are removed from the clusters
+	sp.Spec.ConfigMaps = nil
+	if _, err := Reconcile(ctx, mgmt, sp, c.client, now.Add(3*time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := prodCluster.Get(ctx, client.ObjectKey{Namespace: "apps", Name: "registry-ca"}, propagatedCM); err == nil {
+		t.Error("expected the ConfigMap no longer propagated to be removed")
+	}
+
+	// the objects are removed from the deselected clusters
+	prod.Labels = map[string]string{"env": "staging"}
+	if err := mgmt.Update(ctx, prod); err != nil {
+		t.Fatalf("failed to update ClusterDeployment: %v", err)
+	}
+	if _, err := Reconcile(ctx, mgmt, sp, c.client, now.Add(4*time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sp.Status.Clusters) != 0 || sp.Status.Total != 0 {
+		t.Errorf("expected the deselected cluster to be dropped from the status, got %+v", sp.Status)
+	}
+	if err := prodCluster.Get(ctx, client.ObjectKey{Namespace: "apps", Name: "registry-credentials"}, propagated); err == nil {
+		t.Error("expected the Secret to be removed from the deselected cluster")
+	}
+}
+
+func TestReconcileDoesNotOverwriteForeignObjects(t *testing.T) {
+	ctx := t.Context()
+
+	sp := &kcm.SecretPropagation{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "team-a"},
+		Spec:       kcm.SecretPropagationSpec{Secrets: []string{"registry-credentials"}, TargetNamespaces: []string{"apps"}},
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry-credentials", Namespace: "team-a"}}
+	foreign := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry-credentials", Namespace: "apps"}}
+
+	mgmt := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(sp, secret, newClusterDeployment("prod", nil)).Build()
+	c := &clusters{clients: map[string]client.Client{"prod": fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(foreign).Build()}}
+
+	requeue, err := Reconcile(ctx, mgmt, sp, c.client, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requeue != retryInterval {
+		t.Errorf("expected the failed synchronization to be retried in %s, got %s", retryInterval, requeue)
+	}
+	if len(sp.Status.Clusters) != 1 || !strings.Contains(sp.Status.Clusters[0].Error, "already exists and is not propagated") || sp.Status.Synced != 0 {
+		t.Errorf("unexpected status: %+v", sp.Status)
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	ctx := t.Context()
+
+	sp := &kcm.SecretPropagation{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "team-a"},
+		Status: kcm.SecretPropagationStatus{Clusters: []kcm.SecretPropagationClusterStatus{
+			{ClusterDeployment: "prod"}, {ClusterDeployment: "deleted"},
+		}},
+	}
+	propagated := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name: "registry-credentials", Namespace: "apps", Labels: map[string]string{kcm.SecretPropagationLabel: "registry"},
+	}}
+	unrelated := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "apps"}}
+
+	mgmt := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(newClusterDeployment("prod", nil)).Build()
+	prodCluster := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(propagated, unrelated).Build()
+	c := &clusters{clients: map[string]client.Client{"prod": prodCluster}}
+
+	if err := Cleanup(ctx, mgmt, sp, c.client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	secrets := new(corev1.SecretList)
+	if err := prodCluster.List(ctx, secrets); err != nil {
+		t.Fatalf("failed to list Secrets: %v", err)
+	}
+	if len(secrets.Items) != 1 || secrets.Items[0].Name != "other" {
+		t.Errorf("expected only the propagated Secret to be removed, got %+v", secrets.Items)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package securitybaseline deploys the security baseline configured in the Management
+// to the clusters by the means of an implicit system MultiClusterService.
+package securitybaseline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	manifestsKey = "baseline.yaml"
+
+	defaultDenyNetworkPolicyName = "kcm-default-deny-ingress"
+)
+
+// Manifests returns the manifests of the objects implementing the given security baseline.
+func Manifests(baseline *kcm.SecurityBaseline) (string, error) {
+	level := baseline.PodSecurityStandard
+	if level == "" {
+		level = "baseline"
+	}
+
+	var objects []runtime.Object
+	for _, namespace := range baseline.Namespaces {
+		objects = append(objects, &corev1.Namespace{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+				Labels: map[string]string{
+					"pod-security.kubernetes.io/enforce": level,
+					"pod-security.kubernetes.io/audit":   level,
+					"pod-security.kubernetes.io/warn":    level,
+				},
+			},
+		})
+
+		if baseline.DefaultDenyNetworkPolicies {
+			objects = append(objects, &networkingv1.NetworkPolicy{
+				TypeMeta: metav1.TypeMeta{APIVersion: networkingv1.SchemeGroupVersion.String(), Kind: "NetworkPolicy"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      defaultDenyNetworkPolicyName,
+					Namespace: namespace,
+				},
+				Spec: networkingv1.NetworkPolicySpec{
+					PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+				},
+			})
+		}
+	}
+
+	manifests := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		manifest, err := yaml.Marshal(obj)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, err)
+		}
+		manifests = append(manifests, string(manifest))
+	}
+
+	return strings.Join(manifests, "---\n"), nil
+}
+
+// Reconcile reconciles the ConfigMap, the ServiceTemplate and the MultiClusterService
+// deploying the given security baseline to all the clusters not opted out of it.
+// The MultiClusterService is reconciled only once the ServiceTemplate is valid,
+// pending is true if the ServiceTemplate is yet to be validated.
+func Reconcile(ctx context.Context, cl client.Client, namespace string, owner *metav1.OwnerReference, baseline *kcm.SecurityBaseline) (pending bool, _ error) {
+	l := ctrl.LoggerFrom(ctx)
+
+	manifests, err := Manifests(baseline)
+	if err != nil {
+		return false, err
+	}
+
+	cm := &corev1.ConfigMap{ObjectMeta: objectMeta(namespace, owner)}
+	template := &kcm.ServiceTemplate{ObjectMeta: objectMeta(namespace, owner)}
+	mcs := &kcm.MultiClusterService{ObjectMeta: objectMeta("", owner)}
+
+	for _, obj := range []struct {
+		client.Object
+		mutate func() error
+	}{
+		{cm, func() error {
+			cm.Data = map[string]string{manifestsKey: manifests}
+			return nil
+		}},
+		{template, func() error {
+			template.Spec.Resources = &kcm.SourceSpec{
+				LocalSourceRef: &kcm.LocalSourceRef{Kind: "ConfigMap", Name: cm.Name},
+				DeploymentType: "Remote",
+			}
+			return nil
+		}},
+		{mcs, func() error {
+			mcs.Spec.ClusterSelector = metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      kcm.SecurityBaselineKey,
+						Operator: metav1.LabelSelectorOpNotIn,
+						Values:   []string{kcm.SecurityBaselineOptOutValue},
+					},
+				},
+			}
+			mcs.Spec.ServiceSpec.Services = []kcm.Service{{Name: kcm.SecurityBaselineName, Template: template.Name}}
+			return nil
+		}},
+	} {
+		operation, err := controllerutil.CreateOrUpdate(ctx, cl, obj.Object, obj.mutate)
+		if err != nil {
+			return false, fmt.Errorf("failed to reconcile security baseline %T: %w", obj.Object, err)
+		}
+		if operation == controllerutil.OperationResultCreated || operation == controllerutil.OperationResultUpdated {
+			l.Info("Successfully mutated security baseline object", "object", client.ObjectKeyFromObject(obj.Object), "operation_result", operation)
+		}
+
+		// the MultiClusterService is admitted only with a valid ServiceTemplate
+		if obj.Object == template && !template.Status.Valid {
+			l.V(1).Info("Waiting for the security baseline ServiceTemplate to be validated", "template", client.ObjectKeyFromObject(template))
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Delete deletes the objects deploying the security baseline.
+func Delete(ctx context.Context, cl client.Client, namespace string) error {
+	var errs error
+	for _, obj := range []client.Object{
+		&kcm.MultiClusterService{ObjectMeta: objectMeta("", nil)},
+		&kcm.ServiceTemplate{ObjectMeta: objectMeta(namespace, nil)},
+		&corev1.ConfigMap{ObjectMeta: objectMeta(namespace, nil)},
+	} {
+		errs = errors.Join(errs, client.IgnoreNotFound(cl.Delete(ctx, obj)))
+	}
+
+	return errs
+}
+
+func objectMeta(namespace string, owner *metav1.OwnerReference) metav1.ObjectMeta {
+	obj := metav1.ObjectMeta{
+		Name:      kcm.SecurityBaselineName,
+		Namespace: namespace,
+		Labels: map[string]string{
+			kcm.KCMManagedLabelKey: kcm.KCMManagedLabelValue,
+		},
+	}
+
+	if owner != nil {
+		obj.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+
+	return obj
+}
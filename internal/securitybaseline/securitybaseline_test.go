@@ -0,0 +1,104 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securitybaseline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestManifests(t *testing.T) {
+	manifests, err := Manifests(&kcm.SecurityBaseline{
+		Namespaces:                 []string{"apps"},
+		PodSecurityStandard:        "restricted",
+		DefaultDenyNetworkPolicies: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, `apiVersion: v1
+kind: Namespace
+metadata:
+  creationTimestamp: null
+  labels:
+    pod-security.kubernetes.io/audit: restricted
+    pod-security.kubernetes.io/enforce: restricted
+    pod-security.kubernetes.io/warn: restricted
+  name: apps
+spec: {}
+status: {}
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  creationTimestamp: null
+  name: kcm-default-deny-ingress
+  namespace: apps
+spec:
+  podSelector: {}
+  policyTypes:
+  - Ingress
+`, manifests)
+
+	manifests, err = Manifests(&kcm.SecurityBaseline{Namespaces: []string{"apps"}})
+	require.NoError(t, err)
+	require.Contains(t, manifests, "pod-security.kubernetes.io/enforce: baseline")
+	require.NotContains(t, manifests, "NetworkPolicy")
+}
+
+func TestReconcile(t *testing.T) {
+	const namespace = "kcm-system"
+
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithStatusSubresource(&kcm.ServiceTemplate{}).Build()
+	baseline := &kcm.SecurityBaseline{Namespaces: []string{"apps"}}
+
+	pending, err := Reconcile(t.Context(), cl, namespace, nil, baseline)
+	require.NoError(t, err)
+	require.True(t, pending)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, cl.Get(t.Context(), client.ObjectKey{Namespace: namespace, Name: kcm.SecurityBaselineName}, cm))
+	require.Contains(t, cm.Data[manifestsKey], "name: apps")
+
+	mcs := &kcm.MultiClusterService{}
+	require.True(t, apierrors.IsNotFound(cl.Get(t.Context(), client.ObjectKey{Name: kcm.SecurityBaselineName}, mcs)))
+
+	template := &kcm.ServiceTemplate{}
+	require.NoError(t, cl.Get(t.Context(), client.ObjectKey{Namespace: namespace, Name: kcm.SecurityBaselineName}, template))
+	template.Status.Valid = true
+	require.NoError(t, cl.Status().Update(t.Context(), template))
+
+	pending, err = Reconcile(t.Context(), cl, namespace, nil, baseline)
+	require.NoError(t, err)
+	require.False(t, pending)
+
+	require.NoError(t, cl.Get(t.Context(), client.ObjectKey{Name: kcm.SecurityBaselineName}, mcs))
+	require.Equal(t, []kcm.Service{{Name: kcm.SecurityBaselineName, Template: kcm.SecurityBaselineName}}, mcs.Spec.ServiceSpec.Services)
+	require.Equal(t, kcm.SecurityBaselineKey, mcs.Spec.ClusterSelector.MatchExpressions[0].Key)
+
+	require.NoError(t, Delete(t.Context(), cl, namespace))
+	require.True(t, apierrors.IsNotFound(cl.Get(t.Context(), client.ObjectKey{Name: kcm.SecurityBaselineName}, mcs)))
+	require.True(t, apierrors.IsNotFound(cl.Get(t.Context(), client.ObjectKeyFromObject(template), template)))
+	require.True(t, apierrors.IsNotFound(cl.Get(t.Context(), client.ObjectKeyFromObject(cm), cm)))
+
+	// deleting already absent objects is a no-op
+	require.NoError(t, Delete(t.Context(), cl, namespace))
+}
@@ -0,0 +1,106 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package serviceadvisories flags the services of the clusters installed from the ServiceTemplates
+// past their end of life or with known vulnerabilities as set in the advisories of the ServiceTemplates.
+package serviceadvisories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// Finding is a service affected by the advisories of its ServiceTemplate.
+type Finding struct {
+	// Service is the name of the service.
+	Service string
+	// Template is the name of the ServiceTemplate the service is installed from.
+	Template string
+	// EndOfLife is the passed end of life of the service, nil if the service is supported.
+	EndOfLife *metav1.Time
+	// Vulnerabilities is the list of the IDs of the known vulnerabilities of the service.
+	Vulnerabilities []string
+}
+
+// ForServices returns the findings of the given enabled services with the ServiceTemplates in the given namespace.
+// The services with the missing ServiceTemplates are skipped.
+func ForServices(ctx context.Context, cl client.Client, namespace string, services []kcm.Service, now time.Time) ([]Finding, error) {
+	var findings []Finding
+	for _, svc := range services {
+		if svc.Disable {
+			continue
+		}
+
+		template := new(kcm.ServiceTemplate)
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: svc.Template}, template); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get ServiceTemplate %s/%s: %w", namespace, svc.Template, err)
+		}
+
+		advisories := template.Status.Advisories
+		if advisories == nil {
+			continue
+		}
+
+		finding := Finding{Service: svc.Name, Template: svc.Template, Vulnerabilities: advisories.Vulnerabilities}
+		if advisories.EndOfLife != nil && !now.Before(advisories.EndOfLife.Time) {
+			finding.EndOfLife = advisories.EndOfLife
+		}
+		if finding.EndOfLife != nil || len(finding.Vulnerabilities) > 0 {
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings, nil
+}
+
+// SetCondition sets the ServiceAdvisories condition reporting the given findings
+// to the given conditions or removes the condition if there are no findings.
+func SetCondition(conditions *[]metav1.Condition, findings []Finding) {
+	if len(findings) == 0 {
+		apimeta.RemoveStatusCondition(conditions, kcm.ServiceAdvisoriesCondition)
+		return
+	}
+
+	reason := kcm.ServicesEndOfLifeReason
+	messages := make([]string, 0, len(findings))
+	for _, f := range findings {
+		var issues []string
+		if f.EndOfLife != nil {
+			issues = append(issues, "reached its end of life on "+f.EndOfLife.Format(time.DateOnly))
+		}
+		if len(f.Vulnerabilities) > 0 {
+			reason = kcm.ServicesVulnerableReason
+			issues = append(issues, "is affected by "+strings.Join(f.Vulnerabilities, ", "))
+		}
+		messages = append(messages, fmt.Sprintf("service %s from ServiceTemplate %s %s", f.Service, f.Template, strings.Join(issues, " and ")))
+	}
+
+	apimeta.SetStatusCondition(conditions, metav1.Condition{
+		Type:    kcm.ServiceAdvisoriesCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: strings.Join(messages, "; "),
+	})
+}
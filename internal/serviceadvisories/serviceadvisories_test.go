@@ -0,0 +1,93 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceadvisories
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestForServices(t *testing.T) {
+	now := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	eol := metav1.NewTime(time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC))
+	supported := metav1.NewTime(time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC))
+
+	newTemplate := func(name string, advisories *kcm.ServiceTemplateAdvisories) *kcm.ServiceTemplate {
+		return &kcm.ServiceTemplate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenant", Name: name},
+			Status:     kcm.ServiceTemplateStatus{Advisories: advisories},
+		}
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		newTemplate("ingress-nginx-4-11-0", &kcm.ServiceTemplateAdvisories{EndOfLife: &eol, Vulnerabilities: []string{"CVE-2025-1974"}}),
+		newTemplate("cert-manager-1-15-0", &kcm.ServiceTemplateAdvisories{EndOfLife: &eol}),
+		newTemplate("cert-manager-1-16-2", &kcm.ServiceTemplateAdvisories{EndOfLife: &supported}),
+		newTemplate("kyverno-3-2-6", &kcm.ServiceTemplateAdvisories{Vulnerabilities: []string{"CVE-2024-48921"}}),
+		newTemplate("velero-8-1-0", nil),
+	).Build()
+
+	services := []kcm.Service{
+		{Name: "ingress-nginx", Template: "ingress-nginx-4-11-0"},
+		{Name: "cert-manager", Template: "cert-manager-1-15-0"},
+		{Name: "cert-manager-next", Template: "cert-manager-1-16-2"},
+		{Name: "kyverno", Template: "kyverno-3-2-6", Disable: true},
+		{Name: "velero", Template: "velero-8-1-0"},
+		{Name: "missing", Template: "missing-1-0-0"},
+	}
+
+	findings, err := ForServices(t.Context(), cl, "tenant", services, now)
+	if err != nil {
+		t.Fatalf("ForServices() error = %v", err)
+	}
+	want := []Finding{
+		{Service: "ingress-nginx", Template: "ingress-nginx-4-11-0", EndOfLife: &eol, Vulnerabilities: []string{"CVE-2025-1974"}},
+		{Service: "cert-manager", Template: "cert-manager-1-15-0", EndOfLife: &eol},
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("ForServices() = %+v, want %+v", findings, want)
+	}
+	for i := range want {
+		// the times lose their location on the round trip through the client
+		if findings[i].Service != want[i].Service || findings[i].Template != want[i].Template ||
+			!findings[i].EndOfLife.Equal(want[i].EndOfLife) || !slices.Equal(findings[i].Vulnerabilities, want[i].Vulnerabilities) {
+			t.Fatalf("ForServices() = %+v, want %+v", findings, want)
+		}
+	}
+
+	var conditions []metav1.Condition
+	SetCondition(&conditions, findings)
+	if len(conditions) != 1 || conditions[0].Status != metav1.ConditionTrue || conditions[0].Reason != kcm.ServicesVulnerableReason ||
+		conditions[0].Message != "service ingress-nginx from ServiceTemplate ingress-nginx-4-11-0 reached its end of life on 2025-03-01 and is affected by CVE-2025-1974; "+
+			"service cert-manager from ServiceTemplate cert-manager-1-15-0 reached its end of life on 2025-03-01" {
+		t.Errorf("unexpected conditions %+v", conditions)
+	}
+
+	SetCondition(&conditions, findings[1:])
+	if len(conditions) != 1 || conditions[0].Reason != kcm.ServicesEndOfLifeReason {
+		t.Errorf("unexpected conditions %+v", conditions)
+	}
+
+	SetCondition(&conditions, nil)
+	if len(conditions) != 0 {
+		t.Errorf("expected the condition to be removed, got %+v", conditions)
+	}
+}
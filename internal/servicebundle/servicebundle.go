@@ -0,0 +1,96 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package servicebundle expands the ServiceBundles included by the ClusterDeployments
+// and the MultiClusterServices into the services they deploy.
+package servicebundle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// key returns the namespace and the name of the release of the given service.
+func key(svc kcm.Service) string {
+	namespace := svc.Namespace
+	if namespace == "" {
+		namespace = svc.Name
+	}
+	return namespace + "/" + svc.Name
+}
+
+// Merge returns the services of the given bundles in the order of the bundles followed by the given services.
+// A service with the same name and namespace as a service listed earlier replaces it in place, inheriting
+// its values and valuesFrom if its own ones are not set, so the services of a bundle are the defaults
+// overridden by the services of the later bundles and by the given services.
+func Merge(bundles []kcm.ServiceBundle, services []kcm.Service) []kcm.Service {
+	var merged []kcm.Service
+	add := func(svc kcm.Service) {
+		idx := slices.IndexFunc(merged, func(s kcm.Service) bool { return key(s) == key(svc) })
+		if idx < 0 {
+			merged = append(merged, svc)
+			return
+		}
+
+		if svc.Values == "" {
+			svc.Values = merged[idx].Values
+		}
+		if len(svc.ValuesFrom) == 0 {
+			svc.ValuesFrom = merged[idx].ValuesFrom
+		}
+		merged[idx] = svc
+	}
+
+	for _, bundle := range bundles {
+		for _, svc := range bundle.Spec.Services {
+			add(*svc.DeepCopy())
+		}
+	}
+	for _, svc := range services {
+		add(*svc.DeepCopy())
+	}
+
+	return merged
+}
+
+// Services returns the services of the given spec with the ServiceBundles it includes expanded as per [Merge].
+// The ServiceBundles are looked up in the given namespace. The services are returned as is if the spec
+// includes no bundles. The bundles failed to be fetched are skipped and reported in the returned error.
+func Services(ctx context.Context, cl client.Client, namespace string, spec kcm.ServiceSpec) ([]kcm.Service, error) {
+	if len(spec.Bundles) == 0 {
+		return spec.Services, nil
+	}
+
+	var (
+		bundles []kcm.ServiceBundle
+		errs    error
+	)
+	for _, name := range spec.Bundles {
+		bundle := new(kcm.ServiceBundle)
+		key := client.ObjectKey{Namespace: namespace, Name: name}
+		if err := cl.Get(ctx, key, bundle); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to get ServiceBundle %s: %w", key, err))
+			continue
+		}
+		bundles = append(bundles, *bundle)
+	}
+
+	return Merge(bundles, spec.Services), errs
+}
@@ -0,0 +1,112 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebundle
+
+import (
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestMerge(t *testing.T) {
+	observability := kcm.ServiceBundle{Spec: kcm.ServiceBundleSpec{Services: []kcm.Service{
+		{Name: "prometheus", Template: "prometheus-1-0-0", Values: "retention: 7d"},
+		{Name: "loki", Template: "loki-2-0-0", Values: "replicas: 1"},
+	}}}
+	security := kcm.ServiceBundle{Spec: kcm.ServiceBundleSpec{Services: []kcm.Service{
+		{Name: "kyverno", Template: "kyverno-3-0-0"},
+		{Name: "loki", Template: "loki-2-1-0"},
+	}}}
+
+	for _, tc := range []struct {
+		name     string
+		bundles  []kcm.ServiceBundle
+		services []kcm.Service
+		expected []kcm.Service
+	}{
+		{
+			name:     "no bundles",
+			services: []kcm.Service{{Name: "ingress-nginx", Template: "ingress-nginx-4-11-0"}},
+			expected: []kcm.Service{{Name: "ingress-nginx", Template: "ingress-nginx-4-11-0"}},
+		},
+		{
+			name:     "bundles go first in their order",
+			bundles:  []kcm.ServiceBundle{observability, security},
+			services: []kcm.Service{{Name: "ingress-nginx", Template: "ingress-nginx-4-11-0"}},
+			expected: []kcm.Service{
+				{Name: "prometheus", Template: "prometheus-1-0-0", Values: "retention: 7d"},
+				{Name: "loki", Template: "loki-2-1-0", Values: "replicas: 1"},
+				{Name: "kyverno", Template: "kyverno-3-0-0"},
+				{Name: "ingress-nginx", Template: "ingress-nginx-4-11-0"},
+			},
+		},
+		{
+			name:    "services override the bundle in place",
+			bundles: []kcm.ServiceBundle{observability},
+			services: []kcm.Service{
+				{Name: "ingress-nginx", Template: "ingress-nginx-4-11-0"},
+				{Name: "prometheus", Template: "prometheus-1-1-0"},
+				{Name: "loki", Template: "loki-2-0-0", Values: "replicas: 3"},
+				{Name: "loki", Namespace: "logging", Template: "loki-2-0-0"},
+			},
+			expected: []kcm.Service{
+				{Name: "prometheus", Template: "prometheus-1-1-0", Values: "retention: 7d"},
+				{Name: "loki", Template: "loki-2-0-0", Values: "replicas: 3"},
+				{Name: "ingress-nginx", Template: "ingress-nginx-4-11-0"},
+				{Name: "loki", Namespace: "logging", Template: "loki-2-0-0"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := Merge(tc.bundles, tc.services); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("unexpected services:\ngot:  %+v\nwant: %+v", actual, tc.expected)
+			}
+		})
+	}
+}
+
+func TestServices(t *testing.T) {
+	bundle := &kcm.ServiceBundle{
+		ObjectMeta: metav1.ObjectMeta{Name: "observability", Namespace: "kcm-system"},
+		Spec:       kcm.ServiceBundleSpec{Services: []kcm.Service{{Name: "prometheus", Template: "prometheus-1-0-0"}}},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(bundle).Build()
+
+	spec := kcm.ServiceSpec{
+		Bundles:  []string{"observability", "missing"},
+		Services: []kcm.Service{{Name: "ingress-nginx", Template: "ingress-nginx-4-11-0"}},
+	}
+	services, err := Services(t.Context(), cl, "kcm-system", spec)
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected the not found error of the missing bundle, got %v", err)
+	}
+	expected := []kcm.Service{
+		{Name: "prometheus", Template: "prometheus-1-0-0"},
+		{Name: "ingress-nginx", Template: "ingress-nginx-4-11-0"},
+	}
+	if !reflect.DeepEqual(services, expected) {
+		t.Errorf("unexpected services:\ngot:  %+v\nwant: %+v", services, expected)
+	}
+
+	if _, err := Services(t.Context(), cl, "default", kcm.ServiceSpec{Bundles: []string{"observability"}}); err == nil {
+		t.Error("expected error on the bundle from another namespace")
+	}
+}
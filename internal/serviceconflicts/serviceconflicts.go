@@ -0,0 +1,292 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package serviceconflicts detects the services deployed to the same cluster by several objects,
+// the ClusterDeployment of the cluster and the MultiClusterServices matching it, and reports
+// which of the competing objects deploys the service as per the priority of their services
+// and the tie-breakers of the same priority.
+package serviceconflicts
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/servicebundle"
+	"github.com/K0rdent/kcm/internal/utils/fleet"
+)
+
+// Owner is an object deploying services to a cluster.
+type Owner struct {
+	// Kind is either ClusterDeployment or MultiClusterService.
+	Kind string
+	// Name is the name of the object prefixed with the namespace for the ClusterDeployment.
+	Name string
+	// Priority is the priority of the services of the object.
+	Priority int32
+	// CreationTimestamp is the creation time of the object breaking the ties of the same priority.
+	CreationTimestamp metav1.Time
+}
+
+func (o Owner) String() string {
+	return fmt.Sprintf("%s %s (priority %d)", o.Kind, o.Name, o.Priority)
+}
+
+// Is returns true if the given owner is the same object.
+func (o Owner) Is(other Owner) bool {
+	return o.Kind == other.Kind && o.Name == other.Name
+}
+
+// compareOwners orders the owners by their precedence: the descending priority, then the ClusterDeployment
+// before the MultiClusterServices, then the earlier created object, then the lexicographically lower name.
+func compareOwners(a, b Owner) int {
+	if c := cmp.Compare(b.Priority, a.Priority); c != 0 {
+		return c
+	}
+	if a.Kind != b.Kind {
+		if a.Kind == kcm.ClusterDeploymentKind {
+			return -1
+		}
+		if b.Kind == kcm.ClusterDeploymentKind {
+			return 1
+		}
+	}
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		if a.CreationTimestamp.Before(&b.CreationTimestamp) {
+			return -1
+		}
+		return 1
+	}
+	return cmp.Or(strings.Compare(a.Kind, b.Kind), strings.Compare(a.Name, b.Name))
+}
+
+// ClusterDeploymentOwner returns the [Owner] of the services of the given ClusterDeployment.
+func ClusterDeploymentOwner(cd *kcm.ClusterDeployment) Owner {
+	return Owner{
+		Kind:              kcm.ClusterDeploymentKind,
+		Name:              cd.Namespace + "/" + cd.Name,
+		Priority:          cd.Spec.ServiceSpec.Priority,
+		CreationTimestamp: cd.CreationTimestamp,
+	}
+}
+
+// MultiClusterServiceOwner returns the [Owner] of the services of the given MultiClusterService.
+func MultiClusterServiceOwner(mcs *kcm.MultiClusterService) Owner {
+	return Owner{
+		Kind:              kcm.MultiClusterServiceKind,
+		Name:              mcs.Name,
+		Priority:          mcs.Spec.ServiceSpec.Priority,
+		CreationTimestamp: mcs.CreationTimestamp,
+	}
+}
+
+// Conflict is a service deployed to a cluster by several owners.
+type Conflict struct {
+	// Service is the namespace and the name of the service release in the cluster.
+	Service string
+	// Owners is the list of the owners deploying the service sorted by their precedence.
+	Owners []Owner
+	// Winner is the owner deploying the service, the first of the owners.
+	Winner Owner
+}
+
+// Involves returns true if the given owner is one of the owners of the conflict.
+func (c Conflict) Involves(owner Owner) bool {
+	return slices.ContainsFunc(c.Owners, owner.Is)
+}
+
+// ForCluster returns the conflicts between the given ClusterDeployment and the MultiClusterServices
+// matching its cluster, as well as between the MultiClusterServices, sorted by the service.
+// The services of the ServiceBundles are included, the bundles of the MultiClusterServices
+// are looked up in the given system namespace.
+func ForCluster(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment, systemNamespace string) ([]Conflict, error) {
+	cluster := new(metav1.PartialObjectMetadata)
+	cluster.SetGroupVersionKind(clusterv1.GroupVersion.WithKind(clusterv1.ClusterKind))
+	err := cl.Get(ctx, client.ObjectKeyFromObject(cd), cluster)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to get Cluster %s: %w", client.ObjectKeyFromObject(cd), err)
+	}
+	clusterFound := err == nil
+
+	mcsList := new(kcm.MultiClusterServiceList)
+	if err := cl.List(ctx, mcsList); err != nil {
+		return nil, fmt.Errorf("failed to list MultiClusterServices: %w", err)
+	}
+
+	owners := make(map[string][]Owner)
+	addServices := func(owner Owner, services []kcm.Service) {
+		for _, svc := range services {
+			if svc.Disable {
+				continue
+			}
+			namespace := svc.Namespace
+			if namespace == "" {
+				namespace = svc.Name
+			}
+			key := namespace + "/" + svc.Name
+			if !slices.ContainsFunc(owners[key], owner.Is) {
+				owners[key] = append(owners[key], owner)
+			}
+		}
+	}
+
+	// the missing ServiceBundles are reported with the ServicesReferencesValidation condition of their includers
+	services, _ := servicebundle.Services(ctx, cl, cd.Namespace, cd.Spec.ServiceSpec)
+	addServices(ClusterDeploymentOwner(cd), services)
+	// the MultiClusterServices select the clusters by the labels of the CAPI Cluster
+	if clusterFound {
+		for _, mcs := range mcsList.Items {
+			if !mcs.DeletionTimestamp.IsZero() {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(&mcs.Spec.ClusterSelector)
+			if err != nil {
+				return nil, fmt.Errorf("failed to construct selector from MultiClusterService %s selector: %w", mcs.Name, err)
+			}
+			versionMatches, err := fleet.KubernetesVersionMatches(mcs.Spec.KubernetesVersion, cluster.Labels)
+			if err != nil {
+				return nil, fmt.Errorf("failed to match Kubernetes version of MultiClusterService %s: %w", mcs.Name, err)
+			}
+			if selector.Matches(labels.Set(cluster.Labels)) && versionMatches {
+				services, _ := servicebundle.Services(ctx, cl, systemNamespace, mcs.Spec.ServiceSpec)
+				addServices(MultiClusterServiceOwner(&mcs), services)
+			}
+		}
+	}
+
+	var conflicts []Conflict
+	for service, serviceOwners := range owners {
+		if len(serviceOwners) < 2 {
+			continue
+		}
+
+		slices.SortFunc(serviceOwners, compareOwners)
+		conflicts = append(conflicts, Conflict{Service: service, Owners: serviceOwners, Winner: serviceOwners[0]})
+	}
+	slices.SortFunc(conflicts, func(a, b Conflict) int {
+		return strings.Compare(a.Service, b.Service)
+	})
+
+	return conflicts, nil
+}
+
+// Message returns the description of the given conflict from the point of view of the given owner.
+func Message(conflict Conflict, owner Owner) string {
+	competing := make([]string, 0, len(conflict.Owners)-1)
+	for _, o := range conflict.Owners {
+		if !o.Is(owner) {
+			competing = append(competing, o.String())
+		}
+	}
+
+	msg := fmt.Sprintf("service %s is also deployed by %s", conflict.Service, strings.Join(competing, ", "))
+	if conflict.Winner.Is(owner) {
+		msg += ", deployed by this object"
+	} else {
+		msg += ", deployed by " + conflict.Winner.Kind + " " + conflict.Winner.Name
+	}
+	if conflict.Owners[0].Priority == conflict.Owners[1].Priority {
+		msg += " due to the tie-breakers of the same priority"
+	}
+
+	return msg
+}
+
+// SetCondition sets the ServiceConflict condition of the given owner reflecting the given conflicts
+// in which the owner is involved, the condition is removed if there are no such conflicts.
+// The cluster is prefixed to the messages of the conflicts if not empty.
+func SetCondition(conditions *[]metav1.Condition, owner Owner, conflicts map[string][]Conflict) {
+	var (
+		messages []string
+		reason   = kcm.ServiceConflictWonReason
+	)
+	clusters := make([]string, 0, len(conflicts))
+	for cluster := range conflicts {
+		clusters = append(clusters, cluster)
+	}
+	slices.Sort(clusters)
+
+	for _, cluster := range clusters {
+		for _, conflict := range conflicts[cluster] {
+			if !conflict.Involves(owner) {
+				continue
+			}
+
+			msg := Message(conflict, owner)
+			if cluster != "" {
+				msg = "cluster " + cluster + ": " + msg
+			}
+			messages = append(messages, msg)
+
+			if !conflict.Winner.Is(owner) {
+				reason = kcm.ServiceConflictLostReason
+			}
+		}
+	}
+
+	if len(messages) == 0 {
+		apimeta.RemoveStatusCondition(conditions, kcm.ServiceConflictCondition)
+		return
+	}
+
+	apimeta.SetStatusCondition(conditions, metav1.Condition{
+		Type:    kcm.ServiceConflictCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: strings.Join(messages, "; "),
+	})
+}
+
+// Status returns the conflicts of the given clusters in which the given owner is involved along with
+// their winners to be reported in the status of the owner, sorted by the cluster and the service.
+// The clusters are the namespaced names of the clusters, the empty key denotes the cluster
+// of the ClusterDeployment.
+func Status(owner Owner, conflicts map[string][]Conflict) []kcm.ServiceConflict {
+	clusters := slices.Sorted(maps.Keys(conflicts))
+
+	var status []kcm.ServiceConflict
+	for _, cluster := range clusters {
+		for _, conflict := range conflicts[cluster] {
+			if !conflict.Involves(owner) {
+				continue
+			}
+
+			sc := kcm.ServiceConflict{
+				Service:    conflict.Service,
+				WinnerKind: conflict.Winner.Kind,
+				WinnerName: conflict.Winner.Name,
+				Owners:     make([]string, 0, len(conflict.Owners)),
+			}
+			if cluster != "" {
+				sc.ClusterNamespace, sc.ClusterName, _ = strings.Cut(cluster, "/")
+			}
+			for _, o := range conflict.Owners {
+				sc.Owners = append(sc.Owners, o.String())
+			}
+			status = append(status, sc)
+		}
+	}
+
+	return status
+}
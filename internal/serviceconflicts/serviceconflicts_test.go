@@ -0,0 +1,166 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serviceconflicts
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestForCluster(t *testing.T) {
+	cd := &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenant", Name: "cluster"},
+		Spec: kcm.ClusterDeploymentSpec{ServiceSpec: kcm.ServiceSpec{
+			Priority: 100,
+			Services: []kcm.Service{
+				{Name: "ingress-nginx", Template: "ingress-nginx-4-12-0"},
+				{Name: "cert-manager", Namespace: "kube-system", Template: "cert-manager-1-16-2"},
+				{Name: "kyverno", Template: "kyverno-3-2-6", Disable: true},
+			},
+		}},
+	}
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "tenant", Name: "cluster", Labels: map[string]string{"env": "prod"}}}
+	newMCS := func(name string, priority int32, services ...string) *kcm.MultiClusterService {
+		mcs := &kcm.MultiClusterService{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: kcm.MultiClusterServiceSpec{
+				ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				ServiceSpec:     kcm.ServiceSpec{Priority: priority},
+			},
+		}
+		for _, svc := range services {
+			mcs.Spec.ServiceSpec.Services = append(mcs.Spec.ServiceSpec.Services, kcm.Service{Name: svc, Template: svc})
+		}
+		return mcs
+	}
+	platform := newMCS("platform", 200, "ingress-nginx")
+	security := newMCS("security", 100, "cert-manager", "kyverno")
+	unmatched := newMCS("dev", 300, "ingress-nginx")
+	unmatched.Spec.ClusterSelector.MatchLabels["env"] = "dev"
+
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cluster, platform, security, unmatched).Build()
+
+	conflicts, err := ForCluster(t.Context(), cl, cd, "kcm-system")
+	if err != nil {
+		t.Fatalf("ForCluster() error = %v", err)
+	}
+
+	cdOwner := ClusterDeploymentOwner(cd)
+	platformOwner := MultiClusterServiceOwner(platform)
+	securityOwner := MultiClusterServiceOwner(security)
+	want := []Conflict{
+		{
+			Service: "ingress-nginx/ingress-nginx",
+			Owners:  []Owner{platformOwner, cdOwner},
+			Winner:  platformOwner,
+		},
+	}
+	if !reflect.DeepEqual(conflicts, want) {
+		t.Fatalf("ForCluster() = %+v, want %+v", conflicts, want)
+	}
+
+	var conditions []metav1.Condition
+	SetCondition(&conditions, cdOwner, map[string][]Conflict{"": conflicts})
+	if len(conditions) != 1 || conditions[0].Reason != kcm.ServiceConflictLostReason ||
+		conditions[0].Message != "service ingress-nginx/ingress-nginx is also deployed by MultiClusterService platform (priority 200), deployed by MultiClusterService platform" {
+		t.Errorf("unexpected ClusterDeployment conditions %+v", conditions)
+	}
+
+	conditions = nil
+	SetCondition(&conditions, platformOwner, map[string][]Conflict{"tenant/cluster": conflicts})
+	if len(conditions) != 1 || conditions[0].Reason != kcm.ServiceConflictWonReason ||
+		conditions[0].Message != "cluster tenant/cluster: service ingress-nginx/ingress-nginx is also deployed by ClusterDeployment tenant/cluster (priority 100), deployed by this object" {
+		t.Errorf("unexpected MultiClusterService conditions %+v", conditions)
+	}
+
+	SetCondition(&conditions, securityOwner, nil)
+	SetCondition(&conditions, platformOwner, nil)
+	if len(conditions) != 0 {
+		t.Errorf("expected the condition to be removed, got %+v", conditions)
+	}
+
+	wantStatus := []kcm.ServiceConflict{{
+		ClusterNamespace: "tenant",
+		ClusterName:      "cluster",
+		Service:          "ingress-nginx/ingress-nginx",
+		WinnerKind:       kcm.MultiClusterServiceKind,
+		WinnerName:       "platform",
+		Owners:           []string{"MultiClusterService platform (priority 200)", "ClusterDeployment tenant/cluster (priority 100)"},
+	}}
+	if status := Status(platformOwner, map[string][]Conflict{"tenant/cluster": conflicts}); !reflect.DeepEqual(status, wantStatus) {
+		t.Errorf("Status() = %+v, want %+v", status, wantStatus)
+	}
+	if status := Status(securityOwner, map[string][]Conflict{"tenant/cluster": conflicts}); status != nil {
+		t.Errorf("expected no conflicts of the uninvolved owner, got %+v", status)
+	}
+
+	// the ClusterDeployment takes precedence with the same priority
+	cd.Spec.ServiceSpec.Services = append(cd.Spec.ServiceSpec.Services, kcm.Service{Name: "kyverno", Template: "kyverno-3-2-6"})
+	conflicts, err = ForCluster(t.Context(), cl, cd, "kcm-system")
+	if err != nil {
+		t.Fatalf("ForCluster() error = %v", err)
+	}
+	if len(conflicts) != 2 || conflicts[1].Service != "kyverno/kyverno" || !conflicts[1].Winner.Is(cdOwner) {
+		t.Fatalf("unexpected conflicts %+v", conflicts)
+	}
+	SetCondition(&conditions, securityOwner, map[string][]Conflict{"": conflicts})
+	if len(conditions) != 1 || conditions[0].Reason != kcm.ServiceConflictLostReason ||
+		conditions[0].Message != "service kyverno/kyverno is also deployed by ClusterDeployment tenant/cluster (priority 100), deployed by ClusterDeployment tenant/cluster due to the tie-breakers of the same priority" {
+		t.Errorf("unexpected conditions %+v", conditions)
+	}
+
+	// the services of the included ServiceBundles conflict as the own ones
+	bundle := &kcm.ServiceBundle{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcm-system", Name: "certificates"},
+		Spec:       kcm.ServiceBundleSpec{Services: []kcm.Service{{Name: "cert-manager", Namespace: "kube-system", Template: "cert-manager-1-16-2"}}},
+	}
+	certificates := newMCS("certificates", 100)
+	certificates.Spec.ServiceSpec.Bundles = []string{bundle.Name}
+	cl = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cluster, certificates, bundle).Build()
+
+	conflicts, err = ForCluster(t.Context(), cl, cd, "kcm-system")
+	if err != nil {
+		t.Fatalf("ForCluster() error = %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Service != "kube-system/cert-manager" || !conflicts[0].Winner.Is(cdOwner) {
+		t.Fatalf("unexpected conflicts %+v", conflicts)
+	}
+}
+
+func TestCompareOwners(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(earlier.Add(time.Hour))
+
+	cd := Owner{Kind: kcm.ClusterDeploymentKind, Name: "tenant/cluster", Priority: 100, CreationTimestamp: later}
+	older := Owner{Kind: kcm.MultiClusterServiceKind, Name: "older", Priority: 100, CreationTimestamp: earlier}
+	newer := Owner{Kind: kcm.MultiClusterServiceKind, Name: "a-newer", Priority: 100, CreationTimestamp: later}
+	sameTime := Owner{Kind: kcm.MultiClusterServiceKind, Name: "b-same-time", Priority: 100, CreationTimestamp: later}
+	higher := Owner{Kind: kcm.MultiClusterServiceKind, Name: "higher", Priority: 200, CreationTimestamp: later}
+
+	owners := []Owner{sameTime, newer, older, cd, higher}
+	slices.SortFunc(owners, compareOwners)
+	if want := []Owner{higher, cd, older, newer, sameTime}; !reflect.DeepEqual(owners, want) {
+		t.Errorf("unexpected order of the owners:\ngot:  %v\nwant: %v", owners, want)
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package servicehistory records the revisions the services of the ClusterDeployments
+// and the MultiClusterServices have been successfully deployed with and reverts the services to them on request.
+package servicehistory
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/statemanagement"
+)
+
+// MaxRevisions is the number of the revisions kept in the history of a service.
+const MaxRevisions = 5
+
+// Requested returns true if the rollback of services of the given object is requested with the annotation.
+func Requested(obj client.Object) bool {
+	_, ok := obj.GetAnnotations()[kcm.ServiceRollbackAnnotation]
+	return ok
+}
+
+// Record records the template and the values of each of the given services deployed to all the clusters
+// of the given statuses as the new revision of the service unless it is already the latest revision.
+// The history of the services no longer requested is dropped, only the last [MaxRevisions] revisions are kept.
+func Record(history []kcm.ServiceHistory, services []kcm.Service, statuses []kcm.ServiceStatus, now time.Time) []kcm.ServiceHistory {
+	var result []kcm.ServiceHistory
+	for _, svc := range services {
+		if svc.Disable {
+			continue
+		}
+
+		namespace := effectiveNamespace(svc)
+		i := slices.IndexFunc(history, func(h kcm.ServiceHistory) bool { return h.Name == svc.Name && h.Namespace == namespace })
+		entry := kcm.ServiceHistory{Name: svc.Name, Namespace: namespace}
+		if i >= 0 {
+			entry = *history[i].DeepCopy()
+		}
+
+		var latest *kcm.ServiceRevision
+		if n := len(entry.Revisions); n > 0 {
+			latest = &entry.Revisions[n-1]
+		}
+		if (latest == nil || changed(svc, latest)) && deployed(namespace, svc.Name, statuses) {
+			revision := kcm.ServiceRevision{
+				RecordedAt: metav1.NewTime(now),
+				Template:   svc.Template,
+				Values:     svc.Values,
+				Revision:   1,
+			}
+			if latest != nil {
+				revision.Revision = latest.Revision + 1
+			}
+			entry.Revisions = append(entry.Revisions, revision)
+			if n := len(entry.Revisions); n > MaxRevisions {
+				entry.Revisions = entry.Revisions[n-MaxRevisions:]
+			}
+		}
+
+		if len(entry.Revisions) > 0 {
+			result = append(result, entry)
+		}
+	}
+
+	return result
+}
+
+// Apply reverts the template and the values of the given services to the revisions requested
+// with the given [kcm.ServiceRollbackAnnotation] value. It returns the descriptions of the reverted services
+// or an error if the request cannot be parsed, a service is not found, ambiguous, has no such revision
+// recorded in the history or is already requested with the revision. The services are not modified on error.
+func Apply(services []kcm.Service, history []kcm.ServiceHistory, request string) ([]string, error) {
+	requested, err := parseRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	type change struct {
+		index    int
+		revision kcm.ServiceRevision
+	}
+	var (
+		changes  []change
+		messages []string
+	)
+	for _, name := range slices.Sorted(maps.Keys(requested)) {
+		index := -1
+		for i, svc := range services {
+			if svc.Name != name {
+				continue
+			}
+			if index >= 0 {
+				return nil, fmt.Errorf("service %s is ambiguous, several services with the name are deployed to different namespaces", name)
+			}
+			index = i
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("service %s is not found", name)
+		}
+
+		svc := services[index]
+		namespace := effectiveNamespace(svc)
+		i := slices.IndexFunc(history, func(h kcm.ServiceHistory) bool { return h.Name == name && h.Namespace == namespace })
+		if i < 0 || len(history[i].Revisions) == 0 {
+			return nil, fmt.Errorf("no revision of the service %s is recorded", name)
+		}
+
+		revision, err := target(svc, history[i].Revisions, requested[name])
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %w", name, err)
+		}
+		changes = append(changes, change{index: index, revision: revision})
+		messages = append(messages, fmt.Sprintf("%s to the revision %d with the ServiceTemplate %s", name, revision.Revision, revision.Template))
+	}
+
+	for _, c := range changes {
+		services[c.index].Template = c.revision.Template
+		services[c.index].Values = c.revision.Values
+	}
+
+	return messages, nil
+}
+
+// target returns the revision of the service to revert to: the requested one or, if the revision is not set,
+// the latest revision differing from the one the service is currently requested with.
+func target(svc kcm.Service, revisions []kcm.ServiceRevision, requested int32) (kcm.ServiceRevision, error) {
+	if requested == 0 {
+		for i := len(revisions) - 1; i >= 0; i-- {
+			if changed(svc, &revisions[i]) {
+				return revisions[i], nil
+			}
+		}
+		return kcm.ServiceRevision{}, errors.New("no previous revision is recorded")
+	}
+
+	i := slices.IndexFunc(revisions, func(r kcm.ServiceRevision) bool { return r.Revision == requested })
+	if i < 0 {
+		return kcm.ServiceRevision{}, fmt.Errorf("the revision %d is not recorded", requested)
+	}
+	if !changed(svc, &revisions[i]) {
+		return kcm.ServiceRevision{}, fmt.Errorf("the service is already requested with the revision %d", requested)
+	}
+
+	return revisions[i], nil
+}
+
+// parseRequest parses the value of the [kcm.ServiceRollbackAnnotation] annotation
+// into the requested revisions of the services, 0 stands for the previous revision.
+func parseRequest(request string) (map[string]int32, error) {
+	requested := make(map[string]int32)
+	for item := range strings.SplitSeq(request, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, revision, hasRevision := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid rollback request %q: the service name is empty", item)
+		}
+		if _, ok := requested[name]; ok {
+			return nil, fmt.Errorf("invalid rollback request: the service %s is requested more than once", name)
+		}
+
+		requested[name] = 0
+		if hasRevision {
+			n, err := strconv.ParseInt(strings.TrimSpace(revision), 10, 32)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid rollback request %q: the revision must be a positive number", item)
+			}
+			requested[name] = int32(n)
+		}
+	}
+
+	if len(requested) == 0 {
+		return nil, errors.New("invalid rollback request: no services are requested")
+	}
+
+	return requested, nil
+}
+
+// deployed returns true if the service is reported in all the given statuses and all the conditions
+// of the service and of the features delivering the services are true.
+func deployed(namespace, name string, statuses []kcm.ServiceStatus) bool {
+	if len(statuses) == 0 {
+		return false
+	}
+
+	prefix := namespace + "." + name + "/"
+	for _, status := range statuses {
+		found := false
+		for _, c := range status.Conditions {
+			isService := statemanagement.IsServiceCondition(c.Type)
+			if isService && !strings.HasPrefix(c.Type, prefix) {
+				continue
+			}
+			if c.Status != metav1.ConditionTrue {
+				return false
+			}
+			found = found || isService
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// changed returns true if the template or the values of the given service differ from the given revision.
+func changed(svc kcm.Service, revision *kcm.ServiceRevision) bool {
+	return svc.Template != revision.Template || svc.Values != revision.Values
+}
+
+// effectiveNamespace returns the namespace the service is installed in.
+func effectiveNamespace(svc kcm.Service) string {
+	if svc.Namespace == "" {
+		return svc.Name
+	}
+	return svc.Namespace
+}
@@ -0,0 +1,149 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicehistory
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func readyStatus(cluster string, ready bool, services ...string) kcm.ServiceStatus {
+	status := metav1.ConditionTrue
+	if !ready {
+		status = metav1.ConditionFalse
+	}
+
+	s := kcm.ServiceStatus{ClusterName: cluster, ClusterNamespace: "team-a"}
+	for _, svc := range services {
+		s.Conditions = append(s.Conditions, metav1.Condition{Type: svc + "/" + kcm.SveltosHelmReleaseReadyCondition, Status: status})
+	}
+	return s
+}
+
+func TestRecord(t *testing.T) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	services := []kcm.Service{
+		{Name: "ingress-nginx", Template: "ingress-nginx-4-11-0", Values: "replicas: 2"},
+		{Name: "cert-manager", Namespace: "certs", Template: "cert-manager-1-16-2"},
+		{Name: "disabled", Template: "disabled-1-0-0", Disable: true},
+	}
+	statuses := []kcm.ServiceStatus{
+		readyStatus("dev", true, "ingress-nginx.ingress-nginx", "certs.cert-manager"),
+		readyStatus("prod", true, "ingress-nginx.ingress-nginx"),
+	}
+	statuses[1].Conditions = append(statuses[1].Conditions, metav1.Condition{
+		Type: "certs.cert-manager/" + kcm.SveltosHelmReleaseReadyCondition, Status: metav1.ConditionFalse,
+	})
+
+	history := Record(nil, services, statuses, now)
+	if len(history) != 1 || history[0].Name != "ingress-nginx" || history[0].Namespace != "ingress-nginx" {
+		t.Fatalf("expected only the service ready in all the clusters to be recorded, got %+v", history)
+	}
+	if r := history[0].Revisions; len(r) != 1 || r[0].Revision != 1 || r[0].Values != "replicas: 2" || !r[0].RecordedAt.Equal(&metav1.Time{Time: now}) {
+		t.Fatalf("unexpected revisions: %+v", r)
+	}
+
+	history = Record(history, services, statuses, now.Add(time.Hour))
+	if n := len(history[0].Revisions); n != 1 {
+		t.Fatalf("expected the unchanged revision not to be recorded again, got %d revisions", n)
+	}
+
+	services[0].Values = "replicas: 3"
+	history = Record(history, services, []kcm.ServiceStatus{readyStatus("dev", false, "ingress-nginx.ingress-nginx")}, now)
+	if n := len(history[0].Revisions); n != 1 {
+		t.Fatalf("expected the failing revision not to be recorded, got %d revisions", n)
+	}
+
+	for i := range MaxRevisions + 1 {
+		services[0].Template = "ingress-nginx-4-12-" + string(rune('0'+i))
+		history = Record(history, services, statuses, now)
+	}
+	revisions := history[0].Revisions
+	if len(revisions) != MaxRevisions || revisions[0].Revision != 3 || revisions[MaxRevisions-1].Revision != MaxRevisions+2 {
+		t.Fatalf("expected the last %d revisions to be kept, got %+v", MaxRevisions, revisions)
+	}
+
+	if history = Record(history, nil, statuses, now); history != nil {
+		t.Errorf("expected the history of the removed services to be dropped, got %+v", history)
+	}
+}
+
+func TestApply(t *testing.T) {
+	history := []kcm.ServiceHistory{
+		{
+			Name: "ingress-nginx", Namespace: "ingress-nginx",
+			Revisions: []kcm.ServiceRevision{
+				{Revision: 1, Template: "ingress-nginx-4-10-0"},
+				{Revision: 2, Template: "ingress-nginx-4-11-0", Values: "replicas: 2"},
+			},
+		},
+		{
+			Name: "cert-manager", Namespace: "cert-manager",
+			Revisions: []kcm.ServiceRevision{{Revision: 1, Template: "cert-manager-1-16-2"}},
+		},
+	}
+	newServices := func() []kcm.Service {
+		return []kcm.Service{
+			{Name: "ingress-nginx", Template: "ingress-nginx-4-12-0", Values: "replicas: 2"},
+			{Name: "cert-manager", Template: "cert-manager-1-16-2"},
+			{Name: "dup", Namespace: "a", Template: "dup-1-0-0"},
+			{Name: "dup", Namespace: "b", Template: "dup-1-0-0"},
+		}
+	}
+
+	for _, tc := range []struct {
+		name             string
+		request          string
+		expectedTemplate string
+		expectedValues   string
+		err              string
+	}{
+		{name: "previous revision of a failing upgrade", request: "ingress-nginx", expectedTemplate: "ingress-nginx-4-11-0", expectedValues: "replicas: 2"},
+		{name: "explicit revision", request: " ingress-nginx = 1 ", expectedTemplate: "ingress-nginx-4-10-0"},
+		{name: "unknown revision", request: "ingress-nginx=3", err: "the revision 3 is not recorded"},
+		{name: "already requested revision", request: "cert-manager=1", err: "already requested with the revision 1"},
+		{name: "no previous revision", request: "cert-manager", err: "no previous revision"},
+		{name: "unknown service", request: "ingress-nginx,unknown", err: "service unknown is not found"},
+		{name: "ambiguous service", request: "dup", err: "service dup is ambiguous"},
+		{name: "invalid revision", request: "ingress-nginx=latest", err: "the revision must be a positive number"},
+		{name: "duplicate service", request: "ingress-nginx,ingress-nginx=1", err: "requested more than once"},
+		{name: "empty request", request: " , ", err: "no services are requested"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			services := newServices()
+			reverted, err := Apply(services, history, tc.request)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error containing %q, got %v", tc.err, err)
+				}
+				if services[0].Template != "ingress-nginx-4-12-0" {
+					t.Errorf("expected the services not to be modified on error, got %+v", services[0])
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(reverted) != 1 || services[0].Template != tc.expectedTemplate || services[0].Values != tc.expectedValues {
+				t.Errorf("unexpected result %v: %+v", reverted, services[0])
+			}
+		})
+	}
+}
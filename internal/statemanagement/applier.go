@@ -0,0 +1,436 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemanagement
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// ApplierFieldOwner is the field manager the Applier applies the objects of the services with.
+	ApplierFieldOwner = "kcm-applier"
+
+	applierInventorySuffix = "-applied"
+
+	inventoryNameKey      = "name"
+	inventoryNamespaceKey = "namespace"
+	inventoryObjectsKey   = "objects"
+	inventoryStatusKey    = "status"
+	inventoryMessageKey   = "message"
+)
+
+// ApplierProvider applies the manifests of the resources-based ServiceTemplates to the clusters
+// by the server-side apply against the kubeconfig of the cluster, not requiring any engine in the cluster.
+// The applied objects of each service are tracked in an inventory ConfigMap in the namespace
+// of the ClusterDeployment, the objects no longer part of the service are pruned.
+type ApplierProvider struct {
+	Client        client.Client
+	ClusterClient ClusterClientFunc
+}
+
+var _ Provider = (*ApplierProvider)(nil)
+
+type objectRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// ApplierInventoryName returns the name of the ConfigMap tracking the objects of the given service
+// applied to the cluster of the ClusterDeployment.
+func ApplierInventoryName(cd *kcm.ClusterDeployment, svc kcm.Service) string {
+	return cd.Name + "-" + svc.Name + applierInventorySuffix
+}
+
+// ReconcileServices implements Provider.
+func (p *ApplierProvider) ReconcileServices(ctx context.Context, cd *kcm.ClusterDeployment, services []kcm.Service) error {
+	type desiredService struct {
+		svc       kcm.Service
+		namespace string
+		objects   []*unstructured.Unstructured
+	}
+
+	desired := make([]desiredService, 0, len(services))
+	for _, svc := range services {
+		if svc.Disable {
+			continue
+		}
+
+		tmpl := new(kcm.ServiceTemplate)
+		tmplRef := client.ObjectKey{Namespace: cd.Namespace, Name: svc.Template}
+		if err := p.Client.Get(ctx, tmplRef, tmpl); err != nil {
+			return fmt.Errorf("failed to get ServiceTemplate %s: %w", tmplRef, err)
+		}
+
+		objects, err := p.manifests(ctx, tmpl)
+		if err != nil {
+			return err
+		}
+
+		namespace := svc.Namespace
+		if namespace == "" {
+			namespace = svc.Name
+		}
+		desired = append(desired, desiredService{svc: svc, namespace: namespace, objects: objects})
+	}
+
+	inventories, err := p.listInventories(ctx, cd)
+	if err != nil {
+		return err
+	}
+
+	var (
+		clusterClient client.Client
+		clusterErr    error
+	)
+	if len(desired) > 0 || len(inventories) > 0 {
+		clusterClient, clusterErr = p.ClusterClient(ctx, cd)
+		if clusterErr != nil && len(desired) == 0 {
+			return fmt.Errorf("failed to get client of the cluster: %w", clusterErr)
+		}
+	}
+
+	var errs error
+	keep := make([]string, 0, len(desired))
+	for _, d := range desired {
+		inventory := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ApplierInventoryName(cd, d.svc), Namespace: cd.Namespace}}
+		keep = append(keep, inventory.Name)
+
+		var (
+			applied []objectRef
+			status  = metav1.ConditionTrue
+			message string
+		)
+		if idx := slices.IndexFunc(inventories, func(cm corev1.ConfigMap) bool { return cm.Name == inventory.Name }); idx >= 0 {
+			applied, err = inventoryObjects(&inventories[idx])
+			if err != nil {
+				return err
+			}
+		}
+
+		if clusterClient == nil {
+			// the cluster is not reachable yet, e.g. the kubeconfig has not been issued
+			status, message = metav1.ConditionUnknown, fmt.Sprintf("Waiting for the cluster: %v", clusterErr)
+		} else if applied, err = apply(ctx, clusterClient, d.namespace, d.objects, applied); err != nil {
+			status, message = metav1.ConditionFalse, err.Error()
+			errs = errors.Join(errs, fmt.Errorf("failed to apply service %s: %w", d.svc.Name, err))
+		}
+
+		if err := p.updateInventory(ctx, cd, inventory, d.svc.Name, d.namespace, applied, status, message); err != nil {
+			return err
+		}
+	}
+
+	for _, inventory := range inventories {
+		if slices.Contains(keep, inventory.Name) || clusterClient == nil {
+			continue
+		}
+		if err := p.deleteService(ctx, clusterClient, &inventory); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// DeleteServices implements Provider. The objects are not deleted if the kubeconfig of the cluster does not exist.
+func (p *ApplierProvider) DeleteServices(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	inventories, err := p.listInventories(ctx, cd)
+	if err != nil || len(inventories) == 0 {
+		return err
+	}
+
+	clusterClient, err := p.ClusterClient(ctx, cd)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get client of the cluster: %w", err)
+	}
+
+	var errs error
+	for _, inventory := range inventories {
+		if err := p.deleteService(ctx, clusterClient, &inventory); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// ServicesConditions implements Provider.
+func (p *ApplierProvider) ServicesConditions(ctx context.Context, cd *kcm.ClusterDeployment) ([]metav1.Condition, error) {
+	inventories, err := p.listInventories(ctx, cd)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := make([]metav1.Condition, 0, len(inventories))
+	for _, inventory := range inventories {
+		namespace, name := inventory.Data[inventoryNamespaceKey], inventory.Data[inventoryNameKey]
+		condition := metav1.Condition{
+			Type:    fmt.Sprintf("%s.%s/%s", namespace, name, kcm.AppliedServiceReadyCondition),
+			Status:  metav1.ConditionStatus(inventory.Data[inventoryStatusKey]),
+			Reason:  kcm.SucceededReason,
+			Message: "Service " + namespace + "/" + name,
+		}
+		switch condition.Status {
+		case metav1.ConditionFalse:
+			condition.Reason = kcm.FailedReason
+		case metav1.ConditionUnknown:
+			condition.Reason = kcm.ProgressingReason
+		}
+		if msg := inventory.Data[inventoryMessageKey]; msg != "" {
+			condition.Message += ": " + msg
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, nil
+}
+
+// manifests returns the objects of the given ServiceTemplate defined in the ConfigMap or the Secret
+// of its local source, all of the keys of the source hold multi-document YAML manifests.
+func (p *ApplierProvider) manifests(ctx context.Context, tmpl *kcm.ServiceTemplate) ([]*unstructured.Unstructured, error) {
+	source := tmpl.Spec.Resources
+	if source == nil || source.LocalSourceRef == nil || source.DeploymentType == "Local" {
+		return nil, fmt.Errorf("ServiceTemplate %s/%s is not resources-based with a local source deployed remotely, only such ServiceTemplates are supported with the Applier service delivery", tmpl.Namespace, tmpl.Name)
+	}
+	if !tmpl.Status.Valid {
+		return nil, fmt.Errorf("ServiceTemplate %s/%s is not valid", tmpl.Namespace, tmpl.Name)
+	}
+
+	key := client.ObjectKey{Namespace: tmpl.Namespace, Name: source.LocalSourceRef.Name}
+	data := make(map[string][]byte)
+	switch source.LocalSourceRef.Kind {
+	case "ConfigMap":
+		cm := new(corev1.ConfigMap)
+		if err := p.Client.Get(ctx, key, cm); err != nil {
+			return nil, fmt.Errorf("failed to get ConfigMap %s of ServiceTemplate %s/%s: %w", key, tmpl.Namespace, tmpl.Name, err)
+		}
+		for k, v := range cm.Data {
+			data[k] = []byte(v)
+		}
+	case "Secret":
+		secret := new(corev1.Secret)
+		if err := p.Client.Get(ctx, key, secret); err != nil {
+			return nil, fmt.Errorf("failed to get Secret %s of ServiceTemplate %s/%s: %w", key, tmpl.Namespace, tmpl.Name, err)
+		}
+		data = secret.Data
+	default:
+		return nil, fmt.Errorf("local source of kind %s of ServiceTemplate %s/%s is not supported with the Applier service delivery", source.LocalSourceRef.Kind, tmpl.Namespace, tmpl.Name)
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, k := range slices.Sorted(maps.Keys(data)) {
+		decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data[k]), 4096)
+		for {
+			obj := new(unstructured.Unstructured)
+			if err := decoder.Decode(&obj.Object); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return nil, fmt.Errorf("failed to parse manifests %s of ServiceTemplate %s/%s: %w", k, tmpl.Namespace, tmpl.Name, err)
+			}
+			if len(obj.Object) == 0 {
+				continue
+			}
+			objects = append(objects, obj)
+		}
+	}
+
+	return objects, nil
+}
+
+// apply applies the given objects to the cluster, the namespaced objects without the namespace are applied
+// to the given namespace, and prunes the previously applied objects no longer desired.
+// The references of the applied objects are returned, including the previously applied ones on error.
+func apply(ctx context.Context, cl client.Client, namespace string, objects []*unstructured.Unstructured, previous []objectRef) ([]objectRef, error) {
+	applied := make([]objectRef, 0, len(objects))
+	namespaceEnsured := false
+	for _, obj := range objects {
+		namespaced, err := cl.IsObjectNamespaced(obj)
+		if err != nil {
+			return mergeRefs(previous, applied), fmt.Errorf("failed to get scope of %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if namespaced && obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+		if !namespaced {
+			obj.SetNamespace("")
+		}
+
+		if namespaced && obj.GetNamespace() == namespace && !namespaceEnsured {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+			if err := cl.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+				return mergeRefs(previous, applied), fmt.Errorf("failed to create namespace %s: %w", namespace, err)
+			}
+			namespaceEnsured = true
+		}
+
+		if err := cl.Patch(ctx, obj, client.Apply, client.FieldOwner(ApplierFieldOwner), client.ForceOwnership); err != nil {
+			return mergeRefs(previous, applied), fmt.Errorf("failed to apply %s %s: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err)
+		}
+		applied = append(applied, objectRef{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+		})
+	}
+
+	var errs error
+	for _, ref := range previous {
+		if slices.Contains(applied, ref) {
+			continue
+		}
+		if err := deleteObject(ctx, cl, ref); err != nil {
+			applied = append(applied, ref)
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return applied, errs
+}
+
+// deleteService deletes the objects tracked by the given inventory from the cluster and the inventory itself.
+// Only the inventory is deleted if the cluster client is nil.
+func (p *ApplierProvider) deleteService(ctx context.Context, clusterClient client.Client, inventory *corev1.ConfigMap) error {
+	if clusterClient != nil {
+		refs, err := inventoryObjects(inventory)
+		if err != nil {
+			return err
+		}
+
+		var errs error
+		for _, ref := range slices.Backward(refs) {
+			errs = errors.Join(errs, deleteObject(ctx, clusterClient, ref))
+		}
+		if errs != nil {
+			return errs
+		}
+	}
+
+	if err := p.Client.Delete(ctx, inventory); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete inventory ConfigMap %s/%s: %w", inventory.Namespace, inventory.Name, err)
+	}
+
+	return nil
+}
+
+func (p *ApplierProvider) updateInventory(
+	ctx context.Context,
+	cd *kcm.ClusterDeployment,
+	inventory *corev1.ConfigMap,
+	name, namespace string,
+	applied []objectRef,
+	status metav1.ConditionStatus,
+	message string,
+) error {
+	objects, err := json.Marshal(applied)
+	if err != nil {
+		return fmt.Errorf("failed to marshal applied objects: %w", err)
+	}
+
+	if _, err := ctrl.CreateOrUpdate(ctx, p.Client, inventory, func() error {
+		if inventory.Labels == nil {
+			inventory.Labels = make(map[string]string)
+		}
+		inventory.Labels[kcm.KCMManagedLabelKey] = kcm.KCMManagedLabelValue
+		inventory.Labels[kcm.ServiceDeliveryClusterLabelKey] = cd.Name
+		inventory.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: kcm.GroupVersion.String(),
+			Kind:       kcm.ClusterDeploymentKind,
+			Name:       cd.Name,
+			UID:        cd.UID,
+		}}
+		inventory.Data = map[string]string{
+			inventoryNameKey:      name,
+			inventoryNamespaceKey: namespace,
+			inventoryObjectsKey:   string(objects),
+			inventoryStatusKey:    string(status),
+			inventoryMessageKey:   message,
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile inventory ConfigMap %s/%s: %w", inventory.Namespace, inventory.Name, err)
+	}
+
+	return nil
+}
+
+func (p *ApplierProvider) listInventories(ctx context.Context, cd *kcm.ClusterDeployment) ([]corev1.ConfigMap, error) {
+	inventories := new(corev1.ConfigMapList)
+	if err := p.Client.List(ctx, inventories, client.InNamespace(cd.Namespace), client.MatchingLabels{
+		kcm.KCMManagedLabelKey:             kcm.KCMManagedLabelValue,
+		kcm.ServiceDeliveryClusterLabelKey: cd.Name,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list inventories of the services of ClusterDeployment %s/%s: %w", cd.Namespace, cd.Name, err)
+	}
+
+	return inventories.Items, nil
+}
+
+func inventoryObjects(inventory *corev1.ConfigMap) ([]objectRef, error) {
+	var refs []objectRef
+	if raw := inventory.Data[inventoryObjectsKey]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &refs); err != nil {
+			return nil, fmt.Errorf("failed to parse objects of inventory ConfigMap %s/%s: %w", inventory.Namespace, inventory.Name, err)
+		}
+	}
+
+	return refs, nil
+}
+
+func deleteObject(ctx context.Context, cl client.Client, ref objectRef) error {
+	obj := new(unstructured.Unstructured)
+	obj.SetAPIVersion(ref.APIVersion)
+	obj.SetKind(ref.Kind)
+	obj.SetNamespace(ref.Namespace)
+	obj.SetName(ref.Name)
+
+	if err := cl.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) && !apimeta.IsNoMatchError(err) {
+		return fmt.Errorf("failed to delete %s %s: %w", ref.Kind, client.ObjectKeyFromObject(obj), err)
+	}
+
+	return nil
+}
+
+func mergeRefs(previous, applied []objectRef) []objectRef {
+	merged := slices.Clone(applied)
+	for _, ref := range previous {
+		if !slices.Contains(merged, ref) {
+			merged = append(merged, ref)
+		}
+	}
+
+	return merged
+}
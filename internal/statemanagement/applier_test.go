@@ -0,0 +1,202 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemanagement
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	configMapManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  key: value
+`
+	clusterRoleManifest = `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+rules:
+- apiGroups: [""]
+  resources: [pods]
+  verbs: [get]
+`
+)
+
+// applyAsCreateOrUpdate emulates the server-side apply not supported by the fake client with a create or an update.
+func applyAsCreateOrUpdate(ctx context.Context, cl client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return cl.Patch(ctx, obj, patch, opts...)
+	}
+
+	existing := new(unstructured.Unstructured)
+	existing.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return cl.Create(ctx, obj)
+	}
+
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return cl.Update(ctx, obj)
+}
+
+func newApplierProvider(t *testing.T, templates ...string) (provider *ApplierProvider, mgmtClient, clusterClient client.Client) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{kcm.AddToScheme, corev1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatalf("failed to build scheme: %v", err)
+		}
+	}
+
+	objects := make([]client.Object, 0, 2*len(templates))
+	for _, name := range templates {
+		objects = append(objects,
+			&kcm.ServiceTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+				Spec: kcm.ServiceTemplateSpec{Resources: &kcm.SourceSpec{
+					LocalSourceRef: &kcm.LocalSourceRef{Kind: "ConfigMap", Name: name + "-manifests"},
+					DeploymentType: "Remote",
+				}},
+				Status: kcm.ServiceTemplateStatus{
+					TemplateStatusCommon: kcm.TemplateStatusCommon{
+						TemplateValidationStatus: kcm.TemplateValidationStatus{Valid: true},
+					},
+				},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: name + "-manifests", Namespace: "team-a"},
+				Data:       map[string]string{"manifests.yaml": configMapManifest + "---\n" + clusterRoleManifest},
+			},
+		)
+	}
+
+	mgmtClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	clusterClient = fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).
+		WithRESTMapper(testrestmapper.TestOnlyStaticRESTMapper(clientgoscheme.Scheme)).
+		WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).Build()
+
+	return &ApplierProvider{
+		Client: mgmtClient,
+		ClusterClient: func(context.Context, *kcm.ClusterDeployment) (client.Client, error) {
+			return clusterClient, nil
+		},
+	}, mgmtClient, clusterClient
+}
+
+func TestApplierProviderConformance(t *testing.T) {
+	testConformance(t, func(t *testing.T, templates ...string) conformanceEnv {
+		t.Helper()
+
+		provider, _, _ := newApplierProvider(t, templates...)
+		return conformanceEnv{provider: provider}
+	})
+}
+
+func TestApplierProvider(t *testing.T) {
+	provider, mgmtClient, clusterClient := newApplierProvider(t, "reader")
+	cd := &kcm.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a", UID: "cd-uid"}}
+	services := []kcm.Service{{Name: "reader", Namespace: "reader-system", Template: "reader"}}
+
+	if err := provider.ReconcileServices(t.Context(), cd, services); err != nil {
+		t.Fatalf("failed to reconcile services: %v", err)
+	}
+
+	for _, obj := range []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "reader-system"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "reader-system"}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "reader"}},
+	} {
+		if err := clusterClient.Get(t.Context(), client.ObjectKeyFromObject(obj), obj); err != nil {
+			t.Errorf("expected %T %s to be applied: %v", obj, client.ObjectKeyFromObject(obj), err)
+		}
+	}
+
+	source := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "reader-manifests", Namespace: "team-a"}}
+	if err := mgmtClient.Get(t.Context(), client.ObjectKeyFromObject(source), source); err != nil {
+		t.Fatalf("failed to get manifests ConfigMap: %v", err)
+	}
+	source.Data = map[string]string{"manifests.yaml": configMapManifest}
+	if err := mgmtClient.Update(t.Context(), source); err != nil {
+		t.Fatalf("failed to update manifests ConfigMap: %v", err)
+	}
+
+	if err := provider.ReconcileServices(t.Context(), cd, services); err != nil {
+		t.Fatalf("failed to reconcile services: %v", err)
+	}
+	if err := clusterClient.Get(t.Context(), client.ObjectKey{Name: "reader"}, new(rbacv1.ClusterRole)); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the ClusterRole removed from the manifests to be pruned, got %v", err)
+	}
+
+	// the cluster is no longer reachable
+	provider.ClusterClient = func(context.Context, *kcm.ClusterDeployment) (client.Client, error) {
+		return nil, apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, cd.Name+"-kubeconfig")
+	}
+	if err := provider.ReconcileServices(t.Context(), cd, services); err != nil {
+		t.Fatalf("failed to reconcile services: %v", err)
+	}
+	conditions, err := provider.ServicesConditions(t.Context(), cd)
+	if err != nil {
+		t.Fatalf("failed to get services conditions: %v", err)
+	}
+	if len(conditions) != 1 || conditions[0].Status != metav1.ConditionUnknown {
+		t.Errorf("expected a single Unknown condition while the cluster is not reachable, got %+v", conditions)
+	}
+
+	if err := provider.DeleteServices(t.Context(), cd); err != nil {
+		t.Fatalf("failed to delete services: %v", err)
+	}
+	if err := clusterClient.Get(t.Context(), client.ObjectKey{Name: "settings", Namespace: "reader-system"}, new(corev1.ConfigMap)); err != nil {
+		t.Errorf("expected the objects to be kept if the cluster is gone, got %v", err)
+	}
+	inventory := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ApplierInventoryName(cd, services[0]), Namespace: cd.Namespace}}
+	if err := mgmtClient.Get(t.Context(), client.ObjectKeyFromObject(inventory), inventory); !apierrors.IsNotFound(err) {
+		t.Errorf("expected the inventory to be deleted, got %v", err)
+	}
+
+	provider.ClusterClient = func(context.Context, *kcm.ClusterDeployment) (client.Client, error) {
+		return nil, errors.New("unreachable")
+	}
+	helm := &kcm.ServiceTemplate{ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "team-a"}, Spec: kcm.ServiceTemplateSpec{Helm: &kcm.HelmSpec{}}}
+	if err := mgmtClient.Create(t.Context(), helm); err != nil {
+		t.Fatalf("failed to create ServiceTemplate: %v", err)
+	}
+	if err := provider.ReconcileServices(t.Context(), cd, []kcm.Service{{Name: "ingress", Template: "ingress"}}); err == nil {
+		t.Errorf("expected error for Helm-based ServiceTemplate")
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemanagement
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// conformanceEnv is the environment a Provider is tested for the conformance in.
+type conformanceEnv struct {
+	provider Provider
+	// markReady marks the services delivered to the cluster as ready,
+	// nil if the services are ready once delivered.
+	markReady func(t *testing.T, cd *kcm.ClusterDeployment)
+}
+
+// testConformance tests the given Provider conforms to the contract expected by the ClusterDeployment controller.
+// The environment has to be created with the valid ServiceTemplates of the given names in the "team-a" namespace.
+func testConformance(t *testing.T, newEnv func(t *testing.T, templates ...string) conformanceEnv) {
+	t.Helper()
+
+	env := newEnv(t, "ingress", "monitoring", "logging")
+	cd := &kcm.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a", UID: "cd-uid"}}
+	services := []kcm.Service{
+		{Name: "ingress", Namespace: "ingress-system", Template: "ingress"},
+		{Name: "monitoring", Template: "monitoring"},
+		{Name: "logging", Template: "logging", Disable: true},
+	}
+
+	if err := env.provider.ReconcileServices(t.Context(), cd, services); err != nil {
+		t.Fatalf("failed to reconcile services: %v", err)
+	}
+	expectConditions(t, env.provider, cd, []string{"ingress-system.ingress", "monitoring.monitoring"}, "")
+
+	if env.markReady != nil {
+		env.markReady(t, cd)
+	}
+	expectConditions(t, env.provider, cd, []string{"ingress-system.ingress", "monitoring.monitoring"}, metav1.ConditionTrue)
+
+	if err := env.provider.ReconcileServices(t.Context(), cd, services[:1]); err != nil {
+		t.Fatalf("failed to reconcile services: %v", err)
+	}
+	expectConditions(t, env.provider, cd, []string{"ingress-system.ingress"}, "")
+
+	missing := []kcm.Service{{Name: "missing", Template: "missing"}}
+	if err := env.provider.ReconcileServices(t.Context(), cd, missing); err == nil {
+		t.Errorf("expected error for missing ServiceTemplate")
+	}
+
+	for range 2 {
+		if err := env.provider.DeleteServices(t.Context(), cd); err != nil {
+			t.Fatalf("failed to delete services: %v", err)
+		}
+	}
+	expectConditions(t, env.provider, cd, nil, "")
+}
+
+// expectConditions checks the services conditions are reported for the given releases only
+// and, if the status is set, all of them have the status.
+func expectConditions(t *testing.T, provider Provider, cd *kcm.ClusterDeployment, releases []string, status metav1.ConditionStatus) {
+	t.Helper()
+
+	conditions, err := provider.ServicesConditions(t.Context(), cd)
+	if err != nil {
+		t.Fatalf("failed to get services conditions: %v", err)
+	}
+
+	var got []string
+	for _, c := range conditions {
+		if !IsServiceCondition(c.Type) {
+			t.Errorf("condition %s is not a service condition", c.Type)
+		}
+		if status != "" && c.Status != status {
+			t.Errorf("expected condition %s to be %s, got %s: %s", c.Type, status, c.Status, c.Message)
+		}
+		release, _, _ := strings.Cut(c.Type, "/")
+		got = append(got, release)
+	}
+	slices.Sort(got)
+
+	if !slices.Equal(got, releases) {
+		t.Errorf("unexpected services conditions: got %v, want %v", got, releases)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemanagement
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/flux"
+)
+
+// FluxProvider delivers the services with the Flux HelmReleases reconciled
+// in the management cluster against the kubeconfig of the cluster.
+type FluxProvider struct {
+	Client client.Client
+}
+
+var _ Provider = (*FluxProvider)(nil)
+
+// ReconcileServices implements Provider.
+func (p *FluxProvider) ReconcileServices(ctx context.Context, cd *kcm.ClusterDeployment, services []kcm.Service) error {
+	return flux.ReconcileServices(ctx, p.Client, cd, services)
+}
+
+// DeleteServices implements Provider.
+func (p *FluxProvider) DeleteServices(ctx context.Context, cd *kcm.ClusterDeployment) error {
+	return flux.DeleteServices(ctx, p.Client, cd)
+}
+
+// ServicesConditions implements Provider.
+func (p *FluxProvider) ServicesConditions(ctx context.Context, cd *kcm.ClusterDeployment) ([]metav1.Condition, error) {
+	return flux.ServicesConditions(ctx, p.Client, cd)
+}
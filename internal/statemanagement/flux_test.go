@@ -0,0 +1,80 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemanagement
+
+import (
+	"testing"
+
+	hcv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestFluxProviderConformance(t *testing.T) {
+	testConformance(t, func(t *testing.T, templates ...string) conformanceEnv {
+		t.Helper()
+
+		scheme := runtime.NewScheme()
+		for _, add := range []func(*runtime.Scheme) error{kcm.AddToScheme, hcv2.AddToScheme} {
+			if err := add(scheme); err != nil {
+				t.Fatalf("failed to build scheme: %v", err)
+			}
+		}
+
+		objects := make([]client.Object, 0, len(templates))
+		for _, name := range templates {
+			objects = append(objects, &kcm.ServiceTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+				Spec:       kcm.ServiceTemplateSpec{Helm: &kcm.HelmSpec{ChartSpec: &sourcev1.HelmChartSpec{Chart: name}}},
+				Status: kcm.ServiceTemplateStatus{
+					TemplateStatusCommon: kcm.TemplateStatusCommon{
+						TemplateValidationStatus: kcm.TemplateValidationStatus{Valid: true},
+						ChartRef:                 &hcv2.CrossNamespaceSourceReference{Kind: "HelmChart", Name: name, Namespace: "team-a"},
+					},
+				},
+			})
+		}
+		cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).WithStatusSubresource(&hcv2.HelmRelease{}).Build()
+
+		return conformanceEnv{
+			provider: &FluxProvider{Client: cl},
+			markReady: func(t *testing.T, cd *kcm.ClusterDeployment) {
+				t.Helper()
+
+				releases := new(hcv2.HelmReleaseList)
+				if err := cl.List(t.Context(), releases, client.InNamespace(cd.Namespace)); err != nil {
+					t.Fatalf("failed to list HelmReleases: %v", err)
+				}
+				for _, hr := range releases.Items {
+					apimeta.SetStatusCondition(&hr.Status.Conditions, metav1.Condition{
+						Type:   meta.ReadyCondition,
+						Status: metav1.ConditionTrue,
+						Reason: meta.SucceededReason,
+					})
+					if err := cl.Status().Update(t.Context(), &hr); err != nil {
+						t.Fatalf("failed to update HelmRelease status: %v", err)
+					}
+				}
+			},
+		}
+	})
+}
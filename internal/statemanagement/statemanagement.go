@@ -0,0 +1,174 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package statemanagement delivers the services of the ClusterDeployments to the clusters
+// with the pluggable engines selected per cluster, by the StateManagementProviders or per Management.
+//
+// Sveltos delivers the services within the Profile of the ClusterDeployment along with the policies
+// and is not represented by a Provider. The other engines implement the Provider interface
+// and have to pass the conformance tests of the package.
+package statemanagement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// Provider delivers the services of the ClusterDeployments to the clusters.
+type Provider interface {
+	// ReconcileServices delivers the given services except the disabled ones to the cluster
+	// of the ClusterDeployment and removes the previously delivered services no longer desired.
+	ReconcileServices(ctx context.Context, cd *kcm.ClusterDeployment, services []kcm.Service) error
+	// DeleteServices removes all of the services delivered to the cluster of the ClusterDeployment.
+	DeleteServices(ctx context.Context, cd *kcm.ClusterDeployment) error
+	// ServicesConditions returns the readiness conditions of the services delivered to the cluster
+	// of the ClusterDeployment, one per service, of the <release namespace>.<release name>/<type> type.
+	ServicesConditions(ctx context.Context, cd *kcm.ClusterDeployment) ([]metav1.Condition, error)
+}
+
+// ClusterClientFunc returns the client of the cluster deployed by the given ClusterDeployment.
+type ClusterClientFunc func(ctx context.Context, cd *kcm.ClusterDeployment) (client.Client, error)
+
+// Providers returns the providers of the engines other than Sveltos keyed by the engine.
+// The cluster client is only used by the engines applying the services to the clusters directly.
+func Providers(cl client.Client, clusterClient ClusterClientFunc) map[string]Provider {
+	return map[string]Provider{
+		kcm.ServiceDeliveryFlux:    &FluxProvider{Client: cl},
+		kcm.ServiceDeliveryApplier: &ApplierProvider{Client: cl, ClusterClient: clusterClient},
+	}
+}
+
+// DefaultRequiredCRDs returns the names of the CustomResourceDefinitions the given engine requires.
+func DefaultRequiredCRDs(engine string) []string {
+	switch engine {
+	case kcm.ServiceDeliverySveltos:
+		return []string{"profiles.config.projectsveltos.io", "clustersummaries.config.projectsveltos.io"}
+	case kcm.ServiceDeliveryFlux:
+		return []string{"helmreleases.helm.toolkit.fluxcd.io"}
+	default:
+		return nil
+	}
+}
+
+// Engine returns the engine delivering the services of the given ClusterDeployment. The engine set
+// in the ClusterDeployment takes precedence, then the one of the first StateManagementProvider selecting
+// the ClusterDeployment, then the one set in the Management, Sveltos is used by default
+// or Flux with the Edge management profile.
+// An error is returned if the selecting StateManagementProvider is not ready.
+func Engine(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) (string, error) {
+	engine, smp, err := selectEngine(ctx, cl, cd)
+	if err != nil {
+		return "", err
+	}
+
+	if smp != nil && !smp.Status.Ready {
+		msg := fmt.Sprintf("StateManagementProvider %s of the %s engine is not ready", smp.Name, smp.Spec.Engine)
+		if smp.Status.Error != "" {
+			msg += ": " + smp.Status.Error
+		}
+		return "", errors.New(msg)
+	}
+
+	return engine, nil
+}
+
+// SelectedEngine returns the engine selected for the given ClusterDeployment as [Engine] does
+// regardless of the readiness of the selecting StateManagementProvider, e.g. on the admission.
+func SelectedEngine(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) (string, error) {
+	engine, _, err := selectEngine(ctx, cl, cd)
+	return engine, err
+}
+
+// selectEngine returns the engine selected for the given ClusterDeployment
+// and the StateManagementProvider selecting it, if any.
+func selectEngine(ctx context.Context, cl client.Client, cd *kcm.ClusterDeployment) (string, *kcm.StateManagementProvider, error) {
+	if cd.Spec.ServiceDelivery != "" {
+		return cd.Spec.ServiceDelivery, nil, nil
+	}
+
+	providers := new(kcm.StateManagementProviderList)
+	if err := cl.List(ctx, providers); err != nil {
+		return "", nil, fmt.Errorf("failed to list StateManagementProviders: %w", err)
+	}
+	slices.SortFunc(providers.Items, func(a, b kcm.StateManagementProvider) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, smp := range providers.Items {
+		if smp.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(smp.Spec.Selector)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to parse selector of StateManagementProvider %s: %w", smp.Name, err)
+		}
+		if selector.Empty() || !selector.Matches(labels.Set(cd.Labels)) {
+			continue
+		}
+
+		return smp.Spec.Engine, &smp, nil
+	}
+
+	mgmt := new(kcm.Management)
+	if err := cl.Get(ctx, client.ObjectKey{Name: kcm.ManagementName}, mgmt); err != nil {
+		return "", nil, fmt.Errorf("failed to get Management: %w", err)
+	}
+	if mgmt.Spec.ServiceDelivery != "" {
+		return mgmt.Spec.ServiceDelivery, nil, nil
+	}
+	// Sveltos is not installed with the Edge profile
+	if mgmt.Spec.Profile == kcm.ManagementProfileEdge {
+		return kcm.ServiceDeliveryFlux, nil, nil
+	}
+
+	return kcm.ServiceDeliverySveltos, nil, nil
+}
+
+// SupportsSyncMode reports whether the given engine supports the given sync mode of the services.
+// The engines other than Sveltos only reconcile the services continuously.
+func SupportsSyncMode(engine, syncMode string) bool {
+	return engine == kcm.ServiceDeliverySveltos || syncMode == "" || syncMode == kcm.SyncModeContinuous
+}
+
+// RequiresUniqueServiceNames reports whether the given engine requires the names of the services
+// of a cluster to be unique across the namespaces, e.g. because the objects delivering the services
+// in the management cluster are named after the services.
+func RequiresUniqueServiceNames(engine string) bool {
+	return engine != kcm.ServiceDeliverySveltos
+}
+
+// IsServiceCondition reports whether the given condition type is the readiness condition of a service
+// delivered by any of the engines.
+func IsServiceCondition(conditionType string) bool {
+	i := strings.LastIndex(conditionType, "/")
+	if i < 0 {
+		return false
+	}
+
+	switch conditionType[i+1:] {
+	case kcm.SveltosHelmReleaseReadyCondition, kcm.FluxHelmReleaseReadyCondition, kcm.AppliedServiceReadyCondition:
+		return true
+	default:
+		return false
+	}
+}
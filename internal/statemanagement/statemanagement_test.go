@@ -0,0 +1,164 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statemanagement
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newStateManagementProvider(name, engine string, ready bool, matchLabels map[string]string) *kcm.StateManagementProvider {
+	smp := &kcm.StateManagementProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       kcm.StateManagementProviderSpec{Engine: engine},
+		Status:     kcm.StateManagementProviderStatus{Ready: ready},
+	}
+	if matchLabels != nil {
+		smp.Spec.Selector = &metav1.LabelSelector{MatchLabels: matchLabels}
+	}
+	if !ready {
+		smp.Status.Error = "missing CustomResourceDefinitions: helmreleases.helm.toolkit.fluxcd.io"
+	}
+	return smp
+}
+
+func TestEngine(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kcm.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	edge := map[string]string{"site": "edge"}
+	for _, tc := range []struct {
+		name       string
+		delivery   string
+		labels     map[string]string
+		mgmt       string
+		profile    string
+		providers  []client.Object
+		expected   string
+		expectsErr bool
+	}{
+		{
+			name:     "default",
+			expected: kcm.ServiceDeliverySveltos,
+		},
+		{
+			name:     "set in the Management",
+			mgmt:     kcm.ServiceDeliveryFlux,
+			expected: kcm.ServiceDeliveryFlux,
+		},
+		{
+			name:     "Edge profile",
+			profile:  kcm.ManagementProfileEdge,
+			expected: kcm.ServiceDeliveryFlux,
+		},
+		{
+			name:     "set in the Management with the Edge profile",
+			mgmt:     kcm.ServiceDeliveryApplier,
+			profile:  kcm.ManagementProfileEdge,
+			expected: kcm.ServiceDeliveryApplier,
+		},
+		{
+			name:   "selected by a provider",
+			labels: edge,
+			mgmt:   kcm.ServiceDeliveryFlux,
+			providers: []client.Object{
+				newStateManagementProvider("b-flux", kcm.ServiceDeliveryFlux, true, edge),
+				newStateManagementProvider("a-applier", kcm.ServiceDeliveryApplier, true, edge),
+				newStateManagementProvider("all", kcm.ServiceDeliverySveltos, true, nil),
+			},
+			expected: kcm.ServiceDeliveryApplier,
+		},
+		{
+			name:      "not selected by a provider",
+			labels:    map[string]string{"site": "core"},
+			providers: []client.Object{newStateManagementProvider("applier", kcm.ServiceDeliveryApplier, true, edge)},
+			expected:  kcm.ServiceDeliverySveltos,
+		},
+		{
+			name:       "selected by a not ready provider",
+			labels:     edge,
+			providers:  []client.Object{newStateManagementProvider("flux", kcm.ServiceDeliveryFlux, false, edge)},
+			expectsErr: true,
+		},
+		{
+			name:      "set in the ClusterDeployment",
+			delivery:  kcm.ServiceDeliveryFlux,
+			labels:    edge,
+			providers: []client.Object{newStateManagementProvider("applier", kcm.ServiceDeliveryApplier, false, edge)},
+			expected:  kcm.ServiceDeliveryFlux,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mgmt := &kcm.Management{
+				ObjectMeta: metav1.ObjectMeta{Name: kcm.ManagementName},
+				Spec:       kcm.ManagementSpec{ServiceDelivery: tc.mgmt, Profile: tc.profile},
+			}
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(tc.providers, mgmt)...).Build()
+			cd := &kcm.ClusterDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a", Labels: tc.labels},
+				Spec:       kcm.ClusterDeploymentSpec{ServiceDelivery: tc.delivery},
+			}
+
+			engine, err := Engine(t.Context(), cl, cd)
+			if tc.expectsErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if engine != tc.expected {
+				t.Errorf("unexpected engine: got %q, want %q", engine, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSelectedEngine(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kcm.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	edge := map[string]string{"site": "edge"}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newStateManagementProvider("flux", kcm.ServiceDeliveryFlux, false, edge)).Build()
+	cd := &kcm.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a", Labels: edge}}
+
+	engine, err := SelectedEngine(t.Context(), cl, cd)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if engine != kcm.ServiceDeliveryFlux {
+		t.Errorf("unexpected engine: got %q, want %q", engine, kcm.ServiceDeliveryFlux)
+	}
+}
+
+func TestIsServiceCondition(t *testing.T) {
+	for conditionType, expected := range map[string]bool{
+		"ingress.ingress-nginx/" + kcm.SveltosHelmReleaseReadyCondition: true,
+		"ingress.ingress-nginx/" + kcm.FluxHelmReleaseReadyCondition:    true,
+		"ingress.ingress-nginx/" + kcm.AppliedServiceReadyCondition:     true,
+		kcm.SveltosProfileReadyCondition:                                false,
+		"ingress.ingress-nginx/Other":                                   false,
+	} {
+		if got := IsServiceCondition(conditionType); got != expected {
+			t.Errorf("IsServiceCondition(%q) = %t, want %t", conditionType, got, expected)
+		}
+	}
+}
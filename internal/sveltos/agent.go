@@ -0,0 +1,180 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sveltos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// Namespace is the namespace the Sveltos components are deployed to
+// in the management cluster and the Sveltos agents in the managed clusters.
+const Namespace = "projectsveltos"
+
+// agent is a Sveltos agent deployed to the managed clusters by a Sveltos controller of the management cluster.
+type agent struct {
+	// name is the name of the agent Deployment in the managed clusters.
+	name string
+	// controller is the name of the Deployment of the management cluster controller deploying the agent,
+	// the agent is expected to have the same version as the controller.
+	controller string
+}
+
+var agents = []agent{
+	{name: "sveltos-agent-manager", controller: "classifier-manager"},
+	{name: "drift-detection-manager", controller: "addon-controller"},
+}
+
+// ExpectedAgentVersions returns the versions the Sveltos agents are expected to have
+// by the name of the agent, as per the versions of the Sveltos controllers of the management cluster.
+func ExpectedAgentVersions(ctx context.Context, cl client.Client) (map[string]string, error) {
+	versions := make(map[string]string, len(agents))
+	for _, a := range agents {
+		deployment := new(appsv1.Deployment)
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: a.controller}, deployment); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get Deployment %s/%s: %w", Namespace, a.controller, err)
+		}
+
+		if _, tag := imageTag(deployment); tag != "" {
+			versions[a.name] = tag
+		}
+	}
+
+	return versions, nil
+}
+
+// ReconcileAgents reports the Sveltos agents running in the cluster of the given client and upgrades
+// the agents whose version differs from the expected one. The agents not deployed to the cluster are not reported.
+func ReconcileAgents(ctx context.Context, cl client.Client, expectedVersions map[string]string) ([]kcm.SveltosAgentStatus, error) {
+	l := ctrl.LoggerFrom(ctx)
+
+	var statuses []kcm.SveltosAgentStatus
+	for _, a := range agents {
+		deployment := new(appsv1.Deployment)
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: a.name}, deployment); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get Deployment %s/%s: %w", Namespace, a.name, err)
+		}
+
+		repository, version := imageTag(deployment)
+		status := kcm.SveltosAgentStatus{
+			Name:            a.name,
+			Version:         version,
+			ExpectedVersion: expectedVersions[a.name],
+			Ready:           deploymentReady(deployment),
+		}
+		statuses = append(statuses, status)
+
+		if status.ExpectedVersion == "" || status.Version == status.ExpectedVersion || repository == "" {
+			continue
+		}
+
+		patch := client.MergeFrom(deployment.DeepCopy())
+		deployment.Spec.Template.Spec.Containers[0].Image = repository + ":" + status.ExpectedVersion
+		if err := cl.Patch(ctx, deployment, patch); err != nil {
+			return statuses, fmt.Errorf("failed to upgrade Deployment %s/%s to %s: %w", Namespace, a.name, status.ExpectedVersion, err)
+		}
+		l.Info("Upgraded Sveltos agent", "name", a.name, "from", status.Version, "to", status.ExpectedVersion)
+	}
+
+	return statuses, nil
+}
+
+// AgentsCondition returns the SveltosAgentsHealthy condition reflecting the given agent statuses.
+func AgentsCondition(statuses []kcm.SveltosAgentStatus) metav1.Condition {
+	condition := metav1.Condition{
+		Type:    kcm.SveltosAgentsHealthyCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  kcm.SucceededReason,
+		Message: "All Sveltos agents are ready and up to date",
+	}
+
+	var outdated, notReady []string
+	for _, status := range statuses {
+		if status.ExpectedVersion != "" && status.Version != status.ExpectedVersion {
+			outdated = append(outdated, fmt.Sprintf("%s (%s, expected %s)", status.Name, status.Version, status.ExpectedVersion))
+		}
+		if !status.Ready {
+			notReady = append(notReady, status.Name)
+		}
+	}
+
+	var messages []string
+	if len(outdated) > 0 {
+		condition.Reason = kcm.SveltosAgentsUpgradingReason
+		messages = append(messages, "Upgrading the outdated agents: "+strings.Join(outdated, ", "))
+	}
+	if len(notReady) > 0 {
+		condition.Reason = kcm.FailedReason
+		messages = append(messages, "Agents not ready: "+strings.Join(notReady, ", "))
+	}
+	if len(messages) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Message = strings.Join(messages, "; ")
+	}
+
+	return condition
+}
+
+// imageTag returns the repository and the tag of the image of the first container of the given Deployment.
+func imageTag(deployment *appsv1.Deployment) (repository, tag string) {
+	if len(deployment.Spec.Template.Spec.Containers) == 0 {
+		return "", ""
+	}
+
+	image, _, _ := strings.Cut(deployment.Spec.Template.Spec.Containers[0].Image, "@")
+	// the tag follows the last colon unless it is the port of the registry
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return image, ""
+	}
+
+	return image[:i], image[i+1:]
+}
+
+// deploymentReady reports whether all the replicas of the given Deployment are updated and available.
+func deploymentReady(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return false
+	}
+
+	for _, c := range deployment.Status.Conditions {
+		if c.Type == appsv1.DeploymentAvailable && c.Status != corev1.ConditionTrue {
+			return false
+		}
+	}
+
+	return deployment.Status.UpdatedReplicas == replicas && deployment.Status.AvailableReplicas == replicas
+}
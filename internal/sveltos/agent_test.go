@@ -0,0 +1,106 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sveltos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func newDeployment(name, image string, ready bool) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: Namespace, Name: name},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "manager", Image: image}}},
+			},
+		},
+	}
+	if ready {
+		deployment.Status = appsv1.DeploymentStatus{UpdatedReplicas: 1, AvailableReplicas: 1}
+	}
+	return deployment
+}
+
+func TestImageTag(t *testing.T) {
+	for image, want := range map[string][2]string{
+		"docker.io/projectsveltos/sveltos-agent:v0.51.1":        {"docker.io/projectsveltos/sveltos-agent", "v0.51.1"},
+		"registry:5000/projectsveltos/sveltos-agent:v0.51.1":    {"registry:5000/projectsveltos/sveltos-agent", "v0.51.1"},
+		"registry:5000/projectsveltos/sveltos-agent":            {"registry:5000/projectsveltos/sveltos-agent", ""},
+		"projectsveltos/sveltos-agent:v0.51.1@sha256:0123abcdf": {"projectsveltos/sveltos-agent", "v0.51.1"},
+	} {
+		repository, tag := imageTag(newDeployment("agent", image, true))
+		require.Equal(t, want, [2]string{repository, tag}, image)
+	}
+}
+
+func TestReconcileAgents(t *testing.T) {
+	mgmtClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		newDeployment("classifier-manager", "projectsveltos/classifier:v0.52.0", true),
+		newDeployment("addon-controller", "projectsveltos/addon-controller:v0.52.0", true),
+	).Build()
+
+	expectedVersions, err := ExpectedAgentVersions(t.Context(), mgmtClient)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"sveltos-agent-manager":   "v0.52.0",
+		"drift-detection-manager": "v0.52.0",
+	}, expectedVersions)
+
+	clusterClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		newDeployment("sveltos-agent-manager", "projectsveltos/sveltos-agent:v0.51.1", true),
+	).Build()
+
+	statuses, err := ReconcileAgents(t.Context(), clusterClient, expectedVersions)
+	require.NoError(t, err)
+	require.Equal(t, []kcm.SveltosAgentStatus{
+		{Name: "sveltos-agent-manager", Version: "v0.51.1", ExpectedVersion: "v0.52.0", Ready: true},
+	}, statuses)
+
+	condition := AgentsCondition(statuses)
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Equal(t, kcm.SveltosAgentsUpgradingReason, condition.Reason)
+	require.Equal(t, "Upgrading the outdated agents: sveltos-agent-manager (v0.51.1, expected v0.52.0)", condition.Message)
+
+	deployment := new(appsv1.Deployment)
+	require.NoError(t, clusterClient.Get(t.Context(), client.ObjectKey{Namespace: Namespace, Name: "sveltos-agent-manager"}, deployment))
+	require.Equal(t, "projectsveltos/sveltos-agent:v0.52.0", deployment.Spec.Template.Spec.Containers[0].Image)
+
+	statuses, err = ReconcileAgents(t.Context(), clusterClient, expectedVersions)
+	require.NoError(t, err)
+	require.Equal(t, []kcm.SveltosAgentStatus{
+		{Name: "sveltos-agent-manager", Version: "v0.52.0", ExpectedVersion: "v0.52.0", Ready: true},
+	}, statuses)
+	require.Equal(t, metav1.ConditionTrue, AgentsCondition(statuses).Status)
+}
+
+func TestAgentsConditionNotReady(t *testing.T) {
+	condition := AgentsCondition([]kcm.SveltosAgentStatus{
+		{Name: "sveltos-agent-manager", Version: "v0.52.0", ExpectedVersion: "v0.52.0", Ready: true},
+		{Name: "drift-detection-manager", Version: "v0.52.0", ExpectedVersion: "v0.52.0"},
+	})
+	require.Equal(t, metav1.ConditionFalse, condition.Status)
+	require.Equal(t, kcm.FailedReason, condition.Reason)
+	require.Equal(t, "Agents not ready: drift-detection-manager", condition.Message)
+}
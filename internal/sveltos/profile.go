@@ -225,8 +225,12 @@ func GetHelmCharts(ctx context.Context, c client.Client, namespace string, servi
 		if repo.Spec.SecretRef != nil {
 			helmChart.RegistryCredentialsConfig.CredentialsSecretRef = &corev1.SecretReference{
 				Name:      repo.Spec.SecretRef.Name,
-				Namespace: namespace,
+				Namespace: repo.Namespace,
 			}
+		} else if repo.Spec.Provider != "" && repo.Spec.Provider != kcm.RegistryAuthProviderGeneric {
+			// Sveltos pulls the charts itself and authenticates only with the credentials Secrets.
+			return nil, fmt.Errorf("the %s registry provider of the HelmRepository %s referenced by ServiceTemplate %s is not supported by Sveltos, use the Flux service delivery or a credentials Secret",
+				repo.Spec.Provider, repoRef.String(), tmplRef.String())
 		}
 
 		helmCharts = append(helmCharts, helmChart)
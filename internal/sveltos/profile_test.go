@@ -19,7 +19,16 @@ import (
 	"fmt"
 	"testing"
 
+	helmcontrollerv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/fluxcd/pkg/apis/meta"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
 )
 
 func Test_priorityToTier(t *testing.T) {
@@ -44,3 +53,79 @@ func Test_priorityToTier(t *testing.T) {
 		})
 	}
 }
+
+func TestGetHelmChartsRegistryCredentials(t *testing.T) {
+	const (
+		systemNamespace = "kcm-system"
+		namespace       = "tenant"
+	)
+
+	// the template copied to the tenant namespace references the chart and the repository of the system namespace
+	tmpl := &kcm.ServiceTemplate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "ingress-nginx-4-12-0"},
+		Spec:       kcm.ServiceTemplateSpec{Helm: &kcm.HelmSpec{ChartRef: &helmcontrollerv2.CrossNamespaceSourceReference{}}},
+		Status: kcm.ServiceTemplateStatus{TemplateStatusCommon: kcm.TemplateStatusCommon{
+			TemplateValidationStatus: kcm.TemplateValidationStatus{Valid: true},
+			ChartRef: &helmcontrollerv2.CrossNamespaceSourceReference{
+				Kind:      sourcev1.HelmChartKind,
+				Namespace: systemNamespace,
+				Name:      "ingress-nginx-4-12-0",
+			},
+		}},
+	}
+	chart := &sourcev1.HelmChart{
+		ObjectMeta: metav1.ObjectMeta{Namespace: systemNamespace, Name: "ingress-nginx-4-12-0"},
+		Spec: sourcev1.HelmChartSpec{
+			Chart:     "ingress-nginx",
+			Version:   "4.12.0",
+			SourceRef: sourcev1.LocalHelmChartSourceReference{Kind: sourcev1.HelmRepositoryKind, Name: "ingress-nginx-4-12-0"},
+		},
+	}
+	newRepo := func(secretRef *meta.LocalObjectReference, provider string) *sourcev1.HelmRepository {
+		return &sourcev1.HelmRepository{
+			ObjectMeta: metav1.ObjectMeta{Namespace: systemNamespace, Name: "ingress-nginx-4-12-0"},
+			Spec: sourcev1.HelmRepositorySpec{
+				Type:      "oci",
+				URL:       "oci://registry.example.com/charts",
+				SecretRef: secretRef,
+				Provider:  provider,
+			},
+		}
+	}
+	services := []kcm.Service{{Name: "ingress-nginx", Template: tmpl.Name}}
+
+	for _, tc := range []struct {
+		name        string
+		repo        *sourcev1.HelmRepository
+		credentials *corev1.SecretReference
+		err         string
+	}{
+		{
+			name: "no credentials",
+			repo: newRepo(nil, kcm.RegistryAuthProviderGeneric),
+		},
+		{
+			name:        "credentials secret in the namespace of the repository",
+			repo:        newRepo(&meta.LocalObjectReference{Name: "registry-creds"}, kcm.RegistryAuthProviderGeneric),
+			credentials: &corev1.SecretReference{Namespace: systemNamespace, Name: "registry-creds"},
+		},
+		{
+			name: "workload identity",
+			repo: newRepo(nil, kcm.RegistryAuthProviderAWS),
+			err:  "the aws registry provider of the HelmRepository kcm-system/ingress-nginx-4-12-0 referenced by ServiceTemplate tenant/ingress-nginx-4-12-0 is not supported by Sveltos",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tmpl, chart, tc.repo).Build()
+
+			helmCharts, err := GetHelmCharts(t.Context(), cl, namespace, services)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, helmCharts, 1)
+			require.Equal(t, tc.credentials, helmCharts[0].RegistryCredentialsConfig.CredentialsSecretRef)
+		})
+	}
+}
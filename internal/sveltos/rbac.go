@@ -0,0 +1,116 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sveltos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const clusterRoleBindingsKey = "clusterrolebindings.yaml"
+
+// ReconcileClusterRoleBindingsOpts holds the options to reconcile the ClusterRoleBindings of a cluster.
+type ReconcileClusterRoleBindingsOpts struct {
+	OwnerReference      *metav1.OwnerReference
+	ClusterRoleBindings []kcm.ClusterRoleBinding
+}
+
+// ReconcileClusterRoleBindings reconciles a ConfigMap holding the manifests of the given ClusterRoleBindings
+// and returns the PolicyRef deploying them to the matching clusters.
+func ReconcileClusterRoleBindings(
+	ctx context.Context,
+	cl client.Client,
+	namespace string,
+	name string,
+	opts ReconcileClusterRoleBindingsOpts,
+) (*sveltosv1beta1.PolicyRef, error) {
+	l := ctrl.LoggerFrom(ctx)
+
+	manifests := make([]string, 0, len(opts.ClusterRoleBindings))
+	for _, binding := range opts.ClusterRoleBindings {
+		manifest, err := yaml.Marshal(&rbacv1.ClusterRoleBinding{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: rbacv1.SchemeGroupVersion.String(),
+				Kind:       "ClusterRoleBinding",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: binding.Name,
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     binding.ClusterRole,
+			},
+			Subjects: binding.Subjects,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal ClusterRoleBinding %s: %w", binding.Name, err)
+		}
+		manifests = append(manifests, string(manifest))
+	}
+
+	obj := objectMeta(opts.OwnerReference)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: obj,
+	}
+
+	operation, err := ctrl.CreateOrUpdate(ctx, cl, cm, func() error {
+		cm.Data = map[string]string{
+			clusterRoleBindingsKey: strings.Join(manifests, "---\n"),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if operation == controllerutil.OperationResultCreated || operation == controllerutil.OperationResultUpdated {
+		l.Info("Successfully mutated ClusterRoleBindings ConfigMap", "ConfigMap", client.ObjectKeyFromObject(cm), "operation_result", operation)
+	}
+
+	return &sveltosv1beta1.PolicyRef{
+		Kind:           "ConfigMap",
+		Namespace:      namespace,
+		Name:           name,
+		DeploymentType: sveltosv1beta1.DeploymentTypeRemote,
+	}, nil
+}
+
+// DeleteClusterRoleBindings deletes the ConfigMap holding the manifests of the ClusterRoleBindings.
+func DeleteClusterRoleBindings(ctx context.Context, cl client.Client, namespace, name string) error {
+	err := cl.Delete(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	})
+
+	return client.IgnoreNotFound(err)
+}
@@ -0,0 +1,97 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sveltos
+
+import (
+	"testing"
+
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestReconcileClusterRoleBindings(t *testing.T) {
+	const (
+		namespace = "test"
+		name      = "cluster-rbac"
+	)
+
+	cl := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+
+	policyRef, err := ReconcileClusterRoleBindings(t.Context(), cl, namespace, name, ReconcileClusterRoleBindingsOpts{
+		ClusterRoleBindings: []kcm.ClusterRoleBinding{
+			{
+				Name:        "platform-admins",
+				ClusterRole: "cluster-admin",
+				Subjects:    []rbacv1.Subject{{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "platform"}},
+			},
+			{
+				Name:        "developers",
+				ClusterRole: "view",
+				Subjects:    []rbacv1.Subject{{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "dev"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, &sveltosv1beta1.PolicyRef{
+		Kind:           "ConfigMap",
+		Namespace:      namespace,
+		Name:           name,
+		DeploymentType: sveltosv1beta1.DeploymentTypeRemote,
+	}, policyRef)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, cl.Get(t.Context(), client.ObjectKey{Namespace: namespace, Name: name}, cm))
+	require.Equal(t, kcm.KCMManagedLabelValue, cm.Labels[kcm.KCMManagedLabelKey])
+	require.Equal(t, `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  creationTimestamp: null
+  name: platform-admins
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: cluster-admin
+subjects:
+- apiGroup: rbac.authorization.k8s.io
+  kind: Group
+  name: platform
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  creationTimestamp: null
+  name: developers
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: view
+subjects:
+- apiGroup: rbac.authorization.k8s.io
+  kind: User
+  name: dev
+`, cm.Data[clusterRoleBindingsKey])
+
+	require.NoError(t, DeleteClusterRoleBindings(t.Context(), cl, namespace, name))
+	require.True(t, apierrors.IsNotFound(cl.Get(t.Context(), client.ObjectKey{Namespace: namespace, Name: name}, cm)))
+	require.NoError(t, DeleteClusterRoleBindings(t.Context(), cl, namespace, name))
+}
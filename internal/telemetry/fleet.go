@@ -0,0 +1,85 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// FleetReporter sends the daily report of the anonymized statistics of the fleet configured
+// in the Management, independently of the [Tracker] of the telemetry of the controller.
+type FleetReporter struct {
+	client.Client
+
+	// Reporter overrides the reporter configured in the Management.
+	Reporter Reporter
+
+	// TLSProfile is the TLS profile of the connections to the telemetry endpoint.
+	TLSProfile string
+}
+
+// Start implements [sigs.k8s.io/controller-runtime/pkg/manager.Runnable].
+func (r *FleetReporter) Start(ctx context.Context) error {
+	timer := time.NewTimer(0)
+	for {
+		select {
+		case <-timer.C:
+			r.Tick(ctx)
+			timer.Reset(interval)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Tick sends the fleet report if it is configured in the Management.
+func (r *FleetReporter) Tick(ctx context.Context) {
+	if err := r.report(ctx); err != nil {
+		log.FromContext(ctx).WithName("fleet reporter").Error(err, "failed to report the fleet statistics")
+	}
+}
+
+// report sends the fleet report with the reporter configured in the Management.
+func (r *FleetReporter) report(ctx context.Context) error {
+	mgmt := &kcm.Management{}
+	if err := r.Get(ctx, client.ObjectKey{Name: kcm.ManagementName}, mgmt); err != nil {
+		return fmt.Errorf("failed to get Management: %w", err)
+	}
+	if mgmt.Spec.Telemetry == nil {
+		return nil
+	}
+
+	reporter := r.Reporter
+	if reporter == nil {
+		reporter = NewReporter(mgmt.Spec.Telemetry, r.TLSProfile)
+	}
+	if reporter == nil {
+		return nil
+	}
+
+	summary := &kcm.FleetSummary{}
+	if err := r.Get(ctx, client.ObjectKey{Name: kcm.FleetSummaryName}, summary); err != nil {
+		return fmt.Errorf("failed to get FleetSummary: %w", err)
+	}
+
+	return reporter.Report(ctx, NewFleetReport(mgmt, summary.Status, mgmt.Spec.Telemetry.DisabledCategories, time.Now()))
+}
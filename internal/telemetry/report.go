@@ -0,0 +1,96 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"maps"
+	"slices"
+	"time"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/build"
+)
+
+// FleetReport is the anonymized statistics of the fleet sent with the [Reporter].
+type FleetReport struct {
+	// ManagementID is the hash of the UID of the Management.
+	ManagementID string `json:"managementID"`
+	// KCMVersion is the version of KCM.
+	KCMVersion string `json:"kcmVersion"`
+	// Timestamp is the time of the report.
+	Timestamp time.Time `json:"timestamp"`
+	// ByProvider is the number of clusters per infrastructure provider.
+	ByProvider map[string]int32 `json:"byProvider,omitempty"`
+	// ByTemplate is the number of clusters per hash of the name of ClusterTemplate, so the names of the custom
+	// templates are not disclosed while the templates shipped with KCM are recognized by the hashes of their names.
+	ByTemplate map[string]int32 `json:"byTemplate,omitempty"`
+	// ByKubernetesVersion is the number of clusters per Kubernetes version.
+	ByKubernetesVersion map[string]int32 `json:"byKubernetesVersion,omitempty"`
+	// ByPhase is the number of clusters per lifecycle phase.
+	ByPhase map[string]int32 `json:"byPhase,omitempty"`
+	// TotalClusters is the total number of clusters.
+	TotalClusters int32 `json:"totalClusters"`
+}
+
+// NewFleetReport returns the report of the given fleet summary without the given disabled categories.
+// The names and the namespaces of the clusters are never reported, the names of the templates are hashed.
+func NewFleetReport(mgmt *kcm.Management, summary kcm.FleetSummaryStatus, disabledCategories []string, now time.Time) *FleetReport {
+	report := &FleetReport{
+		ManagementID:  hash(string(mgmt.UID)),
+		KCMVersion:    build.Version,
+		Timestamp:     now.UTC(),
+		TotalClusters: summary.TotalClusters,
+	}
+
+	enabled := func(category string) bool {
+		return !slices.Contains(disabledCategories, category)
+	}
+	if enabled(kcm.TelemetryCategoryProviders) {
+		report.ByProvider = maps.Clone(summary.ByProvider)
+	}
+	if enabled(kcm.TelemetryCategoryTemplates) {
+		report.ByTemplate = hashKeys(summary.ByTemplate)
+	}
+	if enabled(kcm.TelemetryCategoryKubernetesVersions) {
+		report.ByKubernetesVersion = maps.Clone(summary.ByKubernetesVersion)
+	}
+	if enabled(kcm.TelemetryCategoryPhases) {
+		report.ByPhase = maps.Clone(summary.ByPhase)
+	}
+
+	return report
+}
+
+// hashKeys returns the copy of the given counts keyed by the hashes of the keys.
+func hashKeys(counts map[string]int32) map[string]int32 {
+	if counts == nil {
+		return nil
+	}
+
+	hashed := make(map[string]int32, len(counts))
+	for k, v := range counts {
+		hashed[hash(k)] += v
+	}
+
+	return hashed
+}
+
+// hash returns the hex-encoded SHA-256 hash of the given value.
+func hash(v string) string {
+	sum := sha256.Sum256([]byte(v))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,115 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/utils/tlsprofile"
+)
+
+const reportTimeout = 30 * time.Second
+
+// Reporter sends the [FleetReport].
+type Reporter interface {
+	Report(ctx context.Context, report *FleetReport) error
+}
+
+// HTTPReporter posts the report as JSON to the endpoint.
+type HTTPReporter struct {
+	// Client posts the report, defaults to [http.DefaultClient].
+	Client   *http.Client
+	Endpoint string
+}
+
+// Report implements [Reporter].
+func (r *HTTPReporter) Report(ctx context.Context, report *FleetReport) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the telemetry report: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reportTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create the telemetry request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := r.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post the telemetry report to %s: %w", r.Endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status %s of the telemetry endpoint %s", resp.Status, r.Endpoint)
+	}
+
+	return nil
+}
+
+// LogReporter logs the report that would be sent instead of sending it.
+type LogReporter struct{}
+
+// Report implements [Reporter].
+func (LogReporter) Report(ctx context.Context, report *FleetReport) error {
+	body, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the telemetry report: %w", err)
+	}
+
+	log.FromContext(ctx).Info("Telemetry report that would be sent", "report", string(body))
+	return nil
+}
+
+// NewReporter returns the [Reporter] for the given telemetry configuration, nil if the report is disabled.
+// The report is posted with the given TLS profile.
+func NewReporter(telemetry *kcm.Telemetry, tlsProfile string) Reporter {
+	switch {
+	case telemetry == nil:
+		return nil
+	case telemetry.Mode == kcm.TelemetryModePrint:
+		return LogReporter{}
+	case telemetry.Endpoint != "":
+		return &HTTPReporter{Endpoint: telemetry.Endpoint, Client: newHTTPClient(tlsProfile)}
+	default:
+		return nil
+	}
+}
+
+// newHTTPClient returns the HTTP client configured with the given TLS profile.
+func newHTTPClient(tlsProfile string) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	tlsprofile.Apply(tlsProfile, transport.TLSClientConfig)
+
+	return &http.Client{Transport: transport}
+}
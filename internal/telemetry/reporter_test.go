@@ -0,0 +1,123 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestReportFleet(t *testing.T) {
+	mgmt := &kcm.Management{
+		ObjectMeta: metav1.ObjectMeta{Name: kcm.ManagementName, UID: "management-uid"},
+		Spec: kcm.ManagementSpec{Telemetry: &kcm.Telemetry{
+			Mode:               kcm.TelemetryModeSend,
+			DisabledCategories: []string{kcm.TelemetryCategoryPhases},
+		}},
+	}
+	summary := &kcm.FleetSummary{
+		ObjectMeta: metav1.ObjectMeta{Name: kcm.FleetSummaryName},
+		Status: kcm.FleetSummaryStatus{
+			ByProvider:          map[string]int32{"infrastructure-aws": 2},
+			ByTemplate:          map[string]int32{"aws-standalone-cp-0-2-0": 2},
+			ByKubernetesVersion: map[string]int32{"v1.31.2+k0s.0": 2},
+			ByPhase:             map[string]int32{kcm.ClusterPhaseReady: 2},
+			Clusters:            []kcm.FleetClusterSummary{{Namespace: "team-a", Name: "prod"}, {Namespace: "team-b", Name: "dev"}},
+			TotalClusters:       2,
+		},
+	}
+
+	var received map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	mgmt.Spec.Telemetry.Endpoint = server.URL
+
+	reporter := &FleetReporter{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(mgmt, summary).Build()}
+	require.NoError(t, reporter.report(t.Context()))
+
+	require.Len(t, received["managementID"], 64)
+	require.NotContains(t, received["managementID"], "management-uid")
+	require.InDelta(t, 2, received["totalClusters"], 0)
+	require.Equal(t, map[string]any{"infrastructure-aws": float64(2)}, received["byProvider"])
+	require.Equal(t, map[string]any{"v1.31.2+k0s.0": float64(2)}, received["byKubernetesVersion"])
+	require.Equal(t, map[string]any{hash("aws-standalone-cp-0-2-0"): float64(2)}, received["byTemplate"])
+	require.NotContains(t, fmt.Sprint(received), "aws-standalone-cp-0-2-0")
+	require.NotContains(t, received, "byPhase")
+	require.NotContains(t, received, "clusters")
+}
+
+func TestReportFleetDisabled(t *testing.T) {
+	mgmt := &kcm.Management{ObjectMeta: metav1.ObjectMeta{Name: kcm.ManagementName}}
+
+	reported := false
+	reporter := &FleetReporter{
+		Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(mgmt).Build(),
+		Reporter: reporterFunc(func(context.Context, *FleetReport) error {
+			reported = true
+			return nil
+		}),
+	}
+	require.NoError(t, reporter.report(t.Context()))
+	require.False(t, reported, "the report must not be sent unless configured in the Management")
+}
+
+type reporterFunc func(ctx context.Context, report *FleetReport) error
+
+func (f reporterFunc) Report(ctx context.Context, report *FleetReport) error {
+	return f(ctx, report)
+}
+
+func TestNewReporter(t *testing.T) {
+	require.Nil(t, NewReporter(nil, kcm.TLSProfileDefault))
+	require.Equal(t, LogReporter{}, NewReporter(&kcm.Telemetry{Mode: kcm.TelemetryModePrint, Endpoint: "https://telemetry.example.com"}, kcm.TLSProfileDefault))
+
+	reporter := NewReporter(&kcm.Telemetry{Mode: kcm.TelemetryModeSend, Endpoint: "https://telemetry.example.com"}, kcm.TLSProfileRestricted)
+	require.IsType(t, &HTTPReporter{}, reporter)
+	httpReporter := reporter.(*HTTPReporter)
+	require.Equal(t, "https://telemetry.example.com", httpReporter.Endpoint)
+	require.NotSame(t, http.DefaultClient, httpReporter.Client)
+	transport, ok := httpReporter.Client.Transport.(*http.Transport)
+	require.True(t, ok)
+	require.Equal(t, uint16(tls.VersionTLS12), transport.TLSClientConfig.MinVersion)
+	require.NotEmpty(t, transport.TLSClientConfig.CipherSuites, "the Restricted profile must limit the cipher suites")
+}
+
+func TestHTTPReporterStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	reporter := &HTTPReporter{Endpoint: server.URL}
+	err := reporter.Report(t.Context(), NewFleetReport(&kcm.Management{}, kcm.FleetSummaryStatus{}, nil, time.Now()))
+	require.ErrorContains(t, err, "unexpected status 403 Forbidden")
+}
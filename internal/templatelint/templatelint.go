@@ -0,0 +1,197 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package templatelint validates the structure of the Helm charts of the ClusterTemplates KCM relies on,
+// i.e. the chart annotations with the Kubernetes version, the providers and their contracts,
+// the cluster identity kind and the schema of the values.
+package templatelint
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/providers"
+)
+
+const (
+	chartFile  = "Chart.yaml"
+	valuesFile = "values.yaml"
+	schemaFile = "values.schema.json"
+
+	// contractAnnotationPrefix is the prefix of the annotations with the CAPI contract versions of the providers.
+	contractAnnotationPrefix = "cluster.x-k8s.io/"
+)
+
+var (
+	providerPrefixes = []string{providers.InfraPrefix, "control-plane-", "bootstrap-"}
+	contractVersion  = regexp.MustCompile(`^v1(alpha|beta)?\d+$`)
+)
+
+// Issue is a problem with the chart of a ClusterTemplate.
+type Issue struct {
+	// Field is the file or the annotation of the chart the issue is found in.
+	Field string
+	// Message describes the issue.
+	Message string
+}
+
+func (i Issue) String() string {
+	return i.Field + ": " + i.Message
+}
+
+// Error returns the error joining the given issues or nil if there are no issues.
+func Error(issues []Issue) error {
+	errs := make([]error, 0, len(issues))
+	for _, issue := range issues {
+		errs = append(errs, errors.New(issue.String()))
+	}
+	return errors.Join(errs...)
+}
+
+// ClusterTemplate lints the given chart of a ClusterTemplate. The given providers set in the spec
+// of the ClusterTemplate take precedence over the providers in the chart annotations.
+func ClusterTemplate(helmChart *chart.Chart, specProviders kcm.Providers) []Issue {
+	if helmChart.Metadata == nil {
+		return []Issue{{Field: chartFile, Message: "the chart metadata is missing"}}
+	}
+
+	annotations := helmChart.Metadata.Annotations
+
+	var issues []Issue
+	if version, ok := annotations[kcm.ChartAnnotationKubernetesVersion]; ok {
+		if _, err := semver.NewVersion(version); err != nil {
+			issues = append(issues, annotationIssue(kcm.ChartAnnotationKubernetesVersion, "invalid Kubernetes version %q: %v", version, err))
+		}
+	}
+
+	switch mode := annotations[kcm.ChartAnnotationControlPlaneMode]; mode {
+	case "", kcm.ControlPlaneModeHosted, kcm.ControlPlaneModeStandalone:
+	default:
+		issues = append(issues, annotationIssue(kcm.ChartAnnotationControlPlaneMode, "invalid control plane mode %q, expected %s or %s",
+			mode, kcm.ControlPlaneModeHosted, kcm.ControlPlaneModeStandalone))
+	}
+
+	for cni := range strings.SplitSeq(annotations[kcm.ChartAnnotationSupportedCNIs], ",") {
+		switch cni = strings.TrimSpace(cni); cni {
+		case "", kcm.CNICalico, kcm.CNICilium, kcm.CNINone:
+		default:
+			issues = append(issues, annotationIssue(kcm.ChartAnnotationSupportedCNIs, "invalid CNI %q, expected %s, %s or %s",
+				cni, kcm.CNICalico, kcm.CNICilium, kcm.CNINone))
+		}
+	}
+
+	chartProviders := specProviders
+	if len(chartProviders) == 0 {
+		for p := range strings.SplitSeq(annotations[clusterapiv1beta1.ProviderNameLabel], ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				chartProviders = append(chartProviders, p)
+			}
+		}
+	}
+	providerIssues, infraProvider := lintProviders(chartProviders)
+	issues = append(issues, providerIssues...)
+	issues = append(issues, lintContracts(annotations, chartProviders)...)
+	issues = append(issues, lintValues(helmChart, infraProvider)...)
+
+	return issues
+}
+
+// lintProviders lints the providers of the chart and returns the infrastructure provider if it is the only one.
+func lintProviders(chartProviders []string) (issues []Issue, infraProvider string) {
+	if len(chartProviders) == 0 {
+		return []Issue{annotationIssue(clusterapiv1beta1.ProviderNameLabel, "the providers are not set in the chart annotations nor in the ClusterTemplate spec")}, ""
+	}
+
+	var infraProviders []string
+	for _, p := range chartProviders {
+		if !slices.ContainsFunc(providerPrefixes, func(prefix string) bool { return strings.HasPrefix(p, prefix) }) {
+			issues = append(issues, annotationIssue(clusterapiv1beta1.ProviderNameLabel, "provider %q has none of the %s prefixes", p, strings.Join(providerPrefixes, ", ")))
+		}
+		if strings.HasPrefix(p, providers.InfraPrefix) {
+			infraProviders = append(infraProviders, p)
+		}
+	}
+
+	switch len(infraProviders) {
+	case 0:
+		issues = append(issues, annotationIssue(clusterapiv1beta1.ProviderNameLabel, "no infrastructure provider is set"))
+	case 1:
+		infraProvider = infraProviders[0]
+	default:
+		issues = append(issues, annotationIssue(clusterapiv1beta1.ProviderNameLabel, "exactly one infrastructure provider is expected, got %s", strings.Join(infraProviders, ", ")))
+	}
+
+	return issues, infraProvider
+}
+
+// lintContracts lints the annotations with the CAPI contract versions of the providers.
+func lintContracts(annotations map[string]string, chartProviders []string) []Issue {
+	var issues []Issue
+	for key, version := range annotations {
+		provider, ok := strings.CutPrefix(key, contractAnnotationPrefix)
+		if !ok || key == clusterapiv1beta1.ProviderNameLabel {
+			continue
+		}
+		if !slices.Contains(chartProviders, provider) {
+			issues = append(issues, annotationIssue(key, "the contract version is set for provider %q missing in the providers of the chart", provider))
+		}
+		if !contractVersion.MatchString(version) {
+			issues = append(issues, annotationIssue(key, "invalid contract version %q, expected a single version, e.g. v1beta1", version))
+		}
+	}
+
+	slices.SortFunc(issues, func(a, b Issue) int { return strings.Compare(a.String(), b.String()) })
+	return issues
+}
+
+// lintValues lints the default values of the chart against the schema of the values
+// and the cluster identity kind against the kinds supported by the infrastructure provider.
+func lintValues(helmChart *chart.Chart, infraProvider string) []Issue {
+	var issues []Issue
+	if len(helmChart.Schema) > 0 {
+		if err := chartutil.ValidateAgainstSingleSchema(helmChart.Values, helmChart.Schema); err != nil {
+			issues = append(issues, Issue{Field: schemaFile, Message: "the default values do not match the schema: " + strings.TrimSpace(err.Error())})
+		}
+	}
+
+	identity, ok := helmChart.Values["clusterIdentity"].(map[string]any)
+	if !ok || infraProvider == "" {
+		return issues
+	}
+	kind, ok := identity["kind"].(string)
+	if !ok || kind == "" {
+		return issues
+	}
+	if kinds, known := providers.GetClusterIdentityKinds(infraProvider); known && !slices.Contains(kinds, kind) {
+		issues = append(issues, Issue{
+			Field:   valuesFile,
+			Message: fmt.Sprintf("cluster identity kind %q is not supported by provider %s, expected one of %s", kind, infraProvider, strings.Join(kinds, ", ")),
+		})
+	}
+
+	return issues
+}
+
+func annotationIssue(annotation, format string, args ...any) Issue {
+	return Issue{Field: "annotation " + annotation, Message: fmt.Sprintf(format, args...)}
+}
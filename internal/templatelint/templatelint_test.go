@@ -0,0 +1,136 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templatelint
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/projectroot"
+)
+
+func TestClusterTemplate(t *testing.T) {
+	newChart := func(annotations map[string]string, values map[string]any, schema string) *chart.Chart {
+		return &chart.Chart{
+			Metadata: &chart.Metadata{APIVersion: chart.APIVersionV2, Name: "test", Version: "0.1.0", Annotations: annotations},
+			Values:   values,
+			Schema:   []byte(schema),
+		}
+	}
+	awsAnnotations := map[string]string{
+		"cluster.x-k8s.io/provider":           "infrastructure-aws, control-plane-k0sproject-k0smotron, bootstrap-k0sproject-k0smotron",
+		"cluster.x-k8s.io/infrastructure-aws": "v1beta2",
+	}
+
+	for _, tc := range []struct {
+		name      string
+		chart     *chart.Chart
+		providers kcm.Providers
+		want      []string
+	}{
+		{
+			name:  "valid",
+			chart: newChart(awsAnnotations, map[string]any{"clusterIdentity": map[string]any{"kind": "AWSClusterStaticIdentity"}}, `{"type":"object"}`),
+		},
+		{
+			name:  "missing metadata",
+			chart: &chart.Chart{},
+			want:  []string{"Chart.yaml: the chart metadata is missing"},
+		},
+		{
+			name:  "missing providers",
+			chart: newChart(nil, nil, ""),
+			want:  []string{"annotation cluster.x-k8s.io/provider: the providers are not set in the chart annotations nor in the ClusterTemplate spec"},
+		},
+		{
+			name:      "providers from spec",
+			chart:     newChart(nil, nil, ""),
+			providers: kcm.Providers{"infrastructure-docker"},
+		},
+		{
+			name: "invalid annotations",
+			chart: newChart(map[string]string{
+				kcm.ChartAnnotationKubernetesVersion:  "latest",
+				kcm.ChartAnnotationControlPlaneMode:   "managed",
+				kcm.ChartAnnotationSupportedCNIs:      "calico, flannel",
+				"cluster.x-k8s.io/provider":           "infrastructure-aws, infrastructure-azure, addon-helm",
+				"cluster.x-k8s.io/infrastructure-aws": "v1beta1, v1beta2",
+				"cluster.x-k8s.io/bootstrap-kubeadm":  "v1beta1",
+			}, nil, ""),
+			want: []string{
+				`annotation k0rdent.mirantis.com/k8s-version: invalid Kubernetes version "latest": Invalid Semantic Version`,
+				`annotation k0rdent.mirantis.com/control-plane-mode: invalid control plane mode "managed", expected hosted or standalone`,
+				`annotation k0rdent.mirantis.com/supported-cnis: invalid CNI "flannel", expected calico, cilium or none`,
+				`annotation cluster.x-k8s.io/provider: provider "addon-helm" has none of the infrastructure-, control-plane-, bootstrap- prefixes`,
+				`annotation cluster.x-k8s.io/provider: exactly one infrastructure provider is expected, got infrastructure-aws, infrastructure-azure`,
+				`annotation cluster.x-k8s.io/bootstrap-kubeadm: the contract version is set for provider "bootstrap-kubeadm" missing in the providers of the chart`,
+				`annotation cluster.x-k8s.io/infrastructure-aws: invalid contract version "v1beta1, v1beta2", expected a single version, e.g. v1beta1`,
+			},
+		},
+		{
+			name: "invalid values",
+			chart: newChart(awsAnnotations,
+				map[string]any{"clusterIdentity": map[string]any{"kind": "AzureClusterIdentity"}, "workersNumber": "two"},
+				`{"type":"object","properties":{"workersNumber":{"type":"integer"}}}`),
+			want: []string{
+				"values.schema.json: the default values do not match the schema: - workersNumber: Invalid type. Expected: integer, given: string",
+				`values.yaml: cluster identity kind "AzureClusterIdentity" is not supported by provider infrastructure-aws, expected one of AWSClusterStaticIdentity, AWSClusterRoleIdentity, AWSClusterControllerIdentity`,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, issue := range ClusterTemplate(tc.chart, tc.providers) {
+				got = append(got, issue.String())
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("ClusterTemplate() =\n%q\nwant\n%q", got, tc.want)
+			}
+			if err := Error(ClusterTemplate(tc.chart, tc.providers)); (err != nil) != (len(tc.want) > 0) {
+				t.Errorf("Error() = %v", err)
+			}
+		})
+	}
+}
+
+func TestShippedClusterTemplates(t *testing.T) {
+	// the ClusterClass template sets the providers in the ClusterTemplate spec
+	specProviders := map[string]kcm.Providers{"clusterclass": {"infrastructure-docker"}}
+
+	dirs, err := filepath.Glob(filepath.Join(projectroot.Path, "templates", "cluster", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range dirs {
+		if _, err := os.Stat(filepath.Join(dir, "Chart.yaml")); err != nil {
+			continue
+		}
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			helmChart, err := loader.Load(dir)
+			if err != nil {
+				t.Fatalf("failed to load chart: %v", err)
+			}
+			if issues := ClusterTemplate(helmChart, specProviders[filepath.Base(dir)]); len(issues) > 0 {
+				t.Errorf("unexpected issues %v", issues)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package timings tracks the time taken by the provisioning and the upgrades of the
+// ClusterDeployments, reflecting it in their status and in the duration metrics.
+package timings
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/metrics"
+	providersloader "github.com/K0rdent/kcm/internal/providers"
+)
+
+// Start records the start of the provisioning or the upgrade of the given ClusterDeployment if not yet recorded.
+// The provisioning starts at the creation of the ClusterDeployment, an upgrade starts once the ClusterTemplate
+// of the provisioned cluster is changed. The ClusterTemplate changed before the ongoing operation completes
+// is deployed by the same operation.
+func Start(cd *kcm.ClusterDeployment, now time.Time) {
+	if cd.Status.Timings == nil {
+		cd.Status.Timings = new(kcm.ClusterTimings)
+	}
+	timings := cd.Status.Timings
+
+	if timings.Provisioning == nil {
+		timings.Provisioning = &kcm.ClusterOperationTiming{
+			StartTime: cd.CreationTimestamp,
+			Template:  cd.Spec.Template,
+		}
+
+		// the cluster has been deployed before the tracking was in place,
+		// its provisioning time is unknown and must not be observed
+		if lastKnownGood := cd.Status.LastKnownGood; lastKnownGood != nil {
+			timings.Provisioning.Template = lastKnownGood.Template
+			timings.Provisioning.CompletionTime = lastKnownGood.RecordedAt.DeepCopy()
+		}
+	}
+
+	current := timings.Provisioning
+	if timings.LastUpgrade != nil {
+		current = timings.LastUpgrade
+	}
+
+	if current.CompletionTime == nil {
+		current.Template = cd.Spec.Template
+		return
+	}
+
+	if current.Template == cd.Spec.Template {
+		return
+	}
+
+	timings.LastUpgrade = &kcm.ClusterOperationTiming{
+		StartTime:    metav1.NewTime(now),
+		Template:     cd.Spec.Template,
+		FromTemplate: current.Template,
+	}
+}
+
+// Complete records the completion of the ongoing provisioning or upgrade of the given ClusterDeployment
+// successfully deployed with the given ClusterTemplate and observes the duration of the operation.
+func Complete(ctx context.Context, cd *kcm.ClusterDeployment, template *kcm.ClusterTemplate, now time.Time) {
+	timings := cd.Status.Timings
+	if timings == nil || timings.Provisioning == nil {
+		return
+	}
+
+	current, operation := timings.Provisioning, metrics.ClusterOperationProvisioning
+	if timings.LastUpgrade != nil {
+		current, operation = timings.LastUpgrade, metrics.ClusterOperationUpgrade
+	}
+
+	if current.CompletionTime != nil || current.Template != cd.Spec.Template {
+		return
+	}
+
+	duration := now.Sub(current.StartTime.Time)
+	current.CompletionTime = &metav1.Time{Time: now}
+	current.Duration = &metav1.Duration{Duration: duration}
+
+	metrics.ObserveMetricClusterOperationDuration(ctx, operation, infraProviders(template), current.Template, duration)
+}
+
+// infraProviders returns the names of the infrastructure providers of the given ClusterTemplate.
+func infraProviders(template *kcm.ClusterTemplate) string {
+	var names []string
+	for _, provider := range template.Status.Providers {
+		if name, ok := strings.CutPrefix(provider, providersloader.InfraPrefix); ok {
+			names = append(names, name)
+		}
+	}
+
+	return strings.Join(names, ",")
+}
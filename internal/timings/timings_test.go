@@ -0,0 +1,111 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timings
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestTimings(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	template := &kcm.ClusterTemplate{Status: kcm.ClusterTemplateStatus{
+		Providers: kcm.Providers{"infrastructure-aws", "control-plane-k0sproject-k0smotron"},
+	}}
+	cd := &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+		Spec:       kcm.ClusterDeploymentSpec{Template: "aws-1-0-0"},
+	}
+
+	// provisioning
+	Start(cd, created.Add(time.Minute))
+	require.Equal(t, &kcm.ClusterOperationTiming{StartTime: metav1.NewTime(created), Template: "aws-1-0-0"}, cd.Status.Timings.Provisioning)
+
+	// the template is changed before the provisioning completes
+	cd.Spec.Template = "aws-1-0-1"
+	Start(cd, created.Add(2*time.Minute))
+	require.Equal(t, "aws-1-0-1", cd.Status.Timings.Provisioning.Template)
+	require.Nil(t, cd.Status.Timings.LastUpgrade)
+
+	Complete(t.Context(), cd, template, created.Add(12*time.Minute))
+	require.Equal(t, created.Add(12*time.Minute), cd.Status.Timings.Provisioning.CompletionTime.Time)
+	require.Equal(t, 12*time.Minute, cd.Status.Timings.Provisioning.Duration.Duration)
+
+	// the completion is recorded once
+	Complete(t.Context(), cd, template, created.Add(20*time.Minute))
+	require.Equal(t, 12*time.Minute, cd.Status.Timings.Provisioning.Duration.Duration)
+
+	// upgrade
+	upgradeStart := created.Add(time.Hour)
+	cd.Spec.Template = "aws-1-1-0"
+	Start(cd, upgradeStart)
+	require.Equal(t, &kcm.ClusterOperationTiming{
+		StartTime:    metav1.NewTime(upgradeStart),
+		Template:     "aws-1-1-0",
+		FromTemplate: "aws-1-0-1",
+	}, cd.Status.Timings.LastUpgrade)
+
+	// the upgrade is redirected to another template
+	cd.Spec.Template = "aws-1-2-0"
+	Start(cd, upgradeStart.Add(time.Minute))
+	require.Equal(t, metav1.NewTime(upgradeStart), cd.Status.Timings.LastUpgrade.StartTime)
+	require.Equal(t, "aws-1-2-0", cd.Status.Timings.LastUpgrade.Template)
+
+	Complete(t.Context(), cd, template, upgradeStart.Add(5*time.Minute))
+	require.Equal(t, 5*time.Minute, cd.Status.Timings.LastUpgrade.Duration.Duration)
+	require.Equal(t, 12*time.Minute, cd.Status.Timings.Provisioning.Duration.Duration)
+
+	// no upgrade is started while the template is unchanged
+	Start(cd, upgradeStart.Add(time.Hour))
+	require.Equal(t, metav1.NewTime(upgradeStart), cd.Status.Timings.LastUpgrade.StartTime)
+}
+
+func TestStartDeployedBeforeTracking(t *testing.T) {
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	recordedAt := metav1.NewTime(created.Add(24 * time.Hour))
+	cd := &kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+		Spec:       kcm.ClusterDeploymentSpec{Template: "aws-1-0-0"},
+		Status: kcm.ClusterDeploymentStatus{
+			LastKnownGood: &kcm.ClusterRevision{RecordedAt: recordedAt, Template: "aws-1-0-0"},
+		},
+	}
+
+	Start(cd, created.Add(48*time.Hour))
+	require.Equal(t, &kcm.ClusterOperationTiming{
+		StartTime:      metav1.NewTime(created),
+		CompletionTime: &recordedAt,
+		Template:       "aws-1-0-0",
+	}, cd.Status.Timings.Provisioning, "the provisioning of the cluster must be considered complete with unknown duration")
+
+	Complete(t.Context(), cd, &kcm.ClusterTemplate{}, created.Add(48*time.Hour))
+	require.Nil(t, cd.Status.Timings.Provisioning.Duration)
+	require.Nil(t, cd.Status.Timings.LastUpgrade)
+}
+
+func TestInfraProviders(t *testing.T) {
+	require.Equal(t, "aws", infraProviders(&kcm.ClusterTemplate{Status: kcm.ClusterTemplateStatus{
+		Providers: kcm.Providers{"infrastructure-aws", "bootstrap-k0sproject-k0smotron"},
+	}}))
+	require.Equal(t, "aws,internal", infraProviders(&kcm.ClusterTemplate{Status: kcm.ClusterTemplateStatus{
+		Providers: kcm.Providers{"infrastructure-aws", "infrastructure-internal"},
+	}}))
+	require.Empty(t, infraProviders(&kcm.ClusterTemplate{}))
+}
@@ -0,0 +1,204 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trustedca distributes the CA bundle of the organization configured in the Management to the clusters.
+//
+// The bundle is published as a ConfigMap in the configured namespaces of each cluster. A ClusterTemplate
+// supports adding the bundle to the trust store of the machines if its default values define the "trustedCA"
+// key, the template receives the bundle as the following values:
+//
+//	trustedCA:
+//	  bundle: <PEM-encoded CA certificates>
+//
+// The machines bootstrapped before the bundle has changed are rolled out to trust the changed bundle.
+package trustedca
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// ValuesKey is the key of the template values holding the CA bundle.
+	ValuesKey = "trustedCA"
+	// ManagedLabel is the label on the CA bundle ConfigMaps created in the clusters.
+	ManagedLabel = "k0rdent.mirantis.com/trusted-ca"
+	// hashAnnotation is the annotation on the CA bundle ConfigMaps created in the clusters holding the hash of the bundle.
+	hashAnnotation = "k0rdent.mirantis.com/trusted-ca-hash"
+)
+
+// Bundle returns the CA bundle of the given configuration from the ConfigMap in the system namespace
+// along with its hash. The bundle must hold at least one PEM-encoded certificate.
+func Bundle(ctx context.Context, cl client.Client, config *kcm.TrustedCA, systemNamespace string) (bundle, hash string, _ error) {
+	cm := new(corev1.ConfigMap)
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: systemNamespace, Name: config.ConfigMapName}, cm); err != nil {
+		return "", "", fmt.Errorf("failed to get trusted CA ConfigMap %s/%s: %w", systemNamespace, config.ConfigMapName, err)
+	}
+
+	key := config.Key
+	if key == "" {
+		key = "ca.crt"
+	}
+	bundle = cm.Data[key]
+	if err := validate(bundle); err != nil {
+		return "", "", fmt.Errorf("invalid CA bundle in the %s key of ConfigMap %s/%s: %w", key, systemNamespace, config.ConfigMapName, err)
+	}
+
+	sum := sha256.Sum256([]byte(bundle))
+	return bundle, hex.EncodeToString(sum[:]), nil
+}
+
+// validate checks the given bundle holds only the PEM-encoded certificates and at least one.
+func validate(bundle string) error {
+	rest := []byte(bundle)
+	count := 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return fmt.Errorf("unexpected PEM block of the %s type", block.Type)
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		count++
+	}
+	if count == 0 {
+		return errors.New("no certificates found")
+	}
+	return nil
+}
+
+// Values returns the template values of the given CA bundle.
+func Values(bundle string) map[string]any {
+	return map[string]any{"bundle": bundle}
+}
+
+// TemplateSupported returns true if the ClusterTemplate with the given default values
+// supports adding the CA bundle to the trust store of the machines.
+func TemplateSupported(config *apiextensionsv1.JSON) (bool, error) {
+	if config == nil || len(config.Raw) == 0 {
+		return false, nil
+	}
+
+	values := make(map[string]any)
+	if err := json.Unmarshal(config.Raw, &values); err != nil {
+		return false, fmt.Errorf("failed to parse template config: %w", err)
+	}
+
+	_, found, err := unstructured.NestedMap(values, ValuesKey)
+	return found && err == nil, nil
+}
+
+// Publish creates or updates the CA bundle ConfigMap in the namespaces of the cluster of the given client
+// and removes the ConfigMaps from the namespaces no longer configured or under the previous name.
+func Publish(ctx context.Context, cl client.Client, config *kcm.TrustedCA, bundle, hash string) error {
+	for _, namespace := range config.Namespaces {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		if err := cl.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create namespace %s: %w", namespace, err)
+		}
+
+		if err := publishConfigMap(ctx, cl, namespace, config.ConfigMapName, bundle, hash); err != nil {
+			return err
+		}
+	}
+
+	return removeStale(ctx, cl, func(cm *corev1.ConfigMap) bool {
+		return cm.Name == config.ConfigMapName && slices.Contains(config.Namespaces, cm.Namespace)
+	})
+}
+
+// Remove removes the CA bundle ConfigMaps from the cluster of the given client.
+func Remove(ctx context.Context, cl client.Client) error {
+	return removeStale(ctx, cl, func(*corev1.ConfigMap) bool { return false })
+}
+
+// publishConfigMap creates or updates the CA bundle ConfigMap in the given namespace unless the bundle is up to date.
+// The existing ConfigMaps not created by KCM are not overwritten.
+func publishConfigMap(ctx context.Context, cl client.Client, namespace, name, bundle, hash string) error {
+	desired := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      map[string]string{ManagedLabel: "true"},
+			Annotations: map[string]string{hashAnnotation: hash},
+		},
+		Data: map[string]string{"ca.crt": bundle},
+	}
+
+	existing := new(corev1.ConfigMap)
+	err := cl.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		if err := cl.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create CA bundle ConfigMap %s/%s: %w", namespace, name, err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to get CA bundle ConfigMap %s/%s: %w", namespace, name, err)
+	}
+
+	if existing.Labels[ManagedLabel] != "true" {
+		return fmt.Errorf("CA bundle ConfigMap %s/%s already exists and is not managed by KCM", namespace, name)
+	}
+	if existing.Annotations[hashAnnotation] == hash {
+		return nil
+	}
+
+	desired.ResourceVersion = existing.ResourceVersion
+	if err := cl.Update(ctx, desired); err != nil {
+		return fmt.Errorf("failed to update CA bundle ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// removeStale deletes the CA bundle ConfigMaps created by KCM not matching the given predicate.
+func removeStale(ctx context.Context, cl client.Client, keep func(*corev1.ConfigMap) bool) error {
+	configMaps := new(corev1.ConfigMapList)
+	if err := cl.List(ctx, configMaps, client.MatchingLabels{ManagedLabel: "true"}); err != nil {
+		return fmt.Errorf("failed to list CA bundle ConfigMaps: %w", err)
+	}
+
+	for i := range configMaps.Items {
+		cm := &configMaps.Items[i]
+		if keep(cm) {
+			continue
+		}
+		if err := cl.Delete(ctx, cm); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete CA bundle ConfigMap %s/%s: %w", cm.Namespace, cm.Name, err)
+		}
+	}
+
+	return nil
+}
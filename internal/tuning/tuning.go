@@ -0,0 +1,170 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tuning sets the resources and the scheduling of the workloads of the management
+// components defined in the Management in the Helm values of the components.
+package tuning
+
+import (
+	"encoding/json"
+	"fmt"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// VeleroValuesKey is the key of the Velero values in the KCM chart configuration.
+	VeleroValuesKey = "velero"
+	// SveltosValuesKey is the key of the upstream chart values in the Sveltos chart configuration.
+	SveltosValuesKey = "projectsveltos"
+	// DeploymentValuesKey is the key of the Cluster API Operator DeploymentSpec in the provider chart configuration.
+	DeploymentValuesKey = "deployment"
+
+	capiManagerContainerName = "manager"
+)
+
+// sveltosComponents are the keys of the values of the Sveltos controllers in the upstream chart.
+var sveltosComponents = []string{
+	"accessManager",
+	"addonController",
+	"classifierManager",
+	"eventManager",
+	"hcManager",
+	"healthCheckManager",
+	"scManager",
+	"shardController",
+	"techsupportController",
+}
+
+// ApplyKCM sets the tuning of the KCM controller and of Velero in the given KCM chart values.
+func ApplyKCM(values map[string]any, controller, velero *kcm.ComponentTuning) error {
+	if controller != nil {
+		if err := set(values, controller.Resources, "resources"); err != nil {
+			return err
+		}
+		if err := set(values, controller.Replicas, "replicas"); err != nil {
+			return err
+		}
+		if err := set(values, controller.NodeSelector, "controller", "nodeSelector"); err != nil {
+			return err
+		}
+		if err := set(values, controller.Tolerations, "controller", "tolerations"); err != nil {
+			return err
+		}
+	}
+
+	if velero != nil {
+		if err := set(values, velero.Resources, VeleroValuesKey, "resources"); err != nil {
+			return err
+		}
+		if err := set(values, velero.NodeSelector, VeleroValuesKey, "nodeSelector"); err != nil {
+			return err
+		}
+		if err := set(values, velero.Tolerations, VeleroValuesKey, "tolerations"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ApplyCAPIProvider sets the tuning in the DeploymentSpec of the Cluster API Operator provider in the given provider chart values.
+func ApplyCAPIProvider(values map[string]any, tuning *kcm.ComponentTuning) error {
+	if tuning == nil {
+		return nil
+	}
+
+	if err := set(values, tuning.Replicas, DeploymentValuesKey, "replicas"); err != nil {
+		return err
+	}
+	if err := set(values, tuning.NodeSelector, DeploymentValuesKey, "nodeSelector"); err != nil {
+		return err
+	}
+	if err := set(values, tuning.Tolerations, DeploymentValuesKey, "tolerations"); err != nil {
+		return err
+	}
+	if tuning.Resources != nil {
+		containers := []map[string]any{{"name": capiManagerContainerName, "resources": tuning.Resources}}
+		if err := set(values, containers, DeploymentValuesKey, "containers"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ApplySveltos sets the tuning of all the Sveltos controllers in the given Sveltos chart values.
+func ApplySveltos(values map[string]any, tuning *kcm.ComponentTuning) error {
+	if tuning == nil {
+		return nil
+	}
+
+	for _, component := range sveltosComponents {
+		if err := set(values, tuning.Resources, SveltosValuesKey, component, "controller", "resources"); err != nil {
+			return err
+		}
+		if err := set(values, tuning.Replicas, SveltosValuesKey, component, "replicas"); err != nil {
+			return err
+		}
+		if err := set(values, tuning.NodeSelector, SveltosValuesKey, component, "nodeSelector"); err != nil {
+			return err
+		}
+		if err := set(values, tuning.Tolerations, SveltosValuesKey, component, "tolerations"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// set sets the given value at the given path of the values unless the value is empty,
+// the value is converted to its plain JSON representation.
+func set[T any](values map[string]any, value T, path ...string) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the value of '%s': %w", path[len(path)-1], err)
+	}
+
+	var plain any
+	if err := json.Unmarshal(raw, &plain); err != nil {
+		return fmt.Errorf("failed to unmarshal the value of '%s': %w", path[len(path)-1], err)
+	}
+	switch v := plain.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		if len(v) == 0 {
+			return nil
+		}
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+	}
+
+	for _, key := range path[:len(path)-1] {
+		if values[key] == nil {
+			values[key] = make(map[string]any)
+		}
+
+		sub, ok := values[key].(map[string]any)
+		if !ok {
+			return fmt.Errorf("failed to cast '%s' (type %T) to map[string]any", key, values[key])
+		}
+		values = sub
+	}
+	values[path[len(path)-1]] = plain
+
+	return nil
+}
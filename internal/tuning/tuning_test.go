@@ -0,0 +1,123 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuning
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestApply(t *testing.T) {
+	replicas := int32(2)
+	full := &kcm.ComponentTuning{
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+		},
+		Replicas:     &replicas,
+		NodeSelector: map[string]string{"node-role.kubernetes.io/control-plane": ""},
+		Tolerations:  []corev1.Toleration{{Key: "node-role.kubernetes.io/control-plane", Effect: corev1.TaintEffectNoSchedule}},
+	}
+	resources := map[string]any{"limits": map[string]any{"memory": "512Mi"}}
+	nodeSelector := map[string]any{"node-role.kubernetes.io/control-plane": ""}
+	tolerations := []any{map[string]any{"key": "node-role.kubernetes.io/control-plane", "effect": "NoSchedule"}}
+
+	tests := []struct {
+		name     string
+		apply    func(map[string]any) error
+		values   map[string]any
+		expected map[string]any
+		err      string
+	}{
+		{
+			name: "kcm controller and velero",
+			apply: func(values map[string]any) error {
+				return ApplyKCM(values, full, &kcm.ComponentTuning{NodeSelector: full.NodeSelector})
+			},
+			values: map[string]any{
+				"replicas":      1,
+				"controller":    map[string]any{"tlsProfile": "Default"},
+				VeleroValuesKey: map[string]any{"enabled": true},
+			},
+			expected: map[string]any{
+				"resources":     resources,
+				"replicas":      float64(2),
+				"controller":    map[string]any{"tlsProfile": "Default", "nodeSelector": nodeSelector, "tolerations": tolerations},
+				VeleroValuesKey: map[string]any{"enabled": true, "nodeSelector": nodeSelector},
+			},
+		},
+		{
+			name:     "kcm without tuning",
+			apply:    func(values map[string]any) error { return ApplyKCM(values, nil, &kcm.ComponentTuning{}) },
+			values:   map[string]any{"replicas": 1},
+			expected: map[string]any{"replicas": 1},
+		},
+		{
+			name:   "capi provider",
+			apply:  func(values map[string]any) error { return ApplyCAPIProvider(values, full) },
+			values: map[string]any{"configSecret": map[string]any{"name": "aws-variables"}},
+			expected: map[string]any{
+				"configSecret": map[string]any{"name": "aws-variables"},
+				DeploymentValuesKey: map[string]any{
+					"replicas":     float64(2),
+					"nodeSelector": nodeSelector,
+					"tolerations":  tolerations,
+					"containers":   []any{map[string]any{"name": "manager", "resources": resources}},
+				},
+			},
+		},
+		{
+			name: "sveltos",
+			apply: func(values map[string]any) error {
+				return ApplySveltos(values, &kcm.ComponentTuning{Replicas: &replicas})
+			},
+			values:   map[string]any{},
+			expected: map[string]any{SveltosValuesKey: map[string]any{}},
+		},
+		{
+			name:   "invalid values",
+			apply:  func(values map[string]any) error { return ApplyCAPIProvider(values, full) },
+			values: map[string]any{DeploymentValuesKey: "invalid"},
+			err:    "failed to cast 'deployment' (type string) to map[string]any",
+		},
+	}
+
+	sveltosValues := tests[3].expected[SveltosValuesKey].(map[string]any) //nolint:forcetypeassert // test data
+	for _, component := range sveltosComponents {
+		sveltosValues[component] = map[string]any{"replicas": float64(2)}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.apply(tt.values)
+			if tt.err != "" {
+				if err == nil || err.Error() != tt.err {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.values, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, tt.values)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clusterclass implements the ClusterTemplate values conventions
+// of the templates based on a Cluster API ClusterClass.
+//
+// The chart of such a template renders the Cluster with a managed topology
+// from the ClusterClass passed with the "clusterClass" key and the topology
+// variables set with the "variables" key as a map of the variable names to their values.
+package clusterclass
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// ClassValuesKey is the ClusterTemplate values key of the ClusterClass reference.
+	ClassValuesKey = "clusterClass"
+	// VariablesValuesKey is the ClusterTemplate values key of the topology variables.
+	VariablesValuesKey = "variables"
+)
+
+// ClassValues returns the values of the ClusterClass referenced by the ClusterTemplate
+// for a ClusterDeployment in the given namespace.
+func ClassValues(template *kcm.ClusterTemplate, namespace string) map[string]any {
+	return map[string]any{
+		"name":      template.Spec.ClusterClass.Name,
+		"namespace": classNamespace(template, namespace),
+	}
+}
+
+// Get returns the ClusterClass referenced by the ClusterTemplate for a ClusterDeployment in the given namespace.
+func Get(ctx context.Context, cl client.Client, template *kcm.ClusterTemplate, namespace string) (*clusterv1.ClusterClass, error) {
+	cc := new(clusterv1.ClusterClass)
+	key := client.ObjectKey{Namespace: classNamespace(template, namespace), Name: template.Spec.ClusterClass.Name}
+	if err := cl.Get(ctx, key, cc); err != nil {
+		return nil, fmt.Errorf("failed to get ClusterClass %s: %w", key, err)
+	}
+
+	return cc, nil
+}
+
+// ValidateVariables validates the topology variables set in the given ClusterDeployment configuration
+// against the variables defined in the ClusterClass.
+func ValidateVariables(cc *clusterv1.ClusterClass, config *apiextensionsv1.JSON) error {
+	variables := make(map[string]any)
+	if config != nil && len(config.Raw) > 0 {
+		values := make(map[string]any)
+		if err := json.Unmarshal(config.Raw, &values); err != nil {
+			return fmt.Errorf("failed to parse ClusterDeployment configuration: %w", err)
+		}
+
+		if v, ok := values[VariablesValuesKey]; ok {
+			if variables, ok = v.(map[string]any); !ok {
+				return fmt.Errorf("%s must be a map of the variable names to their values", VariablesValuesKey)
+			}
+		}
+	}
+
+	var errs error
+	for name := range variables {
+		if !slices.ContainsFunc(cc.Spec.Variables, func(v clusterv1.ClusterClassVariable) bool { return v.Name == name }) {
+			errs = errors.Join(errs, fmt.Errorf("variable %q is not defined in the ClusterClass %s/%s", name, cc.Namespace, cc.Name))
+		}
+	}
+
+	for _, definition := range cc.Spec.Variables {
+		value, ok := variables[definition.Name]
+		if !ok {
+			if definition.Required {
+				errs = errors.Join(errs, fmt.Errorf("required variable %q is not set", definition.Name))
+			}
+			continue
+		}
+
+		if err := validateVariable(definition, value); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// validateVariable validates the value of the variable against its schema.
+func validateVariable(definition clusterv1.ClusterClassVariable, value any) error {
+	raw, err := json.Marshal(definition.Schema.OpenAPIV3Schema)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the schema of the variable %q: %w", definition.Name, err)
+	}
+
+	schemaV1 := new(apiextensionsv1.JSONSchemaProps)
+	if err := json.Unmarshal(raw, schemaV1); err != nil {
+		return fmt.Errorf("failed to parse the schema of the variable %q: %w", definition.Name, err)
+	}
+
+	schema := new(apiextensions.JSONSchemaProps)
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(schemaV1, schema, nil); err != nil {
+		return fmt.Errorf("failed to convert the schema of the variable %q: %w", definition.Name, err)
+	}
+
+	validator, _, err := validation.NewSchemaValidator(schema)
+	if err != nil {
+		return fmt.Errorf("failed to build the validator of the variable %q: %w", definition.Name, err)
+	}
+
+	return validation.ValidateCustomResource(field.NewPath(VariablesValuesKey, definition.Name), value, validator).ToAggregate()
+}
+
+func classNamespace(template *kcm.ClusterTemplate, namespace string) string {
+	if template.Spec.ClusterClass.Namespace != "" {
+		return template.Spec.ClusterClass.Namespace
+	}
+	return namespace
+}
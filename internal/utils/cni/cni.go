@@ -0,0 +1,64 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cni validates the CNI selected for the ClusterDeployments
+// and builds the system service installing the CNI.
+//
+// A ClusterTemplate lists the CNIs it supports in its chart metadata. Once a CNI is selected,
+// the template is passed the "cni" value and must not install its own CNI.
+package cni
+
+import (
+	"fmt"
+	"slices"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// ServiceName is the name of the service installing the CNI.
+	ServiceName = "kcm-cni"
+	// ValuesKey is the key of the template values holding the selected CNI.
+	ValuesKey = "cni"
+)
+
+// Validate checks that the CNI selected in the given network configuration
+// is supported by the given ClusterTemplate.
+func Validate(network *kcm.ClusterNetwork, template *kcm.ClusterTemplate) error {
+	if len(template.Status.SupportedCNIs) == 0 {
+		return fmt.Errorf("the ClusterTemplate %s does not support selecting the CNI", template.Name)
+	}
+
+	if !slices.Contains(template.Status.SupportedCNIs, network.CNI) {
+		return fmt.Errorf("the CNI %s is not supported by the ClusterTemplate %s, supported CNIs: %v",
+			network.CNI, template.Name, template.Status.SupportedCNIs)
+	}
+
+	return nil
+}
+
+// Services returns the services installing the CNI configured in the given
+// network configuration, none if the CNI is brought by the user.
+func Services(network *kcm.ClusterNetwork) []kcm.Service {
+	if network == nil || network.CNI == kcm.CNINone {
+		return nil
+	}
+
+	return []kcm.Service{{
+		Name:      ServiceName,
+		Namespace: network.Namespace,
+		Template:  network.ServiceTemplate,
+		Values:    network.Values,
+	}}
+}
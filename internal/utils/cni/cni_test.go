@@ -0,0 +1,83 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cni
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestValidate(t *testing.T) {
+	template := func(cnis ...string) *kcm.ClusterTemplate {
+		return &kcm.ClusterTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "aws-standalone-cp"},
+			Status:     kcm.ClusterTemplateStatus{SupportedCNIs: cnis},
+		}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		cni      string
+		template *kcm.ClusterTemplate
+		err      string
+	}{
+		{
+			name:     "supported cni",
+			cni:      kcm.CNICilium,
+			template: template(kcm.CNICalico, kcm.CNICilium, kcm.CNINone),
+		},
+		{
+			name:     "unsupported cni",
+			cni:      kcm.CNINone,
+			template: template(kcm.CNICalico, kcm.CNICilium),
+			err:      "the CNI none is not supported by the ClusterTemplate aws-standalone-cp",
+		},
+		{
+			name:     "template without cni selection",
+			cni:      kcm.CNICalico,
+			template: template(),
+			err:      "the ClusterTemplate aws-standalone-cp does not support selecting the CNI",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(&kcm.ClusterNetwork{CNI: tc.cni}, tc.template)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestServices(t *testing.T) {
+	require.Nil(t, Services(nil))
+	require.Nil(t, Services(&kcm.ClusterNetwork{CNI: kcm.CNINone}))
+	require.Equal(t, []kcm.Service{{
+		Name:      ServiceName,
+		Namespace: "kube-system",
+		Template:  "cilium-1-17-0",
+		Values:    "kubeProxyReplacement: true",
+	}}, Services(&kcm.ClusterNetwork{
+		CNI:             kcm.CNICilium,
+		ServiceTemplate: "cilium-1-17-0",
+		Namespace:       "kube-system",
+		Values:          "kubeProxyReplacement: true",
+	}))
+}
@@ -0,0 +1,111 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// Phase returns the lifecycle phase of the given ClusterDeployment.
+func Phase(cd *kcm.ClusterDeployment) string {
+	if !cd.DeletionTimestamp.IsZero() {
+		return kcm.ClusterPhaseDeleting
+	}
+
+	cond := apimeta.FindStatusCondition(cd.Status.Conditions, kcm.ReadyCondition)
+	switch {
+	case cond == nil || cond.Status == metav1.ConditionUnknown:
+		return kcm.ClusterPhaseProvisioning
+	case cond.Status == metav1.ConditionTrue:
+		return kcm.ClusterPhaseReady
+	default:
+		return kcm.ClusterPhaseNotReady
+	}
+}
+
+// InfrastructureProviders returns the infrastructure providers from the given list of the template providers.
+func InfrastructureProviders(providers []string) []string {
+	var infra []string
+	for _, provider := range providers {
+		if strings.HasPrefix(provider, "infrastructure-") {
+			infra = append(infra, provider)
+		}
+	}
+
+	return infra
+}
+
+// Summarize aggregates the given ClusterDeployments. The templateProviders map holds
+// the infrastructure providers of the ClusterTemplates and the serviceCharts map holds
+// the Helm charts of the ServiceTemplates keyed by their namespaced names.
+func Summarize(clusterDeployments []kcm.ClusterDeployment, templateProviders map[client.ObjectKey][]string, serviceCharts map[client.ObjectKey]Chart) kcm.FleetSummaryStatus {
+	status := kcm.FleetSummaryStatus{
+		ByProvider:          make(map[string]int32),
+		ByTemplate:          make(map[string]int32),
+		ByKubernetesVersion: make(map[string]int32),
+		ByPhase:             make(map[string]int32),
+		ByRegion:            make(map[string]int32),
+		Clusters:            make([]kcm.FleetClusterSummary, 0, len(clusterDeployments)),
+	}
+
+	for i := range clusterDeployments {
+		cd := &clusterDeployments[i]
+
+		summary := kcm.FleetClusterSummary{
+			Name:              cd.Name,
+			Namespace:         cd.Namespace,
+			Template:          cd.Spec.Template,
+			Providers:         templateProviders[client.ObjectKey{Namespace: cd.TemplateNamespace(), Name: cd.Spec.Template}],
+			KubernetesVersion: cd.Status.KubernetesVersion,
+			Phase:             Phase(cd),
+			AvailableUpgrades: cd.Status.AvailableUpgrades,
+
+			Region:                 Region(cd),
+			KubernetesMinorVersion: MinorVersion(cd.Status.KubernetesVersion),
+			Services:               Services(cd, serviceCharts),
+		}
+		status.Clusters = append(status.Clusters, summary)
+
+		status.TotalClusters++
+		status.ByTemplate[summary.Template]++
+		status.ByPhase[summary.Phase]++
+		for _, provider := range summary.Providers {
+			status.ByProvider[provider]++
+		}
+		if summary.KubernetesVersion != "" {
+			status.ByKubernetesVersion[summary.KubernetesVersion]++
+		}
+		if summary.Region != "" {
+			status.ByRegion[summary.Region]++
+		}
+		if len(summary.AvailableUpgrades) > 0 {
+			status.PendingUpgrades++
+		}
+	}
+
+	slices.SortFunc(status.Clusters, func(a, b kcm.FleetClusterSummary) int {
+		return cmp.Or(strings.Compare(a.Namespace, b.Namespace), strings.Compare(a.Name, b.Name))
+	})
+
+	return status
+}
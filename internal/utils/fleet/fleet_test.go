@@ -0,0 +1,114 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"reflect"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newClusterDeployment(namespace, name, template, k8sVersion string, ready metav1.ConditionStatus, upgrades ...string) kcm.ClusterDeployment {
+	cd := kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       kcm.ClusterDeploymentSpec{Template: template},
+		Status: kcm.ClusterDeploymentStatus{
+			KubernetesVersion: k8sVersion,
+			AvailableUpgrades: upgrades,
+		},
+	}
+	if ready != "" {
+		cd.Status.Conditions = []metav1.Condition{{Type: kcm.ReadyCondition, Status: ready}}
+	}
+
+	return cd
+}
+
+func TestSummarize(t *testing.T) {
+	deleting := newClusterDeployment("team-b", "old", "azure-1-0-0", "v1.31.1", metav1.ConditionTrue)
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+
+	clusterDeployments := []kcm.ClusterDeployment{
+		newClusterDeployment("team-b", "dev", "aws-1-0-0", "v1.32.2", metav1.ConditionFalse),
+		newClusterDeployment("team-a", "prod", "aws-1-0-0", "v1.32.2", metav1.ConditionTrue, "aws-1-0-1"),
+		newClusterDeployment("team-a", "new", "azure-1-0-0", "", ""),
+		deleting,
+	}
+	templateProviders := map[client.ObjectKey][]string{
+		{Namespace: "team-a", Name: "aws-1-0-0"}:   {"infrastructure-aws"},
+		{Namespace: "team-b", Name: "aws-1-0-0"}:   {"infrastructure-aws"},
+		{Namespace: "team-a", Name: "azure-1-0-0"}: {"infrastructure-azure"},
+	}
+
+	clusterDeployments[1].Spec.Config = &apiextensionsv1.JSON{Raw: []byte(`{"region":"us-east-2"}`)}
+	clusterDeployments[1].Spec.ServiceSpec.Services = []kcm.Service{
+		{Name: "ingress", Template: "ingress-nginx-4-11-0"},
+		{Name: "disabled", Template: "ingress-nginx-4-11-0", Disable: true},
+	}
+	serviceCharts := map[client.ObjectKey]Chart{
+		{Namespace: "team-a", Name: "ingress-nginx-4-11-0"}: {Name: "ingress-nginx", Version: "4.11.0"},
+	}
+
+	status := Summarize(clusterDeployments, templateProviders, serviceCharts)
+
+	if status.TotalClusters != 4 {
+		t.Errorf("expected 4 clusters, got %d", status.TotalClusters)
+	}
+	if status.PendingUpgrades != 1 {
+		t.Errorf("expected 1 pending upgrade, got %d", status.PendingUpgrades)
+	}
+
+	for name, tc := range map[string]struct {
+		actual, expected map[string]int32
+	}{
+		"providers":           {status.ByProvider, map[string]int32{"infrastructure-aws": 2, "infrastructure-azure": 1}},
+		"templates":           {status.ByTemplate, map[string]int32{"aws-1-0-0": 2, "azure-1-0-0": 2}},
+		"kubernetes versions": {status.ByKubernetesVersion, map[string]int32{"v1.32.2": 2, "v1.31.1": 1}},
+		"regions":             {status.ByRegion, map[string]int32{"us-east-2": 1}},
+		"phases": {status.ByPhase, map[string]int32{
+			kcm.ClusterPhaseReady:        1,
+			kcm.ClusterPhaseNotReady:     1,
+			kcm.ClusterPhaseProvisioning: 1,
+			kcm.ClusterPhaseDeleting:     1,
+		}},
+	} {
+		if !reflect.DeepEqual(tc.actual, tc.expected) {
+			t.Errorf("unexpected counts of %s: expected %v, got %v", name, tc.expected, tc.actual)
+		}
+	}
+
+	var names []string
+	for _, c := range status.Clusters {
+		names = append(names, c.Namespace+"/"+c.Name)
+	}
+	if expected := []string{"team-a/new", "team-a/prod", "team-b/dev", "team-b/old"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected clusters %v, got %v", expected, names)
+	}
+
+	prod := status.Clusters[1]
+	if prod.KubernetesMinorVersion != "1.32" {
+		t.Errorf("expected Kubernetes minor version 1.32, got %s", prod.KubernetesMinorVersion)
+	}
+	expectedServices := []kcm.FleetServiceSummary{{Name: "ingress", Template: "ingress-nginx-4-11-0", Chart: "ingress-nginx", Version: "4.11.0"}}
+	if !reflect.DeepEqual(prod.Services, expectedServices) {
+		t.Errorf("expected services %v, got %v", expectedServices, prod.Services)
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// regionValuesKeys are the keys of the cluster configuration holding the region of the cluster
+// in the order of precedence, e.g. the region of AWS and GCP and the location of Azure.
+var regionValuesKeys = []string{"region", "location"}
+
+// Chart is the Helm chart of a ServiceTemplate.
+type Chart struct {
+	// Name is the name of the chart.
+	Name string
+	// Version is the version of the chart.
+	Version string
+}
+
+// Region returns the region of the given ClusterDeployment set in its configuration or an empty string.
+func Region(cd *kcm.ClusterDeployment) string {
+	if cd.Spec.Config == nil || len(cd.Spec.Config.Raw) == 0 {
+		return ""
+	}
+
+	values := make(map[string]any)
+	if err := json.Unmarshal(cd.Spec.Config.Raw, &values); err != nil {
+		return ""
+	}
+	for _, key := range regionValuesKeys {
+		if region, ok := values[key].(string); ok && region != "" {
+			return region
+		}
+	}
+
+	return ""
+}
+
+// MinorVersion returns the minor version of the given Kubernetes version, e.g. 1.32 for v1.32.2,
+// or an empty string if the version is not valid.
+func MinorVersion(version string) string {
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d.%d", v.Major(), v.Minor())
+}
+
+// Services returns the enabled services of the given ClusterDeployment along with the Helm charts
+// of their ServiceTemplates from the given map keyed by the namespaced names of the ServiceTemplates.
+func Services(cd *kcm.ClusterDeployment, serviceCharts map[client.ObjectKey]Chart) []kcm.FleetServiceSummary {
+	var services []kcm.FleetServiceSummary
+	for _, svc := range cd.Spec.ServiceSpec.Services {
+		if svc.Disable {
+			continue
+		}
+
+		chart := serviceCharts[client.ObjectKey{Namespace: cd.Namespace, Name: svc.Template}]
+		services = append(services, kcm.FleetServiceSummary{
+			Name:     svc.Name,
+			Template: svc.Template,
+			Chart:    chart.Name,
+			Version:  chart.Version,
+		})
+	}
+
+	return services
+}
+
+// ServiceCharts returns the Helm charts of the ServiceTemplates of the services of the given
+// ClusterDeployments keyed by the namespaced names of the ServiceTemplates.
+func ServiceCharts(ctx context.Context, cl client.Reader, clusterDeployments []kcm.ClusterDeployment) (map[client.ObjectKey]Chart, error) {
+	serviceCharts := make(map[client.ObjectKey]Chart)
+	for _, cd := range clusterDeployments {
+		for _, svc := range cd.Spec.ServiceSpec.Services {
+			key := client.ObjectKey{Namespace: cd.Namespace, Name: svc.Template}
+			if _, ok := serviceCharts[key]; ok {
+				continue
+			}
+
+			chart, err := serviceChart(ctx, cl, key)
+			if err != nil {
+				return nil, err
+			}
+			serviceCharts[key] = chart
+		}
+	}
+
+	return serviceCharts, nil
+}
+
+// serviceChart returns the Helm chart of the ServiceTemplate with the given key,
+// the chart is empty if the ServiceTemplate does not exist.
+func serviceChart(ctx context.Context, cl client.Reader, key client.ObjectKey) (Chart, error) {
+	template := new(kcm.ServiceTemplate)
+	if err := cl.Get(ctx, key, template); err != nil {
+		if apierrors.IsNotFound(err) {
+			return Chart{}, nil
+		}
+		return Chart{}, fmt.Errorf("failed to get ServiceTemplate %s: %w", key, err)
+	}
+
+	chart := Chart{Version: template.Status.ChartVersion}
+	switch {
+	case template.Spec.Helm != nil && template.Spec.Helm.ChartSpec != nil:
+		chart.Name = template.Spec.Helm.ChartSpec.Chart
+	case template.Status.ChartRef != nil && template.Status.ChartRef.Kind == sourcev1.HelmChartKind:
+		helmChart := new(sourcev1.HelmChart)
+		ref := client.ObjectKey{Namespace: template.Status.ChartRef.Namespace, Name: template.Status.ChartRef.Name}
+		if ref.Namespace == "" {
+			ref.Namespace = template.Namespace
+		}
+		if err := cl.Get(ctx, ref, helmChart); client.IgnoreNotFound(err) != nil {
+			return Chart{}, fmt.Errorf("failed to get HelmChart %s: %w", ref, err)
+		}
+		chart.Name = helmChart.Spec.Chart
+	}
+
+	return chart, nil
+}
+
+// ServiceConstraint selects the clusters running a service, optionally of a version
+// satisfying the constraint.
+type ServiceConstraint struct {
+	// Name is the name of the service or of its Helm chart.
+	Name string
+	// Constraint is the constraint of the version of the Helm chart of the service.
+	Constraint *semver.Constraints
+}
+
+// ParseServiceConstraint parses the given service constraint in the form of the name
+// of the service optionally followed by the version constraint, e.g. "ingress-nginx<1.10".
+func ParseServiceConstraint(s string) (ServiceConstraint, error) {
+	i := strings.IndexAny(s, "<>=!~^ ")
+	if i < 0 {
+		return ServiceConstraint{Name: s}, nil
+	}
+
+	name, constraint := strings.TrimSpace(s[:i]), strings.TrimSpace(s[i:])
+	if name == "" {
+		return ServiceConstraint{}, fmt.Errorf("invalid service constraint %q: the name of the service is missing", s)
+	}
+	if constraint == "" {
+		return ServiceConstraint{Name: name}, nil
+	}
+
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return ServiceConstraint{}, fmt.Errorf("invalid version constraint of the service constraint %q: %w", s, err)
+	}
+	return ServiceConstraint{Name: name, Constraint: c}, nil
+}
+
+// Matches returns true if one of the given services satisfies the constraint.
+// The service without a valid version does not satisfy the version constraint.
+func (c ServiceConstraint) Matches(services []kcm.FleetServiceSummary) bool {
+	return slices.ContainsFunc(services, func(svc kcm.FleetServiceSummary) bool {
+		if svc.Name != c.Name && svc.Chart != c.Name {
+			return false
+		}
+		if c.Constraint == nil {
+			return true
+		}
+		v, err := semver.NewVersion(svc.Version)
+		return err == nil && c.Constraint.Check(v)
+	})
+}
+
+// Query selects the clusters by their attributes, the empty attributes match all the clusters.
+type Query struct {
+	// Provider is the infrastructure provider of the cluster with or without the infrastructure- prefix.
+	Provider string
+	// Region is the region of the cluster.
+	Region string
+	// KubernetesMinorVersion is the minor version of Kubernetes of the cluster, e.g. 1.32.
+	KubernetesMinorVersion string
+	// Services are the constraints of the services all of which the cluster must satisfy.
+	Services []ServiceConstraint
+}
+
+// Search returns the clusters from the given ones matching the given query.
+func Search(clusters []kcm.FleetClusterSummary, q Query) []kcm.FleetClusterSummary {
+	var matched []kcm.FleetClusterSummary
+	for _, cluster := range clusters {
+		if q.Provider != "" && !slices.Contains(cluster.Providers, q.Provider) && !slices.Contains(cluster.Providers, "infrastructure-"+q.Provider) {
+			continue
+		}
+		if q.Region != "" && cluster.Region != q.Region {
+			continue
+		}
+		if q.KubernetesMinorVersion != "" && cluster.KubernetesMinorVersion != strings.TrimPrefix(q.KubernetesMinorVersion, "v") {
+			continue
+		}
+		if !allServicesMatch(cluster.Services, q.Services) {
+			continue
+		}
+		matched = append(matched, cluster)
+	}
+
+	return matched
+}
+
+func allServicesMatch(services []kcm.FleetServiceSummary, constraints []ServiceConstraint) bool {
+	for _, c := range constraints {
+		if !c.Matches(services) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"reflect"
+	"testing"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestMinorVersion(t *testing.T) {
+	for version, expected := range map[string]string{
+		"v1.32.2":         "1.32",
+		"v1.31.1+k0s.0":   "1.31",
+		"1.30.0":          "1.30",
+		"":                "",
+		"not-a-version-1": "",
+	} {
+		if actual := MinorVersion(version); actual != expected {
+			t.Errorf("expected minor version %q of %q, got %q", expected, version, actual)
+		}
+	}
+}
+
+func TestParseServiceConstraint(t *testing.T) {
+	c, err := ParseServiceConstraint("ingress-nginx<1.10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Name != "ingress-nginx" || c.Constraint == nil {
+		t.Errorf("unexpected constraint %+v", c)
+	}
+
+	c, err = ParseServiceConstraint("cert-manager")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Name != "cert-manager" || c.Constraint != nil {
+		t.Errorf("unexpected constraint %+v", c)
+	}
+
+	for _, s := range []string{"<1.10", "ingress-nginx<not-a-version"} {
+		if _, err := ParseServiceConstraint(s); err == nil {
+			t.Errorf("expected error parsing %q", s)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	clusters := []kcm.FleetClusterSummary{
+		{
+			Name: "old", Providers: []string{"infrastructure-aws"}, Region: "us-east-2", KubernetesMinorVersion: "1.31",
+			Services: []kcm.FleetServiceSummary{{Name: "ingress", Chart: "ingress-nginx", Version: "1.9.6"}},
+		},
+		{
+			Name: "new", Providers: []string{"infrastructure-aws"}, Region: "eu-west-1", KubernetesMinorVersion: "1.32",
+			Services: []kcm.FleetServiceSummary{{Name: "ingress", Chart: "ingress-nginx", Version: "1.11.2"}},
+		},
+		{
+			Name: "azure", Providers: []string{"infrastructure-azure"}, Region: "westeurope", KubernetesMinorVersion: "1.32",
+		},
+	}
+
+	mustParse := func(s string) ServiceConstraint {
+		c, err := ParseServiceConstraint(s)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return c
+	}
+
+	for name, tc := range map[string]struct {
+		query    Query
+		expected []string
+	}{
+		"all":                  {Query{}, []string{"old", "new", "azure"}},
+		"provider":             {Query{Provider: "aws"}, []string{"old", "new"}},
+		"provider with prefix": {Query{Provider: "infrastructure-azure"}, []string{"azure"}},
+		"region":               {Query{Region: "eu-west-1"}, []string{"new"}},
+		"kubernetes minor":     {Query{KubernetesMinorVersion: "v1.32"}, []string{"new", "azure"}},
+		"service version":      {Query{Services: []ServiceConstraint{mustParse("ingress-nginx<1.10")}}, []string{"old"}},
+		"service name":         {Query{Services: []ServiceConstraint{mustParse("ingress")}}, []string{"old", "new"}},
+		"combined":             {Query{Provider: "aws", KubernetesMinorVersion: "1.32", Services: []ServiceConstraint{mustParse("ingress-nginx>=1.10")}}, []string{"new"}},
+		"no matching clusters": {Query{Region: "us-west-1"}, nil},
+	} {
+		var names []string
+		for _, c := range Search(clusters, tc.query) {
+			names = append(names, c.Name)
+		}
+		if !reflect.DeepEqual(names, tc.expected) {
+			t.Errorf("%s: expected clusters %v, got %v", name, tc.expected, names)
+		}
+	}
+}
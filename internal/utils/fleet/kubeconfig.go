@@ -0,0 +1,104 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"fmt"
+	"slices"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// execAPIVersion is the version of the client authentication API of the credential exec plugin.
+	execAPIVersion = "client.authentication.k8s.io/v1"
+	// execCommand is the command of the credential exec plugin.
+	execCommand = "kcm"
+)
+
+// ContextName returns the name of the context of the given ClusterDeployment in the aggregate kubeconfig.
+func ContextName(cd *kcm.ClusterDeployment) string {
+	return cd.Namespace + "/" + cd.Name
+}
+
+// Kubeconfig returns the aggregate kubeconfig with a context per given ClusterDeployment.
+// The servers and the certificate authorities of the clusters are taken from the given kubeconfigs
+// keyed by the names of the ClusterDeployments. The kubeconfig holds no credentials: the short-lived
+// credentials are issued by the fleet view endpoint of the management cluster to the "kcm cluster credentials" exec plugin.
+func Kubeconfig(clusterDeployments []kcm.ClusterDeployment, kubeconfigs map[string][]byte) ([]byte, error) {
+	cfg := clientcmdapi.NewConfig()
+
+	for _, cd := range clusterDeployments {
+		data, ok := kubeconfigs[cd.Name]
+		if !ok {
+			continue
+		}
+
+		clusterCfg, err := clientcmd.Load(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse kubeconfig of ClusterDeployment %s/%s: %w", cd.Namespace, cd.Name, err)
+		}
+		cluster, err := currentCluster(clusterCfg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kubeconfig of ClusterDeployment %s/%s: %w", cd.Namespace, cd.Name, err)
+		}
+
+		name := ContextName(&cd)
+		cfg.Clusters[name] = &clientcmdapi.Cluster{
+			Server:                   cluster.Server,
+			CertificateAuthorityData: cluster.CertificateAuthorityData,
+			TLSServerName:            cluster.TLSServerName,
+		}
+		cfg.AuthInfos[name] = &clientcmdapi.AuthInfo{
+			Exec: &clientcmdapi.ExecConfig{
+				APIVersion:      execAPIVersion,
+				Command:         execCommand,
+				Args:            []string{"cluster", "credentials", cd.Name, "--namespace", cd.Namespace},
+				InstallHint:     "The kcm CLI is required to fetch the credentials of the cluster from the fleet view endpoint of the management cluster, the kubeconfig of the management cluster is read from the KCM_KUBECONFIG environment variable and the URL of the endpoint from the KCM_FLEET_VIEW_URL one",
+				InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+			},
+		}
+		cfg.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+	}
+
+	if len(cfg.Contexts) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(cfg.Contexts))
+	for name := range cfg.Contexts {
+		names = append(names, name)
+	}
+	cfg.CurrentContext = slices.Min(names)
+
+	return clientcmd.Write(*cfg)
+}
+
+// currentCluster returns the cluster of the current context of the given kubeconfig.
+func currentCluster(cfg *clientcmdapi.Config) (*clientcmdapi.Cluster, error) {
+	current, ok := cfg.Contexts[cfg.CurrentContext]
+	if !ok {
+		return nil, fmt.Errorf("current context %q not found", cfg.CurrentContext)
+	}
+	cluster, ok := cfg.Clusters[current.Cluster]
+	if !ok {
+		return nil, fmt.Errorf("cluster %q not found", current.Cluster)
+	}
+
+	return cluster, nil
+}
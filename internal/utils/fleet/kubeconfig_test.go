@@ -0,0 +1,83 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const clusterKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: dev
+  cluster:
+    server: https://dev.example.com:6443
+    certificate-authority-data: Y2E=
+contexts:
+- name: dev-admin@dev
+  context:
+    cluster: dev
+    user: dev-admin
+current-context: dev-admin@dev
+users:
+- name: dev-admin
+  user:
+    token: s3cr3t
+`
+
+func TestKubeconfig(t *testing.T) {
+	dev := newClusterDeployment("team-a", "dev", "aws-1-0-0", "", metav1.ConditionTrue)
+	prod := newClusterDeployment("team-a", "prod", "aws-1-0-0", "", metav1.ConditionTrue)
+
+	data, err := Kubeconfig([]kcm.ClusterDeployment{dev, prod}, map[string][]byte{"dev": []byte(clusterKubeconfig)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg, err := clientcmd.Load(data)
+	if err != nil {
+		t.Fatalf("failed to parse kubeconfig: %v", err)
+	}
+	if len(cfg.Contexts) != 1 || cfg.CurrentContext != "team-a/dev" {
+		t.Fatalf("expected a single context team-a/dev, got %v", cfg.Contexts)
+	}
+
+	cluster := cfg.Clusters["team-a/dev"]
+	if cluster == nil || cluster.Server != "https://dev.example.com:6443" || string(cluster.CertificateAuthorityData) != "ca" {
+		t.Errorf("unexpected cluster: %+v", cluster)
+	}
+
+	user := cfg.AuthInfos["team-a/dev"]
+	if user == nil || user.Token != "" || user.Exec == nil {
+		t.Fatalf("expected the credentials to be fetched by the exec plugin, got %+v", user)
+	}
+	if got, want := user.Exec.Args, []string{"cluster", "credentials", "dev", "--namespace", "team-a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected exec plugin args: got %v, want %v", got, want)
+	}
+
+	if data, err := Kubeconfig([]kcm.ClusterDeployment{prod}, nil); err != nil || data != nil {
+		t.Errorf("expected no kubeconfig without clusters, got %s, %v", data, err)
+	}
+
+	if _, err := Kubeconfig([]kcm.ClusterDeployment{dev}, map[string][]byte{"dev": []byte("current-context: missing\n")}); err == nil {
+		t.Errorf("expected error for kubeconfig without the current context")
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// PropertyLabels returns the labels of the properties of the given ClusterDeployment derived by KCM
+// from the given infrastructure providers of its ClusterTemplate and its configuration.
+// The properties which are unknown or are not valid label values are set to empty strings
+// so the stale labels could be removed.
+func PropertyLabels(cd *kcm.ClusterDeployment, providers []string) map[string]string {
+	var provider string
+	if infra := InfrastructureProviders(providers); len(infra) > 0 {
+		provider = strings.TrimPrefix(infra[0], "infrastructure-")
+	}
+
+	labels := map[string]string{
+		kcm.ProviderLabelKey:          provider,
+		kcm.RegionLabelKey:            Region(cd),
+		kcm.KubernetesVersionLabelKey: MinorVersion(cd.Status.KubernetesVersion),
+	}
+	for key, value := range labels {
+		if len(validation.IsValidLabelValue(value)) > 0 {
+			labels[key] = ""
+		}
+	}
+
+	return labels
+}
+
+// KubernetesVersionMatches returns true if the minor Kubernetes version in the given labels of a cluster
+// satisfies the given constraint. The empty constraint matches all the clusters.
+func KubernetesVersionMatches(constraint string, labels map[string]string) (bool, error) {
+	if constraint == "" {
+		return true, nil
+	}
+
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false, fmt.Errorf("invalid Kubernetes version constraint %q: %w", constraint, err)
+	}
+
+	v, err := semver.NewVersion(labels[kcm.KubernetesVersionLabelKey])
+	return err == nil && c.Check(v), nil
+}
+
+// KubernetesVersionSelector returns the given selector extended with the requirement of the minor Kubernetes
+// version of the clusters to be one of the given versions satisfying the constraint, so the constraint could
+// be evaluated by the label selectors. The selector is returned as is if the constraint is empty.
+func KubernetesVersionSelector(selector metav1.LabelSelector, constraint string, versions []string) (metav1.LabelSelector, error) {
+	if constraint == "" {
+		return selector, nil
+	}
+
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return selector, fmt.Errorf("invalid Kubernetes version constraint %q: %w", constraint, err)
+	}
+
+	var matched []string
+	for _, version := range versions {
+		v, err := semver.NewVersion(version)
+		if err == nil && c.Check(v) && !slices.Contains(matched, version) {
+			matched = append(matched, version)
+		}
+	}
+	slices.Sort(matched)
+
+	selector = *selector.DeepCopy()
+	if len(matched) == 0 {
+		// the contradicting requirements not matching any cluster
+		selector.MatchExpressions = append(selector.MatchExpressions,
+			metav1.LabelSelectorRequirement{Key: kcm.KubernetesVersionLabelKey, Operator: metav1.LabelSelectorOpExists},
+			metav1.LabelSelectorRequirement{Key: kcm.KubernetesVersionLabelKey, Operator: metav1.LabelSelectorOpDoesNotExist},
+		)
+		return selector, nil
+	}
+
+	selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+		Key:      kcm.KubernetesVersionLabelKey,
+		Operator: metav1.LabelSelectorOpIn,
+		Values:   matched,
+	})
+	return selector, nil
+}
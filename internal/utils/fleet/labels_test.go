@@ -0,0 +1,106 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fleet
+
+import (
+	"reflect"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestPropertyLabels(t *testing.T) {
+	cd := &kcm.ClusterDeployment{
+		Spec:   kcm.ClusterDeploymentSpec{Config: &apiextensionsv1.JSON{Raw: []byte(`{"region":"eu-west-1"}`)}},
+		Status: kcm.ClusterDeploymentStatus{KubernetesVersion: "v1.29.3+k0s.0"},
+	}
+
+	expected := map[string]string{
+		kcm.ProviderLabelKey:          "aws",
+		kcm.RegionLabelKey:            "eu-west-1",
+		kcm.KubernetesVersionLabelKey: "1.29",
+	}
+	if actual := PropertyLabels(cd, []string{"bootstrap-k0sproject-k0smotron", "infrastructure-aws"}); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected labels:\ngot:  %v\nwant: %v", actual, expected)
+	}
+
+	cd.Spec.Config.Raw = []byte(`{"region":"not a label value"}`)
+	expected = map[string]string{
+		kcm.ProviderLabelKey:          "",
+		kcm.RegionLabelKey:            "",
+		kcm.KubernetesVersionLabelKey: "1.29",
+	}
+	if actual := PropertyLabels(cd, nil); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected labels:\ngot:  %v\nwant: %v", actual, expected)
+	}
+}
+
+func TestKubernetesVersionSelector(t *testing.T) {
+	base := metav1.LabelSelector{MatchLabels: map[string]string{kcm.ProviderLabelKey: "aws"}}
+	versions := []string{"1.28", "1.29", "1.31", "1.29"}
+
+	for _, tc := range []struct {
+		name       string
+		constraint string
+		matches    map[string]bool
+	}{
+		{
+			name:    "no constraint",
+			matches: map[string]bool{"1.28": true, "1.31": true, "": true},
+		},
+		{
+			name:       "minimal version",
+			constraint: ">=1.29",
+			matches:    map[string]bool{"1.28": false, "1.29": true, "1.31": true, "": false},
+		},
+		{
+			name:       "no matching clusters",
+			constraint: ">=1.32",
+			matches:    map[string]bool{"1.28": false, "1.31": false, "": false},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			selector, err := KubernetesVersionSelector(base, tc.constraint, versions)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			sel, err := metav1.LabelSelectorAsSelector(&selector)
+			if err != nil {
+				t.Fatalf("invalid selector: %v", err)
+			}
+
+			for version, expected := range tc.matches {
+				clusterLabels := labels.Set{kcm.ProviderLabelKey: "aws"}
+				if version != "" {
+					clusterLabels[kcm.KubernetesVersionLabelKey] = version
+				}
+				if actual := sel.Matches(clusterLabels); actual != expected {
+					t.Errorf("expected cluster with version %q to match %t, got %t", version, expected, actual)
+				}
+				if actual, _ := KubernetesVersionMatches(tc.constraint, clusterLabels); actual != expected {
+					t.Errorf("expected version %q to satisfy %q %t, got %t", version, tc.constraint, expected, actual)
+				}
+			}
+		})
+	}
+
+	if _, err := KubernetesVersionSelector(base, "not a constraint", versions); err == nil {
+		t.Error("expected error on the invalid constraint")
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gpu validates the GPU worker instance types of the ClusterDeployments
+// and builds the system service installing the GPU driver and device plugin.
+package gpu
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// ServiceName is the name of the service installing the GPU driver and device plugin.
+const ServiceName = "kcm-gpu-operator"
+
+// instanceTypeValuesKeys is the list of the ClusterTemplate values keys defining the worker instance type.
+var instanceTypeValuesKeys = []string{"instanceType", "vmSize", "machineType"}
+
+// gpuInstanceTypes maps the infrastructure providers to the patterns of their instance types with NVIDIA GPUs.
+var gpuInstanceTypes = map[string]*regexp.Regexp{
+	// P and G instance families
+	"infrastructure-aws": regexp.MustCompile(`^(p[2-5][a-z]*|g[4-6][a-z]*|gr6)\.`),
+	// N-series virtual machines
+	"infrastructure-azure": regexp.MustCompile(`^Standard_N`),
+	// accelerator-optimized machine families
+	"infrastructure-gcp": regexp.MustCompile(`^(a2|a3|g2)-`),
+}
+
+// ValidateInstanceType checks that the worker instance type defined in the given
+// ClusterDeployment configuration has NVIDIA GPUs attached for one of the given providers.
+func ValidateInstanceType(providers []string, config *apiextensionsv1.JSON) error {
+	idx := slices.IndexFunc(providers, func(provider string) bool {
+		_, ok := gpuInstanceTypes[provider]
+		return ok
+	})
+	if idx < 0 {
+		return fmt.Errorf("GPU worker nodes are not supported by the infrastructure providers %s", strings.Join(providers, ", "))
+	}
+	provider := providers[idx]
+
+	instanceType, err := workerInstanceType(config)
+	if err != nil {
+		return err
+	}
+	if instanceType == "" {
+		return fmt.Errorf("the worker instance type must be set for GPU worker nodes, use one of the keys: %s", strings.Join(instanceTypeValuesKeys, ", "))
+	}
+
+	if !gpuInstanceTypes[provider].MatchString(instanceType) {
+		return fmt.Errorf("the instance type %q does not provide GPUs for the provider %s", instanceType, provider)
+	}
+
+	return nil
+}
+
+// Service returns the service installing the GPU driver and device plugin
+// configured in the given GPU options.
+func Service(cfg *kcm.ClusterGPU) kcm.Service {
+	return kcm.Service{
+		Name:      ServiceName,
+		Namespace: cfg.Namespace,
+		Template:  cfg.ServiceTemplate,
+		Values:    cfg.Values,
+	}
+}
+
+// workerInstanceType returns the worker instance type either from the worker machines parameters
+// or from the top-level values as defined by the hosted control plane templates.
+func workerInstanceType(config *apiextensionsv1.JSON) (string, error) {
+	if config == nil || len(config.Raw) == 0 {
+		return "", nil
+	}
+
+	values := make(map[string]any)
+	if err := json.Unmarshal(config.Raw, &values); err != nil {
+		return "", fmt.Errorf("failed to parse ClusterDeployment configuration: %w", err)
+	}
+
+	worker, _ := values["worker"].(map[string]any)
+	for _, key := range instanceTypeValuesKeys {
+		if v, ok := worker[key].(string); ok && v != "" {
+			return v, nil
+		}
+		if v, ok := values[key].(string); ok && v != "" {
+			return v, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gpu
+
+import (
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestValidateInstanceType(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		providers []string
+		config    string
+		err       string
+	}{
+		{
+			name:      "aws gpu instance",
+			providers: []string{"infrastructure-aws", "control-plane-k0sproject-k0smotron"},
+			config:    `{"worker":{"instanceType":"g5.xlarge"}}`,
+		},
+		{
+			name:      "aws hosted control plane gpu instance",
+			providers: []string{"infrastructure-aws"},
+			config:    `{"instanceType":"p4d.24xlarge"}`,
+		},
+		{
+			name:      "aws general purpose instance",
+			providers: []string{"infrastructure-aws"},
+			config:    `{"worker":{"instanceType":"t3.large"}}`,
+			err:       `the instance type "t3.large" does not provide GPUs for the provider infrastructure-aws`,
+		},
+		{
+			name:      "azure gpu instance",
+			providers: []string{"infrastructure-azure"},
+			config:    `{"worker":{"vmSize":"Standard_NC6s_v3"}}`,
+		},
+		{
+			name:      "gcp gpu instance",
+			providers: []string{"infrastructure-gcp"},
+			config:    `{"worker":{"instanceType":"a2-highgpu-1g"}}`,
+		},
+		{
+			name:      "missing instance type",
+			providers: []string{"infrastructure-azure"},
+			config:    `{"workersNumber":2}`,
+			err:       "the worker instance type must be set for GPU worker nodes, use one of the keys: instanceType, vmSize, machineType",
+		},
+		{
+			name:      "unsupported provider",
+			providers: []string{"infrastructure-vsphere"},
+			config:    `{"worker":{"vmTemplate":"ubuntu"}}`,
+			err:       "GPU worker nodes are not supported by the infrastructure providers infrastructure-vsphere",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateInstanceType(tc.providers, &apiextensionsv1.JSON{Raw: []byte(tc.config)})
+			switch {
+			case tc.err == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tc.err != "" && (err == nil || err.Error() != tc.err):
+				t.Errorf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package oidc translates the OIDC configuration of a ClusterDeployment
+// into the API server arguments passed to the ClusterTemplate values.
+package oidc
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// apiServerArgsValuesPath is the path of the API server extra arguments in the ClusterTemplate values.
+var apiServerArgsValuesPath = []string{"k0s", "api", "extraArgs"}
+
+// APIServerArgs returns the API server arguments configuring the given OIDC authentication.
+func APIServerArgs(cfg *kcm.ClusterOIDC) map[string]string {
+	args := map[string]string{
+		"oidc-issuer-url": cfg.IssuerURL,
+		"oidc-client-id":  cfg.ClientID,
+	}
+
+	for arg, value := range map[string]string{
+		"oidc-username-claim":  cfg.ClaimMappings.UsernameClaim,
+		"oidc-username-prefix": cfg.ClaimMappings.UsernamePrefix,
+		"oidc-groups-claim":    cfg.ClaimMappings.GroupsClaim,
+		"oidc-groups-prefix":   cfg.ClaimMappings.GroupsPrefix,
+	} {
+		if value != "" {
+			args[arg] = value
+		}
+	}
+
+	if len(cfg.RequiredClaims) > 0 {
+		claims := make([]string, 0, len(cfg.RequiredClaims))
+		for _, claim := range slices.Sorted(maps.Keys(cfg.RequiredClaims)) {
+			claims = append(claims, claim+"="+cfg.RequiredClaims[claim])
+		}
+		args["oidc-required-claim"] = strings.Join(claims, ",")
+	}
+
+	return args
+}
+
+// SetAPIServerArgs merges the API server arguments configuring the given OIDC authentication
+// into the ClusterTemplate values overriding the arguments with the same names.
+func SetAPIServerArgs(values map[string]any, cfg *kcm.ClusterOIDC) error {
+	args, _, err := unstructured.NestedMap(values, apiServerArgsValuesPath...)
+	if err != nil {
+		return fmt.Errorf("failed to get %s values: %w", strings.Join(apiServerArgsValuesPath, "."), err)
+	}
+	if args == nil {
+		args = make(map[string]any)
+	}
+
+	for arg, value := range APIServerArgs(cfg) {
+		args[arg] = value
+	}
+
+	return unstructured.SetNestedMap(values, args, apiServerArgsValuesPath...)
+}
+
+// TemplateSupported returns true if the ClusterTemplate with the given default values
+// supports passing the API server arguments.
+func TemplateSupported(config *apiextensionsv1.JSON) (bool, error) {
+	if config == nil || len(config.Raw) == 0 {
+		return false, nil
+	}
+
+	values := make(map[string]any)
+	if err := json.Unmarshal(config.Raw, &values); err != nil {
+		return false, fmt.Errorf("failed to parse template config: %w", err)
+	}
+
+	_, found, err := unstructured.NestedMap(values, apiServerArgsValuesPath...)
+	return found && err == nil, nil
+}
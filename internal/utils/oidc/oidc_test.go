@@ -0,0 +1,138 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"reflect"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestSetAPIServerArgs(t *testing.T) {
+	cfg := &kcm.ClusterOIDC{
+		IssuerURL: "https://issuer.example.com",
+		ClientID:  "kubernetes",
+		ClaimMappings: kcm.OIDCClaimMappings{
+			UsernameClaim: "email",
+			GroupsClaim:   "groups",
+			GroupsPrefix:  "oidc:",
+		},
+		RequiredClaims: map[string]string{"hd": "example.com", "aud": "kubernetes"},
+	}
+
+	tests := []struct {
+		name   string
+		values map[string]any
+		want   map[string]any
+	}{
+		{
+			name:   "no api server arguments",
+			values: map[string]any{"workersNumber": float64(2)},
+			want: map[string]any{
+				"workersNumber": float64(2),
+				"k0s": map[string]any{
+					"api": map[string]any{
+						"extraArgs": map[string]any{
+							"oidc-issuer-url":     "https://issuer.example.com",
+							"oidc-client-id":      "kubernetes",
+							"oidc-username-claim": "email",
+							"oidc-groups-claim":   "groups",
+							"oidc-groups-prefix":  "oidc:",
+							"oidc-required-claim": "aud=kubernetes,hd=example.com",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "existing api server arguments",
+			values: map[string]any{
+				"k0s": map[string]any{
+					"version": "v1.32.3+k0s.0",
+					"api": map[string]any{
+						"extraArgs": map[string]any{
+							"anonymous-auth":  "false",
+							"oidc-client-id":  "overridden",
+							"oidc-issuer-url": "https://overridden.example.com",
+						},
+					},
+				},
+			},
+			want: map[string]any{
+				"k0s": map[string]any{
+					"version": "v1.32.3+k0s.0",
+					"api": map[string]any{
+						"extraArgs": map[string]any{
+							"anonymous-auth":      "false",
+							"oidc-issuer-url":     "https://issuer.example.com",
+							"oidc-client-id":      "kubernetes",
+							"oidc-username-claim": "email",
+							"oidc-groups-claim":   "groups",
+							"oidc-groups-prefix":  "oidc:",
+							"oidc-required-claim": "aud=kubernetes,hd=example.com",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetAPIServerArgs(tt.values, cfg); err != nil {
+				t.Fatalf("SetAPIServerArgs() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tt.values, tt.want) {
+				t.Errorf("SetAPIServerArgs() = %v, want %v", tt.values, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateSupported(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *apiextensionsv1.JSON
+		want   bool
+	}{
+		{
+			name: "no config",
+		},
+		{
+			name:   "api server arguments are supported",
+			config: &apiextensionsv1.JSON{Raw: []byte(`{"k0s":{"api":{"extraArgs":{}}}}`)},
+			want:   true,
+		},
+		{
+			name:   "api server arguments are not supported",
+			config: &apiextensionsv1.JSON{Raw: []byte(`{"workersNumber":1}`)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TemplateSupported(tt.config)
+			if err != nil {
+				t.Fatalf("TemplateSupported() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("TemplateSupported() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
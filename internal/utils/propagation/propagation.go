@@ -0,0 +1,96 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package propagation applies the labels and annotations propagated from
+// a ClusterDeployment to the objects of the cluster.
+package propagation
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// Tracked returns true if the object has labels or annotations propagated to it.
+func Tracked(obj metav1.Object) bool {
+	annotations := obj.GetAnnotations()
+	_, labelsTracked := annotations[kcm.PropagatedLabelsAnnotation]
+	_, annotationsTracked := annotations[kcm.PropagatedAnnotationsAnnotation]
+	return labelsTracked || annotationsTracked
+}
+
+// Apply sets the given labels and annotations on the object and removes the
+// previously propagated ones missing in the given maps. The keys of the propagated
+// labels and annotations are recorded in the object annotations.
+// Returns true if the object has been changed.
+func Apply(obj metav1.Object, labels, annotations map[string]string) bool {
+	objLabels := maps.Clone(obj.GetLabels())
+	if objLabels == nil {
+		objLabels = make(map[string]string)
+	}
+	objAnnotations := maps.Clone(obj.GetAnnotations())
+	if objAnnotations == nil {
+		objAnnotations = make(map[string]string)
+	}
+
+	changed := merge(objLabels, objAnnotations, kcm.PropagatedLabelsAnnotation, labels)
+	changed = merge(objAnnotations, objAnnotations, kcm.PropagatedAnnotationsAnnotation, annotations) || changed
+	if !changed {
+		return false
+	}
+
+	obj.SetLabels(objLabels)
+	obj.SetAnnotations(objAnnotations)
+	return true
+}
+
+// merge sets the desired key-value pairs in dst, removes the keys listed in
+// the tracking annotation and missing in the desired ones and updates the tracking annotation.
+func merge(dst, tracking map[string]string, trackingKey string, desired map[string]string) (changed bool) {
+	for _, key := range strings.Split(tracking[trackingKey], ",") {
+		if _, ok := desired[key]; ok || key == "" {
+			continue
+		}
+		if _, ok := dst[key]; ok {
+			delete(dst, key)
+			changed = true
+		}
+	}
+
+	for key, value := range desired {
+		if v, ok := dst[key]; !ok || v != value {
+			dst[key] = value
+			changed = true
+		}
+	}
+
+	if len(desired) == 0 {
+		if _, ok := tracking[trackingKey]; ok {
+			delete(tracking, trackingKey)
+			changed = true
+		}
+		return changed
+	}
+
+	if keys := strings.Join(slices.Sorted(maps.Keys(desired)), ","); tracking[trackingKey] != keys {
+		tracking[trackingKey] = keys
+		changed = true
+	}
+
+	return changed
+}
@@ -0,0 +1,74 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation
+
+import (
+	"maps"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestApply(t *testing.T) {
+	obj := &metav1.ObjectMeta{
+		Labels:      map[string]string{"app": "foo"},
+		Annotations: map[string]string{"note": "bar"},
+	}
+
+	if !Apply(obj, map[string]string{"team": "a", "cost-center": "1"}, map[string]string{"owner": "x"}) {
+		t.Fatal("expected the object to be changed")
+	}
+	expectMap(t, obj.Labels, map[string]string{"app": "foo", "team": "a", "cost-center": "1"})
+	expectMap(t, obj.Annotations, map[string]string{
+		"note":                              "bar",
+		"owner":                             "x",
+		kcm.PropagatedLabelsAnnotation:      "cost-center,team",
+		kcm.PropagatedAnnotationsAnnotation: "owner",
+	})
+	if !Tracked(obj) {
+		t.Error("expected the object to be tracked")
+	}
+
+	if Apply(obj, map[string]string{"team": "a", "cost-center": "1"}, map[string]string{"owner": "x"}) {
+		t.Error("expected the object not to be changed")
+	}
+
+	if !Apply(obj, map[string]string{"team": "b"}, nil) {
+		t.Fatal("expected the object to be changed")
+	}
+	expectMap(t, obj.Labels, map[string]string{"app": "foo", "team": "b"})
+	expectMap(t, obj.Annotations, map[string]string{
+		"note":                         "bar",
+		kcm.PropagatedLabelsAnnotation: "team",
+	})
+
+	if !Apply(obj, nil, nil) {
+		t.Fatal("expected the object to be changed")
+	}
+	expectMap(t, obj.Labels, map[string]string{"app": "foo"})
+	expectMap(t, obj.Annotations, map[string]string{"note": "bar"})
+	if Tracked(obj) {
+		t.Error("expected the object not to be tracked")
+	}
+}
+
+func expectMap(t *testing.T, actual, expected map[string]string) {
+	t.Helper()
+	if !maps.Equal(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
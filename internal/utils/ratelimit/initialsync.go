@@ -0,0 +1,126 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// InitialSyncOptions configures the spreading of the reconciles requested upon the start of a controller.
+type InitialSyncOptions struct {
+	// QPS is the rate of the reconciles of the objects listed upon the start, 0 disables the spreading.
+	QPS float64
+	// Jitter is the maximum random delay added to each of the reconciles.
+	Jitter time.Duration
+}
+
+// InitialSync spreads the reconciles requested by the objects listed by the informers upon the start of
+// a controller, which are otherwise all enqueued at once and hammer the API server and the Helm repositories.
+// The objects created before the start are considered listed, their reconciles are delayed by the given rate
+// and a random jitter, while the reconciles of the changes and the objects created afterwards are not delayed.
+//
+// The start is recorded once the [InitialSync] is started by the manager, i.e. once the manager is elected
+// as the leader along with the controllers, so it must be added to the manager.
+type InitialSync[T comparable] struct {
+	limiter *rate.Limiter
+	// start is the time in unix nanoseconds the controller has been started at, 0 until started
+	start  atomic.Int64
+	jitter time.Duration
+}
+
+// NewInitialSync returns the [InitialSync] of a controller, nil if the spreading is disabled.
+func NewInitialSync[T comparable](opts InitialSyncOptions) *InitialSync[T] {
+	if opts.QPS <= 0 {
+		return nil
+	}
+
+	return &InitialSync[T]{
+		limiter: rate.NewLimiter(rate.Limit(opts.QPS), max(1, int(opts.QPS))),
+		jitter:  opts.Jitter,
+	}
+}
+
+// NeedLeaderElection implements the [sigs.k8s.io/controller-runtime/pkg/manager.LeaderElectionRunnable]
+// interface, the controllers are started once the manager is elected as the leader.
+func (*InitialSync[T]) NeedLeaderElection() bool {
+	return true
+}
+
+// Start records the start of the controller.
+func (s *InitialSync[T]) Start(context.Context) error {
+	s.start.CompareAndSwap(0, time.Now().UnixNano())
+	return nil
+}
+
+// Listed returns true if the given object is considered listed upon the start of the controller.
+// All the objects are considered listed until the start is recorded, as the informers of the controller
+// started along with the [InitialSync] may list the objects first.
+func (s *InitialSync[T]) Listed(obj client.Object) bool {
+	if s == nil {
+		return false
+	}
+	start := s.start.Load()
+	return start == 0 || obj.GetCreationTimestamp().Time.Before(time.Unix(0, start))
+}
+
+// Delay reserves the delay of the next reconcile of a listed object.
+func (s *InitialSync[T]) Delay() time.Duration {
+	delay := s.limiter.Reserve().Delay()
+	if s.jitter > 0 {
+		delay += rand.N(s.jitter)
+	}
+	return delay
+}
+
+// Handler wraps the given handler delaying the reconciles requested by the create events of the listed objects.
+func (s *InitialSync[T]) Handler(h handler.TypedEventHandler[client.Object, T]) handler.TypedEventHandler[client.Object, T] {
+	if s == nil {
+		return h
+	}
+	return &initialSyncHandler[T]{TypedEventHandler: h, sync: s}
+}
+
+type initialSyncHandler[T comparable] struct {
+	handler.TypedEventHandler[client.Object, T]
+
+	sync *InitialSync[T]
+}
+
+func (h *initialSyncHandler[T]) Create(ctx context.Context, e event.TypedCreateEvent[client.Object], q workqueue.TypedRateLimitingInterface[T]) {
+	if h.sync.Listed(e.Object) {
+		q = &delayedQueue[T]{TypedRateLimitingInterface: q, delay: h.sync.Delay}
+	}
+	h.TypedEventHandler.Create(ctx, e, q)
+}
+
+// delayedQueue adds the items after the delay, the earliest delay of an item is kept by the queue.
+type delayedQueue[T comparable] struct {
+	workqueue.TypedRateLimitingInterface[T]
+
+	delay func() time.Duration
+}
+
+func (q *delayedQueue[T]) Add(item T) {
+	q.AddAfter(item, q.delay())
+}
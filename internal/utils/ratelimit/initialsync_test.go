@@ -0,0 +1,87 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+type recordingQueue struct {
+	workqueue.TypedRateLimitingInterface[ctrl.Request]
+
+	added   []string
+	delayed map[string]time.Duration
+}
+
+func (q *recordingQueue) Add(item ctrl.Request) {
+	q.added = append(q.added, item.Name)
+}
+
+func (q *recordingQueue) AddAfter(item ctrl.Request, d time.Duration) {
+	q.delayed[item.Name] = d
+}
+
+func TestInitialSync(t *testing.T) {
+	require.Nil(t, NewInitialSync[ctrl.Request](InitialSyncOptions{}), "expected the spreading to be disabled")
+
+	s := NewInitialSync[ctrl.Request](InitialSyncOptions{QPS: 2})
+	require.True(t, s.NeedLeaderElection())
+	h := s.Handler(&handler.EnqueueRequestForObject{})
+
+	newClusterDeployment := func(name string, created time.Time) client.Object {
+		return &kcm.ClusterDeployment{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		}}
+	}
+
+	// the objects are considered listed until the start is recorded
+	require.True(t, s.Listed(newClusterDeployment("early", time.Now().Add(time.Hour))))
+
+	require.NoError(t, s.Start(t.Context()))
+	start := time.Unix(0, s.start.Load())
+	require.NoError(t, s.Start(t.Context()))
+	require.Equal(t, start, time.Unix(0, s.start.Load()), "expected the first start to be kept")
+
+	listed := func(name string) client.Object { return newClusterDeployment(name, start.Add(-time.Hour)) }
+	created := newClusterDeployment("created", start.Add(time.Second))
+
+	q := &recordingQueue{delayed: make(map[string]time.Duration)}
+	for _, obj := range []client.Object{listed("a"), listed("b"), listed("c"), created, listed("d")} {
+		h.Create(t.Context(), event.CreateEvent{Object: obj}, q)
+	}
+
+	assert.Equal(t, []string{"created"}, q.added, "expected only the created object to be added immediately")
+
+	// the first reconciles fit into the burst, the rest are spread at the rate
+	expected := map[string]time.Duration{"a": 0, "b": 0, "c": 500 * time.Millisecond, "d": time.Second}
+	require.Len(t, q.delayed, len(expected))
+	for name, d := range expected {
+		require.Contains(t, q.delayed, name)
+		assert.InDelta(t, d, q.delayed[name], float64(100*time.Millisecond), "unexpected delay of %s", name)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsprofile
+
+import (
+	"crypto/fips140"
+	"crypto/tls"
+	"fmt"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// restrictedCipherSuites is the list of the FIPS 140 approved cipher suites
+// allowed for TLS 1.2 connections, TLS 1.3 suites are not configurable.
+var restrictedCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+// Resolve returns the profile effectively used for the given one.
+// The Restricted profile is always used if the binary runs in the FIPS 140-3 mode.
+func Resolve(profile string) (string, error) {
+	switch profile {
+	case "", kcm.TLSProfileDefault:
+		if fips140.Enabled() {
+			return kcm.TLSProfileRestricted, nil
+		}
+		return kcm.TLSProfileDefault, nil
+	case kcm.TLSProfileRestricted:
+		return kcm.TLSProfileRestricted, nil
+	default:
+		return "", fmt.Errorf("unknown TLS profile %q, must be one of %s, %s", profile, kcm.TLSProfileDefault, kcm.TLSProfileRestricted)
+	}
+}
+
+// Apply configures the given TLS configuration according to the profile.
+// The Default profile leaves the configuration untouched.
+func Apply(profile string, c *tls.Config) {
+	if profile != kcm.TLSProfileRestricted {
+		return
+	}
+
+	c.MinVersion = tls.VersionTLS12
+	c.CipherSuites = restrictedCipherSuites
+	c.CurvePreferences = []tls.CurveID{tls.CurveP256, tls.CurveP384}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsprofile
+
+import (
+	"crypto/fips140"
+	"crypto/tls"
+	"testing"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func TestResolve(t *testing.T) {
+	defaultProfile := kcm.TLSProfileDefault
+	if fips140.Enabled() {
+		defaultProfile = kcm.TLSProfileRestricted
+	}
+
+	for _, tc := range []struct {
+		profile  string
+		expected string
+		err      bool
+	}{
+		{profile: "", expected: defaultProfile},
+		{profile: kcm.TLSProfileDefault, expected: defaultProfile},
+		{profile: kcm.TLSProfileRestricted, expected: kcm.TLSProfileRestricted},
+		{profile: "Modern", err: true},
+	} {
+		t.Run(tc.profile, func(t *testing.T) {
+			actual, err := Resolve(tc.profile)
+			if tc.err != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected profile %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	c := &tls.Config{}
+	Apply(kcm.TLSProfileDefault, c)
+	if c.MinVersion != 0 || c.CipherSuites != nil {
+		t.Errorf("expected the Default profile not to change the config, got %+v", c)
+	}
+
+	Apply(kcm.TLSProfileRestricted, c)
+	if c.MinVersion != tls.VersionTLS12 {
+		t.Errorf("expected min version TLS 1.2, got %x", c.MinVersion)
+	}
+	for _, id := range c.CipherSuites {
+		for _, insecure := range tls.InsecureCipherSuites() {
+			if id == insecure.ID {
+				t.Errorf("unexpected insecure cipher suite %s", insecure.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/utils/fleet"
+)
+
+// Candidates evaluates the given ClusterTemplates as the upgrade targets of a cluster
+// deployed from the current template. The availableUpgrades are the names of the templates
+// listed as the upgrades of the current template in the ClusterTemplateChains.
+// The current template itself is skipped. The result is sorted by the template name.
+func Candidates(current *kcm.ClusterTemplate, templates []kcm.ClusterTemplate, availableUpgrades []string) []kcm.UpgradeCandidate {
+	currentProviders := fleet.InfrastructureProviders(current.Status.Providers)
+	slices.Sort(currentProviders)
+
+	candidates := make([]kcm.UpgradeCandidate, 0, len(templates))
+	for _, template := range templates {
+		if template.Name == current.Name {
+			continue
+		}
+
+		candidate := kcm.UpgradeCandidate{
+			Template:  template.Name,
+			Available: slices.Contains(availableUpgrades, template.Name),
+		}
+
+		if !candidate.Available {
+			candidate.Reasons = append(candidate.Reasons, kcm.UpgradeCandidateReason{
+				Type:    kcm.UpgradeCandidateReasonNotInChain,
+				Message: fmt.Sprintf("not listed as an upgrade of %s in any ClusterTemplateChain", current.Name),
+			})
+		}
+
+		if !template.Status.Valid {
+			msg := "template is not valid"
+			if template.Status.ValidationError != "" {
+				msg += ": " + template.Status.ValidationError
+			}
+			candidate.Reasons = append(candidate.Reasons, kcm.UpgradeCandidateReason{
+				Type:    kcm.UpgradeCandidateReasonInvalid,
+				Message: msg,
+			})
+		}
+
+		providers := fleet.InfrastructureProviders(template.Status.Providers)
+		slices.Sort(providers)
+		if !slices.Equal(currentProviders, providers) {
+			candidate.Reasons = append(candidate.Reasons, kcm.UpgradeCandidateReason{
+				Type: kcm.UpgradeCandidateReasonProviderMismatch,
+				Message: fmt.Sprintf("infrastructure providers [%s] differ from the current [%s]",
+					strings.Join(providers, ", "), strings.Join(currentProviders, ", ")),
+			})
+		}
+
+		if msg := kubernetesVersionSkew(current.Status.KubernetesVersion, template.Status.KubernetesVersion); msg != "" {
+			candidate.Reasons = append(candidate.Reasons, kcm.UpgradeCandidateReason{
+				Type:    kcm.UpgradeCandidateReasonKubernetesVersionSkew,
+				Message: msg,
+			})
+		}
+
+		if note, ok := template.Annotations[kcm.ClusterTemplateDeprecatedAnnotation]; ok {
+			msg := "template is deprecated"
+			if note != "" {
+				msg += ": " + note
+			}
+			candidate.Reasons = append(candidate.Reasons, kcm.UpgradeCandidateReason{
+				Type:    kcm.UpgradeCandidateReasonDeprecated,
+				Message: msg,
+			})
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	slices.SortFunc(candidates, func(a, b kcm.UpgradeCandidate) int { return strings.Compare(a.Template, b.Template) })
+	return candidates
+}
+
+// kubernetesVersionSkew returns the description of the unsupported skew between the
+// current and the target Kubernetes versions or an empty string if there is none.
+// Versions that are not set or cannot be parsed are not evaluated.
+func kubernetesVersionSkew(current, target string) string {
+	if current == "" || target == "" {
+		return ""
+	}
+
+	currentVersion, err := semver.NewVersion(current)
+	if err != nil {
+		return ""
+	}
+	targetVersion, err := semver.NewVersion(target)
+	if err != nil {
+		return ""
+	}
+
+	switch {
+	case targetVersion.LessThan(currentVersion):
+		return fmt.Sprintf("Kubernetes version %s is lower than the current %s", target, current)
+	case targetVersion.Major() != currentVersion.Major() || targetVersion.Minor() > currentVersion.Minor()+1:
+		return fmt.Sprintf("Kubernetes version %s skips minor versions of the current %s", target, current)
+	}
+
+	return ""
+}
@@ -0,0 +1,116 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upgrade
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newTemplate(name, k8sVersion string, valid bool, providers ...string) kcm.ClusterTemplate {
+	t := kcm.ClusterTemplate{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	t.Status.Valid = valid
+	t.Status.KubernetesVersion = k8sVersion
+	t.Status.Providers = providers
+
+	return t
+}
+
+func TestCandidates(t *testing.T) {
+	current := newTemplate("aws-1-0-0", "v1.31.1", true, "infrastructure-aws", "bootstrap-k0sproject-k0smotron")
+
+	deprecated := newTemplate("aws-1-0-1", "v1.31.2", true, "infrastructure-aws")
+	deprecated.Annotations = map[string]string{kcm.ClusterTemplateDeprecatedAnnotation: "use aws-1-1-0"}
+
+	invalid := newTemplate("aws-1-2-0", "v1.32.0", false, "infrastructure-aws")
+	invalid.Status.ValidationError = "chart not found"
+
+	templates := []kcm.ClusterTemplate{
+		newTemplate("azure-1-0-0", "v1.31.1", true, "infrastructure-azure"),
+		current,
+		newTemplate("aws-1-1-0", "v1.32.0", true, "infrastructure-aws", "control-plane-k0sproject-k0smotron"),
+		deprecated,
+		invalid,
+		newTemplate("aws-0-9-0", "v1.30.5", true, "infrastructure-aws"),
+		newTemplate("aws-2-0-0", "v1.33.0", true, "infrastructure-aws"),
+	}
+
+	expected := []kcm.UpgradeCandidate{
+		{
+			Template: "aws-0-9-0",
+			Reasons: []kcm.UpgradeCandidateReason{
+				{Type: kcm.UpgradeCandidateReasonNotInChain, Message: "not listed as an upgrade of aws-1-0-0 in any ClusterTemplateChain"},
+				{Type: kcm.UpgradeCandidateReasonKubernetesVersionSkew, Message: "Kubernetes version v1.30.5 is lower than the current v1.31.1"},
+			},
+		},
+		{
+			Template: "aws-1-0-1",
+			Reasons: []kcm.UpgradeCandidateReason{
+				{Type: kcm.UpgradeCandidateReasonDeprecated, Message: "template is deprecated: use aws-1-1-0"},
+			},
+			Available: true,
+		},
+		{Template: "aws-1-1-0", Available: true},
+		{
+			Template: "aws-1-2-0",
+			Reasons: []kcm.UpgradeCandidateReason{
+				{Type: kcm.UpgradeCandidateReasonNotInChain, Message: "not listed as an upgrade of aws-1-0-0 in any ClusterTemplateChain"},
+				{Type: kcm.UpgradeCandidateReasonInvalid, Message: "template is not valid: chart not found"},
+			},
+		},
+		{
+			Template: "aws-2-0-0",
+			Reasons: []kcm.UpgradeCandidateReason{
+				{Type: kcm.UpgradeCandidateReasonKubernetesVersionSkew, Message: "Kubernetes version v1.33.0 skips minor versions of the current v1.31.1"},
+			},
+			Available: true,
+		},
+		{
+			Template: "azure-1-0-0",
+			Reasons: []kcm.UpgradeCandidateReason{
+				{Type: kcm.UpgradeCandidateReasonNotInChain, Message: "not listed as an upgrade of aws-1-0-0 in any ClusterTemplateChain"},
+				{Type: kcm.UpgradeCandidateReasonProviderMismatch, Message: "infrastructure providers [infrastructure-azure] differ from the current [infrastructure-aws]"},
+			},
+		},
+	}
+
+	actual := Candidates(&current, templates, []string{"aws-1-1-0", "aws-1-0-1", "aws-2-0-0"})
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("unexpected upgrade candidates:\nexpected: %+v\nactual:   %+v", expected, actual)
+	}
+}
+
+func TestKubernetesVersionSkew(t *testing.T) {
+	for _, tc := range []struct {
+		current, target string
+		skewed          bool
+	}{
+		{current: "v1.31.1", target: "v1.31.2"},
+		{current: "v1.31.1", target: "v1.32.0"},
+		{current: "v1.31.1", target: "v1.33.0", skewed: true},
+		{current: "v1.31.1", target: "v1.31.0", skewed: true},
+		{current: "v1.31.1", target: "v2.0.0", skewed: true},
+		{current: "", target: "v1.33.0"},
+		{current: "v1.31.1", target: "invalid"},
+	} {
+		if skewed := kubernetesVersionSkew(tc.current, tc.target) != ""; skewed != tc.skewed {
+			t.Errorf("kubernetesVersionSkew(%q, %q): expected skew %t, got %t", tc.current, tc.target, tc.skewed, skewed)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+// MultiClusterServiceCrossNamespaceServicesRefs validates that the service and templates references of the given
+// [github.com/K0rdent/kcm/api/v1alpha1.MultiClusterService] with the explicit namespace reference the objects
+// only in the given system namespace. The references without the namespace are resolved by Sveltos
+// in the namespace of each of the selected clusters, so the objects of one tenant could not be delivered
+// to the clusters of another one.
+func MultiClusterServiceCrossNamespaceServicesRefs(ctx context.Context, mcs *kcmv1.MultiClusterService, systemNamespace string) (errs error) {
+	logdev := log.FromContext(ctx).V(1)
+
+	logdev.Info("Validating that the template references do not refer to any resource outside the system namespace")
+	for _, ref := range mcs.Spec.ServiceSpec.TemplateResourceRefs {
+		if ref.Resource.Namespace != "" && ref.Resource.Namespace != systemNamespace {
+			errs = errors.Join(errs, fmt.Errorf(
+				"cross-namespace template references are disallowed, %s %s's namespace %s, system namespace %s",
+				ref.Resource.Kind, ref.Resource.Name, ref.Resource.Namespace, systemNamespace))
+		}
+	}
+
+	logdev.Info("Validating that the services values references do not refer to any resource outside the system namespace")
+	for _, svc := range mcs.Spec.ServiceSpec.Services {
+		for _, v := range svc.ValuesFrom {
+			if v.Namespace != "" && v.Namespace != systemNamespace {
+				errs = errors.Join(errs, fmt.Errorf(
+					"cross-namespace service values references are disallowed, %s %s's namespace %s, system namespace %s",
+					v.Kind, v.Name, v.Namespace, systemNamespace))
+			}
+		}
+	}
+
+	return errs
+}
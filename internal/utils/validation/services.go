@@ -22,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/servicebundle"
 )
 
 // ServicesHaveValidTemplates validates the given array of [github.com/K0rdent/kcm/api/v1alpha1.Service] checking
@@ -43,3 +44,11 @@ func ServicesHaveValidTemplates(ctx context.Context, cl client.Client, services
 
 	return errs
 }
+
+// ServiceSpecHasValidTemplates validates the services of the given [github.com/K0rdent/kcm/api/v1alpha1.ServiceSpec]
+// along with the services of the [github.com/K0rdent/kcm/api/v1alpha1.ServiceBundle] objects it includes, checking
+// the bundles exist in the given namespace and the services reference valid templates as per [ServicesHaveValidTemplates].
+func ServiceSpecHasValidTemplates(ctx context.Context, cl client.Client, spec kcmv1.ServiceSpec, ns string) error {
+	services, err := servicebundle.Services(ctx, cl, ns, spec)
+	return errors.Join(err, ServicesHaveValidTemplates(ctx, cl, services, ns))
+}
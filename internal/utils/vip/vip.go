@@ -0,0 +1,135 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vip
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// ErrPoolExhausted is returned when there are no free addresses left in the pool.
+var ErrPoolExhausted = errors.New("no free addresses left in the pool")
+
+// Range is an inclusive range of IP addresses.
+type Range struct {
+	First netip.Addr
+	Last  netip.Addr
+}
+
+// Contains reports whether the given address belongs to the range.
+func (r Range) Contains(addr netip.Addr) bool {
+	return r.First.Compare(addr) <= 0 && addr.Compare(r.Last) <= 0
+}
+
+// ParseRanges parses the given list of addresses into the list of inclusive ranges.
+// Each item is either a single IP address, a CIDR or a range in the form of "<first>-<last>".
+// For IPv4 CIDRs the network and broadcast addresses are excluded from the range.
+func ParseRanges(addresses []string) ([]Range, error) {
+	var (
+		ranges = make([]Range, 0, len(addresses))
+		errs   error
+	)
+	for _, v := range addresses {
+		r, err := parseRange(v)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		ranges = append(ranges, r)
+	}
+
+	return ranges, errs
+}
+
+// Contains reports whether the given address belongs to any of the ranges.
+func Contains(ranges []Range, addr netip.Addr) bool {
+	for _, r := range ranges {
+		if r.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Allocate returns the first address from the ranges that is not in use.
+// Returns ErrPoolExhausted if all of the addresses are in use.
+func Allocate(ranges []Range, inUse map[netip.Addr]struct{}) (netip.Addr, error) {
+	for _, r := range ranges {
+		for addr := r.First; addr.IsValid() && addr.Compare(r.Last) <= 0; addr = addr.Next() {
+			if _, ok := inUse[addr]; !ok {
+				return addr, nil
+			}
+		}
+	}
+
+	return netip.Addr{}, ErrPoolExhausted
+}
+
+func parseRange(s string) (Range, error) {
+	s = strings.TrimSpace(s)
+
+	if first, last, ok := strings.Cut(s, "-"); ok {
+		firstAddr, err := netip.ParseAddr(strings.TrimSpace(first))
+		if err != nil {
+			return Range{}, fmt.Errorf("failed to parse address range %s: %w", s, err)
+		}
+		lastAddr, err := netip.ParseAddr(strings.TrimSpace(last))
+		if err != nil {
+			return Range{}, fmt.Errorf("failed to parse address range %s: %w", s, err)
+		}
+		if firstAddr.Is4() != lastAddr.Is4() {
+			return Range{}, fmt.Errorf("address range %s mixes IPv4 and IPv6 addresses", s)
+		}
+		if lastAddr.Less(firstAddr) {
+			return Range{}, fmt.Errorf("address range %s is in the reverse order", s)
+		}
+		return Range{First: firstAddr, Last: lastAddr}, nil
+	}
+
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return Range{}, fmt.Errorf("failed to parse CIDR %s: %w", s, err)
+		}
+		prefix = prefix.Masked()
+
+		first, last := prefix.Addr(), lastAddr(prefix)
+		// skip the network and broadcast addresses
+		if first.Is4() && prefix.Bits() < 31 {
+			first, last = first.Next(), last.Prev()
+		}
+		return Range{First: first, Last: last}, nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return Range{}, fmt.Errorf("failed to parse address %s: %w", s, err)
+	}
+
+	return Range{First: addr, Last: addr}, nil
+}
+
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Addr().AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 1 << (7 - i%8)
+	}
+
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
@@ -0,0 +1,109 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vip
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestParseRanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses []string
+		want      []Range
+		wantErr   bool
+	}{
+		{
+			name:      "single address",
+			addresses: []string{"10.0.0.10"},
+			want:      []Range{{First: netip.MustParseAddr("10.0.0.10"), Last: netip.MustParseAddr("10.0.0.10")}},
+		},
+		{
+			name:      "range",
+			addresses: []string{"10.0.0.10 - 10.0.0.20"},
+			want:      []Range{{First: netip.MustParseAddr("10.0.0.10"), Last: netip.MustParseAddr("10.0.0.20")}},
+		},
+		{
+			name:      "ipv4 cidr skips network and broadcast addresses",
+			addresses: []string{"10.0.0.5/24"},
+			want:      []Range{{First: netip.MustParseAddr("10.0.0.1"), Last: netip.MustParseAddr("10.0.0.254")}},
+		},
+		{
+			name:      "ipv4 point-to-point cidr",
+			addresses: []string{"10.0.0.0/31"},
+			want:      []Range{{First: netip.MustParseAddr("10.0.0.0"), Last: netip.MustParseAddr("10.0.0.1")}},
+		},
+		{
+			name:      "ipv6 cidr",
+			addresses: []string{"fd00::/120"},
+			want:      []Range{{First: netip.MustParseAddr("fd00::"), Last: netip.MustParseAddr("fd00::ff")}},
+		},
+		{
+			name:      "malformed address",
+			addresses: []string{"10.0.0.10", "foo"},
+			wantErr:   true,
+		},
+		{
+			name:      "reverse range",
+			addresses: []string{"10.0.0.20-10.0.0.10"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRanges(tt.addresses)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRanges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseRanges() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("ParseRanges()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	ranges, err := ParseRanges([]string{"10.0.0.10-10.0.0.11", "10.0.1.10"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	inUse := map[netip.Addr]struct{}{}
+	for _, want := range []string{"10.0.0.10", "10.0.0.11", "10.0.1.10"} {
+		got, err := Allocate(ranges, inUse)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.String() != want {
+			t.Fatalf("Allocate() = %s, want %s", got, want)
+		}
+		inUse[got] = struct{}{}
+	}
+
+	if _, err := Allocate(ranges, inUse); !errors.Is(err, ErrPoolExhausted) {
+		t.Fatalf("Allocate() error = %v, want %v", err, ErrPoolExhausted)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package windows implements the ClusterTemplate values conventions
+// of the Windows worker node pools.
+//
+// A ClusterTemplate supports Windows worker nodes if its default values define
+// the number of the Windows workers with the "windowsWorkersNumber" key and
+// the Windows machines parameters under the "windowsWorker" key.
+package windows
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	// WorkersNumberValuesKey is the ClusterTemplate values key of the number of the Windows worker nodes.
+	WorkersNumberValuesKey = "windowsWorkersNumber"
+	// WorkerValuesKey is the ClusterTemplate values key of the Windows worker machines parameters.
+	WorkerValuesKey = "windowsWorker"
+)
+
+// supportedProviders is the list of the infrastructure providers supporting Windows worker nodes.
+var supportedProviders = []string{"infrastructure-azure", "infrastructure-vsphere"}
+
+// TemplateSupported returns true if the given ClusterTemplate follows the Windows worker
+// values conventions and requires an infrastructure provider supporting Windows machines.
+func TemplateSupported(template *kcm.ClusterTemplate) (bool, error) {
+	if !slices.ContainsFunc(template.Status.Providers, func(provider string) bool {
+		return slices.Contains(supportedProviders, provider)
+	}) {
+		return false, nil
+	}
+
+	values, err := parseValues(template.Status.Config)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse template config: %w", err)
+	}
+
+	_, hasNumber := values[WorkersNumberValuesKey]
+	_, hasWorker := values[WorkerValuesKey].(map[string]any)
+	return hasNumber && hasWorker, nil
+}
+
+// WorkersNumber returns the number of the Windows worker nodes requested in the given ClusterDeployment configuration.
+// Windows worker nodes require Linux worker nodes to run the cluster components, so an error
+// is returned if the Windows worker nodes are requested along with zero Linux worker nodes.
+func WorkersNumber(config *apiextensionsv1.JSON) (int64, error) {
+	values, err := parseValues(config)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse ClusterDeployment configuration: %w", err)
+	}
+
+	n, _ := values[WorkersNumberValuesKey].(float64)
+	if n <= 0 {
+		return 0, nil
+	}
+
+	if workers, ok := values["workersNumber"].(float64); ok && workers < 1 {
+		return 0, errors.New("at least one Linux worker node is required along with the Windows worker nodes")
+	}
+
+	return int64(n), nil
+}
+
+func parseValues(config *apiextensionsv1.JSON) (map[string]any, error) {
+	values := make(map[string]any)
+	if config == nil || len(config.Raw) == 0 {
+		return values, nil
+	}
+
+	if err := json.Unmarshal(config.Raw, &values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
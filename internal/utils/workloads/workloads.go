@@ -0,0 +1,101 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package workloads selects the workloads run by a KCM manager process, so the admission webhooks,
+// the core controllers and the heavy subsystems can be run by separate Deployments.
+package workloads
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// Webhooks are the admission webhooks.
+	Webhooks = "webhooks"
+	// Controllers are the core controllers along with the periodic runners, e.g. the telemetry.
+	Controllers = "controllers"
+	// Backup is the ManagementBackup controller.
+	Backup = "backup"
+	// Templates are the controllers validating the templates and the template chains.
+	Templates = "templates"
+
+	// All selects all of the workloads.
+	All = "all"
+)
+
+// known holds the workloads in the canonical order.
+var known = []string{Webhooks, Controllers, Backup, Templates}
+
+// Set is the set of the workloads run by a manager process.
+type Set []string
+
+// Parse returns the set of the workloads from the given comma-separated list of them.
+func Parse(s string) (Set, error) {
+	var set Set
+	for w := range strings.SplitSeq(s, ",") {
+		w = strings.TrimSpace(w)
+		switch {
+		case w == All:
+			return slices.Clone(known), nil
+		case !slices.Contains(known, w):
+			return nil, fmt.Errorf("unknown workload %q, must be one of %s or %s", w, strings.Join(known, ", "), All)
+		case !slices.Contains(set, w):
+			set = append(set, w)
+		}
+	}
+
+	slices.SortFunc(set, func(a, b string) int {
+		return slices.Index(known, a) - slices.Index(known, b)
+	})
+	return set, nil
+}
+
+// Has returns true if the set contains the given workload.
+func (s Set) Has(workload string) bool {
+	return slices.Contains(s, workload)
+}
+
+// NeedLeaderElection returns true if the set contains the workloads run only by the leader,
+// the webhooks are served by each replica.
+func (s Set) NeedLeaderElection() bool {
+	return slices.ContainsFunc(s, func(w string) bool { return w != Webhooks })
+}
+
+// LeaderElectionID returns the ID of the leader election of the set based on the given one.
+// The set of all of the workloads uses the given ID, so the single Deployment keeps its lease,
+// while the others are prefixed with their workloads, so the separate Deployments elect their
+// leaders independently. The webhooks are only prefixed if served by a separate Deployment,
+// whose leader rotates the webhook certificates.
+func (s Set) LeaderElectionID(id string) string {
+	var elected []string
+	for _, w := range s {
+		if w != Webhooks {
+			elected = append(elected, w)
+		}
+	}
+	if len(elected) == len(known)-1 {
+		return id
+	}
+	if len(elected) == 0 {
+		elected = s
+	}
+
+	return strings.Join(elected, "-") + "." + id
+}
+
+func (s Set) String() string {
+	return strings.Join(s, ",")
+}
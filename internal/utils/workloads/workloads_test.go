@@ -0,0 +1,95 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloads
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	const id = "31c555b4.k0rdent.mirantis.com"
+
+	tests := []struct {
+		name                     string
+		workloads                string
+		expected                 Set
+		expectedLeaderElection   bool
+		expectedLeaderElectionID string
+		expectedErr              bool
+	}{
+		{
+			name:                     "all",
+			workloads:                All,
+			expected:                 Set{Webhooks, Controllers, Backup, Templates},
+			expectedLeaderElection:   true,
+			expectedLeaderElectionID: id,
+		},
+		{
+			name:                     "all listed out of order",
+			workloads:                "templates, backup,webhooks,controllers,backup",
+			expected:                 Set{Webhooks, Controllers, Backup, Templates},
+			expectedLeaderElection:   true,
+			expectedLeaderElectionID: id,
+		},
+		{
+			name:                     "controllers without the webhooks",
+			workloads:                "templates,controllers,backup",
+			expected:                 Set{Controllers, Backup, Templates},
+			expectedLeaderElection:   true,
+			expectedLeaderElectionID: id,
+		},
+		{
+			name:                     "webhooks only",
+			workloads:                Webhooks,
+			expected:                 Set{Webhooks},
+			expectedLeaderElectionID: "webhooks." + id,
+		},
+		{
+			name:                     "subsystem",
+			workloads:                "backup",
+			expected:                 Set{Backup},
+			expectedLeaderElection:   true,
+			expectedLeaderElectionID: "backup." + id,
+		},
+		{
+			name:        "unknown",
+			workloads:   "controllers,unknown",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := Parse(tt.workloads)
+			if (err != nil) != tt.expectedErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectedErr {
+				return
+			}
+
+			if !reflect.DeepEqual(set, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, set)
+			}
+			if set.NeedLeaderElection() != tt.expectedLeaderElection {
+				t.Errorf("expected leader election %t, got %t", tt.expectedLeaderElection, set.NeedLeaderElection())
+			}
+			if tt.expectedLeaderElectionID != "" && set.LeaderElectionID(id) != tt.expectedLeaderElectionID {
+				t.Errorf("expected leader election ID %s, got %s", tt.expectedLeaderElectionID, set.LeaderElectionID(id))
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package valuestemplate renders the Go templates with the Sprig functions in the configuration
+// of the ClusterDeployments and in the values of their services with the context of the cluster.
+package valuestemplate
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/utils/fleet"
+)
+
+// Context is the data the templates are rendered with.
+type Context struct {
+	// Cluster is the context of the cluster available in the templates as .Cluster.
+	Cluster Cluster
+}
+
+// Cluster is the context of the cluster, e.g. "{{ .Cluster.Name }}" or "{{ .Cluster.Labels.env | default "dev" }}".
+type Cluster struct {
+	// Labels is the map of the labels of the ClusterDeployment.
+	Labels map[string]string
+	// Name is the name of the ClusterDeployment.
+	Name string
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string
+	// Region is the region of the cluster set in its configuration, e.g. the region of AWS or the location of Azure.
+	Region string
+	// APIServerURL is the URL of the API server of the cluster once it is provisioned.
+	APIServerURL string
+	// Endpoints is the list of the endpoints of the cluster reported in the status of the ClusterDeployment.
+	Endpoints []kcm.ClusterEndpoint
+}
+
+// NewContext returns the context of the cluster of the given ClusterDeployment.
+func NewContext(cd *kcm.ClusterDeployment) Context {
+	cluster := Cluster{
+		Labels:    cd.Labels,
+		Name:      cd.Name,
+		Namespace: cd.Namespace,
+		Region:    fleet.Region(cd),
+		Endpoints: cd.Status.Endpoints,
+	}
+	if cluster.Labels == nil {
+		cluster.Labels = map[string]string{}
+	}
+	for _, e := range cd.Status.Endpoints {
+		if e.Type == kcm.EndpointTypeAPIServer {
+			cluster.APIServerURL = e.URL
+			break
+		}
+	}
+
+	return Context{Cluster: cluster}
+}
+
+// funcs returns the Sprig functions available in the templates
+// without the functions exposing the environment of the controller.
+func funcs() template.FuncMap {
+	fm := sprig.TxtFuncMap()
+	for _, name := range []string{"env", "expandenv", "getHostByName"} {
+		delete(fm, name)
+	}
+	return fm
+}
+
+// Parse returns an error if the given text is not a valid template.
+func Parse(text string) error {
+	if !strings.Contains(text, "{{") {
+		return nil
+	}
+	_, err := template.New("values").Funcs(funcs()).Parse(text)
+	return err
+}
+
+// Render renders the given text with the given context, the text without templates is returned as is.
+func Render(text string, data Context) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New("values").Funcs(funcs()).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// RenderValues renders the string values of the given map and of the nested maps and lists in place.
+func RenderValues(values map[string]any, data Context) error {
+	for k, v := range values {
+		rendered, err := renderValue(v, data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+		values[k] = rendered
+	}
+	return nil
+}
+
+// ParseValues returns an error if any of the string values of the given map
+// and of the nested maps and lists is not a valid template.
+func ParseValues(values map[string]any) error {
+	for k, v := range values {
+		if err := parseValue(v); err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// RenderServices returns the copy of the given services with the rendered values.
+func RenderServices(services []kcm.Service, data Context) ([]kcm.Service, error) {
+	if services == nil {
+		return nil, nil
+	}
+
+	rendered := make([]kcm.Service, len(services))
+	for i, svc := range services {
+		values, err := Render(svc.Values, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render values of the service %s: %w", svc.Name, err)
+		}
+		rendered[i] = *svc.DeepCopy()
+		rendered[i].Values = values
+	}
+
+	return rendered, nil
+}
+
+func renderValue(v any, data Context) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return Render(v, data)
+	case map[string]any:
+		return v, RenderValues(v, data)
+	case []any:
+		for i := range v {
+			rendered, err := renderValue(v[i], data)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			v[i] = rendered
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+func parseValue(v any) error {
+	switch v := v.(type) {
+	case string:
+		return Parse(v)
+	case map[string]any:
+		return ParseValues(v)
+	case []any:
+		for i := range v {
+			if err := parseValue(v[i]); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
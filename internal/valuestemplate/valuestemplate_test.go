@@ -0,0 +1,114 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package valuestemplate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcm "github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+func newContext() Context {
+	return NewContext(&kcm.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "team-a", Labels: map[string]string{"env": "staging"}},
+		Spec:       kcm.ClusterDeploymentSpec{Config: &apiextensionsv1.JSON{Raw: []byte(`{"region":"us-east-2"}`)}},
+		Status: kcm.ClusterDeploymentStatus{Endpoints: []kcm.ClusterEndpoint{
+			{Type: kcm.EndpointTypeAPIServer, URL: "https://dev.example.com:6443"},
+		}},
+	})
+}
+
+func TestRender(t *testing.T) {
+	data := newContext()
+	for _, tc := range []struct {
+		name     string
+		text     string
+		expected string
+		err      string
+	}{
+		{name: "no templates", text: "replicas: 2", expected: "replicas: 2"},
+		{name: "cluster context", text: "{{ .Cluster.Namespace }}/{{ .Cluster.Name }} in {{ .Cluster.Region }}", expected: "team-a/dev in us-east-2"},
+		{name: "labels and sprig", text: `{{ .Cluster.Labels.env | upper }}-{{ .Cluster.Labels.tier | default "web" }}`, expected: "STAGING-web"},
+		{name: "endpoints", text: "{{ .Cluster.APIServerURL }} {{ (index .Cluster.Endpoints 0).Type }}", expected: "https://dev.example.com:6443 APIServer"},
+		{name: "escaped template", text: "{{ `{{ .Cluster.metadata.name }}` }}", expected: "{{ .Cluster.metadata.name }}"},
+		{name: "unknown field", text: "{{ .Cluster.Zone }}", err: "can't evaluate field Zone"},
+		{name: "environment is not exposed", text: `{{ env "HOME" }}`, err: `function "env" not defined`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Render(tc.text, data)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected error containing %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestRenderValues(t *testing.T) {
+	values := map[string]any{
+		"clusterName":   "{{ .Cluster.Name }}",
+		"workersNumber": float64(2),
+		"worker": map[string]any{
+			"tags": []any{"{{ .Cluster.Namespace }}", true},
+		},
+	}
+	if err := RenderValues(values, newContext()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]any{
+		"clusterName":   "dev",
+		"workersNumber": float64(2),
+		"worker": map[string]any{
+			"tags": []any{"team-a", true},
+		},
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("unexpected values:\ngot:  %v\nwant: %v", values, expected)
+	}
+
+	err := ParseValues(map[string]any{"worker": map[string]any{"tags": []any{"{{ .Cluster.Name "}}})
+	if err == nil || !strings.HasPrefix(err.Error(), "worker: tags: [0]: ") {
+		t.Errorf("expected the parse error to name the path of the value, got %v", err)
+	}
+}
+
+func TestRenderServices(t *testing.T) {
+	services := []kcm.Service{{Name: "ingress-nginx", Template: "ingress-nginx-4-11-0", Values: "cluster: {{ .Cluster.Name }}"}}
+
+	rendered, err := RenderServices(services, newContext())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rendered[0].Values != "cluster: dev" {
+		t.Errorf("unexpected rendered values %q", rendered[0].Values)
+	}
+	if services[0].Values != "cluster: {{ .Cluster.Name }}" {
+		t.Errorf("expected the services not to be modified, got %q", services[0].Values)
+	}
+}
@@ -37,7 +37,7 @@ type AccessManagementValidator struct {
 }
 
 func (v *AccessManagementValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	v.Client = mgr.GetClient()
+	v.Client = sideEffectFree(mgr.GetClient())
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&v1alpha1.AccessManagement{}).
 		WithValidator(v).
@@ -0,0 +1,120 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/K0rdent/kcm/internal/metrics"
+)
+
+// DefaultValidationBudget is the time the validation of an admission request may take
+// before its advisory checks are skipped, well under the 10s timeout of the webhooks.
+const DefaultValidationBudget = 5 * time.Second
+
+const resultSkipped = "skipped"
+
+// validationCheck is a named step of the validation of an admission request.
+type validationCheck struct {
+	validate func(ctx context.Context) (admission.Warnings, error)
+	name     string
+	// advisory checks only produce warnings, hence they are skipped once the budget is exhausted
+	// instead of failing the request on the admission timeout.
+	advisory bool
+}
+
+// rejectIf returns the check rejecting the object as invalid with the given message on the error of the validation.
+func rejectIf(name, invalidMsg string, validate func(ctx context.Context) error) validationCheck {
+	return validationCheck{
+		name: name,
+		validate: func(ctx context.Context) (admission.Warnings, error) {
+			if err := validate(ctx); err != nil {
+				return nil, fmt.Errorf("%s: %w", invalidMsg, err)
+			}
+			return nil, nil
+		},
+	}
+}
+
+// warnIf returns the advisory check producing the warnings.
+func warnIf(name string, warn func(ctx context.Context) admission.Warnings) validationCheck {
+	return validationCheck{
+		name:     name,
+		advisory: true,
+		validate: func(ctx context.Context) (admission.Warnings, error) {
+			return warn(ctx), nil
+		},
+	}
+}
+
+// runChecks runs the given checks in order until one of them fails, recording the duration of
+// each check and logging the slow and the failed ones. The advisory checks are limited to the
+// given budget of the validation and skipped with a warning once the budget is exhausted.
+func runChecks(ctx context.Context, webhook string, budget time.Duration, checks []validationCheck) (admission.Warnings, error) {
+	l := ctrl.LoggerFrom(ctx).WithValues("webhook", webhook)
+
+	start := time.Now()
+	advisoryCtx, cancel := context.WithDeadline(ctx, start.Add(budget))
+	defer cancel()
+
+	var (
+		warnings admission.Warnings
+		skipped  []string
+	)
+	for _, check := range checks {
+		checkCtx := ctx
+		if check.advisory {
+			if advisoryCtx.Err() != nil {
+				skipped = append(skipped, check.name)
+				metrics.ObserveMetricWebhookCheckDuration(ctx, webhook, check.name, resultSkipped, 0)
+				continue
+			}
+			checkCtx = advisoryCtx
+		}
+
+		checkStart := time.Now()
+		w, err := check.validate(checkCtx)
+		duration := time.Since(checkStart)
+		warnings = append(warnings, w...)
+
+		result := resultSuccess
+		if err != nil {
+			result = resultError
+		}
+		metrics.ObserveMetricWebhookCheckDuration(ctx, webhook, check.name, result, duration)
+
+		cl := l.WithValues("check", check.name, "duration", duration)
+		if duration >= slowThreshold {
+			cl.Info("slow webhook check")
+		}
+		if err != nil {
+			cl.V(1).Info("webhook check failed", "error", err.Error())
+			return warnings, err
+		}
+	}
+
+	if len(skipped) > 0 {
+		l.Info("validation time budget exhausted, skipped the advisory checks", "budget", budget, "duration", time.Since(start), "skipped", skipped)
+		warnings = append(warnings, fmt.Sprintf("the validation exceeded its time budget of %s, the checks %s were skipped", budget, strings.Join(skipped, ", ")))
+	}
+
+	return warnings, nil
+}
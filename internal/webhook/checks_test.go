@@ -0,0 +1,113 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/objects/clusterdeployment"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestRunChecks(t *testing.T) {
+	var ran []string
+	check := func(name string, err error) validationCheck {
+		return rejectIf(name, "invalid", func(context.Context) error {
+			ran = append(ran, name)
+			return err
+		})
+	}
+	warn := func(name string) validationCheck {
+		return warnIf(name, func(ctx context.Context) admission.Warnings {
+			ran = append(ran, name)
+			if _, ok := ctx.Deadline(); !ok {
+				return admission.Warnings{"no deadline"}
+			}
+			return admission.Warnings{name}
+		})
+	}
+
+	tests := []struct {
+		name             string
+		checks           []validationCheck
+		budget           time.Duration
+		expectedRan      []string
+		expectedWarnings admission.Warnings
+		expectedErr      string
+	}{
+		{
+			name:             "all checks pass",
+			checks:           []validationCheck{check("a", nil), warn("b"), check("c", nil)},
+			budget:           time.Minute,
+			expectedRan:      []string{"a", "b", "c"},
+			expectedWarnings: admission.Warnings{"b"},
+		},
+		{
+			name:        "stops on the first failure",
+			checks:      []validationCheck{check("a", nil), check("b", errors.New("failed")), check("c", nil)},
+			budget:      time.Minute,
+			expectedRan: []string{"a", "b"},
+			expectedErr: "invalid: failed",
+		},
+		{
+			name:             "skips the advisory checks once the budget is exhausted",
+			checks:           []validationCheck{check("a", nil), warn("b"), warn("c")},
+			budget:           0,
+			expectedRan:      []string{"a"},
+			expectedWarnings: admission.Warnings{"the validation exceeded its time budget of 0s, the checks b, c were skipped"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ran = nil
+
+			warnings, err := runChecks(t.Context(), "test", tt.budget, tt.checks)
+			if tt.expectedErr != "" {
+				g.Expect(err).To(MatchError(tt.expectedErr))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+			g.Expect(ran).To(Equal(tt.expectedRan))
+			g.Expect(warnings).To(Equal(tt.expectedWarnings))
+		})
+	}
+}
+
+func TestInstrumentedClient(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+
+	cd := clusterdeployment.NewClusterDeployment()
+	cl := instrumented(fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cd).Build())
+
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(cd), &v1alpha1.ClusterDeployment{})).To(Succeed())
+	g.Expect(cl.List(ctx, &v1alpha1.ClusterDeploymentList{}, client.InNamespace(cd.Namespace))).To(Succeed())
+
+	err := cl.Get(ctx, client.ObjectKey{Namespace: cd.Namespace, Name: "missing"}, &v1alpha1.ClusterDeployment{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
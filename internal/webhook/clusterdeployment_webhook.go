@@ -16,37 +16,82 @@ package webhook
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"net/netip"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/robfig/cron/v3"
+	"helm.sh/helm/v3/pkg/chartutil"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/cloudtags"
+	"github.com/K0rdent/kcm/internal/configpolicy"
+	"github.com/K0rdent/kcm/internal/configvalidation"
+	"github.com/K0rdent/kcm/internal/cost"
+	"github.com/K0rdent/kcm/internal/cpmigration"
+	"github.com/K0rdent/kcm/internal/failuredomains"
+	"github.com/K0rdent/kcm/internal/kubernetessupport"
+	"github.com/K0rdent/kcm/internal/machineaccess"
+	"github.com/K0rdent/kcm/internal/networking"
+	"github.com/K0rdent/kcm/internal/osimage"
 	providersloader "github.com/K0rdent/kcm/internal/providers"
+	"github.com/K0rdent/kcm/internal/proxy"
+	"github.com/K0rdent/kcm/internal/rollback"
+	"github.com/K0rdent/kcm/internal/statemanagement"
+	"github.com/K0rdent/kcm/internal/utils/clusterclass"
+	"github.com/K0rdent/kcm/internal/utils/cni"
+	"github.com/K0rdent/kcm/internal/utils/gpu"
+	"github.com/K0rdent/kcm/internal/utils/oidc"
 	"github.com/K0rdent/kcm/internal/utils/validation"
+	"github.com/K0rdent/kcm/internal/utils/vip"
+	"github.com/K0rdent/kcm/internal/utils/windows"
+	"github.com/K0rdent/kcm/internal/valuestemplate"
 )
 
 type ClusterDeploymentValidator struct {
 	client.Client
 
+	SystemNamespace string
+	// PriceSource provides the price lists the cost of the clusters is estimated with.
+	// Defaults to the price lists from the ConfigMap in the system namespace.
+	PriceSource cost.PriceSource
+
 	ValidateClusterUpgradePath bool
+	// ValidationBudget is the time the validation of a request may take before the advisory checks
+	// are skipped. Defaults to [DefaultValidationBudget].
+	ValidationBudget time.Duration
 }
 
-const invalidClusterDeploymentMsg = "the ClusterDeployment is invalid"
+const (
+	invalidClusterDeploymentMsg  = "the ClusterDeployment is invalid"
+	clusterDeploymentWebhookName = "ClusterDeployment"
+)
 
 var errClusterUpgradeForbidden = errors.New("cluster upgrade is forbidden")
 
 func (v *ClusterDeploymentValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	v.Client = mgr.GetClient()
+	v.Client = sideEffectFree(cacheBacked(mgr.GetClient(), mgr.GetAPIReader()))
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&kcmv1.ClusterDeployment{}).
 		WithValidator(v).
@@ -66,80 +111,376 @@ func (v *ClusterDeploymentValidator) ValidateCreate(ctx context.Context, obj run
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected clusterDeployment but got a %T", obj))
 	}
 
-	template, err := v.getClusterDeploymentTemplate(ctx, clusterDeployment.Namespace, clusterDeployment.Spec.Template)
+	template, err := v.getClusterDeploymentTemplate(ctx, clusterDeployment.TemplateNamespace(), clusterDeployment.Spec.Template)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
 	}
 
-	if err := isTemplateValid(template.GetCommonStatus()); err != nil {
-		return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
+	reject := func(name string, validate func(ctx context.Context) error) validationCheck {
+		return rejectIf(name, invalidClusterDeploymentMsg, validate)
 	}
 
-	if err := validateK8sCompatibility(ctx, v.Client, template, clusterDeployment); err != nil {
-		return admission.Warnings{"Failed to validate k8s version compatibility with ServiceTemplates"}, fmt.Errorf("failed to validate k8s compatibility: %w", err)
+	return runChecks(ctx, clusterDeploymentWebhookName, v.validationBudget(), []validationCheck{
+		reject("template", func(context.Context) error { return isTemplateValid(template.GetCommonStatus()) }),
+		{name: "k8s-compatibility", validate: func(ctx context.Context) (admission.Warnings, error) {
+			return v.k8sCompatibility(ctx, template, clusterDeployment)
+		}},
+		reject("kubernetes-skew", func(ctx context.Context) error { return v.validateKubernetesSkew(ctx, template) }),
+		reject("credential", func(ctx context.Context) error { return v.validateCredential(ctx, clusterDeployment, template) }),
+		reject("access-rules", func(ctx context.Context) error { return v.validateAccessRules(ctx, clusterDeployment, true, true) }),
+		reject("template-grants", func(ctx context.Context) error { return v.validateTemplateGrants(ctx, clusterDeployment) }),
+		reject("cross-namespace-services", func(ctx context.Context) error {
+			return validation.ClusterDeployCrossNamespaceServicesRefs(ctx, clusterDeployment)
+		}),
+		reject("service-templates", func(ctx context.Context) error {
+			return validation.ServiceSpecHasValidTemplates(ctx, v.Client, clusterDeployment.Spec.ServiceSpec, clusterDeployment.Namespace)
+		}),
+		reject("service-spec", func(ctx context.Context) error { return v.validateServiceSpec(ctx, nil, clusterDeployment) }),
+		reject("render-values", func(context.Context) error { return validateRenderValues(clusterDeployment) }),
+		reject("authentication", func(context.Context) error { return validateAuthentication(clusterDeployment, template) }),
+		reject("windows-workers", func(context.Context) error { return validateWindowsWorkers(clusterDeployment, template) }),
+		reject("failure-domains", func(context.Context) error { return validateFailureDomains(clusterDeployment, template) }),
+		reject("networks", func(context.Context) error { return validateNetworks(clusterDeployment, template) }),
+		reject("cni", func(ctx context.Context) error { return v.validateCNI(ctx, clusterDeployment, template) }),
+		reject("proxy", func(context.Context) error { return validateProxy(clusterDeployment, template) }),
+		reject("machine-access", func(context.Context) error { return validateMachineAccess(clusterDeployment, template) }),
+		reject("cloud-tags", func(ctx context.Context) error { return v.validateCloudTags(ctx, clusterDeployment, template) }),
+		reject("os-images", func(ctx context.Context) error { return v.validateOSImages(ctx, clusterDeployment, template) }),
+		reject("gpu", func(ctx context.Context) error { return v.validateGPU(ctx, clusterDeployment, template) }),
+		reject("topology-variables", func(ctx context.Context) error { return v.validateTopologyVariables(ctx, clusterDeployment, template) }),
+		reject("control-plane-vip", func(ctx context.Context) error { return v.validateControlPlaneVIP(ctx, clusterDeployment) }),
+		reject("cluster-quotas", func(ctx context.Context) error { return v.validateClusterQuotas(ctx, nil, clusterDeployment, template) }),
+		reject("provider-config", func(ctx context.Context) error {
+			return v.validateProviderConfig(ctx, nil, clusterDeployment, template)
+		}),
+		reject("config-policies", func(ctx context.Context) error {
+			return v.validateConfigPolicies(ctx, nil, clusterDeployment, template)
+		}),
+		reject("force-finalize", func(context.Context) error { return validateForceFinalize(clusterDeployment) }),
+		reject("expiration", func(context.Context) error { return validateExpiration(clusterDeployment) }),
+		reject("lifecycle-hooks", func(context.Context) error { return validateLifecycleHooks(clusterDeployment, template) }),
+		reject("expire-at", func(context.Context) error {
+			if expireAt := clusterDeployment.Spec.ExpireAt; expireAt != nil && time.Now().After(expireAt.Time) {
+				return fmt.Errorf("expireAt %s is in the past", expireAt.UTC().Format(time.RFC3339))
+			}
+			return nil
+		}),
+		warnIf("budget", func(ctx context.Context) admission.Warnings {
+			return v.budgetWarnings(ctx, clusterDeployment, template)
+		}),
+		warnIf("kubernetes-support", func(ctx context.Context) admission.Warnings { return v.kubernetesSupportWarnings(ctx, template) }),
+	})
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (v *ClusterDeploymentValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldClusterDeployment, ok := oldObj.(*kcmv1.ClusterDeployment)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected ClusterDeployment but got a %T", oldObj))
+	}
+	newClusterDeployment, ok := newObj.(*kcmv1.ClusterDeployment)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected ClusterDeployment but got a %T", newObj))
 	}
 
-	if err := v.validateCredential(ctx, clusterDeployment, template); err != nil {
+	if err := validateForceFinalize(newClusterDeployment); err != nil {
 		return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
 	}
+	// the referenced objects may be already gone while the deletion is in progress
+	if !newClusterDeployment.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
 
-	if err := validation.ClusterDeployCrossNamespaceServicesRefs(ctx, clusterDeployment); err != nil {
-		return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
+	reject := func(name string, validate func(ctx context.Context) error) validationCheck {
+		return rejectIf(name, invalidClusterDeploymentMsg, validate)
 	}
 
-	if err := validation.ServicesHaveValidTemplates(ctx, v.Client, clusterDeployment.Spec.ServiceSpec.Services, clusterDeployment.Namespace); err != nil {
-		return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
+	var checks []validationCheck
+	if rollback.Requested(newClusterDeployment) && !rollback.Requested(oldClusterDeployment) {
+		checks = append(checks, reject("rollback", func(ctx context.Context) error { return rollback.Validate(ctx, v.Client, newClusterDeployment) }))
+	}
+
+	if target, ok := cpmigration.Requested(newClusterDeployment); ok {
+		if oldTarget, requested := cpmigration.Requested(oldClusterDeployment); !requested || oldTarget != target {
+			checks = append(checks, reject("control-plane-migration", func(ctx context.Context) error {
+				_, _, err := cpmigration.Validate(ctx, v.Client, newClusterDeployment, target)
+				return err
+			}))
+		}
+	}
+
+	oldTemplate := oldClusterDeployment.Spec.Template
+	newTemplate := newClusterDeployment.Spec.Template
+	templateChanged := oldTemplate != newTemplate || oldClusterDeployment.TemplateNamespace() != newClusterDeployment.TemplateNamespace()
+
+	// the template is looked up by the check preceding the ones it is used by
+	var template *kcmv1.ClusterTemplate
+	checks = append(checks, validationCheck{
+		name: "template-lookup",
+		validate: func(ctx context.Context) (admission.Warnings, error) {
+			var err error
+			if template, err = v.getClusterDeploymentTemplate(ctx, newClusterDeployment.TemplateNamespace(), newTemplate); err != nil {
+				return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
+			}
+			// switching between the copy of the template and the same template referenced
+			// from another namespace is not an upgrade
+			if oldTemplate == newTemplate {
+				return nil, nil
+			}
+
+			// the rollback reverts to the template validated on the recording of the known-good revision,
+			// the control plane migration switches to the template validated on the start of the migration
+			rollingBack := rollback.IsRollback(oldClusterDeployment, newClusterDeployment)
+			migrating := cpmigration.IsMigration(oldClusterDeployment, newClusterDeployment)
+			if cpmigration.InProgress(oldClusterDeployment) && !migrating {
+				return nil, fmt.Errorf("%s: the template cannot be changed while the control plane migration is in progress", invalidClusterDeploymentMsg)
+			}
+			if v.ValidateClusterUpgradePath && !rollingBack && !migrating && !slices.Contains(oldClusterDeployment.Status.AvailableUpgrades, newTemplate) {
+				msg := fmt.Sprintf("Cluster can't be upgraded from %s to %s. This upgrade sequence is not allowed", oldTemplate, newTemplate)
+				return admission.Warnings{msg}, errClusterUpgradeForbidden
+			}
+			return nil, nil
+		},
+	})
+
+	if templateChanged {
+		checks = append(checks,
+			reject("template", func(context.Context) error { return isTemplateValid(template.GetCommonStatus()) }),
+			validationCheck{name: "k8s-compatibility", validate: func(ctx context.Context) (admission.Warnings, error) {
+				return v.k8sCompatibility(ctx, template, newClusterDeployment)
+			}},
+			reject("kubernetes-skew", func(ctx context.Context) error { return v.validateKubernetesSkew(ctx, template) }),
+			reject("template-grants", func(ctx context.Context) error { return v.validateTemplateGrants(ctx, newClusterDeployment) }),
+		)
+	}
+
+	checks = append(checks,
+		reject("credential", func(ctx context.Context) error { return v.validateCredential(ctx, newClusterDeployment, template) }),
+		reject("access-rules", func(ctx context.Context) error {
+			return v.validateAccessRules(ctx, newClusterDeployment,
+				templateChanged,
+				oldClusterDeployment.Spec.Credential != newClusterDeployment.Spec.Credential,
+			)
+		}),
+		reject("cross-namespace-services", func(ctx context.Context) error {
+			return validation.ClusterDeployCrossNamespaceServicesRefs(ctx, newClusterDeployment)
+		}),
+		reject("service-templates", func(ctx context.Context) error {
+			return validation.ServiceSpecHasValidTemplates(ctx, v.Client, newClusterDeployment.Spec.ServiceSpec, newClusterDeployment.Namespace)
+		}),
+		reject("service-spec", func(ctx context.Context) error {
+			return v.validateServiceSpec(ctx, oldClusterDeployment, newClusterDeployment)
+		}),
+		reject("render-values", func(context.Context) error { return validateRenderValues(newClusterDeployment) }),
+		reject("authentication", func(context.Context) error { return validateAuthentication(newClusterDeployment, template) }),
+		reject("windows-workers", func(context.Context) error { return validateWindowsWorkers(newClusterDeployment, template) }),
+		reject("failure-domains", func(context.Context) error { return validateFailureDomains(newClusterDeployment, template) }),
+		reject("networks", func(context.Context) error { return validateNetworks(newClusterDeployment, template) }),
+		reject("cni", func(ctx context.Context) error {
+			// the CNI of a running cluster cannot be replaced, including switching between the CNI of the template and the selected one
+			if selectedCNI(oldClusterDeployment) != selectedCNI(newClusterDeployment) {
+				return errors.New("the CNI of the cluster cannot be changed")
+			}
+			return v.validateCNI(ctx, newClusterDeployment, template)
+		}),
+		reject("proxy", func(context.Context) error { return validateProxy(newClusterDeployment, template) }),
+		reject("machine-access", func(context.Context) error { return validateMachineAccess(newClusterDeployment, template) }),
+		reject("cloud-tags", func(ctx context.Context) error { return v.validateCloudTags(ctx, newClusterDeployment, template) }),
+		reject("os-images", func(ctx context.Context) error { return v.validateOSImages(ctx, newClusterDeployment, template) }),
+		reject("gpu", func(ctx context.Context) error { return v.validateGPU(ctx, newClusterDeployment, template) }),
+		reject("topology-variables", func(ctx context.Context) error {
+			return v.validateTopologyVariables(ctx, newClusterDeployment, template)
+		}),
+		reject("control-plane-vip", func(ctx context.Context) error { return v.validateControlPlaneVIP(ctx, newClusterDeployment) }),
+		reject("cluster-quotas", func(ctx context.Context) error {
+			return v.validateClusterQuotas(ctx, oldClusterDeployment, newClusterDeployment, template)
+		}),
+		reject("provider-config", func(ctx context.Context) error {
+			return v.validateProviderConfig(ctx, oldClusterDeployment, newClusterDeployment, template)
+		}),
+		reject("config-policies", func(ctx context.Context) error {
+			return v.validateConfigPolicies(ctx, oldClusterDeployment, newClusterDeployment, template)
+		}),
+		reject("expiration", func(context.Context) error { return validateExpiration(newClusterDeployment) }),
+		reject("lifecycle-hooks", func(context.Context) error { return validateLifecycleHooks(newClusterDeployment, template) }),
+		warnIf("budget", func(ctx context.Context) admission.Warnings {
+			return v.budgetWarnings(ctx, newClusterDeployment, template)
+		}),
+	)
+
+	return runChecks(ctx, clusterDeploymentWebhookName, v.validationBudget(), checks)
+}
+
+func (v *ClusterDeploymentValidator) validationBudget() time.Duration {
+	if v.ValidationBudget > 0 {
+		return v.ValidationBudget
 	}
+	return DefaultValidationBudget
+}
 
+// k8sCompatibility validates the compatibility of the Kubernetes version of the given template
+// with the ServiceTemplates of the given ClusterDeployment.
+func (v *ClusterDeploymentValidator) k8sCompatibility(ctx context.Context, template *kcmv1.ClusterTemplate, cd *kcmv1.ClusterDeployment) (admission.Warnings, error) {
+	if err := validateK8sCompatibility(ctx, v.Client, template, cd); err != nil {
+		return admission.Warnings{"Failed to validate k8s version compatibility with ServiceTemplates"}, fmt.Errorf("failed to validate k8s compatibility: %w", err)
+	}
 	return nil, nil
 }
 
-// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (v *ClusterDeploymentValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
-	oldClusterDeployment, ok := oldObj.(*kcmv1.ClusterDeployment)
+// validateForceFinalize validates the [kcmv1.ForceFinalizeAnnotation] annotation
+// confirming the forced finalization of the deletion with the UID of the ClusterDeployment.
+func validateForceFinalize(cd *kcmv1.ClusterDeployment) error {
+	value, ok := cd.Annotations[kcmv1.ForceFinalizeAnnotation]
 	if !ok {
-		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected ClusterDeployment but got a %T", oldObj))
+		return nil
 	}
-	newClusterDeployment, ok := newObj.(*kcmv1.ClusterDeployment)
+
+	if cd.DeletionTimestamp.IsZero() {
+		return fmt.Errorf("the %s annotation can be set only on a ClusterDeployment being deleted", kcmv1.ForceFinalizeAnnotation)
+	}
+	if value != string(cd.UID) {
+		return fmt.Errorf("invalid value %q of the %s annotation, expected the UID %q of the ClusterDeployment to confirm the forced finalization", value, kcmv1.ForceFinalizeAnnotation, cd.UID)
+	}
+
+	return nil
+}
+
+// validateExpiration validates the extension of the expiration set with
+// the [kcmv1.ExpirationExtensionAnnotation] annotation against the expiration policy.
+func validateExpiration(cd *kcmv1.ClusterDeployment) error {
+	value, ok := cd.Annotations[kcmv1.ExpirationExtensionAnnotation]
 	if !ok {
-		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected ClusterDeployment but got a %T", newObj))
+		return nil
 	}
-	oldTemplate := oldClusterDeployment.Spec.Template
-	newTemplate := newClusterDeployment.Spec.Template
 
-	template, err := v.getClusterDeploymentTemplate(ctx, newClusterDeployment.Namespace, newTemplate)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
+	extension, err := time.ParseDuration(value)
+	if err != nil || extension < 0 {
+		return fmt.Errorf("invalid value %q of the %s annotation, expected a non-negative duration", value, kcmv1.ExpirationExtensionAnnotation)
+	}
+
+	if policy := cd.Spec.ExpirationPolicy; policy != nil && policy.MaxExtension != nil && extension > policy.MaxExtension.Duration {
+		return fmt.Errorf("expiration extension %s exceeds the maximum extension %s", extension, policy.MaxExtension.Duration)
+	}
+
+	return nil
+}
+
+// validateLifecycleHooks validates the schedules of the maintenance windows of the lifecycle hooks.
+// The hooks are called by Cluster API only for the clusters with a managed topology, thus they are
+// rejected unless the template is ClusterClass-based.
+func validateLifecycleHooks(cd *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) error {
+	if cd.Spec.LifecycleHooks == nil {
+		return nil
+	}
+	if template.Spec.ClusterClass == nil {
+		return fmt.Errorf("lifecycle hooks are supported only by the ClusterClass-based templates, ClusterTemplate %s/%s does not reference a ClusterClass", template.Namespace, template.Name)
 	}
 
-	if oldTemplate != newTemplate {
-		if v.ValidateClusterUpgradePath && !slices.Contains(oldClusterDeployment.Status.AvailableUpgrades, newTemplate) {
-			msg := fmt.Sprintf("Cluster can't be upgraded from %s to %s. This upgrade sequence is not allowed", oldTemplate, newTemplate)
-			return admission.Warnings{msg}, errClusterUpgradeForbidden
+	for _, w := range cd.Spec.LifecycleHooks.MaintenanceWindows {
+		if _, err := cron.ParseStandard(w.Schedule); err != nil {
+			return fmt.Errorf("invalid maintenance window schedule %q: %w", w.Schedule, err)
 		}
+		if w.Duration.Duration <= 0 {
+			return fmt.Errorf("maintenance window duration must be positive, got %s", w.Duration.Duration)
+		}
+	}
+
+	return nil
+}
+
+// budgetWarnings returns the warnings if the estimated monthly cost of the ClusterDeployment
+// exceeds the budget set with the [kcmv1.MonthlyBudgetAnnotation] annotation.
+func (v *ClusterDeploymentValidator) budgetWarnings(ctx context.Context, cd *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) admission.Warnings {
+	budgetValue, ok := cd.Annotations[kcmv1.MonthlyBudgetAnnotation]
+	if !ok {
+		return nil
+	}
+
+	budget, err := strconv.ParseFloat(budgetValue, 64)
+	if err != nil || budget < 0 {
+		return admission.Warnings{fmt.Sprintf("Invalid value %q of the %s annotation, expected a non-negative number", budgetValue, kcmv1.MonthlyBudgetAnnotation)}
+	}
+
+	source := v.PriceSource
+	if source == nil {
+		source = &cost.ConfigMapPriceSource{Client: v.Client, Namespace: v.SystemNamespace}
+	}
+
+	estimate, err := cost.EstimateCluster(ctx, source, template, cd.Spec.Config)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("Failed to estimate the cost of the cluster: %v", err)}
+	}
+	if estimate == nil {
+		return nil
+	}
 
-		if err := isTemplateValid(template.GetCommonStatus()); err != nil {
-			return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
+	var warnings admission.Warnings
+	if estimate.MonthlyCost > budget {
+		warnings = append(warnings, fmt.Sprintf("The estimated monthly cost %s of the cluster exceeds the monthly budget %s", estimate, budgetValue))
+	}
+	if len(estimate.UnpricedInstanceTypes) > 0 {
+		warnings = append(warnings, fmt.Sprintf("The instance types %s are missing in the price list and not accounted in the estimated cost", strings.Join(estimate.UnpricedInstanceTypes, ", ")))
+	}
+
+	return warnings
+}
+
+// getRelease returns the Release of the Management or nil if the Management or the Release does not exist.
+func (v *ClusterDeploymentValidator) getRelease(ctx context.Context) (*kcmv1.Release, error) {
+	mgmt := new(kcmv1.Management)
+	if err := v.Get(ctx, client.ObjectKey{Name: kcmv1.ManagementName}, mgmt); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
 		}
+		return nil, fmt.Errorf("failed to get Management: %w", err)
+	}
 
-		if err := validateK8sCompatibility(ctx, v.Client, template, newClusterDeployment); err != nil {
-			return admission.Warnings{"Failed to validate k8s version compatibility with ServiceTemplates"}, fmt.Errorf("failed to validate k8s compatibility: %w", err)
+	release := new(kcmv1.Release)
+	if err := v.Get(ctx, client.ObjectKey{Name: mgmt.Spec.Release}, release); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
 		}
+		return nil, fmt.Errorf("failed to get Release %s: %w", mgmt.Spec.Release, err)
 	}
 
-	if err := v.validateCredential(ctx, newClusterDeployment, template); err != nil {
-		return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
+	return release, nil
+}
+
+// validateKubernetesSkew validates the Kubernetes version of the given ClusterTemplate
+// against the version skew supported by the management components of the Release.
+func (v *ClusterDeploymentValidator) validateKubernetesSkew(ctx context.Context, template *kcmv1.ClusterTemplate) error {
+	release, err := v.getRelease(ctx)
+	if err != nil {
+		return err
 	}
 
-	if err := validation.ClusterDeployCrossNamespaceServicesRefs(ctx, newClusterDeployment); err != nil {
-		return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
+	return kubernetessupport.CheckSkew(release, template.Status.KubernetesVersion)
+}
+
+// kubernetesSupportWarnings returns the warnings if the Kubernetes version of the given ClusterTemplate
+// approaches or is past its end of life set in the Release of the Management.
+func (v *ClusterDeploymentValidator) kubernetesSupportWarnings(ctx context.Context, template *kcmv1.ClusterTemplate) admission.Warnings {
+	if template.Status.KubernetesVersion == "" {
+		return nil
 	}
 
-	if err := validation.ServicesHaveValidTemplates(ctx, v.Client, newClusterDeployment.Spec.ServiceSpec.Services, newClusterDeployment.Namespace); err != nil {
-		return nil, fmt.Errorf("%s: %w", invalidClusterDeploymentMsg, err)
+	release, err := v.getRelease(ctx)
+	if err != nil || release == nil {
+		return nil
 	}
 
-	return nil, nil
+	endOfLife, err := kubernetessupport.EndOfLife(release, template.Status.KubernetesVersion)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("Failed to check the end of life of the Kubernetes version: %v", err)}
+	}
+
+	description := kubernetessupport.Describe(endOfLife, time.Now())
+	if description == "" {
+		return nil
+	}
+
+	return admission.Warnings{fmt.Sprintf("Kubernetes version %s of the ClusterTemplate %s %s, consider a ClusterTemplate with a supported version",
+		template.Status.KubernetesVersion, template.Name, description)}
 }
 
 func validateK8sCompatibility(ctx context.Context, cl client.Client, template *kcmv1.ClusterTemplate, mc *kcmv1.ClusterDeployment) error {
@@ -194,31 +535,181 @@ func (v *ClusterDeploymentValidator) Default(ctx context.Context, obj runtime.Ob
 		return apierrors.NewBadRequest(fmt.Sprintf("expected clusterDeployment but got a %T", obj))
 	}
 
-	// Only apply defaults when there's no configuration provided;
+	if clusterDeployment.Spec.CloneFrom != "" {
+		req, err := admission.RequestFromContext(ctx)
+		if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+		if req.Operation == admissionv1.Create {
+			if err := v.cloneClusterDeployment(ctx, clusterDeployment); err != nil {
+				return err
+			}
+		}
+	}
+
+	if clusterDeployment.Spec.TemplateRef != nil {
+		clusterDeployment.Spec.Template = clusterDeployment.Spec.TemplateRef.Name
+	}
+
 	// if template ref is empty, then nothing to default
-	if clusterDeployment.Spec.Config != nil || clusterDeployment.Spec.Template == "" {
+	if clusterDeployment.Spec.Template == "" {
 		return nil
 	}
 
-	template, err := v.getClusterDeploymentTemplate(ctx, clusterDeployment.Namespace, clusterDeployment.Spec.Template)
-	if err != nil {
-		return fmt.Errorf("could not get template for the clusterDeployment: %w", err)
+	// Defaulting on update would apply the overlays of the ConfigPolicies changed since
+	// the creation to the existing cluster, e.g. whenever a GitOps tool applies a manifest
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation == admissionv1.Update {
+		return nil
+	}
+
+	// The chart defaults of the template are not stored in the configuration, they are applied
+	// by Helm on install and upgrade, so the defaults of the later template versions take effect
+	// and a value explicitly set to null removes the default.
+	return v.applyConfigOverlays(ctx, clusterDeployment)
+}
+
+// applyConfigOverlays merges the overlays of the ConfigPolicies applied to the given ClusterDeployment
+// into its configuration and lists the applied overlays in the ConfigOverlaysAnnotation annotation.
+// The values set in the configuration take precedence over the overlays.
+func (v *ClusterDeploymentValidator) applyConfigOverlays(ctx context.Context, clusterDeployment *kcmv1.ClusterDeployment) error {
+	policies := &kcmv1.ConfigPolicyList{}
+	if err := v.List(ctx, policies); err != nil {
+		return fmt.Errorf("failed to list ConfigPolicies: %w", err)
+	}
+	if !slices.ContainsFunc(policies.Items, func(p kcmv1.ConfigPolicy) bool { return len(p.Spec.Overlays) > 0 }) {
+		return nil
 	}
 
-	if err := isTemplateValid(template.GetCommonStatus()); err != nil {
-		return fmt.Errorf("template is invalid: %w", err)
+	namespace := &corev1.Namespace{}
+	if err := v.Get(ctx, client.ObjectKey{Name: clusterDeployment.Namespace}, namespace); err != nil {
+		return fmt.Errorf("failed to get Namespace %s: %w", clusterDeployment.Namespace, err)
 	}
 
-	if template.Status.Config == nil {
+	values, applied, err := configpolicy.Overlays(policies.Items, namespace.Labels, clusterDeployment.Labels)
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
 		return nil
 	}
 
-	clusterDeployment.Spec.DryRun = true
-	clusterDeployment.Spec.Config = &apiextensionsv1.JSON{Raw: template.Status.Config.Raw}
+	raw, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the values of the overlays: %w", err)
+	}
+	config, err := mergeStoredConfig(clusterDeployment.Spec.Config, &apiextensionsv1.JSON{Raw: raw})
+	if err != nil {
+		return fmt.Errorf("failed to merge the config with the overlays of the ConfigPolicies: %w", err)
+	}
+	clusterDeployment.Spec.Config = config
+
+	if clusterDeployment.Annotations == nil {
+		clusterDeployment.Annotations = make(map[string]string)
+	}
+	clusterDeployment.Annotations[kcmv1.ConfigOverlaysAnnotation] = strings.Join(applied, ",")
+
+	return nil
+}
+
+// cloneClusterDeployment copies the template, credential, configuration and services
+// of the ClusterDeployment referenced in the CloneFrom field to the given ClusterDeployment.
+// Values already set in the given ClusterDeployment are preserved.
+func (v *ClusterDeploymentValidator) cloneClusterDeployment(ctx context.Context, clusterDeployment *kcmv1.ClusterDeployment) error {
+	source := new(kcmv1.ClusterDeployment)
+	if err := v.Get(ctx, client.ObjectKey{Namespace: clusterDeployment.Namespace, Name: clusterDeployment.Spec.CloneFrom}, source); err != nil {
+		return fmt.Errorf("failed to get ClusterDeployment %s/%s to clone from: %w", clusterDeployment.Namespace, clusterDeployment.Spec.CloneFrom, err)
+	}
+
+	if clusterDeployment.Spec.Template == "" && clusterDeployment.Spec.TemplateRef == nil {
+		clusterDeployment.Spec.Template = source.Spec.Template
+		clusterDeployment.Spec.TemplateRef = source.Spec.TemplateRef.DeepCopy()
+	}
+	if clusterDeployment.Spec.Credential == "" {
+		clusterDeployment.Spec.Credential = source.Spec.Credential
+	}
+
+	if source.Spec.Config != nil {
+		config, err := mergeStoredConfig(clusterDeployment.Spec.Config, source.Spec.Config)
+		if err != nil {
+			return fmt.Errorf("failed to merge config with the ClusterDeployment %s/%s: %w", source.Namespace, source.Name, err)
+		}
+		clusterDeployment.Spec.Config = config
+	}
+
+	if len(clusterDeployment.Spec.ServiceSpec.Services) == 0 && len(clusterDeployment.Spec.ServiceSpec.TemplateResourceRefs) == 0 {
+		clusterDeployment.Spec.ServiceSpec = *source.Spec.ServiceSpec.DeepCopy()
+	}
 
 	return nil
 }
 
+// mergeConfig deep merges the given configuration with the given base values,
+// the values set in the configuration take precedence. The values set to null
+// in the configuration remove the base values as Helm does, so the result is the
+// effective configuration and must not be stored in the ClusterDeployment.
+func mergeConfig(config, base *apiextensionsv1.JSON) (*apiextensionsv1.JSON, error) {
+	baseValues := make(map[string]any)
+	if len(base.Raw) > 0 {
+		if err := json.Unmarshal(base.Raw, &baseValues); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal base values: %w", err)
+		}
+	}
+
+	values := make(map[string]any)
+	if config != nil && len(config.Raw) > 0 {
+		if err := json.Unmarshal(config.Raw, &values); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		}
+	}
+
+	raw, err := json.Marshal(chartutil.CoalesceTables(values, baseValues))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	return &apiextensionsv1.JSON{Raw: raw}, nil
+}
+
+// mergeStoredConfig deep merges the given configuration with the given base values to be stored
+// in the ClusterDeployment, the values set in the configuration take precedence. Unlike [mergeConfig],
+// the values explicitly set to null are kept, so they still remove the chart defaults on install.
+func mergeStoredConfig(config, base *apiextensionsv1.JSON) (*apiextensionsv1.JSON, error) {
+	baseValues := make(map[string]any)
+	if len(base.Raw) > 0 {
+		if err := json.Unmarshal(base.Raw, &baseValues); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal base values: %w", err)
+		}
+	}
+
+	values := make(map[string]any)
+	if config != nil && len(config.Raw) > 0 {
+		if err := json.Unmarshal(config.Raw, &values); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+		}
+	}
+
+	raw, err := json.Marshal(mergeValues(values, baseValues))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	return &apiextensionsv1.JSON{Raw: raw}, nil
+}
+
+// mergeValues deep merges the given values into the given base values keeping the nulls of the values.
+func mergeValues(values, base map[string]any) map[string]any {
+	for k, v := range values {
+		valuesTable, isTable := v.(map[string]any)
+		baseTable, isBaseTable := base[k].(map[string]any)
+		if isTable && isBaseTable {
+			base[k] = mergeValues(valuesTable, baseTable)
+			continue
+		}
+		base[k] = v
+	}
+	return base
+}
+
 func (v *ClusterDeploymentValidator) getClusterDeploymentTemplate(ctx context.Context, templateNamespace, templateName string) (tpl *kcmv1.ClusterTemplate, err error) {
 	tpl = new(kcmv1.ClusterTemplate)
 	return tpl, v.Get(ctx, client.ObjectKey{Namespace: templateNamespace, Name: templateName}, tpl)
@@ -273,6 +764,785 @@ func (v *ClusterDeploymentValidator) validateCredential(ctx context.Context, clu
 	return isCredMatchTemplate(cred, template)
 }
 
+func validateAuthentication(clusterDeployment *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) error {
+	if clusterDeployment.Spec.Authentication == nil || clusterDeployment.Spec.Authentication.OIDC == nil {
+		return nil
+	}
+
+	supported, err := oidc.TemplateSupported(template.Status.Config)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("the ClusterTemplate %s does not support OIDC authentication", template.Name)
+	}
+
+	return nil
+}
+
+func validateWindowsWorkers(clusterDeployment *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) error {
+	windowsWorkers, err := windows.WorkersNumber(clusterDeployment.Spec.Config)
+	if err != nil {
+		return err
+	}
+	if windowsWorkers == 0 {
+		return nil
+	}
+
+	supported, err := windows.TemplateSupported(template)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("the ClusterTemplate %s does not support Windows worker nodes", template.Name)
+	}
+
+	return nil
+}
+
+// validateFailureDomains validates the failure domains are supported by the ClusterTemplate
+// and the control plane of multiple machines is spread across multiple failure domains.
+func validateFailureDomains(clusterDeployment *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) error {
+	fd := clusterDeployment.Spec.FailureDomains
+	if fd == nil {
+		return nil
+	}
+
+	supported, err := failuredomains.TemplateSupported(template.Status.Config)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("the ClusterTemplate %s does not support failure domains", template.Name)
+	}
+
+	if len(fd.ControlPlane) != 1 {
+		return nil
+	}
+
+	config, err := mergeConfig(clusterDeployment.Spec.Config, template.Status.Config)
+	if err != nil {
+		return err
+	}
+	values := struct {
+		ControlPlaneNumber int64 `json:"controlPlaneNumber"`
+	}{}
+	if err := json.Unmarshal(config.Raw, &values); err != nil {
+		return fmt.Errorf("failed to parse ClusterDeployment configuration: %w", err)
+	}
+	if values.ControlPlaneNumber > 1 {
+		return fmt.Errorf("the control plane of %d machines must be spread across at least 2 failure domains, got %s",
+			values.ControlPlaneNumber, fd.ControlPlane[0])
+	}
+
+	return nil
+}
+
+// validateNetworks validates the additional networks are supported by the ClusterTemplate
+// and the subnets of the networks do not overlap.
+func validateNetworks(clusterDeployment *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) error {
+	networks := clusterDeployment.Spec.Networks
+	if networks == nil {
+		return nil
+	}
+
+	supported, err := networking.TemplateSupported(template.Status.Config)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("the ClusterTemplate %s does not support additional networks", template.Name)
+	}
+
+	config, err := mergeConfig(clusterDeployment.Spec.Config, template.Status.Config)
+	if err != nil {
+		return err
+	}
+
+	return networking.Validate(networks, config)
+}
+
+// validateOSImages validates the references to the OSImage catalogs in the configuration
+// of the ClusterDeployment and the defaults of the ClusterTemplate resolve to images.
+func (v *ClusterDeploymentValidator) validateOSImages(ctx context.Context, clusterDeployment *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) error {
+	values, err := clusterDeployment.HelmValues()
+	if err != nil {
+		return err
+	}
+	if values == nil {
+		values = make(map[string]any)
+	}
+
+	_, err = osimage.ResolveValues(ctx, v.Client, template, values, clusterDeployment.Status.OSImages, nil)
+	return err
+}
+
+// validateCNI validates the selected CNI is supported by the ClusterTemplate
+// and the ServiceTemplate installing the CNI is valid.
+func (v *ClusterDeploymentValidator) validateCNI(ctx context.Context, clusterDeployment *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) error {
+	network := clusterDeployment.Spec.Network
+	if network == nil {
+		return nil
+	}
+
+	if err := cni.Validate(network, template); err != nil {
+		return err
+	}
+
+	return validation.ServicesHaveValidTemplates(ctx, v.Client, cni.Services(network), clusterDeployment.Namespace)
+}
+
+// validateProxy validates the proxy is supported by the ClusterTemplate and the proxy URLs are valid.
+func validateProxy(clusterDeployment *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) error {
+	if clusterDeployment.Spec.Proxy == nil {
+		return nil
+	}
+
+	supported, err := proxy.TemplateSupported(template.Status.Config)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("the ClusterTemplate %s does not support the proxy", template.Name)
+	}
+
+	return proxy.Validate(clusterDeployment.Spec.Proxy)
+}
+
+// validateMachineAccess validates the SSH access to the machines and the bastion host are supported
+// by the ClusterTemplate and the public SSH keys and the bastion CIDRs are valid.
+func validateMachineAccess(clusterDeployment *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) error {
+	if clusterDeployment.Spec.MachineAccess == nil {
+		return nil
+	}
+
+	if err := machineaccess.Validate(clusterDeployment.Spec.MachineAccess, template.Status.Config); err != nil {
+		return fmt.Errorf("invalid machine access for the ClusterTemplate %s: %w", template.Name, err)
+	}
+
+	return nil
+}
+
+// validateCloudTags validates the cloud tags are supported by the ClusterTemplate
+// and set all the mandatory tags of the cloud tag policy of the Management.
+func (v *ClusterDeploymentValidator) validateCloudTags(ctx context.Context, clusterDeployment *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) error {
+	supported, err := cloudtags.TemplateSupported(template.Status.Config)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		if len(clusterDeployment.Spec.CloudTags) > 0 {
+			return fmt.Errorf("the ClusterTemplate %s does not support the cloud tags", template.Name)
+		}
+		// the policy applies only to the clusters the cloud resources of which can be tagged
+		return nil
+	}
+
+	mgmt, err := getManagement(ctx, v.Client)
+	if err != nil {
+		if errors.Is(err, errManagementIsNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get Management: %w", err)
+	}
+
+	return cloudtags.Validate(clusterDeployment.Spec.CloudTags, mgmt.Spec.CloudTagPolicy)
+}
+
+// selectedCNI returns the CNI selected for the ClusterDeployment, empty if the CNI of the template is used.
+func selectedCNI(clusterDeployment *kcmv1.ClusterDeployment) string {
+	if clusterDeployment.Spec.Network == nil {
+		return ""
+	}
+	return clusterDeployment.Spec.Network.CNI
+}
+
+func (v *ClusterDeploymentValidator) validateGPU(ctx context.Context, clusterDeployment *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) error {
+	if clusterDeployment.Spec.GPU == nil {
+		return nil
+	}
+
+	if err := gpu.ValidateInstanceType(template.Status.Providers, clusterDeployment.Spec.Config); err != nil {
+		return err
+	}
+
+	return validation.ServicesHaveValidTemplates(ctx, v.Client, []kcmv1.Service{gpu.Service(clusterDeployment.Spec.GPU)}, clusterDeployment.Namespace)
+}
+
+// validateTopologyVariables validates the topology variables of the ClusterDeployment
+// against the ClusterClass referenced by the ClusterTemplate.
+func (v *ClusterDeploymentValidator) validateTopologyVariables(ctx context.Context, clusterDeployment *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) error {
+	if template.Spec.ClusterClass == nil {
+		return nil
+	}
+
+	cc, err := clusterclass.Get(ctx, v.Client, template, clusterDeployment.Namespace)
+	if err != nil {
+		return err
+	}
+
+	return clusterclass.ValidateVariables(cc, clusterDeployment.Spec.Config)
+}
+
+func (v *ClusterDeploymentValidator) validateControlPlaneVIP(ctx context.Context, clusterDeployment *kcmv1.ClusterDeployment) error {
+	cpVIP := clusterDeployment.Spec.ControlPlaneVIP
+	if cpVIP == nil {
+		return nil
+	}
+
+	var (
+		addr netip.Addr
+		err  error
+	)
+	if cpVIP.Address != "" {
+		if addr, err = netip.ParseAddr(cpVIP.Address); err != nil {
+			return fmt.Errorf("invalid control plane VIP: %w", err)
+		}
+
+		clusterDeployments := &kcmv1.ClusterDeploymentList{}
+		if err := v.List(ctx, clusterDeployments, client.MatchingFields{kcmv1.ClusterDeploymentControlPlaneVIPIndexKey: addr.String()}); err != nil {
+			return fmt.Errorf("failed to list ClusterDeployments: %w", err)
+		}
+
+		for _, cd := range clusterDeployments.Items {
+			if cd.Namespace != clusterDeployment.Namespace || cd.Name != clusterDeployment.Name {
+				return fmt.Errorf("control plane VIP %s is already in use by ClusterDeployment %s/%s", addr, cd.Namespace, cd.Name)
+			}
+		}
+	}
+
+	if cpVIP.Pool == "" {
+		return nil
+	}
+
+	pool := &kcmv1.VIPPool{}
+	if err := v.Get(ctx, client.ObjectKey{Name: cpVIP.Pool}, pool); err != nil {
+		return fmt.Errorf("failed to get VIPPool %s: %w", cpVIP.Pool, err)
+	}
+
+	if !addr.IsValid() {
+		return nil
+	}
+
+	ranges, err := vip.ParseRanges(pool.Spec.Addresses)
+	if err != nil {
+		return fmt.Errorf("failed to parse addresses of VIPPool %s: %w", cpVIP.Pool, err)
+	}
+	if !vip.Contains(ranges, addr) {
+		return fmt.Errorf("control plane VIP %s does not belong to VIPPool %s", addr, cpVIP.Pool)
+	}
+
+	return nil
+}
+
+// validateAccessRules ensures the ClusterTemplate and the Credential of the ClusterDeployment
+// are granted to its namespace by the AccessManagement rules if their enforcement is enabled.
+func (v *ClusterDeploymentValidator) validateAccessRules(ctx context.Context, clusterDeployment *kcmv1.ClusterDeployment, checkTemplate, checkCredential bool) error {
+	if (!checkTemplate && !checkCredential) || clusterDeployment.Namespace == v.SystemNamespace {
+		return nil
+	}
+
+	accessMgmt := &kcmv1.AccessManagement{}
+	if err := v.Get(ctx, client.ObjectKey{Name: kcmv1.AccessManagementName}, accessMgmt); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get AccessManagement %s: %w", kcmv1.AccessManagementName, err)
+	}
+	if !accessMgmt.Spec.EnforceAccessRules {
+		return nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := v.Get(ctx, client.ObjectKey{Name: clusterDeployment.Namespace}, namespace); err != nil {
+		return fmt.Errorf("failed to get Namespace %s: %w", clusterDeployment.Namespace, err)
+	}
+
+	var (
+		// the ClusterTemplates referenced from the other namespaces are granted with the ClusterTemplateGrants
+		templateGranted   = !checkTemplate || clusterDeployment.TemplateNamespace() != clusterDeployment.Namespace
+		credentialGranted = !checkCredential || clusterDeployment.Spec.Credential == ""
+	)
+	for _, rule := range accessMgmt.Spec.AccessRules {
+		matches, err := targetNamespacesMatch(rule.TargetNamespaces, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to match target namespaces of the access rule: %w", err)
+		}
+		if !matches {
+			continue
+		}
+
+		if !credentialGranted {
+			credentialGranted = slices.Contains(rule.Credentials, clusterDeployment.Spec.Credential)
+		}
+
+		for _, chainName := range rule.ClusterTemplateChains {
+			if templateGranted {
+				break
+			}
+
+			chain := &kcmv1.ClusterTemplateChain{}
+			if err := v.Get(ctx, client.ObjectKey{Namespace: v.SystemNamespace, Name: chainName}, chain); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return fmt.Errorf("failed to get ClusterTemplateChain %s/%s: %w", v.SystemNamespace, chainName, err)
+			}
+
+			templateGranted = slices.ContainsFunc(chain.Spec.SupportedTemplates, func(t kcmv1.SupportedTemplate) bool {
+				return t.Name == clusterDeployment.Spec.Template
+			})
+		}
+	}
+
+	if !templateGranted {
+		return fmt.Errorf("the ClusterTemplate %s is not granted to the namespace %s by the AccessManagement rules", clusterDeployment.Spec.Template, clusterDeployment.Namespace)
+	}
+	if !credentialGranted {
+		return fmt.Errorf("the Credential %s is not granted to the namespace %s by the AccessManagement rules", clusterDeployment.Spec.Credential, clusterDeployment.Namespace)
+	}
+
+	return nil
+}
+
+// targetNamespacesMatch returns true if the given namespace is selected by the TargetNamespaces.
+func targetNamespacesMatch(targetNamespaces kcmv1.TargetNamespaces, namespace *corev1.Namespace) (bool, error) {
+	if len(targetNamespaces.List) > 0 {
+		return slices.Contains(targetNamespaces.List, namespace.Name), nil
+	}
+
+	var (
+		selector labels.Selector
+		err      error
+	)
+	if targetNamespaces.StringSelector != "" {
+		selector, err = labels.Parse(targetNamespaces.StringSelector)
+	} else {
+		selector, err = metav1.LabelSelectorAsSelector(targetNamespaces.Selector)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// an empty selector selects all namespaces
+	return selector.Empty() || selector.Matches(labels.Set(namespace.Labels)), nil
+}
+
+// validateTemplateGrants ensures the ClusterTemplate referenced from another namespace with the TemplateRef
+// is granted to the namespace of the ClusterDeployment by the ClusterTemplateGrants of the AccessManagement rules.
+func (v *ClusterDeploymentValidator) validateTemplateGrants(ctx context.Context, clusterDeployment *kcmv1.ClusterDeployment) error {
+	templateNamespace := clusterDeployment.TemplateNamespace()
+	if templateNamespace == clusterDeployment.Namespace || clusterDeployment.Namespace == v.SystemNamespace {
+		return nil
+	}
+
+	notGranted := fmt.Errorf("the ClusterTemplate %s/%s is not granted to the namespace %s by the AccessManagement rules",
+		templateNamespace, clusterDeployment.Spec.Template, clusterDeployment.Namespace)
+
+	accessMgmt := &kcmv1.AccessManagement{}
+	if err := v.Get(ctx, client.ObjectKey{Name: kcmv1.AccessManagementName}, accessMgmt); err != nil {
+		if apierrors.IsNotFound(err) {
+			return notGranted
+		}
+		return fmt.Errorf("failed to get AccessManagement %s: %w", kcmv1.AccessManagementName, err)
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := v.Get(ctx, client.ObjectKey{Name: clusterDeployment.Namespace}, namespace); err != nil {
+		return fmt.Errorf("failed to get Namespace %s: %w", clusterDeployment.Namespace, err)
+	}
+
+	for _, rule := range accessMgmt.Spec.AccessRules {
+		matches, err := targetNamespacesMatch(rule.TargetNamespaces, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to match target namespaces of the access rule: %w", err)
+		}
+		if !matches {
+			continue
+		}
+
+		for _, grant := range rule.ClusterTemplateGrants {
+			if grant.Namespace == templateNamespace &&
+				(len(grant.ClusterTemplates) == 0 || slices.Contains(grant.ClusterTemplates, clusterDeployment.Spec.Template)) {
+				return nil
+			}
+		}
+	}
+
+	return notGranted
+}
+
+// validateClusterQuotas validates the ClusterDeployment against the ClusterQuotas selecting its namespace.
+// The nodes and the instance sizes are counted per node pool with the template defaults, the same way
+// the cost of the cluster is estimated, the ClusterDeployments being deleted are not counted.
+// On update, only the changes increasing the resources usage are validated,
+// so the existing ClusterDeployments exceeding a lowered quota can still be updated.
+func (v *ClusterDeploymentValidator) validateClusterQuotas(ctx context.Context, oldClusterDeployment, clusterDeployment *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) error {
+	quotas := &kcmv1.ClusterQuotaList{}
+	if err := v.List(ctx, quotas); err != nil {
+		return fmt.Errorf("failed to list ClusterQuotas: %w", err)
+	}
+	if len(quotas.Items) == 0 {
+		return nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := v.Get(ctx, client.ObjectKey{Name: clusterDeployment.Namespace}, namespace); err != nil {
+		return fmt.Errorf("failed to get Namespace %s: %w", clusterDeployment.Namespace, err)
+	}
+
+	var matched []kcmv1.ClusterQuota
+	for _, quota := range quotas.Items {
+		selector, err := metav1.LabelSelectorAsSelector(&quota.Spec.NamespaceSelector)
+		if err != nil {
+			return fmt.Errorf("failed to parse namespace selector of ClusterQuota %s: %w", quota.Name, err)
+		}
+		if selector.Matches(labels.Set(namespace.Labels)) {
+			matched = append(matched, quota)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	nodes, sizes, err := clusterResources(clusterDeployment, template)
+	if err != nil {
+		return err
+	}
+
+	var oldNodes int64
+	var oldSizes []string
+	if oldClusterDeployment != nil {
+		oldTemplate := template
+		if oldClusterDeployment.Spec.Template != clusterDeployment.Spec.Template {
+			if oldTemplate, err = v.getQuotaTemplate(ctx, oldClusterDeployment); err != nil {
+				return err
+			}
+		}
+		if oldNodes, oldSizes, err = clusterResources(oldClusterDeployment, oldTemplate); err != nil {
+			return err
+		}
+	}
+
+	clusterDeployments := &kcmv1.ClusterDeploymentList{}
+	if err := v.List(ctx, clusterDeployments, client.InNamespace(clusterDeployment.Namespace)); err != nil {
+		return fmt.Errorf("failed to list ClusterDeployments: %w", err)
+	}
+
+	var otherClusters, otherNodes int64
+	for _, cd := range clusterDeployments.Items {
+		if cd.Name == clusterDeployment.Name || !cd.DeletionTimestamp.IsZero() {
+			continue
+		}
+		otherClusters++
+
+		cdTemplate, err := v.getQuotaTemplate(ctx, &cd)
+		if err != nil {
+			return err
+		}
+		n, _, err := clusterResources(&cd, cdTemplate)
+		if err != nil {
+			return fmt.Errorf("failed to parse configuration of ClusterDeployment %s/%s: %w", cd.Namespace, cd.Name, err)
+		}
+		otherNodes += n
+	}
+
+	for _, quota := range matched {
+		if quota.Spec.MaxClusters != nil && oldClusterDeployment == nil && otherClusters+1 > int64(*quota.Spec.MaxClusters) {
+			return fmt.Errorf("ClusterQuota %s: number of ClusterDeployments in namespace %s would exceed the limit of %d",
+				quota.Name, clusterDeployment.Namespace, *quota.Spec.MaxClusters)
+		}
+
+		if quota.Spec.MaxNodes != nil && (oldClusterDeployment == nil || nodes > oldNodes) && otherNodes+nodes > int64(*quota.Spec.MaxNodes) {
+			return fmt.Errorf("ClusterQuota %s: total number of nodes in namespace %s would be %d which exceeds the limit of %d",
+				quota.Name, clusterDeployment.Namespace, otherNodes+nodes, *quota.Spec.MaxNodes)
+		}
+
+		if len(quota.Spec.AllowedInstanceSizes) == 0 {
+			continue
+		}
+		for _, size := range sizes {
+			if !slices.Contains(quota.Spec.AllowedInstanceSizes, size) && !slices.Contains(oldSizes, size) {
+				return fmt.Errorf("ClusterQuota %s: instance size %q is not allowed, allowed sizes are: %s",
+					quota.Name, size, strings.Join(quota.Spec.AllowedInstanceSizes, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+// getQuotaTemplate returns the ClusterTemplate of the given ClusterDeployment to count its resources with,
+// nil if the template no longer exists so only the configuration of the ClusterDeployment is counted.
+func (v *ClusterDeploymentValidator) getQuotaTemplate(ctx context.Context, cd *kcmv1.ClusterDeployment) (*kcmv1.ClusterTemplate, error) {
+	template, err := v.getClusterDeploymentTemplate(ctx, cd.TemplateNamespace(), cd.Spec.Template)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ClusterTemplate %s/%s: %w", cd.TemplateNamespace(), cd.Spec.Template, err)
+	}
+
+	return template, nil
+}
+
+// validateProviderConfig validates the configuration of the ClusterDeployment merged with the defaults
+// of the given template against the catalogs of the offerings of the template infrastructure providers.
+// On update, the ClusterDeployment is validated only if its configuration or template change.
+func (v *ClusterDeploymentValidator) validateProviderConfig(ctx context.Context, oldClusterDeployment, clusterDeployment *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) error {
+	if oldClusterDeployment != nil &&
+		oldClusterDeployment.Spec.Template == clusterDeployment.Spec.Template &&
+		equality.Semantic.DeepEqual(oldClusterDeployment.Spec.Config, clusterDeployment.Spec.Config) {
+		return nil
+	}
+
+	var values map[string]any
+	var errs field.ErrorList
+	for _, provider := range template.Status.Providers {
+		if !strings.HasPrefix(provider, providersloader.InfraPrefix) {
+			continue
+		}
+
+		configValidation := providersloader.GetConfigValidation(provider)
+		if configValidation == nil {
+			continue
+		}
+
+		catalog, err := configvalidation.GetCatalog(ctx, v.Client, v.SystemNamespace, provider)
+		if err != nil {
+			return err
+		}
+		if catalog == nil {
+			continue
+		}
+
+		if values == nil {
+			config := clusterDeployment.Spec.Config
+			if template.Status.Config != nil {
+				if config, err = mergeConfig(config, template.Status.Config); err != nil {
+					return err
+				}
+			}
+			values = make(map[string]any)
+			if config != nil && len(config.Raw) > 0 {
+				if err := json.Unmarshal(config.Raw, &values); err != nil {
+					return fmt.Errorf("failed to unmarshal config: %w", err)
+				}
+			}
+		}
+
+		errs = append(errs, configvalidation.Validate(configValidation, catalog, values)...)
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(kcmv1.GroupVersion.WithKind(kcmv1.ClusterDeploymentKind).GroupKind(), clusterDeployment.Name, errs)
+	}
+
+	return nil
+}
+
+// validateServiceSpec validates the service settings of the given ClusterDeployment: the services must not repeat
+// in the same namespace, must not be installed to several namespaces under the same name if the engine delivering
+// the services names its objects after the services, the priority must be in range and the sync mode must be
+// supported by the engine. The violations are reported together as the invalid fields.
+// On update, the ClusterDeployment is validated only if its service settings or labels change.
+func (v *ClusterDeploymentValidator) validateServiceSpec(ctx context.Context, oldClusterDeployment, clusterDeployment *kcmv1.ClusterDeployment) error {
+	if oldClusterDeployment != nil &&
+		oldClusterDeployment.Spec.ServiceDelivery == clusterDeployment.Spec.ServiceDelivery &&
+		maps.Equal(oldClusterDeployment.Labels, clusterDeployment.Labels) &&
+		equality.Semantic.DeepEqual(oldClusterDeployment.Spec.ServiceSpec, clusterDeployment.Spec.ServiceSpec) {
+		return nil
+	}
+
+	// the engine is only resolved if any of the settings depends on it
+	var engine string
+	getEngine := func() (string, error) {
+		if engine != "" {
+			return engine, nil
+		}
+		var err error
+		engine, err = statemanagement.SelectedEngine(ctx, v.Client, clusterDeployment)
+		return engine, err
+	}
+
+	spec := clusterDeployment.Spec.ServiceSpec
+	path := field.NewPath("spec", "serviceSpec")
+	var errs field.ErrorList
+
+	namespaces := make(map[string]string, len(spec.Services))
+	for i, svc := range spec.Services {
+		namespace := svc.Namespace
+		if namespace == "" {
+			namespace = svc.Name
+		}
+
+		otherNamespace, ok := namespaces[svc.Name]
+		switch {
+		case !ok:
+			namespaces[svc.Name] = namespace
+		case otherNamespace == namespace:
+			errs = append(errs, field.Duplicate(path.Child("services").Index(i).Child("name"), svc.Name))
+		default:
+			engine, err := getEngine()
+			if err != nil {
+				return err
+			}
+			if statemanagement.RequiresUniqueServiceNames(engine) {
+				errs = append(errs, field.Invalid(path.Child("services").Index(i).Child("namespace"), namespace,
+					fmt.Sprintf("service %s is also installed to the namespace %s, the %s engine requires unique service names", svc.Name, otherNamespace, engine)))
+			}
+		}
+	}
+
+	// the unset priority is defaulted by the API server
+	if spec.Priority != 0 && (spec.Priority < 1 || spec.Priority > math.MaxInt32-1) {
+		errs = append(errs, field.Invalid(path.Child("priority"), spec.Priority, fmt.Sprintf("must be between 1 and %d", math.MaxInt32-1)))
+	}
+
+	if spec.SyncMode != "" && spec.SyncMode != kcmv1.SyncModeContinuous {
+		engine, err := getEngine()
+		if err != nil {
+			return err
+		}
+		if !statemanagement.SupportsSyncMode(engine, spec.SyncMode) {
+			errs = append(errs, field.NotSupported(path.Child("syncMode"), spec.SyncMode, []string{kcmv1.SyncModeContinuous}))
+		}
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(kcmv1.GroupVersion.WithKind(kcmv1.ClusterDeploymentKind).GroupKind(), clusterDeployment.Name, errs)
+	}
+
+	return nil
+}
+
+// validateRenderValues validates the templates in the configuration and in the values
+// of the services of the given ClusterDeployment can be parsed if the rendering is enabled.
+func validateRenderValues(clusterDeployment *kcmv1.ClusterDeployment) error {
+	if !clusterDeployment.Spec.RenderValues {
+		return nil
+	}
+
+	var errs field.ErrorList
+	values, err := clusterDeployment.HelmValues()
+	if err != nil {
+		return fmt.Errorf("failed to parse the configuration: %w", err)
+	}
+	if err := valuestemplate.ParseValues(values); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "config"), "", err.Error()))
+	}
+
+	for i, svc := range clusterDeployment.Spec.ServiceSpec.Services {
+		if err := valuestemplate.Parse(svc.Values); err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "serviceSpec", "services").Index(i).Child("values"), svc.Values, err.Error()))
+		}
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(kcmv1.GroupVersion.WithKind(kcmv1.ClusterDeploymentKind).GroupKind(), clusterDeployment.Name, errs)
+	}
+
+	return nil
+}
+
+// validateConfigPolicies validates the configuration of the ClusterDeployment merged with the defaults
+// of the given template against the rules of the ConfigPolicies selecting the ClusterDeployment.
+// The violations are reported as the invalid fields of the configuration.
+// On update, the ClusterDeployment is validated only if its configuration, template or labels change,
+// so the existing ClusterDeployments violating a new policy can still be managed and deleted.
+func (v *ClusterDeploymentValidator) validateConfigPolicies(ctx context.Context, oldClusterDeployment, clusterDeployment *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) error {
+	if oldClusterDeployment != nil &&
+		oldClusterDeployment.Spec.Template == clusterDeployment.Spec.Template &&
+		maps.Equal(oldClusterDeployment.Labels, clusterDeployment.Labels) &&
+		equality.Semantic.DeepEqual(oldClusterDeployment.Spec.Config, clusterDeployment.Spec.Config) {
+		return nil
+	}
+
+	policies := &kcmv1.ConfigPolicyList{}
+	if err := v.List(ctx, policies); err != nil {
+		return fmt.Errorf("failed to list ConfigPolicies: %w", err)
+	}
+	if len(policies.Items) == 0 {
+		return nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := v.Get(ctx, client.ObjectKey{Name: clusterDeployment.Namespace}, namespace); err != nil {
+		return fmt.Errorf("failed to get Namespace %s: %w", clusterDeployment.Namespace, err)
+	}
+
+	config := clusterDeployment.Spec.Config
+	if template.Status.Config != nil {
+		var err error
+		if config, err = mergeConfig(config, template.Status.Config); err != nil {
+			return err
+		}
+	}
+	var raw []byte
+	if config != nil {
+		raw = config.Raw
+	}
+
+	var errs field.ErrorList
+	for _, policy := range policies.Items {
+		if len(policy.Spec.Rules) == 0 {
+			continue
+		}
+		selected, err := configpolicy.Selects(&policy, namespace.Labels, clusterDeployment.Labels)
+		if err != nil {
+			return err
+		}
+		if !selected {
+			continue
+		}
+
+		violations, err := configpolicy.Evaluate(&policy, clusterDeployment, raw)
+		if err != nil {
+			return fmt.Errorf("failed to evaluate ConfigPolicy %s: %w", policy.Name, err)
+		}
+
+		for _, violation := range violations {
+			path := field.NewPath("spec", "config")
+			if violation.FieldPath != "" {
+				for _, name := range strings.Split(violation.FieldPath, ".") {
+					path = path.Child(name)
+				}
+			}
+			errs = append(errs, field.Forbidden(path, violation.String()))
+		}
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(kcmv1.GroupVersion.WithKind(kcmv1.ClusterDeploymentKind).GroupKind(), clusterDeployment.Name, errs)
+	}
+
+	return nil
+}
+
+// clusterResources returns the total number of nodes and the instance sizes of the node pools
+// of the given ClusterDeployment merged with the defaults of the given template, if any.
+func clusterResources(cd *kcmv1.ClusterDeployment, template *kcmv1.ClusterTemplate) (nodes int64, sizes []string, _ error) {
+	var defaults *apiextensionsv1.JSON
+	if template != nil {
+		defaults = template.Status.Config
+	}
+
+	values, err := cost.MergeValues(defaults, cd.Spec.Config)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	for size, number := range cost.NodeCounts(values) {
+		nodes += int64(number)
+		if size != "" {
+			sizes = append(sizes, size)
+		}
+	}
+	slices.Sort(sizes)
+
+	return nodes, sizes, nil
+}
+
 func isCredMatchTemplate(cred *kcmv1.Credential, template *kcmv1.ClusterTemplate) error {
 	idtyKind := cred.Spec.IdentityRef.Kind
 
@@ -16,7 +16,9 @@ package webhook
 
 import (
 	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
@@ -26,14 +28,24 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	clusterapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/configvalidation"
+	"github.com/K0rdent/kcm/internal/cost"
+	"github.com/K0rdent/kcm/test/objects/accessmanagement"
 	"github.com/K0rdent/kcm/test/objects/clusterdeployment"
+	"github.com/K0rdent/kcm/test/objects/clusterquota"
+	"github.com/K0rdent/kcm/test/objects/configpolicy"
 	"github.com/K0rdent/kcm/test/objects/credential"
 	"github.com/K0rdent/kcm/test/objects/management"
+	"github.com/K0rdent/kcm/test/objects/release"
 	"github.com/K0rdent/kcm/test/objects/template"
+	"github.com/K0rdent/kcm/test/objects/templatechain"
+	"github.com/K0rdent/kcm/test/objects/vippool"
 	"github.com/K0rdent/kcm/test/scheme"
 )
 
@@ -253,6 +265,28 @@ func TestClusterDeploymentValidateCreate(t *testing.T) {
 				testConfigMapName, otherNamespace, metav1.NamespaceDefault,
 				testSecretName, otherNamespace, metav1.NamespaceDefault),
 		},
+		{
+			name: "should fail if expireAt is in the past",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithExpireAt(metav1.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+				),
+			},
+			err: "the ClusterDeployment is invalid: expireAt 2025-01-01T00:00:00Z is in the past",
+		},
 		{
 			name: "should succeed",
 			ClusterDeployment: clusterdeployment.NewClusterDeployment(
@@ -321,6 +355,26 @@ func TestClusterDeploymentValidateCreate(t *testing.T) {
 			err:      fmt.Sprintf(`failed to validate k8s compatibility: k8s version v1.30.0 of the ClusterDeployment default/%s does not satisfy constrained version <1.30 from the ServiceTemplate default/%s`, clusterdeployment.DefaultName, testTemplateName),
 			warnings: admission.Warnings{"Failed to validate k8s version compatibility with ServiceTemplates"},
 		},
+		{
+			name: "should fail if the cluster template k8s version exceeds the skew of the management components",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+			),
+			existingObjects: []runtime.Object{
+				cred,
+				management.NewManagement(management.WithRelease("kcm-1-0-0")),
+				release.New(
+					release.WithName("kcm-1-0-0"),
+					release.WithKubernetesVersionSkew(v1alpha1.ComponentKubernetesVersions{Component: "cluster-api", KubernetesVersions: ">=1.28.0 <1.33.0"}),
+				),
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithClusterStatusK8sVersion("v1.33.1+k0s.0"),
+				),
+			},
+			err: "the ClusterDeployment is invalid: the Kubernetes version v1.33.1+k0s.0 is unsupported by the management components cluster-api (>=1.28.0 <1.33.0) of the Release kcm-1-0-0",
+		},
 		{
 			name:              "should fail if the credential is unset",
 			ClusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplate(testTemplateName)),
@@ -402,225 +456,162 @@ func TestClusterDeploymentValidateCreate(t *testing.T) {
 			},
 			err: "the ClusterDeployment is invalid: wrong kind of the ClusterIdentity \"SomeOtherDummyClusterStaticIdentity\" for provider \"aws\"",
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			g := NewWithT(t)
-
-			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
-			validator := &ClusterDeploymentValidator{Client: c}
-			warn, err := validator.ValidateCreate(ctx, tt.ClusterDeployment)
-			if tt.err != "" {
-				g.Expect(err).To(HaveOccurred())
-				g.Expect(err.Error()).To(ContainSubstring(tt.err))
-			} else {
-				g.Expect(err).To(Succeed())
-			}
-
-			g.Expect(warn).To(Equal(tt.warnings))
-		})
-	}
-}
-
-func TestClusterDeploymentValidateUpdate(t *testing.T) {
-	const (
-		upgradeTargetTemplateName  = "upgrade-target-template"
-		unmanagedByKCMTemplateName = "unmanaged-template"
-
-		otherNamespace = "othernamespace"
-	)
-
-	ctx := admission.NewContextWithRequest(t.Context(), admission.Request{
-		AdmissionRequest: admissionv1.AdmissionRequest{
-			Operation: admissionv1.Update,
-		},
-	})
-
-	tests := []struct {
-		name                      string
-		oldClusterDeployment      *v1alpha1.ClusterDeployment
-		newClusterDeployment      *v1alpha1.ClusterDeployment
-		existingObjects           []runtime.Object
-		skipUpgradePathValidation bool
-		err                       string
-		warnings                  admission.Warnings
-	}{
 		{
-			name: "update spec.template: should fail if the new cluster template was found but is invalid (some validation error)",
-			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+			name: "should fail if the credential does not match the docker provider",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
 				clusterdeployment.WithClusterTemplate(testTemplateName),
-				clusterdeployment.WithAvailableUpgrades([]string{newTemplateName}),
+				clusterdeployment.WithCredential(testCredentialName),
 			),
-			newClusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplate(newTemplateName)),
 			existingObjects: []runtime.Object{
-				mgmt,
-				template.NewClusterTemplate(
-					template.WithName(newTemplateName),
-					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{
-						Valid:           false,
-						ValidationError: "validation error example",
+				credential.NewCredential(
+					credential.WithName(testCredentialName),
+					credential.WithReady(true),
+					credential.WithIdentityRef(
+						&corev1.ObjectReference{
+							Kind: "AWSClusterStaticIdentity",
+							Name: "dockerclid",
+						}),
+				),
+				management.NewManagement(
+					management.WithAvailableProviders(v1alpha1.Providers{
+						"infrastructure-docker",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
 					}),
 				),
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithProvidersStatus(
+						"infrastructure-docker",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+				),
 			},
-			err: "the ClusterDeployment is invalid: the template is not valid: validation error example",
+			err: "the ClusterDeployment is invalid: wrong kind of the ClusterIdentity \"AWSClusterStaticIdentity\" for provider \"docker\"",
 		},
 		{
-			name: "update spec.template: should fail if the template is not in the list of available",
-			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+			name: "should succeed if the Secret credential matches the docker provider",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
 				clusterdeployment.WithClusterTemplate(testTemplateName),
 				clusterdeployment.WithCredential(testCredentialName),
-				clusterdeployment.WithAvailableUpgrades([]string{}),
-			),
-			newClusterDeployment: clusterdeployment.NewClusterDeployment(
-				clusterdeployment.WithClusterTemplate(upgradeTargetTemplateName),
-				clusterdeployment.WithCredential(testCredentialName),
 			),
 			existingObjects: []runtime.Object{
-				mgmt, cred,
-				template.NewClusterTemplate(
-					template.WithName(testTemplateName),
-					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
-					template.WithProvidersStatus(
-						"infrastructure-aws",
+				credential.NewCredential(
+					credential.WithName(testCredentialName),
+					credential.WithReady(true),
+					credential.WithIdentityRef(
+						&corev1.ObjectReference{
+							Kind: "Secret",
+							Name: "dockerclid",
+						}),
+				),
+				management.NewManagement(
+					management.WithAvailableProviders(v1alpha1.Providers{
+						"infrastructure-docker",
 						"control-plane-k0smotron",
 						"bootstrap-k0smotron",
-					),
+					}),
 				),
 				template.NewClusterTemplate(
-					template.WithName(upgradeTargetTemplateName),
-					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithName(testTemplateName),
 					template.WithProvidersStatus(
-						"infrastructure-aws",
+						"infrastructure-docker",
 						"control-plane-k0smotron",
 						"bootstrap-k0smotron",
 					),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
 				),
 			},
-			warnings: admission.Warnings{fmt.Sprintf("Cluster can't be upgraded from %s to %s. This upgrade sequence is not allowed", testTemplateName, upgradeTargetTemplateName)},
-			err:      "cluster upgrade is forbidden",
 		},
 		{
-			name: "update spec.template: should succeed if the template is in the list of available",
-			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+			name: "should fail if the template does not support OIDC authentication",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
 				clusterdeployment.WithClusterTemplate(testTemplateName),
 				clusterdeployment.WithCredential(testCredentialName),
-				clusterdeployment.WithAvailableUpgrades([]string{newTemplateName}),
-			),
-			newClusterDeployment: clusterdeployment.NewClusterDeployment(
-				clusterdeployment.WithClusterTemplate(newTemplateName),
-				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithOIDC("https://issuer.example.com", "kubernetes"),
 			),
 			existingObjects: []runtime.Object{
-				mgmt, cred,
+				mgmt,
+				cred,
 				template.NewClusterTemplate(
 					template.WithName(testTemplateName),
-					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
 					template.WithProvidersStatus(
 						"infrastructure-aws",
 						"control-plane-k0smotron",
 						"bootstrap-k0smotron",
 					),
-				),
-				template.NewClusterTemplate(
-					template.WithName(newTemplateName),
 					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
-					template.WithProvidersStatus(
-						"infrastructure-aws",
-						"control-plane-k0smotron",
-						"bootstrap-k0smotron",
-					),
+					template.WithConfigStatus(`{"workersNumber":1}`),
 				),
 			},
+			err: fmt.Sprintf("the ClusterDeployment is invalid: the ClusterTemplate %s does not support OIDC authentication", testTemplateName),
 		},
 		{
-			name:                      "update spec.template: should succeed if upgrade sequence validation is skipped",
-			skipUpgradePathValidation: true,
-			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+			name: "should succeed if the template supports OIDC authentication",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
 				clusterdeployment.WithClusterTemplate(testTemplateName),
 				clusterdeployment.WithCredential(testCredentialName),
-			),
-			newClusterDeployment: clusterdeployment.NewClusterDeployment(
-				clusterdeployment.WithClusterTemplate(newTemplateName),
-				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithOIDC("https://issuer.example.com", "kubernetes"),
 			),
 			existingObjects: []runtime.Object{
-				mgmt, cred,
+				mgmt,
+				cred,
 				template.NewClusterTemplate(
 					template.WithName(testTemplateName),
-					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
 					template.WithProvidersStatus(
 						"infrastructure-aws",
 						"control-plane-k0smotron",
 						"bootstrap-k0smotron",
 					),
-				),
-				template.NewClusterTemplate(
-					template.WithName(newTemplateName),
 					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
-					template.WithProvidersStatus(
-						"infrastructure-aws",
-						"control-plane-k0smotron",
-						"bootstrap-k0smotron",
-					),
+					template.WithConfigStatus(`{"k0s":{"api":{"extraArgs":{}}}}`),
 				),
 			},
 		},
 		{
-			name: "should succeed if spec.template is not changed",
-			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
-				clusterdeployment.WithClusterTemplate(testTemplateName),
-				clusterdeployment.WithConfig(`{"foo":"bar"}`),
-				clusterdeployment.WithCredential(testCredentialName),
-			),
-			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+			name: "should fail if the worker instance type does not provide GPUs",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
 				clusterdeployment.WithClusterTemplate(testTemplateName),
-				clusterdeployment.WithConfig(`{"a":"b"}`),
 				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithConfig(`{"worker":{"instanceType":"t3.large"}}`),
+				clusterdeployment.WithGPU(testSvcTemplate1Name),
 			),
 			existingObjects: []runtime.Object{
 				mgmt,
 				cred,
 				template.NewClusterTemplate(
 					template.WithName(testTemplateName),
-					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{
-						Valid:           false,
-						ValidationError: "validation error example",
-					}),
 					template.WithProvidersStatus(
 						"infrastructure-aws",
 						"control-plane-k0smotron",
 						"bootstrap-k0smotron",
 					),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
 				),
 			},
+			err: `the ClusterDeployment is invalid: the instance type "t3.large" does not provide GPUs for the provider infrastructure-aws`,
 		},
 		{
-			name: "should succeed if serviceTemplates are added",
-			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
-				clusterdeployment.WithClusterTemplate(testTemplateName),
-				clusterdeployment.WithConfig(`{"foo":"bar"}`),
-				clusterdeployment.WithCredential(testCredentialName),
-			),
-			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+			name: "should succeed if the worker instance type provides GPUs",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
 				clusterdeployment.WithClusterTemplate(testTemplateName),
-				clusterdeployment.WithConfig(`{"a":"b"}`),
 				clusterdeployment.WithCredential(testCredentialName),
-				clusterdeployment.WithServiceTemplate(testSvcTemplate1Name),
+				clusterdeployment.WithConfig(`{"worker":{"instanceType":"g5.xlarge"}}`),
+				clusterdeployment.WithGPU(testSvcTemplate1Name),
 			),
 			existingObjects: []runtime.Object{
 				mgmt,
 				cred,
 				template.NewClusterTemplate(
 					template.WithName(testTemplateName),
-					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{
-						Valid:           false,
-						ValidationError: "validation error example",
-					}),
 					template.WithProvidersStatus(
 						"infrastructure-aws",
 						"control-plane-k0smotron",
 						"bootstrap-k0smotron",
 					),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
 				),
 				template.NewServiceTemplate(
 					template.WithName(testSvcTemplate1Name),
@@ -629,121 +620,205 @@ func TestClusterDeploymentValidateUpdate(t *testing.T) {
 			},
 		},
 		{
-			name: "should succeed if serviceTemplates are removed",
-			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
-				clusterdeployment.WithClusterTemplate(testTemplateName),
-				clusterdeployment.WithConfig(`{"foo":"bar"}`),
-				clusterdeployment.WithCredential(testCredentialName),
-				clusterdeployment.WithServiceTemplate(testSvcTemplate1Name),
-			),
-			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+			name: "should fail if the template does not support the selected CNI",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
 				clusterdeployment.WithClusterTemplate(testTemplateName),
-				clusterdeployment.WithConfig(`{"a":"b"}`),
 				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithCNI(v1alpha1.CNICilium, testSvcTemplate1Name),
 			),
 			existingObjects: []runtime.Object{
 				mgmt,
 				cred,
 				template.NewClusterTemplate(
 					template.WithName(testTemplateName),
-					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{
-						Valid:           false,
-						ValidationError: "validation error example",
-					}),
 					template.WithProvidersStatus(
 						"infrastructure-aws",
 						"control-plane-k0smotron",
 						"bootstrap-k0smotron",
 					),
-				),
-				template.NewServiceTemplate(
-					template.WithName(testSvcTemplate1Name),
 					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithClusterStatusSupportedCNIs(v1alpha1.CNICalico, v1alpha1.CNINone),
 				),
 			},
+			err: "the ClusterDeployment is invalid: the CNI cilium is not supported by the ClusterTemplate " + testTemplateName + ", supported CNIs: [calico none]",
 		},
 		{
-			name: "should fail if serviceTemplates are not in the same namespace",
-			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
-				clusterdeployment.WithClusterTemplate(testTemplateName),
-				clusterdeployment.WithConfig(`{"foo":"bar"}`),
-				clusterdeployment.WithCredential(testCredentialName),
-			),
-			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+			name: "should fail if the ServiceTemplate of the selected CNI does not exist",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
 				clusterdeployment.WithClusterTemplate(testTemplateName),
-				clusterdeployment.WithConfig(`{"a":"b"}`),
 				clusterdeployment.WithCredential(testCredentialName),
-				clusterdeployment.WithServiceTemplate(testSvcTemplate1Name),
+				clusterdeployment.WithCNI(v1alpha1.CNICilium, testSvcTemplate1Name),
 			),
 			existingObjects: []runtime.Object{
 				mgmt,
 				cred,
 				template.NewClusterTemplate(
 					template.WithName(testTemplateName),
-					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{
-						Valid:           false,
-						ValidationError: "validation error example",
-					}),
 					template.WithProvidersStatus(
 						"infrastructure-aws",
 						"control-plane-k0smotron",
 						"bootstrap-k0smotron",
 					),
-				),
-				template.NewServiceTemplate(
-					template.WithName(testSvcTemplate1Name),
-					template.WithNamespace(otherNamespace),
 					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithClusterStatusSupportedCNIs(v1alpha1.CNICalico, v1alpha1.CNICilium, v1alpha1.CNINone),
 				),
 			},
-			err: apierrors.NewNotFound(schema.GroupResource{Group: v1alpha1.GroupVersion.Group, Resource: "servicetemplates"}, testSvcTemplate1Name).Error(),
+			err: "the ClusterDeployment is invalid: failed to get ServiceTemplate default/" + testSvcTemplate1Name,
 		},
 		{
-			name: "should fail if the ServiceTemplates were found but are invalid",
-			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
-				clusterdeployment.WithClusterTemplate(testTemplateName),
-				clusterdeployment.WithConfig(`{"foo":"bar"}`),
-				clusterdeployment.WithCredential(testCredentialName),
-			),
-			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+			name: "should succeed if the selected CNI is supported by the template",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
 				clusterdeployment.WithClusterTemplate(testTemplateName),
-				clusterdeployment.WithConfig(`{"a":"b"}`),
 				clusterdeployment.WithCredential(testCredentialName),
-				clusterdeployment.WithServiceTemplate(testSvcTemplate1Name),
+				clusterdeployment.WithCNI(v1alpha1.CNICilium, testSvcTemplate1Name),
 			),
 			existingObjects: []runtime.Object{
 				mgmt,
 				cred,
 				template.NewClusterTemplate(
 					template.WithName(testTemplateName),
-					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{
-						Valid:           false,
-						ValidationError: "validation error example",
-					}),
 					template.WithProvidersStatus(
 						"infrastructure-aws",
 						"control-plane-k0smotron",
 						"bootstrap-k0smotron",
 					),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithClusterStatusSupportedCNIs(v1alpha1.CNICalico, v1alpha1.CNICilium, v1alpha1.CNINone),
 				),
 				template.NewServiceTemplate(
 					template.WithName(testSvcTemplate1Name),
-					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{
-						Valid:           false,
-						ValidationError: "validation error example",
-					}),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
 				),
 			},
-			err: fmt.Sprintf("the ClusterDeployment is invalid: the ServiceTemplate %s/%s is invalid with the error: validation error example", metav1.NamespaceDefault, testSvcTemplate1Name),
+		},
+		{
+			name: "should fail if the control plane VIP is malformed",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithControlPlaneVIP("10.0.0.300", ""),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+				),
+			},
+			err: "the ClusterDeployment is invalid: invalid control plane VIP",
+		},
+		{
+			name: "should fail if the control plane VIP is already in use",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithControlPlaneVIP("10.0.0.10", ""),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+				),
+				clusterdeployment.NewClusterDeployment(
+					clusterdeployment.WithName("other"),
+					clusterdeployment.WithControlPlaneVIP("", vippool.DefaultName),
+					clusterdeployment.WithControlPlaneVIPStatus("10.0.0.10"),
+				),
+			},
+			err: fmt.Sprintf("the ClusterDeployment is invalid: control plane VIP 10.0.0.10 is already in use by ClusterDeployment %s/other", metav1.NamespaceDefault),
+		},
+		{
+			name: "should fail if the VIPPool is not found",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithControlPlaneVIP("", vippool.DefaultName),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+				),
+			},
+			err: fmt.Sprintf("the ClusterDeployment is invalid: failed to get VIPPool %s", vippool.DefaultName),
+		},
+		{
+			name: "should fail if the control plane VIP does not belong to the VIPPool",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithControlPlaneVIP("10.0.1.10", vippool.DefaultName),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+				),
+				vippool.NewVIPPool(vippool.WithAddresses("10.0.0.0/24")),
+			},
+			err: fmt.Sprintf("the ClusterDeployment is invalid: control plane VIP 10.0.1.10 does not belong to VIPPool %s", vippool.DefaultName),
+		},
+		{
+			name: "should succeed if the control plane VIP belongs to the VIPPool",
+			ClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithControlPlaneVIP("10.0.0.10", vippool.DefaultName),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+				),
+				vippool.NewVIPPool(vippool.WithAddresses("10.0.0.0/24")),
+			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
-			validator := &ClusterDeploymentValidator{Client: c, ValidateClusterUpgradePath: !tt.skipUpgradePathValidation}
-			warn, err := validator.ValidateUpdate(ctx, tt.oldClusterDeployment, tt.newClusterDeployment)
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(tt.existingObjects...).
+				WithIndex(&v1alpha1.ClusterDeployment{}, v1alpha1.ClusterDeploymentControlPlaneVIPIndexKey, v1alpha1.ExtractControlPlaneVIPsFromClusterDeployment).
+				Build()
+			validator := &ClusterDeploymentValidator{Client: c}
+			warn, err := validator.ValidateCreate(ctx, tt.ClusterDeployment)
 			if tt.err != "" {
 				g.Expect(err).To(HaveOccurred())
 				g.Expect(err.Error()).To(ContainSubstring(tt.err))
@@ -756,86 +831,2244 @@ func TestClusterDeploymentValidateUpdate(t *testing.T) {
 	}
 }
 
-func TestClusterDeploymentDefault(t *testing.T) {
-	g := NewWithT(t)
+func TestClusterDeploymentValidateUpdate(t *testing.T) {
+	const (
+		upgradeTargetTemplateName  = "upgrade-target-template"
+		unmanagedByKCMTemplateName = "unmanaged-template"
 
-	ctx := t.Context()
+		otherNamespace = "othernamespace"
+	)
 
-	clusterDeploymentConfig := `{"foo":"bar"}`
+	ctx := admission.NewContextWithRequest(t.Context(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Update,
+		},
+	})
 
 	tests := []struct {
-		name            string
-		input           *v1alpha1.ClusterDeployment
-		output          *v1alpha1.ClusterDeployment
-		existingObjects []runtime.Object
-		err             string
+		name                      string
+		oldClusterDeployment      *v1alpha1.ClusterDeployment
+		newClusterDeployment      *v1alpha1.ClusterDeployment
+		existingObjects           []runtime.Object
+		skipUpgradePathValidation bool
+		err                       string
+		warnings                  admission.Warnings
 	}{
 		{
-			name:   "should not set defaults if the config is provided",
-			input:  clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(clusterDeploymentConfig)),
-			output: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(clusterDeploymentConfig)),
-		},
-		{
-			name:   "should not set defaults: template is invalid",
-			input:  clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplate(testTemplateName)),
-			output: clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplate(testTemplateName)),
+			name: "update spec.template: should fail if the new cluster template was found but is invalid (some validation error)",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithAvailableUpgrades([]string{newTemplateName}),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplate(newTemplateName)),
 			existingObjects: []runtime.Object{
 				mgmt,
 				template.NewClusterTemplate(
-					template.WithName(testTemplateName),
+					template.WithName(newTemplateName),
 					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{
 						Valid:           false,
 						ValidationError: "validation error example",
 					}),
 				),
 			},
-			err: "template is invalid: the template is not valid: validation error example",
+			err: "the ClusterDeployment is invalid: the template is not valid: validation error example",
 		},
 		{
-			name:   "should not set defaults: config in template status is unset",
-			input:  clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplate(testTemplateName)),
-			output: clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplate(testTemplateName)),
+			name: "update spec.template: should fail if the template is not in the list of available",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithAvailableUpgrades([]string{}),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(upgradeTargetTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
 			existingObjects: []runtime.Object{
-				mgmt,
+				mgmt, cred,
 				template.NewClusterTemplate(
 					template.WithName(testTemplateName),
 					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+				),
+				template.NewClusterTemplate(
+					template.WithName(upgradeTargetTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
 				),
 			},
+			warnings: admission.Warnings{fmt.Sprintf("Cluster can't be upgraded from %s to %s. This upgrade sequence is not allowed", testTemplateName, upgradeTargetTemplateName)},
+			err:      "cluster upgrade is forbidden",
 		},
 		{
-			name:  "should set defaults",
-			input: clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplate(testTemplateName)),
-			output: clusterdeployment.NewClusterDeployment(
+			name: "update spec.template: should succeed if the template is in the list of available",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
 				clusterdeployment.WithClusterTemplate(testTemplateName),
-				clusterdeployment.WithConfig(clusterDeploymentConfig),
-				clusterdeployment.WithDryRun(true),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithAvailableUpgrades([]string{newTemplateName}),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(newTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
 			),
 			existingObjects: []runtime.Object{
-				mgmt,
+				mgmt, cred,
 				template.NewClusterTemplate(
 					template.WithName(testTemplateName),
 					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
-					template.WithConfigStatus(clusterDeploymentConfig),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+				),
+				template.NewClusterTemplate(
+					template.WithName(newTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
 				),
 			},
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
-			validator := &ClusterDeploymentValidator{Client: c}
-			err := validator.Default(ctx, tt.input)
-			if tt.err != "" {
-				g.Expect(err).To(HaveOccurred())
-				if err.Error() != tt.err {
-					t.Fatalf("expected error '%s', got error: %s", tt.err, err.Error())
-				}
-			} else {
-				g.Expect(err).To(Succeed())
-			}
-			g.Expect(tt.input).To(Equal(tt.output))
+		{
+			name:                      "update spec.template: should succeed if upgrade sequence validation is skipped",
+			skipUpgradePathValidation: true,
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(newTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			existingObjects: []runtime.Object{
+				mgmt, cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+				),
+				template.NewClusterTemplate(
+					template.WithName(newTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+				),
+			},
+		},
+		{
+			name: "should succeed if spec.template is not changed",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(`{"foo":"bar"}`),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(`{"a":"b"}`),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{
+						Valid:           false,
+						ValidationError: "validation error example",
+					}),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+				),
+			},
+		},
+		{
+			name: "should succeed if serviceTemplates are added",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(`{"foo":"bar"}`),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(`{"a":"b"}`),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithServiceTemplate(testSvcTemplate1Name),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{
+						Valid:           false,
+						ValidationError: "validation error example",
+					}),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+				),
+				template.NewServiceTemplate(
+					template.WithName(testSvcTemplate1Name),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+				),
+			},
+		},
+		{
+			name: "should succeed if serviceTemplates are removed",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(`{"foo":"bar"}`),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithServiceTemplate(testSvcTemplate1Name),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(`{"a":"b"}`),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{
+						Valid:           false,
+						ValidationError: "validation error example",
+					}),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+				),
+				template.NewServiceTemplate(
+					template.WithName(testSvcTemplate1Name),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+				),
+			},
+		},
+		{
+			name: "should fail if serviceTemplates are not in the same namespace",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(`{"foo":"bar"}`),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(`{"a":"b"}`),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithServiceTemplate(testSvcTemplate1Name),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{
+						Valid:           false,
+						ValidationError: "validation error example",
+					}),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+				),
+				template.NewServiceTemplate(
+					template.WithName(testSvcTemplate1Name),
+					template.WithNamespace(otherNamespace),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+				),
+			},
+			err: apierrors.NewNotFound(schema.GroupResource{Group: v1alpha1.GroupVersion.Group, Resource: "servicetemplates"}, testSvcTemplate1Name).Error(),
+		},
+		{
+			name: "should fail if the ServiceTemplates were found but are invalid",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(`{"foo":"bar"}`),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(`{"a":"b"}`),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithServiceTemplate(testSvcTemplate1Name),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{
+						Valid:           false,
+						ValidationError: "validation error example",
+					}),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+				),
+				template.NewServiceTemplate(
+					template.WithName(testSvcTemplate1Name),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{
+						Valid:           false,
+						ValidationError: "validation error example",
+					}),
+				),
+			},
+			err: fmt.Sprintf("the ClusterDeployment is invalid: the ServiceTemplate %s/%s is invalid with the error: validation error example", metav1.NamespaceDefault, testSvcTemplate1Name),
+		},
+		{
+			name: "rollback: should succeed reverting to the last known-good template not in the list of available",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(newTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.RollbackAnnotation: ""}),
+				clusterdeployment.WithLastKnownGood(&v1alpha1.ClusterRevision{Template: testTemplateName}),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithLastKnownGood(&v1alpha1.ClusterRevision{Template: testTemplateName}),
+			),
+			existingObjects: []runtime.Object{
+				mgmt, cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithProvidersStatus(
+						"infrastructure-aws",
+						"control-plane-k0smotron",
+						"bootstrap-k0smotron",
+					),
+				),
+			},
+		},
+		{
+			name: "rollback: should fail if no known-good revision is recorded",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(newTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(newTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.RollbackAnnotation: ""}),
+			),
+			existingObjects: []runtime.Object{mgmt, cred},
+			err:             "the ClusterDeployment is invalid: no known-good revision of the cluster is recorded",
+		},
+		{
+			name: "control plane migration: should fail if both templates deploy the same control plane mode",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.ControlPlaneMigrationAnnotation: newTemplateName}),
+				clusterdeployment.WithConditions(metav1.Condition{Type: v1alpha1.ReadyCondition, Status: metav1.ConditionTrue}),
+			),
+			existingObjects: []runtime.Object{
+				mgmt, cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithProvidersStatus("infrastructure-aws"),
+					template.WithClusterStatusControlPlaneMode(v1alpha1.ControlPlaneModeStandalone),
+				),
+				template.NewClusterTemplate(
+					template.WithName(newTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithProvidersStatus("infrastructure-aws"),
+					template.WithClusterStatusControlPlaneMode(v1alpha1.ControlPlaneModeStandalone),
+				),
+			},
+			err: fmt.Sprintf("the ClusterDeployment is invalid: both ClusterTemplates %s and %s deploy the standalone control plane", testTemplateName, newTemplateName),
+		},
+		{
+			name: "control plane migration: should succeed switching to the template of the started migration not in the list of available",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.ControlPlaneMigrationAnnotation: newTemplateName}),
+				clusterdeployment.WithControlPlaneMigration(&v1alpha1.ControlPlaneMigration{
+					FromTemplate: testTemplateName,
+					ToTemplate:   newTemplateName,
+					Phase:        v1alpha1.ControlPlaneMigrationPhaseMigrating,
+				}),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(newTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.ControlPlaneMigrationAnnotation: newTemplateName}),
+			),
+			existingObjects: []runtime.Object{
+				mgmt, cred,
+				template.NewClusterTemplate(
+					template.WithName(newTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithProvidersStatus("infrastructure-aws"),
+				),
+			},
+		},
+		{
+			name: "control plane migration: should fail changing the template while the migration is in progress",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithAvailableUpgrades([]string{upgradeTargetTemplateName}),
+				clusterdeployment.WithControlPlaneMigration(&v1alpha1.ControlPlaneMigration{
+					FromTemplate: testTemplateName,
+					ToTemplate:   newTemplateName,
+					Phase:        v1alpha1.ControlPlaneMigrationPhaseMigrating,
+				}),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(upgradeTargetTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			existingObjects: []runtime.Object{
+				mgmt, cred,
+				template.NewClusterTemplate(
+					template.WithName(upgradeTargetTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithProvidersStatus("infrastructure-aws"),
+				),
+			},
+			err: "the ClusterDeployment is invalid: the template cannot be changed while the control plane migration is in progress",
+		},
+		{
+			name: "update spec.network: should fail switching from the CNI of the template to a selected CNI",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			newClusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithCNI(v1alpha1.CNINone, ""),
+			),
+			existingObjects: []runtime.Object{
+				mgmt, cred,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithProvidersStatus("infrastructure-aws"),
+					template.WithClusterStatusSupportedCNIs(v1alpha1.CNICalico, v1alpha1.CNICilium, v1alpha1.CNINone),
+				),
+			},
+			err: "the ClusterDeployment is invalid: the CNI of the cluster cannot be changed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
+			validator := &ClusterDeploymentValidator{Client: c, ValidateClusterUpgradePath: !tt.skipUpgradePathValidation}
+			warn, err := validator.ValidateUpdate(ctx, tt.oldClusterDeployment, tt.newClusterDeployment)
+			if tt.err != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+
+			g.Expect(warn).To(Equal(tt.warnings))
+		})
+	}
+}
+
+func TestClusterDeploymentValidateAccessRules(t *testing.T) {
+	const (
+		testSystemNamespace = "test-system-namespace"
+		testChainName       = "test-chain"
+	)
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   metav1.NamespaceDefault,
+			Labels: map[string]string{"environment": "dev"},
+		},
+	}
+	chain := templatechain.NewClusterTemplateChain(
+		templatechain.WithName(testChainName),
+		templatechain.WithNamespace(testSystemNamespace),
+		templatechain.WithSupportedTemplates([]v1alpha1.SupportedTemplate{{Name: testTemplateName}}),
+	)
+	grantingRules := []v1alpha1.AccessRule{
+		{
+			TargetNamespaces:      v1alpha1.TargetNamespaces{StringSelector: "environment=dev"},
+			ClusterTemplateChains: []string{testChainName},
+			Credentials:           []string{testCredentialName},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		clusterDeployment *v1alpha1.ClusterDeployment
+		existingObjects   []runtime.Object
+		err               string
+	}{
+		{
+			name: "should succeed if the access rules are not enforced",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate("other-template"),
+				clusterdeployment.WithCredential("other-credential"),
+			),
+			existingObjects: []runtime.Object{
+				namespace,
+				chain,
+				accessmanagement.NewAccessManagement(
+					accessmanagement.WithName(v1alpha1.AccessManagementName),
+					accessmanagement.WithAccessRules(grantingRules),
+				),
+			},
+		},
+		{
+			name: "should succeed in the system namespace",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithNamespace(testSystemNamespace),
+				clusterdeployment.WithClusterTemplate("other-template"),
+			),
+			existingObjects: []runtime.Object{
+				accessmanagement.NewAccessManagement(
+					accessmanagement.WithName(v1alpha1.AccessManagementName),
+					accessmanagement.WithEnforceAccessRules(true),
+				),
+			},
+		},
+		{
+			name: "should succeed if the template and the credential are granted",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			existingObjects: []runtime.Object{
+				namespace,
+				chain,
+				accessmanagement.NewAccessManagement(
+					accessmanagement.WithName(v1alpha1.AccessManagementName),
+					accessmanagement.WithAccessRules(grantingRules),
+					accessmanagement.WithEnforceAccessRules(true),
+				),
+			},
+		},
+		{
+			name: "should fail if the template is not granted",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate("other-template"),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			existingObjects: []runtime.Object{
+				namespace,
+				chain,
+				accessmanagement.NewAccessManagement(
+					accessmanagement.WithName(v1alpha1.AccessManagementName),
+					accessmanagement.WithAccessRules(grantingRules),
+					accessmanagement.WithEnforceAccessRules(true),
+				),
+			},
+			err: fmt.Sprintf("the ClusterTemplate other-template is not granted to the namespace %s by the AccessManagement rules", metav1.NamespaceDefault),
+		},
+		{
+			name: "should fail if the credential is not granted",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential("other-credential"),
+			),
+			existingObjects: []runtime.Object{
+				namespace,
+				chain,
+				accessmanagement.NewAccessManagement(
+					accessmanagement.WithName(v1alpha1.AccessManagementName),
+					accessmanagement.WithAccessRules(grantingRules),
+					accessmanagement.WithEnforceAccessRules(true),
+				),
+			},
+			err: fmt.Sprintf("the Credential other-credential is not granted to the namespace %s by the AccessManagement rules", metav1.NamespaceDefault),
+		},
+		{
+			name: "should fail if the rule does not target the namespace",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+			),
+			existingObjects: []runtime.Object{
+				namespace,
+				chain,
+				accessmanagement.NewAccessManagement(
+					accessmanagement.WithName(v1alpha1.AccessManagementName),
+					accessmanagement.WithAccessRules([]v1alpha1.AccessRule{
+						{
+							TargetNamespaces:      v1alpha1.TargetNamespaces{List: []string{"other"}},
+							ClusterTemplateChains: []string{testChainName},
+							Credentials:           []string{testCredentialName},
+						},
+					}),
+					accessmanagement.WithEnforceAccessRules(true),
+				),
+			},
+			err: fmt.Sprintf("the ClusterTemplate %s is not granted to the namespace %s by the AccessManagement rules", testTemplateName, metav1.NamespaceDefault),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
+			validator := &ClusterDeploymentValidator{Client: c, SystemNamespace: testSystemNamespace}
+			err := validator.validateAccessRules(t.Context(), tt.clusterDeployment, true, true)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateTemplateGrants(t *testing.T) {
+	const (
+		testSystemNamespace  = "test-system-namespace"
+		testCatalogNamespace = "catalog"
+	)
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   metav1.NamespaceDefault,
+			Labels: map[string]string{"environment": "dev"},
+		},
+	}
+	accessManagement := func(grants ...v1alpha1.ClusterTemplateGrant) *v1alpha1.AccessManagement {
+		return accessmanagement.NewAccessManagement(
+			accessmanagement.WithName(v1alpha1.AccessManagementName),
+			accessmanagement.WithAccessRules([]v1alpha1.AccessRule{
+				{
+					TargetNamespaces:      v1alpha1.TargetNamespaces{StringSelector: "environment=dev"},
+					ClusterTemplateGrants: grants,
+				},
+			}),
+		)
+	}
+	notGranted := fmt.Sprintf("the ClusterTemplate %s/%s is not granted to the namespace %s by the AccessManagement rules",
+		testCatalogNamespace, testTemplateName, metav1.NamespaceDefault)
+
+	tests := []struct {
+		name              string
+		clusterDeployment *v1alpha1.ClusterDeployment
+		existingObjects   []runtime.Object
+		err               string
+	}{
+		{
+			name:              "should succeed if the template is in the same namespace",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplate(testTemplateName)),
+		},
+		{
+			name: "should succeed in the system namespace",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithNamespace(testSystemNamespace),
+				clusterdeployment.WithClusterTemplateRef(testCatalogNamespace, testTemplateName),
+			),
+		},
+		{
+			name:              "should succeed if all of the templates of the namespace are granted",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplateRef(testCatalogNamespace, testTemplateName)),
+			existingObjects: []runtime.Object{
+				namespace,
+				accessManagement(v1alpha1.ClusterTemplateGrant{Namespace: testCatalogNamespace}),
+			},
+		},
+		{
+			name:              "should succeed if the template is granted",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplateRef(testCatalogNamespace, testTemplateName)),
+			existingObjects: []runtime.Object{
+				namespace,
+				accessManagement(v1alpha1.ClusterTemplateGrant{Namespace: testCatalogNamespace, ClusterTemplates: []string{testTemplateName}}),
+			},
+		},
+		{
+			name:              "should fail if the AccessManagement does not exist",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplateRef(testCatalogNamespace, testTemplateName)),
+			existingObjects:   []runtime.Object{namespace},
+			err:               notGranted,
+		},
+		{
+			name:              "should fail if the template is not granted",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplateRef(testCatalogNamespace, testTemplateName)),
+			existingObjects: []runtime.Object{
+				namespace,
+				accessManagement(v1alpha1.ClusterTemplateGrant{Namespace: testCatalogNamespace, ClusterTemplates: []string{"other-template"}}),
+			},
+			err: notGranted,
+		},
+		{
+			name:              "should fail if the templates of another namespace are granted",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplateRef(testCatalogNamespace, testTemplateName)),
+			existingObjects: []runtime.Object{
+				namespace,
+				accessManagement(v1alpha1.ClusterTemplateGrant{Namespace: "other"}),
+			},
+			err: notGranted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
+			validator := &ClusterDeploymentValidator{Client: c, SystemNamespace: testSystemNamespace}
+			err := validator.validateTemplateGrants(t.Context(), tt.clusterDeployment)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateClusterQuotas(t *testing.T) {
+	const tenantLabel = "tenant"
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   metav1.NamespaceDefault,
+			Labels: map[string]string{tenantLabel: "foo"},
+		},
+	}
+	clusterTemplate := template.NewClusterTemplate(template.WithConfigStatus(`{"controlPlaneNumber":1,"workersNumber":1}`))
+
+	tests := []struct {
+		name                 string
+		oldClusterDeployment *v1alpha1.ClusterDeployment
+		clusterDeployment    *v1alpha1.ClusterDeployment
+		existingObjects      []runtime.Object
+		err                  string
+	}{
+		{
+			name:              "should succeed if there are no quotas",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"workersNumber":100}`)),
+		},
+		{
+			name:              "should succeed if the quota does not select the namespace",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(),
+			existingObjects: []runtime.Object{
+				namespace,
+				clusterquota.NewClusterQuota(
+					clusterquota.WithNamespaceSelector(map[string]string{tenantLabel: "bar"}),
+					clusterquota.WithMaxClusters(0),
+				),
+			},
+		},
+		{
+			name:              "should fail if the number of clusters exceeds the quota",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(),
+			existingObjects: []runtime.Object{
+				namespace,
+				clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("other")),
+				clusterquota.NewClusterQuota(
+					clusterquota.WithNamespaceSelector(map[string]string{tenantLabel: "foo"}),
+					clusterquota.WithMaxClusters(1),
+				),
+			},
+			err: fmt.Sprintf("ClusterQuota %s: number of ClusterDeployments in namespace %s would exceed the limit of 1", clusterquota.DefaultName, metav1.NamespaceDefault),
+		},
+		{
+			name:              "should not count the ClusterDeployments being deleted",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(),
+			existingObjects: []runtime.Object{
+				namespace,
+				clusterdeployment.NewClusterDeployment(
+					clusterdeployment.WithName("other"),
+					clusterdeployment.WithConfig(`{"workersNumber":10}`),
+					clusterdeployment.WithDeletionTimestamp(metav1.Now()),
+					clusterdeployment.WithFinalizers(v1alpha1.ClusterDeploymentFinalizer),
+				),
+				clusterquota.NewClusterQuota(clusterquota.WithMaxClusters(1), clusterquota.WithMaxNodes(2)),
+			},
+		},
+		{
+			name:                 "should succeed to update if the number of clusters exceeds the quota",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(),
+			clusterDeployment:    clusterdeployment.NewClusterDeployment(),
+			existingObjects: []runtime.Object{
+				namespace,
+				clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("other")),
+				clusterquota.NewClusterQuota(clusterquota.WithMaxClusters(1)),
+			},
+		},
+		{
+			name:              "should fail if the number of nodes exceeds the quota",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"controlPlaneNumber":3,"workersNumber":2}`)),
+			existingObjects: []runtime.Object{
+				namespace,
+				clusterdeployment.NewClusterDeployment(
+					clusterdeployment.WithName("other"),
+					clusterdeployment.WithConfig(`{"controlPlaneNumber":1,"workersNumber":2}`),
+				),
+				clusterquota.NewClusterQuota(clusterquota.WithMaxNodes(7)),
+			},
+			err: fmt.Sprintf("ClusterQuota %s: total number of nodes in namespace %s would be 8 which exceeds the limit of 7", clusterquota.DefaultName, metav1.NamespaceDefault),
+		},
+		{
+			name:              "should fail if the number of nodes with the template defaults exceeds the quota",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"workersNumber":2}`)),
+			existingObjects: []runtime.Object{
+				namespace,
+				clusterquota.NewClusterQuota(clusterquota.WithMaxNodes(2)),
+			},
+			err: fmt.Sprintf("ClusterQuota %s: total number of nodes in namespace %s would be 3 which exceeds the limit of 2", clusterquota.DefaultName, metav1.NamespaceDefault),
+		},
+		{
+			name:              "should succeed if the number of nodes is within the quota",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"controlPlaneNumber":3,"workersNumber":2}`)),
+			existingObjects: []runtime.Object{
+				namespace,
+				clusterdeployment.NewClusterDeployment(
+					clusterdeployment.WithName("other"),
+					clusterdeployment.WithConfig(`{"controlPlaneNumber":1,"workersNumber":2}`),
+				),
+				clusterquota.NewClusterQuota(clusterquota.WithMaxNodes(8)),
+			},
+		},
+		{
+			name:                 "should succeed to update if the number of nodes is not increased",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"workersNumber":5}`)),
+			clusterDeployment:    clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"workersNumber":4}`)),
+			existingObjects: []runtime.Object{
+				namespace,
+				clusterquota.NewClusterQuota(clusterquota.WithMaxNodes(3)),
+			},
+		},
+		{
+			name:              "should fail if the instance size is not allowed",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"controlPlane":{"instanceType":"t3.small"},"worker":{"instanceType":"t3.2xlarge"}}`)),
+			existingObjects: []runtime.Object{
+				namespace,
+				clusterquota.NewClusterQuota(clusterquota.WithAllowedInstanceSizes("t3.small", "t3.medium")),
+			},
+			err: fmt.Sprintf(`ClusterQuota %s: instance size "t3.2xlarge" is not allowed, allowed sizes are: t3.small, t3.medium`, clusterquota.DefaultName),
+		},
+		{
+			name:              "should ignore the instance sizes outside of the node pools",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"bastion":{"instanceType":"t3.2xlarge"}}`)),
+			existingObjects: []runtime.Object{
+				namespace,
+				clusterquota.NewClusterQuota(clusterquota.WithAllowedInstanceSizes("t3.small")),
+			},
+		},
+		{
+			name:              "should succeed if the instance sizes are allowed",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"controlPlane":{"vmSize":"Standard_A4_v2"},"worker":{"vmSize":""}}`)),
+			existingObjects: []runtime.Object{
+				namespace,
+				clusterquota.NewClusterQuota(clusterquota.WithAllowedInstanceSizes("Standard_A4_v2")),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
+			validator := &ClusterDeploymentValidator{Client: c}
+			err := validator.validateClusterQuotas(t.Context(), tt.oldClusterDeployment, tt.clusterDeployment, clusterTemplate)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateProviderConfig(t *testing.T) {
+	const systemNamespace = "kcm-system"
+
+	catalog := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: configvalidation.CatalogConfigMapName, Namespace: systemNamespace},
+		Data:       map[string]string{"infrastructure-aws": "us-east-1: [t3.small, t3.medium]"},
+	}
+	awsTemplate := template.NewClusterTemplate(
+		template.WithProvidersStatus("infrastructure-aws", "control-plane-k0sproject-k0smotron"),
+		template.WithConfigStatus(`{"region":"us-east-1","worker":{"instanceType":"t3.small"}}`),
+	)
+
+	tests := []struct {
+		name                 string
+		oldClusterDeployment *v1alpha1.ClusterDeployment
+		clusterDeployment    *v1alpha1.ClusterDeployment
+		existingObjects      []runtime.Object
+		err                  string
+	}{
+		{
+			name:              "should succeed if there is no catalog",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"worker":{"instanceType":"t3.2xlarge"}}`)),
+		},
+		{
+			name:              "should succeed if the instance types are available in the region",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"controlPlane":{"instanceType":"t3.medium"}}`)),
+			existingObjects:   []runtime.Object{catalog},
+		},
+		{
+			name:              "should fail if the instance type is not available in the region",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"worker":{"instanceType":"t3.2xlarge"}}`)),
+			existingObjects:   []runtime.Object{catalog},
+			err: fmt.Sprintf(`ClusterDeployment.k0rdent.mirantis.com "%s" is invalid: spec.config.worker.instanceType: Unsupported value: "t3.2xlarge": supported values: "t3.small", "t3.medium"`,
+				clusterdeployment.DefaultName),
+		},
+		{
+			name:                 "should succeed to update if the configuration is not changed",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"worker":{"instanceType":"t3.2xlarge"}}`)),
+			clusterDeployment:    clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"worker":{"instanceType":"t3.2xlarge"}}`)),
+			existingObjects:      []runtime.Object{catalog},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
+			validator := &ClusterDeploymentValidator{Client: c, SystemNamespace: systemNamespace}
+			err := validator.validateProviderConfig(t.Context(), tt.oldClusterDeployment, tt.clusterDeployment, awsTemplate)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+				g.Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateConfigPolicies(t *testing.T) {
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   metav1.NamespaceDefault,
+			Labels: map[string]string{"tenant": "foo"},
+		},
+	}
+
+	oddControlPlane := configpolicy.WithRule("odd-control-plane", "config.controlPlaneNumber % 2 == 1", "controlPlaneNumber must be odd", "controlPlaneNumber")
+	approvedInstanceTypes := configpolicy.WithRule("approved-instance-types", "config.worker.instanceType in ['t3.small', 't3.medium']", "", "worker.instanceType")
+
+	tests := []struct {
+		name                 string
+		oldClusterDeployment *v1alpha1.ClusterDeployment
+		clusterDeployment    *v1alpha1.ClusterDeployment
+		template             *v1alpha1.ClusterTemplate
+		existingObjects      []runtime.Object
+		err                  string
+	}{
+		{
+			name:              "should succeed if there are no policies",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"controlPlaneNumber":2}`)),
+		},
+		{
+			name:              "should succeed if the policy does not select the namespace",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"controlPlaneNumber":2}`)),
+			existingObjects: []runtime.Object{
+				namespace,
+				configpolicy.NewConfigPolicy(configpolicy.WithNamespaceSelector(map[string]string{"tenant": "bar"}), oddControlPlane),
+			},
+		},
+		{
+			name:              "should succeed if the policy does not select the cluster",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"controlPlaneNumber":2}`)),
+			existingObjects: []runtime.Object{
+				namespace,
+				configpolicy.NewConfigPolicy(configpolicy.WithClusterSelector(map[string]string{"environment": "prod"}), oddControlPlane),
+			},
+		},
+		{
+			name:              "should succeed if the configuration satisfies the policy",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"controlPlaneNumber":3}`)),
+			existingObjects:   []runtime.Object{namespace, configpolicy.NewConfigPolicy(oddControlPlane)},
+		},
+		{
+			name:              "should fail if the configuration violates the policy",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"controlPlaneNumber":2,"worker":{"instanceType":"t3.2xlarge"}}`)),
+			existingObjects:   []runtime.Object{namespace, configpolicy.NewConfigPolicy(oddControlPlane, approvedInstanceTypes)},
+			err: fmt.Sprintf(`ClusterDeployment.k0rdent.mirantis.com "%s" is invalid: [`+
+				`spec.config.controlPlaneNumber: Forbidden: ConfigPolicy %[2]s rule odd-control-plane: controlPlaneNumber must be odd, `+
+				`spec.config.worker.instanceType: Forbidden: ConfigPolicy %[2]s rule approved-instance-types: failed expression: config.worker.instanceType in ['t3.small', 't3.medium']]`,
+				clusterdeployment.DefaultName, configpolicy.DefaultName),
+		},
+		{
+			name:              "should validate the configuration merged with the template defaults",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"worker":{"instanceType":"t3.small"}}`)),
+			template:          template.NewClusterTemplate(template.WithConfigStatus(`{"controlPlaneNumber":1,"worker":{"instanceType":"t3.2xlarge"}}`)),
+			existingObjects:   []runtime.Object{namespace, configpolicy.NewConfigPolicy(oddControlPlane, approvedInstanceTypes)},
+		},
+		{
+			name:                 "should succeed to update if the configuration is not changed",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"controlPlaneNumber":2}`)),
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithConfig(`{"controlPlaneNumber":2}`),
+				clusterdeployment.WithAnnotations(map[string]string{"foo": "bar"}),
+			),
+			existingObjects: []runtime.Object{namespace, configpolicy.NewConfigPolicy(oddControlPlane)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			if tt.template == nil {
+				tt.template = template.NewClusterTemplate()
+			}
+
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
+			validator := &ClusterDeploymentValidator{Client: c}
+			err := validator.validateConfigPolicies(t.Context(), tt.oldClusterDeployment, tt.clusterDeployment, tt.template)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+				g.Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateServiceSpec(t *testing.T) {
+	withServices := func(priority int32, syncMode string, services ...v1alpha1.Service) clusterdeployment.Opt {
+		return clusterdeployment.WithServiceSpec(v1alpha1.ServiceSpec{Priority: priority, SyncMode: syncMode, Services: services})
+	}
+	withServiceDelivery := func(delivery string) clusterdeployment.Opt {
+		return func(cd *v1alpha1.ClusterDeployment) {
+			cd.Spec.ServiceDelivery = delivery
+		}
+	}
+	ingress := v1alpha1.Service{Name: "ingress-nginx", Template: "ingress-nginx-4-12-0"}
+	ingressKubeSystem := v1alpha1.Service{Name: "ingress-nginx", Namespace: "kube-system", Template: "ingress-nginx-4-12-0"}
+	certManager := v1alpha1.Service{Name: "cert-manager", Template: "cert-manager-1-16-2"}
+
+	tests := []struct {
+		name                 string
+		oldClusterDeployment *v1alpha1.ClusterDeployment
+		clusterDeployment    *v1alpha1.ClusterDeployment
+		existingObjects      []runtime.Object
+		err                  string
+	}{
+		{
+			name:              "should succeed with the unique services",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(withServices(100, "", ingress, certManager)),
+		},
+		{
+			name:              "should fail if the service is repeated in the same namespace",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(withServices(100, "", ingress, certManager, v1alpha1.Service{Name: "ingress-nginx", Namespace: "ingress-nginx", Template: "ingress-nginx-4-11-0"})),
+			err: fmt.Sprintf(`ClusterDeployment.k0rdent.mirantis.com "%s" is invalid: spec.serviceSpec.services[2].name: Duplicate value: "ingress-nginx"`,
+				clusterdeployment.DefaultName),
+		},
+		{
+			name:              "should succeed if the service is installed to several namespaces by Sveltos",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(withServices(100, "", ingress, ingressKubeSystem)),
+			existingObjects:   []runtime.Object{mgmt},
+		},
+		{
+			name:              "should fail if the service is installed to several namespaces by Flux",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(withServices(100, "", ingress, ingressKubeSystem), withServiceDelivery(v1alpha1.ServiceDeliveryFlux)),
+			err: fmt.Sprintf(`ClusterDeployment.k0rdent.mirantis.com "%s" is invalid: spec.serviceSpec.services[1].namespace: Invalid value: "kube-system": service ingress-nginx is also installed to the namespace ingress-nginx, the Flux engine requires unique service names`,
+				clusterdeployment.DefaultName),
+		},
+		{
+			name:              "should succeed with the sync mode supported by Sveltos",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(withServices(100, "DryRun", ingress)),
+			existingObjects:   []runtime.Object{mgmt},
+		},
+		{
+			name:              "should fail with the sync mode not supported by the engine of the Edge profile",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(withServices(100, "ContinuousWithDriftDetection", ingress)),
+			existingObjects:   []runtime.Object{management.NewManagement(management.WithProfile(v1alpha1.ManagementProfileEdge))},
+			err: fmt.Sprintf(`ClusterDeployment.k0rdent.mirantis.com "%s" is invalid: spec.serviceSpec.syncMode: Unsupported value: "ContinuousWithDriftDetection": supported values: "Continuous"`,
+				clusterdeployment.DefaultName),
+		},
+		{
+			name:              "should report all the violations",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(withServices(math.MaxInt32, "OneTime", ingress, ingress), withServiceDelivery(v1alpha1.ServiceDeliveryApplier)),
+			err: fmt.Sprintf(`ClusterDeployment.k0rdent.mirantis.com "%s" is invalid: [spec.serviceSpec.services[1].name: Duplicate value: "ingress-nginx", `+
+				`spec.serviceSpec.priority: Invalid value: 2147483647: must be between 1 and 2147483646, `+
+				`spec.serviceSpec.syncMode: Unsupported value: "OneTime": supported values: "Continuous"]`,
+				clusterdeployment.DefaultName),
+		},
+		{
+			name:                 "should succeed to update if the service settings are not changed",
+			oldClusterDeployment: clusterdeployment.NewClusterDeployment(withServices(100, "", ingress, ingress)),
+			clusterDeployment:    clusterdeployment.NewClusterDeployment(withServices(100, "", ingress, ingress)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
+			validator := &ClusterDeploymentValidator{Client: c}
+			err := validator.validateServiceSpec(t.Context(), tt.oldClusterDeployment, tt.clusterDeployment)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+				g.Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateRenderValues(t *testing.T) {
+	withRenderValues := func(cd *v1alpha1.ClusterDeployment) {
+		cd.Spec.RenderValues = true
+	}
+	withServiceValues := func(values string) clusterdeployment.Opt {
+		return clusterdeployment.WithServiceSpec(v1alpha1.ServiceSpec{Services: []v1alpha1.Service{{Name: "ingress-nginx", Template: "ingress-nginx-4-12-0", Values: values}}})
+	}
+
+	tests := []struct {
+		name              string
+		clusterDeployment *v1alpha1.ClusterDeployment
+		err               string
+	}{
+		{
+			name:              "should succeed if the rendering is disabled",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"clusterName":"{{ .Cluster.Name"}`)),
+		},
+		{
+			name: "should succeed with the valid templates",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(withRenderValues,
+				clusterdeployment.WithConfig(`{"clusterName":"{{ .Cluster.Name }}","tags":["{{ .Cluster.Labels.env | default \"dev\" }}"]}`),
+				withServiceValues("cluster: {{ .Cluster.Name }}")),
+		},
+		{
+			name: "should fail with the invalid templates",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(withRenderValues,
+				clusterdeployment.WithConfig(`{"clusterName":"{{ .Cluster.Name"}`),
+				withServiceValues("cluster: {{ .Cluster.Name | unknown }}")),
+			err: fmt.Sprintf(`ClusterDeployment.k0rdent.mirantis.com "%s" is invalid: [spec.config: Invalid value: "": clusterName: template: values:1: unclosed action, `+
+				`spec.serviceSpec.services[0].values: Invalid value: "cluster: {{ .Cluster.Name | unknown }}": template: values:1: function "unknown" not defined]`,
+				clusterdeployment.DefaultName),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := validateRenderValues(tt.clusterDeployment)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+				g.Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateWindowsWorkers(t *testing.T) {
+	const windowsTemplateConfig = `{"workersNumber":2,"windowsWorkersNumber":0,"windowsWorker":{"vmSize":""}}`
+
+	tests := []struct {
+		name              string
+		clusterDeployment *v1alpha1.ClusterDeployment
+		template          *v1alpha1.ClusterTemplate
+		err               string
+	}{
+		{
+			name:              "should succeed if no Windows workers are requested",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"workersNumber":2,"windowsWorkersNumber":0}`)),
+			template:          template.NewClusterTemplate(template.WithProvidersStatus("infrastructure-aws")),
+		},
+		{
+			name:              "should fail if the provider does not support Windows workers",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"windowsWorkersNumber":2}`)),
+			template: template.NewClusterTemplate(
+				template.WithName(testTemplateName),
+				template.WithProvidersStatus("infrastructure-aws"),
+				template.WithConfigStatus(windowsTemplateConfig),
+			),
+			err: fmt.Sprintf("the ClusterTemplate %s does not support Windows worker nodes", testTemplateName),
+		},
+		{
+			name:              "should fail if the template does not follow the Windows workers conventions",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"windowsWorkersNumber":2}`)),
+			template: template.NewClusterTemplate(
+				template.WithName(testTemplateName),
+				template.WithProvidersStatus("infrastructure-azure"),
+				template.WithConfigStatus(`{"workersNumber":2}`),
+			),
+			err: fmt.Sprintf("the ClusterTemplate %s does not support Windows worker nodes", testTemplateName),
+		},
+		{
+			name:              "should fail if no Linux workers are requested",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"workersNumber":0,"windowsWorkersNumber":2}`)),
+			template: template.NewClusterTemplate(
+				template.WithProvidersStatus("infrastructure-vsphere"),
+				template.WithConfigStatus(windowsTemplateConfig),
+			),
+			err: "at least one Linux worker node is required along with the Windows worker nodes",
+		},
+		{
+			name:              "should succeed if the template supports Windows workers",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"windowsWorkersNumber":2}`)),
+			template: template.NewClusterTemplate(
+				template.WithProvidersStatus("infrastructure-azure"),
+				template.WithConfigStatus(windowsTemplateConfig),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := validateWindowsWorkers(tt.clusterDeployment, tt.template)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateTopologyVariables(t *testing.T) {
+	const clusterClassName = "quick-start"
+
+	clusterClass := &clusterapiv1beta1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterClassName, Namespace: metav1.NamespaceDefault},
+		Spec: clusterapiv1beta1.ClusterClassSpec{
+			Variables: []clusterapiv1beta1.ClusterClassVariable{
+				{
+					Name:     "imageRepository",
+					Required: true,
+					Schema: clusterapiv1beta1.VariableSchema{OpenAPIV3Schema: clusterapiv1beta1.JSONSchemaProps{
+						Type:      "string",
+						MinLength: ptr.To[int64](1),
+					}},
+				},
+				{
+					Name: "etcd",
+					Schema: clusterapiv1beta1.VariableSchema{OpenAPIV3Schema: clusterapiv1beta1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]clusterapiv1beta1.JSONSchemaProps{
+							"replicas": {Type: "integer", Minimum: ptr.To[int64](1)},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		clusterDeployment *v1alpha1.ClusterDeployment
+		template          *v1alpha1.ClusterTemplate
+		err               string
+	}{
+		{
+			name:              "should succeed if the template is not based on a ClusterClass",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"variables":{"unknown":true}}`)),
+			template:          template.NewClusterTemplate(),
+		},
+		{
+			name:              "should succeed if the variables are valid",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"variables":{"imageRepository":"registry.k8s.io","etcd":{"replicas":3}}}`)),
+			template:          template.NewClusterTemplate(template.WithClusterClass(clusterClassName, "")),
+		},
+		{
+			name:              "should fail if the ClusterClass does not exist",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"variables":{"imageRepository":"registry.k8s.io"}}`)),
+			template:          template.NewClusterTemplate(template.WithClusterClass(clusterClassName, "kcm-system")),
+			err:               `failed to get ClusterClass kcm-system/quick-start: clusterclasses.cluster.x-k8s.io "quick-start" not found`,
+		},
+		{
+			name:              "should fail if a required variable is not set",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"variables":{"etcd":{"replicas":3}}}`)),
+			template:          template.NewClusterTemplate(template.WithClusterClass(clusterClassName, "")),
+			err:               `required variable "imageRepository" is not set`,
+		},
+		{
+			name:              "should fail if a variable is not defined in the ClusterClass",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"variables":{"imageRepository":"registry.k8s.io","unknown":true}}`)),
+			template:          template.NewClusterTemplate(template.WithClusterClass(clusterClassName, "")),
+			err:               `variable "unknown" is not defined in the ClusterClass default/quick-start`,
+		},
+		{
+			name:              "should fail if a variable does not match the schema",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"variables":{"imageRepository":"registry.k8s.io","etcd":{"replicas":0}}}`)),
+			template:          template.NewClusterTemplate(template.WithClusterClass(clusterClassName, "")),
+			err:               "variables.etcd.replicas: Invalid value: 0: replicas in body should be greater than or equal to 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(clusterClass).Build()
+			validator := &ClusterDeploymentValidator{Client: c}
+
+			err := validator.validateTopologyVariables(t.Context(), tt.clusterDeployment, tt.template)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateExpiration(t *testing.T) {
+	maxExtension := &v1alpha1.ClusterExpirationPolicy{MaxExtension: &metav1.Duration{Duration: 48 * time.Hour}}
+
+	tests := []struct {
+		name              string
+		clusterDeployment *v1alpha1.ClusterDeployment
+		err               string
+	}{
+		{
+			name:              "should succeed without the extension",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithExpirationPolicy(maxExtension)),
+		},
+		{
+			name: "should fail if the extension is malformed",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.ExpirationExtensionAnnotation: "1d"}),
+			),
+			err: `invalid value "1d" of the k0rdent.mirantis.com/expiration-extension annotation, expected a non-negative duration`,
+		},
+		{
+			name: "should fail if the extension is negative",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.ExpirationExtensionAnnotation: "-1h"}),
+			),
+			err: `invalid value "-1h" of the k0rdent.mirantis.com/expiration-extension annotation, expected a non-negative duration`,
+		},
+		{
+			name: "should fail if the extension exceeds the maximum",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.ExpirationExtensionAnnotation: "72h"}),
+				clusterdeployment.WithExpirationPolicy(maxExtension),
+			),
+			err: "expiration extension 72h0m0s exceeds the maximum extension 48h0m0s",
+		},
+		{
+			name: "should succeed if the extension is within the maximum",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.ExpirationExtensionAnnotation: "24h"}),
+				clusterdeployment.WithExpirationPolicy(maxExtension),
+			),
+		},
+		{
+			name: "should succeed if the extension is not limited",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.ExpirationExtensionAnnotation: "720h"}),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := validateExpiration(tt.clusterDeployment)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateForceFinalize(t *testing.T) {
+	const uid = "8a1f3c2e-5d4b-4e6f-9a7b-0c1d2e3f4a5b"
+	deletionTimestamp := metav1.Now()
+
+	tests := []struct {
+		name              string
+		clusterDeployment *v1alpha1.ClusterDeployment
+		err               string
+	}{
+		{
+			name:              "should succeed without the annotation",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithUID(uid)),
+		},
+		{
+			name: "should fail if the ClusterDeployment is not being deleted",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithUID(uid),
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.ForceFinalizeAnnotation: uid}),
+			),
+			err: "the k0rdent.mirantis.com/force-finalize annotation can be set only on a ClusterDeployment being deleted",
+		},
+		{
+			name: "should fail if the value is not the UID",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithUID(uid),
+				clusterdeployment.WithDeletionTimestamp(deletionTimestamp),
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.ForceFinalizeAnnotation: "true"}),
+			),
+			err: `invalid value "true" of the k0rdent.mirantis.com/force-finalize annotation, expected the UID "` + uid + `" of the ClusterDeployment to confirm the forced finalization`,
+		},
+		{
+			name: "should succeed if the value is the UID",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithUID(uid),
+				clusterdeployment.WithDeletionTimestamp(deletionTimestamp),
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.ForceFinalizeAnnotation: uid}),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := validateForceFinalize(tt.clusterDeployment)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateLifecycleHooks(t *testing.T) {
+	window := func(schedule string, duration time.Duration) v1alpha1.MaintenanceWindow {
+		return v1alpha1.MaintenanceWindow{Schedule: schedule, Duration: metav1.Duration{Duration: duration}}
+	}
+	classTemplate := template.NewClusterTemplate(template.WithName(testTemplateName), template.WithClusterClass("aws-class", ""))
+
+	tests := []struct {
+		name              string
+		clusterDeployment *v1alpha1.ClusterDeployment
+		template          *v1alpha1.ClusterTemplate
+		err               string
+	}{
+		{
+			name:              "should succeed without the lifecycle hooks",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(),
+			template:          template.NewClusterTemplate(template.WithName(testTemplateName)),
+		},
+		{
+			name: "should fail if the template is not ClusterClass-based",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithLifecycleHooks(&v1alpha1.ClusterLifecycleHooks{
+				MaintenanceWindows: []v1alpha1.MaintenanceWindow{window("0 2 * * 6", 4*time.Hour)},
+			})),
+			template: template.NewClusterTemplate(template.WithName(testTemplateName), template.WithNamespace(metav1.NamespaceDefault)),
+			err:      "lifecycle hooks are supported only by the ClusterClass-based templates, ClusterTemplate default/" + testTemplateName + " does not reference a ClusterClass",
+		},
+		{
+			name: "should succeed with valid maintenance windows",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithLifecycleHooks(&v1alpha1.ClusterLifecycleHooks{
+				MaintenanceWindows: []v1alpha1.MaintenanceWindow{window("0 2 * * 6", 4*time.Hour), window("@daily", time.Hour)},
+			})),
+			template: classTemplate,
+		},
+		{
+			name: "should fail if the schedule is malformed",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithLifecycleHooks(&v1alpha1.ClusterLifecycleHooks{
+				MaintenanceWindows: []v1alpha1.MaintenanceWindow{window("0 2 * *", time.Hour)},
+			})),
+			template: classTemplate,
+			err:      `invalid maintenance window schedule "0 2 * *": expected exactly 5 fields, found 4: [0 2 * *]`,
+		},
+		{
+			name: "should fail if the duration is not positive",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithLifecycleHooks(&v1alpha1.ClusterLifecycleHooks{
+				MaintenanceWindows: []v1alpha1.MaintenanceWindow{window("0 2 * * 6", 0)},
+			})),
+			template: classTemplate,
+			err:      "maintenance window duration must be positive, got 0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := validateLifecycleHooks(tt.clusterDeployment, tt.template)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentDefault(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+
+	clusterDeploymentConfig := `{"foo":"bar"}`
+
+	tests := []struct {
+		name            string
+		input           *v1alpha1.ClusterDeployment
+		output          *v1alpha1.ClusterDeployment
+		existingObjects []runtime.Object
+		err             string
+	}{
+		{
+			name:   "should not set defaults if the config is provided",
+			input:  clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(clusterDeploymentConfig)),
+			output: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(clusterDeploymentConfig)),
+		},
+		{
+			name:   "should not store the defaults of the template",
+			input:  clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplate(testTemplateName)),
+			output: clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplate(testTemplateName)),
+			existingObjects: []runtime.Object{
+				mgmt,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithConfigStatus(clusterDeploymentConfig),
+				),
+			},
+		},
+		{
+			name: "should set the template referenced from another namespace",
+			input: clusterdeployment.NewClusterDeployment(func(cd *v1alpha1.ClusterDeployment) {
+				cd.Spec.TemplateRef = &v1alpha1.ClusterTemplateReference{Namespace: "catalog", Name: testTemplateName}
+			}),
+			output: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplateRef("catalog", testTemplateName),
+			),
+			existingObjects: []runtime.Object{mgmt},
+		},
+		{
+			name: "should merge the overlays of the ConfigPolicies keeping the nulls of the config",
+			input: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(`{"region":"us-west-1","bastion":null}`),
+				func(cd *v1alpha1.ClusterDeployment) { cd.Labels = map[string]string{"environment": "prod"} },
+			),
+			output: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(`{"bastion":null,"region":"us-west-1","worker":{"instanceType":"t3.large"}}`),
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.ConfigOverlaysAnnotation: "environments/prod"}),
+				func(cd *v1alpha1.ClusterDeployment) { cd.Labels = map[string]string{"environment": "prod"} },
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: clusterdeployment.DefaultNamespace}},
+				configpolicy.NewConfigPolicy(
+					configpolicy.WithName("environments"),
+					configpolicy.WithOverlay("prod", map[string]string{"environment": "prod"}, `{"bastion":{"enabled":true},"region":"eu-west-1","worker":{"instanceType":"t3.large"}}`),
+					configpolicy.WithOverlay("dev", map[string]string{"environment": "dev"}, `{"worker":{"instanceType":"t3.micro"}}`),
+				),
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithConfigStatus(`{"bastion":{"enabled":false},"region":"","worker":{"instanceType":"t3.small","rootVolumeSize":8}}`),
+				),
+			},
+		},
+		{
+			name: "should keep the partial config without the defaults",
+			input: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(`{"region":"us-west-1","worker":{"instanceType":"t3.large"},"bastion":null}`),
+				clusterdeployment.WithDryRun(true),
+			),
+			output: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithConfig(`{"region":"us-west-1","worker":{"instanceType":"t3.large"},"bastion":null}`),
+				clusterdeployment.WithDryRun(true),
+			),
+			existingObjects: []runtime.Object{
+				mgmt,
+				template.NewClusterTemplate(
+					template.WithName(testTemplateName),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+					template.WithConfigStatus(`{"bastion":{"enabled":false},"region":"","worker":{"instanceType":"t3.small","rootVolumeSize":8},"workersNumber":2}`),
+				),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
+			validator := &ClusterDeploymentValidator{Client: c}
+			err := validator.Default(ctx, tt.input)
+			if tt.err != "" {
+				g.Expect(err).To(HaveOccurred())
+				if err.Error() != tt.err {
+					t.Fatalf("expected error '%s', got error: %s", tt.err, err.Error())
+				}
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+			g.Expect(tt.input).To(Equal(tt.output))
+		})
+	}
+}
+
+func TestClusterDeploymentDefaultOnUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := admission.NewContextWithRequest(t.Context(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Update, DryRun: ptr.To(true)},
+	})
+
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(
+		mgmt,
+		template.NewClusterTemplate(
+			template.WithName(testTemplateName),
+			template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+			template.WithConfigStatus(`{"foo":"bar"}`),
+		),
+	).Build()
+	validator := &ClusterDeploymentValidator{Client: sideEffectFree(c)}
+
+	cd := clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplate(testTemplateName))
+	g.Expect(validator.Default(ctx, cd)).To(Succeed())
+	g.Expect(cd).To(Equal(clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplate(testTemplateName))))
+}
+
+func TestClusterDeploymentDefaultCloneFrom(t *testing.T) {
+	const sourceName = "source"
+
+	source := clusterdeployment.NewClusterDeployment(
+		clusterdeployment.WithName(sourceName),
+		clusterdeployment.WithClusterTemplate(testTemplateName),
+		clusterdeployment.WithCredential(testCredentialName),
+		clusterdeployment.WithConfig(`{"region":"us-east-2","workersNumber":3}`),
+		clusterdeployment.WithServiceTemplate(testSvcTemplate1Name),
+	)
+
+	tests := []struct {
+		name      string
+		operation admissionv1.Operation
+		input     *v1alpha1.ClusterDeployment
+		output    *v1alpha1.ClusterDeployment
+		err       string
+	}{
+		{
+			name:      "should copy the spec of the source on creation",
+			operation: admissionv1.Create,
+			input:     clusterdeployment.NewClusterDeployment(clusterdeployment.WithCloneFrom(sourceName)),
+			output: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithCloneFrom(sourceName),
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential(testCredentialName),
+				clusterdeployment.WithConfig(`{"region":"us-east-2","workersNumber":3}`),
+				clusterdeployment.WithServiceTemplate(testSvcTemplate1Name),
+			),
+		},
+		{
+			name:      "should preserve the overrides and merge the config",
+			operation: admissionv1.Create,
+			input: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithCloneFrom(sourceName),
+				clusterdeployment.WithCredential("other-cred"),
+				clusterdeployment.WithConfig(`{"region":"us-west-1"}`),
+			),
+			output: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithCloneFrom(sourceName),
+				clusterdeployment.WithClusterTemplate(testTemplateName),
+				clusterdeployment.WithCredential("other-cred"),
+				clusterdeployment.WithConfig(`{"region":"us-west-1","workersNumber":3}`),
+				clusterdeployment.WithServiceTemplate(testSvcTemplate1Name),
+			),
+		},
+		{
+			name:      "should not clone on update",
+			operation: admissionv1.Update,
+			input: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithCloneFrom(sourceName),
+				clusterdeployment.WithConfig(`{"region":"us-west-1"}`),
+			),
+			output: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithCloneFrom(sourceName),
+				clusterdeployment.WithConfig(`{"region":"us-west-1"}`),
+			),
+		},
+		{
+			name:      "should fail if the source does not exist",
+			operation: admissionv1.Create,
+			input:     clusterdeployment.NewClusterDeployment(clusterdeployment.WithCloneFrom("absent")),
+			output:    clusterdeployment.NewClusterDeployment(clusterdeployment.WithCloneFrom("absent")),
+			err:       `failed to get ClusterDeployment default/absent to clone from: clusterdeployments.k0rdent.mirantis.com "absent" not found`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := admission.NewContextWithRequest(t.Context(), admission.Request{
+				AdmissionRequest: admissionv1.AdmissionRequest{Operation: tt.operation},
+			})
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(source).Build()
+			validator := &ClusterDeploymentValidator{Client: c}
+			err := validator.Default(ctx, tt.input)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+			g.Expect(tt.input).To(Equal(tt.output))
+		})
+	}
+}
+
+func TestClusterDeploymentBudgetWarnings(t *testing.T) {
+	const systemNamespace = "kcm-system"
+
+	priceList := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: cost.PriceListConfigMapName, Namespace: systemNamespace},
+		Data: map[string]string{
+			"infrastructure-aws": "t3.small: 0.02\nt3.large: 0.08",
+		},
+	}
+
+	awsTemplate := template.NewClusterTemplate(
+		template.WithProvidersStatus("infrastructure-aws"),
+		template.WithConfigStatus(`{"controlPlaneNumber":1,"controlPlane":{"instanceType":"t3.small"},"workersNumber":1,"worker":{"instanceType":"t3.small"}}`),
+	)
+
+	tests := []struct {
+		name              string
+		clusterDeployment *v1alpha1.ClusterDeployment
+		existingObjects   []runtime.Object
+		warnings          admission.Warnings
+	}{
+		{
+			name:              "should not warn without budget",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"workersNumber":10}`)),
+			existingObjects:   []runtime.Object{priceList},
+		},
+		{
+			name: "should not warn if the cost is within the budget",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.MonthlyBudgetAnnotation: "100"}),
+				clusterdeployment.WithConfig(`{"workersNumber":2}`),
+			),
+			existingObjects: []runtime.Object{priceList},
+		},
+		{
+			name: "should not warn without price list",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.MonthlyBudgetAnnotation: "10"}),
+			),
+		},
+		{
+			name: "should warn if the cost exceeds the budget",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.MonthlyBudgetAnnotation: "100"}),
+				clusterdeployment.WithConfig(`{"workersNumber":2,"worker":{"instanceType":"t3.large"}}`),
+			),
+			existingObjects: []runtime.Object{priceList},
+			warnings:        admission.Warnings{"The estimated monthly cost 131.40 USD of the cluster exceeds the monthly budget 100"},
+		},
+		{
+			name: "should warn about unpriced instance types",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.MonthlyBudgetAnnotation: "100"}),
+				clusterdeployment.WithConfig(`{"worker":{"instanceType":"m5.large"}}`),
+			),
+			existingObjects: []runtime.Object{priceList},
+			warnings:        admission.Warnings{"The instance types m5.large are missing in the price list and not accounted in the estimated cost"},
+		},
+		{
+			name: "should warn about invalid budget",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithAnnotations(map[string]string{v1alpha1.MonthlyBudgetAnnotation: "lots"}),
+			),
+			warnings: admission.Warnings{fmt.Sprintf(`Invalid value "lots" of the %s annotation, expected a non-negative number`, v1alpha1.MonthlyBudgetAnnotation)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(tt.existingObjects...).
+				Build()
+			validator := &ClusterDeploymentValidator{Client: c, SystemNamespace: systemNamespace}
+			g.Expect(validator.budgetWarnings(t.Context(), tt.clusterDeployment, awsTemplate)).To(Equal(tt.warnings))
+		})
+	}
+}
+
+func TestClusterDeploymentKubernetesSupportWarnings(t *testing.T) {
+	now := time.Now()
+	supportedRelease := release.New(
+		release.WithName("kcm-1-0-0"),
+		release.WithKubernetesVersions(
+			v1alpha1.KubernetesVersionSupport{Version: "1.30", EndOfLife: metav1.NewTime(now.Add(-24 * time.Hour))},
+			v1alpha1.KubernetesVersionSupport{Version: "1.31", EndOfLife: metav1.NewTime(now.Add(30 * 24 * time.Hour))},
+			v1alpha1.KubernetesVersionSupport{Version: "1.32", EndOfLife: metav1.NewTime(now.Add(365 * 24 * time.Hour))},
+		),
+	)
+	releaseMgmt := management.NewManagement(management.WithRelease("kcm-1-0-0"))
+
+	tests := []struct {
+		name              string
+		kubernetesVersion string
+		existingObjects   []runtime.Object
+		warnings          admission.Warnings
+	}{
+		{
+			name:              "should not warn without Release",
+			kubernetesVersion: "v1.30.5",
+			existingObjects:   []runtime.Object{releaseMgmt},
+		},
+		{
+			name:              "should not warn about supported version",
+			kubernetesVersion: "v1.32.1+k0s.0",
+			existingObjects:   []runtime.Object{releaseMgmt, supportedRelease},
+		},
+		{
+			name:              "should not warn about untracked version",
+			kubernetesVersion: "v1.33.0",
+			existingObjects:   []runtime.Object{releaseMgmt, supportedRelease},
+		},
+		{
+			name:              "should warn about version approaching end of life",
+			kubernetesVersion: "v1.31.2",
+			existingObjects:   []runtime.Object{releaseMgmt, supportedRelease},
+			warnings: admission.Warnings{fmt.Sprintf("Kubernetes version v1.31.2 of the ClusterTemplate %s reaches its end of life on %s, consider a ClusterTemplate with a supported version",
+				testTemplateName, now.Add(30*24*time.Hour).UTC().Format(time.DateOnly))},
+		},
+		{
+			name:              "should warn about version past end of life",
+			kubernetesVersion: "v1.30.5",
+			existingObjects:   []runtime.Object{releaseMgmt, supportedRelease},
+			warnings: admission.Warnings{fmt.Sprintf("Kubernetes version v1.30.5 of the ClusterTemplate %s reached its end of life on %s, consider a ClusterTemplate with a supported version",
+				testTemplateName, now.Add(-24*time.Hour).UTC().Format(time.DateOnly))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(tt.existingObjects...).
+				Build()
+			validator := &ClusterDeploymentValidator{Client: c}
+			tpl := template.NewClusterTemplate(template.WithName(testTemplateName), template.WithClusterStatusK8sVersion(tt.kubernetesVersion))
+			g.Expect(validator.kubernetesSupportWarnings(t.Context(), tpl)).To(Equal(tt.warnings))
+		})
+	}
+}
+
+func TestClusterDeploymentValidateFailureDomains(t *testing.T) {
+	zones := []string{"us-east-1a", "us-east-1b", "us-east-1c"}
+
+	supportedTemplate := template.NewClusterTemplate(
+		template.WithName("aws-standalone-cp"),
+		template.WithConfigStatus(`{"controlPlaneNumber":3,"failureDomains":{"controlPlane":[],"workers":[]}}`),
+	)
+	unsupportedTemplate := template.NewClusterTemplate(
+		template.WithName("vsphere-standalone-cp"),
+		template.WithConfigStatus(`{"controlPlaneNumber":3}`),
+	)
+
+	tests := []struct {
+		name              string
+		clusterDeployment *v1alpha1.ClusterDeployment
+		template          *v1alpha1.ClusterTemplate
+		err               string
+	}{
+		{
+			name:              "should succeed if the failure domains are not configured",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(),
+			template:          unsupportedTemplate,
+		},
+		{
+			name: "should fail if the template does not support failure domains",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithFailureDomains(&v1alpha1.ClusterFailureDomains{Workers: zones}),
+			),
+			template: unsupportedTemplate,
+			err:      "the ClusterTemplate vsphere-standalone-cp does not support failure domains",
+		},
+		{
+			name: "should succeed if the machines are spread across the failure domains",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithFailureDomains(&v1alpha1.ClusterFailureDomains{ControlPlane: zones, Workers: zones}),
+			),
+			template: supportedTemplate,
+		},
+		{
+			name: "should fail if the control plane of multiple machines is in a single failure domain",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithFailureDomains(&v1alpha1.ClusterFailureDomains{ControlPlane: zones[:1]}),
+			),
+			template: supportedTemplate,
+			err:      "the control plane of 3 machines must be spread across at least 2 failure domains, got us-east-1a",
+		},
+		{
+			name: "should succeed if the control plane of a single machine is in a single failure domain",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithConfig(`{"controlPlaneNumber":1}`),
+				clusterdeployment.WithFailureDomains(&v1alpha1.ClusterFailureDomains{ControlPlane: zones[:1]}),
+			),
+			template: supportedTemplate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := validateFailureDomains(tt.clusterDeployment, tt.template)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateNetworks(t *testing.T) {
+	supportedTemplate := template.NewClusterTemplate(
+		template.WithName("vsphere-standalone-cp"),
+		template.WithConfigStatus(`{"clusterNetwork":{"pods":{"cidrBlocks":["10.244.0.0/16"]}},"networks":{"additional":[]}}`),
+	)
+	unsupportedTemplate := template.NewClusterTemplate(
+		template.WithName("aws-standalone-cp"),
+		template.WithConfigStatus(`{}`),
+	)
+
+	tests := []struct {
+		name              string
+		clusterDeployment *v1alpha1.ClusterDeployment
+		template          *v1alpha1.ClusterTemplate
+		err               string
+	}{
+		{
+			name:              "should succeed if the networks are not configured",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(),
+			template:          unsupportedTemplate,
+		},
+		{
+			name: "should fail if the template does not support additional networks",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithNetworks(&v1alpha1.ClusterNetworks{Storage: &v1alpha1.NodeNetwork{Name: "storage"}}),
+			),
+			template: unsupportedTemplate,
+			err:      "the ClusterTemplate aws-standalone-cp does not support additional networks",
+		},
+		{
+			name: "should succeed if the subnets do not overlap",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithNetworks(&v1alpha1.ClusterNetworks{
+					Additional: []v1alpha1.NodeNetwork{{Name: "backend", Subnets: []string{"10.10.0.0/24"}}},
+					Storage:    &v1alpha1.NodeNetwork{Name: "storage", Subnets: []string{"10.20.0.0/24"}},
+				}),
+			),
+			template: supportedTemplate,
+		},
+		{
+			name: "should fail if a subnet overlaps with the pods CIDR of the template",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithNetworks(&v1alpha1.ClusterNetworks{
+					Storage: &v1alpha1.NodeNetwork{Name: "storage", Subnets: []string{"10.244.10.0/24"}},
+				}),
+			),
+			template: supportedTemplate,
+			err:      "subnet 10.244.10.0/24 of network storage overlaps with subnet 10.244.0.0/16 of cluster pods",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := validateNetworks(tt.clusterDeployment, tt.template)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateProxy(t *testing.T) {
+	supportedTemplate := template.NewClusterTemplate(
+		template.WithName("aws-standalone-cp"),
+		template.WithConfigStatus(`{"proxy":{"httpProxy":"","httpsProxy":"","noProxy":[]}}`),
+	)
+	unsupportedTemplate := template.NewClusterTemplate(
+		template.WithName("aws-eks"),
+		template.WithConfigStatus(`{}`),
+	)
+
+	tests := []struct {
+		name              string
+		clusterDeployment *v1alpha1.ClusterDeployment
+		template          *v1alpha1.ClusterTemplate
+		err               string
+	}{
+		{
+			name:              "should succeed if the proxy is not configured",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(),
+			template:          unsupportedTemplate,
+		},
+		{
+			name: "should fail if the template does not support the proxy",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithProxy(&v1alpha1.ClusterProxy{HTTPSProxy: "http://proxy.example.com:3128"}),
+			),
+			template: unsupportedTemplate,
+			err:      "the ClusterTemplate aws-eks does not support the proxy",
+		},
+		{
+			name: "should succeed if the template supports the proxy",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithProxy(&v1alpha1.ClusterProxy{HTTPSProxy: "http://proxy.example.com:3128", NoProxy: []string{"10.0.0.0/16"}}),
+			),
+			template: supportedTemplate,
+		},
+		{
+			name: "should fail if the proxy URL is invalid",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithProxy(&v1alpha1.ClusterProxy{HTTPProxy: "http://:3128"}),
+			),
+			template: supportedTemplate,
+			err:      "invalid proxy URL http://:3128: the host is missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := validateProxy(tt.clusterDeployment, tt.template)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateMachineAccess(t *testing.T) {
+	const publicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIC+sz7OezjP7/HoX5slsmx3U6/uV9PWYuv3eCRARAeCr alice"
+
+	supportedTemplate := template.NewClusterTemplate(
+		template.WithName("aws-standalone-cp"),
+		template.WithConfigStatus(`{"machineAccess":{"user":"","authorizedKeys":""},"bastion":{"enabled":false,"allowedCIDRBlocks":[]}}`),
+	)
+	unsupportedTemplate := template.NewClusterTemplate(
+		template.WithName("aws-eks"),
+		template.WithConfigStatus(`{}`),
+	)
+
+	tests := []struct {
+		name              string
+		clusterDeployment *v1alpha1.ClusterDeployment
+		template          *v1alpha1.ClusterTemplate
+		err               string
+	}{
+		{
+			name:              "should succeed if the machine access is not configured",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(),
+			template:          unsupportedTemplate,
+		},
+		{
+			name: "should fail if the template does not support the SSH keys",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithMachineAccess(&v1alpha1.MachineAccess{User: "ubuntu", AuthorizedKeys: []string{publicKey}}),
+			),
+			template: unsupportedTemplate,
+			err:      "invalid machine access for the ClusterTemplate aws-eks: the template does not support authorizing the SSH keys",
+		},
+		{
+			name: "should succeed if the template supports the SSH keys and the bastion host",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithMachineAccess(&v1alpha1.MachineAccess{
+					User:                    "ubuntu",
+					AuthorizedKeys:          []string{publicKey},
+					AuthorizedKeysConfigMap: "fleet-keys",
+					Bastion:                 &v1alpha1.MachineBastion{Enabled: true, AllowedCIDRBlocks: []string{"10.0.0.0/8"}},
+				}),
+			),
+			template: supportedTemplate,
+		},
+		{
+			name: "should fail if the public SSH key is invalid",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithMachineAccess(&v1alpha1.MachineAccess{User: "ubuntu", AuthorizedKeys: []string{"ssh-rsa invalid"}}),
+			),
+			template: supportedTemplate,
+			err:      `invalid machine access for the ClusterTemplate aws-standalone-cp: invalid public SSH key "ssh-rsa invalid": ssh: no key found`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := validateMachineAccess(tt.clusterDeployment, tt.template)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateCloudTags(t *testing.T) {
+	supportedTemplate := template.NewClusterTemplate(
+		template.WithName("aws-standalone-cp"),
+		template.WithConfigStatus(`{"cloudTags":{}}`),
+	)
+	unsupportedTemplate := template.NewClusterTemplate(
+		template.WithName("vsphere-standalone-cp"),
+		template.WithConfigStatus(`{}`),
+	)
+	policyMgmt := management.NewManagement(management.WithMandatoryCloudTags("cost-center", "owner"))
+
+	tests := []struct {
+		name              string
+		clusterDeployment *v1alpha1.ClusterDeployment
+		template          *v1alpha1.ClusterTemplate
+		existingObjects   []runtime.Object
+		err               string
+	}{
+		{
+			name:              "should succeed if the template does not support the cloud tags regardless of the policy",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(),
+			template:          unsupportedTemplate,
+			existingObjects:   []runtime.Object{policyMgmt},
+		},
+		{
+			name: "should fail if the template does not support the cloud tags",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithCloudTags(map[string]string{"owner": "team-a"}),
+			),
+			template: unsupportedTemplate,
+			err:      "the ClusterTemplate vsphere-standalone-cp does not support the cloud tags",
+		},
+		{
+			name:              "should succeed without the policy",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(),
+			template:          supportedTemplate,
+			existingObjects:   []runtime.Object{mgmt},
+		},
+		{
+			name: "should fail if the mandatory tags are missing",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithCloudTags(map[string]string{"owner": "team-a", "cost-center": ""}),
+			),
+			template:        supportedTemplate,
+			existingObjects: []runtime.Object{policyMgmt},
+			err:             "the mandatory cloud tags are not set: cost-center",
+		},
+		{
+			name: "should succeed if the mandatory tags are set",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithCloudTags(map[string]string{"owner": "team-a", "cost-center": "cc-1"}),
+			),
+			template:        supportedTemplate,
+			existingObjects: []runtime.Object{policyMgmt},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
+			validator := &ClusterDeploymentValidator{Client: c}
+			err := validator.validateCloudTags(t.Context(), tt.clusterDeployment, tt.template)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterDeploymentValidateOSImages(t *testing.T) {
+	ubuntu := &v1alpha1.OSImage{
+		ObjectMeta: metav1.ObjectMeta{Name: "ubuntu-22-04"},
+		Spec:       v1alpha1.OSImageSpec{Provider: "infrastructure-aws"},
+		Status: v1alpha1.OSImageStatus{
+			Images: []v1alpha1.OSImageEntry{{KubernetesVersion: "v1.32", Region: "us-east-1", ID: "ami-0123456789abcdef0"}},
+		},
+	}
+	awsTemplate := template.NewClusterTemplate(
+		template.WithProvidersStatus("infrastructure-aws", "control-plane-k0sproject-k0smotron"),
+		template.WithClusterStatusK8sVersion("v1.32.2+k0s.0"),
+		template.WithConfigStatus(`{"region":"us-east-1","worker":{"amiID":"osimage:ubuntu-22-04"}}`),
+	)
+
+	tests := []struct {
+		name              string
+		clusterDeployment *v1alpha1.ClusterDeployment
+		existingObjects   []runtime.Object
+		err               string
+	}{
+		{
+			name:              "should succeed if the referenced images are overridden",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"worker":{"amiID":"ami-custom"}}`)),
+		},
+		{
+			name:              "should succeed if the referenced images resolve",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"controlPlane":{"amiID":"osimage:ubuntu-22-04"}}`)),
+			existingObjects:   []runtime.Object{ubuntu},
+		},
+		{
+			name:              "should fail if the referenced OSImage does not exist",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(),
+			err:               `failed to get OSImage ubuntu-22-04 referenced by worker.amiID: osimages.k0rdent.mirantis.com "ubuntu-22-04" not found`,
+		},
+		{
+			name:              "should fail if no image matches the region",
+			clusterDeployment: clusterdeployment.NewClusterDeployment(clusterdeployment.WithConfig(`{"region":"eu-west-1"}`)),
+			existingObjects:   []runtime.Object{ubuntu},
+			err:               `OSImage ubuntu-22-04 referenced by worker.amiID has no image of the Kubernetes version v1.32.2+k0s.0 in the region "eu-west-1"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
+			validator := &ClusterDeploymentValidator{Client: c}
+			err := validator.validateOSImages(t.Context(), tt.clusterDeployment, awsTemplate)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
 		})
 	}
 }
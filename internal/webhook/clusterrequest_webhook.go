@@ -0,0 +1,143 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+var (
+	errClusterRequestSpecImmutable     = errors.New("ClusterRequest spec cannot be changed once the decision has been made")
+	errClusterRequestDecisionImmutable = errors.New("ClusterRequest decision cannot be changed once made")
+)
+
+type ClusterRequestValidator struct {
+	client.Client
+}
+
+func (v *ClusterRequestValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	v.Client = sideEffectFree(mgr.GetClient())
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.ClusterRequest{}).
+		WithValidator(v).
+		Complete()
+}
+
+var _ webhook.CustomValidator = &ClusterRequestValidator{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (v *ClusterRequestValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	clusterRequest, ok := obj.(*v1alpha1.ClusterRequest)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected ClusterRequest but got a %T", obj))
+	}
+
+	if err := v.validateTemplate(ctx, clusterRequest); err != nil {
+		return nil, err
+	}
+
+	if clusterRequest.Spec.Credential != "" {
+		if err := v.validateCredential(ctx, clusterRequest.Namespace, clusterRequest.Spec.Credential); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (v *ClusterRequestValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldClusterRequest, ok := oldObj.(*v1alpha1.ClusterRequest)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected ClusterRequest but got a %T", oldObj))
+	}
+	newClusterRequest, ok := newObj.(*v1alpha1.ClusterRequest)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected ClusterRequest but got a %T", newObj))
+	}
+
+	if oldClusterRequest.Status.Approval != nil {
+		if !equality.Semantic.DeepEqual(oldClusterRequest.Spec, newClusterRequest.Spec) {
+			return nil, errClusterRequestSpecImmutable
+		}
+		if !equality.Semantic.DeepEqual(oldClusterRequest.Status.Approval, newClusterRequest.Status.Approval) {
+			return nil, errClusterRequestDecisionImmutable
+		}
+
+		return nil, nil
+	}
+
+	if !equality.Semantic.DeepEqual(oldClusterRequest.Spec, newClusterRequest.Spec) {
+		if _, err := v.ValidateCreate(ctx, newClusterRequest); err != nil {
+			return nil, err
+		}
+	}
+
+	approval := newClusterRequest.Status.Approval
+	if approval == nil || approval.Decision != v1alpha1.ClusterRequestDecisionApproved {
+		return nil, nil
+	}
+
+	credential := newClusterRequest.Spec.Credential
+	if approval.Credential != "" {
+		credential = approval.Credential
+	}
+	if credential == "" {
+		return nil, errors.New("the credential must be set either in the request or in the approval")
+	}
+	if err := v.validateCredential(ctx, newClusterRequest.Namespace, credential); err != nil {
+		return nil, err
+	}
+
+	return nil, v.validateTemplate(ctx, newClusterRequest)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (*ClusterRequestValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ClusterRequestValidator) validateTemplate(ctx context.Context, clusterRequest *v1alpha1.ClusterRequest) error {
+	template := new(v1alpha1.ClusterTemplate)
+	if err := v.Get(ctx, client.ObjectKey{Namespace: clusterRequest.Namespace, Name: clusterRequest.Spec.Template}, template); err != nil {
+		return fmt.Errorf("failed to get ClusterTemplate %s/%s: %w", clusterRequest.Namespace, clusterRequest.Spec.Template, err)
+	}
+
+	if !template.Status.Valid {
+		return fmt.Errorf("the ClusterTemplate %s/%s is not valid: %s", template.Namespace, template.Name, template.Status.ValidationError)
+	}
+
+	return nil
+}
+
+func (v *ClusterRequestValidator) validateCredential(ctx context.Context, namespace, name string) error {
+	if err := v.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, new(v1alpha1.Credential)); err != nil {
+		return fmt.Errorf("failed to get Credential %s/%s: %w", namespace, name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/objects/clusterrequest"
+	"github.com/K0rdent/kcm/test/objects/credential"
+	"github.com/K0rdent/kcm/test/objects/template"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestClusterRequestValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+
+	validTemplate := template.NewClusterTemplate(template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}))
+
+	tests := []struct {
+		name            string
+		clusterRequest  *v1alpha1.ClusterRequest
+		existingObjects []runtime.Object
+		err             string
+	}{
+		{
+			name:           "should fail if the ClusterTemplate does not exist",
+			clusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName)),
+			err:            `failed to get ClusterTemplate default/template: clustertemplates.k0rdent.mirantis.com "template" not found`,
+		},
+		{
+			name:           "should fail if the ClusterTemplate is not valid",
+			clusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName)),
+			existingObjects: []runtime.Object{
+				template.NewClusterTemplate(template.WithValidationStatus(v1alpha1.TemplateValidationStatus{ValidationError: "chart not found"})),
+			},
+			err: "the ClusterTemplate default/template is not valid: chart not found",
+		},
+		{
+			name: "should fail if the Credential does not exist",
+			clusterRequest: clusterrequest.NewClusterRequest(
+				clusterrequest.WithTemplate(template.DefaultName),
+				clusterrequest.WithCredential(credential.DefaultName),
+			),
+			existingObjects: []runtime.Object{validTemplate},
+			err:             `failed to get Credential default/credential: credentials.k0rdent.mirantis.com "credential" not found`,
+		},
+		{
+			name: "should succeed",
+			clusterRequest: clusterrequest.NewClusterRequest(
+				clusterrequest.WithTemplate(template.DefaultName),
+				clusterrequest.WithCredential(credential.DefaultName),
+			),
+			existingObjects: []runtime.Object{validTemplate, credential.NewCredential()},
+		},
+		{
+			name:            "should succeed without the Credential",
+			clusterRequest:  clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName)),
+			existingObjects: []runtime.Object{validTemplate},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
+			validator := &ClusterRequestValidator{Client: c}
+			warn, err := validator.ValidateCreate(ctx, tt.clusterRequest)
+			if tt.err != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(Equal(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+			g.Expect(warn).To(BeEmpty())
+		})
+	}
+}
+
+func TestClusterRequestValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+
+	existingObjects := []runtime.Object{
+		template.NewClusterTemplate(template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true})),
+		credential.NewCredential(),
+	}
+
+	tests := []struct {
+		name              string
+		oldClusterRequest *v1alpha1.ClusterRequest
+		newClusterRequest *v1alpha1.ClusterRequest
+		err               string
+	}{
+		{
+			name:              "should fail if the spec is changed after the decision",
+			oldClusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName), clusterrequest.WithApproval(v1alpha1.ClusterRequestDecisionDenied, "")),
+			newClusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName), clusterrequest.WithApproval(v1alpha1.ClusterRequestDecisionDenied, ""), clusterrequest.WithSize(3, 3)),
+			err:               errClusterRequestSpecImmutable.Error(),
+		},
+		{
+			name:              "should fail if the decision is changed",
+			oldClusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName), clusterrequest.WithApproval(v1alpha1.ClusterRequestDecisionDenied, "")),
+			newClusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName), clusterrequest.WithApproval(v1alpha1.ClusterRequestDecisionApproved, credential.DefaultName)),
+			err:               errClusterRequestDecisionImmutable.Error(),
+		},
+		{
+			name:              "should fail if the request is approved without the Credential",
+			oldClusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName)),
+			newClusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName), clusterrequest.WithApproval(v1alpha1.ClusterRequestDecisionApproved, "")),
+			err:               "the credential must be set either in the request or in the approval",
+		},
+		{
+			name:              "should fail if the request is approved with the nonexistent Credential",
+			oldClusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName)),
+			newClusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName), clusterrequest.WithApproval(v1alpha1.ClusterRequestDecisionApproved, "other")),
+			err:               `failed to get Credential default/other: credentials.k0rdent.mirantis.com "other" not found`,
+		},
+		{
+			name:              "should succeed if the request is approved with the Credential",
+			oldClusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName)),
+			newClusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName), clusterrequest.WithApproval(v1alpha1.ClusterRequestDecisionApproved, credential.DefaultName)),
+		},
+		{
+			name:              "should succeed if the request is denied",
+			oldClusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName)),
+			newClusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName), clusterrequest.WithApproval(v1alpha1.ClusterRequestDecisionDenied, "")),
+		},
+		{
+			name:              "should succeed if the spec is changed before the decision",
+			oldClusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName)),
+			newClusterRequest: clusterrequest.NewClusterRequest(clusterrequest.WithTemplate(template.DefaultName), clusterrequest.WithSize(1, 2)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(existingObjects...).Build()
+			validator := &ClusterRequestValidator{Client: c}
+			warn, err := validator.ValidateUpdate(ctx, tt.oldClusterRequest, tt.newClusterRequest)
+			if tt.err != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(Equal(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+			g.Expect(warn).To(BeEmpty())
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/configpolicy"
+)
+
+type ConfigPolicyValidator struct{}
+
+func (v *ConfigPolicyValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&kcmv1.ConfigPolicy{}).
+		WithValidator(v).
+		Complete()
+}
+
+var _ webhook.CustomValidator = &ConfigPolicyValidator{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (*ConfigPolicyValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	policy, ok := obj.(*kcmv1.ConfigPolicy)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected ConfigPolicy but got a %T", obj))
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(&policy.Spec.NamespaceSelector); err != nil {
+		return nil, fmt.Errorf("invalid namespace selector: %w", err)
+	}
+	if _, err := metav1.LabelSelectorAsSelector(&policy.Spec.ClusterSelector); err != nil {
+		return nil, fmt.Errorf("invalid cluster selector: %w", err)
+	}
+
+	if err := configpolicy.Compile(policy); err != nil {
+		return nil, fmt.Errorf("invalid ConfigPolicy rules: %w", err)
+	}
+
+	for _, overlay := range policy.Spec.Overlays {
+		if _, err := metav1.LabelSelectorAsSelector(&overlay.Selector); err != nil {
+			return nil, fmt.Errorf("invalid selector of overlay %s: %w", overlay.Name, err)
+		}
+		values := make(map[string]any)
+		if err := json.Unmarshal(overlay.Values.Raw, &values); err != nil {
+			return nil, fmt.Errorf("invalid values of overlay %s, the values must be an object: %w", overlay.Name, err)
+		}
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (v *ConfigPolicyValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (*ConfigPolicyValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/objects/configpolicy"
+)
+
+func TestConfigPolicyValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+
+	tests := []struct {
+		name   string
+		policy *v1alpha1.ConfigPolicy
+		err    string
+	}{
+		{
+			name:   "should fail if an expression is malformed",
+			policy: configpolicy.NewConfigPolicy(configpolicy.WithRule("odd", "config.controlPlaneNumber %", "", "")),
+			err:    "invalid ConfigPolicy rules: rule odd:",
+		},
+		{
+			name:   "should fail if an expression does not return a bool",
+			policy: configpolicy.NewConfigPolicy(configpolicy.WithRule("size", "size(config)", "", "")),
+			err:    "invalid ConfigPolicy rules: rule size: expression must return a bool",
+		},
+		{
+			name: "should fail if the selector is invalid",
+			policy: configpolicy.NewConfigPolicy(func(p *v1alpha1.ConfigPolicy) {
+				p.Spec.ClusterSelector = metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Unknown"}}}
+			}),
+			err: "invalid cluster selector",
+		},
+		{
+			name:   "should fail if the values of an overlay are not an object",
+			policy: configpolicy.NewConfigPolicy(configpolicy.WithOverlay("prod", nil, `["t3.large"]`)),
+			err:    "invalid values of overlay prod, the values must be an object",
+		},
+		{
+			name: "should succeed with overlays",
+			policy: configpolicy.NewConfigPolicy(
+				configpolicy.WithOverlay("prod", map[string]string{"environment": "prod"}, `{"worker":{"instanceType":"t3.large"}}`),
+			),
+		},
+		{
+			name: "should succeed",
+			policy: configpolicy.NewConfigPolicy(
+				configpolicy.WithClusterSelector(map[string]string{"environment": "prod"}),
+				configpolicy.WithRule("odd", "config.controlPlaneNumber % 2 == 1", "controlPlaneNumber must be odd", "controlPlaneNumber"),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &ConfigPolicyValidator{}
+			warn, err := validator.ValidateCreate(ctx, tt.policy)
+			if tt.err != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+			g.Expect(warn).To(BeEmpty())
+		})
+	}
+}
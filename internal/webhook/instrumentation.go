@@ -0,0 +1,93 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/K0rdent/kcm/internal/metrics"
+)
+
+// slowThreshold is the duration of a check or a lookup of the webhooks logged as slow.
+const slowThreshold = 500 * time.Millisecond
+
+const (
+	resultSuccess  = "success"
+	resultNotFound = "not_found"
+	resultError    = "error"
+)
+
+// instrumented wraps the given client recording the duration of the lookups of the objects
+// and logging the slow and the failed ones along with the kind and the key of the objects.
+func instrumented(cl client.Client) client.Client {
+	return &instrumentedClient{Client: cl}
+}
+
+type instrumentedClient struct {
+	client.Client
+}
+
+var _ client.Client = (*instrumentedClient)(nil)
+
+func (c *instrumentedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	start := time.Now()
+	err := c.Client.Get(ctx, key, obj, opts...)
+	c.observe(ctx, "get", c.kind(obj), key.String(), time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	start := time.Now()
+	err := c.Client.List(ctx, list, opts...)
+	listOpts := new(client.ListOptions).ApplyOptions(opts)
+	c.observe(ctx, "list", strings.TrimSuffix(c.kind(list), "List"), listOpts.Namespace, time.Since(start), err)
+	return err
+}
+
+func (c *instrumentedClient) kind(obj runtime.Object) string {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return fmt.Sprintf("%T", obj)
+	}
+	return gvk.Kind
+}
+
+func (*instrumentedClient) observe(ctx context.Context, operation, kind, key string, duration time.Duration, err error) {
+	result := resultSuccess
+	switch {
+	case apierrors.IsNotFound(err):
+		result = resultNotFound
+	case err != nil:
+		result = resultError
+	}
+	metrics.ObserveMetricWebhookLookupDuration(ctx, kind, operation, result, duration)
+
+	l := ctrl.LoggerFrom(ctx).WithValues("operation", operation, "kind", kind, "key", key, "duration", duration)
+	switch {
+	case result == resultError:
+		l.Error(err, "webhook lookup failed")
+	case duration >= slowThreshold:
+		l.Info("slow webhook lookup")
+	}
+}
@@ -40,7 +40,7 @@ type ManagementValidator struct {
 var errManagementDeletionForbidden = errors.New("management deletion is forbidden")
 
 func (v *ManagementValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	v.Client = mgr.GetClient()
+	v.Client = sideEffectFree(mgr.GetClient())
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&kcmv1.Management{}).
 		WithValidator(v).
@@ -65,6 +65,12 @@ func (v *ManagementValidator) ValidateCreate(ctx context.Context, obj runtime.Ob
 				field.Forbidden(field.NewPath("spec", "release"), err.Error()),
 			})
 	}
+	if err := validateProfile(mgmt); err != nil {
+		return nil,
+			apierrors.NewInvalid(mgmt.GroupVersionKind().GroupKind(), mgmt.Name, field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "serviceDelivery"), err.Error()),
+			})
+	}
 	return nil, nil
 }
 
@@ -94,6 +100,13 @@ func (v *ManagementValidator) ValidateUpdate(ctx context.Context, oldObj, newObj
 		}
 	}
 
+	if err := validateProfile(newMgmt); err != nil {
+		return nil,
+			apierrors.NewInvalid(newMgmt.GroupVersionKind().GroupKind(), newMgmt.Name, field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "serviceDelivery"), err.Error()),
+			})
+	}
+
 	release := &kcmv1.Release{}
 	if err := v.Get(ctx, client.ObjectKey{Name: newMgmt.Spec.Release}, release); err != nil {
 		return nil, fmt.Errorf("failed to get Release %s: %w", newMgmt.Spec.Release, err)
@@ -106,16 +119,108 @@ func (v *ManagementValidator) ValidateUpdate(ctx context.Context, oldObj, newObj
 			})
 	}
 
+	crdBlockers, crdWarnings, err := getCRDUpgradeIssues(ctx, v.Client, release, oldMgmt, newMgmt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", invalidMgmtMsg, err)
+	}
+	if len(crdBlockers) > 0 {
+		return crdWarnings,
+			apierrors.NewInvalid(newMgmt.GroupVersionKind().GroupKind(), newMgmt.Name, field.ErrorList{
+				field.Forbidden(field.NewPath("spec"), "upgrading the installed CRDs is unsafe: "+strings.Join(crdBlockers, "; ")),
+			})
+	}
+
 	incompatibleContracts, err := getIncompatibleContracts(ctx, v, release, newMgmt)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", invalidMgmtMsg, err)
 	}
 
 	if incompatibleContracts != "" {
-		return admission.Warnings{"The Management object has incompatible CAPI contract versions in ProviderTemplates"}, fmt.Errorf("%s: %s", invalidMgmtMsg, incompatibleContracts)
+		return append(crdWarnings, "The Management object has incompatible CAPI contract versions in ProviderTemplates"), fmt.Errorf("%s: %s", invalidMgmtMsg, incompatibleContracts)
 	}
 
-	return nil, nil
+	return crdWarnings, nil
+}
+
+// componentTemplates returns the ProviderTemplates of the components of the given Management keyed by the component name.
+// The release may be nil if it no longer exists, then only the templates set in the Management are returned.
+func componentTemplates(release *kcmv1.Release, mgmt *kcmv1.Management) map[string]string {
+	templates := make(map[string]string)
+	if release != nil {
+		templates[kcmv1.CoreKCMName] = release.Spec.KCM.Template
+		templates[kcmv1.CoreCAPIName] = release.Spec.CAPI.Template
+	}
+	if mgmt.Spec.Core != nil {
+		if mgmt.Spec.Core.KCM.Template != "" {
+			templates[kcmv1.CoreKCMName] = mgmt.Spec.Core.KCM.Template
+		}
+		if mgmt.Spec.Core.CAPI.Template != "" {
+			templates[kcmv1.CoreCAPIName] = mgmt.Spec.Core.CAPI.Template
+		}
+	}
+	for _, p := range mgmt.Spec.Providers {
+		template := p.Template
+		if template == "" && release != nil {
+			template = release.ProviderTemplate(p.Name)
+		}
+		if template != "" {
+			templates[p.Name] = template
+		}
+	}
+	return templates
+}
+
+// getCRDUpgradeIssues returns the blocking and the non-blocking issues of upgrading the installed CRDs
+// reported by the ProviderTemplates the components of the Management switch to.
+func getCRDUpgradeIssues(ctx context.Context, cl client.Client, release *kcmv1.Release, oldMgmt, newMgmt *kcmv1.Management) (blockers []string, warnings admission.Warnings, _ error) {
+	oldRelease := release
+	if oldMgmt.Spec.Release != newMgmt.Spec.Release {
+		oldRelease = new(kcmv1.Release)
+		if err := cl.Get(ctx, client.ObjectKey{Name: oldMgmt.Spec.Release}, oldRelease); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, nil, fmt.Errorf("failed to get Release %s: %w", oldMgmt.Spec.Release, err)
+			}
+			oldRelease = nil
+		}
+	}
+
+	oldTemplates := componentTemplates(oldRelease, oldMgmt)
+	newTemplates := componentTemplates(release, newMgmt)
+	for _, component := range slices.Sorted(maps.Keys(newTemplates)) {
+		name := newTemplates[component]
+		if name == oldTemplates[component] {
+			continue
+		}
+
+		template := new(kcmv1.ProviderTemplate)
+		if err := cl.Get(ctx, client.ObjectKey{Name: name}, template); err != nil {
+			// the existence of the templates is validated along with the release
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, nil, fmt.Errorf("failed to get ProviderTemplate %s: %w", name, err)
+		}
+
+		for _, issue := range template.Status.CRDUpgradeIssues {
+			msg := fmt.Sprintf("ProviderTemplate %s: %s", name, issue)
+			if issue.Blocking {
+				blockers = append(blockers, msg)
+			} else {
+				warnings = append(warnings, msg)
+			}
+		}
+	}
+
+	return blockers, warnings, nil
+}
+
+// validateProfile checks the Management settings are supported by its profile.
+func validateProfile(mgmt *kcmv1.Management) error {
+	if mgmt.Spec.Profile == kcmv1.ManagementProfileEdge && mgmt.Spec.ServiceDelivery == kcmv1.ServiceDeliverySveltos {
+		return fmt.Errorf("the %s service delivery is not supported with the %s profile", kcmv1.ServiceDeliverySveltos, kcmv1.ManagementProfileEdge)
+	}
+
+	return nil
 }
 
 func checkComponentsRemoval(ctx context.Context, cl client.Client, release *kcmv1.Release, oldMgmt, newMgmt *kcmv1.Management) error {
@@ -57,6 +57,32 @@ func TestManagementValidateCreate(t *testing.T) {
 			},
 			err: fmt.Sprintf(`Management "%s" is invalid: spec.release: Forbidden: release "%s" status is not ready`, management.DefaultName, release.DefaultName),
 		},
+		{
+			name: "Sveltos service delivery with the Edge profile, should fail",
+			management: management.NewManagement(
+				management.WithRelease(release.DefaultName),
+				management.WithProfile(v1alpha1.ManagementProfileEdge),
+				management.WithServiceDelivery(v1alpha1.ServiceDeliverySveltos),
+			),
+			existingObjects: []runtime.Object{
+				release.New(
+					release.WithName(release.DefaultName),
+				),
+			},
+			err: fmt.Sprintf(`Management "%s" is invalid: spec.serviceDelivery: Forbidden: the Sveltos service delivery is not supported with the Edge profile`, management.DefaultName),
+		},
+		{
+			name: "Edge profile, should succeed",
+			management: management.NewManagement(
+				management.WithRelease(release.DefaultName),
+				management.WithProfile(v1alpha1.ManagementProfileEdge),
+			),
+			existingObjects: []runtime.Object{
+				release.New(
+					release.WithName(release.DefaultName),
+				),
+			},
+		},
 		{
 			name: "should succeed",
 			management: management.NewManagement(
@@ -438,6 +464,73 @@ func TestManagementValidateUpdate(t *testing.T) {
 				clusterdeployment.NewClusterDeployment(clusterdeployment.WithClusterTemplate(awsClusterTemplateName)),
 			},
 		},
+		{
+			name: "provider switches to a template with blocking CRD upgrade issues, should fail",
+			oldMgmt: management.NewManagement(
+				management.WithProviders(componentAwsDefaultTpl),
+				management.WithRelease(release.DefaultName),
+			),
+			management: management.NewManagement(
+				management.WithProviders(v1alpha1.Provider{Name: componentAwsDefaultTpl.Name, Component: v1alpha1.Component{Template: "cluster-api-provider-aws-0-0-5"}}),
+				management.WithRelease(release.DefaultName),
+			),
+			existingObjects: []runtime.Object{
+				release.New(),
+				template.NewProviderTemplate(template.WithName(release.DefaultCAPITemplateName)),
+				template.NewProviderTemplate(
+					template.WithName("cluster-api-provider-aws-0-0-5"),
+					template.WithProviderStatusCRDUpgradeIssues(
+						v1alpha1.CRDUpgradeIssue{CRD: "awsclusters.infrastructure.cluster.x-k8s.io", Version: "v1beta1", Message: "the version is removed", Blocking: true},
+						v1alpha1.CRDUpgradeIssue{CRD: "awsmachines.infrastructure.cluster.x-k8s.io", Version: "v1beta1", Message: "the served version is removed"},
+					),
+				),
+			},
+			err:      fmt.Sprintf(`Management "%s" is invalid: spec: Forbidden: upgrading the installed CRDs is unsafe: ProviderTemplate cluster-api-provider-aws-0-0-5: CRD awsclusters.infrastructure.cluster.x-k8s.io version v1beta1: the version is removed`, management.DefaultName),
+			warnings: admission.Warnings{"ProviderTemplate cluster-api-provider-aws-0-0-5: CRD awsmachines.infrastructure.cluster.x-k8s.io version v1beta1: the served version is removed"},
+		},
+		{
+			name: "provider switches to a template with non-blocking CRD upgrade issues, should warn",
+			oldMgmt: management.NewManagement(
+				management.WithProviders(componentAwsDefaultTpl),
+				management.WithRelease(release.DefaultName),
+			),
+			management: management.NewManagement(
+				management.WithProviders(v1alpha1.Provider{Name: componentAwsDefaultTpl.Name, Component: v1alpha1.Component{Template: "cluster-api-provider-aws-0-0-5"}}),
+				management.WithRelease(release.DefaultName),
+			),
+			existingObjects: []runtime.Object{
+				release.New(),
+				template.NewProviderTemplate(template.WithName(release.DefaultCAPITemplateName)),
+				template.NewProviderTemplate(
+					template.WithName("cluster-api-provider-aws-0-0-5"),
+					template.WithProviderStatusCRDUpgradeIssues(
+						v1alpha1.CRDUpgradeIssue{CRD: "awsmachines.infrastructure.cluster.x-k8s.io", Version: "v1beta1", Field: "spec.ami", Message: "the field is removed"},
+					),
+				),
+			},
+			warnings: admission.Warnings{"ProviderTemplate cluster-api-provider-aws-0-0-5: CRD awsmachines.infrastructure.cluster.x-k8s.io version v1beta1 field spec.ami: the field is removed"},
+		},
+		{
+			name: "provider keeps the template with blocking CRD upgrade issues, should succeed",
+			oldMgmt: management.NewManagement(
+				management.WithProviders(componentAwsDefaultTpl),
+				management.WithRelease(release.DefaultName),
+			),
+			management: management.NewManagement(
+				management.WithProviders(componentAwsDefaultTpl),
+				management.WithRelease(release.DefaultName),
+			),
+			existingObjects: []runtime.Object{
+				release.New(),
+				template.NewProviderTemplate(template.WithName(release.DefaultCAPITemplateName)),
+				template.NewProviderTemplate(
+					template.WithName(awsProviderTemplateName),
+					template.WithProviderStatusCRDUpgradeIssues(
+						v1alpha1.CRDUpgradeIssue{CRD: "awsclusters.infrastructure.cluster.x-k8s.io", Version: "v1beta1", Message: "the version is removed", Blocking: true},
+					),
+				),
+			},
+		},
 		{
 			name: "release is not ready, should fail",
 			oldMgmt: management.NewManagement(
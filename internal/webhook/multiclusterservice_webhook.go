@@ -17,15 +17,24 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"math"
+	"slices"
+	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/utils/fleet"
 	"github.com/K0rdent/kcm/internal/utils/validation"
 )
 
@@ -34,11 +43,14 @@ type MultiClusterServiceValidator struct {
 	SystemNamespace string
 }
 
-const invalidMultiClusterServiceMsg = "the MultiClusterService is invalid"
+const (
+	invalidMultiClusterServiceMsg  = "the MultiClusterService is invalid"
+	multiClusterServiceWebhookName = "MultiClusterService"
+)
 
 // SetupWebhookWithManager will setup the manager to manage the webhooks
 func (v *MultiClusterServiceValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	v.Client = mgr.GetClient()
+	v.Client = sideEffectFree(mgr.GetClient())
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&v1alpha1.MultiClusterService{}).
 		WithValidator(v).
@@ -54,11 +66,7 @@ func (v *MultiClusterServiceValidator) ValidateCreate(ctx context.Context, obj r
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected MultiClusterService but got a %T", obj))
 	}
 
-	if err := validation.ServicesHaveValidTemplates(ctx, v.Client, mcs.Spec.ServiceSpec.Services, v.SystemNamespace); err != nil {
-		return nil, fmt.Errorf("%s: %w", invalidMultiClusterServiceMsg, err)
-	}
-
-	return nil, nil
+	return v.validate(ctx, mcs)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
@@ -68,14 +76,168 @@ func (v *MultiClusterServiceValidator) ValidateUpdate(ctx context.Context, _, ne
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected MultiClusterService but got a %T", newObj))
 	}
 
-	if err := validation.ServicesHaveValidTemplates(ctx, v.Client, mcs.Spec.ServiceSpec.Services, v.SystemNamespace); err != nil {
-		return nil, fmt.Errorf("%s: %w", invalidMultiClusterServiceMsg, err)
-	}
-
-	return nil, nil
+	return v.validate(ctx, mcs)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
 func (*MultiClusterServiceValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
 	return nil, nil
 }
+
+// validate runs the checks of the given MultiClusterService on its creation and update.
+func (v *MultiClusterServiceValidator) validate(ctx context.Context, mcs *v1alpha1.MultiClusterService) (admission.Warnings, error) {
+	reject := func(name string, validate func(ctx context.Context) error) validationCheck {
+		return rejectIf(name, invalidMultiClusterServiceMsg, validate)
+	}
+
+	return runChecks(ctx, multiClusterServiceWebhookName, DefaultValidationBudget, []validationCheck{
+		reject("service-templates", func(ctx context.Context) error {
+			return validation.ServiceSpecHasValidTemplates(ctx, v.Client, mcs.Spec.ServiceSpec, v.SystemNamespace)
+		}),
+		reject("cross-namespace-services", func(ctx context.Context) error {
+			return validation.MultiClusterServiceCrossNamespaceServicesRefs(ctx, mcs, v.SystemNamespace)
+		}),
+		reject("cluster-selector", func(context.Context) error { return validateMultiClusterServiceSelector(mcs) }),
+		reject("service-spec", func(context.Context) error { return validateMultiClusterServiceSpec(mcs) }),
+		warnIf("priority-conflicts", func(ctx context.Context) admission.Warnings {
+			return v.priorityConflictWarnings(ctx, mcs)
+		}),
+	})
+}
+
+// validateMultiClusterServiceSelector validates the syntax of the cluster selector
+// and of the Kubernetes version constraint of the given MultiClusterService.
+func validateMultiClusterServiceSelector(mcs *v1alpha1.MultiClusterService) error {
+	var errs field.ErrorList
+	if _, err := metav1.LabelSelectorAsSelector(&mcs.Spec.ClusterSelector); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "clusterSelector"), mcs.Spec.ClusterSelector, err.Error()))
+	}
+	if mcs.Spec.KubernetesVersion != "" {
+		if _, err := semver.NewConstraint(mcs.Spec.KubernetesVersion); err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "kubernetesVersion"), mcs.Spec.KubernetesVersion, err.Error()))
+		}
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind(v1alpha1.MultiClusterServiceKind).GroupKind(), mcs.Name, errs)
+	}
+
+	return nil
+}
+
+// validateMultiClusterServiceSpec validates the services of the given MultiClusterService are unique
+// and its priority is within the allowed range.
+func validateMultiClusterServiceSpec(mcs *v1alpha1.MultiClusterService) error {
+	spec := mcs.Spec.ServiceSpec
+	path := field.NewPath("spec", "serviceSpec")
+	var errs field.ErrorList
+
+	seen := make(map[string]struct{}, len(spec.Services))
+	for i, svc := range spec.Services {
+		key := serviceKey(svc)
+		if _, ok := seen[key]; ok {
+			errs = append(errs, field.Duplicate(path.Child("services").Index(i).Child("name"), svc.Name))
+			continue
+		}
+		seen[key] = struct{}{}
+	}
+
+	// the unset priority is defaulted by the API server
+	if spec.Priority != 0 && (spec.Priority < 1 || spec.Priority > math.MaxInt32-1) {
+		errs = append(errs, field.Invalid(path.Child("priority"), spec.Priority, fmt.Sprintf("must be between 1 and %d", math.MaxInt32-1)))
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind(v1alpha1.MultiClusterServiceKind).GroupKind(), mcs.Name, errs)
+	}
+
+	return nil
+}
+
+// priorityConflictWarnings warns about the other MultiClusterServices deploying the same services
+// with the same priority to any of the existing clusters selected by the given MultiClusterService,
+// as such conflicts are not resolved by the priority but by the tie-breakers.
+func (v *MultiClusterServiceValidator) priorityConflictWarnings(ctx context.Context, mcs *v1alpha1.MultiClusterService) admission.Warnings {
+	if len(mcs.Spec.ServiceSpec.Services) == 0 {
+		return nil
+	}
+
+	mcsList := new(v1alpha1.MultiClusterServiceList)
+	if err := v.List(ctx, mcsList); err != nil {
+		return admission.Warnings{"Failed to check the priority conflicts with other MultiClusterServices: " + err.Error()}
+	}
+
+	var candidates []v1alpha1.MultiClusterService
+	for _, other := range mcsList.Items {
+		if other.Name == mcs.Name || !other.DeletionTimestamp.IsZero() || other.Spec.ServiceSpec.Priority != mcs.Spec.ServiceSpec.Priority {
+			continue
+		}
+		if len(sharedServices(mcs, &other)) > 0 {
+			candidates = append(candidates, other)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	clusters := new(metav1.PartialObjectMetadataList)
+	clusters.SetGroupVersionKind(clusterv1.GroupVersion.WithKind("ClusterList"))
+	if err := v.List(ctx, clusters); err != nil {
+		return admission.Warnings{"Failed to check the priority conflicts with other MultiClusterServices: " + err.Error()}
+	}
+
+	var warnings admission.Warnings
+	for _, other := range candidates {
+		if !selectSameCluster(mcs, &other, clusters.Items) {
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("The MultiClusterService %s deploys the services %s to the same clusters with the same priority %d, "+
+			"the conflicting services are deployed by the object created earlier",
+			other.Name, strings.Join(sharedServices(mcs, &other), ", "), mcs.Spec.ServiceSpec.Priority))
+	}
+
+	return warnings
+}
+
+// serviceKey returns the key of the given service as the namespace and the name of its release.
+func serviceKey(svc v1alpha1.Service) string {
+	namespace := svc.Namespace
+	if namespace == "" {
+		namespace = svc.Name
+	}
+	return namespace + "/" + svc.Name
+}
+
+// sharedServices returns the keys of the enabled services deployed by both of the given MultiClusterServices.
+func sharedServices(a, b *v1alpha1.MultiClusterService) []string {
+	var shared []string
+	for _, svc := range a.Spec.ServiceSpec.Services {
+		if svc.Disable {
+			continue
+		}
+		key := serviceKey(svc)
+		if slices.ContainsFunc(b.Spec.ServiceSpec.Services, func(other v1alpha1.Service) bool {
+			return !other.Disable && serviceKey(other) == key
+		}) && !slices.Contains(shared, key) {
+			shared = append(shared, key)
+		}
+	}
+	return shared
+}
+
+// selectSameCluster returns true if any of the given clusters is selected by both of the given MultiClusterServices.
+// The invalid selectors do not select any cluster.
+func selectSameCluster(a, b *v1alpha1.MultiClusterService, clusters []metav1.PartialObjectMetadata) bool {
+	selects := func(mcs *v1alpha1.MultiClusterService, clusterLabels map[string]string) bool {
+		selector, err := metav1.LabelSelectorAsSelector(&mcs.Spec.ClusterSelector)
+		if err != nil || !selector.Matches(labels.Set(clusterLabels)) {
+			return false
+		}
+		matches, err := fleet.KubernetesVersionMatches(mcs.Spec.KubernetesVersion, clusterLabels)
+		return err == nil && matches
+	}
+
+	return slices.ContainsFunc(clusters, func(cluster metav1.PartialObjectMetadata) bool {
+		return selects(a, cluster.Labels) && selects(b, cluster.Labels)
+	})
+}
@@ -19,15 +19,19 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
 	admissionv1 "k8s.io/api/admission/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/K0rdent/kcm/api/v1alpha1"
 	"github.com/K0rdent/kcm/test/objects/multiclusterservice"
+	"github.com/K0rdent/kcm/test/objects/servicebundle"
 	"github.com/K0rdent/kcm/test/objects/template"
 	"github.com/K0rdent/kcm/test/scheme"
 )
@@ -120,12 +124,166 @@ func TestMultiClusterServiceValidateCreate(t *testing.T) {
 				),
 			},
 		},
+		{
+			name: "should fail if the ServiceBundle is not found in system namespace",
+			mcs: multiclusterservice.NewMultiClusterService(
+				multiclusterservice.WithName(testMCSName),
+				multiclusterservice.WithServiceBundle(servicebundle.DefaultName),
+			),
+			existingObjects: []runtime.Object{
+				servicebundle.NewServiceBundle(servicebundle.WithService(testSvcTemplate1Name, testSvcTemplate1Name)),
+			},
+			err: apierrors.NewNotFound(schema.GroupResource{Group: v1alpha1.GroupVersion.Group, Resource: "servicebundles"}, servicebundle.DefaultName).Error(),
+		},
+		{
+			name: "should fail if the ServiceTemplates of the ServiceBundle are invalid",
+			mcs: multiclusterservice.NewMultiClusterService(
+				multiclusterservice.WithName(testMCSName),
+				multiclusterservice.WithServiceBundle(servicebundle.DefaultName),
+			),
+			existingObjects: []runtime.Object{
+				servicebundle.NewServiceBundle(
+					servicebundle.WithNamespace(testSystemNamespace),
+					servicebundle.WithService(testSvcTemplate1Name, testSvcTemplate1Name),
+				),
+				template.NewServiceTemplate(
+					template.WithName(testSvcTemplate1Name),
+					template.WithNamespace(testSystemNamespace),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: false, ValidationError: "validation error example"}),
+				),
+			},
+			err: fmt.Sprintf("the MultiClusterService is invalid: the ServiceTemplate %s/%s is invalid with the error: validation error example", testSystemNamespace, testSvcTemplate1Name),
+		},
+		{
+			name: "should succeed with the ServiceBundle",
+			mcs: multiclusterservice.NewMultiClusterService(
+				multiclusterservice.WithName(testMCSName),
+				multiclusterservice.WithServiceBundle(servicebundle.DefaultName),
+				multiclusterservice.WithServiceTemplate(testSvcTemplate2Name),
+			),
+			existingObjects: []runtime.Object{
+				servicebundle.NewServiceBundle(
+					servicebundle.WithNamespace(testSystemNamespace),
+					servicebundle.WithService(testSvcTemplate1Name, testSvcTemplate1Name),
+				),
+				template.NewServiceTemplate(
+					template.WithName(testSvcTemplate1Name),
+					template.WithNamespace(testSystemNamespace),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+				),
+				template.NewServiceTemplate(
+					template.WithName(testSvcTemplate2Name),
+					template.WithNamespace(testSystemNamespace),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+				),
+			},
+		},
 		{
 			name: "should succeed without any serviceTemplates",
 			mcs: multiclusterservice.NewMultiClusterService(
 				multiclusterservice.WithName(testMCSName),
 			),
 		},
+		{
+			name: "should fail if the cluster selector is invalid",
+			mcs: multiclusterservice.NewMultiClusterService(
+				multiclusterservice.WithName(testMCSName),
+				multiclusterservice.WithClusterSelector(metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: v1alpha1.ProviderLabelKey, Operator: "Gt", Values: []string{"aws"}}},
+				}),
+			),
+			err: "spec.clusterSelector: Invalid value",
+		},
+		{
+			name: "should fail if the Kubernetes version constraint is invalid",
+			mcs: multiclusterservice.NewMultiClusterService(
+				multiclusterservice.WithName(testMCSName),
+				multiclusterservice.WithKubernetesVersion("newer than 1.29"),
+			),
+			err: "spec.kubernetesVersion: Invalid value",
+		},
+		{
+			name: "should fail if the services values reference another namespace",
+			mcs: multiclusterservice.NewMultiClusterService(
+				multiclusterservice.WithName(testMCSName),
+				func(mcs *v1alpha1.MultiClusterService) {
+					mcs.Spec.ServiceSpec.Services = []v1alpha1.Service{{
+						Name:       testSvcTemplate1Name,
+						Template:   testSvcTemplate1Name,
+						ValuesFrom: []sveltosv1beta1.ValueFrom{{Kind: "Secret", Name: "values", Namespace: "tenant"}},
+					}}
+				},
+			),
+			existingObjects: []runtime.Object{
+				template.NewServiceTemplate(
+					template.WithName(testSvcTemplate1Name),
+					template.WithNamespace(testSystemNamespace),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+				),
+			},
+			err: "cross-namespace service values references are disallowed, Secret values's namespace tenant, system namespace " + testSystemNamespace,
+		},
+		{
+			name: "should fail if a service is duplicated",
+			mcs: multiclusterservice.NewMultiClusterService(
+				multiclusterservice.WithName(testMCSName),
+				multiclusterservice.WithServiceTemplate(testSvcTemplate1Name),
+				multiclusterservice.WithServiceTemplate(testSvcTemplate1Name),
+			),
+			existingObjects: []runtime.Object{
+				template.NewServiceTemplate(
+					template.WithName(testSvcTemplate1Name),
+					template.WithNamespace(testSystemNamespace),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+				),
+			},
+			err: "spec.serviceSpec.services[1].name: Duplicate value",
+		},
+		{
+			name: "should warn about the services deployed to the same clusters with the same priority",
+			mcs: multiclusterservice.NewMultiClusterService(
+				multiclusterservice.WithName(testMCSName),
+				multiclusterservice.WithServiceTemplate(testSvcTemplate1Name),
+				multiclusterservice.WithPriority(100),
+				multiclusterservice.WithClusterSelector(metav1.LabelSelector{MatchLabels: map[string]string{v1alpha1.ProviderLabelKey: "aws"}}),
+				multiclusterservice.WithKubernetesVersion(">=1.29"),
+			),
+			existingObjects: []runtime.Object{
+				template.NewServiceTemplate(
+					template.WithName(testSvcTemplate1Name),
+					template.WithNamespace(testSystemNamespace),
+					template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+				),
+				multiclusterservice.NewMultiClusterService(
+					multiclusterservice.WithName("same-priority"),
+					multiclusterservice.WithServiceTemplate(testSvcTemplate1Name),
+					multiclusterservice.WithPriority(100),
+				),
+				multiclusterservice.NewMultiClusterService(
+					multiclusterservice.WithName("higher-priority"),
+					multiclusterservice.WithServiceTemplate(testSvcTemplate1Name),
+					multiclusterservice.WithPriority(200),
+				),
+				multiclusterservice.NewMultiClusterService(
+					multiclusterservice.WithName("other-clusters"),
+					multiclusterservice.WithServiceTemplate(testSvcTemplate1Name),
+					multiclusterservice.WithPriority(100),
+					multiclusterservice.WithClusterSelector(metav1.LabelSelector{MatchLabels: map[string]string{v1alpha1.ProviderLabelKey: "azure"}}),
+				),
+				&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{
+					Name:      "aws-cluster",
+					Namespace: "default",
+					Labels:    map[string]string{v1alpha1.ProviderLabelKey: "aws", v1alpha1.KubernetesVersionLabelKey: "1.30"},
+				}},
+				&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{
+					Name:      "azure-cluster",
+					Namespace: "default",
+					Labels:    map[string]string{v1alpha1.ProviderLabelKey: "azure", v1alpha1.KubernetesVersionLabelKey: "1.30"},
+				}},
+			},
+			warnings: admission.Warnings{fmt.Sprintf("The MultiClusterService same-priority deploys the services %[1]s/%[1]s to the same clusters with the same priority 100, "+
+				"the conflicting services are deployed by the object created earlier", testSvcTemplate1Name)},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,124 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// negativeResultTTL is the period the objects found missing by the live reads are reported
+// as missing without reading them again, unless they appear in the cache in the meantime.
+const negativeResultTTL = 5 * time.Second
+
+// liveReadBackoff is the backoff of the retries of the live reads failed with a transient error.
+var liveReadBackoff = wait.Backoff{
+	Steps:    3,
+	Duration: 50 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+}
+
+// cacheBacked wraps the given client backed by the informer cache of the manager, reading the objects
+// missing in the cache with the given live reader since the cache may lag behind the objects applied
+// along with the validated one. The objects missing in the API server as well are remembered for
+// a short period, so the bursts of the applies referring to them do not multiply the live reads.
+func cacheBacked(cl client.Client, apiReader client.Reader) client.Client {
+	return newCacheBackedClient(cl, apiReader, clock.RealClock{})
+}
+
+func newCacheBackedClient(cl client.Client, apiReader client.Reader, clk clock.Clock) *cacheBackedClient {
+	return &cacheBackedClient{
+		Client:    cl,
+		apiReader: apiReader,
+		missing:   utilcache.NewExpiringWithClock(clk),
+	}
+}
+
+type cacheBackedClient struct {
+	client.Client
+
+	apiReader client.Reader
+	missing   *utilcache.Expiring
+}
+
+var _ client.Client = (*cacheBackedClient)(nil)
+
+// missingKey is the key of the object remembered as missing.
+type missingKey struct {
+	gvk schema.GroupVersionKind
+	key client.ObjectKey
+}
+
+func (c *cacheBackedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.Client.Get(ctx, key, obj, opts...)
+	if err == nil || (!apierrors.IsNotFound(err) && !isCacheUnavailable(err)) {
+		return err
+	}
+
+	gvk, gvkErr := apiutil.GVKForObject(obj, c.Scheme())
+	if gvkErr != nil {
+		return err
+	}
+	mk := missingKey{gvk: gvk, key: key}
+	if notFound, ok := c.missing.Get(mk); ok {
+		return notFound.(error) //nolint:forcetypeassert // only the errors are stored
+	}
+
+	err = retry.OnError(liveReadBackoff, isTransient, func() error {
+		return c.apiReader.Get(ctx, key, obj, opts...)
+	})
+	if apierrors.IsNotFound(err) {
+		c.missing.Set(mk, err, negativeResultTTL)
+	}
+	return err
+}
+
+func (c *cacheBackedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	err := c.Client.List(ctx, list, opts...)
+	if !isCacheUnavailable(err) {
+		return err
+	}
+
+	return retry.OnError(liveReadBackoff, isTransient, func() error {
+		return c.apiReader.List(ctx, list, opts...)
+	})
+}
+
+// isCacheUnavailable reports whether the given error is returned by the cache not started yet.
+func isCacheUnavailable(err error) bool {
+	var notStarted *cache.ErrCacheNotStarted
+	return errors.As(err, &notStarted)
+}
+
+// isTransient reports whether the given error of a live read is worth retrying.
+func isTransient(err error) bool {
+	return apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsInternalError(err)
+}
@@ -0,0 +1,97 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clocktesting "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/objects/template"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestCacheBackedClient(t *testing.T) {
+	ctx := t.Context()
+
+	applied := template.NewClusterTemplate(template.WithName("applied"), template.WithNamespace("default"))
+	cached := template.NewClusterTemplate(template.WithName("cached"), template.WithNamespace("default"))
+	missing := client.ObjectKey{Namespace: "default", Name: "missing"}
+
+	var liveReads, failures int
+	apiReader := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(applied, cached).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, cl client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			liveReads++
+			if failures > 0 {
+				failures--
+				return apierrors.NewServerTimeout(v1alpha1.GroupVersion.WithResource("clustertemplates").GroupResource(), "get", 1)
+			}
+			return cl.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+	cache := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cached).Build()
+
+	clk := clocktesting.NewFakeClock(time.Now())
+	cl := newCacheBackedClient(cache, apiReader, clk)
+
+	t.Run("cached object is read from the cache", func(t *testing.T) {
+		g := NewWithT(t)
+		liveReads = 0
+
+		g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(cached), &v1alpha1.ClusterTemplate{})).To(Succeed())
+		g.Expect(liveReads).To(Equal(0))
+	})
+
+	t.Run("object missing in the cache is read from the API server", func(t *testing.T) {
+		g := NewWithT(t)
+		liveReads, failures = 0, 1
+
+		tpl := &v1alpha1.ClusterTemplate{}
+		g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(applied), tpl)).To(Succeed())
+		g.Expect(tpl.Name).To(Equal(applied.Name))
+		g.Expect(liveReads).To(Equal(2), "the transient failure is expected to be retried")
+	})
+
+	t.Run("missing object is remembered for a short period", func(t *testing.T) {
+		g := NewWithT(t)
+		liveReads = 0
+
+		for range 3 {
+			err := cl.Get(ctx, missing, &v1alpha1.ClusterTemplate{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		}
+		g.Expect(liveReads).To(Equal(1))
+
+		clk.Step(negativeResultTTL + time.Second)
+		err := cl.Get(ctx, missing, &v1alpha1.ClusterTemplate{})
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		g.Expect(liveReads).To(Equal(2))
+	})
+
+	t.Run("missing object appearing in the cache is found", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(cache.Create(ctx, template.NewClusterTemplate(template.WithName(missing.Name), template.WithNamespace(missing.Namespace)))).To(Succeed())
+		g.Expect(cl.Get(ctx, missing, &v1alpha1.ClusterTemplate{})).To(Succeed())
+	})
+}
@@ -23,12 +23,15 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kcmv1 "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/addonversions"
+	"github.com/K0rdent/kcm/internal/kubernetessupport"
 )
 
 var errManagementIsNotFound = errors.New("no Management object found")
@@ -39,7 +42,7 @@ type ReleaseValidator struct {
 
 // SetupWebhookWithManager will setup the manager to manage the webhooks
 func (v *ReleaseValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	v.Client = mgr.GetClient()
+	v.Client = sideEffectFree(mgr.GetClient())
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&kcmv1.Release{}).
 		WithValidator(v).
@@ -49,13 +52,30 @@ func (v *ReleaseValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
 var _ webhook.CustomValidator = &ReleaseValidator{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
-func (*ReleaseValidator) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+func (*ReleaseValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	release, ok := obj.(*kcmv1.Release)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected Release but got a %T", obj))
+	}
+
+	if err := addonversions.Validate(release); err != nil {
+		return nil, apierrors.NewInvalid(kcmv1.GroupVersion.WithKind(kcmv1.ReleaseKind).GroupKind(), release.Name, field.ErrorList{
+			field.Invalid(field.NewPath("spec", "addonVersions"), release.Spec.AddonVersions, err.Error()),
+		})
+	}
+
+	if err := kubernetessupport.ValidateSkew(release); err != nil {
+		return nil, apierrors.NewInvalid(kcmv1.GroupVersion.WithKind(kcmv1.ReleaseKind).GroupKind(), release.Name, field.ErrorList{
+			field.Invalid(field.NewPath("spec", "kubernetesVersionSkew"), release.Spec.KubernetesVersionSkew, err.Error()),
+		})
+	}
+
 	return nil, nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (*ReleaseValidator) ValidateUpdate(_ context.Context, _, _ runtime.Object) (admission.Warnings, error) {
-	return nil, nil
+func (v *ReleaseValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.ValidateCreate(ctx, newObj)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -30,6 +30,58 @@ import (
 	"github.com/K0rdent/kcm/test/scheme"
 )
 
+func TestReleaseValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := admission.NewContextWithRequest(t.Context(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{Operation: admissionv1.Create}})
+
+	tests := []struct {
+		name    string
+		release *v1alpha1.Release
+		err     string
+	}{
+		{
+			name: "should fail if the Kubernetes versions of the add-ons are invalid",
+			release: release.New(release.WithAddonVersions(v1alpha1.AddonVersions{
+				Provider:           "infrastructure-aws",
+				KubernetesVersions: "not-a-version",
+				CSIDriver:          &v1alpha1.AddonVersion{ChartVersion: "2.33.0"},
+			})),
+			err: `Release.k0rdent.mirantis.com "release-test-0-0-1" is invalid: spec.addonVersions: Invalid value: `,
+		},
+		{
+			name: "should fail if the Kubernetes versions of the management components are invalid",
+			release: release.New(release.WithKubernetesVersionSkew(v1alpha1.ComponentKubernetesVersions{
+				Component:          "cluster-api",
+				KubernetesVersions: "not-a-version",
+			})),
+			err: `Release.k0rdent.mirantis.com "release-test-0-0-1" is invalid: spec.kubernetesVersionSkew: Invalid value: `,
+		},
+		{
+			name: "should succeed",
+			release: release.New(release.WithAddonVersions(v1alpha1.AddonVersions{
+				Provider:           "infrastructure-aws",
+				KubernetesVersions: ">=1.31.0 <1.32.0",
+				CSIDriver:          &v1alpha1.AddonVersion{ChartVersion: "2.33.0"},
+			})),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(_ *testing.T) {
+			validator := &ReleaseValidator{Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()}
+
+			_, err := validator.ValidateCreate(ctx, tt.release)
+			if tt.err != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(HavePrefix(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+		})
+	}
+}
+
 func TestReleaseValidateDelete(t *testing.T) {
 	g := NewWithT(t)
 
@@ -0,0 +1,157 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kcmv1 "github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/utils/validation"
+)
+
+var errServiceBundleDeletionForbidden = errors.New("ServiceBundle deletion is forbidden")
+
+type ServiceBundleValidator struct {
+	client.Client
+	SystemNamespace string
+}
+
+const (
+	invalidServiceBundleMsg  = "the ServiceBundle is invalid"
+	serviceBundleWebhookName = "ServiceBundle"
+)
+
+func (v *ServiceBundleValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	v.Client = sideEffectFree(mgr.GetClient())
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&kcmv1.ServiceBundle{}).
+		WithValidator(v).
+		Complete()
+}
+
+var _ webhook.CustomValidator = &ServiceBundleValidator{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (v *ServiceBundleValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	bundle, ok := obj.(*kcmv1.ServiceBundle)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected ServiceBundle but got a %T", obj))
+	}
+
+	return v.validate(ctx, bundle)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (v *ServiceBundleValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	bundle, ok := newObj.(*kcmv1.ServiceBundle)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected ServiceBundle but got a %T", newObj))
+	}
+
+	return v.validate(ctx, bundle)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (v *ServiceBundleValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	bundle, ok := obj.(*kcmv1.ServiceBundle)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected ServiceBundle but got a %T", obj))
+	}
+
+	clusterDeployments := new(kcmv1.ClusterDeploymentList)
+	if err := v.List(ctx, clusterDeployments,
+		client.InNamespace(bundle.Namespace),
+		client.MatchingFields{kcmv1.ClusterDeploymentServiceBundlesIndexKey: bundle.Name},
+		client.Limit(1)); err != nil {
+		return nil, fmt.Errorf("failed to check if the ServiceBundle %s is in use: %w", client.ObjectKeyFromObject(bundle), err)
+	}
+	if len(clusterDeployments.Items) > 0 {
+		return admission.Warnings{"The ServiceBundle object can't be removed if ClusterDeployment objects including it still exist"}, errServiceBundleDeletionForbidden
+	}
+
+	// MultiClusterServices can only include the ServiceBundles in the system namespace
+	if bundle.Namespace != v.SystemNamespace {
+		return nil, nil
+	}
+
+	multiClusterServices := new(kcmv1.MultiClusterServiceList)
+	if err := v.List(ctx, multiClusterServices,
+		client.MatchingFields{kcmv1.MultiClusterServiceBundlesIndexKey: bundle.Name}); err != nil {
+		return nil, fmt.Errorf("failed to check if the ServiceBundle %s is in use: %w", client.ObjectKeyFromObject(bundle), err)
+	}
+	if len(multiClusterServices.Items) > 0 {
+		names := make([]string, len(multiClusterServices.Items))
+		for i, mcs := range multiClusterServices.Items {
+			names[i] = mcs.Name
+		}
+		return admission.Warnings{fmt.Sprintf("The ServiceBundle object can't be removed if MultiClusterService objects [%s] including it still exist", strings.Join(names, ","))}, errServiceBundleDeletionForbidden
+	}
+
+	return nil, nil
+}
+
+// validate runs the checks of the given ServiceBundle on its creation and update.
+func (v *ServiceBundleValidator) validate(ctx context.Context, bundle *kcmv1.ServiceBundle) (admission.Warnings, error) {
+	reject := func(name string, validate func(ctx context.Context) error) validationCheck {
+		return rejectIf(name, invalidServiceBundleMsg, validate)
+	}
+
+	return runChecks(ctx, serviceBundleWebhookName, DefaultValidationBudget, []validationCheck{
+		reject("services", func(context.Context) error { return validateServiceBundleServices(bundle) }),
+		reject("service-templates", func(ctx context.Context) error {
+			return validation.ServicesHaveValidTemplates(ctx, v.Client, bundle.Spec.Services, bundle.Namespace)
+		}),
+	})
+}
+
+// validateServiceBundleServices validates the services of the given ServiceBundle are unique
+// and reference the values only in the namespace of the bundle.
+func validateServiceBundleServices(bundle *kcmv1.ServiceBundle) error {
+	path := field.NewPath("spec", "services")
+	var errs field.ErrorList
+
+	seen := make(map[string]struct{}, len(bundle.Spec.Services))
+	for i, svc := range bundle.Spec.Services {
+		key := serviceKey(svc)
+		if _, ok := seen[key]; ok {
+			errs = append(errs, field.Duplicate(path.Index(i).Child("name"), svc.Name))
+		}
+		seen[key] = struct{}{}
+
+		for j, ref := range svc.ValuesFrom {
+			if ref.Namespace != "" && ref.Namespace != bundle.Namespace {
+				errs = append(errs, field.Invalid(path.Index(i).Child("valuesFrom").Index(j).Child("namespace"), ref.Namespace,
+					"cross-namespace service values references are disallowed"))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return apierrors.NewInvalid(kcmv1.GroupVersion.WithKind(kcmv1.ServiceBundleKind).GroupKind(), bundle.Name, errs)
+	}
+
+	return nil
+}
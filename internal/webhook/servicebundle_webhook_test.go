@@ -0,0 +1,183 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/objects/clusterdeployment"
+	"github.com/K0rdent/kcm/test/objects/multiclusterservice"
+	"github.com/K0rdent/kcm/test/objects/servicebundle"
+	"github.com/K0rdent/kcm/test/objects/template"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestServiceBundleValidateCreate(t *testing.T) {
+	ctx := admission.NewContextWithRequest(t.Context(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+		},
+	})
+
+	validTemplate := template.NewServiceTemplate(
+		template.WithName(testSvcTemplate1Name),
+		template.WithNamespace(servicebundle.DefaultNamespace),
+		template.WithValidationStatus(v1alpha1.TemplateValidationStatus{Valid: true}),
+	)
+
+	crossNamespaceValues := servicebundle.NewServiceBundle(servicebundle.WithService("ingress-nginx", testSvcTemplate1Name))
+	crossNamespaceValues.Spec.Services[0].ValuesFrom = []sveltosv1beta1.ValueFrom{{Kind: "ConfigMap", Name: "values", Namespace: "other"}}
+
+	tests := []struct {
+		name            string
+		bundle          *v1alpha1.ServiceBundle
+		existingObjects []runtime.Object
+		err             string
+	}{
+		{
+			name:   "should fail if the ServiceTemplate is not found",
+			bundle: servicebundle.NewServiceBundle(servicebundle.WithService("ingress-nginx", testSvcTemplate1Name)),
+			err:    fmt.Sprintf("the ServiceBundle is invalid: failed to get ServiceTemplate %s/%s", servicebundle.DefaultNamespace, testSvcTemplate1Name),
+		},
+		{
+			name: "should fail if the services are duplicated",
+			bundle: servicebundle.NewServiceBundle(
+				servicebundle.WithService("ingress-nginx", testSvcTemplate1Name),
+				servicebundle.WithService("ingress-nginx", testSvcTemplate1Name),
+			),
+			existingObjects: []runtime.Object{validTemplate},
+			err:             "spec.services[1].name: Duplicate value",
+		},
+		{
+			name:            "should fail if the values are referenced from another namespace",
+			bundle:          crossNamespaceValues,
+			existingObjects: []runtime.Object{validTemplate},
+			err:             "spec.services[0].valuesFrom[0].namespace: Invalid value",
+		},
+		{
+			name:            "should succeed",
+			bundle:          servicebundle.NewServiceBundle(servicebundle.WithService("ingress-nginx", testSvcTemplate1Name)),
+			existingObjects: []runtime.Object{validTemplate},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(tt.existingObjects...).Build()
+			validator := &ServiceBundleValidator{Client: c, SystemNamespace: testSystemNamespace}
+			warn, err := validator.ValidateCreate(ctx, tt.bundle)
+			if tt.err != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+			g.Expect(warn).To(BeEmpty())
+		})
+	}
+}
+
+func TestServiceBundleValidateDelete(t *testing.T) {
+	ctx := admission.NewContextWithRequest(t.Context(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Delete,
+		},
+	})
+
+	tests := []struct {
+		name            string
+		bundle          *v1alpha1.ServiceBundle
+		existingObjects []runtime.Object
+		err             string
+		warnings        admission.Warnings
+	}{
+		{
+			name:   "should fail if a ClusterDeployment includes the ServiceBundle",
+			bundle: servicebundle.NewServiceBundle(),
+			existingObjects: []runtime.Object{
+				clusterdeployment.NewClusterDeployment(clusterdeployment.WithServiceBundle(servicebundle.DefaultName)),
+			},
+			warnings: admission.Warnings{"The ServiceBundle object can't be removed if ClusterDeployment objects including it still exist"},
+			err:      errServiceBundleDeletionForbidden.Error(),
+		},
+		{
+			name:   "should succeed if the ClusterDeployment including the ServiceBundle is in another namespace",
+			bundle: servicebundle.NewServiceBundle(),
+			existingObjects: []runtime.Object{
+				clusterdeployment.NewClusterDeployment(
+					clusterdeployment.WithNamespace("other"),
+					clusterdeployment.WithServiceBundle(servicebundle.DefaultName),
+				),
+			},
+		},
+		{
+			name:   "should fail if a MultiClusterService includes the ServiceBundle in system namespace",
+			bundle: servicebundle.NewServiceBundle(servicebundle.WithNamespace(testSystemNamespace)),
+			existingObjects: []runtime.Object{
+				multiclusterservice.NewMultiClusterService(
+					multiclusterservice.WithName(testMCSName),
+					multiclusterservice.WithServiceBundle(servicebundle.DefaultName),
+				),
+			},
+			warnings: admission.Warnings{fmt.Sprintf("The ServiceBundle object can't be removed if MultiClusterService objects [%s] including it still exist", testMCSName)},
+			err:      errServiceBundleDeletionForbidden.Error(),
+		},
+		{
+			name:   "should succeed if the ServiceBundle is not included",
+			bundle: servicebundle.NewServiceBundle(servicebundle.WithNamespace(testSystemNamespace)),
+			existingObjects: []runtime.Object{
+				multiclusterservice.NewMultiClusterService(multiclusterservice.WithName(testMCSName)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.
+				NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(tt.existingObjects...).
+				WithIndex(&v1alpha1.ClusterDeployment{}, v1alpha1.ClusterDeploymentServiceBundlesIndexKey, v1alpha1.ExtractServiceBundlesFromClusterDeployment).
+				WithIndex(&v1alpha1.MultiClusterService{}, v1alpha1.MultiClusterServiceBundlesIndexKey, v1alpha1.ExtractServiceBundlesFromMultiClusterService).
+				Build()
+			validator := &ServiceBundleValidator{Client: c, SystemNamespace: testSystemNamespace}
+
+			warn, err := validator.ValidateDelete(ctx, tt.bundle)
+			if tt.err != "" {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+
+			if len(tt.warnings) > 0 {
+				g.Expect(warn).To(Equal(tt.warnings))
+			} else {
+				g.Expect(warn).To(BeEmpty())
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// errSideEffect is returned on any attempt of a webhook handler to modify the objects.
+// The webhooks are registered with sideEffects=None, hence the API server calls them for
+// the dry-run requests as well, e.g. kubectl apply --dry-run=server or GitOps diffing.
+var errSideEffect = errors.New("webhook handlers must be free of side effects")
+
+// sideEffectFree wraps the given client allowing only the read operations.
+// Each webhook handler must be set up with the wrapped client so that any
+// side effect is reported as an error instead of being silently performed.
+// The read operations are instrumented to identify the slow lookups.
+func sideEffectFree(cl client.Client) client.Client {
+	return &sideEffectFreeClient{Client: instrumented(cl)}
+}
+
+type sideEffectFreeClient struct {
+	client.Client
+}
+
+var _ client.Client = (*sideEffectFreeClient)(nil)
+
+func sideEffectErr(op string, obj client.Object) error {
+	return fmt.Errorf("%w: %s of %T %s is not allowed", errSideEffect, op, obj, client.ObjectKeyFromObject(obj))
+}
+
+func (*sideEffectFreeClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	return sideEffectErr("create", obj)
+}
+
+func (*sideEffectFreeClient) Delete(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	return sideEffectErr("delete", obj)
+}
+
+func (*sideEffectFreeClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	return sideEffectErr("update", obj)
+}
+
+func (*sideEffectFreeClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	return sideEffectErr("patch", obj)
+}
+
+func (*sideEffectFreeClient) DeleteAllOf(_ context.Context, obj client.Object, _ ...client.DeleteAllOfOption) error {
+	return sideEffectErr("delete all", obj)
+}
+
+func (c *sideEffectFreeClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *sideEffectFreeClient) SubResource(subResource string) client.SubResourceClient {
+	return &sideEffectFreeSubResourceClient{SubResourceClient: c.Client.SubResource(subResource), subResource: subResource}
+}
+
+type sideEffectFreeSubResourceClient struct {
+	client.SubResourceClient
+
+	subResource string
+}
+
+func (c *sideEffectFreeSubResourceClient) Create(_ context.Context, obj, _ client.Object, _ ...client.SubResourceCreateOption) error {
+	return sideEffectErr("create of "+c.subResource, obj)
+}
+
+func (c *sideEffectFreeSubResourceClient) Update(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+	return sideEffectErr("update of "+c.subResource, obj)
+}
+
+func (c *sideEffectFreeSubResourceClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+	return sideEffectErr("patch of "+c.subResource, obj)
+}
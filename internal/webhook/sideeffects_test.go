@@ -0,0 +1,82 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/projectroot"
+	"github.com/K0rdent/kcm/test/objects/clusterdeployment"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestSideEffectFreeClient(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+
+	cd := clusterdeployment.NewClusterDeployment()
+	cl := sideEffectFree(fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(cd).Build())
+
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(cd), &v1alpha1.ClusterDeployment{})).To(Succeed())
+	g.Expect(cl.List(ctx, &v1alpha1.ClusterDeploymentList{})).To(Succeed())
+
+	for name, write := range map[string]func() error{
+		"create": func() error {
+			return cl.Create(ctx, clusterdeployment.NewClusterDeployment(clusterdeployment.WithName("new")))
+		},
+		"update":        func() error { return cl.Update(ctx, cd) },
+		"patch":         func() error { return cl.Patch(ctx, cd, client.MergeFrom(cd.DeepCopy())) },
+		"delete":        func() error { return cl.Delete(ctx, cd) },
+		"delete all":    func() error { return cl.DeleteAllOf(ctx, &v1alpha1.ClusterDeployment{}) },
+		"status update": func() error { return cl.Status().Update(ctx, cd) },
+		"status patch":  func() error { return cl.Status().Patch(ctx, cd, client.MergeFrom(cd.DeepCopy())) },
+		"subresource":   func() error { return cl.SubResource("scale").Create(ctx, cd, cd) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			NewWithT(t).Expect(write()).To(MatchError(errSideEffect))
+		})
+	}
+
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(cd), &v1alpha1.ClusterDeployment{})).To(Succeed())
+}
+
+func TestWebhookConfigurationsSideEffectFree(t *testing.T) {
+	g := NewWithT(t)
+
+	manifest, err := os.ReadFile(filepath.Join(projectroot.Path, "templates", "provider", "kcm", "templates", "webhooks.yaml"))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var webhooks, sideEffectFree int
+	for _, line := range strings.Split(string(manifest), "\n") {
+		switch strings.TrimSpace(line) {
+		case "- admissionReviewVersions:":
+			webhooks++
+		case "sideEffects: None":
+			sideEffectFree++
+		}
+	}
+
+	g.Expect(webhooks).To(BeNumerically(">", 0))
+	g.Expect(sideEffectFree).To(Equal(webhooks), "each webhook must be registered with sideEffects=None to be called for the dry-run requests")
+}
@@ -48,7 +48,7 @@ type ClusterTemplateValidator struct {
 }
 
 func (v *ClusterTemplateValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	v.Client = mgr.GetClient()
+	v.Client = sideEffectFree(mgr.GetClient())
 	v.templateKind = v1alpha1.ClusterTemplateKind
 	v.templateChainKind = v1alpha1.ClusterTemplateChainKind
 	return ctrl.NewWebhookManagedBy(mgr).
@@ -115,7 +115,7 @@ type ServiceTemplateValidator struct {
 }
 
 func (v *ServiceTemplateValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	v.Client = mgr.GetClient()
+	v.Client = sideEffectFree(mgr.GetClient())
 	v.templateKind = v1alpha1.ServiceTemplateKind
 	v.templateChainKind = v1alpha1.ServiceTemplateChainKind
 	return ctrl.NewWebhookManagedBy(mgr).
@@ -200,7 +200,7 @@ type ProviderTemplateValidator struct {
 }
 
 func (v *ProviderTemplateValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	v.Client = mgr.GetClient()
+	v.Client = sideEffectFree(mgr.GetClient())
 	v.templateKind = v1alpha1.ProviderTemplateKind
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&v1alpha1.ProviderTemplate{}).
@@ -286,6 +286,16 @@ func (v TemplateValidator) templateIsInUseByCluster(ctx context.Context, templat
 	if len(clusterDeployments.Items) > 0 {
 		return true, nil
 	}
+
+	// the ClusterTemplates granted to the other namespaces may be referenced from them
+	if v.templateKind == v1alpha1.ClusterTemplateKind {
+		if err := v.List(ctx, clusterDeployments,
+			client.MatchingFields{v1alpha1.ClusterDeploymentTemplateRefIndexKey: client.ObjectKeyFromObject(template).String()},
+			client.Limit(1)); err != nil {
+			return false, err
+		}
+		return len(clusterDeployments.Items) > 0, nil
+	}
 	return false, nil
 }
 
@@ -213,6 +213,16 @@ func TestClusterTemplateValidateDelete(t *testing.T) {
 				}),
 			),
 		},
+		{
+			title:    "should fail if ClusterDeployment object from another namespace references the template with the templateRef",
+			template: tpl,
+			existingObjects: []runtime.Object{clusterdeployment.NewClusterDeployment(
+				clusterdeployment.WithNamespace("tenant"),
+				clusterdeployment.WithClusterTemplateRef(templateNamespace, tpl.Name),
+			)},
+			warnings: admission.Warnings{"The ClusterTemplate object can't be removed if ClusterDeployment objects referencing it still exist"},
+			err:      "template deletion is forbidden",
+		},
 		{
 			title:    "should succeed if some ClusterDeployment from another namespace references the template with the same name",
 			template: tpl,
@@ -236,6 +246,7 @@ func TestClusterTemplateValidateDelete(t *testing.T) {
 				WithScheme(scheme.Scheme).
 				WithRuntimeObjects(tt.existingObjects...).
 				WithIndex(&v1alpha1.ClusterDeployment{}, v1alpha1.ClusterDeploymentTemplateIndexKey, v1alpha1.ExtractTemplateNameFromClusterDeployment).
+				WithIndex(&v1alpha1.ClusterDeployment{}, v1alpha1.ClusterDeploymentTemplateRefIndexKey, v1alpha1.ExtractTemplateRefFromClusterDeployment).
 				Build()
 			validator := &ClusterTemplateValidator{
 				TemplateValidator: TemplateValidator{
@@ -36,7 +36,7 @@ type ClusterTemplateChainValidator struct {
 }
 
 func (in *ClusterTemplateChainValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	in.Client = mgr.GetClient()
+	in.Client = sideEffectFree(mgr.GetClient())
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&v1alpha1.ClusterTemplateChain{}).
 		WithValidator(in).
@@ -74,7 +74,7 @@ type ServiceTemplateChainValidator struct {
 }
 
 func (in *ServiceTemplateChainValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	in.Client = mgr.GetClient()
+	in.Client = sideEffectFree(mgr.GetClient())
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&v1alpha1.ServiceTemplateChain{}).
 		WithValidator(in).
@@ -58,6 +58,17 @@ func TestClusterTemplateChainValidateCreate(t *testing.T) {
 			},
 			err: "the template chain spec is invalid",
 		},
+		{
+			name: "should fail if spec is invalid: upgrade cycle",
+			chain: tc.NewClusterTemplateChain(tc.WithName("test"), tc.WithSupportedTemplates(append(supportedTemplates, v1alpha1.SupportedTemplate{
+				Name:              upgradeToTemplateName,
+				AvailableUpgrades: []v1alpha1.AvailableUpgrade{{Name: upgradeFromTemplateName}},
+			}))),
+			warnings: admission.Warnings{
+				"the upgrade graph contains a cycle: template-1-0-1 -> template-1-0-2 -> template-1-0-1",
+			},
+			err: "the template chain spec is invalid",
+		},
 		{
 			name:  "should succeed",
 			chain: tc.NewClusterTemplateChain(tc.WithName("test"), tc.WithSupportedTemplates(append(supportedTemplates, v1alpha1.SupportedTemplate{Name: upgradeToTemplateName}))),
@@ -0,0 +1,87 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/utils/vip"
+)
+
+var errVIPPoolDeletionForbidden = errors.New("VIPPool deletion is forbidden")
+
+type VIPPoolValidator struct {
+	client.Client
+}
+
+func (v *VIPPoolValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	v.Client = sideEffectFree(mgr.GetClient())
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.VIPPool{}).
+		WithValidator(v).
+		Complete()
+}
+
+var _ webhook.CustomValidator = &VIPPoolValidator{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (*VIPPoolValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	pool, ok := obj.(*v1alpha1.VIPPool)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected VIPPool but got a %T", obj))
+	}
+
+	if _, err := vip.ParseRanges(pool.Spec.Addresses); err != nil {
+		return nil, fmt.Errorf("invalid VIPPool addresses: %w", err)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (v *VIPPoolValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (v *VIPPoolValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	pool, ok := obj.(*v1alpha1.VIPPool)
+	if !ok {
+		return admission.Warnings{"Wrong object"}, apierrors.NewBadRequest(fmt.Sprintf("expected VIPPool but got a %T", obj))
+	}
+
+	clusterDeployments := &v1alpha1.ClusterDeploymentList{}
+	if err := v.List(ctx, clusterDeployments,
+		client.MatchingFields{v1alpha1.ClusterDeploymentVIPPoolIndexKey: pool.Name},
+		client.Limit(1)); err != nil {
+		return nil, fmt.Errorf("failed to list ClusterDeployments: %w", err)
+	}
+
+	if len(clusterDeployments.Items) > 0 {
+		return admission.Warnings{"The VIPPool object can't be removed if ClusterDeployment objects referencing it still exist"}, errVIPPoolDeletionForbidden
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/test/objects/clusterdeployment"
+	"github.com/K0rdent/kcm/test/objects/vippool"
+	"github.com/K0rdent/kcm/test/scheme"
+)
+
+func TestVIPPoolValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+
+	tests := []struct {
+		name string
+		pool *v1alpha1.VIPPool
+		err  string
+	}{
+		{
+			name: "should fail if an address is malformed",
+			pool: vippool.NewVIPPool(vippool.WithAddresses("10.0.0.10", "10.0.0.300")),
+			err:  "invalid VIPPool addresses: failed to parse address 10.0.0.300",
+		},
+		{
+			name: "should fail if a range is in the reverse order",
+			pool: vippool.NewVIPPool(vippool.WithAddresses("10.0.0.20-10.0.0.10")),
+			err:  "invalid VIPPool addresses: address range 10.0.0.20-10.0.0.10 is in the reverse order",
+		},
+		{
+			name: "should fail if a range mixes address families",
+			pool: vippool.NewVIPPool(vippool.WithAddresses("10.0.0.10-fd00::10")),
+			err:  "invalid VIPPool addresses: address range 10.0.0.10-fd00::10 mixes IPv4 and IPv6 addresses",
+		},
+		{
+			name: "should succeed",
+			pool: vippool.NewVIPPool(vippool.WithAddresses("10.0.0.10", "10.0.1.0/28", "10.0.2.10-10.0.2.20", "fd00::/120")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+			validator := &VIPPoolValidator{Client: c}
+			warn, err := validator.ValidateCreate(ctx, tt.pool)
+			if tt.err != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+			g.Expect(warn).To(BeEmpty())
+		})
+	}
+}
+
+func TestVIPPoolValidateDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+
+	tests := []struct {
+		name            string
+		pool            *v1alpha1.VIPPool
+		existingObjects []runtime.Object
+		err             string
+		warnings        admission.Warnings
+	}{
+		{
+			name: "should fail if ClusterDeployment objects referencing the VIPPool exist",
+			pool: vippool.NewVIPPool(),
+			existingObjects: []runtime.Object{
+				clusterdeployment.NewClusterDeployment(clusterdeployment.WithControlPlaneVIP("", vippool.DefaultName)),
+			},
+			err:      "VIPPool deletion is forbidden",
+			warnings: admission.Warnings{"The VIPPool object can't be removed if ClusterDeployment objects referencing it still exist"},
+		},
+		{
+			name: "should succeed if ClusterDeployment objects reference another VIPPool",
+			pool: vippool.NewVIPPool(),
+			existingObjects: []runtime.Object{
+				clusterdeployment.NewClusterDeployment(clusterdeployment.WithControlPlaneVIP("", "other")),
+			},
+		},
+		{
+			name: "should succeed",
+			pool: vippool.NewVIPPool(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithRuntimeObjects(tt.existingObjects...).
+				WithIndex(&v1alpha1.ClusterDeployment{}, v1alpha1.ClusterDeploymentVIPPoolIndexKey, v1alpha1.ExtractVIPPoolFromClusterDeployment).
+				Build()
+			validator := &VIPPoolValidator{Client: c}
+			warn, err := validator.ValidateDelete(ctx, tt.pool)
+			if tt.err != "" {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(Equal(tt.err))
+			} else {
+				g.Expect(err).To(Succeed())
+			}
+			g.Expect(warn).To(Equal(tt.warnings))
+		})
+	}
+}
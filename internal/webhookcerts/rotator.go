@@ -0,0 +1,669 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookcerts
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// ProviderCertManager denotes the webhook certificates issued by cert-manager.
+	ProviderCertManager = "cert-manager"
+	// ProviderBuiltin denotes the webhook certificates issued by the self-signed CA maintained by the controller.
+	ProviderBuiltin = "builtin"
+
+	// CACertKey is the key of the CA certificate in the certificate Secret.
+	CACertKey = "ca.crt"
+	// CAKey is the key of the CA private key in the certificate Secret.
+	CAKey = "ca.key"
+	// NextCACertKey is the key of the new CA certificate in the certificate Secret,
+	// the new CA is trusted before the serving certificate is signed with it.
+	NextCACertKey = "next-ca.crt"
+	// NextCAKey is the key of the new CA private key in the certificate Secret.
+	NextCAKey = "next-ca.key"
+	// PreviousCACertKey is the key of the replaced CA certificate in the certificate Secret,
+	// the replaced CA is trusted until every replica serves the certificate signed with the new CA.
+	PreviousCACertKey = "previous-ca.crt"
+
+	// DefaultInterval is the default interval of the certificates rotation checks.
+	DefaultInterval = time.Hour
+	// DefaultSyncInterval is the default interval the replicas pick up the rotated certificates at.
+	DefaultSyncInterval = time.Minute
+
+	// webhookPortName is the name of the port of the webhook Service the webhooks are served at.
+	webhookPortName = "webhook"
+	probeTimeout    = 5 * time.Second
+
+	caValidity          = 10 * 365 * 24 * time.Hour
+	caRotateBefore      = 365 * 24 * time.Hour
+	servingValidity     = 365 * 24 * time.Hour
+	servingRotateBefore = 90 * 24 * time.Hour
+)
+
+// Rotator provisions and rotates the serving certificates of the admission webhooks
+// with a self-signed CA, stores them in the Secret in the format used by cert-manager,
+// writes them to the webhook certificates directory and injects the CA bundle into
+// the webhook configurations and CRD conversion webhooks served by the webhook Service.
+//
+// The certificates are rotated by the leader only, the replicas pick them up with the [Syncer].
+// The CA is rotated in steps, each taken once the CA bundle of the previous one is injected:
+// the new CA is added to the CA bundle, then the serving certificate is signed with the new CA,
+// and the old CA is dropped from the CA bundle once every replica serves the new certificate.
+type Rotator struct {
+	// Client must not be backed by the manager cache,
+	// the certificates are provisioned before the manager is started.
+	client.Client
+
+	// Now returns the current time, defaults to time.Now.
+	Now func() time.Time
+
+	SecretName    string
+	Namespace     string
+	ServiceName   string
+	ClusterDomain string
+	CertDir       string
+	Interval      time.Duration
+	SyncInterval  time.Duration
+}
+
+// NeedLeaderElection implements the [sigs.k8s.io/controller-runtime/pkg/manager.LeaderElectionRunnable]
+// interface, the certificates are rotated by the leader only.
+func (*Rotator) NeedLeaderElection() bool {
+	return true
+}
+
+func (r *Rotator) Start(ctx context.Context) error {
+	l := log.FromContext(ctx).WithName("webhook certificates rotator")
+
+	interval := r.Interval
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := r.Reconcile(ctx); err != nil {
+			l.Error(err, "failed to reconcile webhook certificates")
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// Syncer returns the [Syncer] of the certificates rotated by the given [Rotator].
+func (r *Rotator) Syncer() *Syncer {
+	return &Syncer{rotator: r}
+}
+
+// Bootstrap provisions the certificates if they do not exist yet and writes them to the certificates
+// directory, so the webhooks can be served before the leader is elected. The certificates are
+// issued only if the Secret does not exist, the Secret created concurrently by another replica is used as is.
+func (r *Rotator) Bootstrap(ctx context.Context) error {
+	secret, err := r.getSecret(ctx)
+	if err != nil {
+		return err
+	}
+
+	if secret == nil {
+		data, err := issue(r.now(), r.dnsNames())
+		if err != nil {
+			return err
+		}
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: r.Namespace, Name: r.SecretName},
+			Type:       corev1.SecretTypeTLS,
+			Data:       data,
+		}
+		switch err := r.Create(ctx, secret); {
+		case apierrors.IsAlreadyExists(err):
+			if secret, err = r.getSecret(ctx); err != nil {
+				return err
+			}
+		case err != nil:
+			return fmt.Errorf("failed to store webhook certificates in Secret %s: %w", client.ObjectKeyFromObject(secret), err)
+		default:
+			log.FromContext(ctx).Info("Issued webhook certificates", "secret", client.ObjectKeyFromObject(secret))
+			if err := r.injectCABundle(ctx, caBundle(secret.Data)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return r.writeCertificates(secret)
+}
+
+// Reconcile takes the next step of the rotation of the certificates stored in the Secret,
+// writes them to the certificates directory and ensures they are trusted by the API server.
+func (r *Rotator) Reconcile(ctx context.Context) error {
+	secret, err := r.getSecret(ctx)
+	if err != nil {
+		return err
+	}
+	if secret == nil {
+		return r.Bootstrap(ctx)
+	}
+
+	// the next step is taken only once the current CA bundle is trusted
+	if err := r.injectCABundle(ctx, caBundle(secret.Data)); err != nil {
+		return err
+	}
+
+	data, err := r.rotate(ctx, secret.Data)
+	if err != nil {
+		return err
+	}
+	if data != nil {
+		secret.Data = data
+		if err := r.Update(ctx, secret); err != nil {
+			return fmt.Errorf("failed to store webhook certificates in Secret %s: %w", client.ObjectKeyFromObject(secret), err)
+		}
+		log.FromContext(ctx).Info("Rotated webhook certificates", "secret", client.ObjectKeyFromObject(secret))
+
+		if err := r.injectCABundle(ctx, caBundle(secret.Data)); err != nil {
+			return err
+		}
+	}
+
+	return r.writeCertificates(secret)
+}
+
+// Sync writes the certificates stored in the Secret to the certificates directory.
+func (r *Rotator) Sync(ctx context.Context) error {
+	secret, err := r.getSecret(ctx)
+	if err != nil || secret == nil {
+		return err
+	}
+
+	return r.writeCertificates(secret)
+}
+
+// Syncer picks up the certificates rotated by the leader on each replica.
+type Syncer struct {
+	rotator *Rotator
+}
+
+// NeedLeaderElection implements the [sigs.k8s.io/controller-runtime/pkg/manager.LeaderElectionRunnable]
+// interface, each replica serves the webhooks hence requires the certificates.
+func (*Syncer) NeedLeaderElection() bool {
+	return false
+}
+
+func (s *Syncer) Start(ctx context.Context) error {
+	l := log.FromContext(ctx).WithName("webhook certificates syncer")
+
+	interval := s.rotator.SyncInterval
+	if interval == 0 {
+		interval = DefaultSyncInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.rotator.Sync(ctx); err != nil {
+				l.Error(err, "failed to sync webhook certificates")
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (r *Rotator) now() time.Time {
+	if r.Now != nil {
+		return r.Now()
+	}
+	return time.Now()
+}
+
+func (r *Rotator) dnsNames() []string {
+	clusterDomain := r.ClusterDomain
+	if clusterDomain == "" {
+		clusterDomain = "cluster.local"
+	}
+
+	svc := r.ServiceName + "." + r.Namespace + ".svc"
+	return []string{svc, svc + "." + clusterDomain}
+}
+
+// getSecret returns the Secret storing the certificates or nil if it does not exist.
+func (r *Rotator) getSecret(ctx context.Context) (*corev1.Secret, error) {
+	secret := new(corev1.Secret)
+	key := client.ObjectKey{Namespace: r.Namespace, Name: r.SecretName}
+	if err := r.Get(ctx, key, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get webhook certificate Secret %s: %w", key, err)
+	}
+
+	return secret, nil
+}
+
+// rotate returns the Secret data with the next step of the rotation of the given certificates taken
+// or nil if the certificates are valid.
+func (r *Rotator) rotate(ctx context.Context, data map[string][]byte) (map[string][]byte, error) {
+	now := r.now()
+
+	ca, caKey, err := parseKeyPair(data[CACertKey], data[CAKey])
+	if err != nil || now.After(ca.NotAfter) {
+		// nothing to roll over from
+		return issue(now, r.dnsNames())
+	}
+
+	next := maps.Clone(data)
+	switch {
+	case len(data[NextCACertKey]) > 0:
+		// the new CA is trusted, the serving certificate is signed with it
+		delete(next, NextCACertKey)
+		delete(next, NextCAKey)
+
+		nextCA, nextCAKey, err := parseKeyPair(data[NextCACertKey], data[NextCAKey])
+		if err != nil {
+			return next, nil
+		}
+
+		certPEM, keyPEM, err := newServingCert(now, nextCA, nextCAKey, r.dnsNames())
+		if err != nil {
+			return nil, err
+		}
+
+		next[PreviousCACertKey] = data[CACertKey]
+		next[CACertKey] = data[NextCACertKey]
+		next[CAKey] = data[NextCAKey]
+		next[corev1.TLSCertKey] = certPEM
+		next[corev1.TLSPrivateKeyKey] = keyPEM
+		return next, nil
+	case len(data[PreviousCACertKey]) > 0:
+		// the replaced CA is trusted until every replica serves the certificate signed with the new CA
+		if !r.servedByAllReplicas(ctx, ca) {
+			return nil, nil
+		}
+
+		delete(next, PreviousCACertKey)
+		return next, nil
+	case now.Add(caRotateBefore).After(ca.NotAfter):
+		nextCA, nextCAKey, err := newCA(now)
+		if err != nil {
+			return nil, err
+		}
+		nextCAKeyPEM, err := encodeKey(nextCAKey)
+		if err != nil {
+			return nil, err
+		}
+
+		next[NextCACertKey] = encodeCert(nextCA.Raw)
+		next[NextCAKey] = nextCAKeyPEM
+		return next, nil
+	}
+
+	cert, _, err := parseKeyPair(data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey])
+	if err == nil &&
+		cert.CheckSignatureFrom(ca) == nil &&
+		slices.Equal(cert.DNSNames, r.dnsNames()) &&
+		now.Add(servingRotateBefore).Before(cert.NotAfter) {
+		return nil, nil
+	}
+
+	certPEM, keyPEM, err := newServingCert(now, ca, caKey, r.dnsNames())
+	if err != nil {
+		return nil, err
+	}
+
+	next[corev1.TLSCertKey] = certPEM
+	next[corev1.TLSPrivateKeyKey] = keyPEM
+	return next, nil
+}
+
+// servedByAllReplicas returns true if every ready endpoint of the webhook Service
+// serves the certificate signed with the given CA.
+func (r *Rotator) servedByAllReplicas(ctx context.Context, ca *x509.Certificate) bool {
+	l := log.FromContext(ctx)
+
+	endpointSlices := new(discoveryv1.EndpointSliceList)
+	if err := r.List(ctx, endpointSlices, client.InNamespace(r.Namespace), client.MatchingLabels{discoveryv1.LabelServiceName: r.ServiceName}); err != nil {
+		l.Error(err, "failed to list EndpointSlices of the webhook Service")
+		return false
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: probeTimeout},
+		Config:    &tls.Config{RootCAs: roots, ServerName: r.dnsNames()[0], Time: r.now, MinVersion: tls.VersionTLS12},
+	}
+
+	for _, slice := range endpointSlices.Items {
+		port := webhookPort(slice.Ports)
+		if port == 0 {
+			continue
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+
+			for _, address := range endpoint.Addresses {
+				conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(address, strconv.Itoa(int(port))))
+				if err != nil {
+					l.V(1).Info("Webhook replica does not serve the rotated certificate yet", "address", address, "error", err.Error())
+					return false
+				}
+				_ = conn.Close()
+			}
+		}
+	}
+
+	return true
+}
+
+// webhookPort returns the port of the given EndpointSlice ports the webhooks are served at.
+func webhookPort(ports []discoveryv1.EndpointPort) int32 {
+	for _, port := range ports {
+		if port.Port != nil && (len(ports) == 1 || port.Name != nil && *port.Name == webhookPortName) {
+			return *port.Port
+		}
+	}
+	return 0
+}
+
+// caBundle returns the CA bundle trusting the serving certificates signed with any of the CAs
+// of the given Secret data.
+func caBundle(data map[string][]byte) []byte {
+	return slices.Concat(data[PreviousCACertKey], data[CACertKey], data[NextCACertKey])
+}
+
+// issue returns the Secret data with the new CA and the serving certificate signed with it.
+func issue(now time.Time, dnsNames []string) (map[string][]byte, error) {
+	ca, caKey, err := newCA(now)
+	if err != nil {
+		return nil, err
+	}
+
+	certPEM, keyPEM, err := newServingCert(now, ca, caKey, dnsNames)
+	if err != nil {
+		return nil, err
+	}
+
+	caKeyPEM, err := encodeKey(caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{
+		CACertKey:               encodeCert(ca.Raw),
+		CAKey:                   caKeyPEM,
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+	}, nil
+}
+
+// writeCertificates writes the serving certificate into the certificates directory if it has changed,
+// the webhook server picks up the new certificate without a restart.
+func (r *Rotator) writeCertificates(secret *corev1.Secret) error {
+	if err := os.MkdirAll(r.CertDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create webhook certificates directory: %w", err)
+	}
+
+	// the key goes first so that the certificate watcher never observes a mismatching pair
+	for _, name := range []string{corev1.TLSPrivateKeyKey, corev1.TLSCertKey} {
+		path := filepath.Join(r.CertDir, name)
+		if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, secret.Data[name]) {
+			continue
+		}
+
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, secret.Data[name], 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// injectCABundle sets the CA bundle of the webhooks and the CRD conversion webhooks served by the webhook Service.
+func (r *Rotator) injectCABundle(ctx context.Context, caBundle []byte) error {
+	var errs error
+
+	validating := new(admissionregistrationv1.ValidatingWebhookConfigurationList)
+	if err := r.List(ctx, validating); err != nil {
+		return fmt.Errorf("failed to list ValidatingWebhookConfigurations: %w", err)
+	}
+	for i := range validating.Items {
+		config := &validating.Items[i]
+		original := config.DeepCopy()
+		changed := false
+		for j := range config.Webhooks {
+			changed = r.setCABundle(&config.Webhooks[j].ClientConfig, caBundle) || changed
+		}
+		if changed {
+			errs = errors.Join(errs, r.patch(ctx, config, original))
+		}
+	}
+
+	mutating := new(admissionregistrationv1.MutatingWebhookConfigurationList)
+	if err := r.List(ctx, mutating); err != nil {
+		return fmt.Errorf("failed to list MutatingWebhookConfigurations: %w", err)
+	}
+	for i := range mutating.Items {
+		config := &mutating.Items[i]
+		original := config.DeepCopy()
+		changed := false
+		for j := range config.Webhooks {
+			changed = r.setCABundle(&config.Webhooks[j].ClientConfig, caBundle) || changed
+		}
+		if changed {
+			errs = errors.Join(errs, r.patch(ctx, config, original))
+		}
+	}
+
+	crds := new(apiextv1.CustomResourceDefinitionList)
+	if err := r.List(ctx, crds); err != nil {
+		return fmt.Errorf("failed to list CustomResourceDefinitions: %w", err)
+	}
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		conversion := crd.Spec.Conversion
+		if conversion == nil || conversion.Webhook == nil || conversion.Webhook.ClientConfig == nil {
+			continue
+		}
+
+		clientConfig := conversion.Webhook.ClientConfig
+		if clientConfig.Service == nil || clientConfig.Service.Name != r.ServiceName || clientConfig.Service.Namespace != r.Namespace ||
+			bytes.Equal(clientConfig.CABundle, caBundle) {
+			continue
+		}
+
+		original := crd.DeepCopy()
+		clientConfig.CABundle = caBundle
+		errs = errors.Join(errs, r.patch(ctx, crd, original))
+	}
+
+	return errs
+}
+
+func (r *Rotator) setCABundle(clientConfig *admissionregistrationv1.WebhookClientConfig, caBundle []byte) bool {
+	if clientConfig.Service == nil || clientConfig.Service.Name != r.ServiceName || clientConfig.Service.Namespace != r.Namespace ||
+		bytes.Equal(clientConfig.CABundle, caBundle) {
+		return false
+	}
+
+	clientConfig.CABundle = caBundle
+	return true
+}
+
+func (r *Rotator) patch(ctx context.Context, obj, original client.Object) error {
+	if err := r.Patch(ctx, obj, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to inject CA bundle into %T %s: %w", obj, obj.GetName(), err)
+	}
+	return nil
+}
+
+func newCA(now time.Time) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "kcm-webhook-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+
+	return ca, key, nil
+}
+
+func newServingCert(now time.Time, ca *x509.Certificate, caKey *ecdsa.PrivateKey, dnsNames []string) (certPEM, keyPEM []byte, _ error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate serving key: %w", err)
+	}
+
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notAfter := now.Add(servingValidity)
+	if notAfter.After(ca.NotAfter) {
+		notAfter = ca.NotAfter
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create serving certificate: %w", err)
+	}
+
+	keyPEM, err = encodeKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return encodeCert(der), keyPEM, nil
+}
+
+func newSerialNumber() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	return serial, nil
+}
+
+func parseKeyPair(certPEM, keyPEM []byte) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil {
+		return nil, nil, errors.New("no PEM encoded certificate found")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, nil, errors.New("no PEM encoded private key found")
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	if !key.PublicKey.Equal(cert.PublicKey) {
+		return nil, nil, errors.New("private key does not match the certificate")
+	}
+
+	return cert, key, nil
+}
+
+func encodeCert(der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func encodeKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
@@ -0,0 +1,343 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookcerts
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func serviceClientConfig(name string) admissionregistrationv1.WebhookClientConfig {
+	return admissionregistrationv1.WebhookClientConfig{
+		Service: &admissionregistrationv1.ServiceReference{Namespace: "kcm-system", Name: name},
+	}
+}
+
+func parseCert(t *testing.T, data []byte) *x509.Certificate {
+	t.Helper()
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		t.Fatalf("no PEM encoded certificate found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	return cert
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	if err := apiextv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "kcm-validating-webhook-configuration"},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{Name: "validation.clusterdeployment.k0rdent.mirantis.com", ClientConfig: serviceClientConfig("kcm-webhook-service")},
+		},
+	}
+	foreign := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "foreign-validating-webhook-configuration"},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{Name: "validation.foreign.example.com", ClientConfig: serviceClientConfig("foreign-webhook-service")},
+		},
+	}
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "kcm-mutating-webhook-configuration"},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{Name: "mutation.clusterdeployment.k0rdent.mirantis.com", ClientConfig: serviceClientConfig("kcm-webhook-service")},
+		},
+	}
+	crd := &apiextv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "clusterdeployments.k0rdent.mirantis.com"},
+		Spec: apiextv1.CustomResourceDefinitionSpec{
+			Conversion: &apiextv1.CustomResourceConversion{
+				Strategy: apiextv1.WebhookConverter,
+				Webhook: &apiextv1.WebhookConversion{
+					ClientConfig: &apiextv1.WebhookClientConfig{
+						Service: &apiextv1.ServiceReference{Namespace: "kcm-system", Name: "kcm-webhook-service"},
+					},
+				},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(validating, foreign, mutating, crd).Build()
+
+	now := time.Now()
+	r := &Rotator{
+		Client:      cl,
+		Now:         func() time.Time { return now },
+		SecretName:  "kcm-webhook-serving-cert",
+		Namespace:   "kcm-system",
+		ServiceName: "kcm-webhook-service",
+		CertDir:     filepath.Join(t.TempDir(), "serving-certs"),
+	}
+	if err := r.Reconcile(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	secret := new(corev1.Secret)
+	if err := cl.Get(t.Context(), client.ObjectKey{Namespace: "kcm-system", Name: "kcm-webhook-serving-cert"}, secret); err != nil {
+		t.Fatalf("failed to get Secret: %v", err)
+	}
+	caBundle := secret.Data[CACertKey]
+
+	cert := parseCert(t, secret.Data[corev1.TLSCertKey])
+	expectedDNSNames := []string{"kcm-webhook-service.kcm-system.svc", "kcm-webhook-service.kcm-system.svc.cluster.local"}
+	if !reflect.DeepEqual(cert.DNSNames, expectedDNSNames) {
+		t.Errorf("unexpected DNS names %v, expected %v", cert.DNSNames, expectedDNSNames)
+	}
+	if err := cert.CheckSignatureFrom(parseCert(t, caBundle)); err != nil {
+		t.Errorf("serving certificate is not signed by the CA: %v", err)
+	}
+
+	if _, err := tls.LoadX509KeyPair(filepath.Join(r.CertDir, corev1.TLSCertKey), filepath.Join(r.CertDir, corev1.TLSPrivateKeyKey)); err != nil {
+		t.Errorf("failed to load written certificates: %v", err)
+	}
+
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(validating), validating); err != nil {
+		t.Fatalf("failed to get ValidatingWebhookConfiguration: %v", err)
+	}
+	if !bytes.Equal(validating.Webhooks[0].ClientConfig.CABundle, caBundle) {
+		t.Errorf("expected CA bundle to be injected into ValidatingWebhookConfiguration")
+	}
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(mutating), mutating); err != nil {
+		t.Fatalf("failed to get MutatingWebhookConfiguration: %v", err)
+	}
+	if !bytes.Equal(mutating.Webhooks[0].ClientConfig.CABundle, caBundle) {
+		t.Errorf("expected CA bundle to be injected into MutatingWebhookConfiguration")
+	}
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(crd), crd); err != nil {
+		t.Fatalf("failed to get CustomResourceDefinition: %v", err)
+	}
+	if !bytes.Equal(crd.Spec.Conversion.Webhook.ClientConfig.CABundle, caBundle) {
+		t.Errorf("expected CA bundle to be injected into CustomResourceDefinition")
+	}
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(foreign), foreign); err != nil {
+		t.Fatalf("failed to get ValidatingWebhookConfiguration: %v", err)
+	}
+	if len(foreign.Webhooks[0].ClientConfig.CABundle) != 0 {
+		t.Errorf("expected CA bundle not to be injected into foreign ValidatingWebhookConfiguration")
+	}
+
+	// the valid certificates are kept
+	resourceVersion := secret.ResourceVersion
+	if err := r.Reconcile(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(secret), secret); err != nil {
+		t.Fatalf("failed to get Secret: %v", err)
+	}
+	if secret.ResourceVersion != resourceVersion {
+		t.Errorf("expected valid certificates not to be reissued")
+	}
+
+	// the expiring serving certificate is rotated with the same CA
+	now = now.Add(servingValidity - servingRotateBefore + time.Hour)
+	if err := r.Reconcile(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cl.Get(t.Context(), client.ObjectKeyFromObject(secret), secret); err != nil {
+		t.Fatalf("failed to get Secret: %v", err)
+	}
+	if !bytes.Equal(secret.Data[CACertKey], caBundle) {
+		t.Errorf("expected CA to be kept")
+	}
+	if rotated := parseCert(t, secret.Data[corev1.TLSCertKey]); rotated.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+		t.Errorf("expected serving certificate to be rotated")
+	}
+	written, err := os.ReadFile(filepath.Join(r.CertDir, corev1.TLSCertKey))
+	if err != nil {
+		t.Fatalf("failed to read written certificate: %v", err)
+	}
+	if !bytes.Equal(written, secret.Data[corev1.TLSCertKey]) {
+		t.Errorf("expected rotated certificate to be written")
+	}
+
+	// the expiring CA is rotated in steps
+	oldCA := secret.Data[CACertKey]
+	reconcile := func() {
+		t.Helper()
+		if err := r.Reconcile(t.Context()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := cl.Get(t.Context(), client.ObjectKeyFromObject(secret), secret); err != nil {
+			t.Fatalf("failed to get Secret: %v", err)
+		}
+		if err := cl.Get(t.Context(), client.ObjectKeyFromObject(validating), validating); err != nil {
+			t.Fatalf("failed to get ValidatingWebhookConfiguration: %v", err)
+		}
+	}
+
+	// the new CA is trusted first
+	now = now.Add(caValidity - caRotateBefore)
+	servingCert, servingKey := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	reconcile()
+	newCA := secret.Data[NextCACertKey]
+	if len(newCA) == 0 || !bytes.Equal(secret.Data[CACertKey], oldCA) || !bytes.Equal(secret.Data[corev1.TLSCertKey], servingCert) {
+		t.Fatalf("expected the new CA to be added without replacing the current one")
+	}
+	if !bytes.Equal(validating.Webhooks[0].ClientConfig.CABundle, slices.Concat(oldCA, newCA)) {
+		t.Errorf("expected both CAs to be trusted")
+	}
+
+	// the serving certificate is signed with the new CA once it is trusted
+	reconcile()
+	if !bytes.Equal(secret.Data[CACertKey], newCA) || !bytes.Equal(secret.Data[PreviousCACertKey], oldCA) || len(secret.Data[NextCACertKey]) > 0 {
+		t.Fatalf("expected the new CA to replace the current one")
+	}
+	if err := parseCert(t, secret.Data[corev1.TLSCertKey]).CheckSignatureFrom(parseCert(t, newCA)); err != nil {
+		t.Errorf("serving certificate is not signed by the rotated CA: %v", err)
+	}
+	if !bytes.Equal(validating.Webhooks[0].ClientConfig.CABundle, slices.Concat(oldCA, newCA)) {
+		t.Errorf("expected both CAs to be trusted")
+	}
+
+	// the old CA is trusted until every replica serves the certificate signed with the new CA
+	replica := newReplica(t, servingCert, servingKey)
+	if err := cl.Create(t.Context(), replica.endpointSlice("kcm-system", "kcm-webhook-service")); err != nil {
+		t.Fatalf("failed to create EndpointSlice: %v", err)
+	}
+	reconcile()
+	if !bytes.Equal(secret.Data[PreviousCACertKey], oldCA) {
+		t.Fatalf("expected the old CA to be trusted while the replica serves the old certificate")
+	}
+
+	replica.serve(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	reconcile()
+	if len(secret.Data[PreviousCACertKey]) > 0 {
+		t.Fatalf("expected the old CA to be dropped once every replica serves the new certificate")
+	}
+	if !bytes.Equal(validating.Webhooks[0].ClientConfig.CABundle, newCA) {
+		t.Errorf("expected only the new CA to be trusted")
+	}
+}
+
+func TestSync(t *testing.T) {
+	data, err := issue(time.Now(), []string{"kcm-webhook-service.kcm-system.svc"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kcm-system", Name: "kcm-webhook-serving-cert"},
+		Data:       data,
+	}
+	r := &Rotator{
+		Client:     fake.NewClientBuilder().WithObjects(secret).Build(),
+		SecretName: "kcm-webhook-serving-cert",
+		Namespace:  "kcm-system",
+		CertDir:    filepath.Join(t.TempDir(), "serving-certs"),
+	}
+	if r.Syncer().NeedLeaderElection() || !r.NeedLeaderElection() {
+		t.Errorf("expected the certificates to be rotated by the leader and synced by each replica")
+	}
+
+	if err := r.Sync(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	written, err := os.ReadFile(filepath.Join(r.CertDir, corev1.TLSCertKey))
+	if err != nil {
+		t.Fatalf("failed to read written certificate: %v", err)
+	}
+	if !bytes.Equal(written, data[corev1.TLSCertKey]) {
+		t.Errorf("expected the certificate of the Secret to be written")
+	}
+}
+
+// replica serves the webhooks with the given certificate on the local address.
+type replica struct {
+	listener net.Listener
+	cert     atomic.Pointer[tls.Certificate]
+}
+
+func newReplica(t *testing.T, certPEM, keyPEM []byte) *replica {
+	t.Helper()
+
+	r := new(replica)
+	r.serve(certPEM, keyPEM)
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) { return r.cert.Load(), nil },
+		MinVersion:     tls.VersionTLS12,
+	})
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	r.listener = listener
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			_ = conn.Close()
+		}
+	}()
+
+	return r
+}
+
+func (r *replica) serve(certPEM, keyPEM []byte) {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		panic(err)
+	}
+	r.cert.Store(&cert)
+}
+
+func (r *replica) endpointSlice(namespace, serviceName string) *discoveryv1.EndpointSlice {
+	addr := r.listener.Addr().(*net.TCPAddr)
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      serviceName + "-abcde",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: serviceName},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   []discoveryv1.Endpoint{{Addresses: []string{addr.IP.String()}}},
+		Ports:       []discoveryv1.EndpointPort{{Name: ptr.To(webhookPortName), Port: ptr.To(int32(addr.Port))}},
+	}
+}
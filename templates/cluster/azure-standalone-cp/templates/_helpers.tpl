@@ -10,6 +10,10 @@
     {{- include "cluster.name" . }}-worker-mt
 {{- end }}
 
+{{- define "azuremachinetemplate.windowsworker.name" -}}
+    {{- include "cluster.name" . }}-windows-worker-mt
+{{- end }}
+
 {{- define "k0scontrolplane.name" -}}
     {{- include "cluster.name" . }}-cp
 {{- end }}
@@ -18,6 +22,97 @@
     {{- include "cluster.name" . }}-machine-config
 {{- end }}
 
+{{- define "k0sworkerconfigtemplate.windows.name" -}}
+    {{- include "cluster.name" . }}-windows-machine-config
+{{- end }}
+
 {{- define "machinedeployment.name" -}}
     {{- include "cluster.name" . }}-md
 {{- end }}
+
+{{- define "machinedeployment.windows.name" -}}
+    {{- include "cluster.name" . }}-windows-md
+{{- end }}
+
+{{/*
+proxy.files returns the systemd drop-in of the given k0s unit setting the proxy of the k0s
+service, containerd and kubelet. The in-cluster destinations are reached without the proxy.
+*/}}
+{{- define "proxy.files" -}}
+{{- with .Values.proxy }}
+{{- if or .httpProxy .httpsProxy }}
+{{- $noProxy := concat (list "localhost" "127.0.0.1" ".svc" ".cluster.local") $.Values.clusterNetwork.pods.cidrBlocks $.Values.clusterNetwork.services.cidrBlocks (.noProxy | default list) -}}
+- path: /etc/systemd/system/{{ $.unit }}.service.d/http-proxy.conf
+  permissions: "0644"
+  content: |
+    [Service]
+    {{- with .httpProxy }}
+    Environment="HTTP_PROXY={{ . }}"
+    {{- end }}
+    {{- with .httpsProxy }}
+    Environment="HTTPS_PROXY={{ . }}"
+    {{- end }}
+    Environment="NO_PROXY={{ join "," $noProxy }}"
+{{- end }}
+{{- end }}
+{{- end }}
+
+{{/*
+trustedca.files returns the trusted CA bundle of the organization added to the trust store
+of the machine, the trust store is updated by the trustedca.commands before k0s is started.
+*/}}
+{{- define "trustedca.files" -}}
+{{- with (.Values.trustedCA).bundle -}}
+- path: /usr/local/share/ca-certificates/kcm-trusted-ca.crt
+  permissions: "0644"
+  content: |
+    {{- . | trim | nindent 4 }}
+{{- end }}
+{{- end }}
+
+{{- define "trustedca.commands" -}}
+{{- if (.Values.trustedCA).bundle -}}
+- update-ca-certificates
+{{- end }}
+{{- end }}
+
+{{/*
+machineaccess.files returns the public SSH keys authorized for the user of the machine
+and the sshd configuration reading them in addition to the keys of the user.
+*/}}
+{{- define "machineaccess.files" -}}
+{{- with .Values.machineAccess }}
+{{- if and .user .authorizedKeys -}}
+- path: /etc/ssh/kcm_authorized_keys
+  permissions: "0644"
+  content: |
+    {{- .authorizedKeys | trim | nindent 4 }}
+- path: /etc/ssh/sshd_config.d/10-kcm-authorized-keys.conf
+  permissions: "0644"
+  content: |
+    Match User {{ .user }}
+      AuthorizedKeysFile .ssh/authorized_keys /etc/ssh/kcm_authorized_keys
+{{- end }}
+{{- end }}
+{{- end }}
+
+{{- define "machineaccess.commands" -}}
+{{- if and (.Values.machineAccess).user (.Values.machineAccess).authorizedKeys -}}
+- systemctl reload ssh || systemctl reload sshd
+{{- end }}
+{{- end }}
+
+{{/*
+machine.files returns the files of the given k0s unit configuring the proxy, the trusted CA bundle
+and the SSH access of the machine.
+*/}}
+{{- define "machine.files" -}}
+{{- join "\n" (compact (list (include "proxy.files" . | trim) (include "trustedca.files" .) (include "machineaccess.files" .))) }}
+{{- end }}
+
+{{/*
+machine.commands returns the commands applying the trusted CA bundle and the SSH access of the machine.
+*/}}
+{{- define "machine.commands" -}}
+{{- join "\n" (compact (list (include "trustedca.commands" .) (include "machineaccess.commands" .))) }}
+{{- end }}
@@ -102,6 +102,40 @@ The name of the webhook port. Must be no more than 15 characters
 kcm-webhook
 {{- end }}
 
+{{/*
+The name of the Cluster API Runtime Extension port. Must be no more than 15 characters
+*/}}
+{{- define "kcm.runtimeExtension.portName" -}}
+kcm-runtime-ext
+{{- end }}
+
+{{/*
+The name of the fleet view port. Must be no more than 15 characters
+*/}}
+{{- define "kcm.fleetView.portName" -}}
+kcm-fleet-view
+{{- end }}
+
+{{/*
+Whether the monitoring resources are rendered:
+the Prometheus Operator CRDs are installed either by the bundled stack or beforehand
+*/}}
+{{- define "kcm.monitoring.enabled" -}}
+{{- if and .Values.monitoring.enabled (or (index .Values "kube-prometheus-stack" "enabled") (.Capabilities.APIVersions.Has "monitoring.coreos.com/v1/PrometheusRule")) -}}
+true
+{{- end -}}
+{{- end }}
+
+{{/*
+Labels of the monitoring resources
+*/}}
+{{- define "kcm.monitoring.labels" -}}
+{{ include "kcm.labels" . }}
+{{- with .Values.monitoring.labels }}
+{{ toYaml . }}
+{{- end }}
+{{- end }}
+
 {{- define "rbac.editorVerbs" -}}
 - create
 - delete
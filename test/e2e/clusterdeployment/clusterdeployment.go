@@ -41,6 +41,7 @@ const (
 	ProviderAzure   ProviderType = "infrastructure-azure"
 	ProviderGCP     ProviderType = "infrastructure-gcp"
 	ProviderVSphere ProviderType = "infrastructure-vsphere"
+	ProviderDocker  ProviderType = "infrastructure-docker"
 	ProviderAdopted ProviderType = "infrastructure-internal"
 )
 
@@ -77,6 +78,12 @@ var vsphereStandaloneCPClusterDeploymentTemplateBytes []byte
 //go:embed resources/vsphere-hosted-cp.yaml.tpl
 var vsphereHostedCPClusterDeploymentTemplateBytes []byte
 
+//go:embed resources/docker-standalone-cp.yaml.tpl
+var dockerStandaloneCPClusterDeploymentTemplateBytes []byte
+
+//go:embed resources/docker-hosted-cp.yaml.tpl
+var dockerHostedCPClusterDeploymentTemplateBytes []byte
+
 //go:embed resources/adopted-cluster.yaml.tpl
 var adoptedClusterDeploymentTemplateBytes []byte
 
@@ -157,6 +164,10 @@ func GetUnstructured(templateType templates.Type, clusterName, template string)
 		clusterDeploymentTemplateBytes = gcpStandaloneCPClusterDeploymentTemplateBytes
 	case templates.TemplateGCPGKE:
 		clusterDeploymentTemplateBytes = gcpGkeClusterDeploymentTemplateBytes
+	case templates.TemplateDockerStandaloneCP:
+		clusterDeploymentTemplateBytes = dockerStandaloneCPClusterDeploymentTemplateBytes
+	case templates.TemplateDockerHostedCP:
+		clusterDeploymentTemplateBytes = dockerHostedCPClusterDeploymentTemplateBytes
 	case templates.TemplateAdoptedCluster:
 		clusterDeploymentTemplateBytes = adoptedClusterDeploymentTemplateBytes
 	case templates.TemplateRemoteCluster:
@@ -83,6 +83,13 @@ func New(kc *kubeclient.KubeClient, provider clusterdeployment.ProviderType) *Cl
 			},
 		}
 
+	case clusterdeployment.ProviderDocker:
+		// The Docker provider does not require any credentials, the Secret
+		// is only used as an identity the Credential can refer to.
+		kind = "Secret"
+		version = "v1"
+		group = ""
+		identityName = secretName
 	case clusterdeployment.ProviderAWS:
 		resource = "awsclusterstaticidentities"
 		kind = "AWSClusterStaticIdentity"
@@ -169,7 +176,7 @@ func New(kc *kubeclient.KubeClient, provider clusterdeployment.ProviderType) *Cl
 	validateSecretDataPopulated(secretStringData)
 	ci.createSecret(kc)
 
-	if provider != clusterdeployment.ProviderAdopted {
+	if provider != clusterdeployment.ProviderAdopted && provider != clusterdeployment.ProviderDocker {
 		ci.waitForResourceCRD(kc)
 		ci.createClusterIdentity(kc)
 	}
@@ -54,6 +54,9 @@ const (
 	EnvVarAdoptedKubeconfigPath = "KUBECONFIG_DATA_PATH"
 	EnvVarAdoptedCredential     = "ADOPTED_CREDENTIAL"
 
+	// Docker
+	EnvVarDockerCredential = "DOCKER_CREDENTIAL"
+
 	// Remote
 	EnvVarPrivateSSHKeyB64 = "PRIVATE_SSH_KEY_B64"
 )
@@ -85,8 +85,15 @@ func NewProviderValidator(templateType templates.Type, clusterName string, actio
 				"ccm":                       validateCCM,
 			}
 			resourceOrder = []string{"gcp-managed-control-plane", "gcp-managed-machine-pools", "clusters", "csi-driver", "ccm"}
-		case templates.TemplateAzureStandaloneCP, templates.TemplateAzureHostedCP, templates.TemplateVSphereStandaloneCP:
+		case templates.TemplateAzureStandaloneCP, templates.TemplateAzureHostedCP, templates.TemplateVSphereStandaloneCP,
+			templates.TemplateDockerStandaloneCP:
 			delete(resourcesToValidate, "csi-driver")
+		case templates.TemplateDockerHostedCP:
+			resourcesToValidate = map[string]resourceValidationFunc{
+				"clusters":       validateCluster,
+				"machines":       validateMachines,
+				"control-planes": validateK0smotronControlPlanes,
+			}
 		case templates.TemplateAzureAKS:
 			resourcesToValidate = map[string]resourceValidationFunc{
 				"azure-aso-managed-machine-pools": validateAzureASOManagedMachinePools,
@@ -0,0 +1,14 @@
+apiVersion: k0rdent.mirantis.com/v1alpha1
+kind: ClusterDeployment
+metadata:
+  name: ${CLUSTER_DEPLOYMENT_NAME}
+  namespace: ${NAMESPACE}
+spec:
+  template: ${CLUSTER_DEPLOYMENT_TEMPLATE}
+  credential: ${DOCKER_CREDENTIAL}
+  propagateCredentials: false
+  config:
+    workersNumber: ${WORKERS_NUMBER:=1}
+    k0smotron:
+      service:
+        type: NodePort
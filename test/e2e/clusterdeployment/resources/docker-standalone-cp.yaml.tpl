@@ -0,0 +1,12 @@
+apiVersion: k0rdent.mirantis.com/v1alpha1
+kind: ClusterDeployment
+metadata:
+  name: ${CLUSTER_DEPLOYMENT_NAME}
+  namespace: ${NAMESPACE}
+spec:
+  template: ${CLUSTER_DEPLOYMENT_TEMPLATE}
+  credential: ${DOCKER_CREDENTIAL}
+  propagateCredentials: false
+  config:
+    controlPlaneNumber: ${CONTROL_PLANE_NUMBER:=1}
+    workersNumber: ${WORKERS_NUMBER:=1}
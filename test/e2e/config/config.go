@@ -37,6 +37,7 @@ const (
 	TestingProviderAzure   TestingProvider = "azure"
 	TestingProviderGCP     TestingProvider = "gcp"
 	TestingProviderVsphere TestingProvider = "vsphere"
+	TestingProviderDocker  TestingProvider = "docker"
 	TestingProviderAdopted TestingProvider = "adopted"
 	TestingProviderRemote  TestingProvider = "remote"
 )
@@ -115,6 +116,7 @@ func SetDefaults(ctx context.Context, cl crclient.Client) {
 			TestingProviderAzure:   {},
 			TestingProviderGCP:     {},
 			TestingProviderVsphere: {},
+			TestingProviderDocker:  {},
 			TestingProviderAdopted: {},
 			TestingProviderRemote:  {},
 		}
@@ -32,6 +32,8 @@ func getTemplateType(provider TestingProvider) templates.Type {
 		return templates.TemplateGCPStandaloneCP
 	case TestingProviderVsphere:
 		return templates.TemplateVSphereStandaloneCP
+	case TestingProviderDocker:
+		return templates.TemplateDockerStandaloneCP
 	case TestingProviderAdopted:
 		return templates.TemplateAdoptedCluster
 	case TestingProviderRemote:
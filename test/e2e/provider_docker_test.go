@@ -0,0 +1,159 @@
+// Copyright 2024
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+	"github.com/K0rdent/kcm/internal/faultinjection"
+	internalutils "github.com/K0rdent/kcm/internal/utils"
+	"github.com/K0rdent/kcm/test/e2e/clusterdeployment"
+	"github.com/K0rdent/kcm/test/e2e/clusterdeployment/clusteridentity"
+	"github.com/K0rdent/kcm/test/e2e/config"
+	"github.com/K0rdent/kcm/test/e2e/kubeclient"
+	"github.com/K0rdent/kcm/test/e2e/logs"
+	"github.com/K0rdent/kcm/test/e2e/templates"
+	"github.com/K0rdent/kcm/test/e2e/upgrade"
+)
+
+// The Docker provider (CAPD) creates the machines as containers on the host,
+// so the management cluster must have the host's Docker socket mounted, e.g.
+// by running the tests with KIND_CONFIG_PATH=config/dev/kind-docker.yaml.
+var _ = Context("Docker Templates", Label("provider:local", "provider:docker"), Ordered, func() {
+	var (
+		kc              *kubeclient.KubeClient
+		deleteFuncs     = make(map[string]func() error)
+		clusterTypes    = make(map[string]templates.Type)
+		providerConfigs []config.ProviderTestingConfig
+	)
+
+	BeforeAll(func() {
+		By("get testing configuration")
+		providerConfigs = config.Config[config.TestingProviderDocker]
+
+		if len(providerConfigs) == 0 {
+			Skip("Docker ClusterDeployment testing is skipped")
+		}
+
+		By("creating kube client")
+		kc = kubeclient.NewFromLocal(internalutils.DefaultSystemNamespace)
+		By("providing cluster identity")
+		ci := clusteridentity.New(kc, clusterdeployment.ProviderDocker)
+		ci.WaitForValidCredential(kc)
+		Expect(os.Setenv(clusterdeployment.EnvVarDockerCredential, ci.CredentialName)).Should(Succeed())
+	})
+
+	AfterAll(func() {
+		// If we failed collect the support bundle before the cleanup
+		if CurrentSpecReport().Failed() && cleanup() {
+			By("collecting the support bundle from the management cluster")
+			logs.SupportBundle("")
+		}
+
+		if cleanup() {
+			for clusterName, deleteFunc := range deleteFuncs {
+				deletionValidator := clusterdeployment.NewProviderValidator(
+					clusterTypes[clusterName],
+					clusterName,
+					clusterdeployment.ValidationActionDelete,
+				)
+
+				err := deleteFunc()
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(func() error {
+					return deletionValidator.Validate(context.Background(), kc)
+				}).WithTimeout(10 * time.Minute).WithPolling(10 * time.Second).Should(Succeed())
+			}
+		}
+	})
+
+	It("should work with Docker provider", func() {
+		for i, testingConfig := range providerConfigs {
+			_, _ = fmt.Fprintf(GinkgoWriter, "Testing configuration:\n%s\n", testingConfig.String())
+
+			sdTemplate := testingConfig.Template
+			templateType := templates.GetType(sdTemplate)
+			Expect(templateType).To(BeElementOf(templates.TemplateDockerStandaloneCP, templates.TemplateDockerHostedCP),
+				"the template %s is not a Docker template", sdTemplate)
+
+			sdName := clusterdeployment.GenerateClusterName(fmt.Sprintf("docker-%d", i))
+			templateBy(templateType, fmt.Sprintf("creating a ClusterDeployment %s with template %s", sdName, sdTemplate))
+
+			d := clusterdeployment.GetUnstructured(templateType, sdName, sdTemplate)
+			clusterName := d.GetName()
+
+			// The failures are injected into the first deployment only to verify
+			// the reconciliation recovers from the transient errors.
+			injectFailures := i == 0
+			if injectFailures {
+				templateBy(templateType, "injecting transient failures into the reconciliation")
+				d.SetAnnotations(map[string]string{
+					faultinjection.Annotation: fmt.Sprintf("%s:1,%s:1", faultinjection.PointHelmRender, faultinjection.PointCAPIApply),
+				})
+			}
+
+			deleteFuncs[clusterName] = kc.CreateClusterDeployment(context.Background(), d)
+			clusterTypes[clusterName] = templateType
+
+			templateBy(templateType, "waiting for infrastructure to deploy successfully")
+			deploymentValidator := clusterdeployment.NewProviderValidator(
+				templateType,
+				clusterName,
+				clusterdeployment.ValidationActionDeploy,
+			)
+			Eventually(func() error {
+				return deploymentValidator.Validate(context.Background(), kc)
+			}).WithTimeout(30 * time.Minute).WithPolling(10 * time.Second).Should(Succeed())
+
+			if injectFailures {
+				templateBy(templateType, "verifying the injected failures have been reported")
+				events, err := kc.Client.CoreV1().Events(kc.Namespace).List(context.Background(), metav1.ListOptions{
+					FieldSelector: fields.AndSelectors(
+						fields.OneTermEqualSelector("involvedObject.name", clusterName),
+						fields.OneTermEqualSelector("reason", v1alpha1.FailureInjectedReason),
+					).String(),
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(events.Items).NotTo(BeEmpty(), "no %s events found for the ClusterDeployment %s", v1alpha1.FailureInjectedReason, clusterName)
+			}
+
+			if testingConfig.Upgrade {
+				standaloneClient := kc.NewFromCluster(context.Background(), internalutils.DefaultSystemNamespace, clusterName)
+				clusterUpgrade := upgrade.NewClusterUpgrade(
+					kc.CrClient,
+					standaloneClient.CrClient,
+					internalutils.DefaultSystemNamespace,
+					clusterName,
+					testingConfig.UpgradeTemplate,
+					upgrade.NewDefaultClusterValidator(),
+				)
+				clusterUpgrade.Run(context.Background())
+
+				Eventually(func() error {
+					return deploymentValidator.Validate(context.Background(), kc)
+				}).WithTimeout(30 * time.Minute).WithPolling(10 * time.Second).Should(Succeed())
+			}
+		}
+	})
+})
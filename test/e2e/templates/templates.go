@@ -43,6 +43,8 @@ const (
 	TemplateGCPGKE              Type = "gcp-gke"
 	TemplateVSphereStandaloneCP Type = "vsphere-standalone-cp"
 	TemplateVSphereHostedCP     Type = "vsphere-hosted-cp"
+	TemplateDockerStandaloneCP  Type = "docker-standalone-cp"
+	TemplateDockerHostedCP      Type = "docker-hosted-cp"
 	TemplateAdoptedCluster      Type = "adopted-cluster"
 	TemplateRemoteCluster       Type = "remote-cluster"
 )
@@ -60,6 +62,8 @@ var Types = []Type{
 	TemplateGCPGKE,
 	TemplateVSphereStandaloneCP,
 	TemplateVSphereHostedCP,
+	TemplateDockerStandaloneCP,
+	TemplateDockerHostedCP,
 	TemplateAdoptedCluster,
 	TemplateRemoteCluster,
 }
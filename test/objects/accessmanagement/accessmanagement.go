@@ -51,6 +51,12 @@ func WithAccessRules(accessRules []v1alpha1.AccessRule) Opt {
 	}
 }
 
+func WithEnforceAccessRules(enforce bool) Opt {
+	return func(am *v1alpha1.AccessManagement) {
+		am.Spec.EnforceAccessRules = enforce
+	}
+}
+
 func WithLabels(kv ...string) Opt {
 	return func(am *v1alpha1.AccessManagement) {
 		if am.Labels == nil {
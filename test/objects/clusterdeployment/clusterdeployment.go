@@ -18,6 +18,7 @@ import (
 	sveltosv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/K0rdent/kcm/api/v1alpha1"
 )
@@ -55,6 +56,12 @@ func WithNamespace(namespace string) Opt {
 	}
 }
 
+func WithAnnotations(annotations map[string]string) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Annotations = annotations
+	}
+}
+
 func WithDryRun(dryRun bool) Opt {
 	return func(p *v1alpha1.ClusterDeployment) {
 		p.Spec.DryRun = dryRun
@@ -67,6 +74,13 @@ func WithClusterTemplate(templateName string) Opt {
 	}
 }
 
+func WithClusterTemplateRef(namespace, name string) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Spec.Template = name
+		p.Spec.TemplateRef = &v1alpha1.ClusterTemplateReference{Namespace: namespace, Name: name}
+	}
+}
+
 func WithConfig(config string) Opt {
 	return func(p *v1alpha1.ClusterDeployment) {
 		p.Spec.Config = &apiextensionsv1.JSON{
@@ -83,6 +97,12 @@ func WithServiceTemplate(templateName string) Opt {
 	}
 }
 
+func WithServiceBundle(bundleName string) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Spec.ServiceSpec.Bundles = append(p.Spec.ServiceSpec.Bundles, bundleName)
+	}
+}
+
 func WithServiceSpec(serviceSpec v1alpha1.ServiceSpec) Opt {
 	return func(p *v1alpha1.ClusterDeployment) {
 		p.Spec.ServiceSpec = serviceSpec
@@ -106,3 +126,138 @@ func WithAvailableUpgrades(availableUpgrades []string) Opt {
 		p.Status.AvailableUpgrades = availableUpgrades
 	}
 }
+
+func WithControlPlaneVIP(address, pool string) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Spec.ControlPlaneVIP = &v1alpha1.ControlPlaneVIP{
+			Address: address,
+			Pool:    pool,
+		}
+	}
+}
+
+func WithControlPlaneVIPStatus(address string) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Status.ControlPlaneVIP = address
+	}
+}
+
+func WithGPU(serviceTemplate string) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Spec.GPU = &v1alpha1.ClusterGPU{
+			ServiceTemplate: serviceTemplate,
+			Namespace:       "gpu-operator",
+		}
+	}
+}
+
+func WithOIDC(issuerURL, clientID string) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Spec.Authentication = &v1alpha1.ClusterAuthentication{
+			OIDC: &v1alpha1.ClusterOIDC{
+				IssuerURL: issuerURL,
+				ClientID:  clientID,
+			},
+		}
+	}
+}
+
+func WithExpireAt(expireAt metav1.Time) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Spec.ExpireAt = &expireAt
+	}
+}
+
+func WithExpirationPolicy(policy *v1alpha1.ClusterExpirationPolicy) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Spec.ExpirationPolicy = policy
+	}
+}
+
+func WithLifecycleHooks(hooks *v1alpha1.ClusterLifecycleHooks) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Spec.LifecycleHooks = hooks
+	}
+}
+
+func WithCloneFrom(name string) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Spec.CloneFrom = name
+	}
+}
+
+func WithDeletionTimestamp(deletionTimestamp metav1.Time) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.DeletionTimestamp = &deletionTimestamp
+	}
+}
+
+func WithFinalizers(finalizers ...string) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Finalizers = finalizers
+	}
+}
+
+func WithUID(uid types.UID) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.UID = uid
+	}
+}
+
+func WithFailureDomains(failureDomains *v1alpha1.ClusterFailureDomains) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Spec.FailureDomains = failureDomains
+	}
+}
+
+func WithNetworks(networks *v1alpha1.ClusterNetworks) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Spec.Networks = networks
+	}
+}
+
+func WithCNI(cni, serviceTemplate string) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Spec.Network = &v1alpha1.ClusterNetwork{
+			CNI:             cni,
+			ServiceTemplate: serviceTemplate,
+			Namespace:       "kube-system",
+		}
+	}
+}
+
+func WithProxy(proxy *v1alpha1.ClusterProxy) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Spec.Proxy = proxy
+	}
+}
+
+func WithMachineAccess(access *v1alpha1.MachineAccess) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Spec.MachineAccess = access
+	}
+}
+
+func WithCloudTags(tags map[string]string) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Spec.CloudTags = tags
+	}
+}
+
+func WithLastKnownGood(revision *v1alpha1.ClusterRevision) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Status.LastKnownGood = revision
+	}
+}
+
+func WithConditions(conditions ...metav1.Condition) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Status.Conditions = conditions
+	}
+}
+
+func WithControlPlaneMigration(migration *v1alpha1.ControlPlaneMigration) Opt {
+	return func(p *v1alpha1.ClusterDeployment) {
+		p.Status.ControlPlaneMigration = migration
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterquota
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	DefaultName = "clusterquota"
+)
+
+type Opt func(quota *v1alpha1.ClusterQuota)
+
+func NewClusterQuota(opts ...Opt) *v1alpha1.ClusterQuota {
+	q := &v1alpha1.ClusterQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: DefaultName,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+func WithName(name string) Opt {
+	return func(q *v1alpha1.ClusterQuota) {
+		q.Name = name
+	}
+}
+
+func WithNamespaceSelector(matchLabels map[string]string) Opt {
+	return func(q *v1alpha1.ClusterQuota) {
+		q.Spec.NamespaceSelector = metav1.LabelSelector{MatchLabels: matchLabels}
+	}
+}
+
+func WithMaxClusters(maxClusters int32) Opt {
+	return func(q *v1alpha1.ClusterQuota) {
+		q.Spec.MaxClusters = &maxClusters
+	}
+}
+
+func WithMaxNodes(maxNodes int32) Opt {
+	return func(q *v1alpha1.ClusterQuota) {
+		q.Spec.MaxNodes = &maxNodes
+	}
+}
+
+func WithAllowedInstanceSizes(sizes ...string) Opt {
+	return func(q *v1alpha1.ClusterQuota) {
+		q.Spec.AllowedInstanceSizes = sizes
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterrequest
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	DefaultName = "clusterrequest"
+)
+
+type Opt func(clusterRequest *v1alpha1.ClusterRequest)
+
+func NewClusterRequest(opts ...Opt) *v1alpha1.ClusterRequest {
+	p := &v1alpha1.ClusterRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DefaultName,
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func WithName(name string) Opt {
+	return func(p *v1alpha1.ClusterRequest) {
+		p.Name = name
+	}
+}
+
+func WithNamespace(namespace string) Opt {
+	return func(p *v1alpha1.ClusterRequest) {
+		p.Namespace = namespace
+	}
+}
+
+func WithTemplate(template string) Opt {
+	return func(p *v1alpha1.ClusterRequest) {
+		p.Spec.Template = template
+	}
+}
+
+func WithCredential(credential string) Opt {
+	return func(p *v1alpha1.ClusterRequest) {
+		p.Spec.Credential = credential
+	}
+}
+
+func WithSize(controlPlaneNumber, workersNumber int32) Opt {
+	return func(p *v1alpha1.ClusterRequest) {
+		p.Spec.Size = &v1alpha1.ClusterRequestSize{
+			ControlPlaneNumber: controlPlaneNumber,
+			WorkersNumber:      workersNumber,
+		}
+	}
+}
+
+func WithApproval(decision, credential string) Opt {
+	return func(p *v1alpha1.ClusterRequest) {
+		p.Status.Approval = &v1alpha1.ClusterRequestApproval{
+			Decision:   decision,
+			Credential: credential,
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configpolicy
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	DefaultName = "configpolicy"
+)
+
+type Opt func(policy *v1alpha1.ConfigPolicy)
+
+func NewConfigPolicy(opts ...Opt) *v1alpha1.ConfigPolicy {
+	p := &v1alpha1.ConfigPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: DefaultName,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func WithName(name string) Opt {
+	return func(p *v1alpha1.ConfigPolicy) {
+		p.Name = name
+	}
+}
+
+func WithNamespaceSelector(matchLabels map[string]string) Opt {
+	return func(p *v1alpha1.ConfigPolicy) {
+		p.Spec.NamespaceSelector = metav1.LabelSelector{MatchLabels: matchLabels}
+	}
+}
+
+func WithClusterSelector(matchLabels map[string]string) Opt {
+	return func(p *v1alpha1.ConfigPolicy) {
+		p.Spec.ClusterSelector = metav1.LabelSelector{MatchLabels: matchLabels}
+	}
+}
+
+func WithRule(name, expression, message, fieldPath string) Opt {
+	return func(p *v1alpha1.ConfigPolicy) {
+		p.Spec.Rules = append(p.Spec.Rules, v1alpha1.ConfigPolicyRule{
+			Name:       name,
+			Expression: expression,
+			Message:    message,
+			FieldPath:  fieldPath,
+		})
+	}
+}
+
+func WithOverlay(name string, matchLabels map[string]string, values string) Opt {
+	return func(p *v1alpha1.ConfigPolicy) {
+		p.Spec.Overlays = append(p.Spec.Overlays, v1alpha1.ConfigOverlay{
+			Name:     name,
+			Selector: metav1.LabelSelector{MatchLabels: matchLabels},
+			Values:   apiextensionsv1.JSON{Raw: []byte(values)},
+		})
+	}
+}
@@ -89,3 +89,27 @@ func WithRelease(v string) Opt {
 		management.Spec.Release = v
 	}
 }
+
+func WithProfile(v string) Opt {
+	return func(management *v1alpha1.Management) {
+		management.Spec.Profile = v
+	}
+}
+
+func WithMandatoryCloudTags(keys ...string) Opt {
+	return func(management *v1alpha1.Management) {
+		management.Spec.CloudTagPolicy = &v1alpha1.CloudTagPolicy{MandatoryTags: keys}
+	}
+}
+
+func WithReconciliationPaused(paused bool) Opt {
+	return func(management *v1alpha1.Management) {
+		management.Spec.ReconciliationPaused = paused
+	}
+}
+
+func WithServiceDelivery(v string) Opt {
+	return func(management *v1alpha1.Management) {
+		management.Spec.ServiceDelivery = v
+	}
+}
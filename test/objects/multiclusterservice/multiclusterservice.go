@@ -49,6 +49,31 @@ func WithServiceTemplate(templateName string) Opt {
 	return func(p *v1alpha1.MultiClusterService) {
 		p.Spec.ServiceSpec.Services = append(p.Spec.ServiceSpec.Services, v1alpha1.Service{
 			Template: templateName,
+			Name:     templateName,
 		})
 	}
 }
+
+func WithServiceBundle(bundleName string) Opt {
+	return func(p *v1alpha1.MultiClusterService) {
+		p.Spec.ServiceSpec.Bundles = append(p.Spec.ServiceSpec.Bundles, bundleName)
+	}
+}
+
+func WithClusterSelector(selector metav1.LabelSelector) Opt {
+	return func(p *v1alpha1.MultiClusterService) {
+		p.Spec.ClusterSelector = selector
+	}
+}
+
+func WithKubernetesVersion(constraint string) Opt {
+	return func(p *v1alpha1.MultiClusterService) {
+		p.Spec.KubernetesVersion = constraint
+	}
+}
+
+func WithPriority(priority int32) Opt {
+	return func(p *v1alpha1.MultiClusterService) {
+		p.Spec.ServiceSpec.Priority = priority
+	}
+}
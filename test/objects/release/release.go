@@ -78,6 +78,24 @@ func WithProviders(v ...v1alpha1.NamedProviderTemplate) Opt {
 	}
 }
 
+func WithAddonVersions(v ...v1alpha1.AddonVersions) Opt {
+	return func(r *v1alpha1.Release) {
+		r.Spec.AddonVersions = v
+	}
+}
+
+func WithKubernetesVersions(v ...v1alpha1.KubernetesVersionSupport) Opt {
+	return func(r *v1alpha1.Release) {
+		r.Spec.KubernetesVersions = v
+	}
+}
+
+func WithKubernetesVersionSkew(v ...v1alpha1.ComponentKubernetesVersions) Opt {
+	return func(r *v1alpha1.Release) {
+		r.Spec.KubernetesVersionSkew = v
+	}
+}
+
 func WithReadyStatus(ready bool) Opt {
 	return func(r *v1alpha1.Release) {
 		r.Status.Ready = ready
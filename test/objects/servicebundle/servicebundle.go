@@ -0,0 +1,63 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicebundle
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	DefaultName      = "servicebundle"
+	DefaultNamespace = metav1.NamespaceDefault
+)
+
+type Opt func(bundle *v1alpha1.ServiceBundle)
+
+func NewServiceBundle(opts ...Opt) *v1alpha1.ServiceBundle {
+	b := &v1alpha1.ServiceBundle{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DefaultName,
+			Namespace: DefaultNamespace,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+func WithName(name string) Opt {
+	return func(b *v1alpha1.ServiceBundle) {
+		b.Name = name
+	}
+}
+
+func WithNamespace(namespace string) Opt {
+	return func(b *v1alpha1.ServiceBundle) {
+		b.Namespace = namespace
+	}
+}
+
+func WithService(name, templateName string) Opt {
+	return func(b *v1alpha1.ServiceBundle) {
+		b.Spec.Services = append(b.Spec.Services, v1alpha1.Service{
+			Name:     name,
+			Template: templateName,
+		})
+	}
+}
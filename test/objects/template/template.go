@@ -205,6 +205,16 @@ func WithProviderStatusCAPIContracts(coreAndProvidersContracts ...string) Opt {
 	}
 }
 
+func WithProviderStatusCRDUpgradeIssues(issues ...v1alpha1.CRDUpgradeIssue) Opt {
+	return func(template Template) {
+		pt, ok := template.(*v1alpha1.ProviderTemplate)
+		if !ok {
+			panic(fmt.Sprintf("unexpected type %T, expected ProviderTemplate", template))
+		}
+		pt.Status.CRDUpgradeIssues = issues
+	}
+}
+
 func WithClusterStatusK8sVersion(v string) Opt {
 	return func(template Template) {
 		ct, ok := template.(*v1alpha1.ClusterTemplate)
@@ -227,3 +237,33 @@ func WithClusterStatusProviderContracts(providerContracts map[string]string) Opt
 		ct.Status.ProviderContracts = providerContracts
 	}
 }
+
+func WithClusterClass(name, namespace string) Opt {
+	return func(template Template) {
+		ct, ok := template.(*v1alpha1.ClusterTemplate)
+		if !ok {
+			panic(fmt.Sprintf("unexpected type %T, expected ClusterTemplate", template))
+		}
+		ct.Spec.ClusterClass = &v1alpha1.ClusterClassReference{Name: name, Namespace: namespace}
+	}
+}
+
+func WithClusterStatusControlPlaneMode(mode string) Opt {
+	return func(template Template) {
+		ct, ok := template.(*v1alpha1.ClusterTemplate)
+		if !ok {
+			panic(fmt.Sprintf("unexpected type %T, expected ClusterTemplate", template))
+		}
+		ct.Status.ControlPlaneMode = mode
+	}
+}
+
+func WithClusterStatusSupportedCNIs(cnis ...string) Opt {
+	return func(template Template) {
+		ct, ok := template.(*v1alpha1.ClusterTemplate)
+		if !ok {
+			panic(fmt.Sprintf("unexpected type %T, expected ClusterTemplate", template))
+		}
+		ct.Status.SupportedCNIs = cnis
+	}
+}
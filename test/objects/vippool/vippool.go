@@ -0,0 +1,61 @@
+// Copyright 2025
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vippool
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/K0rdent/kcm/api/v1alpha1"
+)
+
+const (
+	DefaultName = "vippool"
+)
+
+type Opt func(pool *v1alpha1.VIPPool)
+
+func NewVIPPool(opts ...Opt) *v1alpha1.VIPPool {
+	p := &v1alpha1.VIPPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: DefaultName,
+		},
+		Spec: v1alpha1.VIPPoolSpec{
+			Type: v1alpha1.VIPPoolTypeKubeVIP,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func WithName(name string) Opt {
+	return func(p *v1alpha1.VIPPool) {
+		p.Name = name
+	}
+}
+
+func WithAddresses(addresses ...string) Opt {
+	return func(p *v1alpha1.VIPPool) {
+		p.Spec.Addresses = addresses
+	}
+}
+
+func WithType(typ string) Opt {
+	return func(p *v1alpha1.VIPPool) {
+		p.Spec.Type = typ
+	}
+}